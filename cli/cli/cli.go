@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/user"
 	"path"
 	"strings"
 	"time"
@@ -36,13 +37,20 @@ import (
 	"github.com/opencurve/curveadm/internal/configure/hosts"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/event"
+	"github.com/opencurve/curveadm/internal/i18n"
+	"github.com/opencurve/curveadm/internal/plugin"
+	"github.com/opencurve/curveadm/internal/report"
+	"github.com/opencurve/curveadm/internal/secret"
 	"github.com/opencurve/curveadm/internal/storage"
 	tools "github.com/opencurve/curveadm/internal/tools/upgrade"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/internal/verify"
 	log "github.com/opencurve/curveadm/pkg/log/glg"
 	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/opencurve/curveadm/pkg/tracing"
 )
 
 type CurveAdm struct {
@@ -61,6 +69,10 @@ type CurveAdm struct {
 	err        io.Writer
 	storage    *storage.Storage
 	memStorage *utils.SafeMap
+	secrets    *secret.Store
+	verifier   *verify.Verifier
+	events     *event.Bus
+	recorder   *report.Recorder
 
 	// properties (hosts/cluster)
 	hosts               string // hosts
@@ -70,6 +82,17 @@ type CurveAdm struct {
 	clusterTopologyData string // cluster topology
 	clusterPoolData     string // cluster pool
 	monitor             storage.Monitor
+
+	// audit
+	auditSource string // where the command comes from, "local" or client address of API
+
+	// answer of prompts if not nil, see NewSession
+	confirm *string
+
+	// no progress bars, see SetQuiet
+	quiet bool
+	// logs have been printed into stderr, see SetLogOptions
+	mirrored bool
 }
 
 /*
@@ -127,17 +150,15 @@ func (curveadm *CurveAdm) init() error {
 		return err
 	}
 	configure.ReplaceGlobals(config)
+	i18n.SetLanguage(i18n.Detect(config.GetLanguage()))
 
-	// (3) Init logger
+	// (3) Init logger, the log file is opened after options parsed, see SetLogOptions
 	now := time.Now().Format("2006-01-02_15-04-05")
 	logpath := fmt.Sprintf("%s/curveadm-%s.log", curveadm.logDir, now)
-	if err := log.Init(config.GetLogLevel(), logpath); err != nil {
-		return errno.ERR_INIT_LOGGER_FAILED.E(err)
-	} else {
-		log.Info("Init logger success",
-			log.Field("LogPath", logpath),
-			log.Field("LogLevel", config.GetLogLevel()))
-	}
+	log.Defer(config.GetLogLevel(), logpath)
+	log.Info("Init logger success",
+		log.Field("LogPath", logpath),
+		log.Field("LogLevel", config.GetLogLevel()))
 
 	// (4) Init error code
 	errno.Init(logpath)
@@ -181,6 +202,13 @@ func (curveadm *CurveAdm) init() error {
 		return errno.ERR_GET_MONITOR_FAILED.E(err)
 	}
 
+	// (9) Init tracing, it's noop if endpoint not configured
+	err = tracing.Init(config.GetTracingEndpoint(), config.GetTracingInsecure(), Version)
+	if err != nil {
+		log.Error("Init tracing failed", log.Field("Error", err))
+		return errno.ERR_INIT_TRACING_FAILED.E(err)
+	}
+
 	curveadm.logpath = logpath
 	curveadm.config = config
 	curveadm.in = os.Stdin
@@ -188,6 +216,7 @@ func (curveadm *CurveAdm) init() error {
 	curveadm.err = os.Stderr
 	curveadm.storage = s
 	curveadm.memStorage = utils.NewSafeMap()
+	curveadm.secrets = secret.NewStore(s, curveadm.secretPassphrase)
 	curveadm.hosts = hosts.Data
 	curveadm.clusterId = cluster.Id
 	curveadm.clusterUUId = cluster.UUId
@@ -195,10 +224,73 @@ func (curveadm *CurveAdm) init() error {
 	curveadm.clusterTopologyData = cluster.Topology
 	curveadm.clusterPoolData = cluster.Pool
 	curveadm.monitor = monitor
+	curveadm.auditSource = comm.AUDIT_SOURCE_LOCAL
+
+	// (10) Resolve secret references (e.g. secret://s3_sk) in configs by the selected backend
+	resolver, err := secret.NewResolver(config.GetSecretBackendConfig(), curveadm.secrets)
+	if err != nil {
+		return err
+	}
+	secret.ReplaceGlobals(resolver)
+	// parsed topology is cached in disk with secret references, they are resolved when loaded
+	topology.SetCacheDir(path.Join(curveadm.dataDir, "topology_cache"), Version)
+
+	// (11) Verify downloaded scripts, packages and images by trust roots in curveadm.cfg
+	curveadm.verifier = verify.New(config.GetVerifyConfig())
+	verify.ReplaceGlobals(curveadm.verifier)
+
+	// (12) Emit events of state transitions to sinks in curveadm.cfg
+	curveadm.events = event.New(config.GetEventsConfig())
+
+	// (13) Record steps of playbooks for email report
+	curveadm.recorder = report.NewRecorder()
 
 	return nil
 }
 
+/*
+ * SwitchCluster makes curveadm operate the specified cluster in this process,
+ * the current cluster in database is untouched, so several clusters
+ * can be operated concurrently.
+ */
+func (curveadm *CurveAdm) SwitchCluster(name string) error {
+	if name == curveadm.clusterName {
+		return nil
+	}
+
+	clusters, err := curveadm.Storage().GetClusters(name)
+	if err != nil {
+		log.Error("Get clusters failed",
+			log.Field("Error", err))
+		return errno.ERR_GET_CLUSTER_BY_NAME_FAILED.E(err)
+	}
+
+	// the name is matched by LIKE, filter out the exact one
+	for _, cluster := range clusters {
+		if cluster.Name != name {
+			continue
+		}
+		monitor, err := curveadm.Storage().GetMonitor(cluster.Id)
+		if err != nil {
+			log.Error("Get monitor failed", log.Field("Error", err))
+			return errno.ERR_GET_MONITOR_FAILED.E(err)
+		}
+
+		log.Info("Switch cluster success",
+			log.Field("ClusterId", cluster.Id),
+			log.Field("ClusterName", cluster.Name))
+		curveadm.clusterId = cluster.Id
+		curveadm.clusterUUId = cluster.UUId
+		curveadm.clusterName = cluster.Name
+		curveadm.clusterTopologyData = cluster.Topology
+		curveadm.clusterPoolData = cluster.Pool
+		curveadm.monitor = monitor
+		return nil
+	}
+	return errno.ERR_CLUSTER_NOT_FOUND.
+		F("cluster name: %s", name)
+}
+
 func (curveadm *CurveAdm) detectVersion() {
 	latestVersion, err := tools.GetLatestVersion(Version)
 	if err != nil || len(latestVersion) == 0 {
@@ -248,7 +340,7 @@ func (curveadm *CurveAdm) Upgrade() (bool, error) {
 		return false, errno.ERR_CANCEL_OPERATION
 	}
 
-	err = tools.Upgrade(latestVersion)
+	err = tools.Upgrade(latestVersion, curveadm.verifier)
 	if err != nil {
 		return false, err
 	}
@@ -260,7 +352,10 @@ func (curveadm *CurveAdm) DataDir() string                   { return curveadm.d
 func (curveadm *CurveAdm) PluginDir() string                 { return curveadm.pluginDir }
 func (curveadm *CurveAdm) LogDir() string                    { return curveadm.logDir }
 func (curveadm *CurveAdm) TempDir() string                   { return curveadm.tempDir }
+func (curveadm *CurveAdm) StatusCacheDir() string            { return path.Join(curveadm.tempDir, "status") }
+func (curveadm *CurveAdm) BackupDir() string                 { return path.Join(curveadm.dataDir, "backup") }
 func (curveadm *CurveAdm) LogPath() string                   { return curveadm.logpath }
+func (curveadm *CurveAdm) ImageArchivePath() string          { return path.Join(curveadm.dataDir, "images.tar") }
 func (curveadm *CurveAdm) Config() *configure.CurveAdmConfig { return curveadm.config }
 func (curveadm *CurveAdm) SudoAlias() string                 { return curveadm.config.GetSudoAlias() }
 func (curveadm *CurveAdm) SSHTimeout() int                   { return curveadm.config.GetSSHTimeout() }
@@ -270,6 +365,9 @@ func (curveadm *CurveAdm) Out() io.Writer                    { return curveadm.o
 func (curveadm *CurveAdm) Err() io.Writer                    { return curveadm.err }
 func (curveadm *CurveAdm) Storage() *storage.Storage         { return curveadm.storage }
 func (curveadm *CurveAdm) MemStorage() *utils.SafeMap        { return curveadm.memStorage }
+func (curveadm *CurveAdm) Secrets() *secret.Store            { return curveadm.secrets }
+func (curveadm *CurveAdm) Verifier() *verify.Verifier        { return curveadm.verifier }
+func (curveadm *CurveAdm) Recorder() *report.Recorder        { return curveadm.recorder }
 func (curveadm *CurveAdm) Hosts() string                     { return curveadm.hosts }
 func (curveadm *CurveAdm) ClusterId() int                    { return curveadm.clusterId }
 func (curveadm *CurveAdm) ClusterUUId() string               { return curveadm.clusterUUId }
@@ -278,6 +376,60 @@ func (curveadm *CurveAdm) ClusterTopologyData() string       { return curveadm.c
 func (curveadm *CurveAdm) ClusterPoolData() string           { return curveadm.clusterPoolData }
 func (curveadm *CurveAdm) Monitor() storage.Monitor          { return curveadm.monitor }
 
+func (curveadm *CurveAdm) Quiet() bool { return curveadm.quiet }
+
+// SetQuiet hides progress bars of playbook, the result and error are still displayed
+func (curveadm *CurveAdm) SetQuiet(quiet bool) { curveadm.quiet = quiet }
+
+/*
+ * SetLogOptions opens log file (the default one if not specified) and prints logs into stderr by verbosity:
+ *
+ *   0: only log file (default)
+ *   1: info, warning and error logs are also printed
+ *   2: debug logs (e.g. remote commands and their outputs) are also printed
+ *
+ * The logger is global, so it's ignored for sessions of API server and bot.
+ */
+func (curveadm *CurveAdm) SetLogOptions(verbosity int, logfile string) error {
+	if curveadm.confirm != nil {
+		return nil
+	}
+
+	if len(logfile) == 0 {
+		logfile = curveadm.logpath
+	}
+	if err := log.Open(logfile); err != nil {
+		return errno.ERR_INIT_LOGGER_FAILED.E(err)
+	}
+	curveadm.logpath = logfile
+	errno.Init(logfile)
+
+	// only once, the commands of macro run in one process
+	if curveadm.mirrored {
+		return nil
+	} else if verbosity == 1 {
+		log.Mirror("info", curveadm.err)
+	} else if verbosity >= 2 {
+		log.Mirror("debug", curveadm.err)
+	}
+	curveadm.mirrored = verbosity > 0
+	return nil
+}
+
+func (curveadm *CurveAdm) PluginSearchDirs() []string {
+	return plugin.SearchDirs(curveadm.pluginDir)
+}
+
+// PluginEnv returns context of current curveadm which passed to plugins
+func (curveadm *CurveAdm) PluginEnv() plugin.Env {
+	bin, _ := os.Executable()
+	return plugin.Env{
+		Bin:     bin,
+		RootDir: curveadm.rootDir,
+		Cluster: curveadm.clusterName,
+	}
+}
+
 func (curveadm *CurveAdm) GetHost(host string) (*hosts.HostConfig, error) {
 	if len(curveadm.Hosts()) == 0 {
 		return nil, errno.ERR_HOST_NOT_FOUND.
@@ -297,6 +449,19 @@ func (curveadm *CurveAdm) GetHost(host string) (*hosts.HostConfig, error) {
 		F("host: %s", host)
 }
 
+// ExpandHosts expands host pattern (e.g. @rack1,host2) to member hosts
+func (curveadm *CurveAdm) ExpandHosts(pattern string) ([]string, error) {
+	hcs := []*hosts.HostConfig{}
+	if len(curveadm.Hosts()) > 0 {
+		var err error
+		hcs, err = hosts.ParseHosts(curveadm.Hosts())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hosts.ExpandHosts(hcs, pattern)
+}
+
 func (curveadm *CurveAdm) ParseTopologyData(data string) ([]*topology.DeployConfig, error) {
 	ctx := topology.NewContext()
 	hcs, err := hosts.ParseHosts(curveadm.Hosts())
@@ -325,15 +490,23 @@ func (curveadm *CurveAdm) ParseTopology() ([]*topology.DeployConfig, error) {
 
 func (curveadm *CurveAdm) FilterDeployConfig(deployConfigs []*topology.DeployConfig,
 	options topology.FilterOption) []*topology.DeployConfig {
+	matchHosts := map[string]bool{options.Host: true}
+	if hosts.IsHostPattern(options.Host) {
+		members, _ := curveadm.ExpandHosts(options.Host) // already checked by CheckHost
+		matchHosts = utils.Slice2Map(members)
+	}
+
 	dcs := []*topology.DeployConfig{}
 	for _, dc := range deployConfigs {
 		dcId := dc.GetId()
 		role := dc.GetRole()
 		host := dc.GetHost()
 		serviceId := curveadm.GetServiceId(dcId)
+		kind := dc.GetKind()
 		if (options.Id == "*" || options.Id == serviceId) &&
 			(options.Role == "*" || options.Role == role) &&
-			(options.Host == "*" || options.Host == host) {
+			(options.Host == "*" || matchHosts[host]) &&
+			(len(options.Kind) == 0 || options.Kind == "*" || options.Kind == kind) {
 			dcs = append(dcs, dc)
 		}
 	}
@@ -408,14 +581,16 @@ func (curveadm *CurveAdm) CheckRole(role string) error {
 		return err
 	}
 
-	kind := dcs[0].GetKind()
+	kind := topology.GetKind(dcs)
 	roles := topology.CURVEBS_ROLES
 	if kind == topology.KIND_CURVEFS {
 		roles = topology.CURVEFS_ROLES
+	} else if kind == topology.KIND_MIXED {
+		roles = topology.MIXED_ROLES
 	}
 	supported := utils.Slice2Map(roles)
 	if !supported[role] {
-		if kind == topology.KIND_CURVEBS {
+		if kind != topology.KIND_CURVEFS {
 			return errno.ERR_UNSUPPORT_CURVEBS_ROLE.
 				F("role: %s", role)
 		}
@@ -426,6 +601,10 @@ func (curveadm *CurveAdm) CheckRole(role string) error {
 }
 
 func (curveadm *CurveAdm) CheckHost(host string) error {
+	if hosts.IsHostPattern(host) {
+		_, err := curveadm.ExpandHosts(host)
+		return err
+	}
 	_, err := curveadm.GetHost(host)
 	return err
 }
@@ -481,6 +660,20 @@ func (curveadm *CurveAdm) DiffTopology(data1, data2 string) ([]topology.Topology
 	return topology.DiffTopology(data1, data2, ctx)
 }
 
+// operator is the user who executes curveadm, e.g: curve, root(sudo:alice)
+func auditOperator() string {
+	name := comm.AUDIT_OPERATOR_UNKNOWN
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+
+	sudoUser := os.Getenv("SUDO_USER")
+	if len(sudoUser) > 0 && sudoUser != name {
+		return fmt.Sprintf("%s(sudo:%s)", name, sudoUser)
+	}
+	return name
+}
+
 func (curveadm *CurveAdm) PreAudit(now time.Time, args []string) int64 {
 	if len(args) == 0 {
 		return -1
@@ -490,8 +683,15 @@ func (curveadm *CurveAdm) PreAudit(now time.Time, args []string) int64 {
 
 	cwd, _ := os.Getwd()
 	command := fmt.Sprintf("curveadm %s", strings.Join(args, " "))
-	id, err := curveadm.Storage().InsertAuditLog(
-		now, cwd, command, comm.AUDIT_STATUS_ABORT)
+	id, err := curveadm.Storage().InsertAuditLog(storage.AuditLog{
+		ExecuteTime:   now,
+		WorkDirectory: cwd,
+		Command:       command,
+		Status:        comm.AUDIT_STATUS_ABORT,
+		Operator:      auditOperator(),
+		Source:        curveadm.auditSource,
+		Cluster:       curveadm.clusterName,
+	})
 	if err != nil {
 		log.Error("Insert audit log failed",
 			log.Field("Error", err))
@@ -515,6 +715,13 @@ func (curveadm *CurveAdm) PostAudit(id int64, ec error) {
 	}
 
 	auditLog := auditLogs[0]
+	if auditLog.Cluster != curveadm.clusterName { // switched by --cluster
+		err = curveadm.Storage().SetAuditLogCluster(id, curveadm.clusterName)
+		if err != nil {
+			log.Error("Set audit log cluster failed",
+				log.Field("Error", err))
+		}
+	}
 	status := auditLog.Status
 	errorCode := 0
 	if ec == nil {
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/event"
+	"github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+func eventStatus(ec error) string {
+	if ec == nil {
+		return event.STATUS_SUCCESS
+	} else if errors.Is(ec, errno.ERR_CANCEL_OPERATION) {
+		return event.STATUS_CANCEL
+	}
+	return event.STATUS_FAIL
+}
+
+/*
+ * EmitEvent emits event of the executed command (e.g: "curveadm deploy") to
+ * sinks in curveadm.cfg, read-only commands or invocations (e.g. "apply --plan")
+ * emit nothing. The failure of sink
+ * is only logged as warning (printed into stderr with -V), it never fails the command.
+ */
+func (curveadm *CurveAdm) EmitEvent(start time.Time, command string, args []string, ec error) {
+	if !curveadm.events.Enabled() || event.IsReadOnly(args) {
+		return
+	}
+	status := eventStatus(ec)
+	typ := event.TypeOf(command, status)
+	if len(typ) == 0 {
+		return
+	}
+
+	e := event.NewEvent(typ, status)
+	if ec != nil {
+		e.Code = errno.ERR_UNKNOWN.GetCode()
+		e.Error = ec.Error()
+		if code, ok := ec.(*errno.ErrorCode); ok {
+			e.Code = code.GetCode()
+			e.Error = code.GetDescription()
+		}
+	}
+	e.Cluster = curveadm.clusterName
+	e.ClusterUUId = curveadm.clusterUUId
+	e.Command = utils.RedactSecrets(fmt.Sprintf("curveadm %s", strings.Join(args, " ")))
+	e.Operator = auditOperator()
+	e.Source = curveadm.auditSource
+	e.StartTime = start
+
+	for sink, err := range curveadm.events.Emit(e) {
+		log.Warn("Emit event failed",
+			log.Field("Sink", sink),
+			log.Field("Type", e.Type),
+			log.Field("Error", err))
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cli
+
+import (
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+// GetClusterProtection returns nil if the cluster is not protected
+func (curveadm *CurveAdm) GetClusterProtection(clusterId int) (*storage.ClusterProtection, error) {
+	protections, err := curveadm.storage.GetClusterProtection(clusterId)
+	if err != nil {
+		return nil, errno.ERR_GET_CLUSTER_PROTECTION_FAILED.E(err)
+	} else if len(protections) == 0 {
+		return nil, nil
+	}
+	return &protections[0], nil
+}
+
+/*
+ * ConfirmCluster asks user to confirm the operation on cluster, the protected
+ * cluster requires typing the cluster name instead of 'yes'. We treat the
+ * cluster as protected if the protection can't be got from database.
+ */
+func (curveadm *CurveAdm) ConfirmCluster(clusterId int, clusterName, prompt string) bool {
+	protection, err := curveadm.GetClusterProtection(clusterId)
+	protected := err != nil || protection != nil
+	if curveadm.confirm != nil { // answered by API request
+		answer := *curveadm.confirm
+		return answer == clusterName || (!protected && answer == CONFIRM_YES)
+	} else if !protected {
+		return tui.ConfirmYes(prompt)
+	}
+	return tui.ConfirmInput(clusterName, tui.PromptProtectedCluster(prompt, clusterName))
+}
+
+// CheckConfirmable returns error if the confirmation requires answer from
+// stdin which isn't a terminal, so that pipelines fail fast instead of hanging.
+// The destruction of data always requires typing the cluster name.
+func (curveadm *CurveAdm) CheckConfirmable(clusterId int, clusterName string, destroy bool) error {
+	if curveadm.confirm != nil || tui.IsTerminal() {
+		return nil
+	} else if destroy {
+		return errno.ERR_CONFIRM_REQUIRES_TERMINAL.
+			F("destroying data of cluster '%s' requires typing the cluster name in terminal", clusterName)
+	}
+	protection, err := curveadm.GetClusterProtection(clusterId)
+	if err == nil && protection == nil && tui.AssumeYes() {
+		return nil
+	} else if err == nil && protection == nil {
+		return errno.ERR_CONFIRM_REQUIRES_TERMINAL.
+			S("specify --assume-yes (or CURVEADM_ASSUME_YES=true) to answer yes")
+	}
+	return errno.ERR_CONFIRM_REQUIRES_TERMINAL.
+		F("cluster '%s' is protected, the cluster name must be typed in terminal", clusterName)
+}
+
+// ConfirmDestroy asks user to type the cluster name before destroying data,
+// it's never assumed by --assume-yes no matter whether the cluster is protected
+func (curveadm *CurveAdm) ConfirmDestroy(prompt string) bool {
+	if curveadm.confirm != nil { // answered by API request
+		return *curveadm.confirm == curveadm.clusterName
+	}
+	return tui.ConfirmInput(curveadm.clusterName, prompt)
+}
+
+// Confirm asks user to confirm the operation on current cluster
+func (curveadm *CurveAdm) Confirm(prompt string) bool {
+	return curveadm.ConfirmCluster(curveadm.clusterId, curveadm.clusterName, prompt)
+}
+
+// CheckClusterUnlocked returns error if the cluster is protected and not unlocked
+func (curveadm *CurveAdm) CheckClusterUnlocked(clusterId int, clusterName string) error {
+	protection, err := curveadm.GetClusterProtection(clusterId)
+	if err != nil {
+		return err
+	} else if protection == nil || time.Now().Unix() < protection.UnlockUntil {
+		return nil
+	}
+	return errno.ERR_CLUSTER_IS_PROTECTED.
+		F("run 'curveadm cluster unlock %s' to allow the operation for a while", clusterName)
+}
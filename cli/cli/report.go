@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/report"
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+// NeedReport returns true if email report configured for command (e.g. "curveadm deploy")
+func (curveadm *CurveAdm) NeedReport(command string) bool {
+	cfg := curveadm.config.GetReportConfig()
+	return cfg.Enabled() && cfg.Match(strings.TrimPrefix(command, "curveadm "))
+}
+
+// NewReport returns report of the executed command with steps recorded so far
+func (curveadm *CurveAdm) NewReport(start time.Time, command string, args []string, ec error) *report.Report {
+	r := &report.Report{
+		Cluster:   curveadm.clusterName,
+		Command:   utils.RedactSecrets(fmt.Sprintf("curveadm %s", strings.Join(args, " "))),
+		Operator:  auditOperator(),
+		Status:    report.STATUS_SUCCESS,
+		StartTime: start,
+		Duration:  time.Since(start),
+		Steps:     curveadm.recorder.Steps(),
+		Warnings:  curveadm.recorder.Warnings(),
+	}
+	if ec != nil {
+		r.Status = report.STATUS_FAIL
+		if errors.Is(ec, errno.ERR_CANCEL_OPERATION) {
+			r.Status = report.STATUS_CANCEL
+		}
+		r.Error = ec.Error()
+	}
+	return r
+}
+
+/*
+ * SendReport emails report to recipients in curveadm.cfg, the failure is
+ * only logged as warning (printed into stderr with -V), it never fails the command.
+ */
+func (curveadm *CurveAdm) SendReport(r *report.Report) {
+	cfg := curveadm.config.GetReportConfig()
+	password, err := secret.Resolve(cfg.SMTPPassword)
+	if err == nil {
+		cfg.SMTPPassword = password
+		err = report.Send(cfg, r)
+	}
+	if err != nil {
+		log.Warn("Send report failed",
+			log.Field("Command", r.Command),
+			log.Field("To", strings.Join(cfg.To, ", ")),
+			log.Field("Error", errno.ERR_SEND_REPORT_FAILED.E(err)))
+	}
+}
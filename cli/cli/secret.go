@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	ENV_SECRET_PASSPHRASE = "CURVEADM_SECRET_PASSPHRASE"
+)
+
+/*
+ * the master passphrase of secrets store comes from (in order):
+ *   (1) the keyfile specified in curveadm.cfg
+ *   (2) environment variable CURVEADM_SECRET_PASSPHRASE
+ *   (3) prompt, typed twice when the store created
+ */
+func (curveadm *CurveAdm) secretPassphrase(create bool) ([]byte, error) {
+	if keyfile := curveadm.config.GetSecretKeyFile(); len(keyfile) > 0 {
+		data, err := os.ReadFile(keyfile)
+		if err != nil {
+			return nil, errno.ERR_READ_SECRET_PASSPHRASE_FAILED.E(err)
+		}
+		return []byte(strings.TrimRight(string(data), "\r\n")), nil
+	} else if passphrase := os.Getenv(ENV_SECRET_PASSPHRASE); len(passphrase) > 0 {
+		return []byte(passphrase), nil
+	} else if !tui.IsTerminal() {
+		return nil, errno.ERR_SECRET_PASSPHRASE_NOT_SPECIFIED.
+			F("please specify [secrets] keyfile in curveadm.cfg or set $%s", ENV_SECRET_PASSPHRASE)
+	}
+
+	passphrase, err := tui.PromptPassword("Enter passphrase of secrets store: ")
+	if err != nil {
+		return nil, errno.ERR_READ_SECRET_PASSPHRASE_FAILED.E(err)
+	} else if !create {
+		return []byte(passphrase), nil
+	}
+
+	again, err := tui.PromptPassword("Enter same passphrase again: ")
+	if err != nil {
+		return nil, errno.ERR_READ_SECRET_PASSPHRASE_FAILED.E(err)
+	} else if again != passphrase {
+		return nil, errno.ERR_SECRET_PASSPHRASE_MISMATCH
+	}
+	return []byte(passphrase), nil
+}
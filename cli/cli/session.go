@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cli
+
+import (
+	"io"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/report"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/internal/verify"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+const (
+	CONFIRM_YES = "yes"
+)
+
+/*
+ * NewSession returns a curveadm which shares configure and storage with
+ * curveadm, but has its own output, memory storage and cluster, so the
+ * requests of API server never pollute each other.
+ *
+ * The prompts are answered by confirm instead of user: 'yes' or the cluster
+ * name, and the protected cluster only accepts its name.
+ */
+func (curveadm *CurveAdm) NewSession(out io.Writer, source, confirm string) (*CurveAdm, error) {
+	hostses, err := curveadm.storage.GetHostses()
+	if err != nil {
+		log.Error("Get hosts failed",
+			log.Field("Error", err))
+		return nil, errno.ERR_GET_HOSTS_FAILED.E(err)
+	}
+
+	session := *curveadm
+	session.in = strings.NewReader("")
+	session.out = out
+	session.err = out
+	session.memStorage = utils.NewSafeMap()
+	session.hosts = ""
+	if len(hostses) == 1 {
+		session.hosts = hostses[0].Data
+	}
+	// cluster is specified by --cluster in each request
+	session.clusterId = -1
+	session.clusterUUId = ""
+	session.clusterName = ""
+	session.clusterTopologyData = ""
+	session.clusterPoolData = ""
+	session.verifier = verify.New(curveadm.config.GetVerifyConfig())
+	session.recorder = report.NewRecorder()
+	session.auditSource = source
+	session.confirm = &confirm
+	return &session, nil
+}
@@ -0,0 +1,134 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/spf13/cobra"
+)
+
+/*
+ * aliases and macros are defined in curveadm.cfg, e.g:
+ *
+ *   [aliases]
+ *   st = status --verbose
+ *
+ *   [macros]
+ *   restart-role = restart --role $1 && status --role $1
+ *
+ *   $ curveadm st --host host1        # curveadm status --verbose --host host1
+ *   $ curveadm restart-role mds       # curveadm restart --role mds, then curveadm status --role mds
+ *
+ * the builtin command always takes precedence, then alias, macro and plugin.
+ */
+const (
+	MACRO_ALL_ARGUMENTS = "$@"
+)
+
+var macroArgument = regexp.MustCompile(`\$([1-9])`)
+
+func isBuiltinCommand(root *cobra.Command, args []string) bool {
+	_, _, err := root.Find(args)
+	return err == nil
+}
+
+// ExpandAlias replaces the alias in args with the arguments it defined,
+// the args is returned as it is if no alias matched
+func ExpandAlias(curveadm *cli.CurveAdm, root *cobra.Command, args []string) []string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || isBuiltinCommand(root, args) {
+		return args
+	}
+
+	alias, ok := curveadm.Config().GetAliases()[args[0]]
+	if !ok {
+		return args
+	}
+	return append(append([]string{}, alias...), args[1:]...)
+}
+
+// expandMacro replaces $1-$9 with the arguments and $@ with all arguments
+func expandMacro(name string, commands [][]string, params []string) ([][]string, error) {
+	out := [][]string{}
+	for _, command := range commands {
+		args := []string{}
+		for _, arg := range command {
+			if arg == MACRO_ALL_ARGUMENTS {
+				args = append(args, params...)
+				continue
+			}
+
+			var err error
+			arg = macroArgument.ReplaceAllStringFunc(arg, func(s string) string {
+				n, _ := strconv.Atoi(s[1:])
+				if n > len(params) {
+					err = errno.ERR_MACRO_REQUIRES_MORE_ARGUMENTS.
+						F("macro '%s' requires argument %s", name, s)
+					return s
+				}
+				return params[n-1]
+			})
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+		out = append(out, args)
+	}
+	return out, nil
+}
+
+// RunMacroCommand runs commands of macro for 'curveadm <name> [ARGS...]' in order,
+// it stops at the first failed command, and returns false if <name> is not a macro.
+func RunMacroCommand(curveadm *cli.CurveAdm, root *cobra.Command, args []string) (bool, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || isBuiltinCommand(root, args) {
+		return false, nil
+	}
+
+	macro, ok := curveadm.Config().GetMacros()[args[0]]
+	if !ok {
+		return false, nil
+	}
+	commands, err := expandMacro(args[0], macro, args[1:])
+	if err != nil {
+		fmt.Fprintln(curveadm.Err(), err)
+		return true, err
+	}
+
+	for i, command := range commands {
+		curveadm.WriteOutln(color.CyanString("[%d/%d] curveadm %s", i+1, len(commands), strings.Join(command, " ")))
+		cmd := NewCurveAdmCommand(curveadm)
+		cmd.SetArgs(ExpandAlias(curveadm, cmd, command))
+		if err := cmd.Execute(); err != nil {
+			return true, err // the error has been printed by command
+		}
+		curveadm.WriteOutln("")
+	}
+	return true, nil
+}
@@ -0,0 +1,52 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandMacro(t *testing.T) {
+	assert := assert.New(t)
+	macro := [][]string{
+		{"restart", "--role", "$1", "--host", "$2"},
+		{"status", "--role=$1", "$@"},
+	}
+
+	commands, err := expandMacro("m", macro, []string{"mds", "host1"})
+	assert.Nil(err)
+	assert.Equal([][]string{
+		{"restart", "--role", "mds", "--host", "host1"},
+		{"status", "--role=mds", "mds", "host1"},
+	}, commands)
+
+	_, err = expandMacro("m", macro, []string{"mds"})
+	assert.ErrorIs(err, errno.ERR_MACRO_REQUIRES_MORE_ARGUMENTS)
+
+	commands, err = expandMacro("m", [][]string{{"cluster", "ls", "$@"}}, nil)
+	assert.Nil(err)
+	assert.Equal([][]string{{"cluster", "ls"}}, commands)
+}
@@ -0,0 +1,485 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	APPLY_EXAMPLE = `Examples:
+  $ curveadm apply -f topology.yaml                 # Converge cluster to topology.yaml
+  $ curveadm apply -f topology.yaml --plan          # Preview actions without applying
+  $ curveadm apply -f topology.yaml -y              # Apply without confirmation, e.g. in pipelines
+  $ curveadm apply -f topology.yaml --plan -o json  # Output plan in JSON, e.g. for terraform provider`
+
+	APPLY_OUTPUT_TEXT = "text"
+	APPLY_OUTPUT_JSON = "json"
+
+	// bumped only if the plan schema changed incompatibly
+	APPLY_PLAN_FORMAT_VERSION = "1.0"
+
+	PLAN_ACTION_CREATE = "create"
+	PLAN_ACTION_UPDATE = "update"
+	PLAN_ACTION_DELETE = "delete"
+)
+
+type applyOptions struct {
+	filename        string
+	plan            bool
+	insecure        bool
+	poolset         string
+	poolsetDiskType string
+	yes             bool
+	output          string
+}
+
+/*
+ * applyPlan is the minimal actions to converge cluster to desired topology:
+ *   (1) upgrade services whose container image changed
+ *   (2) reload services whose configure changed
+ *   (3) scale out added services, OR migrate deleted services to added ones
+ *
+ * The (3) will update cluster topology at last, so the failed apply can be
+ * retried with the same topology.
+ */
+type applyPlan struct {
+	upgrades []*topology.DeployConfig
+	reloads  []*topology.DeployConfig
+	adds     []*topology.DeployConfig
+	deletes  []*topology.DeployConfig
+}
+
+/*
+ * the machine-readable plan for IaC tools (e.g. terraform provider), fields
+ * are only added within the same format version:
+ *
+ * {
+ *   "format_version": "1.0",
+ *   "cluster": "c1",
+ *   "changed": true,
+ *   "resources": [
+ *     {
+ *       "id": "c9570c0d0252", "role": "chunkserver", "host": "host1",
+ *       "action": "update", "operation": "upgrade", "restart": true,
+ *       "changes": [{"key": "container_image", "before": "...", "after": "..."}]
+ *     }
+ *   ],
+ *   "summary": {"create": 0, "update": 1, "delete": 0},
+ *   "impact": {"restart_services": 1, "affected_hosts": ["host1"], "data_movement": false}
+ * }
+ */
+type (
+	planChange struct {
+		Key    string `json:"key"`
+		Before string `json:"before"`
+		After  string `json:"after"`
+	}
+
+	planResource struct {
+		Id        string       `json:"id"`
+		Role      string       `json:"role"`
+		Host      string       `json:"host"`
+		Action    string       `json:"action"`    // create, update or delete
+		Operation string       `json:"operation"` // upgrade, reload, scale-out or migrate
+		Restart   bool         `json:"restart"`
+		Changes   []planChange `json:"changes"`
+	}
+
+	planSummary struct {
+		Create int `json:"create"`
+		Update int `json:"update"`
+		Delete int `json:"delete"`
+	}
+
+	planImpact struct {
+		RestartServices int      `json:"restart_services"`
+		AffectedHosts   []string `json:"affected_hosts"`
+		// data is migrated or rebalanced between chunkservers or metaservers
+		DataMovement bool `json:"data_movement"`
+	}
+
+	applyPlanOutput struct {
+		FormatVersion string         `json:"format_version"`
+		Cluster       string         `json:"cluster"`
+		Changed       bool           `json:"changed"`
+		Resources     []planResource `json:"resources"`
+		Summary       planSummary    `json:"summary"`
+		Impact        planImpact     `json:"impact"`
+	}
+)
+
+func NewApplyCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options applyOptions
+
+	cmd := &cobra.Command{
+		Use:     "apply -f TOPOLOGY [OPTIONS]",
+		Short:   "Converge cluster to desired topology",
+		Args:    cliutil.NoArgs,
+		Example: APPLY_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(options.filename) == 0 {
+				return errno.ERR_TOPOLOGY_FILE_NOT_FOUND.
+					F("topology file must be specified by -f")
+			}
+			return checkApplyOutput(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.filename, "file", "f", "", "Specify desired topology file")
+	flags.BoolVar(&options.plan, "plan", false, "Only display actions to apply")
+	flags.BoolVarP(&options.insecure, "insecure", "k", false, "Scale out cluster without precheck")
+	flags.StringVar(&options.poolset, "poolset", "default", "Specify the poolset name")
+	flags.StringVar(&options.poolsetDiskType, "poolset-disktype", "ssd", "Specify the disk type of physical pool")
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Apply topology without confirmation")
+	flags.StringVarP(&options.output, "output", "o", APPLY_OUTPUT_TEXT, "Output format of plan (text/json)")
+
+	return cmd
+}
+
+func checkApplyOutput(options applyOptions) error {
+	if options.output != APPLY_OUTPUT_TEXT && options.output != APPLY_OUTPUT_JSON {
+		return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.
+			F("output: %s", options.output)
+	} else if options.output == APPLY_OUTPUT_JSON && !options.plan {
+		return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.
+			F("json output requires --plan")
+	}
+	return nil
+}
+
+func planApply(dcs []*topology.DeployConfig, diffs []topology.TopologyDiff) (applyPlan, error) {
+	plan := applyPlan{}
+	images := map[string]string{}
+	for _, dc := range dcs {
+		images[dc.GetId()] = dc.GetContainerImage()
+	}
+	for _, diff := range diffs {
+		dc := diff.DeployConfig
+		switch diff.DiffType {
+		case topology.DIFF_ADD:
+			plan.adds = append(plan.adds, dc)
+		case topology.DIFF_DELETE:
+			plan.deletes = append(plan.deletes, dc)
+		case topology.DIFF_CHANGE:
+			if images[dc.GetId()] != dc.GetContainerImage() {
+				plan.upgrades = append(plan.upgrades, dc)
+			} else {
+				plan.reloads = append(plan.reloads, dc)
+			}
+		}
+	}
+	for _, dcs := range [][]*topology.DeployConfig{plan.upgrades, plan.reloads, plan.adds, plan.deletes} {
+		configure.SortDeployConfigs(dcs)
+	}
+
+	// there is no way to remove services except migrating them
+	if len(plan.deletes) > 0 && len(plan.adds) == 0 {
+		return plan, errno.ERR_DELETE_SERVICE_WHILE_APPLY_TOPOLOGY_IS_DENIED.
+			F("delete service: %s.host[%s]", plan.deletes[0].GetRole(), plan.deletes[0].GetHost())
+	}
+	// apply reuses the playbooks of scale-out and migrate, both of them deploy services
+	// and update pool for only one role, and the cluster topology is updated at the end
+	// of playbook, so services of different roles can't be converged in one apply.
+	// the apply is idempotent, user can apply intermediate topologies one by one.
+	for _, dc := range append(plan.adds, plan.deletes...) {
+		if dc.GetRole() != plan.adds[0].GetRole() {
+			return plan, errno.ERR_REQUIRE_SAME_ROLE_SERVICES_FOR_APPLY_TOPOLOGY.
+				F("%s and %s, please apply them one role at a time", plan.adds[0].GetRole(), dc.GetRole())
+		}
+	}
+	return plan, nil
+}
+
+func (plan applyPlan) empty() bool {
+	return len(plan.upgrades)+len(plan.reloads)+len(plan.adds)+len(plan.deletes) == 0
+}
+
+func (plan applyPlan) migrating() bool {
+	return len(plan.deletes) > 0
+}
+
+// check added or deleted services as scale-out/migrate command does
+func checkApplyTopology(curveadm *cli.CurveAdm, plan applyPlan, data string, options applyOptions) error {
+	if plan.migrating() {
+		return checkMigrateTopology(curveadm, data)
+	} else if len(plan.adds) == 0 {
+		return nil
+	}
+
+	err := checkScaleOutTopology(curveadm, data)
+	if err != nil {
+		return err
+	}
+	_, err = planScaleOut(curveadm, plan.adds, scaleOutOptions{
+		poolset:         options.poolset,
+		poolsetDiskType: options.poolsetDiskType,
+	})
+	return err
+}
+
+func displayApplyPlan(curveadm *cli.CurveAdm, plan applyPlan) {
+	display := func(action string, dcs []*topology.DeployConfig) {
+		for _, dc := range dcs {
+			curveadm.WriteOutln("  %s %s (host=%s role=%s)", action, dc.GetId(), dc.GetHost(), dc.GetRole())
+		}
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln("%s", color.YellowString("NOTICE: cluster '%s' is about to apply topology:",
+		curveadm.ClusterName()))
+	display(color.BlueString("~ upgrade"), plan.upgrades)
+	display(color.BlueString("~ reload "), plan.reloads)
+	if plan.migrating() {
+		display(color.RedString("- migrate"), plan.deletes)
+		display(color.GreenString("+ migrate"), plan.adds)
+	} else {
+		display(color.GreenString("+ add    "), plan.adds)
+	}
+}
+
+// redactValue masks the value of secret config item, e.g. s3.sk
+func redactValue(key, value string) string {
+	prefix := key + ": "
+	return strings.TrimPrefix(cliutil.RedactSecrets(prefix+value), prefix)
+}
+
+func newApplyPlanOutput(cluster string, dcs []*topology.DeployConfig, plan applyPlan) applyPlanOutput {
+	output := applyPlanOutput{
+		FormatVersion: APPLY_PLAN_FORMAT_VERSION,
+		Cluster:       cluster,
+		Changed:       !plan.empty(),
+		Resources:     []planResource{},
+		Impact:        planImpact{AffectedHosts: []string{}},
+	}
+	current := map[string]*topology.DeployConfig{}
+	for _, dc := range dcs {
+		current[dc.GetId()] = dc
+	}
+
+	hosts := map[string]bool{}
+	add := func(action, operation string, dcs []*topology.DeployConfig) {
+		for _, dc := range dcs {
+			resource := planResource{
+				Id:        dc.GetId(),
+				Role:      dc.GetRole(),
+				Host:      dc.GetHost(),
+				Action:    action,
+				Operation: operation,
+				Restart:   action == PLAN_ACTION_UPDATE,
+				Changes:   []planChange{},
+			}
+			if old, ok := current[dc.GetId()]; ok && action == PLAN_ACTION_UPDATE {
+				for _, change := range topology.DiffConfig(old, dc) {
+					resource.Changes = append(resource.Changes, planChange{
+						Key:    change.Key,
+						Before: redactValue(change.Key, change.Old),
+						After:  redactValue(change.Key, change.New),
+					})
+				}
+			}
+			if resource.Restart {
+				output.Impact.RestartServices++
+			}
+			if role := dc.GetRole(); action != PLAN_ACTION_UPDATE &&
+				(role == topology.ROLE_CHUNKSERVER || role == topology.ROLE_METASERVER) {
+				output.Impact.DataMovement = true
+			}
+			hosts[dc.GetHost()] = true
+			output.Resources = append(output.Resources, resource)
+		}
+	}
+
+	scaleOperation := "scale-out"
+	if plan.migrating() {
+		scaleOperation = "migrate"
+	}
+	add(PLAN_ACTION_UPDATE, "upgrade", plan.upgrades)
+	add(PLAN_ACTION_UPDATE, "reload", plan.reloads)
+	add(PLAN_ACTION_CREATE, scaleOperation, plan.adds)
+	add(PLAN_ACTION_DELETE, scaleOperation, plan.deletes)
+
+	output.Summary = planSummary{
+		Create: len(plan.adds),
+		Update: len(plan.upgrades) + len(plan.reloads),
+		Delete: len(plan.deletes),
+	}
+	for host := range hosts {
+		output.Impact.AffectedHosts = append(output.Impact.AffectedHosts, host)
+	}
+	sort.Strings(output.Impact.AffectedHosts)
+	return output
+}
+
+func displayApplyPlanJSON(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, plan applyPlan) error {
+	output := newApplyPlanOutput(curveadm.ClusterName(), dcs, plan)
+	bytes, err := json.MarshalIndent(output, "", "    ")
+	if err != nil {
+		return errno.ERR_UNKNOWN.E(err)
+	}
+	curveadm.WriteOutln("%s", string(bytes))
+	return nil
+}
+
+func applyTopology(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig,
+	plan applyPlan, data string, options applyOptions) error {
+	// 1) upgrade services whose container image changed
+	if len(plan.upgrades) > 0 {
+		err := recordPreviousImages(curveadm, plan.upgrades)
+		if err != nil {
+			return err
+		}
+		pb, err := genUpgradePlaybook(curveadm, plan.upgrades, upgradeOptions{id: "*", role: "*", host: "*"})
+		if err != nil {
+			return err
+		} else if err = pb.Run(); err != nil {
+			return err
+		}
+	}
+
+	// 2) reload services whose configure changed
+	if len(plan.reloads) > 0 {
+		pb, err := genReloadPlaybook(curveadm, plan.reloads, reloadOptions{id: "*", role: "*", host: "*"})
+		if err != nil {
+			return err
+		} else if err = pb.Run(); err != nil {
+			return err
+		}
+	}
+
+	// 3) migrate or scale out services, which also update cluster topology
+	if plan.migrating() {
+		pb, err := genMigratePlaybook(curveadm, dcs, migrateOptions{
+			poolset:         options.poolset,
+			poolsetDiskType: options.poolsetDiskType,
+		}, data)
+		if err != nil {
+			return err
+		}
+		return pb.Run()
+	} else if len(plan.adds) > 0 {
+		scaleOptions := scaleOutOptions{
+			insecure:        options.insecure,
+			poolset:         options.poolset,
+			poolsetDiskType: options.poolsetDiskType,
+		}
+		err := precheckBeforeScaleOut(curveadm, scaleOptions, data)
+		if err != nil {
+			return err
+		}
+		pb, err := genScaleOutPlaybook(curveadm, dcs, data, scaleOptions)
+		if err != nil {
+			return err
+		}
+		return pb.Run()
+	}
+
+	// 4) only configure changed, update cluster topology directly
+	err := curveadm.Storage().SetClusterTopology(curveadm.ClusterId(), data)
+	if err != nil {
+		return errno.ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED.E(err)
+	}
+	return nil
+}
+
+func runApply(curveadm *cli.CurveAdm, options applyOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) read desired topology from file
+	data, err := readTopology(curveadm, options.filename)
+	if err != nil {
+		return err
+	}
+	_, err = curveadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+
+	// 3) plan actions by difference
+	diffs, err := curveadm.DiffTopology(curveadm.ClusterTopologyData(), data)
+	if err != nil {
+		return err
+	}
+	plan, err := planApply(dcs, diffs)
+	if err != nil {
+		return err
+	} else if !plan.empty() {
+		err = checkApplyTopology(curveadm, plan, data, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 4) display plan
+	if options.output == APPLY_OUTPUT_JSON {
+		return displayApplyPlanJSON(curveadm, dcs, plan)
+	} else if plan.empty() {
+		curveadm.WriteOutln("%s", color.GreenString("Cluster '%s' is already up to date",
+			curveadm.ClusterName()))
+		return nil
+	}
+	displayApplyPlan(curveadm, plan)
+	if options.plan {
+		return nil
+	}
+
+	// 5) confirm by user
+	if !options.yes {
+		if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+			curveadm.WriteOutln(tui.PromptCancelOpetation("apply topology"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 6) apply topology
+	err = applyTopology(curveadm, dcs, plan, data, options)
+	if err != nil {
+		return err
+	}
+
+	// 7) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln("%s", color.GreenString("Cluster '%s' successfully applied ^_^.",
+		curveadm.ClusterName()))
+	return nil
+}
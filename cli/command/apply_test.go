@@ -0,0 +1,180 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	APPLY_TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: opencurvedocker/curvebs:v1.2
+  log_dir: /data/logs/${service_role}
+  data_dir: /data/${service_role}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 8200
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+)
+
+func diffApplyTopology(t *testing.T, data1, data2 string) ([]*topology.DeployConfig, []topology.TopologyDiff) {
+	ctx := topology.NewContext()
+	for _, host := range []string{"host1", "host2", "host3", "host4", "host5", "host6"} {
+		ctx.Add(host, host)
+	}
+	dcs, err := topology.ParseTopology(data1, ctx)
+	assert.Nil(t, err)
+	diffs, err := topology.DiffTopology(data1, data2, ctx)
+	assert.Nil(t, err)
+	return dcs, diffs
+}
+
+func TestApply_PlanApply(t *testing.T) {
+	assert := assert.New(t)
+
+	// nothing changed
+	plan, err := planApply(diffApplyTopology(t, APPLY_TOPOLOGY, APPLY_TOPOLOGY))
+	assert.Nil(err)
+	assert.True(plan.empty())
+
+	// configure changed for chunkserver
+	data := strings.Replace(APPLY_TOPOLOGY, "listen.port: 8200", "listen.port: 8200\n    copyset.scan_interval_sec: 10", 1)
+	plan, err = planApply(diffApplyTopology(t, APPLY_TOPOLOGY, data))
+	assert.Nil(err)
+	assert.Len(plan.upgrades, 0)
+	assert.Len(plan.reloads, 3)
+
+	// image changed for all services
+	data = strings.Replace(APPLY_TOPOLOGY, "curvebs:v1.2", "curvebs:v1.3", 1)
+	plan, err = planApply(diffApplyTopology(t, APPLY_TOPOLOGY, data))
+	assert.Nil(err)
+	assert.Len(plan.upgrades, 6)
+	assert.Len(plan.reloads, 0)
+
+	// scale out
+	data = APPLY_TOPOLOGY + "    - host: host4\n    - host: host5\n    - host: host6\n"
+	plan, err = planApply(diffApplyTopology(t, APPLY_TOPOLOGY, data))
+	assert.Nil(err)
+	assert.Len(plan.adds, 3)
+	assert.False(plan.migrating())
+
+	// migrate
+	data = strings.TrimSuffix(APPLY_TOPOLOGY, "    - host: host3\n") + "    - host: host4\n"
+	plan, err = planApply(diffApplyTopology(t, APPLY_TOPOLOGY, data))
+	assert.Nil(err)
+	assert.Len(plan.adds, 1)
+	assert.Len(plan.deletes, 1)
+	assert.True(plan.migrating())
+}
+
+func TestApply_PlanApplyDenied(t *testing.T) {
+	assert := assert.New(t)
+
+	// delete services without migrating
+	data := strings.TrimSuffix(APPLY_TOPOLOGY, "    - host: host3\n")
+	_, err := planApply(diffApplyTopology(t, APPLY_TOPOLOGY, data))
+	assert.ErrorIs(err, errno.ERR_DELETE_SERVICE_WHILE_APPLY_TOPOLOGY_IS_DENIED)
+
+	// add services with different roles
+	data = strings.Replace(APPLY_TOPOLOGY, "    - host: host3\n", "    - host: host3\n    - host: host4\n", 2)
+	_, err = planApply(diffApplyTopology(t, APPLY_TOPOLOGY, data))
+	assert.ErrorIs(err, errno.ERR_REQUIRE_SAME_ROLE_SERVICES_FOR_APPLY_TOPOLOGY)
+}
+
+func TestApply_PlanOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	// nothing changed
+	dcs, diffs := diffApplyTopology(t, APPLY_TOPOLOGY, APPLY_TOPOLOGY)
+	plan, err := planApply(dcs, diffs)
+	assert.Nil(err)
+	output := newApplyPlanOutput("c1", dcs, plan)
+	assert.False(output.Changed)
+	assert.Equal(APPLY_PLAN_FORMAT_VERSION, output.FormatVersion)
+	assert.Len(output.Resources, 0)
+
+	// image changed for all services
+	data := strings.Replace(APPLY_TOPOLOGY, "curvebs:v1.2", "curvebs:v1.3", 1)
+	dcs, diffs = diffApplyTopology(t, APPLY_TOPOLOGY, data)
+	plan, err = planApply(dcs, diffs)
+	assert.Nil(err)
+	output = newApplyPlanOutput("c1", dcs, plan)
+	assert.True(output.Changed)
+	assert.Equal(planSummary{Update: 6}, output.Summary)
+	assert.Equal(6, output.Impact.RestartServices)
+	assert.Equal([]string{"host1", "host2", "host3"}, output.Impact.AffectedHosts)
+	assert.False(output.Impact.DataMovement)
+	resource := output.Resources[0]
+	assert.Equal(PLAN_ACTION_UPDATE, resource.Action)
+	assert.Equal("upgrade", resource.Operation)
+	assert.Contains(resource.Changes, planChange{
+		Key:    topology.CONFIG_CONTAINER_IMAGE.Key(),
+		Before: "opencurvedocker/curvebs:v1.2",
+		After:  "opencurvedocker/curvebs:v1.3",
+	})
+
+	// migrate
+	data = strings.TrimSuffix(APPLY_TOPOLOGY, "    - host: host3\n") + "    - host: host4\n"
+	dcs, diffs = diffApplyTopology(t, APPLY_TOPOLOGY, data)
+	plan, err = planApply(dcs, diffs)
+	assert.Nil(err)
+	output = newApplyPlanOutput("c1", dcs, plan)
+	assert.Equal(planSummary{Create: 1, Delete: 1}, output.Summary)
+	assert.Equal(0, output.Impact.RestartServices)
+	assert.True(output.Impact.DataMovement)
+	assert.Equal("migrate", output.Resources[0].Operation)
+	assert.Equal(PLAN_ACTION_CREATE, output.Resources[0].Action)
+	assert.Equal(PLAN_ACTION_DELETE, output.Resources[1].Action)
+}
+
+func TestApply_RedactValue(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("v1.2", redactValue("container_image", "v1.2"))
+	assert.Equal(cliutil.REDACTED, redactValue("s3.sk", "secret"))
+	assert.Equal("", redactValue("s3.sk", ""))
+}
@@ -0,0 +1,93 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package artifacts
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewArtifactsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "artifacts",
+		Short: "Manage artifacts for offline deployment",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewPullCommand(curveadm),
+		NewExportCommand(curveadm),
+		NewImportCommand(curveadm),
+	)
+	return cmd
+}
+
+/*
+ * the images required by artifacts consist of:
+ *   (1) images of services in current cluster topology
+ *   (2) image of format configure (if specified)
+ *   (3) images specified by user
+ */
+func getArtifactImages(curveadm *cli.CurveAdm, format string, extra []string) ([]string, error) {
+	images := []string{}
+	exist := map[string]bool{}
+	add := func(image string) {
+		if len(image) > 0 && !exist[image] {
+			exist[image] = true
+			images = append(images, image)
+		}
+	}
+
+	if curveadm.ClusterId() != -1 && len(curveadm.ClusterTopologyData()) > 0 {
+		dcs, err := curveadm.ParseTopology()
+		if err != nil {
+			return nil, err
+		}
+		for _, dc := range dcs {
+			add(dc.GetContainerImage())
+		}
+	}
+
+	if len(format) > 0 {
+		fcs, err := configure.ParseFormat(format)
+		if err != nil {
+			return nil, err
+		}
+		for _, fc := range fcs {
+			add(fc.GetContainerImage())
+		}
+	}
+
+	for _, image := range extra {
+		add(image)
+	}
+
+	if len(images) == 0 {
+		return nil, errno.ERR_NO_IMAGES_FOR_ARTIFACTS.
+			S("please checkout a cluster or specify images by --image")
+	}
+	return images, nil
+}
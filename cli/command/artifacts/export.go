@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package artifacts
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/playbook"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportExample = `Examples:
+  $ curveadm artifacts export                                # Export pulled images of current cluster to artifacts.tar
+  $ curveadm artifacts export -o /path/to/artifacts.tar      # Export to specified file
+  $ curveadm artifacts export --format format.yaml           # Export images of current cluster and format`
+)
+
+type exportOptions struct {
+	output string
+	format string
+	images []string
+}
+
+func NewExportCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options exportOptions
+
+	cmd := &cobra.Command{
+		Use:     "export [OPTIONS]",
+		Short:   "Export pulled images into a tarball",
+		Args:    cliutil.NoArgs,
+		Example: exportExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.output, "output", "o", "artifacts.tar", "Output to specified file")
+	flags.StringVar(&options.format, "format", "", "Specify the path of format configure file")
+	flags.StringSliceVar(&options.images, "image", []string{}, "Specify extra images")
+
+	return cmd
+}
+
+func genExportPlaybook(curveadm *cli.CurveAdm, images []string, output string) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.SAVE_ARTIFACTS,
+		Configs: nil,
+		Options: map[string]interface{}{
+			comm.KEY_ARTIFACT_IMAGES: images,
+			comm.KEY_ARTIFACT_OUTPUT: output,
+		},
+	})
+	return pb
+}
+
+func runExport(curveadm *cli.CurveAdm, options exportOptions) error {
+	// 1) collect images
+	images, err := getArtifactImages(curveadm, options.format, options.images)
+	if err != nil {
+		return err
+	}
+
+	// 2) save images into tarball
+	err = genExportPlaybook(curveadm, images, options.output).Run()
+	if err != nil {
+		return err
+	}
+
+	// 3) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Exported %d images to '%s'"), len(images), options.output)
+	return nil
+}
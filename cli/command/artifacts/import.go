@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package artifacts
+
+import (
+	"io"
+	"os"
+	"path"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importExample = `Examples:
+  $ curveadm artifacts import /path/to/artifacts.tar  # Import artifacts for 'image_source: local'`
+)
+
+type importOptions struct {
+	filename string
+}
+
+func NewImportCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options importOptions
+
+	cmd := &cobra.Command{
+		Use:     "import ARTIFACTS",
+		Short:   "Import artifacts which exported by 'curveadm artifacts export'",
+		Args:    utils.ExactArgs(1),
+		Example: importExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.filename = args[0]
+			return runImport(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(path.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Sync()
+}
+
+func runImport(curveadm *cli.CurveAdm, options importOptions) error {
+	// 1) check artifacts
+	if !utils.PathExist(options.filename) {
+		return errno.ERR_IMAGE_ARCHIVE_NOT_FOUND.
+			F("artifacts: %s", options.filename)
+	}
+
+	// 2) copy artifacts into curveadm data directory, the images in it
+	//    will be loaded onto hosts while deploy with 'image_source: local'
+	err := copyFile(options.filename, curveadm.ImageArchivePath())
+	if err != nil {
+		return errno.ERR_IMPORT_IMAGE_ARCHIVE_FAILED.E(err)
+	}
+
+	// 3) print success prompt
+	curveadm.WriteOutln(color.GreenString("Artifacts '%s' imported, set 'image_source: local' to use it"),
+		options.filename)
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package artifacts
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/playbook"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pullExample = `Examples:
+  $ curveadm artifacts pull                                  # Pull images of current cluster
+  $ curveadm artifacts pull --format format.yaml             # Pull images of current cluster and format
+  $ curveadm artifacts pull --image opencurvedocker/curvebs:v1.2  # Pull specified image`
+)
+
+type pullOptions struct {
+	format string
+	images []string
+}
+
+func NewPullCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options pullOptions
+
+	cmd := &cobra.Command{
+		Use:     "pull [OPTIONS]",
+		Short:   "Pull images into current machine",
+		Args:    cliutil.NoArgs,
+		Example: pullExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPull(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", "", "Specify the path of format configure file")
+	flags.StringSliceVar(&options.images, "image", []string{}, "Specify extra images")
+
+	return cmd
+}
+
+func genPullPlaybook(curveadm *cli.CurveAdm, images []string) *playbook.Playbook {
+	configs := []interface{}{}
+	for _, image := range images {
+		configs = append(configs, image)
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.PULL_ARTIFACT,
+		Configs: configs,
+	})
+	return pb
+}
+
+func runPull(curveadm *cli.CurveAdm, options pullOptions) error {
+	// 1) collect images
+	images, err := getArtifactImages(curveadm, options.format, options.images)
+	if err != nil {
+		return err
+	}
+
+	// 2) pull images in current machine
+	err = genPullPlaybook(curveadm, images).Run()
+	if err != nil {
+		return err
+	}
+
+	// 3) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Pulled %d images, export them by 'curveadm artifacts export'"), len(images))
+	return nil
+}
@@ -25,16 +25,51 @@
 package command
 
 import (
+	"strings"
+	"time"
+
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/tui"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+const (
+	AUDIT_LS_EXAMPLE = `Examples:
+  $ curveadm audit ls                                # List latest 20 audit logs
+  $ curveadm audit ls --cluster my-cluster -n 0      # List all audit logs of cluster 'my-cluster'
+  $ curveadm audit ls --operator alice --status fail # List failed commands executed by alice
+  $ curveadm audit ls --since 24h --command deploy   # List deploy commands in last 24 hours`
+)
+
+var (
+	AUDIT_STATUS = map[string]int{
+		"abort":   comm.AUDIT_STATUS_ABORT,
+		"success": comm.AUDIT_STATUS_SUCCESS,
+		"fail":    comm.AUDIT_STATUS_FAIL,
+		"cancel":  comm.AUDIT_STATUS_CANCEL,
+	}
+)
+
 type auditOptions struct {
-	tail    int
-	verbose bool
+	tail     int
+	verbose  bool
+	cluster  string
+	operator string
+	status   string
+	since    time.Duration
+	command  string
+}
+
+func checkAuditOptions(options auditOptions) error {
+	if _, ok := AUDIT_STATUS[options.status]; len(options.status) > 0 && !ok {
+		return errno.ERR_INVALID_AUDIT_STATUS.
+			F("status: %s", options.status)
+	}
+	return nil
 }
 
 func NewAuditCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -54,15 +89,69 @@ func NewAuditCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.IntVarP(&options.tail, "tail", "n", 20, "Number of lines to show from the end of the logs (0 means all)")
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for clusters")
 
+	cmd.AddCommand(NewAuditListCommand(curveadm))
 	return cmd
 }
 
+func NewAuditListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options auditOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Short:   "List audit logs with filters",
+		Args:    cliutil.NoArgs,
+		Example: AUDIT_LS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkAuditOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAudit(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.IntVarP(&options.tail, "tail", "n", 20, "Number of lines to show from the end of the logs (0 means all)")
+	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for audit logs")
+	flags.StringVar(&options.cluster, "cluster", "", "Specify cluster which command executed in")
+	flags.StringVar(&options.operator, "operator", "", "Specify user who executed command")
+	flags.StringVar(&options.status, "status", "", "Specify command result (success/fail/cancel/abort)")
+	flags.DurationVar(&options.since, "since", 0, "Only show commands executed in the duration (e.g. 24h)")
+	flags.StringVar(&options.command, "command", "", "Only show commands which contain the string")
+
+	return cmd
+}
+
+// NOTE: the operator matches both the user and the sudo user, e.g: root(sudo:alice)
+func filterAuditLogs(auditLogs []storage.AuditLog, options auditOptions) []storage.AuditLog {
+	out := []storage.AuditLog{}
+	now := time.Now()
+	for _, auditLog := range auditLogs {
+		if len(options.cluster) > 0 && auditLog.Cluster != options.cluster {
+			continue
+		} else if len(options.operator) > 0 &&
+			auditLog.Operator != options.operator &&
+			!strings.Contains(auditLog.Operator, "sudo:"+options.operator+")") {
+			continue
+		} else if len(options.status) > 0 && auditLog.Status != AUDIT_STATUS[options.status] {
+			continue
+		} else if options.since > 0 && now.Sub(auditLog.ExecuteTime) > options.since {
+			continue
+		} else if len(options.command) > 0 && !strings.Contains(auditLog.Command, options.command) {
+			continue
+		}
+		out = append(out, auditLog)
+	}
+	return out
+}
+
 func runAudit(curveadm *cli.CurveAdm, options auditOptions) error {
 	auditLogs, err := curveadm.Storage().GetAuditLogs()
 	if err != nil {
 		return errno.ERR_GET_AUDIT_LOGS_FAILE.E(err)
 	}
 
+	auditLogs = filterAuditLogs(auditLogs, options)
 	tail := options.tail
 	if tail != 0 && tail > 0 && tail < len(auditLogs) {
 		auditLogs = auditLogs[len(auditLogs)-tail:]
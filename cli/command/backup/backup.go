@@ -0,0 +1,285 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/backup"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BACKUP_ETCD_EXAMPLE = `Examples:
+  $ curveadm backup etcd                                 # Backup etcd snapshot into ~/.curveadm/data/backup
+  $ curveadm backup etcd --to /data/backup --keep 30     # Backup into specified directory and keep the latest 30 snapshots
+  $ curveadm backup etcd --to s3://my-bucket/curve       # Backup into S3 which snapshotclone (or metaserver) uses
+  $ curveadm backup etcd --to s3://my-bucket/curve --s3-endpoint 10.0.0.1:9000 --s3-access-key AK --s3-secret-key SK`
+
+	BACKUP_MDS_EXAMPLE = `Examples:
+  $ curveadm backup mds                                  # Dump mds metadata (topology, pools, files) into ~/.curveadm/data/backup
+  $ curveadm backup mds --to s3://my-bucket/curve        # Dump into S3 which snapshotclone (or metaserver) uses`
+
+	BACKUP_DB_EXAMPLE = `Examples:
+  $ curveadm backup db                                   # Backup database of curveadm into ~/.curveadm/data/backup
+  $ curveadm backup db --to /data/backup --keep 0        # Backup into specified directory and keep all backups`
+
+	DEFAULT_BACKUP_KEEP = 7
+)
+
+var (
+	// the service role which backup taken through, database is local
+	BACKUP_TARGET_ROLES = map[string]string{
+		backup.BACKUP_KIND_ETCD: topology.ROLE_ETCD,
+		backup.BACKUP_KIND_MDS:  topology.ROLE_MDS,
+	}
+)
+
+type (
+	backupOptions struct {
+		target string
+		to     string
+		host   string
+		keep   int
+		s3     s3Options
+	}
+
+	backupResult struct {
+		location string
+		details  []string
+		removed  []string
+	}
+)
+
+func checkBackupOptions(options backupOptions) error {
+	if !backup.IsValidKind(options.target) {
+		return errno.ERR_INVALID_BACKUP_OPTIONS.
+			F("target: %s, it should be one of %v", options.target, backup.BACKUP_KINDS)
+	} else if options.keep < 0 {
+		return errno.ERR_INVALID_BACKUP_OPTIONS.
+			F("--keep: %d, it should be greater than or equal to 0", options.keep)
+	}
+	return nil
+}
+
+func newBackupTargetCommand(curveadm *cli.CurveAdm, target, short, example string) *cobra.Command {
+	options := backupOptions{target: target}
+
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s [OPTIONS]", target),
+		Short:   short,
+		Args:    cliutil.NoArgs,
+		Example: example,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkBackupOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackup(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.to, "to", "", "Specify directory or S3 location (s3://BUCKET/PREFIX) to store backup (default: ~/.curveadm/data/backup)")
+	if _, ok := BACKUP_TARGET_ROLES[target]; ok {
+		flags.StringVar(&options.host, "host", "", fmt.Sprintf("Specify %s service host which the backup is taken through", BACKUP_TARGET_ROLES[target]))
+	}
+	flags.IntVar(&options.keep, "keep", DEFAULT_BACKUP_KEEP, "Specify number of latest backups to keep, 0 means keep all")
+	addS3Flags(flags, &options.s3)
+
+	return cmd
+}
+
+func NewBackupEtcdCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	return newBackupTargetCommand(curveadm, backup.BACKUP_KIND_ETCD,
+		"Backup etcd snapshot taken from leader", BACKUP_ETCD_EXAMPLE)
+}
+
+func NewBackupMdsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	return newBackupTargetCommand(curveadm, backup.BACKUP_KIND_MDS,
+		"Backup mds metadata dump", BACKUP_MDS_EXAMPLE)
+}
+
+func NewBackupDBCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	return newBackupTargetCommand(curveadm, backup.BACKUP_KIND_DB,
+		"Backup database of curveadm", BACKUP_DB_EXAMPLE)
+}
+
+// the backup is taken through the service on host (default: the first one)
+func getBackupService(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, role, host string) (*topology.DeployConfig, error) {
+	services := curveadm.FilterDeployConfigByRole(dcs, role)
+	if len(services) == 0 {
+		return nil, errno.ERR_INVALID_BACKUP_OPTIONS.
+			F("no %s services in cluster '%s'", role, curveadm.ClusterName())
+	}
+	for _, dc := range services {
+		if len(host) == 0 || dc.GetHost() == host {
+			return dc, nil
+		}
+	}
+	return nil, errno.ERR_INVALID_BACKUP_OPTIONS.
+		F("--host: no %s service on host '%s'", role, host)
+}
+
+func genBackupPlaybook(curveadm *cli.CurveAdm,
+	target string,
+	dc *topology.DeployConfig,
+	localPath string,
+	silent bool) *playbook.Playbook {
+	step := playbook.BACKUP_ETCD_SNAPSHOT
+	if target == backup.BACKUP_KIND_MDS {
+		step = playbook.DUMP_MDS_METADATA
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    step,
+		Configs: []*topology.DeployConfig{dc},
+		Options: map[string]interface{}{
+			comm.KEY_BACKUP_LOCAL_PATH: localPath,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentMainBar: silent,
+			SilentSubBar:  silent,
+		},
+	})
+	return pb
+}
+
+// takeBackup saves backup of target into local path, it returns details of backup
+func takeBackup(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options backupOptions,
+	localPath string,
+	silent bool) ([]string, error) {
+	// database of curveadm is copied by itself
+	if options.target == backup.BACKUP_KIND_DB {
+		if len(curveadm.Config().GetDBPath()) == 0 {
+			return nil, errno.ERR_BACKUP_DATABASE_FAILED.
+				S("only sqlite database can be backed up, backup rqlite by itself")
+		} else if err := curveadm.Storage().Backup(localPath); err != nil {
+			return nil, errno.ERR_BACKUP_DATABASE_FAILED.E(err)
+		}
+		return fileDetails(localPath), nil
+	}
+
+	dc, err := getBackupService(curveadm, dcs, BACKUP_TARGET_ROLES[options.target], options.host)
+	if err != nil {
+		return nil, err
+	}
+	err = genBackupPlaybook(curveadm, options.target, dc, localPath, silent).Run()
+	if err != nil {
+		return nil, err
+	} else if options.target == backup.BACKUP_KIND_MDS {
+		return fileDetails(localPath), nil
+	}
+
+	status := curveadm.MemStorage().Get(comm.KEY_ETCD_SNAPSHOT_STATUS).(*task.EtcdSnapshotStatus)
+	return []string{
+		fmt.Sprintf("Revision: %d", status.Revision),
+		fmt.Sprintf("Total keys: %d", status.TotalKey),
+		fmt.Sprintf("Total size: %s", humanize.IBytes(uint64(status.TotalSize))),
+	}, nil
+}
+
+func fileDetails(localPath string) []string {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return []string{}
+	}
+	return []string{fmt.Sprintf("Size: %s", humanize.IBytes(uint64(info.Size())))}
+}
+
+// doBackup takes backup of target, stores it and removes the expired ones
+func doBackup(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options backupOptions,
+	silent bool) (*backupResult, error) {
+	// 1) open the store before taking backup, so the invalid location fails fast
+	location := cliutil.Choose(len(options.to) > 0, options.to, curveadm.BackupDir())
+	store, err := backup.NewStore(location, getS3Config(dcs, options.s3))
+	if err != nil {
+		return nil, errno.ERR_INVALID_BACKUP_OPTIONS.E(err)
+	}
+
+	// 2) take backup into temporary file
+	cluster := curveadm.ClusterName()
+	name := backup.BackupName(options.target, cluster, time.Now())
+	localPath := path.Join(curveadm.TempDir(), name)
+	defer os.Remove(localPath)
+	details, err := takeBackup(curveadm, dcs, options, localPath, silent)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3) store backup and remove the expired ones
+	if err := store.Put(localPath, name); err != nil {
+		return nil, errno.ERR_STORE_BACKUP_FAILED.E(err)
+	}
+	removed, err := backup.Prune(store, options.target, cluster, options.keep)
+	if err != nil {
+		return nil, errno.ERR_STORE_BACKUP_FAILED.E(err)
+	}
+	return &backupResult{
+		location: store.Location(name),
+		details:  details,
+		removed:  removed,
+	}, nil
+}
+
+func runBackup(curveadm *cli.CurveAdm, options backupOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) take backup
+	result, err := doBackup(curveadm, dcs, options, false)
+	if err != nil {
+		return err
+	}
+
+	// 3) print backup
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Backup %s to '%s' success"), options.target, result.location)
+	for _, detail := range result.details {
+		curveadm.WriteOutln("  - %s", detail)
+	}
+	if len(result.removed) > 0 {
+		curveadm.WriteOutln("  - Expired backups removed: %d", len(result.removed))
+	}
+	return nil
+}
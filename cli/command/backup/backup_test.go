@@ -0,0 +1,133 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/backup"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+const BACKUP_TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: opencurvedocker/curvebs:v1.2
+  data_dir: /data/${service_role}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+snapshotclone_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 5555
+    s3.ak: ak
+    s3.sk: sk
+    s3.nos_address: 10.0.0.10:9000
+    s3.snapshot_bucket_name: snapshot
+  deploy:
+    - host: host1
+`
+
+func parseBackupTopology(t *testing.T) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	ctx.Add("host1", "10.0.0.1")
+	ctx.Add("host2", "10.0.0.2")
+	ctx.Add("host3", "10.0.0.3")
+	dcs, err := topology.ParseTopology(BACKUP_TOPOLOGY, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func TestBackup_GetS3Config(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseBackupTopology(t)
+
+	// S3 of snapshotclone
+	config := getS3Config(dcs, s3Options{})
+	assert.Equal(backup.S3Config{Endpoint: "10.0.0.10:9000", AccessKey: "ak", SecretKey: "sk"}, config)
+
+	// overridden by flags
+	config = getS3Config(dcs, s3Options{endpoint: "10.0.0.11:9000", secretKey: "sk2"})
+	assert.Equal(backup.S3Config{Endpoint: "10.0.0.11:9000", AccessKey: "ak", SecretKey: "sk2"}, config)
+
+	// no S3 in topology
+	config = getS3Config(dcs[:3], s3Options{endpoint: "10.0.0.11:9000"})
+	assert.Equal(backup.S3Config{Endpoint: "10.0.0.11:9000"}, config)
+}
+
+func TestBackup_CheckOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, target := range backup.BACKUP_KINDS {
+		assert.Nil(checkBackupOptions(backupOptions{target: target, keep: 0}))
+	}
+	for _, options := range []backupOptions{{target: "etcd", keep: -1}, {target: "metaserver"}} {
+		err := checkBackupOptions(options)
+		assert.Equal(errno.ERR_INVALID_BACKUP_OPTIONS.GetCode(), err.(*errno.ErrorCode).GetCode())
+	}
+
+	snapshot := filepath.Join(t.TempDir(), "etcd-c1-20231016150405.db")
+	assert.Nil(os.WriteFile(snapshot, []byte("snapshot"), 0644))
+	assert.Nil(checkRestoreEtcdOptions(restoreEtcdOptions{from: snapshot}))
+	assert.Nil(checkRestoreEtcdOptions(restoreEtcdOptions{from: "s3://bucket/etcd-c1-20231016150405.db"}))
+	for _, from := range []string{"", snapshot + ".bak"} {
+		err := checkRestoreEtcdOptions(restoreEtcdOptions{from: from})
+		assert.Equal(errno.ERR_INVALID_RESTORE_OPTIONS.GetCode(), err.(*errno.ErrorCode).GetCode(), from)
+	}
+}
+
+func TestBackup_CheckScheduleAddOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(checkScheduleAddOptions(scheduleAddOptions{target: "etcd", cron: "0 2 * * *", keep: 7}))
+	assert.Nil(checkScheduleAddOptions(scheduleAddOptions{target: "db", cron: "@weekly"}))
+	for _, options := range []scheduleAddOptions{
+		{target: "metaserver", cron: "@daily"},
+		{target: "mds", cron: ""},
+		{target: "mds", cron: "0 25 * * *"},
+		{target: "mds", cron: "@daily", keep: -1},
+	} {
+		err := checkScheduleAddOptions(options)
+		assert.Equal(errno.ERR_INVALID_BACKUP_SCHEDULE_OPTIONS.GetCode(), err.(*errno.ErrorCode).GetCode(), options)
+	}
+}
+
+func TestBackup_BriefError(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("410062: dump mds metadata failed (server-list: timeout)",
+		briefError(errno.ERR_DUMP_MDS_METADATA_FAILED.S("server-list: timeout")))
+	assert.Equal("410064: some backup schedules run failed", briefError(errno.ERR_RUN_BACKUP_SCHEDULES_FAILED))
+}
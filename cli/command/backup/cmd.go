@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/backup"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type s3Options struct {
+	endpoint  string
+	accessKey string
+	secretKey string
+}
+
+func NewBackupCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Backup cluster metadata and manage backup schedules",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewBackupEtcdCommand(curveadm),
+		NewBackupMdsCommand(curveadm),
+		NewBackupDBCommand(curveadm),
+		NewBackupScheduleCommand(curveadm),
+		NewBackupRunCommand(curveadm),
+		NewBackupListCommand(curveadm),
+	)
+	return cmd
+}
+
+func NewRestoreCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore cluster metadata from backup",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewRestoreEtcdCommand(curveadm),
+	)
+	return cmd
+}
+
+func addS3Flags(flags *pflag.FlagSet, options *s3Options) {
+	flags.StringVar(&options.endpoint, "s3-endpoint", "", "Specify S3 endpoint (default: S3 of snapshotclone or metaserver in topology)")
+	flags.StringVar(&options.accessKey, "s3-access-key", "", "Specify S3 access key")
+	flags.StringVar(&options.secretKey, "s3-secret-key", "", "Specify S3 secret key")
+}
+
+/*
+ * the S3 which snapshotclone uploads snapshot to or metaserver deletes
+ * data in is used if not specified, each of them can be overridden by
+ * the flag, e.g: backup to another bucket with same credentials.
+ */
+func getS3Config(dcs []*topology.DeployConfig, options s3Options) backup.S3Config {
+	config := backup.S3Config{}
+	for _, dc := range checker.FilterS3DeployConfigs(dcs, false) {
+		endpoint := cliutil.Choose(len(dc.GetS3Endpoint()) > 0, dc.GetS3Endpoint(), dc.GetS3Address())
+		if len(endpoint) > 0 && endpoint != checker.S3_TEMPLATE_VALUE {
+			config = backup.S3Config{
+				Endpoint:  endpoint,
+				AccessKey: dc.GetS3AccessKey(),
+				SecretKey: dc.GetS3SecretKey(),
+			}
+			break
+		}
+	}
+
+	if len(options.endpoint) > 0 {
+		config.Endpoint = options.endpoint
+	}
+	if len(options.accessKey) > 0 {
+		config.AccessKey = options.accessKey
+	}
+	if len(options.secretKey) > 0 {
+		config.SecretKey = options.secretKey
+	}
+	return config
+}
@@ -0,0 +1,138 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/backup"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BACKUP_LIST_EXAMPLE = `Examples:
+  $ curveadm backup ls                                   # List backup schedules and backups of current cluster
+  $ curveadm backup ls --from s3://my-bucket/curve       # List backups in specified location too`
+)
+
+type listOptions struct {
+	from []string
+	s3   s3Options
+}
+
+func NewBackupListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List backup schedules and backups",
+		Args:    cliutil.NoArgs,
+		Example: BACKUP_LIST_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackupList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&options.from, "from", []string{}, "Specify directories or S3 locations which backups listed from")
+	addS3Flags(flags, &options.s3)
+
+	return cmd
+}
+
+// the backups are listed from default location, locations of schedules and specified ones
+func getBackupLocations(curveadm *cli.CurveAdm, schedules []storage.BackupSchedule, from []string) []string {
+	locations := []string{curveadm.BackupDir()}
+	for _, schedule := range schedules {
+		locations = append(locations, schedule.Location)
+	}
+	locations = append(locations, from...)
+
+	out := []string{}
+	seen := map[string]bool{}
+	for _, location := range locations {
+		if len(location) > 0 && !seen[location] {
+			seen[location] = true
+			out = append(out, location)
+		}
+	}
+	return out
+}
+
+func runBackupList(curveadm *cli.CurveAdm, options listOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) list schedules
+	schedules, err := curveadm.Storage().GetBackupSchedules(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_GET_BACKUP_SCHEDULES_FAILED.E(err)
+	}
+	curveadm.WriteOutln("Backup schedules:")
+	if len(schedules) == 0 {
+		curveadm.WriteOutln("  (none, add one by 'curveadm backup schedule add')")
+	} else {
+		curveadm.WriteOut(tui.FormatBackupSchedules(schedules, curveadm.BackupDir()))
+	}
+
+	// 3) list backups, the unreachable location is warned and skipped
+	backups := []backup.Backup{}
+	config := getS3Config(dcs, options.s3)
+	warnings := []string{}
+	for _, location := range getBackupLocations(curveadm, schedules, options.from) {
+		store, err := backup.NewStore(location, config)
+		if err == nil {
+			var items []backup.Backup
+			if items, err = backup.ListBackups(store, curveadm.ClusterName()); err == nil {
+				backups = append(backups, items...)
+				continue
+			}
+		}
+		warnings = append(warnings, color.YellowString("WARNING: list backups in '%s' failed: %s", location, err))
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].CreateTime.Before(backups[j].CreateTime)
+	})
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln("Backups:")
+	if len(backups) == 0 {
+		curveadm.WriteOutln("  (none)")
+	} else {
+		curveadm.WriteOut(tui.FormatBackups(backups))
+	}
+	for _, warning := range warnings {
+		curveadm.WriteOutln(warning)
+	}
+	return nil
+}
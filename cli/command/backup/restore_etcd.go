@@ -0,0 +1,218 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"os"
+	"path"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/backup"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	RESTORE_ETCD_EXAMPLE = `Examples:
+  $ curveadm restore etcd --from /data/backup/etcd-my-cluster-20231016150405.db  # Restore etcd from local snapshot
+  $ curveadm restore etcd --from s3://my-bucket/curve/etcd-my-cluster-20231016150405.db
+
+  # Restore etcd onto new hosts after the old ones lost: replace hosts of etcd
+  # services in topology by 'curveadm config commit', then restore etcd`
+)
+
+var (
+	// the services on new hosts are deployed before restoring
+	RESTORE_ETCD_DEPLOY_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+	}
+
+	RESTORE_ETCD_STEPS = []int{
+		playbook.STOP_SERVICE,
+		playbook.RESTORE_ETCD_DATA,
+		playbook.START_SERVICE,
+		playbook.WAIT_ETCD_HEALTHY,
+	}
+)
+
+type restoreEtcdOptions struct {
+	from string
+	s3   s3Options
+}
+
+func checkRestoreEtcdOptions(options restoreEtcdOptions) error {
+	if len(options.from) == 0 {
+		return errno.ERR_INVALID_RESTORE_OPTIONS.
+			S("--from: snapshot is required")
+	} else if !backup.IsS3Location(options.from) && !cliutil.PathExist(options.from) {
+		return errno.ERR_INVALID_RESTORE_OPTIONS.
+			F("--from: %s: no such file", options.from)
+	}
+	return nil
+}
+
+func NewRestoreEtcdCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options restoreEtcdOptions
+
+	cmd := &cobra.Command{
+		Use:     "etcd [OPTIONS]",
+		Short:   "Restore etcd cluster from snapshot",
+		Args:    cliutil.NoArgs,
+		Example: RESTORE_ETCD_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkRestoreEtcdOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRestoreEtcd(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.from, "from", "", "Specify snapshot file or S3 object (s3://BUCKET/KEY)")
+	addS3Flags(flags, &options.s3)
+
+	return cmd
+}
+
+// the snapshot is restored into data directory, so it must be on host
+func getRestoreEtcdServices(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) ([]*topology.DeployConfig, error) {
+	etcds := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_ETCD)
+	if len(etcds) == 0 {
+		return nil, errno.ERR_INVALID_RESTORE_OPTIONS.
+			F("no etcd services in cluster '%s'", curveadm.ClusterName())
+	}
+	for _, dc := range etcds {
+		if len(dc.GetDataDir()) == 0 {
+			return nil, errno.ERR_INVALID_RESTORE_OPTIONS.
+				F("data_dir of etcd service on host '%s' is required for restoring", dc.GetHost())
+		}
+	}
+	return etcds, nil
+}
+
+func genRestoreEtcdPlaybook(curveadm *cli.CurveAdm, etcds []*topology.DeployConfig, snapshot string) *playbook.Playbook {
+	news := []*topology.DeployConfig{}
+	for _, dc := range etcds {
+		if _, err := curveadm.GetContainerId(curveadm.GetServiceId(dc.GetId())); err != nil {
+			news = append(news, dc)
+		}
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	if len(news) > 0 {
+		for _, step := range RESTORE_ETCD_DEPLOY_STEPS {
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    step,
+				Configs: news,
+			})
+		}
+	}
+	for _, step := range RESTORE_ETCD_STEPS {
+		config := etcds
+		if step == playbook.WAIT_ETCD_HEALTHY {
+			config = etcds[:1]
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: config,
+			Options: map[string]interface{}{
+				comm.KEY_BACKUP_LOCAL_PATH: snapshot,
+				comm.KEY_ETCD_CLUSTER:      etcds,
+			},
+		})
+	}
+	return pb
+}
+
+// fetch snapshot into temporary directory if it's in S3
+func fetchEtcdSnapshot(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, options restoreEtcdOptions) (string, error) {
+	if !backup.IsS3Location(options.from) {
+		return options.from, nil
+	}
+
+	localPath := path.Join(curveadm.TempDir(), path.Base(options.from))
+	err := backup.FetchS3Object(options.from, getS3Config(dcs, options.s3), localPath)
+	if err != nil {
+		os.Remove(localPath)
+		return "", errno.ERR_FETCH_BACKUP_FAILED.E(err)
+	}
+	return localPath, nil
+}
+
+func runRestoreEtcd(curveadm *cli.CurveAdm, options restoreEtcdOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	etcds, err := getRestoreEtcdServices(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+
+	// 2) protected cluster can only be restored after unlocked
+	err = curveadm.CheckClusterUnlocked(curveadm.ClusterId(), curveadm.ClusterName())
+	if err != nil {
+		return err
+	}
+
+	// 3) confirm by user
+	hosts := []string{}
+	for _, dc := range etcds {
+		hosts = append(hosts, dc.GetHost())
+	}
+	if pass := curveadm.Confirm(tui.PromptRestoreEtcd(options.from, hosts)); !pass {
+		curveadm.WriteOutln(tui.PromptCancelOpetation("restore etcd"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 4) fetch snapshot
+	snapshot, err := fetchEtcdSnapshot(curveadm, dcs, options)
+	if err != nil {
+		return err
+	} else if snapshot != options.from {
+		defer os.Remove(snapshot)
+	}
+
+	// 5) stop all members, restore them from snapshot as a new cluster, then start them
+	err = genRestoreEtcdPlaybook(curveadm, etcds, snapshot).Run()
+	if err != nil {
+		return err
+	}
+
+	// 6) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln("%s", color.GreenString("Restore etcd from '%s' success", options.from))
+	curveadm.WriteOutln(color.YellowString("NOTICE: mds caches metadata in memory, please reload mds " +
+		"(and snapshotclone) to make them consistent with the restored etcd, e.g: curveadm reload --role mds"))
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/backup"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BACKUP_RUN_EXAMPLE = `Examples:
+  $ curveadm backup run                                  # Run the due backup schedules of current cluster
+  $ curveadm backup run --all                            # Run all backup schedules right now
+
+  # Run by cron instead of 'curveadm watch', e.g: add the line below into crontab
+  */5 * * * * curveadm backup run --cluster my-cluster >> /var/log/curveadm-backup.log 2>&1`
+)
+
+type runOptions struct {
+	all bool
+}
+
+// ScheduleRun is the result of backup schedule which runs once
+type ScheduleRun struct {
+	Schedule storage.BackupSchedule
+	Location string
+	Err      error
+}
+
+// the error is in one line, so it can be saved as result and logged by cron
+func briefError(err error) string {
+	if ec, ok := err.(*errno.ErrorCode); ok && len(ec.GetClue()) > 0 {
+		return fmt.Sprintf("%d: %s (%s)", ec.GetCode(), ec.GetDescription(), ec.GetClue())
+	} else if ok {
+		return fmt.Sprintf("%d: %s", ec.GetCode(), ec.GetDescription())
+	}
+	return err.Error()
+}
+
+func (r ScheduleRun) String() string {
+	prefix := fmt.Sprintf("backup %s (schedule %d)", r.Schedule.Target, r.Schedule.Id)
+	if r.Err != nil {
+		return fmt.Sprintf("%s: %s: %s", prefix, color.RedString(backup.BACKUP_STATUS_FAIL), briefError(r.Err))
+	}
+	return fmt.Sprintf("%s: %s: %s", prefix, color.GreenString(backup.BACKUP_STATUS_SUCCESS), r.Location)
+}
+
+func NewBackupRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options runOptions
+
+	cmd := &cobra.Command{
+		Use:     "run [OPTIONS]",
+		Short:   "Run due backup schedules",
+		Args:    cliutil.NoArgs,
+		Example: BACKUP_RUN_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackupRun(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.all, "all", false, "Run all backup schedules even if they are not due")
+
+	return cmd
+}
+
+/*
+ * RunDueBackups runs the backup schedules of current cluster which are due
+ * since their last run (or all if specified), the missed runs (e.g. watch
+ * stopped for days) are merged into one. It's called by `curveadm watch`
+ * every round and `curveadm backup run`.
+ */
+func RunDueBackups(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	now time.Time,
+	all bool) ([]ScheduleRun, error) {
+	schedules, err := curveadm.Storage().GetBackupSchedules(curveadm.ClusterId())
+	if err != nil {
+		return nil, errno.ERR_GET_BACKUP_SCHEDULES_FAILED.E(err)
+	}
+
+	runs := []ScheduleRun{}
+	for _, schedule := range schedules {
+		s, err := backup.ParseSchedule(schedule.Cron)
+		if err == nil && !all && !s.Due(schedule.LastRunTime, now) {
+			continue
+		}
+
+		run := ScheduleRun{Schedule: schedule, Err: err}
+		if err == nil {
+			var result *backupResult
+			result, run.Err = doBackup(curveadm, dcs, backupOptions{
+				target: schedule.Target,
+				to:     schedule.Location,
+				keep:   schedule.Keep,
+			}, true)
+			if run.Err == nil {
+				run.Location = result.location
+			}
+		}
+
+		status, result := backup.BACKUP_STATUS_SUCCESS, run.Location
+		if run.Err != nil {
+			status, result = backup.BACKUP_STATUS_FAIL, briefError(run.Err)
+		}
+		err = curveadm.Storage().SetBackupScheduleResult(schedule.Id, now, status, result)
+		if err != nil {
+			return runs, errno.ERR_SET_BACKUP_SCHEDULE_RESULT_FAILED.E(err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+func runBackupRun(curveadm *cli.CurveAdm, options runOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) run due schedules
+	now := time.Now()
+	runs, err := RunDueBackups(curveadm, dcs, now, options.all)
+	if err != nil {
+		return err
+	} else if len(runs) == 0 {
+		curveadm.WriteOutln("No backup schedules are due")
+		return nil
+	}
+
+	// 3) print results
+	failed := 0
+	for _, run := range runs {
+		curveadm.WriteOutln("[%s] %s", now.Format("2006-01-02 15:04:05"), run)
+		if run.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errno.ERR_RUN_BACKUP_SCHEDULES_FAILED.
+			F("%d of %d failed", failed, len(runs))
+	}
+	return nil
+}
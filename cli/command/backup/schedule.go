@@ -0,0 +1,197 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/backup"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BACKUP_SCHEDULE_ADD_EXAMPLE = `Examples:
+  $ curveadm backup schedule add etcd --cron "0 2 * * *"                 # Backup etcd snapshot at 02:00 every day
+  $ curveadm backup schedule add mds --cron @hourly --keep 48            # Dump mds metadata every hour and keep 2 days
+  $ curveadm backup schedule add db --cron "0 3 * * 0" --to s3://my-bucket/curve
+
+  # The due schedules are executed by 'curveadm watch' or 'curveadm backup run'`
+)
+
+type scheduleAddOptions struct {
+	target string
+	cron   string
+	to     string
+	keep   int
+}
+
+type scheduleRemoveOptions struct {
+	id string
+}
+
+func checkScheduleAddOptions(options scheduleAddOptions) error {
+	if !backup.IsValidKind(options.target) {
+		return errno.ERR_INVALID_BACKUP_SCHEDULE_OPTIONS.
+			F("target: %s, it should be one of %v", options.target, backup.BACKUP_KINDS)
+	} else if _, err := backup.ParseSchedule(options.cron); err != nil {
+		return errno.ERR_INVALID_BACKUP_SCHEDULE_OPTIONS.
+			F("--cron: %s: %s", options.cron, err)
+	} else if options.keep < 0 {
+		return errno.ERR_INVALID_BACKUP_SCHEDULE_OPTIONS.
+			F("--keep: %d, it should be greater than or equal to 0", options.keep)
+	}
+	return nil
+}
+
+func NewBackupScheduleCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage backup schedules",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewScheduleAddCommand(curveadm),
+		NewScheduleRemoveCommand(curveadm),
+	)
+	return cmd
+}
+
+func NewScheduleAddCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options scheduleAddOptions
+
+	cmd := &cobra.Command{
+		Use:       "add TARGET [OPTIONS]",
+		Short:     "Add backup schedule for target (etcd, mds or db)",
+		Args:      cliutil.ExactArgs(1),
+		ValidArgs: backup.BACKUP_KINDS,
+		Example:   BACKUP_SCHEDULE_ADD_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.target = args[0]
+			return checkScheduleAddOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScheduleAdd(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.cron, "cron", "", "Specify cron expression (e.g. \"0 2 * * *\", @daily) when backup runs")
+	flags.StringVar(&options.to, "to", "", "Specify directory or S3 location (s3://BUCKET/PREFIX) to store backup (default: ~/.curveadm/data/backup)")
+	flags.IntVar(&options.keep, "keep", DEFAULT_BACKUP_KEEP, "Specify number of latest backups to keep, 0 means keep all")
+	cmd.MarkFlagRequired("cron")
+
+	return cmd
+}
+
+func NewScheduleRemoveCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options scheduleRemoveOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm ID",
+		Aliases: []string{"delete"},
+		Short:   "Remove backup schedule",
+		Args:    cliutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.id = args[0]
+			return runScheduleRemove(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func runScheduleAdd(curveadm *cli.CurveAdm, options scheduleAddOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) the scheduled backup into S3 uses S3 in topology, the
+	//    credentials are never stored in schedule
+	if backup.IsS3Location(options.to) {
+		if _, err := backup.NewStore(options.to, getS3Config(dcs, s3Options{})); err != nil {
+			return errno.ERR_INVALID_BACKUP_SCHEDULE_OPTIONS.E(err)
+		}
+	}
+
+	// 3) insert schedule
+	err = curveadm.Storage().InsertBackupSchedule(storage.BackupSchedule{
+		ClusterId: curveadm.ClusterId(),
+		Target:    options.target,
+		Cron:      options.cron,
+		Location:  options.to,
+		Keep:      options.keep,
+	})
+	if err != nil {
+		return errno.ERR_INSERT_BACKUP_SCHEDULE_FAILED.E(err)
+	}
+
+	// 4) print next run
+	schedule, _ := backup.ParseSchedule(options.cron)
+	next := schedule.Next(time.Now())
+	curveadm.WriteOutln(color.GreenString("Backup schedule of %s added"), options.target)
+	if !next.IsZero() {
+		curveadm.WriteOutln("  - Next run: %s", next.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func runScheduleRemove(curveadm *cli.CurveAdm, options scheduleRemoveOptions) error {
+	// 1) find schedule in current cluster
+	id, err := strconv.Atoi(options.id)
+	if err != nil {
+		return errno.ERR_INVALID_BACKUP_SCHEDULE_OPTIONS.
+			F("id: %s, it should be a number", options.id)
+	}
+	schedules, err := curveadm.Storage().GetBackupSchedules(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_GET_BACKUP_SCHEDULES_FAILED.E(err)
+	}
+	found := false
+	for _, schedule := range schedules {
+		found = found || schedule.Id == id
+	}
+	if !found {
+		return errno.ERR_BACKUP_SCHEDULE_NOT_FOUND.
+			F("id=%d, cluster=%s", id, curveadm.ClusterName())
+	}
+
+	// 2) delete schedule, the stored backups are untouched
+	err = curveadm.Storage().DeleteBackupSchedule(id, curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_DELETE_BACKUP_SCHEDULE_FAILED.E(err)
+	}
+	curveadm.WriteOutln(color.GreenString("Backup schedule %d removed"), id)
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BALANCE_STATUS_EXAMPLE = `Examples:
+  $ curveadm balance-status                # Display copyset and leader distribution of chunkservers
+  $ curveadm balance-status --tolerate 20  # Mark chunkserver deviated from pool mean more than 20% as imbalanced
+  $ curveadm balance-status --rebalance    # Trigger leader schedule if cluster is imbalanced`
+)
+
+type balanceStatusOptions struct {
+	tolerate  float64
+	rebalance bool
+}
+
+func NewBalanceStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options balanceStatusOptions
+
+	cmd := &cobra.Command{
+		Use:     "balance-status [OPTIONS]",
+		Short:   "Display copyset and leader balance of chunkservers",
+		Args:    cliutil.NoArgs,
+		Example: BALANCE_STATUS_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBalanceStatus(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.Float64Var(&options.tolerate, "tolerate", bs.DEFAULT_BALANCE_TOLERATE, "Specify tolerated deviation (percent) from pool mean")
+	flags.BoolVar(&options.rebalance, "rebalance", false, "Trigger leader schedule if any chunkserver imbalanced")
+
+	return cmd
+}
+
+func genBalancePlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	step int) *playbook.Playbook {
+	// only the first mds is needed
+	dcs = curveadm.FilterDeployConfigByRole(dcs, ROLE_MDS)[:1]
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    step,
+		Configs: dcs,
+	})
+	return pb
+}
+
+// fill the pool and zone of chunkserver from cluster pool which curveadm created
+func locateChunkservers(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	loads []bs.ChunkserverLoad) error {
+	pool := configure.CurveClusterTopo{}
+	if len(curveadm.ClusterPoolData()) > 0 {
+		err := json.Unmarshal([]byte(curveadm.ClusterPoolData()), &pool)
+		if err != nil {
+			return errno.ERR_DECODE_CLUSTER_POOL_JSON_FAILED.E(err)
+		}
+	}
+
+	servers := map[string]configure.Server{}
+	for _, dc := range curveadm.FilterDeployConfigByRole(dcs, ROLE_CHUNKSERVER) {
+		if server, ok := pool.LocateServer(dc); ok {
+			servers[cliutil.JoinHostPort(dc.GetListenIp(), dc.GetListenPort())] = server
+		}
+	}
+	for i := range loads {
+		loads[i].Pool, loads[i].Zone = "-", "-"
+		if server, ok := servers[loads[i].Addr]; ok {
+			loads[i].Pool, loads[i].Zone = server.PhysicalPool, server.Zone
+		}
+	}
+	return nil
+}
+
+func runBalanceStatus(curveadm *cli.CurveAdm, options balanceStatusOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	dcs = topology.SelectByKind(dcs, topology.KIND_CURVEBS)
+	if len(dcs) == 0 {
+		return errno.ERR_UNSUPPORT_CLUSTER_KIND.
+			F("balance-status only supports curvebs cluster")
+	}
+
+	// 2) gather copyset and leader distribution from mds
+	pb := genBalancePlaybook(curveadm, dcs, playbook.GET_CHUNKSERVER_LOAD)
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+	loads := []bs.ChunkserverLoad{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_CHUNKSERVER_LOADS); v != nil {
+		loads = v.([]bs.ChunkserverLoad)
+	}
+	err = locateChunkservers(curveadm, dcs, loads)
+	if err != nil {
+		return err
+	}
+
+	// 3) compute and display imbalance metrics
+	groups := bs.ComputeBalance(loads, options.tolerate)
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", tui.FormatBalanceGroups(groups))
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", tui.FormatChunkserverLoads(loads))
+
+	imbalanced := 0
+	for _, load := range loads {
+		if load.State == bs.BALANCE_STATE_OVERLOAD || load.State == bs.BALANCE_STATE_UNDERLOAD {
+			imbalanced++
+		}
+	}
+	curveadm.WriteOutln("")
+	if imbalanced == 0 {
+		curveadm.WriteOutln(color.GreenString("All chunkservers are balanced (tolerate: %.1f%%)", options.tolerate))
+		return nil
+	}
+	curveadm.WriteOutln(color.YellowString("%d chunkserver(s) are imbalanced (tolerate: %.1f%%)",
+		imbalanced, options.tolerate))
+
+	// 4) trigger curve scheduler to transfer leaders
+	if !options.rebalance {
+		return nil
+	}
+	pb = genBalancePlaybook(curveadm, dcs, playbook.BALANCE_LEADER)
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOutln(color.GreenString("Leader schedule triggered, " +
+		"copysets will be migrated by scheduler of mds in background"))
+	return nil
+}
@@ -0,0 +1,493 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BENCH_EXAMPLE = `Examples:
+  $ curveadm bench --type bs -c client.yaml                                                         # Benchmark CurveBS by 4KiB random write in localhost
+  $ curveadm bench --type bs --pattern randread --size 20G --host machine1,machine2 -c client.yaml  # Benchmark CurveBS in 2 clients
+  $ curveadm bench --type fs --pattern write --bs 1M --runtime 120 -c client.yaml                   # Benchmark CurveFS by 1MiB sequential write`
+
+	BENCH_TYPE_BS = "bs"
+	BENCH_TYPE_FS = "fs"
+
+	BENCH_VOLUME_USER    = "curveadm"
+	BENCH_FS_NAME        = "curveadm-bench"
+	FORMAT_BENCH_NAME    = "curveadm-bench-%d"
+	FORMAT_BENCH_VOLUME  = "/%s-%d"
+	FORMAT_BENCH_MOUNT   = "/tmp/%s"
+	FORMAT_BENCH_FS_FILE = "%s/%s-%s"
+)
+
+var (
+	WHEN_BENCH_CURVEBS = fmt.Sprintf("options.%s == %s", comm.KEY_CLIENT_KIND, topology.KIND_CURVEBS)
+	WHEN_BENCH_CURVEFS = fmt.Sprintf("options.%s == %s", comm.KEY_CLIENT_KIND, topology.KIND_CURVEFS)
+
+	// steps are filtered by the client kind of bench type, see playbook/condition.go
+	BENCH_SETUP_PLAYBOOK_STEPS = []benchSetupStep{
+		{playbook.CHECK_KERNEL_MODULE, comm.KERNERL_MODULE_NBD, WHEN_BENCH_CURVEBS},
+		{playbook.START_NEBD_SERVICE, "", WHEN_BENCH_CURVEBS},
+		{playbook.CREATE_VOLUME, "", WHEN_BENCH_CURVEBS},
+		{playbook.MAP_IMAGE, "", WHEN_BENCH_CURVEBS},
+		{playbook.CHECK_KERNEL_MODULE, comm.KERNERL_MODULE_FUSE, WHEN_BENCH_CURVEFS},
+		{playbook.CHECK_CLIENT_S3, "", WHEN_BENCH_CURVEFS},
+		{playbook.MOUNT_FILESYSTEM, "", WHEN_BENCH_CURVEFS},
+	}
+
+	BENCH_CLUSTER_KIND = map[string]string{
+		BENCH_TYPE_BS: topology.KIND_CURVEBS,
+		BENCH_TYPE_FS: topology.KIND_CURVEFS,
+	}
+)
+
+type benchSetupStep struct {
+	step   int
+	module string // kernel module for CHECK_KERNEL_MODULE
+	when   string
+}
+
+type benchOptions struct {
+	benchType string
+	pattern   string
+	size      string
+	blockSize string
+	iodepth   int
+	numjobs   int
+	runtime   int
+	hosts     []string
+	poolset   string
+	filename  string
+}
+
+// benchRecord is the options and results stored in database for each bench run
+type benchRecord struct {
+	Options task.BenchOptions  `json:"options"`
+	Hosts   []string           `json:"hosts"`
+	Clients []task.BenchResult `json:"clients"`
+	Total   task.BenchResult   `json:"total"`
+}
+
+// parseBenchSize returns the size in GiB, e.g. 10G => 10
+func parseBenchSize(size string) (int, error) {
+	if !strings.HasSuffix(size, "G") {
+		return 0, errno.ERR_INVALID_BENCH_SIZE.F("size: %s", size)
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(size, "G"))
+	if err != nil || n <= 0 {
+		return 0, errno.ERR_INVALID_BENCH_SIZE.F("size: %s", size)
+	}
+	return n, nil
+}
+
+// the size of volume must be a multiple of 10GiB
+func getBenchVolumeSize(size int) int {
+	return (size + 9) / 10 * 10
+}
+
+func checkBenchOptions(options benchOptions) error {
+	if _, ok := BENCH_CLUSTER_KIND[options.benchType]; !ok {
+		return errno.ERR_UNSUPPORT_BENCH_TYPE.
+			F("type: %s", options.benchType)
+	} else if !task.IsValidBenchPattern(options.pattern) {
+		return errno.ERR_UNSUPPORT_BENCH_PATTERN.
+			F("pattern: %s", options.pattern)
+	} else if _, err := parseBenchSize(options.size); err != nil {
+		return err
+	} else if options.iodepth <= 0 || options.numjobs <= 0 || options.runtime <= 0 {
+		return errno.ERR_INVALID_BENCH_OPTIONS.
+			F("--iodepth, --numjobs and --runtime require a positive integer")
+	} else if len(options.hosts) == 0 {
+		return errno.ERR_INVALID_BENCH_OPTIONS.
+			F("--host requires at least one host")
+	} else if len(cliutil.Slice2Map(options.hosts)) != len(options.hosts) {
+		return errno.ERR_INVALID_BENCH_OPTIONS.
+			F("duplicate host in --host: %s", strings.Join(options.hosts, ","))
+	}
+	return nil
+}
+
+func NewBenchCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options benchOptions
+
+	cmd := &cobra.Command{
+		Use:     "bench [OPTIONS]",
+		Short:   "Benchmark cluster by fio",
+		Args:    cliutil.NoArgs,
+		Example: BENCH_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkBenchOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBench(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.benchType, "type", BENCH_TYPE_BS, "Specify bench type (bs/fs)")
+	flags.StringVar(&options.pattern, "pattern", task.BENCH_PATTERN_RANDWRITE, "Specify I/O pattern (read/write/randread/randwrite/rw/randrw)")
+	flags.StringVar(&options.size, "size", "10G", "Specify size of I/O for each client")
+	flags.StringVar(&options.blockSize, "bs", "4k", "Specify block size of I/O")
+	flags.IntVar(&options.iodepth, "iodepth", 128, "Specify I/O depth")
+	flags.IntVar(&options.numjobs, "numjobs", 1, "Specify number of jobs for each client")
+	flags.IntVar(&options.runtime, "runtime", 60, "Specify runtime in seconds")
+	flags.StringSliceVar(&options.hosts, "host", []string{"localhost"}, "Specify client hosts")
+	flags.StringVar(&options.poolset, "poolset", "default", "Specify the poolset name of volume")
+	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
+
+	return cmd
+}
+
+// each host maps its own volume, the volume name can't contain "_" so we use index
+func getBenchVolume(name string, index int) string {
+	return fmt.Sprintf(FORMAT_BENCH_VOLUME, name, index)
+}
+
+func getBenchMountPoint(name string) string {
+	return fmt.Sprintf(FORMAT_BENCH_MOUNT, name)
+}
+
+func genBenchSetupPlaybook(curveadm *cli.CurveAdm,
+	cc *configure.ClientConfig,
+	options benchOptions,
+	name string,
+	index int) *playbook.Playbook {
+	host := options.hosts[index]
+	size, _ := parseBenchSize(options.size)
+	pb := playbook.NewPlaybook(curveadm)
+	for _, s := range BENCH_SETUP_PLAYBOOK_STEPS {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    s.step,
+			Configs: cc,
+			When:    s.when,
+			Options: map[string]interface{}{
+				comm.KEY_MAP_OPTIONS: bs.MapOptions{
+					Host:    host,
+					User:    BENCH_VOLUME_USER,
+					Volume:  getBenchVolume(name, index),
+					Size:    getBenchVolumeSize(size),
+					Create:  true,
+					Poolset: options.poolset,
+				},
+				comm.KEY_MOUNT_OPTIONS: fs.MountOptions{
+					Host:        host,
+					MountFSName: BENCH_FS_NAME,
+					MountFSType: fs.DEFAULT_MOUNT_FSTYPE,
+					MountPoint:  getBenchMountPoint(name),
+					MkdirMount:  true,
+				},
+				comm.KEY_CLIENT_HOST:              host, // for checker
+				comm.KEY_CLIENT_KIND:              BENCH_CLUSTER_KIND[options.benchType],
+				comm.KEY_CHECK_KERNEL_MODULE_NAME: s.module,
+			},
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: s.step == playbook.CHECK_CLIENT_S3,
+			},
+		})
+	}
+	return pb
+}
+
+func genBenchPlaybook(curveadm *cli.CurveAdm,
+	targets []interface{},
+	options task.BenchOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.RUN_BENCHMARK,
+		Configs: targets,
+		Options: map[string]interface{}{
+			comm.KEY_BENCH_OPTIONS: options,
+		},
+	})
+	return pb
+}
+
+func genBenchCleanPlaybook(curveadm *cli.CurveAdm,
+	cc *configure.ClientConfig,
+	options benchOptions,
+	name string,
+	index int) *playbook.Playbook {
+	host := options.hosts[index]
+	step := playbook.UNMAP_IMAGE
+	if options.benchType == BENCH_TYPE_FS {
+		step = playbook.UMOUNT_FILESYSTEM
+	}
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    step,
+		Configs: cc,
+		Options: map[string]interface{}{
+			comm.KEY_MAP_OPTIONS: bs.MapOptions{
+				Host:   host,
+				User:   BENCH_VOLUME_USER,
+				Volume: getBenchVolume(name, index),
+			},
+			comm.KEY_MOUNT_OPTIONS: fs.MountOptions{
+				Host:       host,
+				MountPoint: getBenchMountPoint(name),
+			},
+		},
+	})
+	return pb
+}
+
+func getBenchTarget(curveadm *cli.CurveAdm, options benchOptions, name string, index int) (task.BenchTarget, error) {
+	host := options.hosts[index]
+	target := task.BenchTarget{Host: host}
+	id := curveadm.GetVolumeId(host, BENCH_VOLUME_USER, getBenchVolume(name, index))
+	if options.benchType == BENCH_TYPE_FS {
+		id = curveadm.GetFilesystemId(host, getBenchMountPoint(name))
+	}
+	clients, err := curveadm.Storage().GetClient(id)
+	if err != nil {
+		return target, errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(clients) == 0 {
+		return target, errno.ERR_BENCH_TARGET_NOT_FOUND.
+			F("host: %s", host)
+	}
+
+	target.ContainerId = clients[0].ContainerId
+	if options.benchType == BENCH_TYPE_FS {
+		// all clients share one filesystem, so each one writes its own file
+		path := configure.GetFSClientMountPath(getBenchMountPoint(name))
+		target.Filename = fmt.Sprintf(FORMAT_BENCH_FS_FILE, path, name, host)
+		target.Temporary = true
+		return target, nil
+	}
+
+	auxInfo := &bs.AuxInfo{}
+	err = json.Unmarshal([]byte(clients[0].AuxInfo), auxInfo)
+	if err != nil {
+		return target, errno.ERR_DECODE_VOLUME_INFO_FAILED.E(err)
+	} else if len(auxInfo.Device) == 0 {
+		return target, errno.ERR_BENCH_TARGET_NOT_FOUND.
+			F("host: %s, volume: %s", host, auxInfo.Volume)
+	}
+	target.Filename = auxInfo.Device
+	target.Direct = true
+	return target, nil
+}
+
+/*
+ * cleanBench unmaps (umounts) and deletes the bench volume (filesystem)
+ * in the first n hosts which provisioned, it goes on even if any host failed.
+ */
+func cleanBench(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	cc *configure.ClientConfig,
+	options benchOptions,
+	name string,
+	n int) error {
+	var cleanErr error
+	setErr := func(err error) {
+		if err != nil && cleanErr == nil {
+			cleanErr = err
+		}
+	}
+
+	role := bs.GetVolumeRole(bs.VOLUME_ACTION_DELETE)
+	dcs = curveadm.FilterDeployConfigByRole(dcs, role)
+	for i := 0; i < n; i++ {
+		setErr(genBenchCleanPlaybook(curveadm, cc, options, name, i).Run())
+		if options.benchType == BENCH_TYPE_FS || len(dcs) == 0 {
+			continue
+		}
+
+		pb := playbook.NewPlaybook(curveadm)
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.MANAGE_VOLUME,
+			Configs: dcs[:1],
+			Options: map[string]interface{}{
+				comm.KEY_VOLUME_OPTIONS: bs.VolumeOptions{
+					Action: bs.VOLUME_ACTION_DELETE,
+					User:   BENCH_VOLUME_USER,
+					Volume: getBenchVolume(name, i),
+				},
+			},
+		})
+		setErr(pb.Run())
+	}
+	return cleanErr
+}
+
+// runBenchmark provisions volume (filesystem) in each host and runs fio in all hosts concurrently
+func runBenchmark(curveadm *cli.CurveAdm,
+	cc *configure.ClientConfig,
+	options benchOptions,
+	name string,
+	provisioned *int) ([]task.BenchResult, error) {
+	targets := []interface{}{}
+	for i := range options.hosts {
+		*provisioned = i + 1
+		err := genBenchSetupPlaybook(curveadm, cc, options, name, i).Run()
+		if err != nil {
+			return nil, err
+		}
+		target, err := getBenchTarget(curveadm, options, name, i)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	err := genBenchPlaybook(curveadm, targets, getBenchOptions(options)).Run()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []task.BenchResult{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_BENCH_RESULTS); v != nil {
+		for _, result := range v.(map[string]task.BenchResult) {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func getBenchOptions(options benchOptions) task.BenchOptions {
+	return task.BenchOptions{
+		Pattern:   options.pattern,
+		Size:      options.size,
+		BlockSize: options.blockSize,
+		IODepth:   options.iodepth,
+		NumJobs:   options.numjobs,
+		Runtime:   options.runtime,
+	}
+}
+
+// getPreviousBenchmark returns the latest bench run with same type and options
+func getPreviousBenchmark(curveadm *cli.CurveAdm, kind string, options task.BenchOptions) (*task.BenchResult, error) {
+	benchmarks, err := curveadm.Storage().GetBenchmarks(curveadm.ClusterId())
+	if err != nil {
+		return nil, errno.ERR_GET_BENCHMARKS_FAILED.E(err)
+	}
+
+	for _, benchmark := range benchmarks {
+		record := benchRecord{}
+		if benchmark.Kind != kind || benchmark.Pattern != options.Pattern {
+			continue
+		} else if err := json.Unmarshal([]byte(benchmark.Result), &record); err != nil {
+			continue
+		} else if record.Options != options {
+			continue
+		}
+		return &record.Total, nil
+	}
+	return nil, nil
+}
+
+func saveBenchmark(curveadm *cli.CurveAdm, kind string, record benchRecord) error {
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return errno.ERR_INSERT_BENCHMARK_FAILED.E(err)
+	}
+	options, _ := json.Marshal(record.Options)
+	err = curveadm.Storage().InsertBenchmark(storage.Benchmark{
+		ClusterId: curveadm.ClusterId(),
+		Kind:      kind,
+		Pattern:   record.Options.Pattern,
+		Options:   string(options),
+		Result:    string(bytes),
+	})
+	if err != nil {
+		return errno.ERR_INSERT_BENCHMARK_FAILED.E(err)
+	}
+	return nil
+}
+
+func runBench(curveadm *cli.CurveAdm, options benchOptions) error {
+	// 1) parse cluster topology and client configure
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	kind := BENCH_CLUSTER_KIND[options.benchType]
+	dcs = topology.SelectByKind(dcs, kind)
+	if len(dcs) == 0 {
+		return errno.ERR_UNSUPPORT_CLUSTER_KIND.
+			F("bench type %s requires %s cluster", options.benchType, kind)
+	}
+	cc, err := configure.ParseClientConfig(options.filename)
+	if err != nil {
+		return err
+	} else if cc.GetKind() != kind {
+		return errno.ERR_BENCH_CLIENT_KIND_MISMATCH.
+			F("type: %s, kind: %s", options.benchType, cc.GetKind())
+	}
+
+	// 2) run benchmark and clean the bench volume (filesystem) anyway
+	name := fmt.Sprintf(FORMAT_BENCH_NAME, time.Now().Unix())
+	provisioned := 0
+	results, err := runBenchmark(curveadm, cc, options, name, &provisioned)
+	cleanErr := cleanBench(curveadm, dcs, cc, options, name, provisioned)
+	if err != nil {
+		return err
+	} else if cleanErr != nil {
+		return cleanErr
+	}
+
+	// 3) compare with previous run and save the result
+	benchOptions := getBenchOptions(options)
+	previous, err := getPreviousBenchmark(curveadm, options.benchType, benchOptions)
+	if err != nil {
+		return err
+	}
+	record := benchRecord{
+		Options: benchOptions,
+		Hosts:   options.hosts,
+		Clients: results,
+		Total:   task.AggregateBenchResults(results),
+	}
+	err = saveBenchmark(curveadm, options.benchType, record)
+	if err != nil {
+		return err
+	}
+
+	// 4) display results
+	output := tui.FormatBenchResults(results, record.Total, previous)
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(output)
+	return nil
+}
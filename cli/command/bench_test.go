@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBenchSize(t *testing.T) {
+	assert := assert.New(t)
+
+	size, err := parseBenchSize("10G")
+	assert.Nil(err)
+	assert.Equal(10, size)
+
+	for _, size := range []string{"", "G", "0G", "-1G", "10", "10GiB", "10M"} {
+		_, err = parseBenchSize(size)
+		assert.NotNil(err, size)
+	}
+
+	assert.Equal(10, getBenchVolumeSize(1))
+	assert.Equal(10, getBenchVolumeSize(10))
+	assert.Equal(20, getBenchVolumeSize(11))
+}
+
+func TestCheckBenchOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	options := benchOptions{
+		benchType: BENCH_TYPE_BS,
+		pattern:   "randwrite",
+		size:      "10G",
+		iodepth:   128,
+		numjobs:   1,
+		runtime:   60,
+		hosts:     []string{"host1", "host2"},
+	}
+	assert.Nil(checkBenchOptions(options))
+
+	invalid := options
+	invalid.benchType = "nfs"
+	assert.NotNil(checkBenchOptions(invalid))
+
+	invalid = options
+	invalid.pattern = "trim"
+	assert.NotNil(checkBenchOptions(invalid))
+
+	invalid = options
+	invalid.runtime = 0
+	assert.NotNil(checkBenchOptions(invalid))
+
+	invalid = options
+	invalid.hosts = []string{"host1", "host1"}
+	assert.NotNil(checkBenchOptions(invalid))
+
+	assert.Equal("/curveadm-bench-1-0", getBenchVolume("curveadm-bench-1", 0))
+}
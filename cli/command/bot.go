@@ -0,0 +1,158 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/bot"
+	"github.com/opencurve/curveadm/internal/errno"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BOT_EXAMPLE = `Examples:
+  $ curveadm bot --slack-signing-secret SECRET                      # Serve slash command of slack app on 127.0.0.1:8010/slack
+  $ curveadm bot --token TOKEN --listen :8010                       # Serve generic webhook on all interfaces
+  $ curveadm bot --token TOKEN --format format.yaml                 # Enable 'disks status' by format configure
+
+  $ curl -H "Authorization: Bearer TOKEN" -d '{"text": "status c1"}' http://127.0.0.1:8010/webhook`
+
+	ENV_CURVEADM_BOT_TOKEN     = "CURVEADM_BOT_TOKEN"
+	ENV_SLACK_TOKEN            = "SLACK_TOKEN"
+	ENV_SLACK_SIGNING_SECRET   = "SLACK_SIGNING_SECRET"
+	BOT_CONFIRM_NOTHING        = "" // all prompts are declined
+	BOT_SHUTDOWN_WAIT_DURATION = 5 * time.Second
+)
+
+type botOptions struct {
+	listen             string
+	token              string
+	slackToken         string
+	slackSigningSecret string
+	filename           string
+	certFile           string
+	keyFile            string
+}
+
+func fromEnv(value *string, env string) {
+	if len(*value) == 0 {
+		*value = os.Getenv(env)
+	}
+}
+
+func NewBotCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options botOptions
+
+	cmd := &cobra.Command{
+		Use:     "bot [OPTIONS]",
+		Short:   "Serve read-only commands for chatops",
+		Args:    cliutil.NoArgs,
+		Example: BOT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			fromEnv(&options.token, ENV_CURVEADM_BOT_TOKEN)
+			fromEnv(&options.slackToken, ENV_SLACK_TOKEN)
+			fromEnv(&options.slackSigningSecret, ENV_SLACK_SIGNING_SECRET)
+			if len(options.token) == 0 &&
+				len(options.slackToken) == 0 &&
+				len(options.slackSigningSecret) == 0 {
+				return errno.ERR_BOT_TOKEN_NOT_SPECIFIED
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBot(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.listen, "listen", "127.0.0.1:8010", "Specify address which bot served on")
+	flags.StringVar(&options.token, "token", "", "Specify token which generic webhook authorized by (env: "+ENV_CURVEADM_BOT_TOKEN+")")
+	flags.StringVar(&options.slackToken, "slack-token", "", "Specify verification token of slack slash command (env: "+ENV_SLACK_TOKEN+")")
+	flags.StringVar(&options.slackSigningSecret, "slack-signing-secret", "", "Specify signing secret of slack app (env: "+ENV_SLACK_SIGNING_SECRET+")")
+	flags.StringVarP(&options.filename, "format", "f", "", "Specify format configure file for 'disks status'")
+	flags.StringVar(&options.certFile, "tls-cert", "", "Specify certificate file for serving HTTPS")
+	flags.StringVar(&options.keyFile, "tls-key", "", "Specify private key file for serving HTTPS")
+
+	return cmd
+}
+
+// newBotExecutor executes command in a new session of curveadm, see newAPIExecutor
+func newBotExecutor(curveadm *cli.CurveAdm) bot.Executor {
+	return func(source string, args []string, out io.Writer) error {
+		session, err := curveadm.NewSession(out, source, BOT_CONFIRM_NOTHING)
+		if err != nil {
+			return err
+		}
+		verify.ReplaceGlobals(session.Verifier())
+		defer verify.ReplaceGlobals(curveadm.Verifier())
+
+		id := session.PreAudit(time.Now(), args)
+		cmd := NewCurveAdmCommand(session)
+		cmd.SetArgs(args)
+		cmd.SetOut(out)
+		err = cmd.Execute()
+		session.PostAudit(id, err)
+		return err
+	}
+}
+
+func runBot(curveadm *cli.CurveAdm, options botOptions) error {
+	server := bot.NewServer(bot.Options{
+		Listen:             options.listen,
+		Token:              options.token,
+		CertFile:           options.certFile,
+		KeyFile:            options.keyFile,
+		SlackToken:         options.slackToken,
+		SlackSigningSecret: options.slackSigningSecret,
+		FormatFile:         options.filename,
+	}, newBotExecutor(curveadm))
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.ListenAndServe()
+	}()
+	curveadm.WriteOutln("Serving bot on %s (%s, %s), press Ctrl+C to stop...",
+		options.listen, bot.PATH_WEBHOOK, bot.PATH_SLACK)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err := <-errc:
+		return errno.ERR_SERVE_BOT_FAILED.E(err)
+	case <-ctx.Done():
+		ctx, cancel := context.WithTimeout(context.Background(), BOT_SHUTDOWN_WAIT_DURATION)
+		defer cancel()
+		server.Shutdown(ctx)
+		curveadm.WriteOutln("Bot stopped")
+		return nil
+	}
+}
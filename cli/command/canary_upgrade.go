@@ -0,0 +1,262 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func checkCanaryUpgradeOptions(options upgradeOptions) error {
+	n := 0
+	for _, enable := range []bool{options.canary, options.promote, options.rollback} {
+		if enable {
+			n++
+		}
+	}
+
+	if n > 1 {
+		return errno.ERR_INVALID_CANARY_UPGRADE_OPTIONS.
+			F("--canary, --promote and --rollback-canary can't be specified at the same time")
+	} else if n == 1 && (options.force || options.rolling || options.resume || options.abort) {
+		return errno.ERR_INVALID_CANARY_UPGRADE_OPTIONS.
+			F("--force, --rolling, --resume and --abort can't be specified with canary options")
+	} else if options.canary && options.count <= 0 {
+		return errno.ERR_INVALID_CANARY_UPGRADE_OPTIONS.
+			F("--count requires a positive integer")
+	}
+	return nil
+}
+
+func getCanaries(curveadm *cli.CurveAdm) ([]storage.Canary, error) {
+	canaries, err := curveadm.Storage().GetCanaries(curveadm.ClusterId())
+	if err != nil {
+		return nil, errno.ERR_GET_CANARIES_FAILED.E(err)
+	}
+	return canaries, nil
+}
+
+// the image which services container running with, key: service id
+func getServiceImages(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) (map[string]task.ServiceImage, error) {
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_IMAGES, nil)
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_SERVICE_IMAGE,
+		Configs: dcs,
+	})
+	err := pb.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	images := map[string]task.ServiceImage{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_SERVICE_IMAGES); v != nil {
+		images = v.(map[string]task.ServiceImage)
+	}
+	return images, nil
+}
+
+func canaryUpgrade(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, options upgradeOptions) error {
+	// 1) only one canary upgrade in progress
+	canaries, err := getCanaries(curveadm)
+	if err != nil {
+		return err
+	} else if len(canaries) > 0 {
+		return errno.ERR_CANARY_UPGRADE_IN_PROGRESS.
+			F("%d canary services", len(canaries))
+	} else if options.count > len(dcs) {
+		return errno.ERR_INVALID_CANARY_UPGRADE_OPTIONS.
+			F("--count %d exceed the number of matched services %d", options.count, len(dcs))
+	}
+
+	// 2) get images before upgrade for rollback
+	dcs = dcs[:options.count]
+	images, err := getServiceImages(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+
+	// 3) display title and confirm by user
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.YellowString("Upgrade %d canary services:", len(dcs)))
+	for _, dc := range dcs {
+		curveadm.WriteOutln("  + host=%s  role=%s  image=%s -> %s", dc.GetHost(), dc.GetRole(),
+			images[curveadm.GetServiceId(dc.GetId())].Name, dc.GetContainerImage())
+	}
+	if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOut(tui.PromptCancelOpetation("upgrade service"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 4) record canaries and previous images
+	for _, dc := range dcs {
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		err := curveadm.Storage().InsertCanary(storage.Canary{
+			ClusterId: curveadm.ClusterId(),
+			ServiceId: serviceId,
+			OldImage:  images[serviceId].Name,
+			NewImage:  dc.GetContainerImage(),
+		})
+		if err != nil {
+			return errno.ERR_INSERT_CANARY_FAILED.E(err)
+		}
+	}
+
+	err = savePreviousImages(curveadm, dcs, images)
+	if err != nil {
+		return err
+	}
+
+	// 5) upgrade canaries
+	pb, err := genUpgradePlaybook(curveadm, dcs, upgradeOptions{id: "*", role: "*", host: "*"})
+	if err != nil {
+		return err
+	}
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 6) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Upgrade %d canary services success :)", len(dcs)))
+	curveadm.WriteOutln(color.YellowString("Please validate metrics of canaries, then run " +
+		"'curveadm upgrade --promote' to upgrade the rest services " +
+		"or 'curveadm upgrade --rollback-canary' to rollback canaries"))
+	return nil
+}
+
+// the rest services are upgraded by rolling upgrade
+func promoteCanaryUpgrade(curveadm *cli.CurveAdm,
+	dcsAll, dcs []*topology.DeployConfig,
+	options upgradeOptions) error {
+	// 1) get canaries
+	canaries, err := getCanaries(curveadm)
+	if err != nil {
+		return err
+	} else if len(canaries) == 0 {
+		return errno.ERR_NO_CANARY_UPGRADE_IN_PROGRESS
+	}
+	states, err := getUnfinishedUpgradeStates(curveadm)
+	if err != nil {
+		return err
+	} else if len(states) > 0 {
+		return errno.ERR_ROLLING_UPGRADE_IN_PROGRESS.
+			F("%d services not upgraded", len(states))
+	}
+
+	// 2) exclude canaries which already upgraded
+	upgraded := map[string]bool{}
+	for _, canary := range canaries {
+		upgraded[canary.ServiceId] = true
+	}
+	dcs2upgrade := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		if !upgraded[curveadm.GetServiceId(dc.GetId())] {
+			dcs2upgrade = append(dcs2upgrade, dc)
+		}
+	}
+
+	// 3) rolling upgrade the rest services
+	if len(dcs2upgrade) > 0 {
+		// canaries are kept if cancelled or failed, the failed or paused
+		// upgrade can be resumed by `upgrade --resume`, then promote again
+		err = rollingUpgrade(curveadm, dcsAll, dcs2upgrade, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 4) canaries are promoted once all the rest services upgraded
+	err = curveadm.Storage().DeleteCanaries(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_DELETE_CANARIES_FAILED.E(err)
+	}
+	return nil
+}
+
+func rollbackCanaryUpgrade(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) error {
+	// 1) get canaries
+	canaries, err := getCanaries(curveadm)
+	if err != nil {
+		return err
+	} else if len(canaries) == 0 {
+		return errno.ERR_NO_CANARY_UPGRADE_IN_PROGRESS
+	}
+
+	// 2) services run with the image before upgrade
+	m := map[string]*topology.DeployConfig{}
+	for _, dc := range dcs {
+		m[curveadm.GetServiceId(dc.GetId())] = dc
+	}
+	dcs2rollback := []*topology.DeployConfig{}
+	curveadm.WriteOutln(color.YellowString("Rollback %d canary services:", len(canaries)))
+	for _, canary := range canaries {
+		dc, ok := m[canary.ServiceId]
+		if !ok {
+			continue
+		}
+		curveadm.WriteOutln("  + host=%s  role=%s  image=%s -> %s",
+			dc.GetHost(), dc.GetRole(), canary.NewImage, canary.OldImage)
+		dcs2rollback = append(dcs2rollback, dc.WithContainerImage(canary.OldImage))
+	}
+	if len(dcs2rollback) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 3) confirm by user
+	if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOut(tui.PromptCancelOpetation("rollback canary"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 4) recreate canaries with old image and config
+	images, err := curveadm.Storage().GetPreviousImages(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_GET_PREVIOUS_IMAGES_FAILED.E(err)
+	}
+	configs, err := getPreviousConfigs(images)
+	if err != nil {
+		return err
+	}
+	err = genRollbackPlaybook(curveadm, dcs2rollback, configs).Run()
+	if err != nil {
+		return err
+	}
+
+	// 5) delete canaries and print success prompt
+	err = curveadm.Storage().DeleteCanaries(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_DELETE_CANARIES_FAILED.E(err)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Rollback %d canary services success :)", len(dcs2rollback)))
+	return nil
+}
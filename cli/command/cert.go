@@ -0,0 +1,471 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/cert"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/tui"
+	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CERT_EXAMPLE = `Examples:
+  $ curveadm cert generate             # Generate cluster CA and certificates for services which lack
+  $ curveadm cert distribute           # Distribute certificates to all services
+  $ curveadm cert ls                   # List certificates of cluster
+  $ curveadm cert rotate               # Re-issue certificates and restart services one by one
+  $ curveadm cert rotate --ca          # Rotate cluster CA and all certificates`
+
+	DEFAULT_CA_VALIDITY   = 10 * 365 * 24 * time.Hour
+	DEFAULT_CERT_VALIDITY = 365 * 24 * time.Hour
+)
+
+var (
+	// services restarted in order when rotating certificates, servers before their clients
+	CERT_ROTATE_ROLES = []string{
+		topology.ROLE_ETCD,
+		topology.ROLE_MDS,
+		topology.ROLE_CHUNKSERVER,
+		topology.ROLE_METASERVER,
+		topology.ROLE_SNAPSHOTCLONE,
+	}
+)
+
+type certOptions struct {
+	caValidity    time.Duration
+	validity      time.Duration
+	force         bool
+	rotateCA      bool
+	healthTimeout time.Duration
+}
+
+func checkCertOptions(options certOptions) error {
+	if options.caValidity <= 0 || options.validity <= 0 {
+		return errno.ERR_GENERATE_CERTIFICATE_FAILED.
+			F("validity must be positive")
+	} else if options.healthTimeout <= 0 {
+		return errno.ERR_WAIT_SERVICES_HEALTHY_TIMEOUT.
+			F("health timeout must be positive")
+	}
+	return nil
+}
+
+func addValidityFlags(cmd *cobra.Command, options *certOptions) {
+	flags := cmd.Flags()
+	flags.DurationVar(&options.caValidity, "ca-validity", DEFAULT_CA_VALIDITY, "Specify validity of cluster CA")
+	flags.DurationVar(&options.validity, "validity", DEFAULT_CERT_VALIDITY, "Specify validity of service certificates")
+}
+
+func NewCertCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cert",
+		Short:   "Manage TLS certificates of cluster",
+		Args:    cliutil.NoArgs,
+		Example: CERT_EXAMPLE,
+		RunE:    cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewCertGenerateCommand(curveadm),
+		NewCertDistributeCommand(curveadm),
+		NewCertListCommand(curveadm),
+		NewCertRotateCommand(curveadm),
+	)
+	return cmd
+}
+
+func NewCertGenerateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	options := certOptions{healthTimeout: time.Minute}
+
+	cmd := &cobra.Command{
+		Use:   "generate [OPTIONS]",
+		Short: "Generate cluster CA and service certificates",
+		Args:  cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkCertOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCertGenerate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	addValidityFlags(cmd, &options)
+	cmd.Flags().BoolVarP(&options.force, "force", "f", false, "Re-issue certificates even if they exist")
+	return cmd
+}
+
+func NewCertDistributeCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "distribute",
+		Short: "Distribute certificates into service containers",
+		Args:  cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCertDistribute(curveadm)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func NewCertListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List certificates of cluster",
+		Args:    cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCertList(curveadm)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func NewCertRotateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options certOptions
+
+	cmd := &cobra.Command{
+		Use:   "rotate [OPTIONS]",
+		Short: "Rotate certificates with rolling restart of services",
+		Args:  cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkCertOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCertRotate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	addValidityFlags(cmd, &options)
+	flags := cmd.Flags()
+	flags.BoolVar(&options.rotateCA, "ca", false, "Rotate cluster CA too")
+	flags.DurationVar(&options.healthTimeout, "health-timeout", 10*time.Minute, "Specify timeout for waiting services healthy")
+	return cmd
+}
+
+func serviceCertHosts(dc *topology.DeployConfig) []string {
+	hosts := []string{dc.GetListenIp(), dc.GetHostname(), "localhost", "127.0.0.1"}
+	if ip := dc.GetListenExternalIp(); len(ip) > 0 && ip != dc.GetListenIp() {
+		hosts = append(hosts, ip)
+	}
+	return hosts
+}
+
+func getCertificates(curveadm *cli.CurveAdm) (map[string]storage.Certificate, error) {
+	certs, err := curveadm.Storage().GetCertificates(curveadm.ClusterId())
+	if err != nil {
+		return nil, errno.ERR_GET_CERTIFICATES_FAILED.E(err)
+	}
+	m := map[string]storage.Certificate{}
+	for _, c := range certs {
+		m[c.Name] = c
+	}
+	return m, nil
+}
+
+// the private key of cluster CA is kept in secrets store, the certificates
+// table only records the reference of it
+func caKeySecretName(curveadm *cli.CurveAdm) string {
+	return fmt.Sprintf("tls_ca_key.cluster%d", curveadm.ClusterId())
+}
+
+// getCAKey returns the private key of cluster CA, the key stored in
+// certificates table by previous version is returned as it is
+func getCAKey(curveadm *cli.CurveAdm, key string) (string, error) {
+	if !secret.IsReference(key) {
+		return key, nil
+	}
+	return curveadm.Secrets().Get(secret.ReferenceName(key))
+}
+
+func setCA(curveadm *cli.CurveAdm, bundle *cert.Bundle) error {
+	name := caKeySecretName(curveadm)
+	if err := curveadm.Secrets().Set(name, bundle.Key); err != nil {
+		return err
+	}
+	return setCertificate(curveadm, cert.NAME_CA, &cert.Bundle{
+		Cert: bundle.Cert,
+		Key:  secret.REFERENCE_PREFIX + name,
+	})
+}
+
+func setCertificate(curveadm *cli.CurveAdm, name string, bundle *cert.Bundle) error {
+	err := curveadm.Storage().SetCertificate(storage.Certificate{
+		ClusterId: curveadm.ClusterId(),
+		Name:      name,
+		Cert:      bundle.Cert,
+		Key:       bundle.Key,
+	})
+	if err != nil {
+		return errno.ERR_SET_CERTIFICATE_FAILED.E(err)
+	}
+	return nil
+}
+
+/*
+ * generateCertificates issues certificates for services (and the client
+ * certificate of prometheus) which lack, or all of them if force is true.
+ * When rotating CA, the previous CA is kept in the trusted bundle (ca.crt),
+ * so the services restarted with new certificates still trust the services
+ * not restarted yet. The secrets store is unlocked only if any certificate
+ * issued, because it requires the private key of CA.
+ */
+func generateCertificates(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options certOptions) (int, error) {
+	certs, err := getCertificates(curveadm)
+	if err != nil {
+		return 0, err
+	}
+
+	// 1) cluster CA
+	force := options.force
+	ca, ok := certs[cert.NAME_CA]
+	var caBundle *cert.Bundle
+	if !ok || options.rotateCA {
+		commonName := fmt.Sprintf("curveadm-%s-ca", curveadm.ClusterName())
+		caBundle, err = cert.NewCA(commonName, options.caValidity)
+		if err != nil {
+			return 0, errno.ERR_GENERATE_CERTIFICATE_FAILED.E(err)
+		}
+		trusted := caBundle.Cert
+		if previous, err := cert.ParseCert(ca.Cert); ok && err == nil {
+			trusted += string(cert.EncodeCert(previous))
+		}
+		err = setCA(curveadm, &cert.Bundle{Cert: trusted, Key: caBundle.Key})
+		if err != nil {
+			return 0, err
+		}
+		force = true
+	}
+	issue := func(commonName string, hosts []string) (*cert.Bundle, error) {
+		if caBundle == nil {
+			key, err := getCAKey(curveadm, ca.Key)
+			if err != nil {
+				return nil, err
+			}
+			caBundle = &cert.Bundle{Cert: ca.Cert, Key: key}
+		}
+		return caBundle.Issue(commonName, hosts, options.validity)
+	}
+
+	// 2) service certificates
+	n := 0
+	for _, dc := range dcs {
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		if _, ok := certs[serviceId]; ok && !force {
+			continue
+		}
+		bundle, err := issue(fmt.Sprintf("%s-%s", dc.GetRole(), serviceId), serviceCertHosts(dc))
+		if err != nil {
+			return n, errno.ERR_GENERATE_CERTIFICATE_FAILED.
+				F("host=%s role=%s", dc.GetHost(), dc.GetRole()).E(err)
+		} else if err := setCertificate(curveadm, serviceId, bundle); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	// 3) client certificate of prometheus
+	if _, ok := certs[cert.NAME_PROMETHEUS]; !ok || force {
+		commonName := fmt.Sprintf("curveadm-%s-prometheus", curveadm.ClusterName())
+		bundle, err := issue(commonName, []string{})
+		if err != nil {
+			return n, errno.ERR_GENERATE_CERTIFICATE_FAILED.
+				F("role=prometheus").E(err)
+		} else if err := setCertificate(curveadm, cert.NAME_PROMETHEUS, bundle); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// ensureCertificates issues certificates for services which lack if tls enabled
+func ensureCertificates(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) error {
+	if len(dcs) == 0 || !dcs[0].GetTLSEnable() {
+		return nil
+	}
+	_, err := generateCertificates(curveadm, dcs, certOptions{
+		caValidity: DEFAULT_CA_VALIDITY,
+		validity:   DEFAULT_CERT_VALIDITY,
+	})
+	return err
+}
+
+func checkTLSEnabled(dcs []*topology.DeployConfig) error {
+	if len(dcs) == 0 || !dcs[0].GetTLSEnable() {
+		return errno.ERR_TLS_NOT_ENABLED.
+			F("please set 'tls.enable: true' in global section of topology")
+	}
+	return nil
+}
+
+func runCertGenerate(curveadm *cli.CurveAdm, options certOptions) error {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	n, err := generateCertificates(curveadm, dcs, options)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOutln(color.GreenString("Generated %d certificates for cluster '%s'",
+		n, curveadm.ClusterName()))
+	if n > 0 {
+		curveadm.WriteOutln("Run 'curveadm cert distribute' and restart services to take effect")
+	}
+	return nil
+}
+
+func genSyncCertPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.SYNC_CERT,
+		Configs: dcs,
+	})
+	return pb
+}
+
+func runCertDistribute(curveadm *cli.CurveAdm) error {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	return genSyncCertPlaybook(curveadm, dcs).Run()
+}
+
+func runCertList(curveadm *cli.CurveAdm) error {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	certs, err := getCertificates(curveadm)
+	if err != nil {
+		return err
+	}
+
+	items := []tui.CertificateItem{}
+	ca, ok := certs[cert.NAME_CA]
+	if ok {
+		c, _ := cert.ParseCert(ca.Cert)
+		items = append(items, tui.CertificateItem{Name: cert.NAME_CA, Role: "-", Host: "-", Cert: c})
+	}
+	caBundle := &cert.Bundle{Cert: ca.Cert, Key: ca.Key}
+	for _, dc := range dcs {
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		item := tui.CertificateItem{Name: serviceId, Role: dc.GetRole(), Host: dc.GetHost()}
+		c, ok := certs[serviceId]
+		if !ok {
+			continue
+		} else if err := caBundle.Verify(c.Cert, dc.GetListenIp()); err == nil {
+			item.Cert, _ = cert.ParseCert(c.Cert)
+		}
+		items = append(items, item)
+	}
+
+	curveadm.WriteOut(tui.FormatCertificates(items))
+	return nil
+}
+
+func sortCertRotateServices(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) []*topology.DeployConfig {
+	out := []*topology.DeployConfig{}
+	for _, role := range CERT_ROTATE_ROLES {
+		out = append(out, curveadm.FilterDeployConfigByRole(dcs, role)...)
+	}
+	return out
+}
+
+func runCertRotate(curveadm *cli.CurveAdm, options certOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	} else if err = checkTLSEnabled(dcs); err != nil {
+		return err
+	}
+
+	// 2) confirm by user
+	curveadm.WriteOutln(color.YellowString("Rotate certificates%s and restart %d services one by one",
+		cliutil.Choose(options.rotateCA, " (including cluster CA)", ""), len(dcs)))
+	if pass := curveadm.Confirm(tuicomm.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOut(tuicomm.PromptCancelOpetation("rotate certificates"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 3) re-issue all certificates
+	options.force = true
+	if _, err := generateCertificates(curveadm, dcs, options); err != nil {
+		return err
+	}
+
+	// 4) sync config and certificates for all services
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{Type: playbook.SYNC_CONFIG, Configs: dcs})
+	if err := pb.Run(); err != nil {
+		return err
+	}
+
+	// 5) restart services one by one and wait them healthy
+	services := sortCertRotateServices(curveadm, dcs)
+	all := restartOptions{id: "*", role: "*", host: "*"}
+	for i, dc := range services {
+		curveadm.WriteOutln("")
+		curveadm.WriteOutln("Restart service %s: host=%s role=%s",
+			color.BlueString("%d/%d", i+1, len(services)), dc.GetHost(), dc.GetRole())
+		unit := []*topology.DeployConfig{dc}
+		pb, err := genRestartPlaybook(curveadm, unit, all)
+		if err == nil {
+			err = pb.Run()
+		}
+		if err == nil {
+			err = waitUpgradeHealthy(curveadm, dcs, unit, options.healthTimeout)
+		}
+		if err != nil {
+			curveadm.WriteOutln(color.YellowString("Rotation stopped, " +
+				"run 'curveadm cert rotate' again after fixing the service"))
+			return err
+		}
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Rotate certificates of %d services success :)", len(services)))
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package check
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CHECK_S3_EXAMPLE = `Examples:
+  $ curveadm check s3                  # Check S3 from all snapshotclone and metaserver hosts
+  $ curveadm check s3 --host server-1  # Check S3 from specified host`
+)
+
+type checkS3Options struct {
+	host string
+}
+
+func NewCheckS3Command(curveadm *cli.CurveAdm) *cobra.Command {
+	var options checkS3Options
+
+	cmd := &cobra.Command{
+		Use:     "s3 [OPTIONS]",
+		Short:   "Check S3 endpoint, bucket and credentials",
+		Args:    cliutil.NoArgs,
+		Example: CHECK_S3_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCheckS3(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+
+	return cmd
+}
+
+func runCheckS3(curveadm *cli.CurveAdm, options checkS3Options) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) filter services which access S3
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   "*",
+		Role: "*",
+		Host: options.host,
+	})
+	dcs = checker.FilterS3DeployConfigs(dcs, false)
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED.
+			F("no snapshotclone or metaserver with S3 configured on host: %s", options.host)
+	}
+
+	// 3) check S3 from each service host
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.CHECK_S3,
+		Configs: dcs,
+	})
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln(color.GreenString("Congratulations!!! all S3 checks passed :)"))
+	return nil
+}
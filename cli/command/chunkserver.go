@@ -0,0 +1,243 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package command
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CHUNKSERVER_SET_STATUS_EXAMPLE = `Examples:
+  $ curveadm chunkserver set-status --chunkserver-id 1,2 --status pendding  # Migrate copysets out of chunkserver 1 and 2
+  $ curveadm chunkserver set-status --host server-host1 --status pendding  # Migrate copysets out of all chunkservers on host
+  $ curveadm chunkserver set-status --chunkserver-id 1 --status readwrite  # Accept copysets on chunkserver 1 again`
+)
+
+var (
+	CHUNKSERVER_STATUSES = []string{
+		bs.CHUNKSERVER_STATUS_READWRITE,
+		bs.CHUNKSERVER_STATUS_PENDDING,
+	}
+)
+
+type chunkserverSetStatusOptions struct {
+	ids    string
+	host   string
+	status string
+}
+
+func NewChunkserverCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chunkserver",
+		Short: "Manage chunkservers of curvebs cluster",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewChunkserverSetStatusCommand(curveadm),
+	)
+	return cmd
+}
+
+func parseChunkserverIds(ids string) ([]int, error) {
+	out := []int{}
+	for _, item := range strings.Split(ids, ",") {
+		id, ok := cliutil.Str2Int(strings.TrimSpace(item))
+		if !ok || id < 0 {
+			return nil, errno.ERR_INVALID_SET_STATUS_OPTIONS.
+				F("invalid chunkserver id '%s'", item)
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+func checkChunkserverSetStatusOptions(options chunkserverSetStatusOptions) error {
+	if len(options.ids) > 0 && len(options.host) > 0 {
+		return errno.ERR_INVALID_SET_STATUS_OPTIONS.
+			F("--chunkserver-id and --host can't be specified at the same time")
+	} else if len(options.ids) == 0 && len(options.host) == 0 {
+		return errno.ERR_INVALID_SET_STATUS_OPTIONS.
+			F("--chunkserver-id or --host must be specified")
+	} else if !cliutil.Slice2Map(CHUNKSERVER_STATUSES)[options.status] {
+		return errno.ERR_INVALID_SET_STATUS_OPTIONS.
+			F("--status must be one of %s", strings.Join(CHUNKSERVER_STATUSES, ", "))
+	} else if len(options.ids) > 0 {
+		_, err := parseChunkserverIds(options.ids)
+		return err
+	}
+	return nil
+}
+
+func NewChunkserverSetStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options chunkserverSetStatusOptions
+
+	cmd := &cobra.Command{
+		Use:     "set-status --status STATUS [OPTIONS]",
+		Short:   "Set read-write status of chunkservers",
+		Args:    cliutil.NoArgs,
+		Example: CHUNKSERVER_SET_STATUS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkChunkserverSetStatusOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChunkserverSetStatus(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.ids, "chunkserver-id", "", "Specify chunkserver ids, separated by comma")
+	flags.StringVar(&options.host, "host", "", "Specify host, all chunkservers on it are selected")
+	flags.StringVar(&options.status, "status", "", "Specify status: readwrite, pendding")
+
+	return cmd
+}
+
+// selectChunkservers returns the chunkservers specified by ids or on host
+func selectChunkservers(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	loads []bs.ChunkserverLoad,
+	options chunkserverSetStatusOptions) ([]bs.ChunkserverLoad, error) {
+	selected := []bs.ChunkserverLoad{}
+	if len(options.host) > 0 {
+		addrs := map[string]bool{}
+		for _, dc := range curveadm.FilterDeployConfigByRole(dcs, ROLE_CHUNKSERVER) {
+			if dc.GetHost() == options.host {
+				addrs[cliutil.JoinHostPort(dc.GetListenIp(), dc.GetListenPort())] = true
+			}
+		}
+		for _, load := range loads {
+			if addrs[load.Addr] {
+				selected = append(selected, load)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, errno.ERR_INVALID_SET_STATUS_OPTIONS.
+				F("no chunkservers on host %s", options.host)
+		}
+		return selected, nil
+	}
+
+	m := map[int]bs.ChunkserverLoad{}
+	for _, load := range loads {
+		m[load.Id] = load
+	}
+	ids, _ := parseChunkserverIds(options.ids)
+	for _, id := range ids {
+		load, ok := m[id]
+		if !ok {
+			return nil, errno.ERR_INVALID_SET_STATUS_OPTIONS.
+				F("chunkserver %d not found", id)
+		}
+		selected = append(selected, load)
+	}
+	return selected, nil
+}
+
+func runChunkserverSetStatus(curveadm *cli.CurveAdm, options chunkserverSetStatusOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	dcs = topology.SelectByKind(dcs, topology.KIND_CURVEBS)
+	if len(dcs) == 0 {
+		return errno.ERR_UNSUPPORT_CLUSTER_KIND.
+			F("chunkserver only supports curvebs cluster")
+	}
+
+	// 2) gather chunkservers and locate their zones
+	loads, err := getChunkserverLoads(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+	err = locateChunkservers(curveadm, dcs, loads)
+	if err != nil {
+		return err
+	}
+	selected, err := selectChunkservers(curveadm, dcs, loads, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) copysets should be migratable before marking chunkservers pendding
+	if options.status == bs.CHUNKSERVER_STATUS_PENDDING {
+		ids := []int{}
+		for _, load := range selected {
+			ids = append(ids, load.Id)
+		}
+		if err = bs.CheckPenddingChunkservers(loads, ids); err != nil {
+			return err
+		}
+	}
+
+	// 4) confirm by user
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", tui.FormatChunkserverStatusChanges(selected, options.status))
+	curveadm.WriteOutln("")
+	if pass := curveadm.Confirm(tuicomm.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOutln(tuicomm.PromptCancelOpetation("set chunkserver status"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 5) set status by the first mds
+	ids := []int{}
+	for _, load := range selected {
+		ids = append(ids, load.Id)
+	}
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.SET_CHUNKSERVER_STATUS,
+		Configs: curveadm.FilterDeployConfigByRole(dcs, ROLE_MDS)[:1],
+		Options: map[string]interface{}{
+			comm.KEY_CHUNKSERVER_IDS:    ids,
+			comm.KEY_CHUNKSERVER_STATUS: options.status,
+		},
+	})
+	if err = pb.Run(); err != nil {
+		return err
+	}
+
+	// 6) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Status of %d chunkservers set to %s", len(ids), options.status))
+	if options.status == bs.CHUNKSERVER_STATUS_PENDDING {
+		curveadm.WriteOutln("Copysets will be migrated by scheduler of mds in background, " +
+			"run 'curveadm balance-status' to see the progress")
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkserver_CheckSetStatusOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(checkChunkserverSetStatusOptions(chunkserverSetStatusOptions{ids: "1, 2", status: "pendding"}))
+	assert.Nil(checkChunkserverSetStatusOptions(chunkserverSetStatusOptions{host: "host1", status: "readwrite"}))
+	assert.NotNil(checkChunkserverSetStatusOptions(chunkserverSetStatusOptions{status: "pendding"}))
+	assert.NotNil(checkChunkserverSetStatusOptions(chunkserverSetStatusOptions{ids: "1", host: "host1", status: "pendding"}))
+	assert.NotNil(checkChunkserverSetStatusOptions(chunkserverSetStatusOptions{ids: "1", status: "retired"}))
+	assert.NotNil(checkChunkserverSetStatusOptions(chunkserverSetStatusOptions{ids: "1,a", status: "pendding"}))
+
+	ids, err := parseChunkserverIds("3,1, 2")
+	assert.Nil(err)
+	assert.Equal([]int{3, 1, 2}, ids)
+}
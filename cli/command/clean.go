@@ -25,11 +25,19 @@
 package command
 
 import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuicomm "github.com/opencurve/curveadm/internal/tui"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	utils "github.com/opencurve/curveadm/internal/utils"
@@ -40,7 +48,8 @@ const (
 	CLEAN_EXAMPLE = `Examples:
   $ curveadm clean                               # Clean everything for all services
   $ curveadm clean --only='log,data'             # Clean log and data for all services
-  $ curveadm clean --role=etcd --only=container  # Clean container for etcd services`
+  $ curveadm clean --role=etcd --only=container  # Clean container for etcd services
+  $ curveadm clean --export-report=report.json   # Clean everything and save the destruction manifest`
 )
 
 var (
@@ -61,6 +70,24 @@ type cleanOptions struct {
 	host           string
 	only           []string
 	withoutRecycle bool
+	exportReport   string
+}
+
+// the destruction manifest which exported for audit
+type cleanManifest struct {
+	Cluster     string             `json:"cluster"`
+	ClusterUUId string             `json:"cluster_uuid"`
+	CleanTime   string             `json:"clean_time"`
+	Items       []string           `json:"items"`
+	Summary     cleanSummary       `json:"summary"`
+	Reports     []task.CleanReport `json:"reports"`
+}
+
+type cleanSummary struct {
+	Hosts       int   `json:"hosts"`
+	Containers  int   `json:"containers"`
+	Directories int   `json:"directories"`
+	Bytes       int64 `json:"bytes"`
 }
 
 func checkCleanOptions(curveadm *cli.CurveAdm, options cleanOptions) error {
@@ -94,16 +121,17 @@ func NewCleanCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.id, "id", "*", "Specify service id")
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
-	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
 	flags.StringSliceVarP(&options.only, "only", "o", CLEAN_ITEMS, "Specify clean item")
 	flags.BoolVar(&options.withoutRecycle, "no-recycle", false, "Remove data directory directly instead of recycle chunks")
+	flags.StringVar(&options.exportReport, "export-report", "", "Export the destruction manifest to file")
 
 	return cmd
 }
 
-func genCleanPlaybook(curveadm *cli.CurveAdm,
+func filterCleanServices(curveadm *cli.CurveAdm,
 	dcs []*topology.DeployConfig,
-	options cleanOptions) (*playbook.Playbook, error) {
+	options cleanOptions) ([]*topology.DeployConfig, error) {
 	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
 		Id:   options.id,
 		Role: options.role,
@@ -112,6 +140,100 @@ func genCleanPlaybook(curveadm *cli.CurveAdm,
 	if len(dcs) == 0 {
 		return nil, errno.ERR_NO_SERVICES_MATCHED
 	}
+	return dcs, nil
+}
+
+func genCleanReportPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options cleanOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_CLEAN_REPORT,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_CLEAN_ITEMS:      options.only,
+			comm.KEY_CLEAN_BY_RECYCLE: options.withoutRecycle == false,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar: true,
+			SkipError:    true,
+		},
+	})
+	return pb
+}
+
+// reports are collected concurrently, we sort them in deploy order
+func sortCleanReports(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, reports []task.CleanReport) {
+	order := map[string]int{}
+	for i, dc := range dcs {
+		order[curveadm.GetServiceId(dc.GetId())] = i
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return order[reports[i].ServiceId] < order[reports[j].ServiceId]
+	})
+}
+
+// the size of directory which can't be measured is not counted
+func summarizeCleanReports(reports []task.CleanReport, items []string) cleanSummary {
+	summary := cleanSummary{}
+	hosts := map[string]bool{}
+	clean := utils.Slice2Map(items)
+	for _, report := range reports {
+		hosts[report.Host] = true
+		if clean[comm.CLEAN_ITEM_CONTAINER] &&
+			len(report.ContainerId) > 0 && report.ContainerId != comm.CLEANED_CONTAINER_ID {
+			summary.Containers++
+		}
+		for _, dir := range report.Dirs {
+			summary.Directories++
+			if dir.Bytes > 0 {
+				summary.Bytes += dir.Bytes
+			}
+		}
+	}
+	summary.Hosts = len(hosts)
+	return summary
+}
+
+func displayCleanReport(curveadm *cli.CurveAdm, reports []task.CleanReport, summary cleanSummary) {
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", tuicomm.FormatCleanReports(reports))
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.RedString("%d hosts, %d containers, %d directories (%s) will be destroyed",
+		summary.Hosts, summary.Containers, summary.Directories, humanize.IBytes(uint64(summary.Bytes))))
+}
+
+func exportCleanReport(curveadm *cli.CurveAdm,
+	reports []task.CleanReport,
+	summary cleanSummary,
+	options cleanOptions) error {
+	manifest := cleanManifest{
+		Cluster:     curveadm.ClusterName(),
+		ClusterUUId: curveadm.ClusterUUId(),
+		CleanTime:   time.Now().Format("2006-01-02 15:04:05"),
+		Items:       options.only,
+		Summary:     summary,
+		Reports:     reports,
+	}
+	bytes, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	err = utils.WriteFile(options.exportReport, string(bytes), 0644)
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	curveadm.WriteOutln("Destruction manifest saved to %s", utils.AbsPath(options.exportReport))
+	return nil
+}
+
+func genCleanPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options cleanOptions) (*playbook.Playbook, error) {
+	dcs, err := filterCleanServices(curveadm, dcs, options)
+	if err != nil {
+		return nil, err
+	}
 
 	steps := CLEAN_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
@@ -135,18 +257,75 @@ func runClean(curveadm *cli.CurveAdm, options cleanOptions) error {
 		return err
 	}
 
-	// 2) generate clean playbook
+	// 2) protected cluster can only be cleaned after unlocked, and the
+	//    confirmation below can't be answered without terminal
+	err = curveadm.CheckClusterUnlocked(curveadm.ClusterId(), curveadm.ClusterName())
+	if err != nil {
+		return err
+	}
+	items := utils.Slice2Map(options.only)
+	destroy := items[comm.CLEAN_ITEM_DATA]
+	err = curveadm.CheckConfirmable(curveadm.ClusterId(), curveadm.ClusterName(), destroy)
+	if err != nil {
+		return err
+	}
+
+	// 3) generate clean playbook
 	pb, err := genCleanPlaybook(curveadm, dcs, options)
 	if err != nil {
 		return err
 	}
 
-	// 3) confirm by user
-	if pass := tui.ConfirmYes(tui.PromptCleanService(options.role, options.host, options.only)); !pass {
+	// 4) confirm by user, it requires typing cluster name to destroy data
+	dirs := []string{}
+	if destroy {
+		dirs = append(dirs, playbook.IMPACT_DIR_DATA)
+	}
+	if items[comm.CLEAN_ITEM_LOG] {
+		dirs = append(dirs, playbook.IMPACT_DIR_LOG)
+	}
+	impact := tui.PromptImpact(pb.Impact("clean service", dirs...))
+	if !destroy && len(options.exportReport) == 0 {
+		if pass := curveadm.Confirm(impact + tui.PromptCleanService(options.role, options.host, options.only)); !pass {
+			curveadm.WriteOut(tui.PromptCancelOpetation("clean service"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+		return pb.Run()
+	}
+
+	// 5) report what will be destroyed
+	dcs, _ = filterCleanServices(curveadm, dcs, options)
+	err = genCleanReportPlaybook(curveadm, dcs, options).Run()
+	if err != nil {
+		return err
+	}
+	reports := []task.CleanReport{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_CLEAN_REPORTS); v != nil {
+		reports = v.([]task.CleanReport)
+	}
+	sortCleanReports(curveadm, dcs, reports)
+	summary := summarizeCleanReports(reports, options.only)
+	displayCleanReport(curveadm, reports, summary)
+
+	// 6) confirm by user
+	if destroy {
+		if pass := curveadm.ConfirmDestroy(impact + tui.PromptDestroyCluster(curveadm.ClusterName())); !pass {
+			curveadm.WriteOutln(tui.PromptCancelOpetation("clean service"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	} else if pass := curveadm.Confirm(impact + tui.PromptCleanService(options.role, options.host, options.only)); !pass {
 		curveadm.WriteOut(tui.PromptCancelOpetation("clean service"))
 		return errno.ERR_CANCEL_OPERATION
 	}
 
-	// 4) run playground
+	// 7) export destruction manifest for audit
+	if len(options.exportReport) > 0 {
+		err = exportCleanReport(curveadm, reports, summary, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 8) run playground
 	return pb.Run()
 }
@@ -0,0 +1,66 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"testing"
+
+	comm "github.com/opencurve/curveadm/internal/common"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClean_SummarizeCleanReports(t *testing.T) {
+	assert := assert.New(t)
+
+	reports := []task.CleanReport{
+		{
+			ServiceId:   "c9d5b9e1a7f2",
+			Host:        "host1",
+			ContainerId: "3b8e61f0d2a4",
+			Dirs: []task.CleanDir{
+				{Path: "/data/chunkserver0", Device: "/dev/sdb", Bytes: 1024},
+				{Path: "/logs/chunkserver0", Device: "/dev/sda", Bytes: -1},
+			},
+		},
+		{
+			ServiceId:   "f1e2d3c4b5a6",
+			Host:        "host1",
+			ContainerId: comm.CLEANED_CONTAINER_ID,
+			Dirs: []task.CleanDir{
+				{Path: "/data/chunkserver1", Device: "/dev/sdc", Bytes: 2048},
+			},
+		},
+		{
+			ServiceId:   "a6b5c4d3e2f1",
+			Host:        "host2",
+			ContainerId: "7c1d9a2e5b3f",
+		},
+	}
+
+	summary := summarizeCleanReports(reports, CLEAN_ITEMS)
+	assert.Equal(cleanSummary{Hosts: 2, Containers: 2, Directories: 3, Bytes: 3072}, summary)
+
+	summary = summarizeCleanReports(reports, []string{comm.CLEAN_ITEM_DATA})
+	assert.Equal(0, summary.Containers)
+}
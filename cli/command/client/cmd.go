@@ -39,10 +39,13 @@ func NewClientCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	cmd.AddCommand(
 		NewMapCommand(curveadm),
 		NewUnmapCommand(curveadm),
+		NewUnmapAllCommand(curveadm),
 		NewMountCommand(curveadm),
 		NewUmountCommand(curveadm),
+		NewListCommand(curveadm),
 		NewStatusCommand(curveadm),
 		NewEnterCommand(curveadm),
+		NewCSICommand(curveadm),
 		// NewInstallCommand(curveadm),
 		// NewUninstallCommand(curveadm),
 	)
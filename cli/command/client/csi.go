@@ -0,0 +1,132 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package client
+
+import (
+	"os"
+	"path"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CSI_EXAMPLE = `Examples:
+  $ curveadm client csi                               # Generate curve csi manifests into directory 'curve-csi'
+  $ curveadm client csi -o /path/to/dir               # Generate curve csi manifests into specified directory
+  $ curveadm client csi --namespace curve --user k8s  # Specify kubernetes namespace and owner of volumes
+  $ curveadm client csi -c client.yaml                # Specify s3 credentials for CurveFS by client configure`
+)
+
+type csiOptions struct {
+	output    string
+	namespace string
+	user      string
+	image     string
+	filename  string
+}
+
+func NewCSICommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options csiOptions
+
+	cmd := &cobra.Command{
+		Use:     "csi [OPTIONS]",
+		Short:   "Generate kubernetes manifests of curve csi driver for current cluster",
+		Args:    cliutil.NoArgs,
+		Example: CSI_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCSI(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.output, "output", "o", "curve-csi", "Output manifests to specified directory")
+	flags.StringVar(&options.namespace, "namespace", configure.DEFAULT_CSI_NAMESPACE, "Kubernetes namespace which csi driver deployed in")
+	flags.StringVar(&options.user, "user", configure.DEFAULT_CSI_USER, "Owner of volumes created by csi driver (CurveBS only)")
+	flags.StringVar(&options.image, "image", "", "Specify csi driver image")
+	flags.StringVarP(&options.filename, "conf", "c", "", "Specify curvefs client configure file which contains s3 credentials")
+
+	return cmd
+}
+
+func writeCSIManifests(dir string, manifests []configure.CSIManifest) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+
+	// manifests may contain s3 credentials
+	for _, manifest := range manifests {
+		err := cliutil.WriteFile(path.Join(dir, manifest.Filename), manifest.Content, 0600)
+		if err != nil {
+			return errno.ERR_WRITE_FILE_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func runCSI(curveadm *cli.CurveAdm, options csiOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) parse client configure if specified
+	var cc *configure.ClientConfig
+	if len(options.filename) > 0 {
+		cc, err = configure.ParseClientConfig(options.filename)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 3) generate manifests by cluster endpoints and credentials
+	manifests, err := configure.GenCSIManifests(dcs, configure.CSIOptions{
+		Cluster:   curveadm.ClusterName(),
+		Namespace: options.namespace,
+		User:      options.user,
+		Image:     options.image,
+		Client:    cc,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 4) write manifests to directory
+	err = writeCSIManifests(options.output, manifests)
+	if err != nil {
+		return err
+	}
+	for _, manifest := range manifests {
+		curveadm.WriteOutln("%s", path.Join(options.output, manifest.Filename))
+	}
+	curveadm.WriteOutln(color.GreenString("Generate csi manifests success, apply them by: kubectl apply -f %s"),
+		options.output)
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package client
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	host string
+}
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List clients",
+		Args:    cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "", "Only list clients on the specified host")
+
+	return cmd
+}
+
+func filterClients(clients []storage.Client, host string) []storage.Client {
+	if len(host) == 0 {
+		return clients
+	}
+
+	out := []storage.Client{}
+	for _, client := range clients {
+		if client.Host == host {
+			out = append(out, client)
+		}
+	}
+	return out
+}
+
+func runList(curveadm *cli.CurveAdm, options listOptions) error {
+	// 1) get all clients
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	// 2) display clients
+	clients = filterClients(clients, options.host)
+	output := tui.FormatClients(clients)
+	curveadm.WriteOut(output)
+	return nil
+}
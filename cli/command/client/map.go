@@ -44,7 +44,8 @@ const (
   $ curveadm map user:/volume --host machine1 --create                  # Map volume which created by automatic
   $ curveadm map user:/volume --host machine1 --size=10GiB --create     # Map volume which size is 10GiB and created by automatic
   $ curveadm map user:/volume --host machine1 --create --poolset ssd    # Map volume created by automatic in poolset 'ssd'
-  $ curveadm map user:/volume --host machine1 -c /path/to/client.yaml   # Map volume with specified configure file`
+  $ curveadm map user:/volume --host machine1 -c /path/to/client.yaml   # Map volume with specified configure file
+  $ curveadm map user:/volume --host machine1 --write-iops 1000         # Map volume which write IOPS limited to 1000`
 )
 
 var (
@@ -66,6 +67,7 @@ type mapOptions struct {
 	filename    string
 	noExclusive bool
 	poolset     string
+	qos         qosOptions
 }
 
 func ParseImage(image string) (user, name string, err error) {
@@ -130,6 +132,8 @@ func checkMapOptions(curveadm *cli.CurveAdm, options mapOptions) error {
 		return err
 	} else if _, err = ParseSize(options.size); err != nil {
 		return err
+	} else if _, err = parseQoS(options.qos); err != nil {
+		return err
 	} else if !utils.PathExist(options.filename) {
 		return errno.ERR_CLIENT_CONFIGURE_FILE_NOT_EXIST.
 			F("file path: %s", utils.AbsPath(options.filename))
@@ -162,6 +166,7 @@ func NewMapCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
 	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
 	flags.StringVar(&options.poolset, "poolset", "default", "Specify the poolset name")
+	addQoSFlags(flags, &options.qos)
 	return cmd
 }
 
@@ -170,6 +175,7 @@ func genMapPlaybook(curveadm *cli.CurveAdm,
 	options mapOptions) (*playbook.Playbook, error) {
 	user, name, _ := ParseImage(options.image)
 	size, _ := ParseSize(options.size)
+	qos, _ := parseQoS(options.qos)
 	steps := MAP_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
@@ -185,6 +191,7 @@ func genMapPlaybook(curveadm *cli.CurveAdm,
 					Create:      options.create,
 					NoExclusive: options.noExclusive,
 					Poolset:     options.poolset,
+					QoS:         qos,
 				},
 				comm.KEY_CLIENT_HOST:              options.host, // for checker
 				comm.KEY_CHECK_KERNEL_MODULE_NAME: comm.KERNERL_MODULE_NBD,
@@ -40,7 +40,9 @@ import (
 const (
 	MOUNT_EXAMPLE = `Examples:
   $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml [--fstype s3]    # Mount a s3 CurveFS '/s3_001' to '/path/to/mount'
-  $ curveadm mount /volume_001 /path/to/mount --host machine -c client.yaml --fstype volume  # Mount a volume CurveFS '/volume_001' to '/path/to/mount'`
+  $ curveadm mount /volume_001 /path/to/mount --host machine -c client.yaml --fstype volume  # Mount a volume CurveFS '/volume_001' to '/path/to/mount'
+  $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml --automount   # Mount and remount it on boot by systemd
+  $ curveadm mount status --remount                                                        # Check all mount points and remount the dead ones`
 )
 
 var (
@@ -50,6 +52,10 @@ var (
 		playbook.CHECK_CLIENT_S3,
 		playbook.MOUNT_FILESYSTEM,
 	}
+
+	MOUNT_PLAYBOOK_CONDITIONS = map[int]string{
+		playbook.CHECK_KERNEL_MODULE: "!options." + comm.KEY_MOUNT_INSECURE, // insecure mount doesn't require fuse module
+	}
 )
 
 type mountOptions struct {
@@ -59,6 +65,8 @@ type mountOptions struct {
 	mountPoint  string
 	filename    string
 	insecure    bool
+	automount   bool
+	qos         qosOptions
 }
 
 func checkMountOptions(curveadm *cli.CurveAdm, options mountOptions) error {
@@ -66,7 +74,8 @@ func checkMountOptions(curveadm *cli.CurveAdm, options mountOptions) error {
 		return errno.ERR_FS_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH.
 			F("mount point: %s", options.mountPoint)
 	}
-	return nil
+	_, err := parseQoS(options.qos)
+	return err
 }
 
 func NewMountCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -93,8 +102,15 @@ func NewMountCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.host, "host", "localhost", "Specify target host")
 	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
-	flags.StringVar(&options.mountFSType, "fstype", "s3", "Specify fs data backend")
+	flags.StringVar(&options.mountFSType, "fstype", fs.DEFAULT_MOUNT_FSTYPE, "Specify fs data backend")
 	flags.BoolVarP(&options.insecure, "insecure", "k", false, "Mount without precheck")
+	flags.BoolVar(&options.automount, "automount", false, "Install systemd unit to mount filesystem on boot")
+	addQoSFlags(flags, &options.qos)
+
+	cmd.AddCommand(
+		NewMountListCommand(curveadm),
+		NewMountStatusCommand(curveadm),
+	)
 
 	return cmd
 }
@@ -102,26 +118,26 @@ func NewMountCommand(curveadm *cli.CurveAdm) *cobra.Command {
 func genMountPlaybook(curveadm *cli.CurveAdm,
 	ccs []*configure.ClientConfig,
 	options mountOptions) (*playbook.Playbook, error) {
+	qos, _ := parseQoS(options.qos)
 	steps := MOUNT_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
-		if step == playbook.CHECK_KERNEL_MODULE &&
-			options.insecure {
-			continue
-		}
-
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
 			Configs: ccs,
+			When:    MOUNT_PLAYBOOK_CONDITIONS[step],
 			Options: map[string]interface{}{
 				comm.KEY_MOUNT_OPTIONS: fs.MountOptions{
 					Host:        options.host,
 					MountFSName: options.mountFSName,
 					MountFSType: options.mountFSType,
 					MountPoint:  utils.TrimSuffixRepeat(options.mountPoint, "/"),
+					Automount:   options.automount,
+					QoS:         qos,
 				},
 				comm.KEY_CLIENT_HOST:              options.host, // for checker
 				comm.KEY_CHECK_KERNEL_MODULE_NAME: comm.KERNERL_MODULE_FUSE,
+				comm.KEY_MOUNT_INSECURE:           options.insecure,
 			},
 			ExecOptions: playbook.ExecOptions{
 				SilentSubBar: step == playbook.CHECK_CLIENT_S3,
@@ -0,0 +1,254 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package client
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	tui "github.com/opencurve/curveadm/internal/tui/client"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	REMOUNT_PLAYBOOK_STEPS = []int{
+		playbook.UMOUNT_FILESYSTEM,
+		playbook.MOUNT_FILESYSTEM,
+	}
+)
+
+type mountStatusOptions struct {
+	host    string
+	remount bool
+}
+
+func NewMountListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options mountStatusOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List mounted filesystems",
+		Args:    cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMountList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "", "Only list filesystems mounted on the specified host")
+
+	return cmd
+}
+
+func NewMountStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options mountStatusOptions
+
+	cmd := &cobra.Command{
+		Use:   "status [OPTIONS]",
+		Short: "Check whether mounted filesystems are alive",
+		Args:  cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMountStatus(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "", "Only check filesystems mounted on the specified host")
+	flags.BoolVar(&options.remount, "remount", false, "Remount the stale or unmounted filesystems")
+
+	return cmd
+}
+
+func getMountClients(curveadm *cli.CurveAdm, host string) ([]storage.Client, error) {
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	out := []storage.Client{}
+	for _, client := range filterClients(clients, host) {
+		if client.Kind == topology.KIND_CURVEFS {
+			out = append(out, client)
+		}
+	}
+	return out, nil
+}
+
+func runMountList(curveadm *cli.CurveAdm, options mountStatusOptions) error {
+	clients, err := getMountClients(curveadm, options.host)
+	if err != nil {
+		return err
+	}
+
+	statuses := []fs.MountStatus{}
+	for _, client := range clients {
+		auxInfo, err := fs.DecodeAuxInfo(client.AuxInfo)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, fs.MountStatus{
+			Id:          client.Id,
+			Host:        client.Host,
+			FSName:      auxInfo.FSName,
+			FSType:      auxInfo.FSType,
+			MountPoint:  auxInfo.MountPoint,
+			ContainerId: client.ContainerId,
+			Status:      "-",
+		})
+	}
+	curveadm.WriteOut(tui.FormatMountStatus(statuses))
+	return nil
+}
+
+func genMountStatusPlaybook(curveadm *cli.CurveAdm, clients []storage.Client) *playbook.Playbook {
+	configs := []interface{}{}
+	for _, client := range clients {
+		configs = append(configs, client)
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_MOUNT_STATUS,
+		Configs: configs,
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar: true,
+			SkipError:    true,
+		},
+	})
+	return pb
+}
+
+func getMountStatuses(curveadm *cli.CurveAdm) []fs.MountStatus {
+	statuses := []fs.MountStatus{}
+	v := curveadm.MemStorage().Get(comm.KEY_ALL_MOUNT_STATUS)
+	if v != nil {
+		for _, status := range v.(map[string]fs.MountStatus) {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+func getDeadMounts(statuses []fs.MountStatus) []fs.MountStatus {
+	out := []fs.MountStatus{}
+	for _, status := range statuses {
+		if status.Status == fs.MOUNT_STATUS_STALE ||
+			status.Status == fs.MOUNT_STATUS_UNMOUNTED {
+			out = append(out, status)
+		}
+	}
+	return out
+}
+
+func genRemountPlaybook(curveadm *cli.CurveAdm, statuses []fs.MountStatus) (*playbook.Playbook, error) {
+	pb := playbook.NewPlaybook(curveadm)
+	for _, status := range statuses {
+		// remount with the recorded client config
+		cfgs, err := curveadm.Storage().GetClientConfig(status.Id)
+		if err != nil {
+			return nil, errno.ERR_SELECT_CLIENT_CONFIG_FAILED.E(err)
+		} else if len(cfgs) == 0 {
+			return nil, errno.ERR_CLIENT_CONFIGURE_FILE_NOT_EXIST.
+				F("mount point: %s:%s", status.Host, status.MountPoint)
+		}
+		cc, err := configure.ParseClientCfg(cfgs[0].Data)
+		if err != nil {
+			return nil, err
+		}
+		client, err := curveadm.Storage().GetClient(status.Id)
+		if err != nil {
+			return nil, errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+		} else if len(client) == 0 {
+			continue
+		}
+		auxInfo, err := fs.DecodeAuxInfo(client[0].AuxInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, step := range REMOUNT_PLAYBOOK_STEPS {
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    step,
+				Configs: []*configure.ClientConfig{cc},
+				Options: map[string]interface{}{
+					comm.KEY_MOUNT_OPTIONS: fs.MountOptions{
+						Host:        status.Host,
+						MountFSName: status.FSName,
+						MountFSType: status.FSType,
+						MountPoint:  status.MountPoint,
+						Automount:   auxInfo.Automount,
+					},
+				},
+			})
+		}
+	}
+	return pb, nil
+}
+
+func runMountStatus(curveadm *cli.CurveAdm, options mountStatusOptions) error {
+	// 1) get all mounted filesystems
+	clients, err := getMountClients(curveadm, options.host)
+	if err != nil {
+		return err
+	}
+
+	// 2) probe mount points
+	err = genMountStatusPlaybook(curveadm, clients).Run()
+	statuses := getMountStatuses(curveadm)
+	if len(clients) > 0 {
+		curveadm.WriteOutln("")
+	}
+	curveadm.WriteOut(tui.FormatMountStatus(statuses))
+	if err != nil || !options.remount {
+		return err
+	}
+
+	// 3) remount the dead ones
+	dead := getDeadMounts(statuses)
+	if len(dead) == 0 {
+		return nil
+	}
+	pb, err := genRemountPlaybook(curveadm, dead)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOutln("")
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Remount dead filesystems success ^_^"))
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package client
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/spf13/pflag"
+)
+
+type qosOptions struct {
+	readIOPS  int
+	writeIOPS int
+	readBPS   string
+	writeBPS  string
+}
+
+func addQoSFlags(flags *pflag.FlagSet, options *qosOptions) {
+	flags.IntVar(&options.readIOPS, "read-iops", 0, "Limit read IOPS (0 means unlimited)")
+	flags.IntVar(&options.writeIOPS, "write-iops", 0, "Limit write IOPS (0 means unlimited)")
+	flags.StringVar(&options.readBPS, "read-bps", "", "Limit read bandwidth per second, e.g. 100MiB")
+	flags.StringVar(&options.writeBPS, "write-bps", "", "Limit write bandwidth per second, e.g. 100MiB")
+}
+
+func parseBPS(name, bps string) (int, error) {
+	if len(bps) == 0 {
+		return 0, nil
+	}
+	n, err := humanize.ParseBytes(bps)
+	if err != nil {
+		return 0, errno.ERR_INVALID_CLIENT_QOS_VALUE.
+			F("%s: %s", name, bps)
+	}
+	return int(n), nil
+}
+
+func parseQoS(options qosOptions) (configure.ClientQoS, error) {
+	qos := configure.ClientQoS{
+		ReadIOPS:  options.readIOPS,
+		WriteIOPS: options.writeIOPS,
+	}
+	if qos.ReadIOPS < 0 || qos.WriteIOPS < 0 {
+		return qos, errno.ERR_INVALID_CLIENT_QOS_VALUE.
+			F("read-iops: %d, write-iops: %d", qos.ReadIOPS, qos.WriteIOPS)
+	}
+
+	var err error
+	if qos.ReadBPS, err = parseBPS("read-bps", options.readBPS); err != nil {
+		return qos, err
+	} else if qos.WriteBPS, err = parseBPS("write-bps", options.writeBPS); err != nil {
+		return qos, err
+	}
+	return qos, nil
+}
@@ -42,6 +42,7 @@ var (
 )
 
 type statusOptions struct {
+	host    string
 	verbose bool
 }
 
@@ -59,6 +60,7 @@ func NewStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "", "Only display clients on the specified host")
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for status")
 
 	return cmd
@@ -114,6 +116,7 @@ func runStatus(curveadm *cli.CurveAdm, options statusOptions) error {
 	if err != nil {
 		return errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
 	}
+	clients = filterClients(clients, options.host)
 
 	// 2) generate get status playbook
 	pb, err := genStatusPlaybook(curveadm, clients, options)
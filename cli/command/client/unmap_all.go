@@ -0,0 +1,143 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package client
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	UNMAP_ALL_EXAMPLE = `Examples:
+  $ curveadm client unmap-all --host machine1  # Unmap all volumes mapped on machine1`
+)
+
+type unmapAllOptions struct {
+	host string
+	yes  bool
+}
+
+func NewUnmapAllCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options unmapAllOptions
+
+	cmd := &cobra.Command{
+		Use:     "unmap-all [OPTIONS]",
+		Aliases: []string{"umap-all"},
+		Short:   "Unmap all volumes on host",
+		Args:    cliutil.NoArgs,
+		Example: UNMAP_ALL_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUnmapAll(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "localhost", "Specify target host")
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Automatic yes to prompts")
+
+	return cmd
+}
+
+func getMappedVolumes(curveadm *cli.CurveAdm, host string) ([]bs.MapOptions, error) {
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	volumes := []bs.MapOptions{}
+	for _, client := range filterClients(clients, host) {
+		if client.Kind != topology.KIND_CURVEBS {
+			continue
+		}
+		auxInfo, err := bs.DecodeAuxInfo(client.AuxInfo)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, bs.MapOptions{
+			Host:   host,
+			User:   auxInfo.User,
+			Volume: auxInfo.Volume,
+		})
+	}
+	return volumes, nil
+}
+
+func genUnmapAllPlaybook(curveadm *cli.CurveAdm, volumes []bs.MapOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	for _, volume := range volumes {
+		for _, step := range UNMAP_PLAYBOOK_STEPS {
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    step,
+				Configs: nil,
+				Options: map[string]interface{}{
+					comm.KEY_MAP_OPTIONS: volume,
+				},
+			})
+		}
+	}
+	return pb
+}
+
+func runUnmapAll(curveadm *cli.CurveAdm, options unmapAllOptions) error {
+	// 1) get all volumes mapped on host
+	volumes, err := getMappedVolumes(curveadm, options.host)
+	if err != nil {
+		return err
+	} else if len(volumes) == 0 {
+		curveadm.WriteOutln("No volumes mapped on host '%s'", options.host)
+		return nil
+	}
+
+	// 2) confirm by user
+	names := []string{}
+	for _, volume := range volumes {
+		names = append(names, volume.User+":"+volume.Volume)
+	}
+	if !options.yes {
+		if pass := tui.ConfirmYes(tui.PromptUnmapAll(options.host, names)); !pass {
+			curveadm.WriteOut(tui.PromptCancelOpetation("unmap all volumes"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 3) run playbook
+	err = genUnmapAllPlaybook(curveadm, volumes).Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("All volumes on host '%s' successfully unmapped ^_^.",
+		options.host))
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cluster
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CLONE_EXAMPLE = `Examples:
+  $ curveadm cluster clone prod staging                                     # Clone cluster 'prod' to 'staging'
+  $ curveadm cluster clone prod staging --hosts-map host1=test1,host2=test2 # Clone with hosts replaced
+  $ curveadm cluster clone prod staging --hosts-map 10.0.0.1=10.1.0.1       # Clone with host specified by IP address replaced`
+
+	// hostname, IP address or host in hosts.yaml
+	REGEX_HOST_TOKEN = `[\w.-]+`
+
+	// e.g: "    - host: host1", "  host: 'host1'  # comment"
+	REGEX_HOST_KEY = `^(\s*(?:-\s+)?host\s*:\s*)(["']?)([\w.-]+)(["']?\s*(?:#.*)?)$`
+	// e.g: "    machine1: host1" in block of variable
+	REGEX_VARIABLE_KEY   = `^(\s*[\w.-]+\s*:\s*)(["']?)([\w.-]+)(["']?\s*(?:#.*)?)$`
+	REGEX_VARIABLE_BLOCK = `^(\s*)variable\s*:\s*(?:#.*)?$`
+)
+
+type cloneOptions struct {
+	src         string
+	dst         string
+	hostsMap    []string
+	description string
+}
+
+func NewCloneCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options cloneOptions
+
+	cmd := &cobra.Command{
+		Use:     "clone SOURCE DESTINATION [OPTIONS]",
+		Short:   "Clone cluster from an existing cluster",
+		Args:    utils.ExactArgs(2),
+		Example: CLONE_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.src = args[0]
+			options.dst = args[1]
+			return runClone(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&options.hostsMap, "hosts-map", []string{}, "Specify hosts replaced in topology, e.g: old=new")
+	flags.StringVarP(&options.description, "description", "m", "", "Description for cluster")
+
+	return cmd
+}
+
+func parseHostsMap(items []string) (map[string]string, error) {
+	m := map[string]string{}
+	pattern := regexp.MustCompile(fmt.Sprintf("^%s$", REGEX_HOST_TOKEN))
+	for _, item := range items {
+		pair := strings.SplitN(item, "=", 2)
+		if len(pair) != 2 || !pattern.MatchString(pair[0]) || !pattern.MatchString(pair[1]) {
+			return nil, errno.ERR_INVALID_HOSTS_MAP.F("hosts map: %s", item)
+		}
+		m[pair[0]] = pair[1]
+	}
+	return m, nil
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+/*
+ * rewriteHosts replaces the hosts in topology according to hosts map,
+ * only the value of `host` and variables (e.g: `host: ${machine1}`) which
+ * equal to the whole host will be replaced, other items (e.g: paths or
+ * addresses in config) are kept as they are, e.g:
+ *   host1=test1 replaces "host: host1" but not "host: host10" or "data_dir: /data/host1"
+ */
+func rewriteHosts(data string, hostsMap map[string]string) string {
+	hostKey := regexp.MustCompile(REGEX_HOST_KEY)
+	variableKey := regexp.MustCompile(REGEX_VARIABLE_KEY)
+	variableBlock := regexp.MustCompile(REGEX_VARIABLE_BLOCK)
+	rewrite := func(pattern *regexp.Regexp, line string) string {
+		mu := pattern.FindStringSubmatch(line)
+		if len(mu) == 0 {
+			return line
+		} else if host, ok := hostsMap[mu[3]]; ok {
+			return mu[1] + mu[2] + host + mu[4]
+		}
+		return line
+	}
+
+	lines := strings.Split(data, "\n")
+	blockIndent := -1 // indent of `variable:` which we are in, -1 means not in block
+	for i, line := range lines {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		} else if blockIndent >= 0 && indentOf(line) <= blockIndent {
+			blockIndent = -1
+		}
+
+		if mu := variableBlock.FindStringSubmatch(line); len(mu) > 0 {
+			blockIndent = len(mu[1])
+		} else if blockIndent >= 0 {
+			lines[i] = rewrite(variableKey, line)
+		} else {
+			lines[i] = rewrite(hostKey, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func runClone(curveadm *cli.CurveAdm, options cloneOptions) error {
+	// 1) source cluster must exist and destination must not
+	storage := curveadm.Storage()
+	clusters, err := storage.GetClusters(options.src)
+	if err != nil {
+		return errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err)
+	} else if len(clusters) == 0 {
+		return errno.ERR_CLUSTER_NOT_FOUND.
+			F("cluster name: %s", options.src)
+	}
+	src := clusters[0]
+	clusters, err = storage.GetClusters(options.dst)
+	if err != nil {
+		return errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err)
+	} else if len(clusters) > 0 {
+		return errno.ERR_CLUSTER_ALREADY_EXIST.
+			F("cluster name: %s", options.dst)
+	}
+
+	// 2) rewrite hosts in topology
+	hostsMap, err := parseHostsMap(options.hostsMap)
+	if err != nil {
+		return err
+	}
+	data := rewriteHosts(src.Topology, hostsMap)
+
+	// 3) check topology
+	if len(data) > 0 {
+		dcs, err := curveadm.ParseTopologyData(data)
+		if err != nil {
+			return err
+		}
+		pb, err := genCheckTopologyPlaybook(curveadm, dcs, addOptions{})
+		if err != nil {
+			return err
+		}
+		err = pb.Run()
+		if err != nil {
+			return err
+		}
+	}
+
+	// 4) insert cluster into database
+	description := options.description
+	if len(description) == 0 {
+		description = fmt.Sprintf("cloned from %s", options.src)
+	}
+	err = storage.InsertCluster(options.dst, uuid.NewString(), description, data)
+	if err != nil {
+		return errno.ERR_INSERT_CLUSTER_FAILED.E(err)
+	}
+
+	// 5) print success prompt
+	curveadm.WriteOutln("Cloned cluster '%s' from '%s'", options.dst, options.src)
+	return nil
+}
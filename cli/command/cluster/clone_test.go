@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	topologyData = `
+global:
+  log_dir: /data/logs/host1
+  variable:
+    target: host1
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+  deploy:
+    - host: ${target}
+    - host: host10
+    - host: server-host1
+    - host: 10.0.0.1  # by address
+mds_services:
+  config:
+    etcd.address: 10.0.0.1:2379,10.0.0.10:2379
+`
+)
+
+func TestClone_ParseHostsMap(t *testing.T) {
+	assert := assert.New(t)
+	m, err := parseHostsMap([]string{"host1=test1", "10.0.0.1=10.1.0.1"})
+	assert.Nil(err)
+	assert.Equal(map[string]string{"host1": "test1", "10.0.0.1": "10.1.0.1"}, m)
+
+	for _, item := range []string{"host1", "host1=", "=test1", "host 1=test1"} {
+		_, err = parseHostsMap([]string{item})
+		assert.NotNil(err, item)
+	}
+}
+
+func TestClone_RewriteHosts(t *testing.T) {
+	assert := assert.New(t)
+	data := rewriteHosts(topologyData, map[string]string{
+		"host1":    "test1",
+		"10.0.0.1": "10.1.0.1",
+	})
+	assert.Contains(data, "target: test1")
+	assert.Contains(data, "host: host10")
+	assert.Contains(data, "host: server-host1")
+	assert.Contains(data, "host: 10.1.0.1  # by address")
+	assert.Contains(data, "listen.ip: ${service_host}")
+
+	// paths and addresses in config aren't host
+	assert.Contains(data, "log_dir: /data/logs/host1")
+	assert.Contains(data, "etcd.address: 10.0.0.1:2379,10.0.0.10:2379")
+}
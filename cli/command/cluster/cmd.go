@@ -40,9 +40,13 @@ func NewClusterCommand(curveadm *cli.CurveAdm) *cobra.Command {
 
 	cmd.AddCommand(
 		NewAddCommand(curveadm),
+		NewCloneCommand(curveadm),
 		NewCheckoutCommand(curveadm),
 		NewListCommand(curveadm),
 		NewRemoveCommand(curveadm),
+		NewProtectCommand(curveadm),
+		NewUnprotectCommand(curveadm),
+		NewUnlockCommand(curveadm),
 		// TODO(P1): enable export
 		//NewExportCommand(curveadm),
 		NewImportCommand(curveadm),
@@ -27,6 +27,7 @@ package cluster
 import (
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/errno"
+	st "github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/tui"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	log "github.com/opencurve/curveadm/pkg/log/glg"
@@ -67,8 +68,18 @@ func runList(curveadm *cli.CurveAdm, options listOptions) error {
 		return errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err)
 	}
 
-	// 2) display clusters
-	output := tui.FormatClusters(clusters, options.verbose)
+	// 2) get protections of clusters
+	protections, err := storage.GetClusterProtections()
+	if err != nil {
+		return errno.ERR_GET_CLUSTER_PROTECTION_FAILED.E(err)
+	}
+	id2protection := map[int]st.ClusterProtection{}
+	for _, protection := range protections {
+		id2protection[protection.ClusterId] = protection
+	}
+
+	// 3) display clusters
+	output := tui.FormatClusters(clusters, id2protection, options.verbose)
 	curveadm.WriteOut(output)
 	return nil
 }
@@ -0,0 +1,199 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cluster
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	PROTECT_EXAMPLE = `Examples:
+  $ curveadm cluster protect prod                  # Protect cluster 'prod' from destructive operations
+  $ curveadm cluster unlock prod                   # Allow clean/remove of cluster 'prod' in next 10 minutes
+  $ curveadm cluster unlock prod --duration 1h     # Allow clean/remove of cluster 'prod' in next 1 hour
+  $ curveadm cluster unprotect prod                # Remove protection of cluster 'prod'`
+
+	DEFAULT_UNLOCK_DURATION = 10 * time.Minute
+)
+
+type protectOptions struct {
+	clusterName string
+	duration    time.Duration
+}
+
+func NewProtectCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options protectOptions
+
+	cmd := &cobra.Command{
+		Use:     "protect CLUSTER",
+		Short:   "Protect cluster from destructive operations",
+		Args:    cliutil.ExactArgs(1),
+		Example: PROTECT_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.clusterName = args[0]
+			return runProtect(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func NewUnprotectCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options protectOptions
+
+	cmd := &cobra.Command{
+		Use:     "unprotect CLUSTER",
+		Short:   "Remove protection of cluster",
+		Args:    cliutil.ExactArgs(1),
+		Example: PROTECT_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.clusterName = args[0]
+			return runUnprotect(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func NewUnlockCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options protectOptions
+
+	cmd := &cobra.Command{
+		Use:     "unlock CLUSTER [OPTIONS]",
+		Short:   "Allow clean/remove of protected cluster for a while",
+		Args:    cliutil.ExactArgs(1),
+		Example: PROTECT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if options.duration <= 0 {
+				return errno.ERR_INVALID_UNLOCK_DURATION
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.clusterName = args[0]
+			return runUnlock(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.DurationVar(&options.duration, "duration", DEFAULT_UNLOCK_DURATION, "Specify how long the cluster keeps unlocked")
+
+	return cmd
+}
+
+func getCluster(curveadm *cli.CurveAdm, clusterName string) (storage.Cluster, error) {
+	clusters, err := curveadm.Storage().GetClusters(clusterName)
+	if err != nil {
+		return storage.Cluster{}, errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err)
+	} else if len(clusters) == 0 {
+		return storage.Cluster{}, errno.ERR_CLUSTER_NOT_FOUND.
+			F("cluster name: %s", clusterName)
+	}
+	return clusters[0], nil
+}
+
+func getProtectedCluster(curveadm *cli.CurveAdm, clusterName string) (storage.Cluster, error) {
+	cluster, err := getCluster(curveadm, clusterName)
+	if err != nil {
+		return cluster, err
+	}
+	protection, err := curveadm.GetClusterProtection(cluster.Id)
+	if err != nil {
+		return cluster, err
+	} else if protection == nil {
+		return cluster, errno.ERR_CLUSTER_NOT_PROTECTED.
+			F("cluster name: %s", clusterName)
+	}
+	return cluster, nil
+}
+
+func runProtect(curveadm *cli.CurveAdm, options protectOptions) error {
+	cluster, err := getCluster(curveadm, options.clusterName)
+	if err != nil {
+		return err
+	}
+	protection, err := curveadm.GetClusterProtection(cluster.Id)
+	if err != nil {
+		return err
+	} else if protection != nil {
+		curveadm.WriteOutln("Cluster '%s' is already protected", cluster.Name)
+		return nil
+	}
+
+	err = curveadm.Storage().InsertClusterProtection(cluster.Id)
+	if err != nil {
+		return errno.ERR_SET_CLUSTER_PROTECTION_FAILED.E(err)
+	}
+	curveadm.WriteOutln(color.GreenString("Cluster '%s' is protected now"), cluster.Name)
+	return nil
+}
+
+func runUnprotect(curveadm *cli.CurveAdm, options protectOptions) error {
+	cluster, err := getProtectedCluster(curveadm, options.clusterName)
+	if err != nil {
+		return err
+	}
+
+	prompt := tui.PromptUnprotectCluster(cluster.Name)
+	if pass := curveadm.ConfirmCluster(cluster.Id, cluster.Name, prompt); !pass {
+		curveadm.WriteOutln(tui.PromptCancelOpetation("unprotect cluster"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+	err = curveadm.Storage().DeleteClusterProtection(cluster.Id)
+	if err != nil {
+		return errno.ERR_DELETE_CLUSTER_PROTECTION_FAILED.E(err)
+	}
+	curveadm.WriteOutln("Removed protection of cluster '%s'", cluster.Name)
+	return nil
+}
+
+func runUnlock(curveadm *cli.CurveAdm, options protectOptions) error {
+	cluster, err := getProtectedCluster(curveadm, options.clusterName)
+	if err != nil {
+		return err
+	}
+
+	prompt := tui.PromptUnlockCluster(cluster.Name, options.duration)
+	if pass := curveadm.ConfirmCluster(cluster.Id, cluster.Name, prompt); !pass {
+		curveadm.WriteOutln(tui.PromptCancelOpetation("unlock cluster"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+	until := time.Now().Add(options.duration)
+	err = curveadm.Storage().SetClusterUnlockUntil(cluster.Id, until.Unix())
+	if err != nil {
+		return errno.ERR_SET_CLUSTER_PROTECTION_FAILED.E(err)
+	}
+	curveadm.WriteOutln(color.YellowString("Cluster '%s' is unlocked until %s"),
+		cluster.Name, until.Format("2006-01-02 15:04:05"))
+	return nil
+}
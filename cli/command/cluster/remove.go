@@ -95,16 +95,27 @@ func runRemove(curveadm *cli.CurveAdm, options removeOptions) error {
 	}
 
 	// 2) remove cluster
-	//   2.1): check wether all services removed (ignore by force)
-	//   2.2): confirm by user
-	//   2.3): delete cluster in database
-	if err := checkAllServicesRemoved(curveadm, options, clusters[0].Id); err != nil {
+	//   2.1): check wether cluster unlocked if it's protected
+	//   2.2): check wether all services removed (ignore by force)
+	//   2.3): confirm by user
+	//   2.4): delete cluster and its protection in database
+	clusterId := clusters[0].Id
+	prompt := tui.PromptRemoveCluster(clusterName)
+	if err := curveadm.CheckClusterUnlocked(clusterId, clusterName); err != nil {
 		return err
-	} else if pass := tui.ConfirmYes(tui.PromptRemoveCluster(clusterName)); !pass {
+	} else if err := checkAllServicesRemoved(curveadm, options, clusterId); err != nil {
+		return err
+	} else if pass := curveadm.ConfirmCluster(clusterId, clusterName, prompt); !pass {
 		curveadm.WriteOut(tui.PromptCancelOpetation("remove cluster"))
 		return errno.ERR_CANCEL_OPERATION
 	} else if err := curveadm.Storage().DeleteCluster(clusterName); err != nil {
 		return errno.ERR_DELETE_CLUSTER_FAILED.E(err)
+	} else if err := curveadm.Storage().DeleteClusterProtection(clusterId); err != nil {
+		return errno.ERR_DELETE_CLUSTER_PROTECTION_FAILED.E(err)
+	} else if err := curveadm.Storage().DeleteOperationDurations(clusterId); err != nil {
+		return errno.ERR_DELETE_OPERATION_DURATIONS_FAILED.E(err)
+	} else if err := curveadm.Storage().DeleteBackupSchedules(clusterId); err != nil {
+		return errno.ERR_DELETE_BACKUP_SCHEDULES_FAILED.E(err)
 	}
 
 	// 3) print success prompt
@@ -26,8 +26,14 @@ package command
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/artifacts"
+	"github.com/opencurve/curveadm/cli/command/backup"
+	"github.com/opencurve/curveadm/cli/command/check"
 	"github.com/opencurve/curveadm/cli/command/client"
 	"github.com/opencurve/curveadm/cli/command/cluster"
 	"github.com/opencurve/curveadm/cli/command/config"
@@ -35,9 +41,15 @@ import (
 	"github.com/opencurve/curveadm/cli/command/monitor"
 	"github.com/opencurve/curveadm/cli/command/pfs"
 	"github.com/opencurve/curveadm/cli/command/playground"
+	"github.com/opencurve/curveadm/cli/command/plugin"
+	"github.com/opencurve/curveadm/cli/command/secret"
+	"github.com/opencurve/curveadm/cli/command/snapshot"
 	"github.com/opencurve/curveadm/cli/command/target"
+	"github.com/opencurve/curveadm/cli/command/topology"
+	"github.com/opencurve/curveadm/cli/command/volume"
 	"github.com/opencurve/curveadm/internal/errno"
 	tools "github.com/opencurve/curveadm/internal/tools/upgrade"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -49,41 +61,100 @@ var curveadmExample = `Examples:
   $ curveadm stop                           # Stop current cluster service
   $ curveadm clean                          # Clean current cluster
   $ curveadm enter 6ff561598c6f             # Enter specified service container
-  $ curveadm -u                             # Upgrade curveadm itself to the latest version`
+  $ curveadm -u                             # Upgrade curveadm itself to the latest version
+  $ curveadm zstack ...                     # Run plugin 'curveadm-zstack' in plugin directory or $PATH`
+
+const (
+	ENV_CURVEADM_CLUSTER    = "CURVEADM_CLUSTER"
+	ENV_CURVEADM_ASSUME_YES = "CURVEADM_ASSUME_YES"
+
+	FLAG_ASSUME_YES = "assume-yes"
+)
 
 type rootOptions struct {
 	debug   bool
 	upgrade bool
+	cluster string
+	// answer 'yes' to all confirmations for pipelines
+	assumeYes bool
+	// accept artifacts which can't be verified by published checksum or signature
+	insecureSkipVerify bool
+	// hide progress bars
+	quiet bool
+	// print logs into stderr, 1 for info and 2 for debug
+	verbosity int
+	logFile   string
+}
+
+// AssumeYes returns true if --assume-yes specified in args or by environment,
+// it's used before parsing command line, e.g. skip prompting auto upgrade
+func AssumeYes(args []string) bool {
+	if yes, err := strconv.ParseBool(os.Getenv(ENV_CURVEADM_ASSUME_YES)); err == nil && yes {
+		return true
+	}
+	for _, arg := range args {
+		if arg == "--"+FLAG_ASSUME_YES || arg == "--"+FLAG_ASSUME_YES+"=true" {
+			return true
+		}
+	}
+	return false
 }
 
 func addSubCommands(cmd *cobra.Command, curveadm *cli.CurveAdm) {
 	cmd.AddCommand(
+		artifacts.NewArtifactsCommand(curveadm),   // curveadm artifacts ...
+		backup.NewBackupCommand(curveadm),         // curveadm backup ...
+		backup.NewRestoreCommand(curveadm),        // curveadm restore ...
+		check.NewCheckCommand(curveadm),           // curveadm check ...
 		client.NewClientCommand(curveadm),         // curveadm client
 		cluster.NewClusterCommand(curveadm),       // curveadm cluster ...
 		config.NewConfigCommand(curveadm),         // curveadm config ...
 		hosts.NewHostsCommand(curveadm),           // curveadm hosts ...
 		playground.NewPlaygroundCommand(curveadm), // curveadm playground ...
+		plugin.NewPluginCommand(curveadm),         // curveadm plugin ...
+		secret.NewSecretCommand(curveadm),         // curveadm secret ...
 		target.NewTargetCommand(curveadm),         // curveadm target ...
 		pfs.NewPFSCommand(curveadm),               // curveadm pfs ...
+		snapshot.NewSnapshotCommand(curveadm),     // curveadm snapshot ...
 		monitor.NewMonitorCommand(curveadm),       // curveadm monitor ...
+		topology.NewTopologyCommand(curveadm),     // curveadm topology ...
+		volume.NewVolumeCommand(curveadm),         // curveadm volume ...
 
-		NewAuditCommand(curveadm),      // curveadm audit
-		NewCleanCommand(curveadm),      // curveadm clean
-		NewCompletionCommand(curveadm), // curveadm completion
-		NewDeployCommand(curveadm),     // curveadm deploy
-		NewEnterCommand(curveadm),      // curveadm enter
-		NewExecCommand(curveadm),       // curveadm exec
-		NewFormatCommand(curveadm),     // curveadm format
-		NewMigrateCommand(curveadm),    // curveadm migrate
-		NewPrecheckCommand(curveadm),   // curveadm precheck
-		NewReloadCommand(curveadm),     // curveadm reload
-		NewRestartCommand(curveadm),    // curveadm restart
-		NewScaleOutCommand(curveadm),   // curveadm scale-out
-		NewStartCommand(curveadm),      // curveadm start
-		NewStatusCommand(curveadm),     // curveadm status
-		NewStopCommand(curveadm),       // curveadm stop
-		NewSupportCommand(curveadm),    // curveadm support
-		NewUpgradeCommand(curveadm),    // curveadm upgrade
+		NewApplyCommand(curveadm),         // curveadm apply
+		NewAuditCommand(curveadm),         // curveadm audit
+		NewBalanceStatusCommand(curveadm), // curveadm balance-status
+		NewBenchCommand(curveadm),         // curveadm bench
+		NewBotCommand(curveadm),           // curveadm bot
+		NewCertCommand(curveadm),          // curveadm cert
+		NewChunkserverCommand(curveadm),   // curveadm chunkserver ...
+		NewCleanCommand(curveadm),         // curveadm clean
+		NewCompletionCommand(curveadm),    // curveadm completion
+		NewDeployCommand(curveadm),        // curveadm deploy
+		NewDoctorCommand(curveadm),        // curveadm doctor
+		NewEnterCommand(curveadm),         // curveadm enter
+		NewExecCommand(curveadm),          // curveadm exec
+		NewExportCommand(curveadm),        // curveadm export
+		NewExporterCommand(curveadm),      // curveadm exporter
+		NewFormatCommand(curveadm),        // curveadm format
+		NewInitCommand(curveadm),          // curveadm init
+		NewLogsCommand(curveadm),          // curveadm logs
+		NewMigrateCommand(curveadm),       // curveadm migrate
+		NewPrecheckCommand(curveadm),      // curveadm precheck
+		NewReloadCommand(curveadm),        // curveadm reload
+		NewRestartCommand(curveadm),       // curveadm restart
+		NewRollbackCommand(curveadm),      // curveadm rollback
+		NewScaleInCommand(curveadm),       // curveadm scale-in
+		NewScaleOutCommand(curveadm),      // curveadm scale-out
+		NewServeCommand(curveadm),         // curveadm serve
+		NewStartCommand(curveadm),         // curveadm start
+		NewStatusCommand(curveadm),        // curveadm status
+		NewStopCommand(curveadm),          // curveadm stop
+		NewSupportCommand(curveadm),       // curveadm support
+		NewSupportBundleCommand(curveadm), // curveadm support-bundle
+		NewTopCommand(curveadm),           // curveadm top
+		NewUICommand(curveadm),            // curveadm ui
+		NewUpgradeCommand(curveadm),       // curveadm upgrade
+		NewWatchCommand(curveadm),         // curveadm watch
 		// commonly used shorthands
 		hosts.NewSSHCommand(curveadm),      // curveadm ssh
 		hosts.NewPlaybookCommand(curveadm), // curveadm playbook
@@ -114,25 +185,73 @@ func NewCurveAdmCommand(curveadm *cli.CurveAdm) *cobra.Command {
 			if options.debug {
 				return errno.List()
 			} else if options.upgrade {
-				return tools.Upgrade2Latest(cli.Version)
-			} else if len(args) == 0 {
-				return cliutil.ShowHelp(curveadm.Err())(cmd, args)
+				return tools.Upgrade2Latest(cli.Version, curveadm.Verifier())
+			}
+			return cliutil.ShowHelp(curveadm.Err())(cmd, args)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return nil
+			}
+			suggestions := ""
+			if names := cmd.SuggestionsFor(args[0]); len(names) > 0 {
+				suggestions = fmt.Sprintf("\nDid you mean '%s'?", strings.Join(names, "', '"))
+			}
+			return cliutil.NewUsageError(fmt.Errorf("curveadm: '%s' is not a curveadm command.%s\n"+
+				"See 'curveadm --help'", args[0], suggestions))
+		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// cobra validates required flags after this hook with an uncoded error
+			if err := cmd.ValidateRequiredFlags(); err != nil {
+				return cliutil.NewUsageError(err)
+			}
+			if options.insecureSkipVerify {
+				curveadm.Verifier().SetInsecureSkipVerify(true)
+			}
+			// reset for each command, the API server and bot run many commands in one process
+			tui.SetAssumeYes(options.assumeYes || AssumeYes(nil))
+			curveadm.SetQuiet(options.quiet)
+			verbosity := options.verbosity
+			if options.quiet {
+				verbosity = 0
+			}
+			if err := curveadm.SetLogOptions(verbosity, options.logFile); err != nil {
+				return err
 			}
 
-			return fmt.Errorf("curveadm: '%s' is not a curveadm command.\n"+
-				"See 'curveadm --help'", args[0])
+			// --cluster takes precedence over environment variable
+			if len(options.cluster) == 0 {
+				options.cluster = os.Getenv(ENV_CURVEADM_CLUSTER)
+			}
+			if len(options.cluster) == 0 {
+				return nil
+			}
+			return curveadm.SwitchCluster(options.cluster)
 		},
-		SilenceUsage:          true, // silence usage when an error occurs
-		DisableFlagsInUseLine: true,
+		SilenceUsage:               true, // silence usage when an error occurs
+		SuggestionsMinimumDistance: 2,
+		DisableFlagsInUseLine:      true,
 	}
 
 	cmd.Flags().BoolP("version", "v", false, "Print version information and quit")
 	cmd.PersistentFlags().BoolP("help", "h", false, "Print usage")
 	cmd.Flags().BoolVarP(&options.debug, "debug", "d", false, "Print debug information")
 	cmd.Flags().BoolVarP(&options.upgrade, "upgrade", "u", false, "Upgrade curveadm itself to the latest version")
+	cmd.PersistentFlags().StringVar(&options.cluster, "cluster", "",
+		fmt.Sprintf("Specify cluster to operate instead of current cluster (env: %s)", ENV_CURVEADM_CLUSTER))
+	cmd.PersistentFlags().BoolVar(&options.assumeYes, FLAG_ASSUME_YES, false,
+		fmt.Sprintf("Answer yes to all confirmations and never prompt, for pipelines (env: %s)", ENV_CURVEADM_ASSUME_YES))
+	cmd.PersistentFlags().BoolVar(&options.insecureSkipVerify, "insecure-skip-verify", false,
+		"Skip verifying checksums and signatures of downloaded scripts, packages and images")
+	cmd.PersistentFlags().BoolVarP(&options.quiet, "quiet", "q", false, "Hide progress bars, only display result and error")
+	cmd.PersistentFlags().CountVarP(&options.verbosity, "verbosity", "V",
+		"Print logs into stderr, -V for info and -VV for debug (e.g. remote commands)")
+	cmd.PersistentFlags().StringVar(&options.logFile, "log-file", "",
+		"Specify log file instead of ~/.curveadm/logs/curveadm-<time>.log")
 
 	addSubCommands(cmd, curveadm)
 	setupRootCommand(cmd, curveadm)
+	registerCompletions(cmd, curveadm)
 
 	return cmd
 }
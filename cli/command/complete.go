@@ -0,0 +1,263 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+/*
+ * dynamic completion of resource names which pulled from local database,
+ * e.g:
+ *
+ *   $ curveadm stop --id <TAB>
+ *   6ff561598c6f  -- chunkserver@host1
+ *   ...
+ *
+ * the shell invokes `curveadm __complete ...` for candidates, so these
+ * functions must be fast and never touch remote hosts.
+ */
+type completeFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+const (
+	COMPLETE_FLAG_CLUSTER        = "cluster"
+	COMPLETE_FLAG_HOST           = "host"
+	COMPLETE_FLAG_ID             = "id"
+	COMPLETE_FLAG_ROLE           = "role"
+	COMPLETE_FLAG_CHUNKSERVER_ID = "chunkserver-id"
+
+	// positional argument in usage, e.g: checkout CLUSTER
+	COMPLETE_ARG_CLUSTER = "CLUSTER"
+)
+
+// candidate is "value\tdescription", shell displays description beside value
+func candidate(value, description string) string {
+	if len(description) == 0 {
+		return value
+	}
+	return value + "\t" + description
+}
+
+// filterCandidates keeps candidates which start with prefix, the item before
+// last comma is kept for flags which accept list, e.g: --host host1,ho<TAB>
+func filterCandidates(candidates []string, toComplete string) []string {
+	head, prefix := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		head, prefix = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	out := []string{}
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) {
+			out = append(out, head+c)
+		}
+	}
+	return out
+}
+
+// switch cluster if --cluster specified in command line which being completed
+func completeSwitchCluster(curveadm *cli.CurveAdm, cmd *cobra.Command) {
+	name, err := cmd.Flags().GetString(COMPLETE_FLAG_CLUSTER)
+	if err == nil && len(name) > 0 && name != curveadm.ClusterName() {
+		curveadm.SwitchCluster(name)
+	}
+}
+
+func completeClusters(curveadm *cli.CurveAdm) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		clusters, err := curveadm.Storage().GetClusters("%")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		candidates := []string{}
+		for _, cluster := range clusters {
+			candidates = append(candidates, candidate(cluster.Name, cluster.Description))
+		}
+		return filterCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeHosts(curveadm *cli.CurveAdm) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(curveadm.Hosts()) == 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		hcs, err := hosts.ParseHosts(curveadm.Hosts())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		candidates := []string{}
+		groups := []string{}
+		seen := map[string]bool{}
+		for _, hc := range hcs {
+			candidates = append(candidates, candidate(hc.GetHost(), hc.GetHostname()))
+			for _, group := range hc.GetGroups() {
+				if !seen[group] {
+					seen[group] = true
+					groups = append(groups, group)
+				}
+			}
+		}
+		for _, group := range groups {
+			candidates = append(candidates, candidate("@"+group, "host group"))
+		}
+		return filterCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeServiceIds(curveadm *cli.CurveAdm) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completeSwitchCluster(curveadm, cmd)
+		services, err := curveadm.Storage().GetServices(curveadm.ClusterId())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		// describe service by its role and host if topology is parsable
+		descriptions := map[string]string{}
+		if dcs, err := curveadm.ParseTopology(); err == nil {
+			for _, dc := range dcs {
+				descriptions[curveadm.GetServiceId(dc.GetId())] =
+					fmt.Sprintf("%s@%s", dc.GetRole(), dc.GetHost())
+			}
+		}
+		candidates := []string{}
+		for _, service := range services {
+			candidates = append(candidates, candidate(service.Id, descriptions[service.Id]))
+		}
+		return filterCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeRoles(curveadm *cli.CurveAdm) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completeSwitchCluster(curveadm, cmd)
+		roles := topology.MIXED_ROLES
+		if dcs, err := curveadm.ParseTopology(); err == nil && len(dcs) > 0 {
+			switch topology.GetKind(dcs) {
+			case topology.KIND_CURVEBS:
+				roles = topology.CURVEBS_ROLES
+			case topology.KIND_CURVEFS:
+				roles = topology.CURVEFS_ROLES
+			}
+		}
+		return filterCandidates(roles, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// chunkserver ids are recorded when chunkserver loads collected (e.g. balance-status)
+func completeChunkserverIds(curveadm *cli.CurveAdm) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completeSwitchCluster(curveadm, cmd)
+		data, err := curveadm.Storage().GetChunkservers(curveadm.ClusterId())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		} else if len(data) == 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		loads := []bs.ChunkserverLoad{}
+		if err := json.Unmarshal([]byte(data), &loads); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		// --host narrows chunkservers down to the ones on it
+		host, _ := cmd.Flags().GetString(COMPLETE_FLAG_HOST)
+		addrs := map[string]bool{}
+		if len(host) > 0 {
+			if dcs, err := curveadm.ParseTopology(); err == nil {
+				for _, dc := range curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_CHUNKSERVER) {
+					if dc.GetHost() == host {
+						addrs[cliutil.JoinHostPort(dc.GetListenIp(), dc.GetListenPort())] = true
+					}
+				}
+			}
+		}
+
+		sort.Slice(loads, func(i, j int) bool { return loads[i].Id < loads[j].Id })
+		candidates := []string{}
+		for _, load := range loads {
+			if len(host) > 0 && !addrs[load.Addr] {
+				continue
+			}
+			candidates = append(candidates, candidate(strconv.Itoa(load.Id), load.Addr))
+		}
+		return filterCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// registerCompletions walks all commands and registers completion for
+// resource flags and the CLUSTER argument
+func registerCompletions(root *cobra.Command, curveadm *cli.CurveAdm) {
+	flags := map[string]completeFunc{
+		COMPLETE_FLAG_HOST:           completeHosts(curveadm),
+		COMPLETE_FLAG_ID:             completeServiceIds(curveadm),
+		COMPLETE_FLAG_ROLE:           completeRoles(curveadm),
+		COMPLETE_FLAG_CHUNKSERVER_ID: completeChunkserverIds(curveadm),
+	}
+	root.RegisterFlagCompletionFunc(COMPLETE_FLAG_CLUSTER, completeClusters(curveadm))
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for name, fn := range flags {
+			// monitor services have their own ids and roles
+			if cmd.HasParent() && cmd.Parent().Name() == "monitor" && name != COMPLETE_FLAG_HOST {
+				continue
+			} else if cmd.Flags().Lookup(name) != nil {
+				cmd.RegisterFlagCompletionFunc(name, fn)
+			}
+		}
+		usage := strings.Fields(cmd.Use)
+		if len(usage) > 1 && usage[1] == COMPLETE_ARG_CLUSTER && cmd.ValidArgsFunction == nil {
+			complete := completeClusters(curveadm)
+			cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				if len(args) > 0 {
+					return nil, cobra.ShellCompDirectiveNoFileComp
+				}
+				return complete(cmd, args, toComplete)
+			}
+		}
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(root)
+}
+
+// IsCompletionRequest returns true if the shell is requesting completion
+func IsCompletionRequest(args []string) bool {
+	return len(args) > 0 &&
+		(args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd)
+}
@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterCandidates(t *testing.T) {
+	assert := assert.New(t)
+
+	candidates := []string{
+		candidate("host1", "10.0.0.1"),
+		candidate("host2", "10.0.0.2"),
+		candidate("@rack1", "host group"),
+	}
+	assert.Equal(candidates, filterCandidates(candidates, ""))
+	assert.Equal([]string{"@rack1\thost group"}, filterCandidates(candidates, "@"))
+	assert.Equal([]string{"host1,host2\t10.0.0.2"}, filterCandidates(candidates, "host1,host2"))
+	assert.Equal([]string{}, filterCandidates(candidates, "host3"))
+	assert.Equal("mds", candidate("mds", ""))
+}
+
+func TestIsCompletionRequest(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(IsCompletionRequest([]string{cobra.ShellCompRequestCmd, "stop", "--id", ""}))
+	assert.True(IsCompletionRequest([]string{cobra.ShellCompNoDescRequestCmd, "stop"}))
+	assert.False(IsCompletionRequest([]string{"stop"}))
+	assert.False(IsCompletionRequest(nil))
+}
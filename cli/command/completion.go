@@ -29,6 +29,7 @@ import (
 	"os"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -36,7 +37,10 @@ func NewCompletionCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var completionCmd = &cobra.Command{
 		Use:   "completion [bash|zsh|fish|powershell]",
 		Short: "Generate completion script",
-		Long: fmt.Sprintf(`To load completions:
+		Long: fmt.Sprintf(`Cluster names, hosts, service ids and chunkserver ids are completed
+from local database, chunkserver ids are recorded by balance-status.
+
+To load completions:
 
 Bash:
 
@@ -77,7 +81,9 @@ PowerShell:
 `, "curveadm"),
 		DisableFlagsInUseLine: true,
 		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
-		Args:                  cobra.ExactValidArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			return cliutil.NewUsageError(cobra.ExactValidArgs(1)(cmd, args))
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			switch args[0] {
 			case "bash":
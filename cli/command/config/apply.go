@@ -0,0 +1,328 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package config
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	APPLY_EXAMPLE = `Examples:
+  $ curveadm config apply -f /path/to/topology.yaml  # Apply changed config and restart affected services
+  $ curveadm config apply --rollback                 # Rollback to the config before last apply`
+
+	APPLY_HEALTH_CHECK_INTERVAL = 5 * time.Second
+)
+
+var (
+	APPLY_PLAYBOOK_STEPS = []int{
+		playbook.SYNC_CONFIG,
+		playbook.RESTART_SERVICE,
+	}
+
+	// these config can't take effect by restarting service
+	APPLY_DENIED_ITEMS = map[string]string{
+		topology.CONFIG_CONTAINER_IMAGE.Key(): "curveadm upgrade",
+		topology.CONFIG_LOG_DIR.Key():         "curveadm migrate",
+		topology.CONFIG_DATA_DIR.Key():        "curveadm migrate",
+		topology.CONFIG_CORE_DIR.Key():        "curveadm migrate",
+		topology.CONFIG_LISTEN_IP.Key():       "curveadm migrate",
+		topology.CONFIG_LISTEN_PORT.Key():     "curveadm migrate",
+		// resources are applied while creating container
+		topology.CONFIG_RESOURCES_CPUS.Key():          "curveadm upgrade",
+		topology.CONFIG_RESOURCES_MEMORY.Key():        "curveadm upgrade",
+		topology.CONFIG_RESOURCES_CPUSET_CPUS.Key():   "curveadm upgrade",
+		topology.CONFIG_RESOURCES_CGROUP_PARENT.Key(): "curveadm upgrade",
+	}
+)
+
+type applyOptions struct {
+	filename      string
+	rollback      bool
+	healthTimeout time.Duration
+	yes           bool
+}
+
+type serviceChange struct {
+	dc      *topology.DeployConfig
+	changes []topology.ConfigChange
+}
+
+func checkApplyOptions(options applyOptions) error {
+	if len(options.filename) > 0 && options.rollback {
+		return errno.ERR_INVALID_CONFIG_APPLY_OPTIONS.
+			F("-f and --rollback can't be specified at the same time")
+	} else if len(options.filename) == 0 && !options.rollback {
+		return errno.ERR_INVALID_CONFIG_APPLY_OPTIONS.
+			F("-f or --rollback must be specified")
+	} else if options.healthTimeout <= 0 {
+		return errno.ERR_INVALID_CONFIG_APPLY_OPTIONS.
+			F("--health-timeout requires a positive duration")
+	}
+	return nil
+}
+
+func NewApplyCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options applyOptions
+
+	cmd := &cobra.Command{
+		Use:     "apply -f TOPOLOGY [OPTIONS]",
+		Short:   "Apply changed config and restart affected services",
+		Args:    utils.NoArgs,
+		Example: APPLY_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkApplyOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.filename, "filename", "f", "", "Specify the topology file")
+	flags.BoolVar(&options.rollback, "rollback", false, "Rollback to the topology before last apply")
+	flags.DurationVar(&options.healthTimeout, "health-timeout", 10*time.Minute, "Specify timeout for waiting services healthy")
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Apply config without confirmation")
+
+	return cmd
+}
+
+func readApplyTopology(curveadm *cli.CurveAdm, options applyOptions) (string, error) {
+	if !options.rollback {
+		return readTopology(curveadm, commitOptions{filename: options.filename, slient: true})
+	}
+
+	topologies, err := curveadm.Storage().GetPreviousTopologies(curveadm.ClusterId())
+	if err != nil {
+		return "", errno.ERR_GET_PREVIOUS_TOPOLOGY_FAILED.E(err)
+	} else if len(topologies) == 0 {
+		return "", errno.ERR_NO_PREVIOUS_TOPOLOGY_FOR_ROLLBACK
+	}
+	return topologies[0].Topology, nil
+}
+
+// getServiceChanges returns services which config changed, in deploy order
+func getServiceChanges(dcs1, dcs2 []*topology.DeployConfig) ([]serviceChange, error) {
+	old := map[string]*topology.DeployConfig{}
+	for _, dc := range dcs1 {
+		old[dc.GetId()] = dc
+	}
+
+	services := []serviceChange{}
+	for _, dc := range dcs2 {
+		dc1, ok := old[dc.GetId()]
+		if !ok {
+			continue
+		}
+		changes := topology.DiffConfig(dc1, dc)
+		if len(changes) == 0 {
+			continue
+		}
+		for _, change := range changes {
+			if command, ok := APPLY_DENIED_ITEMS[change.Key]; ok {
+				return nil, errno.ERR_CHANGE_CONFIG_WHILE_APPLY_IS_DENIED.
+					F("%s: %s (please use '%s')", dc.GetId(), change.Key, command)
+			}
+		}
+		services = append(services, serviceChange{dc: dc, changes: changes})
+	}
+	return services, nil
+}
+
+func displayServiceChanges(curveadm *cli.CurveAdm, services []serviceChange) {
+	curveadm.WriteOutln(color.YellowString("Config of %d services changed, restart them one by one:", len(services)))
+	for _, service := range services {
+		dc := service.dc
+		curveadm.WriteOutln("")
+		curveadm.WriteOutln("  * host=%s  role=%s  id=%s",
+			dc.GetHost(), dc.GetRole(), curveadm.GetServiceId(dc.GetId()))
+		for _, change := range service.changes {
+			if len(change.Old) > 0 {
+				curveadm.WriteOutln(color.RedString("    - %s: %s", change.Key, change.Old))
+			}
+			if len(change.New) > 0 {
+				curveadm.WriteOutln(color.GreenString("    + %s: %s", change.Key, change.New))
+			}
+		}
+	}
+	curveadm.WriteOutln("")
+}
+
+func genApplyPlaybook(curveadm *cli.CurveAdm, dc *topology.DeployConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range APPLY_PLAYBOOK_STEPS {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: []*topology.DeployConfig{dc},
+		})
+	}
+	return pb
+}
+
+// the restarted service should be alive and all copysets should be healthy
+func isServiceHealthy(probes []task.HealthProbe, serviceId string) bool {
+	alive := false
+	for _, probe := range probes {
+		switch probe.Probe {
+		case task.HEALTH_PROBE_LIVENESS:
+			if probe.Target == serviceId {
+				alive = probe.Status == task.HEALTH_STATUS_OK
+			}
+		case task.HEALTH_PROBE_COPYSET:
+			if probe.Status != task.HEALTH_STATUS_OK {
+				return false
+			}
+		}
+	}
+	return alive
+}
+
+func waitServiceHealthy(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	dc *topology.DeployConfig,
+	timeout time.Duration) error {
+	// copyset health is probed by the first mds
+	probeDcs := []*topology.DeployConfig{dc}
+	mds := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)
+	if len(mds) > 0 && mds[0].GetId() != dc.GetId() {
+		probeDcs = append(probeDcs, mds[0])
+	}
+	serviceId := curveadm.GetServiceId(dc.GetId())
+
+	curveadm.WriteOut("Waiting service %s healthy...", serviceId)
+	deadline := time.Now().Add(timeout)
+	for {
+		curveadm.MemStorage().Set(comm.KEY_ALL_HEALTH_PROBES, nil)
+		pb := playbook.NewPlaybook(curveadm)
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.PROBE_SERVICE_HEALTH,
+			Configs: probeDcs,
+			ExecOptions: playbook.ExecOptions{
+				SilentMainBar: true,
+				SilentSubBar:  true,
+				SkipError:     true,
+			},
+		})
+		pb.Run()
+
+		probes := []task.HealthProbe{}
+		if v := curveadm.MemStorage().Get(comm.KEY_ALL_HEALTH_PROBES); v != nil {
+			probes = v.([]task.HealthProbe)
+		}
+		if isServiceHealthy(probes, serviceId) {
+			curveadm.WriteOutln(color.GreenString(" OK"))
+			return nil
+		} else if time.Now().After(deadline) {
+			curveadm.WriteOutln(color.RedString(" TIMEOUT"))
+			return errno.ERR_WAIT_SERVICES_HEALTHY_TIMEOUT.
+				F("timeout: %s", timeout)
+		}
+		time.Sleep(APPLY_HEALTH_CHECK_INTERVAL)
+	}
+}
+
+func runApply(curveadm *cli.CurveAdm, options applyOptions) error {
+	// 1) parse cluster topology
+	dcs1, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) read topology from file, OR the previous one
+	data, err := readApplyTopology(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) check topology: adding or deleting service is denied
+	err = checkDiff(curveadm, data)
+	if err != nil {
+		return err
+	}
+	dcs2, err := curveadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+
+	// 4) compute services which config changed
+	services, err := getServiceChanges(dcs1, dcs2)
+	if err != nil {
+		return err
+	} else if len(services) == 0 {
+		curveadm.WriteOutln("No service config changed")
+	} else {
+		displayServiceChanges(curveadm, services)
+	}
+
+	// 5) confirm by user
+	if !options.yes {
+		if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+			curveadm.WriteOutln(tui.PromptCancelOpetation("apply config"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 6) record current topology for rollback, then update it
+	oldData := curveadm.ClusterTopologyData()
+	err = curveadm.Storage().SetPreviousTopology(curveadm.ClusterId(), oldData)
+	if err != nil {
+		return errno.ERR_SET_PREVIOUS_TOPOLOGY_FAILED.E(err)
+	}
+	err = curveadm.Storage().SetClusterTopology(curveadm.ClusterId(), data)
+	if err != nil {
+		return errno.ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED.E(err)
+	}
+
+	// 7) restart affected services one by one
+	for i, service := range services {
+		curveadm.WriteOutln(color.YellowString("[%d/%d] Restart service %s",
+			i+1, len(services), curveadm.GetServiceId(service.dc.GetId())))
+		err = genApplyPlaybook(curveadm, service.dc).Run()
+		if err == nil {
+			err = waitServiceHealthy(curveadm, dcs2, service.dc, options.healthTimeout)
+		}
+		if err != nil {
+			curveadm.WriteOutln(color.YellowString("NOTICE: run 'curveadm config apply --rollback' " +
+				"to rollback config"))
+			return err
+		}
+	}
+
+	// 8) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Config of cluster '%s' successfully applied ^_^.",
+		curveadm.ClusterName()))
+	return nil
+}
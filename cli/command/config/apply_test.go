@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	APPLY_TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: opencurvedocker/curvebs:v1.2
+  log_dir: /data/logs/${service_role}
+  data_dir: /data/${service_role}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 8200
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+)
+
+func parseApplyTopology(t *testing.T, data string) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	for _, host := range []string{"host1", "host2", "host3"} {
+		ctx.Add(host, host)
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func TestApply_GetServiceChanges(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseApplyTopology(t, APPLY_TOPOLOGY)
+
+	// nothing changed
+	services, err := getServiceChanges(dcs, parseApplyTopology(t, APPLY_TOPOLOGY))
+	assert.Nil(err)
+	assert.Len(services, 0)
+
+	// add config item for etcd and chunkserver in host2
+	data := strings.Replace(APPLY_TOPOLOGY, "    - host: host2\n    - host: host3\n",
+		"    - host: host2\n      config:\n        copyset.scan_interval_sec: 10\n    - host: host3\n", 2)
+	services, err = getServiceChanges(dcs, parseApplyTopology(t, data))
+	assert.Nil(err)
+	assert.Len(services, 2) // etcd and chunkserver
+	assert.Equal(topology.ROLE_ETCD, services[0].dc.GetRole())
+	assert.Equal(topology.ROLE_CHUNKSERVER, services[1].dc.GetRole())
+	assert.Equal("host2", services[1].dc.GetHost())
+	assert.Equal([]topology.ConfigChange{
+		{Key: "copyset.scan_interval_sec", Old: "", New: "10"},
+	}, services[1].changes)
+
+	// change config item
+	data = strings.Replace(APPLY_TOPOLOGY, "listen.client_port: 2379", "listen.client_port: 2389", 1)
+	services, err = getServiceChanges(dcs, parseApplyTopology(t, data))
+	assert.Nil(err)
+	assert.Len(services, 3)
+	assert.Equal([]topology.ConfigChange{
+		{Key: "listen.client_port", Old: "2379", New: "2389"},
+	}, services[0].changes)
+}
+
+func TestApply_DeniedConfig(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseApplyTopology(t, APPLY_TOPOLOGY)
+
+	for _, data := range []string{
+		strings.Replace(APPLY_TOPOLOGY, "curvebs:v1.2", "curvebs:v1.3", 1),
+		strings.Replace(APPLY_TOPOLOGY, "data_dir: /data/", "data_dir: /data1/", 1),
+		strings.Replace(APPLY_TOPOLOGY, "listen.port: 8200", "listen.port: 8300", 1),
+	} {
+		_, err := getServiceChanges(dcs, parseApplyTopology(t, data))
+		assert.True(errors.Is(err, errno.ERR_CHANGE_CONFIG_WHILE_APPLY_IS_DENIED))
+	}
+}
+
+func TestApply_CheckApplyOptions(t *testing.T) {
+	assert := assert.New(t)
+	timeout := 10 * time.Minute
+
+	assert.Nil(checkApplyOptions(applyOptions{filename: "topology.yaml", healthTimeout: timeout}))
+	assert.Nil(checkApplyOptions(applyOptions{rollback: true, healthTimeout: timeout}))
+	assert.NotNil(checkApplyOptions(applyOptions{healthTimeout: timeout}))
+	assert.NotNil(checkApplyOptions(applyOptions{filename: "topology.yaml", rollback: true, healthTimeout: timeout}))
+	assert.NotNil(checkApplyOptions(applyOptions{filename: "topology.yaml"}))
+}
@@ -42,6 +42,8 @@ func NewConfigCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		NewShowCommand(curveadm),
 		NewDiffCommand(curveadm),
 		NewCommitCommand(curveadm),
+		NewApplyCommand(curveadm),
+		NewSetCommand(curveadm),
 	)
 	return cmd
 }
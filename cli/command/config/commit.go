@@ -202,7 +202,7 @@ func runCommit(curveadm *cli.CurveAdm, options commitOptions) error {
 	}
 
 	// 4) confirm by user
-	if pass := tui.ConfirmYes("Do you want to continue?"); !pass {
+	if pass := curveadm.Confirm("Do you want to continue?"); !pass {
 		curveadm.WriteOutln(tui.PromptCancelOpetation("commit topology"))
 		return errno.ERR_CANCEL_OPERATION
 	}
@@ -0,0 +1,440 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	SET_EXAMPLE = `Examples:
+  $ curveadm config set --role chunkserver copyset.scan_interval_sec=10                   # Set config and restart chunkservers one by one
+  $ curveadm config set --role chunkserver raft_sync=false --hot                          # Set config at runtime without restarting chunkservers
+  $ curveadm config set --role mds mds_enable_copyset_scheduler=false --hot --assume-yes  # Set config at runtime without confirmation`
+
+	// the value is quoted if it contains characters which have special meaning in YAML
+	YAML_SPECIAL_CHARACTERS = "#:{}[],&*!|>'\"%@`"
+)
+
+var (
+	// gflag name of brpc server, e.g: raft_sync
+	REGEX_GFLAG = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	SET_HOT_PLAYBOOK_STEPS = []int{
+		playbook.SYNC_CONFIG,
+		playbook.SET_SERVICE_FLAGS,
+	}
+)
+
+type (
+	setOptions struct {
+		role          string
+		items         []string
+		hot           bool
+		healthTimeout time.Duration
+	}
+
+	configItem struct {
+		key   string
+		value string
+	}
+
+	// setPlan tells how the changed config of service takes effect:
+	// the flags are set at runtime if it's not nil, otherwise restart service
+	setPlan struct {
+		serviceChange
+		flags map[string]string
+	}
+)
+
+func parseConfigItems(items []string) ([]configItem, error) {
+	out := []configItem{}
+	for _, item := range items {
+		pair := strings.SplitN(item, "=", 2)
+		if len(pair) != 2 || len(strings.TrimSpace(pair[0])) == 0 {
+			return nil, errno.ERR_INVALID_CONFIG_SET_OPTIONS.
+				F("invalid config item '%s', it must be in the format of key=value", item)
+		}
+
+		key := strings.TrimSpace(pair[0])
+		if command, ok := APPLY_DENIED_ITEMS[key]; ok {
+			return nil, errno.ERR_CHANGE_CONFIG_WHILE_APPLY_IS_DENIED.
+				F("%s (please use '%s')", key, command)
+		}
+		out = append(out, configItem{key: key, value: strings.TrimSpace(pair[1])})
+	}
+	return out, nil
+}
+
+func checkSetOptions(options setOptions) error {
+	if !utils.Slice2Map(topology.MIXED_ROLES)[options.role] {
+		return errno.ERR_INVALID_CONFIG_SET_OPTIONS.
+			F("--role must be one of %s", strings.Join(topology.MIXED_ROLES, ", "))
+	} else if options.healthTimeout <= 0 {
+		return errno.ERR_INVALID_CONFIG_SET_OPTIONS.
+			F("--health-timeout requires a positive duration")
+	}
+	_, err := parseConfigItems(options.items)
+	return err
+}
+
+func NewSetCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options setOptions
+
+	cmd := &cobra.Command{
+		Use:     "set KEY=VALUE [KEY=VALUE...] --role ROLE [OPTIONS]",
+		Short:   "Set config of role and make it take effect",
+		Args:    utils.RequiresMinArgs(1),
+		Example: SET_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.items = args
+			return checkSetOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.role, "role", "", "Specify service role")
+	flags.BoolVar(&options.hot, "hot", false, "Set gflags at runtime instead of restarting services")
+	flags.DurationVar(&options.healthTimeout, "health-timeout", 10*time.Minute, "Specify timeout for waiting services healthy")
+	cmd.MarkFlagRequired("role")
+
+	return cmd
+}
+
+func quoteConfigValue(value string) string {
+	if len(value) == 0 || strings.ContainsAny(value, YAML_SPECIAL_CHARACTERS) ||
+		strings.TrimSpace(value) != value {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+/*
+ * setTopologyConfig sets key of role's common config in topology text,
+ * the text is rewritten line by line to keep user's comments and order:
+ *
+ * chunkserver_services:
+ *   config:                 <- appended if not exist
+ *     listen.ip: ...
+ *     key: value            <- replaced if exist, otherwise appended
+ *   deploy:
+ *     ...
+ */
+func setTopologyConfig(data, role, key, value string) (string, error) {
+	section := regexp.MustCompile(fmt.Sprintf(`^%s_services\s*:`, role))
+	config := regexp.MustCompile(`^(\s*)config\s*:\s*(#.*)?$`)
+	item := regexp.MustCompile(fmt.Sprintf(`^(\s*)%s\s*:`, regexp.QuoteMeta(key)))
+	indentOf := func(line string) int { return len(line) - len(strings.TrimLeft(line, " ")) }
+	isContent := func(line string) bool {
+		trimed := strings.TrimSpace(line)
+		return len(trimed) > 0 && trimed[0] != '#'
+	}
+
+	lines := strings.Split(data, "\n")
+	start := -1
+	for i, line := range lines {
+		if section.MatchString(line) {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return "", errno.ERR_REWRITE_TOPOLOGY_FOR_CONFIG_SET_FAILED.
+			F("%s_services not found", role)
+	}
+
+	// find the config block of section
+	sectionIndent, configLine, itemIndent, insertAt := -1, -1, -1, start+1
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		if !isContent(line) {
+			continue
+		}
+		indent := indentOf(line)
+		if indent == 0 {
+			break
+		} else if sectionIndent == -1 {
+			sectionIndent = indent
+		}
+
+		if configLine == -1 {
+			if mu := config.FindStringSubmatch(line); mu != nil && len(mu[1]) == sectionIndent {
+				configLine, insertAt = i, i+1
+			}
+			continue
+		} else if indent <= sectionIndent {
+			break
+		}
+
+		if itemIndent == -1 {
+			itemIndent = indent
+		}
+		if indent == itemIndent && item.MatchString(line) {
+			lines[i] = fmt.Sprintf("%s%s: %s", strings.Repeat(" ", indent), key, quoteConfigValue(value))
+			return strings.Join(lines, "\n"), nil
+		}
+		insertAt = i + 1
+	}
+
+	if sectionIndent == -1 {
+		sectionIndent = 2
+	}
+	if itemIndent == -1 {
+		itemIndent = sectionIndent + 2
+	}
+	added := []string{fmt.Sprintf("%s%s: %s", strings.Repeat(" ", itemIndent), key, quoteConfigValue(value))}
+	if configLine == -1 {
+		added = append([]string{strings.Repeat(" ", sectionIndent) + "config:"}, added...)
+	}
+	out := append([]string{}, lines[:insertAt]...)
+	out = append(out, added...)
+	out = append(out, lines[insertAt:]...)
+	return strings.Join(out, "\n"), nil
+}
+
+/*
+ * planSetServices decides how the changed config of services takes effect:
+ * with --hot, the service whose changed items are all gflags (e.g. raft_sync)
+ * and which serves brpc /flags endpoint sets them at runtime, the others
+ * (e.g. copyset.scan_interval_sec which only read from config file at
+ * startup) are restarted. The config file is synced in both ways, so the
+ * runtime values persist after restarting, except the gflags which aren't
+ * items of config file template, we warn user of them after setting.
+ */
+func planSetServices(services []serviceChange, hot bool) []setPlan {
+	plans := []setPlan{}
+	for _, service := range services {
+		plan := setPlan{serviceChange: service}
+		_, supported := task.ServiceFlagsAddr(service.dc)
+		if hot && supported {
+			flags := map[string]string{}
+			for _, change := range service.changes {
+				if !REGEX_GFLAG.MatchString(change.Key) || len(change.New) == 0 {
+					flags = nil
+					break
+				}
+				flags[change.Key] = change.New
+			}
+			plan.flags = flags
+		}
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+func displaySetPlans(curveadm *cli.CurveAdm, plans []setPlan) {
+	curveadm.WriteOutln(color.YellowString("Config of %d services changed:", len(plans)))
+	for _, plan := range plans {
+		dc := plan.dc
+		how := color.GreenString("set at runtime")
+		if plan.flags == nil {
+			how = color.YellowString("restart")
+		}
+		curveadm.WriteOutln("")
+		curveadm.WriteOutln("  * host=%s  role=%s  id=%s  (%s)",
+			dc.GetHost(), dc.GetRole(), curveadm.GetServiceId(dc.GetId()), how)
+		for _, change := range plan.changes {
+			if len(change.Old) > 0 {
+				curveadm.WriteOutln(color.RedString("    - %s: %s", change.Key, change.Old))
+			}
+			if len(change.New) > 0 {
+				curveadm.WriteOutln(color.GreenString("    + %s: %s", change.Key, change.New))
+			}
+		}
+	}
+	curveadm.WriteOutln("")
+}
+
+// setServiceFlags sets flags of services at runtime, it returns the
+// services which flags can't be set, they should be restarted instead
+func setServiceFlags(curveadm *cli.CurveAdm, plans []setPlan) ([]setPlan, error) {
+	dcs := []*topology.DeployConfig{}
+	flags := map[string]map[string]string{}
+	for _, plan := range plans {
+		dcs = append(dcs, plan.dc)
+		flags[curveadm.GetServiceId(plan.dc.GetId())] = plan.flags
+	}
+
+	curveadm.MemStorage().Set(comm.KEY_SERVICE_FLAGS, flags)
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_FLAGS_SET, nil)
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range SET_HOT_PLAYBOOK_STEPS {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: dcs,
+		})
+	}
+	if err := pb.Run(); err != nil {
+		return nil, err
+	}
+
+	results := []task.ServiceFlag{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_SERVICE_FLAGS_SET); v != nil {
+		results = v.([]task.ServiceFlag)
+	}
+	applied := map[string]int{}
+	curveadm.WriteOutln("")
+	for _, result := range results {
+		if result.Applied && !result.Persisted {
+			applied[result.Id]++
+			curveadm.WriteOutln(color.YellowString("  %s: %s=%s applied, but it's not an item of config file "+
+				"and will be lost after restarting", result.Id, result.Flag, result.Value))
+		} else if result.Applied {
+			applied[result.Id]++
+			curveadm.WriteOutln(color.GreenString("  %s: %s=%s applied", result.Id, result.Flag, result.Value))
+		} else {
+			curveadm.WriteOutln(color.RedString("  %s: %s=%s not applied: %s",
+				result.Id, result.Flag, result.Value, result.Message))
+		}
+	}
+	curveadm.WriteOutln("")
+
+	restarts := []setPlan{}
+	for _, plan := range plans {
+		if applied[curveadm.GetServiceId(plan.dc.GetId())] != len(plan.flags) {
+			plan.flags = nil
+			restarts = append(restarts, plan)
+		}
+	}
+	return restarts, nil
+}
+
+func runSet(curveadm *cli.CurveAdm, options setOptions) error {
+	// 1) parse cluster topology
+	dcs1, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	} else if len(curveadm.FilterDeployConfigByRole(dcs1, options.role)) == 0 {
+		return errno.ERR_INVALID_CONFIG_SET_OPTIONS.
+			F("no %s services in cluster topology", options.role)
+	}
+
+	// 2) rewrite config of role in topology
+	items, err := parseConfigItems(options.items)
+	if err != nil {
+		return err
+	}
+	data := curveadm.ClusterTopologyData()
+	for _, item := range items {
+		data, err = setTopologyConfig(data, options.role, item.key, item.value)
+		if err != nil {
+			return err
+		}
+	}
+	err = checkDiff(curveadm, data)
+	if err != nil {
+		return err
+	}
+	dcs2, err := curveadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+
+	// 3) compute services which config changed and how they take effect
+	services, err := getServiceChanges(dcs1, dcs2)
+	if err != nil {
+		return err
+	} else if len(services) == 0 {
+		curveadm.WriteOutln("No service config changed")
+		return nil
+	}
+	plans := planSetServices(services, options.hot)
+	displaySetPlans(curveadm, plans)
+
+	// 4) confirm by user
+	if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOutln(tui.PromptCancelOpetation("set config"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 5) record current topology for rollback, then update it
+	err = curveadm.Storage().SetPreviousTopology(curveadm.ClusterId(), curveadm.ClusterTopologyData())
+	if err != nil {
+		return errno.ERR_SET_PREVIOUS_TOPOLOGY_FAILED.E(err)
+	}
+	err = curveadm.Storage().SetClusterTopology(curveadm.ClusterId(), data)
+	if err != nil {
+		return errno.ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED.E(err)
+	}
+
+	// 6) set flags at runtime, fallback to restart if failed
+	hots, restarts := []setPlan{}, []setPlan{}
+	for _, plan := range plans {
+		if plan.flags != nil {
+			hots = append(hots, plan)
+		} else {
+			restarts = append(restarts, plan)
+		}
+	}
+	if len(hots) > 0 {
+		failed, err := setServiceFlags(curveadm, hots)
+		if err != nil {
+			curveadm.WriteOutln(color.YellowString("NOTICE: run 'curveadm config apply --rollback' " +
+				"to rollback config"))
+			return err
+		} else if len(failed) > 0 {
+			curveadm.WriteOutln(color.YellowString("%d services can't set config at runtime, restart them instead",
+				len(failed)))
+		}
+		restarts = append(restarts, failed...)
+	}
+
+	// 7) restart services one by one
+	for i, plan := range restarts {
+		curveadm.WriteOutln(color.YellowString("[%d/%d] Restart service %s",
+			i+1, len(restarts), curveadm.GetServiceId(plan.dc.GetId())))
+		err = genApplyPlaybook(curveadm, plan.dc).Run()
+		if err == nil {
+			err = waitServiceHealthy(curveadm, dcs2, plan.dc, options.healthTimeout)
+		}
+		if err != nil {
+			curveadm.WriteOutln(color.YellowString("NOTICE: run 'curveadm config apply --rollback' " +
+				"to rollback config"))
+			return err
+		}
+	}
+
+	// 8) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Config of %s services successfully set ^_^.", options.role))
+	return nil
+}
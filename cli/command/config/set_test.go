@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_SetTopologyConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	// replace existing item
+	data, err := setTopologyConfig(APPLY_TOPOLOGY, topology.ROLE_CHUNKSERVER, "listen.ip", "10.0.0.1")
+	assert.Nil(err)
+	assert.Equal(strings.Replace(APPLY_TOPOLOGY,
+		"listen.ip: ${service_host}\n    listen.port: 8200", "listen.ip: 10.0.0.1\n    listen.port: 8200", 1), data)
+
+	// append item to config block
+	data, err = setTopologyConfig(APPLY_TOPOLOGY, topology.ROLE_CHUNKSERVER, "raft_sync", "false")
+	assert.Nil(err)
+	assert.Equal(strings.Replace(APPLY_TOPOLOGY,
+		"    listen.port: 8200\n", "    listen.port: 8200\n    raft_sync: false\n", 1), data)
+	dcs := parseApplyTopology(t, data)
+	services, err := getServiceChanges(parseApplyTopology(t, APPLY_TOPOLOGY), dcs)
+	assert.Nil(err)
+	assert.Len(services, 3)
+	assert.Equal([]topology.ConfigChange{{Key: "raft_sync", Old: "", New: "false"}}, services[0].changes)
+
+	// append config block
+	data = strings.Replace(APPLY_TOPOLOGY, "  config:\n    listen.ip: ${service_host}\n    listen.port: 8200\n", "", 1)
+	data, err = setTopologyConfig(data, topology.ROLE_CHUNKSERVER, "listen.port", "8200")
+	assert.Nil(err)
+	assert.Contains(data, "chunkserver_services:\n  config:\n    listen.port: 8200\n  deploy:\n")
+
+	// quote special value
+	data, err = setTopologyConfig(APPLY_TOPOLOGY, topology.ROLE_ETCD, "listen.ip", "${service_host}")
+	assert.Nil(err)
+	assert.Contains(data, "    listen.ip: \"${service_host}\"\n    listen.port: 2380")
+
+	// role not found
+	_, err = setTopologyConfig(APPLY_TOPOLOGY, topology.ROLE_MDS, "raft_sync", "false")
+	assert.True(errors.Is(err, errno.ERR_REWRITE_TOPOLOGY_FOR_CONFIG_SET_FAILED))
+}
+
+func TestSet_PlanSetServices(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseApplyTopology(t, APPLY_TOPOLOGY)
+
+	gflag, _ := setTopologyConfig(APPLY_TOPOLOGY, topology.ROLE_CHUNKSERVER, "raft_sync", "false")
+	item, _ := setTopologyConfig(gflag, topology.ROLE_CHUNKSERVER, "copyset.scan_interval_sec", "10")
+	etcd, _ := setTopologyConfig(APPLY_TOPOLOGY, topology.ROLE_ETCD, "raft_sync", "false")
+	for _, c := range []struct {
+		data    string
+		hot     bool
+		runtime bool
+	}{
+		{gflag, true, true},
+		{gflag, false, false},
+		{item, true, false}, // copyset.scan_interval_sec requires restart
+		{etcd, true, false}, // etcd has no brpc /flags endpoint
+	} {
+		services, err := getServiceChanges(dcs, parseApplyTopology(t, c.data))
+		assert.Nil(err)
+		plans := planSetServices(services, c.hot)
+		assert.Len(plans, 3)
+		for _, plan := range plans {
+			assert.Equal(c.runtime, plan.flags != nil)
+		}
+		if c.runtime {
+			assert.Equal(map[string]string{"raft_sync": "false"}, plans[0].flags)
+		}
+	}
+}
+
+func TestSet_CheckSetOptions(t *testing.T) {
+	assert := assert.New(t)
+	timeout := 10 * time.Minute
+
+	assert.Nil(checkSetOptions(setOptions{role: "chunkserver", items: []string{"raft_sync=false"}, healthTimeout: timeout}))
+	assert.Nil(checkSetOptions(setOptions{role: "mds", items: []string{"a=b=c", "k="}, healthTimeout: timeout}))
+	assert.NotNil(checkSetOptions(setOptions{role: "unknown", items: []string{"raft_sync=false"}, healthTimeout: timeout}))
+	assert.NotNil(checkSetOptions(setOptions{role: "chunkserver", items: []string{"raft_sync"}, healthTimeout: timeout}))
+	assert.NotNil(checkSetOptions(setOptions{role: "chunkserver", items: []string{"=false"}, healthTimeout: timeout}))
+	assert.NotNil(checkSetOptions(setOptions{role: "chunkserver", items: []string{"raft_sync=false"}}))
+
+	// config which requires recreating container is denied
+	err := checkSetOptions(setOptions{role: "chunkserver", items: []string{"data_dir=/data1"}, healthTimeout: timeout})
+	assert.True(errors.Is(err, errno.ERR_CHANGE_CONFIG_WHILE_APPLY_IS_DENIED))
+}
@@ -113,13 +113,19 @@ var (
 )
 
 type deployOptions struct {
+	kind            string
 	skip            []string
 	insecure        bool
+	skipCompatCheck bool
 	poolset         string
 	poolsetDiskType string
 }
 
 func checkDeployOptions(options deployOptions) error {
+	if err := checkKindOption(options.kind); err != nil {
+		return err
+	}
+
 	supported := utils.Slice2Map(CAN_SKIP_ROLES)
 	for _, role := range options.skip {
 		if !supported[role] {
@@ -147,8 +153,10 @@ func NewDeployCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
+	flags.StringVar(&options.kind, "kind", "*", "Specify cluster kind of mixed topology (curvebs/curvefs)")
 	flags.StringSliceVar(&options.skip, "skip", []string{}, "Specify skipped service roles")
 	flags.BoolVarP(&options.insecure, "insecure", "k", false, "Deploy without precheck")
+	flags.BoolVar(&options.skipCompatCheck, "skip-compat-check", false, "Deploy even if images are unsupported by current curveadm")
 	flags.StringVar(&options.poolset, "poolset", "default", "Specify the poolset name")
 	flags.StringVar(&options.poolsetDiskType, "poolset-disktype", "ssd", "Specify the disk type of physical pool")
 
@@ -190,6 +198,7 @@ func precheckBeforeDeploy(curveadm *cli.CurveAdm,
 
 	// 2) generate precheck playbook
 	pb, err := genPrecheckPlaybook(curveadm, dcs, precheckOptions{
+		kind:              options.kind,
 		skipSnapshotClone: utils.Slice2Map(options.skip)[ROLE_SNAPSHOTCLONE],
 	})
 	if err != nil {
@@ -220,7 +229,7 @@ func genDeployPlaybook(curveadm *cli.CurveAdm,
 	dcs []*topology.DeployConfig,
 	options deployOptions) (*playbook.Playbook, error) {
 	var steps []int
-	kind := dcs[0].GetKind()
+	kind := dcs[0].GetKind() // the first service of part tells its kind
 	if kind == topology.KIND_CURVEBS {
 		steps = CURVEBS_DEPLOY_STEPS
 	} else {
@@ -241,6 +250,16 @@ func genDeployPlaybook(curveadm *cli.CurveAdm,
 			role := DEPLOY_FILTER_ROLE[step]
 			config = curveadm.FilterDeployConfigByRole(config, role)
 		}
+		if topology.IsMixed(dcs) && options.kind != topology.KIND_CURVEFS {
+			// the shared etcd of mixed topology is deployed by curvebs part,
+			// unless only the curvefs part is deployed
+			config = curveadm.FilterDeployConfig(config, topology.FilterOption{
+				Id: "*", Role: "*", Host: "*", Kind: kind,
+			})
+			if len(config) == 0 {
+				continue
+			}
+		}
 		n := len(config)
 		if DEPLOY_LIMIT_SERVICE[step] > 0 {
 			n = DEPLOY_LIMIT_SERVICE[step]
@@ -279,7 +298,7 @@ func serviceStats(dcs []*topology.DeployConfig) string {
 	count := statistics(dcs)
 	netcd := count[topology.ROLE_ETCD]
 	nmds := count[topology.ROLE_MDS]
-	nchunkserevr := count[topology.ROLE_METASERVER]
+	nchunkserevr := count[topology.ROLE_CHUNKSERVER]
 	nsnapshotclone := count[topology.ROLE_SNAPSHOTCLONE]
 	nmetaserver := count[topology.ROLE_METASERVER]
 
@@ -303,6 +322,17 @@ func displayDeployTitle(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) {
 	curveadm.WriteOutln("")
 }
 
+// select the parts of mixed topology which will be deployed
+func selectDeployParts(dcs []*topology.DeployConfig, options deployOptions) [][]*topology.DeployConfig {
+	parts := [][]*topology.DeployConfig{}
+	for _, part := range topology.SplitByKind(dcs) {
+		if options.kind == "*" || options.kind == part[0].GetKind() {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
 /*
  * Deploy Steps:
  *   1) pull image
@@ -327,24 +357,40 @@ func runDeploy(curveadm *cli.CurveAdm, options deployOptions) error {
 	// 2) skip service role
 	dcs = skipServiceRole(dcs, options)
 
-	// 3) precheck before deploy
+	// 3) check compatibility between curveadm and images
+	if !options.skipCompatCheck {
+		err = configure.CheckServicesCompatibility(cli.Version, dcs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 4) precheck before deploy
 	err = precheckBeforeDeploy(curveadm, dcs, options)
 	if err != nil {
 		return err
 	}
 
-	// 4) generate deploy playbook
-	pb, err := genDeployPlaybook(curveadm, dcs, options)
+	// 5) generate certificates for services if tls enabled
+	err = ensureCertificates(curveadm, dcs)
 	if err != nil {
 		return err
 	}
 
-	// 5) display title
-	displayDeployTitle(curveadm, dcs)
+	// 6) deploy each kind of mixed topology in turn, curvebs first
+	for i, part := range selectDeployParts(dcs, options) {
+		pb, err := genDeployPlaybook(curveadm, part, options)
+		if err != nil {
+			return err
+		}
 
-	// 6) run playground
-	if err = pb.Run(); err != nil {
-		return err
+		if i > 0 {
+			curveadm.WriteOutln("")
+		}
+		displayDeployTitle(curveadm, part)
+		if err = pb.Run(); err != nil {
+			return err
+		}
 	}
 
 	// 7) print success prompt
@@ -0,0 +1,205 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DOCTOR_EXAMPLE = `Examples:
+  $ curveadm doctor                        # Check environment of all hosts
+  $ curveadm doctor --skip swap,thp        # Check all items except swap and thp
+  $ curveadm doctor --format json          # Output results in JSON format
+  $ curveadm doctor --strict               # Treat warning as failure`
+
+	DOCTOR_FORMAT_TABLE = "table"
+	DOCTOR_FORMAT_JSON  = "json"
+)
+
+type doctorOptions struct {
+	skip   []string
+	format string
+	strict bool
+}
+
+func checkDoctorOptions(options doctorOptions) error {
+	supported := cliutil.Slice2Map(checker.DoctorItems())
+	for _, item := range options.skip {
+		if !supported[item] {
+			return errno.ERR_UNSUPPORT_SKIPPED_CHECK_ITEM.
+				F("skip item: %s", item)
+		}
+	}
+
+	if options.format != DOCTOR_FORMAT_TABLE && options.format != DOCTOR_FORMAT_JSON {
+		return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.
+			F("format: %s", options.format)
+	}
+	return nil
+}
+
+func NewDoctorCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options doctorOptions
+
+	cmd := &cobra.Command{
+		Use:     "doctor [OPTIONS]",
+		Short:   "Diagnose environment of all hosts in cluster",
+		Args:    cliutil.NoArgs,
+		Example: DOCTOR_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkDoctorOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	usage := fmt.Sprintf("Specify skipped check item (%s)", strings.Join(checker.DoctorItems(), ","))
+	flags.StringSliceVar(&options.skip, "skip", []string{}, usage)
+	flags.StringVar(&options.format, "format", DOCTOR_FORMAT_TABLE, "Output format (table/json)")
+	flags.BoolVar(&options.strict, "strict", false, "Exit with failure if any warning check failed")
+
+	return cmd
+}
+
+func genDoctorPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options doctorOptions) *playbook.Playbook {
+	silent := options.format == DOCTOR_FORMAT_JSON
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.DOCTOR_ENVIRONMENT,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_ALL_DEPLOY_CONFIGS:   dcs,
+			comm.KEY_DOCTOR_SKIPPED_ITEMS: options.skip,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SkipError:     true,
+			SilentMainBar: silent,
+			SilentSubBar:  silent,
+		},
+	})
+	return pb
+}
+
+// the host which can't be reached has no result, we add ssh failure for it
+// unless ssh check is skipped
+func collectDoctorResults(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options doctorOptions) []checker.DoctorResult {
+	results := []checker.DoctorResult{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_DOCTOR_RESULTS); v != nil {
+		results = v.([]checker.DoctorResult)
+	}
+	if cliutil.Slice2Map(options.skip)[checker.DOCTOR_ITEM_SSH] {
+		return results
+	}
+
+	checked := map[string]bool{}
+	for _, result := range results {
+		checked[result.Host] = true
+	}
+	for _, dc := range dcs {
+		host := dc.GetHost()
+		if checked[host] {
+			continue
+		}
+		checked[host] = true
+		results = append(results, checker.DoctorResult{
+			Host:     host,
+			Item:     checker.DOCTOR_ITEM_SSH,
+			Severity: checker.DOCTOR_SEVERITY_CRITICAL,
+			Status:   checker.DOCTOR_STATUS_FAIL,
+			Expect:   "connected",
+			Actual:   "unreachable",
+		})
+	}
+	return results
+}
+
+func runDoctor(curveadm *cli.CurveAdm, options doctorOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) generate doctor playbook and run it,
+	//    the error is returned after results displayed
+	pb := genDoctorPlaybook(curveadm, dcs, options)
+	runErr := pb.Run()
+
+	// 3) display results
+	results := collectDoctorResults(curveadm, dcs, options)
+	tui.SortDoctorResults(results)
+	if options.format == DOCTOR_FORMAT_JSON {
+		bytes, err := json.MarshalIndent(results, "", "    ")
+		if err != nil {
+			return errno.ERR_UNKNOWN.E(err)
+		}
+		curveadm.WriteOutln("%s", string(bytes))
+	} else {
+		curveadm.WriteOutln("")
+		curveadm.WriteOut("%s", tui.FormatDoctorResults(results))
+	}
+
+	// 4) exit code for CI
+	ncritical, nwarning := 0, 0
+	for _, result := range results {
+		if result.Status != checker.DOCTOR_STATUS_FAIL {
+			continue
+		} else if result.Severity == checker.DOCTOR_SEVERITY_CRITICAL {
+			ncritical++
+		} else {
+			nwarning++
+		}
+	}
+
+	if runErr != nil {
+		return runErr
+	} else if ncritical > 0 || (options.strict && nwarning > 0) {
+		return errno.ERR_DOCTOR_CHECK_FAILED.
+			F("critical failed: %d, warning failed: %d", ncritical, nwarning)
+	} else if options.format == DOCTOR_FORMAT_TABLE {
+		curveadm.WriteOutln("")
+		curveadm.WriteOutln("%s", color.GreenString("All critical checks passed (warning: %d)", nwarning))
+	}
+	return nil
+}
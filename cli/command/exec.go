@@ -26,27 +26,62 @@ import (
 	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
 	"github.com/opencurve/curveadm/internal/tools"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+const (
+	EXEC_EXAMPLE = `Examples:
+  $ curveadm exec 3c5e8a2b1f4d ls /curvebs/conf                  # Exec command in the specified service container
+  $ curveadm exec --host @rack1 --role chunkserver -- ls /tmp   # Exec command in all chunkserver containers of group rack1
+  $ curveadm exec --host @all -- uptime                         # Exec command on all hosts in parallel
+  $ curveadm exec --host @rack1,host4 --sudo -c 20 -- 'df -h'   # Exec command with sudo, at most 20 hosts at the same time`
+)
+
 type execOptions struct {
-	id  string
-	cmd string
+	id          string
+	role        string
+	host        string
+	cmd         string
+	onHost      bool // execute on hosts instead of service containers
+	sudo        bool
+	timeout     int
+	concurrency uint
 }
 
 func NewExecCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options execOptions
 
 	cmd := &cobra.Command{
-		Use:   "exec ID [OPTIONS]",
-		Short: "Exec a cmd in service container",
+		Use:     "exec [ID] [OPTIONS] CMD",
+		Short:   "Exec a cmd in service container",
+		Args:    cliutil.RequiresMinArgs(1),
+		Example: EXEC_EXAMPLE,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// exec in all services which matched by --role/--host
+			if options.role != "*" {
+				options.id = "*"
+				options.cmd = strings.Join(args, " ")
+				return checkCommonOptions(curveadm, options.id, options.role, options.host)
+			}
+
+			// exec on all hosts which matched by --host
+			if options.host != "*" {
+				options.onHost = true
+				options.cmd = strings.Join(args, " ")
+				_, err := curveadm.ExpandHosts(options.host)
+				return err
+			}
 			options.id = args[0]
 			options.cmd = strings.Join(args[1:], " ")
-			args = args[:1]
 			return curveadm.CheckId(options.id)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -55,15 +90,88 @@ func NewExecCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		DisableFlagsInUseLine: true,
 	}
 
+	flags := cmd.Flags()
+	flags.StringVar(&options.role, "role", "*", "Exec in all services of the specified role")
+	flags.StringVar(&options.host, "host", "*", "Exec on the specified hosts or host groups (e.g. @rack1), or in their services if --role specified")
+	flags.BoolVar(&options.sudo, "sudo", false, "Exec on hosts with sudo")
+	flags.IntVar(&options.timeout, "timeout", 0, "Specify the timeout seconds of executing on each host (default: no timeout)")
+	flags.UintVarP(&options.concurrency, "concurrency", "c", 10, "Specify the number of hosts executing at the same time")
+
 	return cmd
 }
 
+func genExecHostPlaybook(curveadm *cli.CurveAdm,
+	hcs []*hosts.HostConfig,
+	options execOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.EXEC_HOST_COMMAND,
+		Configs: hcs,
+		Options: map[string]interface{}{
+			comm.KEY_EXEC_HOST_OPTIONS: task.ExecHostOptions{
+				Command:    options.cmd,
+				Sudo:       options.sudo,
+				TimeoutSec: options.timeout,
+			},
+		},
+		ExecOptions: playbook.ExecOptions{
+			Concurrency:  options.concurrency,
+			SilentSubBar: true,
+		},
+	})
+	return pb
+}
+
+// exec on hosts:
+//  1. expand hosts
+//  2. run command on hosts in parallel
+//  3. print output of all hosts
+func runExecHosts(curveadm *cli.CurveAdm, options execOptions) error {
+	// 1) expand hosts
+	names, err := curveadm.ExpandHosts(options.host)
+	if err != nil {
+		return err
+	}
+	hcs := []*hosts.HostConfig{}
+	for _, name := range names {
+		hc, err := curveadm.GetHost(name)
+		if err != nil {
+			return err
+		}
+		hcs = append(hcs, hc)
+	}
+
+	// 2) run playbook, the output of reachable hosts should be printed even if some failed
+	runErr := genExecHostPlaybook(curveadm, hcs, options).Run()
+
+	// 3) print output
+	results := map[string]task.ExecHostResult{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_EXEC_HOST_RESULTS); v != nil {
+		results = v.(map[string]task.ExecHostResult)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatExecHostResults(names, results))
+	if runErr != nil {
+		return runErr
+	}
+	for _, name := range names {
+		if result, ok := results[name]; !ok || !result.Success {
+			return errno.ERR_EXEC_HOST_COMMAND_FAILED
+		}
+	}
+	return nil
+}
+
 // exec:
 //  1. parse cluster topology
 //  2. filter service
 //  3. get container id
 //  4. exec cmd in remote container
 func runExec(curveadm *cli.CurveAdm, options execOptions) error {
+	if options.onHost {
+		return runExecHosts(curveadm, options)
+	}
+
 	// 1) parse cluster topology
 	dcs, err := curveadm.ParseTopology()
 	if err != nil {
@@ -73,21 +181,29 @@ func runExec(curveadm *cli.CurveAdm, options execOptions) error {
 	// 2) filter service
 	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
 		Id:   options.id,
-		Role: "*",
-		Host: "*",
+		Role: options.role,
+		Host: options.host,
 	})
 	if len(dcs) == 0 {
 		return errno.ERR_NO_SERVICES_MATCHED
 	}
 
-	// 3) get container id
-	dc := dcs[0]
-	serviceId := curveadm.GetServiceId(dc.GetId())
-	containerId, err := curveadm.GetContainerId(serviceId)
-	if err != nil {
-		return err
-	}
+	for _, dc := range dcs {
+		// 3) get container id
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		containerId, err := curveadm.GetContainerId(serviceId)
+		if err != nil {
+			return err
+		}
 
-	// 4) exec cmd in remote container
-	return tools.ExecCmdInRemoteContainer(curveadm, dc.GetHost(), containerId, options.cmd)
+		// 4) exec cmd in remote container
+		if options.id == "*" {
+			curveadm.WriteOutln("==> %s %s (%s)", dc.GetHost(), dc.GetRole(), serviceId)
+		}
+		err = tools.ExecCmdInRemoteContainer(curveadm, dc.GetHost(), containerId, options.cmd)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
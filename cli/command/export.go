@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	EXPORT_EXAMPLE = `Examples:
+  $ curveadm export --format curve-operator                         # Print CurveCluster CR of current cluster
+  $ curveadm export --format curve-operator -c format.yaml          # Take disks of chunkservers from format configure
+  $ curveadm export --format curve-operator -o cluster.yaml         # Write CR into file
+  $ curveadm export --format curve-operator --namespace curve-test  # Specify kubernetes namespace of CR`
+
+	EXPORT_FORMAT_CURVE_OPERATOR = "curve-operator"
+)
+
+type exportOptions struct {
+	format    string
+	filename  string
+	namespace string
+	output    string
+}
+
+func NewExportCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options exportOptions
+
+	cmd := &cobra.Command{
+		Use:     "export --format FORMAT [OPTIONS]",
+		Short:   "Export cluster into manifests of other deployment tools",
+		Args:    cliutil.NoArgs,
+		Example: EXPORT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if options.format != EXPORT_FORMAT_CURVE_OPERATOR {
+				return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.
+					F("format: %s", options.format)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", EXPORT_FORMAT_CURVE_OPERATOR, "Specify export format (curve-operator)")
+	flags.StringVarP(&options.filename, "conf", "c", "", "Specify format configure file which contains disks of chunkservers")
+	flags.StringVar(&options.namespace, "namespace", configure.DEFAULT_OPERATOR_NAMESPACE, "Kubernetes namespace which cluster deployed in")
+	flags.StringVarP(&options.output, "output", "o", "", "Output to specified file instead of stdout")
+
+	return cmd
+}
+
+func runExport(curveadm *cli.CurveAdm, options exportOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) parse format configure for disks if specified
+	var fcs []*configure.FormatConfig
+	if len(options.filename) > 0 {
+		fcs, err = configure.ParseFormat(options.filename)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 3) generate custom resource of curve-operator
+	manifest, err := configure.GenOperatorManifest(dcs, configure.OperatorOptions{
+		Cluster:   curveadm.ClusterName(),
+		Namespace: options.namespace,
+		Formats:   fcs,
+	})
+	if err != nil {
+		return err
+	} else if len(options.output) == 0 {
+		curveadm.WriteOut("%s", manifest)
+		return nil
+	}
+
+	// 4) write manifest into file, it may contain s3 credentials
+	err = cliutil.WriteFile(options.output, manifest, 0600)
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	curveadm.WriteOutln(color.GreenString("Export cluster success, apply it by: kubectl apply -f %s"),
+		options.output)
+	return nil
+}
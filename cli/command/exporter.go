@@ -0,0 +1,315 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	EXPORTER_EXAMPLE = `Examples:
+  $ curveadm exporter                                 # Serve metrics on 0.0.0.0:9710/metrics
+  $ curveadm exporter --listen 127.0.0.1:9999         # Serve metrics on specified address
+  $ curveadm exporter --format-config format.yaml     # Export format progress of disks in format.yaml`
+
+	EXPORTER_NAMESPACE = "curveadm"
+	EXPORTER_PATH      = "/metrics"
+)
+
+type (
+	exporterOptions struct {
+		listen       string
+		interval     time.Duration
+		formatConfig string
+	}
+
+	exporterMetrics struct {
+		registry          *prometheus.Registry
+		clusterInfo       *prometheus.GaugeVec
+		clusterServices   *prometheus.GaugeVec
+		serviceUp         *prometheus.GaugeVec
+		formatProgress    *prometheus.GaugeVec
+		formatDone        *prometheus.GaugeVec
+		operationSuccess  *prometheus.GaugeVec
+		operationTime     *prometheus.GaugeVec
+		operationErrno    *prometheus.GaugeVec
+		lastRefreshTime   prometheus.Gauge
+		lastRefreshFailed prometheus.Gauge
+	}
+)
+
+func newGaugeVec(name, help string, labels ...string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: EXPORTER_NAMESPACE,
+		Name:      name,
+		Help:      help,
+	}, labels)
+}
+
+func newGauge(name, help string) prometheus.Gauge {
+	return prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: EXPORTER_NAMESPACE,
+		Name:      name,
+		Help:      help,
+	})
+}
+
+func newExporterMetrics() *exporterMetrics {
+	m := &exporterMetrics{
+		registry:          prometheus.NewRegistry(),
+		clusterInfo:       newGaugeVec("cluster_info", "Cluster managed by curveadm", "cluster", "uuid", "kind"),
+		clusterServices:   newGaugeVec("cluster_services", "Number of services in cluster", "cluster", "role"),
+		serviceUp:         newGaugeVec("service_up", "Whether the service container is running", "cluster", "id", "role", "host"),
+		formatProgress:    newGaugeVec("format_progress_ratio", "Progress of formatting chunkfile pool", "host", "device"),
+		formatDone:        newGaugeVec("format_done", "Whether formatting chunkfile pool is done", "host", "device"),
+		operationSuccess:  newGaugeVec("last_operation_success", "Whether the last operation succeeded", "operation"),
+		operationTime:     newGaugeVec("last_operation_timestamp_seconds", "Execute time of the last operation", "operation"),
+		operationErrno:    newGaugeVec("last_operation_error_code", "Error code of the last operation", "operation"),
+		lastRefreshTime:   newGauge("exporter_last_refresh_timestamp_seconds", "Time of the last refresh"),
+		lastRefreshFailed: newGauge("exporter_last_refresh_failed", "Whether the last refresh failed"),
+	}
+	m.registry.MustRegister(
+		m.clusterInfo,
+		m.clusterServices,
+		m.serviceUp,
+		m.formatProgress,
+		m.formatDone,
+		m.operationSuccess,
+		m.operationTime,
+		m.operationErrno,
+		m.lastRefreshTime,
+		m.lastRefreshFailed,
+	)
+	return m
+}
+
+func NewExporterCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options exporterOptions
+
+	cmd := &cobra.Command{
+		Use:     "exporter [OPTIONS]",
+		Short:   "Serve prometheus metrics of cluster managed by curveadm",
+		Args:    cliutil.NoArgs,
+		Example: EXPORTER_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if options.interval <= 0 {
+				return errno.ERR_INVALID_WATCH_INTERVAL.
+					F("interval: %s", options.interval)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExporter(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.listen, "listen", "0.0.0.0:9710", "Specify address which metrics served on")
+	flags.DurationVar(&options.interval, "interval", 60*time.Second, "Specify interval between refreshing metrics")
+	flags.StringVar(&options.formatConfig, "format-config", "", "Specify format configuration file for exporting format progress")
+
+	return cmd
+}
+
+func refreshServiceMetrics(curveadm *cli.CurveAdm, m *exporterMetrics, dcs []*topology.DeployConfig) {
+	cluster := curveadm.ClusterName()
+	m.clusterInfo.Reset()
+	m.clusterServices.Reset()
+	m.serviceUp.Reset()
+	m.clusterInfo.WithLabelValues(cluster, curveadm.ClusterUUId(), topology.GetKind(dcs)).Set(1)
+	for _, dc := range dcs {
+		m.clusterServices.WithLabelValues(cluster, dc.GetRole()).Inc()
+	}
+
+	// the error is ignored because the unreachable service is reported as down
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_STATUS, nil)
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range GET_STATUS_PLAYBOOK_STEPS {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: dcs,
+			ExecOptions: playbook.ExecOptions{
+				SilentMainBar: true,
+				SilentSubBar:  true,
+				SkipError:     true,
+			},
+		})
+	}
+	pb.Run()
+
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_SERVICE_STATUS); v != nil {
+		for _, status := range v.(map[string]task.ServiceStatus) {
+			up := 0.0
+			if strings.HasPrefix(status.Status, "Up") {
+				up = 1
+			}
+			m.serviceUp.WithLabelValues(cluster, status.Id, status.Role, status.Host).Set(up)
+		}
+	}
+}
+
+// formatted: 85/90 (device usage / format percent)
+func refreshFormatMetrics(curveadm *cli.CurveAdm, m *exporterMetrics, fcs []*configure.FormatConfig) {
+	m.formatProgress.Reset()
+	m.formatDone.Reset()
+	if len(fcs) == 0 {
+		return
+	}
+
+	curveadm.MemStorage().Set(comm.KEY_ALL_FORMAT_STATUS, nil)
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_FORMAT_STATUS,
+		Configs: fcs,
+		ExecOptions: playbook.ExecOptions{
+			SilentMainBar: true,
+			SilentSubBar:  true,
+			SkipError:     true,
+		},
+	})
+	pb.Run()
+
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_FORMAT_STATUS); v != nil {
+		for _, status := range v.(map[string]bs.FormatStatus) {
+			items := strings.Split(status.Formatted, "/")
+			usage, err1 := strconv.ParseFloat(items[0], 64)
+			percent, err2 := strconv.ParseFloat(items[len(items)-1], 64)
+			if err1 == nil && err2 == nil && percent > 0 {
+				m.formatProgress.WithLabelValues(status.Host, status.Device).Set(usage / percent)
+			}
+			done := 0.0
+			if status.Status == "Done" {
+				done = 1
+			}
+			m.formatDone.WithLabelValues(status.Host, status.Device).Set(done)
+		}
+	}
+}
+
+// operation is the subcommand of audit log, e.g: "curveadm deploy -k" => "deploy"
+func refreshOperationMetrics(curveadm *cli.CurveAdm, m *exporterMetrics) error {
+	auditLogs, err := curveadm.Storage().GetAuditLogs()
+	if err != nil {
+		return errno.ERR_GET_AUDIT_LOGS_FAILE.E(err)
+	}
+
+	m.operationSuccess.Reset()
+	m.operationTime.Reset()
+	m.operationErrno.Reset()
+	for _, auditLog := range auditLogs { // ordered by id
+		items := strings.Fields(auditLog.Command)
+		if len(items) < 2 || auditLog.Status == comm.AUDIT_STATUS_ABORT {
+			continue // operation is still running
+		}
+		operation := items[1]
+		success := 0.0
+		if auditLog.Status == comm.AUDIT_STATUS_SUCCESS {
+			success = 1
+		}
+		m.operationSuccess.WithLabelValues(operation).Set(success)
+		m.operationTime.WithLabelValues(operation).Set(float64(auditLog.ExecuteTime.Unix()))
+		m.operationErrno.WithLabelValues(operation).Set(float64(auditLog.ErrorCode))
+	}
+	return nil
+}
+
+func refreshMetrics(curveadm *cli.CurveAdm,
+	m *exporterMetrics,
+	dcs []*topology.DeployConfig,
+	fcs []*configure.FormatConfig) {
+	refreshServiceMetrics(curveadm, m, dcs)
+	refreshFormatMetrics(curveadm, m, fcs)
+	err := refreshOperationMetrics(curveadm, m)
+	m.lastRefreshTime.SetToCurrentTime()
+	if err != nil {
+		m.lastRefreshFailed.Set(1)
+	} else {
+		m.lastRefreshFailed.Set(0)
+	}
+}
+
+func runExporter(curveadm *cli.CurveAdm, options exporterOptions) error {
+	// 1) parse cluster topology and format configure
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	fcs := []*configure.FormatConfig{}
+	if len(options.formatConfig) > 0 {
+		fcs, err = configure.ParseFormat(options.formatConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2) serve metrics
+	m := newExporterMetrics()
+	mux := http.NewServeMux()
+	mux.Handle(EXPORTER_PATH, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: options.listen, Handler: mux}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.ListenAndServe()
+	}()
+	curveadm.WriteOutln("Serving metrics on http://%s%s, press Ctrl+C to stop...",
+		options.listen, EXPORTER_PATH)
+
+	// 3) refresh metrics until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	for {
+		refreshMetrics(curveadm, m, dcs, fcs)
+		select {
+		case err := <-errc:
+			return errno.ERR_SERVE_METRICS_FAILED.E(err)
+		case <-ctx.Done():
+			server.Shutdown(context.Background())
+			curveadm.WriteOutln("Exporter stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
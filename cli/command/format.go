@@ -23,23 +23,36 @@
 package command
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/task/task/bs"
 	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
 	tui "github.com/opencurve/curveadm/internal/tui/format"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 const (
 	FORMAT_EXAMPLE = `Examples:
   $ curveadm format -f /path/to/format.yaml           # Format chunkfile pool with specified configure file
+  $ curveadm format -c 4 -f /path/to/format.yaml      # Format at most 4 disks at the same time in each host
   $ curveadm format --status -f /path/to/format.yaml  # Display formatting status
-  $ curveadm format --stop   -f /path/to/format.yaml  # Stop formatting progress`
+  $ curveadm format --status -o json                  # Display formatting status in JSON
+  $ curveadm format --status --watch                  # Refresh formatting status every 2 seconds
+  $ curveadm format --status --columns host,status    # Only display columns host and status
+  $ curveadm format --stop   -f /path/to/format.yaml  # Stop formatting progress
+  $ curveadm format --expand -f /path/to/format.yaml  # Expand chunkfile pool to the larger format percent
+  $ curveadm format --resume -f /path/to/format.yaml  # Resume interrupted formatting
+  $ curveadm format --host @rack1 -f format.yaml      # Only format disks on hosts in group rack1`
 )
 
 var (
@@ -54,13 +67,28 @@ var (
 	FORMAT_STOP_PLAYBOOK_STEPS = []int{
 		playbook.STOP_FORMAT,
 	}
+
+	FORMAT_EXPAND_PLAYBOOK_STEPS = []int{
+		playbook.EXPAND_CHUNKFILE_POOL,
+	}
+
+	FORMAT_RESUME_PLAYBOOK_STEPS = []int{
+		playbook.RESUME_FORMAT,
+	}
 )
 
 type formatOptions struct {
 	filename   string
 	showStatus bool
 	stopFormat bool
+	expand     bool
+	resume     bool
 	concurrent uint
+	host       string
+	output     string
+	columns    []string
+	wide       bool
+	watch      time.Duration
 }
 
 func NewFormatCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -69,8 +97,22 @@ func NewFormatCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "format [OPTIONS]",
 		Short:   "Format chunkfile pool",
-		Args:    cliutil.NoArgs,
+		Args:    cliutil.WatchArgs(&options.watch),
 		Example: FORMAT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !output.ValidFormat(options.output) {
+				return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.F("output: %s", options.output)
+			} else if options.output == output.FORMAT_JSON && !options.showStatus {
+				return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.F("json output requires --status")
+			} else if options.watch != 0 && (!options.showStatus || options.watch < 0) {
+				return errno.ERR_INVALID_WATCH_OPTIONS.F("--watch requires --status and a positive duration")
+			} else if err := tuicomm.CheckColumns(tui.STATUS_TITLE, options.columns); err != nil {
+				return errno.ERR_UNKNOWN_TABLE_COLUMN.E(err)
+			} else if options.host == "*" {
+				return nil
+			}
+			return curveadm.CheckHost(options.host)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runFormat(curveadm, options)
 		},
@@ -81,7 +123,14 @@ func NewFormatCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVarP(&options.filename, "formatting", "f", "format.yaml", "Specify the configure file for formatting chunkfile pool")
 	flags.BoolVar(&options.showStatus, "status", false, "Show formatting status")
 	flags.BoolVar(&options.stopFormat, "stop", false, "Stop formatting progress")
-	flags.UintVarP(&options.concurrent, "concurrent", "c", 10, "Specify the number of concurrent for formatting")
+	flags.BoolVar(&options.resume, "resume", false, "Resume interrupted formatting, only format the remainder")
+	flags.BoolVar(&options.expand, "expand", false, "Expand formatted chunkfile pool with larger format percent")
+	flags.UintVarP(&options.concurrent, "concurrent", "c", 0, "Specify the number of concurrent formatting disks in each host (default: all disks)")
+	flags.StringVar(&options.host, "host", "*", "Only format disks on the specified host or host group (e.g. @rack1)")
+	flags.StringVarP(&options.output, "output", "o", output.FORMAT_TEXT, "Output format of formatting status (text/json)")
+	flags.StringSliceVar(&options.columns, "columns", []string{}, "Specify columns of formatting status to display in order (e.g. host,device,status)")
+	flags.BoolVar(&options.wide, "wide", false, "Display all columns of formatting status")
+	cliutil.AddWatchFlag(cmd, &options.watch)
 
 	return cmd
 }
@@ -93,7 +142,13 @@ func genFormatPlaybook(curveadm *cli.CurveAdm,
 		return nil, errno.ERR_NO_DISK_FOR_FORMATTING
 	}
 
-	if options.showStatus && options.stopFormat {
+	modes := 0
+	for _, set := range []bool{options.showStatus, options.stopFormat, options.expand, options.resume} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
 		return nil, errno.ERR_UNSUPPORT_CONFIGURE_VALUE_TYPE
 	}
 
@@ -104,21 +159,61 @@ func genFormatPlaybook(curveadm *cli.CurveAdm,
 	if options.stopFormat {
 		steps = FORMAT_STOP_PLAYBOOK_STEPS
 	}
+	if options.expand {
+		steps = FORMAT_EXPAND_PLAYBOOK_STEPS
+	}
+	if options.resume {
+		steps = FORMAT_RESUME_PLAYBOOK_STEPS
+	}
+	// formatting disks which exceed the host's concurrency wait in queue,
+	// so every formatting task occupies the concurrency until it done
+	concurrency, hostConcurrency := uint(0), uint(0)
+	hosts := disksPerHost(fcs)
+	if !options.showStatus && !options.stopFormat && !options.expand && !options.resume &&
+		options.concurrent > 0 && int(options.concurrent) < maxDisks(hosts) {
+		concurrency = options.concurrent * uint(len(hosts))
+		hostConcurrency = options.concurrent
+	}
+
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
 			Configs: fcs,
+			Options: map[string]interface{}{
+				comm.KEY_FORMAT_WAIT_DONE: hostConcurrency > 0,
+			},
 			ExecOptions: playbook.ExecOptions{
-				Concurrency:  options.concurrent,
-				SilentSubBar: options.showStatus,
+				Concurrency:     concurrency,
+				HostConcurrency: hostConcurrency,
+				SilentSubBar:    options.showStatus,
+				// progress bars would break the JSON output or flicker in watch mode
+				SilentMainBar: options.output == output.FORMAT_JSON || options.watch > 0,
 			},
 		})
 	}
 	return pb, nil
 }
 
-func displayFormatStatus(curveadm *cli.CurveAdm, fcs []*configure.FormatConfig, options formatOptions) {
+func disksPerHost(fcs []*configure.FormatConfig) map[string]int {
+	hosts := map[string]int{}
+	for _, fc := range fcs {
+		hosts[fc.GetHost()]++
+	}
+	return hosts
+}
+
+func maxDisks(hosts map[string]int) int {
+	max := 0
+	for _, n := range hosts {
+		if n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+func getFormatStatuses(curveadm *cli.CurveAdm) []bs.FormatStatus {
 	statuses := []bs.FormatStatus{}
 	v := curveadm.MemStorage().Get(comm.KEY_ALL_FORMAT_STATUS)
 	if v != nil {
@@ -127,37 +222,258 @@ func displayFormatStatus(curveadm *cli.CurveAdm, fcs []*configure.FormatConfig,
 			statuses = append(statuses, status)
 		}
 	}
+	return statuses
+}
+
+func newDisksOutput(statuses []bs.FormatStatus) output.Disks {
+	out := output.Disks{
+		Header: output.NewHeader(output.KIND_DISKS),
+		Disks:  []output.Disk{},
+	}
+	for _, status := range statuses {
+		out.Disks = append(out.Disks, output.Disk{
+			Host:       status.Host,
+			Device:     status.Device,
+			MountPoint: status.MountPoint,
+			Usage:      status.Usage,
+			Percent:    status.Percent,
+			Chunks:     status.Chunks,
+			Written:    status.Written,
+			Size:       status.Size,
+			Rate:       status.Rate,
+			Status:     status.Status,
+		})
+	}
+	sort.Slice(out.Disks, func(i, j int) bool {
+		d1, d2 := out.Disks[i], out.Disks[j]
+		if d1.Host == d2.Host {
+			return d1.Device < d2.Device
+		}
+		return d1.Host < d2.Host
+	})
+	return out
+}
+
+func displayFormatStatusJSON(curveadm *cli.CurveAdm) error {
+	data, err := output.Marshal(newDisksOutput(getFormatStatuses(curveadm)))
+	if err != nil {
+		return errno.ERR_UNKNOWN.E(err)
+	}
+	curveadm.WriteOutln("%s", data)
+	return nil
+}
 
-	output := tui.FormatStatus(statuses)
+func displayFormatStatus(curveadm *cli.CurveAdm,
+	fcs []*configure.FormatConfig,
+	options formatOptions,
+	highlighter *tuicomm.Highlighter) {
+	statuses := getFormatStatuses(curveadm)
+	output := tui.FormatStatus(statuses, tuicomm.TableOptions{Columns: options.columns, Wide: options.wide})
+	if highlighter != nil {
+		output = highlighter.Highlight(output)
+	}
 	curveadm.WriteOutln("")
 	curveadm.WriteOut("%s", output)
 	return
 }
 
+func saveFormatProgress(curveadm *cli.CurveAdm, fcs []*configure.FormatConfig, status string) error {
+	for _, fc := range fcs {
+		err := curveadm.Storage().SetFormatProgress(storage.FormatProgress{
+			Host:       fc.GetHost(),
+			Device:     fc.GetDevice(),
+			MountPoint: fc.GetMountPoint(),
+			Percent:    fc.GetFormatPercent(),
+			Status:     status,
+		})
+		if err != nil {
+			return errno.ERR_SET_FORMAT_PROGRESS_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func saveFormatStatus(curveadm *cli.CurveAdm) error {
+	v := curveadm.MemStorage().Get(comm.KEY_ALL_FORMAT_STATUS)
+	if v == nil {
+		return nil
+	}
+
+	for _, status := range v.(map[string]bs.FormatStatus) {
+		err := curveadm.Storage().SetFormatProgress(storage.FormatProgress{
+			Host:       status.Host,
+			Device:     status.Device,
+			MountPoint: status.MountPoint,
+			Percent:    status.Percent,
+			Chunks:     status.Chunks,
+			Status:     status.Status,
+		})
+		if err != nil {
+			return errno.ERR_SET_FORMAT_PROGRESS_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func displayLastProgress(curveadm *cli.CurveAdm, fcs []*configure.FormatConfig) error {
+	hosts := []string{}
+	seen := map[string]bool{}
+	disks := map[string]bool{}
+	for _, fc := range fcs {
+		if !seen[fc.GetHost()] {
+			seen[fc.GetHost()] = true
+			hosts = append(hosts, fc.GetHost())
+		}
+		disks[fmt.Sprintf("%s:%s", fc.GetHost(), fc.GetDevice())] = true
+	}
+	progresses, err := curveadm.Storage().GetFormatProgressesByHosts(hosts)
+	if err != nil {
+		return errno.ERR_GET_FORMAT_PROGRESSES_FAILED.E(err)
+	}
+
+	out := []storage.FormatProgress{}
+	for _, progress := range progresses {
+		if disks[fmt.Sprintf("%s:%s", progress.Host, progress.Device)] {
+			out = append(out, progress)
+		}
+	}
+	if len(out) > 0 {
+		curveadm.WriteOutln("Last formatting progress:")
+		curveadm.WriteOut("%s", tui.FormatProgresses(out))
+		curveadm.WriteOutln("")
+	}
+	return nil
+}
+
+// format the remainder of disks, the disks which never formatted will format from scratch
+func runResumeFormat(curveadm *cli.CurveAdm, fcs []*configure.FormatConfig, options formatOptions) error {
+	err := displayLastProgress(curveadm, fcs)
+	if err != nil {
+		return err
+	}
+
+	pb, err := genFormatPlaybook(curveadm, fcs, options)
+	if err != nil {
+		return err
+	} else if err = pb.Run(); err != nil {
+		return err
+	}
+
+	unformatted := []*configure.FormatConfig{}
+	if v := curveadm.MemStorage().Get(comm.KEY_FORMAT_UNFORMATTED_DISKS); v != nil {
+		disks := v.(map[string]bool)
+		for _, fc := range fcs {
+			if disks[fmt.Sprintf("%s:%s", fc.GetHost(), fc.GetDevice())] {
+				unformatted = append(unformatted, fc)
+			}
+		}
+	}
+	if len(unformatted) > 0 {
+		options.resume = false
+		pb, err = genFormatPlaybook(curveadm, unformatted, options)
+		if err != nil {
+			return err
+		} else if err = pb.Run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func filterFormatConfig(curveadm *cli.CurveAdm, fcs []*configure.FormatConfig, host string) ([]*configure.FormatConfig, error) {
+	if host == "*" {
+		return fcs, nil
+	}
+	hosts, err := curveadm.ExpandHosts(host)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := cliutil.Slice2Map(hosts)
+	out := []*configure.FormatConfig{}
+	for _, fc := range fcs {
+		if selected[fc.GetHost()] {
+			out = append(out, fc)
+		}
+	}
+	return out, nil
+}
+
+func watchFormatStatus(curveadm *cli.CurveAdm, fcs []*configure.FormatConfig, options formatOptions) error {
+	highlighter := tuicomm.NewHighlighter()
+	return tuicomm.Watch(options.watch, func() error {
+		pb, err := genFormatPlaybook(curveadm, fcs, options)
+		if err != nil {
+			return err
+		}
+		// keep watching even if some hosts are unreachable this time
+		pb.Run()
+		if err := saveFormatStatus(curveadm); err != nil {
+			return err
+		}
+
+		if options.output == output.FORMAT_JSON {
+			return displayFormatStatusJSON(curveadm)
+		}
+		curveadm.WriteOut(tuicomm.ANSI_CLEAR_SCREEN)
+		displayFormatStatus(curveadm, fcs, options, highlighter)
+		return nil
+	})
+}
+
 func runFormat(curveadm *cli.CurveAdm, options formatOptions) error {
 	// 1) parse format config
 	fcs, err := configure.ParseFormat(options.filename)
 	if err != nil {
 		return err
 	}
+	fcs, err = filterFormatConfig(curveadm, fcs, options.host)
+	if err != nil {
+		return err
+	}
+
+	// 2) resume formatting
+	if options.resume {
+		err = runResumeFormat(curveadm, fcs, options)
+		if err == nil {
+			err = saveFormatProgress(curveadm, fcs, "Formatting")
+		}
+		if err == nil {
+			tuicomm.PromptFormat()
+		}
+		return err
+	}
+
+	// 3) refresh formatting status until interrupted
+	if options.watch > 0 {
+		return watchFormatStatus(curveadm, fcs, options)
+	}
 
-	// 2) generate start playbook
+	// 4) generate start playbook
 	pb, err := genFormatPlaybook(curveadm, fcs, options)
 	if err != nil {
 		return err
 	}
 
-	// 3) run playbook
+	// 5) run playbook
 	err = pb.Run()
 	if err != nil {
 		return err
 	}
 
-	// 4) print status or prompt
+	// 6) save progress, and print status or prompt
 	if options.showStatus {
-		displayFormatStatus(curveadm, fcs, options)
-	} else {
+		err = saveFormatStatus(curveadm)
+		if options.output == output.FORMAT_JSON {
+			if jerr := displayFormatStatusJSON(curveadm); jerr != nil {
+				return jerr
+			}
+		} else {
+			displayFormatStatus(curveadm, fcs, options, nil)
+		}
+	} else if !options.stopFormat {
+		err = saveFormatProgress(curveadm, fcs, "Formatting")
 		tuicomm.PromptFormat()
 	}
-	return nil
+	return err
 }
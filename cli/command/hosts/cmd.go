@@ -44,6 +44,11 @@ func NewHostsCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		NewListCommand(curveadm),
 		NewSSHCommand(curveadm),
 		NewPlaybookCommand(curveadm),
+		NewInitCommand(curveadm),
+		NewFactsCommand(curveadm),
+		NewPingCommand(curveadm),
+		NewImportCommand(curveadm),
+		NewExportCommand(curveadm),
 	)
 	return cmd
 }
@@ -29,6 +29,8 @@ import (
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/configure/hosts"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/i18n"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
@@ -36,12 +38,14 @@ import (
 
 const (
 	COMMIT_EXAMPLE = `Examples:
-  $ curveadm hosts commit /path/to/hosts.yaml  # Commit hosts`
+  $ curveadm hosts commit /path/to/hosts.yaml          # Commit hosts
+  $ curveadm hosts commit /path/to/hosts.yaml --facts  # Commit hosts and gather facts of them`
 )
 
 type commitOptions struct {
 	filename string
 	slient   bool
+	facts    bool
 }
 
 func NewCommitCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -61,19 +65,20 @@ func NewCommitCommand(curveadm *cli.CurveAdm) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.slient, "slient", "s", false, "Slient output for config commit")
+	flags.BoolVar(&options.facts, "facts", false, "Gather facts of hosts after commit")
 
 	return cmd
 }
 
-func readAndCheckHosts(curveadm *cli.CurveAdm, options commitOptions) (string, error) {
+func readAndCheckHosts(curveadm *cli.CurveAdm, options commitOptions) (string, []*hosts.HostConfig, error) {
 	// 1) read hosts from file
 	if !utils.PathExist(options.filename) {
-		return "", errno.ERR_HOSTS_FILE_NOT_FOUND.
+		return "", nil, errno.ERR_HOSTS_FILE_NOT_FOUND.
 			F("%s: no such file", utils.AbsPath(options.filename))
 	}
 	data, err := utils.ReadFile(options.filename)
 	if err != nil {
-		return data, errno.ERR_READ_HOSTS_FILE_FAILED.E(err)
+		return data, nil, errno.ERR_READ_HOSTS_FILE_FAILED.E(err)
 	}
 
 	// 2) display difference
@@ -84,31 +89,53 @@ func readAndCheckHosts(curveadm *cli.CurveAdm, options commitOptions) (string, e
 	}
 
 	// 3) check hosts data
-	_, err = hosts.ParseHosts(data)
-	return data, err
+	hcs, err := hosts.ParseHosts(data)
+	return data, hcs, err
 }
 
-func runCommit(curveadm *cli.CurveAdm, options commitOptions) error {
-	// 1) read and check hosts
-	data, err := readAndCheckHosts(curveadm, options)
-	if err != nil {
-		return err
-	}
-
-	// 2) confirm by user
-	pass := tui.ConfirmYes("Do you want to continue?")
+// commitHosts saves checked hosts after confirmation, shared by commit and import
+func commitHosts(curveadm *cli.CurveAdm, data string, hcs []*hosts.HostConfig, facts bool) error {
+	// 1) confirm by user
+	pass := tui.ConfirmYes(i18n.T(tui.DEFAULT_CONFIRM_PROMPT))
 	if !pass {
 		curveadm.WriteOut(tui.PromptCancelOpetation("commit hosts"))
 		return errno.ERR_CANCEL_OPERATION
 	}
 
-	// 3) update hosts in database
-	err = curveadm.Storage().SetHosts(data)
+	// 2) update hosts in database
+	err := curveadm.Storage().SetHosts(data)
 	if err != nil {
 		return errno.ERR_UPDATE_HOSTS_FAILED.E(err)
 	}
 
+	// 3) remove facts of hosts which deleted and gather facts if needed
+	names := []string{}
+	for _, hc := range hcs {
+		names = append(names, hc.GetHost())
+	}
+	err = checker.PruneHostFacts(curveadm, names)
+	if err != nil {
+		return err
+	}
+	if facts {
+		err = refreshHostFacts(curveadm, hcs)
+		if err != nil {
+			return err
+		}
+	}
+
 	// 4) print success prompt
 	curveadm.WriteOutln(color.GreenString("Hosts updated"))
 	return nil
 }
+
+func runCommit(curveadm *cli.CurveAdm, options commitOptions) error {
+	// 1) read and check hosts
+	data, hcs, err := readAndCheckHosts(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) confirm and commit hosts
+	return commitHosts(curveadm, data, hcs, options.facts)
+}
@@ -0,0 +1,99 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	EXPORT_EXAMPLE = `Examples:
+  $ curveadm hosts export                                     # Export hosts in YAML format
+  $ curveadm hosts export --format ansible                    # Export hosts as ansible inventory
+  $ curveadm hosts export --format ansible -o inventory.ini   # Export ansible inventory to file`
+
+	EXPORT_FORMAT_YAML    = "yaml"
+	EXPORT_FORMAT_ANSIBLE = "ansible"
+)
+
+type exportOptions struct {
+	format string
+	output string
+}
+
+func NewExportCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options exportOptions
+
+	cmd := &cobra.Command{
+		Use:     "export [OPTIONS]",
+		Short:   "Export hosts",
+		Args:    utils.NoArgs,
+		Example: EXPORT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if options.format != EXPORT_FORMAT_YAML && options.format != EXPORT_FORMAT_ANSIBLE {
+				return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.
+					F("format: %s", options.format)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", EXPORT_FORMAT_YAML, "Output format (yaml/ansible)")
+	flags.StringVarP(&options.output, "output", "o", "", "Output to specified file instead of stdout")
+
+	return cmd
+}
+
+func runExport(curveadm *cli.CurveAdm, options exportOptions) error {
+	data := curveadm.Hosts()
+	if len(data) == 0 {
+		return errno.ERR_EMPTY_HOSTS
+	}
+
+	if options.format == EXPORT_FORMAT_ANSIBLE {
+		var err error
+		data, err = hosts.FormatAnsibleInventory(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(options.output) == 0 {
+		curveadm.WriteOut("%s", data)
+		return nil
+	}
+	err := utils.WriteFile(options.output, data, 0644)
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	return nil
+}
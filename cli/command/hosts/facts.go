@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FACTS_EXAMPLE = `Examples:
+  $ curveadm hosts facts                     # Show facts of all hosts which stored
+  $ curveadm hosts facts --refresh           # Gather facts of all hosts and store them
+  $ curveadm hosts facts --refresh -l rack1  # Gather facts of hosts which belong to label 'rack1'`
+)
+
+type factsOptions struct {
+	refresh bool
+	verbose bool
+	labels  []string
+}
+
+func NewFactsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options factsOptions
+
+	cmd := &cobra.Command{
+		Use:     "facts [OPTIONS]",
+		Short:   "Show hardware facts of hosts",
+		Args:    cliutil.NoArgs,
+		Example: FACTS_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFacts(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.refresh, "refresh", false, "Gather facts of hosts by SSH and store them")
+	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for facts")
+	flags.StringSliceVarP(&options.labels, "labels", "l", []string{}, "Specify the host labels")
+
+	return cmd
+}
+
+func genRefreshFactsPlaybook(curveadm *cli.CurveAdm, hcs []*hosts.HostConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.REFRESH_HOST_FACTS,
+		Configs: hcs,
+		ExecOptions: playbook.ExecOptions{
+			SkipError: true,
+		},
+	})
+	return pb
+}
+
+// refreshHostFacts gathers and stores facts of hosts, the unreachable hosts keep the old facts
+func refreshHostFacts(curveadm *cli.CurveAdm, hcs []*hosts.HostConfig) error {
+	curveadm.MemStorage().Set(comm.KEY_ALL_LINT_HOST_FACTS, nil)
+	err := genRefreshFactsPlaybook(curveadm, hcs).Run()
+
+	facts := map[string]step.HostFacts{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_LINT_HOST_FACTS); v != nil {
+		facts = v.(map[string]step.HostFacts)
+	}
+	if err2 := checker.SaveHostFacts(curveadm, facts); err2 != nil {
+		return err2
+	}
+	return err
+}
+
+func displayHostFacts(curveadm *cli.CurveAdm, hcs []*hosts.HostConfig, verbose bool) error {
+	facts, err := checker.LoadHostFacts(curveadm)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for _, hc := range hcs {
+		names = append(names, hc.GetHost())
+	}
+	curveadm.WriteOut(tui.FormatHostFacts(names, facts, verbose))
+	return nil
+}
+
+func runFacts(curveadm *cli.CurveAdm, options factsOptions) error {
+	// 1) filter hosts by labels
+	hcs, err := filter(curveadm.Hosts(), options.labels)
+	if err != nil {
+		return err
+	}
+
+	// 2) gather facts of hosts if refresh
+	if options.refresh {
+		err = refreshHostFacts(curveadm, hcs)
+		curveadm.WriteOutln("")
+	}
+
+	// 3) display facts, show it even if some hosts failed
+	if err2 := displayHostFacts(curveadm, hcs, options.verbose); err2 != nil {
+		return err2
+	}
+	return err
+}
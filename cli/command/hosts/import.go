@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	IMPORT_EXAMPLE = `Examples:
+  $ curveadm hosts import --from-ansible inventory.ini                # Import hosts from ansible inventory
+  $ curveadm hosts import --from-ansible inventory.ini -o hosts.yaml  # Convert inventory into hosts.yaml only`
+)
+
+type importOptions struct {
+	ansible string
+	output  string
+	facts   bool
+}
+
+func NewImportCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options importOptions
+
+	cmd := &cobra.Command{
+		Use:     "import --from-ansible INVENTORY [OPTIONS]",
+		Short:   "Import hosts from ansible inventory",
+		Args:    utils.NoArgs,
+		Example: IMPORT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(options.ansible) == 0 {
+				return errno.ERR_HOSTS_FILE_NOT_FOUND.
+					F("ansible inventory must be specified by --from-ansible")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.ansible, "from-ansible", "", "Specify ansible inventory in INI format")
+	flags.StringVarP(&options.output, "output", "o", "", "Write converted hosts to file instead of committing")
+	flags.BoolVar(&options.facts, "facts", false, "Gather facts of hosts after commit")
+
+	return cmd
+}
+
+func runImport(curveadm *cli.CurveAdm, options importOptions) error {
+	// 1) read and convert inventory
+	if !utils.PathExist(options.ansible) {
+		return errno.ERR_HOSTS_FILE_NOT_FOUND.
+			F("%s: no such file", utils.AbsPath(options.ansible))
+	}
+	content, err := utils.ReadFile(options.ansible)
+	if err != nil {
+		return errno.ERR_READ_ANSIBLE_INVENTORY_FAILED.E(err)
+	}
+	inventory, err := hosts.ParseAnsibleInventory(content)
+	if err != nil {
+		return err
+	}
+	data, ignored := inventory.ToHosts()
+	if len(ignored) > 0 {
+		curveadm.WriteOutln(color.YellowString("WARNING: ignored ansible variables: %s",
+			strings.Join(ignored, ", ")))
+	}
+
+	// 2) write converted hosts to file
+	if len(options.output) > 0 {
+		err := utils.WriteFile(options.output, data, 0644)
+		if err != nil {
+			return errno.ERR_WRITE_FILE_FAILED.E(err)
+		}
+		curveadm.WriteOutln(color.GreenString("Hosts converted into %s"), options.output)
+		return nil
+	}
+
+	// 3) display difference and check hosts
+	curveadm.WriteOutln(utils.Diff(curveadm.Hosts(), data))
+	hcs, err := hosts.ParseHosts(data)
+	if err != nil {
+		return err
+	}
+
+	// 4) confirm and commit hosts
+	return commitHosts(curveadm, data, hcs, options.facts)
+}
@@ -0,0 +1,184 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DEFAULT_INIT_HOST_USER = "curve"
+)
+
+var (
+	initExample = `Examples:
+  $ curveadm hosts init                           # Init all hosts
+  $ curveadm hosts init -l group1                 # Init hosts which belong to label 'group1'
+  $ curveadm hosts init --skip engine,sysctl      # Init all hosts but skip install container engine and apply sysctls
+  $ curveadm hosts init --dir /data/curve         # Init all hosts and create directory '/data/curve' in them`
+)
+
+type initOptions struct {
+	labels  []string
+	skip    []string
+	modules []string
+	user    string
+	dirs    []string
+	yes     bool
+}
+
+func checkInitOptions(curveadm *cli.CurveAdm, options initOptions) error {
+	supported := utils.Slice2Map(task.INIT_HOST_ITEMS)
+	for _, item := range options.skip {
+		if !supported[item] {
+			return errno.ERR_UNSUPPORT_INIT_HOST_ITEM.
+				F("init host item: %s", item)
+		}
+	}
+	return nil
+}
+
+func NewInitCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options initOptions
+
+	cmd := &cobra.Command{
+		Use:     "init [OPTIONS]",
+		Short:   "Init hosts for deploying cluster",
+		Args:    cliutil.NoArgs,
+		Example: initExample,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkInitOptions(curveadm, options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInit(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&options.labels, "labels", "l", []string{}, "Specify the host labels")
+	flags.StringSliceVar(&options.skip, "skip", []string{}, "Specify skipped init item (engine/module/sysctl/ulimit/user/dir)")
+	flags.StringSliceVar(&options.modules, "module", task.DEFAULT_INIT_HOST_MODULES, "Specify kernel modules to load")
+	flags.StringVar(&options.user, "user", DEFAULT_INIT_HOST_USER, "Specify the user which owns the directories")
+	flags.StringSliceVar(&options.dirs, "dir", []string{}, "Specify extra directories to create")
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Init hosts without confirmation")
+
+	return cmd
+}
+
+// directories which services of current cluster require in each host
+func getInitDirs(curveadm *cli.CurveAdm, hcs []*hosts.HostConfig, extra []string) map[string][]string {
+	dirs := map[string][]string{}
+	for _, hc := range hcs {
+		dirs[hc.GetHost()] = append([]string{}, extra...)
+	}
+	if curveadm.ClusterId() == -1 {
+		return dirs
+	}
+
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return dirs
+	}
+	for _, dc := range dcs {
+		host := dc.GetHost()
+		if _, ok := dirs[host]; !ok {
+			continue
+		}
+		for _, dir := range []string{dc.GetLogDir(), dc.GetDataDir(), dc.GetCoreDir()} {
+			if len(dir) > 0 {
+				dirs[host] = append(dirs[host], dir)
+			}
+		}
+	}
+	return dirs
+}
+
+func genInitPlaybook(curveadm *cli.CurveAdm,
+	hcs []*hosts.HostConfig,
+	options initOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.INIT_HOST,
+		Configs: hcs,
+		Options: map[string]interface{}{
+			comm.KEY_INIT_HOST_OPTIONS: task.InitHostOptions{
+				Skip:    options.skip,
+				Modules: options.modules,
+				User:    options.user,
+				Dirs:    getInitDirs(curveadm, hcs, options.dirs),
+			},
+		},
+	})
+	return pb
+}
+
+func runInit(curveadm *cli.CurveAdm, options initOptions) error {
+	// 1) filter hosts
+	var hcs []*hosts.HostConfig
+	var err error
+	data := curveadm.Hosts()
+	if len(data) > 0 {
+		hcs, err = filter(data, options.labels)
+		if err != nil {
+			return err
+		}
+	}
+	if len(hcs) == 0 {
+		curveadm.WriteOutln("No hosts matched")
+		return nil
+	}
+
+	// 2) confirm by user
+	names := []string{}
+	for _, hc := range hcs {
+		names = append(names, hc.GetHost())
+	}
+	if !options.yes {
+		if pass := tui.ConfirmYes(tui.PromptInitHosts(names)); !pass {
+			curveadm.WriteOut(tui.PromptCancelOpetation("init hosts"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 3) run playbook
+	err = genInitPlaybook(curveadm, hcs, options).Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Hosts successfully initialized ^_^."))
+	return nil
+}
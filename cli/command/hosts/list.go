@@ -23,16 +23,25 @@
 package hosts
 
 import (
+	"strings"
+
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/tui"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
+	outfmt "github.com/opencurve/curveadm/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 type listOptions struct {
 	verbose bool
 	labels  []string
+	groups  []string
+	output  string
+	columns []string
+	wide    bool
 }
 
 func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -43,6 +52,14 @@ func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		Aliases: []string{"list"},
 		Short:   "List hosts",
 		Args:    cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !outfmt.ValidFormat(options.output) {
+				return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.F("output: %s", options.output)
+			} else if err := tuicommon.CheckColumns(tui.HOSTS_TITLE, options.columns); err != nil {
+				return errno.ERR_UNKNOWN_TABLE_COLUMN.E(err)
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(curveadm, options)
 		},
@@ -52,6 +69,10 @@ func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for hosts")
 	flags.StringSliceVarP(&options.labels, "labels", "l", []string{}, "Specify the host labels")
+	flags.StringSliceVarP(&options.groups, "group", "g", []string{}, "Only list hosts belong to the specified groups")
+	flags.StringVarP(&options.output, "output", "o", outfmt.FORMAT_TEXT, "Output format of hosts (text/json)")
+	flags.StringSliceVar(&options.columns, "columns", []string{}, "Specify columns to display in order (e.g. host,hostname,labels)")
+	flags.BoolVar(&options.wide, "wide", false, "Display all columns without truncating")
 
 	return cmd
 }
@@ -151,6 +172,61 @@ func filter(data string, labels []string) ([]*hosts.HostConfig, error) {
 	return out, nil
 }
 
+// return hosts which belong to any of groups
+func filterGroups(hcs []*hosts.HostConfig, groups []string) ([]*hosts.HostConfig, error) {
+	if len(groups) == 0 {
+		return hcs, nil
+	}
+
+	patterns := []string{}
+	for _, group := range groups {
+		patterns = append(patterns, hosts.HOST_GROUP_PREFIX+strings.TrimPrefix(group, hosts.HOST_GROUP_PREFIX))
+	}
+	members, err := hosts.ExpandHosts(hcs, strings.Join(patterns, hosts.HOST_PATTERN_SPLITER))
+	if err != nil {
+		return nil, err
+	}
+
+	out := []*hosts.HostConfig{}
+	selected := cliutil.Slice2Map(members)
+	for _, hc := range hcs {
+		if selected[hc.GetHost()] {
+			out = append(out, hc)
+		}
+	}
+	return out, nil
+}
+
+func newHostsOutput(hcs []*hosts.HostConfig) outfmt.Hosts {
+	out := outfmt.Hosts{
+		Header: outfmt.NewHeader(outfmt.KIND_HOSTS),
+		Hosts:  []outfmt.Host{},
+	}
+	for _, hc := range hcs {
+		out.Hosts = append(out.Hosts, outfmt.Host{
+			Host:           hc.GetHost(),
+			Hostname:       hc.GetHostname(),
+			SSHHostname:    hc.GetSSHHostname(),
+			SSHPort:        hc.GetSSHPort(),
+			User:           hc.GetUser(),
+			PrivateKeyFile: hc.GetPrivateKeyFile(),
+			Transport:      hc.GetTransport(),
+			Labels:         append([]string{}, hc.GetLabels()...),
+			Groups:         append([]string{}, hc.GetGroups()...),
+		})
+	}
+	return out
+}
+
+func displayHostsJSON(curveadm *cli.CurveAdm, hcs []*hosts.HostConfig) error {
+	data, err := outfmt.Marshal(newHostsOutput(hcs))
+	if err != nil {
+		return errno.ERR_UNKNOWN.E(err)
+	}
+	curveadm.WriteOutln("%s", data)
+	return nil
+}
+
 func runList(curveadm *cli.CurveAdm, options listOptions) error {
 	var hcs []*hosts.HostConfig
 	var err error
@@ -160,9 +236,17 @@ func runList(curveadm *cli.CurveAdm, options listOptions) error {
 		if err != nil {
 			return err
 		}
+		hcs, err = filterGroups(hcs, options.groups)
+		if err != nil {
+			return err
+		}
 	}
 
-	output := tui.FormatHosts(hcs, options.verbose)
+	if options.output == outfmt.FORMAT_JSON {
+		return displayHostsJSON(curveadm, hcs)
+	}
+	output := tui.FormatHosts(hcs, options.verbose,
+		tuicommon.TableOptions{Columns: options.columns, Wide: options.wide})
 	curveadm.WriteOut(output)
 	return nil
 }
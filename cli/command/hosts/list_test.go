@@ -3,6 +3,7 @@ package hosts
 import (
 	"testing"
 
+	"github.com/opencurve/curveadm/internal/configure/hosts"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -98,3 +99,74 @@ func TestPlaybookLabel_MixPattern(t *testing.T) {
 	run(t, data, []string{"&group1", "&group2"}, []string{"host2"})
 	run(t, data, []string{"&group1", "&group2", "!host2"}, []string{})
 }
+
+const (
+	groupData = `
+hosts:
+  - host: host1
+    hostname: 1.1.1.1
+    groups: [rack1, ssd]
+  - host: host2
+    hostname: 2.2.2.2
+    groups: [rack1]
+  - host: host3
+    hostname: 3.3.3.3
+    groups: [rack2, ssd]
+`
+)
+
+func runGroups(t *testing.T, groups []string, out []string) {
+	assert := assert.New(t)
+	hcs, err := filter(groupData, []string{})
+	assert.Nil(err)
+	hcs, err = filterGroups(hcs, groups)
+	assert.Nil(err)
+	assert.Equal(len(hcs), len(out))
+	for i, hc := range hcs {
+		assert.Equal(hc.GetHost(), out[i])
+	}
+}
+
+func TestPlaybookGroup_Filter(t *testing.T) {
+	runGroups(t, []string{}, []string{"host1", "host2", "host3"})
+	runGroups(t, []string{"rack1"}, []string{"host1", "host2"})
+	runGroups(t, []string{"@ssd"}, []string{"host1", "host3"})
+	runGroups(t, []string{"rack2", "rack1"}, []string{"host1", "host2", "host3"})
+
+	hcs, err := filter(groupData, []string{})
+	assert.Nil(t, err)
+	_, err = filterGroups(hcs, []string{"rack3"})
+	assert.NotNil(t, err)
+}
+
+func TestPlaybookGroup_ExpandHosts(t *testing.T) {
+	assert := assert.New(t)
+	hcs, err := filter(groupData, []string{})
+	assert.Nil(err)
+
+	for pattern, expect := range map[string][]string{
+		"*":            {"host1", "host2", "host3"},
+		"@all":         {"host1", "host2", "host3"},
+		"host2":        {"host2"},
+		"@rack1":       {"host1", "host2"},
+		"@rack2,host2": {"host2", "host3"},
+		"@ssd, @rack1": {"host1", "host2", "host3"},
+	} {
+		members, err := hosts.ExpandHosts(hcs, pattern)
+		assert.Nil(err)
+		assert.Equal(expect, members, pattern)
+	}
+
+	for _, pattern := range []string{"@rack3", "host4", "@rack1,host4"} {
+		_, err := hosts.ExpandHosts(hcs, pattern)
+		assert.NotNil(err, pattern)
+	}
+
+	_, err = filter(`
+hosts:
+  - host: host1
+    hostname: 1.1.1.1
+    groups: ["rack@1"]
+`, []string{})
+	assert.NotNil(err)
+}
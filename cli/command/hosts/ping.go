@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	PING_EXAMPLE = `Examples:
+  $ curveadm hosts ping                      # Check SSH reachability, authentication and sudo of all hosts
+  $ curveadm hosts ping -l rack1 -g group1   # Check hosts which belong to label 'rack1' and group 'group1'
+  $ curveadm hosts ping --fix-known-hosts    # Refresh the changed host keys after machines reinstalled`
+)
+
+type pingOptions struct {
+	labels        []string
+	groups        []string
+	fixKnownHosts bool
+	concurrency   uint
+}
+
+func NewPingCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options pingOptions
+
+	cmd := &cobra.Command{
+		Use:     "ping [OPTIONS]",
+		Short:   "Check reachability and latency of hosts",
+		Args:    cliutil.NoArgs,
+		Example: PING_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPing(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&options.labels, "labels", "l", []string{}, "Specify the host labels")
+	flags.StringSliceVarP(&options.groups, "group", "g", []string{}, "Only check hosts belong to the specified groups")
+	flags.BoolVar(&options.fixKnownHosts, "fix-known-hosts", false, "Remove the changed host keys from known_hosts and trust the new ones")
+	flags.UintVarP(&options.concurrency, "concurrency", "c", 10, "Specify the number of hosts checking at the same time")
+
+	return cmd
+}
+
+func genPingPlaybook(curveadm *cli.CurveAdm,
+	hcs []*hosts.HostConfig,
+	options pingOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.PING_HOST,
+		Configs: hcs,
+		Options: map[string]interface{}{
+			comm.KEY_PING_HOST_OPTIONS: task.PingHostOptions{
+				FixKnownHosts: options.fixKnownHosts,
+			},
+		},
+		ExecOptions: playbook.ExecOptions{
+			Concurrency:  options.concurrency,
+			SilentSubBar: true,
+		},
+	})
+	return pb
+}
+
+func runPing(curveadm *cli.CurveAdm, options pingOptions) error {
+	// 1) filter hosts by labels and groups
+	hcs, err := filter(curveadm.Hosts(), options.labels)
+	if err != nil {
+		return err
+	}
+	hcs, err = filterGroups(hcs, options.groups)
+	if err != nil {
+		return err
+	}
+
+	// 2) ping hosts in parallel
+	if err := genPingPlaybook(curveadm, hcs, options).Run(); err != nil {
+		return err
+	}
+
+	// 3) display results
+	names := []string{}
+	for _, hc := range hcs {
+		names = append(names, hc.GetHost())
+	}
+	results := map[string]task.PingHostResult{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_PING_HOST_RESULTS); v != nil {
+		results = v.(map[string]task.PingHostResult)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatPingHostResults(names, results))
+	for _, name := range names {
+		if result, ok := results[name]; !ok || !result.Success() {
+			return errno.ERR_PING_HOSTS_FAILED
+		}
+	}
+	return nil
+}
@@ -30,7 +30,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type showOptions struct{}
+type showOptions struct {
+	facts   bool
+	verbose bool
+}
 
 func NewShowCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options showOptions
@@ -45,11 +48,23 @@ func NewShowCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		DisableFlagsInUseLine: true,
 	}
 
+	flags := cmd.Flags()
+	flags.BoolVar(&options.facts, "facts", false, "Show facts of hosts instead of hosts data")
+	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for facts")
+
 	return cmd
 }
 
 func runShow(curveadm *cli.CurveAdm, options showOptions) error {
 	hosts := curveadm.Hosts()
+	if options.facts && len(hosts) > 0 {
+		hcs, err := filter(hosts, nil)
+		if err != nil {
+			return err
+		}
+		return displayHostFacts(curveadm, hcs, options.verbose)
+	}
+
 	if len(hosts) == 0 {
 		curveadm.WriteOutln("<empty hosts>")
 	} else {
@@ -0,0 +1,468 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/google/uuid"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/i18n"
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	INIT_EXAMPLE = `Examples:
+  $ curveadm init                    # Create hosts, disks and topology step by step in current directory
+  $ curveadm init --dir /root/curve  # Create them in the specified directory`
+
+	INIT_FILE_HOSTS    = "hosts.yaml"
+	INIT_FILE_FORMAT   = "format.yaml" // disks of chunkservers, see 'curveadm format'
+	INIT_FILE_TOPOLOGY = "topology.yaml"
+
+	INIT_AUTH_KEY            = "key"
+	INIT_AUTH_PASSWORD       = "password"
+	INIT_SSH_PASSWORD_SECRET = "%s_ssh_password"
+
+	DEFAULT_INIT_CLUSTER_NAME = "my-cluster"
+	DEFAULT_INIT_SSH_PORT     = "22"
+)
+
+type (
+	initOptions struct {
+		dir string
+	}
+
+	// wizard asks questions one by one, the answer is validated as soon as
+	// it's typed and the question is asked again until the answer is valid
+	wizard struct {
+		curveadm *cli.CurveAdm
+		reader   *bufio.Reader
+	}
+)
+
+func NewInitCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options initOptions
+
+	cmd := &cobra.Command{
+		Use:     "init [OPTIONS]",
+		Short:   "Create hosts, disks and topology of first cluster interactively",
+		Args:    cliutil.NoArgs,
+		Example: INIT_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInit(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.dir, "dir", ".", "Specify the directory which generated files saved in")
+
+	return cmd
+}
+
+func answerError(err error) string {
+	if ec, ok := err.(*errno.ErrorCode); ok && len(ec.GetClue()) > 0 {
+		return fmt.Sprintf("%s (%s)", i18n.T(ec.GetDescription()), ec.GetClue())
+	} else if ok {
+		return i18n.T(ec.GetDescription())
+	}
+	return err.Error()
+}
+
+func (w *wizard) readLine() (string, error) {
+	line, err := w.reader.ReadString('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	if err != nil {
+		return "", errno.ERR_INIT_WIZARD_INTERRUPTED
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (w *wizard) ask(question, defaultValue string, validate func(answer string) error) (string, error) {
+	for {
+		if len(defaultValue) > 0 {
+			w.curveadm.WriteOut("%s [%s]: ", question, defaultValue)
+		} else {
+			w.curveadm.WriteOut("%s: ", question)
+		}
+		answer, err := w.readLine()
+		if err != nil {
+			return "", err
+		} else if len(answer) == 0 {
+			answer = defaultValue
+		}
+
+		if err := validate(answer); err != nil {
+			w.curveadm.WriteOutln(color.RedString("  %s", answerError(err)))
+			continue
+		}
+		return answer, nil
+	}
+}
+
+func (w *wizard) askList(question, defaultValue string, validate func(items []string) error) ([]string, error) {
+	answer, err := w.ask(question, defaultValue, func(answer string) error {
+		return validate(configure.ParseWizardList(answer))
+	})
+	return configure.ParseWizardList(answer), err
+}
+
+func (w *wizard) askPassword(question string) (string, error) {
+	for {
+		var password string
+		var err error
+		if tui.IsTerminal() {
+			password, err = tui.PromptPassword(question + ": ")
+		} else {
+			w.curveadm.WriteOut("%s: ", question)
+			password, err = w.readLine()
+		}
+		if err != nil {
+			return "", errno.ERR_INIT_WIZARD_INTERRUPTED
+		} else if len(password) > 0 {
+			return password, nil
+		}
+		w.curveadm.WriteOutln(color.RedString("  %s", i18n.T("password is empty")))
+	}
+}
+
+func (w *wizard) confirm() (bool, error) {
+	w.curveadm.WriteOut("%s%s ", i18n.T(tui.DEFAULT_CONFIRM_PROMPT), i18n.T(" [yes/no]: (default=no)"))
+	answer, err := w.readLine()
+	return answer == "yes", err
+}
+
+func requireOneOf(choices ...string) func(answer string) error {
+	return func(answer string) error {
+		if !cliutil.Slice2Map(choices)[answer] {
+			return errno.ERR_INVALID_INIT_ANSWER.
+				F("%s: it must be one of %s", answer, strings.Join(choices, "/"))
+		}
+		return nil
+	}
+}
+
+func checkClusterNotExist(curveadm *cli.CurveAdm, name string) error {
+	if err := configure.ValidateWizardName(name); err != nil {
+		return err
+	}
+	clusters, err := curveadm.Storage().GetClusters(name)
+	if err != nil {
+		return errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err)
+	}
+	for _, cluster := range clusters {
+		if cluster.Name == name {
+			return errno.ERR_CLUSTER_ALREADY_EXIST.F("cluster name: %s", name)
+		}
+	}
+	return nil
+}
+
+func (w *wizard) askHosts(cfg *configure.WizardConfig) error {
+	ips, err := w.askList(i18n.T("IP addresses of hosts (separated by comma)"), "", configure.ValidateWizardIPs)
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for i := range ips {
+		names = append(names, fmt.Sprintf("host%d", i+1))
+	}
+	names, err = w.askList(i18n.T("Names of hosts"), strings.Join(names, ","), func(items []string) error {
+		return configure.ValidateWizardHostNames(items, len(ips))
+	})
+	if err != nil {
+		return err
+	}
+	for i, ip := range ips {
+		cfg.Hosts = append(cfg.Hosts, configure.WizardHost{Name: names[i], IP: ip})
+	}
+	return nil
+}
+
+// askSSH returns the SSH password which saved as secret after confirmation
+func (w *wizard) askSSH(cfg *configure.WizardConfig, cluster string) (string, error) {
+	user, err := w.ask(i18n.T("SSH user"), cliutil.GetCurrentUser(), configure.ValidateWizardName)
+	if err != nil {
+		return "", err
+	}
+	port, err := w.ask(i18n.T("SSH port"), DEFAULT_INIT_SSH_PORT, configure.ValidateWizardSSHPort)
+	if err != nil {
+		return "", err
+	}
+	cfg.User = user
+	cfg.SSHPort, _ = cliutil.Str2Int(port)
+
+	auth, err := w.ask(i18n.T("SSH authentication (key/password)"), INIT_AUTH_KEY, func(answer string) error {
+		if err := requireOneOf(INIT_AUTH_KEY, INIT_AUTH_PASSWORD)(answer); err != nil {
+			return err
+		}
+		backend := w.curveadm.Config().GetSecretBackendConfig().Backend
+		if answer == INIT_AUTH_PASSWORD && !secret.IsStoreBackend(backend) {
+			return errno.ERR_SECRET_BACKEND_IS_READ_ONLY.F("backend: %s", backend)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	} else if auth == INIT_AUTH_KEY {
+		defaultKeyFile := filepath.Join(cliutil.GetCurrentHomeDir(), ".ssh", "id_rsa")
+		cfg.PrivateKeyFile, err = w.ask(i18n.T("SSH private key file"), defaultKeyFile,
+			configure.ValidateWizardPrivateKeyFile)
+		return "", err
+	}
+
+	cfg.Password = secret.REFERENCE_PREFIX + fmt.Sprintf(INIT_SSH_PASSWORD_SECRET, cluster)
+	return w.askPassword(i18n.T("SSH password"))
+}
+
+func (w *wizard) askServices(cfg *configure.WizardConfig) error {
+	names := []string{}
+	for _, host := range cfg.Hosts {
+		names = append(names, host.Name)
+	}
+	cfg.Services = map[string][]string{}
+	for _, role := range configure.WizardRoles(cfg.Kind) {
+		hosts, err := w.askList(i18n.Tf("Hosts of %s services", role), strings.Join(names, ","),
+			func(items []string) error {
+				return configure.ValidateWizardServiceHosts(role, items, cfg.Hosts)
+			})
+		if err != nil {
+			return err
+		}
+		cfg.Services[role] = hosts
+	}
+	return nil
+}
+
+func (w *wizard) askDisks(cfg *configure.WizardConfig) error {
+	if cfg.Kind != topology.KIND_CURVEBS {
+		return nil
+	}
+	devices, err := w.askList(i18n.T("Disk devices of each chunkserver host (e.g. /dev/sdb,/dev/sdc)"), "",
+		configure.ValidateWizardDevices)
+	if err != nil {
+		return err
+	}
+	percent, err := w.ask(i18n.T("Percent of each disk formatted into chunkfile pool"),
+		fmt.Sprintf("%d", configure.DEFAULT_WIZARD_FORMAT_PERCENT), configure.ValidateWizardFormatPercent)
+	if err != nil {
+		return err
+	}
+	cfg.Devices = devices
+	cfg.FormatPercent, _ = cliutil.Str2Int(percent)
+	return nil
+}
+
+// checkGenerated parses generated configs by the same way as committing them,
+// the password is checked in plain text for it hasn't been saved as secret yet
+func checkGenerated(cfg *configure.WizardConfig, password, hostsData, topologyData string) error {
+	if len(password) > 0 {
+		plain := *cfg
+		plain.Password = password
+		data, err := plain.GenHosts()
+		if err != nil {
+			return err
+		}
+		hostsData = data
+	}
+	hcs, err := hosts.ParseHosts(hostsData)
+	if err != nil {
+		return err
+	}
+
+	ctx := topology.NewContext()
+	for _, hc := range hcs {
+		ctx.Add(hc.GetHost(), hc.GetHostname())
+	}
+	dcs, err := topology.ParseTopology(topologyData, ctx)
+	if err != nil {
+		return err
+	} else if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_IN_TOPOLOGY
+	}
+	return nil
+}
+
+func writeGenerated(dir string, files map[string]string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	for _, name := range []string{INIT_FILE_HOSTS, INIT_FILE_FORMAT, INIT_FILE_TOPOLOGY} {
+		if len(files[name]) == 0 {
+			continue
+		}
+		err := cliutil.WriteFile(filepath.Join(dir, name), files[name], 0644)
+		if err != nil {
+			return errno.ERR_WRITE_FILE_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func commitGenerated(curveadm *cli.CurveAdm, cluster, password string, cfg *configure.WizardConfig, files map[string]string) error {
+	// 1) save SSH password which referenced by hosts
+	if len(password) > 0 {
+		err := curveadm.Secrets().Set(secret.ReferenceName(cfg.Password), password)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2) commit hosts and remove facts of hosts which replaced
+	err := curveadm.Storage().SetHosts(files[INIT_FILE_HOSTS])
+	if err != nil {
+		return errno.ERR_UPDATE_HOSTS_FAILED.E(err)
+	}
+	names := []string{}
+	for _, host := range cfg.Hosts {
+		names = append(names, host.Name)
+	}
+	if err := checker.PruneHostFacts(curveadm, names); err != nil {
+		return err
+	}
+
+	// 3) add cluster with topology and checkout it
+	err = curveadm.Storage().InsertCluster(cluster, uuid.NewString(),
+		"created by curveadm init", files[INIT_FILE_TOPOLOGY])
+	if err != nil {
+		return errno.ERR_INSERT_CLUSTER_FAILED.E(err)
+	}
+	err = curveadm.Storage().CheckoutCluster(cluster)
+	if err != nil {
+		return errno.ERR_CHECKOUT_CLUSTER_FAILED.E(err)
+	}
+	return nil
+}
+
+func runInit(curveadm *cli.CurveAdm, options initOptions) error {
+	// 1) never overwrite files which edited by user
+	for _, name := range []string{INIT_FILE_HOSTS, INIT_FILE_FORMAT, INIT_FILE_TOPOLOGY} {
+		filename := filepath.Join(options.dir, name)
+		if cliutil.PathExist(filename) {
+			return errno.ERR_INIT_FILE_ALREADY_EXIST.F("%s", cliutil.AbsPath(filename))
+		}
+	}
+
+	// 2) ask questions
+	w := &wizard{curveadm: curveadm, reader: bufio.NewReader(curveadm.In())}
+	cfg := &configure.WizardConfig{}
+	cluster, err := w.ask(i18n.T("Cluster name"), DEFAULT_INIT_CLUSTER_NAME, func(answer string) error {
+		return checkClusterNotExist(curveadm, answer)
+	})
+	if err != nil {
+		return err
+	}
+	cfg.Kind, err = w.ask(i18n.T("Cluster kind (curvebs/curvefs)"), topology.KIND_CURVEBS,
+		requireOneOf(topology.KIND_CURVEBS, topology.KIND_CURVEFS))
+	if err != nil {
+		return err
+	}
+	if err := w.askHosts(cfg); err != nil {
+		return err
+	}
+	password, err := w.askSSH(cfg, cluster)
+	if err != nil {
+		return err
+	}
+	if err := w.askServices(cfg); err != nil {
+		return err
+	}
+	if err := w.askDisks(cfg); err != nil {
+		return err
+	}
+	cfg.ContainerImage, err = w.ask(i18n.T("Container image"),
+		configure.DefaultWizardContainerImage(cfg.Kind), func(answer string) error { return nil })
+	if err != nil {
+		return err
+	}
+
+	// 3) generate and check configs
+	files := map[string]string{}
+	for name, gen := range map[string]func() (string, error){
+		INIT_FILE_HOSTS:    cfg.GenHosts,
+		INIT_FILE_FORMAT:   cfg.GenFormat,
+		INIT_FILE_TOPOLOGY: cfg.GenTopology,
+	} {
+		if files[name], err = gen(); err != nil {
+			return err
+		}
+	}
+	err = checkGenerated(cfg, password, files[INIT_FILE_HOSTS], files[INIT_FILE_TOPOLOGY])
+	if err != nil {
+		return err
+	}
+
+	// 4) display generated configs and confirm by user
+	for _, name := range []string{INIT_FILE_HOSTS, INIT_FILE_FORMAT, INIT_FILE_TOPOLOGY} {
+		if len(files[name]) > 0 {
+			curveadm.WriteOutln("")
+			curveadm.WriteOutln(color.CyanString("%s:", filepath.Join(options.dir, name)))
+			curveadm.WriteOut("%s", files[name])
+		}
+	}
+	curveadm.WriteOutln("")
+	if len(curveadm.Hosts()) > 0 {
+		curveadm.WriteOutln(color.YellowString(i18n.T("WARNING: the committed hosts will be replaced by %s"),
+			INIT_FILE_HOSTS))
+	}
+	if pass, err := w.confirm(); err != nil {
+		return err
+	} else if !pass {
+		curveadm.WriteOut(tui.PromptCancelOpetation("init"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 5) save files and commit them
+	if err := writeGenerated(options.dir, files); err != nil {
+		return err
+	}
+	if err := commitGenerated(curveadm, cluster, password, cfg, files); err != nil {
+		return err
+	}
+
+	// 6) print next steps
+	curveadm.WriteOutln(color.GreenString(i18n.Tf("Cluster '%s' added and checked out, next steps:", cluster)))
+	curveadm.WriteOutln("  $ curveadm hosts init")
+	if len(files[INIT_FILE_FORMAT]) > 0 {
+		curveadm.WriteOutln("  $ curveadm format -f %s", filepath.Join(options.dir, INIT_FILE_FORMAT))
+	}
+	curveadm.WriteOutln("  $ curveadm deploy")
+	return nil
+}
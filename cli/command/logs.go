@@ -0,0 +1,234 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LOGS_EXAMPLE = `Examples:
+  $ curveadm logs --role chunkserver --since 1h            # Display logs of all chunkservers in last hour
+  $ curveadm logs --host server1 --grep ERROR              # Display logs which contains "ERROR" of services in server1
+  $ curveadm logs --id c9570d5a4d0d --tail 100 --follow    # Display last 100 lines of service and stream new logs
+  $ curveadm logs --role mds --format json                 # Display logs as JSON lines`
+
+	LOGS_FORMAT_TEXT = "text"
+	LOGS_FORMAT_JSON = "json"
+
+	LOGS_TIME_LAYOUT = "2006-01-02 15:04:05.000"
+)
+
+type logsOptions struct {
+	id         string
+	role       string
+	host       string
+	since      string
+	tail       int
+	grep       string
+	format     string
+	follow     bool
+	concurrent uint
+}
+
+func checkLogsOptions(options logsOptions) error {
+	if options.format != LOGS_FORMAT_TEXT && options.format != LOGS_FORMAT_JSON {
+		return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.
+			F("format: %s", options.format)
+	} else if _, err := regexp.Compile(options.grep); err != nil {
+		return errno.ERR_INVALID_LOGS_GREP_PATTERN.E(err)
+	} else if _, err := task.ParseLogsSince(options.since, time.Now()); err != nil {
+		return errno.ERR_INVALID_LOGS_SINCE.F("since: %s", options.since)
+	}
+	return nil
+}
+
+func NewLogsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options logsOptions
+
+	cmd := &cobra.Command{
+		Use:     "logs [OPTIONS]",
+		Short:   "Display merged logs of services",
+		Args:    cliutil.NoArgs,
+		Example: LOGS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkLogsOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
+	flags.StringVar(&options.since, "since", "", "Show logs since timestamp (e.g. 2023-08-27T10:00:00) or relative (e.g. 1h)")
+	flags.IntVar(&options.tail, "tail", 0, "Number of lines to show from the end of each service logs (0 means all)")
+	flags.StringVar(&options.grep, "grep", "", "Only show lines which match the regular expression")
+	flags.StringVar(&options.format, "format", LOGS_FORMAT_TEXT, "Output format (text/json)")
+	flags.BoolVarP(&options.follow, "follow", "f", false, "Follow new logs until interrupted")
+	flags.UintVarP(&options.concurrent, "concurrent", "c", 10, "Specify the number of services fetched logs concurrently")
+
+	return cmd
+}
+
+func genLogsPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	concurrent uint,
+	options task.LogsOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_SERVICE_LOGS,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_LOGS_OPTIONS: options,
+		},
+		ExecOptions: playbook.ExecOptions{
+			Concurrency:   concurrent,
+			SilentMainBar: true,
+			SilentSubBar:  true,
+			SkipError:     true,
+		},
+	})
+	return pb
+}
+
+// fetch logs of all services and merge them by timestamp
+func fetchLogs(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options logsOptions) ([]task.LogLine, error) {
+	since, _ := task.ParseLogsSince(options.since, time.Now()) // checked in PreRunE
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_LOGS, nil)
+	err := genLogsPlaybook(curveadm, dcs, options.concurrent, task.LogsOptions{
+		Since: since,
+		Tail:  options.tail,
+	}).Run()
+
+	lines := []task.LogLine{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_SERVICE_LOGS); v != nil {
+		lines = v.([]task.LogLine)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+	return lines, err
+}
+
+func displayLogLine(curveadm *cli.CurveAdm, line task.LogLine, options logsOptions) {
+	if options.format == LOGS_FORMAT_JSON {
+		bytes, _ := json.Marshal(line)
+		curveadm.WriteOutln("%s", string(bytes))
+	} else {
+		curveadm.WriteOutln("%s %s %s | %s",
+			line.Time.Local().Format(LOGS_TIME_LAYOUT),
+			color.BlueString("%s/%s", line.Host, line.Role),
+			color.CyanString(line.Service),
+			line.Line)
+	}
+}
+
+func displayLogs(curveadm *cli.CurveAdm, lines []task.LogLine, options logsOptions) {
+	regex := regexp.MustCompile(options.grep)
+	for _, line := range lines {
+		if regex.MatchString(line.Line) {
+			displayLogLine(curveadm, line, options)
+		}
+	}
+}
+
+// follow streams new lines of all services as they are written, until interrupted
+func followLogs(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, options logsOptions) error {
+	var mutex sync.Mutex
+	regex := regexp.MustCompile(options.grep)
+	follow := func(line task.LogLine) {
+		if !regex.MatchString(line.Line) {
+			return
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		displayLogLine(curveadm, line, options)
+	}
+
+	// every service holds a connection until interrupted, so they run all at once
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	done := make(chan error, 1)
+	go func() {
+		pb := genLogsPlaybook(curveadm, dcs, uint(len(dcs)), task.LogsOptions{Follow: follow})
+		done <- pb.Run()
+	}()
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-done:
+		return err
+	}
+}
+
+func runLogs(curveadm *cli.CurveAdm, options logsOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) filter service
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 3) fetch and display logs
+	lines, err := fetchLogs(curveadm, dcs, options)
+	displayLogs(curveadm, lines, options)
+	if !options.follow {
+		return err
+	}
+
+	// 4) stream new logs until interrupted
+	return followLogs(curveadm, dcs, options)
+}
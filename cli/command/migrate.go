@@ -23,6 +23,11 @@
 package command
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -30,15 +35,24 @@ import (
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+const (
+	MIGRATE_EXAMPLE = `Examples:
+  $ curveadm migrate topology.yaml                            # Migrate services to topology.yaml
+  $ curveadm migrate --role mds --from host1 --to host4       # Migrate mds in host1 to host4
+  $ curveadm migrate --role etcd --from host1 --to host4 -k   # Migrate etcd without health check`
+)
+
 var (
 	MIGRATE_ETCD_STEPS = []int{
 		playbook.STOP_SERVICE,
 		playbook.CLEAN_SERVICE, // only container
+		playbook.MIGRATE_ETCD_MEMBER,
 		playbook.PULL_IMAGE,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
@@ -100,23 +114,63 @@ var (
 		topology.ROLE_SNAPSHOTCLONE: MIGRATE_SNAPSHOTCLONE_STEPS,
 		topology.ROLE_METASERVER:    MIGRATE_METASERVER_STEPS,
 	}
+
+	// the address of these roles are referenced by config of other services
+	MIGRATE_SYNC_PEERS_ROLES = map[string]bool{
+		topology.ROLE_ETCD:          true,
+		topology.ROLE_MDS:           true,
+		topology.ROLE_SNAPSHOTCLONE: true,
+	}
 )
 
 type migrateOptions struct {
 	filename        string
 	poolset         string
 	poolsetDiskType string
+	role            string
+	from            string
+	to              string
+	insecure        bool
+	healthTimeout   time.Duration
+}
+
+// migrate services by topology file, OR by --role, --from and --to
+func checkMigrateOptions(options migrateOptions) error {
+	byHost := len(options.role) > 0 || len(options.from) > 0 || len(options.to) > 0
+	if len(options.filename) > 0 && byHost {
+		return errno.ERR_INVALID_MIGRATE_OPTIONS.
+			F("topology file and --role/--from/--to can't be specified at the same time")
+	} else if len(options.filename) == 0 && !byHost {
+		return errno.ERR_INVALID_MIGRATE_OPTIONS.
+			F("topology file or --role/--from/--to must be specified")
+	} else if byHost && (len(options.role) == 0 || len(options.from) == 0 || len(options.to) == 0) {
+		return errno.ERR_INVALID_MIGRATE_OPTIONS.
+			F("--role, --from and --to must be specified together")
+	} else if byHost && options.from == options.to {
+		return errno.ERR_INVALID_MIGRATE_OPTIONS.
+			F("--from and --to are the same host: %s", options.from)
+	} else if options.healthTimeout <= 0 {
+		return errno.ERR_INVALID_MIGRATE_OPTIONS.
+			F("--health-timeout requires a positive duration")
+	}
+	return nil
 }
 
 func NewMigrateCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options migrateOptions
 
 	cmd := &cobra.Command{
-		Use:   "migrate TOPOLOGY",
-		Short: "Migrate services",
-		Args:  cliutil.ExactArgs(1),
+		Use:     "migrate [TOPOLOGY] [OPTIONS]",
+		Short:   "Migrate services",
+		Args:    cliutil.RequiresMaxArgs(1),
+		Example: MIGRATE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				options.filename = args[0]
+			}
+			return checkMigrateOptions(options)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			options.filename = args[0]
 			return runMigrate(curveadm, options)
 		},
 		DisableFlagsInUseLine: true,
@@ -125,10 +179,55 @@ func NewMigrateCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.poolset, "poolset", "default", "Specify the poolset")
 	flags.StringVar(&options.poolsetDiskType, "poolset-disktype", "ssd", "Specify the disk type of physical pool")
+	flags.StringVar(&options.role, "role", "", "Specify service role to migrate")
+	flags.StringVar(&options.from, "from", "", "Specify host which services migrate from")
+	flags.StringVar(&options.to, "to", "", "Specify host which services migrate to")
+	flags.BoolVarP(&options.insecure, "insecure", "k", false, "Migrate services without health check")
+	flags.DurationVar(&options.healthTimeout, "health-timeout", 10*time.Minute, "Specify timeout for waiting services healthy")
 
 	return cmd
 }
 
+/*
+ * replaceServiceHost replaces the host of deploy items in section
+ * "<role>_services", it returns the new topology data and the number
+ * of replaced items.
+ */
+func replaceServiceHost(data, role, from, to string) (string, int) {
+	section := regexp.MustCompile(fmt.Sprintf(`^%s_services\s*:`, role))
+	item := regexp.MustCompile(fmt.Sprintf(`^(\s*(?:-\s*)?host\s*:\s*)%s(\s*(?:#.*)?)$`, regexp.QuoteMeta(from)))
+
+	n := 0
+	inSection := false
+	lines := strings.Split(data, "\n")
+	for i, line := range lines {
+		if len(line) > 0 && line[0] != ' ' && line[0] != '#' {
+			inSection = section.MatchString(line)
+			continue
+		} else if !inSection || !item.MatchString(line) {
+			continue
+		}
+		lines[i] = item.ReplaceAllString(line, "${1}"+to+"${2}")
+		n++
+	}
+	return strings.Join(lines, "\n"), n
+}
+
+func readMigrateTopology(curveadm *cli.CurveAdm, options migrateOptions) (string, error) {
+	if len(options.filename) > 0 {
+		return readTopology(curveadm, options.filename)
+	}
+
+	oldData := curveadm.ClusterTopologyData()
+	data, n := replaceServiceHost(oldData, options.role, options.from, options.to)
+	if n == 0 {
+		return "", errno.ERR_NO_SERVICES_FOR_MIGRATING.
+			F("no %s services in host %s", options.role, options.from)
+	}
+	curveadm.WriteOut("%s", cliutil.Diff(oldData, data))
+	return data, nil
+}
+
 // NOTE: you can only migrate same role whole host services ervey time
 func checkMigrateTopology(curveadm *cli.CurveAdm, data string) error {
 	diffs, err := curveadm.DiffTopology(curveadm.ClusterTopologyData(), data)
@@ -204,6 +303,16 @@ func genMigratePlaybook(curveadm *cli.CurveAdm,
 			config = dcs2del
 		case playbook.BACKUP_ETCD_DATA:
 			config = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_ETCD)
+		case playbook.MIGRATE_ETCD_MEMBER:
+			config = excludeDeployConfigs(curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_ETCD), dcs2del)[:1]
+		case playbook.SYNC_CONFIG:
+			if role == topology.ROLE_ETCD { // join the existing cluster
+				config = []*topology.DeployConfig{}
+				for _, dc := range dcs2add {
+					config = append(config, dc.WithServiceConfig(task.ETCD_INITIAL_CLUSTER_STATE,
+						task.ETCD_INITIAL_CLUSTER_STATE_EXISTING))
+				}
+			}
 		case CREATE_PHYSICAL_POOL,
 			CREATE_LOGICAL_POOL:
 			config = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)[:1]
@@ -212,6 +321,8 @@ func genMigratePlaybook(curveadm *cli.CurveAdm,
 		// options
 		options := map[string]interface{}{}
 		switch step {
+		case playbook.MIGRATE_ETCD_MEMBER:
+			options[comm.KEY_MIGRATE_SERVERS] = migrates
 		case playbook.CLEAN_SERVICE:
 			options[comm.KEY_CLEAN_ITEMS] = []string{comm.CLEAN_ITEM_CONTAINER}
 			options[comm.KEY_CLEAN_BY_RECYCLE] = true
@@ -239,9 +350,50 @@ func genMigratePlaybook(curveadm *cli.CurveAdm,
 			},
 		})
 	}
+
+	// sync config of other services which reference the migrated services
+	if MIGRATE_SYNC_PEERS_ROLES[role] {
+		dcsNew, err := curveadm.ParseTopologyData(data)
+		if err != nil {
+			return nil, err
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.SYNC_CONFIG,
+			Configs: excludeDeployConfigs(dcsNew, dcs2add),
+		})
+	}
 	return pb, nil
 }
 
+// return deploy configs which belong to dcs1, but not belong to dcs2
+func excludeDeployConfigs(dcs1, dcs2 []*topology.DeployConfig) []*topology.DeployConfig {
+	exclude := map[string]bool{}
+	for _, dc := range dcs2 {
+		exclude[dc.GetId()] = true
+	}
+
+	dcs := []*topology.DeployConfig{}
+	for _, dc := range dcs1 {
+		if !exclude[dc.GetId()] {
+			dcs = append(dcs, dc)
+		}
+	}
+	return dcs
+}
+
+// the services of same role except excluded ones should be healthy
+func waitMigrateHealthy(curveadm *cli.CurveAdm,
+	dcs, exclude []*topology.DeployConfig,
+	role string,
+	options migrateOptions) error {
+	if options.insecure {
+		return nil
+	}
+	dcs = excludeDeployConfigs(dcs, exclude)
+	unit := curveadm.FilterDeployConfigByRole(dcs, role)
+	return waitUpgradeHealthy(curveadm, dcs, unit, options.healthTimeout)
+}
+
 func displayMigrateTitle(curveadm *cli.CurveAdm, data string) {
 	migrates := getMigrates(curveadm, data)
 	from := migrates[0].From
@@ -260,8 +412,8 @@ func runMigrate(curveadm *cli.CurveAdm, options migrateOptions) error {
 		return err
 	}
 
-	// 2) read topology from file
-	data, err := readTopology(curveadm, options.filename)
+	// 2) read topology from file, OR replace host of services
+	data, err := readMigrateTopology(curveadm, options)
 	if err != nil {
 		return err
 	}
@@ -276,7 +428,7 @@ func runMigrate(curveadm *cli.CurveAdm, options migrateOptions) error {
 	displayMigrateTitle(curveadm, data)
 
 	// 5) confirm by user
-	if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+	if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
 		curveadm.WriteOutln(tui.PromptCancelOpetation("migrate service"))
 		return errno.ERR_CANCEL_OPERATION
 	}
@@ -287,15 +439,43 @@ func runMigrate(curveadm *cli.CurveAdm, options migrateOptions) error {
 		return err
 	}
 
+	// 7) check services healthy before migrating, the old ones are excluded
+	//    because the host which migrate from maybe down
+	migrates := getMigrates(curveadm, data)
+	role := migrates[0].From.GetRole()
+	dcs2del := []*topology.DeployConfig{}
+	for _, migrate := range migrates {
+		dcs2del = append(dcs2del, migrate.From)
+	}
+	err = waitMigrateHealthy(curveadm, dcs, dcs2del, role, options)
+	if err != nil {
+		return err
+	}
+
 	// 8) run playground
 	err = pb.Run()
 	if err != nil {
 		return err
 	}
 
-	// 9) print success prompt
+	// 9) check services healthy after migrated
+	dcsNew, err := curveadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOutln("")
+	err = waitMigrateHealthy(curveadm, dcsNew, nil, role, options)
+	if err != nil {
+		return err
+	}
+
+	// 10) print success prompt
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln(color.GreenString("Services successfully migrateed ^_^."))
+	if MIGRATE_SYNC_PEERS_ROLES[role] {
+		curveadm.WriteOutln(color.YellowString("NOTICE: config of other services has been synced, " +
+			"run 'curveadm reload' to make them take effect"))
+	}
 	// TODO(P1): warning iff there is changed configs
 	// tui.PromptMigrate()
 	return nil
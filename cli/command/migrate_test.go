@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate_ReplaceServiceHost(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `kind: curvebs
+etcd_services:
+  deploy:
+    - host: host1
+    - host: host2  # comment
+mds_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host10
+`
+	out, n := replaceServiceHost(data, "mds", "host1", "host4")
+	assert.Equal(1, n)
+	assert.Equal(`kind: curvebs
+etcd_services:
+  deploy:
+    - host: host1
+    - host: host2  # comment
+mds_services:
+  deploy:
+    - host: host4
+    - host: host2
+    - host: host10
+`, out)
+
+	out, n = replaceServiceHost(data, "etcd", "host2", "host4")
+	assert.Equal(1, n)
+	assert.Contains(out, "    - host: host4  # comment\nmds_services")
+
+	_, n = replaceServiceHost(data, "chunkserver", "host1", "host4")
+	assert.Equal(0, n)
+	_, n = replaceServiceHost(data, "mds", "host3", "host4")
+	assert.Equal(0, n)
+}
+
+func TestMigrate_CheckMigrateOptions(t *testing.T) {
+	assert := assert.New(t)
+	timeout := 10 * time.Minute
+
+	assert.Nil(checkMigrateOptions(migrateOptions{filename: "topology.yaml", healthTimeout: timeout}))
+	assert.Nil(checkMigrateOptions(migrateOptions{role: "mds", from: "host1", to: "host4", healthTimeout: timeout}))
+
+	for _, options := range []migrateOptions{
+		{healthTimeout: timeout},
+		{filename: "topology.yaml", role: "mds", healthTimeout: timeout},
+		{role: "mds", from: "host1", healthTimeout: timeout},
+		{role: "mds", from: "host1", to: "host1", healthTimeout: timeout},
+		{role: "mds", from: "host1", to: "host4"},
+	} {
+		assert.ErrorIs(checkMigrateOptions(options), errno.ERR_INVALID_MIGRATE_OPTIONS)
+	}
+}
@@ -0,0 +1,206 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package monitor
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ALERTS_APPLY_EXAMPLE = `Examples:
+  $ curveadm monitor alerts apply                # Apply the builtin alert rules
+  $ curveadm monitor alerts apply rules.yaml     # Apply alert rules in rules.yaml
+  $ curveadm monitor alerts apply --version 3    # Rollback to the alert rules of version 3`
+)
+
+type alertsApplyOptions struct {
+	filename string
+	version  int
+}
+
+func NewAlertsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alerts",
+		Short: "Manage alert rules of prometheus",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewAlertsApplyCommand(curveadm),
+		NewAlertsListCommand(curveadm),
+	)
+	return cmd
+}
+
+func NewAlertsApplyCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options alertsApplyOptions
+
+	cmd := &cobra.Command{
+		Use:     "apply [RULES] [OPTIONS]",
+		Short:   "Install or update alert rules and reload prometheus",
+		Args:    cliutil.RequiresMaxArgs(1),
+		Example: ALERTS_APPLY_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				options.filename = args[0]
+			}
+			return runAlertsApply(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&options.version, "version", 0, "Specify alert rules version to rollback")
+
+	return cmd
+}
+
+func NewAlertsListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all versions of applied alert rules",
+		Args:  cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAlertsList(curveadm)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func readAlertRules(curveadm *cli.CurveAdm, options alertsApplyOptions) (string, error) {
+	if len(options.filename) > 0 {
+		data, err := cliutil.ReadFile(options.filename)
+		if err != nil {
+			return "", errno.ERR_READ_FILE_FAILED.E(err)
+		}
+		return data, nil
+	} else if options.version == 0 {
+		return scripts.CURVE_ALERT_RULES, nil
+	}
+
+	versions, err := curveadm.Storage().GetAlertRules(curveadm.ClusterId())
+	if err != nil {
+		return "", errno.ERR_GET_ALERT_RULES_FAILED.E(err)
+	}
+	for _, version := range versions {
+		if version.Id == options.version {
+			return version.Rules, nil
+		}
+	}
+	return "", errno.ERR_ALERT_RULES_VERSION_NOT_FOUND.
+		F("version: %d", options.version)
+}
+
+func genAlertsApplyPlaybook(curveadm *cli.CurveAdm,
+	mcs []*configure.MonitorConfig,
+	rules string) (*playbook.Playbook, error) {
+	mcs = configure.FilterMonitorConfig(curveadm, mcs, configure.FilterMonitorOption{
+		Id:   "*",
+		Role: configure.ROLE_PROMETHEUS,
+		Host: "*",
+	})
+	if len(mcs) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED.
+			F("prometheus not deployed")
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.APPLY_ALERT_RULES,
+		Configs: mcs,
+		Options: map[string]interface{}{
+			comm.KEY_ALERT_RULES: rules,
+		},
+	})
+	return pb, nil
+}
+
+func runAlertsApply(curveadm *cli.CurveAdm, options alertsApplyOptions) error {
+	// 1) read alert rules and check it
+	if len(options.filename) > 0 && options.version > 0 {
+		return errno.ERR_INVALID_ALERT_RULES.
+			F("rules file and --version can't be specified at the same time")
+	}
+	rules, err := readAlertRules(curveadm, options)
+	if err != nil {
+		return err
+	}
+	_, err = configure.ParseAlertRules(rules)
+	if err != nil {
+		return err
+	}
+
+	// 2) parse monitor configure
+	mcs, err := parseMonitorConfig(curveadm)
+	if err != nil {
+		return err
+	}
+
+	// 3) generate apply playbook
+	pb, err := genAlertsApplyPlaybook(curveadm, mcs, rules)
+	if err != nil {
+		return err
+	}
+
+	// 4) run playground
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 5) save alert rules as a new version
+	err = curveadm.Storage().InsertAlertRules(curveadm.ClusterId(), rules)
+	if err != nil {
+		return errno.ERR_INSERT_ALERT_RULES_FAILED.E(err)
+	}
+
+	// 6) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Apply alert rules success ^_^"))
+	return nil
+}
+
+func runAlertsList(curveadm *cli.CurveAdm) error {
+	if curveadm.ClusterId() == -1 {
+		return errno.ERR_NO_CLUSTER_SPECIFIED
+	}
+
+	versions, err := curveadm.Storage().GetAlertRules(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_GET_ALERT_RULES_FAILED.E(err)
+	}
+	curveadm.WriteOut(tui.FormatAlertRules(versions))
+	return nil
+}
@@ -44,6 +44,7 @@ func NewMonitorCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		NewCleanCommand(curveadm),
 		NewRestartCommand(curveadm),
 		NewReloadCommand(curveadm),
+		NewAlertsCommand(curveadm),
 	)
 	return cmd
 }
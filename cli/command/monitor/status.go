@@ -23,17 +23,28 @@
 package monitor
 
 import (
+	"errors"
+	"time"
+
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
 	"github.com/opencurve/curveadm/internal/task/task/monitor"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
 	tui "github.com/opencurve/curveadm/internal/tui/service"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+const (
+	STATUS_EXAMPLE = `Examples:
+  $ curveadm monitor status                # Display monitor services status
+  $ curveadm monitor status --watch        # Refresh status every 2 seconds, changed rows are highlighted
+  $ curveadm monitor status --watch 5s     # Refresh status every 5 seconds`
+)
+
 var (
 	GET_MONITOR_STATUS_PLAYBOOK_STEPS = []int{
 		playbook.INIT_MONITOR_STATUS,
@@ -46,14 +57,23 @@ type statusOptions struct {
 	role    string
 	host    string
 	verbose bool
+	watch   time.Duration
 }
 
 func NewStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options statusOptions
 	cmd := &cobra.Command{
-		Use:   "status [OPTIONS]",
-		Short: "Display monitor services status",
-		Args:  cliutil.NoArgs,
+		Use:     "status [OPTIONS]",
+		Short:   "Display monitor services status",
+		Args:    cliutil.WatchArgs(&options.watch),
+		Example: STATUS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if options.watch < 0 {
+				return errno.ERR_INVALID_WATCH_OPTIONS.
+					F("--watch requires a positive duration: %s", options.watch)
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(curveadm, options)
 		},
@@ -65,6 +85,7 @@ func NewStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.role, "role", "*", "Specify monitor service role")
 	flags.StringVar(&options.host, "host", "*", "Specify monitor service host")
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for status")
+	cliutil.AddWatchFlag(cmd, &options.watch)
 	return cmd
 }
 
@@ -100,8 +121,9 @@ func genStatusPlaybook(curveadm *cli.CurveAdm,
 			Type:    step,
 			Configs: mcs,
 			ExecOptions: playbook.ExecOptions{
-				SilentSubBar:  true,
-				SilentMainBar: step == playbook.INIT_MONITOR_STATUS,
+				SilentSubBar: true,
+				// progress bars would flicker in watch mode
+				SilentMainBar: step == playbook.INIT_MONITOR_STATUS || options.watch > 0,
 				SkipError:     true,
 			},
 		})
@@ -109,7 +131,10 @@ func genStatusPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
-func displayStatus(curveadm *cli.CurveAdm, dcs []*configure.MonitorConfig, options statusOptions) {
+func displayStatus(curveadm *cli.CurveAdm,
+	dcs []*configure.MonitorConfig,
+	options statusOptions,
+	highlighter *tuicommon.Highlighter) {
 	statuses := []monitor.MonitorStatus{}
 	value := curveadm.MemStorage().Get(comm.KEY_MONITOR_STATUS)
 	if value != nil {
@@ -120,31 +145,56 @@ func displayStatus(curveadm *cli.CurveAdm, dcs []*configure.MonitorConfig, optio
 	}
 
 	output := tui.FormatMonitorStatus(statuses, options.verbose)
+	if highlighter != nil {
+		output = highlighter.Highlight(output)
+	}
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln("cluster name      : %s", curveadm.ClusterName())
-	curveadm.WriteOutln("cluster kind      : %s", dcs[0].GetKind())
+	curveadm.WriteOutln("cluster kind      : %s", dcs[0].GetClusterKind())
 	curveadm.WriteOutln("")
 	curveadm.WriteOut("%s", output)
 }
 
-func runStatus(curveadm *cli.CurveAdm, options statusOptions) error {
-	// 1) parse monitor config
-	mcs, err := parseMonitorConfig(curveadm)
-	if err != nil {
-		return err
-	}
-
-	// 2) generate get status playbook
+func showStatus(curveadm *cli.CurveAdm,
+	mcs []*configure.MonitorConfig,
+	options statusOptions,
+	highlighter *tuicommon.Highlighter) error {
+	// 1) generate get status playbook
 	pb, err := genStatusPlaybook(curveadm, mcs, options)
 	if err != nil {
 		return err
 	}
 
-	// 3) run playground
+	// 2) run playground
 	err = pb.Run()
 
-	// 4) display service status
-	displayStatus(curveadm, mcs, options)
+	// 3) display service status
+	if options.watch > 0 {
+		curveadm.WriteOut(tuicommon.ANSI_CLEAR_SCREEN)
+	}
+	displayStatus(curveadm, mcs, options, highlighter)
 	return err
+}
 
+func runStatus(curveadm *cli.CurveAdm, options statusOptions) error {
+	// 1) parse monitor config
+	mcs, err := parseMonitorConfig(curveadm)
+	if err != nil {
+		return err
+	}
+
+	// 2) show status once
+	if options.watch == 0 {
+		return showStatus(curveadm, mcs, options, nil)
+	}
+
+	// 3) refresh until interrupted
+	highlighter := tuicommon.NewHighlighter()
+	return tuicommon.Watch(options.watch, func() error {
+		err := showStatus(curveadm, mcs, options, highlighter)
+		if errors.Is(err, errno.ERR_NO_SERVICES_MATCHED) {
+			return err
+		}
+		return nil
+	})
 }
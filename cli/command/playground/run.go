@@ -27,8 +27,10 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
@@ -44,6 +46,13 @@ const (
 	KIND_CURVEFS = topology.KIND_CURVEFS
 
 	FORMAT_PLAYGROUND_NAME = "playground-%s-%d" // playground-curvebs-1656035415
+	FORMAT_NODES_CLIENT    = `
+kind: curvebs
+mds.listen.addr: %s:6700,%s:6701,%s:6702
+`
+
+	DEFAULT_POOLSET      = "default"
+	DEFAULT_POOLSET_TYPE = "ssd"
 )
 
 var (
@@ -64,6 +73,9 @@ type runOptions struct {
 	kind           string
 	mountPoint     string
 	containerImage string
+	nodes          int
+	subnet         string
+	diskSize       string
 }
 
 func checkRunOptions(curveadm *cli.CurveAdm, options runOptions) error {
@@ -75,7 +87,7 @@ func checkRunOptions(curveadm *cli.CurveAdm, options runOptions) error {
 	}
 
 	if kind == KIND_CURVEBS {
-		return nil
+		return checkNodesOptions(options)
 	}
 
 	// checker for curvefs
@@ -91,6 +103,25 @@ func checkRunOptions(curveadm *cli.CurveAdm, options runOptions) error {
 	return nil
 }
 
+func checkNodesOptions(options runOptions) error {
+	if options.nodes == 1 {
+		return nil
+	} else if options.nodes < configure.MIN_PLAYGROUND_NODES ||
+		options.nodes > configure.MAX_PLAYGROUND_NODES {
+		return errno.ERR_INVALID_PLAYGROUND_NODES.
+			F("nodes=%d, it should be 1 or between %d and %d", options.nodes,
+				configure.MIN_PLAYGROUND_NODES, configure.MAX_PLAYGROUND_NODES)
+	} else if n, err := humanize.ParseBytes(options.diskSize); err != nil || n == 0 {
+		return errno.ERR_INVALID_PLAYGROUND_DISK_SIZE.
+			F("disk-size=%s", options.diskSize)
+	}
+
+	// guarantee the subnet is large enough for all nodes
+	cfg := &configure.PlaygroundConfig{Nodes: options.nodes, Subnet: options.subnet}
+	_, err := cfg.GetNodeIP(options.nodes - 1)
+	return err
+}
+
 func NewRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options runOptions
 
@@ -113,27 +144,25 @@ func NewRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVarP(&options.kind, "kind", "k", "curvefs", "Specify the type of playground (curvebs/curvefs)")
 	flags.StringVar(&options.mountPoint, "mountpoint", "p", "Specify the mountpoint for CurveFS playground")
 	flags.StringVarP(&options.containerImage, "container_image", "i", "opencurvedocker/curvebs:playground", "Specify the playground container image")
+	flags.IntVar(&options.nodes, "nodes", 1, "Specify the number of nodes, each node runs in its own container if it's greater than 1")
+	flags.StringVar(&options.subnet, "subnet", configure.DEFAULT_PLAYGROUND_SUBNET, "Specify the subnet of docker network for multi-node playground")
+	flags.StringVar(&options.diskSize, "disk-size", configure.DEFAULT_PLAYGROUND_DISK_SIZE, "Specify the size of loopback disk for each chunkserver of multi-node playground")
 
 	return cmd
 }
 
-func genRunPlaybook(curveadm *cli.CurveAdm,
-	dcs []*topology.DeployConfig,
-	cc *configure.ClientConfig,
-	options runOptions) (*playbook.Playbook, error) {
+func genRunPlaybook(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConfig) (*playbook.Playbook, error) {
 	steps := RUN_PLAYGROUND_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
+		options := map[string]interface{}{}
+		if step == playbook.INIT_PLAYGROUND {
+			options[comm.KEY_POOLSET] = configure.Poolset{Name: DEFAULT_POOLSET, Type: DEFAULT_POOLSET_TYPE}
+		}
 		pb.AddStep(&playbook.PlaybookStep{
-			Type: step,
-			Configs: &configure.PlaygroundConfig{
-				Kind:           options.kind,
-				Name:           options.name,
-				ContainerImage: options.containerImage,
-				Mountpoint:     options.mountPoint,
-				DeployConfigs:  dcs,
-				ClientConfig:   cc,
-			},
+			Type:    step,
+			Configs: cfg,
+			Options: options,
 			ExecOptions: playbook.ExecOptions{
 				SilentSubBar: true,
 			},
@@ -142,39 +171,83 @@ func genRunPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+// parse topology and client configure, all services on localhost for
+// single container playground, otherwise each node has its own ip
+func parseConfigs(cfg *configure.PlaygroundConfig) ([]*topology.DeployConfig, *configure.ClientConfig, error) {
+	ctx := topology.NewContext()
+	if !cfg.IsMultiNode() {
+		ctx.Add("localhost", "127.0.0.1")
+		dcs, err := topology.ParseTopology(script.TOPOLOGY, ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		cc, err := configure.ParseClientCfg(script.CLIENT)
+		return dcs, cc, err
+	}
+
+	nodes := []string{}
+	for i := 0; i < cfg.GetNodes(); i++ {
+		ip, err := cfg.GetNodeIP(i)
+		if err != nil {
+			return nil, nil, err
+		}
+		nodes = append(nodes, cfg.GetNodeName(i))
+		ctx.Add(cfg.GetNodeName(i), ip)
+	}
+	data, err := script.NodesTopology(nodes)
+	if err != nil {
+		return nil, nil, err
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	controlIP, _ := cfg.GetNodeIP(0)
+	cc, err := configure.ParseClientCfg(fmt.Sprintf(FORMAT_NODES_CLIENT, controlIP, controlIP, controlIP))
+	return dcs, cc, err
+}
+
 func runRun(curveadm *cli.CurveAdm, options runOptions) error {
 	// 1) print prompt
 	curveadm.WriteOutln(color.GreenString("Start to run playground '%s', it will takes 1~2 minutes\n"), options.name)
 
-	// 2) parse topology
-	ctx := topology.NewContext()
-	ctx.Add("localhost", "127.0.0.1")
-	dcs, err := topology.ParseTopology(script.TOPOLOGY, ctx)
-	if err != nil {
-		return err
+	// 2) parse topology and client configure
+	cfg := &configure.PlaygroundConfig{
+		Kind:           options.kind,
+		Name:           options.name,
+		ContainerImage: options.containerImage,
+		Mountpoint:     options.mountPoint,
+		Nodes:          options.nodes,
+		Subnet:         options.subnet,
+		DiskSize:       options.diskSize,
 	}
-
-	// 3) parse client configure
-	cc, err := configure.ParseClientCfg(script.CLIENT)
+	dcs, cc, err := parseConfigs(cfg)
 	if err != nil {
 		return err
 	}
+	cfg.DeployConfigs = dcs
+	cfg.ClientConfig = cc
 
-	// 4) generate run playground
-	pb, err := genRunPlaybook(curveadm, dcs, cc, options)
+	// 3) generate run playground
+	pb, err := genRunPlaybook(curveadm, cfg)
 	if err != nil {
 		return err
 	}
 
-	// 5) run playground
+	// 4) run playground
 	err = pb.Run()
 	if err != nil {
 		return err
 	}
 
-	// 6) print success prompt
+	// 5) print success prompt
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln(color.GreenString("Playground '%s' successfully deployed ^_^",
 		options.name))
+	if cfg.IsMultiNode() {
+		curveadm.WriteOutln(color.GreenString("Nodes run in network '%s', enter node by: docker exec -it %s bash",
+			cfg.GetNetwork(), cfg.GetNodeName(0)))
+	}
 	return nil
 }
@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/plugin"
+	"github.com/spf13/cobra"
+)
+
+// RunPluginCommand runs plugin 'curveadm-<name>' for 'curveadm <name> [ARGS...]'
+// iff <name> is not a builtin command, it returns false if no plugin handled it.
+func RunPluginCommand(curveadm *cli.CurveAdm, root *cobra.Command, args []string) (bool, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return false, nil
+	} else if _, _, err := root.Find(args); err == nil {
+		return false, nil // builtin command always takes precedence
+	}
+
+	p, ok := plugin.Lookup(curveadm.PluginSearchDirs(), args[0])
+	if !ok {
+		return false, nil
+	}
+
+	if cluster := os.Getenv(ENV_CURVEADM_CLUSTER); len(cluster) > 0 {
+		if err := curveadm.SwitchCluster(cluster); err != nil {
+			fmt.Fprintln(curveadm.Err(), err)
+			return true, err
+		}
+	}
+
+	cmd := p.Command(curveadm.PluginEnv(), args[1:])
+	cmd.Stdin = curveadm.In()
+	cmd.Stdout = curveadm.Out()
+	cmd.Stderr = curveadm.Err()
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err == nil || errors.As(err, &exitErr) {
+		return true, err // plugin reports its error itself
+	}
+
+	ec := errno.ERR_RUN_PLUGIN_FAILED.F("plugin: %s, %v", p.Path, err)
+	fmt.Fprintln(curveadm.Err(), ec)
+	return true, ec
+}
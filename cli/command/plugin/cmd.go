@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package plugin
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/plugin"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewPluginCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage third-party plugins (curveadm-<name> executables)",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewListCommand(curveadm),
+		NewInstallCommand(curveadm),
+		NewRemoveCommand(curveadm),
+		NewRunCommand(curveadm),
+	)
+	return cmd
+}
+
+func lookupPlugin(curveadm *cli.CurveAdm, name string) (plugin.Plugin, error) {
+	if !plugin.ValidName(name) {
+		return plugin.Plugin{}, errno.ERR_INVALID_PLUGIN_NAME.F("name: %s", name)
+	}
+	p, ok := plugin.Lookup(curveadm.PluginSearchDirs(), name)
+	if !ok {
+		return plugin.Plugin{}, errno.ERR_PLUGIN_NOT_FOUND.F("name: %s", name)
+	}
+	return p, nil
+}
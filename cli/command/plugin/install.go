@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package plugin
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/plugin"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	sdk "github.com/opencurve/curveadm/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+const (
+	INSTALL_EXAMPLE = `Examples:
+  $ curveadm plugin install ./curveadm-zstack             # Install plugin 'zstack'
+  $ curveadm plugin install ./zstack-v2 --name zstack     # Install plugin 'zstack' from file with other name
+  $ curveadm plugin install ./curveadm-zstack --force     # Overwrite installed plugin 'zstack'`
+)
+
+type installOptions struct {
+	filename string
+	name     string
+	force    bool
+}
+
+func NewInstallCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options installOptions
+
+	cmd := &cobra.Command{
+		Use:     "install FILE [OPTIONS]",
+		Short:   "Install plugin into plugin directory",
+		Args:    cliutil.ExactArgs(1),
+		Example: INSTALL_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.filename = args[0]
+			return runInstall(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.name, "name", "", "Specify plugin name (default: file name without 'curveadm-' prefix)")
+	flags.BoolVarP(&options.force, "force", "f", false, "Overwrite plugin if it already installed")
+
+	return cmd
+}
+
+func copyExecutable(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// write to temporary file first, avoid leaving a broken plugin
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	} else if err = out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+func runInstall(curveadm *cli.CurveAdm, options installOptions) error {
+	// 1) check plugin name
+	name := options.name
+	if len(name) == 0 {
+		name = strings.TrimPrefix(filepath.Base(options.filename), sdk.BINARY_PREFIX)
+	}
+	if !plugin.ValidName(name) {
+		return errno.ERR_INVALID_PLUGIN_NAME.F("name: %s", name)
+	}
+
+	// 2) copy plugin into plugin directory
+	dst := filepath.Join(curveadm.PluginDir(), sdk.BINARY_PREFIX+name)
+	if cliutil.PathExist(dst) && !options.force {
+		return errno.ERR_PLUGIN_ALREADY_INSTALLED.F("name: %s", name)
+	}
+	if err := copyExecutable(options.filename, dst); err != nil {
+		return errno.ERR_INSTALL_PLUGIN_FAILED.E(err)
+	}
+
+	// 3) plugin should answer its manifest, otherwise it's not a curveadm plugin
+	p := plugin.Plugin{Name: name, Path: dst}
+	manifest, err := p.Manifest(curveadm.PluginEnv())
+	if err != nil {
+		os.Remove(dst)
+		return err
+	}
+
+	curveadm.WriteOutln("Installed plugin '%s' (version: %s) to %s", name, manifest.Version, dst)
+	return nil
+}
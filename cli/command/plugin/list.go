@@ -0,0 +1,73 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package plugin
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/plugin"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	verbose bool
+}
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List plugins",
+		Args:    cliutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for plugins")
+
+	return cmd
+}
+
+func runList(curveadm *cli.CurveAdm, options listOptions) error {
+	items := []tui.PluginItem{}
+	for _, p := range plugin.Discover(curveadm.PluginSearchDirs()) {
+		manifest, err := p.Manifest(curveadm.PluginEnv())
+		if err != nil {
+			log.Warn("Get plugin manifest failed",
+				log.Field("plugin", p.Path),
+				log.Field("error", err))
+		}
+		items = append(items, tui.PluginItem{Plugin: p, Manifest: manifest})
+	}
+
+	output := tui.FormatPlugins(items, options.verbose)
+	curveadm.WriteOut(output)
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	name string
+}
+
+func NewRemoveCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options removeOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm NAME",
+		Aliases: []string{"remove", "delete"},
+		Short:   "Remove plugin from plugin directory",
+		Args:    cliutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			return runRemove(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+// plugins found in $PATH are managed by others (e.g. package manager), we never touch them
+func runRemove(curveadm *cli.CurveAdm, options removeOptions) error {
+	p, err := lookupPlugin(curveadm, options.name)
+	if err != nil {
+		return err
+	} else if filepath.Dir(p.Path) != filepath.Clean(curveadm.PluginDir()) {
+		return errno.ERR_PLUGIN_NOT_INSTALLED_BY_US.F("path: %s", p.Path)
+	}
+
+	if err := os.Remove(p.Path); err != nil {
+		return errno.ERR_REMOVE_PLUGIN_FAILED.E(err)
+	}
+	curveadm.WriteOutln("Removed plugin '%s'", options.name)
+	return nil
+}
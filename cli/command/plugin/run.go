@@ -0,0 +1,159 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package plugin
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	sdk "github.com/opencurve/curveadm/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+const (
+	RUN_EXAMPLE = `Examples:
+  $ curveadm plugin run zstack register-storage                        # Run step 'register-storage' of plugin 'zstack' on all hosts
+  $ curveadm plugin run zstack register-storage --host @rack1          # Run step on hosts of group 'rack1'
+  $ curveadm plugin run zstack register-storage --option pool=pool1    # Run step with options`
+)
+
+type runOptions struct {
+	name    string
+	step    string
+	host    string
+	options []string
+}
+
+func NewRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options runOptions
+
+	cmd := &cobra.Command{
+		Use:     "run NAME STEP [OPTIONS]",
+		Short:   "Run step provided by plugin",
+		Args:    cliutil.ExactArgs(2),
+		Example: RUN_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			options.step = args[1]
+			return runRun(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "*", "Specify target hosts, e.g. host1,@group1")
+	flags.StringSliceVar(&options.options, "option", []string{}, "Specify step option as KEY=VALUE")
+
+	return cmd
+}
+
+func parseStepOptions(options []string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, option := range options {
+		items := strings.SplitN(option, "=", 2)
+		if len(items) != 2 || len(items[0]) == 0 {
+			return nil, errno.ERR_INVALID_PLUGIN_STEP_OPTION.F("option: %s", option)
+		}
+		out[items[0]] = items[1]
+	}
+	return out, nil
+}
+
+func genRunPlaybook(curveadm *cli.CurveAdm,
+	hcs []*hosts.HostConfig,
+	options task.PluginStepOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.RUN_PLUGIN_STEP,
+		Configs: hcs,
+		Options: map[string]interface{}{
+			comm.KEY_PLUGIN_STEP_OPTIONS: options,
+		},
+	})
+	return pb
+}
+
+func runRun(curveadm *cli.CurveAdm, options runOptions) error {
+	// 1) check plugin provides the step
+	p, err := lookupPlugin(curveadm, options.name)
+	if err != nil {
+		return err
+	}
+	env := curveadm.PluginEnv()
+	manifest, err := p.Manifest(env)
+	if err != nil {
+		return err
+	} else if !cliutil.Slice2Map(manifest.Steps)[options.step] {
+		return errno.ERR_UNSUPPORT_PLUGIN_STEP.
+			F("plugin: %s, step: %s", options.name, options.step)
+	}
+
+	// 2) expand hosts
+	stepOptions, err := parseStepOptions(options.options)
+	if err != nil {
+		return err
+	}
+	names, err := curveadm.ExpandHosts(options.host)
+	if err != nil {
+		return err
+	}
+	hcs := []*hosts.HostConfig{}
+	for _, name := range names {
+		hc, err := curveadm.GetHost(name)
+		if err != nil {
+			return err
+		}
+		hcs = append(hcs, hc)
+	}
+
+	// 3) run step on hosts in parallel, the message of plugin is printed even if some failed
+	runErr := genRunPlaybook(curveadm, hcs, task.PluginStepOptions{
+		Plugin:  p,
+		Step:    options.step,
+		Options: stepOptions,
+	}).Run()
+
+	// 4) print message
+	results := map[string]sdk.Result{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_PLUGIN_STEP_RESULTS); v != nil {
+		results = v.(map[string]sdk.Result)
+	}
+	for _, name := range names {
+		if result, ok := results[name]; ok && len(result.Message) > 0 {
+			curveadm.WriteOutln("%s: %s", name, result.Message)
+		}
+	}
+	if runErr != nil {
+		return runErr
+	}
+	curveadm.WriteOutln("%s", color.GreenString("Step '%s' of plugin '%s' succeeded on %d host(s)",
+		options.step, options.name, len(hcs)))
+	return nil
+}
@@ -23,6 +23,7 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -32,8 +33,11 @@ import (
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/plugin"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	utils "github.com/opencurve/curveadm/internal/utils"
+	sdk "github.com/opencurve/curveadm/pkg/plugin"
 	"github.com/spf13/cobra"
 )
 
@@ -41,7 +45,10 @@ const (
 	PRECHECK_EXAMPLE = `Examples:
   $ curveadm precheck                         # Check all items
   $ curveadm precheck --skip topology         # Check all items except topology
-  $ curveadm precheck --skip topology,kernel  # Check all items except topology and kernel`
+  $ curveadm precheck --skip topology,kernel  # Check all items except topology and kernel
+  $ curveadm precheck --ntp                   # Check all items and clock skew of hosts
+  $ curveadm precheck --ntp --ntp-fix         # Check clock skew and sync skewed hosts by chrony
+  $ curveadm precheck --skip plugin           # Check all items except prechecks provided by plugins`
 )
 
 const (
@@ -52,6 +59,9 @@ const (
 	CHECK_ITEM_NERWORK    = "network"
 	CHECK_ITEM_DATE       = "date"
 	CHECK_ITEM_SERVICE    = "service"
+	CHECK_ITEM_RESOURCE   = "resource"
+	CHECK_ITEM_NTP        = "ntp"
+	CHECK_ITEM_PLUGIN     = "plugin"
 )
 
 var (
@@ -68,7 +78,8 @@ var (
 		playbook.GET_HOST_DATE, // date
 		playbook.CHECK_HOST_DATE,
 		playbook.CHECK_CHUNKFILE_POOL, // service
-		//playbook.CHECK_S3,
+		playbook.CHECK_S3,
+		playbook.CHECK_HOST_RESOURCES, // resource
 	}
 
 	CURVEFS_PRECHECK_STEPS = []int{
@@ -82,6 +93,14 @@ var (
 		playbook.CHECK_NETWORK_FIREWALL,
 		playbook.GET_HOST_DATE, // date
 		playbook.CHECK_HOST_DATE,
+		playbook.CHECK_S3,             // service
+		playbook.CHECK_HOST_RESOURCES, // resource
+	}
+
+	// only added when --ntp specified
+	NTP_PRECHECK_STEPS = []int{
+		playbook.GET_HOST_CLOCK_OFFSET,
+		playbook.CHECK_CLOCK_SKEW,
 	}
 
 	PRECHECK_POST_STEPS = []int{
@@ -100,6 +119,9 @@ var (
 		playbook.CHECK_HOST_DATE:             CHECK_ITEM_DATE,
 		playbook.CHECK_CHUNKFILE_POOL:        CHECK_ITEM_SERVICE,
 		playbook.CHECK_S3:                    CHECK_ITEM_SERVICE,
+		playbook.CHECK_HOST_RESOURCES:        CHECK_ITEM_RESOURCE,
+		playbook.GET_HOST_CLOCK_OFFSET:       CHECK_ITEM_NTP,
+		playbook.CHECK_CLOCK_SKEW:            CHECK_ITEM_NTP,
 	}
 
 	CHECK_ITEMS = []string{
@@ -110,13 +132,21 @@ var (
 		CHECK_ITEM_NERWORK,
 		CHECK_ITEM_DATE,
 		CHECK_ITEM_SERVICE,
+		CHECK_ITEM_RESOURCE,
+		CHECK_ITEM_PLUGIN,
 	}
 )
 
 type precheckOptions struct {
+	kind              string
 	skipSnapshotClone bool
 	skip              []string
 	//only              []string
+	ntp          bool
+	ntpReference string
+	ntpMaxOffset int
+	ntpFix       bool
+	ntpServers   []string
 }
 
 func checkPrecheckOptions(options precheckOptions) error {
@@ -126,6 +156,15 @@ func checkPrecheckOptions(options precheckOptions) error {
 			return errno.ERR_UNSUPPORT_SKIPPED_CHECK_ITEM
 		}
 	}
+
+	if err := checkKindOption(options.kind); err != nil {
+		return err
+	}
+
+	if options.ntpMaxOffset <= 0 {
+		return errno.ERR_INVALID_NTP_MAX_OFFSET.
+			F("--ntp-max-offset: %d", options.ntpMaxOffset)
+	}
 	return nil
 }
 
@@ -149,7 +188,13 @@ func NewPrecheckCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	usage := fmt.Sprintf("Specify skipped check item (%s)", strings.Join(CHECK_ITEMS, ","))
 	flags.StringSliceVar(&options.skip, "skip", []string{}, usage)
+	flags.StringVar(&options.kind, "kind", "*", "Specify cluster kind of mixed topology (curvebs/curvefs)")
 	//flags.StringSliceVar(&options.only, "only", CHECK_ITEMS, usage)
+	flags.BoolVar(&options.ntp, "ntp", false, "Check clock skew of hosts")
+	flags.StringVar(&options.ntpReference, "ntp-reference", "", "Specify reference host for clock skew (default: curveadm machine)")
+	flags.IntVar(&options.ntpMaxOffset, "ntp-max-offset", checker.DEFAULT_NTP_MAX_OFFSET_MS, "Specify max clock offset in milliseconds")
+	flags.BoolVar(&options.ntpFix, "ntp-fix", false, "Install and configure chrony for skewed hosts")
+	flags.StringSliceVar(&options.ntpServers, "ntp-servers", []string{}, "Specify NTP servers for chrony (default: reference host or pool.ntp.org)")
 
 	return cmd
 }
@@ -169,11 +214,27 @@ func skipPrecheckSteps(precheckSteps []int, options precheckOptions) []int {
 func genPrecheckPlaybook(curveadm *cli.CurveAdm,
 	dcs []*topology.DeployConfig,
 	options precheckOptions) (*playbook.Playbook, error) {
-	kind := dcs[0].GetKind()
+	// the topology is always checked as a whole, and other items
+	// only check the services of specified kind for mixed topology
+	all := dcs
+	if len(options.kind) > 0 && options.kind != "*" {
+		dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+			Id: "*", Role: "*", Host: "*", Kind: options.kind,
+		})
+		if len(dcs) == 0 {
+			return nil, errno.ERR_NO_SERVICES_MATCHED
+		}
+	}
+
+	// curvebs precheck steps cover curvefs's, so we use them for mixed topology
+	kind := topology.GetKind(dcs)
 	steps := CURVEFS_PRECHECK_STEPS
-	if kind == topology.KIND_CURVEBS {
+	if kind == topology.KIND_CURVEBS || kind == topology.KIND_MIXED {
 		steps = CURVEBS_PRECHECK_STEPS
 	}
+	if options.ntp {
+		steps = append(append([]int{}, steps...), NTP_PRECHECK_STEPS...)
+	}
 	steps = skipPrecheckSteps(steps, options)
 
 	// add playbook step
@@ -186,22 +247,31 @@ func genPrecheckPlaybook(curveadm *cli.CurveAdm,
 		case playbook.CHECK_KERNEL_VERSION:
 			// TODO:
 			configs = curveadm.FilterDeployConfigByRole(dcs, ROLE_CHUNKSERVER)
-		case playbook.CHECK_HOST_DATE:
+		case playbook.CHECK_HOST_DATE,
+			playbook.CHECK_CLOCK_SKEW:
 			configs = configs[:1]
 		case playbook.CHECK_CHUNKFILE_POOL:
 			configs = curveadm.FilterDeployConfigByRole(dcs, ROLE_CHUNKSERVER)
+		case playbook.CHECK_S3:
+			configs = checker.FilterS3DeployConfigs(dcs, options.skipSnapshotClone)
+			if len(configs) == 0 {
+				continue
+			}
 		}
 
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
 			Configs: configs,
 			Options: map[string]interface{}{
-				comm.KEY_ALL_DEPLOY_CONFIGS:       dcs,
+				comm.KEY_ALL_DEPLOY_CONFIGS:       all,
 				comm.KEY_CHECK_WITH_WEAK:          false,
 				comm.KEY_CHECK_SKIP_SNAPSHOECLONE: options.skipSnapshotClone,
+				comm.KEY_NTP_REFERENCE_HOST:       options.ntpReference,
+				comm.KEY_NTP_MAX_OFFSET:           options.ntpMaxOffset,
 			},
 			ExecOptions: playbook.ExecOptions{
-				SilentSubBar: step == playbook.CHECK_HOST_DATE,
+				SilentSubBar: step == playbook.CHECK_HOST_DATE ||
+					step == playbook.CHECK_CLOCK_SKEW,
 			},
 		})
 	}
@@ -221,6 +291,106 @@ func genPrecheckPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+// remediation playbook: sync skewed hosts by chrony and check clock skew again
+func genSyncClockPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options precheckOptions) (*playbook.Playbook, error) {
+	servers := options.ntpServers
+	if len(servers) == 0 && len(options.ntpReference) > 0 {
+		hc, err := curveadm.GetHost(options.ntpReference)
+		if err != nil {
+			return nil, err
+		}
+		servers = []string{hc.GetHostname()}
+	}
+
+	steps := append([]int{playbook.SYNC_HOST_CLOCK}, NTP_PRECHECK_STEPS...)
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		configs := dcs
+		options := map[string]interface{}{}
+		switch step {
+		case playbook.SYNC_HOST_CLOCK:
+			options[comm.KEY_NTP_SERVERS] = servers
+		case playbook.GET_HOST_CLOCK_OFFSET: // measure again
+			options[comm.KEY_ALL_HOST_CLOCK_OFFSET] = map[string]int64{}
+		case playbook.CHECK_CLOCK_SKEW:
+			configs = configs[:1]
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: configs,
+			Options: options,
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: step == playbook.CHECK_CLOCK_SKEW,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func getPluginHosts(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) ([]sdk.Host, error) {
+	out := []sdk.Host{}
+	existed := map[string]bool{}
+	for _, dc := range dcs {
+		if existed[dc.GetHost()] {
+			continue
+		}
+		existed[dc.GetHost()] = true
+		hc, err := curveadm.GetHost(dc.GetHost())
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, plugin.NewHost(hc))
+	}
+	return out, nil
+}
+
+// runPluginPrechecks runs all prechecks provided by plugins one by one
+func runPluginPrechecks(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) error {
+	env := curveadm.PluginEnv()
+	plugins := plugin.Discover(curveadm.PluginSearchDirs())
+	if len(plugins) == 0 {
+		return nil
+	}
+	hosts, err := getPluginHosts(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+
+	failed := []string{}
+	for _, p := range plugins {
+		manifest, err := p.Manifest(env)
+		if err != nil {
+			return err
+		}
+		for _, check := range manifest.Prechecks {
+			result, err := p.Precheck(env, &sdk.Request{
+				Cluster: curveadm.ClusterName(),
+				Name:    check,
+				Hosts:   hosts,
+			})
+			if err != nil {
+				return err
+			}
+
+			name := fmt.Sprintf("%s/%s", p.Name, check)
+			if result.Success {
+				curveadm.WriteOutln("+ Check %s %s", name, color.GreenString("[OK]"))
+			} else {
+				curveadm.WriteOutln("+ Check %s %s: %s", name, color.RedString("[FAIL]"), result.Message)
+				failed = append(failed, name)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return errno.ERR_PLUGIN_PRECHECK_FAILED.
+			F("failed: %s", strings.Join(failed, ","))
+	}
+	return nil
+}
+
 func runPrecheck(curveadm *cli.CurveAdm, options precheckOptions) error {
 	// 1) parse cluster topology
 	dcs, err := curveadm.ParseTopology()
@@ -236,11 +406,26 @@ func runPrecheck(curveadm *cli.CurveAdm, options precheckOptions) error {
 
 	// 3) run playground
 	err = pb.Run()
+	if errors.Is(err, errno.ERR_HOST_CLOCK_SKEW_EXCEED_THRESHOLD) && options.ntpFix {
+		curveadm.WriteOutln("")
+		pb, err = genSyncClockPlaybook(curveadm, dcs, options)
+		if err != nil {
+			return err
+		}
+		err = pb.Run()
+	}
 	if err != nil {
 		return err
 	}
 
-	// 4) print success prompt
+	// 4) run prechecks provided by plugins
+	if !utils.Slice2Map(options.skip)[CHECK_ITEM_PLUGIN] {
+		if err := runPluginPrechecks(curveadm, dcs); err != nil {
+			return err
+		}
+	}
+
+	// 5) print success prompt
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln(color.GreenString("Congratulations!!! all precheck passed :)"))
 	return nil
@@ -67,7 +67,7 @@ func NewReloadCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.id, "id", "*", "Specify service id")
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
-	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
 
 	return cmd
 }
@@ -109,7 +109,8 @@ func runReload(curveadm *cli.CurveAdm, options reloadOptions) error {
 	}
 
 	// 3) confirm by user
-	if pass := tui.ConfirmYes(tui.PromptReloadService(options.id, options.role, options.host)); !pass {
+	impact := tui.PromptImpact(pb.Impact("reload service"))
+	if pass := curveadm.Confirm(impact + tui.PromptReloadService(options.id, options.role, options.host)); !pass {
 		curveadm.WriteOut(tui.PromptCancelOpetation("reload service"))
 		return errno.ERR_CANCEL_OPERATION
 	}
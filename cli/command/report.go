@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"sort"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/report"
+	"github.com/opencurve/curveadm/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// collect resulting health of cluster by status playbook, nil if no cluster
+func collectHealth(curveadm *cli.CurveAdm) []report.Service {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil || len(dcs) == 0 {
+		return nil
+	}
+
+	pb, err := genStatusPlaybook(curveadm, dcs, statusOptions{
+		id:     "*",
+		role:   "*",
+		host:   "*",
+		kind:   "*",
+		deep:   true,
+		output: output.FORMAT_JSON, // silent
+	})
+	if err != nil {
+		return nil
+	}
+	pb.Run()
+
+	services := []report.Service{}
+	for _, status := range getServiceStatuses(curveadm) {
+		services = append(services, report.Service{
+			Id:     status.Id,
+			Role:   status.Role,
+			Host:   status.Host,
+			Status: status.Status,
+			Health: status.Health,
+		})
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Id < services[j].Id
+	})
+	return services
+}
+
+/*
+ * SendReport emails summary of major operation (e.g. deploy, upgrade) to
+ * recipients in curveadm.cfg after it completed, including steps, durations,
+ * warnings and resulting health of cluster.
+ */
+func SendReport(curveadm *cli.CurveAdm, start time.Time, cmd *cobra.Command, args []string, ec error) {
+	if !curveadm.NeedReport(cmd.CommandPath()) {
+		return
+	}
+
+	r := curveadm.NewReport(start, cmd.CommandPath(), args, ec)
+	r.Services = collectHealth(curveadm)
+	curveadm.SendReport(r)
+}
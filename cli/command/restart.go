@@ -34,7 +34,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-
 var (
 	RESTART_PLAYBOOK_STEPS = []int{
 		playbook.RESTART_SERVICE,
@@ -66,7 +65,7 @@ func NewRestartCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.id, "id", "*", "Specify service id")
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
-	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
 
 	return cmd
 }
@@ -108,7 +107,8 @@ func runRestart(curveadm *cli.CurveAdm, options restartOptions) error {
 	}
 
 	// 3) confirm by user
-	if pass := tui.ConfirmYes(tui.PromptRestartService(options.id, options.role, options.host)); !pass {
+	impact := tui.PromptImpact(pb.Impact("restart service"))
+	if pass := curveadm.Confirm(impact + tui.PromptRestartService(options.id, options.role, options.host)); !pass {
 		curveadm.WriteOut(tui.PromptCancelOpetation("restart service"))
 		return errno.ERR_CANCEL_OPERATION
 	}
@@ -0,0 +1,261 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ROLLBACK_EXAMPLE = `Examples:
+  $ curveadm rollback                     # Rollback all upgraded services to previous image
+  $ curveadm rollback --role chunkserver  # Rollback chunkservers to previous image
+  $ curveadm rollback --host machine1     # Rollback services in host machine1 to previous image`
+)
+
+var (
+	// the previous image existed in host, so we needn't pull it,
+	// and the config files which service running with before upgrade
+	// are restored after config synced
+	ROLLBACK_PLAYBOOK_STEPS = []int{
+		playbook.STOP_SERVICE,
+		playbook.CLEAN_SERVICE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.RESTORE_SERVICE_CONFIG,
+		playbook.START_SERVICE,
+	}
+)
+
+type rollbackOptions struct {
+	id            string
+	role          string
+	host          string
+	healthTimeout time.Duration
+}
+
+func NewRollbackCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options rollbackOptions
+
+	cmd := &cobra.Command{
+		Use:     "rollback [OPTIONS]",
+		Short:   "Rollback service to the image before upgrade",
+		Args:    cliutil.NoArgs,
+		Example: ROLLBACK_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkCommonOptions(curveadm, options.id, options.role, options.host)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollback(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
+	flags.DurationVar(&options.healthTimeout, "health-timeout", 10*time.Minute, "Specify timeout for waiting services healthy")
+
+	return cmd
+}
+
+/*
+ * the service which already running with the target image is skipped,
+ * so the previous image and config wouldn't be overwritten when we upgrade
+ * a service more than once (e.g: resume a failed upgrade).
+ */
+func savePreviousImages(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	images map[string]task.ServiceImage) error {
+	for _, dc := range dcs {
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		image, ok := images[serviceId]
+		if !ok || image.Name == dc.GetContainerImage() {
+			continue
+		}
+
+		config, err := json.Marshal(image.Configs)
+		if err != nil {
+			return errno.ERR_SET_PREVIOUS_IMAGE_FAILED.E(err)
+		}
+		err = curveadm.Storage().SetPreviousImage(storage.PreviousImage{
+			ClusterId: curveadm.ClusterId(),
+			ServiceId: serviceId,
+			Image:     image.Name,
+			Digest:    image.Digest,
+			Config:    string(config),
+		})
+		if err != nil {
+			return errno.ERR_SET_PREVIOUS_IMAGE_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+// record the image which services running with before upgrade for rollback
+func recordPreviousImages(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) error {
+	images, err := getServiceImages(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+	return savePreviousImages(curveadm, dcs, images)
+}
+
+// the previous config files of services, key: service id
+func getPreviousConfigs(images []storage.PreviousImage) (map[string]map[string]string, error) {
+	configs := map[string]map[string]string{}
+	for _, image := range images {
+		if len(image.Config) == 0 {
+			continue
+		}
+		config := map[string]string{}
+		err := json.Unmarshal([]byte(image.Config), &config)
+		if err != nil {
+			return nil, errno.ERR_GET_PREVIOUS_IMAGES_FAILED.E(err)
+		}
+		configs[image.ServiceId] = config
+	}
+	return configs, nil
+}
+
+func genRollbackPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	configs map[string]map[string]string) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range ROLLBACK_PLAYBOOK_STEPS {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: dcs,
+			Options: map[string]interface{}{
+				comm.KEY_CLEAN_ITEMS:                  []string{comm.CLEAN_ITEM_CONTAINER},
+				comm.KEY_CLEAN_BY_RECYCLE:             true,
+				comm.KEY_ALL_PREVIOUS_SERVICE_CONFIGS: configs,
+			},
+		})
+	}
+	return pb
+}
+
+// the digest is preferred because the tag maybe point to another image now
+func previousImageOf(image storage.PreviousImage) string {
+	if len(image.Digest) > 0 {
+		return image.Digest
+	}
+	return image.Image
+}
+
+func runRollback(curveadm *cli.CurveAdm, options rollbackOptions) error {
+	// 1) parse cluster topology
+	dcsAll, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) filter deploy config
+	dcs := curveadm.FilterDeployConfig(dcsAll, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 3) services which has previous image
+	images, err := curveadm.Storage().GetPreviousImages(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_GET_PREVIOUS_IMAGES_FAILED.E(err)
+	}
+	m := map[string]storage.PreviousImage{}
+	for _, image := range images {
+		m[image.ServiceId] = image
+	}
+	dcs2rollback := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		if _, ok := m[curveadm.GetServiceId(dc.GetId())]; ok {
+			dcs2rollback = append(dcs2rollback, dc)
+		}
+	}
+	if len(dcs2rollback) == 0 {
+		return errno.ERR_NO_PREVIOUS_IMAGE_FOR_ROLLBACK
+	}
+	configs, err := getPreviousConfigs(images)
+	if err != nil {
+		return err
+	}
+
+	// 4) display title and confirm by user
+	curveadm.WriteOutln(color.YellowString("Rollback %d services one by one:", len(dcs2rollback)))
+	for _, dc := range dcs2rollback {
+		image := m[curveadm.GetServiceId(dc.GetId())]
+		curveadm.WriteOutln("  + host=%s  role=%s  image=%s (%s)",
+			dc.GetHost(), dc.GetRole(), image.Image, image.Digest)
+	}
+	if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOut(tui.PromptCancelOpetation("rollback service"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 5) rollback service one by one and wait it healthy
+	total := len(dcs2rollback)
+	for i, dc := range dcs2rollback {
+		curveadm.WriteOutln("")
+		curveadm.WriteOutln("Rollback %s service:", color.BlueString("%d/%d", i+1, total))
+
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		dc = dc.WithContainerImage(previousImageOf(m[serviceId]))
+		err := genRollbackPlaybook(curveadm, []*topology.DeployConfig{dc}, configs).Run()
+		if err != nil {
+			return err
+		}
+		err = waitUpgradeHealthy(curveadm, dcsAll, []*topology.DeployConfig{dc}, options.healthTimeout)
+		if err != nil {
+			return err
+		}
+
+		// the previous image is used up
+		err = curveadm.Storage().DeletePreviousImage(curveadm.ClusterId(), serviceId)
+		if err != nil {
+			return errno.ERR_DELETE_PREVIOUS_IMAGE_FAILED.E(err)
+		}
+	}
+
+	// 6) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Rollback %d services success :)", total))
+	return nil
+}
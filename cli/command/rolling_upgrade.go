@@ -0,0 +1,373 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	UPGRADE_STATE_PENDING   = "pending"
+	UPGRADE_STATE_UPGRADING = "upgrading"
+	UPGRADE_STATE_DONE      = "done"
+	UPGRADE_STATE_FAILED    = "failed"
+
+	UPGRADE_HEALTH_CHECK_INTERVAL = 5 * time.Second
+)
+
+func checkRollingUpgradeOptions(options upgradeOptions) error {
+	if options.pauseAfter < 0 {
+		return errno.ERR_INVALID_ROLLING_UPGRADE_OPTIONS.
+			F("--pause-after requires a non-negative integer")
+	} else if options.healthTimeout <= 0 {
+		return errno.ERR_INVALID_ROLLING_UPGRADE_OPTIONS.
+			F("--health-timeout requires a positive duration")
+	} else if options.resume && options.abort {
+		return errno.ERR_INVALID_ROLLING_UPGRADE_OPTIONS.
+			F("--resume and --abort can't be specified at the same time")
+	} else if options.rolling && (options.resume || options.abort) {
+		return errno.ERR_INVALID_ROLLING_UPGRADE_OPTIONS.
+			F("--rolling can't be specified with --resume or --abort")
+	} else if options.force && (options.rolling || options.resume) {
+		return errno.ERR_INVALID_ROLLING_UPGRADE_OPTIONS.
+			F("--force can't be specified with --rolling or --resume")
+	}
+	return nil
+}
+
+func setUpgradeState(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, status string) error {
+	for _, dc := range dcs {
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		err := curveadm.Storage().SetUpgradeState(curveadm.ClusterId(),
+			serviceId, dc.GetContainerImage(), status)
+		if err != nil {
+			return errno.ERR_SET_UPGRADE_STATE_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+// the states which not done, empty if there is no rolling upgrade in progress
+func getUnfinishedUpgradeStates(curveadm *cli.CurveAdm) ([]storage.UpgradeState, error) {
+	states, err := curveadm.Storage().GetUpgradeStates(curveadm.ClusterId())
+	if err != nil {
+		return nil, errno.ERR_GET_UPGRADE_STATES_FAILED.E(err)
+	}
+
+	unfinished := []storage.UpgradeState{}
+	for _, state := range states {
+		if state.Status != UPGRADE_STATE_DONE {
+			unfinished = append(unfinished, state)
+		}
+	}
+	return unfinished, nil
+}
+
+func zoneOfServices(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) (map[string]string, error) {
+	pool := configure.CurveClusterTopo{}
+	if len(curveadm.ClusterPoolData()) > 0 {
+		err := json.Unmarshal([]byte(curveadm.ClusterPoolData()), &pool)
+		if err != nil {
+			return nil, errno.ERR_DECODE_CLUSTER_POOL_JSON_FAILED.E(err)
+		}
+	}
+
+	zones := map[string]string{}
+	for _, dc := range dcs {
+		if server, ok := pool.LocateServer(dc); ok {
+			zones[dc.GetId()] = fmt.Sprintf("%s/%s", server.PhysicalPool+server.Pool, server.Zone)
+		}
+	}
+	return zones, nil
+}
+
+/*
+ * splitUpgradeUnits splits services into units which upgraded at a time,
+ * each service is an unit by default, and the chunkservers/metaservers
+ * in the same zone are grouped into one unit if byZone is true.
+ */
+func splitUpgradeUnits(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	byZone bool) ([][]*topology.DeployConfig, error) {
+	zones := map[string]string{}
+	if byZone {
+		var err error
+		zones, err = zoneOfServices(curveadm, dcs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	units := [][]*topology.DeployConfig{}
+	index := map[string]int{}
+	for _, dc := range dcs {
+		zone, ok := zones[dc.GetId()]
+		if !ok {
+			units = append(units, []*topology.DeployConfig{dc})
+			continue
+		}
+		if _, ok := index[zone]; !ok {
+			index[zone] = len(units)
+			units = append(units, []*topology.DeployConfig{})
+		}
+		units[index[zone]] = append(units[index[zone]], dc)
+	}
+	return units, nil
+}
+
+/*
+ * isUpgradeHealthy returns true if all services in unit are running,
+ * copysets are healthy and the leader count of services in unit are
+ * same as previous sample, which means the leader re-election stabilized.
+ */
+func isUpgradeHealthy(probes []task.HealthProbe,
+	unit map[string]bool,
+	prevLeaders map[string]string) (bool, map[string]string) {
+	healthy := true
+	leaders := map[string]string{}
+	for _, probe := range probes {
+		switch probe.Probe {
+		case task.HEALTH_PROBE_LIVENESS:
+			if unit[probe.Target] && probe.Status != task.HEALTH_STATUS_OK {
+				healthy = false
+			}
+		case task.HEALTH_PROBE_COPYSET:
+			if probe.Status != task.HEALTH_STATUS_OK {
+				healthy = false
+			}
+		case task.HEALTH_PROBE_LEADER_COUNT:
+			if !unit[probe.Target] {
+				continue
+			}
+			leaders[probe.Target] = probe.Value
+			prev, ok := prevLeaders[probe.Target]
+			if !ok || prev != probe.Value || probe.Status != task.HEALTH_STATUS_OK {
+				healthy = false
+			}
+		}
+	}
+	return healthy, leaders
+}
+
+func waitUpgradeHealthy(curveadm *cli.CurveAdm,
+	dcs, unit []*topology.DeployConfig,
+	timeout time.Duration) error {
+	// copyset health is probed by mds
+	targets := map[string]bool{}
+	probeDcs := []*topology.DeployConfig{}
+	for _, dc := range unit {
+		targets[curveadm.GetServiceId(dc.GetId())] = true
+		probeDcs = append(probeDcs, dc)
+	}
+	for _, dc := range curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS) {
+		if !targets[curveadm.GetServiceId(dc.GetId())] {
+			probeDcs = append(probeDcs, dc)
+		}
+	}
+
+	curveadm.WriteOut("Waiting services healthy...")
+	leaders := map[string]string{}
+	deadline := time.Now().Add(timeout)
+	for {
+		curveadm.MemStorage().Set(comm.KEY_ALL_HEALTH_PROBES, nil)
+		genWatchPlaybook(curveadm, probeDcs).Run()
+		probes := collectHealthProbes(curveadm, probeDcs)
+
+		var healthy bool
+		healthy, leaders = isUpgradeHealthy(probes, targets, leaders)
+		if healthy {
+			curveadm.WriteOutln(color.GreenString(" OK"))
+			return nil
+		} else if time.Now().After(deadline) {
+			curveadm.WriteOutln(color.RedString(" TIMEOUT"))
+			return errno.ERR_WAIT_SERVICES_HEALTHY_TIMEOUT.
+				F("timeout: %s", timeout)
+		}
+		time.Sleep(UPGRADE_HEALTH_CHECK_INTERVAL)
+	}
+}
+
+func displayRollingUpgradeTitle(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	units [][]*topology.DeployConfig) {
+	curveadm.WriteOutln(color.YellowString("Rolling upgrade %d services in %d units", len(dcs), len(units)))
+	curveadm.WriteOutln(color.YellowString("Upgrade services: %s", serviceStats(dcs)))
+}
+
+func runRollingUpgrade(curveadm *cli.CurveAdm,
+	dcsAll, dcs []*topology.DeployConfig,
+	options upgradeOptions) error {
+	// 1) split services into units
+	units, err := splitUpgradeUnits(curveadm, dcs, options.byZone)
+	if err != nil {
+		return err
+	}
+
+	// 2) display title and confirm by user
+	displayRollingUpgradeTitle(curveadm, dcs, units)
+	if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+		curveadm.WriteOut(tui.PromptCancelOpetation("upgrade service"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 3) upgrade units one by one and wait them healthy
+	total := len(units)
+	all := upgradeOptions{id: "*", role: "*", host: "*"}
+	for i, unit := range units {
+		curveadm.WriteOutln("")
+		curveadm.WriteOutln("Upgrade %s unit:", color.BlueString("%d/%d", i+1, total))
+		for _, dc := range unit {
+			curveadm.WriteOutln("  + host=%s  role=%s  image=%s", dc.GetHost(), dc.GetRole(), dc.GetContainerImage())
+		}
+
+		// 3.1) upgrade unit
+		err := setUpgradeState(curveadm, unit, UPGRADE_STATE_UPGRADING)
+		if err != nil {
+			return err
+		}
+		err = recordPreviousImages(curveadm, unit)
+		if err != nil {
+			return err
+		}
+		pb, err := genUpgradePlaybook(curveadm, unit, all)
+		if err == nil {
+			err = pb.Run()
+		}
+
+		// 3.2) health gate
+		if err == nil {
+			err = waitUpgradeHealthy(curveadm, dcsAll, unit, options.healthTimeout)
+		}
+		if err != nil {
+			setUpgradeState(curveadm, unit, UPGRADE_STATE_FAILED)
+			curveadm.WriteOutln(color.YellowString("Rolling upgrade stopped, " +
+				"run 'curveadm upgrade --resume' to retry or 'curveadm upgrade --abort' to abort"))
+			return err
+		}
+		err = setUpgradeState(curveadm, unit, UPGRADE_STATE_DONE)
+		if err != nil {
+			return err
+		}
+
+		// 3.3) pause after N units
+		if options.pauseAfter > 0 && i+1 == options.pauseAfter && i+1 < total {
+			curveadm.WriteOutln("")
+			curveadm.WriteOutln(color.YellowString("Rolling upgrade paused after %d units, "+
+				"run 'curveadm upgrade --resume' to continue", i+1))
+			return nil
+		}
+	}
+
+	// 4) clean upgrade states and print success prompt
+	err = curveadm.Storage().DeleteUpgradeStates(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_DELETE_UPGRADE_STATES_FAILED.E(err)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Rolling upgrade %d services success :)", len(dcs)))
+	return nil
+}
+
+func rollingUpgrade(curveadm *cli.CurveAdm,
+	dcsAll, dcs []*topology.DeployConfig,
+	options upgradeOptions) error {
+	// 1) only one rolling upgrade in progress
+	states, err := getUnfinishedUpgradeStates(curveadm)
+	if err != nil {
+		return err
+	} else if len(states) > 0 {
+		return errno.ERR_ROLLING_UPGRADE_IN_PROGRESS.
+			F("%d services not upgraded", len(states))
+	}
+
+	// 2) record states of services which to upgrade
+	err = curveadm.Storage().DeleteUpgradeStates(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_DELETE_UPGRADE_STATES_FAILED.E(err)
+	}
+	err = setUpgradeState(curveadm, dcs, UPGRADE_STATE_PENDING)
+	if err != nil {
+		return err
+	}
+
+	// 3) rolling upgrade
+	return runRollingUpgrade(curveadm, dcsAll, dcs, options)
+}
+
+func resumeRollingUpgrade(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options upgradeOptions) error {
+	// 1) get services which not upgraded
+	states, err := getUnfinishedUpgradeStates(curveadm)
+	if err != nil {
+		return err
+	} else if len(states) == 0 {
+		return errno.ERR_NO_ROLLING_UPGRADE_IN_PROGRESS
+	}
+
+	// 2) services maybe removed from topology after paused
+	m := map[string]*topology.DeployConfig{}
+	for _, dc := range dcs {
+		m[curveadm.GetServiceId(dc.GetId())] = dc
+	}
+	dcs2upgrade := []*topology.DeployConfig{}
+	for _, state := range states {
+		if dc, ok := m[state.ServiceId]; ok {
+			dcs2upgrade = append(dcs2upgrade, dc)
+		}
+	}
+	if len(dcs2upgrade) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 3) rolling upgrade
+	return runRollingUpgrade(curveadm, dcs, dcs2upgrade, options)
+}
+
+func abortRollingUpgrade(curveadm *cli.CurveAdm) error {
+	states, err := getUnfinishedUpgradeStates(curveadm)
+	if err != nil {
+		return err
+	} else if len(states) == 0 {
+		return errno.ERR_NO_ROLLING_UPGRADE_IN_PROGRESS
+	}
+
+	err = curveadm.Storage().DeleteUpgradeStates(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_DELETE_UPGRADE_STATES_FAILED.E(err)
+	}
+	curveadm.WriteOutln(color.GreenString("Rolling upgrade aborted, %d services not upgraded", len(states)))
+	return nil
+}
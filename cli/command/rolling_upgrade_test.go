@@ -0,0 +1,73 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"testing"
+
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func probe(name, target, status, value string) task.HealthProbe {
+	return task.HealthProbe{Probe: name, Target: target, Status: status, Value: value}
+}
+
+func TestRollingUpgrade_IsUpgradeHealthy(t *testing.T) {
+	assert := assert.New(t)
+	unit := map[string]bool{"cs1": true}
+	ok := task.HEALTH_STATUS_OK
+
+	// leader count requires twice same samples
+	probes := []task.HealthProbe{
+		probe(task.HEALTH_PROBE_LIVENESS, "cs1", ok, "running"),
+		probe(task.HEALTH_PROBE_LEADER_COUNT, "cs1", ok, "10"),
+		probe(task.HEALTH_PROBE_COPYSET, "mds1", ok, "healthy"),
+	}
+	healthy, leaders := isUpgradeHealthy(probes, unit, map[string]string{})
+	assert.False(healthy)
+	assert.Equal(map[string]string{"cs1": "10"}, leaders)
+	healthy, _ = isUpgradeHealthy(probes, unit, leaders)
+	assert.True(healthy)
+	healthy, _ = isUpgradeHealthy(probes, unit, map[string]string{"cs1": "8"})
+	assert.False(healthy)
+
+	// unhealthy copysets or service not running
+	healthy, _ = isUpgradeHealthy([]task.HealthProbe{
+		probe(task.HEALTH_PROBE_LIVENESS, "cs1", ok, "running"),
+		probe(task.HEALTH_PROBE_COPYSET, "mds1", task.HEALTH_STATUS_CRITICAL, "unhealthy"),
+	}, unit, map[string]string{})
+	assert.False(healthy)
+	healthy, _ = isUpgradeHealthy([]task.HealthProbe{
+		probe(task.HEALTH_PROBE_LIVENESS, "cs1", task.HEALTH_STATUS_UNKNOWN, "unreachable"),
+	}, unit, map[string]string{})
+	assert.False(healthy)
+
+	// services out of unit are ignored
+	healthy, _ = isUpgradeHealthy([]task.HealthProbe{
+		probe(task.HEALTH_PROBE_LIVENESS, "cs1", ok, "running"),
+		probe(task.HEALTH_PROBE_LIVENESS, "cs2", task.HEALTH_STATUS_CRITICAL, "exited"),
+		probe(task.HEALTH_PROBE_LEADER_COUNT, "cs2", ok, "3"),
+	}, unit, map[string]string{})
+	assert.True(healthy)
+}
@@ -0,0 +1,647 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	SCALE_IN_EXAMPLE = `Examples:
+  $ curveadm scale-in --host host4                       # Retire all chunkservers/metaservers in host4
+  $ curveadm scale-in --host host4 --chunkserver-id 10   # Retire the chunkserver which id is 10 in host4
+  $ curveadm scale-in --host host4 --role etcd           # Retire etcd in host4 and remove it from etcd cluster
+  $ curveadm scale-in --host host4 --timeout 2h -y       # Retire services without confirmation`
+
+	SCALE_IN_DRAIN_CHECK_INTERVAL = 10 * time.Second
+)
+
+var (
+	SCALE_IN_ROLES = map[string]string{
+		topology.KIND_CURVEBS: topology.ROLE_CHUNKSERVER,
+		topology.KIND_CURVEFS: topology.ROLE_METASERVER,
+	}
+
+	// the storage role of cluster kind is the default one
+	SCALE_IN_ALLOWED_ROLES = map[string]bool{
+		topology.ROLE_ETCD:        true,
+		topology.ROLE_MDS:         true,
+		topology.ROLE_CHUNKSERVER: true,
+		topology.ROLE_METASERVER:  true,
+	}
+)
+
+type scaleInOptions struct {
+	host          string
+	role          string
+	chunkserverId int
+	timeout       time.Duration
+	yes           bool
+}
+
+func checkScaleInOptions(options scaleInOptions) error {
+	if len(options.host) == 0 {
+		return errno.ERR_INVALID_SCALE_IN_OPTIONS.
+			F("--host must be specified")
+	} else if len(options.role) > 0 && !SCALE_IN_ALLOWED_ROLES[options.role] {
+		return errno.ERR_INVALID_SCALE_IN_OPTIONS.
+			F("--role must be one of etcd, mds, chunkserver and metaserver")
+	} else if options.timeout <= 0 {
+		return errno.ERR_INVALID_SCALE_IN_OPTIONS.
+			F("--timeout requires a positive duration")
+	}
+	return nil
+}
+
+func NewScaleInCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options scaleInOptions
+
+	cmd := &cobra.Command{
+		Use:     "scale-in [OPTIONS]",
+		Short:   "Scale in cluster",
+		Args:    cliutil.NoArgs,
+		Example: SCALE_IN_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkScaleInOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScaleIn(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.host, "host", "", "Specify host which services retire from")
+	flags.StringVar(&options.role, "role", "", "Specify role of services to retire (default chunkserver/metaserver)")
+	flags.IntVar(&options.chunkserverId, "chunkserver-id", -1, "Specify chunkserver id to retire (curvebs only)")
+	flags.DurationVar(&options.timeout, "timeout", 24*time.Hour, "Specify timeout for waiting data migrated")
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Scale in cluster without confirmation")
+
+	return cmd
+}
+
+/*
+ * deployItems returns the line range [start, end) of each deploy item
+ * in section "<role>_services", e.g.
+ *
+ * chunkserver_services:
+ *   deploy:
+ *     - host: server-host1   <- start
+ *       instances: 3
+ *     - host: server-host2   <- end
+ */
+func deployItems(lines []string, role string) [][2]int {
+	section := regexp.MustCompile(fmt.Sprintf(`^%s_services\s*:`, role))
+	deploy := regexp.MustCompile(`^(\s*)deploy\s*:`)
+	item := regexp.MustCompile(`^(\s*)-(\s|$)`)
+	indentOf := func(line string) int { return len(line) - len(strings.TrimLeft(line, " ")) }
+	isContent := func(line string) bool {
+		trimed := strings.TrimSpace(line)
+		return len(trimed) > 0 && trimed[0] != '#'
+	}
+
+	items := [][2]int{}
+	inSection, inDeploy := false, false
+	deployIndent, itemIndent := -1, -1
+	for i, line := range lines {
+		if !isContent(line) {
+			continue
+		} else if line[0] != ' ' {
+			inSection = section.MatchString(line)
+			inDeploy = false
+			continue
+		} else if !inSection {
+			continue
+		}
+
+		indent := indentOf(line)
+		if inDeploy && indent <= deployIndent && !item.MatchString(line) {
+			inDeploy = false
+		}
+		if !inDeploy {
+			if mu := deploy.FindStringSubmatch(line); mu != nil {
+				inDeploy, deployIndent, itemIndent = true, len(mu[1]), -1
+			}
+			continue
+		}
+
+		if mu := item.FindStringSubmatch(line); mu != nil &&
+			(itemIndent == -1 || len(mu[1]) == itemIndent) {
+			itemIndent = len(mu[1])
+			items = append(items, [2]int{i, i + 1})
+		} else if len(items) > 0 {
+			items[len(items)-1][1] = i + 1
+		}
+	}
+	return items
+}
+
+/*
+ * scaleInTopology rewrites the deploy item of role which host sequence
+ * is hostSequence: it keeps remain instances of the item if remain > 0,
+ * otherwise it removes the whole item. The later items are pinned with
+ * their original name to keep their service ids unchanged.
+ */
+func scaleInTopology(data, role string, hostSequence, remain int) (string, error) {
+	lines := strings.Split(data, "\n")
+	items := deployItems(lines, role)
+	if hostSequence < 0 || hostSequence >= len(items) {
+		return "", errno.ERR_REWRITE_TOPOLOGY_FOR_SCALE_IN_FAILED.
+			F("%s deploy item #%d not found", role, hostSequence)
+	}
+
+	if remain > 0 {
+		instances := regexp.MustCompile(`^(\s*(?:-\s+)?(?:instances|replicas|replica)\s*:\s*)\d+(.*)$`)
+		item := items[hostSequence]
+		for i := item[0]; i < item[1]; i++ {
+			if instances.MatchString(lines[i]) {
+				lines[i] = instances.ReplaceAllString(lines[i], "${1}"+strconv.Itoa(remain)+"${2}")
+				return strings.Join(lines, "\n"), nil
+			}
+		}
+		return "", errno.ERR_REWRITE_TOPOLOGY_FOR_SCALE_IN_FAILED.
+			F("instances of %s deploy item #%d not found", role, hostSequence)
+	}
+
+	name := regexp.MustCompile(`^\s*(?:-\s+)?name\s*:`)
+	first := regexp.MustCompile(`^(\s*-\s*)`)
+	out := []string{}
+	out = append(out, lines[:items[hostSequence][0]]...)
+	for seq := hostSequence + 1; seq < len(items); seq++ {
+		item := lines[items[seq][0]:items[seq][1]]
+		named := false
+		for _, line := range item {
+			named = named || name.MatchString(line)
+		}
+		out = append(out, item[0])
+		if !named {
+			indent := len(first.FindString(item[0]))
+			if strings.TrimSpace(item[0]) == "-" {
+				indent += 2
+			}
+			out = append(out, fmt.Sprintf("%sname: \"%d\"", strings.Repeat(" ", indent), seq))
+		}
+		out = append(out, item[1:]...)
+	}
+	out = append(out, lines[items[len(items)-1][1]:]...)
+	return strings.Join(out, "\n"), nil
+}
+
+// the default role is the storage role of cluster kind, it's ambiguous for
+// mixed topology if both chunkserver and metaserver deployed in the host
+func getDefaultScaleInRole(dcs []*topology.DeployConfig, host string) (string, error) {
+	roles := []string{}
+	for _, part := range topology.SplitByKind(dcs) {
+		role := SCALE_IN_ROLES[part[0].GetKind()]
+		for _, dc := range part {
+			if dc.GetRole() == role && dc.GetHost() == host {
+				roles = append(roles, role)
+				break
+			}
+		}
+	}
+
+	if len(roles) > 1 {
+		return "", errno.ERR_INVALID_SCALE_IN_OPTIONS.
+			F("both %s are deployed in host %s, please specify --role", strings.Join(roles, " and "), host)
+	} else if len(roles) == 0 {
+		return SCALE_IN_ROLES[topology.SplitByKind(dcs)[0][0].GetKind()], nil
+	}
+	return roles[0], nil
+}
+
+func getScaleInServices(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options scaleInOptions) ([]*topology.DeployConfig, error) {
+	role := options.role
+	if len(role) == 0 {
+		var err error
+		role, err = getDefaultScaleInRole(dcs, options.host)
+		if err != nil {
+			return nil, err
+		}
+	}
+	services := []*topology.DeployConfig{}
+	for _, dc := range curveadm.FilterDeployConfigByRole(dcs, role) {
+		if dc.GetHost() == options.host {
+			services = append(services, dc)
+		}
+	}
+	if len(services) == 0 {
+		return nil, errno.ERR_NO_SERVICES_FOR_SCALE_IN_CLUSTER.
+			F("no %s services in host %s", role, options.host)
+	} else if options.chunkserverId < 0 {
+		return services, nil
+	} else if role != topology.ROLE_CHUNKSERVER {
+		return nil, errno.ERR_INVALID_SCALE_IN_OPTIONS.
+			F("--chunkserver-id only supports curvebs cluster")
+	}
+
+	// locate the chunkserver by its address
+	loads, err := getChunkserverLoads(curveadm, dcs)
+	if err != nil {
+		return nil, err
+	}
+	addr := ""
+	for _, load := range loads {
+		if load.Id == options.chunkserverId {
+			addr = load.Addr
+		}
+	}
+	for _, dc := range services {
+		if cliutil.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()) != addr {
+			continue
+		} else if dc.GetInstancesSequence() != dc.GetInstances()-1 {
+			return nil, errno.ERR_SCALE_IN_NON_LAST_INSTANCE_IS_DENIED.
+				F("chunkserver %d is instance %d of %d in host %s",
+					options.chunkserverId, dc.GetInstancesSequence(), dc.GetInstances(), options.host)
+		}
+		return []*topology.DeployConfig{dc}, nil
+	}
+	return nil, errno.ERR_NO_SERVICES_FOR_SCALE_IN_CLUSTER.
+		F("chunkserver %d not found in host %s", options.chunkserverId, options.host)
+}
+
+func readScaleInTopology(curveadm *cli.CurveAdm, dcs2del []*topology.DeployConfig) (string, error) {
+	// the number of instances to retire for each deploy item
+	retires := map[int]int{}
+	instances := map[int]int{}
+	for _, dc := range dcs2del {
+		retires[dc.GetHostSequence()]++
+		instances[dc.GetHostSequence()] = dc.GetInstances()
+	}
+	sequences := []int{}
+	for seq := range retires {
+		sequences = append(sequences, seq)
+	}
+	// rewrite from the last item, the former item's name is not pinned yet
+	sort.Sort(sort.Reverse(sort.IntSlice(sequences)))
+
+	var err error
+	oldData := curveadm.ClusterTopologyData()
+	data := oldData
+	role := dcs2del[0].GetRole()
+	for _, seq := range sequences {
+		data, err = scaleInTopology(data, role, seq, instances[seq]-retires[seq])
+		if err != nil {
+			return "", err
+		}
+	}
+	curveadm.WriteOut("%s", cliutil.Diff(oldData, data))
+	return data, nil
+}
+
+// the rewritten topology should only delete the retired services
+func checkScaleInTopology(curveadm *cli.CurveAdm,
+	dcs, dcs2del []*topology.DeployConfig,
+	data string) error {
+	diffs, err := diffTopology(curveadm, data)
+	if err != nil {
+		return err
+	}
+
+	expect := map[string]bool{}
+	for _, dc := range dcs2del {
+		expect[dc.GetId()] = true
+	}
+	deleted := diffs[topology.DIFF_DELETE]
+	if len(diffs[topology.DIFF_ADD]) > 0 || len(diffs[topology.DIFF_CHANGE]) > 0 {
+		return errno.ERR_REWRITE_TOPOLOGY_FOR_SCALE_IN_FAILED.
+			F("unexpected services added or changed")
+	} else if len(deleted) != len(dcs2del) {
+		return errno.ERR_REWRITE_TOPOLOGY_FOR_SCALE_IN_FAILED.
+			F("expect %d services deleted, but %d", len(dcs2del), len(deleted))
+	}
+	for _, dc := range deleted {
+		if !expect[dc.GetId()] {
+			return errno.ERR_REWRITE_TOPOLOGY_FOR_SCALE_IN_FAILED.
+				F("unexpected service deleted: %s", dc.GetId())
+		}
+	}
+
+	role := dcs2del[0].GetRole()
+	remains := excludeDeployConfigs(curveadm.FilterDeployConfigByRole(dcs, role), dcs2del)
+	if getHostNum(remains) < 3 {
+		return errno.ERR_REQUIRES_3_HOSTS_AFTER_SCALE_IN.
+			F("%s: %d hosts remain", role, getHostNum(remains))
+	}
+	return nil
+}
+
+func getChunkserverLoads(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) ([]bs.ChunkserverLoad, error) {
+	curveadm.MemStorage().Set(comm.KEY_ALL_CHUNKSERVER_LOADS, nil)
+	pb := genBalancePlaybook(curveadm, dcs, playbook.GET_CHUNKSERVER_LOAD)
+	if err := pb.Run(); err != nil {
+		return nil, err
+	}
+	loads := []bs.ChunkserverLoad{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_CHUNKSERVER_LOADS); v != nil {
+		loads = v.([]bs.ChunkserverLoad)
+	}
+	return loads, nil
+}
+
+// mark chunkservers as pendding, then mds will migrate copysets out of them
+func drainChunkservers(curveadm *cli.CurveAdm,
+	dcs, dcs2del []*topology.DeployConfig,
+	options scaleInOptions) error {
+	addrs := map[string]bool{}
+	for _, dc := range dcs2del {
+		addrs[cliutil.JoinHostPort(dc.GetListenIp(), dc.GetListenPort())] = true
+	}
+	loads, err := getChunkserverLoads(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+	ids := []int{}
+	for _, load := range loads {
+		if addrs[load.Addr] {
+			ids = append(ids, load.Id)
+		}
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.SET_CHUNKSERVER_PENDDING,
+		Configs: curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)[:1],
+		Options: map[string]interface{}{
+			comm.KEY_SCALE_IN_CHUNKSERVER_IDS: ids,
+		},
+	})
+	if err = pb.Run(); err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	deadline := time.Now().Add(options.timeout)
+	for {
+		copysets := 0
+		for _, load := range loads {
+			if addrs[load.Addr] {
+				copysets += load.Copysets
+			}
+		}
+		if copysets == 0 {
+			curveadm.WriteOutln(color.GreenString("All copysets migrated out of chunkservers"))
+			return nil
+		} else if time.Now().After(deadline) {
+			return errno.ERR_WAIT_CHUNKSERVERS_DRAINED_TIMEOUT.
+				F("timeout: %s, %d copysets remain", options.timeout, copysets)
+		}
+		curveadm.WriteOutln("Waiting %d copysets migrated...", copysets)
+		time.Sleep(SCALE_IN_DRAIN_CHECK_INTERVAL)
+		loads, err = getChunkserverLoads(curveadm, dcs)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func genScaleInPlaybook(curveadm *cli.CurveAdm,
+	dcs, dcs2del []*topology.DeployConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	if dcs2del[0].GetRole() != topology.ROLE_ETCD {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.STOP_SERVICE,
+			Configs: dcs2del,
+		})
+		return pb
+	}
+
+	// etcd members are removed one by one, the next one is removed
+	// after the cluster without the former one is healthy
+	remains := excludeDeployConfigs(curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_ETCD), dcs2del)
+	for i, dc := range dcs2del {
+		cluster := append(append([]*topology.DeployConfig{}, remains...), dcs2del[i+1:]...)
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.REMOVE_ETCD_MEMBER,
+			Configs: remains[:1],
+			Options: map[string]interface{}{
+				comm.KEY_ETCD_MEMBER: dc,
+			},
+		})
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.STOP_SERVICE,
+			Configs: []*topology.DeployConfig{dc},
+		})
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.WAIT_ETCD_HEALTHY,
+			Configs: remains[:1],
+			Options: map[string]interface{}{
+				comm.KEY_ETCD_CLUSTER: cluster,
+			},
+		})
+	}
+	return pb
+}
+
+// sync config of remaining services which reference the retired services, e.g: etcd peers
+func genScaleInSyncPeersPlaybook(curveadm *cli.CurveAdm, data string) (*playbook.Playbook, error) {
+	dcs, err := curveadm.ParseTopologyData(data)
+	if err != nil {
+		return nil, err
+	}
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.SYNC_CONFIG,
+		Configs: dcs,
+	})
+	return pb, nil
+}
+
+func genScaleInCleanPlaybook(curveadm *cli.CurveAdm,
+	dcs2del []*topology.DeployConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.CLEAN_SERVICE,
+		Configs: dcs2del,
+		Options: map[string]interface{}{
+			comm.KEY_CLEAN_ITEMS: []string{
+				comm.CLEAN_ITEM_LOG,
+				comm.CLEAN_ITEM_DATA,
+				comm.CLEAN_ITEM_CONTAINER,
+			},
+			comm.KEY_CLEAN_BY_RECYCLE: true,
+		},
+	})
+	return pb
+}
+
+// remove service records and servers of cluster pool, then update topology
+func commitScaleIn(curveadm *cli.CurveAdm, dcs2del []*topology.DeployConfig, data string) error {
+	for _, dc := range dcs2del {
+		err := curveadm.Storage().DeleteService(curveadm.GetServiceId(dc.GetId()))
+		if err != nil {
+			return errno.ERR_DELETE_SERVICE_CONTAINER_ID_FAILED.E(err)
+		}
+	}
+
+	poolData := curveadm.ClusterPoolData()
+	if len(poolData) > 0 {
+		pool := configure.CurveClusterTopo{}
+		err := json.Unmarshal([]byte(poolData), &pool)
+		if err != nil {
+			return errno.ERR_DECODE_CLUSTER_POOL_JSON_FAILED.E(err)
+		}
+		retired := map[string]bool{}
+		for _, dc := range dcs2del {
+			if server, ok := pool.LocateServer(dc); ok {
+				retired[server.Name] = true
+			}
+		}
+		servers := []configure.Server{}
+		for _, server := range pool.Servers {
+			if !retired[server.Name] {
+				servers = append(servers, server)
+			}
+		}
+		pool.Servers = servers
+		bytes, err := json.Marshal(pool)
+		if err != nil {
+			return errno.ERR_ENCODE_CLUSTER_POOL_JSON_FAILED.E(err)
+		}
+		poolData = string(bytes)
+	}
+
+	err := curveadm.Storage().SetClusterPool(curveadm.ClusterId(), data, poolData)
+	if err != nil {
+		return errno.ERR_UPDATE_CLUSTER_POOL_FAILED.E(err)
+	}
+	return nil
+}
+
+func displayScaleInTitle(curveadm *cli.CurveAdm, dcs2del []*topology.DeployConfig) {
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.YellowString("NOTICE: cluster '%s' is about to scale in:",
+		curveadm.ClusterName()))
+	curveadm.WriteOutln(color.YellowString("  - Scale in services: %s*%d",
+		dcs2del[0].GetRole(), len(dcs2del)))
+	curveadm.WriteOutln(color.YellowString("  - Scale in host: %s", dcs2del[0].GetHost()))
+	curveadm.WriteOutln(color.YellowString("WARNING: data of these services will be cleaned after migrated"))
+}
+
+func runScaleIn(curveadm *cli.CurveAdm, options scaleInOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) get services to retire
+	dcs2del, err := getScaleInServices(curveadm, dcs, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) rewrite topology and check it
+	data, err := readScaleInTopology(curveadm, dcs2del)
+	if err != nil {
+		return err
+	}
+	err = checkScaleInTopology(curveadm, dcs, dcs2del, data)
+	if err != nil {
+		return err
+	}
+
+	// 4) display title
+	displayScaleInTitle(curveadm, dcs2del)
+
+	// 5) confirm by user
+	if !options.yes {
+		if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+			curveadm.WriteOutln(tui.PromptCancelOpetation("scale-in"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 6) migrate data out of chunkservers (curvebs)
+	if dcs2del[0].GetRole() == topology.ROLE_CHUNKSERVER {
+		err = drainChunkservers(curveadm, dcs, dcs2del, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 7) stop services, etcd members are removed from cluster before stopped
+	err = genScaleInPlaybook(curveadm, dcs, dcs2del).Run()
+	if err != nil {
+		return err
+	}
+
+	// 8) wait copysets recovered by other metaservers (curvefs) OR mds leader elected
+	role := dcs2del[0].GetRole()
+	if role == topology.ROLE_METASERVER || role == topology.ROLE_MDS {
+		curveadm.WriteOutln("")
+		remains := excludeDeployConfigs(dcs, dcs2del)
+		err = waitUpgradeHealthy(curveadm, remains, nil, options.timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 9) clean data, log and container of services
+	err = genScaleInCleanPlaybook(curveadm, dcs2del).Run()
+	if err != nil {
+		return err
+	}
+
+	// 10) update service records, cluster pool and topology
+	err = commitScaleIn(curveadm, dcs2del, data)
+	if err != nil {
+		return err
+	}
+
+	// 11) update peer addresses in config of remaining services (etcd, mds)
+	if MIGRATE_SYNC_PEERS_ROLES[role] {
+		pb, err := genScaleInSyncPeersPlaybook(curveadm, data)
+		if err != nil {
+			return err
+		} else if err = pb.Run(); err != nil {
+			return err
+		}
+	}
+
+	// 12) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Cluster '%s' successfully scaled in ^_^.",
+		curveadm.ClusterName()))
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const scaleInTopologyData = `kind: curvebs
+chunkserver_services:
+  config:
+    copysets: 100
+  deploy:
+    - host: host1
+      instances: 3
+    - host: host2  # comment
+      instances: 3
+      config:
+        data_dir: /data
+    - host: host3
+      name: host3
+      instances: 3
+    - host: host4
+      instances: 3
+
+snapshotclone_services:
+  deploy:
+    - host: host1
+`
+
+func TestScaleIn_RemoveDeployItem(t *testing.T) {
+	assert := assert.New(t)
+
+	out, err := scaleInTopology(scaleInTopologyData, "chunkserver", 1, 0)
+	assert.Nil(err)
+	assert.Equal(`kind: curvebs
+chunkserver_services:
+  config:
+    copysets: 100
+  deploy:
+    - host: host1
+      instances: 3
+    - host: host3
+      name: host3
+      instances: 3
+    - host: host4
+      name: "3"
+      instances: 3
+
+snapshotclone_services:
+  deploy:
+    - host: host1
+`, out)
+
+	// the last item
+	out, err = scaleInTopology(scaleInTopologyData, "chunkserver", 3, 0)
+	assert.Nil(err)
+	assert.NotContains(out, "host4")
+	assert.Contains(out, "      instances: 3\n\nsnapshotclone_services")
+}
+
+func TestScaleIn_DecreaseInstances(t *testing.T) {
+	assert := assert.New(t)
+
+	out, err := scaleInTopology(scaleInTopologyData, "chunkserver", 1, 2)
+	assert.Nil(err)
+	assert.Contains(out, "    - host: host2  # comment\n      instances: 2\n")
+	assert.Equal(1, strings.Count(out, "instances: 2"))
+}
+
+func TestScaleIn_DeployItemNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := scaleInTopology(scaleInTopologyData, "chunkserver", 4, 0)
+	assert.NotNil(err)
+	_, err = scaleInTopology(scaleInTopologyData, "metaserver", 0, 0)
+	assert.NotNil(err)
+	_, err = scaleInTopology(scaleInTopologyData, "snapshotclone", 0, 1)
+	assert.NotNil(err) // no instances line
+}
+
+func TestScaleIn_CheckOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(checkScaleInOptions(scaleInOptions{host: "host1", timeout: time.Hour}))
+	assert.Nil(checkScaleInOptions(scaleInOptions{host: "host1", role: "etcd", timeout: time.Hour}))
+	assert.Nil(checkScaleInOptions(scaleInOptions{host: "host1", role: "mds", timeout: time.Hour}))
+	assert.NotNil(checkScaleInOptions(scaleInOptions{host: "host1", role: "snapshotclone", timeout: time.Hour}))
+	assert.NotNil(checkScaleInOptions(scaleInOptions{role: "etcd", timeout: time.Hour}))
+	assert.NotNil(checkScaleInOptions(scaleInOptions{host: "host1"}))
+}
@@ -23,6 +23,7 @@
 package command
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/fatih/color"
@@ -31,21 +32,30 @@ import (
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/i18n"
 	"github.com/opencurve/curveadm/internal/playbook"
-	tui "github.com/opencurve/curveadm/internal/tui/common"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/tui"
+	tuicomm "github.com/opencurve/curveadm/internal/tui/common"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	utils "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 var (
-	// etcd
+	// etcd: the members are added one by one by SCALE_OUT_ETCD_MEMBER_STEPS at first
 	SCALE_OUT_ETCD_STEPS = []int{
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// the next member is added after the cluster with the former one is healthy
+	SCALE_OUT_ETCD_MEMBER_STEPS = []int{
+		playbook.ADD_ETCD_MEMBER,
 		playbook.PULL_IMAGE,
 		playbook.CREATE_CONTAINER,
 		playbook.SYNC_CONFIG,
 		playbook.START_SERVICE,
-		playbook.UPDATE_TOPOLOGY,
+		playbook.WAIT_ETCD_HEALTHY,
 	}
 
 	// mds
@@ -119,6 +129,7 @@ type scaleOutOptions struct {
 	filename        string
 	poolset         string
 	poolsetDiskType string
+	skipZoneCheck   bool
 }
 
 func NewScaleOutCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -140,6 +151,7 @@ func NewScaleOutCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		"Scale out cluster without precheck")
 	flags.StringVar(&options.poolset, "poolset", "default", "Specify the poolset name")
 	flags.StringVar(&options.poolsetDiskType, "poolset-disktype", "ssd", "Specify the disk type of physical pool")
+	flags.BoolVar(&options.skipZoneCheck, "skip-zone-check", false, "Scale out cluster even if zones are unbalanced")
 
 	return cmd
 }
@@ -224,14 +236,20 @@ func checkScaleOutTopology(curveadm *cli.CurveAdm, data string) error {
 }
 
 func genScaleOutPrecheckPlaybook(curveadm *cli.CurveAdm, data string) (*playbook.Playbook, error) {
-	dcsAll, _ := curveadm.ParseTopologyData(data)
-	kind := dcsAll[0].GetKind()
+	dcsAll, err := curveadm.ParseTopologyData(data)
+	if err != nil {
+		return nil, err
+	}
+	diffs, err := diffTopology(curveadm, data)
+	if err != nil {
+		return nil, err
+	}
+	dcs2scaleOut := diffs[topology.DIFF_ADD]
+	kind := dcs2scaleOut[0].GetKind() // the kind of scaled out services for mixed topology
 	steps := CURVEFS_PRECHECK_STEPS
 	if kind == topology.KIND_CURVEBS {
 		steps = CURVEBS_PRECHECK_STEPS
 	}
-	diffs, _ := diffTopology(curveadm, data)
-	dcs2scaleOut := diffs[topology.DIFF_ADD]
 
 	// add playbook step
 	pb := playbook.NewPlaybook(curveadm)
@@ -314,13 +332,19 @@ func genScaleOutPlaybook(curveadm *cli.CurveAdm,
 	dcs []*topology.DeployConfig,
 	data string,
 	options scaleOutOptions) (*playbook.Playbook, error) {
-	diffs, _ := diffTopology(curveadm, data)
+	diffs, err := diffTopology(curveadm, data)
+	if err != nil {
+		return nil, err
+	}
 	dcs2scaleOut := diffs[topology.DIFF_ADD]
 	role := dcs2scaleOut[0].GetRole()
 	steps := SCALE_OUT_ROLE_STEPS[role]
 	poolset := configure.Poolset{Name: options.poolset, Type: options.poolsetDiskType}
 
 	pb := playbook.NewPlaybook(curveadm)
+	if role == topology.ROLE_ETCD {
+		addScaleOutEtcdMemberSteps(curveadm, pb, dcs, dcs2scaleOut)
+	}
 	for _, step := range steps {
 		// configs
 		config := dcs2scaleOut
@@ -361,17 +385,97 @@ func genScaleOutPlaybook(curveadm *cli.CurveAdm,
 			},
 		})
 	}
+
+	// sync config of other services which reference the new services, e.g: etcd peers
+	if MIGRATE_SYNC_PEERS_ROLES[role] {
+		dcsNew, err := curveadm.ParseTopologyData(data)
+		if err != nil {
+			return nil, err
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.SYNC_CONFIG,
+			Configs: excludeDeployConfigs(dcsNew, dcs2scaleOut),
+		})
+	}
 	return pb, nil
 }
 
-func displayScaleOutTitle(curveadm *cli.CurveAdm, data string) {
-	diffs, _ := diffTopology(curveadm, data)
-	dcs := diffs[topology.DIFF_ADD]
+/*
+ * addScaleOutEtcdMemberSteps adds etcd members one by one: the member is
+ * added by the first existing etcd, then it starts to join the cluster
+ * which consists of existing members and the former added ones, the
+ * initial-cluster of it should be exactly these members.
+ */
+func addScaleOutEtcdMemberSteps(curveadm *cli.CurveAdm,
+	pb *playbook.Playbook,
+	dcs, dcs2add []*topology.DeployConfig) {
+	etcds := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_ETCD)
+	members := append([]*topology.DeployConfig{}, etcds...)
+	for _, dc := range dcs2add {
+		members = append(members, dc)
+		cluster := append([]*topology.DeployConfig{}, members...)
+		for _, step := range SCALE_OUT_ETCD_MEMBER_STEPS {
+			config := []*topology.DeployConfig{dc}
+			options := map[string]interface{}{}
+			switch step {
+			case playbook.ADD_ETCD_MEMBER:
+				config = etcds[:1]
+				options[comm.KEY_ETCD_MEMBER] = dc
+			case playbook.SYNC_CONFIG:
+				config = []*topology.DeployConfig{dc.
+					WithServiceConfig(task.ETCD_INITIAL_CLUSTER_STATE, task.ETCD_INITIAL_CLUSTER_STATE_EXISTING).
+					WithServiceConfig(task.ETCD_INITIAL_CLUSTER, task.EtcdInitialCluster(cluster))}
+			case playbook.WAIT_ETCD_HEALTHY:
+				config = etcds[:1]
+				options[comm.KEY_ETCD_CLUSTER] = cluster
+			}
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    step,
+				Configs: config,
+				Options: options,
+			})
+		}
+	}
+}
+
+// only scale out chunkserver or metaserver will change the cluster pool,
+// the unbalanced zones is only warned if user specified --skip-zone-check
+func planScaleOut(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options scaleOutOptions) (*configure.ScaleOutPlan, error) {
+	role := dcs[0].GetRole()
+	if role != topology.ROLE_CHUNKSERVER && role != topology.ROLE_METASERVER {
+		return nil, nil
+	}
+
+	pool := configure.CurveClusterTopo{}
+	if len(curveadm.ClusterPoolData()) > 0 {
+		err := json.Unmarshal([]byte(curveadm.ClusterPoolData()), &pool)
+		if err != nil {
+			return nil, errno.ERR_DECODE_CLUSTER_POOL_JSON_FAILED.E(err)
+		}
+	}
+	poolset := configure.Poolset{Name: options.poolset, Type: options.poolsetDiskType}
+	pool = configure.SelectClusterPool(pool, dcs[0].GetKind())
+	plan := configure.PlanScaleOut(pool, dcs, poolset)
+	err := plan.CheckZoneBalance()
+	if err != nil && options.skipZoneCheck {
+		curveadm.WriteOutln("%s", color.YellowString("WARNING: %s", err.Error()))
+		err = nil
+	}
+	return &plan, err
+}
+
+func displayScaleOutTitle(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, plan *configure.ScaleOutPlan) {
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln(color.YellowString("NOTICE: cluster '%s' is about to scale out:",
 		curveadm.ClusterName()))
 	curveadm.WriteOutln(color.YellowString("  - Scale out services: %s*%d",
 		dcs[0].GetRole(), len(dcs)))
+	if plan != nil {
+		curveadm.WriteOutln("")
+		curveadm.WriteOut(tui.FormatScaleOutPlan(*plan))
+	}
 }
 
 func runScaleOut(curveadm *cli.CurveAdm, options scaleOutOptions) error {
@@ -393,33 +497,51 @@ func runScaleOut(curveadm *cli.CurveAdm, options scaleOutOptions) error {
 		return err
 	}
 
-	// 4) display title
-	displayScaleOutTitle(curveadm, data)
+	diffs, err := diffTopology(curveadm, data)
+	if err != nil {
+		return err
+	}
+	dcs2add := diffs[topology.DIFF_ADD]
+
+	// 4) plan the layout of cluster pool and check zone balance
+	plan, err := planScaleOut(curveadm, dcs2add, options)
+	if err != nil {
+		return err
+	}
 
-	// 5) confirm by user
-	if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
-		curveadm.WriteOutln(tui.PromptCancelOpetation("scale-out"))
-		return nil
+	// 5) display title and preview
+	displayScaleOutTitle(curveadm, dcs2add, plan)
+
+	// 6) confirm by user
+	if pass := curveadm.Confirm(i18n.T(tuicomm.DEFAULT_CONFIRM_PROMPT)); !pass {
+		curveadm.WriteOutln(tuicomm.PromptCancelOpetation("scale-out"))
+		return errno.ERR_CANCEL_OPERATION
 	}
 
-	// 6) precheck before deploy
+	// 7) precheck before deploy
 	err = precheckBeforeScaleOut(curveadm, options, data)
 	if err != nil {
 		return err
 	}
 
-	// 7) generate scale-out playbook
+	// 8) generate certificates for new services if tls enabled
+	err = ensureCertificates(curveadm, dcs2add)
+	if err != nil {
+		return err
+	}
+
+	// 9) generate scale-out playbook
 	pb, err := genScaleOutPlaybook(curveadm, dcs, data, options)
 	if err != nil {
 		return err
 	}
 
-	// 8) run playground
+	// 10) run playground
 	if err = pb.Run(); err != nil {
 		return err
 	}
 
-	// 9) print success prompt
+	// 11) print success prompt
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln(color.GreenString("Cluster '%s' successfully scaled out ^_^."),
 		curveadm.ClusterName())
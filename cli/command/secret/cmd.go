@@ -0,0 +1,56 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package secret
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// secrets can only be managed by curveadm in store backend, others are read-only
+func checkStoreBackend(curveadm *cli.CurveAdm) error {
+	backend := curveadm.Config().GetSecretBackendConfig().Backend
+	if !secret.IsStoreBackend(backend) {
+		return errno.ERR_SECRET_BACKEND_IS_READ_ONLY.F("backend: %s", backend)
+	}
+	return nil
+}
+
+func NewSecretCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Manage encrypted secrets which referenced by secret://NAME in configs",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewSetCommand(curveadm),
+		NewListCommand(curveadm),
+		NewRemoveCommand(curveadm),
+	)
+	return cmd
+}
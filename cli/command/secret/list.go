@@ -0,0 +1,61 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package secret
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/secret"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List names of secrets",
+		Args:    cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkStoreBackend(curveadm)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(curveadm)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+// the values are never printed, only names and their references
+func runList(curveadm *cli.CurveAdm) error {
+	names, err := curveadm.Secrets().List()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		curveadm.WriteOutln("%s%s", secret.REFERENCE_PREFIX, name)
+	}
+	return nil
+}
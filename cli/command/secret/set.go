@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package secret
+
+import (
+	"io"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	SET_EXAMPLE = `Examples:
+  $ curveadm secret set s3_sk                        # Set secret 's3_sk', the value is typed without echo
+  $ curveadm secret set host1_password -f pass.txt   # Set secret 'host1_password' from file
+  $ echo -n "sk" | curveadm secret set s3_sk         # Set secret 's3_sk' from stdin`
+)
+
+type setOptions struct {
+	name     string
+	filename string
+}
+
+func NewSetCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options setOptions
+
+	cmd := &cobra.Command{
+		Use:     "set NAME [OPTIONS]",
+		Short:   "Add or update secret",
+		Args:    cliutil.ExactArgs(1),
+		Example: SET_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			if err := checkStoreBackend(curveadm); err != nil {
+				return err
+			} else if !secret.IsValidName(options.name) {
+				return errno.ERR_INVALID_SECRET_NAME.F("name: %s", options.name)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.filename, "file", "f", "", "Read secret value from the specified file")
+
+	return cmd
+}
+
+func readValue(curveadm *cli.CurveAdm, options setOptions) (string, error) {
+	if len(options.filename) > 0 {
+		data, err := cliutil.ReadFile(options.filename)
+		if err != nil {
+			return "", errno.ERR_READ_SECRET_VALUE_FAILED.E(err)
+		}
+		return strings.TrimRight(data, "\r\n"), nil
+	} else if tui.IsTerminal() {
+		value, err := tui.PromptPassword("Enter value of secret '" + options.name + "': ")
+		if err != nil {
+			return "", errno.ERR_READ_SECRET_VALUE_FAILED.E(err)
+		}
+		return value, nil
+	}
+
+	data, err := io.ReadAll(curveadm.In())
+	if err != nil {
+		return "", errno.ERR_READ_SECRET_VALUE_FAILED.E(err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func runSet(curveadm *cli.CurveAdm, options setOptions) error {
+	// 1) read secret value
+	value, err := readValue(curveadm, options)
+	if err != nil {
+		return err
+	} else if len(value) == 0 {
+		return errno.ERR_SECRET_VALUE_IS_EMPTY.F("name: %s", options.name)
+	}
+
+	// 2) encrypt and save it
+	if err := curveadm.Secrets().Set(options.name, value); err != nil {
+		return err
+	}
+
+	// 3) print success prompt
+	curveadm.WriteOutln("Secret '%s' saved, reference it by '%s%s' in configs",
+		options.name, secret.REFERENCE_PREFIX, options.name)
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/api"
+	"github.com/opencurve/curveadm/internal/errno"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	SERVE_EXAMPLE = `Examples:
+  $ curveadm serve --token TOKEN                                    # Serve API on 127.0.0.1:8000
+  $ CURVEADM_API_TOKEN=TOKEN curveadm serve --listen :8000          # Serve API on all interfaces
+  $ curveadm serve --tls-cert server.crt --tls-key server.key       # Serve API over HTTPS
+  $ curveadm serve --grpc-listen 127.0.0.1:8001                     # Serve gRPC API besides REST API
+
+  $ curl -H "Authorization: Bearer TOKEN" http://127.0.0.1:8000/api/v1/clusters
+  $ curl -H "Authorization: Bearer TOKEN" -d '{"operation": "status"}' http://127.0.0.1:8000/api/v1/clusters/c1/jobs
+  $ curl -H "Authorization: Bearer TOKEN" http://127.0.0.1:8000/api/v1/jobs/JOB_ID/events`
+
+	ENV_CURVEADM_API_TOKEN = "CURVEADM_API_TOKEN"
+)
+
+type serveOptions struct {
+	listen   string
+	token    string
+	certFile string
+	keyFile  string
+	// gRPC is disabled if not specified
+	grpcListen string
+}
+
+func NewServeCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options serveOptions
+
+	cmd := &cobra.Command{
+		Use:     "serve [OPTIONS]",
+		Short:   "Serve REST and gRPC API for managing clusters",
+		Args:    cliutil.NoArgs,
+		Example: SERVE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(options.token) == 0 {
+				options.token = os.Getenv(ENV_CURVEADM_API_TOKEN)
+			}
+			if len(options.token) == 0 {
+				return errno.ERR_API_TOKEN_NOT_SPECIFIED
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.listen, "listen", "127.0.0.1:8000", "Specify address which API served on")
+	flags.StringVar(&options.token, "token", "", "Specify token which requests authorized by (env: "+ENV_CURVEADM_API_TOKEN+")")
+	flags.StringVar(&options.certFile, "tls-cert", "", "Specify certificate file for serving HTTPS")
+	flags.StringVar(&options.keyFile, "tls-key", "", "Specify private key file for serving HTTPS")
+	flags.StringVar(&options.grpcListen, "grpc-listen", "", "Specify address which gRPC API served on")
+
+	return cmd
+}
+
+/*
+ * newAPIExecutor executes command in a new session of curveadm for each job,
+ * the jobs are executed one by one, so it's safe to replace the globals.
+ */
+func newAPIExecutor(curveadm *cli.CurveAdm) api.Executor {
+	return func(job *api.Job, args []string, out io.Writer) error {
+		session, err := curveadm.NewSession(out, job.Source, job.Confirm)
+		if err != nil {
+			return err
+		}
+		verify.ReplaceGlobals(session.Verifier())
+		defer verify.ReplaceGlobals(curveadm.Verifier())
+
+		now := time.Now()
+		id := session.PreAudit(now, args)
+		cmd := NewCurveAdmCommand(session)
+		cmd.SetArgs(args)
+		cmd.SetOut(out)
+		executed, err := cmd.ExecuteC()
+		session.PostAudit(id, err)
+		session.EmitEvent(now, executed.CommandPath(), args, err)
+		return err
+	}
+}
+
+func runServe(curveadm *cli.CurveAdm, options serveOptions) error {
+	server := api.NewServer(curveadm, api.Options{
+		Listen:   options.listen,
+		Token:    options.token,
+		CertFile: options.certFile,
+		KeyFile:  options.keyFile,
+		TempDir:  curveadm.TempDir(),
+
+		GRPCListen: options.grpcListen,
+	}, newAPIExecutor(curveadm))
+	errc := make(chan error, 2)
+	go func() {
+		errc <- server.ListenAndServe()
+	}()
+	if len(options.grpcListen) > 0 {
+		go func() {
+			errc <- server.ListenAndServeGRPC()
+		}()
+		curveadm.WriteOutln("Serving gRPC API on %s", options.grpcListen)
+	}
+	curveadm.WriteOutln("Serving API on %s%s, press Ctrl+C to stop...", options.listen, api.API_PREFIX)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err := <-errc:
+		return errno.ERR_SERVE_API_FAILED.E(err)
+	case <-ctx.Done():
+		server.Shutdown(context.Background())
+		curveadm.WriteOutln("API server stopped")
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package snapshot
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	STATUS_EXAMPLE = `Examples:
+  $ curveadm snapshot status               # Display snapshotclone services and in-flight snapshot/clone jobs
+  $ curveadm snapshot status --user curve  # Only display jobs of user 'curve'`
+)
+
+type statusOptions struct {
+	user string
+}
+
+func NewStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options statusOptions
+
+	cmd := &cobra.Command{
+		Use:     "status [OPTIONS]",
+		Short:   "Display snapshotclone service status and in-flight jobs",
+		Args:    cliutil.NoArgs,
+		Example: STATUS_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatus(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.user, "user", "", "Only display jobs of specified user")
+
+	return cmd
+}
+
+func genStatusPlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options statusOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_SNAPSHOTCLONE_STATUS,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_SNAPSHOTCLONE_USER: options.user,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar: true,
+			SkipError:    true,
+		},
+	})
+	return pb
+}
+
+func runStatus(curveadm *cli.CurveAdm, options statusOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	dcs = topology.SelectByKind(dcs, topology.KIND_CURVEBS)
+	if len(dcs) == 0 {
+		return errno.ERR_UNSUPPORT_CLUSTER_KIND.
+			F("snapshot only supports curvebs cluster")
+	}
+	dcs = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_SNAPSHOTCLONE)
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED.
+			F("role: %s", topology.ROLE_SNAPSHOTCLONE)
+	}
+
+	// 2) probe all snapshotclone services
+	err = genStatusPlaybook(curveadm, dcs, options).Run()
+
+	// 3) display service status and jobs, the jobs are reported by
+	//    the leader, which is the only one answering http request
+	statuses := []bs.SnapshotCloneStatus{}
+	jobs := []bs.SnapshotCloneJob{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_SNAPSHOTCLONE_STATUS); v != nil {
+		for _, status := range v.(map[string]bs.SnapshotCloneStatus) {
+			statuses = append(statuses, status)
+			if status.Running && len(jobs) == 0 {
+				jobs = status.Jobs
+			}
+		}
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", tui.FormatSnapshotCloneStatus(statuses))
+	curveadm.WriteOutln("")
+	if len(jobs) == 0 {
+		curveadm.WriteOutln(color.GreenString("No in-flight snapshot or clone jobs"))
+	} else {
+		curveadm.WriteOut("%s", tui.FormatSnapshotCloneJobs(jobs))
+	}
+	return err
+}
@@ -68,6 +68,15 @@ func checkCommonOptions(curveadm *cli.CurveAdm, id, role, host string) error {
 	return nil
 }
 
+// the kind option only makes sense for mixed topology
+func checkKindOption(kind string) error {
+	switch kind {
+	case "*", topology.KIND_CURVEBS, topology.KIND_CURVEFS:
+		return nil
+	}
+	return errno.ERR_UNSUPPORT_CLUSTER_KIND.F("kind: %s", kind)
+}
+
 func NewStartCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options startOptions
 
@@ -87,7 +96,7 @@ func NewStartCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.id, "id", "*", "Specify service id")
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
-	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
 
 	return cmd
 }
@@ -25,8 +25,11 @@
 package command
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
@@ -35,11 +38,28 @@ import (
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
 	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
 	tui "github.com/opencurve/curveadm/internal/tui/service"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+	"github.com/opencurve/curveadm/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+const (
+	STATUS_EXAMPLE = `Examples:
+  $ curveadm status                       # Display service status, cached for 10 seconds
+  $ curveadm status --refresh             # Query service status again ignoring cache
+  $ curveadm status --concurrency 64      # Probe 64 services at most at the same time
+  $ curveadm status --watch               # Refresh status every 2 seconds, changed rows are highlighted
+  $ curveadm status --watch 5s            # Refresh status every 5 seconds
+  $ curveadm status --wide                # Display all columns
+  $ curveadm status --columns id,status   # Only display columns id and status`
+
+	DEFAULT_STATUS_CONCURRENCY = 32
+	DEFAULT_STATUS_CACHE_TTL   = 10 * time.Second
+)
+
 var (
 	GET_STATUS_PLAYBOOK_STEPS = []int{
 		playbook.INIT_SERVIE_STATUS,
@@ -51,17 +71,48 @@ type statusOptions struct {
 	id            string
 	role          string
 	host          string
+	kind          string
 	verbose       bool
 	showInstances bool
+	deep          bool
+	output        string
+	concurrency   uint
+	cacheTTL      time.Duration
+	refresh       bool
+	watch         time.Duration
+	columns       []string
+	wide          bool
+}
+
+func checkStatusOptions(options statusOptions) error {
+	if options.cacheTTL < 0 {
+		return errno.ERR_INVALID_STATUS_OPTIONS.
+			F("--cache-ttl requires a non-negative duration: %s", options.cacheTTL)
+	} else if options.watch < 0 {
+		return errno.ERR_INVALID_STATUS_OPTIONS.
+			F("--watch requires a positive duration: %s", options.watch)
+	} else if err := tuicommon.CheckColumns(tui.STATUS_TITLE, options.columns); err != nil {
+		return errno.ERR_UNKNOWN_TABLE_COLUMN.E(err)
+	}
+	return nil
 }
 
 func NewStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options statusOptions
 
 	cmd := &cobra.Command{
-		Use:   "status [OPTIONS]",
-		Short: "Display service status",
-		Args:  cliutil.NoArgs,
+		Use:     "status [OPTIONS]",
+		Short:   "Display service status",
+		Args:    cliutil.WatchArgs(&options.watch),
+		Example: STATUS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !output.ValidFormat(options.output) {
+				return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.F("output: %s", options.output)
+			} else if err := checkStatusOptions(options); err != nil {
+				return err
+			}
+			return checkKindOption(options.kind)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(curveadm, options)
 		},
@@ -71,9 +122,18 @@ func NewStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.id, "id", "*", "Specify service id")
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
-	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
+	flags.StringVar(&options.kind, "kind", "*", "Specify cluster kind of mixed topology (curvebs/curvefs)")
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for status")
 	flags.BoolVarP(&options.showInstances, "show-instances", "s", false, "Display service num")
+	flags.BoolVar(&options.deep, "deep", false, "Query internal health of each service")
+	flags.StringVarP(&options.output, "output", "o", output.FORMAT_TEXT, "Output format of status (text/json)")
+	flags.UintVar(&options.concurrency, "concurrency", DEFAULT_STATUS_CONCURRENCY, "Specify the number of services probed at the same time")
+	flags.DurationVar(&options.cacheTTL, "cache-ttl", DEFAULT_STATUS_CACHE_TTL, "Reuse status collected within the duration (0 means no cache, ignored with --watch)")
+	flags.BoolVar(&options.refresh, "refresh", false, "Query status again ignoring cache")
+	cliutil.AddWatchFlag(cmd, &options.watch)
+	flags.StringSliceVar(&options.columns, "columns", []string{}, "Specify columns to display in order (e.g. id,host,status)")
+	flags.BoolVar(&options.wide, "wide", false, "Display all columns")
 
 	return cmd
 }
@@ -93,8 +153,8 @@ func getClusterMdsLeader(statuses []task.ServiceStatus) string {
 			continue
 		}
 		dc := status.Config
-		leader := fmt.Sprintf("%s:%d / %s",
-			dc.GetListenIp(), dc.GetListenPort(), status.Id)
+		leader := fmt.Sprintf("%s / %s",
+			cliutil.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()), status.Id)
 		leaders = append(leaders, leader)
 	}
 	if len(leaders) > 0 {
@@ -103,7 +163,17 @@ func getClusterMdsLeader(statuses []task.ServiceStatus) string {
 	return color.RedString("<no leader>")
 }
 
-func displayStatus(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, options statusOptions) {
+func filterStatusByKind(statuses []task.ServiceStatus, kind string) []task.ServiceStatus {
+	out := []task.ServiceStatus{}
+	for _, status := range statuses {
+		if status.Config.GetKind() == kind {
+			out = append(out, status)
+		}
+	}
+	return out
+}
+
+func getServiceStatuses(curveadm *cli.CurveAdm) []task.ServiceStatus {
 	statuses := []task.ServiceStatus{}
 	value := curveadm.MemStorage().Get(comm.KEY_ALL_SERVICE_STATUS)
 	if value != nil {
@@ -112,13 +182,90 @@ func displayStatus(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, options
 			statuses = append(statuses, status)
 		}
 	}
+	return statuses
+}
 
-	output := tui.FormatStatus(statuses, options.verbose, options.showInstances)
+func newStatusOutput(cluster string, dcs []*topology.DeployConfig, statuses []task.ServiceStatus) output.Status {
+	out := output.Status{
+		Header:      output.NewHeader(output.KIND_STATUS),
+		Cluster:     cluster,
+		ClusterKind: topology.GetKind(dcs),
+		MDS:         []output.MDS{},
+		Services:    []output.Service{},
+	}
+	for _, part := range topology.SplitByKind(dcs) {
+		kind := part[0].GetKind()
+		mds := output.MDS{Kind: kind, Addr: getClusterMdsAddr(part), Leaders: []string{}}
+		for _, status := range statuses {
+			if status.IsLeader && status.Config.GetKind() == kind {
+				mds.Leaders = append(mds.Leaders, status.Id)
+			}
+		}
+		sort.Strings(mds.Leaders)
+		out.MDS = append(out.MDS, mds)
+	}
+
+	for _, status := range statuses {
+		out.Services = append(out.Services, output.Service{
+			Id:          status.Id,
+			ParentId:    status.ParentId,
+			Kind:        status.Config.GetKind(),
+			Role:        status.Role,
+			Host:        status.Host,
+			Instances:   status.Instances,
+			ContainerId: status.ContainerId,
+			Ports:       status.Ports,
+			IsLeader:    status.IsLeader,
+			Status:      status.Status,
+			Health:      status.Health,
+			LogDir:      status.LogDir,
+			DataDir:     status.DataDir,
+		})
+	}
+	sort.Slice(out.Services, func(i, j int) bool {
+		return out.Services[i].Id < out.Services[j].Id
+	})
+	return out
+}
+
+func displayStatusJSON(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) error {
+	data, err := output.Marshal(newStatusOutput(curveadm.ClusterName(), dcs, getServiceStatuses(curveadm)))
+	if err != nil {
+		return errno.ERR_UNKNOWN.E(err)
+	}
+	curveadm.WriteOutln("%s", data)
+	return nil
+}
+
+func displayStatus(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options statusOptions,
+	cachedAt time.Time,
+	highlighter *tuicommon.Highlighter) {
+	statuses := getServiceStatuses(curveadm)
+	output := tui.FormatStatus(statuses, options.verbose, options.showInstances, options.deep,
+		tuicommon.TableOptions{Columns: options.columns, Wide: options.wide})
+	if highlighter != nil {
+		output = highlighter.Highlight(output)
+	}
 	curveadm.WriteOutln("")
+	if !cachedAt.IsZero() {
+		curveadm.WriteOutln(color.YellowString("Status cached %s ago, use --refresh to query again",
+			time.Since(cachedAt).Round(time.Second)))
+		curveadm.WriteOutln("")
+	}
 	curveadm.WriteOutln("cluster name      : %s", curveadm.ClusterName())
-	curveadm.WriteOutln("cluster kind      : %s", dcs[0].GetKind())
-	curveadm.WriteOutln("cluster mds addr  : %s", getClusterMdsAddr(dcs))
-	curveadm.WriteOutln("cluster mds leader: %s", getClusterMdsLeader(statuses))
+	curveadm.WriteOutln("cluster kind      : %s", topology.GetKind(dcs))
+	if !topology.IsMixed(dcs) {
+		curveadm.WriteOutln("cluster mds addr  : %s", getClusterMdsAddr(dcs))
+		curveadm.WriteOutln("cluster mds leader: %s", getClusterMdsLeader(statuses))
+	} else {
+		for _, part := range topology.SplitByKind(dcs) {
+			kind := part[0].GetKind()
+			curveadm.WriteOutln("%s mds addr  : %s", kind, getClusterMdsAddr(part))
+			curveadm.WriteOutln("%s mds leader: %s", kind, getClusterMdsLeader(filterStatusByKind(statuses, kind)))
+		}
+	}
 	curveadm.WriteOutln("")
 	curveadm.WriteOut("%s", output)
 }
@@ -130,45 +277,105 @@ func genStatusPlaybook(curveadm *cli.CurveAdm,
 		Id:   options.id,
 		Role: options.role,
 		Host: options.host,
+		Kind: options.kind,
 	})
 	if len(dcs) == 0 {
 		return nil, errno.ERR_NO_SERVICES_MATCHED
 	}
 
+	// progress bars would break the JSON output
+	isJSON := options.output == output.FORMAT_JSON
 	steps := GET_STATUS_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
 			Configs: dcs,
+			Options: map[string]interface{}{
+				comm.KEY_STATUS_DEEP: options.deep,
+			},
 			ExecOptions: playbook.ExecOptions{
-				//Concurrency:   10,
-				SilentSubBar:  true,
-				SilentMainBar: step == playbook.INIT_SERVIE_STATUS,
-				SkipError:     true,
+				Concurrency: options.concurrency,
+				// services in one host share one pooled SSH connection
+				HostConcurrency: uint(curveadm.Config().GetSSHPoolOptions().MaxSessions),
+				SilentSubBar:    true,
+				SilentMainBar:   step == playbook.INIT_SERVIE_STATUS || isJSON,
+				SkipError:       true,
 			},
 		})
 	}
 	return pb, nil
 }
 
-func runStatus(curveadm *cli.CurveAdm, options statusOptions) error {
-	// 1) parse cluster topology
-	dcs, err := curveadm.ParseTopology()
+// collectStatus collects service status into memory storage, from cache if possible
+func collectStatus(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options statusOptions) (time.Time, error) {
+	if cachedAt, ok := loadStatusCache(curveadm, dcs, options); ok {
+		return cachedAt, nil
+	}
+
+	pb, err := genStatusPlaybook(curveadm, dcs, options)
 	if err != nil {
+		return time.Time{}, err
+	}
+	now := time.Now()
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_STATUS, nil)
+	err = pb.Run()
+	if err == nil {
+		if cerr := saveStatusCache(curveadm, options, now); cerr != nil {
+			log.Warn("Save status cache failed", log.Field("Error", cerr))
+		}
+	}
+	return time.Time{}, err
+}
+
+// showStatus shows status once, the rows changed are highlighted if highlighter not nil
+func showStatus(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options statusOptions,
+	highlighter *tuicommon.Highlighter) error {
+	// 1) collect status by playbook or from cache
+	cachedAt, err := collectStatus(curveadm, dcs, options)
+	if errors.Is(err, errno.ERR_NO_SERVICES_MATCHED) {
 		return err
 	}
 
-	// 2) generate get status playbook
-	pb, err := genStatusPlaybook(curveadm, dcs, options)
+	// 2) display service status
+	if options.watch > 0 && options.output != output.FORMAT_JSON {
+		curveadm.WriteOut(tuicommon.ANSI_CLEAR_SCREEN)
+	}
+	if options.output == output.FORMAT_JSON {
+		if jerr := displayStatusJSON(curveadm, dcs); jerr != nil {
+			return jerr
+		}
+	} else {
+		displayStatus(curveadm, dcs, options, cachedAt, highlighter)
+	}
+	return err
+}
+
+func runStatus(curveadm *cli.CurveAdm, options statusOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
 	if err != nil {
 		return err
 	}
 
-	// 3) run playground
-	err = pb.Run()
+	// 2) show status once
+	if options.watch == 0 {
+		return showStatus(curveadm, dcs, options, nil)
+	}
 
-	// 4) display service status
-	displayStatus(curveadm, dcs, options)
-	return err
+	// 3) refresh until interrupted, status is queried again each time like --refresh,
+	//    otherwise the interval shorter than --cache-ttl only shows the same cached status
+	options.refresh = true
+	highlighter := tuicommon.NewHighlighter()
+	return tuicommon.Watch(options.watch, func() error {
+		err := showStatus(curveadm, dcs, options, highlighter)
+		if errors.Is(err, errno.ERR_NO_SERVICES_MATCHED) {
+			return err
+		}
+		return nil
+	})
 }
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+/*
+ * statusCache holds service statuses collected by last `curveadm status`,
+ * which stored in ~/.curveadm/temp/status/<md5 of key>.json and reused
+ * within --cache-ttl, so repeated invocations (or --watch) are cheap.
+ * The key includes topology and filter options, and cache is dropped
+ * by any other playbook which may change services.
+ */
+type statusCache struct {
+	Time     time.Time            `json:"time"`
+	Statuses []task.ServiceStatus `json:"statuses"`
+}
+
+func statusCachePath(curveadm *cli.CurveAdm, options statusOptions) string {
+	key := fmt.Sprintf("%s|%s|%s|%s|%s|%t|%s",
+		curveadm.ClusterUUId(), options.id, options.role, options.host, options.kind,
+		options.deep, utils.MD5Sum(curveadm.ClusterTopologyData()))
+	return path.Join(curveadm.StatusCacheDir(), utils.MD5Sum(key)+".json")
+}
+
+// loadStatusCache loads unexpired statuses into memory storage as playbook does
+func loadStatusCache(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options statusOptions) (time.Time, bool) {
+	if options.cacheTTL <= 0 || options.refresh {
+		return time.Time{}, false
+	}
+
+	data, err := os.ReadFile(statusCachePath(curveadm, options))
+	if err != nil {
+		return time.Time{}, false
+	}
+	cache := statusCache{}
+	if err := json.Unmarshal(data, &cache); err != nil || time.Since(cache.Time) > options.cacheTTL {
+		return time.Time{}, false
+	}
+
+	// deploy config isn't cached, attach it by service id
+	m := map[string]*topology.DeployConfig{}
+	for _, dc := range dcs {
+		m[curveadm.GetServiceId(dc.GetId())] = dc
+	}
+	statuses := map[string]task.ServiceStatus{}
+	for _, status := range cache.Statuses {
+		dc, ok := m[status.Id]
+		if !ok {
+			return time.Time{}, false
+		}
+		status.Config = dc
+		statuses[status.Id] = status
+	}
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_STATUS, statuses)
+	return cache.Time, true
+}
+
+func saveStatusCache(curveadm *cli.CurveAdm, options statusOptions, now time.Time) error {
+	if options.cacheTTL <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(statusCache{
+		Time:     now,
+		Statuses: getServiceStatuses(curveadm),
+	})
+	if err != nil {
+		return err
+	}
+	filename := statusCachePath(curveadm, options)
+	if err := os.MkdirAll(path.Dir(filename), 0755); err != nil {
+		return err
+	}
+	// write into temporary file first, concurrent reader never sees partial cache
+	tmpfile := fmt.Sprintf("%s.%s", filename, utils.RandString(8))
+	if err := os.WriteFile(tmpfile, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpfile, filename)
+}
@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const statusCacheTopology = `
+kind: curvebs
+etcd_services:
+  deploy:
+    - host: host1
+    - host: host2
+`
+
+func TestStatusCache(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("HOME", t.TempDir())
+	curveadm, err := cli.NewCurveAdm()
+	assert.Nil(err)
+
+	ctx := topology.NewContext()
+	ctx.Add("host1", "10.0.0.1")
+	ctx.Add("host2", "10.0.0.2")
+	dcs, err := topology.ParseTopology(statusCacheTopology, ctx)
+	assert.Nil(err)
+
+	statuses := map[string]task.ServiceStatus{}
+	for _, dc := range dcs {
+		id := curveadm.GetServiceId(dc.GetId())
+		statuses[id] = task.ServiceStatus{Id: id, Role: dc.GetRole(), Host: dc.GetHost(), Status: "Up 1 minutes", Config: dc}
+	}
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_STATUS, statuses)
+	options := statusOptions{id: "*", role: "*", host: "*", kind: "*", cacheTTL: time.Minute}
+	assert.Nil(saveStatusCache(curveadm, options, time.Now().Add(-time.Second)))
+
+	// hit: deploy config is attached by service id
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_STATUS, nil)
+	cachedAt, ok := loadStatusCache(curveadm, dcs, options)
+	assert.True(ok)
+	assert.False(cachedAt.IsZero())
+	loaded := getServiceStatuses(curveadm)
+	assert.Len(loaded, 2)
+	for _, status := range loaded {
+		assert.Equal("Up 1 minutes", status.Status)
+		assert.NotNil(status.Config)
+	}
+
+	// miss: refresh, expired, filter changed, service not in topology
+	refresh := options
+	refresh.refresh = true
+	_, ok = loadStatusCache(curveadm, dcs, refresh)
+	assert.False(ok)
+	expired := options
+	expired.cacheTTL = time.Millisecond
+	_, ok = loadStatusCache(curveadm, dcs, expired)
+	assert.False(ok)
+	deep := options
+	deep.deep = true
+	_, ok = loadStatusCache(curveadm, dcs, deep)
+	assert.False(ok)
+	_, ok = loadStatusCache(curveadm, dcs[:1], options)
+	assert.False(ok)
+
+	// cache directory is removed by any other playbook
+	assert.Nil(os.RemoveAll(curveadm.StatusCacheDir()))
+	_, ok = loadStatusCache(curveadm, dcs, options)
+	assert.False(ok)
+}
+
+func TestCheckStatusOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(checkStatusOptions(statusOptions{cacheTTL: 0}))
+	assert.NotNil(checkStatusOptions(statusOptions{cacheTTL: -time.Second}))
+	assert.NotNil(checkStatusOptions(statusOptions{watch: -time.Second}))
+}
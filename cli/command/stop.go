@@ -44,6 +44,7 @@ type stopOptions struct {
 	id   string
 	role string
 	host string
+	kind string
 }
 
 func NewStopCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -54,6 +55,9 @@ func NewStopCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		Short: "Stop service",
 		Args:  cliutil.NoArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkKindOption(options.kind); err != nil {
+				return err
+			}
 			return checkCommonOptions(curveadm, options.id, options.role, options.host)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -65,7 +69,8 @@ func NewStopCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.id, "id", "*", "Specify service id")
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
-	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
+	flags.StringVar(&options.kind, "kind", "*", "Specify cluster kind of mixed topology (curvebs/curvefs)")
 
 	return cmd
 }
@@ -77,6 +82,7 @@ func genStopPlaybook(curveadm *cli.CurveAdm,
 		Id:   options.id,
 		Role: options.role,
 		Host: options.host,
+		Kind: options.kind,
 	})
 	if len(dcs) == 0 {
 		return nil, errno.ERR_NO_SERVICES_MATCHED
@@ -107,7 +113,8 @@ func runStop(curveadm *cli.CurveAdm, options stopOptions) error {
 	}
 
 	// 3) confirm by user
-	pass := tui.ConfirmYes(tui.PromptStopService(options.id, options.role, options.host));
+	impact := tui.PromptImpact(pb.Impact("stop service"))
+	pass := curveadm.Confirm(impact + tui.PromptStopService(options.id, options.role, options.host))
 	if !pass {
 		curveadm.WriteOut(tui.PromptCancelOpetation("stop service"))
 		return errno.ERR_CANCEL_OPERATION
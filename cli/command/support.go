@@ -152,7 +152,8 @@ func runSupport(curveadm *cli.CurveAdm, options supportOptions) error {
 
 	// 4) confirm by user
 	if pass := tui.ConfirmYes(tui.PromptCollectService()); !pass {
-		return nil
+		curveadm.WriteOutln(tui.PromptCancelOpetation("support"))
+		return errno.ERR_CANCEL_OPERATION
 	}
 
 	// 5) run playbook
@@ -0,0 +1,227 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	SUPPORT_BUNDLE_EXAMPLE = `Examples:
+  $ curveadm support-bundle                        # Collect support bundle into current directory
+  $ curveadm support-bundle --since 6h --redact    # Collect logs in last 6 hours and mask secrets
+  $ curveadm support-bundle --output /tmp          # Save support bundle into /tmp`
+
+	BUNDLE_DIR_CURVEADM = "curveadm"
+	BUNDLE_TIME_LAYOUT  = "20060102-150405"
+)
+
+var (
+	SUPPORT_BUNDLE_PLAYBOOK_STEPS = []int{
+		playbook.COLLECT_BUNDLE_HOST,
+		playbook.COLLECT_BUNDLE_TOOLS,
+		playbook.COLLECT_BUNDLE_SERVICE,
+	}
+)
+
+type supportBundleOptions struct {
+	output string
+	since  time.Duration
+	redact bool
+}
+
+func checkSupportBundleOptions(options supportBundleOptions) error {
+	if options.since < time.Minute {
+		return errno.ERR_INVALID_BUNDLE_SINCE.
+			F("since: %s", options.since)
+	} else if !cliutil.PathExist(options.output) {
+		return errno.ERR_BUNDLE_OUTPUT_DIRECTORY_NOT_EXIST.
+			F("output: %s", options.output)
+	}
+	return nil
+}
+
+func NewSupportBundleCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options supportBundleOptions
+
+	cmd := &cobra.Command{
+		Use:     "support-bundle [OPTIONS]",
+		Short:   "Collect diagnosis information into a tarball for bug report",
+		Args:    cliutil.NoArgs,
+		Example: SUPPORT_BUNDLE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkSupportBundleOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSupportBundle(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.output, "output", "o", ".", "Specify directory which support bundle saved in")
+	flags.DurationVar(&options.since, "since", 24*time.Hour, "Collect logs modified in recent duration")
+	flags.BoolVar(&options.redact, "redact", false, "Mask passwords, keys and tokens in collected files")
+
+	return cmd
+}
+
+func genSupportBundlePlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	dir string,
+	options supportBundleOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range SUPPORT_BUNDLE_PLAYBOOK_STEPS {
+		configs := dcs
+		if step == playbook.COLLECT_BUNDLE_TOOLS { // only the first mds is needed
+			configs = curveadm.FilterDeployConfigByRole(dcs, ROLE_MDS)[:1]
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: configs,
+			Options: map[string]interface{}{
+				comm.KEY_BUNDLE_DIR:   dir,
+				comm.KEY_BUNDLE_SINCE: options.since,
+			},
+			ExecOptions: playbook.ExecOptions{
+				SkipError: true, // collect as much as possible
+			},
+		})
+	}
+	return pb
+}
+
+// collect topology, pool, hosts and database records of curveadm
+func collectCurveAdm(curveadm *cli.CurveAdm, dir string, options supportBundleOptions) error {
+	dir = path.Join(dir, BUNDLE_DIR_CURVEADM)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+
+	services, err := curveadm.Storage().GetServices(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_GET_ALL_SERVICES_CONTAINER_ID_FAILED.E(err)
+	}
+	lines := []string{}
+	for _, service := range services {
+		lines = append(lines, fmt.Sprintf("%s %s", service.Id, service.ContainerId))
+	}
+
+	auditLogs, err := curveadm.Storage().GetAuditLogs()
+	if err != nil {
+		return errno.ERR_GET_AUDIT_LOGS_FAILE.E(err)
+	}
+	audits := []string{}
+	for _, auditLog := range auditLogs {
+		audits = append(audits, fmt.Sprintf("%d %s %d %d %s", auditLog.Id,
+			auditLog.ExecuteTime.Format(time.RFC3339), auditLog.Status,
+			auditLog.ErrorCode, auditLog.Command))
+	}
+
+	files := map[string]string{
+		"topology.yaml": curveadm.ClusterTopologyData(),
+		"pool.json":     curveadm.ClusterPoolData(),
+		"hosts.yaml":    curveadm.Hosts(),
+		"services.txt":  strings.Join(lines, "\n"),
+		"audit.txt":     strings.Join(audits, "\n"),
+	}
+	for name, content := range files {
+		err := cliutil.WriteFile(path.Join(dir, name), content, 0644)
+		if err != nil {
+			return errno.ERR_WRITE_FILE_FAILED.E(err)
+		}
+	}
+
+	// the database may contain secrets which can't be redacted
+	dbPath := curveadm.Config().GetDBPath()
+	if len(dbPath) == 0 || options.redact {
+		return nil
+	}
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		return errno.ERR_READ_FILE_FAILED.E(err)
+	}
+	err = os.WriteFile(path.Join(dir, path.Base(dbPath)), data, 0644)
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	return nil
+}
+
+func runSupportBundle(curveadm *cli.CurveAdm, options supportBundleOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) create bundle directory
+	name := fmt.Sprintf("curveadm-bundle-%s-%s",
+		curveadm.ClusterName(), time.Now().Format(BUNDLE_TIME_LAYOUT))
+	dir := path.Join(cliutil.AbsPath(options.output), name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errno.ERR_CREATE_DIRECTORY_FAILED.E(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// 3) collect curveadm records, host information, tool outputs and service logs
+	err = collectCurveAdm(curveadm, dir, options)
+	if err != nil {
+		return err
+	}
+	pb := genSupportBundlePlaybook(curveadm, dcs, dir, options)
+	pbErr := pb.Run()
+
+	// 4) redact secrets
+	if options.redact {
+		if err := cliutil.RedactDirectory(dir); err != nil {
+			return errno.ERR_REDACT_SUPPORT_BUNDLE_FAILED.E(err)
+		}
+	}
+
+	// 5) archive bundle into tarball
+	tarball := dir + ".tar.gz"
+	if err := cliutil.TarGzDirectory(dir, tarball); err != nil {
+		return errno.ERR_ARCHIVE_SUPPORT_BUNDLE_FAILED.E(err)
+	}
+
+	curveadm.WriteOutln("")
+	if pbErr != nil {
+		curveadm.WriteOutln(color.YellowString("Some information collected failed, the bundle is incomplete"))
+	}
+	curveadm.WriteOutln(color.GreenString("Support bundle saved in %s", tarball))
+	return nil
+}
@@ -0,0 +1,204 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	TOP_EXAMPLE = `Examples:
+  $ curveadm top                          # Refresh load of chunkservers/metaservers every 2 seconds
+  $ curveadm top --interval 5s            # Refresh every 5 seconds
+  $ curveadm top --host machine1          # Only show services in host machine1
+  $ curveadm top -n 1                     # Sample once and exit`
+
+	ANSI_CLEAR_SCREEN = "\033[H\033[2J"
+)
+
+type topOptions struct {
+	interval   time.Duration
+	host       string
+	iterations int
+}
+
+func checkTopOptions(options topOptions) error {
+	if options.interval <= 0 {
+		return errno.ERR_INVALID_WATCH_INTERVAL.
+			F("interval: %s", options.interval)
+	}
+	return nil
+}
+
+func NewTopCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options topOptions
+
+	cmd := &cobra.Command{
+		Use:     "top [OPTIONS]",
+		Short:   "Display load of services in terminal",
+		Args:    cliutil.NoArgs,
+		Example: TOP_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkTopOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTop(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.DurationVar(&options.interval, "interval", 2*time.Second, "Specify interval between refreshes")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
+	flags.IntVarP(&options.iterations, "iterations", "n", 0, "Specify number of refreshes before exit (0 means forever)")
+
+	return cmd
+}
+
+func genTopPlaybook(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.SAMPLE_SERVICE_METRICS,
+		Configs: dcs,
+		ExecOptions: playbook.ExecOptions{
+			SilentMainBar: true,
+			SilentSubBar:  true,
+			SkipError:     true,
+		},
+	})
+	return pb
+}
+
+// pool of service which in cluster pool curveadm created
+func locateServices(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) (map[string]string, error) {
+	pool := configure.CurveClusterTopo{}
+	if len(curveadm.ClusterPoolData()) > 0 {
+		err := json.Unmarshal([]byte(curveadm.ClusterPoolData()), &pool)
+		if err != nil {
+			return nil, errno.ERR_DECODE_CLUSTER_POOL_JSON_FAILED.E(err)
+		}
+	}
+
+	pools := map[string]string{}
+	for _, dc := range dcs {
+		name := "-"
+		if server, ok := pool.LocateServer(dc); ok {
+			name = server.PhysicalPool
+			if dc.GetKind() == topology.KIND_CURVEFS {
+				name = server.Pool
+			}
+		}
+		pools[curveadm.GetServiceId(dc.GetId())] = name
+	}
+	return pools, nil
+}
+
+func sampleMetrics(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	pools map[string]string) []task.ServiceMetrics {
+	// the error is ignored because the service which sample
+	// failed is displayed without load
+	curveadm.MemStorage().Set(comm.KEY_ALL_SERVICE_METRICS, nil)
+	genTopPlaybook(curveadm, dcs).Run()
+
+	metrics := []task.ServiceMetrics{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_SERVICE_METRICS); v != nil {
+		metrics = v.([]task.ServiceMetrics)
+	}
+	for i := range metrics {
+		metrics[i].Pool = pools[metrics[i].Id]
+	}
+	return metrics
+}
+
+func displayTop(curveadm *cli.CurveAdm, metrics []task.ServiceMetrics) {
+	curveadm.WriteOut(ANSI_CLEAR_SCREEN)
+	curveadm.WriteOutln("Cluster: %s    Updated: %s",
+		curveadm.ClusterName(), time.Now().Format("2006-01-02 15:04:05"))
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatTopPools(metrics))
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatTopServices(metrics))
+}
+
+func runTop(curveadm *cli.CurveAdm, options topOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) only chunkserver/metaserver serve io, both of them for mixed topology
+	services := []*topology.DeployConfig{}
+	for _, dc := range curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   "*",
+		Role: "*",
+		Host: options.host,
+	}) {
+		if dc.GetRole() == ROLE_CHUNKSERVER || dc.GetRole() == ROLE_METASERVER {
+			services = append(services, dc)
+		}
+	}
+	dcs = services
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+	pools, err := locateServices(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+
+	// 3) refresh until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	for i := 1; ; i++ {
+		displayTop(curveadm, sampleMetrics(curveadm, dcs, pools))
+		if options.iterations > 0 && i >= options.iterations {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package topology
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	GENERATE_EXAMPLE = `Examples:
+  $ curveadm topology generate                                        # Generate topology of curvebs for 3 hosts
+  $ curveadm topology generate --hosts 6 --disks-per-host 8           # Generate topology of curvebs for 6 hosts with 8 disks each
+  $ curveadm topology generate --kind curvefs --hosts 5 --replicas 5  # Generate topology of curvefs with 5 replicas
+  $ curveadm topology generate -o topology.yaml                       # Generate topology into file`
+)
+
+type generateOptions struct {
+	kind           string
+	hosts          int
+	disksPerHost   int
+	replicas       int
+	copysets       int
+	containerImage string
+	output         string
+}
+
+func NewGenerateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options generateOptions
+
+	cmd := &cobra.Command{
+		Use:     "generate [OPTIONS]",
+		Short:   "Generate topology for deployment size by best practice",
+		Args:    utils.NoArgs,
+		Example: GENERATE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if options.kind == topology.KIND_CURVEFS && cmd.Flags().Changed("disks-per-host") {
+				return errno.ERR_INVALID_TOPOLOGY_GENERATE_OPTIONS.
+					F("--disks-per-host is only for curvebs")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.kind, "kind", topology.KIND_CURVEBS, "Specify the cluster kind (curvebs/curvefs)")
+	flags.IntVar(&options.hosts, "hosts", configure.DEFAULT_TEMPLATE_HOSTS, "Specify the number of hosts")
+	flags.IntVar(&options.disksPerHost, "disks-per-host", configure.DEFAULT_TEMPLATE_DISKS_PER_HOST, "Specify the number of disks in each host, one chunkserver per disk")
+	flags.IntVar(&options.replicas, "replicas", topology.DEFAULT_COPYSET_REPLICAS, "Specify the replicas of each copyset")
+	flags.IntVar(&options.copysets, "copysets", topology.DEFAULT_CHUNKSERVER_COPYSETS, "Specify the copysets of each chunkserver or metaserver")
+	flags.StringVar(&options.containerImage, "image", "", "Specify the container image (default: image of the kind)")
+	flags.StringVarP(&options.output, "output", "o", "", "Output to specified file instead of stdout")
+
+	return cmd
+}
+
+func runGenerate(curveadm *cli.CurveAdm, options generateOptions) error {
+	data, err := configure.GenTopologyTemplate(configure.TopologyTemplateOptions{
+		Kind:           options.kind,
+		Hosts:          options.hosts,
+		DisksPerHost:   options.disksPerHost,
+		Replicas:       options.replicas,
+		Copysets:       options.copysets,
+		ContainerImage: options.containerImage,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(options.output) == 0 {
+		curveadm.WriteOut("%s", data)
+		return nil
+	}
+	err = utils.WriteFile(options.output, data, 0644)
+	if err != nil {
+		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	}
+	curveadm.WriteOutln("Topology generated in '%s', please review it before deploying", options.output)
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package topology
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	"github.com/opencurve/curveadm/internal/tui"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LINT_EXAMPLE = `Examples:
+  $ curveadm topology lint topology.yaml            # Lint topology with facts of hosts
+  $ curveadm topology lint topology.yaml --offline  # Lint topology with stored facts, without connecting hosts`
+)
+
+type lintOptions struct {
+	filename string
+	offline  bool
+}
+
+func NewLintCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options lintOptions
+
+	cmd := &cobra.Command{
+		Use:     "lint TOPOLOGY [OPTIONS]",
+		Short:   "Check topology against best practices",
+		Args:    utils.ExactArgs(1),
+		Example: LINT_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.filename = args[0]
+			return runLint(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.offline, "offline", false, "Skip gathering facts of hosts, use the stored facts instead")
+
+	return cmd
+}
+
+func readTopology(filename string) (string, error) {
+	if !utils.PathExist(filename) {
+		return "", errno.ERR_TOPOLOGY_FILE_NOT_FOUND.
+			F("%s: no such file", utils.AbsPath(filename))
+	}
+	data, err := utils.ReadFile(filename)
+	if err != nil {
+		return "", errno.ERR_READ_TOPOLOGY_FILE_FAILED.E(err)
+	}
+	return data, nil
+}
+
+// facts only need to be gathered once for each host
+func genLintPlaybook(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) *playbook.Playbook {
+	hosts := map[string]bool{}
+	configs := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		if !hosts[dc.GetHost()] {
+			hosts[dc.GetHost()] = true
+			configs = append(configs, dc)
+		}
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GATHER_HOST_FACTS,
+		Configs: configs,
+		ExecOptions: playbook.ExecOptions{
+			SkipError: true,
+		},
+	})
+	return pb
+}
+
+// the host which can't be reached has no facts, and it will be reported by lint
+func gatherHostFacts(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) map[string]step.HostFacts {
+	curveadm.MemStorage().Set(comm.KEY_ALL_LINT_HOST_FACTS, nil)
+	genLintPlaybook(curveadm, dcs).Run()
+
+	facts := map[string]step.HostFacts{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_LINT_HOST_FACTS); v != nil {
+		facts = v.(map[string]step.HostFacts)
+	}
+	return facts
+}
+
+/*
+ * getHostFacts returns the stored facts of hosts which refreshed by the gathered
+ * facts if not offline, it returns nil if no facts at all which skip checking resources.
+ */
+func getHostFacts(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, offline bool) (map[string]step.HostFacts, error) {
+	stored, err := checker.LoadHostFacts(curveadm)
+	if err != nil {
+		return nil, err
+	}
+	facts := map[string]step.HostFacts{}
+	for host, item := range stored {
+		facts[host] = item.Facts
+	}
+
+	if !offline {
+		gathered := gatherHostFacts(curveadm, dcs)
+		err = checker.SaveHostFacts(curveadm, gathered)
+		if err != nil {
+			return nil, err
+		}
+		for host, f := range gathered {
+			facts[host] = f
+		}
+	}
+
+	if len(facts) == 0 {
+		return nil, nil
+	}
+	return facts, nil
+}
+
+func runLint(curveadm *cli.CurveAdm, options lintOptions) error {
+	// 1) parse topology in file
+	data, err := readTopology(options.filename)
+	if err != nil {
+		return err
+	}
+	dcs, err := curveadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+
+	// 2) gather facts of hosts for checking resources
+	facts, err := getHostFacts(curveadm, dcs, options.offline)
+	if err != nil {
+		return err
+	}
+
+	// 3) lint topology and display issues
+	issues := checker.LintTopology(dcs, facts)
+	nerror := 0
+	for _, issue := range issues {
+		if issue.Severity == checker.LINT_SEVERITY_ERROR {
+			nerror++
+		}
+	}
+	if len(issues) > 0 {
+		curveadm.WriteOutln("")
+		curveadm.WriteOut("%s", tui.FormatLintIssues(issues))
+	}
+
+	if nerror > 0 {
+		return errno.ERR_LINT_TOPOLOGY_FAILED.
+			F("error: %d, warning: %d", nerror, len(issues)-nerror)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Lint topology passed (warning: %d)", len(issues)))
+	return nil
+}
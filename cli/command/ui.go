@@ -0,0 +1,278 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/tui/dashboard"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/output"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	UI_EXAMPLE = `Examples:
+  $ curveadm ui                           # Open dashboard of current cluster
+  $ curveadm ui --cluster my-cluster      # Open dashboard of specified cluster`
+
+	UI_TAB_CLUSTERS   = "Clusters"
+	UI_TAB_SERVICES   = "Services"
+	UI_TAB_DISKS      = "Disks"
+	UI_TAB_OPERATIONS = "Operations"
+
+	UI_ACTION_CHECKOUT = "enter"
+	UI_ACTION_LOGS     = "l"
+	UI_ACTION_STATUS   = "s"
+
+	UI_OPERATIONS_LIMIT = 100
+	UI_LOGS_TAIL        = "200"
+
+	ANSI_ENTER_ALT_SCREEN = "\033[?1049h\033[?25l"
+	ANSI_LEAVE_ALT_SCREEN = "\033[?25h\033[?1049l"
+)
+
+/*
+ * uiState holds the dashboard and the resources of selectable rows.
+ *
+ * The actions are read-only (view logs, check status), they run as
+ * sub-process of curveadm with terminal restored, so the output and
+ * audit are the same as typed by user.
+ */
+type uiState struct {
+	curveadm  *cli.CurveAdm
+	dashboard *dashboard.Dashboard
+	clusters  []string
+	services  []string
+}
+
+func NewUICommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ui",
+		Short:   "Open terminal dashboard of clusters, services, disks and operations",
+		Args:    cliutil.NoArgs,
+		Example: UI_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUI(curveadm)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func newUIState(curveadm *cli.CurveAdm) *uiState {
+	return &uiState{
+		curveadm: curveadm,
+		dashboard: dashboard.New([]*dashboard.Tab{
+			{
+				Name:    UI_TAB_CLUSTERS,
+				Title:   dashboard.CLUSTERS_TITLE,
+				Actions: []dashboard.Action{{Key: UI_ACTION_CHECKOUT, Name: "open"}},
+			},
+			{
+				Name:  UI_TAB_SERVICES,
+				Title: dashboard.SERVICES_TITLE,
+				Actions: []dashboard.Action{
+					{Key: UI_ACTION_LOGS, Name: "logs"},
+					{Key: UI_ACTION_STATUS, Name: "status"},
+				},
+			},
+			{Name: UI_TAB_DISKS, Title: dashboard.DISKS_TITLE},
+			{Name: UI_TAB_OPERATIONS, Title: dashboard.OPERATIONS_TITLE},
+		}),
+	}
+}
+
+func (s *uiState) loadClusters() {
+	curveadm := s.curveadm
+	clusters, err := curveadm.Storage().GetClusters("%")
+	if err != nil {
+		s.dashboard.SetRows(UI_TAB_CLUSTERS, nil, errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err).Error())
+		return
+	}
+	rows, names := dashboard.ClusterRows(clusters, curveadm.ClusterName())
+	s.clusters = names
+	s.dashboard.SetRows(UI_TAB_CLUSTERS, rows, "no clusters, add one by 'curveadm cluster add'")
+}
+
+// loadServices collects status of services, from status cache unless refresh
+func (s *uiState) loadServices(refresh bool) {
+	curveadm := s.curveadm
+	s.services = nil
+	if curveadm.ClusterId() == -1 {
+		s.dashboard.SetRows(UI_TAB_SERVICES, nil, "no cluster opened, select one in tab Clusters")
+		return
+	}
+
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		s.dashboard.SetRows(UI_TAB_SERVICES, nil, err.Error())
+		return
+	}
+	options := statusOptions{
+		id:          "*",
+		role:        "*",
+		host:        "*",
+		kind:        "*",
+		output:      output.FORMAT_JSON, // silent progress bars
+		concurrency: DEFAULT_STATUS_CONCURRENCY,
+		cacheTTL:    DEFAULT_STATUS_CACHE_TTL,
+		refresh:     refresh,
+	}
+	if _, err := collectStatus(curveadm, dcs, options); err != nil {
+		s.dashboard.Message = err.Error()
+	}
+	rows, ids := dashboard.ServiceRows(getServiceStatuses(curveadm))
+	s.services = ids
+	s.dashboard.SetRows(UI_TAB_SERVICES, rows, "no services deployed")
+}
+
+func (s *uiState) loadDisks() {
+	progresses, err := s.curveadm.Storage().GetFormatProgresses()
+	if err != nil {
+		s.dashboard.SetRows(UI_TAB_DISKS, nil, errno.ERR_GET_FORMAT_PROGRESSES_FAILED.E(err).Error())
+		return
+	}
+	s.dashboard.SetRows(UI_TAB_DISKS, dashboard.DiskRows(progresses), "no disks formatted by 'curveadm format'")
+}
+
+func (s *uiState) loadOperations() {
+	auditLogs, err := s.curveadm.Storage().GetAuditLogs()
+	if err != nil {
+		s.dashboard.SetRows(UI_TAB_OPERATIONS, nil, errno.ERR_GET_AUDIT_LOGS_FAILE.E(err).Error())
+		return
+	}
+	s.dashboard.SetRows(UI_TAB_OPERATIONS, dashboard.OperationRows(auditLogs, UI_OPERATIONS_LIMIT), "")
+}
+
+func (s *uiState) load(refresh bool) {
+	s.dashboard.Message = ""
+	s.loadClusters()
+	s.loadServices(refresh)
+	s.loadDisks()
+	s.loadOperations()
+	cluster := cliutil.Choose(len(s.curveadm.ClusterName()) > 0, s.curveadm.ClusterName(), "-")
+	s.dashboard.Header = fmt.Sprintf("CurveAdm Dashboard    Cluster: %s    Updated: %s",
+		cluster, time.Now().Format("2006-01-02 15:04:05"))
+}
+
+// runCommand suspends dashboard and runs curveadm with args in terminal
+func (s *uiState) runCommand(fd int, args ...string) error {
+	binary, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(os.Stdout, ANSI_LEAVE_ALT_SCREEN)
+	args = append(args, "--cluster", s.curveadm.ClusterName())
+	cmd := exec.Command(binary, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Run() // the error has been printed by sub-process
+	fmt.Fprint(os.Stdout, "\nPress any key to return to dashboard...")
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	dashboard.ReadKey(bufio.NewReader(os.Stdin))
+	term.Restore(fd, state)
+	fmt.Fprint(os.Stdout, ANSI_ENTER_ALT_SCREEN)
+	return nil
+}
+
+func (s *uiState) perform(fd int, action string) error {
+	d := s.dashboard
+	selected := d.Selected()
+	switch {
+	case action == dashboard.KEY_REFRESH:
+		s.load(true)
+	case selected < 0:
+		return nil
+	case d.Current().Name == UI_TAB_CLUSTERS && action == UI_ACTION_CHECKOUT:
+		// the current cluster in database is untouched, see SwitchCluster
+		if err := s.curveadm.SwitchCluster(s.clusters[selected]); err != nil {
+			d.Message = err.Error()
+			return nil
+		}
+		s.load(false)
+	case d.Current().Name == UI_TAB_SERVICES && action == UI_ACTION_LOGS:
+		return s.runCommand(fd, "logs", "--id", s.services[selected], "--tail", UI_LOGS_TAIL)
+	case d.Current().Name == UI_TAB_SERVICES && action == UI_ACTION_STATUS:
+		return s.runCommand(fd, "status", "--id", s.services[selected], "--verbose", "--refresh")
+	}
+	return nil
+}
+
+func runUI(curveadm *cli.CurveAdm) error {
+	// 1) the dashboard reads keys in raw mode
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errno.ERR_UI_REQUIRES_TERMINAL
+	}
+
+	// 2) load resources before entering dashboard
+	s := newUIState(curveadm)
+	curveadm.WriteOutln("Loading dashboard...")
+	s.load(false)
+
+	// 3) handle keys until quit
+	fmt.Fprint(os.Stdout, ANSI_ENTER_ALT_SCREEN)
+	defer fmt.Fprint(os.Stdout, ANSI_LEAVE_ALT_SCREEN)
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		if _, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			s.dashboard.Height = height
+		}
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(os.Stdout, s.dashboard.Render())
+		key, err := dashboard.ReadKey(reader)
+		term.Restore(fd, state)
+		if err != nil {
+			return nil
+		}
+
+		action, quit := s.dashboard.HandleKey(key)
+		if quit {
+			return nil
+		} else if len(action) == 0 {
+			continue
+		}
+		if action == dashboard.KEY_REFRESH {
+			fmt.Fprint(os.Stdout, "\r\nRefreshing...")
+		}
+		if err := s.perform(fd, action); err != nil {
+			return err
+		}
+	}
+}
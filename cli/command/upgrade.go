@@ -25,9 +25,12 @@
 package command
 
 import (
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
@@ -36,6 +39,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	UPGRADE_EXAMPLE = `Examples:
+  $ curveadm upgrade                                       # Upgrade all services one by one
+  $ curveadm upgrade --rolling --by-zone --pause-after 1   # Upgrade one zone at a time and pause after the first zone
+  $ curveadm upgrade --resume                              # Resume the paused or failed rolling upgrade
+  $ curveadm upgrade --canary --role chunkserver --count 2 # Upgrade 2 chunkservers as canary
+  $ curveadm upgrade --promote --role chunkserver          # Upgrade the rest chunkservers after canary validated
+  $ curveadm upgrade --rollback-canary                     # Rollback canary services`
+)
+
 var (
 	UPGRADE_PLAYBOOK_STEPS = []int{
 		// TODO(P0): we can skip it for upgrade one service more than once
@@ -49,20 +62,39 @@ var (
 )
 
 type upgradeOptions struct {
-	id    string
-	role  string
-	host  string
-	force bool
+	id              string
+	role            string
+	host            string
+	force           bool
+	skipCompatCheck bool
+	rolling         bool
+	byZone          bool
+	pauseAfter      int
+	healthTimeout   time.Duration
+	resume          bool
+	abort           bool
+	canary          bool
+	count           int
+	promote         bool
+	rollback        bool
 }
 
 func NewUpgradeCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options upgradeOptions
 
 	cmd := &cobra.Command{
-		Use:   "upgrade [OPTIONS]",
-		Short: "Upgrade service",
-		Args:  cliutil.NoArgs,
+		Use:     "upgrade [OPTIONS]",
+		Short:   "Upgrade service",
+		Args:    cliutil.NoArgs,
+		Example: UPGRADE_EXAMPLE,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := checkRollingUpgradeOptions(options)
+			if err == nil {
+				err = checkCanaryUpgradeOptions(options)
+			}
+			if err != nil {
+				return err
+			}
 			return checkCommonOptions(curveadm, options.id, options.role, options.host)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -74,8 +106,19 @@ func NewUpgradeCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&options.id, "id", "*", "Specify service id")
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
-	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.host, "host", "*", "Specify service host or host group (e.g. @rack1)")
 	flags.BoolVarP(&options.force, "force", "f", false, "Never prompt")
+	flags.BoolVar(&options.skipCompatCheck, "skip-compat-check", false, "Upgrade even if images are unsupported by current curveadm")
+	flags.BoolVar(&options.rolling, "rolling", false, "Upgrade services one by one and wait them healthy")
+	flags.BoolVar(&options.byZone, "by-zone", false, "Upgrade chunkservers/metaservers one zone at a time in rolling upgrade")
+	flags.IntVar(&options.pauseAfter, "pause-after", 0, "Pause rolling upgrade after upgrading N units")
+	flags.DurationVar(&options.healthTimeout, "health-timeout", 10*time.Minute, "Specify timeout for waiting services healthy")
+	flags.BoolVar(&options.resume, "resume", false, "Resume the paused or failed rolling upgrade")
+	flags.BoolVar(&options.abort, "abort", false, "Abort the rolling upgrade")
+	flags.BoolVar(&options.canary, "canary", false, "Only upgrade a few services as canary")
+	flags.IntVar(&options.count, "count", 1, "Specify the number of canary services")
+	flags.BoolVar(&options.promote, "promote", false, "Upgrade the rest services after canary validated")
+	flags.BoolVar(&options.rollback, "rollback-canary", false, "Rollback canary services to the image before upgrade")
 
 	return cmd
 }
@@ -122,24 +165,30 @@ func upgradeAtOnce(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, options
 	displayTitle(curveadm, dcs, options)
 
 	// 2) confirm by user
-	if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+	if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
 		curveadm.WriteOut(tui.PromptCancelOpetation("upgrade service"))
 		return errno.ERR_CANCEL_OPERATION
 	}
 
-	// 3) generate upgrade playbook
+	// 3) record previous images for rollback
+	err := recordPreviousImages(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+
+	// 4) generate upgrade playbook
 	pb, err := genUpgradePlaybook(curveadm, dcs, options)
 	if err != nil {
 		return err
 	}
 
-	// 4) run playbook
+	// 5) run playbook
 	err = pb.Run()
 	if err != nil {
 		return err
 	}
 
-	// 5) print success prompt
+	// 6) print success prompt
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln(color.GreenString("Upgrade %d services success :)", len(dcs)))
 	return nil
@@ -156,24 +205,30 @@ func upgradeOneByOne(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, optio
 		curveadm.WriteOutln("")
 		curveadm.WriteOutln("Upgrade %s service:", color.BlueString("%d/%d", i+1, total))
 		curveadm.WriteOutln("  + host=%s  role=%s  image=%s", dc.GetHost(), dc.GetRole(), dc.GetContainerImage())
-		if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+		if pass := curveadm.Confirm(tui.DEFAULT_CONFIRM_PROMPT); !pass {
 			curveadm.WriteOut(tui.PromptCancelOpetation("upgrade service"))
 			return errno.ERR_CANCEL_OPERATION
 		}
 
-		// 2.2) generate upgrade playbook
+		// 2.2) record previous image for rollback
+		err := recordPreviousImages(curveadm, []*topology.DeployConfig{dc})
+		if err != nil {
+			return err
+		}
+
+		// 2.3) generate upgrade playbook
 		pb, err := genUpgradePlaybook(curveadm, []*topology.DeployConfig{dc}, options)
 		if err != nil {
 			return err
 		}
 
-		// 2.3) run playbook
+		// 2.4) run playbook
 		err = pb.Run()
 		if err != nil {
 			return err
 		}
 
-		// 2.4) print success prompt
+		// 2.5) print success prompt
 		curveadm.WriteOutln("")
 		curveadm.WriteOutln(color.GreenString("Upgrade %d/%d sucess :)"), i+1, total)
 	}
@@ -187,7 +242,17 @@ func runUpgrade(curveadm *cli.CurveAdm, options upgradeOptions) error {
 		return err
 	}
 
-	// 2) filter deploy config
+	// 2) resume or abort rolling upgrade, OR rollback canary
+	if options.abort {
+		return abortRollingUpgrade(curveadm)
+	} else if options.resume {
+		return resumeRollingUpgrade(curveadm, dcs, options)
+	} else if options.rollback {
+		return rollbackCanaryUpgrade(curveadm, dcs)
+	}
+
+	// 3) filter deploy config
+	dcsAll := dcs
 	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
 		Id:   options.id,
 		Role: options.role,
@@ -197,11 +262,31 @@ func runUpgrade(curveadm *cli.CurveAdm, options upgradeOptions) error {
 		return errno.ERR_NO_SERVICES_MATCHED
 	}
 
-	// 3.1) upgrade service at once
+	// 4) check compatibility between curveadm and images
+	if !options.skipCompatCheck {
+		err = configure.CheckServicesCompatibility(cli.Version, dcs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 5.1) upgrade canary services OR promote canary
+	if options.canary {
+		return canaryUpgrade(curveadm, dcs, options)
+	} else if options.promote {
+		return promoteCanaryUpgrade(curveadm, dcsAll, dcs, options)
+	}
+
+	// 5.2) OR upgrade service at once
 	if options.force {
 		return upgradeAtOnce(curveadm, dcs, options)
 	}
 
-	// 3.2) OR upgrade service one by one
+	// 5.3) OR rolling upgrade with health gates
+	if options.rolling {
+		return rollingUpgrade(curveadm, dcsAll, dcs, options)
+	}
+
+	// 5.4) OR upgrade service one by one
 	return upgradeOneByOne(curveadm, dcs, options)
 }
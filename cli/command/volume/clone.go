@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CLONE_EXAMPLE = `Examples:
+  $ curveadm volume clone /volume curve:/clone                                      # Clone volume from another volume
+  $ curveadm volume clone 5e0e2a3a-0e8c-4bb6-9b23-6d22b1f4e8c1 curve:/clone --lazy  # Lazy clone volume from snapshot`
+)
+
+type cloneOptions struct {
+	source string
+	image  string
+	lazy   bool
+}
+
+func NewCloneCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options cloneOptions
+
+	cmd := &cobra.Command{
+		Use:     "clone SOURCE USER:VOLUME [OPTIONS]",
+		Short:   "Clone volume from volume or snapshot",
+		Args:    cliutil.ExactArgs(2),
+		Example: CLONE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.source = args[0]
+			options.image = args[1]
+			_, _, err := client.ParseImage(options.image)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClone(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.lazy, "lazy", false, "Clone lazily, the data copied in background")
+
+	return cmd
+}
+
+func runClone(curveadm *cli.CurveAdm, options cloneOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	uuid, err := runVolumeAction(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_CLONE,
+		User:   user,
+		Volume: name,
+		Source: options.source,
+		Lazy:   options.lazy,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Volume %s cloned from %s (task uuid: %s) ^_^"),
+		options.image, options.source, uuid)
+	return nil
+}
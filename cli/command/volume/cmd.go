@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewVolumeCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volume",
+		Short: "Manage volumes of CurveBS",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewCreateCommand(curveadm),
+		NewExtendCommand(curveadm),
+		NewSnapshotCommand(curveadm),
+		NewCloneCommand(curveadm),
+		NewRemoveCommand(curveadm),
+		NewListCommand(curveadm),
+	)
+	return cmd
+}
+
+func genVolumePlaybook(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options bs.VolumeOptions) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.MANAGE_VOLUME,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_VOLUME_OPTIONS: options,
+		},
+	})
+	return pb
+}
+
+/*
+ * runVolumeAction performs the volume action in the first service which
+ * responsible for it (mds for create/extend/delete/list, snapshotclone for
+ * snapshot/clone), and returns the output of the action.
+ */
+func runVolumeAction(curveadm *cli.CurveAdm, options bs.VolumeOptions) (string, error) {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return "", err
+	}
+	dcs = topology.SelectByKind(dcs, topology.KIND_CURVEBS)
+	if len(dcs) == 0 {
+		return "", errno.ERR_UNSUPPORT_CLUSTER_KIND.
+			F("volume only supports curvebs cluster")
+	}
+
+	// 2) filter service
+	role := bs.GetVolumeRole(options.Action)
+	dcs = curveadm.FilterDeployConfigByRole(dcs, role)
+	if len(dcs) == 0 {
+		return "", errno.ERR_NO_SERVICES_MATCHED.
+			F("role: %s", role)
+	}
+
+	// 3) run playbook
+	err = genVolumePlaybook(curveadm, dcs[:1], options).Run()
+	if err != nil {
+		return "", err
+	}
+
+	output := ""
+	if v := curveadm.MemStorage().Get(comm.KEY_VOLUME_OUTPUT); v != nil {
+		output = v.(string)
+	}
+	return output, nil
+}
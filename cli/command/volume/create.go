@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CREATE_EXAMPLE = `Examples:
+  $ curveadm volume create curve:/volume               # Create volume which size is 10GiB
+  $ curveadm volume create curve:/volume --size 50GiB  # Create volume which size is 50GiB`
+)
+
+type createOptions struct {
+	image string
+	size  string
+}
+
+func checkImageAndSize(image, size string) error {
+	if _, _, err := client.ParseImage(image); err != nil {
+		return err
+	}
+	_, err := client.ParseSize(size)
+	return err
+}
+
+func NewCreateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options createOptions
+
+	cmd := &cobra.Command{
+		Use:     "create USER:VOLUME [OPTIONS]",
+		Short:   "Create volume",
+		Args:    cliutil.ExactArgs(1),
+		Example: CREATE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return checkImageAndSize(options.image, options.size)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
+
+	return cmd
+}
+
+func runCreate(curveadm *cli.CurveAdm, options createOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	size, _ := client.ParseSize(options.size)
+	_, err := runVolumeAction(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_CREATE,
+		User:   user,
+		Volume: name,
+		Size:   size,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Volume %s created (size: %s) ^_^"),
+		options.image, options.size)
+	return nil
+}
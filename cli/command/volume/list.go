@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LIST_EXAMPLE = `Examples:
+  $ curveadm volume ls curve             # List volumes of user 'curve'
+  $ curveadm volume ls curve --dir /dir  # List volumes of user 'curve' under directory '/dir'`
+)
+
+type listOptions struct {
+	user string
+	dir  string
+}
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls USER [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List volumes",
+		Args:    cliutil.ExactArgs(1),
+		Example: LIST_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.user = args[0]
+			return runList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.dir, "dir", "/", "Specify directory")
+
+	return cmd
+}
+
+func runList(curveadm *cli.CurveAdm, options listOptions) error {
+	output, err := runVolumeAction(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_LIST,
+		User:   options.user,
+		Volume: options.dir,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln("%s", output)
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	image string
+	yes   bool
+}
+
+func NewRemoveCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options removeOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm USER:VOLUME [OPTIONS]",
+		Aliases: []string{"delete"},
+		Short:   "Delete volume",
+		Args:    cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			_, _, err := client.ParseImage(options.image)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&options.yes, "yes", "y", false, "Automatic yes to prompts")
+
+	return cmd
+}
+
+func runRemove(curveadm *cli.CurveAdm, options removeOptions) error {
+	// 1) confirm by user
+	if !options.yes {
+		if pass := curveadm.Confirm(tui.PromptRemoveVolume(options.image)); !pass {
+			curveadm.WriteOut(tui.PromptCancelOpetation("remove volume"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 2) delete volume
+	user, name, _ := client.ParseImage(options.image)
+	_, err := runVolumeAction(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_DELETE,
+		User:   user,
+		Volume: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Volume %s deleted ^_^"), options.image)
+	return nil
+}
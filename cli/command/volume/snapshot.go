@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package volume
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	SNAPSHOT_EXAMPLE = `Examples:
+  $ curveadm volume snapshot curve:/volume --name snap1  # Create snapshot 'snap1' for volume`
+)
+
+type snapshotOptions struct {
+	image string
+	name  string
+}
+
+func NewSnapshotCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options snapshotOptions
+
+	cmd := &cobra.Command{
+		Use:     "snapshot USER:VOLUME [OPTIONS]",
+		Short:   "Create snapshot of volume",
+		Args:    cliutil.ExactArgs(1),
+		Example: SNAPSHOT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			_, _, err := client.ParseImage(options.image)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSnapshot(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.name, "name", "", "Specify snapshot name")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func runSnapshot(curveadm *cli.CurveAdm, options snapshotOptions) error {
+	user, name, _ := client.ParseImage(options.image)
+	uuid, err := runVolumeAction(curveadm, bs.VolumeOptions{
+		Action: bs.VOLUME_ACTION_SNAPSHOT,
+		User:   user,
+		Volume: name,
+		Name:   options.name,
+	})
+	if err != nil {
+		return err
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Snapshot %s of volume %s created (uuid: %s) ^_^"),
+		options.name, options.image, uuid)
+	return nil
+}
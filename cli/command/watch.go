@@ -0,0 +1,301 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/backup"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	WATCH_EXAMPLE = `Examples:
+  $ curveadm watch                                         # Probe services every 30 seconds
+  $ curveadm watch --interval 1m --retention 72h           # Probe every minute and keep samples for 3 days
+  $ curveadm watch --webhook http://alert.example.com/hook # Post alert to webhook when status changed
+  $ curveadm watch --once                                  # Probe services once and exit
+
+  # The due backup schedules (see 'curveadm backup schedule') are executed every round`
+
+	WEBHOOK_TIMEOUT = 5 * time.Second
+)
+
+type watchOptions struct {
+	interval  time.Duration
+	webhooks  []string
+	retention time.Duration
+	once      bool
+}
+
+// payload which posted to webhook when the status of probe changed
+type healthAlert struct {
+	Cluster string `json:"cluster"`
+	Probe   string `json:"probe"`
+	Target  string `json:"target"`
+	Host    string `json:"host"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Value   string `json:"value"`
+	Time    string `json:"time"`
+}
+
+func checkWatchOptions(options watchOptions) error {
+	if options.interval <= 0 {
+		return errno.ERR_INVALID_WATCH_INTERVAL.
+			F("interval: %s", options.interval)
+	}
+	return nil
+}
+
+func NewWatchCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options watchOptions
+
+	cmd := &cobra.Command{
+		Use:     "watch [OPTIONS]",
+		Short:   "Probe health of services and run backup schedules periodically",
+		Args:    cliutil.NoArgs,
+		Example: WATCH_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkWatchOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.DurationVar(&options.interval, "interval", 30*time.Second, "Specify interval between probes")
+	flags.StringArrayVar(&options.webhooks, "webhook", []string{}, "Specify webhook which alert posted to")
+	flags.DurationVar(&options.retention, "retention", 7*24*time.Hour, "Specify how long samples retained (0 means forever)")
+	flags.BoolVar(&options.once, "once", false, "Probe services once and exit")
+
+	return cmd
+}
+
+func genWatchPlaybook(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.PROBE_SERVICE_HEALTH,
+		Configs: dcs,
+		ExecOptions: playbook.ExecOptions{
+			SilentMainBar: true,
+			SilentSubBar:  true,
+			SkipError:     true,
+		},
+	})
+	return pb
+}
+
+func probeKey(probe, target string) string {
+	return fmt.Sprintf("%s/%s", probe, target)
+}
+
+// the service which can't be reached has no probe, we treat its liveness as unknown
+func collectHealthProbes(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) []task.HealthProbe {
+	probes := []task.HealthProbe{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_HEALTH_PROBES); v != nil {
+		probes = v.([]task.HealthProbe)
+	}
+
+	probed := map[string]bool{}
+	for _, probe := range probes {
+		probed[probe.Target] = true
+	}
+	for _, dc := range dcs {
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		if probed[serviceId] || curveadm.IsSkip(dc) {
+			continue
+		}
+		probes = append(probes, task.HealthProbe{
+			Probe:  task.HEALTH_PROBE_LIVENESS,
+			Target: serviceId,
+			Host:   dc.GetHost(),
+			Status: task.HEALTH_STATUS_UNKNOWN,
+			Value:  "unreachable",
+		})
+	}
+	return probes
+}
+
+func postAlert(webhook string, alert healthAlert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: WEBHOOK_TIMEOUT}
+	resp, err := client.Post(webhook, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responds %s", resp.Status)
+	}
+	return nil
+}
+
+func colorStatus(status string) string {
+	switch status {
+	case task.HEALTH_STATUS_OK:
+		return color.GreenString(status)
+	case task.HEALTH_STATUS_WARNING:
+		return color.YellowString(status)
+	case task.HEALTH_STATUS_CRITICAL:
+		return color.RedString(status)
+	}
+	return color.BlueString(status)
+}
+
+func alertTransition(curveadm *cli.CurveAdm, options watchOptions, alert healthAlert) {
+	curveadm.WriteOutln("[%s] %s %s (host=%s): %s -> %s (%s)",
+		alert.Time, alert.Probe, alert.Target, alert.Host,
+		colorStatus(alert.From), colorStatus(alert.To), alert.Value)
+	for _, webhook := range options.webhooks {
+		if err := postAlert(webhook, alert); err != nil {
+			curveadm.WriteOutln(color.YellowString("WARNING: post alert to %s failed: %s", webhook, err))
+		}
+	}
+}
+
+func watchOnce(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options watchOptions,
+	lastStatus map[string]string) error {
+	// 1) run probe playbook, the error is ignored because
+	//    the service which probe failed is reported as unknown
+	curveadm.MemStorage().Set(comm.KEY_ALL_HEALTH_PROBES, nil)
+	genWatchPlaybook(curveadm, dcs).Run()
+
+	// 2) save samples and alert the status transition
+	now := time.Now()
+	clusterId := curveadm.ClusterId()
+	for _, probe := range collectHealthProbes(curveadm, dcs) {
+		err := curveadm.Storage().InsertHealthSample(storage.HealthSample{
+			ClusterId:  clusterId,
+			Probe:      probe.Probe,
+			Target:     probe.Target,
+			Status:     probe.Status,
+			Value:      probe.Value,
+			SampleTime: now,
+		})
+		if err != nil {
+			return errno.ERR_INSERT_HEALTH_SAMPLE_FAILED.E(err)
+		}
+
+		key := probeKey(probe.Probe, probe.Target)
+		from, ok := lastStatus[key]
+		lastStatus[key] = probe.Status
+		// the first sample which is ok isn't a transition
+		if from == probe.Status || (!ok && probe.Status == task.HEALTH_STATUS_OK) {
+			continue
+		} else if !ok {
+			from = task.HEALTH_STATUS_UNKNOWN
+		}
+		alertTransition(curveadm, options, healthAlert{
+			Cluster: curveadm.ClusterName(),
+			Probe:   probe.Probe,
+			Target:  probe.Target,
+			Host:    probe.Host,
+			From:    from,
+			To:      probe.Status,
+			Value:   probe.Value,
+			Time:    now.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	// 3) prune expired samples
+	if options.retention > 0 {
+		err := curveadm.Storage().DeleteHealthSamplesBefore(clusterId, now.Add(-options.retention))
+		if err != nil {
+			return errno.ERR_DELETE_HEALTH_SAMPLES_FAILED.E(err)
+		}
+	}
+
+	// 4) run due backup schedules, the failed one is reported and retried at its next time
+	runs, err := backup.RunDueBackups(curveadm, dcs, now, false)
+	for _, run := range runs {
+		curveadm.WriteOutln("[%s] %s", now.Format("2006-01-02 15:04:05"), run)
+	}
+	return err
+}
+
+func runWatch(curveadm *cli.CurveAdm, options watchOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) load latest status, so that restarting watch won't alert again
+	samples, err := curveadm.Storage().GetLatestHealthSamples(curveadm.ClusterId())
+	if err != nil {
+		return errno.ERR_GET_LATEST_HEALTH_SAMPLES_FAILED.E(err)
+	}
+	lastStatus := map[string]string{}
+	for _, sample := range samples {
+		lastStatus[probeKey(sample.Probe, sample.Target)] = sample.Status
+	}
+
+	// 3) probe services until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if !options.once {
+		curveadm.WriteOutln("Watching cluster '%s' every %s, press Ctrl+C to stop...",
+			curveadm.ClusterName(), options.interval)
+	}
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	for {
+		err := watchOnce(curveadm, dcs, options, lastStatus)
+		if err != nil || options.once {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			curveadm.WriteOutln("Watch stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
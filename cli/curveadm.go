@@ -29,27 +29,49 @@ import (
 
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/cli/command"
+	"github.com/opencurve/curveadm/internal/errno"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+	"github.com/opencurve/curveadm/pkg/tracing"
 )
 
+// exit flushes the logs buffered before options parsed, e.g. command line parse error
+func exit(code int) {
+	log.Flush()
+	os.Exit(code)
+}
+
 func Execute() {
 	curveadm, err := cli.NewCurveAdm()
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exit(errno.ExitCode(err))
 	}
 
-	yes, err := curveadm.Upgrade()
-	if err != nil {
-		os.Exit(1)
-	} else if yes {
-		os.Exit(0)
+	// never prompt upgrading curveadm itself in pipelines or shell completion
+	if !command.AssumeYes(os.Args[1:]) && !command.IsCompletionRequest(os.Args[1:]) {
+		yes, err := curveadm.Upgrade()
+		if err != nil {
+			exit(errno.ExitCode(err))
+		} else if yes {
+			exit(0)
+		}
 	}
 
-	id := curveadm.PreAudit(time.Now(), os.Args[1:])
+	now := time.Now()
+	id := curveadm.PreAudit(now, os.Args[1:])
 	cmd := command.NewCurveAdmCommand(curveadm)
-	err = cmd.Execute()
-	curveadm.PostAudit(id, err)
-	if err != nil {
-		os.Exit(1)
+	args := command.ExpandAlias(curveadm, cmd, os.Args[1:])
+	handled, err := command.RunMacroCommand(curveadm, cmd, args)
+	if !handled {
+		handled, err = command.RunPluginCommand(curveadm, cmd, args)
+	}
+	if !handled {
+		cmd.SetArgs(args)
+		cmd, err = cmd.ExecuteC()
 	}
+	curveadm.PostAudit(id, err)
+	curveadm.EmitEvent(now, cmd.CommandPath(), os.Args[1:], err)
+	command.SendReport(curveadm, now, cmd, os.Args[1:], err)
+	tracing.Shutdown()
+	exit(errno.ExitCode(err))
 }
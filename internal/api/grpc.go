@@ -0,0 +1,262 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	v1 "github.com/opencurve/curveadm/pkg/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer serves the same API as REST by gRPC, see pkg/api/v1/curveadm.proto
+type grpcServer struct {
+	v1.UnimplementedCurveAdmServer
+	server *Server
+}
+
+// grpcError converts error returned by API into gRPC status
+func grpcError(err error) error {
+	code := codes.Internal
+	switch httpStatus(err) {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusServiceUnavailable:
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, err.Error())
+}
+
+func unixTime(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+func protoJob(job *Job) *v1.Job {
+	snapshot := job.Snapshot()
+	return &v1.Job{
+		Id:         snapshot.Id,
+		Cluster:    snapshot.Cluster,
+		Operation:  snapshot.Operation,
+		Args:       snapshot.Args,
+		Status:     snapshot.Status,
+		Code:       int64(snapshot.Code),
+		Error:      snapshot.Error,
+		CreateTime: snapshot.CreateTime.Unix(),
+		StartTime:  unixTime(snapshot.StartTime),
+		EndTime:    unixTime(snapshot.EndTime),
+	}
+}
+
+func protoCluster(cluster ClusterResponse) *v1.Cluster {
+	return &v1.Cluster{
+		Id:          int64(cluster.Id),
+		Uuid:        cluster.UUId,
+		Name:        cluster.Name,
+		Description: cluster.Description,
+		CreateTime:  cluster.CreateTime.Unix(),
+		Current:     cluster.Current,
+		Protected:   cluster.Protected,
+	}
+}
+
+func (s *Server) authorizedContext(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(v1.METADATA_AUTHORIZATION) {
+		token := strings.TrimPrefix(value, v1.PREFIX_BEARER)
+		if len(token) > 0 &&
+			subtle.ConstantTimeCompare([]byte(token), []byte(s.options.Token)) == 1 {
+			return nil
+		}
+	}
+	return grpcError(errno.ERR_API_UNAUTHORIZED)
+}
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.authorizedContext(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamInterceptor(srv interface{}, stream grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.authorizedContext(stream.Context()); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+func (s *Server) newGRPCServer() (*grpc.Server, error) {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(s.unaryInterceptor),
+		grpc.StreamInterceptor(s.streamInterceptor),
+	}
+	if len(s.options.CertFile) > 0 && len(s.options.KeyFile) > 0 {
+		creds, err := credentials.NewServerTLSFromFile(s.options.CertFile, s.options.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	server := grpc.NewServer(opts...)
+	v1.RegisterCurveAdmServer(server, &grpcServer{server: s})
+	return server, nil
+}
+
+// ServeGRPC serves gRPC API on listener until Shutdown called
+func (s *Server) ServeGRPC(listener net.Listener) error {
+	server, err := s.newGRPCServer()
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.grpcServers = append(s.grpcServers, server)
+	s.mutex.Unlock()
+	return server.Serve(listener)
+}
+
+func (s *Server) ListenAndServeGRPC() error {
+	listener, err := net.Listen("tcp", s.options.GRPCListen)
+	if err != nil {
+		return err
+	}
+	return s.ServeGRPC(listener)
+}
+
+func source(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func (g *grpcServer) ListClusters(ctx context.Context, req *v1.ListClustersRequest) (*v1.ListClustersResponse, error) {
+	clusters, err := g.server.Clusters()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	response := &v1.ListClustersResponse{}
+	for _, cluster := range clusters {
+		response.Clusters = append(response.Clusters, protoCluster(cluster))
+	}
+	return response, nil
+}
+
+func (g *grpcServer) GetCluster(ctx context.Context, req *v1.GetClusterRequest) (*v1.Cluster, error) {
+	cluster, err := g.server.findCluster(req.GetName())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return protoCluster(g.server.clusterResponse(*cluster)), nil
+}
+
+func (g *grpcServer) GetTopology(ctx context.Context, req *v1.GetTopologyRequest) (*v1.Topology, error) {
+	cluster, err := g.server.findCluster(req.GetCluster())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &v1.Topology{Cluster: cluster.Name, Topology: cluster.Topology}, nil
+}
+
+func (g *grpcServer) SubmitJob(ctx context.Context, req *v1.SubmitJobRequest) (*v1.Job, error) {
+	if len(req.GetFile()) > MAX_REQUEST_BODY {
+		return nil, grpcError(errno.ERR_INVALID_API_REQUEST.F("file exceeds %d bytes", MAX_REQUEST_BODY))
+	}
+	job, err := g.server.Submit(req.GetCluster(), JobRequest{
+		Operation: req.GetOperation(),
+		Args:      req.GetArgs(),
+		Confirm:   req.GetConfirm(),
+		File:      req.GetFile(),
+	}, source(ctx))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return protoJob(job), nil
+}
+
+func (g *grpcServer) GetJob(ctx context.Context, req *v1.GetJobRequest) (*v1.Job, error) {
+	job, ok := g.server.jobs.Get(req.GetId())
+	if !ok {
+		return nil, grpcError(errno.ERR_API_JOB_NOT_FOUND.F("id: %s", req.GetId()))
+	}
+	return protoJob(job), nil
+}
+
+func (g *grpcServer) ListJobs(ctx context.Context, req *v1.ListJobsRequest) (*v1.ListJobsResponse, error) {
+	response := &v1.ListJobsResponse{}
+	for _, job := range g.server.jobs.List() {
+		response.Jobs = append(response.Jobs, protoJob(job))
+	}
+	return response, nil
+}
+
+// WatchJob streams output lines from offset, the last event carries the finished job
+func (g *grpcServer) WatchJob(req *v1.WatchJobRequest, stream v1.CurveAdm_WatchJobServer) error {
+	job, ok := g.server.jobs.Get(req.GetId())
+	if !ok {
+		return grpcError(errno.ERR_API_JOB_NOT_FOUND.F("id: %s", req.GetId()))
+	}
+
+	offset := int(req.GetOffset())
+	if offset < 0 {
+		offset = 0
+	}
+	for {
+		lines, finished, updated := job.Follow(offset)
+		for _, line := range lines {
+			offset++
+			if err := stream.Send(&v1.JobEvent{Line: line, Offset: int64(offset)}); err != nil {
+				return err
+			}
+		}
+		if finished {
+			return stream.Send(&v1.JobEvent{Offset: int64(offset), Job: protoJob(job)})
+		}
+
+		select {
+		case <-updated:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
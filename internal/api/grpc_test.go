@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	v1 "github.com/opencurve/curveadm/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestGRPCServer(t *testing.T, executor Executor) (*Server, string) {
+	s := NewServer(nil, Options{Token: "secret", TempDir: "/tmp"}, executor)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	go s.ServeGRPC(listener)
+	return s, listener.Addr().String()
+}
+
+func TestGRPCAuth(t *testing.T) {
+	assert := assert.New(t)
+
+	s, address := newTestGRPCServer(t, nil)
+	defer s.Shutdown(context.Background())
+
+	for _, token := range []string{"", "wrong"} {
+		client, err := v1.Dial(address, token, nil)
+		assert.Nil(err)
+		_, err = client.ListJobs(context.Background(), &v1.ListJobsRequest{})
+		assert.Equal(codes.Unauthenticated, status.Code(err))
+		client.Close()
+	}
+
+	client, err := v1.Dial(address, "secret", nil)
+	assert.Nil(err)
+	defer client.Close()
+	_, err = client.ListJobs(context.Background(), &v1.ListJobsRequest{})
+	assert.Nil(err)
+	_, err = client.GetJob(context.Background(), &v1.GetJobRequest{Id: "not-exist"})
+	assert.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestGRPCWatchJob(t *testing.T) {
+	assert := assert.New(t)
+
+	s, address := newTestGRPCServer(t, func(job *Job, args []string, out io.Writer) error {
+		fmt.Fprintf(out, "line1\nline2\n")
+		return nil
+	})
+	defer s.Shutdown(context.Background())
+
+	job := newJob("c1", "status", []string{"status", "--cluster", "c1"})
+	assert.Nil(s.jobs.Submit(job))
+
+	client, err := v1.Dial(address, "secret", nil)
+	assert.Nil(err)
+	defer client.Close()
+	stream, err := client.WatchJob(context.Background(), &v1.WatchJobRequest{Id: job.Id, Offset: 1})
+	assert.Nil(err)
+	events := []*v1.JobEvent{}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(err)
+		events = append(events, event)
+	}
+	assert.Equal(2, len(events))
+	assert.Equal("line2", events[0].GetLine())
+	assert.Equal(int64(2), events[0].GetOffset())
+	assert.Equal(JOB_STATUS_SUCCESS, events[1].GetJob().GetStatus())
+	assert.Equal([]string{"status", "--cluster", "c1"}, events[1].GetJob().GetArgs())
+}
@@ -0,0 +1,272 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package api
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+const (
+	JOB_STATUS_PENDING = "pending"
+	JOB_STATUS_RUNNING = "running"
+	JOB_STATUS_SUCCESS = "success"
+	JOB_STATUS_FAILED  = "failed"
+
+	MAX_PENDING_JOBS = 64
+	MAX_KEEP_JOBS    = 256
+)
+
+type (
+	// Executor runs curveadm command with args for job, all output written into out
+	Executor func(job *Job, args []string, out io.Writer) error
+
+	Job struct {
+		Id         string     `json:"id"`
+		Cluster    string     `json:"cluster"`
+		Operation  string     `json:"operation"`
+		Args       []string   `json:"args"`
+		Status     string     `json:"status"`
+		Code       int        `json:"code"`
+		Error      string     `json:"error,omitempty"`
+		CreateTime time.Time  `json:"create_time"`
+		StartTime  *time.Time `json:"start_time,omitempty"`
+		EndTime    *time.Time `json:"end_time,omitempty"`
+
+		Source  string `json:"-"` // client address of request
+		Confirm string `json:"-"` // answer of prompts
+		File    string `json:"-"` // content of configure file passed to command
+
+		mutex   sync.Mutex
+		lines   []string
+		partial string
+		updated chan struct{} // closed and renewed once output appended or job finished
+	}
+
+	/*
+	 * JobManager executes jobs one by one in order, because the SSH pool and
+	 * some other resources are shared by the whole process.
+	 */
+	JobManager struct {
+		executor Executor
+		tempDir  string
+		mutex    sync.Mutex
+		jobs     map[string]*Job
+		ids      []string // in create order
+		queue    chan *Job
+	}
+)
+
+func newJob(cluster, operation string, args []string) *Job {
+	return &Job{
+		Id:         uuid.NewString(),
+		Cluster:    cluster,
+		Operation:  operation,
+		Args:       args,
+		Status:     JOB_STATUS_PENDING,
+		CreateTime: time.Now(),
+		updated:    make(chan struct{}),
+	}
+}
+
+func (job *Job) notify() {
+	close(job.updated)
+	job.updated = make(chan struct{})
+}
+
+// Write splits output into lines, the last incomplete line is kept until job finished
+func (job *Job) Write(p []byte) (int, error) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+
+	data := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(job.partial + string(p))
+	lines := strings.Split(data, "\n")
+	job.partial = lines[len(lines)-1]
+	if len(lines) > 1 {
+		job.lines = append(job.lines, lines[:len(lines)-1]...)
+		job.notify()
+	}
+	return len(p), nil
+}
+
+func (job *Job) finished() bool {
+	return job.Status == JOB_STATUS_SUCCESS || job.Status == JOB_STATUS_FAILED
+}
+
+func (job *Job) start() {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+
+	now := time.Now()
+	job.Status = JOB_STATUS_RUNNING
+	job.StartTime = &now
+	job.notify()
+}
+
+func (job *Job) finish(err error) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+
+	if len(job.partial) > 0 {
+		job.lines = append(job.lines, job.partial)
+		job.partial = ""
+	}
+	now := time.Now()
+	job.EndTime = &now
+	job.Status = JOB_STATUS_SUCCESS
+	if err != nil {
+		job.Status = JOB_STATUS_FAILED
+		job.Error = err.Error()
+		job.Code = errno.ERR_UNKNOWN.GetCode()
+		if code, ok := err.(*errno.ErrorCode); ok {
+			job.Code = code.GetCode()
+			job.Error = code.GetDescription()
+		}
+	}
+	job.notify()
+}
+
+/*
+ * Follow returns output lines from offset, whether the job finished and
+ * a channel which closed once there are more lines or the job finished.
+ */
+func (job *Job) Follow(offset int) ([]string, bool, <-chan struct{}) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+
+	lines := []string{}
+	if offset < len(job.lines) {
+		lines = append(lines, job.lines[offset:]...)
+	}
+	return lines, job.finished(), job.updated
+}
+
+// Snapshot returns a copy of job which can be encoded safely
+func (job *Job) Snapshot() Job {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+
+	return Job{
+		Id:         job.Id,
+		Cluster:    job.Cluster,
+		Operation:  job.Operation,
+		Args:       job.Args,
+		Status:     job.Status,
+		Code:       job.Code,
+		Error:      job.Error,
+		CreateTime: job.CreateTime,
+		StartTime:  job.StartTime,
+		EndTime:    job.EndTime,
+	}
+}
+
+func NewJobManager(executor Executor, tempDir string) *JobManager {
+	return &JobManager{
+		executor: executor,
+		tempDir:  tempDir,
+		jobs:     map[string]*Job{},
+		queue:    make(chan *Job, MAX_PENDING_JOBS),
+	}
+}
+
+func (m *JobManager) Submit(job *Job) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	select {
+	case m.queue <- job:
+	default:
+		return errno.ERR_TOO_MANY_PENDING_API_JOBS.F("max pending jobs: %d", MAX_PENDING_JOBS)
+	}
+
+	m.jobs[job.Id] = job
+	m.ids = append(m.ids, job.Id)
+	// forget the oldest finished jobs
+	for len(m.ids) > MAX_KEEP_JOBS {
+		oldest := m.jobs[m.ids[0]]
+		if _, finished, _ := oldest.Follow(0); !finished {
+			break
+		}
+		delete(m.jobs, m.ids[0])
+		m.ids = m.ids[1:]
+	}
+	return nil
+}
+
+func (m *JobManager) Get(id string) (*Job, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	job, ok := m.jobs[id]
+	return job, ok
+}
+
+// List returns all jobs, the latest first
+func (m *JobManager) List() []*Job {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	jobs := []*Job{}
+	for i := len(m.ids) - 1; i >= 0; i-- {
+		jobs = append(jobs, m.jobs[m.ids[i]])
+	}
+	return jobs
+}
+
+// execute writes configure file into temporary directory if required, and then run the command
+func (m *JobManager) execute(job *Job) error {
+	args := append([]string{}, job.Args...)
+	if len(job.File) > 0 {
+		file, err := os.CreateTemp(m.tempDir, "api-*.yaml")
+		if err != nil {
+			return errno.ERR_WRITE_FILE_FAILED.E(err)
+		}
+		defer os.Remove(file.Name())
+		_, err = file.WriteString(job.File)
+		file.Close()
+		if err != nil {
+			return errno.ERR_WRITE_FILE_FAILED.E(err)
+		}
+		args = replaceFilePlaceholder(args, file.Name())
+	}
+	return m.executor(job, args, job)
+}
+
+// Run executes the submitted jobs until stop closed
+func (m *JobManager) Run(stop <-chan struct{}) {
+	for {
+		select {
+		case job := <-m.queue:
+			job.start()
+			job.finish(m.execute(job))
+		case <-stop:
+			return
+		}
+	}
+}
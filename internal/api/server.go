@@ -0,0 +1,500 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"google.golang.org/grpc"
+)
+
+/*
+ * GET  /api/v1/clusters                  list clusters
+ * GET  /api/v1/clusters/{name}           get cluster
+ * GET  /api/v1/clusters/{name}/topology  get cluster topology
+ * POST /api/v1/clusters/{name}/jobs      submit job which executes operation on cluster
+ * GET  /api/v1/jobs                      list jobs
+ * GET  /api/v1/jobs/{id}                 get job
+ * GET  /api/v1/jobs/{id}/events          stream output of job by server-sent events
+ *
+ * all requests require token by header 'Authorization: Bearer TOKEN',
+ * or query parameter '?token=TOKEN' for EventSource which can't set header.
+ */
+const (
+	API_PREFIX = "/api/v1"
+
+	HEADER_AUTHORIZATION = "Authorization"
+	HEADER_LAST_EVENT_ID = "Last-Event-ID"
+	PREFIX_BEARER        = "Bearer "
+	QUERY_TOKEN          = "token"
+
+	// replaced by path of configure file which passed by request
+	FILE_PLACEHOLDER = "{file}"
+
+	EVENT_OUTPUT   = "output"
+	EVENT_DONE     = "done"
+	SSE_KEEP_ALIVE = 15 * time.Second
+
+	MAX_REQUEST_BODY = 4 << 20
+)
+
+type (
+	Options struct {
+		Listen   string
+		Token    string
+		CertFile string // serve HTTPS if both certificate and key specified
+		KeyFile  string
+		TempDir  string // where configure files of request stored
+		// serve gRPC API on the address if specified, shares token and TLS with REST
+		GRPCListen string
+	}
+
+	operation struct {
+		command      []string
+		requiresFile bool
+	}
+
+	JobRequest struct {
+		Operation string   `json:"operation"`
+		Args      []string `json:"args"`    // extra options of command, e.g: ["--skip", "snapshotclone"]
+		Confirm   string   `json:"confirm"` // 'yes' or cluster name for protected cluster
+		File      string   `json:"file"`    // content of topology or format configure
+	}
+
+	ClusterResponse struct {
+		Id          int       `json:"id"`
+		UUId        string    `json:"uuid"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		CreateTime  time.Time `json:"create_time"`
+		Current     bool      `json:"current"`
+		Protected   bool      `json:"protected"`
+	}
+
+	TopologyResponse struct {
+		Cluster  string `json:"cluster"`
+		Topology string `json:"topology"`
+	}
+
+	ErrorResponse struct {
+		Code    int      `json:"code"`
+		Message string   `json:"message"`
+		Clue    string   `json:"clue,omitempty"`
+		Hosts   []string `json:"hosts,omitempty"`
+		Hints   []string `json:"hints,omitempty"`
+	}
+
+	route struct {
+		method  string
+		pattern []string // "*" matches any item
+		handler func(w http.ResponseWriter, r *http.Request, params []string)
+	}
+
+	Server struct {
+		curveadm *cli.CurveAdm
+		options  Options
+		jobs     *JobManager
+		routes   []route
+		server   *http.Server
+		stop     chan struct{}
+
+		mutex       sync.Mutex
+		grpcServers []*grpc.Server
+	}
+)
+
+var (
+	OPERATIONS = map[string]operation{
+		"deploy":          {command: []string{"deploy"}},
+		"status":          {command: []string{"status"}},
+		"format":          {command: []string{"format", "-f", FILE_PLACEHOLDER}, requiresFile: true},
+		"format-status":   {command: []string{"format", "--status", "-f", FILE_PLACEHOLDER}, requiresFile: true},
+		"commit-topology": {command: []string{"config", "commit", FILE_PLACEHOLDER}, requiresFile: true},
+	}
+)
+
+func replaceFilePlaceholder(args []string, filename string) []string {
+	for i, arg := range args {
+		if arg == FILE_PLACEHOLDER {
+			args[i] = filename
+		}
+	}
+	return args
+}
+
+// BuildArgs returns arguments of curveadm command for the operation on cluster
+func BuildArgs(cluster string, request JobRequest) ([]string, error) {
+	op, ok := OPERATIONS[request.Operation]
+	if !ok {
+		return nil, errno.ERR_UNSUPPORT_API_OPERATION.F("operation: %s", request.Operation)
+	} else if op.requiresFile && len(request.File) == 0 {
+		return nil, errno.ERR_INVALID_API_REQUEST.
+			F("operation '%s' requires configure file content in 'file'", request.Operation)
+	}
+
+	for _, arg := range request.Args {
+		if arg == "--cluster" || strings.HasPrefix(arg, "--cluster=") || arg == FILE_PLACEHOLDER {
+			return nil, errno.ERR_INVALID_API_REQUEST.F("argument '%s' is not allowed", arg)
+		}
+	}
+
+	args := append([]string{}, op.command...)
+	args = append(args, request.Args...)
+	return append(args, "--cluster", cluster), nil
+}
+
+func NewServer(curveadm *cli.CurveAdm, options Options, executor Executor) *Server {
+	s := &Server{
+		curveadm: curveadm,
+		options:  options,
+		jobs:     NewJobManager(executor, options.TempDir),
+		stop:     make(chan struct{}),
+	}
+	s.routes = []route{
+		{http.MethodGet, []string{"clusters"}, s.listClusters},
+		{http.MethodGet, []string{"clusters", "*"}, s.getCluster},
+		{http.MethodGet, []string{"clusters", "*", "topology"}, s.getTopology},
+		{http.MethodPost, []string{"clusters", "*", "jobs"}, s.submitJob},
+		{http.MethodGet, []string{"jobs"}, s.listJobs},
+		{http.MethodGet, []string{"jobs", "*"}, s.getJob},
+		{http.MethodGet, []string{"jobs", "*", "events"}, s.streamJob},
+	}
+	s.server = &http.Server{Addr: options.Listen, Handler: s}
+	go s.jobs.Run(s.stop)
+	return s
+}
+
+func (s *Server) ListenAndServe() error {
+	if len(s.options.CertFile) > 0 && len(s.options.KeyFile) > 0 {
+		return s.server.ListenAndServeTLS(s.options.CertFile, s.options.KeyFile)
+	}
+	return s.server.ListenAndServe()
+}
+
+// Shutdown stops accepting requests, the running job is left to exit with process
+func (s *Server) Shutdown(ctx context.Context) error {
+	close(s.stop)
+	s.mutex.Lock()
+	for _, server := range s.grpcServers {
+		server.Stop() // watching streams never end by themselves
+	}
+	s.mutex.Unlock()
+	return s.server.Shutdown(ctx)
+}
+
+// httpStatus returns the HTTP status of error returned by API
+func httpStatus(err error) int {
+	code, ok := err.(*errno.ErrorCode)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+
+	switch code.GetCode() {
+	case errno.ERR_API_UNAUTHORIZED.GetCode():
+		return http.StatusUnauthorized
+	case errno.ERR_INVALID_API_REQUEST.GetCode(),
+		errno.ERR_UNSUPPORT_API_OPERATION.GetCode():
+		return http.StatusBadRequest
+	case errno.ERR_CLUSTER_NOT_FOUND.GetCode(),
+		errno.ERR_API_JOB_NOT_FOUND.GetCode():
+		return http.StatusNotFound
+	case errno.ERR_TOO_MANY_PENDING_API_JOBS.GetCode():
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	response := ErrorResponse{
+		Code:    errno.ERR_UNKNOWN.GetCode(),
+		Message: err.Error(),
+	}
+	if code, ok := err.(*errno.ErrorCode); ok {
+		response.Code = code.GetCode()
+		response.Message = code.GetDescription()
+		response.Clue = code.GetClue()
+		response.Hosts = code.GetHosts()
+		response.Hints = code.GetHints()
+	}
+	writeJSON(w, status, response)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token := r.URL.Query().Get(QUERY_TOKEN)
+	if header := r.Header.Get(HEADER_AUTHORIZATION); len(header) > 0 {
+		token = strings.TrimPrefix(header, PREFIX_BEARER)
+	}
+	return len(token) > 0 &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(s.options.Token)) == 1
+}
+
+func match(pattern, items []string) ([]string, bool) {
+	if len(pattern) != len(items) {
+		return nil, false
+	}
+	params := []string{}
+	for i, item := range pattern {
+		if item == "*" {
+			params = append(params, items[i])
+		} else if item != items[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, errno.ERR_API_UNAUTHORIZED)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, API_PREFIX+"/")
+	if path == r.URL.Path {
+		writeError(w, http.StatusNotFound, errno.ERR_INVALID_API_REQUEST.F("path: %s", r.URL.Path))
+		return
+	}
+	items := strings.Split(strings.Trim(path, "/"), "/")
+	methodAllowed := true
+	for _, route := range s.routes {
+		params, ok := match(route.pattern, items)
+		if !ok {
+			continue
+		} else if route.method != r.Method {
+			methodAllowed = false
+			continue
+		}
+		route.handler(w, r, params)
+		return
+	}
+
+	if !methodAllowed {
+		writeError(w, http.StatusMethodNotAllowed,
+			errno.ERR_INVALID_API_REQUEST.F("method: %s, path: %s", r.Method, r.URL.Path))
+		return
+	}
+	writeError(w, http.StatusNotFound, errno.ERR_INVALID_API_REQUEST.F("path: %s", r.URL.Path))
+}
+
+func (s *Server) findCluster(name string) (*storage.Cluster, error) {
+	clusters, err := s.curveadm.Storage().GetClusters(name)
+	if err != nil {
+		return nil, errno.ERR_GET_CLUSTER_BY_NAME_FAILED.E(err)
+	}
+	// the name is matched by LIKE, filter out the exact one
+	for _, cluster := range clusters {
+		if cluster.Name == name {
+			return &cluster, nil
+		}
+	}
+	return nil, errno.ERR_CLUSTER_NOT_FOUND.F("cluster name: %s", name)
+}
+
+func (s *Server) clusterResponse(cluster storage.Cluster) ClusterResponse {
+	// treat the cluster as protected if the protection can't be got
+	protection, err := s.curveadm.GetClusterProtection(cluster.Id)
+	return ClusterResponse{
+		Id:          cluster.Id,
+		UUId:        cluster.UUId,
+		Name:        cluster.Name,
+		Description: cluster.Description,
+		CreateTime:  cluster.CreateTime,
+		Current:     cluster.Current,
+		Protected:   err != nil || protection != nil,
+	}
+}
+
+func (s *Server) Clusters() ([]ClusterResponse, error) {
+	clusters, err := s.curveadm.Storage().GetClusters("%")
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLUSTERS_FAILED.E(err)
+	}
+
+	response := []ClusterResponse{}
+	for _, cluster := range clusters {
+		response = append(response, s.clusterResponse(cluster))
+	}
+	return response, nil
+}
+
+func (s *Server) listClusters(w http.ResponseWriter, r *http.Request, params []string) {
+	response, err := s.Clusters()
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, r *http.Request, params []string) {
+	cluster, err := s.findCluster(params[0])
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.clusterResponse(*cluster))
+}
+
+func (s *Server) getTopology(w http.ResponseWriter, r *http.Request, params []string) {
+	cluster, err := s.findCluster(params[0])
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, TopologyResponse{
+		Cluster:  cluster.Name,
+		Topology: cluster.Topology,
+	})
+}
+
+// Submit creates job of operation on cluster and queues it for executing
+func (s *Server) Submit(clusterName string, request JobRequest, source string) (*Job, error) {
+	cluster, err := s.findCluster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	args, err := BuildArgs(cluster.Name, request)
+	if err != nil {
+		return nil, err
+	}
+
+	job := newJob(cluster.Name, request.Operation, args)
+	job.Confirm = request.Confirm
+	job.File = request.File
+	job.Source = source
+	if host, _, err := net.SplitHostPort(source); err == nil {
+		job.Source = host
+	}
+	if err := s.jobs.Submit(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *Server) submitJob(w http.ResponseWriter, r *http.Request, params []string) {
+	request := JobRequest{}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MAX_REQUEST_BODY))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, errno.ERR_INVALID_API_REQUEST.E(err))
+		return
+	}
+
+	job, err := s.Submit(params[0], request, r.RemoteAddr)
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("%s/jobs/%s", API_PREFIX, job.Id))
+	writeJSON(w, http.StatusAccepted, job.Snapshot())
+}
+
+func (s *Server) listJobs(w http.ResponseWriter, r *http.Request, params []string) {
+	response := []Job{}
+	for _, job := range s.jobs.List() {
+		response = append(response, job.Snapshot())
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request, params []string) {
+	job, ok := s.jobs.Get(params[0])
+	if !ok {
+		writeError(w, http.StatusNotFound, errno.ERR_API_JOB_NOT_FOUND.F("id: %s", params[0]))
+		return
+	}
+	writeJSON(w, http.StatusOK, job.Snapshot())
+}
+
+/*
+ * event: output       event: done
+ * id: 1               data: {"id": "...", "status": "success", ...}
+ * data: LINE
+ *
+ * the client can resume from the last received line by header Last-Event-ID
+ */
+func (s *Server) streamJob(w http.ResponseWriter, r *http.Request, params []string) {
+	job, ok := s.jobs.Get(params[0])
+	if !ok {
+		writeError(w, http.StatusNotFound, errno.ERR_API_JOB_NOT_FOUND.F("id: %s", params[0]))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError,
+			errno.ERR_INVALID_API_REQUEST.S("streaming unsupported"))
+		return
+	}
+
+	offset := 0
+	if id, err := strconv.Atoi(r.Header.Get(HEADER_LAST_EVENT_ID)); err == nil && id > 0 {
+		offset = id
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(SSE_KEEP_ALIVE)
+	defer ticker.Stop()
+	for {
+		lines, finished, updated := job.Follow(offset)
+		for _, line := range lines {
+			offset++
+			fmt.Fprintf(w, "event: %s\nid: %d\ndata: %s\n\n", EVENT_OUTPUT, offset, line)
+		}
+		if finished {
+			data, _ := json.Marshal(job.Snapshot())
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", EVENT_DONE, data)
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-updated:
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
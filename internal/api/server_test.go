@@ -0,0 +1,161 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	args, err := BuildArgs("c1", JobRequest{Operation: "deploy", Args: []string{"--skip", "snapshotclone"}})
+	assert.Nil(err)
+	assert.Equal([]string{"deploy", "--skip", "snapshotclone", "--cluster", "c1"}, args)
+
+	args, err = BuildArgs("c1", JobRequest{Operation: "format-status", File: "host: []"})
+	assert.Nil(err)
+	assert.Equal([]string{"format", "--status", "-f", FILE_PLACEHOLDER, "--cluster", "c1"}, args)
+
+	for _, request := range []JobRequest{
+		{Operation: "serve"},
+		{Operation: "format"},
+		{Operation: "status", Args: []string{"--cluster", "c2"}},
+		{Operation: "status", Args: []string{"--cluster=c2"}},
+	} {
+		_, err := BuildArgs("c1", request)
+		assert.NotNil(err, request)
+	}
+}
+
+func TestJobOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	job := newJob("c1", "status", nil)
+	job.Write([]byte("line1\nli"))
+	job.Write([]byte("ne2\r\nprogress\r"))
+	job.Write([]byte("last"))
+	lines, finished, _ := job.Follow(0)
+	assert.Equal([]string{"line1", "line2", "progress"}, lines)
+	assert.False(finished)
+
+	job.finish(errno.ERR_CLUSTER_NOT_FOUND)
+	lines, finished, _ = job.Follow(2)
+	assert.Equal([]string{"progress", "last"}, lines)
+	assert.True(finished)
+	assert.Equal(JOB_STATUS_FAILED, job.Status)
+	assert.Equal(errno.ERR_CLUSTER_NOT_FOUND.GetCode(), job.Code)
+}
+
+func newTestServer(executor Executor) (*Server, *httptest.Server) {
+	s := NewServer(nil, Options{Token: "secret", TempDir: "/tmp"}, executor)
+	return s, httptest.NewServer(s)
+}
+
+func request(method, url, token, body string) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if len(token) > 0 {
+		req.Header.Set(HEADER_AUTHORIZATION, PREFIX_BEARER+token)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+func TestServerAuth(t *testing.T) {
+	assert := assert.New(t)
+
+	s, ts := newTestServer(nil)
+	defer ts.Close()
+	defer close(s.stop)
+
+	for _, token := range []string{"", "wrong"} {
+		resp, err := request(http.MethodGet, ts.URL+"/api/v1/jobs", token, "")
+		assert.Nil(err)
+		assert.Equal(http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	resp, err := request(http.MethodGet, ts.URL+"/api/v1/jobs", "secret", "")
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	resp, err = http.Get(ts.URL + "/api/v1/jobs?token=secret")
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = request(http.MethodDelete, ts.URL+"/api/v1/jobs", "secret", "")
+	assert.Nil(err)
+	assert.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+	resp, err = request(http.MethodGet, ts.URL+"/api/v1/disks", "secret", "")
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServerStreamJob(t *testing.T) {
+	assert := assert.New(t)
+
+	executed := [][]string{}
+	s, ts := newTestServer(func(job *Job, args []string, out io.Writer) error {
+		executed = append(executed, args)
+		fmt.Fprintf(out, "cluster %s\nconfirm %s\n", job.Cluster, job.Confirm)
+		return nil
+	})
+	defer ts.Close()
+	defer close(s.stop)
+
+	job := newJob("c1", "status", []string{"status", "--cluster", "c1"})
+	job.Confirm = "yes"
+	assert.Nil(s.jobs.Submit(job))
+
+	resp, err := request(http.MethodGet, ts.URL+"/api/v1/jobs/"+job.Id+"/events", "secret", "")
+	assert.Nil(err)
+	assert.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+	events := []string{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "data: ") {
+			events = append(events, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	assert.Equal(3, len(events))
+	assert.Equal("cluster c1", events[0])
+	assert.Equal("confirm yes", events[1])
+	done := Job{}
+	assert.Nil(json.Unmarshal([]byte(events[2]), &done))
+	assert.Equal(JOB_STATUS_SUCCESS, done.Status)
+	assert.Equal([][]string{{"status", "--cluster", "c1"}}, executed)
+
+	resp, err = request(http.MethodGet, ts.URL+"/api/v1/jobs/not-exist", "secret", "")
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,185 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	S3_REQUEST_TIMEOUT = 30 * time.Minute
+)
+
+/*
+ * S3Client accesses S3 compatible storage (e.g. MinIO, Ceph RGW) in path
+ * style with AWS signature version 2, same as `curveadm check s3`:
+ *   Signature = Base64(HMAC-SHA1(SecretKey, "<VERB>\n\n\n<Date>\n<Resource>"))
+ * the request must not carry Content-MD5 and Content-Type headers.
+ */
+type (
+	S3Config struct {
+		Endpoint  string
+		AccessKey string
+		SecretKey string
+	}
+
+	S3Client struct {
+		config S3Config
+		client *http.Client
+	}
+
+	listBucketResult struct {
+		Contents []struct {
+			Key string `xml:"Key"`
+		} `xml:"Contents"`
+		IsTruncated bool   `xml:"IsTruncated"`
+		NextMarker  string `xml:"NextMarker"`
+	}
+)
+
+func NewS3Client(config S3Config) *S3Client {
+	if !strings.HasPrefix(config.Endpoint, "http://") && !strings.HasPrefix(config.Endpoint, "https://") {
+		config.Endpoint = "http://" + config.Endpoint
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	return &S3Client{
+		config: config,
+		client: &http.Client{Timeout: S3_REQUEST_TIMEOUT},
+	}
+}
+
+func signS3Request(secretKey, verb, resource, date string) string {
+	stringToSign := fmt.Sprintf("%s\n\n\n%s\n%s", verb, date, resource)
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// the query is not part of signed resource for listing objects
+func (c *S3Client) do(verb, resource, query string, body io.Reader, size int64) (*http.Response, error) {
+	u := c.config.Endpoint + (&url.URL{Path: resource}).EscapedPath()
+	if len(query) > 0 {
+		u += "?" + query
+	}
+	request, err := http.NewRequest(verb, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	date := time.Now().UTC().Format(http.TimeFormat)
+	request.ContentLength = size
+	request.Header.Set("Date", date)
+	request.Header.Set("Authorization", fmt.Sprintf("AWS %s:%s",
+		c.config.AccessKey, signS3Request(c.config.SecretKey, verb, resource, date)))
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode/100 != 2 {
+		defer response.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, fmt.Errorf("%s %s: %s %s", verb, resource, response.Status, strings.TrimSpace(string(message)))
+	}
+	return response, nil
+}
+
+func (c *S3Client) PutObject(bucket, key, localPath string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	response, err := c.do(http.MethodPut, fmt.Sprintf("/%s/%s", bucket, key), "", file, info.Size())
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
+
+func (c *S3Client) GetObject(bucket, key, localPath string) error {
+	response, err := c.do(http.MethodGet, fmt.Sprintf("/%s/%s", bucket, key), "", nil, 0)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	file, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(file, response.Body)
+	return err
+}
+
+func (c *S3Client) DeleteObject(bucket, key string) error {
+	response, err := c.do(http.MethodDelete, fmt.Sprintf("/%s/%s", bucket, key), "", nil, 0)
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
+
+// ListObjects returns keys which start with prefix, the pages are followed by marker
+func (c *S3Client) ListObjects(bucket, prefix string) ([]string, error) {
+	keys := []string{}
+	marker := ""
+	for {
+		query := url.Values{"prefix": {prefix}, "marker": {marker}}.Encode()
+		response, err := c.do(http.MethodGet, fmt.Sprintf("/%s/", bucket), query, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		result := listBucketResult{}
+		err = xml.NewDecoder(response.Body).Decode(&result)
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, content := range result.Contents {
+			keys = append(keys, content.Key)
+		}
+		if !result.IsTruncated || len(result.Contents) == 0 {
+			return keys, nil
+		}
+		marker = result.NextMarker
+		if len(marker) == 0 {
+			marker = result.Contents[len(result.Contents)-1].Key
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 is an in-memory S3 which verifies signature and pages the listing
+func fakeS3(secretKey string) *httptest.Server {
+	var mutex sync.Mutex
+	objects := map[string]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		resource := r.URL.Path
+		expect := fmt.Sprintf("AWS ak:%s", signS3Request(secretKey, r.Method, resource, r.Header.Get("Date")))
+		if r.Header.Get("Authorization") != expect || len(r.Header.Get("Content-Type")) > 0 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[resource] = string(data)
+		case http.MethodDelete:
+			delete(objects, resource)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			if !strings.HasSuffix(resource, "/") {
+				data, ok := objects[resource]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+				}
+				io.WriteString(w, data)
+				return
+			}
+			keys := []string{}
+			for object := range objects {
+				key := strings.TrimPrefix(object, resource)
+				if strings.HasPrefix(key, r.URL.Query().Get("prefix")) && key > r.URL.Query().Get("marker") {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			truncated := len(keys) > 1 // one key per page
+			if truncated {
+				keys = keys[:1]
+			}
+			io.WriteString(w, "<ListBucketResult>")
+			for _, key := range keys {
+				fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", key)
+			}
+			fmt.Fprintf(w, "<IsTruncated>%t</IsTruncated></ListBucketResult>", truncated)
+		}
+	}))
+}
+
+func TestS3Store(t *testing.T) {
+	assert := assert.New(t)
+	server := fakeS3("sk")
+	defer server.Close()
+	dir := t.TempDir()
+	src := filepath.Join(dir, "snapshot.db")
+	assert.Nil(os.WriteFile(src, []byte("snapshot"), 0644))
+
+	// wrong secret key
+	store, err := NewStore("s3://bucket/curve", S3Config{Endpoint: server.URL, AccessKey: "ak", SecretKey: "xx"})
+	assert.Nil(err)
+	assert.NotNil(store.Put(src, "etcd-c1-20231001000000.db"))
+
+	store, err = NewStore("s3://bucket/curve/", S3Config{Endpoint: server.URL, AccessKey: "ak", SecretKey: "sk"})
+	assert.Nil(err)
+	for _, name := range []string{"etcd-c1-20231001000000.db", "etcd-c1-20231002000000.db", "etcd-c1-20231003000000.db"} {
+		assert.Nil(store.Put(src, name))
+	}
+	other, _ := NewStore("s3://bucket/curve/other", S3Config{Endpoint: server.URL, AccessKey: "ak", SecretKey: "sk"})
+	assert.Nil(other.Put(src, "etcd-c1-20230901000000.db"))
+
+	names, err := store.List()
+	assert.Nil(err)
+	assert.Equal([]string{"etcd-c1-20231001000000.db", "etcd-c1-20231002000000.db", "etcd-c1-20231003000000.db"}, names)
+
+	removed, err := Prune(store, BACKUP_KIND_ETCD, "c1", 2)
+	assert.Nil(err)
+	assert.Equal([]string{"etcd-c1-20231001000000.db"}, removed)
+
+	dest := filepath.Join(dir, "restore.db")
+	err = FetchS3Object("s3://bucket/curve/etcd-c1-20231003000000.db",
+		S3Config{Endpoint: server.URL, AccessKey: "ak", SecretKey: "sk"}, dest)
+	assert.Nil(err)
+	data, _ := os.ReadFile(dest)
+	assert.Equal("snapshot", string(data))
+	assert.NotNil(store.Get("etcd-c1-20231001000000.db", dest))
+}
@@ -0,0 +1,225 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ * Schedule is a cron expression which specifies when the backup runs,
+ * it consists of 5 fields: minute, hour, day of month, month and day of
+ * week (0 and 7 are Sunday), e.g:
+ *
+ *   0 2 * * *          # at 02:00 every day
+ *   30 0-23/6 * * *    # at minute 30 past every 6th hour
+ *   0 3 * * 1-5        # at 03:00 on Monday through Friday
+ *
+ * Each field is "*", "a", "a-b", one of them with step (e.g. "0-23/6")
+ * or a list of them (e.g. "1,15"). The descriptors @hourly, @daily,
+ * @weekly, @monthly and @yearly are supported too. As standard cron, the
+ * day matches if either day of month or day of week matches when both
+ * of them are restricted.
+ */
+type (
+	Schedule struct {
+		expr   string
+		minute uint64
+		hour   uint64
+		dom    uint64
+		month  uint64
+		dow    uint64
+		anyDom bool
+		anyDow bool
+	}
+
+	field struct {
+		name     string
+		min, max int
+	}
+)
+
+const (
+	// the next time is searched within this number of steps, which
+	// covers years even though the expression is sparse (e.g. @yearly)
+	SCHEDULE_MAX_STEPS = 100000
+)
+
+var (
+	descriptors = map[string]string{
+		"@hourly":   "0 * * * *",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@weekly":   "0 0 * * 0",
+		"@monthly":  "0 0 1 * *",
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+	}
+
+	fields = []field{
+		{"minute", 0, 59},
+		{"hour", 0, 23},
+		{"day of month", 1, 31},
+		{"month", 1, 12},
+		{"day of week", 0, 7},
+	}
+)
+
+func parseNumber(f field, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s: '%s' should be in range [%d, %d]", f.name, value, f.min, f.max)
+	}
+	return n, nil
+}
+
+// parseField returns bits of matched values, e.g: 1-5/2 => 0b101010
+func parseField(f field, value string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		rng, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s: invalid step in '%s'", f.name, item)
+			}
+			rng, step = item[:i], n
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			items := strings.SplitN(rng, "-", 2)
+			var err error
+			if start, err = parseNumber(f, items[0]); err != nil {
+				return 0, err
+			} else if end, err = parseNumber(f, items[1]); err != nil {
+				return 0, err
+			} else if start > end {
+				return 0, fmt.Errorf("%s: invalid range '%s'", f.name, rng)
+			}
+		default:
+			n, err := parseNumber(f, rng)
+			if err != nil {
+				return 0, err
+			}
+			start = n
+			if step == 1 { // "a" means a single value, "a/n" means a-max/n
+				end = n
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	value := expr
+	if strings.HasPrefix(expr, "@") {
+		v, ok := descriptors[expr]
+		if !ok {
+			return nil, fmt.Errorf("unknown descriptor '%s'", expr)
+		}
+		value = v
+	}
+
+	items := strings.Fields(value)
+	if len(items) != len(fields) {
+		return nil, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(items))
+	}
+	bits := make([]uint64, len(fields))
+	for i, f := range fields {
+		var err error
+		if bits[i], err = parseField(f, items[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	dow := bits[4]
+	if dow&(1<<7) != 0 { // 7 is Sunday too
+		dow |= 1
+	}
+	return &Schedule{
+		expr:   expr,
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    dow,
+		anyDom: items[2] == "*",
+		anyDow: items[4] == "*",
+	}, nil
+}
+
+func (s *Schedule) String() string { return s.expr }
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDom && s.anyDow:
+		return true
+	case s.anyDom:
+		return dow
+	case s.anyDow:
+		return dom
+	}
+	return dom || dow
+}
+
+// Next returns the first matched time after t, zero time if nothing
+// matched (e.g. 0 0 30 2 *)
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for i := 0; i < SCHEDULE_MAX_STEPS; i++ {
+		y, m, d := t.Date()
+		switch {
+		case s.month&(1<<uint(m)) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Due returns true if the schedule should run at now since its last run
+func (s *Schedule) Due(last, now time.Time) bool {
+	next := s.Next(last)
+	return !next.IsZero() && !next.After(now)
+}
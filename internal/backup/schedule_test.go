@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	assert := assert.New(t)
+	for _, expr := range []string{"0 2 * * *", "*/15 * * * *", "0 0-23/6 1,15 * 1-5", "0 0 * * 7", "@daily", " @weekly "} {
+		_, err := ParseSchedule(expr)
+		assert.Nil(err, expr)
+	}
+	for _, expr := range []string{"", "0 2 * *", "60 * * * *", "0 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@every"} {
+		_, err := ParseSchedule(expr)
+		assert.NotNil(err, expr)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	assert := assert.New(t)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2023, month, day, hour, minute, 0, 0, time.Local)
+	}
+	next := func(expr string, t time.Time) time.Time {
+		s, err := ParseSchedule(expr)
+		assert.Nil(err)
+		return s.Next(t)
+	}
+
+	// 2023-10-16 is Monday
+	now := at(10, 16, 10, 30)
+	assert.Equal(at(10, 17, 2, 0), next("0 2 * * *", now))
+	assert.Equal(at(10, 16, 10, 45), next("*/15 * * * *", now))
+	assert.Equal(at(10, 16, 12, 30), next("30 0-23/6 * * *", now))
+	assert.Equal(at(10, 22, 0, 0), next("@weekly", now))
+	assert.Equal(at(11, 1, 0, 0), next("@monthly", now))
+	assert.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), next("@yearly", now))
+	assert.Equal(at(10, 16, 10, 31), next("* * * * *", now.Add(10*time.Second)))
+
+	// either day of month or day of week matches
+	assert.Equal(at(10, 20, 0, 0), next("0 0 1 * 5", now))
+	assert.Equal(at(11, 1, 0, 0), next("0 0 1 * *", now))
+	assert.Equal(at(10, 22, 0, 0), next("0 0 * * 7", now))
+
+	// never matched
+	assert.True(next("0 0 30 2 *", now).IsZero())
+}
+
+func TestScheduleDue(t *testing.T) {
+	assert := assert.New(t)
+	s, err := ParseSchedule("0 2 * * *")
+	assert.Nil(err)
+
+	last := time.Date(2023, 10, 16, 2, 0, 0, 0, time.Local)
+	assert.False(s.Due(last, last.Add(23*time.Hour)))
+	assert.True(s.Due(last, last.Add(24*time.Hour)))
+	assert.True(s.Due(last, last.Add(72*time.Hour))) // missed runs are merged
+}
@@ -0,0 +1,333 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	SCHEME_S3 = "s3://"
+
+	BACKUP_KIND_ETCD = "etcd" // etcd snapshot
+	BACKUP_KIND_MDS  = "mds"  // mds metadata dump, e.g: topology, pools
+	BACKUP_KIND_DB   = "db"   // database of curveadm
+
+	// e.g: etcd-my-cluster-20231016150405.db
+	BACKUP_TIME_LAYOUT = "20060102150405"
+
+	SUFFIX_PARTIAL_FILE = ".part"
+
+	// status of scheduled backup
+	BACKUP_STATUS_SUCCESS = "SUCCESS"
+	BACKUP_STATUS_FAIL    = "FAIL"
+)
+
+var (
+	BACKUP_KINDS = []string{BACKUP_KIND_ETCD, BACKUP_KIND_MDS, BACKUP_KIND_DB}
+
+	backupSuffixes = map[string]string{
+		BACKUP_KIND_ETCD: ".db",
+		BACKUP_KIND_MDS:  ".txt",
+		BACKUP_KIND_DB:   ".db",
+	}
+)
+
+/*
+ * Store is where the backups kept, which is a local directory or
+ * a S3 bucket with optional prefix, e.g:
+ *   /data/backup
+ *   s3://my-bucket/curve/backup
+ */
+type (
+	Store interface {
+		Put(localPath, name string) error
+		Get(name, localPath string) error
+		List() ([]string, error)
+		Remove(name string) error
+		Location(name string) string
+	}
+
+	localStore struct {
+		dir string
+	}
+
+	s3Store struct {
+		client *S3Client
+		bucket string
+		prefix string // without trailing slash
+	}
+
+	Backup struct {
+		Kind       string
+		Name       string
+		Location   string
+		CreateTime time.Time
+	}
+)
+
+func IsValidKind(kind string) bool {
+	_, ok := backupSuffixes[kind]
+	return ok
+}
+
+func IsS3Location(location string) bool {
+	return strings.HasPrefix(location, SCHEME_S3)
+}
+
+// ParseS3Location splits s3://bucket/key into bucket and key
+func ParseS3Location(location string) (string, string, error) {
+	if !IsS3Location(location) {
+		return "", "", fmt.Errorf("'%s' is not a S3 location", location)
+	}
+	items := strings.SplitN(strings.TrimPrefix(location, SCHEME_S3), "/", 2)
+	if len(items[0]) == 0 {
+		return "", "", fmt.Errorf("bucket is missing in '%s'", location)
+	} else if len(items) == 1 {
+		return items[0], "", nil
+	}
+	return items[0], strings.Trim(items[1], "/"), nil
+}
+
+func NewStore(location string, config S3Config) (Store, error) {
+	if !IsS3Location(location) {
+		if len(location) == 0 {
+			return nil, fmt.Errorf("backup location is empty")
+		}
+		return &localStore{dir: location}, nil
+	}
+
+	bucket, prefix, err := ParseS3Location(location)
+	if err != nil {
+		return nil, err
+	} else if len(config.Endpoint) == 0 || len(config.AccessKey) == 0 || len(config.SecretKey) == 0 {
+		return nil, fmt.Errorf("S3 endpoint, access key and secret key are required for '%s'", location)
+	}
+	return &s3Store{client: NewS3Client(config), bucket: bucket, prefix: prefix}, nil
+}
+
+// FetchS3Object downloads the object specified by s3://bucket/key into local path
+func FetchS3Object(location string, config S3Config, localPath string) error {
+	bucket, key, err := ParseS3Location(location)
+	if err != nil {
+		return err
+	} else if len(key) == 0 {
+		return fmt.Errorf("object key is missing in '%s'", location)
+	}
+	return NewS3Client(config).GetObject(bucket, key, localPath)
+}
+
+// BackupName returns the name of backup which sorted by its create time
+func BackupName(kind, cluster string, t time.Time) string {
+	return fmt.Sprintf("%s-%s-%s%s", kind, cluster, t.Format(BACKUP_TIME_LAYOUT), backupSuffixes[kind])
+}
+
+// BackupTime returns the create time of backup, false if it's not a backup of kind and cluster
+func BackupTime(kind, cluster, name string) (time.Time, bool) {
+	prefix, suffix := fmt.Sprintf("%s-%s-", kind, cluster), backupSuffixes[kind]
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return time.Time{}, false
+	}
+	value := strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix)
+	t, err := time.ParseInLocation(BACKUP_TIME_LAYOUT, value, time.Local)
+	return t, err == nil
+}
+
+// ExpiredBackups returns the backups of kind and cluster except the latest keep ones,
+// the others (e.g. backups of other cluster) in the same store are untouched
+func ExpiredBackups(names []string, kind, cluster string, keep int) []string {
+	backups := []string{}
+	for _, name := range names {
+		if _, ok := BackupTime(kind, cluster, name); ok {
+			backups = append(backups, name)
+		}
+	}
+	if keep <= 0 || len(backups) <= keep {
+		return []string{}
+	}
+	sort.Strings(backups)
+	return backups[:len(backups)-keep]
+}
+
+// ListBackups returns backups of all kinds of cluster in store, sorted by create time
+func ListBackups(store Store, cluster string) ([]Backup, error) {
+	names, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []Backup{}
+	for _, name := range names {
+		for _, kind := range BACKUP_KINDS {
+			if t, ok := BackupTime(kind, cluster, name); ok {
+				backups = append(backups, Backup{
+					Kind:       kind,
+					Name:       name,
+					Location:   store.Location(name),
+					CreateTime: t,
+				})
+				break
+			}
+		}
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].CreateTime.Before(backups[j].CreateTime)
+	})
+	return backups, nil
+}
+
+// Prune removes expired backups from store, it returns the removed ones
+func Prune(store Store, kind, cluster string, keep int) ([]string, error) {
+	names, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	for _, name := range ExpiredBackups(names, kind, cluster, keep) {
+		if err := store.Remove(name); err != nil {
+			return removed, err
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
+
+/*
+ * local store
+ */
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	} else if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// the backup is copied into partial file first, so an incomplete backup never be listed
+func (s *localStore) Put(localPath, name string) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	partialPath := s.Location(name) + SUFFIX_PARTIAL_FILE
+	if err := copyFile(localPath, partialPath); err != nil {
+		os.Remove(partialPath)
+		return err
+	}
+	return os.Rename(partialPath, s.Location(name))
+}
+
+func (s *localStore) Get(name, localPath string) error {
+	return copyFile(s.Location(name), localPath)
+}
+
+func (s *localStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasSuffix(entry.Name(), SUFFIX_PARTIAL_FILE) {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (s *localStore) Remove(name string) error {
+	return os.Remove(s.Location(name))
+}
+
+func (s *localStore) Location(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+/*
+ * S3 store
+ */
+func (s *s3Store) key(name string) string {
+	if len(s.prefix) == 0 {
+		return name
+	}
+	return path.Join(s.prefix, name)
+}
+
+func (s *s3Store) Put(localPath, name string) error {
+	return s.client.PutObject(s.bucket, s.key(name), localPath)
+}
+
+func (s *s3Store) Get(name, localPath string) error {
+	return s.client.GetObject(s.bucket, s.key(name), localPath)
+}
+
+// only the objects directly under prefix are listed
+func (s *s3Store) List() ([]string, error) {
+	prefix := s.key("")
+	if len(prefix) > 0 {
+		prefix += "/"
+	}
+	keys, err := s.client.ListObjects(s.bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, prefix)
+		if len(name) > 0 && !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (s *s3Store) Remove(name string) error {
+	return s.client.DeleteObject(s.bucket, s.key(name))
+}
+
+func (s *s3Store) Location(name string) string {
+	return fmt.Sprintf("%s%s/%s", SCHEME_S3, s.bucket, s.key(name))
+}
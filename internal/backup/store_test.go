@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseS3Location(t *testing.T) {
+	assert := assert.New(t)
+	for _, tc := range []struct {
+		location string
+		bucket   string
+		key      string
+		ok       bool
+	}{
+		{"s3://bucket", "bucket", "", true},
+		{"s3://bucket/", "bucket", "", true},
+		{"s3://bucket/curve/backup/", "bucket", "curve/backup", true},
+		{"s3:///backup", "", "", false},
+		{"/data/backup", "", "", false},
+	} {
+		bucket, key, err := ParseS3Location(tc.location)
+		assert.Equal(tc.ok, err == nil, tc.location)
+		assert.Equal(tc.bucket, bucket)
+		assert.Equal(tc.key, key)
+	}
+
+	_, err := NewStore("s3://bucket", S3Config{Endpoint: "127.0.0.1:9000"})
+	assert.NotNil(err)
+	store, err := NewStore("s3://bucket/curve", S3Config{Endpoint: "127.0.0.1:9000", AccessKey: "ak", SecretKey: "sk"})
+	assert.Nil(err)
+	assert.Equal("s3://bucket/curve/a.db", store.Location("a.db"))
+}
+
+func TestExpiredBackups(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2023, 10, 16, 15, 4, 5, 0, time.Local)
+	name := BackupName(BACKUP_KIND_ETCD, "c1", now)
+	assert.Equal("etcd-c1-20231016150405.db", name)
+	created, ok := BackupTime(BACKUP_KIND_ETCD, "c1", name)
+	assert.True(ok)
+	assert.True(created.Equal(now))
+
+	names := []string{
+		"etcd-c1-20231016150405.db",
+		"etcd-c1-20231014150405.db",
+		"etcd-c1-20231015150405.db",
+		"etcd-c2-20231013150405.db", // other cluster
+		"etcd-c1-latest.db",         // not a backup
+		"README",
+	}
+	assert.Equal([]string{"etcd-c1-20231014150405.db"}, ExpiredBackups(names, BACKUP_KIND_ETCD, "c1", 2))
+	assert.Equal([]string{}, ExpiredBackups(names, BACKUP_KIND_ETCD, "c1", 3))
+	assert.Equal([]string{}, ExpiredBackups(names, BACKUP_KIND_ETCD, "c1", 0))
+
+	// retention is per kind
+	names = append(names,
+		BackupName(BACKUP_KIND_MDS, "c1", now),
+		BackupName(BACKUP_KIND_DB, "c1", now))
+	assert.Equal("mds-c1-20231016150405.txt", names[len(names)-2])
+	assert.Equal([]string{"etcd-c1-20231014150405.db"}, ExpiredBackups(names, BACKUP_KIND_ETCD, "c1", 2))
+	assert.Equal([]string{}, ExpiredBackups(names, BACKUP_KIND_MDS, "c1", 1))
+	assert.True(IsValidKind(BACKUP_KIND_DB))
+	assert.False(IsValidKind("metaserver"))
+}
+
+func TestLocalStore(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	src := filepath.Join(dir, "snapshot.db")
+	assert.Nil(os.WriteFile(src, []byte("snapshot"), 0644))
+
+	store, err := NewStore(filepath.Join(dir, "backup"), S3Config{})
+	assert.Nil(err)
+	for day := 1; day <= 3; day++ {
+		name := BackupName(BACKUP_KIND_ETCD, "c1", time.Date(2023, 10, day, 0, 0, 0, 0, time.Local))
+		assert.Nil(store.Put(src, name))
+	}
+	names, err := store.List()
+	assert.Nil(err)
+	assert.Len(names, 3)
+
+	removed, err := Prune(store, BACKUP_KIND_ETCD, "c1", 1)
+	assert.Nil(err)
+	assert.Equal([]string{"etcd-c1-20231001000000.db", "etcd-c1-20231002000000.db"}, removed)
+	names, err = store.List()
+	assert.Nil(err)
+	assert.Equal([]string{"etcd-c1-20231003000000.db"}, names)
+
+	dest := filepath.Join(dir, "restore.db")
+	assert.Nil(store.Get(names[0], dest))
+	data, err := os.ReadFile(dest)
+	assert.Nil(err)
+	assert.Equal("snapshot", string(data))
+
+	// backups of all kinds
+	assert.Nil(store.Put(src, BackupName(BACKUP_KIND_MDS, "c1", time.Date(2023, 10, 2, 0, 0, 0, 0, time.Local))))
+	assert.Nil(store.Put(src, BackupName(BACKUP_KIND_DB, "c2", time.Date(2023, 10, 2, 0, 0, 0, 0, time.Local))))
+	backups, err := ListBackups(store, "c1")
+	assert.Nil(err)
+	assert.Len(backups, 2)
+	assert.Equal(BACKUP_KIND_MDS, backups[0].Kind)
+	assert.Equal(BACKUP_KIND_ETCD, backups[1].Kind)
+	assert.Equal(filepath.Join(dir, "backup", "etcd-c1-20231003000000.db"), backups[1].Location)
+}
@@ -0,0 +1,198 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+/*
+ * the bot only accepts whitelisted read-only commands from chat:
+ *
+ *   status [CLUSTER]          => curveadm status --cluster CLUSTER
+ *   disks status [CLUSTER]    => curveadm format --status -f FORMAT --cluster CLUSTER
+ *   balance status [CLUSTER]  => curveadm balance-status --cluster CLUSTER
+ *   clusters                  => curveadm cluster ls
+ *   hosts                     => curveadm hosts ls
+ *   help
+ */
+const (
+	COMMAND_HELP = "help"
+
+	// chat platforms limit the length of message
+	MAX_REPLY_LENGTH = 30000
+
+	REGEX_ANSI_COLOR = "\x1b\\[[0-9;]*m"
+	REGEX_CLUSTER    = "^[a-zA-Z0-9][a-zA-Z0-9_.-]*$"
+)
+
+type (
+	// Executor runs curveadm command with args, all output written into out
+	Executor func(source string, args []string, out io.Writer) error
+
+	Options struct {
+		Listen   string
+		Token    string // token of generic webhook
+		CertFile string
+		KeyFile  string
+
+		SlackToken         string // verification token of slash command
+		SlackSigningSecret string // signing secret of slack app, preferred over verification token
+
+		FormatFile string // format configure which disks status queried by
+	}
+
+	command struct {
+		usage          string
+		acceptsCluster bool
+		args           func(options Options) ([]string, error)
+	}
+
+	// Runner parses the text from chat and executes it one by one
+	Runner struct {
+		options  Options
+		executor Executor
+		mutex    sync.Mutex
+	}
+)
+
+var (
+	COMMANDS = map[string]command{
+		"status": {
+			usage:          "status [CLUSTER]",
+			acceptsCluster: true,
+			args:           staticArgs("status"),
+		},
+		"disks status": {
+			usage:          "disks status [CLUSTER]",
+			acceptsCluster: true,
+			args: func(options Options) ([]string, error) {
+				if len(options.FormatFile) == 0 {
+					return nil, errno.ERR_UNSUPPORT_BOT_COMMAND.
+						S("disks status requires bot started with --format")
+				}
+				return []string{"format", "--status", "-f", options.FormatFile}, nil
+			},
+		},
+		"balance status": {
+			usage:          "balance status [CLUSTER]",
+			acceptsCluster: true,
+			args:           staticArgs("balance-status"),
+		},
+		"clusters": {usage: "clusters", args: staticArgs("cluster", "ls")},
+		"hosts":    {usage: "hosts", args: staticArgs("hosts", "ls")},
+	}
+
+	ansiColorRegex = regexp.MustCompile(REGEX_ANSI_COLOR)
+	clusterRegex   = regexp.MustCompile(REGEX_CLUSTER)
+)
+
+func staticArgs(args ...string) func(Options) ([]string, error) {
+	return func(Options) ([]string, error) {
+		return append([]string{}, args...), nil
+	}
+}
+
+func Usage() string {
+	usages := []string{}
+	for _, cmd := range COMMANDS {
+		usages = append(usages, "  "+cmd.usage)
+	}
+	sort.Strings(usages)
+	return fmt.Sprintf("Supported commands:\n%s\n  %s", strings.Join(usages, "\n"), COMMAND_HELP)
+}
+
+// ParseCommand returns arguments of curveadm command for text, nil for help
+func ParseCommand(text string, options Options) ([]string, error) {
+	items := strings.Fields(text)
+	if len(items) == 0 || (len(items) == 1 && items[0] == COMMAND_HELP) {
+		return nil, nil
+	}
+
+	// match the longest command
+	for n := 2; n >= 1; n-- {
+		if len(items) < n {
+			continue
+		}
+		cmd, ok := COMMANDS[strings.Join(items[:n], " ")]
+		if !ok {
+			continue
+		}
+
+		rest := items[n:]
+		if len(rest) > 1 || (len(rest) == 1 && !cmd.acceptsCluster) {
+			return nil, errno.ERR_UNSUPPORT_BOT_COMMAND.F("usage: %s", cmd.usage)
+		}
+		args, err := cmd.args(options)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) == 1 {
+			if !clusterRegex.MatchString(rest[0]) {
+				return nil, errno.ERR_UNSUPPORT_BOT_COMMAND.F("invalid cluster name: %s", rest[0])
+			}
+			args = append(args, "--cluster", rest[0])
+		}
+		return args, nil
+	}
+	return nil, errno.ERR_UNSUPPORT_BOT_COMMAND.F("command: %s", text)
+}
+
+func NewRunner(options Options, executor Executor) *Runner {
+	return &Runner{options: options, executor: executor}
+}
+
+// Run executes text from source and returns plain output which is formatted for chat
+func (r *Runner) Run(source, text string) (string, error) {
+	args, err := ParseCommand(text, r.options)
+	if err != nil {
+		return "", err
+	} else if args == nil {
+		return Usage(), nil
+	}
+
+	// the SSH pool and some other resources are shared, so execute one by one
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	out := &bytes.Buffer{}
+	err = r.executor(source, args, out)
+	return Format(out.String()), err
+}
+
+// Format strips color of output and truncates it if too long
+func Format(output string) string {
+	output = ansiColorRegex.ReplaceAllString(output, "")
+	output = strings.TrimRight(output, "\n")
+	if len(output) > MAX_REPLY_LENGTH {
+		output = output[:MAX_REPLY_LENGTH] + "\n...(truncated)"
+	}
+	return output
+}
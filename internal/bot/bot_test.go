@@ -0,0 +1,187 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	assert := assert.New(t)
+	options := Options{FormatFile: "format.yaml"}
+
+	args, err := ParseCommand("status", options)
+	assert.Nil(err)
+	assert.Equal([]string{"status"}, args)
+
+	args, err = ParseCommand("  status   c1 ", options)
+	assert.Nil(err)
+	assert.Equal([]string{"status", "--cluster", "c1"}, args)
+
+	args, err = ParseCommand("disks status c1", options)
+	assert.Nil(err)
+	assert.Equal([]string{"format", "--status", "-f", "format.yaml", "--cluster", "c1"}, args)
+
+	args, err = ParseCommand("balance status", options)
+	assert.Nil(err)
+	assert.Equal([]string{"balance-status"}, args)
+
+	args, err = ParseCommand("help", options)
+	assert.Nil(err)
+	assert.Nil(args)
+
+	for _, text := range []string{
+		"deploy",
+		"clean c1",
+		"status c1 --only",
+		"status --cluster",
+		"status c1 c2",
+		"clusters c1",
+	} {
+		_, err = ParseCommand(text, options)
+		assert.Error(err, text)
+	}
+
+	_, err = ParseCommand("disks status", Options{})
+	assert.Error(err)
+}
+
+func TestFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("Id  Status\n1   Up", Format("\x1b[32mId\x1b[0m  Status\n1   Up\n\n"))
+	output := Format(strings.Repeat("a", MAX_REPLY_LENGTH+1))
+	assert.True(strings.HasSuffix(output, "(truncated)"))
+}
+
+func newTestServer(options Options, executed chan []string) *Server {
+	return NewServer(options, func(source string, args []string, out io.Writer) error {
+		executed <- append([]string{source}, args...)
+		if args[0] == "hosts" {
+			return errno.ERR_GET_HOSTS_FAILED
+		}
+		fmt.Fprintf(out, "output of %s\n", strings.Join(args, " "))
+		return nil
+	})
+}
+
+func TestWebhook(t *testing.T) {
+	assert := assert.New(t)
+	executed := make(chan []string, 1)
+	server := httptest.NewServer(newTestServer(Options{Token: "T1"}, executed).server.Handler)
+	defer server.Close()
+
+	post := func(token, body string) (int, WebhookResponse) {
+		request, _ := http.NewRequest(http.MethodPost, server.URL+PATH_WEBHOOK, strings.NewReader(body))
+		request.Header.Set(HEADER_AUTHORIZATION, PREFIX_BEARER+token)
+		resp, err := http.DefaultClient.Do(request)
+		assert.Nil(err)
+		defer resp.Body.Close()
+		response := WebhookResponse{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		return resp.StatusCode, response
+	}
+
+	status, _ := post("T2", `{"text": "status"}`)
+	assert.Equal(http.StatusUnauthorized, status)
+
+	status, response := post("T1", `{"text": "status c1", "user": "alice"}`)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal("```\noutput of status --cluster c1\n```", response.Text)
+	assert.Equal([]string{"webhook:alice", "status", "--cluster", "c1"}, <-executed)
+
+	_, response = post("T1", `{"text": "hosts"}`)
+	<-executed
+	assert.Equal(errno.ERR_GET_HOSTS_FAILED.GetCode(), response.Code)
+
+	_, response = post("T1", `{"text": "deploy"}`)
+	assert.Equal(errno.ERR_UNSUPPORT_BOT_COMMAND.GetCode(), response.Code)
+	assert.Len(executed, 0)
+}
+
+func TestSlack(t *testing.T) {
+	assert := assert.New(t)
+
+	replies := make(chan SlackResponse, 1)
+	slack := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := SlackResponse{}
+		json.NewDecoder(r.Body).Decode(&response)
+		replies <- response
+	}))
+	defer slack.Close()
+
+	executed := make(chan []string, 1)
+	s := newTestServer(Options{SlackSigningSecret: "S1"}, executed)
+	s.client = slack.Client()
+	server := httptest.NewServer(s.server.Handler)
+	defer server.Close()
+
+	post := func(secret string, timestamp time.Time, text string) (int, SlackResponse) {
+		body := url.Values{
+			"text":         {text},
+			"user_name":    {"alice"},
+			"response_url": {slack.URL + "/reply"},
+		}.Encode()
+		ts := strconv.FormatInt(timestamp.Unix(), 10)
+		request, _ := http.NewRequest(http.MethodPost, server.URL+PATH_SLACK, strings.NewReader(body))
+		request.Header.Set(HEADER_SLACK_TIMESTAMP, ts)
+		request.Header.Set(HEADER_SLACK_SIGNATURE, SlackSignature(secret, ts, []byte(body)))
+		resp, err := http.DefaultClient.Do(request)
+		assert.Nil(err)
+		defer resp.Body.Close()
+		response := SlackResponse{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		return resp.StatusCode, response
+	}
+
+	status, _ := post("S2", time.Now(), "status")
+	assert.Equal(http.StatusUnauthorized, status)
+	status, _ = post("S1", time.Now().Add(-time.Hour), "status")
+	assert.Equal(http.StatusUnauthorized, status)
+
+	// acknowledged at once, replied by response_url
+	status, response := post("S1", time.Now(), "status c1")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal(SLACK_RESPONSE_EPHEMERAL, response.ResponseType)
+	assert.Equal([]string{"slack:alice", "status", "--cluster", "c1"}, <-executed)
+	reply := <-replies
+	assert.Equal(SLACK_RESPONSE_IN_CHANNEL, reply.ResponseType)
+	assert.Equal("```\noutput of status --cluster c1\n```", reply.Text)
+
+	// unsupported command rejected with usage
+	_, response = post("S1", time.Now(), "clean")
+	assert.Contains(response.Text, "Supported commands")
+	assert.Len(executed, 0)
+}
@@ -0,0 +1,274 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+/*
+ * POST /webhook  generic webhook, header 'Authorization: Bearer TOKEN'
+ *                request:  {"text": "status c1", "user": "alice"}
+ *                response: {"text": "```...```", "code": 0, "error": ""}
+ *
+ * POST /slack    slash command of slack, e.g: /curveadm status c1
+ *                acknowledged at once, the output posted to response_url later
+ */
+const (
+	PATH_WEBHOOK = "/webhook"
+	PATH_SLACK   = "/slack"
+
+	HEADER_AUTHORIZATION   = "Authorization"
+	HEADER_SLACK_SIGNATURE = "X-Slack-Signature"
+	HEADER_SLACK_TIMESTAMP = "X-Slack-Request-Timestamp"
+	PREFIX_BEARER          = "Bearer "
+	SLACK_SIGNATURE_PREFIX = "v0="
+	SLACK_SIGNATURE_VER    = "v0"
+
+	SLACK_RESPONSE_IN_CHANNEL = "in_channel"
+	SLACK_RESPONSE_EPHEMERAL  = "ephemeral"
+
+	MAX_REQUEST_BODY     = 64 << 10
+	MAX_PENDING_COMMANDS = 8
+	MAX_SLACK_CLOCK_SKEW = 5 * time.Minute
+	SLACK_REPLY_TIMEOUT  = 10 * time.Second
+)
+
+type (
+	WebhookRequest struct {
+		Text string `json:"text"`
+		User string `json:"user"`
+	}
+
+	WebhookResponse struct {
+		Text  string `json:"text"`
+		Code  int    `json:"code"`
+		Error string `json:"error,omitempty"`
+	}
+
+	SlackResponse struct {
+		ResponseType string `json:"response_type"`
+		Text         string `json:"text"`
+	}
+
+	Server struct {
+		options Options
+		runner  *Runner
+		server  *http.Server
+		client  *http.Client
+		pending int32
+	}
+)
+
+func NewServer(options Options, executor Executor) *Server {
+	s := &Server{
+		options: options,
+		runner:  NewRunner(options, executor),
+		client:  &http.Client{Timeout: SLACK_REPLY_TIMEOUT},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(PATH_WEBHOOK, s.handleWebhook)
+	mux.HandleFunc(PATH_SLACK, s.handleSlack)
+	s.server = &http.Server{Addr: options.Listen, Handler: mux}
+	return s
+}
+
+func (s *Server) ListenAndServe() error {
+	if len(s.options.CertFile) > 0 && len(s.options.KeyFile) > 0 {
+		return s.server.ListenAndServeTLS(s.options.CertFile, s.options.KeyFile)
+	}
+	return s.server.ListenAndServe()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func codeBlock(text string) string {
+	return fmt.Sprintf("```\n%s\n```", text)
+}
+
+func errorText(err error) (int, string) {
+	if code, ok := err.(*errno.ErrorCode); ok {
+		if len(code.GetClue()) > 0 {
+			return code.GetCode(), fmt.Sprintf("%s (%s)", code.GetDescription(), code.GetClue())
+		}
+		return code.GetCode(), code.GetDescription()
+	}
+	return errno.ERR_UNKNOWN.GetCode(), err.Error()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func equal(a, b string) bool {
+	return len(a) > 0 && subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get(HEADER_AUTHORIZATION), PREFIX_BEARER)
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	} else if len(s.options.Token) == 0 || !equal(token, s.options.Token) {
+		code, text := errorText(errno.ERR_BOT_UNAUTHORIZED)
+		writeJSON(w, http.StatusUnauthorized, WebhookResponse{Code: code, Error: text})
+		return
+	}
+
+	request := WebhookRequest{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MAX_REQUEST_BODY)).Decode(&request)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, WebhookResponse{
+			Code:  errno.ERR_INVALID_API_REQUEST.GetCode(),
+			Error: err.Error(),
+		})
+		return
+	}
+
+	output, err := s.runner.Run(fmt.Sprintf("webhook:%s", request.User), request.Text)
+	response := WebhookResponse{Text: codeBlock(output)}
+	if err != nil {
+		response.Code, response.Error = errorText(err)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+/*
+ * verifySlack checks the request signed by signing secret of slack app:
+ *   v0=hex(hmac_sha256(secret, "v0:" + timestamp + ":" + body))
+ * the verification token in form is checked if signing secret not specified.
+ */
+func (s *Server) verifySlack(r *http.Request, body []byte, form url.Values) bool {
+	if len(s.options.SlackSigningSecret) > 0 {
+		timestamp := r.Header.Get(HEADER_SLACK_TIMESTAMP)
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return false
+		} else if skew := time.Since(time.Unix(seconds, 0)); skew > MAX_SLACK_CLOCK_SKEW || skew < -MAX_SLACK_CLOCK_SKEW {
+			return false
+		}
+		return equal(r.Header.Get(HEADER_SLACK_SIGNATURE),
+			SlackSignature(s.options.SlackSigningSecret, timestamp, body))
+	}
+	return len(s.options.SlackToken) > 0 && equal(form.Get("token"), s.options.SlackToken)
+}
+
+func SlackSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s:%s:", SLACK_SIGNATURE_VER, timestamp)
+	mac.Write(body)
+	return SLACK_SIGNATURE_PREFIX + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Server) replySlack(responseURL string, response SlackResponse) {
+	data, _ := json.Marshal(response)
+	resp, err := s.client.Post(responseURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		log.Error("Reply slack failed", log.Field("Error", err))
+		return
+	}
+	resp.Body.Close()
+}
+
+func (s *Server) handleSlack(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MAX_REQUEST_BODY))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	} else if !s.verifySlack(r, body, form) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// reject the unsupported command at once
+	text := form.Get("text")
+	args, err := ParseCommand(text, s.options)
+	if err != nil {
+		_, message := errorText(err)
+		writeJSON(w, http.StatusOK, SlackResponse{
+			ResponseType: SLACK_RESPONSE_EPHEMERAL,
+			Text:         fmt.Sprintf("%s\n%s", message, Usage()),
+		})
+		return
+	} else if args == nil {
+		writeJSON(w, http.StatusOK, SlackResponse{SLACK_RESPONSE_EPHEMERAL, Usage()})
+		return
+	}
+
+	// slack requires response in 3 seconds, so execute in background
+	responseURL := form.Get("response_url")
+	if !strings.HasPrefix(responseURL, "https://") {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	} else if atomic.AddInt32(&s.pending, 1) > MAX_PENDING_COMMANDS {
+		atomic.AddInt32(&s.pending, -1)
+		_, message := errorText(errno.ERR_TOO_MANY_PENDING_BOT_COMMANDS)
+		writeJSON(w, http.StatusOK, SlackResponse{SLACK_RESPONSE_EPHEMERAL, message})
+		return
+	}
+	source := fmt.Sprintf("slack:%s", form.Get("user_name"))
+	go func() {
+		defer atomic.AddInt32(&s.pending, -1)
+		output, err := s.runner.Run(source, text)
+		text := codeBlock(output)
+		if err != nil {
+			_, message := errorText(err)
+			text = fmt.Sprintf("%s\n%s", message, text)
+		}
+		s.replySlack(responseURL, SlackResponse{SLACK_RESPONSE_IN_CHANNEL, text})
+	}()
+	writeJSON(w, http.StatusOK, SlackResponse{
+		ResponseType: SLACK_RESPONSE_EPHEMERAL,
+		Text:         fmt.Sprintf("Running `%s`...", strings.TrimSpace(text)),
+	})
+}
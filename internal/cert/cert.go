@@ -0,0 +1,200 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"time"
+)
+
+const (
+	PEM_TYPE_CERTIFICATE = "CERTIFICATE"
+	PEM_TYPE_PRIVATE_KEY = "EC PRIVATE KEY"
+
+	ORGANIZATION = "CurveAdm"
+
+	// the name of cluster CA in certificates table, others are service ids
+	NAME_CA = "ca"
+	// the client certificate of prometheus which scrapes services with TLS
+	NAME_PROMETHEUS = "prometheus"
+)
+
+var (
+	ErrInvalidPEM = errors.New("invalid PEM block")
+)
+
+// Bundle is a certificate with its private key, both PEM encoded
+type Bundle struct {
+	Cert string
+	Key  string
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func encode(der []byte, key *ecdsa.PrivateKey) (*Bundle, error) {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Bundle{
+		Cert: string(pem.EncodeToMemory(&pem.Block{Type: PEM_TYPE_CERTIFICATE, Bytes: der})),
+		Key:  string(pem.EncodeToMemory(&pem.Block{Type: PEM_TYPE_PRIVATE_KEY, Bytes: keyDER})),
+	}, nil
+}
+
+func EncodeCert(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: PEM_TYPE_CERTIFICATE, Bytes: cert.Raw})
+}
+
+// ParseCert parses the first certificate in PEM data
+func ParseCert(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != PEM_TYPE_CERTIFICATE {
+		return nil, ErrInvalidPEM
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func ParseKey(data string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != PEM_TYPE_PRIVATE_KEY {
+		return nil, ErrInvalidPEM
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+// NewCA generates a self-signed CA which signs all certificates of cluster
+func NewCA(commonName string, validity time.Duration) (*Bundle, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{ORGANIZATION},
+		},
+		NotBefore:             now.Add(-time.Hour), // tolerate clock skew between hosts
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	return encode(der, key)
+}
+
+/*
+ * Issue signs a certificate for service by CA, the hosts (IP or DNS name)
+ * become the subject alternative names. The certificate is used for
+ * both server and client authentication, because services (e.g. etcd peers)
+ * are clients of each other.
+ */
+func (ca *Bundle) Issue(commonName string, hosts []string, validity time.Duration) (*Bundle, error) {
+	caCert, err := ParseCert(ca.Cert)
+	if err != nil {
+		return nil, err
+	}
+	caKey, err := ParseKey(ca.Key)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notAfter := now.Add(validity)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{ORGANIZATION},
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if len(host) > 0 {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	return encode(der, key)
+}
+
+// Verify checks the certificate is signed by CA and valid for host now
+func (ca *Bundle) Verify(cert, host string) error {
+	caCert, err := ParseCert(ca.Cert)
+	if err != nil {
+		return err
+	}
+	c, err := ParseCert(cert)
+	if err != nil {
+		return err
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	_, err = c.Verify(x509.VerifyOptions{
+		DNSName:   host,
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package cert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssue(t *testing.T) {
+	assert := assert.New(t)
+
+	ca, err := NewCA("curveadm-test-ca", 24*time.Hour)
+	assert.Nil(err)
+	c, err := ParseCert(ca.Cert)
+	assert.Nil(err)
+	assert.True(c.IsCA)
+	assert.Equal("curveadm-test-ca", c.Subject.CommonName)
+
+	bundle, err := ca.Issue("etcd-c690bde11d1a", []string{"10.0.0.1", "host1", ""}, 48*time.Hour)
+	assert.Nil(err)
+	c, err = ParseCert(bundle.Cert)
+	assert.Nil(err)
+	assert.False(c.IsCA)
+	assert.Equal([]string{"host1"}, c.DNSNames)
+	assert.Len(c.IPAddresses, 1)
+	_, err = ParseKey(bundle.Key)
+	assert.Nil(err)
+
+	// the certificate never outlives CA
+	caCert, _ := ParseCert(ca.Cert)
+	assert.False(c.NotAfter.After(caCert.NotAfter))
+
+	assert.Nil(ca.Verify(bundle.Cert, "10.0.0.1"))
+	assert.Nil(ca.Verify(bundle.Cert, "host1"))
+	assert.NotNil(ca.Verify(bundle.Cert, "10.0.0.2"))
+
+	other, err := NewCA("other-ca", time.Hour)
+	assert.Nil(err)
+	assert.NotNil(other.Verify(bundle.Cert, "10.0.0.1"))
+}
+
+func TestParseInvalidPEM(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := ParseCert("not a certificate")
+	assert.Equal(ErrInvalidPEM, err)
+	_, err = ParseKey("")
+	assert.Equal(ErrInvalidPEM, err)
+
+	ca, err := NewCA("curveadm-test-ca", time.Hour)
+	assert.Nil(err)
+	_, err = ParseCert(ca.Key)
+	assert.Equal(ErrInvalidPEM, err)
+
+	// the first certificate of trusted bundle is parsed
+	previous, err := NewCA("previous-ca", time.Hour)
+	assert.Nil(err)
+	c, err := ParseCert(ca.Cert + previous.Cert)
+	assert.Nil(err)
+	assert.Equal("curveadm-test-ca", c.Subject.CommonName)
+	assert.Equal(ca.Cert, string(EncodeCert(c)))
+}
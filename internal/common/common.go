@@ -51,32 +51,110 @@ const (
 	KEY_NUMBER_OF_CHUNKSERVER = "NUMBER_OF_CHUNKSERVER"
 
 	// format
-	KEY_ALL_FORMAT_STATUS = "ALL_FORMAT_STATUS"
+	KEY_ALL_FORMAT_STATUS        = "ALL_FORMAT_STATUS"
+	KEY_FORMAT_WAIT_DONE         = "FORMAT_WAIT_DONE"
+	KEY_FORMAT_UNFORMATTED_DISKS = "FORMAT_UNFORMATTED_DISKS"
 
 	// check
 	KEY_CHECK_WITH_WEAK          = "CHECK_WITH_WEAK"
 	KEY_CHECK_KERNEL_MODULE_NAME = "CHECK_KERNEL_MODULE_NAME"
 	KEY_CHECK_SKIP_SNAPSHOECLONE = "CHECK_SKIP_SNAPSHOTCLONE"
 	KEY_ALL_HOST_DATE            = "ALL_HOST_DATE"
+	KEY_ALL_HOST_FACTS           = "ALL_HOST_FACTS"
+	KEY_ALL_HOST_CLOCK_OFFSET    = "ALL_HOST_CLOCK_OFFSET"
+	KEY_NTP_REFERENCE_HOST       = "NTP_REFERENCE_HOST"
+	KEY_NTP_MAX_OFFSET           = "NTP_MAX_OFFSET"
+	KEY_NTP_SERVERS              = "NTP_SERVERS"
+	KEY_CLOCK_SKEWED_HOSTS       = "CLOCK_SKEWED_HOSTS"
+
+	// hosts
+	KEY_INIT_HOST_OPTIONS     = "INIT_HOST_OPTIONS"
+	KEY_EXEC_HOST_OPTIONS     = "EXEC_HOST_OPTIONS"
+	KEY_ALL_EXEC_HOST_RESULTS = "ALL_EXEC_HOST_RESULTS"
+	KEY_PING_HOST_OPTIONS     = "PING_HOST_OPTIONS"
+	KEY_ALL_PING_HOST_RESULTS = "ALL_PING_HOST_RESULTS"
+
+	// plugin
+	KEY_PLUGIN_STEP_OPTIONS     = "PLUGIN_STEP_OPTIONS"
+	KEY_ALL_PLUGIN_STEP_RESULTS = "ALL_PLUGIN_STEP_RESULTS"
+
+	// doctor
+	KEY_DOCTOR_SKIPPED_ITEMS = "DOCTOR_SKIPPED_ITEMS"
+	KEY_ALL_DOCTOR_RESULTS   = "ALL_DOCTOR_RESULTS"
 
 	// scale-out / migrate
 	KEY_SCALE_OUT_CLUSTER = "SCALE_OUT_CLUSTER"
 	KEY_MIGRATE_SERVERS   = "MIGRATE_SERVERS"
 	KEY_NEW_TOPOLOGY_DATA = "NEW_TOPOLOGY_DATA"
 
+	// etcd member
+	KEY_ETCD_MEMBER  = "ETCD_MEMBER"
+	KEY_ETCD_CLUSTER = "ETCD_CLUSTER"
+
+	// backup / restore
+	KEY_BACKUP_LOCAL_PATH    = "BACKUP_LOCAL_PATH"
+	KEY_ETCD_SNAPSHOT_STATUS = "ETCD_SNAPSHOT_STATUS"
+
+	// scale-in
+	KEY_SCALE_IN_CHUNKSERVER_IDS = "SCALE_IN_CHUNKSERVER_IDS"
+
+	// watch
+	KEY_ALL_HEALTH_PROBES = "ALL_HEALTH_PROBES"
+
+	// logs
+	KEY_LOGS_OPTIONS     = "LOGS_OPTIONS"
+	KEY_ALL_SERVICE_LOGS = "ALL_SERVICE_LOGS"
+
+	// support bundle
+	KEY_BUNDLE_DIR   = "BUNDLE_DIR"
+	KEY_BUNDLE_SINCE = "BUNDLE_SINCE"
+
+	// top
+	KEY_ALL_SERVICE_METRICS = "ALL_SERVICE_METRICS"
+
+	// config set --hot
+	KEY_SERVICE_FLAGS         = "SERVICE_FLAGS"
+	KEY_ALL_SERVICE_FLAGS_SET = "ALL_SERVICE_FLAGS_SET"
+
+	// canary upgrade
+	KEY_ALL_SERVICE_IMAGES = "ALL_SERVICE_IMAGES"
+
+	// rollback
+	KEY_ALL_PREVIOUS_SERVICE_CONFIGS = "ALL_PREVIOUS_SERVICE_CONFIGS"
+
+	// topology lint
+	KEY_ALL_LINT_HOST_FACTS = "ALL_LINT_HOST_FACTS"
+
+	// balance status
+	KEY_ALL_CHUNKSERVER_LOADS = "ALL_CHUNKSERVER_LOADS"
+
+	// chunkserver set-status
+	KEY_CHUNKSERVER_IDS    = "CHUNKSERVER_IDS"
+	KEY_CHUNKSERVER_STATUS = "CHUNKSERVER_STATUS"
+
+	// snapshot status
+	KEY_ALL_SNAPSHOTCLONE_STATUS = "ALL_SNAPSHOTCLONE_STATUS"
+	KEY_SNAPSHOTCLONE_USER       = "SNAPSHOTCLONE_USER"
+
 	// status
 	KEY_ALL_SERVICE_STATUS = "ALL_SERVICE_STATUS"
 	SERVICE_STATUS_CLEANED = "Cleaned"
 	SERVICE_STATUS_LOSED   = "Losed"
 	SERVICE_STATUS_UNKNOWN = "Unknown"
+	KEY_STATUS_DEEP        = "STATUS_DEEP"
 
 	// clean
-	KEY_CLEAN_ITEMS      = "CLEAN_ITEMS"
-	KEY_CLEAN_BY_RECYCLE = "CLEAN_BY_RECYCLE"
-	CLEAN_ITEM_LOG       = "log"
-	CLEAN_ITEM_DATA      = "data"
-	CLEAN_ITEM_CONTAINER = "container"
-	CLEANED_CONTAINER_ID = "-"
+	KEY_CLEAN_ITEMS       = "CLEAN_ITEMS"
+	KEY_CLEAN_BY_RECYCLE  = "CLEAN_BY_RECYCLE"
+	CLEAN_ITEM_LOG        = "log"
+	CLEAN_ITEM_DATA       = "data"
+	CLEAN_ITEM_CONTAINER  = "container"
+	CLEANED_CONTAINER_ID  = "-"
+	KEY_ALL_CLEAN_REPORTS = "ALL_CLEAN_REPORTS"
+
+	// artifacts
+	KEY_ARTIFACT_IMAGES = "ARTIFACT_IMAGES"
+	KEY_ARTIFACT_OUTPUT = "ARTIFACT_OUTPUT"
 
 	// client
 	KEY_CLIENT_HOST           = "CLIENT_HOST"
@@ -85,6 +163,12 @@ const (
 	KEY_CLIENT_STATUS_VERBOSE = "CLIENT_STATUS_VERBOSE"
 	KEY_MAP_OPTIONS           = "MAP_OPTIONS"
 	KEY_MOUNT_OPTIONS         = "MOUNT_OPTIONS"
+	KEY_MOUNT_INSECURE        = "MOUNT_INSECURE"
+	KEY_VOLUME_OPTIONS        = "VOLUME_OPTIONS"
+	KEY_VOLUME_OUTPUT         = "VOLUME_OUTPUT"
+	KEY_ALL_MOUNT_STATUS      = "ALL_MOUNT_STATUS"
+	KEY_BENCH_OPTIONS         = "BENCH_OPTIONS"
+	KEY_ALL_BENCH_RESULTS     = "ALL_BENCH_RESULTS"
 	CLIENT_STATUS_LOSED       = "Losed"
 	CLIENT_STATUS_UNKNOWN     = "Unknown"
 	KERNERL_MODULE_NBD        = "nbd"
@@ -116,6 +200,7 @@ const (
 	KEY_SERVICE_HOSTS    = "SERVICE_HOSTS"
 	KEY_MONITOR_STATUS   = "MONITOR_STATUS"
 	CLEANED_MONITOR_CONF = "-"
+	KEY_ALERT_RULES      = "ALERT_RULES"
 )
 
 // others
@@ -125,3 +210,8 @@ const (
 	AUDIT_STATUS_FAIL
 	AUDIT_STATUS_CANCEL
 )
+
+const (
+	AUDIT_SOURCE_LOCAL     = "local"
+	AUDIT_OPERATOR_UNKNOWN = "unknown"
+)
@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"bytes"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/spf13/viper"
+)
+
+const (
+	KEY_ALERT_GROUPS = "groups"
+	KEY_ALERT_NAME   = "name"
+	KEY_ALERT_RULES  = "rules"
+)
+
+type AlertGroup struct {
+	Name  string
+	Rules int
+}
+
+/*
+ * alert rules is the prometheus rule file, e.g:
+ *
+ *   groups:
+ *   - name: curve
+ *     rules:
+ *     - alert: ChunkserverDown
+ *       expr: up{job="chunkserver"} == 0
+ *
+ * we only check its skeleton here, the expressions will be
+ * checked by promtool inside prometheus container.
+ */
+func ParseAlertRules(data string) ([]AlertGroup, error) {
+	parser := viper.New()
+	parser.SetConfigType("yaml")
+	err := parser.ReadConfig(bytes.NewBufferString(data))
+	if err != nil {
+		return nil, errno.ERR_INVALID_ALERT_RULES.E(err)
+	}
+
+	items, ok := parser.Get(KEY_ALERT_GROUPS).([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, errno.ERR_INVALID_ALERT_RULES.
+			F("'%s' not found or empty", KEY_ALERT_GROUPS)
+	}
+
+	groups := []AlertGroup{}
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errno.ERR_INVALID_ALERT_RULES.
+				F("invalid group: %v", item)
+		}
+
+		name, ok := m[KEY_ALERT_NAME].(string)
+		if !ok || len(name) == 0 {
+			return nil, errno.ERR_INVALID_ALERT_RULES.
+				F("group requires '%s'", KEY_ALERT_NAME)
+		}
+		rules, ok := m[KEY_ALERT_RULES].([]interface{})
+		if !ok || len(rules) == 0 {
+			return nil, errno.ERR_INVALID_ALERT_RULES.
+				F("group '%s' requires '%s'", name, KEY_ALERT_RULES)
+		}
+		groups = append(groups, AlertGroup{Name: name, Rules: len(rules)})
+	}
+	return groups, nil
+}
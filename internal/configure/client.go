@@ -30,6 +30,7 @@ import (
 	"github.com/opencurve/curveadm/internal/build"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
 	"github.com/opencurve/curveadm/internal/utils"
 	log "github.com/opencurve/curveadm/pkg/log/glg"
 	"github.com/opencurve/curveadm/pkg/variable"
@@ -52,6 +53,11 @@ const (
 	KEY_CLIENT_S3_ADDRESS     = "s3.endpoint"
 	KEY_CLIENT_S3_BUCKET_NAME = "s3.bucket_name"
 
+	KEY_CLIENT_QOS_READ_IOPS  = "qos.read_iops"
+	KEY_CLIENT_QOS_WRITE_IOPS = "qos.write_iops"
+	KEY_CLIENT_QOS_READ_BPS   = "qos.read_bps"
+	KEY_CLIENT_QOS_WRITE_BPS  = "qos.write_bps"
+
 	DEFAULT_CORE_LOCATE_DIR = "/core"
 )
 
@@ -68,6 +74,18 @@ var (
 		KEY_CORE_DIR:        true,
 		KEY_CONTAINER_PID:   true,
 		KEY_ENVIRONMENT:     true,
+
+		KEY_CLIENT_QOS_READ_IOPS:  true,
+		KEY_CLIENT_QOS_WRITE_IOPS: true,
+		KEY_CLIENT_QOS_READ_BPS:   true,
+		KEY_CLIENT_QOS_WRITE_BPS:  true,
+	}
+
+	clientQoSKeys = []string{
+		KEY_CLIENT_QOS_READ_IOPS,
+		KEY_CLIENT_QOS_WRITE_IOPS,
+		KEY_CLIENT_QOS_READ_BPS,
+		KEY_CLIENT_QOS_WRITE_BPS,
 	}
 
 	LAYOUT_CURVEBS_ROOT_DIR = topology.GetCurveBSProjectLayout().ProjectRootDir
@@ -79,6 +97,14 @@ type (
 		Config map[string]interface{}
 	}
 
+	// zero means unlimited, bps is in bytes per second
+	ClientQoS struct {
+		ReadIOPS  int
+		WriteIOPS int
+		ReadBPS   int
+		WriteBPS  int
+	}
+
 	ClientConfig struct {
 		config        map[string]interface{}
 		serviceConfig map[string]string
@@ -94,12 +120,31 @@ func NewClientConfig(config map[string]interface{}) (*ClientConfig, error) {
 		if !ok {
 			return nil, errno.ERR_UNSUPPORT_CLIENT_CONFIGURE_VALUE_TYPE.
 				F("%s: %v", k, v)
+		} else if secret.IsReference(value) {
+			realValue, err := secret.Resolve(value)
+			if err != nil {
+				return nil, err
+			}
+			value = realValue
+			config[k] = realValue
 		}
 		if !excludeClientConfig[k] { // TODO(P0): check bool or integer
 			serviceConfig[k] = value
 		}
 	}
 
+	for _, key := range clientQoSKeys {
+		v, ok := config[key]
+		if !ok {
+			continue
+		}
+		value, _ := utils.All2Str(v)
+		if n, ok := utils.Str2Int(value); !ok || n < 0 {
+			return nil, errno.ERR_INVALID_CLIENT_QOS_VALUE.
+				F("%s: %v", key, v)
+		}
+	}
+
 	vars := variable.NewVariables()
 	vars.Register(variable.Variable{Name: "prefix", Value: "/curvebs/nebd"})
 	err := vars.Build()
@@ -192,6 +237,16 @@ func (cc *ClientConfig) getBool(key string) bool {
 	return v.(bool)
 }
 
+func (cc *ClientConfig) getInt(key string) int {
+	v := cc.config[strings.ToLower(key)]
+	if v == nil {
+		return 0
+	}
+	value, _ := utils.All2Str(v)
+	n, _ := utils.Str2Int(value)
+	return n
+}
+
 func (cc *ClientConfig) GetKind() string                     { return cc.getString(KEY_KIND) }
 func (cc *ClientConfig) GetDataDir() string                  { return cc.getString(KEY_DATA_DIR) }
 func (cc *ClientConfig) GetLogDir() string                   { return cc.getString(KEY_LOG_DIR) }
@@ -216,6 +271,36 @@ func (cc *ClientConfig) GetContainerImage() string {
 	return containerImage
 }
 
+func (cc *ClientConfig) GetQoS() ClientQoS {
+	return ClientQoS{
+		ReadIOPS:  cc.getInt(KEY_CLIENT_QOS_READ_IOPS),
+		WriteIOPS: cc.getInt(KEY_CLIENT_QOS_WRITE_IOPS),
+		ReadBPS:   cc.getInt(KEY_CLIENT_QOS_READ_BPS),
+		WriteBPS:  cc.getInt(KEY_CLIENT_QOS_WRITE_BPS),
+	}
+}
+
+// Override returns the qos which non-zero items replaced by specified one,
+// e.g. the limits from command line flags take precedence over configure file
+func (qos ClientQoS) Override(other ClientQoS) ClientQoS {
+	choose := func(a, b int) int {
+		if b > 0 {
+			return b
+		}
+		return a
+	}
+	return ClientQoS{
+		ReadIOPS:  choose(qos.ReadIOPS, other.ReadIOPS),
+		WriteIOPS: choose(qos.WriteIOPS, other.WriteIOPS),
+		ReadBPS:   choose(qos.ReadBPS, other.ReadBPS),
+		WriteBPS:  choose(qos.WriteBPS, other.WriteBPS),
+	}
+}
+
+func (qos ClientQoS) Enabled() bool {
+	return qos.ReadIOPS > 0 || qos.WriteIOPS > 0 || qos.ReadBPS > 0 || qos.WriteBPS > 0
+}
+
 func (cc *ClientConfig) GetClusterMDSAddr() string {
 	if cc.GetKind() == topology.KIND_CURVEBS {
 		return cc.getString(KEY_CURVEBS_LISTEN_MDS_ADDRS)
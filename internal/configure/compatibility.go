@@ -0,0 +1,144 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+const (
+	// e.g. opencurvedocker/curvebs:v1.2.6, harbor.cloud.netease.com/curve/curvefs:2.7.0-rc1
+	REGEX_IMAGE_VERSION = `^v?(\d+)\.(\d+)`
+)
+
+/*
+ * compatibility matrix between curveadm and cluster images:
+ *   curveadm >= Curveadm supports images of kind between [MinImage, MaxImage],
+ *   and the rule with the largest curveadm version which <= current version wins.
+ *
+ * NOTE: image version only compare by major and minor version
+ */
+type Compatibility struct {
+	Curveadm string
+	Kind     string
+	MinImage string
+	MaxImage string
+}
+
+var COMPATIBILITY_MATRIX = []Compatibility{
+	{Curveadm: "0.1.0", Kind: topology.KIND_CURVEBS, MinImage: "1.2", MaxImage: "1.2"},
+	{Curveadm: "0.1.0", Kind: topology.KIND_CURVEFS, MinImage: "2.3", MaxImage: "2.4"},
+	{Curveadm: "0.2.0", Kind: topology.KIND_CURVEBS, MinImage: "1.2", MaxImage: "1.3"},
+	{Curveadm: "0.2.0", Kind: topology.KIND_CURVEFS, MinImage: "2.3", MaxImage: "2.6"},
+	{Curveadm: "0.3.0", Kind: topology.KIND_CURVEBS, MinImage: "1.2", MaxImage: "1.5"},
+	{Curveadm: "0.3.0", Kind: topology.KIND_CURVEFS, MinImage: "2.3", MaxImage: "2.7"},
+}
+
+// convert version "x.y.z" to comparable number, return -1 if invalid
+func versionNumber(version string) int {
+	num := 0
+	for _, item := range strings.Split(strings.TrimPrefix(version, "v"), ".") {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return -1
+		}
+		num = num*1000 + n
+	}
+	return num
+}
+
+// return major.minor version of image, return false if the tag isn't a version (e.g. latest)
+func ParseImageVersion(image string) (string, bool) {
+	idx := strings.LastIndex(image, ":")
+	if idx < 0 || strings.Contains(image[idx:], "/") { // without tag, e.g. 127.0.0.1:5000/curvebs
+		return "", false
+	}
+
+	tag := image[idx+1:]
+	mu := regexp.MustCompile(REGEX_IMAGE_VERSION).FindStringSubmatch(tag)
+	if len(mu) == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("%s.%s", mu[1], mu[2]), true
+}
+
+func getCompatibility(curveadmVersion, kind string) (Compatibility, bool) {
+	var out Compatibility
+	found := false
+	current := versionNumber(curveadmVersion)
+	for _, c := range COMPATIBILITY_MATRIX {
+		v := versionNumber(c.Curveadm)
+		if c.Kind != kind || v > current {
+			continue
+		} else if !found || v > versionNumber(out.Curveadm) {
+			out, found = c, true
+		}
+	}
+	return out, found
+}
+
+// check whether the image with specified kind is supported by curveadm
+func CheckCompatibility(curveadmVersion, kind, image string) error {
+	version, ok := ParseImageVersion(image)
+	if !ok { // custom tag, we can't know its version
+		return nil
+	}
+
+	c, ok := getCompatibility(curveadmVersion, kind)
+	if !ok {
+		return nil
+	}
+
+	v := versionNumber(version)
+	if v < versionNumber(c.MinImage) || v > versionNumber(c.MaxImage) {
+		return errno.ERR_UNSUPPORT_IMAGE_VERSION.
+			F("curveadm v%s supports %s images from v%s to v%s, but the image is %s "+
+				"(upgrade curveadm or use '--skip-compat-check' to ignore it)",
+				curveadmVersion, kind, c.MinImage, c.MaxImage, image)
+	}
+	return nil
+}
+
+// check images of all services, the same image only be checked once
+func CheckServicesCompatibility(curveadmVersion string, dcs []*topology.DeployConfig) error {
+	checked := map[string]bool{}
+	for _, dc := range dcs {
+		image := dc.GetContainerImage()
+		if checked[image] {
+			continue
+		}
+		checked[image] = true
+
+		err := CheckCompatibility(curveadmVersion, dc.GetKind(), image)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
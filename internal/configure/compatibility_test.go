@@ -0,0 +1,67 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImageVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	for image, expect := range map[string]string{
+		"opencurvedocker/curvebs:v1.2.6":              "1.2",
+		"opencurvedocker/curvefs:2.7.0-rc1":           "2.7",
+		"127.0.0.1:5000/opencurvedocker/curvebs:v1.3": "1.3",
+	} {
+		version, ok := ParseImageVersion(image)
+		assert.True(ok)
+		assert.Equal(expect, version)
+	}
+
+	for _, image := range []string{
+		"opencurvedocker/curvebs",
+		"opencurvedocker/curvebs:latest",
+		"127.0.0.1:5000/opencurvedocker/curvebs",
+	} {
+		_, ok := ParseImageVersion(image)
+		assert.False(ok)
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(CheckCompatibility("0.3.0", "curvebs", "opencurvedocker/curvebs:v1.2"))
+	assert.Nil(CheckCompatibility("0.3.0", "curvebs", "opencurvedocker/curvebs:v1.5.1"))
+	assert.Nil(CheckCompatibility("0.3.0", "curvefs", "opencurvedocker/curvefs:latest"))
+	assert.NotNil(CheckCompatibility("0.3.0", "curvebs", "opencurvedocker/curvebs:v1.6"))
+	assert.NotNil(CheckCompatibility("0.3.0", "curvefs", "opencurvedocker/curvefs:v2.2"))
+
+	// the rule with largest curveadm version which <= current version wins
+	assert.NotNil(CheckCompatibility("0.2.5", "curvebs", "opencurvedocker/curvebs:v1.4"))
+	assert.Nil(CheckCompatibility("0.2.5", "curvebs", "opencurvedocker/curvebs:v1.3"))
+	assert.Nil(CheckCompatibility("0.0.1", "curvebs", "opencurvedocker/curvebs:v9.9"))
+}
@@ -0,0 +1,485 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	CSI_DRIVER_CURVEBS        = "curve.csi.netease.com"
+	CSI_DRIVER_CURVEFS        = "csi.curvefs.com"
+	DEFAULT_CSI_NAMESPACE     = "kube-system"
+	DEFAULT_CSI_USER          = "k8s"
+	DEFAULT_CURVEBS_CSI_IMAGE = "opencurvedocker/curve-csi:v3.0.0"
+	DEFAULT_CURVEFS_CSI_IMAGE = "opencurvedocker/curvefs-csi:latest"
+
+	CSI_MANIFEST_CONFIG       = "config"
+	CSI_MANIFEST_DRIVER       = "driver"
+	CSI_MANIFEST_STORAGECLASS = "storageclass"
+
+	TEMPLATE_CSI_HEADER = `# Generated by curveadm for cluster '{{.Cluster}}', apply it by:
+#   $ kubectl apply -f .
+`
+
+	TEMPLATE_CURVEBS_CSI_CONFIG = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{.Name}}-config
+  namespace: {{.Namespace}}
+data:
+  client.conf: |
+    mds.listen.addr={{.MDSAddr}}
+    global.logPath=/var/log/curve
+`
+
+	TEMPLATE_CURVEFS_CSI_CONFIG = `apiVersion: v1
+kind: Secret
+metadata:
+  name: {{.Name}}-secret
+  namespace: {{.Namespace}}
+type: Opaque
+stringData:
+  s3AccessKey: "{{.S3AccessKey}}"
+  s3SecretKey: "{{.S3SecretKey}}"
+  s3Endpoint: "{{.S3Endpoint}}"
+  s3Bucket: "{{.S3Bucket}}"
+`
+
+	TEMPLATE_CURVEBS_CSI_STORAGECLASS = `apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: curvebs
+provisioner: {{.Driver}}
+parameters:
+  user: {{.User}}
+  cloneLazy: "true"
+reclaimPolicy: Delete
+allowVolumeExpansion: true
+`
+
+	TEMPLATE_CURVEFS_CSI_STORAGECLASS = `apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: curvefs
+provisioner: {{.Driver}}
+parameters:
+  mdsAddr: {{.MDSAddr}}
+  fsType: s3
+  csi.storage.k8s.io/provisioner-secret-name: {{.Name}}-secret
+  csi.storage.k8s.io/provisioner-secret-namespace: {{.Namespace}}
+  csi.storage.k8s.io/node-publish-secret-name: {{.Name}}-secret
+  csi.storage.k8s.io/node-publish-secret-namespace: {{.Namespace}}
+reclaimPolicy: Delete
+allowVolumeExpansion: false
+`
+
+	TEMPLATE_CSI_DRIVER = `apiVersion: storage.k8s.io/v1
+kind: CSIDriver
+metadata:
+  name: {{.Driver}}
+spec:
+  attachRequired: false
+  podInfoOnMount: false
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{.Name}}
+  namespace: {{.Namespace}}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{.Name}}
+rules:
+  - apiGroups: [""]
+    resources: ["persistentvolumes"]
+    verbs: ["get", "list", "watch", "create", "delete", "patch"]
+  - apiGroups: [""]
+    resources: ["persistentvolumeclaims"]
+    verbs: ["get", "list", "watch", "update"]
+  - apiGroups: [""]
+    resources: ["persistentvolumeclaims/status"]
+    verbs: ["patch"]
+  - apiGroups: [""]
+    resources: ["nodes", "pods", "secrets"]
+    verbs: ["get", "list", "watch"]
+  - apiGroups: [""]
+    resources: ["events"]
+    verbs: ["list", "watch", "create", "update", "patch"]
+  - apiGroups: ["storage.k8s.io"]
+    resources: ["storageclasses", "csinodes"]
+    verbs: ["get", "list", "watch"]
+  - apiGroups: ["coordination.k8s.io"]
+    resources: ["leases"]
+    verbs: ["get", "watch", "list", "delete", "update", "create"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: {{.Name}}
+subjects:
+  - kind: ServiceAccount
+    name: {{.Name}}
+    namespace: {{.Namespace}}
+roleRef:
+  kind: ClusterRole
+  name: {{.Name}}
+  apiGroup: rbac.authorization.k8s.io
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.Name}}-controller
+  namespace: {{.Namespace}}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: {{.Name}}-controller
+  template:
+    metadata:
+      labels:
+        app: {{.Name}}-controller
+    spec:
+      serviceAccountName: {{.Name}}
+      containers:
+        - name: csi-provisioner
+          image: registry.k8s.io/sig-storage/csi-provisioner:v3.5.0
+          args: ["--csi-address=/csi/csi.sock", "--leader-election=true"]
+          volumeMounts:
+            - name: socket-dir
+              mountPath: /csi
+        - name: csi-resizer
+          image: registry.k8s.io/sig-storage/csi-resizer:v1.8.0
+          args: ["--csi-address=/csi/csi.sock", "--leader-election=true"]
+          volumeMounts:
+            - name: socket-dir
+              mountPath: /csi
+        - name: csi-plugin
+          image: {{.Image}}
+          args:
+{{- range .Args}}
+            - "{{.}}"
+{{- end}}
+            - "--controllerserver=true"
+          env:
+            - name: NODE_ID
+              valueFrom:
+                fieldRef:
+                  fieldPath: spec.nodeName
+          volumeMounts:
+            - name: socket-dir
+              mountPath: /csi
+{{- if .ConfigMap}}
+            - name: config
+              mountPath: /etc/curve
+{{- end}}
+      volumes:
+        - name: socket-dir
+          emptyDir: {}
+{{- if .ConfigMap}}
+        - name: config
+          configMap:
+            name: {{.Name}}-config
+{{- end}}
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: {{.Name}}-node
+  namespace: {{.Namespace}}
+spec:
+  selector:
+    matchLabels:
+      app: {{.Name}}-node
+  template:
+    metadata:
+      labels:
+        app: {{.Name}}-node
+    spec:
+      serviceAccountName: {{.Name}}
+      hostNetwork: true
+      containers:
+        - name: node-driver-registrar
+          image: registry.k8s.io/sig-storage/csi-node-driver-registrar:v2.8.0
+          args:
+            - "--csi-address=/csi/csi.sock"
+            - "--kubelet-registration-path=/var/lib/kubelet/plugins/{{.Driver}}/csi.sock"
+          volumeMounts:
+            - name: socket-dir
+              mountPath: /csi
+            - name: registration-dir
+              mountPath: /registration
+        - name: csi-plugin
+          image: {{.Image}}
+          securityContext:
+            privileged: true
+          args:
+{{- range .Args}}
+            - "{{.}}"
+{{- end}}
+            - "--nodeserver=true"
+          env:
+            - name: NODE_ID
+              valueFrom:
+                fieldRef:
+                  fieldPath: spec.nodeName
+          volumeMounts:
+            - name: socket-dir
+              mountPath: /csi
+            - name: kubelet-dir
+              mountPath: /var/lib/kubelet
+              mountPropagation: Bidirectional
+            - name: dev-dir
+              mountPath: /dev
+            - name: modules-dir
+              mountPath: /lib/modules
+              readOnly: true
+{{- if .ConfigMap}}
+            - name: config
+              mountPath: /etc/curve
+{{- end}}
+      volumes:
+        - name: socket-dir
+          hostPath:
+            path: /var/lib/kubelet/plugins/{{.Driver}}
+            type: DirectoryOrCreate
+        - name: registration-dir
+          hostPath:
+            path: /var/lib/kubelet/plugins_registry
+            type: Directory
+        - name: kubelet-dir
+          hostPath:
+            path: /var/lib/kubelet
+            type: Directory
+        - name: dev-dir
+          hostPath:
+            path: /dev
+        - name: modules-dir
+          hostPath:
+            path: /lib/modules
+{{- if .ConfigMap}}
+        - name: config
+          configMap:
+            name: {{.Name}}-config
+{{- end}}
+`
+)
+
+type (
+	CSIOptions struct {
+		Cluster   string
+		Namespace string
+		User      string // curvebs only: owner of volumes created by csi
+		Image     string
+		Client    *ClientConfig // curvefs only: s3 credentials
+	}
+
+	// CSIManifest is one file of the kubernetes integration bundle, e.g. curvebs-csi-driver.yaml
+	CSIManifest struct {
+		Filename string
+		Content  string
+	}
+
+	csiVariables struct {
+		Cluster     string
+		Namespace   string
+		Name        string
+		Driver      string
+		Image       string
+		User        string
+		MDSAddr     string
+		Args        []string
+		ConfigMap   bool
+		S3AccessKey string
+		S3SecretKey string
+		S3Endpoint  string
+		S3Bucket    string
+	}
+)
+
+func renderCSIManifest(text string, variables csiVariables) (string, error) {
+	tmpl, err := template.New("csi").Option("missingkey=error").Parse(TEMPLATE_CSI_HEADER + text)
+	if err != nil {
+		return "", errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+
+	buffer := bytes.NewBufferString("")
+	err = tmpl.Execute(buffer, variables)
+	if err != nil {
+		return "", errno.ERR_RENDER_TEMPLATE_FAILED.E(err)
+	}
+	return buffer.String(), nil
+}
+
+func filterDeployConfig(dcs []*topology.DeployConfig, kind, role string) []*topology.DeployConfig {
+	out := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		if dc.GetKind() == kind && dc.GetRole() == role {
+			out = append(out, dc)
+		}
+	}
+	return out
+}
+
+func getCurveBSCSIVariables(dcs []*topology.DeployConfig, variables csiVariables) (csiVariables, error) {
+	variables.Name = "curvebs-csi"
+	variables.Driver = CSI_DRIVER_CURVEBS
+	variables.ConfigMap = true
+	variables.Args = []string{
+		"--drivername=" + CSI_DRIVER_CURVEBS,
+		"--endpoint=unix:///csi/csi.sock",
+		"--nodeid=$(NODE_ID)",
+	}
+	if len(variables.Image) == 0 {
+		variables.Image = DEFAULT_CURVEBS_CSI_IMAGE
+	}
+
+	// snapshot and clone through the nginx proxy of snapshotclone services
+	snapshotclones := filterDeployConfig(dcs, topology.KIND_CURVEBS, topology.ROLE_SNAPSHOTCLONE)
+	if len(snapshotclones) > 0 {
+		dc := snapshotclones[0]
+		variables.Args = append(variables.Args, fmt.Sprintf("--snapshot-server=http://%s",
+			utils.JoinHostPort(dc.GetListenIp(), dc.GetListenProxyPort())))
+	}
+	return variables, nil
+}
+
+func getCurveFSCSIVariables(cc *ClientConfig, variables csiVariables) (csiVariables, error) {
+	variables.Name = "curvefs-csi"
+	variables.Driver = CSI_DRIVER_CURVEFS
+	variables.Args = []string{
+		"--endpoint=unix:///csi/csi.sock",
+		"--nodeid=$(NODE_ID)",
+	}
+	if len(variables.Image) == 0 {
+		variables.Image = DEFAULT_CURVEFS_CSI_IMAGE
+	}
+
+	// s3 is specified by client configure while creating filesystem
+	if cc == nil || cc.GetKind() != topology.KIND_CURVEFS {
+		return variables, errno.ERR_REQUIRE_S3_CONFIGURE_FOR_CSI.
+			S("please specify curvefs client configure by '-c'")
+	}
+	variables.S3AccessKey = cc.GetS3AccessKey()
+	variables.S3SecretKey = cc.GetS3SecretKey()
+	variables.S3Endpoint = cc.GetS3Address()
+	variables.S3Bucket = cc.GetS3BucketName()
+	for key, value := range map[string]string{
+		KEY_CLIENT_S3_ACCESS_KEY:  variables.S3AccessKey,
+		KEY_CLIENT_S3_SECRET_KEY:  variables.S3SecretKey,
+		KEY_CLIENT_S3_ADDRESS:     variables.S3Endpoint,
+		KEY_CLIENT_S3_BUCKET_NAME: variables.S3Bucket,
+	} {
+		if len(value) == 0 {
+			return variables, errno.ERR_REQUIRE_S3_CONFIGURE_FOR_CSI.
+				F("%s: not found in client configure", key)
+		}
+	}
+	return variables, nil
+}
+
+func genCSIManifests(dcs []*topology.DeployConfig, kind string, options CSIOptions) ([]CSIManifest, error) {
+	mdss := filterDeployConfig(dcs, kind, topology.ROLE_MDS)
+	if len(mdss) == 0 {
+		return nil, nil
+	}
+	mdsAddr, err := mdss[0].GetVariables().Get("cluster_mds_addr")
+	if err != nil {
+		return nil, errno.ERR_RESOLVE_VARIABLE_FAILED.E(err)
+	}
+
+	variables := csiVariables{
+		Cluster:   options.Cluster,
+		Namespace: options.Namespace,
+		Image:     options.Image,
+		User:      options.User,
+		MDSAddr:   mdsAddr,
+	}
+	templates := map[string]string{}
+	if kind == topology.KIND_CURVEBS {
+		variables, err = getCurveBSCSIVariables(dcs, variables)
+		templates[CSI_MANIFEST_CONFIG] = TEMPLATE_CURVEBS_CSI_CONFIG
+		templates[CSI_MANIFEST_STORAGECLASS] = TEMPLATE_CURVEBS_CSI_STORAGECLASS
+	} else {
+		variables, err = getCurveFSCSIVariables(options.Client, variables)
+		templates[CSI_MANIFEST_CONFIG] = TEMPLATE_CURVEFS_CSI_CONFIG
+		templates[CSI_MANIFEST_STORAGECLASS] = TEMPLATE_CURVEFS_CSI_STORAGECLASS
+	}
+	if err != nil {
+		return nil, err
+	}
+	templates[CSI_MANIFEST_DRIVER] = TEMPLATE_CSI_DRIVER
+
+	manifests := []CSIManifest{}
+	for _, name := range []string{CSI_MANIFEST_CONFIG, CSI_MANIFEST_DRIVER, CSI_MANIFEST_STORAGECLASS} {
+		content, err := renderCSIManifest(templates[name], variables)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, CSIManifest{
+			Filename: fmt.Sprintf("%s-%s.yaml", variables.Name, name),
+			Content:  content,
+		})
+	}
+	return manifests, nil
+}
+
+/*
+ * GenCSIManifests generates the kubernetes manifests of curve csi driver
+ * for each kind of cluster in topology (both for mixed cluster), e.g:
+ *
+ *   curvebs-csi-config.yaml        # configmap: client.conf with mds address
+ *   curvebs-csi-driver.yaml        # csidriver, rbac, controller and node plugin
+ *   curvebs-csi-storageclass.yaml  # storageclass for PVC
+ */
+func GenCSIManifests(dcs []*topology.DeployConfig, options CSIOptions) ([]CSIManifest, error) {
+	if len(options.Namespace) == 0 {
+		options.Namespace = DEFAULT_CSI_NAMESPACE
+	}
+	if len(options.User) == 0 {
+		options.User = DEFAULT_CSI_USER
+	}
+
+	manifests := []CSIManifest{}
+	for _, kind := range []string{topology.KIND_CURVEBS, topology.KIND_CURVEFS} {
+		m, err := genCSIManifests(dcs, kind, options)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, m...)
+	}
+	if len(manifests) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED.
+			F("role: %s", topology.ROLE_MDS)
+	}
+	return manifests, nil
+}
@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	CSI_CURVEBS_TOPOLOGY = `
+kind: curvebs
+global:
+  listen.ip: ${service_host}
+
+etcd_services:
+  config:
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: host1
+
+mds_services:
+  config:
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: host1
+    - host: host2
+
+chunkserver_services:
+  config:
+    listen.port: 8200
+  deploy:
+    - host: host1
+
+snapshotclone_services:
+  config:
+    listen.port: 5555
+    listen.dummy_port: 8081
+    listen.proxy_port: 8080
+  deploy:
+    - host: host1
+`
+
+	CSI_CURVEFS_TOPOLOGY = `
+kind: curvefs
+global:
+  listen.ip: ${service_host}
+
+etcd_services:
+  config:
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: host1
+
+mds_services:
+  config:
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: host1
+
+metaserver_services:
+  config:
+    listen.port: 6800
+    listen.external_port: 7800
+  deploy:
+    - host: host1
+`
+)
+
+func parseCSITopology(t *testing.T, data string) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	for _, host := range []string{"host1", "host2"} {
+		ctx.Add(host, host)
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func TestGenCurveBSCSIManifests(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := parseCSITopology(t, CSI_CURVEBS_TOPOLOGY)
+	manifests, err := GenCSIManifests(dcs, CSIOptions{Cluster: "my-cluster"})
+	assert.Nil(err)
+	assert.Len(manifests, 3)
+	assert.Equal("curvebs-csi-config.yaml", manifests[0].Filename)
+	assert.Equal("curvebs-csi-driver.yaml", manifests[1].Filename)
+	assert.Equal("curvebs-csi-storageclass.yaml", manifests[2].Filename)
+
+	assert.True(strings.HasPrefix(manifests[0].Content, "# Generated by curveadm for cluster 'my-cluster'"))
+	assert.Contains(manifests[0].Content, "mds.listen.addr=host1:6700,host2:6700")
+	assert.Contains(manifests[0].Content, "namespace: "+DEFAULT_CSI_NAMESPACE)
+	assert.Contains(manifests[1].Content, "--snapshot-server=http://host1:8080")
+	assert.Contains(manifests[1].Content, "image: "+DEFAULT_CURVEBS_CSI_IMAGE)
+	assert.Contains(manifests[2].Content, "provisioner: "+CSI_DRIVER_CURVEBS)
+	assert.Contains(manifests[2].Content, "user: "+DEFAULT_CSI_USER)
+}
+
+func TestGenCurveFSCSIManifests(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := parseCSITopology(t, CSI_CURVEFS_TOPOLOGY)
+	_, err := GenCSIManifests(dcs, CSIOptions{})
+	assert.Equal(errno.ERR_REQUIRE_S3_CONFIGURE_FOR_CSI.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	cc, err := ParseClientCfg(`
+kind: curvefs
+mdsOpt.rpcRetryOpt.addrs: host1:6700
+s3.ak: ak
+s3.sk: sk
+s3.endpoint: http://127.0.0.1:9000
+s3.bucket_name: curvefs
+`)
+	assert.Nil(err)
+	manifests, err := GenCSIManifests(dcs, CSIOptions{Namespace: "curve", Image: "curvefs-csi:test", Client: cc})
+	assert.Nil(err)
+	assert.Len(manifests, 3)
+	assert.Equal("curvefs-csi-config.yaml", manifests[0].Filename)
+	assert.Contains(manifests[0].Content, `s3Bucket: "curvefs"`)
+	assert.Contains(manifests[0].Content, "namespace: curve")
+	assert.Contains(manifests[1].Content, "image: curvefs-csi:test")
+	assert.NotContains(manifests[1].Content, "configMap:")
+	assert.Contains(manifests[2].Content, "mdsAddr: host1:6700")
+}
@@ -28,10 +28,18 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/opencurve/curveadm/internal/build"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/event"
+	"github.com/opencurve/curveadm/internal/i18n"
+	"github.com/opencurve/curveadm/internal/report"
+	"github.com/opencurve/curveadm/internal/secret"
 	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/internal/verify"
+	"github.com/opencurve/curveadm/pkg/module"
 	"github.com/spf13/viper"
 )
 
@@ -40,30 +48,128 @@ import (
  * log_level = error
  * sudo_alias = "sudo"
  * timeout = 180
+ * language = zh  # en/zh, detected from $LANG if empty
  *
  * [ssh_connections]
  * retries = 3
  * timeout = 10
+ * pool = true
+ * pool_max_sessions = 8
+ * pool_max_idle = 2  # 0: close connection once no one using it
+ * pool_keepalive = 30  # 0: disable keep-alive
+ * pool_verbose = false
  *
  * [database]
  * url = "sqlite:///home/curve/.curveadm/data/curveadm.db"
+ *
+ * [tracing]
+ * endpoint = "127.0.0.1:4318"
+ * insecure = true
+ *
+ * [secrets]
+ * backend = store  # store/env/vault/exec
+ * keyfile = "/home/curve/.curveadm/secrets.key"
+ * env_prefix = "CURVEADM_SECRET_"
+ * vault_addr = "https://vault.example.com:8200"
+ * vault_token = ""  # $VAULT_TOKEN if empty
+ * vault_path = "secret/data/curveadm"
+ * vault_field = "value"
+ * exec_command = "/usr/local/bin/get-secret"
+ *
+ * [verify]  # images are verified by the keyless signature of opencurve workflows if no cosign key/identity
+ * insecure_skip_verify = false
+ * gpg_keyring = "/home/curve/.curveadm/trust/curveadm.gpg"
+ * cosign_key = "/home/curve/.curveadm/trust/cosign.pub"
+ * cosign_identity = "https://github.com/opencurve/curve/.github/workflows/release.yml@refs/heads/master"
+ * cosign_issuer = "https://token.actions.githubusercontent.com"
+ *
+ * [events]
+ * webhook = "http://cmdb.example.com/hook"  # separated by comma
+ * kafka_rest_proxy = "http://127.0.0.1:8082"
+ * kafka_topic = "curveadm-events"
+ * file = "/home/curve/.curveadm/logs/events.ndjson"
+ * timeout = 5
+ *
+ * [report]
+ * smtp_host = "smtp.example.com"
+ * smtp_port = 587
+ * smtp_user = "curveadm@example.com"
+ * smtp_password = "secret://smtp_password"
+ * from = "curveadm@example.com"
+ * to = "ops@example.com, dba@example.com"
+ * format = html  # html/text
+ * commands = "deploy, upgrade, format"
+ * timeout = 10
+ *
+ * [proxy]
+ * http_proxy = "http://proxy.example.com:3128"
+ * https_proxy = "http://proxy.example.com:3128"
+ * no_proxy = "localhost,127.0.0.1,.example.com"
+ *
+ * [aliases]
+ * st = status --verbose
+ *
+ * [macros]
+ * restart-role = restart --role $1 && status --role $1  # $1-$9 and $@ are arguments
  */
 const (
-	KEY_LOG_LEVEL    = "log_level"
-	KEY_SUDO_ALIAS   = "sudo_alias"
-	KEY_ENGINE       = "engine"
-	KEY_TIMEOUT      = "timeout"
-	KEY_AUTO_UPGRADE = "auto_upgrade"
-	KEY_SSH_RETRIES  = "retries"
-	KEY_SSH_TIMEOUT  = "timeout"
-	KEY_DB_URL       = "url"
+	KEY_LOG_LEVEL        = "log_level"
+	KEY_SUDO_ALIAS       = "sudo_alias"
+	KEY_ENGINE           = "engine"
+	KEY_TIMEOUT          = "timeout"
+	KEY_AUTO_UPGRADE     = "auto_upgrade"
+	KEY_LANGUAGE         = "language"
+	KEY_SSH_RETRIES      = "retries"
+	KEY_SSH_TIMEOUT      = "timeout"
+	KEY_SSH_POOL         = "pool"
+	KEY_SSH_MAX_SESSIONS = "pool_max_sessions"
+	KEY_SSH_MAX_IDLE     = "pool_max_idle"
+	KEY_SSH_KEEPALIVE    = "pool_keepalive"
+	KEY_SSH_POOL_VERBOSE = "pool_verbose"
+	KEY_DB_URL           = "url"
+	KEY_TRACING_ENDPOINT = "endpoint"
+	KEY_TRACING_INSECURE = "insecure"
+	KEY_SECRET_BACKEND   = "backend"
+	KEY_SECRET_KEYFILE   = "keyfile"
+	KEY_SECRET_ENV       = "env_prefix"
+	KEY_VAULT_ADDR       = "vault_addr"
+	KEY_VAULT_TOKEN      = "vault_token"
+	KEY_VAULT_PATH       = "vault_path"
+	KEY_VAULT_FIELD      = "vault_field"
+	KEY_SECRET_EXEC      = "exec_command"
+	KEY_SKIP_VERIFY      = "insecure_skip_verify"
+	KEY_GPG_KEYRING      = "gpg_keyring"
+	KEY_COSIGN_KEY       = "cosign_key"
+	KEY_COSIGN_IDENTITY  = "cosign_identity"
+	KEY_COSIGN_ISSUER    = "cosign_issuer"
+	KEY_EVENT_WEBHOOK    = "webhook"
+	KEY_KAFKA_REST_PROXY = "kafka_rest_proxy"
+	KEY_KAFKA_TOPIC      = "kafka_topic"
+	KEY_EVENT_FILE       = "file"
+	KEY_EVENT_TIMEOUT    = "timeout"
+	KEY_SMTP_HOST        = "smtp_host"
+	KEY_SMTP_PORT        = "smtp_port"
+	KEY_SMTP_USER        = "smtp_user"
+	KEY_SMTP_PASSWORD    = "smtp_password"
+	KEY_REPORT_FROM      = "from"
+	KEY_REPORT_TO        = "to"
+	KEY_REPORT_FORMAT    = "format"
+	KEY_REPORT_COMMANDS  = "commands"
+	KEY_REPORT_TIMEOUT   = "timeout"
+	KEY_HTTP_PROXY       = "http_proxy"
+	KEY_HTTPS_PROXY      = "https_proxy"
+	KEY_NO_PROXY         = "no_proxy"
 
 	// rqlite://127.0.0.1:4000
 	// sqlite:///home/curve/.curveadm/data/curveadm.db
 	REGEX_DB_URL = "^(sqlite|rqlite)://(.+)$"
+	REGEX_URL    = "^https?://.+$"
 	DB_SQLITE    = "sqlite"
 	DB_RQLITE    = "rqlite"
 
+	// separator of commands in macro
+	MACRO_SEPARATOR = "&&"
+
 	WITHOUT_SUDO = " "
 )
 
@@ -74,15 +180,50 @@ type (
 		Engine      string
 		Timeout     int
 		AutoUpgrade bool
+		Language    string // empty means detecting from locale environment
 		SSHRetries  int
 		SSHTimeout  int
-		DBUrl       string
+		// share SSH connections among tasks in one playbook
+		SSHPool            bool
+		SSHPoolMaxSessions int
+		SSHPoolMaxIdle     int
+		SSHPoolKeepAlive   int
+		SSHPoolVerbose     bool
+		DBUrl              string
+		// OTLP/HTTP endpoint which spans exported to, tracing is disabled if empty
+		TracingEndpoint string
+		TracingInsecure bool
+		// the file which holds master passphrase of secrets store,
+		// $CURVEADM_SECRET_PASSPHRASE or prompt if empty
+		SecretKeyFile string
+		// where secret references resolved from, see secret.BACKENDS
+		SecretBackend secret.BackendConfig
+		// trust roots which downloaded scripts, packages and images verified by
+		Verify verify.Config
+		// sinks which events of state transitions emitted to
+		Events event.Config
+		// email report sent after major operations
+		Report report.Config
+		// proxy for image pulls and artifact downloads, overridden by hosts
+		HTTPProxy module.HTTPProxyConfig
+		// alias -> arguments which replaced with, e.g: st -> [status --verbose]
+		Aliases map[string][]string
+		// macro -> arguments of commands which run in order
+		Macros map[string][][]string
 	}
 
 	CurveAdm struct {
 		Defaults       map[string]interface{} `mapstructure:"defaults"`
 		SSHConnections map[string]interface{} `mapstructure:"ssh_connections"`
 		DataBase       map[string]interface{} `mapstructure:"database"`
+		Tracing        map[string]interface{} `mapstructure:"tracing"`
+		Secrets        map[string]interface{} `mapstructure:"secrets"`
+		Verify         map[string]interface{} `mapstructure:"verify"`
+		Events         map[string]interface{} `mapstructure:"events"`
+		Report         map[string]interface{} `mapstructure:"report"`
+		Proxy          map[string]interface{} `mapstructure:"proxy"`
+		Aliases        map[string]interface{} `mapstructure:"aliases"`
+		Macros         map[string]interface{} `mapstructure:"macros"`
 	}
 )
 
@@ -104,14 +245,20 @@ func ReplaceGlobals(cfg *CurveAdmConfig) {
 func newDefault() *CurveAdmConfig {
 	home, _ := os.UserHomeDir()
 	cfg := &CurveAdmConfig{
-		LogLevel:    "error",
-		SudoAlias:   "sudo",
-		Engine:      "docker",
-		Timeout:     180,
-		AutoUpgrade: true,
-		SSHRetries:  3,
-		SSHTimeout:  10,
-		DBUrl:       fmt.Sprintf("sqlite://%s/.curveadm/data/curveadm.db", home),
+		LogLevel:           "error",
+		SudoAlias:          "sudo",
+		Engine:             "docker",
+		Timeout:            180,
+		AutoUpgrade:        true,
+		SSHRetries:         3,
+		SSHTimeout:         10,
+		SSHPool:            true,
+		SSHPoolMaxSessions: 8,
+		SSHPoolMaxIdle:     2,
+		SSHPoolKeepAlive:   30,
+		DBUrl:              fmt.Sprintf("sqlite://%s/.curveadm/data/curveadm.db", home),
+		Aliases:            map[string][]string{},
+		Macros:             map[string][][]string{},
 	}
 	return cfg
 }
@@ -129,6 +276,19 @@ func requirePositiveInt(k string, v interface{}) (int, error) {
 	return num, nil
 }
 
+// 0 is allowed for the item which disabled by 0 (e.g. pool_keepalive)
+func requireNonNegativeInt(k string, v interface{}) (int, error) {
+	num, ok := utils.Str2Int(v.(string))
+	if !ok {
+		return 0, errno.ERR_CONFIGURE_VALUE_REQUIRES_INTEGER.
+			F("%s: %v", k, v)
+	} else if num < 0 {
+		return 0, errno.ERR_CONFIGURE_VALUE_REQUIRES_NON_NEGATIVE_INTEGER.
+			F("%s: %v", k, v)
+	}
+	return num, nil
+}
+
 func requirePositiveBool(k string, v interface{}) (bool, error) {
 	yes, ok := utils.Str2Bool(v.(string))
 	if !ok {
@@ -177,6 +337,14 @@ func parseDefaultsSection(cfg *CurveAdmConfig, defaults map[string]interface{})
 			}
 			cfg.AutoUpgrade = yes
 
+		// language
+		case KEY_LANGUAGE:
+			if !i18n.Supported(v.(string)) {
+				return errno.ERR_UNSUPPORT_CURVEADM_LANGUAGE.
+					F("%s: %s", KEY_LANGUAGE, v.(string))
+			}
+			cfg.Language = v.(string)
+
 		default:
 			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
 				F("%s: %s", k, v)
@@ -209,6 +377,42 @@ func parseConnectionSection(cfg *CurveAdmConfig, connection map[string]interface
 			}
 			cfg.SSHTimeout = num
 
+		// ssh connection pool
+		case KEY_SSH_POOL:
+			yes, err := requirePositiveBool(KEY_SSH_POOL, v)
+			if err != nil {
+				return err
+			}
+			cfg.SSHPool = yes
+
+		case KEY_SSH_MAX_SESSIONS:
+			num, err := requirePositiveInt(KEY_SSH_MAX_SESSIONS, v)
+			if err != nil {
+				return err
+			}
+			cfg.SSHPoolMaxSessions = num
+
+		case KEY_SSH_MAX_IDLE:
+			num, err := requireNonNegativeInt(KEY_SSH_MAX_IDLE, v)
+			if err != nil {
+				return err
+			}
+			cfg.SSHPoolMaxIdle = num
+
+		case KEY_SSH_KEEPALIVE:
+			num, err := requireNonNegativeInt(KEY_SSH_KEEPALIVE, v)
+			if err != nil {
+				return err
+			}
+			cfg.SSHPoolKeepAlive = num
+
+		case KEY_SSH_POOL_VERBOSE:
+			yes, err := requirePositiveBool(KEY_SSH_POOL_VERBOSE, v)
+			if err != nil {
+				return err
+			}
+			cfg.SSHPoolVerbose = yes
+
 		default:
 			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
 				F("%s: %s", k, v)
@@ -244,6 +448,330 @@ func parseDatabaseSection(cfg *CurveAdmConfig, database map[string]interface{})
 	return nil
 }
 
+func parseTracingSection(cfg *CurveAdmConfig, tracing map[string]interface{}) error {
+	if tracing == nil {
+		return nil
+	}
+
+	for k, v := range tracing {
+		switch k {
+		// OTLP/HTTP endpoint, e.g: 127.0.0.1:4318
+		case KEY_TRACING_ENDPOINT:
+			cfg.TracingEndpoint = v.(string)
+
+		// export spans without TLS
+		case KEY_TRACING_INSECURE:
+			yes, err := requirePositiveBool(KEY_TRACING_INSECURE, v)
+			if err != nil {
+				return err
+			}
+			cfg.TracingInsecure = yes
+
+		default:
+			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+				F("%s: %s", k, v)
+		}
+	}
+
+	return nil
+}
+
+func parseSecretsSection(cfg *CurveAdmConfig, secrets map[string]interface{}) error {
+	if secrets == nil {
+		return nil
+	}
+
+	for k, v := range secrets {
+		switch k {
+		// secret backend
+		case KEY_SECRET_BACKEND:
+			backend := v.(string)
+			if !utils.Slice2Map(secret.BACKENDS)[backend] {
+				return errno.ERR_UNSUPPORT_SECRET_BACKEND.
+					F("%s: %s", KEY_SECRET_BACKEND, backend)
+			}
+			cfg.SecretBackend.Backend = backend
+
+		// keyfile of master passphrase
+		case KEY_SECRET_KEYFILE:
+			cfg.SecretKeyFile = v.(string)
+
+		case KEY_SECRET_ENV:
+			cfg.SecretBackend.EnvPrefix = v.(string)
+
+		case KEY_VAULT_ADDR:
+			cfg.SecretBackend.VaultAddr = v.(string)
+
+		case KEY_VAULT_TOKEN:
+			cfg.SecretBackend.VaultToken = v.(string)
+
+		case KEY_VAULT_PATH:
+			cfg.SecretBackend.VaultPath = v.(string)
+
+		case KEY_VAULT_FIELD:
+			cfg.SecretBackend.VaultField = v.(string)
+
+		case KEY_SECRET_EXEC:
+			cfg.SecretBackend.ExecCommand = v.(string)
+
+		default:
+			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+				F("%s: %s", k, v)
+		}
+	}
+
+	return nil
+}
+
+func parseVerifySection(cfg *CurveAdmConfig, verify map[string]interface{}) error {
+	if verify == nil {
+		return nil
+	}
+
+	for k, v := range verify {
+		switch k {
+		// accept unverifiable artifacts, same as --insecure-skip-verify
+		case KEY_SKIP_VERIFY:
+			yes, err := requirePositiveBool(KEY_SKIP_VERIFY, v)
+			if err != nil {
+				return err
+			}
+			cfg.Verify.InsecureSkipVerify = yes
+
+		// public keyring which checksum files signed by
+		case KEY_GPG_KEYRING:
+			cfg.Verify.GPGKeyring = v.(string)
+
+		// cosign public key which images signed by
+		case KEY_COSIGN_KEY:
+			cfg.Verify.CosignKey = v.(string)
+
+		// keyless signing identity of images
+		case KEY_COSIGN_IDENTITY:
+			cfg.Verify.CosignIdentity = v.(string)
+
+		case KEY_COSIGN_ISSUER:
+			cfg.Verify.CosignIssuer = v.(string)
+
+		default:
+			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+				F("%s: %s", k, v)
+		}
+	}
+
+	return nil
+}
+
+func parseEventsSection(cfg *CurveAdmConfig, events map[string]interface{}) error {
+	if events == nil {
+		return nil
+	}
+
+	pattern := regexp.MustCompile(REGEX_URL)
+	for k, v := range events {
+		switch k {
+		// webhooks which events posted to
+		case KEY_EVENT_WEBHOOK:
+			for _, url := range strings.Split(v.(string), ",") {
+				url = strings.TrimSpace(url)
+				if len(url) == 0 {
+					continue
+				} else if !pattern.MatchString(url) {
+					return errno.ERR_INVALID_EVENT_SINK.F("%s: %s", KEY_EVENT_WEBHOOK, url)
+				}
+				cfg.Events.Webhooks = append(cfg.Events.Webhooks, url)
+			}
+
+		// REST proxy of kafka
+		case KEY_KAFKA_REST_PROXY:
+			url := v.(string)
+			if !pattern.MatchString(url) {
+				return errno.ERR_INVALID_EVENT_SINK.F("%s: %s", KEY_KAFKA_REST_PROXY, url)
+			}
+			cfg.Events.KafkaRestProxy = url
+
+		case KEY_KAFKA_TOPIC:
+			cfg.Events.KafkaTopic = v.(string)
+
+		// local NDJSON file
+		case KEY_EVENT_FILE:
+			cfg.Events.File = v.(string)
+
+		case KEY_EVENT_TIMEOUT:
+			num, err := requirePositiveInt(KEY_EVENT_TIMEOUT, v)
+			if err != nil {
+				return err
+			}
+			cfg.Events.Timeout = time.Duration(num) * time.Second
+
+		default:
+			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+				F("%s: %s", k, v)
+		}
+	}
+
+	if len(cfg.Events.KafkaRestProxy) > 0 && len(cfg.Events.KafkaTopic) == 0 {
+		return errno.ERR_INVALID_EVENT_SINK.F("%s requires %s", KEY_KAFKA_REST_PROXY, KEY_KAFKA_TOPIC)
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	out := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) > 0 {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func parseReportSection(cfg *CurveAdmConfig, section map[string]interface{}) error {
+	if section == nil {
+		return nil
+	}
+
+	for k, v := range section {
+		switch k {
+		case KEY_SMTP_HOST:
+			cfg.Report.SMTPHost = v.(string)
+
+		case KEY_SMTP_PORT:
+			num, err := requirePositiveInt(KEY_SMTP_PORT, v)
+			if err != nil {
+				return err
+			}
+			cfg.Report.SMTPPort = num
+
+		case KEY_SMTP_USER:
+			cfg.Report.SMTPUser = v.(string)
+
+		// it should be a secret reference, e.g. secret://smtp_password
+		case KEY_SMTP_PASSWORD:
+			cfg.Report.SMTPPassword = v.(string)
+
+		case KEY_REPORT_FROM:
+			cfg.Report.From = v.(string)
+
+		// recipients, separated by comma
+		case KEY_REPORT_TO:
+			cfg.Report.To = splitList(v.(string))
+
+		case KEY_REPORT_FORMAT:
+			format := v.(string)
+			if format != report.FORMAT_HTML && format != report.FORMAT_TEXT {
+				return errno.ERR_INVALID_REPORT_CONFIGURE.F("%s: %s", KEY_REPORT_FORMAT, format)
+			}
+			cfg.Report.Format = format
+
+		// commands which report sent after, separated by comma
+		case KEY_REPORT_COMMANDS:
+			cfg.Report.Commands = splitList(v.(string))
+
+		case KEY_REPORT_TIMEOUT:
+			num, err := requirePositiveInt(KEY_REPORT_TIMEOUT, v)
+			if err != nil {
+				return err
+			}
+			cfg.Report.Timeout = time.Duration(num) * time.Second
+
+		default:
+			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+				F("%s: %s", k, v)
+		}
+	}
+
+	if len(cfg.Report.To) > 0 && len(cfg.Report.SMTPHost) == 0 {
+		return errno.ERR_INVALID_REPORT_CONFIGURE.F("%s requires %s", KEY_REPORT_TO, KEY_SMTP_HOST)
+	}
+	return nil
+}
+
+func parseProxySection(cfg *CurveAdmConfig, section map[string]interface{}) error {
+	if section == nil {
+		return nil
+	}
+
+	for k, v := range section {
+		switch k {
+		case KEY_HTTP_PROXY:
+			cfg.HTTPProxy.HTTPProxy = v.(string)
+
+		case KEY_HTTPS_PROXY:
+			cfg.HTTPProxy.HTTPSProxy = v.(string)
+
+		// hosts or domains which bypass proxy, separated by comma
+		case KEY_NO_PROXY:
+			cfg.HTTPProxy.NoProxy = strings.Join(splitList(v.(string)), ",")
+
+		default:
+			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+				F("%s: %s", k, v)
+		}
+	}
+
+	for key, proxy := range map[string]string{
+		KEY_HTTP_PROXY:  cfg.HTTPProxy.HTTPProxy,
+		KEY_HTTPS_PROXY: cfg.HTTPProxy.HTTPSProxy,
+	} {
+		if err := module.ValidateHTTPProxy(proxy); err != nil {
+			return errno.ERR_INVALID_PROXY_CONFIGURE.F("%s: %s: %v", key, proxy, err)
+		}
+	}
+	return nil
+}
+
+func parseAliasesSection(cfg *CurveAdmConfig, section map[string]interface{}) error {
+	if section == nil {
+		return nil
+	}
+
+	for k, v := range section {
+		args, err := utils.SplitArgs(v.(string))
+		if err != nil {
+			return errno.ERR_INVALID_ALIAS_OR_MACRO.F("%s: %s: %v", k, v, err)
+		} else if len(args) == 0 {
+			return errno.ERR_INVALID_ALIAS_OR_MACRO.F("%s: empty command", k)
+		}
+		cfg.Aliases[k] = args
+	}
+	return nil
+}
+
+func parseMacrosSection(cfg *CurveAdmConfig, section map[string]interface{}) error {
+	if section == nil {
+		return nil
+	}
+
+	for k, v := range section {
+		args, err := utils.SplitArgs(v.(string))
+		if err != nil {
+			return errno.ERR_INVALID_ALIAS_OR_MACRO.F("%s: %s: %v", k, v, err)
+		} else if _, ok := cfg.Aliases[k]; ok {
+			return errno.ERR_INVALID_ALIAS_OR_MACRO.F("%s: defined as both alias and macro", k)
+		}
+
+		commands := [][]string{{}}
+		for _, arg := range args {
+			n := len(commands)
+			if arg != MACRO_SEPARATOR {
+				commands[n-1] = append(commands[n-1], arg)
+			} else if len(commands[n-1]) > 0 {
+				commands = append(commands, []string{})
+			}
+		}
+		if len(commands[len(commands)-1]) == 0 {
+			commands = commands[:len(commands)-1]
+		}
+		if len(commands) == 0 {
+			return errno.ERR_INVALID_ALIAS_OR_MACRO.F("%s: empty command", k)
+		}
+		cfg.Macros[k] = commands
+	}
+	return nil
+}
+
 type sectionParser struct {
 	parser  func(*CurveAdmConfig, map[string]interface{}) error
 	section map[string]interface{}
@@ -275,6 +803,14 @@ func ParseCurveAdmConfig(filename string) (*CurveAdmConfig, error) {
 		{parseDefaultsSection, global.Defaults},
 		{parseConnectionSection, global.SSHConnections},
 		{parseDatabaseSection, global.DataBase},
+		{parseTracingSection, global.Tracing},
+		{parseSecretsSection, global.Secrets},
+		{parseVerifySection, global.Verify},
+		{parseEventsSection, global.Events},
+		{parseReportSection, global.Report},
+		{parseProxySection, global.Proxy},
+		{parseAliasesSection, global.Aliases},
+		{parseMacrosSection, global.Macros},
 	}
 	for _, item := range items {
 		err := item.parser(cfg, item.section)
@@ -288,10 +824,12 @@ func ParseCurveAdmConfig(filename string) (*CurveAdmConfig, error) {
 }
 
 func (cfg *CurveAdmConfig) GetLogLevel() string  { return cfg.LogLevel }
+func (cfg *CurveAdmConfig) GetLanguage() string  { return cfg.Language }
 func (cfg *CurveAdmConfig) GetTimeout() int      { return cfg.Timeout }
 func (cfg *CurveAdmConfig) GetAutoUpgrade() bool { return cfg.AutoUpgrade }
 func (cfg *CurveAdmConfig) GetSSHRetries() int   { return cfg.SSHRetries }
 func (cfg *CurveAdmConfig) GetSSHTimeout() int   { return cfg.SSHTimeout }
+func (cfg *CurveAdmConfig) GetSSHPool() bool     { return cfg.SSHPool }
 func (cfg *CurveAdmConfig) GetEngine() string    { return cfg.Engine }
 func (cfg *CurveAdmConfig) GetSudoAlias() string {
 	if len(cfg.SudoAlias) == 0 {
@@ -300,6 +838,19 @@ func (cfg *CurveAdmConfig) GetSudoAlias() string {
 	return cfg.SudoAlias
 }
 
+func (cfg *CurveAdmConfig) GetSSHPoolOptions() module.SSHPoolOptions {
+	return module.SSHPoolOptions{
+		MaxSessions:  cfg.SSHPoolMaxSessions,
+		MaxIdle:      cfg.SSHPoolMaxIdle,
+		KeepAliveSec: cfg.SSHPoolKeepAlive,
+	}
+}
+
+func (cfg *CurveAdmConfig) GetSSHPoolVerbose() bool { return cfg.SSHPoolVerbose }
+
+func (cfg *CurveAdmConfig) GetTracingEndpoint() string { return cfg.TracingEndpoint }
+func (cfg *CurveAdmConfig) GetTracingInsecure() bool   { return cfg.TracingInsecure }
+
 func (cfg *CurveAdmConfig) GetDBUrl() string {
 	return cfg.DBUrl
 }
@@ -312,3 +863,12 @@ func (cfg *CurveAdmConfig) GetDBPath() string {
 	}
 	return mu[2]
 }
+
+func (cfg *CurveAdmConfig) GetSecretKeyFile() string                     { return cfg.SecretKeyFile }
+func (cfg *CurveAdmConfig) GetSecretBackendConfig() secret.BackendConfig { return cfg.SecretBackend }
+func (cfg *CurveAdmConfig) GetVerifyConfig() verify.Config               { return cfg.Verify }
+func (cfg *CurveAdmConfig) GetEventsConfig() event.Config                { return cfg.Events }
+func (cfg *CurveAdmConfig) GetReportConfig() report.Config               { return cfg.Report }
+func (cfg *CurveAdmConfig) GetHTTPProxyConfig() module.HTTPProxyConfig   { return cfg.HTTPProxy }
+func (cfg *CurveAdmConfig) GetAliases() map[string][]string              { return cfg.Aliases }
+func (cfg *CurveAdmConfig) GetMacros() map[string][][]string             { return cfg.Macros }
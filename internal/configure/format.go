@@ -25,6 +25,7 @@ package configure
 import (
 	"strings"
 
+	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/viper"
@@ -53,6 +54,7 @@ var (
 type (
 	FormatConfig struct {
 		ContainerIamge string
+		ImageSource    string
 		Host           string
 		Device         string
 		MountPoint     string
@@ -63,6 +65,7 @@ type (
 
 	Format struct {
 		ContainerImage string   `mapstructure:"container_image"`
+		ImageSource    string   `mapstructure:"image_source"`
 		Hosts          []string `mapstructure:"host"`
 		Disks          []string `mapstructure:"disk"`
 		BlockSize      int      `mapstructure:"block_size"`
@@ -128,8 +131,9 @@ func ParseFormat(filename string) ([]*FormatConfig, error) {
 	}
 
 	format := &Format{
-		BlockSize: DEFAULT_BLOCK_SIZE,
-		ChunkSize: DEFAULT_CHUNK_SIZE,
+		ImageSource: topology.IMAGE_SOURCE_REMOTE,
+		BlockSize:   DEFAULT_BLOCK_SIZE,
+		ChunkSize:   DEFAULT_CHUNK_SIZE,
 	}
 	err = parser.Unmarshal(format)
 	if err != nil {
@@ -141,6 +145,11 @@ func ParseFormat(filename string) ([]*FormatConfig, error) {
 		containerImage = format.ContainerImage
 	}
 
+	if format.ImageSource != topology.IMAGE_SOURCE_REMOTE &&
+		format.ImageSource != topology.IMAGE_SOURCE_LOCAL {
+		return nil, errno.ERR_UNSUPPORT_IMAGE_SOURCE.F("image_source: %s", format.ImageSource)
+	}
+
 	if !isValidBlockSize(format.BlockSize) {
 		return nil, errno.ERR_INVALID_BLOCK_SIZE.F("block_size: %d", format.BlockSize)
 	}
@@ -152,6 +161,7 @@ func ParseFormat(filename string) ([]*FormatConfig, error) {
 			if err != nil {
 				return nil, err
 			}
+			fc.ImageSource = format.ImageSource
 			fc.BlockSize = format.BlockSize
 			fc.ChunkSize = format.ChunkSize
 			fcs = append(fcs, fc)
@@ -162,6 +172,7 @@ func ParseFormat(filename string) ([]*FormatConfig, error) {
 }
 
 func (fc *FormatConfig) GetContainerImage() string { return fc.ContainerIamge }
+func (fc *FormatConfig) GetImageSource() string    { return fc.ImageSource }
 func (fc *FormatConfig) GetHost() string           { return fc.Host }
 func (fc *FormatConfig) GetDevice() string         { return fc.Device }
 func (fc *FormatConfig) GetMountPoint() string     { return fc.MountPoint }
@@ -0,0 +1,607 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/spf13/viper"
+)
+
+const (
+	ANSIBLE_GROUP_ALL       = "all"
+	ANSIBLE_GROUP_UNGROUPED = "ungrouped"
+	ANSIBLE_SECTION_VARS    = ":vars"
+	ANSIBLE_SECTION_CHILD   = ":children"
+
+	ANSIBLE_VAR_HOST        = "ansible_host"
+	ANSIBLE_VAR_PORT        = "ansible_port"
+	ANSIBLE_VAR_USER        = "ansible_user"
+	ANSIBLE_VAR_PRIVATE_KEY = "ansible_ssh_private_key_file"
+	ANSIBLE_VAR_BECOME_USER = "ansible_become_user"
+	ANSIBLE_VAR_CONNECTION  = "ansible_connection"
+	ANSIBLE_VAR_SSH_ARGS    = "ansible_ssh_common_args"
+
+	// hosts items without ansible equivalent, e.g. curveadm_container_engine=podman
+	ANSIBLE_VAR_PREFIX_CURVEADM = "curveadm_"
+	ANSIBLE_LIST_SPLITER        = ","
+
+	// e.g: "-o ProxyJump=bastion", "-J bastion", "-o ProxyCommand='ssh -W %h:%p bastion'"
+	REGEX_SSH_PROXY_JUMP    = `(?:-o\s*ProxyJump=|-J\s*)(\S+)`
+	REGEX_SSH_PROXY_COMMAND = `-o\s*ProxyCommand=(?:"([^"]*)"|'([^']*)'|(\S+))`
+	// e.g: "web[01:50]", "db-[a:f]"
+	REGEX_ANSIBLE_HOST_RANGE = `\[[^\]]*:[^\]]*\]`
+)
+
+var (
+	// ansible variable -> hosts item
+	ANSIBLE_VARS = map[string]string{
+		ANSIBLE_VAR_HOST:           CONFIG_HOSTNAME.Key(),
+		"ansible_ssh_host":         CONFIG_HOSTNAME.Key(),
+		ANSIBLE_VAR_PORT:           CONFIG_SSH_PORT.Key(),
+		"ansible_ssh_port":         CONFIG_SSH_PORT.Key(),
+		ANSIBLE_VAR_USER:           CONFIG_USER.Key(),
+		"ansible_ssh_user":         CONFIG_USER.Key(),
+		ANSIBLE_VAR_PRIVATE_KEY:    CONFIG_PRIVATE_CONFIG_FILE.Key(),
+		"ansible_private_key_file": CONFIG_PRIVATE_CONFIG_FILE.Key(),
+		"ansible_password":         CONFIG_PASSWORD.Key(),
+		"ansible_ssh_pass":         CONFIG_PASSWORD.Key(),
+		ANSIBLE_VAR_BECOME_USER:    CONFIG_BECOME_USER.Key(),
+	}
+
+	// order of items in converted hosts.yaml, the others sorted by name
+	HOSTS_ITEMS_ORDER = []string{
+		CONFIG_HOST.Key(),
+		CONFIG_HOSTNAME.Key(),
+		CONFIG_SSH_HOSTNAME.Key(),
+		CONFIG_USER.Key(),
+		CONFIG_SSH_PORT.Key(),
+		CONFIG_PRIVATE_CONFIG_FILE.Key(),
+		CONFIG_PASSWORD.Key(),
+		CONFIG_BECOME_USER.Key(),
+		CONFIG_TRANSPORT.Key(),
+		CONFIG_PROXY_JUMP.Key(),
+		CONFIG_PROXY_COMMAND.Key(),
+	}
+
+	sshProxyJumpRegex    = regexp.MustCompile(REGEX_SSH_PROXY_JUMP)
+	sshProxyCommandRegex = regexp.MustCompile(REGEX_SSH_PROXY_COMMAND)
+	ansibleHostRange     = regexp.MustCompile(REGEX_ANSIBLE_HOST_RANGE)
+	yamlPlainRegex       = regexp.MustCompile(`^[A-Za-z0-9_./~$][A-Za-z0-9_./~${}@:=+-]*$`)
+)
+
+type (
+	// AnsibleInventory is the INI inventory of ansible
+	AnsibleInventory struct {
+		hosts     []string // in order of first appearance
+		hostVars  map[string]map[string]string
+		groups    []string            // in order of first appearance
+		members   map[string][]string // group -> hosts
+		children  map[string][]string // group -> child groups
+		groupVars map[string]map[string]string
+	}
+
+	hostsItem struct {
+		key   string
+		value interface{} // string, int or []string
+	}
+)
+
+/*
+ * splitInventoryLine splits line into words like shell does,
+ * e.g. `host1 ansible_ssh_common_args="-o ProxyJump=bastion" # comment`
+ */
+func splitInventoryLine(line string) ([]string, error) {
+	words := []string{}
+	word := strings.Builder{}
+	inWord := false
+	quote := rune(0)
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inWord = true
+		case c == '#' && !inWord:
+			return words, nil
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape")
+	} else if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+func parseInventoryVars(words []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, word := range words {
+		items := strings.SplitN(word, "=", 2)
+		if len(items) != 2 || len(items[0]) == 0 {
+			return nil, fmt.Errorf("variable '%s' should be key=value", word)
+		}
+		vars[items[0]] = items[1]
+	}
+	return vars, nil
+}
+
+func appendIfAbsent(slice []string, item string) []string {
+	for _, s := range slice {
+		if s == item {
+			return slice
+		}
+	}
+	return append(slice, item)
+}
+
+func (inventory *AnsibleInventory) addGroup(group string) {
+	inventory.groups = appendIfAbsent(inventory.groups, group)
+}
+
+func (inventory *AnsibleInventory) addHost(group, host string, vars map[string]string) {
+	inventory.hosts = appendIfAbsent(inventory.hosts, host)
+	inventory.members[group] = appendIfAbsent(inventory.members[group], host)
+	if inventory.hostVars[host] == nil {
+		inventory.hostVars[host] = map[string]string{}
+	}
+	for k, v := range vars {
+		inventory.hostVars[host][k] = v
+	}
+}
+
+// ParseAnsibleInventory parses INI inventory, host patterns like web[01:50] are unsupported
+func ParseAnsibleInventory(data string) (*AnsibleInventory, error) {
+	inventory := &AnsibleInventory{
+		hostVars:  map[string]map[string]string{},
+		members:   map[string][]string{},
+		children:  map[string][]string{},
+		groupVars: map[string]map[string]string{},
+	}
+
+	group, section := ANSIBLE_GROUP_UNGROUPED, ""
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		} else if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			group, section = line[1:len(line)-1], ""
+			for _, suffix := range []string{ANSIBLE_SECTION_VARS, ANSIBLE_SECTION_CHILD} {
+				if strings.HasSuffix(group, suffix) {
+					group, section = strings.TrimSuffix(group, suffix), suffix
+				}
+			}
+			inventory.addGroup(group)
+			continue
+		}
+
+		words, err := splitInventoryLine(line)
+		if err != nil {
+			return nil, errno.ERR_PARSE_ANSIBLE_INVENTORY_FAILED.F("line %d: %v", n, err)
+		}
+		switch section {
+		case ANSIBLE_SECTION_VARS:
+			vars, err := parseInventoryVars(words)
+			if err != nil {
+				return nil, errno.ERR_PARSE_ANSIBLE_INVENTORY_FAILED.F("line %d: %v", n, err)
+			}
+			if inventory.groupVars[group] == nil {
+				inventory.groupVars[group] = map[string]string{}
+			}
+			for k, v := range vars {
+				inventory.groupVars[group][k] = v
+			}
+		case ANSIBLE_SECTION_CHILD:
+			inventory.addGroup(words[0])
+			inventory.children[group] = appendIfAbsent(inventory.children[group], words[0])
+		default:
+			host := words[0]
+			if ansibleHostRange.MatchString(host) {
+				return nil, errno.ERR_PARSE_ANSIBLE_INVENTORY_FAILED.
+					F("line %d: host range '%s' is unsupported, please list hosts one by one", n, host)
+			}
+			vars, err := parseInventoryVars(words[1:])
+			if err != nil {
+				return nil, errno.ERR_PARSE_ANSIBLE_INVENTORY_FAILED.F("line %d: %v", n, err)
+			}
+			// host1:2222
+			if items := strings.Split(host, ":"); len(items) == 2 {
+				host = items[0]
+				if _, ok := vars[ANSIBLE_VAR_PORT]; !ok {
+					vars[ANSIBLE_VAR_PORT] = items[1]
+				}
+			}
+			inventory.addHost(group, host, vars)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errno.ERR_PARSE_ANSIBLE_INVENTORY_FAILED.E(err)
+	}
+
+	if len(inventory.hosts) == 0 {
+		return nil, errno.ERR_PARSE_ANSIBLE_INVENTORY_FAILED.S("no host found in inventory")
+	}
+	return inventory, nil
+}
+
+// ancestors returns groups which the group belongs to, the farther comes first
+func (inventory *AnsibleInventory) ancestors(group string, visited map[string]bool) []string {
+	groups := []string{}
+	for _, parent := range inventory.groups {
+		if visited[parent] || !utils.Slice2Map(inventory.children[parent])[group] {
+			continue
+		}
+		visited[parent] = true
+		groups = append(groups, inventory.ancestors(parent, visited)...)
+		groups = append(groups, parent)
+	}
+	return groups
+}
+
+// hostGroups returns all groups which host belongs to, including parent groups
+func (inventory *AnsibleInventory) hostGroups(host string) []string {
+	groups := []string{}
+	visited := map[string]bool{}
+	for _, group := range inventory.groups {
+		if !utils.Slice2Map(inventory.members[group])[host] {
+			continue
+		}
+		for _, g := range append(inventory.ancestors(group, visited), group) {
+			groups = appendIfAbsent(groups, g)
+		}
+	}
+	return groups
+}
+
+/*
+ * convertAnsibleVars converts ansible variables into hosts items,
+ * and returns variables which can't be converted.
+ */
+func convertAnsibleVars(vars map[string]string) (map[string]interface{}, []string) {
+	config := map[string]interface{}{}
+	ignored := []string{}
+	_, explicit := vars[ANSIBLE_VAR_PREFIX_CURVEADM+CONFIG_HOSTNAME.Key()]
+	for name, value := range vars {
+		key, ok := ANSIBLE_VARS[name]
+		if ok && key == CONFIG_HOSTNAME.Key() && explicit {
+			// ansible_host is the SSH address if hostname specified explicitly
+			config[CONFIG_SSH_HOSTNAME.Key()] = value
+		} else if ok {
+			config[key] = value
+		} else if strings.HasPrefix(name, ANSIBLE_VAR_PREFIX_CURVEADM) {
+			key := strings.TrimPrefix(name, ANSIBLE_VAR_PREFIX_CURVEADM)
+			if key == KEY_LABELS || key == KEY_ENVS {
+				config[key] = strings.Split(value, ANSIBLE_LIST_SPLITER)
+			} else {
+				config[key] = value
+			}
+		} else if name == ANSIBLE_VAR_CONNECTION && value == module.TRANSPORT_LOCAL {
+			config[CONFIG_TRANSPORT.Key()] = module.TRANSPORT_LOCAL
+		} else if name == ANSIBLE_VAR_CONNECTION && value == module.TRANSPORT_SSH {
+			continue
+		} else if name == ANSIBLE_VAR_SSH_ARGS || name == "ansible_ssh_extra_args" {
+			converted := false
+			if mu := sshProxyJumpRegex.FindStringSubmatch(value); len(mu) > 0 {
+				config[CONFIG_PROXY_JUMP.Key()] = mu[1]
+				converted = true
+			}
+			if mu := sshProxyCommandRegex.FindStringSubmatch(value); len(mu) > 0 {
+				config[CONFIG_PROXY_COMMAND.Key()] = mu[1] + mu[2] + mu[3]
+				converted = true
+			}
+			if !converted {
+				ignored = append(ignored, name)
+			}
+		} else {
+			ignored = append(ignored, name)
+		}
+	}
+
+	if port, ok := config[CONFIG_SSH_PORT.Key()].(string); ok {
+		if n, err := strconv.Atoi(port); err == nil {
+			config[CONFIG_SSH_PORT.Key()] = n
+		}
+	}
+	return config, ignored
+}
+
+func sortedItems(config map[string]interface{}) []hostsItem {
+	items := []hostsItem{}
+	for _, key := range HOSTS_ITEMS_ORDER {
+		if value, ok := config[key]; ok {
+			items = append(items, hostsItem{key, value})
+		}
+	}
+
+	keys := []string{}
+	for key := range config {
+		if !utils.Slice2Map(HOSTS_ITEMS_ORDER)[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		items = append(items, hostsItem{key, config[key]})
+	}
+	return items
+}
+
+func yamlValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		values := []string{}
+		for _, s := range v {
+			values = append(values, yamlValue(s))
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case string:
+		if yamlPlainRegex.MatchString(v) {
+			return v
+		}
+		return strconv.Quote(v)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+/*
+ * ToHosts converts inventory into hosts.yaml:
+ *   (1) [all:vars] -> global
+ *   (2) variables of groups and host -> items of host, the nearer group takes precedence
+ *   (3) groups which host belongs to -> groups of host
+ *
+ * The variables which can't be converted are returned for notice.
+ */
+func (inventory *AnsibleInventory) ToHosts() (string, []string) {
+	ignored := map[string]bool{}
+	out := bytes.NewBufferString("")
+
+	global, skipped := convertAnsibleVars(inventory.groupVars[ANSIBLE_GROUP_ALL])
+	for _, name := range skipped {
+		ignored[name] = true
+	}
+	if len(global) > 0 {
+		out.WriteString("global:\n")
+		for _, item := range sortedItems(global) {
+			fmt.Fprintf(out, "  %s: %s\n", item.key, yamlValue(item.value))
+		}
+		out.WriteString("\n")
+	}
+
+	out.WriteString("hosts:\n")
+	for _, host := range inventory.hosts {
+		vars := map[string]string{}
+		groups := []string{}
+		for _, group := range inventory.hostGroups(host) {
+			for k, v := range inventory.groupVars[group] {
+				vars[k] = v
+			}
+			if group != ANSIBLE_GROUP_ALL && group != ANSIBLE_GROUP_UNGROUPED {
+				groups = append(groups, group)
+			}
+		}
+		for k, v := range inventory.hostVars[host] {
+			vars[k] = v
+		}
+
+		config, skipped := convertAnsibleVars(vars)
+		for _, name := range skipped {
+			ignored[name] = true
+		}
+		config[CONFIG_HOST.Key()] = host
+		if _, ok := config[CONFIG_HOSTNAME.Key()]; !ok {
+			config[CONFIG_HOSTNAME.Key()] = host
+		}
+		if len(groups) > 0 {
+			config[KEY_GROUPS] = groups
+		}
+
+		for i, item := range sortedItems(config) {
+			prefix := "    "
+			if i == 0 {
+				prefix = "  - "
+			}
+			fmt.Fprintf(out, "%s%s: %s\n", prefix, item.key, yamlValue(item.value))
+		}
+	}
+
+	names := []string{}
+	for name := range ignored {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return out.String(), names
+}
+
+func inventoryValue(value string) string {
+	if len(value) > 0 && !strings.ContainsAny(value, " \t\"'#\\") {
+		return value
+	} else if !strings.Contains(value, "'") {
+		return "'" + value + "'"
+	}
+	return strconv.Quote(value)
+}
+
+func writeInventoryVars(out *bytes.Buffer, vars [][2]string, spliter string) {
+	for i, kv := range vars {
+		if i > 0 {
+			out.WriteString(spliter)
+		}
+		out.WriteString(kv[0] + "=" + inventoryValue(kv[1]))
+	}
+}
+
+/*
+ * ansibleVars converts hosts items into ansible variables, the password
+ * is exported only if it is a secret reference, e.g. secret://host1_password.
+ */
+func ansibleVars(config map[string]interface{}) [][2]string {
+	vars := [][2]string{}
+	add := func(name string, value interface{}) {
+		vars = append(vars, [2]string{name, utils.Atoa(value)})
+	}
+
+	sshArgs := []string{}
+	for _, item := range sortedItems(config) {
+		key, value := item.key, item.value
+		switch key {
+		case CONFIG_HOST.Key(), KEY_GROUPS:
+			continue
+		case CONFIG_HOSTNAME.Key():
+			if _, ok := config[CONFIG_SSH_HOSTNAME.Key()]; ok {
+				add(ANSIBLE_VAR_PREFIX_CURVEADM+key, value)
+			} else {
+				add(ANSIBLE_VAR_HOST, value)
+			}
+		case CONFIG_SSH_HOSTNAME.Key():
+			add(ANSIBLE_VAR_HOST, value)
+		case CONFIG_USER.Key():
+			if value != "${user}" {
+				add(ANSIBLE_VAR_USER, value)
+			}
+		case CONFIG_SSH_PORT.Key():
+			add(ANSIBLE_VAR_PORT, value)
+		case CONFIG_PRIVATE_CONFIG_FILE.Key():
+			add(ANSIBLE_VAR_PRIVATE_KEY, value)
+		case CONFIG_PASSWORD.Key():
+			if secret.IsReference(utils.Atoa(value)) {
+				add(ANSIBLE_VAR_PREFIX_CURVEADM+key, value)
+			}
+		case CONFIG_BECOME_USER.Key():
+			add(ANSIBLE_VAR_BECOME_USER, value)
+		case CONFIG_TRANSPORT.Key(), CONFIG_PROTOCOL.Key():
+			add(ANSIBLE_VAR_CONNECTION, value)
+		case CONFIG_PROXY_JUMP.Key():
+			sshArgs = append(sshArgs, "-o ProxyJump="+utils.Atoa(value))
+		case CONFIG_PROXY_COMMAND.Key():
+			sshArgs = append(sshArgs, fmt.Sprintf("-o ProxyCommand=\"%s\"", value))
+		case KEY_LABELS, KEY_ENVS:
+			values := []string{}
+			if slice, ok := value.([]interface{}); ok {
+				for _, v := range slice {
+					values = append(values, utils.Atoa(v))
+				}
+			}
+			add(ANSIBLE_VAR_PREFIX_CURVEADM+key, strings.Join(values, ANSIBLE_LIST_SPLITER))
+		default:
+			add(ANSIBLE_VAR_PREFIX_CURVEADM+key, value)
+		}
+	}
+	if len(sshArgs) > 0 {
+		add(ANSIBLE_VAR_SSH_ARGS, strings.Join(sshArgs, " "))
+	}
+	return vars
+}
+
+/*
+ * FormatAnsibleInventory converts hosts.yaml into INI inventory:
+ *
+ *   host1 ansible_host=10.0.0.1 ansible_port=22
+ *   host2 ansible_host=10.0.0.2 ansible_port=22
+ *
+ *   [rack1]
+ *   host1
+ *
+ *   [all:vars]
+ *   ansible_user=curve
+ */
+func FormatAnsibleInventory(data string) (string, error) {
+	if len(data) == 0 {
+		return "", errno.ERR_EMPTY_HOSTS
+	}
+	parser := viper.NewWithOptions(viper.KeyDelimiter("::"))
+	parser.SetConfigType("yaml")
+	err := parser.ReadConfig(bytes.NewBuffer([]byte(data)))
+	if err != nil {
+		return "", errno.ERR_PARSE_HOSTS_FAILED.E(err)
+	}
+	hosts := &Hosts{}
+	if err := parser.Unmarshal(hosts); err != nil {
+		return "", errno.ERR_PARSE_HOSTS_FAILED.E(err)
+	}
+
+	out := bytes.NewBufferString("# converted from hosts of curveadm, plain passwords are not exported\n")
+	groups := []string{}
+	members := map[string][]string{}
+	for i, config := range hosts.Host {
+		host := utils.Atoa(config[CONFIG_HOST.Key()])
+		if len(host) == 0 {
+			return "", errno.ERR_HOST_FIELD_MISSING.F("hosts[%d].host = nil", i)
+		}
+		out.WriteString(host)
+		if vars := ansibleVars(config); len(vars) > 0 {
+			out.WriteString(" ")
+			writeInventoryVars(out, vars, " ")
+		}
+		out.WriteString("\n")
+
+		slice, _ := config[KEY_GROUPS].([]interface{})
+		for _, value := range slice {
+			group := utils.Atoa(value)
+			groups = appendIfAbsent(groups, group)
+			members[group] = append(members[group], host)
+		}
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(out, "\n[%s]\n%s\n", group, strings.Join(members[group], "\n"))
+	}
+	if vars := ansibleVars(hosts.Global); len(vars) > 0 {
+		fmt.Fprintf(out, "\n[%s%s]\n", ANSIBLE_GROUP_ALL, ANSIBLE_SECTION_VARS)
+		writeInventoryVars(out, vars, "\n")
+		out.WriteString("\n")
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,162 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ANSIBLE_INVENTORY = `
+# curve cluster
+local ansible_connection=local
+
+[rack1]
+host1 ansible_host=10.0.0.1
+host2 ansible_host=10.0.0.2 ansible_user=admin
+
+[rack2]
+host3:2222 ansible_host=10.0.0.3 ansible_ssh_common_args='-o ProxyJump=jump@bastion:22'
+
+[storage:children]
+rack1
+rack2
+
+[storage:vars]
+curveadm_labels=ssd,nvme
+foo=bar
+
+[all:vars]
+ansible_user=curve
+ansible_ssh_private_key_file=/home/curve/.ssh/id_rsa
+`
+)
+
+func TestSplitInventoryLine(t *testing.T) {
+	assert := assert.New(t)
+
+	words, err := splitInventoryLine(`host1 a=1 b="x y" c='-o "z"' d=e\ f # comment`)
+	assert.Nil(err)
+	assert.Equal([]string{"host1", "a=1", "b=x y", `c=-o "z"`, "d=e f"}, words)
+
+	_, err = splitInventoryLine(`host1 a="x`)
+	assert.NotNil(err)
+}
+
+func TestAnsibleInventory_ToHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	inventory, err := ParseAnsibleInventory(ANSIBLE_INVENTORY)
+	assert.Nil(err)
+	data, ignored := inventory.ToHosts()
+	assert.Equal([]string{"foo"}, ignored)
+	assert.Equal(`global:
+  user: curve
+  private_key_file: /home/curve/.ssh/id_rsa
+
+hosts:
+  - host: local
+    hostname: local
+    transport: local
+  - host: host1
+    hostname: 10.0.0.1
+    groups: [storage, rack1]
+    labels: [ssd, nvme]
+  - host: host2
+    hostname: 10.0.0.2
+    user: admin
+    groups: [storage, rack1]
+    labels: [ssd, nvme]
+  - host: host3
+    hostname: 10.0.0.3
+    ssh_port: 2222
+    proxy_jump: jump@bastion:22
+    groups: [storage, rack2]
+    labels: [ssd, nvme]
+`, data)
+
+	// host range
+	_, err = ParseAnsibleInventory("[web]\nweb[01:50]\n")
+	assert.NotNil(err)
+	_, err = ParseAnsibleInventory("[web:vars]\nansible_user=curve\n")
+	assert.NotNil(err)
+}
+
+func TestFormatAnsibleInventory(t *testing.T) {
+	assert := assert.New(t)
+
+	hosts := `
+global:
+  user: curve
+  ssh_port: 22
+
+hosts:
+  - host: host1
+    hostname: 10.0.0.1
+    groups: [rack1]
+  - host: host2
+    hostname: 10.0.0.2
+    ssh_hostname: 192.168.0.2
+    password: secret://host2_password
+    proxy_command: ssh -W %h:%p bastion
+    container_engine: podman
+    groups: [rack1, rack2]
+  - host: host3
+    hostname: 10.0.0.3
+    password: plain
+`
+	data, err := FormatAnsibleInventory(hosts)
+	assert.Nil(err)
+	assert.Equal(`# converted from hosts of curveadm, plain passwords are not exported
+host1 ansible_host=10.0.0.1
+host2 curveadm_hostname=10.0.0.2 ansible_host=192.168.0.2 curveadm_password=secret://host2_password curveadm_container_engine=podman ansible_ssh_common_args='-o ProxyCommand="ssh -W %h:%p bastion"'
+host3 ansible_host=10.0.0.3
+
+[rack1]
+host1
+host2
+
+[rack2]
+host2
+
+[all:vars]
+ansible_user=curve
+ansible_port=22
+`, data)
+
+	// convert back
+	inventory, err := ParseAnsibleInventory(data)
+	assert.Nil(err)
+	data, ignored := inventory.ToHosts()
+	assert.Len(ignored, 0)
+	assert.Contains(data, `  - host: host2
+    hostname: 10.0.0.2
+    ssh_hostname: 192.168.0.2
+    password: secret://host2_password
+    proxy_command: "ssh -W %h:%p bastion"
+    container_engine: podman
+    groups: [rack1, rack2]
+`)
+}
@@ -67,15 +67,46 @@ func (hc *HostConfig) getBool(i *comm.Item) bool {
 	return v.(bool)
 }
 
-func (hc *HostConfig) GetHost() string           { return hc.getString(CONFIG_HOST) }
-func (hc *HostConfig) GetHostname() string       { return hc.getString(CONFIG_HOSTNAME) }
-func (hc *HostConfig) GetSSHHostname() string    { return hc.getString(CONFIG_SSH_HOSTNAME) }
-func (hc *HostConfig) GetSSHPort() int           { return hc.getInt(CONFIG_SSH_PORT) }
-func (hc *HostConfig) GetPrivateKeyFile() string { return hc.getString(CONFIG_PRIVATE_CONFIG_FILE) }
-func (hc *HostConfig) GetForwardAgent() bool     { return hc.getBool(CONFIG_FORWARD_AGENT) }
-func (hc *HostConfig) GetBecomeUser() string     { return hc.getString(CONFIG_BECOME_USER) }
-func (hc *HostConfig) GetLabels() []string       { return hc.labels }
-func (hc *HostConfig) GetEnvs() []string         { return hc.envs }
+func (hc *HostConfig) GetHost() string            { return hc.getString(CONFIG_HOST) }
+func (hc *HostConfig) GetHostname() string        { return hc.getString(CONFIG_HOSTNAME) }
+func (hc *HostConfig) GetSSHHostname() string     { return hc.getString(CONFIG_SSH_HOSTNAME) }
+func (hc *HostConfig) GetSSHPort() int            { return hc.getInt(CONFIG_SSH_PORT) }
+func (hc *HostConfig) GetPrivateKeyFile() string  { return hc.getString(CONFIG_PRIVATE_CONFIG_FILE) }
+func (hc *HostConfig) GetPassword() string        { return hc.getString(CONFIG_PASSWORD) }
+func (hc *HostConfig) GetCertificateFile() string { return hc.getString(CONFIG_CERTIFICATE_FILE) }
+func (hc *HostConfig) GetIdentityAgent() string   { return hc.getString(CONFIG_IDENTITY_AGENT) }
+func (hc *HostConfig) GetForwardAgent() bool      { return hc.getBool(CONFIG_FORWARD_AGENT) }
+func (hc *HostConfig) GetProxyJump() string       { return hc.getString(CONFIG_PROXY_JUMP) }
+func (hc *HostConfig) GetProxyCommand() string    { return hc.getString(CONFIG_PROXY_COMMAND) }
+func (hc *HostConfig) GetBecomeUser() string      { return hc.getString(CONFIG_BECOME_USER) }
+func (hc *HostConfig) GetContainerEngine() string { return hc.getString(CONFIG_CONTAINER_ENGINE) }
+func (hc *HostConfig) GetLabels() []string        { return hc.labels }
+func (hc *HostConfig) GetEnvs() []string          { return hc.envs }
+func (hc *HostConfig) GetGroups() []string        { return hc.groups }
+
+func (hc *HostConfig) GetProtocol() string { return hc.getString(CONFIG_PROTOCOL) }
+
+// proxy of host overrides the global one in curveadm.cfg field by field
+func (hc *HostConfig) GetHTTPProxyConfig() module.HTTPProxyConfig {
+	proxy := module.HTTPProxyConfig{
+		HTTPProxy:  hc.getString(CONFIG_HTTP_PROXY),
+		HTTPSProxy: hc.getString(CONFIG_HTTPS_PROXY),
+		NoProxy:    hc.getString(CONFIG_NO_PROXY),
+	}
+	if curveadm.GlobalCurveAdmConfig == nil {
+		return proxy
+	}
+	return proxy.Merge(curveadm.GlobalCurveAdmConfig.GetHTTPProxyConfig())
+}
+
+// protocol takes effect if transport not specified explicitly
+func (hc *HostConfig) GetTransport() string {
+	_, explicit := hc.config[CONFIG_TRANSPORT.Key()]
+	if protocol := hc.GetProtocol(); len(protocol) > 0 && !explicit {
+		return protocol
+	}
+	return hc.getString(CONFIG_TRANSPORT)
+}
 
 func (hc *HostConfig) GetUser() string {
 	user := hc.getString(CONFIG_USER)
@@ -95,11 +126,19 @@ func (hc *HostConfig) GetSSHConfig() *module.SSHConfig {
 		Host:              hostname,
 		Port:              (uint)(hc.GetSSHPort()),
 		PrivateKeyPath:    hc.GetPrivateKeyFile(),
+		Password:          hc.GetPassword(),
+		CertificatePath:   hc.GetCertificateFile(),
+		IdentityAgent:     hc.GetIdentityAgent(),
 		ForwardAgent:      hc.GetForwardAgent(),
 		BecomeMethod:      "sudo",
 		BecomeFlags:       "-iu",
 		BecomeUser:        hc.GetBecomeUser(),
 		ConnectTimeoutSec: curveadm.GlobalCurveAdmConfig.GetSSHTimeout(),
 		ConnectRetries:    curveadm.GlobalCurveAdmConfig.GetSSHRetries(),
+		Transport:         hc.GetTransport(),
+		ContainerEngine:   hc.GetContainerEngine(),
+		ProxyJump:         hc.GetProxyJump(),
+		ProxyCommand:      hc.GetProxyCommand(),
+		HTTPProxy:         hc.GetHTTPProxyConfig(),
 	}
 }
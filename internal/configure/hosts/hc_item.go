@@ -29,10 +29,12 @@ import (
 
 	comm "github.com/opencurve/curveadm/internal/configure/common"
 	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
 )
 
 const (
-	DEFAULT_SSH_PORT = 22
+	DEFAULT_SSH_PORT       = 22
+	DEFAULT_LOCAL_HOSTNAME = "127.0.0.1"
 )
 
 var (
@@ -49,7 +51,12 @@ var (
 		"hostname",
 		comm.REQUIRE_STRING,
 		false,
-		nil,
+		func(hc *HostConfig) interface{} {
+			if hc.GetTransport() == module.TRANSPORT_LOCAL {
+				return DEFAULT_LOCAL_HOSTNAME
+			}
+			return nil
+		},
 	)
 
 	CONFIG_SSH_HOSTNAME = itemset.Insert(
@@ -84,6 +91,28 @@ var (
 		},
 	)
 
+	// SSH password, it should be a secret reference (e.g. secret://host1_password)
+	CONFIG_PASSWORD = itemset.Insert(
+		"password",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	CONFIG_CERTIFICATE_FILE = itemset.Insert(
+		"certificate_file",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	CONFIG_IDENTITY_AGENT = itemset.Insert(
+		"identity_agent",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
 	CONFIG_FORWARD_AGENT = itemset.Insert(
 		"forward_agent",
 		comm.REQUIRE_BOOL,
@@ -91,10 +120,69 @@ var (
 		false,
 	)
 
+	CONFIG_PROXY_JUMP = itemset.Insert(
+		"proxy_jump",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	CONFIG_PROXY_COMMAND = itemset.Insert(
+		"proxy_command",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	// proxy for image pulls and artifact downloads, [proxy] in curveadm.cfg if empty
+	CONFIG_HTTP_PROXY = itemset.Insert(
+		"http_proxy",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	CONFIG_HTTPS_PROXY = itemset.Insert(
+		"https_proxy",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	CONFIG_NO_PROXY = itemset.Insert(
+		"no_proxy",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
 	CONFIG_BECOME_USER = itemset.Insert(
 		"become_user",
 		comm.REQUIRE_STRING,
 		false,
 		nil,
 	)
+
+	// ssh or local, the local host needn't hostname and SSH private key
+	CONFIG_TRANSPORT = itemset.Insert(
+		"transport",
+		comm.REQUIRE_STRING,
+		false,
+		module.TRANSPORT_SSH,
+	)
+
+	// alias of transport: ssh, local
+	CONFIG_PROTOCOL = itemset.Insert(
+		"protocol",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	CONFIG_CONTAINER_ENGINE = itemset.Insert(
+		"container_engine",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
 )
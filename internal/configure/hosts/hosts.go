@@ -29,15 +29,25 @@ import (
 	"strings"
 
 	"github.com/opencurve/curveadm/internal/build"
+	comm "github.com/opencurve/curveadm/internal/configure/common"
 	"github.com/opencurve/curveadm/internal/configure/os"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
 	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
 	"github.com/spf13/viper"
 )
 
 const (
 	KEY_LABELS = "labels"
 	KEY_ENVS   = "envs"
+	KEY_GROUPS = "groups"
+
+	// --host @rack1,host2: all hosts in group rack1 plus host2
+	HOST_GROUP_PREFIX    = "@"
+	HOST_PATTERN_SPLITER = ","
+	HOST_PATTERN_ALL     = "*"
+	HOST_GROUP_ALL       = "all" // implicit group which all hosts belong to
 
 	PERMISSIONS_600 = 384 // -rw------- (256 + 128 = 384)
 )
@@ -53,6 +63,7 @@ type (
 		config   map[string]interface{}
 		labels   []string
 		envs     []string
+		groups   []string
 	}
 )
 
@@ -111,6 +122,29 @@ func (hc *HostConfig) convertEnvs() error {
 	return nil
 }
 
+func (hc *HostConfig) convertGroups() error {
+	value := hc.config[KEY_GROUPS]
+	slice, ok := (value).([]interface{})
+	if !ok {
+		return errno.ERR_CONFIGURE_VALUE_REQUIRES_STRING_SLICE.
+			F("hosts[%d].%s = %v", hc.sequence, KEY_GROUPS, value)
+	}
+
+	for _, value := range slice {
+		v, ok := utils.All2Str(value)
+		if !ok {
+			return errno.ERR_CONFIGURE_VALUE_REQUIRES_STRING_SLICE.
+				F("hosts[%d].%s = %v", hc.sequence, KEY_GROUPS, value)
+		} else if len(v) == 0 || strings.ContainsAny(v, HOST_GROUP_PREFIX+HOST_PATTERN_SPLITER+HOST_PATTERN_ALL+" ") {
+			return errno.ERR_INVALID_HOST_GROUP_NAME.
+				F("hosts[%d].%s = %v", hc.sequence, KEY_GROUPS, v)
+		}
+		hc.groups = append(hc.groups, v)
+	}
+
+	return nil
+}
+
 func (hc *HostConfig) Build() error {
 	for key, value := range hc.config {
 		if key == KEY_LABELS { // convert labels
@@ -125,6 +159,12 @@ func (hc *HostConfig) Build() error {
 			}
 			hc.config[key] = nil // delete labels section
 			continue
+		} else if key == KEY_GROUPS { // convert groups
+			if err := hc.convertGroups(); err != nil {
+				return err
+			}
+			hc.config[key] = nil // delete groups section
+			continue
 		}
 
 		if itemset.Get(key) == nil {
@@ -135,9 +175,12 @@ func (hc *HostConfig) Build() error {
 		v, err := itemset.Build(key, value)
 		if err != nil {
 			return err
-		} else {
-			hc.config[key] = v
+		} else if str, ok := v.(string); ok && secret.IsReference(str) {
+			if v, err = secret.Resolve(str); err != nil {
+				return err
+			}
 		}
+		hc.config[key] = v
 	}
 
 	privateKeyFile := hc.GetPrivateKeyFile()
@@ -156,9 +199,50 @@ func (hc *HostConfig) Build() error {
 	} else if !strings.HasPrefix(privateKeyFile, "/") {
 		return errno.ERR_PRIVATE_KEY_FILE_REQUIRE_ABSOLUTE_PATH.
 			F("hosts[%d].private_key_file = %s", hc.sequence, privateKeyFile)
+	} else if protocol := hc.GetProtocol(); len(protocol) > 0 && protocol != hc.GetTransport() {
+		return errno.ERR_PROTOCOL_CONFLICT_WITH_TRANSPORT.
+			F("hosts[%d].protocol = %s, hosts[%d].transport = %s",
+				hc.sequence, protocol, hc.sequence, hc.GetTransport())
+	} else if !utils.Slice2Map(module.TRANSPORTS)[hc.GetTransport()] {
+		return errno.ERR_UNSUPPORT_HOST_TRANSPORT.
+			F("hosts[%d].transport = %s", hc.sequence, hc.GetTransport())
+	} else if engine := hc.GetContainerEngine(); len(engine) > 0 &&
+		engine != module.ENGINE_AUTO && !utils.Slice2Map(module.CONTAINER_ENGINES)[engine] {
+		return errno.ERR_UNSUPPORT_HOST_CONTAINER_ENGINE.
+			F("hosts[%d].container_engine = %s", hc.sequence, engine)
+	} else if len(hc.GetProxyJump()) > 0 && len(hc.GetProxyCommand()) > 0 {
+		return errno.ERR_PROXY_JUMP_CONFLICT_WITH_PROXY_COMMAND.
+			F("hosts[%d]: proxy_jump and proxy_command can't be both specified", hc.sequence)
+	} else if _, err := module.ParseProxyJump(hc.GetProxyJump(), hc.GetUser()); err != nil {
+		return errno.ERR_INVALID_PROXY_JUMP.
+			F("hosts[%d].proxy_jump = %s: %v", hc.sequence, hc.GetProxyJump(), err)
+	}
+	for _, item := range []*comm.Item{CONFIG_HTTP_PROXY, CONFIG_HTTPS_PROXY} {
+		if err := module.ValidateHTTPProxy(hc.getString(item)); err != nil {
+			return errno.ERR_INVALID_HOST_HTTP_PROXY.
+				F("hosts[%d].%s = %s: %v", hc.sequence, item.Key(), hc.getString(item), err)
+		}
 	}
 
-	if hc.GetForwardAgent() == false {
+	if hc.GetTransport() == module.TRANSPORT_LOCAL { // needn't SSH private key
+		return nil
+	}
+
+	certificateFile := hc.GetCertificateFile()
+	if len(certificateFile) > 0 {
+		if !strings.HasPrefix(certificateFile, "/") {
+			return errno.ERR_CERTIFICATE_FILE_REQUIRE_ABSOLUTE_PATH.
+				F("hosts[%d].certificate_file = %s", hc.sequence, certificateFile)
+		} else if !utils.PathExist(certificateFile) {
+			return errno.ERR_CERTIFICATE_FILE_NOT_EXIST.
+				F("%s: no such file", certificateFile)
+		}
+	}
+
+	if agent := hc.GetIdentityAgent(); len(agent) > 0 && !hc.GetForwardAgent() {
+		return errno.ERR_IDENTITY_AGENT_REQUIRES_FORWARD_AGENT.
+			F("hosts[%d].identity_agent = %s", hc.sequence, agent)
+	} else if hc.GetForwardAgent() == false && len(hc.GetPassword()) == 0 {
 		if !utils.PathExist(privateKeyFile) {
 			return errno.ERR_PRIVATE_KEY_FILE_NOT_EXIST.
 				F("%s: no such file", privateKeyFile)
@@ -175,6 +259,7 @@ func NewHostConfig(sequence int, config map[string]interface{}) *HostConfig {
 		sequence: sequence,
 		config:   config,
 		labels:   []string{},
+		groups:   []string{},
 	}
 }
 
@@ -216,3 +301,54 @@ func ParseHosts(data string) ([]*HostConfig, error) {
 	build.DEBUG(build.DEBUG_HOSTS, hosts)
 	return hcs, nil
 }
+
+func IsHostPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, HOST_GROUP_PREFIX) ||
+		strings.Contains(pattern, HOST_PATTERN_SPLITER)
+}
+
+/*
+ * ExpandHosts expands the host pattern to member hosts in order of hosts.yaml:
+ *   *             all hosts
+ *   host1         the host itself
+ *   @rack1        all hosts belong to group rack1
+ *   @all          all hosts, every host belongs to the implicit group "all"
+ *   @rack1,host2  union of above, separated by comma
+ */
+func ExpandHosts(hcs []*HostConfig, pattern string) ([]string, error) {
+	selected := map[string]bool{}
+	for _, item := range strings.Split(pattern, HOST_PATTERN_SPLITER) {
+		item = strings.TrimSpace(item)
+		found := false
+		for _, hc := range hcs {
+			match := false
+			if item == HOST_PATTERN_ALL || item == HOST_GROUP_PREFIX+HOST_GROUP_ALL {
+				match = true
+			} else if strings.HasPrefix(item, HOST_GROUP_PREFIX) {
+				match = utils.Slice2Map(hc.GetGroups())[item[len(HOST_GROUP_PREFIX):]]
+			} else {
+				match = hc.GetHost() == item
+			}
+			if match {
+				selected[hc.GetHost()] = true
+				found = true
+			}
+		}
+
+		if found || item == HOST_PATTERN_ALL {
+			continue
+		} else if strings.HasPrefix(item, HOST_GROUP_PREFIX) {
+			return nil, errno.ERR_HOST_GROUP_NOT_FOUND.
+				F("group: %s", item[len(HOST_GROUP_PREFIX):])
+		}
+		return nil, errno.ERR_HOST_NOT_FOUND.F("host: %s", item)
+	}
+
+	hosts := []string{}
+	for _, hc := range hcs {
+		if selected[hc.GetHost()] {
+			hosts = append(hosts, hc.GetHost())
+		}
+	}
+	return hosts, nil
+}
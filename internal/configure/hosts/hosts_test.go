@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package hosts
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/curveadm"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHosts_LocalProtocol(t *testing.T) {
+	assert := assert.New(t)
+
+	hcs, err := ParseHosts(`
+hosts:
+  - host: local1
+    protocol: local
+  - host: local2
+    hostname: 10.0.0.1
+    transport: local
+`)
+	assert.Nil(err)
+	assert.Len(hcs, 2)
+	assert.Equal(module.TRANSPORT_LOCAL, hcs[0].GetTransport())
+	assert.Equal(DEFAULT_LOCAL_HOSTNAME, hcs[0].GetHostname())
+	assert.Equal(module.TRANSPORT_LOCAL, hcs[1].GetTransport())
+	assert.Equal("10.0.0.1", hcs[1].GetHostname())
+
+	// conflict
+	_, err = ParseHosts(`
+hosts:
+  - host: local1
+    protocol: local
+    transport: ssh
+`)
+	assert.NotNil(err)
+
+	// unsupported protocol
+	_, err = ParseHosts(`
+hosts:
+  - host: local1
+    hostname: 10.0.0.1
+    protocol: telnet
+`)
+	assert.NotNil(err)
+}
+
+func TestParseHosts_HTTPProxy(t *testing.T) {
+	assert := assert.New(t)
+	curveadm.ReplaceGlobals(&curveadm.CurveAdmConfig{
+		HTTPProxy: module.HTTPProxyConfig{
+			HTTPProxy:  "http://global:3128",
+			HTTPSProxy: "http://global:3128",
+			NoProxy:    "localhost",
+		},
+	})
+	defer curveadm.ReplaceGlobals(nil)
+
+	// proxy of host overrides the global one field by field
+	hcs, err := ParseHosts(`
+hosts:
+  - host: local1
+    protocol: local
+    https_proxy: http://host1:3128
+  - host: local2
+    protocol: local
+`)
+	assert.Nil(err)
+	assert.Equal(module.HTTPProxyConfig{
+		HTTPProxy:  "http://global:3128",
+		HTTPSProxy: "http://host1:3128",
+		NoProxy:    "localhost",
+	}, hcs[0].GetSSHConfig().HTTPProxy)
+	assert.Equal("http://global:3128", hcs[1].GetSSHConfig().HTTPProxy.HTTPSProxy)
+
+	_, err = ParseHosts(`
+hosts:
+  - host: local1
+    protocol: local
+    http_proxy: socks5://host1:1080
+`)
+	assert.ErrorIs(err, errno.ERR_INVALID_HOST_HTTP_PROXY)
+}
+
+type fakeResolver map[string]string
+
+func (r fakeResolver) Get(name string) (string, error) {
+	return r[name], nil
+}
+
+func TestParseHosts_SecretPassword(t *testing.T) {
+	assert := assert.New(t)
+	secret.ReplaceGlobals(fakeResolver{"host1_password": "p@ss"})
+	defer secret.ReplaceGlobals(nil)
+
+	// private key is not required if password specified
+	hcs, err := ParseHosts(`
+hosts:
+  - host: host1
+    hostname: 10.0.0.1
+    private_key_file: /not/exist/id_rsa
+    password: secret://host1_password
+`)
+	assert.Nil(err)
+	assert.Len(hcs, 1)
+	assert.Equal("p@ss", hcs[0].GetPassword())
+}
@@ -33,6 +33,8 @@ import (
 	"github.com/opencurve/curveadm/internal/configure/hosts"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/viper"
 )
 
@@ -47,6 +49,7 @@ const (
 	KEY_RETENTION_TIME    = "retention.time"
 	KEY_RETENTION_SIZE    = "retention.size"
 	KEY_PROMETHEUS_TARGET = "target"
+	KEY_PROMETHEUS_TLS    = "tls"
 	KEY_GRAFANA_USER      = "username"
 	KEY_GRAFANA_PASSWORD  = "password"
 
@@ -54,6 +57,9 @@ const (
 	KRY_NODE_LISTEN_PORT = "node_listen_port"
 	KEY_PROMETHEUS_IP    = "prometheus_listen_ip"
 	KEY_PROMETHEUS_PORT  = "prometheus_listen_port"
+
+	// the label which overrides scheme of targets in file_sd_configs
+	LABEL_SCHEME = "__scheme__"
 )
 
 type monitor struct {
@@ -64,12 +70,13 @@ type monitor struct {
 }
 
 type MonitorConfig struct {
-	kind   string
-	id     string // role_host
-	role   string
-	host   string
-	config map[string]interface{}
-	ctx    *topology.Context
+	kind        string // kind of monitor image
+	clusterKind string
+	id          string // role_host
+	role        string
+	host        string
+	config      map[string]interface{}
+	ctx         *topology.Context
 }
 
 type serviceTarget struct {
@@ -111,6 +118,10 @@ func (m *MonitorConfig) GetKind() string {
 	return m.kind
 }
 
+func (m *MonitorConfig) GetClusterKind() string {
+	return m.clusterKind
+}
+
 func (m *MonitorConfig) GetId() string {
 	return m.id
 }
@@ -163,6 +174,12 @@ func (m *MonitorConfig) GetPrometheusTarget() string {
 	return m.getString(&m.config, KEY_PROMETHEUS_TARGET)
 }
 
+// GetPrometheusTLSEnable returns true if prometheus scrapes cluster with TLS
+func (m *MonitorConfig) GetPrometheusTLSEnable() bool {
+	v, ok := m.config[KEY_PROMETHEUS_TLS].(bool)
+	return ok && v
+}
+
 func (m *MonitorConfig) GetPrometheusIp() string {
 	return m.getString(&m.config, KEY_PROMETHEUS_IP)
 }
@@ -206,21 +223,25 @@ func parsePrometheusTarget(dcs []*topology.DeployConfig) (string, error) {
 		var item string
 		switch role {
 		case topology.ROLE_ETCD:
-			item = fmt.Sprintf("%s:%d", ip, dc.GetListenClientPort())
+			item = utils.JoinHostPort(ip, dc.GetListenClientPort())
 		case topology.ROLE_MDS,
 			topology.ROLE_CHUNKSERVER,
 			topology.ROLE_METASERVER:
-			item = fmt.Sprintf("%s:%d", ip, dc.GetListenPort())
+			item = utils.JoinHostPort(ip, dc.GetListenPort())
 		case topology.ROLE_SNAPSHOTCLONE:
-			item = fmt.Sprintf("%s:%d", ip, dc.GetListenDummyPort())
+			item = utils.JoinHostPort(ip, dc.GetListenDummyPort())
 		}
 		if _, ok := tMap[role]; ok {
 			t := tMap[role]
 			t.Targets = append(t.Targets, item)
 			tMap[role] = t
 		} else {
+			labels := map[string]string{"job": role}
+			if role == topology.ROLE_ETCD && dc.GetTLSEnable() { // etcd serves metrics on client port
+				labels[LABEL_SCHEME] = dc.GetEtcdScheme()
+			}
 			tMap[role] = serviceTarget{
-				Labels:  map[string]string{"job": role},
+				Labels:  labels,
 				Targets: []string{item},
 			}
 		}
@@ -257,6 +278,13 @@ func ParseMonitorConfig(curveadm *cli.CurveAdm, filename string, data string, hs
 	if err := parser.Unmarshal(&config); err != nil {
 		return nil, errno.ERR_PARSE_MONITOR_CONFIGURE_FAILED.E(err)
 	}
+	if password, ok := config.Grafana[KEY_GRAFANA_PASSWORD].(string); ok {
+		realPassword, err := secret.Resolve(password)
+		if err != nil {
+			return nil, err
+		}
+		config.Grafana[KEY_GRAFANA_PASSWORD] = realPassword
+	}
 
 	// get host -> hostname(ip)
 	ctx := topology.NewContext()
@@ -268,19 +296,26 @@ func ParseMonitorConfig(curveadm *cli.CurveAdm, filename string, data string, hs
 		ctx.Add(hc.GetHost(), hc.GetHostname())
 	}
 
+	// the monitor config is shipped in the image of first service,
+	// so its kind goes with the image rather than the cluster
 	mkind := dcs[0].GetKind()
 	mconfImage := dcs[0].GetContainerImage()
+	ckind := topology.GetKind(dcs)
+	// only deploy the components which configured in monitor.yaml
 	roles := []string{}
-	switch {
-	case config.NodeExporter != nil:
+	if config.NodeExporter != nil {
 		roles = append(roles, ROLE_NODE_EXPORTER)
-		fallthrough
-	case config.Prometheus != nil:
+	}
+	if config.Prometheus != nil {
 		roles = append(roles, ROLE_PROMETHEUS)
-		fallthrough
-	case config.Grafana != nil:
+	}
+	if config.Grafana != nil {
 		roles = append(roles, ROLE_GRAFANA)
 	}
+	if len(roles) == 0 {
+		return nil, errno.ERR_PARSE_MONITOR_CONFIGURE_FAILED.
+			F("no monitor component (node_exporter/prometheus/grafana) configured")
+	}
 	ret := []*MonitorConfig{}
 	for _, role := range roles {
 		host := getHost(&config, role)
@@ -295,13 +330,15 @@ func ParseMonitorConfig(curveadm *cli.CurveAdm, filename string, data string, hs
 				config.Prometheus[KRY_NODE_LISTEN_PORT] = config.NodeExporter[KEY_LISTEN_PORT]
 			}
 			config.Prometheus[KEY_PROMETHEUS_TARGET] = target
+			config.Prometheus[KEY_PROMETHEUS_TLS] = dcs[0].GetTLSEnable()
 			ret = append(ret, &MonitorConfig{
-				kind:   mkind,
-				id:     fmt.Sprintf("%s_%s", role, host),
-				role:   role,
-				host:   host,
-				config: config.Prometheus,
-				ctx:    ctx,
+				kind:        mkind,
+				clusterKind: ckind,
+				id:          fmt.Sprintf("%s_%s", role, host),
+				role:        role,
+				host:        host,
+				config:      config.Prometheus,
+				ctx:         ctx,
 			})
 		case ROLE_GRAFANA:
 			if config.Prometheus != nil {
@@ -309,17 +346,19 @@ func ParseMonitorConfig(curveadm *cli.CurveAdm, filename string, data string, hs
 				config.Grafana[KEY_PROMETHEUS_IP] = ctx.Lookup(config.Prometheus[KEY_HOST].(string))
 			}
 			ret = append(ret, &MonitorConfig{
-				kind:   mkind,
-				id:     fmt.Sprintf("%s_%s", role, host),
-				role:   role,
-				host:   host,
-				config: config.Grafana,
-				ctx:    ctx,
+				kind:        mkind,
+				clusterKind: ckind,
+				id:          fmt.Sprintf("%s_%s", role, host),
+				role:        role,
+				host:        host,
+				config:      config.Grafana,
+				ctx:         ctx,
 			}, &MonitorConfig{
-				kind: mkind,
-				id:   fmt.Sprintf("%s_%s", ROLE_MONITOR_CONF, host),
-				role: ROLE_MONITOR_CONF,
-				host: host,
+				kind:        mkind,
+				clusterKind: ckind,
+				id:          fmt.Sprintf("%s_%s", ROLE_MONITOR_CONF, host),
+				role:        ROLE_MONITOR_CONF,
+				host:        host,
 				config: map[string]interface{}{
 					KEY_CONTAINER_IMAGE: mconfImage,
 				},
@@ -328,12 +367,13 @@ func ParseMonitorConfig(curveadm *cli.CurveAdm, filename string, data string, hs
 		case ROLE_NODE_EXPORTER:
 			for _, h := range hs {
 				ret = append(ret, &MonitorConfig{
-					kind:   mkind,
-					id:     fmt.Sprintf("%s_%s", role, h),
-					role:   role,
-					host:   h,
-					config: config.NodeExporter,
-					ctx:    ctx,
+					kind:        mkind,
+					clusterKind: ckind,
+					id:          fmt.Sprintf("%s_%s", role, h),
+					role:        role,
+					host:        h,
+					config:      config.NodeExporter,
+					ctx:         ctx,
 				})
 			}
 		}
@@ -0,0 +1,320 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"bytes"
+	"path/filepath"
+	"text/template"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+const (
+	OPERATOR_API_VERSION       = "operator.curve.io/v1"
+	DEFAULT_OPERATOR_NAMESPACE = "curve"
+	// percentage of disk formatted into chunkfile pool
+	DEFAULT_OPERATOR_DEVICE_PERCENTAGE = 90
+
+	TEMPLATE_OPERATOR_HEADER = `# Generated by curveadm from cluster '{{.Cluster}}' for curve-operator, apply it by:
+#   $ kubectl apply -f {{.Cluster}}.yaml
+#
+# The nodes are named by hosts of curveadm, please make sure they
+# are same as the kubernetes node names before applying.
+`
+
+	TEMPLATE_OPERATOR_CURVEBS = `apiVersion: {{.APIVersion}}
+kind: CurveCluster
+metadata:
+  name: {{.Cluster}}
+  namespace: {{.Namespace}}
+spec:
+  curveVersion:
+    image: {{.Image}}
+    imagePullPolicy: IfNotPresent
+  nodes:
+{{- range .Nodes}}
+    - {{.}}
+{{- end}}
+  dataDirHostPath: {{.DataDir}}
+  logDirHostPath: {{.LogDir}}
+  etcd:
+    peerPort: {{.Etcd.Port}}
+    clientPort: {{.Etcd.ClientPort}}
+  mds:
+    port: {{.MDS.Port}}
+    dummyPort: {{.MDS.DummyPort}}
+  storage:
+    useSelectedNodes: {{.UseSelectedNodes}}
+    nodes:
+{{- range .StorageNodes}}
+      - {{.}}
+{{- end}}
+    port: {{.Storage.Port}}
+    copySets: {{.Storage.Copysets}}
+    devices:
+{{- range .Devices}}
+{{- if .Name}}
+      - name: {{.Name}}
+{{- else}}
+      - name: "" # please fill the block device mounted on the path
+{{- end}}
+        mountPath: {{.MountPath}}
+        percentage: {{.Percentage}}
+{{- end}}
+  snapShotClone:
+    enable: {{.SnapshotClone.Enable}}
+{{- if .SnapshotClone.Enable}}
+    port: {{.SnapshotClone.Port}}
+    dummyPort: {{.SnapshotClone.DummyPort}}
+    proxyPort: {{.SnapshotClone.ProxyPort}}
+    s3Config:
+      ak: "{{.SnapshotClone.S3AccessKey}}"
+      sk: "{{.SnapshotClone.S3SecretKey}}"
+      nosAddress: "{{.SnapshotClone.S3Address}}"
+      snapShotBucketName: "{{.SnapshotClone.S3Bucket}}"
+{{- end}}
+`
+
+	TEMPLATE_OPERATOR_CURVEFS = `apiVersion: {{.APIVersion}}
+kind: Curvefs
+metadata:
+  name: {{.Cluster}}
+  namespace: {{.Namespace}}
+spec:
+  curveVersion:
+    image: {{.Image}}
+    imagePullPolicy: IfNotPresent
+  nodes:
+{{- range .Nodes}}
+    - {{.}}
+{{- end}}
+  dataDirHostPath: {{.DataDir}}
+  logDirHostPath: {{.LogDir}}
+  etcd:
+    peerPort: {{.Etcd.Port}}
+    clientPort: {{.Etcd.ClientPort}}
+  mds:
+    port: {{.MDS.Port}}
+    dummyPort: {{.MDS.DummyPort}}
+  metaserver:
+    port: {{.Storage.Port}}
+    externalPort: {{.Storage.ExternalPort}}
+    copySets: {{.Storage.Copysets}}
+`
+)
+
+type (
+	OperatorOptions struct {
+		Cluster   string
+		Namespace string
+		Formats   []*FormatConfig // curvebs only: disks of chunkservers
+	}
+
+	operatorService struct {
+		Enable       bool
+		Port         int
+		ClientPort   int
+		DummyPort    int
+		ProxyPort    int
+		ExternalPort int
+		Copysets     int
+		S3AccessKey  string
+		S3SecretKey  string
+		S3Address    string
+		S3Bucket     string
+	}
+
+	operatorDevice struct {
+		Name       string
+		MountPath  string
+		Percentage int
+	}
+
+	operatorVariables struct {
+		APIVersion       string
+		Cluster          string
+		Namespace        string
+		Image            string
+		Nodes            []string
+		DataDir          string
+		LogDir           string
+		Etcd             operatorService
+		MDS              operatorService
+		Storage          operatorService // chunkserver or metaserver
+		SnapshotClone    operatorService
+		UseSelectedNodes bool
+		StorageNodes     []string
+		Devices          []operatorDevice
+	}
+)
+
+// hostDir returns the directory of services on host, e.g. /curvebs/data for /curvebs/mds/data
+func hostDir(dc *topology.DeployConfig, dir, name string) string {
+	if len(dir) > 0 {
+		return filepath.Dir(dir)
+	}
+	return filepath.Join(filepath.Dir(dc.GetPrefix()), name)
+}
+
+func uniqueHosts(dcs []*topology.DeployConfig) []string {
+	hosts := []string{}
+	exist := map[string]bool{}
+	for _, dc := range dcs {
+		if !exist[dc.GetHost()] {
+			hosts = append(hosts, dc.GetHost())
+			exist[dc.GetHost()] = true
+		}
+	}
+	return hosts
+}
+
+// operatorDevices returns disks of chunkservers, which all nodes share in curve-operator
+func operatorDevices(chunkservers []*topology.DeployConfig, fcs []*FormatConfig) []operatorDevice {
+	devices := []operatorDevice{}
+	exist := map[string]bool{}
+	for _, fc := range fcs {
+		if !exist[fc.GetMountPoint()] {
+			devices = append(devices, operatorDevice{
+				Name:       fc.GetDevice(),
+				MountPath:  fc.GetMountPoint(),
+				Percentage: fc.GetFormatPercent(),
+			})
+			exist[fc.GetMountPoint()] = true
+		}
+	}
+	if len(fcs) > 0 {
+		return devices
+	}
+
+	// the block devices are unknown without format configure
+	for _, dc := range chunkservers {
+		mountPath := dc.GetDataDir()
+		if len(mountPath) == 0 {
+			mountPath = filepath.Join(dc.GetPrefix(), "data")
+		}
+		if dc.GetHost() == chunkservers[0].GetHost() && !exist[mountPath] {
+			devices = append(devices, operatorDevice{
+				MountPath:  mountPath,
+				Percentage: DEFAULT_OPERATOR_DEVICE_PERCENTAGE,
+			})
+			exist[mountPath] = true
+		}
+	}
+	return devices
+}
+
+func getOperatorVariables(dcs []*topology.DeployConfig, options OperatorOptions) (operatorVariables, error) {
+	variables := operatorVariables{
+		APIVersion: OPERATOR_API_VERSION,
+		Cluster:    options.Cluster,
+		Namespace:  options.Namespace,
+		Nodes:      uniqueHosts(dcs),
+	}
+	if len(variables.Namespace) == 0 {
+		variables.Namespace = DEFAULT_OPERATOR_NAMESPACE
+	}
+
+	kind := dcs[0].GetKind()
+	etcds := filterDeployConfig(dcs, kind, topology.ROLE_ETCD)
+	mdss := filterDeployConfig(dcs, kind, topology.ROLE_MDS)
+	storageRole := topology.ROLE_CHUNKSERVER
+	if kind == topology.KIND_CURVEFS {
+		storageRole = topology.ROLE_METASERVER
+	}
+	storages := filterDeployConfig(dcs, kind, storageRole)
+	if len(etcds) == 0 || len(mdss) == 0 || len(storages) == 0 {
+		return variables, errno.ERR_REQUIRE_SERVICES_FOR_OPERATOR.
+			F("etcd: %d, mds: %d, %s: %d", len(etcds), len(mdss), storageRole, len(storages))
+	}
+
+	// the operator lays out data and log directories of services under the same path
+	variables.Image = mdss[0].GetContainerImage()
+	variables.DataDir = hostDir(mdss[0], mdss[0].GetDataDir(), "data")
+	variables.LogDir = hostDir(mdss[0], mdss[0].GetLogDir(), "logs")
+	variables.Etcd = operatorService{
+		Port:       etcds[0].GetListenPort(),
+		ClientPort: etcds[0].GetListenClientPort(),
+	}
+	variables.MDS = operatorService{
+		Port:      mdss[0].GetListenPort(),
+		DummyPort: mdss[0].GetListenDummyPort(),
+	}
+	variables.Storage = operatorService{
+		Port:         storages[0].GetListenPort(),
+		ExternalPort: storages[0].GetListenExternalPort(),
+		Copysets:     storages[0].GetCopysets(),
+	}
+	variables.StorageNodes = uniqueHosts(storages)
+	variables.UseSelectedNodes = len(variables.StorageNodes) != len(variables.Nodes)
+	if kind == topology.KIND_CURVEFS {
+		return variables, nil
+	}
+
+	variables.Devices = operatorDevices(storages, options.Formats)
+	snapshotclones := filterDeployConfig(dcs, kind, topology.ROLE_SNAPSHOTCLONE)
+	if len(snapshotclones) > 0 {
+		dc := snapshotclones[0]
+		variables.SnapshotClone = operatorService{
+			Enable:      true,
+			Port:        dc.GetListenPort(),
+			DummyPort:   dc.GetListenDummyPort(),
+			ProxyPort:   dc.GetListenProxyPort(),
+			S3AccessKey: dc.GetS3AccessKey(),
+			S3SecretKey: dc.GetS3SecretKey(),
+			S3Address:   dc.GetS3Address(),
+			S3Bucket:    dc.GetS3BucketName(),
+		}
+	}
+	return variables, nil
+}
+
+// GenOperatorManifest converts cluster topology into CurveCluster (curvebs) or Curvefs (curvefs) CR
+func GenOperatorManifest(dcs []*topology.DeployConfig, options OperatorOptions) (string, error) {
+	if len(dcs) == 0 {
+		return "", errno.ERR_NO_SERVICES_IN_TOPOLOGY
+	} else if topology.IsMixed(dcs) {
+		return "", errno.ERR_UNSUPPORT_CLUSTER_KIND.
+			F("operator doesn't support mixed topology")
+	}
+	variables, err := getOperatorVariables(dcs, options)
+	if err != nil {
+		return "", err
+	}
+
+	text := TEMPLATE_OPERATOR_CURVEBS
+	if dcs[0].GetKind() == topology.KIND_CURVEFS {
+		text = TEMPLATE_OPERATOR_CURVEFS
+	}
+	tmpl, err := template.New("operator").Option("missingkey=error").Parse(TEMPLATE_OPERATOR_HEADER + text)
+	if err != nil {
+		return "", errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+	buffer := bytes.NewBufferString("")
+	err = tmpl.Execute(buffer, variables)
+	if err != nil {
+		return "", errno.ERR_RENDER_TEMPLATE_FAILED.E(err)
+	}
+	return buffer.String(), nil
+}
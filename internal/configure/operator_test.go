@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenCurveBSOperatorManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := parseCSITopology(t, CSI_CURVEBS_TOPOLOGY)
+	manifest, err := GenOperatorManifest(dcs, OperatorOptions{Cluster: "my-cluster"})
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(manifest, "# Generated by curveadm from cluster 'my-cluster'"))
+	assert.Contains(manifest, "kind: CurveCluster")
+	assert.Contains(manifest, "namespace: "+DEFAULT_OPERATOR_NAMESPACE)
+	assert.Contains(manifest, "  nodes:\n    - host1\n    - host2\n")
+	assert.Contains(manifest, "peerPort: 2380\n    clientPort: 2379")
+	assert.Contains(manifest, "port: 6700\n    dummyPort: 7700")
+	assert.Contains(manifest, "useSelectedNodes: true\n    nodes:\n      - host1\n    port: 8200")
+	assert.Contains(manifest, `- name: "" # please fill`)
+	assert.Contains(manifest, "proxyPort: 8080")
+
+	// disks from format configure
+	manifest, err = GenOperatorManifest(dcs, OperatorOptions{
+		Cluster:   "my-cluster",
+		Namespace: "storage",
+		Formats: []*FormatConfig{
+			{Host: "host1", Device: "/dev/sdb", MountPoint: "/data/chunkserver0", FormtPercent: 80},
+			{Host: "host2", Device: "/dev/sdb", MountPoint: "/data/chunkserver0", FormtPercent: 80},
+		},
+	})
+	assert.Nil(err)
+	assert.Contains(manifest, "namespace: storage")
+	assert.Contains(manifest, "      - name: /dev/sdb\n        mountPath: /data/chunkserver0\n        percentage: 80\n")
+	assert.Equal(1, strings.Count(manifest, "/dev/sdb"))
+}
+
+func TestGenCurveFSOperatorManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := parseCSITopology(t, CSI_CURVEFS_TOPOLOGY)
+	manifest, err := GenOperatorManifest(dcs, OperatorOptions{Cluster: "fs"})
+	assert.Nil(err)
+	assert.Contains(manifest, "kind: Curvefs")
+	assert.Contains(manifest, "port: 6800\n    externalPort: 6800")
+	assert.NotContains(manifest, "snapShotClone")
+
+	_, err = GenOperatorManifest(dcs[:1], OperatorOptions{Cluster: "fs"})
+	assert.Equal(errno.ERR_REQUIRE_SERVICES_FOR_OPERATOR.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
@@ -23,12 +23,22 @@
 package configure
 
 import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
 	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
 )
 
 const (
 	DEFAULT_CURVEBS_CONTAINER_IMAGE = "opencurvedocker/curvebs-playground:v1.2"
 	DEFAULT_CURVEFS_CONTAINER_IMAGE = "opencurvedocker/curvefs-playground:v2.3"
+
+	DEFAULT_PLAYGROUND_SUBNET    = "172.30.0.0/24"
+	DEFAULT_PLAYGROUND_DISK_SIZE = "10G"
+	MIN_PLAYGROUND_NODES         = 3 // one chunkserver per node, 3 replicas in 3 zones
+	MAX_PLAYGROUND_NODES         = 16
 )
 
 type (
@@ -37,6 +47,9 @@ type (
 		Name           string
 		ContainerImage string
 		Mountpoint     string
+		Nodes          int    // run each node in its own container if > 1
+		Subnet         string // subnet of docker network which nodes in
+		DiskSize       string // size of loopback disk for each chunkserver
 
 		DeployConfigs []*topology.DeployConfig
 		ClientConfig  *ClientConfig
@@ -57,3 +70,58 @@ func (cfg *PlaygroundConfig) GetContainIamge() string {
 	}
 	return DEFAULT_CURVEFS_CONTAINER_IMAGE
 }
+
+func (cfg *PlaygroundConfig) GetNodes() int {
+	if cfg.Nodes <= 0 {
+		return 1
+	}
+	return cfg.Nodes
+}
+
+func (cfg *PlaygroundConfig) IsMultiNode() bool {
+	return cfg.GetNodes() > 1
+}
+
+func (cfg *PlaygroundConfig) GetSubnet() string {
+	if len(cfg.Subnet) > 0 {
+		return cfg.Subnet
+	}
+	return DEFAULT_PLAYGROUND_SUBNET
+}
+
+func (cfg *PlaygroundConfig) GetDiskSize() string {
+	if len(cfg.DiskSize) > 0 {
+		return cfg.DiskSize
+	}
+	return DEFAULT_PLAYGROUND_DISK_SIZE
+}
+
+// the docker network has the same name with playground
+func (cfg *PlaygroundConfig) GetNetwork() string {
+	return cfg.Name
+}
+
+// e.g: playground-curvebs-1656035415-node0
+func (cfg *PlaygroundConfig) GetNodeName(index int) string {
+	return fmt.Sprintf("%s-node%d", cfg.Name, index)
+}
+
+// the first address of subnet is reserved for gateway, e.g:
+//
+//	172.30.0.0/24 => node0: 172.30.0.2, node1: 172.30.0.3, ...
+func (cfg *PlaygroundConfig) GetNodeIP(index int) (string, error) {
+	subnet := cfg.GetSubnet()
+	_, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil || ipnet.IP.To4() == nil {
+		return "", errno.ERR_INVALID_PLAYGROUND_SUBNET.
+			F("subnet: %s", subnet)
+	}
+
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(ipnet.IP.To4())+uint32(index)+2)
+	if !ipnet.Contains(ip) {
+		return "", errno.ERR_INVALID_PLAYGROUND_SUBNET.
+			F("subnet %s is too small for node%d", subnet, index)
+	}
+	return ip.String(), nil
+}
@@ -0,0 +1,60 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaygroundNodeIP(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &PlaygroundConfig{Name: "playground-curvebs-1", Nodes: 3}
+	assert.True(cfg.IsMultiNode())
+	assert.Equal("playground-curvebs-1-node2", cfg.GetNodeName(2))
+	for index, expect := range map[int]string{
+		0: "172.30.0.2",
+		2: "172.30.0.4",
+	} {
+		ip, err := cfg.GetNodeIP(index)
+		assert.Nil(err)
+		assert.Equal(expect, ip)
+	}
+
+	cfg.Subnet = "10.10.1.248/29"
+	ip, err := cfg.GetNodeIP(3)
+	assert.Nil(err)
+	assert.Equal("10.10.1.253", ip)
+	_, err = cfg.GetNodeIP(6)
+	assert.NotNil(err)
+
+	for _, subnet := range []string{"172.30.0.0", "fd00::/64"} {
+		cfg.Subnet = subnet
+		_, err = cfg.GetNodeIP(0)
+		assert.NotNil(err)
+	}
+
+	assert.False((&PlaygroundConfig{}).IsMultiNode())
+}
@@ -151,19 +151,45 @@ func formatName(dc *topology.DeployConfig) string {
 	return fmt.Sprintf("%s_%s_%d", dc.GetHost(), dc.GetName(), dc.GetInstancesSequence())
 }
 
+// LocateServer returns the server in cluster pool which the service belongs to
+func (topo *CurveClusterTopo) LocateServer(dc *topology.DeployConfig) (Server, bool) {
+	name := formatName(dc)
+	for _, server := range topo.Servers {
+		if server.Name == name {
+			return server, true
+		}
+	}
+	return Server{}, false
+}
+
+func isPoolServer(dc *topology.DeployConfig, kind string) bool {
+	role := dc.GetRole()
+	return (role == ROLE_CHUNKSERVER && kind == KIND_CURVEBS) ||
+		(role == ROLE_METASERVER && kind == KIND_CURVEFS)
+}
+
+// each replica of copyset is placed in different zone, so zones are as many as replicas
+func poolReplicas(dcs []*topology.DeployConfig, kind string) int {
+	for _, dc := range dcs {
+		if isPoolServer(dc, kind) {
+			return dc.GetCopysetReplicas()
+		}
+	}
+	return DEFAULT_REPLICAS_PER_COPYSET
+}
+
 func createLogicalPool(dcs []*topology.DeployConfig, logicalPool, poolset string) (LogicalPool, []Server) {
 	var zone string
 	copysets := 0
 	servers := []Server{}
-	zones := DEFAULT_ZONES_PER_POOL
+	kind := dcs[0].GetKind()
+	replicas := poolReplicas(dcs, kind)
+	zones := replicas
 	nextZone := genNextZone(zones)
 	physicalPool := logicalPool
-	kind := dcs[0].GetKind()
 	SortDeployConfigs(dcs)
 	for _, dc := range dcs {
-		role := dc.GetRole()
-		if (role == ROLE_CHUNKSERVER && kind == KIND_CURVEBS) ||
-			(role == ROLE_METASERVER && kind == KIND_CURVEFS) {
+		if isPoolServer(dc, kind) {
 			if dc.GetParentId() == dc.GetId() {
 				zone = nextZone()
 			}
@@ -200,7 +226,7 @@ func createLogicalPool(dcs []*topology.DeployConfig, logicalPool, poolset string
 	}
 
 	// copysets
-	copysets = (int)(copysets / DEFAULT_REPLICAS_PER_COPYSET)
+	copysets = (int)(copysets / replicas)
 	if copysets == 0 {
 		copysets = 1
 	}
@@ -210,7 +236,7 @@ func createLogicalPool(dcs []*topology.DeployConfig, logicalPool, poolset string
 		Name:     logicalPool,
 		Copysets: copysets,
 		Zones:    zones,
-		Replicas: DEFAULT_REPLICAS_PER_COPYSET,
+		Replicas: replicas,
 	}
 	if kind == KIND_CURVEBS {
 		lpool.ScatterWidth = DEFAULT_SCATTER_WIDTH
@@ -221,10 +247,13 @@ func createLogicalPool(dcs []*topology.DeployConfig, logicalPool, poolset string
 	return lpool, servers
 }
 
+// the services are of one kind, or one part of mixed topology whose first
+// service tells its kind, see topology.SplitByKind
 func generateClusterPool(dcs []*topology.DeployConfig, poolName string, poolset Poolset) CurveClusterTopo {
+	kind := dcs[0].GetKind() // NOTE: dcs are sorted by createLogicalPool
 	lpool, servers := createLogicalPool(dcs, poolName, poolset.Name)
 	topo := CurveClusterTopo{Servers: servers, NPools: 1}
-	if dcs[0].GetKind() == KIND_CURVEBS {
+	if kind == KIND_CURVEBS {
 		topo.LogicalPools = []LogicalPool{lpool}
 		topo.Poolsets = []Poolset{poolset}
 	} else {
@@ -286,6 +315,59 @@ func MigrateClusterServer(old *CurveClusterTopo, migrates []*MigrateServer) {
 	}
 }
 
+func isKindServer(server Server, kind string) bool {
+	if kind == KIND_CURVEBS {
+		return len(server.PhysicalPool) > 0
+	}
+	return len(server.Pool) > 0
+}
+
+/*
+ * the cluster pool of mixed topology consists of curvebs part and curvefs
+ * part, the servers of each part are told by their pool field (physicalpool
+ * for curvebs, pool for curvefs), each part is created by its own mds.
+ */
+
+// SelectClusterPool returns the part of cluster pool which belongs to kind
+func SelectClusterPool(topo CurveClusterTopo, kind string) CurveClusterTopo {
+	part := CurveClusterTopo{Servers: []Server{}}
+	for _, server := range topo.Servers {
+		if isKindServer(server, kind) {
+			part.Servers = append(part.Servers, server)
+		}
+	}
+	if kind == KIND_CURVEBS {
+		part.Poolsets = topo.Poolsets
+		part.LogicalPools = topo.LogicalPools
+		part.NPools = len(topo.LogicalPools)
+	} else {
+		part.Pools = topo.Pools
+		part.NPools = len(topo.Pools)
+	}
+	return part
+}
+
+// MergeClusterPool replaces the part of cluster pool which belongs to kind
+func MergeClusterPool(topo *CurveClusterTopo, part CurveClusterTopo, kind string) {
+	servers := []Server{}
+	for _, server := range topo.Servers {
+		if !isKindServer(server, kind) {
+			servers = append(servers, server)
+		}
+	}
+	topo.Servers = append(servers, part.Servers...)
+	if kind == KIND_CURVEBS {
+		topo.Poolsets = part.Poolsets
+		topo.LogicalPools = part.LogicalPools
+	} else {
+		topo.Pools = part.Pools
+	}
+	topo.NPools = len(topo.LogicalPools)
+	if len(topo.Pools) > topo.NPools {
+		topo.NPools = len(topo.Pools)
+	}
+}
+
 func GenerateDefaultClusterPool(dcs []*topology.DeployConfig, poolset Poolset) (topo CurveClusterTopo, err error) {
 	topo = generateClusterPool(dcs, "pool1", poolset)
 	return
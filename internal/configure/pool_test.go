@@ -0,0 +1,143 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	MIXED_POOL_TOPOLOGY = `
+kind: mixed
+global:
+  log_dir: /data/logs/${service_role}
+  data_dir: /data/${service_role}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 8200
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+fs_mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6701
+    listen.dummy_port: 7701
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+metaserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6800
+    listen.external_port: 7800
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+)
+
+func generateMixedClusterPool(t *testing.T) (CurveClusterTopo, CurveClusterTopo) {
+	ctx := topology.NewContext()
+	for _, host := range []string{"host1", "host2", "host3"} {
+		ctx.Add(host, host)
+	}
+	dcs, err := topology.ParseTopology(MIXED_POOL_TOPOLOGY, ctx)
+	assert.Nil(t, err)
+
+	bs, err := GenerateDefaultClusterPool(topology.SelectByKind(dcs, KIND_CURVEBS), Poolset{Name: "default"})
+	assert.Nil(t, err)
+	fs, err := GenerateDefaultClusterPool(topology.SelectByKind(dcs, KIND_CURVEFS), Poolset{})
+	assert.Nil(t, err)
+	return bs, fs
+}
+
+func TestGenerateMixedClusterPool(t *testing.T) {
+	assert := assert.New(t)
+
+	// the shared etcd in curvefs part doesn't make it a curvebs pool
+	bs, fs := generateMixedClusterPool(t)
+	assert.Len(bs.Servers, 3)
+	assert.Len(bs.LogicalPools, 1)
+	assert.Len(bs.Pools, 0)
+	assert.Len(fs.Servers, 3)
+	assert.Len(fs.Pools, 1)
+	assert.Len(fs.LogicalPools, 0)
+}
+
+func TestMergeClusterPool(t *testing.T) {
+	assert := assert.New(t)
+
+	bs, fs := generateMixedClusterPool(t)
+	pool := CurveClusterTopo{}
+	MergeClusterPool(&pool, bs, KIND_CURVEBS)
+	MergeClusterPool(&pool, fs, KIND_CURVEFS)
+	assert.Len(pool.Servers, 6)
+	assert.Equal(1, pool.NPools)
+	assert.Equal(bs, SelectClusterPool(pool, KIND_CURVEBS))
+	assert.Equal(fs, SelectClusterPool(pool, KIND_CURVEFS))
+
+	// merge the curvebs part again replaces the old one
+	bs.Servers = bs.Servers[:2]
+	MergeClusterPool(&pool, bs, KIND_CURVEBS)
+	assert.Len(pool.Servers, 5)
+	assert.Equal(bs, SelectClusterPool(pool, KIND_CURVEBS))
+	assert.Equal(fs, SelectClusterPool(pool, KIND_CURVEFS))
+
+	// scale out curvefs part
+	fs.Pools = append(fs.Pools, LogicalPool{Name: "pool2"})
+	fs.NPools = 2
+	MergeClusterPool(&pool, fs, KIND_CURVEFS)
+	assert.Equal(2, pool.NPools)
+	assert.Equal(fs, SelectClusterPool(pool, KIND_CURVEFS))
+}
@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"sort"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+type (
+	ZoneLayout struct {
+		Pool        string
+		Zone        string
+		Hosts       int
+		OldServices int
+		NewServices int // services after scale out
+	}
+
+	PoolLayout struct {
+		Name        string
+		Zones       []ZoneLayout
+		OldCopysets int
+		NewCopysets int // copysets after scale out
+		// the ratio of existing data which will be rebalanced to new services
+		MovementRatio float64
+	}
+
+	ScaleOutPlan struct {
+		Pools []PoolLayout
+	}
+)
+
+func poolOfServer(server Server) string {
+	if len(server.PhysicalPool) > 0 {
+		return server.PhysicalPool
+	}
+	return server.Pool
+}
+
+func copysetsOfPools(topo CurveClusterTopo) map[string]int {
+	m := map[string]int{}
+	for _, pool := range topo.LogicalPools { // curvebs
+		m[pool.PhysicalPool] += pool.Copysets
+	}
+	for _, pool := range topo.Pools { // curvefs
+		m[pool.Name] += pool.Copysets
+	}
+	return m
+}
+
+/*
+ * PlanScaleOut calculates the layout of cluster pool after scaling out
+ * services "dcs", the old cluster pool wouldn't be changed.
+ */
+func PlanScaleOut(old CurveClusterTopo, dcs []*topology.DeployConfig, poolset Poolset) ScaleOutPlan {
+	topo := old
+	topo.Servers = append([]Server{}, old.Servers...)
+	topo.LogicalPools = append([]LogicalPool{}, old.LogicalPools...)
+	topo.Pools = append([]LogicalPool{}, old.Pools...)
+	topo.Poolsets = append([]Poolset{}, old.Poolsets...)
+	ScaleOutClusterPool(&topo, dcs, poolset)
+	return diffClusterPool(old, topo)
+}
+
+func diffClusterPool(old, new CurveClusterTopo) ScaleOutPlan {
+	type key struct{ pool, zone string }
+	zones := map[key]*ZoneLayout{}
+	hosts := map[key]map[string]bool{}
+	layout := func(server Server) *ZoneLayout {
+		k := key{poolOfServer(server), server.Zone}
+		if _, ok := zones[k]; !ok {
+			zones[k] = &ZoneLayout{Pool: k.pool, Zone: k.zone}
+			hosts[k] = map[string]bool{}
+		}
+		hosts[k][server.InternalIp] = true
+		return zones[k]
+	}
+	for _, server := range old.Servers {
+		layout(server).OldServices++
+	}
+	for _, server := range new.Servers {
+		layout(server).NewServices++
+	}
+
+	// group zones by pool
+	pools := map[string]*PoolLayout{}
+	oldCopysets, newCopysets := copysetsOfPools(old), copysetsOfPools(new)
+	for k, zone := range zones {
+		zone.Hosts = len(hosts[k])
+		if _, ok := pools[k.pool]; !ok {
+			pools[k.pool] = &PoolLayout{
+				Name:        k.pool,
+				OldCopysets: oldCopysets[k.pool],
+				NewCopysets: newCopysets[k.pool],
+			}
+		}
+		pools[k.pool].Zones = append(pools[k.pool].Zones, *zone)
+	}
+
+	plan := ScaleOutPlan{}
+	for _, pool := range pools {
+		oldServices, newServices := 0, 0
+		for _, zone := range pool.Zones {
+			oldServices += zone.OldServices
+			newServices += zone.NewServices
+		}
+		// data only rebalance among services in the same pool
+		if oldServices > 0 && newServices > oldServices {
+			pool.MovementRatio = float64(newServices-oldServices) / float64(newServices)
+		}
+		sort.Slice(pool.Zones, func(i, j int) bool {
+			return pool.Zones[i].Zone < pool.Zones[j].Zone
+		})
+		plan.Pools = append(plan.Pools, *pool)
+	}
+	sort.Slice(plan.Pools, func(i, j int) bool {
+		return plan.Pools[i].Name < plan.Pools[j].Name
+	})
+	return plan
+}
+
+/*
+ * CheckZoneBalance checks every pool which changed has the same number
+ * of zones as replicas, and the number of services in each zone differ
+ * by at most the number of services in one host, because the replicas of
+ * copyset are placed in different zones and the smallest zone limits the
+ * capacity of pool.
+ */
+func (plan ScaleOutPlan) CheckZoneBalance() error {
+	for _, pool := range plan.Pools {
+		min, max, maxPerHost := -1, 0, 0
+		changed := false
+		for _, zone := range pool.Zones {
+			if zone.NewServices != zone.OldServices {
+				changed = true
+			}
+			if min == -1 || zone.NewServices < min {
+				min = zone.NewServices
+			}
+			if zone.NewServices > max {
+				max = zone.NewServices
+			}
+			if zone.Hosts > 0 && (zone.NewServices+zone.Hosts-1)/zone.Hosts > maxPerHost {
+				maxPerHost = (zone.NewServices + zone.Hosts - 1) / zone.Hosts
+			}
+		}
+
+		if !changed {
+			continue
+		} else if len(pool.Zones) < DEFAULT_ZONES_PER_POOL {
+			return errno.ERR_UNBALANCED_ZONES_WHILE_SCALE_OUT.
+				F("pool %s has %d zones, requires %d", pool.Name, len(pool.Zones), DEFAULT_ZONES_PER_POOL)
+		} else if max-min > maxPerHost {
+			return errno.ERR_UNBALANCED_ZONES_WHILE_SCALE_OUT.
+				F("pool %s: zone services range from %d to %d", pool.Name, min, max)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package topology
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/opencurve/curveadm/pkg/variable"
+)
+
+/*
+ * cache of parsed topology which keyed by hash of topology text and hosts:
+ *   memory: repeated parsing in one command or long-running process (e.g. serve, bot)
+ *   disk:   repeated commands in quick succession (e.g. status, logs, exec), it's
+ *           the rendered services without secrets, which resolved again when loaded
+ *
+ * NOTE: the parsed deploy configs are shared among callers, it's safe
+ * because they are immutable after parsed (With* returns a copy).
+ */
+const (
+	TOPOLOGY_CACHE_TTL  = 30 * time.Second // secrets maybe rotated
+	TOPOLOGY_CACHE_SIZE = 16
+
+	// the random value differs in each parsing, so it's never persisted
+	VARIABLE_RANDOM_UUID = "${random_uuid}"
+)
+
+type (
+	cacheEntry struct {
+		dcs    []*DeployConfig
+		expire time.Time
+	}
+
+	topologyCache struct {
+		mutex   sync.Mutex
+		entries map[string]cacheEntry
+	}
+
+	// the persisted service, see DeployConfig
+	cachedService struct {
+		Kind              string            `json:"kind"`
+		Id                string            `json:"id"`
+		ParentId          string            `json:"parent_id"`
+		Role              string            `json:"role"`
+		Host              string            `json:"host"`
+		Hostname          string            `json:"hostname"`
+		Name              string            `json:"name"`
+		Instances         int               `json:"instances"`
+		HostSequence      int               `json:"host_sequence"`
+		InstancesSequence int               `json:"instances_sequence"`
+		Config            map[string]string `json:"config"` // secrets unresolved
+		Variables         map[string]string `json:"variables"`
+	}
+)
+
+var (
+	tcache = &topologyCache{entries: map[string]cacheEntry{}}
+
+	// the disk cache is disabled if dir is empty
+	cacheDir     string
+	cacheVersion string
+)
+
+// SetCacheDir enables the disk cache, it's invalidated once curveadm upgraded
+func SetCacheDir(dir, version string) {
+	cacheDir, cacheVersion = dir, version
+}
+
+func cacheKey(data string, ctx *Context) string {
+	hosts := []string{}
+	for host := range ctx.m {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	h := sha256.New()
+	h.Write([]byte(cacheVersion))
+	h.Write([]byte{0})
+	h.Write([]byte(data))
+	for _, host := range hosts {
+		h.Write([]byte{0})
+		h.Write([]byte(host + "=" + ctx.m[host]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns copy of cached deploy configs, the caller may reorder or append it
+func (c *topologyCache) get(key string, now time.Time) ([]*DeployConfig, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expire) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return append([]*DeployConfig{}, entry.dcs...), true
+}
+
+func (c *topologyCache) put(key string, dcs []*DeployConfig, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for k, entry := range c.entries {
+		if now.After(entry.expire) {
+			delete(c.entries, k)
+		}
+	}
+	// evict the entry which expires first
+	for len(c.entries) >= TOPOLOGY_CACHE_SIZE {
+		oldest := ""
+		for k, entry := range c.entries {
+			if len(oldest) == 0 || entry.expire.Before(c.entries[oldest].expire) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = cacheEntry{
+		dcs:    append([]*DeployConfig{}, dcs...),
+		expire: now.Add(TOPOLOGY_CACHE_TTL),
+	}
+}
+
+// PurgeCache drops all parsed topology in memory, e.g. after secrets changed
+func PurgeCache() {
+	tcache.mutex.Lock()
+	defer tcache.mutex.Unlock()
+	tcache.entries = map[string]cacheEntry{}
+}
+
+func newCachedService(dc *DeployConfig) cachedService {
+	return cachedService{
+		Kind:              dc.kind,
+		Id:                dc.id,
+		ParentId:          dc.parentId,
+		Role:              dc.role,
+		Host:              dc.host,
+		Hostname:          dc.hostname,
+		Name:              dc.name,
+		Instances:         dc.instances,
+		HostSequence:      dc.hostSequence,
+		InstancesSequence: dc.instancesSequence,
+		Config:            dc.rendered,
+		Variables:         dc.variables.Values(),
+	}
+}
+
+// the service restored is same as parsed, except secrets resolved again
+func (s cachedService) restore(ctx *Context) (*DeployConfig, error) {
+	vars := variable.NewVariables()
+	for name, value := range s.Variables {
+		vars.Register(variable.Variable{Name: name, Value: value, Resolved: true})
+	}
+	if err := vars.Build(); err != nil {
+		return nil, err
+	}
+
+	dc := &DeployConfig{
+		kind:              s.Kind,
+		id:                s.Id,
+		parentId:          s.ParentId,
+		role:              s.Role,
+		host:              s.Host,
+		hostname:          s.Hostname,
+		name:              s.Name,
+		instances:         s.Instances,
+		hostSequence:      s.HostSequence,
+		instancesSequence: s.InstancesSequence,
+		config:            map[string]interface{}{},
+		serviceConfig:     map[string]string{},
+		rendered:          s.Config,
+		variables:         vars,
+		ctx:               ctx,
+	}
+	for k, v := range s.Config {
+		realv, err := secret.Resolve(v)
+		if err != nil {
+			return nil, err
+		}
+		dc.config[k] = realv
+	}
+	return dc, dc.convert()
+}
+
+func cachePath(key string) string {
+	return filepath.Join(cacheDir, key+".json")
+}
+
+// loadCache returns the services persisted, any error means cache missed
+func loadCache(key string, ctx *Context) ([]*DeployConfig, bool) {
+	if len(cacheDir) == 0 {
+		return nil, false
+	}
+	data, err := os.ReadFile(cachePath(key))
+	if err != nil {
+		return nil, false
+	}
+	services := []cachedService{}
+	if err := json.Unmarshal(data, &services); err != nil || len(services) == 0 {
+		return nil, false
+	}
+
+	dcs := []*DeployConfig{}
+	for _, service := range services {
+		dc, err := service.restore(ctx)
+		if err != nil {
+			return nil, false
+		}
+		dcs = append(dcs, dc)
+	}
+	return dcs, true
+}
+
+// storeCache persists the services, the cache is best effort so error is ignored
+func storeCache(key, data string, dcs []*DeployConfig) {
+	if len(cacheDir) == 0 || strings.Contains(data, VARIABLE_RANDOM_UUID) {
+		return
+	}
+	services := []cachedService{}
+	for _, dc := range dcs {
+		services = append(services, newCachedService(dc))
+	}
+	bytes, err := json.Marshal(services)
+	if err != nil || os.MkdirAll(cacheDir, 0700) != nil {
+		return
+	}
+
+	// write into temporary file and rename it, concurrent commands never see partial file
+	tmpfile, err := os.CreateTemp(cacheDir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmpfile.Write(bytes)
+	if cerr := tmpfile.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || os.Rename(tmpfile.Name(), cachePath(key)) != nil {
+		os.Remove(tmpfile.Name())
+		return
+	}
+	pruneCache()
+}
+
+// pruneCache removes the oldest files if the number of cached topology exceeds limit
+func pruneCache() {
+	files, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	if err != nil || len(files) <= TOPOLOGY_CACHE_SIZE {
+		return
+	}
+
+	mtimes := map[string]time.Time{}
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			mtimes[file] = info.ModTime()
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return mtimes[files[i]].Before(mtimes[files[j]])
+	})
+	for _, file := range files[:len(files)-TOPOLOGY_CACHE_SIZE] {
+		os.Remove(file)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTopologyCache(t *testing.T) {
+	assert := assert.New(t)
+	PurgeCache()
+	defer PurgeCache()
+
+	// same topology and hosts: reuse deploy configs, but not the slice
+	dcs1 := parseMixedTopology(t)
+	dcs2 := parseMixedTopology(t)
+	assert.Equal(len(dcs1), len(dcs2))
+	assert.Same(dcs1[0], dcs2[0])
+	dcs2[0] = nil
+	assert.NotNil(parseMixedTopology(t)[0])
+
+	// hostname changed
+	ctx := NewContext()
+	for _, host := range []string{"host1", "host2", "host3"} {
+		ctx.Add(host, "10.0.0.1")
+	}
+	dcs3, err := ParseTopology(MIXED_TOPOLOGY, ctx)
+	assert.Nil(err)
+	assert.NotSame(dcs1[0], dcs3[0])
+	assert.Equal("10.0.0.1", dcs3[0].GetHostname())
+
+	// error is never cached
+	_, err = ParseTopology("kind: unknown", ctx)
+	assert.NotNil(err)
+	_, err = ParseTopology("kind: unknown", ctx)
+	assert.NotNil(err)
+
+	PurgeCache()
+	assert.NotSame(dcs1[0], parseMixedTopology(t)[0])
+}
+
+func TestParseTopologyDiskCache(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("CURVEADM_TEST_SECRET_S3_SK", "123456")
+	secret.ReplaceGlobals(secret.NewEnvResolver("CURVEADM_TEST_SECRET_"))
+	dir := t.TempDir()
+	SetCacheDir(dir, "v1")
+	PurgeCache()
+	defer func() {
+		SetCacheDir("", "")
+		secret.ReplaceGlobals(nil)
+		PurgeCache()
+	}()
+
+	data := strings.Replace(MIXED_TOPOLOGY, "global:\n", "global:\n  s3.sk: secret://s3_sk\n", 1)
+	ctx := NewContext()
+	for _, host := range []string{"host1", "host2", "host3"} {
+		ctx.Add(host, host)
+	}
+	dcs1, err := ParseTopology(data, ctx)
+	assert.Nil(err)
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Len(files, 1)
+	bytes, err := os.ReadFile(files[0])
+	assert.Nil(err)
+	assert.Contains(string(bytes), "secret://s3_sk")
+	assert.NotContains(string(bytes), "123456")
+
+	// loaded from disk: same as parsed and secrets resolved again
+	PurgeCache()
+	t.Setenv("CURVEADM_TEST_SECRET_S3_SK", "654321")
+	dcs2, err := ParseTopology(data, ctx)
+	assert.Nil(err)
+	assert.Equal(len(dcs1), len(dcs2))
+	for i := range dcs1 {
+		assert.NotSame(dcs1[i], dcs2[i])
+		assert.Equal(dcs1[i].GetId(), dcs2[i].GetId())
+		assert.Equal(dcs1[i].GetKind(), dcs2[i].GetKind())
+		assert.Equal(dcs1[i].GetVariables().Values(), dcs2[i].GetVariables().Values())
+		assert.Equal("654321", dcs2[i].GetS3SecretKey())
+		dcs1[i].GetServiceConfig()[CONFIG_S3_SECRET_KEY.key] = "654321"
+		assert.Equal(dcs1[i].GetServiceConfig(), dcs2[i].GetServiceConfig())
+	}
+
+	// curveadm upgraded
+	SetCacheDir(dir, "v2")
+	PurgeCache()
+	dcs3, err := ParseTopology(data, ctx)
+	assert.Nil(err)
+	files, _ = filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Len(files, 2)
+	assert.Equal(dcs1[0].GetId(), dcs3[0].GetId())
+
+	// random value is never persisted
+	data = strings.Replace(data, "global:\n", "global:\n  s3.ak: ${random_uuid}\n", 1)
+	_, err = ParseTopology(data, ctx)
+	assert.Nil(err)
+	files, _ = filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Len(files, 2)
+}
+
+func TestTopologyCacheExpireAndEvict(t *testing.T) {
+	assert := assert.New(t)
+	c := &topologyCache{entries: map[string]cacheEntry{}}
+	now := time.Now()
+
+	dcs := []*DeployConfig{{id: "etcd_host1_0_0"}}
+	c.put("key", dcs, now)
+	_, ok := c.get("key", now.Add(TOPOLOGY_CACHE_TTL-time.Second))
+	assert.True(ok)
+	_, ok = c.get("key", now.Add(TOPOLOGY_CACHE_TTL+time.Second))
+	assert.False(ok)
+
+	for i := 0; i < TOPOLOGY_CACHE_SIZE+2; i++ {
+		c.put(fmt.Sprintf("key%d", i), dcs, now.Add(time.Duration(i)*time.Millisecond))
+	}
+	assert.Len(c.entries, TOPOLOGY_CACHE_SIZE)
+	_, ok = c.get("key0", now)
+	assert.False(ok)
+	_, ok = c.get(fmt.Sprintf("key%d", TOPOLOGY_CACHE_SIZE+1), now)
+	assert.True(ok)
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/opencurve/curveadm/internal/build"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
 	"github.com/opencurve/curveadm/internal/utils"
 	log "github.com/opencurve/curveadm/pkg/log/glg"
 	"github.com/opencurve/curveadm/pkg/variable"
@@ -38,12 +39,16 @@ import (
 const (
 	KIND_CURVEBS = "curvebs"
 	KIND_CURVEFS = "curvefs"
+	KIND_MIXED   = "mixed" // curvebs and curvefs share one etcd cluster
 
 	ROLE_ETCD          = "etcd"
 	ROLE_MDS           = "mds"
 	ROLE_CHUNKSERVER   = "chunkserver"
 	ROLE_SNAPSHOTCLONE = "snapshotclone"
 	ROLE_METASERVER    = "metaserver"
+
+	IMAGE_SOURCE_REMOTE = "remote" // pull image from registry
+	IMAGE_SOURCE_LOCAL  = "local"  // load image from archive imported by curveadm
 )
 
 type (
@@ -61,6 +66,7 @@ type (
 
 		config        map[string]interface{}
 		serviceConfig map[string]string
+		rendered      map[string]string // config rendered but secrets unresolved, see cache
 		variables     *variable.Variables
 		ctx           *Context
 	}
@@ -69,6 +75,7 @@ type (
 		Id   string
 		Role string
 		Host string
+		Kind string // empty means any kind
 	}
 )
 
@@ -159,6 +166,8 @@ func (dc *DeployConfig) renderVariables() error {
 		log.Error("Build variables failed",
 			log.Field("error", err))
 		return errno.ERR_RESOLVE_VARIABLE_FAILED.E(err)
+	} else if err := resolveAddressVariables(vars); err != nil {
+		return errno.ERR_RESOLVE_VARIABLE_FAILED.E(err)
 	}
 
 	err := func(values ...*string) error {
@@ -175,11 +184,17 @@ func (dc *DeployConfig) renderVariables() error {
 		return errno.ERR_RENDERING_VARIABLE_FAILED.E(err)
 	}
 
+	dc.rendered = map[string]string{}
 	for k, v := range dc.config {
 		realv, err := vars.Rendering(v.(string))
 		if err != nil {
 			return errno.ERR_RENDERING_VARIABLE_FAILED.E(err)
 		}
+		dc.rendered[k] = realv
+		realv, err = secret.Resolve(realv) // e.g. s3.sk: secret://s3_sk
+		if err != nil {
+			return err
+		}
 		dc.config[k] = realv
 		build.DEBUG(build.DEBUG_TOPOLOGY,
 			build.Field{k, v},
@@ -246,7 +261,11 @@ func (dc *DeployConfig) convert() error {
 		}
 	}
 
-	return nil
+	if source := dc.GetImageSource(); source != IMAGE_SOURCE_REMOTE && source != IMAGE_SOURCE_LOCAL {
+		return errno.ERR_UNSUPPORT_IMAGE_SOURCE.
+			F("%s: %s", CONFIG_IMAGE_SOURCE.key, source)
+	}
+	return dc.checkResources()
 }
 
 func (dc *DeployConfig) ResolveHost() error {
@@ -282,3 +301,29 @@ func (dc *DeployConfig) Build() error {
 	}
 	return dc.convert()
 }
+
+// returns a copy of deploy config which runs with the specified image
+func (dc *DeployConfig) WithContainerImage(image string) *DeployConfig {
+	config := map[string]interface{}{}
+	for k, v := range dc.config {
+		config[k] = v
+	}
+	config[CONFIG_CONTAINER_IMAGE.key] = image
+
+	clone := *dc
+	clone.config = config
+	return &clone
+}
+
+// returns a copy of deploy config which overrides the specified service config
+func (dc *DeployConfig) WithServiceConfig(key, value string) *DeployConfig {
+	serviceConfig := map[string]string{}
+	for k, v := range dc.serviceConfig {
+		serviceConfig[k] = v
+	}
+	serviceConfig[key] = value
+
+	clone := *dc
+	clone.serviceConfig = serviceConfig
+	return &clone
+}
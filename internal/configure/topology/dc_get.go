@@ -58,6 +58,9 @@ const (
 	BINARY_CURVE_TOOL_V2    = "curve"
 	METAFILE_CHUNKFILE_POOL = "chunkfilepool.meta"
 	METAFILE_CHUNKSERVER_ID = "chunkserver.dat"
+	FILE_TLS_CA_CERT        = "ca.crt"
+	FILE_TLS_CERT           = "server.crt"
+	FILE_TLS_KEY            = "server.key"
 )
 
 var (
@@ -127,6 +130,8 @@ func (dc *DeployConfig) GetVariables() *variable.Variables   { return dc.variabl
 func (dc *DeployConfig) GetPrefix() string           { return dc.getString(CONFIG_PREFIX) }
 func (dc *DeployConfig) GetReportUsage() bool        { return dc.getBool(CONFIG_REPORT_USAGE) }
 func (dc *DeployConfig) GetContainerImage() string   { return dc.getString(CONFIG_CONTAINER_IMAGE) }
+func (dc *DeployConfig) GetImageSource() string      { return dc.getString(CONFIG_IMAGE_SOURCE) }
+func (dc *DeployConfig) GetSystemdUnit() string      { return dc.getString(CONFIG_SYSTEMD_UNIT) }
 func (dc *DeployConfig) GetLogDir() string           { return dc.getString(CONFIG_LOG_DIR) }
 func (dc *DeployConfig) GetDataDir() string          { return dc.getString(CONFIG_DATA_DIR) }
 func (dc *DeployConfig) GetCoreDir() string          { return dc.getString(CONFIG_CORE_DIR) }
@@ -137,15 +142,27 @@ func (dc *DeployConfig) GetListenDummyPort() int     { return dc.getInt(CONFIG_L
 func (dc *DeployConfig) GetListenProxyPort() int     { return dc.getInt(CONFIG_LISTEN_PROXY_PORT) }
 func (dc *DeployConfig) GetListenExternalIp() string { return dc.getString(CONFIG_LISTEN_EXTERNAL_IP) }
 func (dc *DeployConfig) GetCopysets() int            { return dc.getInt(CONFIG_COPYSETS) }
+func (dc *DeployConfig) GetCopysetReplicas() int     { return dc.getInt(CONFIG_COPYSET_REPLICAS) }
 func (dc *DeployConfig) GetS3AccessKey() string      { return dc.getString(CONFIG_S3_ACCESS_KEY) }
 func (dc *DeployConfig) GetS3SecretKey() string      { return dc.getString(CONFIG_S3_SECRET_KEY) }
 func (dc *DeployConfig) GetS3Address() string        { return dc.getString(CONFIG_S3_ADDRESS) }
 func (dc *DeployConfig) GetS3BucketName() string     { return dc.getString(CONFIG_S3_BUCKET_NAME) }
+func (dc *DeployConfig) GetS3Endpoint() string       { return dc.getString(CONFIG_S3_ENDPOINT) }
+func (dc *DeployConfig) GetS3FSBucketName() string   { return dc.getString(CONFIG_S3_FS_BUCKET_NAME) }
 func (dc *DeployConfig) GetEnableRDMA() bool         { return dc.getBool(CONFIG_ENABLE_RDMA) }
 func (dc *DeployConfig) GetEnableRenameAt2() bool    { return dc.getBool(CONFIG_ENABLE_RENAMEAT2) }
 func (dc *DeployConfig) GetEtcdAuthEnable() bool     { return dc.getBool(CONFIG_ETCD_AUTH_ENABLE) }
 func (dc *DeployConfig) GetEtcdAuthUsername() string { return dc.getString(CONFIG_ETCD_AUTH_USERNAME) }
 func (dc *DeployConfig) GetEtcdAuthPassword() string { return dc.getString(CONFIG_ETCD_AUTH_PASSWORD) }
+func (dc *DeployConfig) GetTLSEnable() bool          { return dc.getBool(CONFIG_TLS_ENABLE) }
+func (dc *DeployConfig) GetResourcesCpus() string    { return dc.getString(CONFIG_RESOURCES_CPUS) }
+func (dc *DeployConfig) GetResourcesMemory() string  { return dc.getString(CONFIG_RESOURCES_MEMORY) }
+func (dc *DeployConfig) GetResourcesCpusetCpus() string {
+	return dc.getString(CONFIG_RESOURCES_CPUSET_CPUS)
+}
+func (dc *DeployConfig) GetResourcesCgroupParent() string {
+	return dc.getString(CONFIG_RESOURCES_CGROUP_PARENT)
+}
 func (dc *DeployConfig) GetEnableChunkfilePool() bool {
 	return dc.getBool(CONFIG_ENABLE_CHUNKFILE_POOL)
 }
@@ -216,6 +233,9 @@ type (
 		ServiceConfPath    string // /curvebs/mds/conf/mds.conf
 		ServiceConfSrcPath string // /curvebs/conf/mds.conf
 		ServiceConfFiles   []ConfFile
+		ServiceCACertPath  string // /curvebs/mds/conf/ca.crt
+		ServiceCertPath    string // /curvebs/mds/conf/server.crt
+		ServiceKeyPath     string // /curvebs/mds/conf/server.key
 
 		// tools
 		ToolsRootDir        string // /curvebs/tools
@@ -296,6 +316,9 @@ func (dc *DeployConfig) GetProjectLayout() Layout {
 		ServiceConfPath:    fmt.Sprintf("%s/%s.conf", serviceConfDir, role),
 		ServiceConfSrcPath: fmt.Sprintf("%s/%s.conf", confSrcDir, role),
 		ServiceConfFiles:   serviceConfFiles,
+		ServiceCACertPath:  fmt.Sprintf("%s/%s", serviceConfDir, FILE_TLS_CA_CERT),
+		ServiceCertPath:    fmt.Sprintf("%s/%s", serviceConfDir, FILE_TLS_CERT),
+		ServiceKeyPath:     fmt.Sprintf("%s/%s", serviceConfDir, FILE_TLS_KEY),
 
 		// tools
 		ToolsRootDir:        toolsRootDir,
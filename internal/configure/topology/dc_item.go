@@ -50,6 +50,7 @@ const (
 	DEFAULT_ENABLE_EXTERNAL_SERVER          = false
 	DEFAULT_CHUNKSERVER_COPYSETS            = 100 // copysets per chunkserver
 	DEFAULT_METASERVER_COPYSETS             = 100 // copysets per metaserver
+	DEFAULT_COPYSET_REPLICAS                = 3   // replicas of each copyset, one replica per zone
 )
 
 type (
@@ -108,6 +109,21 @@ var (
 		},
 	)
 
+	CONFIG_IMAGE_SOURCE = itemset.insert(
+		"image_source",
+		REQUIRE_STRING,
+		true,
+		IMAGE_SOURCE_REMOTE,
+	)
+
+	// the service runs outside container and is managed by the systemd unit if specified
+	CONFIG_SYSTEMD_UNIT = itemset.insert(
+		"systemd_unit",
+		REQUIRE_STRING,
+		true,
+		nil,
+	)
+
 	CONFIG_LOG_DIR = itemset.insert(
 		"log_dir",
 		REQUIRE_STRING,
@@ -228,6 +244,13 @@ var (
 		},
 	)
 
+	CONFIG_COPYSET_REPLICAS = itemset.insert(
+		"copyset_replicas",
+		REQUIRE_POSITIVE_INTEGER,
+		true,
+		DEFAULT_COPYSET_REPLICAS,
+	)
+
 	CONFIG_S3_ACCESS_KEY = itemset.insert(
 		"s3.ak",
 		REQUIRE_STRING,
@@ -256,6 +279,21 @@ var (
 		nil,
 	)
 
+	// curvefs metaserver deletes data in S3
+	CONFIG_S3_ENDPOINT = itemset.insert(
+		"s3.endpoint",
+		REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	CONFIG_S3_FS_BUCKET_NAME = itemset.insert(
+		"s3.bucket_name",
+		REQUIRE_STRING,
+		false,
+		nil,
+	)
+
 	CONFIG_ENABLE_RDMA = itemset.insert(
 		"enable_rdma",
 		REQUIRE_BOOL,
@@ -304,6 +342,41 @@ var (
 		false,
 		nil,
 	)
+
+	CONFIG_TLS_ENABLE = itemset.insert(
+		"tls.enable",
+		REQUIRE_BOOL,
+		true,
+		false,
+	)
+
+	CONFIG_RESOURCES_CPUS = itemset.insert(
+		"resources.cpus",
+		REQUIRE_STRING,
+		true,
+		nil,
+	)
+
+	CONFIG_RESOURCES_MEMORY = itemset.insert(
+		"resources.memory",
+		REQUIRE_STRING,
+		true,
+		nil,
+	)
+
+	CONFIG_RESOURCES_CPUSET_CPUS = itemset.insert(
+		"resources.cpuset_cpus",
+		REQUIRE_STRING,
+		true,
+		nil,
+	)
+
+	CONFIG_RESOURCES_CGROUP_PARENT = itemset.insert(
+		"resources.cgroup_parent",
+		REQUIRE_STRING,
+		true,
+		nil,
+	)
 )
 
 func (i *item) Key() string {
@@ -25,8 +25,11 @@
 package topology
 
 import (
+	"sort"
+
 	"github.com/mitchellh/hashstructure/v2"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
 const (
@@ -40,8 +43,19 @@ type TopologyDiff struct {
 	DeployConfig *DeployConfig
 }
 
+// the old or new value is empty if the config item is added or removed
+type ConfigChange struct {
+	Key string
+	Old string
+	New string
+}
+
+// hashstructure ignores unexported fields, so we hash the rendered configure
 func hash(dc *DeployConfig) (uint64, error) {
-	return hashstructure.Hash(*dc, hashstructure.FormatV2, nil)
+	return hashstructure.Hash(struct {
+		Config        map[string]interface{}
+		ServiceConfig map[string]string
+	}{dc.config, dc.serviceConfig}, hashstructure.FormatV2, nil)
 }
 
 func same(dc1, dc2 *DeployConfig) (bool, error) {
@@ -135,3 +149,26 @@ func DiffTopology(data1, data2 string, ctx *Context) ([]TopologyDiff, error) {
 
 	return diffs, nil
 }
+
+// DiffConfig returns the changed config items between two deploy configs of same service
+func DiffConfig(dc1, dc2 *DeployConfig) []ConfigChange {
+	keys := map[string]bool{}
+	for k := range dc1.config {
+		keys[k] = true
+	}
+	for k := range dc2.config {
+		keys[k] = true
+	}
+
+	changes := []ConfigChange{}
+	for k := range keys {
+		v1, v2 := utils.Atoa(dc1.config[k]), utils.Atoa(dc2.config[k])
+		if v1 != v2 {
+			changes = append(changes, ConfigChange{Key: k, Old: v1, New: v2})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package topology
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+const (
+	REGEX_MEMORY_SIZE = `^(\d+(?:\.\d+)?)\s*([kKmMgGtT]?)(?:[iI]?[bB])?$`
+)
+
+var memoryUnits = map[string]uint64{
+	"":  1,
+	"k": 1 << 10,
+	"m": 1 << 20,
+	"g": 1 << 30,
+	"t": 1 << 40,
+}
+
+// ParseCpus parses cpus (e.g: 2, 0.5) which same as docker --cpus
+func ParseCpus(cpus string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(cpus), 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// ParseMemory parses memory size (e.g: 512m, 8g, 8GiB) into bytes,
+// the unit is binary which same as docker --memory
+func ParseMemory(memory string) (uint64, bool) {
+	mu := regexp.MustCompile(REGEX_MEMORY_SIZE).FindStringSubmatch(strings.TrimSpace(memory))
+	if len(mu) == 0 {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(mu[1], 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return uint64(n * float64(memoryUnits[strings.ToLower(mu[2])])), true
+}
+
+// ParseCpusetCpus parses cpu list (e.g: 0-3,8) into cpu indexs
+func ParseCpusetCpus(cpuset string) ([]int, bool) {
+	cpus := []int{}
+	for _, item := range strings.Split(strings.TrimSpace(cpuset), ",") {
+		bounds := strings.Split(strings.TrimSpace(item), "-")
+		if len(bounds) > 2 {
+			return nil, false
+		}
+		start, err := strconv.Atoi(bounds[0])
+		if err != nil || start < 0 {
+			return nil, false
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.Atoi(bounds[1])
+			if err != nil || end < start {
+				return nil, false
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, true
+}
+
+func (dc *DeployConfig) checkResources() error {
+	if cpus := dc.GetResourcesCpus(); len(cpus) > 0 {
+		if _, ok := ParseCpus(cpus); !ok {
+			return errno.ERR_INVALID_RESOURCES_CPUS.
+				F("%s: %s", CONFIG_RESOURCES_CPUS.key, cpus)
+		}
+	}
+	if memory := dc.GetResourcesMemory(); len(memory) > 0 {
+		if _, ok := ParseMemory(memory); !ok {
+			return errno.ERR_INVALID_RESOURCES_MEMORY.
+				F("%s: %s", CONFIG_RESOURCES_MEMORY.key, memory)
+		}
+	}
+	if cpuset := dc.GetResourcesCpusetCpus(); len(cpuset) > 0 {
+		if _, ok := ParseCpusetCpus(cpuset); !ok {
+			return errno.ERR_INVALID_RESOURCES_CPUSET_CPUS.
+				F("%s: %s", CONFIG_RESOURCES_CPUSET_CPUS.key, cpuset)
+		}
+	}
+	return nil
+}
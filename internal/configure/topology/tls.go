@@ -0,0 +1,209 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	TLS_CA_CERT = "${tls_ca_cert}"
+	TLS_CERT    = "${tls_cert}"
+	TLS_KEY     = "${tls_key}"
+
+	SCHEME_HTTP  = "http"
+	SCHEME_HTTPS = "https"
+)
+
+var (
+	// the service config items which enable TLS if tls.enable is true,
+	// items not exist in the config file of service are completed by
+	// CompleteTLSConfig.
+	tlsServiceConfigs = map[string]map[string]string{
+		ROLE_ETCD: { // both client-transport-security and peer-transport-security
+			"cert-file":        TLS_CERT,
+			"key-file":         TLS_KEY,
+			"trusted-ca-file":  TLS_CA_CERT,
+			"client-cert-auth": "true",
+		},
+		ROLE_MDS: { // etcd client
+			"etcd.tls.enable":    "true",
+			"etcd.tls.ca_file":   TLS_CA_CERT,
+			"etcd.tls.cert_file": TLS_CERT,
+			"etcd.tls.key_file":  TLS_KEY,
+		},
+		ROLE_SNAPSHOTCLONE: { // etcd client and HTTP server
+			"etcd.tls.enable":    "true",
+			"etcd.tls.ca_file":   TLS_CA_CERT,
+			"etcd.tls.cert_file": TLS_CERT,
+			"etcd.tls.key_file":  TLS_KEY,
+			"server.ssl.enable":  "true",
+			"server.ssl.cert":    TLS_CERT,
+			"server.ssl.key":     TLS_KEY,
+		},
+	}
+
+	// the sections of etcd.conf which consist of tlsServiceConfigs[ROLE_ETCD]
+	tlsEtcdSections = []string{"client-transport-security", "peer-transport-security"}
+
+	// the etcd URLs which switched to https if tls.enable is true
+	tlsEtcdURLConfigs = map[string]bool{
+		"listen-client-urls":          true,
+		"advertise-client-urls":       true,
+		"listen-peer-urls":            true,
+		"initial-advertise-peer-urls": true,
+		"initial-cluster":             true,
+	}
+)
+
+// GetEtcdScheme returns the scheme of etcd client and peer URLs
+func (dc *DeployConfig) GetEtcdScheme() string {
+	if dc.GetTLSEnable() {
+		return SCHEME_HTTPS
+	}
+	return SCHEME_HTTP
+}
+
+/*
+ * GetTLSClientOptions returns the options for etcdctl and curl (both accept
+ * the same flags) to access services with the certificate of this service,
+ * it's empty if TLS disabled.
+ */
+func (dc *DeployConfig) GetTLSClientOptions() string {
+	if !dc.GetTLSEnable() {
+		return ""
+	}
+	layout := dc.GetProjectLayout()
+	return fmt.Sprintf("--cacert %s --cert %s --key %s",
+		layout.ServiceCACertPath, layout.ServiceCertPath, layout.ServiceKeyPath)
+}
+
+func (dc *DeployConfig) renderTLSConfig(v string) string {
+	layout := dc.GetProjectLayout()
+	return strings.NewReplacer(
+		TLS_CA_CERT, layout.ServiceCACertPath,
+		TLS_CERT, layout.ServiceCertPath,
+		TLS_KEY, layout.ServiceKeyPath,
+	).Replace(v)
+}
+
+func tlsConfigKeys(role string) []string {
+	keys := []string{}
+	for key := range tlsServiceConfigs[role] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// the section of yaml which starts at line i, it ends before next top-level item
+func yamlSectionEnd(lines []string, i int) int {
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if len(strings.TrimSpace(line)) > 0 && line[0] != ' ' && line[0] != '\t' && line[0] != '#' {
+			break
+		}
+	}
+	return i
+}
+
+/*
+ * CompleteTLSConfig adds the items which enable TLS but lack in the main
+ * config file of service (e.g. the template of old image), lines are the
+ * mutated config file, the delimiter splits key and value:
+ *
+ *   mds.conf:  "etcd.tls.enable=true" appended if no item "etcd.tls.enable"
+ *   etcd.conf: the *-transport-security sections are replaced as a whole,
+ *              because their items are nested and may be commented out
+ */
+func (dc *DeployConfig) CompleteTLSConfig(lines []string, delimiter string) []string {
+	role := dc.GetRole()
+	if !dc.GetTLSEnable() || len(tlsServiceConfigs[role]) == 0 {
+		return lines
+	}
+
+	keys := tlsConfigKeys(role)
+	if role == ROLE_ETCD {
+		out := []string{}
+		sections := utils.Slice2Map(tlsEtcdSections)
+		for i := 0; i < len(lines); i++ {
+			if sections[strings.TrimSuffix(strings.TrimSpace(lines[i]), ":")] && lines[i][0] != ' ' {
+				i = yamlSectionEnd(lines, i) - 1
+				continue
+			}
+			out = append(out, lines[i])
+		}
+		for _, section := range tlsEtcdSections {
+			out = append(out, section+":")
+			for _, key := range keys {
+				value := dc.renderTLSConfig(tlsServiceConfigs[role][key])
+				out = append(out, fmt.Sprintf("  %s%s%s", key, delimiter, value))
+			}
+		}
+		return out
+	}
+
+	exist := map[string]bool{}
+	sep := strings.TrimSpace(delimiter)
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if i := strings.Index(line, sep); i > 0 && !strings.HasPrefix(line, "#") {
+			exist[strings.ToLower(strings.TrimSpace(line[:i]))] = true
+		}
+	}
+	for _, key := range keys {
+		if !exist[key] {
+			value := dc.renderTLSConfig(tlsServiceConfigs[role][key])
+			lines = append(lines, fmt.Sprintf("%s%s%s", key, delimiter, value))
+		}
+	}
+	return lines
+}
+
+/*
+ * MutateTLSConfig returns the value of service config item which enables TLS,
+ * the value is returned unchanged if TLS disabled or the item irrelevant, e.g:
+ *
+ *   etcd.conf: "  cert-file: " => "  cert-file: /curvebs/etcd/conf/server.crt"
+ *   etcd.conf: "listen-client-urls: http://10.0.0.1:2379" => "https://10.0.0.1:2379"
+ */
+func (dc *DeployConfig) MutateTLSConfig(key, value string) string {
+	if !dc.GetTLSEnable() {
+		return value
+	}
+
+	key = strings.ToLower(strings.TrimSpace(key))
+	role := dc.GetRole()
+	if role == ROLE_ETCD && tlsEtcdURLConfigs[key] {
+		return strings.ReplaceAll(value, "http://", "https://")
+	}
+
+	v, ok := tlsServiceConfigs[role][key]
+	if !ok {
+		return value
+	}
+	return dc.renderTLSConfig(v)
+}
@@ -26,6 +26,8 @@ package topology
 
 import (
 	"bytes"
+	"fmt"
+	"time"
 
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/utils"
@@ -57,6 +59,13 @@ type (
 		MetaserverServices    Service `mapstructure:"metaserver_services"`
 		ChunkserverServices   Service `mapstructure:"chunkserver_services"`
 		SnapshotcloneServices Service `mapstructure:"snapshotclone_services"`
+		FSMdsServices         Service `mapstructure:"fs_mds_services"` // mixed topology only
+	}
+
+	section struct {
+		kind     string
+		role     string
+		services Service
 	}
 )
 
@@ -72,8 +81,19 @@ var (
 		ROLE_MDS,
 		ROLE_METASERVER,
 	}
+	MIXED_ROLES = []string{
+		ROLE_ETCD,
+		ROLE_MDS,
+		ROLE_CHUNKSERVER,
+		ROLE_SNAPSHOTCLONE,
+		ROLE_METASERVER,
+	}
 )
 
+// the name prefix of curvefs mds in mixed topology,
+// which makes its id differ from curvebs mds on the same host
+const MIXED_FS_MDS_NAME_PREFIX = "fs"
+
 func merge(parent, child map[string]interface{}, deep int) {
 	for k, v := range parent {
 		if child[k] == nil {
@@ -94,7 +114,80 @@ func newIfNil(config map[string]interface{}) map[string]interface{} {
 	return config
 }
 
+func (topology *Topology) getServices(role string) Service {
+	switch role {
+	case ROLE_ETCD:
+		return topology.EtcdServices
+	case ROLE_MDS:
+		return topology.MdsServices
+	case ROLE_CHUNKSERVER:
+		return topology.ChunkserverServices
+	case ROLE_SNAPSHOTCLONE:
+		return topology.SnapshotcloneServices
+	case ROLE_METASERVER:
+		return topology.MetaserverServices
+	}
+	return Service{}
+}
+
+/*
+ * mixed topology deploys curvebs and curvefs in one cluster:
+ *   etcd_services           shared by curvebs and curvefs (kind: curvebs)
+ *   mds_services            curvebs mds
+ *   chunkserver_services    curvebs chunkserver
+ *   snapshotclone_services  curvebs snapshotclone
+ *   fs_mds_services         curvefs mds
+ *   metaserver_services     curvefs metaserver
+ */
+func (topology *Topology) sections() ([]section, error) {
+	kind := topology.Kind
+	sections := []section{}
+	switch kind {
+	case KIND_CURVEBS, KIND_CURVEFS:
+		roles := CURVEBS_ROLES
+		if kind == KIND_CURVEFS {
+			roles = CURVEFS_ROLES
+		}
+		for _, role := range roles {
+			sections = append(sections, section{kind, role, topology.getServices(role)})
+		}
+	case KIND_MIXED:
+		for _, role := range MIXED_ROLES {
+			k := utils.Choose(role == ROLE_METASERVER, KIND_CURVEFS, KIND_CURVEBS)
+			sections = append(sections, section{k, role, topology.getServices(role)})
+			if role == ROLE_MDS {
+				sections = append(sections, section{KIND_CURVEFS, role, topology.FSMdsServices})
+			}
+		}
+	default:
+		return nil, errno.ERR_UNSUPPORT_CLUSTER_KIND
+	}
+	return sections, nil
+}
+
+// ParseTopology parses topology into deploy configs, which reused if parsed recently
 func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
+	if ctx == nil {
+		ctx = NewContext()
+	}
+	key, now := cacheKey(data, ctx), time.Now()
+	if dcs, ok := tcache.get(key, now); ok {
+		return dcs, nil
+	} else if dcs, ok := loadCache(key, ctx); ok {
+		tcache.put(key, dcs, now)
+		return append([]*DeployConfig{}, dcs...), nil
+	}
+
+	dcs, err := parseTopology(data, ctx)
+	if err != nil {
+		return nil, err
+	}
+	tcache.put(key, dcs, now)
+	storeCache(key, data, dcs)
+	return dcs, nil
+}
+
+func parseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 	if len(data) == 0 {
 		return nil, errno.ERR_EMPTY_CLUSTER_TOPOLOGY
 	}
@@ -113,32 +206,15 @@ func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 	}
 
 	// check topology kind
-	kind := topology.Kind
-	roles := []string{}
-	if kind == KIND_CURVEBS {
-		roles = append(roles, CURVEBS_ROLES...)
-	} else if kind == KIND_CURVEFS {
-		roles = append(roles, CURVEFS_ROLES...)
-	} else {
-		return nil, errno.ERR_UNSUPPORT_CLUSTER_KIND
+	sections, err := topology.sections()
+	if err != nil {
+		return nil, err
 	}
 
 	dcs := []*DeployConfig{}
 	globalConfig := newIfNil(topology.Global)
-	for _, role := range roles {
-		services := Service{}
-		switch role {
-		case ROLE_ETCD:
-			services = topology.EtcdServices
-		case ROLE_MDS:
-			services = topology.MdsServices
-		case ROLE_CHUNKSERVER:
-			services = topology.ChunkserverServices
-		case ROLE_SNAPSHOTCLONE:
-			services = topology.SnapshotcloneServices
-		case ROLE_METASERVER:
-			services = topology.MetaserverServices
-		}
+	for _, section := range sections {
+		kind, role, services := section.kind, section.role, section.services
 
 		// merge global config into services config
 		servicesConfig := newIfNil(services.Config)
@@ -168,9 +244,15 @@ func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 				instances = deploy.Replica
 			}
 
+			name := deploy.Name
+			if topology.Kind == KIND_MIXED && kind == KIND_CURVEFS &&
+				role == ROLE_MDS && len(name) == 0 {
+				name = fmt.Sprintf("%s%d", MIXED_FS_MDS_NAME_PREFIX, hostSequence)
+			}
+
 			for instancesSequence := 0; instancesSequence < instances; instancesSequence++ {
 				dc, err := NewDeployConfig(ctx, kind,
-					role, deploy.Host, deploy.Name, instances,
+					role, deploy.Host, name, instances,
 					hostSequence, instancesSequence, utils.DeepCopy(deployConfig))
 				if err != nil {
 					return nil, err // already is error code
@@ -196,16 +278,75 @@ func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 		}
 	}
 
-	// add cluster variables
-	for idx, dc := range dcs {
-		if err = AddClusterVariables(dcs, idx); err != nil {
-			return nil, err // already is error code
-		} else if err = dc.GetVariables().Build(); err != nil {
-			return nil, errno.ERR_RESOLVE_VARIABLE_FAILED.E(err)
-		}
+	// add cluster variables, the services of mixed topology only
+	// see the cluster which has the same kind with them
+	for _, part := range SplitByKind(dcs) {
+		kind := part[0].GetKind()
+		for idx, dc := range part {
+			if dc.GetKind() != kind { // shared etcd
+				continue
+			} else if err = AddClusterVariables(part, idx); err != nil {
+				return nil, err // already is error code
+			} else if err = dc.GetVariables().Build(); err != nil {
+				return nil, errno.ERR_RESOLVE_VARIABLE_FAILED.E(err)
+			}
 
-		dc.GetVariables().Debug()
+			dc.GetVariables().Debug()
+		}
 	}
 
 	return dcs, nil
 }
+
+// IsMixed returns true if the services come from mixed topology
+func IsMixed(dcs []*DeployConfig) bool {
+	for _, dc := range dcs {
+		if dc.GetKind() != dcs[0].GetKind() {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ * SplitByKind splits services of mixed topology into curvebs part and
+ * curvefs part, the shared etcd services are appended to the tail of
+ * curvefs part, so the first service of each part always tell its kind.
+ * The services of single kind topology are returned as one part.
+ */
+func SplitByKind(dcs []*DeployConfig) [][]*DeployConfig {
+	if !IsMixed(dcs) {
+		return [][]*DeployConfig{dcs}
+	}
+
+	bs, fs, etcd := []*DeployConfig{}, []*DeployConfig{}, []*DeployConfig{}
+	for _, dc := range dcs {
+		if dc.GetKind() == KIND_CURVEFS {
+			fs = append(fs, dc)
+			continue
+		}
+		bs = append(bs, dc)
+		if dc.GetRole() == ROLE_ETCD {
+			etcd = append(etcd, dc)
+		}
+	}
+	return [][]*DeployConfig{bs, append(fs, etcd...)}
+}
+
+// GetKind returns the kind of cluster which services belong to
+func GetKind(dcs []*DeployConfig) string {
+	if IsMixed(dcs) {
+		return KIND_MIXED
+	}
+	return dcs[0].GetKind()
+}
+
+// SelectByKind returns the part of services which belong to specified kind
+func SelectByKind(dcs []*DeployConfig, kind string) []*DeployConfig {
+	for _, part := range SplitByKind(dcs) {
+		if part[0].GetKind() == kind {
+			return part
+		}
+	}
+	return []*DeployConfig{}
+}
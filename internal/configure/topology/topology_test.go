@@ -0,0 +1,251 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	MIXED_TOPOLOGY = `
+kind: mixed
+global:
+  log_dir: /data/logs/${service_role}
+  data_dir: /data/${service_role}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 8200
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+fs_mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6701
+    listen.dummy_port: 7701
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+
+metaserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6800
+    listen.external_port: 7800
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+)
+
+func parseMixedTopology(t *testing.T) []*DeployConfig {
+	ctx := NewContext()
+	for _, host := range []string{"host1", "host2", "host3"} {
+		ctx.Add(host, host)
+	}
+	dcs, err := ParseTopology(MIXED_TOPOLOGY, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func TestParseMixedTopology(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseMixedTopology(t)
+	assert.Len(dcs, 15)
+	assert.True(IsMixed(dcs))
+	assert.Equal(KIND_MIXED, GetKind(dcs))
+
+	// curvebs mds and curvefs mds on the same host have different id
+	ids := map[string]bool{}
+	for _, dc := range dcs {
+		assert.False(ids[dc.GetId()], dc.GetId())
+		ids[dc.GetId()] = true
+	}
+
+	// each mds only see the mds of its kind
+	for _, dc := range dcs {
+		if dc.GetRole() != ROLE_MDS {
+			continue
+		}
+		addr, err := dc.GetVariables().Get("cluster_mds_addr")
+		assert.Nil(err)
+		port := "6700"
+		if dc.GetKind() == KIND_CURVEFS {
+			port = "6701"
+		}
+		assert.Equal("host1:"+port+",host2:"+port+",host3:"+port, addr)
+
+		etcd, err := dc.GetVariables().Get("cluster_etcd_addr")
+		assert.Nil(err)
+		assert.Equal("host1:2379,host2:2379,host3:2379", etcd)
+	}
+}
+
+func TestSplitByKind(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseMixedTopology(t)
+
+	parts := SplitByKind(dcs)
+	assert.Len(parts, 2)
+	assert.Equal(KIND_CURVEBS, parts[0][0].GetKind())
+	assert.Len(parts[0], 9) // etcd, mds, chunkserver
+	assert.Equal(KIND_CURVEFS, parts[1][0].GetKind())
+	assert.Len(parts[1], 9) // mds, metaserver, shared etcd
+	assert.Equal(ROLE_ETCD, parts[1][8].GetRole())
+
+	assert.Len(SelectByKind(dcs, KIND_CURVEFS), 9)
+	assert.Len(SplitByKind(parts[0]), 1)
+}
+
+func TestMutateTLSConfig(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseMixedTopology(t)
+	etcd, mds := dcs[0], dcs[3]
+	assert.Equal(ROLE_ETCD, etcd.GetRole())
+	assert.Equal(ROLE_MDS, mds.GetRole())
+
+	// tls disabled
+	assert.False(etcd.GetTLSEnable())
+	assert.Equal("http://host1:2379", etcd.MutateTLSConfig("listen-client-urls", "http://host1:2379"))
+	assert.Equal("", etcd.MutateTLSConfig("  cert-file", ""))
+
+	// tls enabled
+	for _, dc := range []*DeployConfig{etcd, mds} {
+		dc.config[CONFIG_TLS_ENABLE.key] = true
+	}
+	assert.Equal("https://host1:2379", etcd.MutateTLSConfig("listen-client-urls", "http://host1:2379"))
+	assert.Equal("etcd1=https://host1:2380,etcd2=https://host2:2380",
+		etcd.MutateTLSConfig("initial-cluster", "etcd1=http://host1:2380,etcd2=http://host2:2380"))
+	assert.Equal("/curvebs/etcd/conf/server.crt", etcd.MutateTLSConfig("  cert-file", ""))
+	assert.Equal("/curvebs/etcd/conf/ca.crt", etcd.MutateTLSConfig("  trusted-ca-file", ""))
+	assert.Equal("/curvebs/mds/conf/server.key", mds.MutateTLSConfig("etcd.tls.key_file", ""))
+	assert.Equal("6700", mds.MutateTLSConfig("mds.listen.port", "6700"))
+	assert.Equal("https", etcd.GetEtcdScheme())
+	assert.Equal("--cacert /curvebs/mds/conf/ca.crt --cert /curvebs/mds/conf/server.crt "+
+		"--key /curvebs/mds/conf/server.key", mds.GetTLSClientOptions())
+}
+
+func TestCompleteTLSConfig(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseMixedTopology(t)
+	etcd, mds := dcs[0], dcs[3]
+
+	// tls disabled
+	for _, dc := range []*DeployConfig{etcd, mds} {
+		dc.config[CONFIG_TLS_ENABLE.key] = false
+	}
+	lines := []string{"mds.listen.addr=127.0.0.1:6700"}
+	assert.Equal(lines, mds.CompleteTLSConfig(lines, "="))
+
+	// lacked items are appended
+	for _, dc := range []*DeployConfig{etcd, mds} {
+		dc.config[CONFIG_TLS_ENABLE.key] = true
+	}
+	lines = []string{"mds.listen.addr=127.0.0.1:6700", "etcd.tls.enable=true", "#etcd.tls.ca_file=/etc/ca.crt"}
+	assert.Equal([]string{
+		"mds.listen.addr=127.0.0.1:6700",
+		"etcd.tls.enable=true",
+		"#etcd.tls.ca_file=/etc/ca.crt",
+		"etcd.tls.ca_file=/curvebs/mds/conf/ca.crt",
+		"etcd.tls.cert_file=/curvebs/mds/conf/server.crt",
+		"etcd.tls.key_file=/curvebs/mds/conf/server.key",
+	}, mds.CompleteTLSConfig(lines, "="))
+
+	// sections of etcd are replaced
+	lines = []string{
+		"name: etcd1",
+		"client-transport-security:",
+		"  # Path to the client server TLS cert file.",
+		"  cert-file:",
+		"",
+		"  client-cert-auth: false",
+		"debug: false",
+	}
+	security := []string{
+		"  cert-file: /curvebs/etcd/conf/server.crt",
+		"  client-cert-auth: true",
+		"  key-file: /curvebs/etcd/conf/server.key",
+		"  trusted-ca-file: /curvebs/etcd/conf/ca.crt",
+	}
+	expect := []string{"name: etcd1", "debug: false", "client-transport-security:"}
+	expect = append(expect, security...)
+	expect = append(expect, "peer-transport-security:")
+	expect = append(expect, security...)
+	assert.Equal(expect, etcd.CompleteTLSConfig(lines, ": "))
+}
+
+func TestIPv6Variables(t *testing.T) {
+	assert := assert.New(t)
+	ctx := NewContext()
+	for i, host := range []string{"host1", "host2", "host3"} {
+		ctx.Add(host, fmt.Sprintf("fd00::%d", i+1))
+	}
+	dcs, err := ParseTopology(MIXED_TOPOLOGY, ctx)
+	assert.Nil(err)
+
+	etcd, mds := dcs[0], dcs[3]
+	assert.Equal("fd00::1", etcd.GetListenIp())
+	render := func(dc *DeployConfig, value string) string {
+		out, err := dc.GetVariables().Rendering(BracketAddress(value))
+		assert.Nil(err)
+		return out
+	}
+	assert.Equal("fd00::1", render(mds, "${service_addr}"))
+	assert.Equal("[fd00::1]:6700", render(mds, "${service_addr}:${service_port}"))
+	assert.Equal("::", render(mds, "${service_any_addr}"))
+	assert.Equal("[fd00::1]:6700,[fd00::2]:6700,[fd00::3]:6700", render(mds, "${cluster_mds_addr}"))
+	assert.Equal("etcd00=http://[fd00::1]:2380,etcd10=http://[fd00::2]:2380,etcd20=http://[fd00::3]:2380",
+		render(etcd, "${cluster_etcd_http_addr}"))
+
+	dcs = parseMixedTopology(t)
+	assert.Equal("host1:6700", render(dcs[3], "${service_addr}:${service_port}"))
+	assert.Equal("0.0.0.0", render(dcs[3], "${service_any_addr}"))
+}
@@ -68,6 +68,9 @@ type Var struct {
  *   ${service_proxy_port}         "8080" (snapshotclone)
  *   ${service_external_addr}      "10.0.10.1" (chunkserver/metaserver)
  *   ${service_external_port}      "7800" (metaserver)
+ *   ${service_bracket_addr}       "10.0.0.1" or "[fd00::1]", for "${service_bracket_addr}:${service_port}"
+ *   ${service_bracket_external_addr} "10.0.10.1" or "[fd00:10::1]" (chunkserver/metaserver)
+ *   ${service_any_addr}           "0.0.0.0" or "::", wildcard address of the same family as service_addr
  *   ${log_dir}                    "/data/logs"
  *   ${data_dir}                   "/data"
  *   ${random_uuid}                "6fa8f01c411d7655d0354125c36847bb"
@@ -84,6 +87,8 @@ type Var struct {
  *   ${cluster_snapshotclone_dummy_port}      "8081,8082,8083"
  *   ${cluster_snapshotclone_nginx_upstream}  "server 10.0.0.1:5555; server 10.0.0.3:5555; server 10.0.0.3:5555;"
  *   ${cluster_metaserver_addr}               "10.0.10.1:6701,10.0.10.2:6701,10.0.10.3:6701"
+ *
+ * NOTE: the IPv6 address followed by port is bracketed, e.g. "[fd00::1]:2380"
  */
 var (
 	serviceVars = []Var{
@@ -105,6 +110,9 @@ var (
 		{name: "service_proxy_port", role: []string{ROLE_SNAPSHOTCLONE}},
 		{name: "service_external_addr", role: []string{ROLE_CHUNKSERVER, ROLE_METASERVER}, lookup: true},
 		{name: "service_external_port", role: []string{ROLE_METASERVER}},
+		{name: "service_bracket_addr"},
+		{name: "service_bracket_external_addr", role: []string{ROLE_CHUNKSERVER, ROLE_METASERVER}},
+		{name: "service_any_addr"},
 		{name: "log_dir"},
 		{name: "data_dir"},
 		{name: "random_uuid"},
@@ -179,7 +187,8 @@ func joinEtcdPeer(dcs []*DeployConfig) string {
 		instanceSquence := dc.GetInstancesSequence()
 		peerHost := dc.GetListenIp()
 		peerPort := dc.GetListenPort()
-		peer := fmt.Sprintf("etcd%d%d=http://%s:%d", hostSequence, instanceSquence, peerHost, peerPort)
+		peer := fmt.Sprintf("etcd%d%d=%s://%s", hostSequence, instanceSquence,
+			dc.GetEtcdScheme(), utils.JoinHostPort(peerHost, peerPort))
 		peers = append(peers, peer)
 	}
 	return strings.Join(peers, ",")
@@ -202,7 +211,7 @@ func joinPeer(dcs []*DeployConfig, selectRole string, selectPort int) string {
 		case SELECT_LISTEN_PROXY_PORT:
 			peerPort = dc.GetListenProxyPort()
 		}
-		peer := fmt.Sprintf("%s:%d", peerHost, peerPort)
+		peer := utils.JoinHostPort(peerHost, peerPort)
 		peers = append(peers, peer)
 	}
 	return strings.Join(peers, ",")
@@ -227,7 +236,7 @@ func joinNginxUpstreamServer(dcs []*DeployConfig) string {
 		}
 		peerHost := dc.GetListenIp()
 		peerPort := dc.GetListenPort()
-		server := fmt.Sprintf("server %s:%d;", peerHost, peerPort)
+		server := fmt.Sprintf("server %s;", utils.JoinHostPort(peerHost, peerPort))
 		servers = append(servers, server)
 	}
 	return strings.Join(servers, " ")
@@ -275,6 +284,10 @@ func getValue(name string, dcs []*DeployConfig, idx int) string {
 			return utils.Atoa(dc.get(CONFIG_LISTEN_EXTERNAL_PORT))
 		}
 		return utils.Atoa(dc.get(CONFIG_LISTEN_PORT))
+	case "service_bracket_addr", "service_any_addr": // see resolveAddressVariables
+		return "${service_addr}"
+	case "service_bracket_external_addr":
+		return "${service_external_addr}"
 	case "log_dir":
 		return dc.GetProjectLayout().ServiceLogDir
 	case "data_dir":
@@ -307,3 +320,42 @@ func getValue(name string, dcs []*DeployConfig, idx int) string {
 
 	return ""
 }
+
+/*
+ * resolveAddressVariables derives the address variables from the resolved
+ * listen ip, which maybe another variable (e.g. ${service_host}) before.
+ */
+func resolveAddressVariables(vars *variable.Variables) error {
+	derives := []struct {
+		name   string
+		from   string
+		derive func(string) string
+	}{
+		{"service_bracket_addr", "service_addr", utils.BracketHost},
+		{"service_bracket_external_addr", "service_external_addr", utils.BracketHost},
+		{"service_any_addr", "service_addr", utils.AnyAddress},
+	}
+	for _, d := range derives {
+		from, err := vars.Get(d.from)
+		if err != nil { // not registered for this role
+			continue
+		} else if err := vars.Set(d.name, d.derive(from)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * BracketAddress rewrites address followed by port in service config
+ * template (e.g. "${service_addr}:${service_port}") into its bracketed
+ * variable, so it's still a valid endpoint if the address is IPv6.
+ */
+func BracketAddress(value string) string {
+	return addrReplacer.Replace(value)
+}
+
+var addrReplacer = strings.NewReplacer(
+	"${service_addr}:", "${service_bracket_addr}:",
+	"${service_external_addr}:", "${service_bracket_external_addr}:",
+)
@@ -0,0 +1,233 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+const (
+	DEFAULT_TEMPLATE_HOSTS          = 3
+	DEFAULT_TEMPLATE_DISKS_PER_HOST = 1
+	MAX_TEMPLATE_DISKS_PER_HOST     = 100 // chunkserver listens on 82${format_instances_sequence}
+	TEMPLATE_HOST_NAME              = "server-host%d"
+	TEMPLATE_COORDINATORS           = 3 // etcd and mds, odd number for quorum
+
+	TEMPLATE_TOPOLOGY = `# Generated by 'curveadm topology generate', please review it before deploying:
+#   1) replace server-host* with the hosts committed by 'curveadm hosts commit'
+{{- if eq .Kind "curvebs"}}
+#   2) mount disks on data_dir of chunkservers by 'curveadm format'
+#   3) snapshotclone services require S3, add them if needed
+{{- end}}
+#
+# Deployment: {{.Hosts}} hosts{{if eq .Kind "curvebs"}}, {{.DisksPerHost}} disks per host{{end}}, {{.Replicas}} replicas
+# Zones: each copyset places one replica in each zone, hosts are assigned round-robin
+{{- range .Zones}}
+#   {{.Name}}: {{.Hosts}}
+{{- end}}
+{{- if .Unbalanced}}
+#   NOTE: hosts are not a multiple of replicas, capacity of zones is unbalanced
+{{- end}}
+# Copysets: {{.Servers}} {{.Role}}s * {{.Copysets}} copysets / {{.Replicas}} replicas = {{.TotalCopysets}} copysets in pool1
+
+kind: {{.Kind}}
+global:
+  container_image: {{.ContainerImage}}
+  log_dir: ${home}/logs/${service_role}${service_host_sequence}
+  data_dir: ${home}/data/${service_role}${service_host_sequence}
+  variable:
+    home: /data
+{{- range .Machines}}
+    machine{{.Index}}: {{.Name}}
+{{- end}}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+{{- range .Coordinators}}
+    - host: ${machine{{.Index}}}
+{{- end}}
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+{{- range .Coordinators}}
+    - host: ${machine{{.Index}}}
+{{- end}}
+{{- if eq .Kind "curvebs"}}
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 82${format_instances_sequence}
+    data_dir: /data/chunkserver${service_instances_sequence}  # disk N is mounted on /data/chunkserverN
+    copysets: {{.Copysets}}
+    copyset_replicas: {{.Replicas}}
+  deploy:
+{{- range .Machines}}
+    - host: ${machine{{.Index}}}
+{{- if gt $.DisksPerHost 1}}
+      instances: {{$.DisksPerHost}}
+{{- end}}
+{{- end}}
+{{- else}}
+
+metaserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6800
+    listen.external_port: 7800
+    copysets: {{.Copysets}}
+    copyset_replicas: {{.Replicas}}
+  deploy:
+{{- range .Machines}}
+    - host: ${machine{{.Index}}}
+{{- end}}
+{{- end}}
+`
+)
+
+type (
+	TopologyTemplateOptions struct {
+		Kind           string
+		Hosts          int
+		DisksPerHost   int // curvebs only, one chunkserver per disk
+		Replicas       int
+		Copysets       int // copysets per chunkserver or metaserver
+		ContainerImage string
+	}
+
+	templateMachine struct {
+		Index int
+		Name  string
+	}
+
+	templateZone struct {
+		Name  string
+		Hosts string
+	}
+)
+
+func (options TopologyTemplateOptions) check() error {
+	if options.Kind != topology.KIND_CURVEBS && options.Kind != topology.KIND_CURVEFS {
+		return errno.ERR_INVALID_TOPOLOGY_GENERATE_OPTIONS.
+			F("kind: %s, it must be curvebs or curvefs", options.Kind)
+	} else if options.Hosts <= 0 || options.Replicas <= 0 || options.Copysets <= 0 {
+		return errno.ERR_INVALID_TOPOLOGY_GENERATE_OPTIONS.
+			F("hosts, replicas and copysets require positive integer")
+	} else if options.Replicas > options.Hosts {
+		return errno.ERR_INVALID_TOPOLOGY_GENERATE_OPTIONS.
+			F("%d replicas require at least %d hosts, one zone per replica", options.Replicas, options.Replicas)
+	} else if options.Kind == topology.KIND_CURVEBS &&
+		(options.DisksPerHost <= 0 || options.DisksPerHost > MAX_TEMPLATE_DISKS_PER_HOST) {
+		return errno.ERR_INVALID_TOPOLOGY_GENERATE_OPTIONS.
+			F("disks per host must be between 1 and %d", MAX_TEMPLATE_DISKS_PER_HOST)
+	}
+	return nil
+}
+
+// zones are assigned round-robin by host, the same as creating pool
+func templateZones(machines []templateMachine, replicas int) []templateZone {
+	hosts := make([][]string, replicas)
+	for i, machine := range machines {
+		hosts[i%replicas] = append(hosts[i%replicas], machine.Name)
+	}
+	zones := []templateZone{}
+	for i := range hosts {
+		zones = append(zones, templateZone{
+			Name:  fmt.Sprintf("zone%d", i+1),
+			Hosts: strings.Join(hosts[i], ", "),
+		})
+	}
+	return zones
+}
+
+/*
+ * GenTopologyTemplate generates topology for deployment size by best practice:
+ *   - 3 etcd and mds (1 if less than 3 hosts) for quorum
+ *   - one chunkserver per disk (curvebs) or one metaserver per host (curvefs)
+ *   - zones as many as replicas, so replicas of copyset are in different hosts
+ */
+func GenTopologyTemplate(options TopologyTemplateOptions) (string, error) {
+	if err := options.check(); err != nil {
+		return "", err
+	}
+
+	machines := []templateMachine{}
+	for i := 1; i <= options.Hosts; i++ {
+		machines = append(machines, templateMachine{Index: i, Name: fmt.Sprintf(TEMPLATE_HOST_NAME, i)})
+	}
+	coordinators := machines[:1]
+	if len(machines) >= TEMPLATE_COORDINATORS {
+		coordinators = machines[:TEMPLATE_COORDINATORS]
+	}
+	role, servers := topology.ROLE_CHUNKSERVER, options.Hosts*options.DisksPerHost
+	if options.Kind == topology.KIND_CURVEFS {
+		role, servers = topology.ROLE_METASERVER, options.Hosts
+	}
+	totalCopysets := servers * options.Copysets / options.Replicas
+	if totalCopysets == 0 {
+		totalCopysets = 1
+	}
+	containerImage := options.ContainerImage
+	if len(containerImage) == 0 {
+		containerImage = DefaultWizardContainerImage(options.Kind)
+	}
+
+	tmpl, err := template.New("topology").Option("missingkey=error").Parse(TEMPLATE_TOPOLOGY)
+	if err != nil {
+		return "", errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+	buffer := bytes.NewBufferString("")
+	err = tmpl.Execute(buffer, map[string]interface{}{
+		"Kind":           options.Kind,
+		"ContainerImage": containerImage,
+		"Hosts":          options.Hosts,
+		"DisksPerHost":   options.DisksPerHost,
+		"Replicas":       options.Replicas,
+		"Copysets":       options.Copysets,
+		"Machines":       machines,
+		"Coordinators":   coordinators,
+		"Zones":          templateZones(machines, options.Replicas),
+		"Unbalanced":     options.Hosts%options.Replicas != 0,
+		"Role":           role,
+		"Servers":        servers,
+		"TotalCopysets":  totalCopysets,
+	})
+	if err != nil {
+		return "", errno.ERR_RENDER_TEMPLATE_FAILED.E(err)
+	}
+	return buffer.String(), nil
+}
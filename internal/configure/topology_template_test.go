@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseTemplateTopology(t *testing.T, data string, hosts int) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	for i := 1; i <= hosts; i++ {
+		ctx.Add(fmt.Sprintf(TEMPLATE_HOST_NAME, i), fmt.Sprintf("10.0.1.%d", i))
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func TestGenCurveBSTopologyTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := GenTopologyTemplate(TopologyTemplateOptions{
+		Kind:         topology.KIND_CURVEBS,
+		Hosts:        5,
+		DisksPerHost: 8,
+		Replicas:     3,
+		Copysets:     100,
+	})
+	assert.Nil(err)
+	assert.Contains(data, "# Deployment: 5 hosts, 8 disks per host, 3 replicas\n")
+	assert.Contains(data, "#   zone1: server-host1, server-host4\n#   zone2: server-host2, server-host5\n#   zone3: server-host3\n")
+	assert.Contains(data, "#   NOTE: hosts are not a multiple of replicas")
+	assert.Contains(data, "# Copysets: 40 chunkservers * 100 copysets / 3 replicas = 1333 copysets in pool1\n")
+
+	// the pool created from topology is the same as described
+	dcs := parseTemplateTopology(t, data, 5)
+	assert.Len(dcs, 3+3+40)
+	topo, err := GenerateDefaultClusterPool(dcs, Poolset{Name: "default", Type: "ssd"})
+	assert.Nil(err)
+	assert.Len(topo.Servers, 40)
+	assert.Equal(LogicalPool{
+		Name:         "pool1",
+		Replicas:     3,
+		Zones:        3,
+		Copysets:     1333,
+		PhysicalPool: "pool1",
+	}, topo.LogicalPools[0])
+	zones := map[string]string{}
+	for _, server := range topo.Servers {
+		zones[server.InternalIp] = server.Zone
+	}
+	assert.Equal("zone1", zones["10.0.1.4"])
+	assert.Equal("zone3", zones["10.0.1.3"])
+}
+
+func TestGenCurveFSTopologyTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := GenTopologyTemplate(TopologyTemplateOptions{
+		Kind:     topology.KIND_CURVEFS,
+		Hosts:    2,
+		Replicas: 2,
+		Copysets: 1,
+	})
+	assert.Nil(err)
+	assert.Contains(data, "# Deployment: 2 hosts, 2 replicas\n")
+	assert.NotContains(data, "chunkserver")
+	assert.NotContains(data, "NOTE")
+
+	dcs := parseTemplateTopology(t, data, 2)
+	assert.Len(dcs, 1+1+2)
+	topo, err := GenerateDefaultClusterPool(dcs, Poolset{})
+	assert.Nil(err)
+	assert.Equal(2, topo.Pools[0].Replicas)
+	assert.Equal(2, topo.Pools[0].Zones)
+	assert.Equal(1, topo.Pools[0].Copysets)
+}
+
+func TestGenTopologyTemplateInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, options := range []TopologyTemplateOptions{
+		{Kind: "curve", Hosts: 3, DisksPerHost: 1, Replicas: 3, Copysets: 100},
+		{Kind: topology.KIND_CURVEBS, Hosts: 2, DisksPerHost: 1, Replicas: 3, Copysets: 100},
+		{Kind: topology.KIND_CURVEBS, Hosts: 3, DisksPerHost: 101, Replicas: 3, Copysets: 100},
+		{Kind: topology.KIND_CURVEFS, Hosts: 3, Replicas: 3, Copysets: 0},
+	} {
+		_, err := GenTopologyTemplate(options)
+		assert.Equal(errno.ERR_INVALID_TOPOLOGY_GENERATE_OPTIONS.GetCode(), err.(*errno.ErrorCode).GetCode())
+	}
+}
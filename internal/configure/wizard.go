@@ -0,0 +1,332 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	DEFAULT_WIZARD_CURVEFS_CONTAINER_IMAGE = "opencurvedocker/curvefs:latest"
+	DEFAULT_WIZARD_FORMAT_PERCENT          = 90
+	WIZARD_CHUNKSERVER_DATA_DIR            = "/data/chunkserver" // disk N is mounted on data_dir of instance N
+	REGEX_WIZARD_NAME                      = "^[a-zA-Z0-9][a-zA-Z0-9_.-]*$"
+
+	TEMPLATE_WIZARD_HEADER = `# Generated by 'curveadm init'
+`
+
+	TEMPLATE_WIZARD_HOSTS = `global:
+  user: {{.User}}
+  ssh_port: {{.SSHPort}}
+{{- if .Password}}
+  password: {{.Password}}
+{{- else}}
+  private_key_file: {{.PrivateKeyFile}}
+{{- end}}
+
+hosts:
+{{- range .Hosts}}
+  - host: {{.Name}}
+    hostname: {{.IP}}
+{{- end}}
+`
+
+	TEMPLATE_WIZARD_FORMAT = `host:
+{{- range .Hosts}}
+  - {{.}}
+{{- end}}
+disk:
+{{- range .Disks}}
+  - {{.}}
+{{- end}}
+`
+
+	TEMPLATE_WIZARD_TOPOLOGY = `kind: {{.Kind}}
+global:
+  container_image: {{.ContainerImage}}
+  log_dir: /data/logs/${service_role}${service_host_sequence}
+  data_dir: /data/${service_role}${service_host_sequence}
+{{- range .Services}}
+
+{{.Role}}_services:
+  config:
+    listen.ip: ${service_host}
+{{- range .Config}}
+    {{.}}
+{{- end}}
+  deploy:
+{{- $instances := .Instances}}
+{{- range .Hosts}}
+    - host: {{.}}
+{{- if gt $instances 1}}
+      instances: {{$instances}}
+{{- end}}
+{{- end}}
+{{- end}}
+`
+)
+
+/*
+ * WizardConfig holds the answers of 'curveadm init', which are rendered
+ * into hosts.yaml, format.yaml and a minimal topology.yaml, e.g:
+ *
+ *   hosts:     host1 (10.0.1.1), host2 (10.0.1.2), host3 (10.0.1.3)
+ *   services:  etcd/mds/chunkserver on host1,host2,host3
+ *   disks:     /dev/sdb => /data/chunkserver0, /dev/sdc => /data/chunkserver1
+ *
+ * each chunkserver host runs one chunkserver instance per disk.
+ */
+type (
+	WizardHost struct {
+		Name string
+		IP   string
+	}
+
+	WizardConfig struct {
+		Kind           string
+		ContainerImage string
+		Hosts          []WizardHost
+		User           string
+		SSHPort        int
+		PrivateKeyFile string
+		Password       string              // secret reference, e.g: secret://my-cluster_ssh_password
+		Services       map[string][]string // role: hosts
+		Devices        []string            // curvebs only: disks of chunkserver
+		FormatPercent  int
+	}
+
+	wizardService struct {
+		Role      string
+		Config    []string
+		Hosts     []string
+		Instances int
+	}
+)
+
+// WizardRoles returns the roles of minimal cluster, snapshotclone is excluded for it requires S3
+func WizardRoles(kind string) []string {
+	if kind == topology.KIND_CURVEFS {
+		return []string{topology.ROLE_ETCD, topology.ROLE_MDS, topology.ROLE_METASERVER}
+	}
+	return []string{topology.ROLE_ETCD, topology.ROLE_MDS, topology.ROLE_CHUNKSERVER}
+}
+
+func DefaultWizardContainerImage(kind string) string {
+	if kind == topology.KIND_CURVEFS {
+		return DEFAULT_WIZARD_CURVEFS_CONTAINER_IMAGE
+	}
+	return DEFAULT_CONTAINER_IMAGE
+}
+
+// ParseWizardList splits answer separated by comma or space, e.g: "host1, host2 host3"
+func ParseWizardList(answer string) []string {
+	return strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+func checkWizardDuplicate(items []string) error {
+	exist := map[string]bool{}
+	for _, item := range items {
+		if exist[item] {
+			return errno.ERR_INVALID_INIT_ANSWER.F("duplicate item: %s", item)
+		}
+		exist[item] = true
+	}
+	return nil
+}
+
+func ValidateWizardName(name string) error {
+	if !regexp.MustCompile(REGEX_WIZARD_NAME).MatchString(name) {
+		return errno.ERR_INVALID_INIT_ANSWER.
+			F("%s: name requires letters, digits, '_', '.' or '-'", name)
+	}
+	return nil
+}
+
+func ValidateWizardIPs(ips []string) error {
+	if len(ips) == 0 {
+		return errno.ERR_INVALID_INIT_ANSWER.F("at least one host required")
+	}
+	for _, ip := range ips {
+		if net.ParseIP(ip) == nil {
+			return errno.ERR_INVALID_INIT_ANSWER.F("%s: invalid IP address", ip)
+		}
+	}
+	return checkWizardDuplicate(ips)
+}
+
+func ValidateWizardHostNames(names []string, n int) error {
+	if len(names) != n {
+		return errno.ERR_INVALID_INIT_ANSWER.
+			F("%d names for %d hosts", len(names), n)
+	}
+	for _, name := range names {
+		if err := ValidateWizardName(name); err != nil {
+			return err
+		}
+	}
+	return checkWizardDuplicate(names)
+}
+
+func ValidateWizardSSHPort(answer string) error {
+	port, ok := utils.Str2Int(answer)
+	if !ok || port <= 0 || port > 65535 {
+		return errno.ERR_INVALID_INIT_ANSWER.F("%s: invalid SSH port", answer)
+	}
+	return nil
+}
+
+func ValidateWizardPrivateKeyFile(filename string) error {
+	if !strings.HasPrefix(filename, "/") {
+		return errno.ERR_PRIVATE_KEY_FILE_REQUIRE_ABSOLUTE_PATH.
+			F("private_key_file = %s", filename)
+	} else if !utils.PathExist(filename) {
+		return errno.ERR_PRIVATE_KEY_FILE_NOT_EXIST.
+			F("%s: no such file", filename)
+	} else if utils.GetFilePermissions(filename) != hosts.PERMISSIONS_600 {
+		return errno.ERR_PRIVATE_KEY_FILE_REQUIRE_600_PERMISSIONS.
+			F("%s: mode (%d)", filename, utils.GetFilePermissions(filename))
+	}
+	return nil
+}
+
+// ValidateWizardServiceHosts checks the hosts of role are all answered before
+func ValidateWizardServiceHosts(role string, names []string, hosts []WizardHost) error {
+	if len(names) == 0 {
+		return errno.ERR_INVALID_INIT_ANSWER.F("at least one host required for %s", role)
+	}
+	exist := map[string]bool{}
+	for _, host := range hosts {
+		exist[host.Name] = true
+	}
+	for _, name := range names {
+		if !exist[name] {
+			return errno.ERR_INVALID_INIT_ANSWER.F("%s: unknown host", name)
+		}
+	}
+	return checkWizardDuplicate(names)
+}
+
+func ValidateWizardDevices(devices []string) error {
+	if len(devices) == 0 {
+		return errno.ERR_INVALID_INIT_ANSWER.F("at least one disk required for chunkserver")
+	}
+	for _, device := range devices {
+		if !strings.HasPrefix(device, "/dev/") {
+			return errno.ERR_INVALID_DEVICE.F("device: %s", device)
+		}
+	}
+	return checkWizardDuplicate(devices)
+}
+
+func ValidateWizardFormatPercent(answer string) error {
+	percent, ok := utils.Str2Int(answer)
+	if !ok {
+		return errno.ERR_FORMAT_PERCENT_REQUIRES_INTERGET.
+			F("percent: %s", answer)
+	} else if percent <= 0 || percent > 100 {
+		return errno.ERR_FORMAT_PERCENT_MUST_BE_BETWEEN_1_AND_100.
+			F("percent: %s", answer)
+	}
+	return nil
+}
+
+func renderWizardTemplate(text string, data interface{}) (string, error) {
+	tmpl, err := template.New("wizard").Option("missingkey=error").Parse(TEMPLATE_WIZARD_HEADER + text)
+	if err != nil {
+		return "", errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+	buffer := bytes.NewBufferString("")
+	err = tmpl.Execute(buffer, data)
+	if err != nil {
+		return "", errno.ERR_RENDER_TEMPLATE_FAILED.E(err)
+	}
+	return buffer.String(), nil
+}
+
+// e.g: /dev/sdb:/data/chunkserver0:90
+func (cfg *WizardConfig) disks() []string {
+	disks := []string{}
+	for i, device := range cfg.Devices {
+		disks = append(disks, fmt.Sprintf("%s:%s%d:%d", device, WIZARD_CHUNKSERVER_DATA_DIR, i, cfg.FormatPercent))
+	}
+	return disks
+}
+
+func (cfg *WizardConfig) services() []wizardService {
+	services := []wizardService{}
+	for _, role := range WizardRoles(cfg.Kind) {
+		service := wizardService{Role: role, Hosts: cfg.Services[role], Instances: 1}
+		switch role {
+		case topology.ROLE_ETCD:
+			service.Config = []string{"listen.port: 2380", "listen.client_port: 2379"}
+		case topology.ROLE_MDS:
+			service.Config = []string{"listen.port: 6700", "listen.dummy_port: 7700"}
+		case topology.ROLE_CHUNKSERVER:
+			service.Config = []string{
+				"listen.port: 82${format_instances_sequence}",
+				"data_dir: " + WIZARD_CHUNKSERVER_DATA_DIR + "${service_instances_sequence}",
+				"copysets: 100",
+			}
+			service.Instances = len(cfg.Devices)
+		case topology.ROLE_METASERVER:
+			service.Config = []string{"listen.port: 6800", "listen.external_port: 7800"}
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+func (cfg *WizardConfig) GenHosts() (string, error) {
+	return renderWizardTemplate(TEMPLATE_WIZARD_HOSTS, cfg)
+}
+
+// GenFormat returns empty string for curvefs which has no disks to format
+func (cfg *WizardConfig) GenFormat() (string, error) {
+	if cfg.Kind != topology.KIND_CURVEBS {
+		return "", nil
+	}
+	return renderWizardTemplate(TEMPLATE_WIZARD_FORMAT, map[string]interface{}{
+		"Hosts": cfg.Services[topology.ROLE_CHUNKSERVER],
+		"Disks": cfg.disks(),
+	})
+}
+
+func (cfg *WizardConfig) GenTopology() (string, error) {
+	return renderWizardTemplate(TEMPLATE_WIZARD_TOPOLOGY, map[string]interface{}{
+		"Kind":           cfg.Kind,
+		"ContainerImage": cfg.ContainerImage,
+		"Services":       cfg.services(),
+	})
+}
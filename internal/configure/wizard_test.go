@@ -0,0 +1,175 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package configure
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWizardConfig(kind string) *WizardConfig {
+	names := []string{"host1", "host2", "host3"}
+	cfg := &WizardConfig{
+		Kind:           kind,
+		ContainerImage: DefaultWizardContainerImage(kind),
+		Hosts: []WizardHost{
+			{Name: "host1", IP: "10.0.1.1"},
+			{Name: "host2", IP: "10.0.1.2"},
+			{Name: "host3", IP: "10.0.1.3"},
+		},
+		User:          "curve",
+		SSHPort:       22,
+		Password:      "secret://my-cluster_ssh_password",
+		Services:      map[string][]string{},
+		FormatPercent: DEFAULT_WIZARD_FORMAT_PERCENT,
+	}
+	for _, role := range WizardRoles(kind) {
+		cfg.Services[role] = names
+	}
+	if kind == topology.KIND_CURVEBS {
+		cfg.Devices = []string{"/dev/sdb", "/dev/sdc"}
+	}
+	return cfg
+}
+
+func TestWizardGenCurveBS(t *testing.T) {
+	assert := assert.New(t)
+	cfg := newTestWizardConfig(topology.KIND_CURVEBS)
+	t.Setenv("CURVEADM_SECRET_MY_CLUSTER_SSH_PASSWORD", "123456")
+	secret.ReplaceGlobals(secret.NewEnvResolver("CURVEADM_SECRET_"))
+	defer secret.ReplaceGlobals(nil)
+
+	// hosts
+	data, err := cfg.GenHosts()
+	assert.Nil(err)
+	assert.Contains(data, "  password: secret://my-cluster_ssh_password\n")
+	assert.NotContains(data, "private_key_file")
+	hcs, err := hosts.ParseHosts(data)
+	assert.Nil(err)
+	assert.Len(hcs, 3)
+	assert.Equal("10.0.1.2", hcs[1].GetHostname())
+	assert.Equal("curve", hcs[1].GetUser())
+
+	// topology: one chunkserver per disk
+	data, err = cfg.GenTopology()
+	assert.Nil(err)
+	assert.NotContains(data, "snapshotclone")
+	ctx := topology.NewContext()
+	for _, hc := range hcs {
+		ctx.Add(hc.GetHost(), hc.GetHostname())
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(err)
+	assert.Len(dcs, 3+3+6)
+	dataDirs := []string{}
+	for _, dc := range dcs {
+		if dc.GetRole() == topology.ROLE_CHUNKSERVER && dc.GetHost() == "host1" {
+			dataDirs = append(dataDirs, dc.GetDataDir())
+		}
+	}
+	assert.Equal([]string{"/data/chunkserver0", "/data/chunkserver1"}, dataDirs)
+
+	// format: disks are mounted on data directories of chunkservers
+	data, err = cfg.GenFormat()
+	assert.Nil(err)
+	filename := filepath.Join(t.TempDir(), "format.yaml")
+	assert.Nil(os.WriteFile(filename, []byte(data), 0644))
+	fcs, err := ParseFormat(filename)
+	assert.Nil(err)
+	assert.Len(fcs, 6)
+	assert.Equal("/dev/sdc", fcs[1].GetDevice())
+	assert.Equal("/data/chunkserver1", fcs[1].GetMountPoint())
+	assert.Equal(90, fcs[1].GetFormatPercent())
+}
+
+func TestWizardGenCurveFS(t *testing.T) {
+	assert := assert.New(t)
+	cfg := newTestWizardConfig(topology.KIND_CURVEFS)
+	cfg.Password = ""
+	cfg.PrivateKeyFile = "/home/curve/.ssh/id_rsa"
+
+	data, err := cfg.GenHosts()
+	assert.Nil(err)
+	assert.Contains(data, "  private_key_file: /home/curve/.ssh/id_rsa\n")
+	assert.True(strings.HasPrefix(data, "# Generated by 'curveadm init'"))
+
+	data, err = cfg.GenTopology()
+	assert.Nil(err)
+	assert.Contains(data, "metaserver_services:")
+	assert.NotContains(data, "instances")
+	ctx := topology.NewContext()
+	for _, host := range cfg.Hosts {
+		ctx.Add(host.Name, host.IP)
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(err)
+	assert.Len(dcs, 9)
+
+	data, err = cfg.GenFormat()
+	assert.Nil(err)
+	assert.Equal("", data)
+}
+
+func TestWizardValidate(t *testing.T) {
+	assert := assert.New(t)
+	code := func(err error) int {
+		if err == nil {
+			return 0
+		}
+		return err.(*errno.ErrorCode).GetCode()
+	}
+	invalid := errno.ERR_INVALID_INIT_ANSWER.GetCode()
+	hostList := []WizardHost{{Name: "host1"}, {Name: "host2"}}
+
+	assert.Equal([]string{"host1", "host2", "host3"}, ParseWizardList(" host1, host2 host3,"))
+	assert.Nil(ValidateWizardName("my-cluster_1.0"))
+	assert.Equal(invalid, code(ValidateWizardName("-cluster")))
+	assert.Nil(ValidateWizardIPs([]string{"10.0.1.1", "fe80::1"}))
+	assert.Equal(invalid, code(ValidateWizardIPs([]string{})))
+	assert.Equal(invalid, code(ValidateWizardIPs([]string{"10.0.1.256"})))
+	assert.Equal(invalid, code(ValidateWizardIPs([]string{"10.0.1.1", "10.0.1.1"})))
+	assert.Nil(ValidateWizardHostNames([]string{"host1", "host2"}, 2))
+	assert.Equal(invalid, code(ValidateWizardHostNames([]string{"host1"}, 2)))
+	assert.Nil(ValidateWizardSSHPort("22"))
+	assert.Equal(invalid, code(ValidateWizardSSHPort("65536")))
+	assert.Equal(errno.ERR_PRIVATE_KEY_FILE_REQUIRE_ABSOLUTE_PATH.GetCode(),
+		code(ValidateWizardPrivateKeyFile("id_rsa")))
+	assert.Equal(errno.ERR_PRIVATE_KEY_FILE_NOT_EXIST.GetCode(),
+		code(ValidateWizardPrivateKeyFile("/path/not/exist")))
+	assert.Nil(ValidateWizardServiceHosts("etcd", []string{"host2"}, hostList))
+	assert.Equal(invalid, code(ValidateWizardServiceHosts("etcd", []string{"host3"}, hostList)))
+	assert.Equal(invalid, code(ValidateWizardServiceHosts("etcd", []string{}, hostList)))
+	assert.Nil(ValidateWizardDevices([]string{"/dev/sdb", "/dev/nvme0n1"}))
+	assert.Equal(errno.ERR_INVALID_DEVICE.GetCode(), code(ValidateWizardDevices([]string{"sdb"})))
+	assert.Nil(ValidateWizardFormatPercent("90"))
+	assert.Equal(errno.ERR_FORMAT_PERCENT_MUST_BE_BETWEEN_1_AND_100.GetCode(),
+		code(ValidateWizardFormatPercent("0")))
+}
@@ -40,6 +40,12 @@ type ErrorCode struct {
 	code        int
 	description string
 	clue        string
+	hints       []string // remediation, e.g: check the SSH port is open
+	doc         string   // link to document, the wiki page of code by default
+
+	// where the error occurred, set for error returned by task
+	hosts []string
+	step  string
 }
 
 var (
@@ -91,6 +97,66 @@ func (e *ErrorCode) GetClue() string {
 	return e.clue
 }
 
+func (e *ErrorCode) GetHints() []string {
+	return e.hints
+}
+
+func (e *ErrorCode) GetHosts() []string {
+	return e.hosts
+}
+
+func (e *ErrorCode) GetStep() string {
+	return e.step
+}
+
+// Hint appends remediation hints for error code, it's called once on declaring
+func (e *ErrorCode) Hint(hints ...string) *ErrorCode {
+	e.hints = append(e.hints, hints...)
+	return e
+}
+
+// Doc overrides the default document link (wiki page) of error code
+func (e *ErrorCode) Doc(url string) *ErrorCode {
+	e.doc = url
+	return e
+}
+
+// At returns a copy of error code which records the host and step it occurred,
+// the copy is still matched by errors.Is with the declared error code
+func (e *ErrorCode) At(host, step string) *ErrorCode {
+	newEC := *e
+	newEC.hosts = nil
+	if len(host) > 0 {
+		newEC.hosts = []string{host}
+	}
+	newEC.step = step
+	return &newEC
+}
+
+// Group merges hosts of other into e if they are the same failure (code and
+// step) but occurred on different hosts, the clue of e is kept as example
+func (e *ErrorCode) Group(other *ErrorCode) bool {
+	if len(e.hosts) == 0 || len(other.hosts) == 0 ||
+		e.code != other.code || e.step != other.step {
+		return false
+	}
+	seen := map[string]bool{}
+	for _, host := range e.hosts {
+		seen[host] = true
+	}
+	for _, host := range other.hosts {
+		if !seen[host] {
+			e.hosts = append(e.hosts, host)
+		}
+	}
+	return true
+}
+
+func (e *ErrorCode) Is(target error) bool {
+	ec, ok := target.(*ErrorCode)
+	return ok && ec.code == e.code
+}
+
 // added clue for error code
 func (e *ErrorCode) E(err error) *ErrorCode {
 	e.clue = err.Error()
@@ -111,6 +177,8 @@ func (e *ErrorCode) FD(format string, s ...interface{}) *ErrorCode {
 	newEC := &ErrorCode{
 		code:        e.code,
 		description: e.description,
+		hints:       e.hints,
+		doc:         e.doc,
 	}
 	newEC.description = fmt.Sprintf(newEC.description+" "+format, s...)
 	return newEC
@@ -120,7 +188,12 @@ func (e *ErrorCode) Error() string {
 	if e.code == CODE_CANCEL_OPERATION {
 		return ""
 	}
-	return tui.PromptErrorCode(e.code, e.description, e.clue, gLogpath)
+	return tui.PromptErrorCode(e.code, e.description, e.clue, gLogpath, tui.ErrorDetail{
+		Hosts: e.hosts,
+		Step:  e.step,
+		Hints: e.hints,
+		Doc:   e.doc,
+	})
 }
 
 /*
@@ -136,6 +209,12 @@ func (e *ErrorCode) Error() string {
  *     * 114: plauground table
  *     * 115: audit table
  *     * 116: any table
+ *     * 117: monitor table
+ *     * 118: health samples table
+ *     * 119: alert rules table
+ *   120: execute SQL statement (upgrade states table)
+ *   121: execute SQL statement (canaries table)
+ *   122: execute SQL statement (previous images table)
  *
  * 2xx: command options
  *   20*: hosts
@@ -167,6 +246,10 @@ func (e *ErrorCode) Error() string {
  *   43*: curvefs client
  *   44*: polarfs
  *   45*: playground
+ *   46*: compatibility
+ *   47*: benchmark
+ *   48*: certificate
+ *   49*: artifact verification
  *
  * 5xx: checker
  *   50*: topology
@@ -198,6 +281,7 @@ var (
 	ERR_GET_USER_HOME_DIR_FAILED            = EC(000001, "get user home dir failed")
 	ERR_CREATE_CURVEADM_SUBDIRECTORY_FAILED = EC(000002, "create curveadm subdirectory failed")
 	ERR_INIT_LOGGER_FAILED                  = EC(000003, "init logger failed")
+	ERR_INIT_TRACING_FAILED                 = EC(000004, "init tracing failed")
 
 	// 100: database/SQL (init failed)
 	ERR_INIT_SQL_DATABASE_FAILED = EC(100000, "init SQLite database failed")
@@ -219,6 +303,7 @@ var (
 	ERR_SET_SERVICE_CONTAINER_ID_FAILED      = EC(112001, "execute SQL failed which set service container id")
 	ERR_GET_SERVICE_CONTAINER_ID_FAILED      = EC(112002, "execute SQL failed which get service container id")
 	ERR_GET_ALL_SERVICES_CONTAINER_ID_FAILED = EC(112003, "execute SQL failed which get all services container id")
+	ERR_DELETE_SERVICE_CONTAINER_ID_FAILED   = EC(112004, "execute SQL failed which delete service container id")
 	// 113: database/SQL (execute SQL statement: clients table)
 	ERR_INSERT_CLIENT_FAILED           = EC(113000, "execute SQL failed which insert client")
 	ERR_GET_CLIENT_CONTAINER_ID_FAILED = EC(113001, "execute SQL failed which get client container id")
@@ -241,8 +326,62 @@ var (
 	ERR_GET_MONITOR_FAILED     = EC(117000, "execute SQL failed while get monitor")
 	ERR_REPLACE_MONITOR_FAILED = EC(117001, "execute SQL failed while replace monitor")
 	ERR_UPDATE_MONITOR_FAILED  = EC(117002, "execute SQL failed while update monitor")
+	// 118: database/SQL (execute SQL statement: health samples table)
+	ERR_INSERT_HEALTH_SAMPLE_FAILED      = EC(118000, "execute SQL failed which insert health sample")
+	ERR_GET_LATEST_HEALTH_SAMPLES_FAILED = EC(118001, "execute SQL failed which get latest health samples")
+	ERR_DELETE_HEALTH_SAMPLES_FAILED     = EC(118002, "execute SQL failed which delete health samples")
+	// 119: database/SQL (execute SQL statement: alert rules table)
+	ERR_INSERT_ALERT_RULES_FAILED = EC(119000, "execute SQL failed which insert alert rules")
+	ERR_GET_ALERT_RULES_FAILED    = EC(119001, "execute SQL failed which get alert rules")
+	// 120: database/SQL (execute SQL statement: upgrade states table)
+	ERR_SET_UPGRADE_STATE_FAILED     = EC(120000, "execute SQL failed which set upgrade state")
+	ERR_GET_UPGRADE_STATES_FAILED    = EC(120001, "execute SQL failed which get upgrade states")
+	ERR_DELETE_UPGRADE_STATES_FAILED = EC(120002, "execute SQL failed which delete upgrade states")
+	// 121: database/SQL (execute SQL statement: canaries table)
+	ERR_INSERT_CANARY_FAILED   = EC(121000, "execute SQL failed which insert canary")
+	ERR_GET_CANARIES_FAILED    = EC(121001, "execute SQL failed which get canaries")
+	ERR_DELETE_CANARIES_FAILED = EC(121002, "execute SQL failed which delete canaries")
+	// 122: database/SQL (execute SQL statement: previous images table)
+	ERR_SET_PREVIOUS_IMAGE_FAILED    = EC(122000, "execute SQL failed which set previous image")
+	ERR_GET_PREVIOUS_IMAGES_FAILED   = EC(122001, "execute SQL failed which get previous images")
+	ERR_DELETE_PREVIOUS_IMAGE_FAILED = EC(122002, "execute SQL failed which delete previous image")
+	// 123: database/SQL (execute SQL statement: previous topologies table)
+	ERR_SET_PREVIOUS_TOPOLOGY_FAILED = EC(123000, "execute SQL failed which set previous topology")
+	ERR_GET_PREVIOUS_TOPOLOGY_FAILED = EC(123001, "execute SQL failed which get previous topology")
+	// 124: database/SQL (execute SQL statement: format progresses table)
+	ERR_SET_FORMAT_PROGRESS_FAILED   = EC(124000, "execute SQL failed which set format progress")
+	ERR_GET_FORMAT_PROGRESSES_FAILED = EC(124001, "execute SQL failed which get format progresses")
+	// 125: database/SQL (execute SQL statement: benchmarks table)
+	ERR_INSERT_BENCHMARK_FAILED = EC(125000, "execute SQL failed which insert benchmark")
+	ERR_GET_BENCHMARKS_FAILED   = EC(125001, "execute SQL failed which get benchmarks")
+	// 126: database/SQL (execute SQL statement: host facts table)
+	ERR_SET_HOST_FACTS_FAILED    = EC(126000, "execute SQL failed which set host facts")
+	ERR_GET_HOST_FACTS_FAILED    = EC(126001, "execute SQL failed which get host facts")
+	ERR_DELETE_HOST_FACTS_FAILED = EC(126002, "execute SQL failed which delete host facts")
+	// 127: database/SQL (execute SQL statement: secrets table)
+	ERR_SET_SECRETS_FAILED = EC(127000, "execute SQL failed which set secrets")
+	ERR_GET_SECRETS_FAILED = EC(127001, "execute SQL failed which get secrets")
+	// 128: database/SQL (execute SQL statement: certificates table)
+	ERR_SET_CERTIFICATE_FAILED    = EC(128000, "execute SQL failed which set certificate")
+	ERR_GET_CERTIFICATES_FAILED   = EC(128001, "execute SQL failed which get certificates")
+	ERR_DELETE_CERTIFICATE_FAILED = EC(128002, "execute SQL failed which delete certificates")
+	// 129: database/SQL (execute SQL statement: cluster protections table)
+	ERR_SET_CLUSTER_PROTECTION_FAILED    = EC(129000, "execute SQL failed which set cluster protection")
+	ERR_GET_CLUSTER_PROTECTION_FAILED    = EC(129001, "execute SQL failed which get cluster protection")
+	ERR_DELETE_CLUSTER_PROTECTION_FAILED = EC(129002, "execute SQL failed which delete cluster protection")
+	// 130: database/SQL (execute SQL statement: operation durations table)
+	ERR_INSERT_OPERATION_DURATION_FAILED  = EC(130000, "execute SQL failed which insert operation duration")
+	ERR_GET_OPERATION_DURATIONS_FAILED    = EC(130001, "execute SQL failed which get operation durations")
+	ERR_DELETE_OPERATION_DURATIONS_FAILED = EC(130002, "execute SQL failed which delete operation durations")
+	// 131: database/SQL (execute SQL statement: backup schedules table)
+	ERR_INSERT_BACKUP_SCHEDULE_FAILED     = EC(131000, "execute SQL failed which insert backup schedule")
+	ERR_GET_BACKUP_SCHEDULES_FAILED       = EC(131001, "execute SQL failed which get backup schedules")
+	ERR_SET_BACKUP_SCHEDULE_RESULT_FAILED = EC(131002, "execute SQL failed which set backup schedule result")
+	ERR_DELETE_BACKUP_SCHEDULE_FAILED     = EC(131003, "execute SQL failed which delete backup schedule")
+	ERR_DELETE_BACKUP_SCHEDULES_FAILED    = EC(131004, "execute SQL failed which delete backup schedules")
 
 	// 200: command options (hosts)
+	ERR_UNSUPPORT_INIT_HOST_ITEM = EC(200000, "unsupport init host item")
 
 	// 210: command options (cluster)
 	ERR_ID_NOT_FOUND                   = EC(210000, "id not found")
@@ -253,7 +392,48 @@ var (
 	ERR_UNSUPPORT_CLEAN_ITEM           = EC(210005, "unsupport clean item")
 	ERR_NO_SERVICES_MATCHED            = EC(210006, "no services matched")
 	// TODO: please check pool set disk type
-	ERR_INVALID_DISK_TYPE = EC(210007, "poolset disk type must be lowercase and can only be one of ssd, hdd and nvme")
+	ERR_INVALID_DISK_TYPE                 = EC(210007, "poolset disk type must be lowercase and can only be one of ssd, hdd and nvme")
+	ERR_UNSUPPORT_OUTPUT_FORMAT           = EC(210008, "unsupport output format")
+	ERR_INVALID_NTP_MAX_OFFSET            = EC(210009, "--ntp-max-offset requires a positive integer")
+	ERR_INVALID_WATCH_INTERVAL            = EC(210010, "--interval requires a positive duration")
+	ERR_INVALID_LOGS_GREP_PATTERN         = EC(210011, "invalid regular expression for --grep")
+	ERR_INVALID_BUNDLE_SINCE              = EC(210012, "--since requires a duration not less than 1 minute")
+	ERR_BUNDLE_OUTPUT_DIRECTORY_NOT_EXIST = EC(210013, "support bundle output directory not exist")
+	ERR_INVALID_ALERT_RULES               = EC(210014, "invalid alert rules")
+	ERR_ALERT_RULES_VERSION_NOT_FOUND     = EC(210015, "alert rules version not found")
+	ERR_INVALID_AUDIT_STATUS              = EC(210016, "invalid audit status, it must be one of success/fail/cancel/abort")
+	ERR_INVALID_ROLLING_UPGRADE_OPTIONS   = EC(210017, "invalid rolling upgrade options")
+	ERR_ROLLING_UPGRADE_IN_PROGRESS       = EC(210018, "rolling upgrade is in progress, please resume or abort it first")
+	ERR_NO_ROLLING_UPGRADE_IN_PROGRESS    = EC(210019, "no rolling upgrade in progress")
+	ERR_INVALID_CANARY_UPGRADE_OPTIONS    = EC(210020, "invalid canary upgrade options")
+	ERR_CANARY_UPGRADE_IN_PROGRESS        = EC(210021, "canary upgrade is in progress, please promote or rollback it first")
+	ERR_NO_CANARY_UPGRADE_IN_PROGRESS     = EC(210022, "no canary upgrade in progress")
+	ERR_NO_PREVIOUS_IMAGE_FOR_ROLLBACK    = EC(210023, "no previous image recorded for rollback")
+	ERR_INVALID_HOSTS_MAP                 = EC(210024, "invalid hosts map, it must be in the format of old=new")
+	ERR_INVALID_MIGRATE_OPTIONS           = EC(210025, "invalid migrate options")
+	ERR_INVALID_SCALE_IN_OPTIONS          = EC(210026, "invalid scale-in options")
+	ERR_INVALID_CONFIG_APPLY_OPTIONS      = EC(210027, "invalid config apply options")
+	ERR_NO_PREVIOUS_TOPOLOGY_FOR_ROLLBACK = EC(210028, "no previous topology recorded for rollback")
+	ERR_INVALID_UNLOCK_DURATION           = EC(210029, "--duration requires a positive duration")
+	ERR_API_TOKEN_NOT_SPECIFIED           = EC(210030, "API server requires a token, please specify --token or $CURVEADM_API_TOKEN")
+	ERR_BOT_TOKEN_NOT_SPECIFIED           = EC(210031, "bot requires a token, please specify --token, --slack-token or --slack-signing-secret")
+	ERR_INVALID_STATUS_OPTIONS            = EC(210032, "invalid status options")
+	ERR_UI_REQUIRES_TERMINAL              = EC(210033, "ui requires an interactive terminal")
+	ERR_MACRO_REQUIRES_MORE_ARGUMENTS     = EC(210034, "macro requires more arguments")
+	ERR_UNKNOWN_TABLE_COLUMN              = EC(210035, "unknown table column")
+	ERR_INVALID_WATCH_OPTIONS             = EC(210036, "invalid watch options")
+	ERR_INVALID_INIT_ANSWER               = EC(210037, "invalid answer for init wizard")
+	ERR_INIT_WIZARD_INTERRUPTED           = EC(210038, "init wizard interrupted before all questions answered")
+	ERR_INIT_FILE_ALREADY_EXIST           = EC(210039, "file generated by init wizard already exists, please specify another directory by --dir")
+	ERR_INVALID_TOPOLOGY_GENERATE_OPTIONS = EC(210040, "invalid topology generate options")
+	ERR_INVALID_CONFIG_SET_OPTIONS        = EC(210041, "invalid config set options")
+	ERR_INVALID_SET_STATUS_OPTIONS        = EC(210042, "invalid chunkserver set-status options")
+	ERR_INVALID_BACKUP_OPTIONS            = EC(210043, "invalid backup options")
+	ERR_INVALID_RESTORE_OPTIONS           = EC(210044, "invalid restore options")
+	ERR_INVALID_BACKUP_SCHEDULE_OPTIONS   = EC(210045, "invalid backup schedule options")
+	ERR_BACKUP_SCHEDULE_NOT_FOUND         = EC(210046, "backup schedule not found")
+	ERR_CONFIRM_REQUIRES_TERMINAL         = EC(210047, "confirmation requires an interactive terminal")
+	ERR_INVALID_LOGS_SINCE                = EC(210048, "--since requires a timestamp (e.g. 2023-08-27T10:00:00) or duration (e.g. 1h)")
 
 	// 220: commad options (client common)
 	ERR_UNSUPPORT_CLIENT_KIND = EC(220000, "unsupport client kind")
@@ -278,17 +458,59 @@ var (
 	ERR_MUST_SPECIFY_MOUNTPOINT_FOR_CURVEFS_PLAYGROUND = EC(230001, "you must specify mountpoint for curvefs playground")
 	ERR_PLAYGROUND_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH    = EC(230002, "mount point must be an absolute path")
 	ERR_PLAYGROUND_MOUNTPOINT_NOT_EXIST                = EC(230003, "mount point not exist")
+	ERR_INVALID_PLAYGROUND_NODES                       = EC(230004, "invalid number of playground nodes")
+	ERR_INVALID_PLAYGROUND_SUBNET                      = EC(230005, "invalid playground subnet")
+	ERR_INVALID_PLAYGROUND_DISK_SIZE                   = EC(230006, "invalid playground disk size")
+
+	// 240: command options (artifacts)
+	ERR_NO_IMAGES_FOR_ARTIFACTS     = EC(240000, "no images for artifacts")
+	ERR_IMAGE_ARCHIVE_NOT_FOUND     = EC(240001, "image archive not found")
+	ERR_IMPORT_IMAGE_ARCHIVE_FAILED = EC(240002, "import image archive failed")
+
+	// 250: command options (bench)
+	ERR_UNSUPPORT_BENCH_TYPE       = EC(250000, "unsupport bench type, it must be bs or fs")
+	ERR_UNSUPPORT_BENCH_PATTERN    = EC(250001, "unsupport bench pattern")
+	ERR_INVALID_BENCH_SIZE         = EC(250002, "bench size requires a positive integer with \"G\" suffix, like 10G")
+	ERR_INVALID_BENCH_OPTIONS      = EC(250003, "invalid bench options")
+	ERR_BENCH_CLIENT_KIND_MISMATCH = EC(250004, "the kind of client configure mismatch with bench type")
+
+	// 260: command options (secret)
+	ERR_INVALID_SECRET_NAME             = EC(260000, "invalid secret name, it can only contain letters, digits, '_', '.' and '-'")
+	ERR_SECRET_NOT_FOUND                = EC(260001, "secret not found")
+	ERR_SECRET_VALUE_IS_EMPTY           = EC(260002, "secret value is empty")
+	ERR_READ_SECRET_PASSPHRASE_FAILED   = EC(260003, "read passphrase of secrets store failed")
+	ERR_SECRET_PASSPHRASE_MISMATCH      = EC(260004, "the passphrases typed twice are mismatch")
+	ERR_WRONG_SECRET_PASSPHRASE         = EC(260005, "wrong passphrase of secrets store")
+	ERR_DECRYPT_SECRETS_FAILED          = EC(260006, "decrypt secrets store failed")
+	ERR_ENCRYPT_SECRETS_FAILED          = EC(260007, "encrypt secrets store failed")
+	ERR_SECRETS_STORE_NOT_INITIALIZED   = EC(260008, "secrets store is not initialized")
+	ERR_SECRET_PASSPHRASE_NOT_SPECIFIED = EC(260009, "passphrase of secrets store not specified")
+	ERR_READ_SECRET_VALUE_FAILED        = EC(260010, "read secret value failed")
+	ERR_QUERY_SECRET_BACKEND_FAILED     = EC(260011, "query secret from backend failed")
+	ERR_INVALID_SECRET_BACKEND_CONFIG   = EC(260012, "invalid secret backend config")
+	ERR_SECRET_BACKEND_IS_READ_ONLY     = EC(260013, "secret backend is read-only, please manage secrets in it directly")
+
+	// 270: command options (plugin)
+	ERR_INVALID_PLUGIN_NAME        = EC(270000, "invalid plugin name, it can only contain letters, digits, '_' and '-'")
+	ERR_PLUGIN_NOT_FOUND           = EC(270001, "plugin not found")
+	ERR_UNSUPPORT_PLUGIN_STEP      = EC(270002, "unsupport plugin step, see 'curveadm plugin ls'")
+	ERR_PLUGIN_ALREADY_INSTALLED   = EC(270003, "plugin already installed, please use --force to overwrite it")
+	ERR_PLUGIN_NOT_INSTALLED_BY_US = EC(270004, "plugin is not in plugin directory, please remove it manually")
+	ERR_INVALID_PLUGIN_STEP_OPTION = EC(270005, "invalid plugin step option, it must be KEY=VALUE")
 
 	// 301: configure (common: invalid configure value)
 	ERR_UNSUPPORT_CONFIGURE_VALUE_TYPE = EC(301000, "unsupport configure value type")
 	// lose 301001
-	ERR_CONFIGURE_VALUE_REQUIRES_BOOL             = EC(301002, "configure value requires bool")
-	ERR_CONFIGURE_VALUE_REQUIRES_INTEGER          = EC(301003, "configure value requires integer")
-	ERR_CONFIGURE_VALUE_REQUIRES_NON_EMPTY_STRING = EC(301004, "configure value requires non-empty string")
-	ERR_CONFIGURE_VALUE_REQUIRES_POSITIVE_INTEGER = EC(301005, "configure value requires positive integer")
-	ERR_CONFIGURE_VALUE_REQUIRES_STRING_SLICE     = EC(301006, "configure value requires string array")
-	ERR_UNSUPPORT_VARIABLE_VALUE_TYPE             = EC(301100, "unsupport variable value type")
-	ERR_INVALID_VARIABLE_VALUE                    = EC(301101, "invalid variable value")
+	ERR_CONFIGURE_VALUE_REQUIRES_BOOL                 = EC(301002, "configure value requires bool")
+	ERR_CONFIGURE_VALUE_REQUIRES_INTEGER              = EC(301003, "configure value requires integer")
+	ERR_CONFIGURE_VALUE_REQUIRES_NON_EMPTY_STRING     = EC(301004, "configure value requires non-empty string")
+	ERR_CONFIGURE_VALUE_REQUIRES_POSITIVE_INTEGER     = EC(301005, "configure value requires positive integer")
+	ERR_CONFIGURE_VALUE_REQUIRES_STRING_SLICE         = EC(301006, "configure value requires string array")
+	ERR_UNSUPPORT_IMAGE_SOURCE                        = EC(301007, "unsupport image source")
+	ERR_CONFIGURE_VALUE_REQUIRES_NON_NEGATIVE_INTEGER = EC(301008, "configure value requires non-negative integer")
+	ERR_UNSUPPORT_VARIABLE_VALUE_TYPE                 = EC(301100, "unsupport variable value type")
+	ERR_INVALID_VARIABLE_VALUE                        = EC(301101, "invalid variable value")
+	ERR_RESOLVE_SECRET_REFERENCE_FAILED               = EC(301200, "resolve secret reference failed")
 
 	// 310: configure (curveadm.cfg: parse failed)
 	ERR_PARSE_CURVRADM_CONFIGURE_FAILED = EC(310000, "parse curveadm configure failed")
@@ -296,12 +518,20 @@ var (
 	ERR_UNSUPPORT_CURVEADM_LOG_LEVEL      = EC(311000, "unsupport curveadm log level")
 	ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM = EC(311001, "unsupport curveadm configure item")
 	ERR_UNSUPPORT_CURVEADM_DATABASE_URL   = EC(311002, "unsupport curveadm database url")
+	ERR_UNSUPPORT_SECRET_BACKEND          = EC(311003, "unsupport secret backend (store/env/vault/exec)")
+	ERR_INVALID_EVENT_SINK                = EC(311004, "invalid event sink")
+	ERR_INVALID_REPORT_CONFIGURE          = EC(311005, "invalid report configure")
+	ERR_INVALID_PROXY_CONFIGURE           = EC(311006, "invalid proxy configure, it should be http(s)://[user:password@]host[:port]")
+	ERR_INVALID_ALIAS_OR_MACRO            = EC(311007, "invalid alias or macro")
+	ERR_UNSUPPORT_CURVEADM_LANGUAGE       = EC(311008, "unsupport curveadm language (en/zh)")
 
 	// 320: configure (hosts.yaml: parse failed)
-	ERR_HOSTS_FILE_NOT_FOUND   = EC(320000, "hosts file not found")
-	ERR_READ_HOSTS_FILE_FAILED = EC(320001, "read hosts file failed")
-	ERR_EMPTY_HOSTS            = EC(320002, "hosts is empty")
-	ERR_PARSE_HOSTS_FAILED     = EC(320003, "parse hosts failed")
+	ERR_HOSTS_FILE_NOT_FOUND           = EC(320000, "hosts file not found")
+	ERR_READ_HOSTS_FILE_FAILED         = EC(320001, "read hosts file failed")
+	ERR_EMPTY_HOSTS                    = EC(320002, "hosts is empty")
+	ERR_PARSE_HOSTS_FAILED             = EC(320003, "parse hosts failed")
+	ERR_READ_ANSIBLE_INVENTORY_FAILED  = EC(320004, "read ansible inventory failed")
+	ERR_PARSE_ANSIBLE_INVENTORY_FAILED = EC(320005, "parse ansible inventory failed")
 	// 321: configure (hosts.yaml: invalid configure value)
 	ERR_UNSUPPORT_HOSTS_CONFIGURE_ITEM           = EC(321000, "unsupport hosts configure item")
 	ERR_HOST_FIELD_MISSING                       = EC(321001, "host field missing")
@@ -312,6 +542,16 @@ var (
 	ERR_PRIVATE_KEY_FILE_REQUIRE_600_PERMISSIONS = EC(321006, "SSH private key file require 600 permissions")
 	ERR_DUPLICATE_HOST                           = EC(321007, "host is duplicate")
 	ERR_HOSTNAME_REQUIRES_VALID_IP_ADDRESS       = EC(321008, "hostname requires valid IP address")
+	ERR_UNSUPPORT_HOST_TRANSPORT                 = EC(321009, "unsupport host transport")
+	ERR_UNSUPPORT_HOST_CONTAINER_ENGINE          = EC(321010, "unsupport host container engine")
+	ERR_INVALID_PROXY_JUMP                       = EC(321011, "invalid proxy_jump, it should be [user@]host[:port][,...]")
+	ERR_PROXY_JUMP_CONFLICT_WITH_PROXY_COMMAND   = EC(321012, "proxy_jump conflicts with proxy_command")
+	ERR_CERTIFICATE_FILE_REQUIRE_ABSOLUTE_PATH   = EC(321013, "SSH certificate file needs to be an absolute path")
+	ERR_CERTIFICATE_FILE_NOT_EXIST               = EC(321014, "SSH certificate file not exist")
+	ERR_IDENTITY_AGENT_REQUIRES_FORWARD_AGENT    = EC(321015, "identity_agent requires forward_agent to be true")
+	ERR_INVALID_HOST_GROUP_NAME                  = EC(321016, "invalid host group name")
+	ERR_PROTOCOL_CONFLICT_WITH_TRANSPORT         = EC(321017, "protocol conflicts with transport, please specify one of them")
+	ERR_INVALID_HOST_HTTP_PROXY                  = EC(321018, "invalid host http proxy, it should be http(s)://[user:password@]host[:port]")
 
 	// 322: configure (monitor.yaml: parse failed)
 	ERR_PARSE_MONITOR_CONFIGURE_FAILED   = EC(322000, "parse monitor configure failed")
@@ -336,6 +576,11 @@ var (
 	ERR_INSTANCES_REQUIRES_POSITIVE_INTEGER = EC(331002, "instances requires a positive integer")
 	ERR_INVALID_VARIABLE_SECTION            = EC(331003, "invalid variable section")
 	ERR_DUPLICATE_SERVICE_ID                = EC(331004, "service id is duplicate")
+	ERR_INVALID_RESOURCES_CPUS              = EC(331005, "resources.cpus requires a positive number")
+	ERR_INVALID_RESOURCES_MEMORY            = EC(331006, "resources.memory requires a positive size (e.g. 512m, 8g)")
+	ERR_INVALID_RESOURCES_CPUSET_CPUS       = EC(331007, "resources.cpuset_cpus requires a cpu list (e.g. 0-3,8)")
+	ERR_REQUIRE_S3_CONFIGURE_FOR_CSI        = EC(331008, "curvefs csi requires s3 configure (s3.ak, s3.sk, s3.endpoint, s3.bucket_name) in client configure")
+	ERR_REQUIRE_SERVICES_FOR_OPERATOR       = EC(331009, "curve-operator requires etcd, mds and chunkserver/metaserver services in topology")
 	// 332: configure (topology.yaml: update topology)
 	ERR_DELETE_SERVICE_WHILE_COMMIT_TOPOLOGY_IS_DENIED   = EC(332000, "delete service while commit topology is denied")
 	ERR_ADD_SERVICE_WHILE_COMMIT_TOPOLOGY_IS_DENIED      = EC(332001, "add service while commit topology is denied")
@@ -349,6 +594,15 @@ var (
 	ERR_NO_SERVICES_FOR_MIGRATING                        = EC(332009, "no service for migrating")
 	ERR_REQUIRE_SAME_ROLE_SERVICES_FOR_MIGRATING         = EC(332010, "require same role services for migrating")
 	ERR_REQUIRE_WHOLE_HOST_SERVICES_FOR_MIGRATING        = EC(332011, "require whole host services for migrating")
+	ERR_UNBALANCED_ZONES_WHILE_SCALE_OUT                 = EC(332012, "zones are unbalanced while scale out")
+	ERR_DELETE_SERVICE_WHILE_APPLY_TOPOLOGY_IS_DENIED    = EC(332013, "delete service without migrating while apply topology is denied")
+	ERR_REQUIRE_SAME_ROLE_SERVICES_FOR_APPLY_TOPOLOGY    = EC(332014, "require same role services to add or migrate while apply topology")
+	ERR_NO_SERVICES_FOR_SCALE_IN_CLUSTER                 = EC(332015, "no service for scale in cluster")
+	ERR_REQUIRES_3_HOSTS_AFTER_SCALE_IN                  = EC(332016, "requires at least 3 hosts to distrubute zones after scale in")
+	ERR_SCALE_IN_NON_LAST_INSTANCE_IS_DENIED             = EC(332017, "scale in non-last instance of host is denied")
+	ERR_REWRITE_TOPOLOGY_FOR_SCALE_IN_FAILED             = EC(332018, "rewrite topology for scale in failed")
+	ERR_CHANGE_CONFIG_WHILE_APPLY_IS_DENIED              = EC(332019, "change config which requires recreating container while apply config is denied")
+	ERR_REWRITE_TOPOLOGY_FOR_CONFIG_SET_FAILED           = EC(332020, "rewrite topology for config set failed")
 
 	// 340: configure (format.yaml: parse failed)
 	ERR_FORMAT_CONFIGURE_FILE_NOT_EXIST = EC(340000, "format configure file not exits")
@@ -370,9 +624,15 @@ var (
 	ERR_REQUIRE_CURVEBS_KIND_CLIENT_CONFIGURE_FILE = EC(351002, "require curvebs kind client configure file")
 	ERR_REQUIRE_CURVEFS_KIND_CLIENT_CONFIGURE_FILE = EC(351003, "require curvefs kind client configure file")
 	ERR_INVALID_CLUSTER_LISTEN_MDS_ADDRESS         = EC(351004, "invalid cluster MDS listen address")
+	ERR_INVALID_CLIENT_QOS_VALUE                   = EC(351005, "invalid client qos value")
 
 	// 400: common (hosts)
-	ERR_HOST_NOT_FOUND = EC(400000, "host not found")
+	ERR_HOST_NOT_FOUND           = EC(400000, "host not found")
+	ERR_ENCODE_HOST_FACTS_FAILED = EC(400001, "encode host facts to json failed")
+	ERR_DECODE_HOST_FACTS_FAILED = EC(400002, "decode host facts from json failed")
+	ERR_HOST_GROUP_NOT_FOUND     = EC(400003, "host group not found")
+	ERR_EXEC_HOST_COMMAND_FAILED = EC(400004, "execute command failed on some hosts")
+	ERR_PING_HOSTS_FAILED        = EC(400005, "some hosts are unreachable, unauthenticated or without sudo")
 
 	// 410: common (services command)
 	ERR_NO_CLUSTER_SPECIFIED                 = EC(410001, "no cluster specified")
@@ -398,6 +658,47 @@ var (
 	ERR_ENCRYPT_FILE_FAILED                  = EC(410021, "encrypt file failed")
 	ERR_CLIENT_ID_NOT_FOUND                  = EC(410022, "client id not found")
 	ERR_ENABLE_ETCD_AUTH_FAILED              = EC(410023, "enable etcd auth failed")
+	ERR_INVALID_STEP_CONDITION               = EC(410024, "invalid playbook step condition")
+	ERR_DOCTOR_CHECK_FAILED                  = EC(410025, "doctor check failed")
+	ERR_PARSE_CHUNKSERVER_LIST_FAILED        = EC(410026, "parse chunkserver list failed")
+	ERR_SERVE_METRICS_FAILED                 = EC(410027, "serve metrics failed")
+	ERR_REDACT_SUPPORT_BUNDLE_FAILED         = EC(410028, "redact support bundle failed")
+	ERR_ARCHIVE_SUPPORT_BUNDLE_FAILED        = EC(410029, "archive support bundle failed")
+	ERR_WAIT_SERVICES_HEALTHY_TIMEOUT        = EC(410030, "wait services healthy timeout")
+	ERR_LINT_TOPOLOGY_FAILED                 = EC(410031, "lint topology failed")
+	ERR_MIGRATE_ETCD_MEMBER_FAILED           = EC(410032, "migrate etcd member failed")
+	ERR_WAIT_CHUNKSERVERS_DRAINED_TIMEOUT    = EC(410033, "wait chunkservers drained timeout")
+	ERR_ENCODE_CLUSTER_POOL_JSON_FAILED      = EC(410034, "encode cluster pool to json string failed")
+	ERR_CHUNKFILE_POOL_DEVICE_NOT_MOUNTED    = EC(410035, "device of chunkfile pool not mounted, please format it first")
+	ERR_CLUSTER_IS_PROTECTED                 = EC(410036, "cluster is protected, please unlock it first")
+	ERR_CLUSTER_NOT_PROTECTED                = EC(410037, "cluster is not protected")
+	ERR_SERVE_API_FAILED                     = EC(410038, "serve API failed")
+	ERR_INVALID_API_REQUEST                  = EC(410039, "invalid API request")
+	ERR_API_UNAUTHORIZED                     = EC(410040, "unauthorized API request, please specify token by header 'Authorization: Bearer TOKEN'")
+	ERR_UNSUPPORT_API_OPERATION              = EC(410041, "unsupport API operation")
+	ERR_API_JOB_NOT_FOUND                    = EC(410042, "API job not found")
+	ERR_TOO_MANY_PENDING_API_JOBS            = EC(410043, "too many pending API jobs")
+	ERR_SERVE_BOT_FAILED                     = EC(410044, "serve bot failed")
+	ERR_UNSUPPORT_BOT_COMMAND                = EC(410045, "unsupport bot command, only read-only commands allowed")
+	ERR_BOT_UNAUTHORIZED                     = EC(410046, "unauthorized bot request")
+	ERR_TOO_MANY_PENDING_BOT_COMMANDS        = EC(410047, "too many pending bot commands")
+	ERR_GET_PLUGIN_MANIFEST_FAILED           = EC(410048, "get plugin manifest failed")
+	ERR_UNSUPPORT_PLUGIN_PROTOCOL            = EC(410049, "unsupport plugin protocol version")
+	ERR_RUN_PLUGIN_FAILED                    = EC(410050, "run plugin failed")
+	ERR_INSTALL_PLUGIN_FAILED                = EC(410051, "install plugin failed")
+	ERR_REMOVE_PLUGIN_FAILED                 = EC(410052, "remove plugin failed")
+	ERR_SEND_REPORT_FAILED                   = EC(410053, "send report failed")
+	ERR_TOO_MANY_PENDDING_CHUNKSERVERS       = EC(410054, "too many chunkservers would be pendding, copysets can't be migrated")
+	ERR_CHANGE_ETCD_MEMBER_FAILED            = EC(410055, "change etcd member failed")
+	ERR_WAIT_ETCD_CLUSTER_HEALTHY_TIMEOUT    = EC(410056, "wait etcd cluster healthy timeout")
+	ERR_BACKUP_ETCD_SNAPSHOT_FAILED          = EC(410057, "backup etcd snapshot failed")
+	ERR_VERIFY_ETCD_SNAPSHOT_FAILED          = EC(410058, "verify etcd snapshot failed")
+	ERR_RESTORE_ETCD_DATA_FAILED             = EC(410059, "restore etcd data failed")
+	ERR_STORE_BACKUP_FAILED                  = EC(410060, "store backup failed")
+	ERR_FETCH_BACKUP_FAILED                  = EC(410061, "fetch backup failed")
+	ERR_DUMP_MDS_METADATA_FAILED             = EC(410062, "dump mds metadata failed")
+	ERR_BACKUP_DATABASE_FAILED               = EC(410063, "backup database of curveadm failed")
+	ERR_RUN_BACKUP_SCHEDULES_FAILED          = EC(410064, "some backup schedules run failed")
 
 	// 420: common (curvebs client)
 	ERR_VOLUME_ALREADY_MAPPED             = EC(420000, "volume already mapped")
@@ -409,12 +710,17 @@ var (
 	ERR_UNMAP_VOLUME_FAILED               = EC(420006, "unmap volume failed")
 	ERR_OLD_TARGET_DAEMON_IS_ABNORMAL     = EC(420007, "old target daemon is abnormal")
 	ERR_TARGET_DAEMON_IS_ABNORMAL         = EC(420008, "target daemon is abnormal")
+	ERR_DECODE_VOLUME_INFO_FAILED         = EC(420009, "decode volume info from json failed")
+	ERR_SET_VOLUME_THROTTLE_FAILED        = EC(420010, "set volume throttle failed")
+	ERR_UNSUPPORT_VOLUME_ACTION           = EC(420011, "unsupport volume action")
+	ERR_MANAGE_VOLUME_FAILED              = EC(420012, "manage volume failed")
 
 	// 430: common (curvefs client)
-	ERR_FS_PATH_ALREADY_MOUNTED  = EC(430000, "path already mounted")
-	ERR_CREATE_FILESYSTEM_FAILED = EC(430001, "create filesystem failed")
-	ERR_MOUNT_FILESYSTEM_FAILED  = EC(430002, "mount filesystem failed")
-	ERR_UMOUNT_FILESYSTEM_FAILED = EC(430003, "umount filesystem failed")
+	ERR_FS_PATH_ALREADY_MOUNTED       = EC(430000, "path already mounted")
+	ERR_CREATE_FILESYSTEM_FAILED      = EC(430001, "create filesystem failed")
+	ERR_MOUNT_FILESYSTEM_FAILED       = EC(430002, "mount filesystem failed")
+	ERR_UMOUNT_FILESYSTEM_FAILED      = EC(430003, "umount filesystem failed")
+	ERR_DECODE_FILESYSTEM_INFO_FAILED = EC(430004, "decode filesystem info from json failed")
 
 	// 440: common (polarfs)
 	ERR_GET_OS_REELASE_FAILED       = EC(440000, "get os release failed")
@@ -424,6 +730,28 @@ var (
 	// 450: common (playground)
 	ERR_PLAYGROUND_NOT_FOUND = EC(450000, "playground not found")
 
+	// 460: common (compatibility)
+	ERR_UNSUPPORT_IMAGE_VERSION = EC(460000, "image version is not supported by current curveadm")
+
+	// 470: common (benchmark)
+	ERR_RUN_FIO_FAILED          = EC(470000, "run fio failed")
+	ERR_PARSE_FIO_OUTPUT_FAILED = EC(470001, "parse fio output failed")
+	ERR_BENCH_TARGET_NOT_FOUND  = EC(470002, "bench target not found")
+
+	// 480: common (certificate)
+	ERR_GENERATE_CERTIFICATE_FAILED = EC(480000, "generate certificate failed")
+	ERR_CERTIFICATE_NOT_FOUND       = EC(480001, "certificate not found")
+	ERR_INVALID_CERTIFICATE         = EC(480002, "invalid certificate")
+	ERR_TLS_NOT_ENABLED             = EC(480003, "tls is not enabled in topology")
+
+	// 490: common (artifact verification)
+	ERR_DOWNLOAD_ARTIFACT_FAILED         = EC(490000, "download artifact failed")
+	ERR_DOWNLOAD_CHECKSUM_FAILED         = EC(490001, "download published checksum of artifact failed")
+	ERR_ARTIFACT_CHECKSUM_MISMATCH       = EC(490002, "artifact checksum mismatch with published one")
+	ERR_READ_TRUST_ROOT_FAILED           = EC(490003, "read trust root failed")
+	ERR_VERIFY_ARTIFACT_SIGNATURE_FAILED = EC(490004, "verify GPG signature of artifact checksum failed")
+	ERR_VERIFY_IMAGE_SIGNATURE_FAILED    = EC(490006, "verify image signature by cosign failed")
+
 	// 500: checker (topology/s3)
 	ERR_INVALID_S3_ACCESS_KEY  = EC(500000, "invalid S3 access key")
 	ERR_INVALID_S3_SECRET_KEY  = EC(500001, "invalid S3 secret key")
@@ -434,6 +762,7 @@ var (
 	ERR_DATA_DIRECTORY_ALREADY_IN_USE   = EC(501001, "data directory already in use")
 	// 502: checker (topology/address)
 	ERR_DUPLICATE_LISTEN_ADDRESS = EC(502000, "listen address is duplicate")
+	ERR_INCONSISTENT_IP_FAMILY   = EC(502001, "IP family (IPv4/IPv6) of listen addresses is inconsistent across the cluster")
 	// 503: checker (topology/service)
 	ERR_ETCD_REQUIRES_3_SERVICES          = EC(503000, "etcd requires at least 3 services")
 	ERR_MDS_REQUIRES_3_SERVICES           = EC(503001, "mds requires at least 3 services")
@@ -469,9 +798,20 @@ var (
 	// 550: checker (date)
 	ERR_INVALID_DATE_FORMAT                  = EC(550000, "invalid date format")
 	ERR_HOST_TIME_DIFFERENCE_OVER_30_SECONDS = EC(550001, "host time difference over 30 seconds")
+	ERR_HOST_CLOCK_SKEW_EXCEED_THRESHOLD     = EC(550002, "host clock skew exceed threshold")
+	ERR_NTP_REFERENCE_HOST_NOT_FOUND         = EC(550003, "ntp reference host not found in topology")
+	ERR_INSTALL_CHRONY_FAILED                = EC(550004, "install chrony failed")
+	ERR_CONFIGURE_CHRONY_FAILED              = EC(550005, "configure chrony failed")
 
 	// 560: checker (service)
-	ERR_CHUNKFILE_POOL_NOT_EXIST = EC(560000, "there is no chunkfile pool in data directory")
+	ERR_CHUNKFILE_POOL_NOT_EXIST            = EC(560000, "there is no chunkfile pool in data directory")
+	ERR_HOST_CPUS_NOT_ENOUGH_FOR_SERVICES   = EC(560001, "host cpus are not enough for reservations of services")
+	ERR_HOST_MEMORY_NOT_ENOUGH_FOR_SERVICES = EC(560002, "host memory is not enough for reservations of services")
+	ERR_CPUSET_CPUS_EXCEED_HOST_CPUS        = EC(560003, "cpuset cpus exceed cpus of host")
+	ERR_S3_ENDPOINT_UNREACHABLE             = EC(560004, "S3 endpoint is unreachable")
+	ERR_S3_CREDENTIALS_REJECTED             = EC(560005, "S3 access key or secret key rejected")
+	ERR_S3_BUCKET_NOT_EXIST                 = EC(560006, "S3 bucket not exist")
+	ERR_S3_PROBE_OBJECT_FAILED              = EC(560007, "write/read/delete S3 probe object failed")
 
 	// 570: checker (client)
 	ERR_INVALID_CURVEFS_CLIENT_S3_ACCESS_KEY  = EC(570000, "invalid curvefs client S3 access key")
@@ -483,6 +823,7 @@ var (
 	ERR_CONTAINER_ENGINE_NOT_INSTALLED = EC(590000, "container engine docker/podman not installed")
 	ERR_DOCKER_DAEMON_IS_NOT_RUNNING   = EC(590001, "docker daemon is not running")
 	ERR_NO_SPACE_LEFT_ON_DEVICE        = EC(590002, "no space left on device")
+	ERR_PLUGIN_PRECHECK_FAILED         = EC(590003, "plugin precheck failed")
 
 	// 600: exeute task (common)
 	ERR_EXECUTE_COMMAND_TIMED_OUT = EC(600000, "execute command timed out")
@@ -490,11 +831,13 @@ var (
 	ERR_WRITE_FILE_FAILED         = EC(600002, "write file failed")
 	ERR_BUILD_REGEX_FAILED        = EC(600003, "build regex failed")
 	ERR_BUILD_TEMPLATE_FAILED     = EC(600004, "build template failed")
+	ERR_RENDER_TEMPLATE_FAILED    = EC(600005, "render template failed")
 
 	// 610: exeute task (ssh command)
 	ERR_DOWNLOAD_FILE_FROM_REMOTE_BY_SSH_FAILED         = EC(610000, "download file from remote by ssh failed")
 	ERR_UPLOAD_FILE_TO_REMOTE_BY_SSH_FAILED             = EC(610001, "upload file to remote by ssh failed")
 	ERR_CONNECT_REMOTE_HOST_WITH_INTERACT_BY_SSH_FAILED = EC(610002, "connect remote host with interact by ssh failed")
+	ERR_TRANSFERRED_FILE_CHECKSUM_MISMATCH              = EC(610003, "transferred file checksum (sha256) mismatch")
 
 	// 620: execute task (shell command)
 	ERR_EDIT_FILE_FAILED                           = EC(620000, "edit file failed (sed)")
@@ -526,6 +869,17 @@ var (
 	ERR_SECURE_COPY_FILE_TO_REMOTE_FAILED          = EC(620026, "secure copy file to remote failed (scp)")
 	ERR_GET_BLOCK_DEVICE_UUID_FAILED               = EC(620027, "get block device uuid failed (blkid)")
 	ERR_RESERVE_FILESYSTEM_BLOCKS_FAILED           = EC(620028, "reserve filesystem blocks (tune2fs)")
+	ERR_GATHER_HOST_FACTS_FAILED                   = EC(620029, "gather host facts failed")
+	ERR_CHANGE_FILE_OWNER_FAILED                   = EC(620030, "change file owner failed (chown)")
+	ERR_RELOAD_SYSTEMD_MANAGER_FAILED              = EC(620031, "reload systemd manager configuration failed (systemctl daemon-reload)")
+	ERR_START_SYSTEMD_UNIT_FAILED                  = EC(620032, "start systemd unit failed (systemctl start)")
+	ERR_STOP_SYSTEMD_UNIT_FAILED                   = EC(620033, "stop systemd unit failed (systemctl stop)")
+	ERR_RESTART_SYSTEMD_UNIT_FAILED                = EC(620034, "restart systemd unit failed (systemctl restart)")
+	ERR_ENABLE_SYSTEMD_UNIT_FAILED                 = EC(620035, "enable systemd unit failed (systemctl enable)")
+	ERR_DISABLE_SYSTEMD_UNIT_FAILED                = EC(620036, "disable systemd unit failed (systemctl disable)")
+	ERR_GET_SYSTEMD_UNIT_STATUS_FAILED             = EC(620037, "get systemd unit status failed (systemctl is-active)")
+	ERR_COMPUTE_SHA256_CHECKSUM_FAILED             = EC(620038, "compute SHA256 message digest failed (sha256sum)")
+	ERR_SYSTEMD_UNIT_IS_NOT_ACTIVE                 = EC(620040, "systemd unit is not active")
 	ERR_RUN_SCRIPT_FAILED                          = EC(620998, "run script failed (bash script.sh)")
 	ERR_RUN_A_BASH_COMMAND_FAILED                  = EC(620999, "run a bash command failed (bash -c)")
 
@@ -545,9 +899,18 @@ var (
 	ERR_INSPECT_CONTAINER_FAILED         = EC(630012, "get container low-level information failed")
 	ERR_GET_CONTAINER_LOGS_FAILED        = EC(630013, "get container logs failed")
 	ERR_UPDATE_CONTAINER_FAILED          = EC(630014, "update container failed")
+	ERR_SAVE_IMAGE_FAILED                = EC(630015, "save image failed")
+	ERR_LOAD_IMAGE_FAILED                = EC(630016, "load image failed")
+	ERR_CREATE_NETWORK_FAILED            = EC(630017, "create network failed")
+	ERR_REMOVE_NETWORK_FAILED            = EC(630018, "remove network failed")
+	ERR_TAG_IMAGE_FAILED                 = EC(630019, "tag image failed")
+	ERR_SET_DAEMON_PROXY_FAILED          = EC(630020, "configure HTTP proxy of docker daemon failed")
 
 	// 690: execuetr task (others)
 	ERR_START_CRONTAB_IN_CONTAINER_FAILED = EC(690000, "start crontab in container failed")
+	ERR_CHECK_ALERT_RULES_FAILED          = EC(690001, "check alert rules by promtool failed")
+	ERR_RELOAD_PROMETHEUS_FAILED          = EC(690002, "reload prometheus failed")
+	ERR_INSTALL_CONTAINER_ENGINE_FAILED   = EC(690003, "install container engine failed")
 
 	// 900: others
 	ERR_CANCEL_OPERATION = EC(CODE_CANCEL_OPERATION, "cancel operation")
@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package errno
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtAndGroup(t *testing.T) {
+	assert := assert.New(t)
+	ec := EC(999001, "test failed").Hint("try again")
+
+	e1 := ec.S("connection refused").At("host1", "Start Service (StartContainer)")
+	e2 := ec.S("connection reset").At("host2", "Start Service (StartContainer)")
+	assert.Equal([]string{"host1"}, e1.GetHosts())
+	assert.Empty(ec.GetHosts()) // declared error code untouched
+	assert.True(errors.Is(e1, ec))
+	assert.True(errors.Is(fmt.Errorf("wrapped: %w", e1), ec))
+	assert.False(errors.Is(e1, ERR_UNKNOWN))
+
+	// same failure on different hosts
+	assert.True(e1.Group(e2))
+	assert.True(e1.Group(e2))
+	assert.Equal([]string{"host1", "host2"}, e1.GetHosts())
+
+	assert.Equal("connection refused", e1.GetClue())
+
+	// different code or step, or without host
+	assert.False(e1.Group(ERR_UNKNOWN.At("host3", "Start Service (StartContainer)")))
+	assert.False(e1.Group(ec.S("connection refused").At("host3", "Stop Service (StopContainer)")))
+	assert.False(e1.Group(ec.S("connection refused")))
+	assert.Equal([]string{"host1", "host2"}, e1.GetHosts())
+}
+
+func TestErrorOutput(t *testing.T) {
+	assert := assert.New(t)
+	ec := EC(999002, "test failed").Hint("check the disk", "retry later")
+
+	lines := []string{}
+	for i := 1; i <= 15; i++ {
+		lines = append(lines, fmt.Sprintf("line%d", i))
+	}
+	out := ec.S(strings.Join(lines, "\n")+"\n").At("host1", "Format (Mkfs)").Error()
+	assert.Contains(out, "Error-Host: ")
+	assert.Contains(out, "host1")
+	assert.Contains(out, "Format (Mkfs)")
+	assert.Contains(out, "5 lines omitted")
+	assert.NotContains(out, "line5\n")
+	assert.Contains(out, "line15")
+	assert.Contains(out, "Try This:")
+	assert.Contains(out, "check the disk")
+	assert.Contains(out, "retry later")
+	assert.Contains(out, "wiki/errno9#999002")
+
+	out = ec.Doc("https://example.com/doc").S("").Error()
+	assert.NotContains(out, "Error-Host")
+	assert.NotContains(out, "Error-Clue")
+	assert.Contains(out, "https://example.com/doc")
+}
+
+func TestHintTranslated(t *testing.T) {
+	assert := assert.New(t)
+	defer i18n.SetLanguage(i18n.Language())
+
+	i18n.SetLanguage(i18n.LANG_ZH)
+	for _, e := range elist {
+		if e.code >= 999000 { // declared by test
+			continue
+		}
+		for _, hint := range e.hints {
+			assert.NotEqual(hint, i18n.T(hint), "%06d: %s", e.code, hint)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package errno
+
+import (
+	"errors"
+	"os/exec"
+
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+/*
+ * exit codes of curveadm, they are stable for pipelines to branch on:
+ *
+ *   0  success
+ *   1  unknown error
+ *   2  invalid usage: unknown command, options or arguments (2xxxxx)
+ *   3  invalid configure: curveadm.cfg, hosts, topology ... (3xxxxx)
+ *   4  precheck failed (5xxxxx)
+ *   5  execute task on remote hosts failed (6xxxxx)
+ *   6  operation cancelled (900000)
+ *   7  curveadm itself failed: init or database (0xxxxx, 1xxxxx)
+ *   8  operation failed (4xxxxx)
+ */
+const (
+	EXIT_CODE_SUCCESS   = 0
+	EXIT_CODE_UNKNOWN   = 1
+	EXIT_CODE_USAGE     = 2
+	EXIT_CODE_CONFIGURE = 3
+	EXIT_CODE_PRECHECK  = 4
+	EXIT_CODE_REMOTE    = 5
+	EXIT_CODE_CANCELLED = 6
+	EXIT_CODE_LOCAL     = 7
+	EXIT_CODE_OPERATION = 8
+)
+
+var exitCodes = map[int]int{ // error class (the first digit of error code) -> exit code
+	0: EXIT_CODE_LOCAL,
+	1: EXIT_CODE_LOCAL,
+	2: EXIT_CODE_USAGE,
+	3: EXIT_CODE_CONFIGURE,
+	4: EXIT_CODE_OPERATION,
+	5: EXIT_CODE_PRECHECK,
+	6: EXIT_CODE_REMOTE,
+}
+
+// ExitCode returns exit code for err which returned by command
+func ExitCode(err error) int {
+	var ec *ErrorCode
+	var exitErr *exec.ExitError
+	var usageErr *utils.UsageError
+	if err == nil {
+		return EXIT_CODE_SUCCESS
+	} else if errors.As(err, &exitErr) { // plugin exited with its own code
+		return exitErr.ExitCode()
+	} else if errors.As(err, &usageErr) { // returned by command line parser
+		return EXIT_CODE_USAGE
+	} else if !errors.As(err, &ec) {
+		return EXIT_CODE_UNKNOWN
+	} else if ec.code == CODE_CANCEL_OPERATION {
+		return EXIT_CODE_CANCELLED
+	} else if code, ok := exitCodes[ec.code/100000]; ok {
+		return code
+	}
+	return EXIT_CODE_UNKNOWN
+}
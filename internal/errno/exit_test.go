@@ -0,0 +1,61 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package errno
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	assert := assert.New(t)
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{nil, EXIT_CODE_SUCCESS},
+		{utils.NewUsageError(errors.New("unknown flag: --foo")), EXIT_CODE_USAGE},
+		{fmt.Errorf("wrapped: %w", utils.NewUsageError(errors.New("accepts no arguments"))), EXIT_CODE_USAGE},
+		{errors.New("connection refused"), EXIT_CODE_UNKNOWN},
+		{ERR_INIT_LOGGER_FAILED, EXIT_CODE_LOCAL},
+		{ERR_INIT_SQL_DATABASE_FAILED, EXIT_CODE_LOCAL},
+		{ERR_NO_SERVICES_MATCHED, EXIT_CODE_USAGE},
+		{ERR_PARSE_TOPOLOGY_FAILED, EXIT_CODE_CONFIGURE},
+		{ERR_PLAYGROUND_NOT_FOUND, EXIT_CODE_OPERATION},
+		{ERR_PLUGIN_PRECHECK_FAILED, EXIT_CODE_PRECHECK},
+		{ERR_RUN_A_BASH_COMMAND_FAILED, EXIT_CODE_REMOTE},
+		{ERR_CANCEL_OPERATION, EXIT_CODE_CANCELLED},
+		{ERR_UNKNOWN, EXIT_CODE_UNKNOWN},
+		{fmt.Errorf("wrapped: %w", ERR_PARSE_TOPOLOGY_FAILED), EXIT_CODE_CONFIGURE},
+	} {
+		assert.Equal(tc.code, ExitCode(tc.err), "%v", tc.err)
+	}
+
+	err := exec.Command("sh", "-c", "exit 42").Run()
+	assert.Equal(42, ExitCode(err))
+}
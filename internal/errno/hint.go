@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package errno
+
+/*
+ * remediation hints of the common failures, they are rendered in the
+ * "Try This" section of error output, e.g:
+ *
+ *   Error-Code: 510000
+ *   Error-Description: SSH connect failed
+ *   Error-Host: host1, host2
+ *   Error-Step: Check SSH Connect <ssh> (Connect)
+ *   Error-Clue: dial tcp 10.0.1.1:22: connect: connection refused
+ *   Try This:
+ *     * ping the host and make sure sshd is listening on the configured port
+ *     * ...
+ */
+func init() {
+	// 5xx: checker
+	ERR_SSH_CONNECT_FAILED.Hint(
+		"ping the host and make sure sshd is listening on the configured port",
+		"verify user, port and private key of the host by 'curveadm hosts show'",
+		"run 'curveadm hosts ping' to check all hosts, add '--fix-known-hosts' if host key changed")
+	ERR_CREATE_DIRECOTRY_PERMISSION_DENIED.Hint(
+		"make sure the SSH user has write permission on the directory, or enable 'become_user' in hosts")
+	ERR_EXECUTE_CONTAINER_ENGINE_COMMAND_PERMISSION_DENIED.Hint(
+		"add the SSH user into docker group: sudo usermod -aG docker <user>",
+		"or set 'sudo_alias' in curveadm.cfg to run docker with sudo")
+	ERR_KERNEL_NBD_MODULE_NOT_LOADED.Hint(
+		"load the module on the host: sudo modprobe nbd")
+	ERR_KERNEL_FUSE_MODULE_NOT_LOADED.Hint(
+		"load the module on the host: sudo modprobe fuse")
+	ERR_PORT_ALREADY_IN_USE.Hint(
+		"find the process which is listening on the port: sudo ss -tlnp | grep <port>",
+		"stop the process, or change the port of service in topology by 'curveadm config commit'")
+	ERR_HOST_CLOCK_SKEW_EXCEED_THRESHOLD.Hint(
+		"run 'curveadm precheck --ntp --ntp-fix' to install and configure chrony for skewed hosts")
+	ERR_CHUNKFILE_POOL_NOT_EXIST.Hint(
+		"format the disks by 'curveadm format -f format.yaml' before deploy",
+		"check the formatting progress by 'curveadm format --status'")
+	ERR_CONTAINER_ENGINE_NOT_INSTALLED.Hint(
+		"install docker (or podman) on the host, see https://docs.docker.com/engine/install/")
+	ERR_DOCKER_DAEMON_IS_NOT_RUNNING.Hint(
+		"start docker daemon on the host: sudo systemctl start docker")
+	ERR_NO_SPACE_LEFT_ON_DEVICE.Hint(
+		"free up space of data directory or docker root directory on the host: df -h")
+
+	// 6xx: execute task
+	ERR_EXECUTE_COMMAND_TIMED_OUT.Hint(
+		"check the load and network of the host, then retry",
+		"increase 'timeout' in curveadm.cfg if the host is slow")
+	ERR_PULL_IMAGE_FAILED.Hint(
+		"check the image name and tag in topology",
+		"make sure the host can reach the registry, or configure 'registry-mirrors' of docker",
+		"run 'docker login <registry>' on the host if the registry requires authentication")
+	ERR_START_CONTAINER_FAILED.Hint(
+		"check the logs of service by 'curveadm logs --id <id>'",
+		"check the configure of service in topology, e.g: listen port, data directory")
+}
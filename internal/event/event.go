@@ -0,0 +1,177 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package event
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * every operation which changes the state of cluster emits an event once
+ * it finished, e.g:
+ *
+ *   {"id": "...", "type": "cluster.deployed", "status": "success", "cluster": "c1", ...}
+ *   {"id": "...", "type": "precheck.failed", "status": "fail", "code": 500000, ...}
+ *
+ * the type of failed or canceled operation is derived from the mapped type,
+ * it's "<resource>.<verb>.failed" or "<resource>.<verb>.canceled", e.g:
+ *
+ *   curveadm deploy:        cluster.deployed, cluster.deploy.failed
+ *   curveadm config commit: topology.committed, topology.commit.canceled
+ *   curveadm precheck:      precheck.passed, precheck.failed
+ *
+ * the invocation which only shows status or plan (e.g. "format --status",
+ * "apply --plan") changes nothing and emits nothing.
+ */
+const (
+	STATUS_SUCCESS = "success"
+	STATUS_FAIL    = "fail"
+	STATUS_CANCEL  = "cancel"
+
+	SUFFIX_FAILED   = "failed"
+	SUFFIX_CANCELED = "canceled"
+)
+
+type Event struct {
+	Id          string    `json:"id"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Code        int       `json:"code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Cluster     string    `json:"cluster"`
+	ClusterUUId string    `json:"cluster_uuid"`
+	Command     string    `json:"command"`
+	Operator    string    `json:"operator"`
+	Source      string    `json:"source"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+}
+
+var (
+	// the flags which make command read-only
+	READONLY_FLAGS = []string{"--status", "--plan", "--dry-run"}
+
+	// command path (without "curveadm") -> event type, others are read-only and emit nothing
+	EVENT_TYPES = map[string]string{
+		"deploy":           "cluster.deployed",
+		"clean":            "cluster.cleaned",
+		"scale-out":        "cluster.scaled_out",
+		"scale-in":         "cluster.scaled_in",
+		"apply":            "cluster.applied",
+		"start":            "service.started",
+		"stop":             "service.stopped",
+		"restart":          "service.restarted",
+		"reload":           "service.reloaded",
+		"migrate":          "service.migrated",
+		"upgrade":          "upgrade.completed",
+		"rollback":         "upgrade.rolledback",
+		"format":           "disk.formatted",
+		"precheck":         "precheck.passed",
+		"config commit":    "topology.committed",
+		"config apply":     "topology.applied",
+		"hosts commit":     "hosts.committed",
+		"hosts import":     "hosts.imported",
+		"cluster add":      "cluster.added",
+		"cluster rm":       "cluster.removed",
+		"cluster clone":    "cluster.cloned",
+		"cluster import":   "cluster.imported",
+		"cert generate":    "certificate.generated",
+		"cert distribute":  "certificate.distributed",
+		"cert rotate":      "certificate.rotated",
+		"monitor deploy":   "monitor.deployed",
+		"monitor clean":    "monitor.cleaned",
+		"client install":   "client.installed",
+		"client uninstall": "client.uninstalled",
+		"client map":       "volume.mapped",
+		"client unmap":     "volume.unmapped",
+		"client unmap-all": "volume.unmapped",
+		"client mount":     "filesystem.mounted",
+		"client umount":    "filesystem.umounted",
+		"map":              "volume.mapped",
+		"unmap":            "volume.unmapped",
+		"mount":            "filesystem.mounted",
+		"umount":           "filesystem.umounted",
+		"volume create":    "volume.created",
+		"volume clone":     "volume.cloned",
+		"volume extend":    "volume.extended",
+		"volume rm":        "volume.removed",
+		"volume snapshot":  "volume.snapshotted",
+		"playground run":   "playground.created",
+		"playground rm":    "playground.removed",
+	}
+)
+
+// TypeOf returns type of event for command with status, empty if the command emits nothing
+func TypeOf(command, status string) string {
+	command = strings.TrimSpace(strings.TrimPrefix(command, "curveadm"))
+	typ, ok := EVENT_TYPES[command]
+	if !ok {
+		return ""
+	}
+
+	// resource of mapped type and verb of command, e.g: "topology", "commit"
+	fields := strings.Fields(command)
+	resource, verb := strings.SplitN(typ, ".", 2)[0], fields[len(fields)-1]
+	prefix := resource
+	if verb != resource {
+		prefix = fmt.Sprintf("%s.%s", resource, verb)
+	}
+	switch status {
+	case STATUS_FAIL:
+		return fmt.Sprintf("%s.%s", prefix, SUFFIX_FAILED)
+	case STATUS_CANCEL:
+		return fmt.Sprintf("%s.%s", prefix, SUFFIX_CANCELED)
+	}
+	return typ
+}
+
+// IsReadOnly returns true if any flag in args makes command read-only, e.g: "--plan"
+func IsReadOnly(args []string) bool {
+	readonly := map[string]bool{}
+	for _, flag := range READONLY_FLAGS {
+		readonly[flag] = true
+	}
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, value, _ := strings.Cut(arg, "=")
+		if readonly[name] && value != "false" {
+			return true
+		}
+	}
+	return false
+}
+
+func NewEvent(typ, status string) *Event {
+	return &Event{
+		Id:      uuid.NewString(),
+		Type:    typ,
+		Status:  status,
+		EndTime: time.Now(),
+	}
+}
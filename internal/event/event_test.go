@@ -0,0 +1,121 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package event
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeOf(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("cluster.deployed", TypeOf("curveadm deploy", STATUS_SUCCESS))
+	assert.Equal("service.restarted", TypeOf("curveadm restart", STATUS_SUCCESS))
+	assert.Equal("upgrade.completed", TypeOf("curveadm upgrade", STATUS_SUCCESS))
+	assert.Equal("precheck.failed", TypeOf("curveadm precheck", STATUS_FAIL))
+	assert.Equal("topology.commit.canceled", TypeOf("curveadm config commit", STATUS_CANCEL))
+	assert.Equal("cluster.deploy.failed", TypeOf("curveadm deploy", STATUS_FAIL))
+	assert.Equal("volume.unmap-all.failed", TypeOf("curveadm client unmap-all", STATUS_FAIL))
+	assert.Equal("upgrade.failed", TypeOf("curveadm upgrade", STATUS_FAIL))
+	assert.Equal("", TypeOf("curveadm status", STATUS_SUCCESS))
+	assert.Equal("", TypeOf("curveadm hosts ls", STATUS_FAIL))
+}
+
+func TestIsReadOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsReadOnly([]string{"format", "--status"}))
+	assert.True(IsReadOnly([]string{"apply", "--plan=true"}))
+	assert.False(IsReadOnly([]string{"apply", "--plan=false"}))
+	assert.False(IsReadOnly([]string{"deploy", "-k"}))
+	assert.False(IsReadOnly([]string{"exec", "--host", "@all", "--", "ls", "--status"}))
+}
+
+func TestEmit(t *testing.T) {
+	assert := assert.New(t)
+
+	bodies := map[string][]byte{}
+	contentTypes := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodies[r.URL.Path], _ = io.ReadAll(r.Body)
+		contentTypes[r.URL.Path] = r.Header.Get("Content-Type")
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	filename := path.Join(t.TempDir(), "logs", "events.ndjson")
+	bus := New(Config{
+		Webhooks:       []string{server.URL + "/hook", server.URL + "/broken"},
+		KafkaRestProxy: server.URL + "/",
+		KafkaTopic:     "curveadm-events",
+		File:           filename,
+	})
+	assert.True(bus.Enabled())
+	assert.False(New(Config{}).Enabled())
+
+	e := NewEvent("cluster.deployed", STATUS_SUCCESS)
+	e.Cluster = "c1"
+	e.ClusterUUId = "uuid1"
+	errs := bus.Emit(e)
+	assert.Len(errs, 1)
+	assert.Contains(errs, "webhook "+server.URL+"/broken")
+
+	// webhook
+	hook := Event{}
+	assert.Nil(json.Unmarshal(bodies["/hook"], &hook))
+	assert.Equal(CONTENT_TYPE_JSON, contentTypes["/hook"])
+	assert.Equal(e.Id, hook.Id)
+	assert.Equal("c1", hook.Cluster)
+
+	// kafka
+	records := kafkaRecords{}
+	assert.Nil(json.Unmarshal(bodies["/topics/curveadm-events"], &records))
+	assert.Equal(CONTENT_TYPE_KAFKA, contentTypes["/topics/curveadm-events"])
+	assert.Len(records.Records, 1)
+	assert.Equal("uuid1", records.Records[0].Key)
+	assert.Equal("cluster.deployed", records.Records[0].Value.Type)
+
+	// file: appended line by line
+	bus.Emit(NewEvent("service.restarted", STATUS_SUCCESS))
+	data, err := os.ReadFile(filename)
+	assert.Nil(err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(lines, 2)
+	last := Event{}
+	assert.Nil(json.Unmarshal([]byte(lines[1]), &last))
+	assert.Equal("service.restarted", last.Type)
+	info, err := os.Stat(filename)
+	assert.Nil(err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+}
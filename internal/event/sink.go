@@ -0,0 +1,188 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+/*
+ * [events]
+ * webhook = "http://cmdb.example.com/hook, http://chatops.example.com/hook"
+ * kafka_rest_proxy = "http://127.0.0.1:8082"
+ * kafka_topic = "curveadm-events"
+ * file = "/home/curve/.curveadm/logs/events.ndjson"
+ * timeout = 5
+ *
+ * kafka is reached by its REST proxy (confluent REST API v2),
+ * the events of one cluster keyed by cluster uuid to keep them in order.
+ */
+const (
+	CONTENT_TYPE_JSON  = "application/json"
+	CONTENT_TYPE_KAFKA = "application/vnd.kafka.json.v2+json"
+
+	DEFAULT_TIMEOUT = 5 * time.Second
+)
+
+type (
+	Config struct {
+		Webhooks       []string
+		KafkaRestProxy string
+		KafkaTopic     string
+		File           string // events appended as newline delimited JSON
+		Timeout        time.Duration
+	}
+
+	Sink interface {
+		Name() string
+		Emit(event *Event) error
+	}
+
+	Bus struct {
+		sinks []Sink
+	}
+
+	webhookSink struct {
+		url    string
+		client *http.Client
+	}
+
+	kafkaSink struct {
+		proxy  string
+		topic  string
+		client *http.Client
+	}
+
+	fileSink struct {
+		filename string
+	}
+
+	kafkaRecord struct {
+		Key   string `json:"key"`
+		Value *Event `json:"value"`
+	}
+
+	kafkaRecords struct {
+		Records []kafkaRecord `json:"records"`
+	}
+)
+
+func post(client *http.Client, url, contentType string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(url, contentType, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s responds %s", url, resp.Status)
+	}
+	return nil
+}
+
+func (s *webhookSink) Name() string { return "webhook " + s.url }
+
+func (s *webhookSink) Emit(event *Event) error {
+	return post(s.client, s.url, CONTENT_TYPE_JSON, event)
+}
+
+func (s *kafkaSink) Name() string { return "kafka " + s.topic }
+
+func (s *kafkaSink) Emit(event *Event) error {
+	url := fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(s.proxy, "/"), s.topic)
+	return post(s.client, url, CONTENT_TYPE_KAFKA, kafkaRecords{
+		Records: []kafkaRecord{{Key: event.ClusterUUId, Value: event}},
+	})
+}
+
+func (s *fileSink) Name() string { return "file " + s.filename }
+
+// Emit appends one line, the event may contain command line which is sensitive
+func (s *fileSink) Emit(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filename), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+func New(cfg Config) *Bus {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+	client := &http.Client{Timeout: timeout}
+
+	bus := &Bus{}
+	for _, url := range cfg.Webhooks {
+		bus.sinks = append(bus.sinks, &webhookSink{url: url, client: client})
+	}
+	if len(cfg.KafkaRestProxy) > 0 {
+		bus.sinks = append(bus.sinks, &kafkaSink{
+			proxy:  cfg.KafkaRestProxy,
+			topic:  cfg.KafkaTopic,
+			client: client,
+		})
+	}
+	if len(cfg.File) > 0 {
+		bus.sinks = append(bus.sinks, &fileSink{filename: cfg.File})
+	}
+	return bus
+}
+
+func (bus *Bus) Enabled() bool { return bus != nil && len(bus.sinks) > 0 }
+
+/*
+ * Emit delivers event to all sinks, one unreachable sink never blocks
+ * the others, the errors returned by sink name.
+ */
+func (bus *Bus) Emit(event *Event) map[string]error {
+	errs := map[string]error{}
+	for _, sink := range bus.sinks {
+		if err := sink.Emit(event); err != nil {
+			errs[sink.Name()] = err
+		}
+	}
+	return errs
+}
@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+// Package i18n translates user-facing strings (prompts, table headers and
+// error hints) into the language selected by 'language' in curveadm.cfg or
+// the locale environment, e.g: LANG=zh_CN.UTF-8.
+//
+// The English string itself is the key of message catalog, so the string
+// which has no translation is displayed as it is:
+//
+//	i18n.T("Do you want to continue?")           // 是否继续？
+//	i18n.Tf("cluster '%s' not found", "my-cluster")
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	LANG_EN = "en"
+	LANG_ZH = "zh"
+)
+
+var (
+	// environment variables of locale, in order of precedence
+	LOCALE_ENVS = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+	catalogs = map[string]map[string]string{
+		LANG_EN: {},
+		LANG_ZH: zh,
+	}
+
+	gLanguage = LANG_EN
+)
+
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Detect returns the configured language, or the language of locale
+// environment if not configured, English is the default
+func Detect(configured string) string {
+	if len(configured) > 0 {
+		return configured
+	}
+	for _, env := range LOCALE_ENVS {
+		locale := os.Getenv(env)
+		if len(locale) == 0 {
+			continue
+		}
+		// e.g: zh_CN.UTF-8, zh_TW, C.UTF-8
+		lang := strings.ToLower(strings.SplitN(locale, "_", 2)[0])
+		if Supported(lang) {
+			return lang
+		}
+		return LANG_EN
+	}
+	return LANG_EN
+}
+
+// SetLanguage selects the catalog, the unsupported language is ignored
+func SetLanguage(lang string) {
+	if Supported(lang) {
+		gLanguage = lang
+	}
+}
+
+func Language() string {
+	return gLanguage
+}
+
+// T returns the translation of message, or message itself if not translated
+func T(message string) string {
+	if s, ok := catalogs[gLanguage][message]; ok {
+		return s
+	}
+	return message
+}
+
+// Tf formats the translation of format
+func Tf(format string, a ...interface{}) string {
+	return fmt.Sprintf(T(format), a...)
+}
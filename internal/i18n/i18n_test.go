@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	assert := assert.New(t)
+	for _, env := range LOCALE_ENVS {
+		t.Setenv(env, "")
+	}
+	assert.Equal(LANG_EN, Detect(""))
+	assert.Equal(LANG_ZH, Detect(LANG_ZH))
+
+	t.Setenv("LANG", "zh_CN.UTF-8")
+	assert.Equal(LANG_ZH, Detect(""))
+	assert.Equal(LANG_EN, Detect(LANG_EN))
+
+	// LC_ALL overrides LANG
+	t.Setenv("LC_ALL", "C.UTF-8")
+	assert.Equal(LANG_EN, Detect(""))
+}
+
+func TestTranslate(t *testing.T) {
+	assert := assert.New(t)
+	defer SetLanguage(Language())
+
+	SetLanguage(LANG_ZH)
+	assert.Equal("是否继续？", T("Do you want to continue?"))
+	assert.Equal("集群 '%s' 已受保护，请输入集群名称以确认：", T("Cluster '%s' is protected, type the cluster name to confirm:"))
+	assert.Equal("警告：主机 'host1' 上的卷 'vol1' 将被取消映射",
+		Tf("WARNING: volumes '%s' on host '%s' will be unmapped", "vol1", "host1"))
+	assert.Equal("not translated", T("not translated"))
+
+	// unsupported language is ignored
+	SetLanguage("fr")
+	assert.Equal(LANG_ZH, Language())
+
+	SetLanguage(LANG_EN)
+	assert.Equal("Do you want to continue?", T("Do you want to continue?"))
+}
+
+// translation must keep verbs and template actions of the English string
+func TestCatalog(t *testing.T) {
+	assert := assert.New(t)
+	verb := regexp.MustCompile(`%(\[\d\])?[sdv]`)
+	action := regexp.MustCompile(`\{\{[^}]*\}\}`)
+	for key, value := range zh {
+		assert.Len(verb.FindAllString(value, -1), len(verb.FindAllString(key, -1)), key)
+		assert.ElementsMatch(unique(action.FindAllString(key, -1)), unique(action.FindAllString(value, -1)), key)
+	}
+}
+
+func unique(items []string) []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,265 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package i18n
+
+// zh is the Chinese catalog, keyed by the English string
+var zh = map[string]string{
+	// prompts
+	"Do you want to continue?":                                                                                              "是否继续？",
+	" [yes/no]: (default=no)":                                                                                               " [yes/no]：（默认 no）",
+	" [yes/no]: yes (assumed)":                                                                                              " [yes/no]：yes（已默认确认）",
+	"Type the cluster name to confirm:":                                                                                     "请输入集群名称以确认：",
+	"Cluster '%s' is protected, type the cluster name to confirm:":                                                          "集群 '%s' 已受保护，请输入集群名称以确认：",
+	"WARNING: service items which matched will start":                                                                       "警告：匹配的服务将被启动",
+	"WARNING: stop service may cause client IO be hang":                                                                     "警告：停止服务可能导致客户端 IO 卡住",
+	"WARNING: service items which matched will restart":                                                                     "警告：匹配的服务将被重启",
+	"WARNING: service items which matched will reload":                                                                      "警告：匹配的服务将被重新加载",
+	"WARNING: service items which matched will be cleaned up":                                                               "警告：匹配的服务将被清理",
+	"WARNING: cluster '%s' will be removed,\nand all data in it will be cleaned up":                                         "警告：集群 '%s' 将被删除，\n其中所有数据都将被清理",
+	"WARNING: data listed above in cluster '%s' will be destroyed,\nand it can't be recovered":                              "警告：集群 '%s' 中上述数据将被销毁，\n且无法恢复",
+	"WARNING: hosts '%s' will be initialized,\nwhich install packages and change system settings":                           "警告：主机 '%s' 将被初始化，\n这会安装软件包并修改系统设置",
+	"WARNING: volumes '%s' on host '%s' will be unmapped":                                                                   "警告：主机 '%[2]s' 上的卷 '%[1]s' 将被取消映射",
+	"WARNING: volume '%s' will be deleted,\nand it can't be recovered":                                                      "警告：卷 '%s' 将被删除，\n且无法恢复",
+	"WARNING: etcd services on hosts '%s' will be stopped,\nand their data will be replaced by snapshot '%s'":               "警告：主机 '%s' 上的 etcd 服务将被停止，\n其数据将被快照 '%s' 替换",
+	"WARNING: protection of cluster '%s' will be removed,\ndestructive operations only require 'yes' to confirm after that": "警告：集群 '%s' 的保护将被移除，\n此后破坏性操作只需输入 'yes' 即可确认",
+	"WARNING: cluster '%s' can be cleaned or removed in next %s":                                                            "警告：集群 '%s' 在接下来的 %s 内可被清理或删除",
+	"scale out cluster": "扩容集群",
+	"migrate services":  "迁移服务",
+	// PROMPT_COMMON_WARNING
+	`{{.warning}} 
+  - Service id: {{.id}} ("*" means all id)
+  - Service role: {{.role}} ("*" means all roles)
+  - Service host: {{.host}} ("*" means all hosts)
+`: `{{.warning}}
+  - 服务 ID：{{.id}}（"*" 表示所有 ID）
+  - 服务角色：{{.role}}（"*" 表示所有角色）
+  - 服务主机：{{.host}}（"*" 表示所有主机）
+`,
+	// PROMPT_CLEAN_SERVICE
+	`{{.warning}}
+  - Service role: {{.role}} ("*" means all roles)
+  - Service host: {{.host}} ("*" means all hosts)
+  - Clean items : [{{.items}}]
+`: `{{.warning}}
+  - 服务角色：{{.role}}（"*" 表示所有角色）
+  - 服务主机：{{.host}}（"*" 表示所有主机）
+  - 清理项  ：[{{.items}}]
+`,
+	// PROMPT_COLLECT_SERVICE
+	`FYI:
+  > We will collect service logs for troubleshooting, 
+  > and send these logs to the curve center.
+  > Please don't worry about the data security,
+  > we guarantee that all logs are encrypted
+  > and only you have the secret key.
+`: `提示：
+  > 我们将收集服务日志用于排查问题，
+  > 并将这些日志发送到 Curve 中心。
+  > 请不用担心数据安全，
+  > 我们保证所有日志均已加密，
+  > 且只有您持有密钥。
+`,
+	// PROMPT_TOPOLOGY_CHANGE_NOTICE
+	`
+NOTICE: If you have modified the configuration of some services while 
+{{.operation}} and you want make these configurations effect, you 
+should reload the corresponding services after the {{.operation}} success.
+`: `
+注意：如果您在{{.operation}}时修改了某些服务的配置，并希望这些配置生效，
+请在{{.operation}}成功后重新加载（reload）相应的服务。
+`,
+	// PROMPT_FORMAT
+	`
+NOTICE: Now we run all formating container successfully and it will
+format disk in the background, please make sure that the formatting 
+all done before deploy cluster, you can use the "curveadm format --status" 
+to watch the formatting progress.
+`: `
+注意：所有格式化容器均已成功运行，磁盘将在后台进行格式化，
+请确保在部署集群前格式化全部完成，您可以使用 "curveadm format --status"
+查看格式化进度。
+`,
+	// PROMPT_CANCEL_OPERATION
+	`[x] {{.operation}} canceled`: `[x] 已取消{{.operation}}`,
+
+	// operations
+	"start service":   "启动服务",
+	"stop service":    "停止服务",
+	"restart service": "重启服务",
+	"reload service":  "重新加载服务",
+	"clean service":   "清理服务",
+	"upgrade service": "升级服务",
+	"remove cluster":  "删除集群",
+	"remove volume":   "删除卷",
+	"init hosts":      "初始化主机",
+	"commit hosts":    "提交主机",
+	"init":            "初始化",
+
+	// init wizard
+	"Cluster name":                               "集群名称",
+	"Cluster kind (curvebs/curvefs)":             "集群类型（curvebs/curvefs）",
+	"IP addresses of hosts (separated by comma)": "主机 IP 地址（以逗号分隔）",
+	"Names of hosts":                             "主机名称",
+	"SSH user":                                   "SSH 用户",
+	"SSH port":                                   "SSH 端口",
+	"SSH authentication (key/password)":          "SSH 认证方式（key/password）",
+	"SSH private key file":                       "SSH 私钥文件",
+	"SSH password":                               "SSH 密码",
+	"password is empty":                          "密码为空",
+	"Hosts of %s services":                       "%s 服务所在主机",
+	"Percent of each disk formatted into chunkfile pool":             "每块磁盘格式化为 chunkfile pool 的百分比",
+	"Disk devices of each chunkserver host (e.g. /dev/sdb,/dev/sdc)": "每台 chunkserver 主机的磁盘设备（如 /dev/sdb,/dev/sdc）",
+	"Container image": "容器镜像",
+	"WARNING: the committed hosts will be replaced by %s": "警告：已提交的主机将被 %s 替换",
+	"Cluster '%s' added and checked out, next steps:":     "集群 '%s' 已添加并切换，后续步骤：",
+
+	// impact summary
+	"Impact summary:":              "影响概要：",
+	"  - Operation : ":             "  - 操作    ：",
+	"  - Services  : ":             "  - 服务    ：",
+	"  - Hosts     : ":             "  - 主机    ：",
+	"  - Data dirs : ":             "  - 数据目录：",
+	"  - Estimated : ":             "  - 预计耗时：",
+	"untouched":                    "不涉及",
+	"unknown (no history)":         "未知（无历史记录）",
+	"%s (average of last %d runs)": "%s（最近 %d 次的平均值）",
+	"... and %d more":              "……以及其他 %d 项",
+
+	// error output
+	"Error-Code: ":                    "错误码：",
+	"Error-Description: ":             "错误描述：",
+	"Error-Host: ":                    "错误主机：",
+	"Error-Step: ":                    "错误步骤：",
+	"Error-Clue: ":                    "错误线索：",
+	"Try This:":                       "尝试以下操作：",
+	"How to Solve:":                   "解决方法：",
+	"  * Website: ":                   "  * 网站：",
+	"  * Log: ":                       "  * 日志：",
+	"  * WeChat: ":                    "  * 微信：",
+	"... (%d lines omitted, see log)": "……（省略 %d 行，详见日志）",
+	"SSH connect failed":              "SSH 连接失败",
+	"no cluster specified":            "未指定集群",
+	"cancel operation":                "操作已取消",
+
+	// error hints
+	"ping the host and make sure sshd is listening on the configured port":                           "ping 该主机，并确认 sshd 正在监听所配置的端口",
+	"verify user, port and private key of the host by 'curveadm hosts show'":                         "通过 'curveadm hosts show' 核对主机的用户、端口和私钥",
+	"run 'curveadm hosts ping' to check all hosts, add '--fix-known-hosts' if host key changed":      "执行 'curveadm hosts ping' 检查所有主机，若主机密钥已变更请加上 '--fix-known-hosts'",
+	"make sure the SSH user has write permission on the directory, or enable 'become_user' in hosts": "确认 SSH 用户对该目录有写权限，或在 hosts 中启用 'become_user'",
+	"add the SSH user into docker group: sudo usermod -aG docker <user>":                             "将 SSH 用户加入 docker 组：sudo usermod -aG docker <user>",
+	"or set 'sudo_alias' in curveadm.cfg to run docker with sudo":                                    "或在 curveadm.cfg 中设置 'sudo_alias'，以 sudo 运行 docker",
+	"load the module on the host: sudo modprobe nbd":                                                 "在主机上加载内核模块：sudo modprobe nbd",
+	"load the module on the host: sudo modprobe fuse":                                                "在主机上加载内核模块：sudo modprobe fuse",
+	"find the process which is listening on the port: sudo ss -tlnp | grep <port>":                   "查找监听该端口的进程：sudo ss -tlnp | grep <port>",
+	"stop the process, or change the port of service in topology by 'curveadm config commit'":        "停止该进程，或通过 'curveadm config commit' 修改拓扑中服务的端口",
+	"run 'curveadm precheck --ntp --ntp-fix' to install and configure chrony for skewed hosts":       "执行 'curveadm precheck --ntp --ntp-fix'，为时钟偏差的主机安装并配置 chrony",
+	"format the disks by 'curveadm format -f format.yaml' before deploy":                             "部署前通过 'curveadm format -f format.yaml' 格式化磁盘",
+	"check the formatting progress by 'curveadm format --status'":                                    "通过 'curveadm format --status' 查看格式化进度",
+	"install docker (or podman) on the host, see https://docs.docker.com/engine/install/":            "在主机上安装 docker（或 podman），参见 https://docs.docker.com/engine/install/",
+	"start docker daemon on the host: sudo systemctl start docker":                                   "在主机上启动 docker 守护进程：sudo systemctl start docker",
+	"free up space of data directory or docker root directory on the host: df -h":                    "清理主机上数据目录或 docker 根目录的空间：df -h",
+	"check the load and network of the host, then retry":                                             "检查主机的负载和网络后重试",
+	"increase 'timeout' in curveadm.cfg if the host is slow":                                         "如果主机较慢，请调大 curveadm.cfg 中的 'timeout'",
+	"check the image name and tag in topology":                                                       "检查拓扑中的镜像名称和标签",
+	"make sure the host can reach the registry, or configure 'registry-mirrors' of docker":           "确认主机能访问镜像仓库，或配置 docker 的 'registry-mirrors'",
+	"run 'docker login <registry>' on the host if the registry requires authentication":              "如果镜像仓库需要认证，请在主机上执行 'docker login <registry>'",
+	"check the logs of service by 'curveadm logs --id <id>'":                                         "通过 'curveadm logs --id <id>' 查看服务日志",
+	"check the configure of service in topology, e.g: listen port, data directory":                   "检查拓扑中服务的配置，例如：监听端口、数据目录",
+
+	// table headers
+	"Id":               "ID",
+	"Name":             "名称",
+	"Role":             "角色",
+	"Host":             "主机",
+	"Hosts":            "主机",
+	"Hostname":         "主机名",
+	"User":             "用户",
+	"Port":             "端口",
+	"Ports":            "端口",
+	"Private Key File": "私钥文件",
+	"Forward Agent":    "转发代理",
+	"Become User":      "切换用户",
+	"Labels":           "标签",
+	"Groups":           "分组",
+	"Envs":             "环境变量",
+	"Container Id":     "容器 ID",
+	"Image":            "镜像",
+	"Status":           "状态",
+	"State":            "状态",
+	"Health":           "健康",
+	"Replicas":         "副本数",
+	"Log Dir":          "日志目录",
+	"Data Dir":         "数据目录",
+	"Cluster":          "集群",
+	"Description":      "描述",
+	"Create Time":      "创建时间",
+	"Update Time":      "更新时间",
+	"Execute Time":     "执行时间",
+	"Apply Time":       "应用时间",
+	"Operator":         "操作者",
+	"Command":          "命令",
+	"Device":           "设备",
+	"MountPoint":       "挂载点",
+	"Mount Point":      "挂载点",
+	"Formatted":        "已格式化",
+	"Written":          "已写入",
+	"Rate":             "速率",
+	"Chunks":           "Chunk 数",
+	"Percent":          "百分比",
+	"Disks":            "磁盘数",
+	"Done":             "已完成",
+	"Progress":         "进度",
+	"Version":          "版本",
+	"Kind":             "类型",
+	"Type":             "类型",
+	"Size":             "大小",
+	"Path":             "路径",
+	"Directory":        "目录",
+	"Message":          "消息",
+	"Error":            "错误",
+	"Severity":         "级别",
+	"Item":             "检查项",
+	"Services":         "服务",
+	"Active":           "活跃",
+	"Protection":       "保护",
+	"Rules":            "规则",
+	"Rule":             "规则",
+	"Source":           "来源",
+	"Target":           "目标",
+	"Memory":           "内存",
+	"Disk":             "磁盘",
+	"Space":            "空间",
+	"Steps":            "步骤",
+	"Instances":        "实例数",
+	"OS":               "操作系统",
+	"Kernel":           "内核",
+	"Arch":             "架构",
+	"CPU":              "CPU",
+	"NIC":              "网卡",
+	"Address":          "地址",
+	"Reachable":        "可达",
+	"Auth":             "认证",
+	"Sudo":             "Sudo",
+	"Connect":          "连接耗时",
+	"RTT":              "RTT",
+}
@@ -0,0 +1,331 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package playbook
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+/*
+ * condition of playbook step, e.g:
+ *
+ *   facts.kernel < 5.0
+ *   options.CLIENT_KIND == curvebs && facts.os != centos
+ *   facts.arch == aarch64 || facts.arch == arm64
+ *
+ * variables:
+ *   facts.<name>:   facts of the host which task executed in, see step.HostFacts
+ *   options.<key>:  value stored in memory storage, see internal/common
+ *
+ * the condition which only references options is evaluated once before creating tasks,
+ * otherwise it will be evaluated in each task after the host facts gathered.
+ */
+const (
+	PREFIX_FACTS   = "facts."
+	PREFIX_OPTIONS = "options."
+
+	REGEX_VERSION      = "^(\\d+(?:\\.\\d+)*)"
+	REGEX_SUFFIX_CHUNK = "\\d+|\\D+"
+)
+
+var (
+	// NOTE: the order matters, ">=" must before ">"
+	CONDITION_OPERATORS = []string{"==", "!=", ">=", "<=", ">", "<"}
+)
+
+type lookupFunc func(name string) (string, bool)
+
+func factValue(facts *step.HostFacts, name string) (string, bool) {
+	switch name {
+	case step.FACT_OS:
+		return facts.OS, true
+	case step.FACT_OS_VERSION:
+		return facts.OSVersion, true
+	case step.FACT_KERNEL_RELEASE:
+		return facts.KernelRelease, true
+	case step.FACT_ARCH:
+		return facts.Arch, true
+	case step.FACT_CPUS:
+		return strconv.Itoa(facts.CPUs), true
+	case step.FACT_MEMORY:
+		return strconv.FormatUint(facts.Memory, 10), true
+	case step.FACT_CONTAINER_ENGINE:
+		return facts.ContainerEngine, true
+	}
+	return "", false
+}
+
+// parseVersion returns the leading version and the suffix after it,
+// e.g: "5.10.0-23-amd64" => [5 10 0], "-23-amd64"
+func parseVersion(s string) ([]int, string, bool) {
+	mu := regexp.MustCompile(REGEX_VERSION).FindStringSubmatch(s)
+	if len(mu) == 0 {
+		return nil, "", false
+	}
+
+	version := []int{}
+	for _, item := range strings.Split(mu[1], ".") {
+		n, _ := strconv.Atoi(item)
+		version = append(version, n)
+	}
+	return version, s[len(mu[1]):], true
+}
+
+func compareInt(x, y int) int {
+	if x < y {
+		return -1
+	} else if x > y {
+		return 1
+	}
+	return 0
+}
+
+// compare suffixes in natural order, the numbers in them are compared
+// as number, e.g: "-9-amd64" < "-10-amd64"
+func compareSuffix(a, b string) int {
+	regex := regexp.MustCompile(REGEX_SUFFIX_CHUNK)
+	ca, cb := regex.FindAllString(a, -1), regex.FindAllString(b, -1)
+	for i := 0; i < len(ca) && i < len(cb); i++ {
+		x, err1 := strconv.Atoi(ca[i])
+		y, err2 := strconv.Atoi(cb[i])
+		if err1 == nil && err2 == nil {
+			if n := compareInt(x, y); n != 0 {
+				return n
+			}
+		} else if n := strings.Compare(ca[i], cb[i]); n != 0 {
+			return n
+		}
+	}
+	return compareInt(len(ca), len(cb))
+}
+
+/*
+ * compare values as version if both of them start with number, otherwise
+ * compare them as string, so "5.0 == 5" holds but "centos == 5" doesn't.
+ * The value without suffix matches any suffix (e.g: "4.19.0-16-amd64 == 4.19"),
+ * otherwise the suffixes are compared if versions are equal, so
+ * "5.10.0-22-amd64 < 5.10.0-23-amd64" and they are not equal.
+ */
+func compareValue(a, b string) int {
+	va, sa, ok1 := parseVersion(a)
+	vb, sb, ok2 := parseVersion(b)
+	if !ok1 || !ok2 {
+		return strings.Compare(a, b)
+	}
+
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		x, y := 0, 0
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		if n := compareInt(x, y); n != 0 {
+			return n
+		}
+	}
+	if len(sa) == 0 || len(sb) == 0 {
+		return 0
+	}
+	return compareSuffix(sa, sb)
+}
+
+func resolve(operand string, lookup lookupFunc) (string, error) {
+	operand = strings.TrimSpace(operand)
+	if strings.HasPrefix(operand, PREFIX_FACTS) || strings.HasPrefix(operand, PREFIX_OPTIONS) {
+		value, ok := lookup(operand)
+		if !ok {
+			return "", errno.ERR_INVALID_STEP_CONDITION.
+				F("unknown variable: %s", operand)
+		}
+		return value, nil
+	}
+	return strings.Trim(operand, "\"'"), nil
+}
+
+// splitTerm returns the operands and operator of term, operator is
+// empty if term is a boolean operand, e.g: "!options.MOUNT_INSECURE"
+func splitTerm(term string) ([]string, string) {
+	for _, op := range CONDITION_OPERATORS {
+		if idx := strings.Index(term, op); idx >= 0 {
+			return []string{term[:idx], term[idx+len(op):]}, op
+		}
+	}
+	return []string{strings.TrimPrefix(strings.TrimSpace(term), "!")}, ""
+}
+
+func evalTerm(term string, lookup lookupFunc) (bool, error) {
+	operands, op := splitTerm(term)
+	if len(op) > 0 {
+		lhs, err := resolve(operands[0], lookup)
+		if err != nil {
+			return false, err
+		}
+		rhs, err := resolve(operands[1], lookup)
+		if err != nil {
+			return false, err
+		}
+
+		n := compareValue(lhs, rhs)
+		switch op {
+		case "==":
+			return n == 0, nil
+		case "!=":
+			return n != 0, nil
+		case ">=":
+			return n >= 0, nil
+		case "<=":
+			return n <= 0, nil
+		case ">":
+			return n > 0, nil
+		case "<":
+			return n < 0, nil
+		}
+	}
+
+	// no operator, treat it as boolean
+	negative := strings.HasPrefix(strings.TrimSpace(term), "!")
+	value, err := resolve(operands[0], lookup)
+	if err != nil {
+		return false, err
+	}
+	ok := len(value) > 0 && value != "false" && value != "0"
+	return ok != negative, nil
+}
+
+// evalCondition evaluates condition which composed by "||" and "&&",
+// "&&" has higher precedence than "||" and parentheses are not supported
+func evalCondition(condition string, lookup lookupFunc) (bool, error) {
+	if len(strings.TrimSpace(condition)) == 0 {
+		return true, nil
+	}
+
+	for _, or := range strings.Split(condition, "||") {
+		matched := true
+		for _, term := range strings.Split(or, "&&") {
+			if len(strings.TrimSpace(term)) == 0 {
+				return false, errno.ERR_INVALID_STEP_CONDITION.
+					F("condition: %s", condition)
+			}
+			ok, err := evalTerm(term, lookup)
+			if err != nil {
+				return false, err
+			} else if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isFactsCondition returns true if any operand of condition references facts
+func isFactsCondition(condition string) bool {
+	for _, or := range strings.Split(condition, "||") {
+		for _, term := range strings.Split(or, "&&") {
+			operands, _ := splitTerm(term)
+			for _, operand := range operands {
+				if strings.HasPrefix(strings.TrimSpace(operand), PREFIX_FACTS) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func optionsLookup(options map[string]interface{}, memStorage *utils.SafeMap) lookupFunc {
+	return func(name string) (string, bool) {
+		if !strings.HasPrefix(name, PREFIX_OPTIONS) {
+			return "", false
+		}
+
+		key := strings.TrimPrefix(name, PREFIX_OPTIONS)
+		v, ok := options[key]
+		if !ok {
+			v = memStorage.Get(key)
+		}
+		if v == nil {
+			return "", true
+		}
+		return fmt.Sprintf("%v", v), true
+	}
+}
+
+func factsLookup(options lookupFunc, facts *step.HostFacts) lookupFunc {
+	return func(name string) (string, bool) {
+		if strings.HasPrefix(name, PREFIX_FACTS) {
+			return factValue(facts, strings.TrimPrefix(name, PREFIX_FACTS))
+		}
+		return options(name)
+	}
+}
+
+// matchCondition evaluates condition which only references options,
+// the condition which references facts is always matched here.
+func (p *Playbook) matchCondition(s *PlaybookStep) (bool, error) {
+	if isFactsCondition(s.When) {
+		return true, nil
+	}
+	return evalCondition(s.When, optionsLookup(s.Options, p.curveadm.MemStorage()))
+}
+
+// the task will be skipped if condition not matched
+func (p *Playbook) addConditionSteps(t *task.Task, s *PlaybookStep) {
+	if !isFactsCondition(s.When) {
+		return
+	}
+
+	var facts step.HostFacts
+	memStorage := p.curveadm.MemStorage()
+	options := optionsLookup(s.Options, memStorage)
+	t.PrependStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			ok, err := evalCondition(s.When, factsLookup(options, &facts))
+			if err != nil {
+				return err
+			} else if !ok {
+				return task.ERR_SKIP_TASK
+			}
+			return nil
+		},
+	})
+	t.PrependStep(&step.GatherFacts{
+		MemStorage:  memStorage,
+		Out:         &facts,
+		ExecOptions: p.curveadm.ExecOptions(),
+	})
+}
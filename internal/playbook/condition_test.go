@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package playbook
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	facts := &step.HostFacts{
+		OS:            "centos",
+		KernelRelease: "4.19.0-16-amd64",
+		Arch:          "x86_64",
+		CPUs:          8,
+	}
+	memStorage := utils.NewSafeMap()
+	memStorage.Set("CLIENT_KIND", "curvebs")
+	options := map[string]interface{}{"SKIP": true}
+	lookup := factsLookup(optionsLookup(options, memStorage), facts)
+
+	tests := []struct {
+		condition string
+		matched   bool
+		hasError  bool
+	}{
+		{"", true, false},
+		{"facts.kernel < 5.0", true, false},
+		{"facts.kernel >= 4.19", true, false},
+		{"facts.kernel > 4.19", false, false},
+		{"facts.kernel == 4.19.0", true, false},
+		{"facts.kernel != 4.19", false, false},
+		{"5.0 == 5", true, false},
+		{"5.0 != 5.0.1", true, false},
+		{"centos == 5", false, false},
+		{"5.10.0-23-amd64 == 5.10.0-22-amd64", false, false},
+		{"5.10.0-23-amd64 != 5.10.0-22-amd64", true, false},
+		{"5.10.0-23-amd64 > 5.10.0-22-amd64", true, false},
+		{"5.10.0-9-amd64 < 5.10.0-10-amd64", true, false},
+		{"5.10.0-23-amd64 == 5.10.0-23-amd64", true, false},
+		{"5.10.0-23-amd64 == 5.10", true, false},
+		{"facts.cpus >= 16", false, false},
+		{"facts.os == centos", true, false},
+		{"facts.os != 'centos'", false, false},
+		{"options.CLIENT_KIND == curvebs && facts.arch == x86_64", true, false},
+		{"facts.arch == aarch64 || facts.arch == x86_64", true, false},
+		{"facts.arch == aarch64 || options.CLIENT_KIND == curvefs", false, false},
+		{"options.SKIP", true, false},
+		{"!options.SKIP", false, false},
+		{"options.NOT_EXIST", false, false},
+		{"facts.unknown == 1", false, true},
+		{"facts.os == centos &&", false, true},
+	}
+	for _, t := range tests {
+		matched, err := evalCondition(t.condition, lookup)
+		assert.Equal(t.hasError, err != nil, t.condition)
+		assert.Equal(t.matched, matched, t.condition)
+	}
+}
+
+func TestIsFactsCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		condition string
+		isFacts   bool
+	}{
+		{"", false},
+		{"facts.kernel < 5.0", true},
+		{"!facts.container_engine", true},
+		{"options.CLIENT_KIND == curvebs && facts.os != centos", true},
+		{"options.CLIENT_KIND == curvebs || 5.0 <= facts.kernel", true},
+		{"options.CLIENT_KIND == curvebs", false},
+		{"options.LABEL == 'facts.os'", false},
+		{"options.facts.os", false},
+	}
+	for _, t := range tests {
+		assert.Equal(t.isFacts, isFactsCondition(t.condition), t.condition)
+	}
+}
@@ -50,13 +50,19 @@ const (
 	GET_HOST_DATE
 	CHECK_HOST_DATE
 	CHECK_CHUNKFILE_POOL
+	CHECK_HOST_RESOURCES
 	CHECK_S3
 	CLEAN_PRECHECK_ENVIRONMENT
+	DOCTOR_ENVIRONMENT
+	GET_HOST_CLOCK_OFFSET
+	CHECK_CLOCK_SKEW
+	SYNC_HOST_CLOCK
 
 	// common
 	PULL_IMAGE
 	CREATE_CONTAINER
 	SYNC_CONFIG
+	SYNC_CERT
 	START_SERVICE
 	START_ETCD
 	ENABLE_ETCD_AUTH
@@ -71,28 +77,61 @@ const (
 	UPDATE_TOPOLOGY
 	INIT_SERVIE_STATUS
 	GET_SERVICE_STATUS
+	PROBE_SERVICE_HEALTH
+	GET_SERVICE_LOGS
 	CLEAN_SERVICE
 	INIT_SUPPORT
 	COLLECT_REPORT
 	COLLECT_CURVEADM
 	COLLECT_SERVICE
 	COLLECT_CLIENT
+	COLLECT_BUNDLE_HOST
+	COLLECT_BUNDLE_TOOLS
+	COLLECT_BUNDLE_SERVICE
+	SAMPLE_SERVICE_METRICS
+	SET_SERVICE_FLAGS
+	GET_SERVICE_IMAGE
+	RESTORE_SERVICE_CONFIG
+	GATHER_HOST_FACTS
+	REFRESH_HOST_FACTS
+	MIGRATE_ETCD_MEMBER
+	ADD_ETCD_MEMBER
+	REMOVE_ETCD_MEMBER
+	WAIT_ETCD_HEALTHY
+	GET_CLEAN_REPORT
+	PULL_ARTIFACT
+	SAVE_ARTIFACTS
+	INIT_HOST
+	EXEC_HOST_COMMAND
+	PING_HOST
 	BACKUP_ETCD_DATA
+	BACKUP_ETCD_SNAPSHOT
+	RESTORE_ETCD_DATA
+	DUMP_MDS_METADATA
 	CHECK_MDS_ADDRESS
 	INIT_CLIENT_STATUS
 	GET_CLIENT_STATUS
 	INSTALL_CLIENT
 	UNINSTALL_CLIENT
+	RUN_BENCHMARK
+	RUN_PLUGIN_STEP
 
 	// bs
 	FORMAT_CHUNKFILE_POOL
 	GET_FORMAT_STATUS
 	STOP_FORMAT
+	EXPAND_CHUNKFILE_POOL
+	RESUME_FORMAT
 	BALANCE_LEADER
+	GET_CHUNKSERVER_LOAD
+	SET_CHUNKSERVER_PENDDING
+	SET_CHUNKSERVER_STATUS
 	START_NEBD_SERVICE
 	CREATE_VOLUME
 	MAP_IMAGE
 	UNMAP_IMAGE
+	MANAGE_VOLUME
+	GET_SNAPSHOTCLONE_STATUS
 
 	// monitor
 	PULL_MONITOR_IMAGE
@@ -105,6 +144,7 @@ const (
 	INIT_MONITOR_STATUS
 	GET_MONITOR_STATUS
 	CLEAN_MONITOR
+	APPLY_ALERT_RULES
 
 	// bs/target
 	START_TARGET_DAEMON
@@ -117,6 +157,7 @@ const (
 	CHECK_CLIENT_S3
 	MOUNT_FILESYSTEM
 	UMOUNT_FILESYSTEM
+	GET_MOUNT_STATUS
 
 	// polarfs
 	DETECT_OS_RELEASE
@@ -150,12 +191,17 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 	var t *task.Task
 	once := map[string]bool{}
 	curveadm := p.curveadm
-	ts := tasks.NewTasks()
+	ts := tasks.NewTasks(curveadm.Out())
 	for i := 0; i < config.Len(); i++ {
 		// only need to execute task once per host
 		switch step.Type {
 		case CHECK_SSH_CONNECT,
 			GET_HOST_DATE,
+			DOCTOR_ENVIRONMENT,
+			GET_HOST_CLOCK_OFFSET,
+			SYNC_HOST_CLOCK,
+			COLLECT_BUNDLE_HOST,
+			CHECK_HOST_RESOURCES,
 			PULL_IMAGE:
 			host := config.GetDC(i).GetHost()
 			if once[host] {
@@ -190,12 +236,22 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = checker.NewCheckDate(curveadm, nil)
 		case CHECK_CHUNKFILE_POOL:
 			t, err = checker.NewCheckChunkfilePoolTask(curveadm, config.GetDC(i))
+		case CHECK_HOST_RESOURCES:
+			t, err = checker.NewCheckHostResourcesTask(curveadm, config.GetDC(i))
 		case CHECK_S3:
 			t, err = checker.NewCheckS3Task(curveadm, config.GetDC(i))
 		case CHECK_MDS_ADDRESS:
 			t, err = checker.NewCheckMdsAddressTask(curveadm, config.GetCC(i))
 		case CLEAN_PRECHECK_ENVIRONMENT:
 			t, err = checker.NewCleanEnvironmentTask(curveadm, config.GetDC(i))
+		case DOCTOR_ENVIRONMENT:
+			t, err = checker.NewDoctorTask(curveadm, config.GetDC(i))
+		case GET_HOST_CLOCK_OFFSET:
+			t, err = checker.NewGetHostClockOffsetTask(curveadm, config.GetDC(i))
+		case CHECK_CLOCK_SKEW:
+			t, err = checker.NewCheckClockSkewTask(curveadm, nil)
+		case SYNC_HOST_CLOCK:
+			t, err = checker.NewSyncHostClockTask(curveadm, config.GetDC(i))
 		// common
 		case PULL_IMAGE:
 			t, err = comm.NewPullImageTask(curveadm, config.GetDC(i))
@@ -203,6 +259,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = comm.NewCreateContainerTask(curveadm, config.GetDC(i))
 		case SYNC_CONFIG:
 			t, err = comm.NewSyncConfigTask(curveadm, config.GetDC(i))
+		case SYNC_CERT:
+			t, err = comm.NewSyncCertTask(curveadm, config.GetDC(i))
 		case START_SERVICE,
 			START_ETCD,
 			START_MDS,
@@ -225,6 +283,10 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = comm.NewInitServiceStatusTask(curveadm, config.GetDC(i))
 		case GET_SERVICE_STATUS:
 			t, err = comm.NewGetServiceStatusTask(curveadm, config.GetDC(i))
+		case PROBE_SERVICE_HEALTH:
+			t, err = comm.NewProbeHealthTask(curveadm, config.GetDC(i))
+		case GET_SERVICE_LOGS:
+			t, err = comm.NewGetServiceLogsTask(curveadm, config.GetDC(i))
 		case CLEAN_SERVICE:
 			t, err = comm.NewCleanServiceTask(curveadm, config.GetDC(i))
 		case INIT_SUPPORT:
@@ -237,8 +299,52 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = comm.NewCollectServiceTask(curveadm, config.GetDC(i))
 		case COLLECT_CLIENT:
 			t, err = comm.NewCollectClientTask(curveadm, config.GetAny(i))
+		case COLLECT_BUNDLE_HOST:
+			t, err = comm.NewCollectBundleHostTask(curveadm, config.GetDC(i))
+		case COLLECT_BUNDLE_TOOLS:
+			t, err = comm.NewCollectBundleToolsTask(curveadm, config.GetDC(i))
+		case COLLECT_BUNDLE_SERVICE:
+			t, err = comm.NewCollectBundleServiceTask(curveadm, config.GetDC(i))
+		case SAMPLE_SERVICE_METRICS:
+			t, err = comm.NewSampleServiceMetricsTask(curveadm, config.GetDC(i))
+		case SET_SERVICE_FLAGS:
+			t, err = comm.NewSetServiceFlagsTask(curveadm, config.GetDC(i))
+		case GET_SERVICE_IMAGE:
+			t, err = comm.NewGetServiceImageTask(curveadm, config.GetDC(i))
+		case RESTORE_SERVICE_CONFIG:
+			t, err = comm.NewRestoreServiceConfigTask(curveadm, config.GetDC(i))
+		case GATHER_HOST_FACTS:
+			t, err = checker.NewGatherHostFactsTask(curveadm, config.GetDC(i))
+		case REFRESH_HOST_FACTS:
+			t, err = checker.NewRefreshHostFactsTask(curveadm, config.GetHC(i))
 		case BACKUP_ETCD_DATA:
 			t, err = comm.NewBackupEtcdDataTask(curveadm, config.GetDC(i))
+		case BACKUP_ETCD_SNAPSHOT:
+			t, err = comm.NewBackupEtcdSnapshotTask(curveadm, config.GetDC(i))
+		case RESTORE_ETCD_DATA:
+			t, err = comm.NewRestoreEtcdDataTask(curveadm, config.GetDC(i))
+		case DUMP_MDS_METADATA:
+			t, err = comm.NewDumpMdsMetadataTask(curveadm, config.GetDC(i))
+		case MIGRATE_ETCD_MEMBER:
+			t, err = comm.NewMigrateEtcdMemberTask(curveadm, config.GetDC(i))
+		case ADD_ETCD_MEMBER:
+			t, err = comm.NewAddEtcdMemberTask(curveadm, config.GetDC(i))
+		case REMOVE_ETCD_MEMBER:
+			t, err = comm.NewRemoveEtcdMemberTask(curveadm, config.GetDC(i))
+		case WAIT_ETCD_HEALTHY:
+			t, err = comm.NewWaitEtcdHealthyTask(curveadm, config.GetDC(i))
+		case GET_CLEAN_REPORT:
+			t, err = comm.NewGetCleanReportTask(curveadm, config.GetDC(i))
+		case PULL_ARTIFACT:
+			t, err = comm.NewPullArtifactTask(curveadm, config.GetAny(i))
+		case SAVE_ARTIFACTS:
+			t, err = comm.NewSaveArtifactsTask(curveadm, nil)
+		case INIT_HOST:
+			t, err = comm.NewInitHostTask(curveadm, config.GetHC(i))
+		case EXEC_HOST_COMMAND:
+			t, err = comm.NewExecHostTask(curveadm, config.GetHC(i))
+		case PING_HOST:
+			t, err = comm.NewPingHostTask(curveadm, config.GetHC(i))
 		case INIT_CLIENT_STATUS:
 			t, err = comm.NewInitClientStatusTask(curveadm, config.GetAny(i))
 		case GET_CLIENT_STATUS:
@@ -247,6 +353,10 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = comm.NewInstallClientTask(curveadm, config.GetCC(i))
 		case UNINSTALL_CLIENT:
 			t, err = comm.NewUninstallClientTask(curveadm, nil)
+		case RUN_BENCHMARK:
+			t, err = comm.NewRunBenchmarkTask(curveadm, config.GetAny(i))
+		case RUN_PLUGIN_STEP:
+			t, err = comm.NewRunPluginStepTask(curveadm, config.GetHC(i))
 		// bs
 		case FORMAT_CHUNKFILE_POOL:
 			t, err = bs.NewFormatChunkfilePoolTask(curveadm, config.GetFC(i))
@@ -254,8 +364,18 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = bs.NewGetFormatStatusTask(curveadm, config.GetFC(i))
 		case STOP_FORMAT:
 			t, err = bs.NewStopFormatTask(curveadm, config.GetFC(i))
+		case EXPAND_CHUNKFILE_POOL:
+			t, err = bs.NewExpandChunkfilePoolTask(curveadm, config.GetFC(i))
+		case RESUME_FORMAT:
+			t, err = bs.NewResumeFormatTask(curveadm, config.GetFC(i))
 		case BALANCE_LEADER:
 			t, err = bs.NewBalanceTask(curveadm, config.GetDC(i))
+		case GET_CHUNKSERVER_LOAD:
+			t, err = bs.NewGetChunkserverLoadTask(curveadm, config.GetDC(i))
+		case SET_CHUNKSERVER_PENDDING:
+			t, err = bs.NewSetChunkserverPenddingTask(curveadm, config.GetDC(i))
+		case SET_CHUNKSERVER_STATUS:
+			t, err = bs.NewSetChunkserverStatusTask(curveadm, config.GetDC(i))
 		case START_NEBD_SERVICE:
 			t, err = bs.NewStartNEBDServiceTask(curveadm, config.GetCC(i))
 		case CREATE_VOLUME:
@@ -264,6 +384,10 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = bs.NewMapTask(curveadm, config.GetCC(i))
 		case UNMAP_IMAGE:
 			t, err = bs.NewUnmapTask(curveadm, nil)
+		case MANAGE_VOLUME:
+			t, err = bs.NewManageVolumeTask(curveadm, config.GetDC(i))
+		case GET_SNAPSHOTCLONE_STATUS:
+			t, err = bs.NewGetSnapshotCloneStatusTask(curveadm, config.GetDC(i))
 		// bs/target
 		case START_TARGET_DAEMON:
 			t, err = bs.NewStartTargetDaemonTask(curveadm, config.GetCC(i))
@@ -282,6 +406,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = fs.NewMountFSTask(curveadm, config.GetCC(i))
 		case UMOUNT_FILESYSTEM:
 			t, err = fs.NewUmountFSTask(curveadm, config.GetCC(i))
+		case GET_MOUNT_STATUS:
+			t, err = fs.NewGetMountStatusTask(curveadm, config.GetAny(i))
 		// polarfs
 		case DETECT_OS_RELEASE:
 			t, err = bs.NewDetectOSReleaseTask(curveadm, nil)
@@ -321,6 +447,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = monitor.NewGetMonitorStatusTask(curveadm, config.GetMC(i))
 		case CLEAN_MONITOR:
 			t, err = monitor.NewCleanMonitorTask(curveadm, config.GetMC(i))
+		case APPLY_ALERT_RULES:
+			t, err = monitor.NewApplyAlertRulesTask(curveadm, config.GetMC(i))
 
 		default:
 			return nil, errno.ERR_UNKNOWN_TASK_TYPE.
@@ -333,6 +461,7 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			continue
 		}
 
+		p.addConditionSteps(t, step)
 		if config.GetType() == TYPE_CONFIG_DEPLOY { // merge task status into one
 			t.SetTid(config.GetDC(i).GetId())
 			t.SetPtid(config.GetDC(i).GetParentId())
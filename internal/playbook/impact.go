@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package playbook
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+const (
+	// directories of service touched by operation, see Impact
+	IMPACT_DIR_DATA = "data"
+	IMPACT_DIR_LOG  = "log"
+
+	// the duration is estimated by the average of latest runs
+	IMPACT_HISTORY_RUNS = 5
+)
+
+// services which planned to operate, deduplicated by id in the order of steps
+func (p *Playbook) plannedServices() []*topology.DeployConfig {
+	dcs := []*topology.DeployConfig{}
+	seen := map[string]bool{}
+	for _, step := range p.steps {
+		configs, ok := step.Configs.([]*topology.DeployConfig)
+		if !ok {
+			continue
+		}
+		for _, dc := range configs {
+			if !seen[dc.GetId()] {
+				seen[dc.GetId()] = true
+				dcs = append(dcs, dc)
+			}
+		}
+	}
+	return dcs
+}
+
+func (p *Playbook) estimate(operation string) (time.Duration, int) {
+	curveadm := p.curveadm
+	durations, err := curveadm.Storage().GetOperationDurations(curveadm.ClusterId(), operation, IMPACT_HISTORY_RUNS)
+	if err != nil {
+		log.Error("Get operation durations failed",
+			log.Field("Operation", operation),
+			log.Field("Error", errno.ERR_GET_OPERATION_DURATIONS_FAILED.E(err)))
+		return 0, 0
+	} else if len(durations) == 0 {
+		return 0, 0
+	}
+
+	var total int64
+	for _, d := range durations {
+		total += d.Duration
+	}
+	return time.Duration(total/int64(len(durations))) * time.Millisecond, len(durations)
+}
+
+/*
+ * Impact summarizes services, hosts and directories (IMPACT_DIR_DATA or
+ * IMPACT_DIR_LOG) which the planned playbook will touch, and estimates
+ * its duration by the history of operation.
+ *
+ * The duration of playbook is recorded for the next estimation once it
+ * succeeds, so Impact should be called before Run.
+ */
+func (p *Playbook) Impact(operation string, dirs ...string) tui.Impact {
+	p.operation = operation
+	impact := tui.Impact{Operation: operation}
+	hosts := map[string]bool{}
+	for _, dc := range p.plannedServices() {
+		serviceId := p.curveadm.GetServiceId(dc.GetId())
+		impact.Services = append(impact.Services, fmt.Sprintf("%s@%s (%s)", dc.GetRole(), dc.GetHost(), serviceId))
+		if !hosts[dc.GetHost()] {
+			hosts[dc.GetHost()] = true
+			impact.Hosts = append(impact.Hosts, dc.GetHost())
+		}
+		for _, dir := range dirs {
+			path := dc.GetDataDir()
+			if dir == IMPACT_DIR_LOG {
+				path = dc.GetLogDir()
+			}
+			if len(path) > 0 {
+				impact.Dirs = append(impact.Dirs, fmt.Sprintf("%s:%s", dc.GetHost(), path))
+			}
+		}
+	}
+	sort.Strings(impact.Hosts)
+	impact.Estimated, impact.Runs = p.estimate(operation)
+	return impact
+}
+
+func (p *Playbook) recordDuration(start time.Time) {
+	curveadm := p.curveadm
+	err := curveadm.Storage().InsertOperationDuration(storage.OperationDuration{
+		ClusterId: curveadm.ClusterId(),
+		Operation: p.operation,
+		Services:  len(p.plannedServices()),
+		Duration:  time.Since(start).Milliseconds(),
+	})
+	if err != nil {
+		log.Error("Insert operation duration failed",
+			log.Field("Operation", p.operation),
+			log.Field("Error", errno.ERR_INSERT_OPERATION_DURATION_FAILED.E(err)))
+	}
+}
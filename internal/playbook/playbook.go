@@ -25,8 +25,18 @@
 package playbook
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/tasks"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/opencurve/curveadm/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 /*
@@ -52,6 +62,7 @@ type (
 		Type    int
 		Configs interface{}
 		Options map[string]interface{}
+		When    string // condition, see condition.go
 		tasks.ExecOptions
 	}
 
@@ -59,6 +70,7 @@ type (
 		curveadm  *cli.CurveAdm
 		steps     []*PlaybookStep
 		postSteps []*PlaybookStep
+		operation string // set by Impact, its duration is recorded if succeeded
 	}
 
 	ExecOptions = tasks.ExecOptions
@@ -79,34 +91,111 @@ func (p *Playbook) AddPostStep(s *PlaybookStep) {
 	p.postSteps = append(p.postSteps, s)
 }
 
-func (p *Playbook) run(steps []*PlaybookStep) error {
+func (p *Playbook) run(traceCtx context.Context, steps []*PlaybookStep) error {
 	for i, step := range steps {
+		matched, err := p.matchCondition(step)
+		if err != nil {
+			return err
+		} else if !matched {
+			continue
+		}
+
 		tasks, err := p.createTasks(step)
 		if err != nil {
 			return err
 		}
 
-		err = tasks.Execute(step.ExecOptions)
+		options := step.ExecOptions
+		if p.curveadm.Quiet() {
+			options.SilentMainBar = true
+			options.SilentSubBar = true
+		}
+		start := time.Now()
+		ctx, span := tracing.Start(traceCtx, tasks.Name(),
+			attribute.Int(tracing.ATTR_STEP_TYPE, step.Type),
+			attribute.Int(tracing.ATTR_TASK_COUNT, tasks.Count()))
+		err = tasks.ExecuteContext(ctx, options)
+		tracing.End(span, err)
+		if tasks.Count() > 0 {
+			p.curveadm.Recorder().AddStep(tasks.Name(), start, err)
+		}
 		if err != nil {
 			return err
 		}
 
 		isLast := (i == len(steps)-1)
-		if !step.ExecOptions.SilentMainBar && !isLast {
+		if !options.SilentMainBar && !isLast {
 			p.curveadm.WriteOutln("")
 		}
 	}
 	return nil
 }
 
-func (p *Playbook) Run() error {
+func (p *Playbook) openSSHPool() bool {
+	config := p.curveadm.Config()
+	if !config.GetSSHPool() {
+		return false
+	}
+	module.OpenSSHPool(config.GetSSHPoolOptions())
+	return true
+}
+
+// the pool is shared with other playbooks running at the same time,
+// the metrics are logged once the last one done
+func (p *Playbook) closeSSHPool() {
+	metrics, closed := module.CloseSSHPool()
+	if !closed {
+		return
+	}
+	logf := log.Debug
+	if p.curveadm.Config().GetSSHPoolVerbose() { // printed into stderr with -V
+		logf = log.Info
+	}
+	logf("Close SSH connection pool",
+		log.Field("Dials", metrics.Dials),
+		log.Field("Reuses", metrics.Reuses),
+		log.Field("Reconnects", metrics.Reconnects),
+		log.Field("Closed", metrics.Closed),
+		log.Field("Peak", metrics.Peak))
+}
+
+// any playbook except status may change services, drop the cached status
+func (p *Playbook) invalidateStatusCache() {
+	for _, step := range append(p.steps, p.postSteps...) {
+		if step.Type != INIT_SERVIE_STATUS && step.Type != GET_SERVICE_STATUS {
+			os.RemoveAll(p.curveadm.StatusCacheDir())
+			return
+		}
+	}
+}
+
+func (p *Playbook) Run() (err error) {
+	ctx, span := tracing.Start(context.Background(), "playbook",
+		attribute.String(tracing.ATTR_CLUSTER, p.curveadm.ClusterName()),
+		attribute.String(tracing.ATTR_COMMAND, strings.Join(os.Args, " ")))
+	defer func() { tracing.End(span, err) }()
+
+	defer p.invalidateStatusCache()
+
+	// share SSH connections among all tasks (including post steps) in this playbook
+	if p.openSSHPool() {
+		defer p.closeSSHPool()
+	}
+
 	defer func() {
 		if len(p.postSteps) == 0 {
 			return
 		}
 		p.curveadm.WriteOutln("")
-		p.run(p.postSteps)
+		if err := p.run(ctx, p.postSteps); err != nil {
+			p.curveadm.Recorder().Warn(fmt.Sprintf("post step failed: %s", err))
+		}
 	}()
 
-	return p.run(p.steps)
+	start := time.Now()
+	err = p.run(ctx, p.steps)
+	if err == nil && len(p.operation) > 0 {
+		p.recordDuration(start)
+	}
+	return err
 }
@@ -0,0 +1,211 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	sdk "github.com/opencurve/curveadm/pkg/plugin"
+)
+
+const (
+	REGEX_PLUGIN_NAME = "^[a-zA-Z0-9][a-zA-Z0-9_-]*$"
+
+	// plugin should print its manifest immediately
+	MANIFEST_TIMEOUT = 10 * time.Second
+)
+
+type (
+	Plugin struct {
+		Name     string
+		Path     string
+		Shadowed []string // same name plugins which hidden by this one
+	}
+
+	// Env is the context of curveadm passed to plugin by environments
+	Env struct {
+		Bin     string
+		RootDir string
+		Cluster string
+	}
+)
+
+func ValidName(name string) bool {
+	return regexp.MustCompile(REGEX_PLUGIN_NAME).MatchString(name)
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && info.Mode()&0111 != 0
+}
+
+// SearchDirs returns directories which plugins searched in, plugin directory first
+func SearchDirs(pluginDir string) []string {
+	dirs := []string{pluginDir}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if len(dir) > 0 {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// Discover finds all plugins in dirs, the former directory takes precedence
+func Discover(dirs []string) []Plugin {
+	plugins := []Plugin{}
+	index := map[string]int{}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.TrimPrefix(entry.Name(), sdk.BINARY_PREFIX)
+			path := filepath.Join(dir, entry.Name())
+			if !strings.HasPrefix(entry.Name(), sdk.BINARY_PREFIX) ||
+				!ValidName(name) || !isExecutable(path) {
+				continue
+			}
+
+			if i, ok := index[name]; ok {
+				plugins[i].Shadowed = append(plugins[i].Shadowed, path)
+				continue
+			}
+			index[name] = len(plugins)
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+func Lookup(dirs []string, name string) (Plugin, bool) {
+	if !ValidName(name) {
+		return Plugin{}, false
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, sdk.BINARY_PREFIX+name)
+		if isExecutable(path) {
+			return Plugin{Name: name, Path: path}, true
+		}
+	}
+	return Plugin{}, false
+}
+
+func NewHost(hc *hosts.HostConfig) sdk.Host {
+	return sdk.Host{
+		Host:           hc.GetHost(),
+		Hostname:       hc.GetHostname(),
+		SSHHostname:    hc.GetSSHHostname(),
+		SSHPort:        hc.GetSSHPort(),
+		User:           hc.GetUser(),
+		PrivateKeyFile: hc.GetPrivateKeyFile(),
+		Labels:         hc.GetLabels(),
+	}
+}
+
+func (env Env) Environ() []string {
+	return append(os.Environ(),
+		fmt.Sprintf("%s=%s", sdk.ENV_CURVEADM_BIN, env.Bin),
+		fmt.Sprintf("%s=%s", sdk.ENV_CURVEADM_ROOT_DIR, env.RootDir),
+		fmt.Sprintf("%s=%s", sdk.ENV_CURVEADM_CLUSTER, env.Cluster),
+		fmt.Sprintf("%s=%d", sdk.ENV_CURVEADM_PROTOCOL, sdk.PROTOCOL_VERSION))
+}
+
+// Command returns command which runs plugin with args, the caller sets its stdio
+func (p Plugin) Command(env Env, args []string) *exec.Cmd {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Env = env.Environ()
+	return cmd
+}
+
+func (p Plugin) Manifest(env Env) (*sdk.Manifest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), MANIFEST_TIMEOUT)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, p.Path, sdk.COMMAND_MANIFEST)
+	cmd.Env = env.Environ()
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errno.ERR_GET_PLUGIN_MANIFEST_FAILED.
+			F("plugin: %s, %v", p.Path, err)
+	}
+
+	manifest := &sdk.Manifest{}
+	if err := json.Unmarshal(out, manifest); err != nil {
+		return nil, errno.ERR_GET_PLUGIN_MANIFEST_FAILED.
+			F("plugin: %s, %v", p.Path, err)
+	} else if manifest.Protocol != sdk.PROTOCOL_VERSION {
+		return nil, errno.ERR_UNSUPPORT_PLUGIN_PROTOCOL.
+			F("plugin: %s, protocol: %d, expected: %d",
+				p.Path, manifest.Protocol, sdk.PROTOCOL_VERSION)
+	}
+	return manifest, nil
+}
+
+// call invokes precheck or step of plugin, stderr of plugin is passed through
+func (p Plugin) call(env Env, command string, req *sdk.Request) (*sdk.Result, error) {
+	req.Protocol = sdk.PROTOCOL_VERSION
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, errno.ERR_RUN_PLUGIN_FAILED.E(err)
+	}
+
+	cmd := p.Command(env, []string{command, req.Name})
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errno.ERR_RUN_PLUGIN_FAILED.
+			F("plugin: %s, %s %s: %v", p.Name, command, req.Name, err)
+	}
+
+	result := &sdk.Result{}
+	if err := json.Unmarshal(out, result); err != nil {
+		return nil, errno.ERR_RUN_PLUGIN_FAILED.
+			F("plugin: %s, invalid result: %s", p.Name, string(out))
+	}
+	return result, nil
+}
+
+func (p Plugin) Precheck(env Env, req *sdk.Request) (*sdk.Result, error) {
+	return p.call(env, sdk.COMMAND_PRECHECK, req)
+}
+
+func (p Plugin) Step(env Env, req *sdk.Request) (*sdk.Result, error) {
+	return p.call(env, sdk.COMMAND_STEP, req)
+}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	sdk "github.com/opencurve/curveadm/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TEST_PLUGIN_SCRIPT = `#!/bin/sh
+case "$1" in
+__manifest) echo '{"name": "zstack", "version": "0.1.0", "protocol": 1, "prechecks": ["agent"]}' ;;
+__precheck) cat >/dev/null; echo "{\"success\": false, \"message\": \"cluster $CURVEADM_PLUGIN_CLUSTER\"}" ;;
+*) exit 3 ;;
+esac
+`
+)
+
+func writeFile(t *testing.T, dir, name, content string, mode os.FileMode) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidName(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(ValidName("zstack"))
+	assert.True(ValidName("disk-array_v2"))
+	assert.False(ValidName(""))
+	assert.False(ValidName("-zstack"))
+	assert.False(ValidName("../zstack"))
+	assert.False(ValidName("zstack.sh"))
+}
+
+func TestDiscover(t *testing.T) {
+	assert := assert.New(t)
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	path1 := writeFile(t, dir1, "curveadm-zstack", TEST_PLUGIN_SCRIPT, 0755)
+	path2 := writeFile(t, dir2, "curveadm-zstack", TEST_PLUGIN_SCRIPT, 0755)
+	path3 := writeFile(t, dir2, "curveadm-array", TEST_PLUGIN_SCRIPT, 0755)
+	writeFile(t, dir2, "curveadm-noexec", TEST_PLUGIN_SCRIPT, 0644)
+	writeFile(t, dir2, "kubectl-zstack", TEST_PLUGIN_SCRIPT, 0755)
+	os.Mkdir(filepath.Join(dir2, "curveadm-dir"), 0755)
+
+	plugins := Discover([]string{dir1, dir2, filepath.Join(dir1, "not-exist")})
+	assert.Equal([]Plugin{
+		{Name: "array", Path: path3},
+		{Name: "zstack", Path: path1, Shadowed: []string{path2}},
+	}, plugins)
+
+	p, ok := Lookup([]string{dir1, dir2}, "zstack")
+	assert.True(ok)
+	assert.Equal(path1, p.Path)
+	_, ok = Lookup([]string{dir1, dir2}, "noexec")
+	assert.False(ok)
+	_, ok = Lookup([]string{dir1, dir2}, "../curveadm-zstack")
+	assert.False(ok)
+}
+
+func TestManifestAndPrecheck(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	p := Plugin{Name: "zstack", Path: writeFile(t, dir, "curveadm-zstack", TEST_PLUGIN_SCRIPT, 0755)}
+	env := Env{Cluster: "c1"}
+
+	manifest, err := p.Manifest(env)
+	assert.Nil(err)
+	assert.Equal("0.1.0", manifest.Version)
+	assert.Equal([]string{"agent"}, manifest.Prechecks)
+
+	result, err := p.Precheck(env, &sdk.Request{Name: "agent"})
+	assert.Nil(err)
+	assert.Equal(&sdk.Result{Success: false, Message: "cluster c1"}, result)
+
+	_, err = p.Step(env, &sdk.Request{Name: "register"})
+	assert.Equal(errno.ERR_RUN_PLUGIN_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestManifest_ProtocolMismatch(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho '{\"name\": \"old\", \"protocol\": 0}'\n"
+	p := Plugin{Name: "old", Path: writeFile(t, dir, "curveadm-old", script, 0755)}
+
+	_, err := p.Manifest(Env{})
+	assert.Equal(errno.ERR_UNSUPPORT_PLUGIN_PROTOCOL.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
@@ -0,0 +1,193 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package report
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ * [report]
+ * smtp_host = "smtp.example.com"
+ * smtp_port = 587  # 465 for implicit TLS, otherwise STARTTLS if server supports
+ * smtp_user = "curveadm@example.com"
+ * smtp_password = "secret://smtp_password"
+ * from = "curveadm@example.com"
+ * to = "ops@example.com, dba@example.com"
+ * format = html  # html/text
+ * commands = "deploy, upgrade, format"
+ * timeout = 10
+ */
+const (
+	DEFAULT_SMTP_PORT = 25
+	SMTPS_PORT        = 465
+	DEFAULT_TIMEOUT   = 10 * time.Second
+)
+
+type Config struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUser     string
+	SMTPPassword string // maybe a secret reference, resolved by caller
+	From         string
+	To           []string
+	Format       string
+	Commands     []string
+	Timeout      time.Duration
+}
+
+func (cfg Config) Enabled() bool {
+	return len(cfg.SMTPHost) > 0 && len(cfg.To) > 0
+}
+
+// Match returns true if report should be sent for command (e.g. "deploy")
+func (cfg Config) Match(command string) bool {
+	commands := cfg.Commands
+	if len(commands) == 0 {
+		commands = DEFAULT_COMMANDS
+	}
+	for _, c := range commands {
+		if c == command {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg Config) from() string {
+	if len(cfg.From) > 0 {
+		return cfg.From
+	} else if len(cfg.SMTPUser) > 0 {
+		return cfg.SMTPUser
+	}
+	return "curveadm@localhost"
+}
+
+// Message builds MIME message of report
+func Message(cfg Config, r *Report, now time.Time) ([]byte, error) {
+	contentType := "text/plain"
+	if cfg.Format == FORMAT_HTML {
+		contentType = "text/html"
+	}
+	body, err := r.Render(cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "From: %s\r\n", cfg.from())
+	fmt.Fprintf(buffer, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	fmt.Fprintf(buffer, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", r.Subject()))
+	fmt.Fprintf(buffer, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(buffer, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(buffer, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	fmt.Fprintf(buffer, "\r\n")
+	buffer.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return buffer.Bytes(), nil
+}
+
+func dial(cfg Config, addr string, timeout time.Duration) (*smtp.Client, error) {
+	tlsConfig := &tls.Config{ServerName: cfg.SMTPHost}
+	if cfg.SMTPPort == SMTPS_PORT {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", addr, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		conn.SetDeadline(time.Now().Add(timeout))
+		return smtp.NewClient(conn, cfg.SMTPHost)
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+	client, err := smtp.NewClient(conn, cfg.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+// Send emails report to all recipients, password should be resolved already
+func Send(cfg Config, r *Report) error {
+	msg, err := Message(cfg, r, time.Now())
+	if err != nil {
+		return err
+	}
+
+	port, timeout := cfg.SMTPPort, cfg.Timeout
+	if port <= 0 {
+		port = DEFAULT_SMTP_PORT
+	}
+	if timeout <= 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+	cfg.SMTPPort = port
+	client, err := dial(cfg, net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(port)), timeout)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if len(cfg.SMTPUser) > 0 {
+		auth := smtp.PlainAuth("", cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(cfg.from()); err != nil {
+		return err
+	}
+	for _, to := range cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,207 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package report
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+const (
+	FORMAT_TEXT = "text"
+	FORMAT_HTML = "html"
+
+	STATUS_SUCCESS = "success"
+	STATUS_FAIL    = "fail"
+	STATUS_CANCEL  = "cancel"
+
+	SERVICE_STATUS_UP = "Up"
+	HEALTH_UNHEALTHY  = "unhealthy"
+)
+
+var (
+	// commands which reported if no commands specified in curveadm.cfg
+	DEFAULT_COMMANDS = []string{"deploy", "upgrade", "format"}
+)
+
+type (
+	Step struct {
+		Name     string
+		Start    time.Time
+		Duration time.Duration
+		Error    string
+	}
+
+	Service struct {
+		Id     string
+		Role   string
+		Host   string
+		Status string
+		Health string
+	}
+
+	Report struct {
+		Cluster   string
+		Command   string
+		Operator  string
+		Status    string
+		Error     string
+		StartTime time.Time
+		Duration  time.Duration
+		Steps     []Step
+		Warnings  []string
+		Services  []Service // resulting health, empty if cluster has no services
+	}
+
+	// Recorder records steps of playbooks and warnings during one command
+	Recorder struct {
+		mutex    sync.Mutex
+		steps    []Step
+		warnings []string
+	}
+)
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// AddStep records step, it's a no-op for nil recorder
+func (r *Recorder) AddStep(name string, start time.Time, err error) {
+	if r == nil {
+		return
+	}
+	step := Step{Name: name, Start: start, Duration: time.Since(start)}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+func (r *Recorder) Warn(warning string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.warnings = append(r.warnings, warning)
+}
+
+func (r *Recorder) Steps() []Step {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Step{}, r.steps...)
+}
+
+func (r *Recorder) Warnings() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.warnings...)
+}
+
+// Healthy returns false if any service is not up or unhealthy
+func (r *Report) Healthy() bool {
+	for _, s := range r.Services {
+		if !strings.HasPrefix(s.Status, SERVICE_STATUS_UP) || s.Health == HEALTH_UNHEALTHY {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Report) Subject() string {
+	return "[curveadm] " + r.Command + " " + r.Cluster + ": " + r.Status
+}
+
+func round(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
+
+var funcs = map[string]interface{}{
+	"round": round,
+	"time":  func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+}
+
+var textReport = texttemplate.Must(texttemplate.New("text").Funcs(funcs).Parse(
+	`Cluster   : {{.Cluster}}
+Command   : {{.Command}}
+Operator  : {{.Operator}}
+Status    : {{.Status}}{{if .Error}}
+Error     : {{.Error}}{{end}}
+Start Time: {{time .StartTime}}
+Duration  : {{round .Duration}}
+
+Steps:
+{{range .Steps}}  - {{.Name}} ({{round .Duration}}){{if .Error}} [ERROR] {{.Error}}{{else}} [OK]{{end}}
+{{else}}  (none)
+{{end}}{{if .Warnings}}
+Warnings:
+{{range .Warnings}}  - {{.}}
+{{end}}{{end}}
+Health: {{if not .Services}}unknown{{else if .Healthy}}healthy{{else}}unhealthy{{end}}
+{{range .Services}}  - {{.Id}} {{.Role}} {{.Host}} {{.Status}} {{.Health}}
+{{end}}`))
+
+var htmlReport = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(
+	`<html><body>
+<h3>curveadm {{.Command}}: {{.Status}}</h3>
+<table>
+<tr><td>Cluster</td><td>{{.Cluster}}</td></tr>
+<tr><td>Operator</td><td>{{.Operator}}</td></tr>
+{{if .Error}}<tr><td>Error</td><td>{{.Error}}</td></tr>
+{{end}}<tr><td>Start Time</td><td>{{time .StartTime}}</td></tr>
+<tr><td>Duration</td><td>{{round .Duration}}</td></tr>
+</table>
+<h4>Steps</h4>
+<table border="1" cellspacing="0" cellpadding="4">
+<tr><th>Step</th><th>Duration</th><th>Result</th></tr>
+{{range .Steps}}<tr><td>{{.Name}}</td><td>{{round .Duration}}</td><td>{{if .Error}}ERROR: {{.Error}}{{else}}OK{{end}}</td></tr>
+{{end}}</table>
+{{if .Warnings}}<h4>Warnings</h4>
+<ul>
+{{range .Warnings}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}<h4>Health: {{if not .Services}}unknown{{else if .Healthy}}healthy{{else}}unhealthy{{end}}</h4>
+{{if .Services}}<table border="1" cellspacing="0" cellpadding="4">
+<tr><th>Id</th><th>Role</th><th>Host</th><th>Status</th><th>Health</th></tr>
+{{range .Services}}<tr><td>{{.Id}}</td><td>{{.Role}}</td><td>{{.Host}}</td><td>{{.Status}}</td><td>{{.Health}}</td></tr>
+{{end}}</table>
+{{end}}</body></html>
+`))
+
+// Render renders report in text or html
+func (r *Report) Render(format string) (string, error) {
+	var err error
+	buffer := &bytes.Buffer{}
+	if format == FORMAT_HTML {
+		err = htmlReport.Execute(buffer, r)
+	} else {
+		err = textReport.Execute(buffer, r)
+	}
+	return buffer.String(), err
+}
@@ -0,0 +1,207 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newReport() *Report {
+	recorder := NewRecorder()
+	start := time.Now().Add(-time.Minute)
+	recorder.AddStep("Pull Image", start, nil)
+	recorder.AddStep("Start Service", start, fmt.Errorf("container <exited>"))
+	recorder.Warn("post step failed: timeout")
+	return &Report{
+		Cluster:   "my-cluster",
+		Command:   "curveadm deploy",
+		Operator:  "curve",
+		Status:    STATUS_FAIL,
+		Error:     "start service failed",
+		StartTime: start,
+		Duration:  time.Minute,
+		Steps:     recorder.Steps(),
+		Warnings:  recorder.Warnings(),
+		Services: []Service{
+			{Id: "c1", Role: "mds", Host: "host1", Status: "Up 1 minutes", Health: "healthy"},
+			{Id: "c2", Role: "etcd", Host: "host2", Status: "Exited (1) 1 seconds ago", Health: "-"},
+		},
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	assert := assert.New(t)
+
+	var nilRecorder *Recorder
+	nilRecorder.AddStep("Pull Image", time.Now(), nil)
+	nilRecorder.Warn("ignored")
+
+	r := newReport()
+	assert.Len(r.Steps, 2)
+	assert.Equal("", r.Steps[0].Error)
+	assert.Equal("container <exited>", r.Steps[1].Error)
+	assert.True(r.Steps[0].Duration >= time.Minute)
+	assert.Equal([]string{"post step failed: timeout"}, r.Warnings)
+}
+
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+
+	r := newReport()
+	assert.False(r.Healthy())
+	assert.Equal("[curveadm] curveadm deploy my-cluster: fail", r.Subject())
+
+	text, err := r.Render(FORMAT_TEXT)
+	assert.Nil(err)
+	assert.Contains(text, "Status    : fail")
+	assert.Contains(text, "Error     : start service failed")
+	assert.Contains(text, "  - Pull Image (1m0s) [OK]")
+	assert.Contains(text, "  - Start Service (1m0s) [ERROR] container <exited>")
+	assert.Contains(text, "  - post step failed: timeout")
+	assert.Contains(text, "Health: unhealthy")
+
+	html, err := r.Render(FORMAT_HTML)
+	assert.Nil(err)
+	assert.Contains(html, "<h3>curveadm curveadm deploy: fail</h3>")
+	assert.Contains(html, "ERROR: container &lt;exited&gt;")
+	assert.Contains(html, "<li>post step failed: timeout</li>")
+	assert.Contains(html, "<h4>Health: unhealthy</h4>")
+
+	r.Services = r.Services[:1]
+	assert.True(r.Healthy())
+	r.Services = nil
+	text, err = r.Render(FORMAT_TEXT)
+	assert.Nil(err)
+	assert.Contains(text, "Health: unknown")
+}
+
+func TestConfigMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := Config{}
+	assert.False(cfg.Enabled())
+	assert.True(cfg.Match("deploy"))
+	assert.True(cfg.Match("upgrade"))
+	assert.False(cfg.Match("status"))
+
+	cfg = Config{SMTPHost: "smtp.example.com", To: []string{"ops@example.com"}, Commands: []string{"scale-out"}}
+	assert.True(cfg.Enabled())
+	assert.True(cfg.Match("scale-out"))
+	assert.False(cfg.Match("deploy"))
+}
+
+func TestMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := Config{
+		SMTPUser: "curveadm@example.com",
+		To:       []string{"ops@example.com", "dba@example.com"},
+		Format:   FORMAT_HTML,
+	}
+	msg, err := Message(cfg, newReport(), time.Now())
+	assert.Nil(err)
+	assert.Contains(string(msg), "From: curveadm@example.com\r\n")
+	assert.Contains(string(msg), "To: ops@example.com, dba@example.com\r\n")
+	assert.Contains(string(msg), "Subject: [curveadm] curveadm deploy my-cluster: fail\r\n")
+	assert.Contains(string(msg), "Content-Type: text/html; charset=utf-8\r\n")
+}
+
+// fake SMTP server which accepts one mail without authentication
+func serveSMTP(t *testing.T, l net.Listener, mails chan<- string) {
+	conn, err := l.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	fmt.Fprintf(conn, "220 localhost ESMTP\r\n")
+	data, inData := []string{}, false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if inData {
+			if line == "." {
+				inData = false
+				mails <- strings.Join(data, "\n")
+				fmt.Fprintf(conn, "250 OK\r\n")
+			} else {
+				data = append(data, line)
+			}
+			continue
+		}
+
+		switch strings.ToUpper(strings.SplitN(line, " ", 2)[0]) {
+		case "EHLO", "HELO":
+			fmt.Fprintf(conn, "250 localhost\r\n")
+		case "DATA":
+			inData = true
+			fmt.Fprintf(conn, "354 End data with <CR><LF>.<CR><LF>\r\n")
+		case "QUIT":
+			fmt.Fprintf(conn, "221 Bye\r\n")
+			return
+		default:
+			data = append(data, line)
+			fmt.Fprintf(conn, "250 OK\r\n")
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	defer l.Close()
+	mails := make(chan string, 1)
+	go serveSMTP(t, l, mails)
+
+	port, _ := strconv.Atoi(strings.Split(l.Addr().String(), ":")[1])
+	cfg := Config{
+		SMTPHost: "127.0.0.1",
+		SMTPPort: port,
+		From:     "curveadm@example.com",
+		To:       []string{"ops@example.com", "dba@example.com"},
+		Format:   FORMAT_TEXT,
+		Timeout:  3 * time.Second,
+	}
+	assert.Nil(Send(cfg, newReport()))
+
+	mail := <-mails
+	assert.Contains(mail, "MAIL FROM:<curveadm@example.com>")
+	assert.Contains(mail, "RCPT TO:<ops@example.com>")
+	assert.Contains(mail, "RCPT TO:<dba@example.com>")
+	assert.Contains(mail, "Content-Type: text/plain; charset=utf-8")
+	assert.Contains(mail, "  - Start Service (1m0s) [ERROR] container <exited>")
+}
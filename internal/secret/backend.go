@@ -0,0 +1,263 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+const (
+	BACKEND_STORE = "store" // encrypted blob in curveadm database (default)
+	BACKEND_ENV   = "env"
+	BACKEND_VAULT = "vault"
+	BACKEND_EXEC  = "exec"
+
+	DEFAULT_ENV_PREFIX  = "CURVEADM_SECRET_"
+	DEFAULT_VAULT_PATH  = "secret/data/curveadm"
+	DEFAULT_VAULT_FIELD = "value"
+	ENV_VAULT_ADDR      = "VAULT_ADDR"
+	ENV_VAULT_TOKEN     = "VAULT_TOKEN"
+
+	BACKEND_TIMEOUT = 30 * time.Second
+)
+
+var (
+	BACKENDS = []string{BACKEND_STORE, BACKEND_ENV, BACKEND_VAULT, BACKEND_EXEC}
+)
+
+type (
+	BackendConfig struct {
+		Backend     string
+		EnvPrefix   string
+		VaultAddr   string
+		VaultToken  string
+		VaultPath   string // KV path which secrets under, e.g. secret/data/curveadm for KV v2
+		VaultField  string // field of KV data which holds the value
+		ExecCommand string // the secret name appended as last argument, value printed to stdout
+	}
+
+	// EnvResolver reads secret from environment variable, e.g. s3_sk -> $CURVEADM_SECRET_S3_SK
+	EnvResolver struct {
+		prefix string
+	}
+
+	// VaultResolver reads secret from HashiCorp Vault by HTTP API, both KV v1 and v2 supported
+	VaultResolver struct {
+		address string
+		token   string
+		path    string
+		field   string
+	}
+
+	// ExecResolver reads secret from stdout of operator-supplied command
+	ExecResolver struct {
+		command []string
+	}
+
+	// cachedResolver avoids querying backend again for configs parsed multiple times
+	cachedResolver struct {
+		resolver Resolver
+		mutex    sync.Mutex
+		cache    map[string]string
+	}
+)
+
+func envName(prefix, name string) string {
+	name = strings.NewReplacer(".", "_", "-", "_").Replace(name)
+	return prefix + strings.ToUpper(name)
+}
+
+func NewEnvResolver(prefix string) *EnvResolver {
+	return &EnvResolver{prefix: prefix}
+}
+
+func (r *EnvResolver) Get(name string) (string, error) {
+	key := envName(r.prefix, name)
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return "", errno.ERR_SECRET_NOT_FOUND.F("name: %s, env: $%s", name, key)
+	}
+	return value, nil
+}
+
+func NewVaultResolver(address, token, path, field string) *VaultResolver {
+	return &VaultResolver{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		path:    strings.Trim(path, "/"),
+		field:   field,
+	}
+}
+
+/*
+ * KV v1: {"data": {"value": "..."}}
+ * KV v2: {"data": {"data": {"value": "..."}, "metadata": {...}}}
+ */
+func ParseVaultData(body []byte, field string) (string, bool) {
+	response := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", false
+	}
+
+	data := response.Data
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	value, ok := data[field].(string)
+	return value, ok
+}
+
+func (r *VaultResolver) Get(name string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/%s", r.address, r.path, name)
+	resp, err := resty.New().SetTimeout(BACKEND_TIMEOUT).R().
+		SetHeader("X-Vault-Token", r.token).
+		Get(url)
+	if err != nil {
+		return "", errno.ERR_QUERY_SECRET_BACKEND_FAILED.E(err)
+	} else if resp.StatusCode() == 404 {
+		return "", errno.ERR_SECRET_NOT_FOUND.F("name: %s, url: %s", name, url)
+	} else if resp.StatusCode() != 200 {
+		return "", errno.ERR_QUERY_SECRET_BACKEND_FAILED.
+			F("url: %s, status: %s", url, resp.Status())
+	}
+
+	value, ok := ParseVaultData(resp.Body(), r.field)
+	if !ok {
+		return "", errno.ERR_SECRET_NOT_FOUND.
+			F("name: %s, field '%s' not found in %s", name, r.field, url)
+	}
+	return value, nil
+}
+
+func NewExecResolver(command string) *ExecResolver {
+	return &ExecResolver{command: strings.Fields(command)}
+}
+
+func (r *ExecResolver) Get(name string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), BACKEND_TIMEOUT)
+	defer cancel()
+
+	args := append(append([]string{}, r.command[1:]...), name)
+	cmd := exec.CommandContext(ctx, r.command[0], args...)
+	cmd.Stderr = os.Stderr // the command may ask for authentication
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errno.ERR_QUERY_SECRET_BACKEND_FAILED.
+			F("%s %s: %v", strings.Join(r.command, " "), name, err)
+	}
+
+	value := strings.TrimRight(string(out), "\r\n")
+	if len(value) == 0 {
+		return "", errno.ERR_SECRET_NOT_FOUND.F("name: %s", name)
+	}
+	return value, nil
+}
+
+func newCachedResolver(resolver Resolver) *cachedResolver {
+	return &cachedResolver{
+		resolver: resolver,
+		cache:    map[string]string{},
+	}
+}
+
+func (r *cachedResolver) Get(name string) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if value, ok := r.cache[name]; ok {
+		return value, nil
+	}
+	value, err := r.resolver.Get(name)
+	if err != nil {
+		return "", err
+	}
+	r.cache[name] = value
+	return value, nil
+}
+
+func IsStoreBackend(backend string) bool {
+	return len(backend) == 0 || backend == BACKEND_STORE
+}
+
+// NewResolver returns the resolver of backend selected in curveadm.cfg
+func NewResolver(config BackendConfig, store *Store) (Resolver, error) {
+	if IsStoreBackend(config.Backend) {
+		return store, nil
+	}
+
+	switch config.Backend {
+	case BACKEND_ENV:
+		prefix := config.EnvPrefix
+		if len(prefix) == 0 {
+			prefix = DEFAULT_ENV_PREFIX
+		}
+		return NewEnvResolver(prefix), nil
+
+	case BACKEND_VAULT:
+		address := config.VaultAddr
+		if len(address) == 0 {
+			address = os.Getenv(ENV_VAULT_ADDR)
+		}
+		token := config.VaultToken
+		if len(token) == 0 {
+			token = os.Getenv(ENV_VAULT_TOKEN)
+		}
+		if len(address) == 0 || len(token) == 0 {
+			return nil, errno.ERR_INVALID_SECRET_BACKEND_CONFIG.
+				F("vault requires address and token, set them in curveadm.cfg or $%s/$%s",
+					ENV_VAULT_ADDR, ENV_VAULT_TOKEN)
+		}
+		path := config.VaultPath
+		if len(path) == 0 {
+			path = DEFAULT_VAULT_PATH
+		}
+		field := config.VaultField
+		if len(field) == 0 {
+			field = DEFAULT_VAULT_FIELD
+		}
+		return newCachedResolver(NewVaultResolver(address, token, path, field)), nil
+
+	case BACKEND_EXEC:
+		if len(strings.TrimSpace(config.ExecCommand)) == 0 {
+			return nil, errno.ERR_INVALID_SECRET_BACKEND_CONFIG.
+				F("exec backend requires command")
+		}
+		return newCachedResolver(NewExecResolver(config.ExecCommand)), nil
+	}
+
+	return nil, errno.ERR_UNSUPPORT_SECRET_BACKEND.F("backend: %s", config.Backend)
+}
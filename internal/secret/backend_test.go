@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package secret
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvResolver(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("CURVEADM_SECRET_S3_SK", "sk")
+	t.Setenv("CURVEADM_SECRET_HOST1_PASSWORD", "p@ss")
+	r := NewEnvResolver(DEFAULT_ENV_PREFIX)
+	v, err := r.Get("s3_sk")
+	assert.Nil(err)
+	assert.Equal("sk", v)
+	v, err = r.Get("host1-password")
+	assert.Nil(err)
+	assert.Equal("p@ss", v)
+	_, err = r.Get("not_exist")
+	assert.NotNil(err)
+}
+
+func TestExecResolver(t *testing.T) {
+	assert := assert.New(t)
+
+	v, err := NewExecResolver("echo prefix").Get("s3_sk")
+	assert.Nil(err)
+	assert.Equal("prefix s3_sk", v)
+
+	_, err = NewExecResolver("false").Get("s3_sk")
+	assert.NotNil(err)
+}
+
+func TestParseVaultData(t *testing.T) {
+	assert := assert.New(t)
+
+	// KV v1
+	v, ok := ParseVaultData([]byte(`{"data": {"value": "sk"}}`), "value")
+	assert.True(ok)
+	assert.Equal("sk", v)
+
+	// KV v2
+	v, ok = ParseVaultData([]byte(`{"data": {"data": {"value": "sk"}, "metadata": {"version": 1}}}`), "value")
+	assert.True(ok)
+	assert.Equal("sk", v)
+
+	_, ok = ParseVaultData([]byte(`{"data": {"password": "sk"}}`), "value")
+	assert.False(ok)
+	_, ok = ParseVaultData([]byte(`not json`), "value")
+	assert.False(ok)
+}
+
+func TestVaultResolver(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+		} else if r.URL.Path == "/v1/secret/data/curveadm/s3_sk" {
+			w.Write([]byte(`{"data": {"data": {"value": "sk"}, "metadata": {}}}`))
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r, err := NewResolver(BackendConfig{
+		Backend:    BACKEND_VAULT,
+		VaultAddr:  server.URL,
+		VaultToken: "token",
+	}, nil)
+	assert.Nil(err)
+	for i := 0; i < 2; i++ {
+		v, err := r.Get("s3_sk")
+		assert.Nil(err)
+		assert.Equal("sk", v)
+	}
+	assert.Equal(1, requests) // cached
+
+	_, err = r.Get("not_exist")
+	assert.NotNil(err)
+
+	_, err = NewVaultResolver(server.URL, "wrong", DEFAULT_VAULT_PATH, DEFAULT_VAULT_FIELD).Get("s3_sk")
+	assert.NotNil(err)
+}
+
+func TestNewResolver(t *testing.T) {
+	assert := assert.New(t)
+
+	store := &Store{}
+	r, err := NewResolver(BackendConfig{}, store)
+	assert.Nil(err)
+	assert.Equal(store, r)
+
+	t.Setenv(ENV_VAULT_ADDR, "")
+	t.Setenv(ENV_VAULT_TOKEN, "")
+	_, err = NewResolver(BackendConfig{Backend: BACKEND_VAULT}, store)
+	assert.NotNil(err)
+	_, err = NewResolver(BackendConfig{Backend: BACKEND_EXEC}, store)
+	assert.NotNil(err)
+	_, err = NewResolver(BackendConfig{Backend: "unknown"}, store)
+	assert.NotNil(err)
+}
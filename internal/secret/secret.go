@@ -0,0 +1,164 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// the value in hosts/topology which references a secret, e.g. secret://s3_sk
+	REFERENCE_PREFIX = "secret://"
+	REGEX_NAME       = "^[a-zA-Z0-9_.-]+$"
+
+	ENVELOPE_VERSION = 1
+	SALT_SIZE        = 16
+	KEY_SIZE         = 32 // AES-256
+
+	// scrypt parameters recommended for interactive logins
+	SCRYPT_N = 1 << 15
+	SCRYPT_R = 8
+	SCRYPT_P = 1
+)
+
+var (
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted secrets")
+	ErrEmptyPassphrase = errors.New("passphrase is empty")
+)
+
+/*
+ * Envelope is the encrypted blob stored in database:
+ *   key = scrypt(passphrase, salt)
+ *   data = AES-256-GCM(key, nonce, json(secrets))
+ */
+type Envelope struct {
+	Version int    `json:"version"`
+	Salt    string `json:"salt"`
+	Nonce   string `json:"nonce"`
+	Data    string `json:"data"`
+}
+
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, REFERENCE_PREFIX)
+}
+
+func ReferenceName(value string) string {
+	return strings.TrimPrefix(value, REFERENCE_PREFIX)
+}
+
+func IsValidName(name string) bool {
+	return regexp.MustCompile(REGEX_NAME).MatchString(name)
+}
+
+func newGCM(passphrase, salt []byte) (cipher.AEAD, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+	key, err := scrypt.Key(passphrase, salt, SCRYPT_N, SCRYPT_R, SCRYPT_P, KEY_SIZE)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts all secrets by passphrase, a new salt and nonce generated every time
+func Seal(secrets map[string]string, passphrase []byte) (string, error) {
+	salt := make([]byte, SALT_SIZE)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return "", err
+	}
+	envelope := Envelope{
+		Version: ENVELOPE_VERSION,
+		Salt:    base64.StdEncoding.EncodeToString(salt),
+		Nonce:   base64.StdEncoding.EncodeToString(nonce),
+		Data:    base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, plaintext, nil)),
+	}
+	bytes, err := json.Marshal(envelope)
+	return string(bytes), err
+}
+
+// Open decrypts the blob sealed by Seal, returns ErrWrongPassphrase if authentication failed
+func Open(blob string, passphrase []byte) (map[string]string, error) {
+	envelope := Envelope{}
+	if err := json.Unmarshal([]byte(blob), &envelope); err != nil {
+		return nil, err
+	} else if envelope.Version != ENVELOPE_VERSION {
+		return nil, fmt.Errorf("unsupported secrets version %d", envelope.Version)
+	}
+
+	decode := base64.StdEncoding.DecodeString
+	salt, err := decode(envelope.Salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := decode(envelope.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	data, err := decode(envelope.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	} else if len(nonce) != gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plaintext, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	secrets := map[string]string{}
+	err = json.Unmarshal(plaintext, &secrets)
+	return secrets, err
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package secret
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+type mapResolver map[string]string
+
+func (r mapResolver) Get(name string) (string, error) {
+	if v, ok := r[name]; ok {
+		return v, nil
+	}
+	return "", errno.ERR_SECRET_NOT_FOUND.F("name: %s", name)
+}
+
+func TestSealAndOpen(t *testing.T) {
+	assert := assert.New(t)
+
+	secrets := map[string]string{"s3_sk": "sk", "host1_password": "p@ss"}
+	blob, err := Seal(secrets, []byte("passphrase"))
+	assert.Nil(err)
+	assert.NotContains(blob, "p@ss")
+
+	out, err := Open(blob, []byte("passphrase"))
+	assert.Nil(err)
+	assert.Equal(secrets, out)
+
+	_, err = Open(blob, []byte("wrong"))
+	assert.Equal(ErrWrongPassphrase, err)
+
+	// salt and nonce differ every time
+	blob2, err := Seal(secrets, []byte("passphrase"))
+	assert.Nil(err)
+	assert.NotEqual(blob, blob2)
+
+	_, err = Seal(secrets, nil)
+	assert.Equal(ErrEmptyPassphrase, err)
+}
+
+func TestResolve(t *testing.T) {
+	assert := assert.New(t)
+	defer ReplaceGlobals(nil)
+
+	ReplaceGlobals(nil)
+	v, err := Resolve("plaintext")
+	assert.Nil(err)
+	assert.Equal("plaintext", v)
+	_, err = Resolve("secret://s3_sk")
+	assert.NotNil(err)
+
+	ReplaceGlobals(mapResolver{"s3_sk": "sk"})
+	v, err = Resolve("secret://s3_sk")
+	assert.Nil(err)
+	assert.Equal("sk", v)
+	_, err = Resolve("secret://not_exist")
+	assert.NotNil(err)
+	_, err = Resolve("secret://a/b")
+	assert.NotNil(err)
+}
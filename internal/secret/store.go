@@ -0,0 +1,174 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package secret
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+)
+
+type (
+	// PassphraseFunc returns the master passphrase, create is true if the store is empty
+	PassphraseFunc func(create bool) ([]byte, error)
+
+	Resolver interface {
+		Get(name string) (string, error)
+	}
+
+	// Store keeps all secrets in one encrypted blob of curveadm database,
+	// it's unlocked lazily when the first secret accessed.
+	Store struct {
+		storage    *storage.Storage
+		passphrase PassphraseFunc
+		mutex      sync.Mutex
+		key        []byte
+		secrets    map[string]string
+	}
+)
+
+var globalResolver Resolver
+
+func ReplaceGlobals(resolver Resolver) {
+	globalResolver = resolver
+}
+
+// Resolve returns the secret value if value is a reference (e.g. secret://s3_sk), otherwise value itself
+func Resolve(value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+
+	name := ReferenceName(value)
+	if !IsValidName(name) {
+		return "", errno.ERR_RESOLVE_SECRET_REFERENCE_FAILED.
+			F("%s: invalid secret name", value)
+	} else if globalResolver == nil {
+		return "", errno.ERR_SECRETS_STORE_NOT_INITIALIZED.S(value)
+	}
+	return globalResolver.Get(name)
+}
+
+func NewStore(s *storage.Storage, passphrase PassphraseFunc) *Store {
+	return &Store{
+		storage:    s,
+		passphrase: passphrase,
+	}
+}
+
+func (s *Store) unlock() error {
+	if s.secrets != nil {
+		return nil
+	}
+
+	secretses, err := s.storage.GetSecretses()
+	if err != nil {
+		return errno.ERR_GET_SECRETS_FAILED.E(err)
+	}
+	create := len(secretses) == 0
+	passphrase, err := s.passphrase(create)
+	if err != nil {
+		return err
+	} else if len(passphrase) == 0 {
+		return errno.ERR_SECRET_PASSPHRASE_NOT_SPECIFIED
+	}
+
+	secrets := map[string]string{}
+	if !create {
+		secrets, err = Open(secretses[0].Data, passphrase)
+		if err == ErrWrongPassphrase {
+			return errno.ERR_WRONG_SECRET_PASSPHRASE
+		} else if err != nil {
+			return errno.ERR_DECRYPT_SECRETS_FAILED.E(err)
+		}
+	}
+	s.key = passphrase
+	s.secrets = secrets
+	return nil
+}
+
+func (s *Store) save() error {
+	blob, err := Seal(s.secrets, s.key)
+	if err != nil {
+		return errno.ERR_ENCRYPT_SECRETS_FAILED.E(err)
+	} else if err := s.storage.SetSecrets(blob); err != nil {
+		return errno.ERR_SET_SECRETS_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *Store) Get(name string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.unlock(); err != nil {
+		return "", err
+	}
+	value, ok := s.secrets[name]
+	if !ok {
+		return "", errno.ERR_SECRET_NOT_FOUND.F("name: %s", name)
+	}
+	return value, nil
+}
+
+func (s *Store) Set(name, value string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.unlock(); err != nil {
+		return err
+	}
+	s.secrets[name] = value
+	return s.save()
+}
+
+func (s *Store) Remove(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.unlock(); err != nil {
+		return err
+	} else if _, ok := s.secrets[name]; !ok {
+		return errno.ERR_SECRET_NOT_FOUND.F("name: %s", name)
+	}
+	delete(s.secrets, name)
+	return s.save()
+}
+
+// List returns names of all secrets in order, the values are never listed
+func (s *Store) List() ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.unlock(); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for name := range s.secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -30,8 +30,16 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
+const (
+	SQLITE_STMT_CACHE_SIZE = 128
+)
+
 type SQLiteDB struct {
 	db *sql.DB
+	// prepared statements keyed by query, most of queries are constants
+	// in storage package, the generated ones (e.g. IN (?, ?, ...)) may
+	// overflow the cache and they are executed without prepared
+	stmts map[string]*sql.Stmt
 	sync.Mutex
 }
 
@@ -50,7 +58,7 @@ var (
 )
 
 func NewSQLiteDB() *SQLiteDB {
-	return &SQLiteDB{}
+	return &SQLiteDB{stmts: map[string]*sql.Stmt{}}
 }
 
 func (db *SQLiteDB) Open(url string) error {
@@ -61,9 +69,33 @@ func (db *SQLiteDB) Open(url string) error {
 }
 
 func (db *SQLiteDB) Close() error {
+	db.Lock()
+	defer db.Unlock()
+
+	for query, stmt := range db.stmts {
+		stmt.Close()
+		delete(db.stmts, query)
+	}
 	return db.db.Close()
 }
 
+// prepare returns cached prepared statement or nil if the cache is full,
+// caller must hold the lock
+func (db *SQLiteDB) prepare(query string) (*sql.Stmt, error) {
+	if stmt, ok := db.stmts[query]; ok {
+		return stmt, nil
+	} else if len(db.stmts) >= SQLITE_STMT_CACHE_SIZE {
+		return nil, nil
+	}
+
+	stmt, err := db.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	db.stmts[query] = stmt
+	return stmt, nil
+}
+
 func (result *Rows) Next() bool {
 	return result.rows.Next()
 }
@@ -80,7 +112,17 @@ func (db *SQLiteDB) Query(query string, args ...any) (IQueryResult, error) {
 	db.Lock()
 	defer db.Unlock()
 
-	rows, err := db.db.Query(query, args...)
+	stmt, err := db.prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	if stmt != nil {
+		rows, err = stmt.Query(args...)
+	} else {
+		rows, err = db.db.Query(query, args...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -95,11 +137,16 @@ func (db *SQLiteDB) Write(query string, args ...any) (IWriteResult, error) {
 	db.Lock()
 	defer db.Unlock()
 
-	stmt, err := db.db.Prepare(query)
+	stmt, err := db.prepare(query)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := stmt.Exec(args...)
+	var result sql.Result
+	if stmt != nil {
+		result, err = stmt.Exec(args...)
+	} else {
+		result, err = db.db.Exec(query, args...)
+	}
 	return &Result{result: result}, err
 }
@@ -178,8 +178,14 @@ var (
 	// select services in cluster
 	SelectServicesInCluster = `SELECT * FROM containers WHERE cluster_id = ?`
 
+	// index for selecting services in cluster
+	CreateContainersClusterIndex = `CREATE INDEX IF NOT EXISTS idx_containers_cluster_id ON containers(cluster_id)`
+
 	// set service container id
 	SetContainerId = `UPDATE containers SET container_id = ? WHERE id = ?`
+
+	// delete service
+	DeleteService = `DELETE FROM containers WHERE id = ?`
 )
 
 // client
@@ -266,6 +272,9 @@ type AuditLog struct {
 	Command       string
 	Status        int
 	ErrorCode     int
+	Operator      string // user who executed the command
+	Source        string // "local" or client address of API
+	Cluster       string // current cluster when command executed
 }
 
 var (
@@ -281,20 +290,42 @@ var (
 		)
 	`
 
+	// columns added after audit table created, the old table will be altered
+	AuditOperatorColumns = []string{"operator", "source", "cluster"}
+
+	// check audit column
+	CheckAuditColumn = `
+		SELECT COUNT(*) AS total
+		FROM pragma_table_info('audit')
+		WHERE name = ?
+	`
+
+	// add audit column
+	AddAuditColumn = `ALTER TABLE audit ADD COLUMN %s TEXT NOT NULL DEFAULT ''`
+
 	// insert audit log
 	InsertAuditLog = `
-		INSERT INTO audit(execute_time, work_directory, command, status)
-		            VALUES(?, ?, ?, ?)
+		INSERT INTO audit(execute_time, work_directory, command, status, operator, source, cluster)
+		            VALUES(?, ?, ?, ?, ?, ?, ?)
 	`
 
 	// set audit log status
 	SetAuditLogStatus = `UPDATE audit SET status = ?, error_code = ? WHERE id = ?`
 
+	// set audit log cluster
+	SetAuditLogCluster = `UPDATE audit SET cluster = ? WHERE id = ?`
+
 	// select audit log
-	SelectAuditLog = `SELECT * FROM audit`
+	SelectAuditLog = `
+		SELECT id, execute_time, work_directory, command, status, error_code, operator, source, cluster
+		FROM audit
+	`
 
 	// select audit log by id
-	SelectAuditLogById = `SELECT * FROM audit WHERE id = ?`
+	SelectAuditLogById = `
+		SELECT id, execute_time, work_directory, command, status, error_code, operator, source, cluster
+		FROM audit WHERE id = ?
+	`
 )
 
 // any: we can store anything
@@ -318,6 +349,9 @@ var (
 	// set item
 	SetAnyItem = `UPDATE any SET data = ? WHERE id = ?`
 
+	// insert or replace item
+	ReplaceAnyItem = `REPLACE INTO any(id, data) VALUES(?, ?)`
+
 	// select item by id
 	SelectAnyItem = `SELECT * FROM any WHERE id = ?`
 
@@ -325,6 +359,225 @@ var (
 	DeleteAnyItem = `DELETE from any WHERE id = ?`
 )
 
+// health sample
+type HealthSample struct {
+	Id         int
+	ClusterId  int
+	Probe      string
+	Target     string
+	Status     string
+	Value      string
+	SampleTime time.Time
+}
+
+var (
+	// table: health_samples
+	CreateHealthSamplesTable = `
+		CREATE TABLE IF NOT EXISTS health_samples (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cluster_id INTEGER NOT NULL,
+			probe TEXT NOT NULL,
+			target TEXT NOT NULL,
+			status TEXT NOT NULL,
+			value TEXT NOT NULL,
+			sample_time DATE NOT NULL
+		)
+	`
+
+	// indices for selecting latest samples and deleting expired samples
+	CreateHealthSamplesTargetIndex = `
+		CREATE INDEX IF NOT EXISTS idx_health_samples_target
+		ON health_samples(cluster_id, probe, target)
+	`
+	CreateHealthSamplesTimeIndex = `
+		CREATE INDEX IF NOT EXISTS idx_health_samples_time
+		ON health_samples(cluster_id, sample_time)
+	`
+
+	// insert health sample
+	InsertHealthSample = `
+		INSERT INTO health_samples(cluster_id, probe, target, status, value, sample_time)
+		                    VALUES(?, ?, ?, ?, ?, ?)
+	`
+
+	// select latest health sample of each probe target
+	SelectLatestHealthSamples = `
+		SELECT * FROM health_samples
+		WHERE id IN (
+			SELECT MAX(id) FROM health_samples
+			WHERE cluster_id = ?
+			GROUP BY probe, target
+		)
+	`
+
+	// delete health samples which out of retention
+	DeleteHealthSamplesBefore = `DELETE FROM health_samples WHERE cluster_id = ? AND sample_time < ?`
+)
+
+// alert rules
+type AlertRules struct {
+	Id         int
+	ClusterId  int
+	Rules      string
+	CreateTime time.Time
+}
+
+var (
+	// table: alert_rules, each apply inserts a new version
+	CreateAlertRulesTable = `
+		CREATE TABLE IF NOT EXISTS alert_rules (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cluster_id INTEGER NOT NULL,
+			rules TEXT NOT NULL,
+			create_time DATE NOT NULL
+		)
+	`
+
+	// insert alert rules
+	InsertAlertRules = `INSERT INTO alert_rules(cluster_id, rules, create_time) VALUES(?, ?, datetime('now','localtime'))`
+
+	// select all versions of alert rules, the latest one first
+	SelectAlertRules = `SELECT * FROM alert_rules WHERE cluster_id = ? ORDER BY id DESC`
+)
+
+// upgrade state
+type UpgradeState struct {
+	ClusterId  int
+	ServiceId  string
+	Image      string
+	Status     string
+	UpdateTime time.Time
+}
+
+var (
+	// table: upgrade_states, each service has one state in a rolling upgrade
+	CreateUpgradeStatesTable = `
+		CREATE TABLE IF NOT EXISTS upgrade_states (
+			cluster_id INTEGER NOT NULL,
+			service_id TEXT NOT NULL,
+			image TEXT NOT NULL,
+			status TEXT NOT NULL,
+			update_time DATE NOT NULL,
+			PRIMARY KEY (cluster_id, service_id)
+		)
+	`
+
+	// insert or update upgrade state, the rowid keeps unchanged on update
+	UpsertUpgradeState = `
+		INSERT INTO upgrade_states(cluster_id, service_id, image, status, update_time)
+		                    VALUES(?, ?, ?, ?, datetime('now','localtime'))
+		ON CONFLICT(cluster_id, service_id) DO UPDATE SET
+			image = excluded.image,
+			status = excluded.status,
+			update_time = excluded.update_time
+	`
+
+	// select upgrade states in the order they added
+	SelectUpgradeStates = `SELECT * FROM upgrade_states WHERE cluster_id = ? ORDER BY rowid`
+
+	// delete all upgrade states of cluster
+	DeleteUpgradeStates = `DELETE FROM upgrade_states WHERE cluster_id = ?`
+)
+
+// canary
+type Canary struct {
+	ClusterId  int
+	ServiceId  string
+	OldImage   string
+	NewImage   string
+	CreateTime time.Time
+}
+
+var (
+	// table: canaries, the services which upgraded as canary
+	CreateCanariesTable = `
+		CREATE TABLE IF NOT EXISTS canaries (
+			cluster_id INTEGER NOT NULL,
+			service_id TEXT NOT NULL,
+			old_image TEXT NOT NULL,
+			new_image TEXT NOT NULL,
+			create_time DATE NOT NULL,
+			PRIMARY KEY (cluster_id, service_id)
+		)
+	`
+
+	// insert canary
+	InsertCanary = `
+		INSERT INTO canaries(cluster_id, service_id, old_image, new_image, create_time)
+		              VALUES(?, ?, ?, ?, datetime('now','localtime'))
+	`
+
+	// select canaries
+	SelectCanaries = `SELECT * FROM canaries WHERE cluster_id = ?`
+
+	// delete all canaries of cluster
+	DeleteCanaries = `DELETE FROM canaries WHERE cluster_id = ?`
+)
+
+// previous image
+type PreviousImage struct {
+	ClusterId  int
+	ServiceId  string
+	Image      string
+	Digest     string
+	Config     string // config files of service in JSON, path -> content
+	UpdateTime time.Time
+}
+
+var (
+	// table: previous_images, the image which service running with before upgrade
+	CreatePreviousImagesTable = `
+		CREATE TABLE IF NOT EXISTS previous_images (
+			cluster_id INTEGER NOT NULL,
+			service_id TEXT NOT NULL,
+			image TEXT NOT NULL,
+			digest TEXT NOT NULL,
+			config TEXT NOT NULL,
+			update_time DATE NOT NULL,
+			PRIMARY KEY (cluster_id, service_id)
+		)
+	`
+
+	// replace previous image
+	ReplacePreviousImage = `
+		REPLACE INTO previous_images(cluster_id, service_id, image, digest, config, update_time)
+		                      VALUES(?, ?, ?, ?, ?, datetime('now','localtime'))
+	`
+
+	// select previous images
+	SelectPreviousImages = `SELECT * FROM previous_images WHERE cluster_id = ?`
+
+	// delete previous image of service
+	DeletePreviousImage = `DELETE FROM previous_images WHERE cluster_id = ? AND service_id = ?`
+)
+
+// previous topology
+type PreviousTopology struct {
+	ClusterId  int
+	Topology   string
+	UpdateTime time.Time
+}
+
+var (
+	// table: previous_topologies, the topology before config applied
+	CreatePreviousTopologiesTable = `
+		CREATE TABLE IF NOT EXISTS previous_topologies (
+			cluster_id INTEGER PRIMARY KEY,
+			topology TEXT NOT NULL,
+			update_time DATE NOT NULL
+		)
+	`
+
+	// replace previous topology
+	ReplacePreviousTopology = `
+		REPLACE INTO previous_topologies(cluster_id, topology, update_time)
+		                          VALUES(?, ?, datetime('now','localtime'))
+	`
+
+	// select previous topology
+	SelectPreviousTopology = `SELECT * FROM previous_topologies WHERE cluster_id = ?`
+)
+
 var (
 	// check pool column
 	CheckPoolColumn = `
@@ -366,3 +619,303 @@ var (
 
 	ReplaceMonitor = `REPLACE INTO monitors (cluster_id, monitor) VALUES(?, ?)`
 )
+
+// format progress
+type FormatProgress struct {
+	Host       string
+	Device     string
+	MountPoint string
+	Percent    int
+	Chunks     int
+	Status     string
+	UpdateTime time.Time
+}
+
+var (
+	// table: format_progresses, the formatting progress of each disk
+	CreateFormatProgressesTable = `
+		CREATE TABLE IF NOT EXISTS format_progresses (
+			host TEXT NOT NULL,
+			device TEXT NOT NULL,
+			mount_point TEXT NOT NULL,
+			percent INTEGER NOT NULL,
+			chunks INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			update_time DATE NOT NULL,
+			PRIMARY KEY (host, device)
+		)
+	`
+
+	// replace format progress
+	ReplaceFormatProgress = `
+		REPLACE INTO format_progresses(host, device, mount_point, percent, chunks, status, update_time)
+		                        VALUES(?, ?, ?, ?, ?, ?, datetime('now','localtime'))
+	`
+
+	// select format progresses
+	SelectFormatProgresses = `SELECT * FROM format_progresses`
+
+	// select format progresses of specified hosts, which uses the primary key (host, device)
+	SelectFormatProgressesByHosts = `SELECT * FROM format_progresses WHERE host IN (%s)`
+)
+
+// benchmark
+type Benchmark struct {
+	Id         int
+	ClusterId  int
+	Kind       string
+	Pattern    string
+	Options    string
+	Result     string
+	CreateTime time.Time
+}
+
+var (
+	// table: benchmarks, the result of each bench run
+	CreateBenchmarksTable = `
+		CREATE TABLE IF NOT EXISTS benchmarks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cluster_id INTEGER NOT NULL,
+			kind TEXT NOT NULL,
+			pattern TEXT NOT NULL,
+			options TEXT NOT NULL,
+			result TEXT NOT NULL,
+			create_time DATE NOT NULL
+		)
+	`
+
+	// insert benchmark
+	InsertBenchmark = `
+		INSERT INTO benchmarks(cluster_id, kind, pattern, options, result, create_time)
+		                VALUES(?, ?, ?, ?, ?, datetime('now','localtime'))
+	`
+
+	// select benchmarks of cluster, the latest one first
+	SelectBenchmarks = `SELECT * FROM benchmarks WHERE cluster_id = ? ORDER BY id DESC`
+)
+
+// host facts
+type HostFacts struct {
+	Host       string
+	Facts      string
+	UpdateTime time.Time
+}
+
+var (
+	// table: host_facts, the hardware facts of each host in hosts
+	CreateHostFactsTable = `
+		CREATE TABLE IF NOT EXISTS host_facts (
+			host TEXT PRIMARY KEY,
+			facts TEXT NOT NULL,
+			update_time DATE NOT NULL
+		)
+	`
+
+	// replace host facts
+	ReplaceHostFacts = `
+		REPLACE INTO host_facts(host, facts, update_time)
+		                 VALUES(?, ?, datetime('now','localtime'))
+	`
+
+	// select all host facts
+	SelectHostFacts = `SELECT * FROM host_facts`
+
+	// delete host facts
+	DeleteHostFacts = `DELETE FROM host_facts WHERE host = ?`
+)
+
+// secrets
+type Secrets struct {
+	Id               int
+	Data             string
+	LastModifiedTime time.Time
+}
+
+var (
+	// table: secrets, only one row which holds the encrypted blob of all secrets
+	CreateSecretsTable = `
+		CREATE TABLE IF NOT EXISTS secrets (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			data TEXT NOT NULL,
+			lastmodified_time DATE NOT NULL
+		)
+	`
+
+	// insert secrets
+	InsertSecrets = `INSERT INTO secrets(data, lastmodified_time) VALUES(?, datetime('now','localtime'))`
+
+	// set secrets
+	SetSecrets = `UPDATE secrets SET data = ?, lastmodified_time = datetime('now','localtime') WHERE id = ?`
+
+	// select secrets
+	SelectSecrets = `SELECT * FROM secrets`
+)
+
+// certificate
+type Certificate struct {
+	ClusterId  int
+	Name       string // "ca" or service id
+	Cert       string
+	Key        string
+	UpdateTime time.Time
+}
+
+var (
+	// table: certificates, the cluster CA and certificates issued for services
+	CreateCertificatesTable = `
+		CREATE TABLE IF NOT EXISTS certificates (
+			cluster_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			cert TEXT NOT NULL,
+			key TEXT NOT NULL,
+			update_time DATE NOT NULL,
+			PRIMARY KEY (cluster_id, name)
+		)
+	`
+
+	// replace certificate
+	ReplaceCertificate = `
+		REPLACE INTO certificates(cluster_id, name, cert, key, update_time)
+		                   VALUES(?, ?, ?, ?, datetime('now','localtime'))
+	`
+
+	// select all certificates of cluster
+	SelectCertificates = `SELECT * FROM certificates WHERE cluster_id = ? ORDER BY rowid`
+
+	// delete all certificates of cluster
+	DeleteCertificates = `DELETE FROM certificates WHERE cluster_id = ?`
+)
+
+// cluster protection
+type ClusterProtection struct {
+	ClusterId   int
+	UnlockUntil int64 // unix timestamp, destructive operations are allowed before it
+	CreateTime  time.Time
+}
+
+var (
+	// table: cluster_protections, the protected clusters
+	CreateClusterProtectionsTable = `
+		CREATE TABLE IF NOT EXISTS cluster_protections (
+			cluster_id INTEGER PRIMARY KEY,
+			unlock_until INTEGER NOT NULL,
+			create_time DATE NOT NULL
+		)
+	`
+
+	// insert cluster protection, it's locked at beginning
+	InsertClusterProtection = `
+		INSERT INTO cluster_protections(cluster_id, unlock_until, create_time)
+		                         VALUES(?, 0, datetime('now','localtime'))
+	`
+
+	// set unlock time of cluster protection
+	SetClusterUnlockUntil = `UPDATE cluster_protections SET unlock_until = ? WHERE cluster_id = ?`
+
+	// select cluster protection
+	SelectClusterProtection = `SELECT * FROM cluster_protections WHERE cluster_id = ?`
+
+	// select all cluster protections
+	SelectClusterProtections = `SELECT * FROM cluster_protections`
+
+	// delete cluster protection
+	DeleteClusterProtection = `DELETE FROM cluster_protections WHERE cluster_id = ?`
+)
+
+// operation duration
+type OperationDuration struct {
+	Id         int
+	ClusterId  int
+	Operation  string // e.g. "stop service"
+	Services   int    // number of services operated
+	Duration   int64  // milliseconds
+	FinishTime time.Time
+}
+
+var (
+	// table: operation_durations, the durations of succeeded operations
+	// which used to estimate the duration of next one
+	CreateOperationDurationsTable = `
+		CREATE TABLE IF NOT EXISTS operation_durations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cluster_id INTEGER NOT NULL,
+			operation TEXT NOT NULL,
+			services INTEGER NOT NULL,
+			duration INTEGER NOT NULL,
+			finish_time DATE NOT NULL
+		)
+	`
+
+	// insert operation duration
+	InsertOperationDuration = `
+		INSERT INTO operation_durations(cluster_id, operation, services, duration, finish_time)
+		                         VALUES(?, ?, ?, ?, datetime('now','localtime'))
+	`
+
+	// select the latest durations of operation, the latest first
+	SelectOperationDurations = `
+		SELECT * FROM operation_durations
+		WHERE cluster_id = ? AND operation = ?
+		ORDER BY id DESC LIMIT ?
+	`
+
+	// delete all operation durations of cluster
+	DeleteOperationDurations = `DELETE FROM operation_durations WHERE cluster_id = ?`
+)
+
+// backup schedule
+type BackupSchedule struct {
+	Id          int
+	ClusterId   int
+	Target      string // etcd, mds or db
+	Cron        string
+	Location    string // directory or s3://BUCKET/PREFIX, empty means the default one
+	Keep        int
+	LastRunTime time.Time
+	LastStatus  string
+	LastResult  string // location of backup or error
+	CreateTime  time.Time
+}
+
+var (
+	// table: backup_schedules, the backups which run periodically by
+	// `curveadm watch` or `curveadm backup run`
+	CreateBackupSchedulesTable = `
+		CREATE TABLE IF NOT EXISTS backup_schedules (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cluster_id INTEGER NOT NULL,
+			target TEXT NOT NULL,
+			cron TEXT NOT NULL,
+			location TEXT NOT NULL,
+			keep INTEGER NOT NULL,
+			last_run_time DATE NOT NULL,
+			last_status TEXT NOT NULL,
+			last_result TEXT NOT NULL,
+			create_time DATE NOT NULL
+		)
+	`
+
+	// insert backup schedule, the first run is the next matched time since now
+	InsertBackupSchedule = `
+		INSERT INTO backup_schedules(cluster_id, target, cron, location, keep, last_run_time, last_status, last_result, create_time)
+		                      VALUES(?, ?, ?, ?, ?, datetime('now','localtime'), '', '', datetime('now','localtime'))
+	`
+
+	// set result of the last run
+	SetBackupScheduleResult = `
+		UPDATE backup_schedules SET last_run_time = ?, last_status = ?, last_result = ?
+		WHERE id = ?
+	`
+
+	// select backup schedules of cluster
+	SelectBackupSchedules = `SELECT * FROM backup_schedules WHERE cluster_id = ? ORDER BY id`
+
+	// delete backup schedule
+	DeleteBackupSchedule = `DELETE FROM backup_schedules WHERE id = ? AND cluster_id = ?`
+
+	// delete all backup schedules of cluster
+	DeleteBackupSchedules = `DELETE FROM backup_schedules WHERE cluster_id = ?`
+
+	// copy database into file, only for sqlite
+	BackupDatabase = `VACUUM INTO ?`
+)
@@ -25,6 +25,8 @@ package storage
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -82,6 +84,23 @@ func (s *Storage) init() error {
 		CreateAuditTable,
 		CreateMonitorTable,
 		CreateAnyTable,
+		CreateHealthSamplesTable,
+		CreateAlertRulesTable,
+		CreateUpgradeStatesTable,
+		CreateCanariesTable,
+		CreatePreviousImagesTable,
+		CreatePreviousTopologiesTable,
+		CreateFormatProgressesTable,
+		CreateBenchmarksTable,
+		CreateHostFactsTable,
+		CreateSecretsTable,
+		CreateCertificatesTable,
+		CreateClusterProtectionsTable,
+		CreateOperationDurationsTable,
+		CreateBackupSchedulesTable,
+		CreateContainersClusterIndex,
+		CreateHealthSamplesTargetIndex,
+		CreateHealthSamplesTimeIndex,
 	}
 
 	for _, sql := range sqls {
@@ -91,9 +110,41 @@ func (s *Storage) init() error {
 		}
 	}
 
+	return s.migrateAuditTable()
+}
+
+// add operator columns for audit table which created by old version
+func (s *Storage) migrateAuditTable() error {
+	for _, column := range AuditOperatorColumns {
+		result, err := s.db.Query(CheckAuditColumn, column)
+		if err != nil {
+			return err
+		}
+
+		total := 0
+		if result.Next() {
+			err = result.Scan(&total)
+		}
+		result.Close()
+		if err != nil {
+			return err
+		} else if total > 0 {
+			continue
+		}
+
+		err = s.write(fmt.Sprintf(AddAuditColumn, column))
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// placeholders returns "?, ?, ..." for IN clause
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
 func (s *Storage) write(query string, args ...any) error {
 	_, err := s.db.Write(query, args...)
 	return err
@@ -267,13 +318,17 @@ func (s *Storage) SetContainId(serviceId, containerId string) error {
 	return s.write(SetContainerId, containerId, serviceId)
 }
 
+func (s *Storage) DeleteService(serviceId string) error {
+	return s.write(DeleteService, serviceId)
+}
+
 // client
 func (s *Storage) InsertClient(id, kind, host, containerId, auxInfo string) error {
 	return s.write(InsertClient, id, kind, host, containerId, auxInfo)
 }
 
 func (s *Storage) SetClientAuxInfo(id, auxInfo string) error {
-	return s.write(SetClientAuxInfo, id, auxInfo)
+	return s.write(SetClientAuxInfo, auxInfo, id)
 }
 
 func (s *Storage) getClients(query string, args ...interface{}) ([]Client, error) {
@@ -365,8 +420,9 @@ func (s *Storage) GetPlaygroundById(id string) ([]Playground, error) {
 }
 
 // audit
-func (s *Storage) InsertAuditLog(time time.Time, workDir, command string, status int) (int64, error) {
-	result, err := s.db.Write(InsertAuditLog, time, workDir, command, status)
+func (s *Storage) InsertAuditLog(auditLog AuditLog) (int64, error) {
+	result, err := s.db.Write(InsertAuditLog, auditLog.ExecuteTime, auditLog.WorkDirectory,
+		auditLog.Command, auditLog.Status, auditLog.Operator, auditLog.Source, auditLog.Cluster)
 	if err != nil {
 		return 0, err
 	}
@@ -378,6 +434,10 @@ func (s *Storage) SetAuditLogStatus(id int64, status, errorCode int) error {
 	return s.write(SetAuditLogStatus, status, errorCode, id)
 }
 
+func (s *Storage) SetAuditLogCluster(id int64, cluster string) error {
+	return s.write(SetAuditLogCluster, cluster, id)
+}
+
 func (s *Storage) getAuditLogs(query string, args ...interface{}) ([]AuditLog, error) {
 	result, err := s.db.Query(query, args...)
 	if err != nil {
@@ -393,7 +453,10 @@ func (s *Storage) getAuditLogs(query string, args ...interface{}) ([]AuditLog, e
 			&auditLog.WorkDirectory,
 			&auditLog.Command,
 			&auditLog.Status,
-			&auditLog.ErrorCode)
+			&auditLog.ErrorCode,
+			&auditLog.Operator,
+			&auditLog.Source,
+			&auditLog.Cluster)
 		if err != nil {
 			return nil, err
 		}
@@ -414,6 +477,7 @@ func (s *Storage) GetAuditLog(id int64) ([]AuditLog, error) {
 // any item prefix
 const (
 	PREFIX_CLIENT_CONFIG = 0x01
+	PREFIX_CHUNKSERVERS  = 0x02
 )
 
 func (s *Storage) realId(prefix int, id string) string {
@@ -451,6 +515,27 @@ func (s *Storage) DeleteClientConfig(id string) error {
 	return s.write(DeleteAnyItem, id)
 }
 
+// chunkservers which last seen in cluster, for completing chunkserver ids
+func (s *Storage) SetChunkservers(clusterId int, data string) error {
+	id := s.realId(PREFIX_CHUNKSERVERS, strconv.Itoa(clusterId))
+	return s.write(ReplaceAnyItem, id, data)
+}
+
+func (s *Storage) GetChunkservers(clusterId int) (string, error) {
+	id := s.realId(PREFIX_CHUNKSERVERS, strconv.Itoa(clusterId))
+	result, err := s.db.Query(SelectAnyItem, id)
+	if err != nil {
+		return "", err
+	}
+	defer result.Close()
+
+	var item Any
+	if result.Next() {
+		err = result.Scan(&item.Id, &item.Data)
+	}
+	return item.Data, err
+}
+
 func (s *Storage) GetMonitor(clusterId int) (Monitor, error) {
 	monitor := Monitor{
 		ClusterId: clusterId,
@@ -485,3 +570,499 @@ func (s *Storage) DeleteMonitor(clusterId int) error {
 func (s *Storage) ReplaceMonitor(m Monitor) error {
 	return s.write(ReplaceMonitor, m.ClusterId, m.Monitor)
 }
+
+// health sample
+func (s *Storage) InsertHealthSample(sample HealthSample) error {
+	return s.write(InsertHealthSample, sample.ClusterId, sample.Probe,
+		sample.Target, sample.Status, sample.Value, sample.SampleTime)
+}
+
+func (s *Storage) GetLatestHealthSamples(clusterId int) ([]HealthSample, error) {
+	result, err := s.db.Query(SelectLatestHealthSamples, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	samples := []HealthSample{}
+	var sample HealthSample
+	for result.Next() {
+		err = result.Scan(&sample.Id,
+			&sample.ClusterId,
+			&sample.Probe,
+			&sample.Target,
+			&sample.Status,
+			&sample.Value,
+			&sample.SampleTime)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, nil
+}
+
+func (s *Storage) DeleteHealthSamplesBefore(clusterId int, before time.Time) error {
+	return s.write(DeleteHealthSamplesBefore, clusterId, before)
+}
+
+// alert rules
+func (s *Storage) InsertAlertRules(clusterId int, rules string) error {
+	return s.write(InsertAlertRules, clusterId, rules)
+}
+
+func (s *Storage) GetAlertRules(clusterId int) ([]AlertRules, error) {
+	result, err := s.db.Query(SelectAlertRules, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	versions := []AlertRules{}
+	var rules AlertRules
+	for result.Next() {
+		err = result.Scan(&rules.Id, &rules.ClusterId, &rules.Rules, &rules.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, rules)
+	}
+
+	return versions, nil
+}
+
+// upgrade state
+func (s *Storage) SetUpgradeState(clusterId int, serviceId, image, status string) error {
+	return s.write(UpsertUpgradeState, clusterId, serviceId, image, status)
+}
+
+func (s *Storage) GetUpgradeStates(clusterId int) ([]UpgradeState, error) {
+	result, err := s.db.Query(SelectUpgradeStates, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	states := []UpgradeState{}
+	var state UpgradeState
+	for result.Next() {
+		err = result.Scan(&state.ClusterId,
+			&state.ServiceId,
+			&state.Image,
+			&state.Status,
+			&state.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+func (s *Storage) DeleteUpgradeStates(clusterId int) error {
+	return s.write(DeleteUpgradeStates, clusterId)
+}
+
+// canary
+func (s *Storage) InsertCanary(canary Canary) error {
+	return s.write(InsertCanary, canary.ClusterId, canary.ServiceId,
+		canary.OldImage, canary.NewImage)
+}
+
+func (s *Storage) GetCanaries(clusterId int) ([]Canary, error) {
+	result, err := s.db.Query(SelectCanaries, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	canaries := []Canary{}
+	var canary Canary
+	for result.Next() {
+		err = result.Scan(&canary.ClusterId,
+			&canary.ServiceId,
+			&canary.OldImage,
+			&canary.NewImage,
+			&canary.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		canaries = append(canaries, canary)
+	}
+
+	return canaries, nil
+}
+
+func (s *Storage) DeleteCanaries(clusterId int) error {
+	return s.write(DeleteCanaries, clusterId)
+}
+
+// previous image
+func (s *Storage) SetPreviousImage(image PreviousImage) error {
+	return s.write(ReplacePreviousImage, image.ClusterId, image.ServiceId,
+		image.Image, image.Digest, image.Config)
+}
+
+func (s *Storage) GetPreviousImages(clusterId int) ([]PreviousImage, error) {
+	result, err := s.db.Query(SelectPreviousImages, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	images := []PreviousImage{}
+	var image PreviousImage
+	for result.Next() {
+		err = result.Scan(&image.ClusterId,
+			&image.ServiceId,
+			&image.Image,
+			&image.Digest,
+			&image.Config,
+			&image.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	return images, nil
+}
+
+func (s *Storage) DeletePreviousImage(clusterId int, serviceId string) error {
+	return s.write(DeletePreviousImage, clusterId, serviceId)
+}
+
+// previous topology
+func (s *Storage) SetPreviousTopology(clusterId int, topology string) error {
+	return s.write(ReplacePreviousTopology, clusterId, topology)
+}
+
+func (s *Storage) GetPreviousTopologies(clusterId int) ([]PreviousTopology, error) {
+	result, err := s.db.Query(SelectPreviousTopology, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	topologies := []PreviousTopology{}
+	var topology PreviousTopology
+	for result.Next() {
+		err = result.Scan(&topology.ClusterId,
+			&topology.Topology,
+			&topology.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		topologies = append(topologies, topology)
+	}
+
+	return topologies, nil
+}
+
+// format progress
+func (s *Storage) SetFormatProgress(progress FormatProgress) error {
+	return s.write(ReplaceFormatProgress, progress.Host, progress.Device,
+		progress.MountPoint, progress.Percent, progress.Chunks, progress.Status)
+}
+
+func (s *Storage) GetFormatProgresses() ([]FormatProgress, error) {
+	return s.getFormatProgresses(SelectFormatProgresses)
+}
+
+// GetFormatProgressesByHosts selects progresses of all disks on hosts in one query
+func (s *Storage) GetFormatProgressesByHosts(hosts []string) ([]FormatProgress, error) {
+	if len(hosts) == 0 {
+		return []FormatProgress{}, nil
+	}
+
+	args := []interface{}{}
+	for _, host := range hosts {
+		args = append(args, host)
+	}
+	query := fmt.Sprintf(SelectFormatProgressesByHosts, placeholders(len(hosts)))
+	return s.getFormatProgresses(query, args...)
+}
+
+func (s *Storage) getFormatProgresses(query string, args ...interface{}) ([]FormatProgress, error) {
+	result, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	progresses := []FormatProgress{}
+	var progress FormatProgress
+	for result.Next() {
+		err = result.Scan(&progress.Host,
+			&progress.Device,
+			&progress.MountPoint,
+			&progress.Percent,
+			&progress.Chunks,
+			&progress.Status,
+			&progress.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		progresses = append(progresses, progress)
+	}
+
+	return progresses, nil
+}
+
+// benchmark
+func (s *Storage) InsertBenchmark(benchmark Benchmark) error {
+	return s.write(InsertBenchmark, benchmark.ClusterId, benchmark.Kind,
+		benchmark.Pattern, benchmark.Options, benchmark.Result)
+}
+
+func (s *Storage) GetBenchmarks(clusterId int) ([]Benchmark, error) {
+	result, err := s.db.Query(SelectBenchmarks, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	benchmarks := []Benchmark{}
+	var benchmark Benchmark
+	for result.Next() {
+		err = result.Scan(&benchmark.Id,
+			&benchmark.ClusterId,
+			&benchmark.Kind,
+			&benchmark.Pattern,
+			&benchmark.Options,
+			&benchmark.Result,
+			&benchmark.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		benchmarks = append(benchmarks, benchmark)
+	}
+
+	return benchmarks, nil
+}
+
+// host facts
+func (s *Storage) SetHostFacts(host, facts string) error {
+	return s.write(ReplaceHostFacts, host, facts)
+}
+
+func (s *Storage) GetHostFacts() ([]HostFacts, error) {
+	result, err := s.db.Query(SelectHostFacts)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	items := []HostFacts{}
+	var item HostFacts
+	for result.Next() {
+		err = result.Scan(&item.Host, &item.Facts, &item.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (s *Storage) DeleteHostFacts(host string) error {
+	return s.write(DeleteHostFacts, host)
+}
+
+// secrets
+func (s *Storage) SetSecrets(data string) error {
+	secretses, err := s.GetSecretses()
+	if err != nil {
+		return err
+	} else if len(secretses) == 0 {
+		return s.write(InsertSecrets, data)
+	}
+	return s.write(SetSecrets, data, secretses[0].Id)
+}
+
+func (s *Storage) GetSecretses() ([]Secrets, error) {
+	result, err := s.db.Query(SelectSecrets)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	var secretses []Secrets
+	var secrets Secrets
+	for result.Next() {
+		err = result.Scan(&secrets.Id, &secrets.Data, &secrets.LastModifiedTime)
+		secretses = append(secretses, secrets)
+		break
+	}
+	return secretses, err
+}
+
+// certificate
+func (s *Storage) SetCertificate(cert Certificate) error {
+	return s.write(ReplaceCertificate, cert.ClusterId, cert.Name, cert.Cert, cert.Key)
+}
+
+func (s *Storage) GetCertificates(clusterId int) ([]Certificate, error) {
+	result, err := s.db.Query(SelectCertificates, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	certs := []Certificate{}
+	var cert Certificate
+	for result.Next() {
+		err = result.Scan(&cert.ClusterId,
+			&cert.Name,
+			&cert.Cert,
+			&cert.Key,
+			&cert.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+func (s *Storage) DeleteCertificates(clusterId int) error {
+	return s.write(DeleteCertificates, clusterId)
+}
+
+// cluster protection
+func (s *Storage) InsertClusterProtection(clusterId int) error {
+	return s.write(InsertClusterProtection, clusterId)
+}
+
+func (s *Storage) SetClusterUnlockUntil(clusterId int, unlockUntil int64) error {
+	return s.write(SetClusterUnlockUntil, unlockUntil, clusterId)
+}
+
+func (s *Storage) getClusterProtections(query string, args ...interface{}) ([]ClusterProtection, error) {
+	result, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	protections := []ClusterProtection{}
+	var protection ClusterProtection
+	for result.Next() {
+		err = result.Scan(&protection.ClusterId,
+			&protection.UnlockUntil,
+			&protection.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		protections = append(protections, protection)
+	}
+
+	return protections, nil
+}
+
+func (s *Storage) GetClusterProtection(clusterId int) ([]ClusterProtection, error) {
+	return s.getClusterProtections(SelectClusterProtection, clusterId)
+}
+
+func (s *Storage) GetClusterProtections() ([]ClusterProtection, error) {
+	return s.getClusterProtections(SelectClusterProtections)
+}
+
+func (s *Storage) DeleteClusterProtection(clusterId int) error {
+	return s.write(DeleteClusterProtection, clusterId)
+}
+
+// operation duration
+func (s *Storage) InsertOperationDuration(d OperationDuration) error {
+	return s.write(InsertOperationDuration, d.ClusterId, d.Operation, d.Services, d.Duration)
+}
+
+// GetOperationDurations returns the latest n durations of operation, the latest first
+func (s *Storage) GetOperationDurations(clusterId int, operation string, n int) ([]OperationDuration, error) {
+	result, err := s.db.Query(SelectOperationDurations, clusterId, operation, n)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	durations := []OperationDuration{}
+	var d OperationDuration
+	for result.Next() {
+		err = result.Scan(&d.Id,
+			&d.ClusterId,
+			&d.Operation,
+			&d.Services,
+			&d.Duration,
+			&d.FinishTime)
+		if err != nil {
+			return nil, err
+		}
+		durations = append(durations, d)
+	}
+
+	return durations, nil
+}
+
+func (s *Storage) DeleteOperationDurations(clusterId int) error {
+	return s.write(DeleteOperationDurations, clusterId)
+}
+
+// backup schedule
+func (s *Storage) InsertBackupSchedule(schedule BackupSchedule) error {
+	return s.write(InsertBackupSchedule, schedule.ClusterId, schedule.Target,
+		schedule.Cron, schedule.Location, schedule.Keep)
+}
+
+// the run time is stored in local time as datetime('now','localtime') does
+func (s *Storage) SetBackupScheduleResult(id int, runTime time.Time, status, result string) error {
+	return s.write(SetBackupScheduleResult, runTime.Local().Format("2006-01-02 15:04:05"), status, result, id)
+}
+
+func (s *Storage) GetBackupSchedules(clusterId int) ([]BackupSchedule, error) {
+	result, err := s.db.Query(SelectBackupSchedules, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	schedules := []BackupSchedule{}
+	var schedule BackupSchedule
+	for result.Next() {
+		err = result.Scan(&schedule.Id,
+			&schedule.ClusterId,
+			&schedule.Target,
+			&schedule.Cron,
+			&schedule.Location,
+			&schedule.Keep,
+			&schedule.LastRunTime,
+			&schedule.LastStatus,
+			&schedule.LastResult,
+			&schedule.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+		// the local time without zone is parsed as UTC
+		t := schedule.LastRunTime
+		schedule.LastRunTime = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+func (s *Storage) DeleteBackupSchedule(id, clusterId int) error {
+	return s.write(DeleteBackupSchedule, id, clusterId)
+}
+
+func (s *Storage) DeleteBackupSchedules(clusterId int) error {
+	return s.write(DeleteBackupSchedules, clusterId)
+}
+
+// Backup copies the whole database into file which must not exist
+func (s *Storage) Backup(path string) error {
+	return s.write(BackupDatabase, path)
+}
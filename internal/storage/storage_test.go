@@ -0,0 +1,205 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package storage
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFormatProgressesByHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := NewStorage("sqlite://" + filepath.Join(t.TempDir(), "curveadm.db"))
+	assert.Nil(err)
+	for _, p := range []FormatProgress{
+		{Host: "host1", Device: "/dev/sda", MountPoint: "/data/chunkserver0"},
+		{Host: "host1", Device: "/dev/sdb", MountPoint: "/data/chunkserver1"},
+		{Host: "host2", Device: "/dev/sda", MountPoint: "/data/chunkserver0"},
+		{Host: "host3", Device: "/dev/sda", MountPoint: "/data/chunkserver0"},
+	} {
+		assert.Nil(s.SetFormatProgress(p))
+	}
+
+	progresses, err := s.GetFormatProgressesByHosts([]string{"host1", "host3"})
+	assert.Nil(err)
+	disks := []string{}
+	for _, p := range progresses {
+		disks = append(disks, p.Host+":"+p.Device)
+	}
+	sort.Strings(disks)
+	assert.Equal([]string{"host1:/dev/sda", "host1:/dev/sdb", "host3:/dev/sda"}, disks)
+
+	progresses, err = s.GetFormatProgressesByHosts(nil)
+	assert.Nil(err)
+	assert.Len(progresses, 0)
+
+	// prepared statements are reused across calls
+	progresses, err = s.GetFormatProgresses()
+	assert.Nil(err)
+	assert.Len(progresses, 4)
+	progresses, err = s.GetFormatProgresses()
+	assert.Nil(err)
+	assert.Len(progresses, 4)
+}
+
+func TestPlaceholders(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("?", placeholders(1))
+	assert.Equal("?, ?, ?", placeholders(3))
+}
+
+func TestGetOperationDurations(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := NewStorage("sqlite://" + filepath.Join(t.TempDir(), "curveadm.db"))
+	assert.Nil(err)
+	for _, d := range []OperationDuration{
+		{ClusterId: 1, Operation: "stop service", Services: 3, Duration: 1000},
+		{ClusterId: 1, Operation: "stop service", Services: 3, Duration: 2000},
+		{ClusterId: 1, Operation: "stop service", Services: 3, Duration: 3000},
+		{ClusterId: 1, Operation: "restart service", Services: 3, Duration: 4000},
+		{ClusterId: 2, Operation: "stop service", Services: 1, Duration: 5000},
+	} {
+		assert.Nil(s.InsertOperationDuration(d))
+	}
+
+	// the latest first
+	durations, err := s.GetOperationDurations(1, "stop service", 2)
+	assert.Nil(err)
+	assert.Len(durations, 2)
+	assert.Equal(int64(3000), durations[0].Duration)
+	assert.Equal(int64(2000), durations[1].Duration)
+
+	assert.Nil(s.DeleteOperationDurations(1))
+	durations, err = s.GetOperationDurations(1, "stop service", 2)
+	assert.Nil(err)
+	assert.Len(durations, 0)
+	durations, err = s.GetOperationDurations(2, "stop service", 2)
+	assert.Nil(err)
+	assert.Len(durations, 1)
+}
+
+func TestBackupSchedules(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	s, err := NewStorage("sqlite://" + filepath.Join(dir, "curveadm.db"))
+	assert.Nil(err)
+	for _, schedule := range []BackupSchedule{
+		{ClusterId: 1, Target: "etcd", Cron: "@daily", Keep: 7},
+		{ClusterId: 1, Target: "db", Cron: "0 2 * * *", Location: "/data/backup", Keep: 0},
+		{ClusterId: 2, Target: "mds", Cron: "@hourly", Keep: 24},
+	} {
+		assert.Nil(s.InsertBackupSchedule(schedule))
+	}
+
+	schedules, err := s.GetBackupSchedules(1)
+	assert.Nil(err)
+	assert.Len(schedules, 2)
+	assert.Equal("etcd", schedules[0].Target)
+	assert.Equal("/data/backup", schedules[1].Location)
+	assert.WithinDuration(time.Now(), schedules[0].LastRunTime, time.Minute)
+
+	runTime := time.Date(2023, 10, 16, 2, 0, 0, 0, time.Local)
+	assert.Nil(s.SetBackupScheduleResult(schedules[0].Id, runTime, "SUCCESS", "/backup/etcd.db"))
+	schedules, err = s.GetBackupSchedules(1)
+	assert.Nil(err)
+	assert.True(runTime.Equal(schedules[0].LastRunTime))
+	assert.Equal("SUCCESS", schedules[0].LastStatus)
+
+	// schedule of other cluster is untouched
+	assert.Nil(s.DeleteBackupSchedule(schedules[0].Id, 2))
+	assert.Nil(s.DeleteBackupSchedule(schedules[0].Id, 1))
+	schedules, err = s.GetBackupSchedules(1)
+	assert.Nil(err)
+	assert.Len(schedules, 1)
+	assert.Nil(s.DeleteBackupSchedules(2))
+	schedules, err = s.GetBackupSchedules(2)
+	assert.Nil(err)
+	assert.Len(schedules, 0)
+
+	// backup database
+	backupPath := filepath.Join(dir, "backup.db")
+	assert.Nil(s.Backup(backupPath))
+	b, err := NewStorage("sqlite://" + backupPath)
+	assert.Nil(err)
+	schedules, err = b.GetBackupSchedules(1)
+	assert.Nil(err)
+	assert.Len(schedules, 1)
+}
+
+func TestClientAuxInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := NewStorage("sqlite://" + filepath.Join(t.TempDir(), "curveadm.db"))
+	assert.Nil(err)
+	assert.Nil(s.InsertClient("c1", "curvebs", "host1", "container1", ""))
+	assert.Nil(s.InsertClient("c2", "curvebs", "host2", "container2", ""))
+	assert.Nil(s.SetClientAuxInfo("c1", `{"device":"/dev/nbd0"}`))
+
+	clients, err := s.GetClient("c1")
+	assert.Nil(err)
+	assert.Len(clients, 1)
+	assert.Equal(`{"device":"/dev/nbd0"}`, clients[0].AuxInfo)
+	clients, err = s.GetClient("c2")
+	assert.Nil(err)
+	assert.Len(clients, 1)
+	assert.Equal("", clients[0].AuxInfo)
+}
+
+func TestPreviousImages(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := NewStorage("sqlite://" + filepath.Join(t.TempDir(), "curveadm.db"))
+	assert.Nil(err)
+	assert.Nil(s.SetPreviousImage(PreviousImage{
+		ClusterId: 1,
+		ServiceId: "c9570c0d",
+		Image:     "opencurvedocker/curvebs:v1.2",
+		Digest:    "sha256:3e1c",
+		Config:    `{"/curvebs/mds/conf/mds.conf":"mds.listen.addr=10.0.0.1:6700\n"}`,
+	}))
+
+	// replace the previous image of same service
+	assert.Nil(s.SetPreviousImage(PreviousImage{
+		ClusterId: 1,
+		ServiceId: "c9570c0d",
+		Image:     "opencurvedocker/curvebs:v1.1",
+		Config:    "{}",
+	}))
+	images, err := s.GetPreviousImages(1)
+	assert.Nil(err)
+	assert.Len(images, 1)
+	assert.Equal("opencurvedocker/curvebs:v1.1", images[0].Image)
+	assert.Equal("{}", images[0].Config)
+
+	assert.Nil(s.DeletePreviousImage(1, "c9570c0d"))
+	images, err = s.GetPreviousImages(1)
+	assert.Nil(err)
+	assert.Len(images, 0)
+}
@@ -29,27 +29,29 @@ import (
 )
 
 type Context struct {
-	sshClient *module.SSHClient
+	transport module.Transport
 	module    *module.Module
 	register  *Register
+	progress  *Progress
 }
 
-func NewContext(sshClient *module.SSHClient) (*Context, error) {
+func NewContext(transport module.Transport) (*Context, error) {
 	return &Context{
-		sshClient: sshClient,
-		module:    module.NewModule(sshClient),
+		transport: transport,
+		module:    module.NewModule(transport),
 		register:  NewRegister(),
+		progress:  NewProgress(),
 	}, nil
 }
 
 func (ctx *Context) Close() {
-	if ctx.sshClient != nil {
-		ctx.sshClient.Client().Close()
+	if ctx.transport != nil {
+		ctx.transport.Close()
 	}
 }
 
-func (ctx *Context) SSHClient() *module.SSHClient {
-	return ctx.sshClient
+func (ctx *Context) Transport() module.Transport {
+	return ctx.transport
 }
 
 func (ctx *Context) Module() *module.Module {
@@ -59,3 +61,12 @@ func (ctx *Context) Module() *module.Module {
 func (ctx *Context) Register() *Register {
 	return ctx.register
 }
+
+func (ctx *Context) Progress() *Progress {
+	return ctx.progress
+}
+
+// report progress to specified one instead of the context owned
+func (ctx *Context) BindProgress(progress *Progress) {
+	ctx.progress = progress
+}
@@ -0,0 +1,51 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package context
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Progress is the message which step reported while executing,
+// it will be displayed behind the sub bar of task.
+type Progress struct {
+	sync.RWMutex
+	message string
+}
+
+func NewProgress() *Progress {
+	return &Progress{}
+}
+
+func (p *Progress) Set(format string, a ...interface{}) {
+	p.Lock()
+	defer p.Unlock()
+	p.message = fmt.Sprintf(format, a...)
+}
+
+func (p *Progress) Get() string {
+	p.RLock()
+	defer p.RUnlock()
+	return p.message
+}
@@ -27,6 +27,9 @@ global:
   scrape_interval: 3s
   evaluation_interval: 15s
 
+rule_files:
+  - 'rules/*.yml'
+
 scrape_configs:
   - job_name: 'prometheus'
     static_configs:
@@ -35,20 +38,63 @@ scrape_configs:
   - job_name: 'curve_metrics'
     file_sd_configs:
     - files: ['target.json']
-
+%s
   - job_name: 'node'
     static_configs:
       - targets: %s
 `
 
+// the client certificate for scraping etcd with TLS, installed by curveadm
+var PROMETHEUS_TLS_CONFIG = `    tls_config:
+      ca_file: /etc/prometheus/tls/ca.crt
+      cert_file: /etc/prometheus/tls/client.crt
+      key_file: /etc/prometheus/tls/client.key
+`
+
+// the default alert rules for curve cluster, which can be overridden by
+// "curveadm monitor alerts apply"
+var CURVE_ALERT_RULES = `
+groups:
+- name: curve
+  rules:
+  - alert: ChunkserverDown
+    expr: up{job="chunkserver"} == 0
+    for: 1m
+    labels:
+      severity: critical
+    annotations:
+      summary: 'chunkserver {{ $labels.instance }} is down'
+  - alert: CopysetUnhealthy
+    expr: sum(mds_scheduler_metric_add_peer_num) > 0
+    for: 10m
+    labels:
+      severity: warning
+    annotations:
+      summary: 'copysets are still recovering after 10 minutes'
+  - alert: DiskFull
+    expr: (node_filesystem_avail_bytes{fstype!~"tmpfs|overlay"} / node_filesystem_size_bytes) < 0.1
+    for: 5m
+    labels:
+      severity: critical
+    annotations:
+      summary: 'disk {{ $labels.mountpoint }} of {{ $labels.instance }} is almost full'
+  - alert: ReplacementStuck
+    expr: sum(mds_scheduler_metric_operator_num) > 0
+    for: 1h
+    labels:
+      severity: warning
+    annotations:
+      summary: 'scheduler operators not finished for 1 hour, replacement may be stuck'
+`
+
 var GRAFANA_DATA_SOURCE = `
 datasources:
 - name: 'Prometheus'
   type: 'prometheus'
   access: 'proxy'
   org_id: 1
-  url: 'http://%s:%d'
+  url: 'http://%s'
   is_default: true
   version: 1
   editable: true
-`
\ No newline at end of file
+`
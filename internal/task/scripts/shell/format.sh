@@ -10,10 +10,16 @@ chunkfile_size=$3
 chunkfile_pool_dir=$4
 chunkfile_pool_meta_path=$5
 chunkfile_block_size=$6
+append_num=$7 # only append chunks for expanding chunkfile pool
+
+allocate="-allocatePercent=$percent"
+if [ -n "$append_num" ]; then
+  allocate="-allocateByPercent=false -preAllocateNum=$append_num"
+fi
 
 mkdir -p $chunkfile_pool_dir
 $binary \
-  -allocatePercent=$percent \
+  $allocate \
   -fileSize=$chunkfile_size \
   -filePoolDir=$chunkfile_pool_dir \
   -filePoolMetaPath=$chunkfile_pool_meta_path \
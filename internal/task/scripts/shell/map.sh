@@ -18,5 +18,5 @@ if [ $? -ne 0 ]; then
   cat ${g_stderr}
   exit 1
 else
-  echo "SUCCESS"
+  echo "SUCCESS $(grep -o '/dev/nbd[0-9]*' ${g_stderr} | tail -n 1)"
 fi
@@ -25,8 +25,16 @@
 package step
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/internal/verify"
 	"github.com/opencurve/curveadm/pkg/module"
 )
 
@@ -43,6 +51,24 @@ type (
 		module.ExecOptions
 	}
 
+	SaveImage struct {
+		Images []string
+		Output string
+		Out    *string
+		module.ExecOptions
+	}
+
+	/*
+	 * LoadImage loads image from local archive (exported by `curveadm artifacts
+	 * export`) into remote host, it will be skipped if the image already exist
+	 */
+	LoadImage struct {
+		Image   string
+		Archive string // local path of image archive
+		Out     *string
+		module.ExecOptions
+	}
+
 	Volume struct { // bind mount a volume
 		HostPath      string
 		ContainerPath string
@@ -52,12 +78,17 @@ type (
 		Image             string
 		Command           string
 		AddHost           []string
+		CgroupParent      string
+		Cpus              string // number of CPUs, e.g: 2.5
+		CpusetCpus        string // CPUs in which to allow execution, e.g: 0-3,8
 		Devices           []string
 		Entrypoint        string
 		Envs              []string
 		Hostname          string
 		Init              bool
+		IP                string // static ip in user-defined network
 		LinuxCapabilities []string
+		Memory            string // memory limit, e.g: 8g
 		Mount             string
 		Name              string
 		Network           string
@@ -70,6 +101,7 @@ type (
 		SecurityOptions   []string
 		Ulimits           []string
 		Volumes           []Volume
+		IgnoreExisted     bool // reuse the container which has the same name, image and command
 		Out               *string
 		module.ExecOptions
 	}
@@ -121,6 +153,7 @@ type (
 		Command     string
 		Success     *bool
 		Out         *string
+		Writer      io.Writer // stream output into it instead of Out, e.g. dump of ops tool
 		module.ExecOptions
 	}
 
@@ -150,10 +183,29 @@ type (
 
 	ContainerLogs struct {
 		ContainerId string
+		Since       string // e.g: 1h, 2023-08-26T10:00:00Z
+		Tail        int    // all logs if <= 0
+		Timestamps  bool   // prefix each line with RFC3339Nano timestamp
 		Out         *string
+		Writer      io.Writer // stream logs into it instead of Out, logs maybe gigabytes
 		Success     *bool
 		module.ExecOptions
 	}
+
+	CreateNetwork struct {
+		Name    string
+		Subnet  string // e.g: 172.30.0.0/24
+		Out     *string
+		Success *bool
+		module.ExecOptions
+	}
+
+	RemoveNetwork struct {
+		Name    string
+		Out     *string
+		Success *bool
+		module.ExecOptions
+	}
 )
 
 func (s *EngineInfo) Execute(ctx *context.Context) error {
@@ -162,17 +214,224 @@ func (s *EngineInfo) Execute(ctx *context.Context) error {
 	return PostHandle(s.Success, s.Out, out, err, errno.ERR_GET_CONTAINER_ENGINE_INFO_FAILED.FD("(%s info)", s.ExecWithEngine))
 }
 
+/*
+ * configureDaemonProxy writes the proxy of host into systemd drop-in of docker,
+ * the daemon is restarted to take it effect only if no container running in the
+ * host, otherwise restarting is left to user because it stops the containers.
+ */
+func configureDaemonProxy(ctx *context.Context, image string, options module.ExecOptions) error {
+	content := ctx.Module().DockerCli().HTTPProxy().SystemdDropIn()
+	dir := path.Dir(module.DOCKER_PROXY_DROP_IN)
+	out, err := ctx.Module().Shell().Mkdir(dir).AddOption("-p").Execute(options)
+	if err != nil {
+		return errno.ERR_CREATE_DIRECTORY_FAILED.S(out).E(err)
+	}
+	err = (&InstallFile{
+		Content:      &content,
+		HostDestPath: module.DOCKER_PROXY_DROP_IN,
+		ExecOptions:  options,
+	}).Execute(ctx)
+	if err != nil {
+		return err
+	}
+	out, err = ctx.Module().Shell().Systemctl("daemon-reload").Execute(options)
+	if err != nil {
+		return errno.ERR_SET_DAEMON_PROXY_FAILED.S(out).E(err)
+	}
+
+	out, err = ctx.Module().DockerCli().ListContainers().AddOption("--quiet").Execute(options)
+	if err != nil {
+		return errno.ERR_LIST_CONTAINERS_FAILED.S(out).E(err)
+	} else if len(strings.TrimSpace(out)) > 0 {
+		return errno.ERR_SET_DAEMON_PROXY_FAILED.
+			F("%s: proxy of docker daemon is written into %s, but containers are running, "+
+				"please restart docker to take effect", image, module.DOCKER_PROXY_DROP_IN)
+	}
+	out, err = ctx.Module().Shell().Systemctl("restart", "docker").Execute(options)
+	if err != nil {
+		return errno.ERR_SET_DAEMON_PROXY_FAILED.S(out).E(err)
+	}
+	return nil
+}
+
 func (s *PullImage) Execute(ctx *context.Context) error {
-	cli := ctx.Module().DockerCli().PullImage(s.Image)
+	// verify signature of image in curveadm machine before pulled into hosts,
+	// and pull the digest which verified rather than the tag
+	image, err := verify.Globals().VerifyImage(s.Image)
+	if err != nil {
+		return err
+	}
+
+	// the proxy injected into CLI takes no effect for docker daemon
+	if ctx.Module().DockerCli().DaemonProxyMissing(s.ExecOptions) {
+		err = configureDaemonProxy(ctx, s.Image, s.ExecOptions)
+		if err != nil {
+			return err
+		}
+	}
+
+	options := s.ExecOptions
+	options.ExecWithProxy = true
+	cli := ctx.Module().DockerCli().PullImage(image)
+	out, err := cli.Execute(options)
+	err = PostHandle(nil, s.Out, out, err, errno.ERR_PULL_IMAGE_FAILED.FD("(%s pull IMAGE)", s.ExecWithEngine))
+	if err != nil || image == s.Image {
+		return err
+	}
+
+	// the container is created by the image name in topology
+	out, err = ctx.Module().DockerCli().TagImage(image, s.Image).Execute(s.ExecOptions)
+	return PostHandle(nil, nil, out, err, errno.ERR_TAG_IMAGE_FAILED.FD("(%s tag IMAGE)", s.ExecWithEngine))
+}
+
+func (s *SaveImage) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().SaveImage(s.Images...)
+	cli.AddOption("--output %s", s.Output)
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_SAVE_IMAGE_FAILED.FD("(%s save IMAGE)", s.ExecWithEngine))
+}
+
+func (s *LoadImage) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().InspectImage(s.Image)
+	if _, err := cli.Execute(s.ExecOptions); err == nil {
+		return nil // image already exist
+	} else if !utils.PathExist(s.Archive) {
+		return errno.ERR_IMAGE_ARCHIVE_NOT_FOUND.F("archive: %s", s.Archive)
+	}
+
+	// (1) upload archive into remote temporary directory, every task has
+	//     its own archive because the tasks on the same host (e.g. format
+	//     disks) load images at the same time
+	remotePath := loadImageArchivePath()
+	upload := &UploadFile{
+		LocalPath:   s.Archive,
+		RemotePath:  remotePath,
+		Verify:      true,
+		ExecOptions: s.ExecOptions,
+	}
+	if err := upload.Execute(ctx); err != nil {
+		ctx.Module().Shell().Remove(partialFilePath(remotePath)).Execute(s.ExecOptions)
+		return err
+	}
+
+	// (2) load images from archive and remove it
+	cli = ctx.Module().DockerCli().LoadImage()
+	cli.AddOption("--input %s", remotePath)
+	out, err := cli.Execute(s.ExecOptions)
+	err = PostHandle(nil, s.Out, out, err, errno.ERR_LOAD_IMAGE_FAILED.FD("(%s load IMAGE)", s.ExecWithEngine))
+	if err != nil {
+		ctx.Module().Shell().Remove(remotePath).Execute(s.ExecOptions)
+		return err
+	}
+	out, err = ctx.Module().Shell().Remove(remotePath).Execute(s.ExecOptions)
+	return PostHandle(nil, nil, out, err, errno.ERR_REMOVE_FILES_OR_DIRECTORIES_FAILED)
+}
+
+func loadImageArchivePath() string {
+	return fmt.Sprintf("%s/curveadm-images-%s.tar", TEMP_DIR, utils.RandString(12))
+}
+
+// find the container which has the same name, return its id if exist
+func (s *CreateContainer) existed(ctx *context.Context) (string, bool) {
+	if len(s.Name) == 0 {
+		return "", false
+	}
+
+	cli := ctx.Module().DockerCli().ListContainers()
+	cli.AddOption("--all")
+	cli.AddOption("--quiet")
+	cli.AddOption("--no-trunc")
+	cli.AddOption("--filter name='^/?%s$'", s.Name)
+	out, err := cli.Execute(s.ExecOptions)
+	if err != nil {
+		return "", false
+	}
+
+	out = strings.TrimSpace(out)
+	if len(out) == 0 || strings.Contains(out, "\n") {
+		return "", false
+	}
+	return out, true
+}
+
+// stringList is the entrypoint or command of container, podman < 4.0 reports entrypoint as string
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*l = strings.Fields(s)
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// normalizeCommand makes command line comparable with the arguments which split by shell
+func normalizeCommand(command string) string {
+	command = strings.NewReplacer("'", "", "\"", "").Replace(command)
+	return strings.Join(strings.Fields(command), " ")
+}
+
+// matched returns true if the existed container created by the same image, entrypoint and command,
+// the entrypoint or command is ignored if not specified, it's inherited from image
+func (s *CreateContainer) matched(ctx *context.Context, containerId string) bool {
+	cli := ctx.Module().DockerCli().InspectContainer(containerId)
+	cli.AddOption("--format '{{json .Config}}'")
 	out, err := cli.Execute(s.ExecOptions)
-	return PostHandle(nil, s.Out, out, err, errno.ERR_PULL_IMAGE_FAILED.FD("(%s pull IMAGE)", s.ExecWithEngine))
+	if err != nil {
+		return false
+	}
+
+	config := struct {
+		Image      string
+		Entrypoint stringList
+		Cmd        stringList
+	}{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &config); err != nil {
+		return false
+	} else if config.Image != s.Image {
+		return false
+	} else if len(s.Entrypoint) > 0 &&
+		normalizeCommand(strings.Join(config.Entrypoint, " ")) != normalizeCommand(s.Entrypoint) {
+		return false
+	} else if len(s.Command) > 0 &&
+		normalizeCommand(strings.Join(config.Cmd, " ")) != normalizeCommand(s.Command) {
+		return false
+	}
+	return true
 }
 
 func (s *CreateContainer) Execute(ctx *context.Context) error {
+	if s.IgnoreExisted {
+		if containerId, ok := s.existed(ctx); ok {
+			if s.matched(ctx, containerId) {
+				return PostHandle(nil, s.Out, containerId, nil, nil)
+			}
+
+			// the image or command changed, recreate it
+			err := (&RemoveContainer{
+				ContainerId: containerId,
+				ExecOptions: s.ExecOptions,
+			}).Execute(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	cli := ctx.Module().DockerCli().CreateContainer(s.Image, s.Command)
 	for _, host := range s.AddHost {
 		cli.AddOption("--add-host %s", host)
 	}
+	if len(s.CgroupParent) > 0 {
+		cli.AddOption("--cgroup-parent %s", s.CgroupParent)
+	}
+	if len(s.Cpus) > 0 {
+		cli.AddOption("--cpus %s", s.Cpus)
+	}
+	if len(s.CpusetCpus) > 0 {
+		cli.AddOption("--cpuset-cpus %s", s.CpusetCpus)
+	}
 	for _, device := range s.Devices {
 		cli.AddOption("--device %s", device)
 	}
@@ -191,6 +450,9 @@ func (s *CreateContainer) Execute(ctx *context.Context) error {
 	for _, capability := range s.LinuxCapabilities {
 		cli.AddOption("--cap-add %s", capability)
 	}
+	if len(s.Memory) > 0 {
+		cli.AddOption("--memory %s", s.Memory)
+	}
 	if len(s.Mount) > 0 {
 		cli.AddOption("--mount %s", s.Mount)
 	}
@@ -202,6 +464,9 @@ func (s *CreateContainer) Execute(ctx *context.Context) error {
 	} else {
 		cli.AddOption("--network host")
 	}
+	if len(s.IP) > 0 {
+		cli.AddOption("--ip %s", s.IP)
+	}
 	if len(s.Pid) > 0 {
 		cli.AddOption("--pid %s", s.Pid)
 	}
@@ -289,8 +554,12 @@ func (s *ListContainers) Execute(ctx *context.Context) error {
 
 func (s *ContainerExec) Execute(ctx *context.Context) error {
 	cli := ctx.Module().DockerCli().ContainerExec(*s.ContainerId, s.Command)
+	ec := errno.ERR_RUN_COMMAND_IN_CONTAINER_FAILED.FD("(%s exec CONTAINER COMMAND)", s.ExecWithEngine)
+	if s.Writer != nil {
+		return PostHandle(s.Success, nil, "", cli.ExecuteStream(s.ExecOptions, s.Writer), ec)
+	}
 	out, err := cli.Execute(s.ExecOptions)
-	return PostHandle(s.Success, s.Out, out, err, errno.ERR_RUN_COMMAND_IN_CONTAINER_FAILED.FD("(%s exec CONTAINER COMMAND)", s.ExecWithEngine))
+	return PostHandle(s.Success, s.Out, out, err, ec)
 }
 
 func (s *CopyFromContainer) Execute(ctx *context.Context) error {
@@ -317,6 +586,34 @@ func (s *InspectContainer) Execute(ctx *context.Context) error {
 
 func (s *ContainerLogs) Execute(ctx *context.Context) error {
 	cli := ctx.Module().DockerCli().ContainerLogs(s.ContainerId)
+	if len(s.Since) > 0 {
+		cli.AddOption("--since %s", s.Since)
+	}
+	if s.Tail > 0 {
+		cli.AddOption("--tail %d", s.Tail)
+	}
+	if s.Timestamps {
+		cli.AddOption("--timestamps")
+	}
+	ec := errno.ERR_GET_CONTAINER_LOGS_FAILED.FD("(%s logs ID)", s.ExecWithEngine)
+	if s.Writer != nil {
+		return PostHandle(s.Success, nil, "", cli.ExecuteStream(s.ExecOptions, s.Writer), ec)
+	}
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, ec)
+}
+
+func (s *CreateNetwork) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().CreateNetwork(s.Name)
+	if len(s.Subnet) > 0 {
+		cli.AddOption("--subnet %s", s.Subnet)
+	}
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_CREATE_NETWORK_FAILED.FD("(%s network create NAME)", s.ExecWithEngine))
+}
+
+func (s *RemoveNetwork) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().RemoveNetwork(s.Name)
 	out, err := cli.Execute(s.ExecOptions)
-	return PostHandle(s.Success, s.Out, out, err, errno.ERR_GET_CONTAINER_LOGS_FAILED.FD("(%s logs ID)", s.ExecWithEngine))
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_REMOVE_NETWORK_FAILED.FD("(%s network rm NAME)", s.ExecWithEngine))
 }
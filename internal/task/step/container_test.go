@@ -0,0 +1,124 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package step
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateContainerIgnoreExisted(t *testing.T) {
+	assert := assert.New(t)
+
+	newStep := func(containerId *string) *CreateContainer {
+		return &CreateContainer{
+			Image:         "opencurvedocker/curvebs:v1.2",
+			Command:       "--role chunkserver --args='-a=1 -b=2'",
+			Name:          "curvebs-chunkserver-1",
+			IgnoreExisted: true,
+			Out:           containerId,
+			ExecOptions:   module.ExecOptions{ExecWithEngine: "docker"},
+		}
+	}
+	tests := []struct {
+		config   string // output of inspect
+		reused   bool
+		commands []string // prefix of commands executed after inspect
+	}{
+		{
+			config: `{"Image":"opencurvedocker/curvebs:v1.2","Entrypoint":["/entrypoint.sh"],"Cmd":["--role","chunkserver","--args=-a=1 -b=2"]}`,
+			reused: true,
+		},
+		{ // image changed
+			config:   `{"Image":"opencurvedocker/curvebs:v1.1","Entrypoint":["/entrypoint.sh"],"Cmd":["--role","chunkserver","--args=-a=1 -b=2"]}`,
+			commands: []string{"docker rm", "docker create"},
+		},
+		{ // command changed
+			config:   `{"Image":"opencurvedocker/curvebs:v1.2","Entrypoint":"/entrypoint.sh","Cmd":["--role","chunkserver","--args=-a=1"]}`,
+			commands: []string{"docker rm", "docker create"},
+		},
+	}
+	for _, tt := range tests {
+		var containerId string
+		ctx, transport := newFakeContext(t, map[string]string{})
+		transport.outputs["docker ps"] = "c0ffee\n"
+		transport.outputs["docker inspect"] = tt.config + "\n"
+		err := newStep(&containerId).Execute(ctx)
+		assert.Nil(err)
+		assert.Len(transport.commands, 2+len(tt.commands))
+		for i, prefix := range tt.commands {
+			assert.True(strings.HasPrefix(transport.commands[2+i], prefix), transport.commands[2+i])
+		}
+		if tt.reused {
+			assert.Equal("c0ffee", containerId)
+		}
+	}
+
+	// not existed
+	ctx, transport := newFakeContext(t, map[string]string{})
+	err := newStep(nil).Execute(ctx)
+	assert.Nil(err)
+	assert.Len(transport.commands, 2)
+	assert.True(strings.HasPrefix(transport.commands[1], "docker create"))
+}
+
+func TestNormalizeCommand(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("--role mds --args=-a=1 -b=2", normalizeCommand("--role mds  --args='-a=1 -b=2'"))
+	assert.Equal("/bin/bash", normalizeCommand(" /bin/bash "))
+}
+
+func TestLoadImage(t *testing.T) {
+	assert := assert.New(t)
+
+	// image already exist
+	ctx, transport := newFakeContext(t, map[string]string{})
+	err := (&LoadImage{
+		Image:       "opencurvedocker/curvebs:v1.2",
+		Archive:     "/not/exist/images.tar",
+		ExecOptions: module.ExecOptions{ExecWithEngine: "docker"},
+	}).Execute(ctx)
+	assert.Nil(err)
+	assert.Len(transport.commands, 1)
+
+	// image not exist and archive not found
+	ctx, _ = newFakeContext(t, map[string]string{"docker image inspect": ""})
+	err = (&LoadImage{
+		Image:       "opencurvedocker/curvebs:v1.2",
+		Archive:     "/not/exist/images.tar",
+		ExecOptions: module.ExecOptions{ExecWithEngine: "docker"},
+	}).Execute(ctx)
+	assert.NotNil(err)
+
+	// tasks on the same host never share the uploaded archive
+	paths := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		path := loadImageArchivePath()
+		assert.True(strings.HasPrefix(path, TEMP_DIR+"/curveadm-images-"))
+		paths[path] = true
+	}
+	assert.Len(paths, 100)
+}
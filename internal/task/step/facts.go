@@ -0,0 +1,283 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package step
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	FACT_OS               = "os"
+	FACT_OS_VERSION       = "os_version"
+	FACT_KERNEL_RELEASE   = "kernel"
+	FACT_ARCH             = "arch"
+	FACT_CPUS             = "cpus"
+	FACT_CPU_MODEL        = "cpu_model"
+	FACT_MEMORY           = "memory" // KiB
+	FACT_CONTAINER_ENGINE = "engine"
+	FACT_BLOCK_DEVICES    = "disks" // JSON output of lsblk
+	FACT_NICS             = "nics"  // e.g: eth0:10000 eth1:-1, speed in Mb/s
+
+	// all facts are gathered by one command, which save lots of SSH round-trips
+	TEMPLATE_GATHER_FACTS = `bash -c '` +
+		`. /etc/os-release 2>/dev/null; ` +
+		`echo "os=$ID"; ` +
+		`echo "os_version=$VERSION_ID"; ` +
+		`echo "kernel=$(uname -r)"; ` +
+		`echo "arch=$(uname -m)"; ` +
+		`echo "cpus=$(nproc)"; ` +
+		`echo "cpu_model=$(grep -m1 "^model name" /proc/cpuinfo | cut -d: -f2)"; ` +
+		`grep ^MemTotal: /proc/meminfo | sed s/^MemTotal:/memory=/; ` +
+		`echo "engine=$(%s --version 2>/dev/null)"; ` +
+		`echo "disks=$(lsblk --json --bytes --nodeps --output NAME,SIZE,TYPE,ROTA 2>/dev/null | tr -d "\n")"; ` +
+		`echo "nics=$(for nic in /sys/class/net/*; do [ -e $nic/device ] && echo -n "${nic##*/}:$(cat $nic/speed 2>/dev/null || echo -1) "; done)"` +
+		`'`
+)
+
+type (
+	BlockDevice struct {
+		Name       string
+		Size       uint64 // bytes
+		Type       string
+		Rotational bool
+	}
+
+	// physical network interface, the speed is -1 if unknown (e.g: link down)
+	NIC struct {
+		Name  string
+		Speed int // Mb/s
+	}
+
+	HostFacts struct {
+		OS              string
+		OSVersion       string
+		KernelRelease   string
+		Arch            string
+		CPUs            int
+		CPUModel        string
+		Memory          uint64 // KiB
+		ContainerEngine string // e.g: Docker version 20.10.7, build f0df350
+		BlockDevices    []BlockDevice
+		NICs            []NIC
+	}
+
+	// facts of one host, which gathered only once in one run
+	cachedFacts struct {
+		sync.Mutex
+		gathered bool
+		facts    HostFacts
+		err      error
+	}
+
+	// the facts will be cached in memory storage if it specified,
+	// so that all tasks for the same host only gather facts once
+	GatherFacts struct {
+		MemStorage *utils.SafeMap
+		Out        *HostFacts
+		module.ExecOptions
+	}
+)
+
+func (f *HostFacts) GetBlockDevice(name string) (BlockDevice, bool) {
+	name = strings.TrimPrefix(name, "/dev/")
+	for _, device := range f.BlockDevices {
+		if device.Name == name {
+			return device, true
+		}
+	}
+	return BlockDevice{}, false
+}
+
+func (f *HostFacts) HasContainerEngine() bool {
+	return len(f.ContainerEngine) > 0
+}
+
+// MaxNICSpeed returns the speed of fastest NIC, 0 if unknown
+func (f *HostFacts) MaxNICSpeed() int {
+	speed := 0
+	for _, nic := range f.NICs {
+		if nic.Speed > speed {
+			speed = nic.Speed
+		}
+	}
+	return speed
+}
+
+func parseNICs(value string) []NIC {
+	nics := []NIC{}
+	for _, item := range strings.Fields(value) {
+		items := strings.SplitN(item, ":", 2)
+		if len(items) != 2 {
+			continue
+		}
+		speed, err := strconv.Atoi(items[1])
+		if err != nil {
+			speed = -1
+		}
+		nics = append(nics, NIC{Name: items[0], Speed: speed})
+	}
+	return nics
+}
+
+func parseBlockDevices(value string) []BlockDevice {
+	devices := []BlockDevice{}
+	output := LsblkOutput{}
+	if err := json.Unmarshal([]byte(value), &output); err != nil {
+		return devices
+	}
+
+	for _, device := range output.BlockDevices {
+		devices = append(devices, BlockDevice{
+			Name:       device.Name,
+			Size:       uint64(device.Size),
+			Type:       device.Type,
+			Rotational: bool(device.Rotational),
+		})
+	}
+	return devices
+}
+
+func ParseHostFacts(out string) *HostFacts {
+	facts := &HostFacts{BlockDevices: []BlockDevice{}, NICs: []NIC{}}
+	for _, line := range strings.Split(out, "\n") {
+		items := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(items) != 2 {
+			continue
+		}
+
+		key, value := items[0], strings.TrimSpace(items[1])
+		switch key {
+		case FACT_OS:
+			facts.OS = value
+		case FACT_OS_VERSION:
+			facts.OSVersion = strings.Trim(value, "\"")
+		case FACT_KERNEL_RELEASE:
+			facts.KernelRelease = value
+		case FACT_ARCH:
+			facts.Arch = value
+		case FACT_CPUS:
+			facts.CPUs, _ = strconv.Atoi(value)
+		case FACT_CPU_MODEL:
+			facts.CPUModel = value
+		case FACT_MEMORY: // e.g: 16318412 kB
+			if fields := strings.Fields(value); len(fields) > 0 {
+				facts.Memory, _ = strconv.ParseUint(fields[0], 10, 64)
+			}
+		case FACT_CONTAINER_ENGINE:
+			facts.ContainerEngine = value
+		case FACT_BLOCK_DEVICES:
+			facts.BlockDevices = parseBlockDevices(value)
+		case FACT_NICS:
+			facts.NICs = parseNICs(value)
+		}
+	}
+	return facts
+}
+
+func (s *GatherFacts) gather(ctx *context.Context) (*HostFacts, error) {
+	engine := module.GetContainerEngine(ctx.Transport(), s.ExecOptions).Binary()
+	cmd := ctx.Module().Shell().Command(fmt.Sprintf(TEMPLATE_GATHER_FACTS, engine))
+	out, err := cmd.Execute(s.ExecOptions)
+	if err != nil {
+		return nil, errno.ERR_GATHER_HOST_FACTS_FAILED.S(out)
+	}
+
+	facts := ParseHostFacts(out)
+	if len(facts.KernelRelease) == 0 {
+		return nil, errno.ERR_GATHER_HOST_FACTS_FAILED.
+			F("unrecognized output: %s", out)
+	}
+	return facts, nil
+}
+
+func getCachedFacts(memStorage *utils.SafeMap, host string) *cachedFacts {
+	var cf *cachedFacts
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string]*cachedFacts{}
+		v := kv.Get(comm.KEY_ALL_HOST_FACTS)
+		if v != nil {
+			m = v.(map[string]*cachedFacts)
+		}
+
+		if _, ok := m[host]; !ok {
+			m[host] = &cachedFacts{}
+		}
+		cf = m[host]
+		kv.Set(comm.KEY_ALL_HOST_FACTS, m)
+		return nil
+	})
+	return cf
+}
+
+func factsKey(ctx *context.Context) string {
+	if ctx.Transport() == nil {
+		return "localhost"
+	}
+	return ctx.Transport().Config().Host
+}
+
+// GetHostFacts returns the facts of host (address) which already gathered in this run
+func GetHostFacts(memStorage *utils.SafeMap, host string) (HostFacts, bool) {
+	cf := getCachedFacts(memStorage, host)
+	cf.Lock()
+	defer cf.Unlock()
+	return cf.facts, cf.gathered && cf.err == nil
+}
+
+func (s *GatherFacts) Execute(ctx *context.Context) error {
+	if s.MemStorage == nil {
+		facts, err := s.gather(ctx)
+		if err != nil {
+			return err
+		}
+		*s.Out = *facts
+		return nil
+	}
+
+	cf := getCachedFacts(s.MemStorage, factsKey(ctx))
+	cf.Lock()
+	defer cf.Unlock()
+	if !cf.gathered {
+		facts, err := s.gather(ctx)
+		if err == nil {
+			cf.facts = *facts
+		}
+		cf.gathered, cf.err = true, err
+	}
+
+	if cf.err != nil {
+		return cf.err
+	}
+	*s.Out = cf.facts
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package step
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHostFacts(t *testing.T) {
+	assert := assert.New(t)
+
+	out := `os=debian
+os_version="11"
+kernel=5.10.0-23-amd64
+arch=x86_64
+cpus=16
+cpu_model= Intel(R) Xeon(R) Silver 4210 CPU @ 2.20GHz
+memory=       16318412 kB
+engine=Docker version 20.10.7, build f0df350
+disks={"blockdevices": [{"name":"sda", "size":480103981056, "type":"disk", "rota":false},{"name":"sdb", "size":"4000787030016", "type":"disk", "rota":"1"}]}
+nics=eth0:10000 eth1:-1 eth2:
+`
+	facts := ParseHostFacts(out)
+	assert.Equal("debian", facts.OS)
+	assert.Equal("11", facts.OSVersion)
+	assert.Equal("5.10.0-23-amd64", facts.KernelRelease)
+	assert.Equal("x86_64", facts.Arch)
+	assert.Equal(16, facts.CPUs)
+	assert.Equal("Intel(R) Xeon(R) Silver 4210 CPU @ 2.20GHz", facts.CPUModel)
+	assert.Equal(uint64(16318412), facts.Memory)
+	assert.True(facts.HasContainerEngine())
+	assert.Len(facts.BlockDevices, 2)
+
+	device, ok := facts.GetBlockDevice("/dev/sdb")
+	assert.True(ok)
+	assert.Equal(uint64(4000787030016), device.Size)
+	assert.True(device.Rotational)
+	_, ok = facts.GetBlockDevice("/dev/sdc")
+	assert.False(ok)
+	assert.Equal([]NIC{{"eth0", 10000}, {"eth1", -1}, {"eth2", -1}}, facts.NICs)
+	assert.Equal(10000, facts.MaxNICSpeed())
+
+	facts = ParseHostFacts("")
+	assert.Equal("", facts.KernelRelease)
+	assert.False(facts.HasContainerEngine())
+	assert.Equal(0, facts.MaxNICSpeed())
+}
@@ -26,11 +26,13 @@ package step
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os"
 	"path"
 	"regexp"
 	"strings"
+	"text/template"
 
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
@@ -61,11 +63,28 @@ type (
 		module.ExecOptions
 	}
 
+	// render go template with variables and install it into host or container,
+	// the mode and owner only take effect when installing into host
+	RenderTemplate struct {
+		Template          string
+		Variables         map[string]interface{}
+		HostDestPath      string
+		ContainerId       *string
+		ContainerDestPath string
+		Mode              string // e.g: 0644
+		Owner             string // e.g: root:root
+		module.ExecOptions
+	}
+
 	Mutate func(string, string, string) (string, error)
 
+	// Complete adds the items which lack in file after all lines mutated
+	Complete func(lines []string) []string
+
 	Filter struct {
 		KVFieldSplit string
 		Mutate       Mutate
+		Complete     Complete // optional
 		Input        *string
 		Output       *string
 	}
@@ -77,6 +96,7 @@ type (
 		ContainerDestPath string
 		KVFieldSplit      string
 		Mutate            func(string, string, string) (string, error)
+		Complete          Complete // optional
 		module.ExecOptions
 	}
 
@@ -99,12 +119,6 @@ type (
 		module.ExecOptions
 	}
 
-	DownloadFile struct {
-		RemotePath string
-		LocalPath  string
-		module.ExecOptions
-	}
-
 	CreateAndUploadDir struct {
 		HostDirName       string
 		ContainerDestId   *string
@@ -129,7 +143,7 @@ func (s *ReadFile) Execute(ctx *context.Context) error {
 	}
 
 	// localPath
-	localPath := utils.RandFilename(TEMP_DIR)
+	localPath := utils.RandLocalFilename()
 	defer os.Remove(localPath)
 	if !s.ExecInLocal {
 		err := ctx.Module().File().Download(remotePath, localPath)
@@ -144,8 +158,8 @@ func (s *ReadFile) Execute(ctx *context.Context) error {
 		}
 	}
 
-	data, err := utils.ReadFile(localPath)
-	*s.Content = data
+	data, err := os.ReadFile(localPath) // keep content as it is
+	*s.Content = string(data)
 	if err != nil {
 		return errno.ERR_READ_FILE_FAILED.E(err)
 	}
@@ -153,7 +167,7 @@ func (s *ReadFile) Execute(ctx *context.Context) error {
 }
 
 func (s *InstallFile) Execute(ctx *context.Context) error {
-	localPath := utils.RandFilename(TEMP_DIR)
+	localPath := utils.RandLocalFilename()
 	defer os.Remove(localPath)
 	err := utils.WriteFile(localPath, *s.Content, 0644)
 	if err != nil {
@@ -161,6 +175,9 @@ func (s *InstallFile) Execute(ctx *context.Context) error {
 	}
 
 	remotePath := utils.RandFilename(TEMP_DIR)
+	if s.ExecInLocal {
+		remotePath = utils.RandLocalFilename()
+	}
 	if !s.ExecInLocal {
 		// defer ctx.Module().Shell().Remove(remotePath).Execute(module.ExecOptions{})
 		err = ctx.Module().File().Upload(localPath, remotePath)
@@ -195,6 +212,54 @@ func (s *InstallFile) Execute(ctx *context.Context) error {
 	return nil
 }
 
+func (s *RenderTemplate) render() (string, error) {
+	tmpl, err := template.New("render").Option("missingkey=error").Parse(s.Template)
+	if err != nil {
+		return "", errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+
+	buffer := bytes.NewBufferString("")
+	err = tmpl.Execute(buffer, s.Variables)
+	if err != nil {
+		return "", errno.ERR_RENDER_TEMPLATE_FAILED.E(err)
+	}
+	return buffer.String(), nil
+}
+
+func (s *RenderTemplate) Execute(ctx *context.Context) error {
+	content, err := s.render()
+	if err != nil {
+		return err
+	}
+
+	err = (&InstallFile{
+		Content:           &content,
+		HostDestPath:      s.HostDestPath,
+		ContainerId:       s.ContainerId,
+		ContainerDestPath: s.ContainerDestPath,
+		ExecOptions:       s.ExecOptions,
+	}).Execute(ctx)
+	if err != nil || len(s.HostDestPath) == 0 {
+		return err
+	}
+
+	if len(s.Mode) > 0 {
+		cmd := ctx.Module().Shell().Chmod(s.Mode, s.HostDestPath)
+		_, err = cmd.Execute(s.ExecOptions)
+		if err != nil {
+			return errno.ERR_CHANGE_FILE_MODE_FAILED.E(err)
+		}
+	}
+	if len(s.Owner) > 0 {
+		cmd := ctx.Module().Shell().Chown(s.Owner, s.HostDestPath)
+		_, err = cmd.Execute(s.ExecOptions)
+		if err != nil {
+			return errno.ERR_CHANGE_FILE_OWNER_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
 func (s *Filter) kvSplit(line string, key, value *string) error {
 	pattern := fmt.Sprintf(REGEX_KV_SPLIT, s.KVFieldSplit, s.KVFieldSplit)
 	regex, err := regexp.Compile(pattern)
@@ -233,6 +298,9 @@ func (s *Filter) Execute(ctx *context.Context) error {
 		output = append(output, out)
 	}
 
+	if s.Complete != nil {
+		output = s.Complete(output)
+	}
 	*s.Output = strings.Join(output, "\n")
 	return nil
 }
@@ -249,6 +317,7 @@ func (s *SyncFile) Execute(ctx *context.Context) error {
 	steps = append(steps, &Filter{
 		KVFieldSplit: s.KVFieldSplit,
 		Mutate:       s.Mutate,
+		Complete:     s.Complete,
 		Input:        &input,
 		Output:       &output,
 	})
@@ -297,10 +366,6 @@ func (s *SyncFileDirectly) Execute(ctx *context.Context) error {
 	return nil
 }
 
-func (s *DownloadFile) Execute(ctx *context.Context) error {
-	return ctx.Module().File().Download(s.RemotePath, s.LocalPath)
-}
-
 func (s *TrySyncFile) Execute(ctx *context.Context) error {
 	var input string
 	step := &ReadFile{
@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package step
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+ * schemas for JSON output of remote tools, only fields we cared are declared.
+ *
+ * NOTE: lsblk before util-linux 2.33 prints all values as string
+ * (e.g: "size": "480103981056", "rota": "0"), so we use JSONInt and
+ * JSONBool which accept both number/boolean and string.
+ */
+type (
+	JSONInt  int64
+	JSONBool bool
+
+	// lsblk --json --bytes
+	LsblkOutput struct {
+		BlockDevices []LsblkDevice `json:"blockdevices"`
+	}
+
+	LsblkDevice struct {
+		Name       string        `json:"name"`
+		Size       JSONInt       `json:"size"`
+		Type       string        `json:"type"`
+		Rotational JSONBool      `json:"rota"`
+		FSType     string        `json:"fstype"`
+		MountPoint string        `json:"mountpoint"`
+		Children   []LsblkDevice `json:"children"`
+	}
+)
+
+func (n *JSONInt) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), "\"")
+	if s == "null" || len(s) == 0 {
+		*n = 0
+		return nil
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*n = JSONInt(v)
+	return nil
+}
+
+func (b *JSONBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), "\"") {
+	case "true", "1":
+		*b = true
+	case "false", "0", "null", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean value: %s", string(data))
+	}
+	return nil
+}
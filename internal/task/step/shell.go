@@ -26,6 +26,7 @@ package step
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -39,6 +40,9 @@ const (
 	ERR_NOT_MOUNTED          = "not mounted"
 	ERR_MOUNTPOINT_NOT_FOUND = "mountpoint not found"
 	ERROR_DEVICE_BUSY        = "Device or resource busy"
+
+	FILESYSTEM_TYPE_EXT4 = "ext4"
+	FILE_TYPE_DIRECTORY  = "directory"
 )
 
 type (
@@ -94,15 +98,17 @@ type (
 	}
 
 	CreateFilesystem struct {
-		Device string
-		Out    *string
+		Device          string
+		IgnoreFormatted bool // skip mkfs if the device already has an ext4 filesystem
+		Out             *string
 		module.ExecOptions
 	}
 
 	MountFilesystem struct {
-		Source    string
-		Directory string
-		Out       *string
+		Source        string
+		Directory     string
+		IgnoreMounted bool // skip mount if the source already mounted on the directory
+		Out           *string
 		module.ExecOptions
 	}
 
@@ -269,6 +275,7 @@ type (
 		Command string
 		Success *bool
 		Out     *string
+		Writer  io.Writer // stream output into it instead of Out, e.g. tail -F
 		module.ExecOptions
 	}
 )
@@ -292,19 +299,35 @@ func (s *List) Execute(ctx *context.Context) error {
 	return PostHandle(nil, s.Out, out, err, errno.ERR_EDIT_FILE_FAILED)
 }
 
+func (s *CreateDirectory) exist(ctx *context.Context, path string) bool {
+	cmd := ctx.Module().Shell().Stat(path)
+	cmd.AddOption("--format=%%F")
+	out, err := cmd.Execute(s.ExecOptions)
+	return err == nil && strings.TrimSpace(out) == FILE_TYPE_DIRECTORY
+}
+
+// the existed directory is skipped, so it needn't the permission of its parent
 func (s *CreateDirectory) Execute(ctx *context.Context) error {
 	for _, path := range s.Paths {
 		if len(path) == 0 {
 			continue
+		} else if s.exist(ctx, path) {
+			PostHandle(s.Success, s.Out, "", nil, nil)
+			continue
 		}
 
 		cmd := ctx.Module().Shell().Mkdir(path)
 		cmd.AddOption("--parents") // no error if existing, make parent directories as needed
 
 		out, err := cmd.Execute(s.ExecOptions)
+		if err != nil && s.exist(ctx, path) { // created by others meanwhile
+			out, err = "", nil
+		}
 		err = PostHandle(s.Success, s.Out, out, err, errno.ERR_CREATE_DIRECTORY_FAILED)
 		if err != nil {
 			return err
+		} else if s.Success != nil && !*s.Success {
+			return nil
 		}
 	}
 	return nil
@@ -357,7 +380,19 @@ func (s *Cat) Execute(ctx *context.Context) error {
 	return PostHandle(s.Success, s.Out, out, err, errno.ERR_CONCATENATE_FILE_FAILED)
 }
 
+func (s *CreateFilesystem) formatted(ctx *context.Context) bool {
+	cmd := ctx.Module().Shell().BlkId(s.Device)
+	cmd.AddOption("-o value")
+	cmd.AddOption("-s TYPE")
+	out, err := cmd.Execute(s.ExecOptions)
+	return err == nil && strings.TrimSpace(out) == FILESYSTEM_TYPE_EXT4
+}
+
 func (s *CreateFilesystem) Execute(ctx *context.Context) error {
+	if s.IgnoreFormatted && s.formatted(ctx) {
+		return nil
+	}
+
 	cmd := ctx.Module().Shell().Mkfs(s.Device)
 	// force mke2fs to create a filesystem, even if the specified device is not a partition
 	// on a block special device, or if other parameters do not make sense
@@ -367,7 +402,22 @@ func (s *CreateFilesystem) Execute(ctx *context.Context) error {
 	return PostHandle(nil, s.Out, out, err, errno.ERR_BUILD_A_LINUX_FILE_SYSTEM_FAILED)
 }
 
+// findmnt exits with 0 iff the source mounted on the directory, the error message
+// of mount (e.g. "already mounted or mount point busy") can't tell it
+func (s *MountFilesystem) mounted(ctx *context.Context) bool {
+	cmd := ctx.Module().Shell().FindMnt()
+	cmd.AddOption("--noheadings")
+	cmd.AddOption("--source %s", s.Source)
+	cmd.AddOption("--mountpoint %s", s.Directory)
+	_, err := cmd.Execute(s.ExecOptions)
+	return err == nil
+}
+
 func (s *MountFilesystem) Execute(ctx *context.Context) error {
+	if s.IgnoreMounted && s.mounted(ctx) {
+		return nil
+	}
+
 	cmd := ctx.Module().Shell().Mount(s.Source, s.Directory)
 	out, err := cmd.Execute(s.ExecOptions)
 	return PostHandle(nil, s.Out, out, err, errno.ERR_MOUNT_A_FILESYSTEM_FAILED)
@@ -461,6 +511,9 @@ func (s *SocketStatistics) Execute(ctx *context.Context) error {
 
 func (s *Ping) Execute(ctx *context.Context) error {
 	cmd := ctx.Module().Shell().Ping(*s.Destination)
+	if utils.IsIPv6(*s.Destination) {
+		cmd.AddOption("-6")
+	}
 	if s.Count > 0 {
 		cmd.AddOption("-c %d", s.Count)
 	}
@@ -474,6 +527,9 @@ func (s *Ping) Execute(ctx *context.Context) error {
 
 func (s *Curl) Execute(ctx *context.Context) error {
 	cmd := ctx.Module().Shell().Curl(s.Url)
+	if strings.Contains(s.Url, "[") { // bracketed IPv6 address, not glob
+		cmd.AddOption("--globoff")
+	}
 	if len(s.Form) > 0 {
 		cmd.AddOption("--form %s", s.Form)
 	}
@@ -596,7 +652,7 @@ func (s *Rpm) Execute(ctx *context.Context) error {
 }
 
 func (s *Scp) Execute(ctx *context.Context) error {
-	localPath := utils.RandFilename(TEMP_DIR)
+	localPath := utils.RandLocalFilename()
 	defer os.Remove(localPath)
 	mode := 0644
 	if s.Mode > 0 {
@@ -607,7 +663,7 @@ func (s *Scp) Execute(ctx *context.Context) error {
 		return errno.ERR_WRITE_FILE_FAILED.E(err)
 	}
 
-	config := ctx.SSHClient().Config()
+	config := ctx.Transport().Config()
 	cmd := ctx.Module().Shell().Scp(localPath, config.User, config.Host, s.RemotePath)
 	cmd.AddOption("-P %d", config.Port)
 	if !config.ForwardAgent {
@@ -623,6 +679,10 @@ func (s *Scp) Execute(ctx *context.Context) error {
 
 func (s *Command) Execute(ctx *context.Context) error {
 	cmd := ctx.Module().Shell().Command(s.Command)
+	if s.Writer != nil {
+		return PostHandle(s.Success, nil, "", cmd.ExecuteStream(s.ExecOptions, s.Writer),
+			errno.ERR_RUN_A_BASH_COMMAND_FAILED)
+	}
 	out, err := cmd.Execute(s.ExecOptions)
 	return PostHandle(s.Success, s.Out, out, err, errno.ERR_RUN_A_BASH_COMMAND_FAILED)
 }
@@ -0,0 +1,176 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package step
+
+import (
+	stdctx "context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTransport records executed commands, the command which has prefix in failed fails
+type fakeTransport struct {
+	module.LocalTransport
+	commands []string
+	failed   map[string]string // command prefix: output
+	outputs  map[string]string // command prefix: output
+}
+
+func (t *fakeTransport) ExecStream(ctx stdctx.Context, command string, w io.Writer) error {
+	t.commands = append(t.commands, command)
+	for prefix, out := range t.failed {
+		if strings.HasPrefix(command, prefix) {
+			io.WriteString(w, out)
+			return errors.New("exit status 1")
+		}
+	}
+	for prefix, out := range t.outputs {
+		if strings.HasPrefix(command, prefix) {
+			io.WriteString(w, out)
+		}
+	}
+	return nil
+}
+
+func newFakeContext(t *testing.T, failed map[string]string) (*context.Context, *fakeTransport) {
+	transport := &fakeTransport{failed: failed, outputs: map[string]string{}}
+	ctx, err := context.NewContext(transport)
+	assert.Nil(t, err)
+	return ctx, transport
+}
+
+func TestMountFilesystem(t *testing.T) {
+	assert := assert.New(t)
+
+	// already mounted: skip mount
+	ctx, transport := newFakeContext(t, map[string]string{})
+	err := (&MountFilesystem{
+		Source:        "/dev/sdb",
+		Directory:     "/data/chunkserver0",
+		IgnoreMounted: true,
+	}).Execute(ctx)
+	assert.Nil(err)
+	assert.Equal([]string{
+		"findmnt --noheadings --source /dev/sdb --mountpoint /data/chunkserver0",
+	}, transport.commands)
+
+	// not mounted: mount it
+	ctx, transport = newFakeContext(t, map[string]string{"findmnt": ""})
+	err = (&MountFilesystem{
+		Source:        "/dev/sdb",
+		Directory:     "/data/chunkserver0",
+		IgnoreMounted: true,
+	}).Execute(ctx)
+	assert.Nil(err)
+	assert.Len(transport.commands, 2)
+	assert.Equal("mount  /dev/sdb /data/chunkserver0", transport.commands[1])
+
+	// mount point busy by another source: mount failed
+	ctx, _ = newFakeContext(t, map[string]string{
+		"findmnt": "",
+		"mount":   "mount: /data/chunkserver0: /dev/sdc already mounted or mount point busy.",
+	})
+	err = (&MountFilesystem{
+		Source:        "/dev/sdb",
+		Directory:     "/data/chunkserver0",
+		IgnoreMounted: true,
+	}).Execute(ctx)
+	assert.NotNil(err)
+	assert.Equal(errno.ERR_MOUNT_A_FILESYSTEM_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// not ignore mounted: mount directly
+	ctx, transport = newFakeContext(t, map[string]string{})
+	err = (&MountFilesystem{
+		Source:    "/dev/sdb",
+		Directory: "/data/chunkserver0",
+	}).Execute(ctx)
+	assert.Nil(err)
+	assert.Equal([]string{"mount  /dev/sdb /data/chunkserver0"}, transport.commands)
+}
+
+func TestCreateDirectory(t *testing.T) {
+	assert := assert.New(t)
+
+	// existed directory is skipped
+	ctx, transport := newFakeContext(t, map[string]string{"stat --format=%F /data/log": ""})
+	transport.outputs["stat --format=%F /data/chunkserver0"] = "directory\n"
+	err := (&CreateDirectory{
+		Paths: []string{"/data/chunkserver0", "", "/data/log"},
+	}).Execute(ctx)
+	assert.Nil(err)
+	assert.Equal([]string{
+		"stat --format=%F /data/chunkserver0",
+		"stat --format=%F /data/log",
+		"mkdir --parents /data/log",
+	}, transport.commands)
+
+	// the path is a regular file
+	ctx, transport = newFakeContext(t, map[string]string{"mkdir": "mkdir: cannot create directory '/data/log': File exists"})
+	transport.outputs["stat"] = "regular file\n"
+	err = (&CreateDirectory{Paths: []string{"/data/log"}}).Execute(ctx)
+	assert.NotNil(err)
+	assert.Equal(errno.ERR_CREATE_DIRECTORY_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// the first failure is kept if success is handled by user
+	success := true
+	ctx, _ = newFakeContext(t, map[string]string{"stat": "", "mkdir --parents /data/log": "Permission denied"})
+	err = (&CreateDirectory{
+		Paths:   []string{"/data/log", "/data/chunkserver0"},
+		Success: &success,
+	}).Execute(ctx)
+	assert.Nil(err)
+	assert.False(success)
+}
+
+func TestCreateFilesystem(t *testing.T) {
+	assert := assert.New(t)
+
+	// already formatted: skip mkfs
+	ctx, transport := newFakeContext(t, map[string]string{})
+	transport.outputs["blkid"] = "ext4\n"
+	err := (&CreateFilesystem{Device: "/dev/sdb", IgnoreFormatted: true}).Execute(ctx)
+	assert.Nil(err)
+	assert.Equal([]string{"blkid -o value -s TYPE /dev/sdb"}, transport.commands)
+
+	// no filesystem: mkfs
+	ctx, transport = newFakeContext(t, map[string]string{"blkid": ""})
+	err = (&CreateFilesystem{Device: "/dev/sdb", IgnoreFormatted: true}).Execute(ctx)
+	assert.Nil(err)
+	assert.Len(transport.commands, 2)
+	assert.True(strings.HasPrefix(transport.commands[1], "mkfs.ext4"))
+
+	// not ignore formatted: always mkfs
+	ctx, transport = newFakeContext(t, map[string]string{})
+	transport.outputs["blkid"] = "ext4\n"
+	err = (&CreateFilesystem{Device: "/dev/sdb"}).Execute(ctx)
+	assert.Nil(err)
+	assert.Len(transport.commands, 1)
+	assert.True(strings.HasPrefix(transport.commands[0], "mkfs.ext4"))
+}
@@ -0,0 +1,212 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package step
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	SYSTEMD_UNIT_DIR = "/etc/systemd/system"
+
+	SYSTEMD_UNIT_STATUS_ACTIVE   = "active"
+	SYSTEMD_UNIT_STATUS_INACTIVE = "inactive"
+	SYSTEMD_UNIT_STATUS_FAILED   = "failed"
+
+	// the default unit file for service which running outside container
+	TEMPLATE_SYSTEMD_SERVICE_UNIT = `[Unit]
+Description={{.description}}
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=simple
+{{- if .user}}
+User={{.user}}
+{{- end}}
+{{- if .workdir}}
+WorkingDirectory={{.workdir}}
+{{- end}}
+{{- range .envs}}
+Environment={{.}}
+{{- end}}
+ExecStart={{.command}}
+Restart={{.restart}}
+LimitCORE=infinity
+LimitNOFILE=65535
+
+[Install]
+WantedBy=multi-user.target
+`
+)
+
+/*
+ * the systemd steps keep the same shape with container steps
+ * (e.g. StartUnit vs StartContainer), so the service task could
+ * switch between them without changing its step sequence.
+ */
+type (
+	InstallUnitFile struct {
+		Name      string // unit name, e.g: curvebs-mds.service
+		Template  string // TEMPLATE_SYSTEMD_SERVICE_UNIT if empty
+		Variables map[string]interface{}
+		module.ExecOptions
+	}
+
+	RemoveUnitFile struct {
+		Name string
+		module.ExecOptions
+	}
+
+	DaemonReload struct {
+		module.ExecOptions
+	}
+
+	StartUnit struct {
+		Name    string
+		Success *bool
+		Out     *string
+		module.ExecOptions
+	}
+
+	StopUnit struct {
+		Name string
+		Out  *string
+		module.ExecOptions
+	}
+
+	RestartUnit struct {
+		Name string
+		Out  *string
+		module.ExecOptions
+	}
+
+	EnableUnit struct {
+		Name string
+		Now  bool // start the unit at the same time
+		Out  *string
+		module.ExecOptions
+	}
+
+	DisableUnit struct {
+		Name string
+		Now  bool // stop the unit at the same time
+		Out  *string
+		module.ExecOptions
+	}
+
+	// Out stores the unit status: active, inactive, failed...
+	GetUnitStatus struct {
+		Name string
+		Out  *string
+		module.ExecOptions
+	}
+)
+
+func UnitFilePath(name string) string {
+	return fmt.Sprintf("%s/%s", SYSTEMD_UNIT_DIR, name)
+}
+
+func (s *InstallUnitFile) Execute(ctx *context.Context) error {
+	tmpl := s.Template
+	if len(tmpl) == 0 {
+		tmpl = TEMPLATE_SYSTEMD_SERVICE_UNIT
+	}
+
+	return (&RenderTemplate{
+		Template:     tmpl,
+		Variables:    s.Variables,
+		HostDestPath: UnitFilePath(s.Name),
+		Mode:         "0644",
+		ExecOptions:  s.ExecOptions,
+	}).Execute(ctx)
+}
+
+func (s *RemoveUnitFile) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Remove(UnitFilePath(s.Name))
+	cmd.AddOption("--force")
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(nil, nil, out, err, errno.ERR_REMOVE_FILES_OR_DIRECTORIES_FAILED)
+}
+
+func (s *DaemonReload) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl("daemon-reload")
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(nil, nil, out, err, errno.ERR_RELOAD_SYSTEMD_MANAGER_FAILED)
+}
+
+func (s *StartUnit) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl("start", s.Name)
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_START_SYSTEMD_UNIT_FAILED)
+}
+
+func (s *StopUnit) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl("stop", s.Name)
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_STOP_SYSTEMD_UNIT_FAILED)
+}
+
+func (s *RestartUnit) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl("restart", s.Name)
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_RESTART_SYSTEMD_UNIT_FAILED)
+}
+
+func (s *EnableUnit) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl("enable", s.Name)
+	if s.Now {
+		cmd.AddOption("--now")
+	}
+
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_ENABLE_SYSTEMD_UNIT_FAILED)
+}
+
+func (s *DisableUnit) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl("disable", s.Name)
+	if s.Now {
+		cmd.AddOption("--now")
+	}
+
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_DISABLE_SYSTEMD_UNIT_FAILED)
+}
+
+func (s *GetUnitStatus) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl("is-active", s.Name)
+	out, err := cmd.Execute(s.ExecOptions)
+	out = strings.TrimSpace(out)
+	// NOTE: is-active exits with non-zero code if the unit is not active,
+	// so we only treat it as error when nothing printed
+	if err != nil && len(out) == 0 {
+		return errno.ERR_GET_SYSTEMD_UNIT_STATUS_FAILED.E(err)
+	}
+	*s.Out = out
+	return nil
+}
@@ -0,0 +1,233 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package step
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	SUFFIX_PARTIAL_FILE = ".part"
+)
+
+/*
+ * UploadFile/DownloadFile transfer file into temporary file (*.part) first,
+ * verify its SHA256 checksum and then rename it to the destination, so the
+ * partial file can be resumed in next time if the transfer interrupted.
+ */
+type (
+	UploadFile struct {
+		LocalPath  string
+		RemotePath string
+		Checksum   string // expected SHA256 checksum, computed from local file if empty
+		Verify     bool   // verify SHA256 checksum after transferred
+		Resume     bool   // resume the partial transfer
+		RateLimit  int64  // bytes per second, 0 means no limit
+		module.ExecOptions
+	}
+
+	DownloadFile struct {
+		RemotePath string
+		LocalPath  string
+		Verify     bool
+		Resume     bool
+		RateLimit  int64
+		module.ExecOptions
+	}
+)
+
+func localSha256Sum(filepath string) (string, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func remoteSha256Sum(ctx *context.Context, filepath string, options module.ExecOptions) (string, error) {
+	cmd := ctx.Module().Shell().Sha256Sum(filepath)
+	out, err := cmd.Execute(options)
+	if err != nil {
+		return "", errno.ERR_COMPUTE_SHA256_CHECKSUM_FAILED.S(out)
+	}
+
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return "", errno.ERR_COMPUTE_SHA256_CHECKSUM_FAILED.
+			F("unrecognized output: %s", out)
+	}
+	return fields[0], nil
+}
+
+// the partial file path in remote must be stable for resuming
+func partialFilePath(filepath string) string {
+	return fmt.Sprintf("%s/curveadm-%s%s", TEMP_DIR, utils.MD5Sum(filepath), SUFFIX_PARTIAL_FILE)
+}
+
+func reportProgress(ctx *context.Context, filepath string) func(int64, int64) {
+	name := path.Base(filepath)
+	return func(transferred, total int64) {
+		percent := 100
+		if total > 0 {
+			percent = int(transferred * 100 / total)
+		}
+		ctx.Progress().Set("%s %s/%s (%d%%)", name,
+			humanize.IBytes(uint64(transferred)), humanize.IBytes(uint64(total)), percent)
+	}
+}
+
+// file manager for ExecInLocal, which not reach remote host
+func fileManager(ctx *context.Context, options module.ExecOptions) *module.FileManager {
+	if options.ExecInLocal {
+		return module.NewFileManager(module.NewLocalTransport(module.SSHConfig{}))
+	}
+	return ctx.Module().File()
+}
+
+func (s *UploadFile) transfer(ctx *context.Context, partialPath string, resume bool) error {
+	err := fileManager(ctx, s.ExecOptions).UploadWithOptions(s.LocalPath, partialPath, module.TransferOptions{
+		Resume:    resume,
+		RateLimit: s.RateLimit,
+		Progress:  reportProgress(ctx, s.LocalPath),
+	})
+	if err != nil {
+		return errno.ERR_UPLOAD_FILE_TO_REMOTE_BY_SSH_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *UploadFile) Execute(ctx *context.Context) error {
+	expect := s.Checksum
+	if s.Verify && len(expect) == 0 {
+		checksum, err := localSha256Sum(s.LocalPath)
+		if err != nil {
+			return errno.ERR_READ_FILE_FAILED.E(err)
+		}
+		expect = checksum
+	}
+
+	// (1) transfer to partial file
+	partialPath := partialFilePath(s.RemotePath)
+	err := s.transfer(ctx, partialPath, s.Resume)
+	if err != nil {
+		return err
+	}
+
+	// (2) verify checksum, retransfer the whole file if resumed one mismatch
+	if s.Verify {
+		actual, err := remoteSha256Sum(ctx, partialPath, s.ExecOptions)
+		if err != nil {
+			return err
+		} else if actual != expect && s.Resume {
+			if err := s.transfer(ctx, partialPath, false); err != nil {
+				return err
+			} else if actual, err = remoteSha256Sum(ctx, partialPath, s.ExecOptions); err != nil {
+				return err
+			}
+		}
+
+		if actual != expect {
+			return errno.ERR_TRANSFERRED_FILE_CHECKSUM_MISMATCH.
+				F("%s: expect %s, actual %s", s.RemotePath, expect, actual)
+		}
+	}
+
+	// (3) rename to destination
+	cmd := ctx.Module().Shell().Rename(partialPath, s.RemotePath)
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(nil, nil, out, err, errno.ERR_RENAME_FILE_OR_DIRECTORY_FAILED)
+}
+
+func (s *DownloadFile) transfer(ctx *context.Context, partialPath string, resume bool) error {
+	err := fileManager(ctx, s.ExecOptions).DownloadWithOptions(s.RemotePath, partialPath, module.TransferOptions{
+		Resume:    resume,
+		RateLimit: s.RateLimit,
+		Progress:  reportProgress(ctx, s.RemotePath),
+	})
+	if err != nil {
+		return errno.ERR_DOWNLOAD_FILE_FROM_REMOTE_BY_SSH_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *DownloadFile) Execute(ctx *context.Context) error {
+	expect := ""
+	if s.Verify {
+		checksum, err := remoteSha256Sum(ctx, s.RemotePath, s.ExecOptions)
+		if err != nil {
+			return err
+		}
+		expect = checksum
+	}
+
+	// (1) transfer to partial file which beside the destination,
+	// so it can be renamed atomically
+	partialPath := s.LocalPath + SUFFIX_PARTIAL_FILE
+	err := s.transfer(ctx, partialPath, s.Resume)
+	if err != nil {
+		return err
+	}
+
+	// (2) verify checksum, retransfer the whole file if resumed one mismatch
+	if s.Verify {
+		actual, err := localSha256Sum(partialPath)
+		if err != nil {
+			return errno.ERR_READ_FILE_FAILED.E(err)
+		} else if actual != expect && s.Resume {
+			if err := s.transfer(ctx, partialPath, false); err != nil {
+				return err
+			} else if actual, err = localSha256Sum(partialPath); err != nil {
+				return errno.ERR_READ_FILE_FAILED.E(err)
+			}
+		}
+
+		if actual != expect {
+			return errno.ERR_TRANSFERRED_FILE_CHECKSUM_MISMATCH.
+				F("%s: expect %s, actual %s", s.LocalPath, expect, actual)
+		}
+	}
+
+	// (3) rename to destination
+	if err := os.Rename(partialPath, s.LocalPath); err != nil {
+		return errno.ERR_RENAME_FILE_OR_DIRECTORY_FAILED.E(err)
+	}
+	return nil
+}
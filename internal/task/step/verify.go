@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package step
+
+import (
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/verify"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+type (
+	// VerifyChecksum refuses the file downloaded from Url if mismatch with published checksum
+	VerifyChecksum struct {
+		Url  string
+		Path string
+		module.ExecOptions
+	}
+)
+
+func (s *VerifyChecksum) Execute(ctx *context.Context) error {
+	verifier := verify.Globals()
+	if verifier.SkipVerify() {
+		return nil
+	}
+
+	expected, err := verifier.Checksum(s.Url)
+	if err != nil {
+		return err
+	}
+	actual, err := remoteSha256Sum(ctx, s.Path, s.ExecOptions)
+	if err != nil {
+		return err
+	} else if actual != expected {
+		return errno.ERR_ARTIFACT_CHECKSUM_MISMATCH.
+			F("%s: expected %s, actual %s", s.Url, expected, actual)
+	}
+	return nil
+}
@@ -60,7 +60,7 @@ func NewAddTargetTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task
 	targetScriptPath := "/curvebs/tools/sbin/target.sh"
 	targetScript := scripts.TARGET
 	cmd := fmt.Sprintf("/bin/bash %s %s %s %v %d %d", targetScriptPath, user, volume, options.Create, options.Size, options.Blocksize)
-	toolsConf := fmt.Sprintf(FORMAT_TOOLS_CONF, cc.GetClusterMDSAddr())
+	toolsConfVariables := map[string]interface{}{"mdsAddr": cc.GetClusterMDSAddr()}
 
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
@@ -72,8 +72,9 @@ func NewAddTargetTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task
 	t.AddStep(&step2CheckTgtdStatus{
 		output: &output,
 	})
-	t.AddStep(&step.InstallFile{ // install tools.conf
-		Content:           &toolsConf,
+	t.AddStep(&step.RenderTemplate{ // install tools.conf
+		Template:          TEMPLATE_TOOLS_CONF,
+		Variables:         toolsConfVariables,
 		ContainerId:       &containerId,
 		ContainerDestPath: "/etc/curve/tools.conf",
 		ExecOptions:       curveadm.ExecOptions(),
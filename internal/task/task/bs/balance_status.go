@@ -0,0 +1,281 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+const (
+	COMMAND_LIST_CHUNKSERVERS = "curve_ops_tool chunkserver-list -checkHealth=false"
+	COMMAND_CURL_LEADER_NUM   = "curl -s --connect-timeout 1 --max-time 3 http://%s:%d/vars/topology_metric_chunkserver_*_leader_num"
+
+	// e.g: chunkServerID = 1, diskType = nvme, hostIP = 10.0.0.1, port = 8200, ..., onlineState = ONLINE, copysetNum = 100, ...
+	REGEX_CHUNKSERVER_FIELD = `(\w+) = ([^,]+)`
+	// e.g: topology_metric_chunkserver_1_leader_num : 33
+	REGEX_CHUNKSERVER_LEADER_NUM = `(?m)^topology_metric_chunkserver_(\d+)_leader_num\s*:\s*(\d+)\s*$`
+
+	CHUNKSERVER_ONLINE = "ONLINE"
+
+	BALANCE_STATE_BALANCED   = "balanced"
+	BALANCE_STATE_OVERLOAD   = "overloaded"
+	BALANCE_STATE_UNDERLOAD  = "underloaded"
+	BALANCE_STATE_OFFLINE    = "offline"
+	DEFAULT_BALANCE_TOLERATE = 10 // percent
+)
+
+type (
+	// ChunkserverLoad is the copyset and leader distribution of one chunkserver
+	ChunkserverLoad struct {
+		Id       int
+		Addr     string // ip:port
+		Online   bool
+		Status   string // rwStatus, e.g: READWRITE, PENDDING
+		Copysets int
+		Leaders  int
+		Pool     string
+		Zone     string
+		State    string
+	}
+
+	/*
+	 * BalanceGroup is the imbalance metrics of chunkservers in pool or zone,
+	 * the deviation is coefficient of variation (stddev/mean) in percent.
+	 */
+	BalanceGroup struct {
+		Name             string // pool or pool/zone
+		Chunkservers     int
+		Copysets         int
+		Leaders          int
+		CopysetDeviation float64
+		LeaderDeviation  float64
+	}
+)
+
+func ParseChunkserverList(out string) []ChunkserverLoad {
+	loads := []ChunkserverLoad{}
+	regex := regexp.MustCompile(REGEX_CHUNKSERVER_FIELD)
+	for _, line := range regexp.MustCompile("\r?\n").Split(out, -1) {
+		fields := map[string]string{}
+		for _, mu := range regex.FindAllStringSubmatch(line, -1) {
+			fields[mu[1]] = mu[2]
+		}
+
+		id, ok := utils.Str2Int(fields["chunkServerID"])
+		if !ok {
+			continue
+		}
+		copysets, _ := utils.Str2Int(fields["copysetNum"])
+		loads = append(loads, ChunkserverLoad{
+			Id:       id,
+			Addr:     net.JoinHostPort(fields["hostIP"], fields["port"]),
+			Online:   fields["onlineState"] == CHUNKSERVER_ONLINE,
+			Status:   fields["rwStatus"],
+			Copysets: copysets,
+		})
+	}
+	return loads
+}
+
+// ParseLeaderNum returns the leader number of each chunkserver which indexed by id
+func ParseLeaderNum(out string) map[int]int {
+	leaders := map[int]int{}
+	regex := regexp.MustCompile(REGEX_CHUNKSERVER_LEADER_NUM)
+	for _, mu := range regex.FindAllStringSubmatch(out, -1) {
+		id, _ := strconv.Atoi(mu[1])
+		n, _ := strconv.Atoi(mu[2])
+		leaders[id] = n
+	}
+	return leaders
+}
+
+func deviation(values []int) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(values))
+	if mean == 0 {
+		return mean, 0
+	}
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(values)))
+	return mean, stddev / mean * 100
+}
+
+func newBalanceGroup(name string, loads []*ChunkserverLoad) BalanceGroup {
+	group := BalanceGroup{Name: name, Chunkservers: len(loads)}
+	copysets, leaders := []int{}, []int{}
+	for _, load := range loads {
+		group.Copysets += load.Copysets
+		group.Leaders += load.Leaders
+		copysets = append(copysets, load.Copysets)
+		leaders = append(leaders, load.Leaders)
+	}
+	_, group.CopysetDeviation = deviation(copysets)
+	_, group.LeaderDeviation = deviation(leaders)
+	return group
+}
+
+/*
+ * ComputeBalance computes the imbalance metrics for each pool and zone,
+ * and marks the chunkserver whose copysets or leaders deviate from the
+ * mean of its pool more than tolerate percent as overloaded or underloaded.
+ * the offline chunkserver is excluded from the metrics.
+ */
+func ComputeBalance(loads []ChunkserverLoad, tolerate float64) []BalanceGroup {
+	pools := map[string][]*ChunkserverLoad{}
+	zones := map[string][]*ChunkserverLoad{}
+	for i := range loads {
+		load := &loads[i]
+		if !load.Online {
+			load.State = BALANCE_STATE_OFFLINE
+			continue
+		}
+		zone := fmt.Sprintf("%s/%s", load.Pool, load.Zone)
+		pools[load.Pool] = append(pools[load.Pool], load)
+		zones[zone] = append(zones[zone], load)
+	}
+
+	groups := []BalanceGroup{}
+	for name, members := range pools {
+		copysets, leaders := []int{}, []int{}
+		for _, load := range members {
+			copysets = append(copysets, load.Copysets)
+			leaders = append(leaders, load.Leaders)
+		}
+		meanCopysets, _ := deviation(copysets)
+		meanLeaders, _ := deviation(leaders)
+		for _, load := range members {
+			load.State = BALANCE_STATE_BALANCED
+			over := float64(load.Copysets) > meanCopysets*(1+tolerate/100) ||
+				float64(load.Leaders) > meanLeaders*(1+tolerate/100)
+			under := float64(load.Copysets) < meanCopysets*(1-tolerate/100) ||
+				float64(load.Leaders) < meanLeaders*(1-tolerate/100)
+			if over {
+				load.State = BALANCE_STATE_OVERLOAD
+			} else if under {
+				load.State = BALANCE_STATE_UNDERLOAD
+			}
+		}
+		groups = append(groups, newBalanceGroup(name, members))
+	}
+	for name, members := range zones {
+		groups = append(groups, newBalanceGroup(name, members))
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// saveChunkservers records chunkservers for completing --chunkserver-id,
+// it's best effort because the loads have been collected
+func saveChunkservers(curveadm *cli.CurveAdm, loads []ChunkserverLoad) {
+	data, err := json.Marshal(loads)
+	if err == nil {
+		err = curveadm.Storage().SetChunkservers(curveadm.ClusterId(), string(data))
+	}
+	if err != nil {
+		log.Warn("Save chunkservers failed", log.Field("Error", err))
+	}
+}
+
+func NewGetChunkserverLoadTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Get Chunkserver Load", subname, hc.GetSSHConfig())
+
+	// add step
+	var chunkservers, leaders string
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     COMMAND_LIST_CHUNKSERVERS,
+		Out:         &chunkservers,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     fmt.Sprintf(COMMAND_CURL_LEADER_NUM, dc.GetListenIp(), dc.GetListenDummyPort()),
+		Out:         &leaders,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			loads := ParseChunkserverList(chunkservers)
+			if len(loads) == 0 {
+				return errno.ERR_PARSE_CHUNKSERVER_LIST_FAILED.
+					F("output: %s", chunkservers)
+			}
+
+			m := ParseLeaderNum(leaders)
+			for i := range loads {
+				loads[i].Leaders = m[loads[i].Id]
+			}
+			curveadm.MemStorage().Set(comm.KEY_ALL_CHUNKSERVER_LOADS, loads)
+			saveChunkservers(curveadm, loads)
+			return nil
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChunkserverList(t *testing.T) {
+	assert := assert.New(t)
+
+	out := `curve chunkserver list:
+chunkServerID = 1, diskType = nvme, hostIP = 10.0.0.1, port = 8200, rwStatus = READWRITE, diskState = DISK_NORMAL, onlineState = ONLINE, copysetNum = 100, mountPoint = local:///curvebs/chunkserver/data
+chunkServerID = 2, diskType = nvme, hostIP = 10.0.0.2, port = 8200, rwStatus = READWRITE, diskState = DISK_NORMAL, onlineState = OFFLINE, copysetNum = 0, mountPoint = local:///curvebs/chunkserver/data
+total chunkserver: 2, online: 1`
+	loads := ParseChunkserverList(out)
+	assert.Equal([]ChunkserverLoad{
+		{Id: 1, Addr: "10.0.0.1:8200", Online: true, Status: "READWRITE", Copysets: 100},
+		{Id: 2, Addr: "10.0.0.2:8200", Online: false, Status: "READWRITE", Copysets: 0},
+	}, loads)
+
+	leaders := ParseLeaderNum("topology_metric_chunkserver_1_leader_num : 33\n" +
+		"topology_metric_chunkserver_2_leader_num : 0\n")
+	assert.Equal(map[int]int{1: 33, 2: 0}, leaders)
+}
+
+func TestComputeBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	loads := []ChunkserverLoad{
+		{Id: 1, Online: true, Copysets: 100, Leaders: 33, Pool: "pool1", Zone: "zone1"},
+		{Id: 2, Online: true, Copysets: 100, Leaders: 34, Pool: "pool1", Zone: "zone2"},
+		{Id: 3, Online: true, Copysets: 130, Leaders: 33, Pool: "pool1", Zone: "zone3"},
+		{Id: 4, Online: true, Copysets: 70, Leaders: 33, Pool: "pool1", Zone: "zone3"},
+		{Id: 5, Online: false, Copysets: 0, Leaders: 0, Pool: "pool1", Zone: "zone1"},
+	}
+	groups := ComputeBalance(loads, 10)
+	states := []string{}
+	for _, load := range loads {
+		states = append(states, load.State)
+	}
+	assert.Equal([]string{
+		BALANCE_STATE_BALANCED,
+		BALANCE_STATE_BALANCED,
+		BALANCE_STATE_OVERLOAD,
+		BALANCE_STATE_UNDERLOAD,
+		BALANCE_STATE_OFFLINE,
+	}, states)
+
+	names := []string{}
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	assert.Equal([]string{"pool1", "pool1/zone1", "pool1/zone2", "pool1/zone3"}, names)
+	assert.Equal(4, groups[0].Chunkservers)
+	assert.Equal(400, groups[0].Copysets)
+	assert.InDelta(21.2, groups[0].CopysetDeviation, 0.1)
+	assert.Equal(0.0, groups[1].CopysetDeviation)
+}
@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	COMMAND_SET_CHUNKSERVER_STATUS = "curve_ops_tool set-chunkserver -chunkserverId=%d -chunkserverStatus=%s"
+
+	// the status accepted by curve_ops_tool, it's lowercase of rwStatus
+	CHUNKSERVER_STATUS_READWRITE = "readwrite"
+	CHUNKSERVER_STATUS_PENDDING  = "pendding"
+)
+
+/*
+ * CheckPenddingChunkservers checks whether the copysets of chunkservers
+ * can be migrated after they are marked as pendding: the replicas of a
+ * copyset are placed in different zones, so mds can only migrate them to
+ * the other chunkservers in the same zone, which must be online and not
+ * pendding. The pool and zone of loads should be filled by caller.
+ */
+func CheckPenddingChunkservers(loads []ChunkserverLoad, ids []int) error {
+	pendding := map[int]bool{}
+	for _, id := range ids {
+		pendding[id] = true
+	}
+
+	zones := map[string]int{} // pool/zone -> number of chunkservers can accept copysets
+	touched := map[string]bool{}
+	for _, load := range loads {
+		zone := fmt.Sprintf("%s/%s", load.Pool, load.Zone)
+		if _, ok := zones[zone]; !ok {
+			zones[zone] = 0
+		}
+		if pendding[load.Id] {
+			touched[zone] = true
+		} else if load.Online && !strings.EqualFold(load.Status, CHUNKSERVER_STATUS_PENDDING) {
+			zones[zone]++
+		}
+	}
+
+	names := []string{}
+	for zone := range touched {
+		if zones[zone] == 0 {
+			names = append(names, zone)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return errno.ERR_TOO_MANY_PENDDING_CHUNKSERVERS.
+			F("no online readwrite chunkserver remains in zone %s", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+func NewSetChunkserverStatusTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Set Chunkserver Status", subname, hc.GetSSHConfig())
+
+	// add step
+	ids := curveadm.MemStorage().Get(comm.KEY_CHUNKSERVER_IDS).([]int)
+	status := curveadm.MemStorage().Get(comm.KEY_CHUNKSERVER_STATUS).(string)
+	for _, id := range ids {
+		t.AddStep(&step.ContainerExec{
+			ContainerId: &containerId,
+			Command:     fmt.Sprintf(COMMAND_SET_CHUNKSERVER_STATUS, id, status),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+
+	return t, nil
+}
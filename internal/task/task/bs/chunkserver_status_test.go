@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPenddingChunkservers(t *testing.T) {
+	assert := assert.New(t)
+
+	loads := []ChunkserverLoad{
+		{Id: 1, Online: true, Status: "READWRITE", Pool: "pool1", Zone: "zone1"},
+		{Id: 2, Online: true, Status: "READWRITE", Pool: "pool1", Zone: "zone1"},
+		{Id: 3, Online: true, Status: "READWRITE", Pool: "pool1", Zone: "zone2"},
+		{Id: 4, Online: false, Status: "READWRITE", Pool: "pool1", Zone: "zone2"},
+		{Id: 5, Online: true, Status: "READWRITE", Pool: "pool1", Zone: "zone3"},
+		{Id: 6, Online: true, Status: "PENDDING", Pool: "pool1", Zone: "zone3"},
+	}
+
+	// another chunkserver in zone1 accepts copysets
+	assert.Nil(CheckPenddingChunkservers(loads, []int{1}))
+	assert.Nil(CheckPenddingChunkservers(loads, []int{2}))
+	// chunkserver 4 is offline and chunkserver 6 is pendding already
+	for _, ids := range [][]int{{1, 2}, {3}, {5}, {1, 3}} {
+		err := CheckPenddingChunkservers(loads, ids)
+		assert.True(errors.Is(err, errno.ERR_TOO_MANY_PENDDING_CHUNKSERVERS))
+	}
+}
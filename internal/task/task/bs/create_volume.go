@@ -38,7 +38,7 @@ import (
 )
 
 const (
-	FORMAT_TOOLS_CONF = `mdsAddr=%s
+	TEMPLATE_TOOLS_CONF = `mdsAddr={{.mdsAddr}}
 rootUserName=root
 rootUserPassword=root_password
 `
@@ -112,7 +112,7 @@ func NewCreateVolumeTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*t
 	var out string
 	containerName := volume2ContainerName(options.User, options.Volume)
 	containerId := containerName
-	toolsConf := fmt.Sprintf(FORMAT_TOOLS_CONF, cc.GetClusterMDSAddr())
+	toolsConfVariables := map[string]interface{}{"mdsAddr": cc.GetClusterMDSAddr()}
 	script := scripts.CREATE_VOLUME
 	scriptPath := "/curvebs/nebd/sbin/create.sh"
 	command := fmt.Sprintf("/bin/bash %s %s %s %d %s", scriptPath, options.User, options.Volume, options.Size, options.Poolset)
@@ -129,8 +129,9 @@ func NewCreateVolumeTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*t
 	t.AddStep(&step.Lambda{
 		Lambda: checkVolumeStatus(&out),
 	})
-	t.AddStep(&step.InstallFile{ // install tools.conf
-		Content:           &toolsConf,
+	t.AddStep(&step.RenderTemplate{ // install tools.conf
+		Template:          TEMPLATE_TOOLS_CONF,
+		Variables:         toolsConfVariables,
 		ContainerId:       &containerName,
 		ContainerDestPath: "/etc/curve/tools.conf",
 		ExecOptions:       curveadm.ExecOptions(),
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	os "github.com/opencurve/curveadm/internal/configure/os"
 	"github.com/opencurve/curveadm/internal/configure/topology"
@@ -50,6 +51,15 @@ const (
 
 	// 82511eb8-e4e3-4a50-a736-d584fbf533fa
 	REEGX_DEVICE_UUID = "^.{8}-.{4}-.{4}-.{4}-.{12}$"
+
+	FORMAT_POLL_INTERVAL = 5 * time.Second
+
+	// count chunks which already allocated in chunkfile pool
+	CMD_COUNT_CHUNKS = "bash -c 'ls %s/%s 2>/dev/null | wc -l'"
+
+	// sample used bytes of disk twice for write rate, see parseWriteRate
+	CMD_SAMPLE_DISK_USED        = "bash -c 'df -B1 --output=used,size %[1]s 2>/dev/null | tail -1; sleep %[2]d; df -B1 --output=used,size %[1]s 2>/dev/null | tail -1'"
+	FORMAT_RATE_SAMPLE_INTERVAL = 1 // seconds
 )
 
 type (
@@ -62,6 +72,12 @@ type (
 		skipAdd    bool
 		curveadm   *cli.CurveAdm
 	}
+
+	step2WaitFormatDone struct {
+		fc            *configure.FormatConfig
+		containerName string
+		curveadm      *cli.CurveAdm
+	}
 )
 
 func skipFormat(output *string, name string) step.LambdaType {
@@ -163,6 +179,55 @@ func (s *step2EditFSTab) Execute(ctx *context.Context) error {
 	})
 }
 
+/*
+ * output of `df --output=pcent`:
+ *   Use%
+ *     1%
+ */
+func parseDeviceUsage(out string) (int, bool) {
+	lines := strings.Split(out, "\n")
+	if len(lines) < 2 {
+		return 0, false
+	}
+	usage := strings.TrimSuffix(strings.TrimSpace(lines[1]), "%")
+	return utils.Str2Int(usage)
+}
+
+// the formatting container will be removed after chunkfile pool formatted
+func (s *step2WaitFormatDone) Execute(ctx *context.Context) error {
+	var names, usage string
+	options := s.curveadm.ExecOptions()
+	for {
+		steps := []task.Step{
+			&step.ListContainers{
+				ShowAll:     true,
+				Format:      "'{{.Names}}'",
+				Filter:      fmt.Sprintf("name=%s", s.containerName),
+				Out:         &names,
+				ExecOptions: options,
+			},
+			&step.ShowDiskFree{
+				Files:       []string{s.fc.GetMountPoint()},
+				Format:      "pcent",
+				Out:         &usage,
+				ExecOptions: options,
+			},
+		}
+		for _, step := range steps {
+			if err := step.Execute(ctx); err != nil {
+				return err
+			}
+		}
+
+		if names != s.containerName {
+			return nil
+		} else if n, ok := parseDeviceUsage(usage); ok {
+			ctx.Progress().Set("formatted %d/%d%%", n, s.fc.GetFormatPercent())
+		}
+		time.Sleep(FORMAT_POLL_INTERVAL)
+	}
+}
+
 func device2ContainerName(device string) string {
 	return fmt.Sprintf("curvebs-format-%s", utils.MD5Sum(device))
 }
@@ -247,10 +312,18 @@ func NewFormatChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConf
 		ExecOptions:              curveadm.ExecOptions(),
 	})
 	// 3: run container to format chunkfile pool
-	t.AddStep(&step.PullImage{
-		Image:       fc.GetContainerImage(),
-		ExecOptions: curveadm.ExecOptions(),
-	})
+	if fc.GetImageSource() == topology.IMAGE_SOURCE_LOCAL {
+		t.AddStep(&step.LoadImage{
+			Image:       fc.GetContainerImage(),
+			Archive:     curveadm.ImageArchivePath(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	} else {
+		t.AddStep(&step.PullImage{
+			Image:       fc.GetContainerImage(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step.CreateContainer{
 		Image:       fc.GetContainerImage(),
 		Command:     formatCommand,
@@ -271,6 +344,14 @@ func NewFormatChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConf
 		ContainerId: &containerId,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	// 4: occupy the host's concurrency until formatted if required
+	if v := curveadm.MemStorage().Get(comm.KEY_FORMAT_WAIT_DONE); v != nil && v.(bool) {
+		t.AddStep(&step2WaitFormatDone{
+			fc:            fc,
+			containerName: containerName,
+			curveadm:      curveadm,
+		})
+	}
 
 	return t, nil
 }
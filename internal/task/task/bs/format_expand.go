@@ -0,0 +1,202 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+)
+
+type step2CreateExpandContainer struct {
+	fc          *configure.FormatConfig
+	chunks      *int64
+	containerId *string
+	curveadm    *cli.CurveAdm
+}
+
+// output of `df -B1 --output=used,size`: "Used 1B-blocks\n 1024 4096"
+func parseDiskUsedAndSize(out string) (uint64, uint64, bool) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+
+	used, err1 := strconv.ParseUint(fields[0], 10, 64)
+	size, err2 := strconv.ParseUint(fields[1], 10, 64)
+	return used, size, err1 == nil && err2 == nil
+}
+
+/*
+ * the number of chunks which need to append for reaching format percent:
+ *   (size * percent / 100 - used) / (chunk size + chunk header size)
+ *
+ * NOTE: the used space includes chunks which already in pool or used by chunkserver
+ */
+func calcExpandChunks(used, size uint64, percent, chunkSize int) int64 {
+	expect := size / 100 * uint64(percent)
+	if expect <= used {
+		return 0
+	}
+	return int64((expect - used) / uint64(chunkSize+DEFAULT_CHUNKFILE_HEADER_SIZE))
+}
+
+func checkDeviceMounted(fc *configure.FormatConfig, success *bool) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success {
+			return errno.ERR_CHUNKFILE_POOL_DEVICE_NOT_MOUNTED.
+				F("host=%s device=%s mountPoint=%s", fc.GetHost(), fc.GetDevice(), fc.GetMountPoint())
+		}
+		return nil
+	}
+}
+
+func computeExpandChunks(fc *configure.FormatConfig, out *string, chunks *int64) step.LambdaType {
+	return func(ctx *context.Context) error {
+		used, size, ok := parseDiskUsedAndSize(*out)
+		if !ok {
+			return errno.ERR_INVALID_DEVICE_USAGE.
+				F("device usage: %s", *out)
+		}
+
+		*chunks = calcExpandChunks(used, size, fc.GetFormatPercent(), fc.GetChunkSize())
+		if *chunks <= 0 { // already reach format percent
+			return task.ERR_SKIP_TASK
+		}
+		return nil
+	}
+}
+
+func (s *step2CreateExpandContainer) Execute(ctx *context.Context) error {
+	fc := s.fc
+	layout := topology.GetCurveBSProjectLayout()
+	formatScriptPath := fmt.Sprintf("%s/format.sh", layout.ToolsBinDir)
+	formatCommand := fmt.Sprintf("%s %s %d %d %s %s %d %d", formatScriptPath, layout.FormatBinaryPath,
+		fc.GetFormatPercent(), fc.GetChunkSize(), layout.ChunkfilePoolDir, layout.ChunkfilePoolMetaPath,
+		fc.GetBlockSize(), *s.chunks)
+
+	return (&step.CreateContainer{
+		Image:       fc.GetContainerImage(),
+		Command:     formatCommand,
+		Entrypoint:  "/bin/bash",
+		Name:        device2ContainerName(fc.GetDevice()),
+		Remove:      true,
+		Volumes:     []step.Volume{{HostPath: fc.GetMountPoint(), ContainerPath: layout.ChunkfilePoolRootDir}},
+		Out:         s.containerId,
+		ExecOptions: s.curveadm.ExecOptions(),
+	}).Execute(ctx)
+}
+
+func NewExpandChunkfilePoolTask(curveadm *cli.CurveAdm, fc *configure.FormatConfig) (*task.Task, error) {
+	host := fc.GetHost()
+	hc, err := curveadm.GetHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	device := fc.GetDevice()
+	mountPoint := fc.GetMountPoint()
+	subname := fmt.Sprintf("host=%s device=%s mountPoint=%s usage=%d%%",
+		host, device, mountPoint, fc.GetFormatPercent())
+	t := task.NewTask("Expand Chunkfile Pool", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var output, containerId, diskFree string
+	var success bool
+	var chunks int64
+	containerName := device2ContainerName(device)
+	layout := topology.GetCurveBSProjectLayout()
+	formatScript := scripts.FORMAT
+	formatScriptPath := fmt.Sprintf("%s/format.sh", layout.ToolsBinDir)
+
+	// 1: skip if formating container exist
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.Names}}'",
+		Filter:      fmt.Sprintf("name=%s", containerName),
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: skipFormat(&output, containerName),
+	})
+	// 2: compute chunks to append, the device must be formatted before
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf("mountpoint -q %s", mountPoint),
+		Success:     &success,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkDeviceMounted(fc, &success),
+	})
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf("df -B1 --output=used,size %s", mountPoint),
+		Out:         &diskFree,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: computeExpandChunks(fc, &diskFree, &chunks),
+	})
+	// 3: run container to append chunks, chunkserver keeps online
+	if fc.GetImageSource() == topology.IMAGE_SOURCE_LOCAL {
+		t.AddStep(&step.LoadImage{
+			Image:       fc.GetContainerImage(),
+			Archive:     curveadm.ImageArchivePath(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	} else {
+		t.AddStep(&step.PullImage{
+			Image:       fc.GetContainerImage(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	t.AddStep(&step2CreateExpandContainer{
+		fc:          fc,
+		chunks:      &chunks,
+		containerId: &containerId,
+		curveadm:    curveadm,
+	})
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &containerId,
+		ContainerDestPath: formatScriptPath,
+		Content:           &formatScript,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.StartContainer{
+		ContainerId: &containerId,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
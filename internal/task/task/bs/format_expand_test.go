@@ -0,0 +1,52 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiskUsedAndSize(t *testing.T) {
+	assert := assert.New(t)
+
+	used, size, ok := parseDiskUsedAndSize("     Used     1B-blocks\n1073741824 10737418240\n")
+	assert.True(ok)
+	assert.Equal(uint64(1073741824), used)
+	assert.Equal(uint64(10737418240), size)
+
+	_, _, ok = parseDiskUsedAndSize("df: /data/chunkserver0: No such file or directory")
+	assert.False(ok)
+}
+
+func TestCalcExpandChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	chunkSize := 16 * 1024 * 1024
+	size := uint64(100) * uint64(chunkSize+DEFAULT_CHUNKFILE_HEADER_SIZE)
+	used := size / 100 * 10
+	assert.Equal(int64(10), calcExpandChunks(used, size, 20, chunkSize))
+	assert.Equal(int64(0), calcExpandChunks(used, size, 10, chunkSize))
+	assert.Equal(int64(0), calcExpandChunks(used, size, 5, chunkSize))
+}
@@ -0,0 +1,190 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+type step2MountFormattedDevice struct {
+	fc       *configure.FormatConfig
+	mounted  *bool
+	curveadm *cli.CurveAdm
+}
+
+func addUnformattedDisk(memStorage *utils.SafeMap, fc *configure.FormatConfig) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		disks := map[string]bool{}
+		if v := kv.Get(comm.KEY_FORMAT_UNFORMATTED_DISKS); v != nil {
+			disks = v.(map[string]bool)
+		}
+		disks[fmt.Sprintf("%s:%s", fc.GetHost(), fc.GetDevice())] = true
+		kv.Set(comm.KEY_FORMAT_UNFORMATTED_DISKS, disks)
+		return nil
+	})
+}
+
+/*
+ * the device maybe unmounted after host rebooted, we mount it again if
+ * it has filesystem, otherwise it never formatted and we skip it,
+ * and it will be formatted from scratch later.
+ */
+func (s *step2MountFormattedDevice) Execute(ctx *context.Context) error {
+	if *s.mounted {
+		return nil
+	}
+
+	var uuid string
+	var success bool
+	options := s.curveadm.ExecOptions()
+	err := (&step.BlockId{
+		Device:      s.fc.GetDevice(),
+		Format:      "value",
+		MatchTag:    "UUID",
+		Success:     &success,
+		Out:         &uuid,
+		ExecOptions: options,
+	}).Execute(ctx)
+	if err != nil {
+		return err
+	} else if !success || len(uuid) == 0 {
+		addUnformattedDisk(s.curveadm.MemStorage(), s.fc)
+		return task.ERR_SKIP_TASK
+	}
+
+	steps := []task.Step{
+		&step.CreateDirectory{
+			Paths:       []string{s.fc.GetMountPoint()},
+			ExecOptions: options,
+		},
+		&step.MountFilesystem{
+			Source:        s.fc.GetDevice(),
+			Directory:     s.fc.GetMountPoint(),
+			IgnoreMounted: true, // the fstab entry may mount it meanwhile
+			ExecOptions:   options,
+		},
+	}
+	for _, step := range steps {
+		if err := step.Execute(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewResumeFormatTask(curveadm *cli.CurveAdm, fc *configure.FormatConfig) (*task.Task, error) {
+	host := fc.GetHost()
+	hc, err := curveadm.GetHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	device := fc.GetDevice()
+	mountPoint := fc.GetMountPoint()
+	subname := fmt.Sprintf("host=%s device=%s mountPoint=%s usage=%d%%",
+		host, device, mountPoint, fc.GetFormatPercent())
+	t := task.NewTask("Resume Format Chunkfile Pool", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var output, containerId, diskFree string
+	var mounted bool
+	var chunks int64
+	containerName := device2ContainerName(device)
+	layout := topology.GetCurveBSProjectLayout()
+	formatScript := scripts.FORMAT
+	formatScriptPath := fmt.Sprintf("%s/format.sh", layout.ToolsBinDir)
+
+	// 1: skip if formating container exist, it's still formatting
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.Names}}'",
+		Filter:      fmt.Sprintf("name=%s", containerName),
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: skipFormat(&output, containerName),
+	})
+	// 2: make sure the formatted device mounted
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf("mountpoint -q %s", mountPoint),
+		Success:     &mounted,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2MountFormattedDevice{
+		fc:       fc,
+		mounted:  &mounted,
+		curveadm: curveadm,
+	})
+	// 3: compute the remainder chunks, skip if already done
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf("df -B1 --output=used,size %s", mountPoint),
+		Out:         &diskFree,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: computeExpandChunks(fc, &diskFree, &chunks),
+	})
+	// 4: run container to format the remainder
+	if fc.GetImageSource() == topology.IMAGE_SOURCE_LOCAL {
+		t.AddStep(&step.LoadImage{
+			Image:       fc.GetContainerImage(),
+			Archive:     curveadm.ImageArchivePath(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	} else {
+		t.AddStep(&step.PullImage{
+			Image:       fc.GetContainerImage(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	t.AddStep(&step2CreateExpandContainer{
+		fc:          fc,
+		chunks:      &chunks,
+		containerId: &containerId,
+		curveadm:    curveadm,
+	})
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &containerId,
+		ContainerDestPath: formatScriptPath,
+		Content:           &formatScript,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.StartContainer{
+		ContainerId: &containerId,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
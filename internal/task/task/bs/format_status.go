@@ -26,10 +26,11 @@ import (
 	"fmt"
 	"strings"
 
-	comm "github.com/opencurve/curveadm/internal/common"
-	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
@@ -41,6 +42,8 @@ type (
 		config          *configure.FormatConfig
 		deviceUsage     *string
 		containerStatus *string
+		chunks          *string
+		usedSamples     *string
 		containerName   string
 		memStorage      *utils.SafeMap
 	}
@@ -51,9 +54,36 @@ type (
 		MountPoint string
 		Formatted  string // 85/90
 		Status     string // Done, Mounting, Pulling image, Formating
+		Usage      int    // 85
+		Percent    int    // 90
+		Chunks     int    // allocated chunks in chunkfile pool
+		Written    uint64 // used bytes of disk
+		Size       uint64 // total bytes of disk
+		Rate       uint64 // written bytes per second
 	}
 )
 
+/*
+ * usedSamples: "used size" sampled twice by the interval
+ *   1073741824 10737418240
+ *   1199570944 10737418240
+ */
+func parseWriteRate(usedSamples string, interval int) (written, size, rate uint64, ok bool) {
+	lines := strings.Split(strings.TrimSpace(usedSamples), "\n")
+	if len(lines) != 2 || interval <= 0 {
+		return 0, 0, 0, false
+	}
+
+	before, _, ok1 := parseDiskUsedAndSize(lines[0])
+	written, size, ok2 := parseDiskUsedAndSize(lines[1])
+	if !ok1 || !ok2 {
+		return 0, 0, 0, false
+	} else if written > before {
+		rate = (written - before) / uint64(interval)
+	}
+	return written, size, rate, true
+}
+
 func setFormatStatus(memStorage *utils.SafeMap, id string, status FormatStatus) {
 	memStorage.TX(func(kv *utils.SafeMap) error {
 		m := map[string]FormatStatus{}
@@ -101,6 +131,9 @@ func (s *step2FormatStatus) Execute(ctx *context.Context) error {
 		status = "Pulling image"
 	}
 
+	chunks, _ := utils.Str2Int(strings.TrimSpace(*s.chunks))
+	// bytes and rate are informative, they are zero if sampling failed
+	written, size, rate, _ := parseWriteRate(*s.usedSamples, FORMAT_RATE_SAMPLE_INTERVAL)
 	id := fmt.Sprintf("%s:%s", host, device)
 	setFormatStatus(s.memStorage, id, FormatStatus{
 		Host:       host,
@@ -108,6 +141,12 @@ func (s *step2FormatStatus) Execute(ctx *context.Context) error {
 		MountPoint: mountPoint,
 		Formatted:  formated,
 		Status:     status,
+		Usage:      usage,
+		Percent:    s.config.GetFormatPercent(),
+		Chunks:     chunks,
+		Written:    written,
+		Size:       size,
+		Rate:       rate,
 	})
 	return nil
 }
@@ -125,7 +164,7 @@ func NewGetFormatStatusTask(curveadm *cli.CurveAdm, fc *configure.FormatConfig)
 	t := task.NewTask("Get Format Status", subname, hc.GetSSHConfig())
 
 	// add step to task
-	var deviceUsage, containerStatus string
+	var deviceUsage, containerStatus, chunks, usedSamples string
 	containerName := device2ContainerName(device)
 	t.AddStep(&step.ShowDiskFree{
 		Files:       []string{device},
@@ -140,10 +179,22 @@ func NewGetFormatStatusTask(curveadm *cli.CurveAdm, fc *configure.FormatConfig)
 		Out:         &containerStatus,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf(CMD_COUNT_CHUNKS, fc.GetMountPoint(), topology.LAYOUT_CURVEBS_CHUNKFILE_POOL_DIR),
+		Out:         &chunks,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf(CMD_SAMPLE_DISK_USED, device, FORMAT_RATE_SAMPLE_INTERVAL),
+		Out:         &usedSamples,
+		ExecOptions: curveadm.ExecOptions(),
+	})
 	t.AddStep(&step2FormatStatus{
 		config:          fc,
 		deviceUsage:     &deviceUsage,
 		containerStatus: &containerStatus,
+		chunks:          &chunks,
+		usedSamples:     &usedSamples,
 		containerName:   containerName,
 		memStorage:      curveadm.MemStorage(),
 	})
@@ -0,0 +1,49 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWriteRate(t *testing.T) {
+	assert := assert.New(t)
+
+	written, size, rate, ok := parseWriteRate("1073741824 10737418240\n1199570944 10737418240\n", 1)
+	assert.True(ok)
+	assert.Equal(uint64(1199570944), written)
+	assert.Equal(uint64(10737418240), size)
+	assert.Equal(uint64(125829120), rate)
+
+	// used space shrinks, e.g. chunks deleted
+	_, _, rate, ok = parseWriteRate("2048 4096\n1024 4096", 1)
+	assert.True(ok)
+	assert.Equal(uint64(0), rate)
+
+	_, _, _, ok = parseWriteRate("1024 4096", 1)
+	assert.False(ok)
+	_, _, _, ok = parseWriteRate("", 1)
+	assert.False(ok)
+}
@@ -106,6 +106,10 @@ func NewInstallPolarFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (
 	subname := fmt.Sprintf("host=%s release=%s", host, release)
 	t := task.NewTask("Install PolarFS", subname, hc.GetSSHConfig())
 
+	// download package through HTTP proxy of host if any
+	downloadOptions := curveadm.ExecOptions()
+	downloadOptions.ExecWithProxy = true
+
 	// add step to task
 	var input, output string
 	randStr := utils.RandString(10)
@@ -121,6 +125,11 @@ func NewInstallPolarFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (
 		Insecure:    true,
 		Output:      tarball,
 		Silent:      true,
+		ExecOptions: downloadOptions,
+	})
+	t.AddStep(&step.VerifyChecksum{
+		Url:         URL_POLARFS_PACKAGE,
+		Path:        tarball,
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.Tar{
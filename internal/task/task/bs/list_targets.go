@@ -96,7 +96,7 @@ func (s *step2FormatTarget) Execute(ctx *context.Context) error {
 				Tid:    mu[1],
 				Name:   mu[2],
 				Store:  "-",
-				Portal: fmt.Sprintf("%s:%d", s.hostname, DEFAULT_TGTD_LISTEN_PORT),
+				Portal: utils.JoinHostPort(s.hostname, DEFAULT_TGTD_LISTEN_PORT),
 			}
 			addTarget(s.memStorage, mu[1], target)
 			continue
@@ -23,12 +23,14 @@
 package bs
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/scripts"
@@ -40,6 +42,13 @@ const (
 	TOOLS_V2_CONFIG_DELIMITER = ": "
 	TOOLS_V2_CONFIG_SRC_PATH  = "/curvebs/conf/curve.yaml"
 	TOOLS_V2_CONFIG_DEST_PATH = "/etc/curve/curve.yaml"
+
+	THROTTLE_TYPE_IOPS_READ  = "IOPS_READ"
+	THROTTLE_TYPE_IOPS_WRITE = "IOPS_WRITE"
+	THROTTLE_TYPE_BPS_READ   = "BPS_READ"
+	THROTTLE_TYPE_BPS_WRITE  = "BPS_WRITE"
+
+	FORMAT_UPDATE_THROTTLE = "%s bs update throttle --path %s --user %s --type %s --limit %d"
 )
 
 type (
@@ -51,6 +60,13 @@ type (
 		Size        int
 		NoExclusive bool
 		Poolset     string
+		QoS         configure.ClientQoS
+	}
+
+	step2SetVolumeThrottle struct {
+		containerId string
+		commands    []string
+		curveadm    *cli.CurveAdm
 	}
 )
 
@@ -63,6 +79,98 @@ func checkMapStatus(success *bool, out *string) step.LambdaType {
 	}
 }
 
+func parseMapDevice(out string) string {
+	items := strings.Fields(out)
+	if len(items) < 2 {
+		return ""
+	}
+	return items[1]
+}
+
+func setClientDevice(curveadm *cli.CurveAdm, options MapOptions, throttled bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		volumeId := curveadm.GetVolumeId(options.Host, options.User, options.Volume)
+		auxInfo := &AuxInfo{
+			User:      options.User,
+			Volume:    options.Volume,
+			Poolset:   options.Poolset,
+			Device:    parseMapDevice(*out),
+			Throttled: throttled,
+		}
+		bytes, err := json.Marshal(auxInfo)
+		if err != nil {
+			return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
+		}
+
+		err = curveadm.Storage().SetClientAuxInfo(volumeId, string(bytes))
+		if err != nil {
+			return errno.ERR_SET_CLIENT_AUX_INFO_FAILED.E(err)
+		}
+		return nil
+	}
+}
+
+type throttleLimit struct {
+	throttleType string
+	limit        int
+}
+
+func getThrottleLimits(qos configure.ClientQoS) []throttleLimit {
+	return []throttleLimit{
+		{THROTTLE_TYPE_IOPS_READ, qos.ReadIOPS},
+		{THROTTLE_TYPE_IOPS_WRITE, qos.WriteIOPS},
+		{THROTTLE_TYPE_BPS_READ, qos.ReadBPS},
+		{THROTTLE_TYPE_BPS_WRITE, qos.WriteBPS},
+	}
+}
+
+/*
+ * the throttle is stored in MDS as attribute of volume, so it takes effect
+ * for all clients of the volume and outlives the client, we reset it (limit
+ * 0 means unlimited) when the volume unmapped, see NewUnmapTask.
+ */
+func getThrottleCommands(user, volume string, qos configure.ClientQoS) []string {
+	binaryPath := topology.GetCurveBSProjectLayout().ToolsV2BinaryPath
+	commands := []string{}
+	for _, item := range getThrottleLimits(qos) {
+		if item.limit > 0 {
+			commands = append(commands, fmt.Sprintf(FORMAT_UPDATE_THROTTLE,
+				binaryPath, volume, user, item.throttleType, item.limit))
+		}
+	}
+	return commands
+}
+
+func getResetThrottleCommands(user, volume string) []string {
+	binaryPath := topology.GetCurveBSProjectLayout().ToolsV2BinaryPath
+	commands := []string{}
+	for _, item := range getThrottleLimits(configure.ClientQoS{}) {
+		commands = append(commands, fmt.Sprintf(FORMAT_UPDATE_THROTTLE,
+			binaryPath, volume, user, item.throttleType, 0))
+	}
+	return commands
+}
+
+func (s *step2SetVolumeThrottle) Execute(ctx *context.Context) error {
+	for _, command := range s.commands {
+		var out string
+		var success bool
+		err := (&step.ContainerExec{
+			ContainerId: &s.containerId,
+			Command:     command,
+			Success:     &success,
+			Out:         &out,
+			ExecOptions: s.curveadm.ExecOptions(),
+		}).Execute(ctx)
+		if err != nil {
+			return err
+		} else if !success {
+			return errno.ERR_SET_VOLUME_THROTTLE_FAILED.S(out)
+		}
+	}
+	return nil
+}
+
 func getMapOptions(options MapOptions) string {
 	mapOptions := []string{}
 	if options.NoExclusive {
@@ -140,6 +248,17 @@ func NewMapTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task,
 	t.AddStep(&step.Lambda{
 		Lambda: checkMapStatus(&success, &out),
 	})
+	qos := cc.GetQoS().Override(options.QoS)
+	t.AddStep(&step.Lambda{
+		Lambda: setClientDevice(curveadm, options, qos.Enabled(), &out),
+	})
+	if qos.Enabled() {
+		t.AddStep(&step2SetVolumeThrottle{
+			containerId: containerId,
+			commands:    getThrottleCommands(options.User, options.Volume, qos),
+			curveadm:    curveadm,
+		})
+	}
 
 	return t, nil
 }
@@ -0,0 +1,60 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMapDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("/dev/nbd0", parseMapDevice("SUCCESS /dev/nbd0"))
+	assert.Equal("/dev/nbd12", parseMapDevice("SUCCESS /dev/nbd12\n"))
+	assert.Equal("", parseMapDevice("SUCCESS "))
+	assert.Equal("", parseMapDevice(""))
+}
+
+func TestGetThrottleCommands(t *testing.T) {
+	assert := assert.New(t)
+
+	commands := getThrottleCommands("curve", "/test", configure.ClientQoS{})
+	assert.Len(commands, 0)
+
+	commands = getThrottleCommands("curve", "/test", configure.ClientQoS{
+		WriteIOPS: 1000,
+		ReadBPS:   104857600,
+	})
+	assert.Equal([]string{
+		"/curvebs/tools-v2/sbin/curve bs update throttle --path /test --user curve --type IOPS_WRITE --limit 1000",
+		"/curvebs/tools-v2/sbin/curve bs update throttle --path /test --user curve --type BPS_READ --limit 104857600",
+	}, commands)
+
+	// reset all throttle types when unmap
+	commands = getResetThrottleCommands("curve", "/test")
+	assert.Len(commands, 4)
+	assert.Equal("/curvebs/tools-v2/sbin/curve bs update throttle --path /test --user curve --type IOPS_READ --limit 0", commands[0])
+}
@@ -0,0 +1,304 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	SNAPSHOTCLONE_LIST_LIMIT = 1000
+
+	JOB_TYPE_SNAPSHOT = "snapshot"
+	JOB_TYPE_CLONE    = "clone"
+	JOB_TYPE_RECOVER  = "recover"
+)
+
+var (
+	// see also: curve/src/snapshotcloneserver/common/define.h
+	SNAPSHOT_STATUS = map[int]string{
+		0: "done",
+		1: "pending",
+		2: "deleting",
+		3: "errorDeleting",
+		4: "canceling",
+		5: "error",
+	}
+
+	CLONE_STATUS = map[int]string{
+		0: "done",
+		1: "cloning",
+		2: "recovering",
+		3: "cleaning",
+		4: "errorCleaning",
+		5: "error",
+		6: "retrying",
+		7: "metaInstalled",
+	}
+)
+
+type (
+	// SnapshotCloneJob is a snapshot or clone task which not finished yet
+	SnapshotCloneJob struct {
+		UUID     string
+		Type     string
+		User     string
+		Source   string // the volume snapshotted or the source of clone
+		Target   string // the snapshot name or the destination of clone
+		Progress int
+		Status   string
+		Time     int64
+	}
+
+	SnapshotCloneStatus struct {
+		Id       string
+		Host     string
+		Addr     string // ip:port
+		Running  bool   // http service answering
+		Pending  int    // in-flight snapshots
+		Cloning  int    // in-flight clones (queue depth)
+		Failed   int    // failed snapshots and clones
+		Jobs     []SnapshotCloneJob
+		ErrorMsg string
+	}
+
+	snapshotListResponse struct {
+		Code      string `json:"Code"`
+		Message   string `json:"Message"`
+		Snapshots []struct {
+			File     string `json:"File"`
+			Name     string `json:"Name"`
+			Progress int    `json:"Progress"`
+			Status   int    `json:"Status"`
+			Time     int64  `json:"Time"`
+			UUID     string `json:"UUID"`
+			User     string `json:"User"`
+		} `json:"Snapshots"`
+	}
+
+	cloneListResponse struct {
+		Code      string `json:"Code"`
+		Message   string `json:"Message"`
+		TaskInfos []struct {
+			File       string `json:"File"`
+			Src        string `json:"Src"`
+			Progress   int    `json:"Progress"`
+			TaskType   int    `json:"TaskType"` // 0: clone, 1: recover
+			TaskStatus int    `json:"TaskStatus"`
+			Time       int64  `json:"Time"`
+			UUID       string `json:"UUID"`
+			User       string `json:"User"`
+		} `json:"TaskInfos"`
+	}
+)
+
+func getListCommand(dc *topology.DeployConfig, action, user string) string {
+	query := fmt.Sprintf("Limit=%d&Offset=0", SNAPSHOTCLONE_LIST_LIMIT)
+	if len(user) > 0 {
+		query = fmt.Sprintf("%s&User=%s", query, user)
+	}
+	return fmt.Sprintf("curl -g -s --connect-timeout 3 'http://%s/SnapshotCloneService?Action=%s&Version=%s&%s'",
+		utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()), action, SNAPSHOTCLONE_API_VERSION, query)
+}
+
+func isFinished(status string) bool {
+	return status == "done"
+}
+
+func isFailed(status string) bool {
+	return strings.HasPrefix(status, "error")
+}
+
+// ParseSnapshotList returns the unfinished snapshots, the number of pending and failed ones
+func ParseSnapshotList(out string) ([]SnapshotCloneJob, int, int, error) {
+	response := snapshotListResponse{}
+	err := json.Unmarshal([]byte(strings.TrimSpace(out)), &response)
+	if err != nil {
+		return nil, 0, 0, err
+	} else if response.Code != SNAPSHOTCLONE_CODE_OK {
+		return nil, 0, 0, fmt.Errorf("code: %s, message: %s", response.Code, response.Message)
+	}
+
+	jobs := []SnapshotCloneJob{}
+	pending, failed := 0, 0
+	for _, snapshot := range response.Snapshots {
+		status := utils.Choose(len(SNAPSHOT_STATUS[snapshot.Status]) > 0,
+			SNAPSHOT_STATUS[snapshot.Status], fmt.Sprintf("unknown(%d)", snapshot.Status))
+		if isFinished(status) {
+			continue
+		} else if isFailed(status) {
+			failed++
+		} else {
+			pending++
+		}
+		jobs = append(jobs, SnapshotCloneJob{
+			UUID:     snapshot.UUID,
+			Type:     JOB_TYPE_SNAPSHOT,
+			User:     snapshot.User,
+			Source:   snapshot.File,
+			Target:   snapshot.Name,
+			Progress: snapshot.Progress,
+			Status:   status,
+			Time:     snapshot.Time,
+		})
+	}
+	return jobs, pending, failed, nil
+}
+
+// ParseCloneList returns the unfinished clones, the number of cloning and failed ones
+func ParseCloneList(out string) ([]SnapshotCloneJob, int, int, error) {
+	response := cloneListResponse{}
+	err := json.Unmarshal([]byte(strings.TrimSpace(out)), &response)
+	if err != nil {
+		return nil, 0, 0, err
+	} else if response.Code != SNAPSHOTCLONE_CODE_OK {
+		return nil, 0, 0, fmt.Errorf("code: %s, message: %s", response.Code, response.Message)
+	}
+
+	jobs := []SnapshotCloneJob{}
+	cloning, failed := 0, 0
+	for _, info := range response.TaskInfos {
+		status := utils.Choose(len(CLONE_STATUS[info.TaskStatus]) > 0,
+			CLONE_STATUS[info.TaskStatus], fmt.Sprintf("unknown(%d)", info.TaskStatus))
+		if isFinished(status) {
+			continue
+		} else if isFailed(status) {
+			failed++
+		} else {
+			cloning++
+		}
+		jobs = append(jobs, SnapshotCloneJob{
+			UUID:     info.UUID,
+			Type:     utils.Choose(info.TaskType == 1, JOB_TYPE_RECOVER, JOB_TYPE_CLONE),
+			User:     info.User,
+			Source:   info.Src,
+			Target:   info.File,
+			Progress: info.Progress,
+			Status:   status,
+			Time:     info.Time,
+		})
+	}
+	return jobs, cloning, failed, nil
+}
+
+func setSnapshotCloneStatus(curveadm *cli.CurveAdm, status SnapshotCloneStatus) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string]SnapshotCloneStatus{}
+		v := kv.Get(comm.KEY_ALL_SNAPSHOTCLONE_STATUS)
+		if v != nil {
+			m = v.(map[string]SnapshotCloneStatus)
+		}
+		m[status.Id] = status
+		kv.Set(comm.KEY_ALL_SNAPSHOTCLONE_STATUS, m)
+		return nil
+	})
+}
+
+func saveSnapshotCloneStatus(curveadm *cli.CurveAdm, status SnapshotCloneStatus,
+	success *bool, snapshots, clones *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		defer func() { setSnapshotCloneStatus(curveadm, status) }()
+		if !*success {
+			status.ErrorMsg = "http service not answering"
+			return nil
+		}
+
+		sjobs, pending, sfailed, err := ParseSnapshotList(*snapshots)
+		if err != nil {
+			status.ErrorMsg = err.Error()
+			return nil
+		}
+		cjobs, cloning, cfailed, err := ParseCloneList(*clones)
+		if err != nil {
+			status.ErrorMsg = err.Error()
+			return nil
+		}
+
+		status.Running = true
+		status.Pending = pending
+		status.Cloning = cloning
+		status.Failed = sfailed + cfailed
+		status.Jobs = append(sjobs, cjobs...)
+		return nil
+	}
+}
+
+func NewGetSnapshotCloneStatusTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Get SnapshotClone Status", subname, hc.GetSSHConfig())
+
+	// add step
+	var success bool
+	var snapshots, clones string
+	user, _ := curveadm.MemStorage().Get(comm.KEY_SNAPSHOTCLONE_USER).(string)
+	status := SnapshotCloneStatus{
+		Id:   dc.GetId(),
+		Host: dc.GetHost(),
+		Addr: utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()),
+	}
+	setSnapshotCloneStatus(curveadm, status)
+
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     getListCommand(dc, "ListSnapshot", user),
+		Success:     &success,
+		Out:         &snapshots,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     getListCommand(dc, "ListClone", user),
+		Success:     &success,
+		Out:         &clones,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: saveSnapshotCloneStatus(curveadm, status, &success, &snapshots, &clones),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSnapshotList(t *testing.T) {
+	assert := assert.New(t)
+
+	jobs, pending, failed, err := ParseSnapshotList(`{"Code":"0","Message":"Exec success.","Snapshots":[
+		{"File":"/test","Name":"snap1","Progress":100,"Status":0,"Time":1694300000000000,"UUID":"a","User":"curve"},
+		{"File":"/test","Name":"snap2","Progress":30,"Status":1,"Time":1694300000000000,"UUID":"b","User":"curve"},
+		{"File":"/test","Name":"snap3","Progress":0,"Status":5,"Time":1694300000000000,"UUID":"c","User":"curve"}]}`)
+	assert.Nil(err)
+	assert.Equal(1, pending)
+	assert.Equal(1, failed)
+	assert.Len(jobs, 2)
+	assert.Equal(JOB_TYPE_SNAPSHOT, jobs[0].Type)
+	assert.Equal("pending", jobs[0].Status)
+	assert.Equal("snap2", jobs[0].Target)
+	assert.Equal("error", jobs[1].Status)
+
+	_, _, _, err = ParseSnapshotList(`{"Code":"-1","Message":"Internal error."}`)
+	assert.NotNil(err)
+}
+
+func TestParseCloneList(t *testing.T) {
+	assert := assert.New(t)
+
+	jobs, cloning, failed, err := ParseCloneList(`{"Code":"0","Message":"Exec success.","TaskInfos":[
+		{"File":"/clone1","Src":"/test","Progress":100,"TaskType":0,"TaskStatus":0,"UUID":"a","User":"curve"},
+		{"File":"/clone2","Src":"/test","Progress":50,"TaskType":0,"TaskStatus":1,"UUID":"b","User":"curve"},
+		{"File":"/test","Src":"c","Progress":10,"TaskType":1,"TaskStatus":2,"UUID":"c","User":"curve"},
+		{"File":"/clone3","Src":"/test","Progress":0,"TaskType":0,"TaskStatus":9,"UUID":"d","User":"curve"}]}`)
+	assert.Nil(err)
+	assert.Equal(3, cloning)
+	assert.Equal(0, failed)
+	assert.Len(jobs, 3)
+	assert.Equal(JOB_TYPE_CLONE, jobs[0].Type)
+	assert.Equal("/clone2", jobs[0].Target)
+	assert.Equal(JOB_TYPE_RECOVER, jobs[1].Type)
+	assert.Equal("recovering", jobs[1].Status)
+	assert.Equal("unknown(9)", jobs[2].Status)
+
+	_, _, _, err = ParseCloneList("curl: (7) Failed to connect")
+	assert.NotNil(err)
+}
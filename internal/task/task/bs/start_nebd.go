@@ -51,13 +51,24 @@ type (
 	}
 
 	AuxInfo struct {
-		User    string `json:"user"`
-		Volume  string `json:"volume"`
-		Poolset string `json:"poolset"`
-		Config  string `json:"config,omitempty"` // TODO(P1)
+		User      string `json:"user"`
+		Volume    string `json:"volume"`
+		Poolset   string `json:"poolset"`
+		Device    string `json:"device,omitempty"`
+		Throttled bool   `json:"throttled,omitempty"` // volume throttle set by map
+		Config    string `json:"config,omitempty"`    // TODO(P1)
 	}
 )
 
+func DecodeAuxInfo(data string) (*AuxInfo, error) {
+	auxInfo := &AuxInfo{}
+	err := json.Unmarshal([]byte(data), auxInfo)
+	if err != nil {
+		return nil, errno.ERR_DECODE_VOLUME_INFO_FAILED.E(err)
+	}
+	return auxInfo, nil
+}
+
 func formatImage(user, volume string) string {
 	return fmt.Sprintf("cbd:pool/%s_%s_", volume, user)
 }
@@ -48,6 +48,13 @@ type (
 		execOptions module.ExecOptions
 	}
 
+	step2ResetVolumeThrottle struct {
+		output   *string
+		user     string
+		volume   string
+		curveadm *cli.CurveAdm
+	}
+
 	step2RemoveContainer struct {
 		curveadm    *cli.CurveAdm
 		status      *string
@@ -93,6 +100,36 @@ func (s *step2UnmapImage) Execute(ctx *context.Context) error {
 	return errno.ERR_UNMAP_VOLUME_FAILED.S(out)
 }
 
+// reset the volume throttle which set by map, the tools is in the client container
+func (s *step2ResetVolumeThrottle) Execute(ctx *context.Context) error {
+	items := strings.Split(*s.output, " ")
+	if len(items) < 2 || !strings.HasPrefix(items[1], "Up") {
+		return nil
+	}
+
+	return (&step2SetVolumeThrottle{
+		containerId: items[0],
+		commands:    getResetThrottleCommands(s.user, s.volume),
+		curveadm:    s.curveadm,
+	}).Execute(ctx)
+}
+
+// whether the volume throttle was set when the volume mapped
+func isVolumeThrottled(curveadm *cli.CurveAdm, volumeId string) (bool, error) {
+	clients, err := curveadm.Storage().GetClient(volumeId)
+	if err != nil {
+		return false, errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(clients) == 0 || len(clients[0].AuxInfo) == 0 {
+		return false, nil
+	}
+
+	auxInfo, err := DecodeAuxInfo(clients[0].AuxInfo)
+	if err != nil {
+		return false, err
+	}
+	return auxInfo.Throttled, nil
+}
+
 func (s *step2RemoveContainer) Execute(ctx *context.Context) error {
 	if len(*s.status) == 0 {
 		return nil
@@ -141,6 +178,10 @@ func NewUnmapTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 	if err != nil {
 		return nil, err
 	}
+	throttled, err := isVolumeThrottled(curveadm, volumeId)
+	if err != nil {
+		return nil, err
+	}
 
 	subname := fmt.Sprintf("hostname=%s volume=%s:%s containerId=%s",
 		hc.GetHostname(), options.User, options.Volume, tui.TrimContainerId(containerId))
@@ -158,6 +199,14 @@ func NewUnmapTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 		Out:         &output,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	if throttled {
+		t.AddStep(&step2ResetVolumeThrottle{
+			output:   &output,
+			user:     options.User,
+			volume:   options.Volume,
+			curveadm: curveadm,
+		})
+	}
 	t.AddStep(&step2UnmapImage{
 		output:      &output,
 		user:        options.User,
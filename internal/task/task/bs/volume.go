@@ -0,0 +1,183 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	VOLUME_ACTION_CREATE   = "create"
+	VOLUME_ACTION_EXTEND   = "extend"
+	VOLUME_ACTION_DELETE   = "delete"
+	VOLUME_ACTION_LIST     = "list"
+	VOLUME_ACTION_SNAPSHOT = "snapshot"
+	VOLUME_ACTION_CLONE    = "clone"
+
+	// tools-v2 in mds container
+	FORMAT_CREATE_FILE = "%s bs create file --path %s --user %s --size %dGiB"
+	FORMAT_EXTEND_FILE = "%s bs update file --path %s --user %s --size %dGiB"
+	FORMAT_DELETE_FILE = "%s bs delete file --path %s --user %s"
+	FORMAT_LIST_DIR    = "%s bs list dir --path %s --user %s"
+
+	// HTTP API of snapshotclone service
+	SNAPSHOTCLONE_API_VERSION = "0.0.6"
+	FORMAT_SNAPSHOTCLONE_API  = "curl -g -s 'http://%s/SnapshotCloneService?Action=%s&Version=%s&User=%s&%s'"
+	SNAPSHOTCLONE_CODE_OK     = "0"
+)
+
+type (
+	VolumeOptions struct {
+		Action string
+		User   string
+		Volume string
+		Size   int    // GiB, for create and extend
+		Name   string // snapshot name
+		Source string // clone source, volume or snapshot uuid
+		Lazy   bool   // lazy clone
+	}
+
+	snapshotCloneResponse struct {
+		Code      string `json:"Code"`
+		Message   string `json:"Message"`
+		RequestId string `json:"RequestId"`
+		UUID      string `json:"UUID"`
+	}
+)
+
+// GetVolumeRole returns the role of service which the volume action performed in
+func GetVolumeRole(action string) string {
+	switch action {
+	case VOLUME_ACTION_SNAPSHOT, VOLUME_ACTION_CLONE:
+		return topology.ROLE_SNAPSHOTCLONE
+	}
+	return topology.ROLE_MDS
+}
+
+func getVolumeCommand(dc *topology.DeployConfig, options VolumeOptions) (string, error) {
+	binaryPath := dc.GetProjectLayout().ToolsV2BinaryPath
+	user, volume := options.User, options.Volume
+	switch options.Action {
+	case VOLUME_ACTION_CREATE:
+		return fmt.Sprintf(FORMAT_CREATE_FILE, binaryPath, volume, user, options.Size), nil
+	case VOLUME_ACTION_EXTEND:
+		return fmt.Sprintf(FORMAT_EXTEND_FILE, binaryPath, volume, user, options.Size), nil
+	case VOLUME_ACTION_DELETE:
+		return fmt.Sprintf(FORMAT_DELETE_FILE, binaryPath, volume, user), nil
+	case VOLUME_ACTION_LIST:
+		return fmt.Sprintf(FORMAT_LIST_DIR, binaryPath, volume, user), nil
+	case VOLUME_ACTION_SNAPSHOT:
+		query := fmt.Sprintf("File=%s&Name=%s", volume, options.Name)
+		return fmt.Sprintf(FORMAT_SNAPSHOTCLONE_API, utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()),
+			"CreateSnapshot", SNAPSHOTCLONE_API_VERSION, user, query), nil
+	case VOLUME_ACTION_CLONE:
+		query := fmt.Sprintf("Source=%s&Destination=%s&Lazy=%t", options.Source, volume, options.Lazy)
+		return fmt.Sprintf(FORMAT_SNAPSHOTCLONE_API, utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()),
+			"Clone", SNAPSHOTCLONE_API_VERSION, user, query), nil
+	}
+	return "", errno.ERR_UNSUPPORT_VOLUME_ACTION.
+		F("action: %s", options.Action)
+}
+
+// the snapshotclone service always responds with json, e.g. {"Code":"0","Message":"Exec success.",...}
+func parseSnapshotCloneResponse(out string) (string, error) {
+	response := snapshotCloneResponse{}
+	err := json.Unmarshal([]byte(strings.TrimSpace(out)), &response)
+	if err != nil {
+		return "", errno.ERR_MANAGE_VOLUME_FAILED.S(out)
+	} else if response.Code != SNAPSHOTCLONE_CODE_OK {
+		return "", errno.ERR_MANAGE_VOLUME_FAILED.
+			F("code: %s, message: %s", response.Code, response.Message)
+	}
+	return response.UUID, nil
+}
+
+func checkVolumeOutput(curveadm *cli.CurveAdm, options VolumeOptions,
+	success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success {
+			return errno.ERR_MANAGE_VOLUME_FAILED.S(*out)
+		}
+
+		output := *out
+		switch options.Action {
+		case VOLUME_ACTION_SNAPSHOT, VOLUME_ACTION_CLONE:
+			uuid, err := parseSnapshotCloneResponse(output)
+			if err != nil {
+				return err
+			}
+			output = uuid
+		}
+		curveadm.MemStorage().Set(comm.KEY_VOLUME_OUTPUT, output)
+		return nil
+	}
+}
+
+func NewManageVolumeTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_VOLUME_OPTIONS).(VolumeOptions)
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	command, err := getVolumeCommand(dc, options)
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("action=%s volume=%s:%s containerId=%s",
+		options.Action, options.User, options.Volume, tui.TrimContainerId(containerId))
+	t := task.NewTask("Manage Volume", subname, hc.GetSSHConfig())
+
+	// add step
+	var success bool
+	var out string
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     command,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkVolumeOutput(curveadm, options, &success, &out),
+	})
+
+	return t, nil
+}
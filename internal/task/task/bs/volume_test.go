@@ -0,0 +1,43 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSnapshotCloneResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	uuid, err := parseSnapshotCloneResponse(`{"Code":"0","Message":"Exec success.","RequestId":"abc","UUID":"5e0e2a3a"}`)
+	assert.Nil(err)
+	assert.Equal("5e0e2a3a", uuid)
+
+	_, err = parseSnapshotCloneResponse(`{"Code":"-8","Message":"File not exist.","RequestId":"abc"}`)
+	assert.NotNil(err)
+
+	_, err = parseSnapshotCloneResponse("curl: (7) Failed to connect")
+	assert.NotNil(err)
+}
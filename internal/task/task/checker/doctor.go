@@ -0,0 +1,342 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	DOCTOR_SEVERITY_CRITICAL = "critical"
+	DOCTOR_SEVERITY_WARNING  = "warning"
+
+	DOCTOR_STATUS_PASS = "pass"
+	DOCTOR_STATUS_FAIL = "fail"
+
+	DOCTOR_ITEM_SSH                  = "ssh"
+	DOCTOR_ITEM_KERNEL               = "kernel"
+	DOCTOR_ITEM_OPEN_FILES           = "open_files"
+	DOCTOR_ITEM_CLOCK_SKEW           = "clock_skew"
+	DOCTOR_ITEM_CONTAINER            = "container_engine"
+	DOCTOR_ITEM_PORT                 = "port"
+	DOCTOR_ITEM_DISK_SCHEDULER       = "disk_scheduler"
+	DOCTOR_ITEM_TRANSPARENT_HUGEPAGE = "thp"
+	DOCTOR_ITEM_SWAP                 = "swap"
+
+	DOCTOR_LEAST_OPEN_FILES = 65535
+
+	PATH_TRANSPARENT_HUGEPAGE = "/sys/kernel/mm/transparent_hugepage/enabled"
+	PATH_SWAPS                = "/proc/swaps"
+	FORMAT_DISK_SCHEDULER     = "/sys/block/%s/queue/scheduler"
+)
+
+var (
+	// scheduler which not recommended for SSD
+	SSD_UNRECOMMENDED_SCHEDULERS = []string{"cfq", "bfq"}
+)
+
+type (
+	DoctorResult struct {
+		Host     string `json:"host"`
+		Item     string `json:"item"`
+		Severity string `json:"severity"`
+		Status   string `json:"status"`
+		Expect   string `json:"expect"`
+		Actual   string `json:"actual"`
+	}
+
+	// doctorEnv is the environment shared by all checks in one host
+	doctorEnv struct {
+		curveadm *cli.CurveAdm
+		facts    step.HostFacts
+		dcs      []*topology.DeployConfig // services in this host
+		options  module.ExecOptions
+	}
+
+	/*
+	 * doctorCheck is one item of doctor suite, adding new check only need
+	 * to append it into DOCTOR_CHECKS. the check function returns the actual
+	 * value it found and whether it meets expectation.
+	 */
+	doctorCheck struct {
+		item     string
+		severity string
+		expect   string
+		check    func(ctx *context.Context, env *doctorEnv) (string, bool)
+	}
+)
+
+var (
+	DOCTOR_CHECKS = []doctorCheck{
+		{DOCTOR_ITEM_KERNEL, DOCTOR_SEVERITY_CRITICAL, ">= " + CHUNKSERVER_LEAST_KERNEL_VERSION, doctorKernel},
+		{DOCTOR_ITEM_OPEN_FILES, DOCTOR_SEVERITY_WARNING, fmt.Sprintf(">= %d", DOCTOR_LEAST_OPEN_FILES), doctorOpenFiles},
+		{DOCTOR_ITEM_CLOCK_SKEW, DOCTOR_SEVERITY_CRITICAL, fmt.Sprintf("<= %ds", MAX_TIME_DIFFERENCE), doctorClockSkew},
+		{DOCTOR_ITEM_CONTAINER, DOCTOR_SEVERITY_CRITICAL, "running", doctorContainerEngine},
+		{DOCTOR_ITEM_PORT, DOCTOR_SEVERITY_CRITICAL, "not in use", doctorPort},
+		{DOCTOR_ITEM_DISK_SCHEDULER, DOCTOR_SEVERITY_WARNING, "not cfq/bfq for SSD", doctorDiskScheduler},
+		{DOCTOR_ITEM_TRANSPARENT_HUGEPAGE, DOCTOR_SEVERITY_WARNING, "not always", doctorTransparentHugepage},
+		{DOCTOR_ITEM_SWAP, DOCTOR_SEVERITY_WARNING, "off", doctorSwap},
+	}
+)
+
+func DoctorItems() []string {
+	items := []string{DOCTOR_ITEM_SSH}
+	for _, c := range DOCTOR_CHECKS {
+		items = append(items, c.item)
+	}
+	return items
+}
+
+func AddDoctorResult(memStorage *utils.SafeMap, result DoctorResult) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		results := []DoctorResult{}
+		v := kv.Get(comm.KEY_ALL_DOCTOR_RESULTS)
+		if v != nil {
+			results = v.([]DoctorResult)
+		}
+		results = append(results, result)
+		kv.Set(comm.KEY_ALL_DOCTOR_RESULTS, results)
+		return nil
+	})
+}
+
+func runCommand(ctx *context.Context, command string, options module.ExecOptions) (string, error) {
+	out, err := ctx.Module().Shell().Command(command).Execute(options)
+	return strings.TrimSpace(out), err
+}
+
+func doctorKernel(ctx *context.Context, env *doctorEnv) (string, bool) {
+	release := env.facts.KernelRelease
+	mu := regexp.MustCompile(REGEX_KERNEL_VAERSION).FindStringSubmatch(release)
+	if len(mu) == 0 {
+		return release, false
+	}
+	return release, calcKernelVersion(mu[1]) >= calcKernelVersion(CHUNKSERVER_LEAST_KERNEL_VERSION)
+}
+
+func doctorOpenFiles(ctx *context.Context, env *doctorEnv) (string, bool) {
+	options := env.options
+	options.ExecWithSudo = false // limit of login user
+	out, err := runCommand(ctx, "ulimit -n", options)
+	if err != nil {
+		return out, false
+	} else if out == "unlimited" {
+		return out, true
+	}
+	n, err := strconv.Atoi(out)
+	return out, err == nil && n >= DOCTOR_LEAST_OPEN_FILES
+}
+
+func doctorClockSkew(ctx *context.Context, env *doctorEnv) (string, bool) {
+	start := time.Now().Unix()
+	out, err := runCommand(ctx, "date +%s", env.options)
+	end := time.Now().Unix()
+	if err != nil {
+		return out, false
+	}
+
+	remote, err := strconv.ParseInt(out, 10, 64)
+	if err != nil {
+		return out, false
+	}
+
+	// the remote time should between start and end
+	skew := int64(0)
+	if remote < start {
+		skew = start - remote
+	} else if remote > end {
+		skew = remote - end
+	}
+	return fmt.Sprintf("%ds", skew), skew <= MAX_TIME_DIFFERENCE
+}
+
+func doctorContainerEngine(ctx *context.Context, env *doctorEnv) (string, bool) {
+	engine := module.GetContainerEngine(ctx.Transport(), env.options)
+	_, err := ctx.Module().DockerCli().DockerInfo().Execute(env.options)
+	if err != nil {
+		return fmt.Sprintf("%s not running", engine.Name()), false
+	}
+	return fmt.Sprintf("%s running", engine.Name()), true
+}
+
+// the port listened by service's own container (e.g: cluster deployed) isn't in use
+func doctorPort(ctx *context.Context, env *doctorEnv) (string, bool) {
+	out, err := runCommand(ctx, CMD_LIST_LISTEN_SOCKETS, env.options)
+	if err != nil {
+		return "list listening sockets failed", false
+	}
+
+	inUse := []string{}
+	sockets := parseListenSockets(out)
+	for _, dc := range env.dcs {
+		for _, address := range getServiceListenAddresses(dc) {
+			for _, socket := range sockets {
+				if socket.port != address.Port ||
+					isOwnedByService(ctx, env.curveadm, dc, socket.pid, env.options) {
+					continue
+				}
+				inUse = append(inUse, strconv.Itoa(address.Port))
+				break
+			}
+		}
+	}
+
+	if len(inUse) > 0 {
+		return "in use: " + strings.Join(inUse, ","), false
+	}
+	return "-", true
+}
+
+func doctorDiskScheduler(ctx *context.Context, env *doctorEnv) (string, bool) {
+	unrecommended := utils.Slice2Map(SSD_UNRECOMMENDED_SCHEDULERS)
+	bad := []string{}
+	for _, device := range env.facts.BlockDevices {
+		if device.Type != "disk" || device.Rotational {
+			continue
+		}
+
+		out, err := runCommand(ctx, "cat "+fmt.Sprintf(FORMAT_DISK_SCHEDULER, device.Name), env.options)
+		if err != nil {
+			continue // e.g: device without scheduler
+		}
+		// e.g: mq-deadline kyber [bfq] none
+		mu := regexp.MustCompile(`\[(\S+)\]`).FindStringSubmatch(out)
+		if len(mu) > 0 && unrecommended[mu[1]] {
+			bad = append(bad, fmt.Sprintf("%s:%s", device.Name, mu[1]))
+		}
+	}
+
+	if len(bad) > 0 {
+		return strings.Join(bad, ","), false
+	}
+	return "-", true
+}
+
+func doctorTransparentHugepage(ctx *context.Context, env *doctorEnv) (string, bool) {
+	out, err := runCommand(ctx, "cat "+PATH_TRANSPARENT_HUGEPAGE, env.options)
+	if err != nil {
+		return "-", true // THP not supported
+	}
+	mu := regexp.MustCompile(`\[(\S+)\]`).FindStringSubmatch(out)
+	if len(mu) == 0 {
+		return out, false
+	}
+	return mu[1], mu[1] != "always"
+}
+
+func doctorSwap(ctx *context.Context, env *doctorEnv) (string, bool) {
+	out, err := runCommand(ctx, "cat "+PATH_SWAPS, env.options)
+	if err != nil {
+		return out, false
+	}
+
+	// the first line is header
+	lines := strings.Split(out, "\n")
+	if len(lines) > 1 {
+		return fmt.Sprintf("on (%d devices)", len(lines)-1), false
+	}
+	return "off", true
+}
+
+func runDoctorChecks(curveadm *cli.CurveAdm, host string, env *doctorEnv, skipped map[string]bool) step.LambdaType {
+	return func(ctx *context.Context) error {
+		memStorage := curveadm.MemStorage()
+		if !skipped[DOCTOR_ITEM_SSH] {
+			AddDoctorResult(memStorage, DoctorResult{
+				Host:     host,
+				Item:     DOCTOR_ITEM_SSH,
+				Severity: DOCTOR_SEVERITY_CRITICAL,
+				Status:   DOCTOR_STATUS_PASS,
+				Expect:   "connected",
+				Actual:   "connected",
+			})
+		}
+
+		for _, c := range DOCTOR_CHECKS {
+			if skipped[c.item] {
+				continue
+			}
+
+			ctx.Progress().Set("checking %s", c.item)
+			actual, ok := c.check(ctx, env)
+			AddDoctorResult(memStorage, DoctorResult{
+				Host:     host,
+				Item:     c.item,
+				Severity: c.severity,
+				Status:   utils.Choose(ok, DOCTOR_STATUS_PASS, DOCTOR_STATUS_FAIL),
+				Expect:   c.expect,
+				Actual:   actual,
+			})
+		}
+		return nil
+	}
+}
+
+func NewDoctorTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	host := dc.GetHost()
+	subname := fmt.Sprintf("host=%s", host)
+	t := task.NewTask("Doctor Environment", subname, hc.GetSSHConfig())
+
+	// add step to task
+	env := &doctorEnv{curveadm: curveadm, options: curveadm.ExecOptions()}
+	dcs := curveadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS).([]*topology.DeployConfig)
+	for _, dc := range dcs {
+		if dc.GetHost() == host {
+			env.dcs = append(env.dcs, dc)
+		}
+	}
+	skipped := map[string]bool{}
+	if v := curveadm.MemStorage().Get(comm.KEY_DOCTOR_SKIPPED_ITEMS); v != nil {
+		skipped = utils.Slice2Map(v.([]string))
+	}
+
+	t.AddStep(&step.GatherFacts{
+		MemStorage:  curveadm.MemStorage(),
+		Out:         &env.facts,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: runDoctorChecks(curveadm, host, env, skipped),
+	})
+
+	return t, nil
+}
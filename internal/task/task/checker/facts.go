@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package checker
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/step"
+)
+
+// StoredHostFacts is the facts of host which persisted in database
+type StoredHostFacts struct {
+	Host       string
+	Facts      step.HostFacts
+	UpdateTime time.Time
+}
+
+// SaveHostFacts persists the gathered facts, key: host
+func SaveHostFacts(curveadm *cli.CurveAdm, facts map[string]step.HostFacts) error {
+	for host, f := range facts {
+		bytes, err := json.Marshal(f)
+		if err != nil {
+			return errno.ERR_ENCODE_HOST_FACTS_FAILED.E(err)
+		}
+		err = curveadm.Storage().SetHostFacts(host, string(bytes))
+		if err != nil {
+			return errno.ERR_SET_HOST_FACTS_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+// LoadHostFacts returns the facts persisted in database, key: host
+func LoadHostFacts(curveadm *cli.CurveAdm) (map[string]StoredHostFacts, error) {
+	items, err := curveadm.Storage().GetHostFacts()
+	if err != nil {
+		return nil, errno.ERR_GET_HOST_FACTS_FAILED.E(err)
+	}
+
+	m := map[string]StoredHostFacts{}
+	for _, item := range items {
+		facts := step.HostFacts{}
+		err := json.Unmarshal([]byte(item.Facts), &facts)
+		if err != nil {
+			return nil, errno.ERR_DECODE_HOST_FACTS_FAILED.E(err)
+		}
+		m[item.Host] = StoredHostFacts{
+			Host:       item.Host,
+			Facts:      facts,
+			UpdateTime: item.UpdateTime,
+		}
+	}
+	return m, nil
+}
+
+// PruneHostFacts deletes the facts of hosts which not in hosts any more
+func PruneHostFacts(curveadm *cli.CurveAdm, hosts []string) error {
+	items, err := curveadm.Storage().GetHostFacts()
+	if err != nil {
+		return errno.ERR_GET_HOST_FACTS_FAILED.E(err)
+	}
+
+	keep := map[string]bool{}
+	for _, host := range hosts {
+		keep[host] = true
+	}
+	for _, item := range items {
+		if keep[item.Host] {
+			continue
+		}
+		err = curveadm.Storage().DeleteHostFacts(item.Host)
+		if err != nil {
+			return errno.ERR_DELETE_HOST_FACTS_FAILED.E(err)
+		}
+	}
+	return nil
+}
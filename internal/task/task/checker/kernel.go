@@ -110,10 +110,17 @@ func NewCheckKernelVersionTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig
 
 	// add step to task
 	var out string
-	t.AddStep(&step.UnixName{
-		KernelRelease: true,
-		Out:           &out,
-		ExecOptions:   curveadm.ExecOptions(),
+	var facts step.HostFacts
+	t.AddStep(&step.GatherFacts{
+		MemStorage:  curveadm.MemStorage(),
+		Out:         &facts,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			out = facts.KernelRelease
+			return nil
+		},
 	})
 	t.AddStep(&step.Lambda{
 		Lambda: checkKernelVersion(&out, dc),
@@ -0,0 +1,330 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package checker
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	LINT_SEVERITY_WARN  = "WARN"
+	LINT_SEVERITY_ERROR = "ERROR"
+
+	LINT_RULE_REPLICAS  = "replicas"
+	LINT_RULE_QUORUM    = "quorum"
+	LINT_RULE_ZONE      = "zone"
+	LINT_RULE_PORT      = "port"
+	LINT_RULE_DIRECTORY = "directory"
+	LINT_RULE_CPU       = "cpu"
+	LINT_RULE_MEMORY    = "memory"
+	LINT_RULE_NETWORK   = "network"
+	LINT_RULE_FACTS     = "facts"
+
+	LINT_REPLICAS = 3 // replicas of copyset, which placed in different zones
+	LINT_ZONES    = 3
+
+	LINT_NIC_SPEED = 10000 // Mb/s, recommended for chunkserver/metaserver
+)
+
+var (
+	// recommended resources for each service
+	LINT_SERVICE_CPUS = map[string]int{
+		ROLE_ETCD:          1,
+		ROLE_MDS:           1,
+		ROLE_CHUNKSERVER:   2,
+		ROLE_SNAPSHOTCLONE: 1,
+		ROLE_METASERVER:    2,
+	}
+	LINT_SERVICE_MEMORY = map[string]uint64{ // KiB
+		ROLE_ETCD:          2 * 1024 * 1024,
+		ROLE_MDS:           2 * 1024 * 1024,
+		ROLE_CHUNKSERVER:   4 * 1024 * 1024,
+		ROLE_SNAPSHOTCLONE: 1 * 1024 * 1024,
+		ROLE_METASERVER:    4 * 1024 * 1024,
+	}
+)
+
+type LintIssue struct {
+	Severity string
+	Rule     string
+	Target   string
+	Message  string
+}
+
+type linter struct {
+	dcs    []*topology.DeployConfig
+	facts  map[string]step.HostFacts // key: host
+	issues []LintIssue
+}
+
+func (l *linter) report(severity, rule, target, format string, a ...interface{}) {
+	l.issues = append(l.issues, LintIssue{
+		Severity: severity,
+		Rule:     rule,
+		Target:   target,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+func (l *linter) servicesByRole() (map[string][]*topology.DeployConfig, []string) {
+	m := map[string][]*topology.DeployConfig{}
+	roles := []string{}
+	for _, dc := range l.dcs {
+		role := dc.GetRole()
+		if _, ok := m[role]; !ok {
+			roles = append(roles, role)
+		}
+		m[role] = append(m[role], dc)
+	}
+	return m, roles
+}
+
+// the number of services in each host, key: host
+func countByHost(dcs []*topology.DeployConfig) (map[string]int, []string) {
+	m := map[string]int{}
+	hosts := []string{}
+	for _, dc := range dcs {
+		if _, ok := m[dc.GetHost()]; !ok {
+			hosts = append(hosts, dc.GetHost())
+		}
+		m[dc.GetHost()]++
+	}
+	return m, hosts
+}
+
+/*
+ * (1) chunkserver/metaserver requires at least 3 hosts for 3 replicas
+ * (2) etcd/mds requires odd number of services for quorum
+ */
+func (l *linter) lintReplicas() {
+	m, roles := l.servicesByRole()
+	for _, role := range roles {
+		dcs := m[role]
+		_, hosts := countByHost(dcs)
+		switch role {
+		case ROLE_CHUNKSERVER, ROLE_METASERVER:
+			if len(hosts) < LINT_REPLICAS {
+				l.report(LINT_SEVERITY_ERROR, LINT_RULE_REPLICAS, role,
+					"%d replicas requires at least %d hosts, but only %d hosts", LINT_REPLICAS, LINT_REPLICAS, len(hosts))
+			}
+		case ROLE_ETCD, ROLE_MDS:
+			if len(dcs) < 3 {
+				l.report(LINT_SEVERITY_WARN, LINT_RULE_QUORUM, role,
+					"%d services can't tolerate any failure, 3 services at least is recommended", len(dcs))
+			} else if len(dcs)%2 == 0 {
+				l.report(LINT_SEVERITY_WARN, LINT_RULE_QUORUM, role,
+					"%d services tolerate the same failures as %d, odd number is recommended", len(dcs), len(dcs)-1)
+			}
+		}
+	}
+}
+
+/*
+ * the hosts of chunkserver/metaserver are distributed into 3 zones in turn,
+ * so the number of hosts should be multiple of 3 and each host should
+ * have the same number of services for balance.
+ */
+func (l *linter) lintZone() {
+	m, roles := l.servicesByRole()
+	for _, role := range roles {
+		if role != ROLE_CHUNKSERVER && role != ROLE_METASERVER {
+			continue
+		}
+
+		count, hosts := countByHost(m[role])
+		if len(hosts) >= LINT_ZONES && len(hosts)%LINT_ZONES != 0 {
+			l.report(LINT_SEVERITY_WARN, LINT_RULE_ZONE, role,
+				"%d hosts can't be distributed evenly into %d zones", len(hosts), LINT_ZONES)
+		}
+		min, max := -1, 0
+		for _, host := range hosts {
+			if min == -1 || count[host] < min {
+				min = count[host]
+			}
+			if count[host] > max {
+				max = count[host]
+			}
+		}
+		if min != max {
+			l.report(LINT_SEVERITY_WARN, LINT_RULE_ZONE, role,
+				"services per host range from %d to %d, zones will be unbalanced", min, max)
+		}
+	}
+}
+
+func (l *linter) lintPort() {
+	used := map[string]*topology.DeployConfig{}
+	for _, dc := range l.dcs {
+		for _, address := range getServiceListenAddresses(dc) {
+			key := utils.JoinHostPort(address.IP, address.Port)
+			if other, ok := used[key]; ok {
+				l.report(LINT_SEVERITY_ERROR, LINT_RULE_PORT, dc.GetId(),
+					"listen address %s collides with %s", key, other.GetId())
+				continue
+			}
+			used[key] = dc
+		}
+	}
+}
+
+func (l *linter) lintDirectory() {
+	used := map[string]*topology.DeployConfig{}
+	for _, dc := range l.dcs {
+		for _, dir := range getServiceDirectorys(dc) {
+			if dir.Type == CORE_DIR { // core directory can be shared
+				continue
+			}
+			key := fmt.Sprintf("%s:%s", dc.GetHost(), dir.Path)
+			if other, ok := used[key]; ok {
+				l.report(LINT_SEVERITY_ERROR, LINT_RULE_DIRECTORY, dc.GetId(),
+					"%s %s is duplicated with %s", dir.Type, dir.Path, other.GetId())
+				continue
+			}
+			used[key] = dc
+		}
+	}
+}
+
+// the resources of host which services recommended versus gathered facts
+func (l *linter) lintResources() {
+	if l.facts == nil {
+		return
+	}
+
+	cpus := map[string]int{}
+	memory := map[string]uint64{}
+	storage := map[string]bool{} // host which chunkserver/metaserver placed in
+	hosts := []string{}
+	for _, dc := range l.dcs {
+		host := dc.GetHost()
+		if _, ok := cpus[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		cpus[host] += LINT_SERVICE_CPUS[dc.GetRole()]
+		memory[host] += LINT_SERVICE_MEMORY[dc.GetRole()]
+		if dc.GetRole() == ROLE_CHUNKSERVER || dc.GetRole() == ROLE_METASERVER {
+			storage[host] = true
+		}
+	}
+
+	for _, host := range hosts {
+		facts, ok := l.facts[host]
+		if !ok {
+			l.report(LINT_SEVERITY_WARN, LINT_RULE_FACTS, host,
+				"facts of host not gathered, skip checking resources")
+			continue
+		}
+		if facts.CPUs > 0 && cpus[host] > facts.CPUs {
+			l.report(LINT_SEVERITY_WARN, LINT_RULE_CPU, host,
+				"services recommend %d CPUs, but host only has %d", cpus[host], facts.CPUs)
+		}
+		if facts.Memory > 0 && memory[host] > facts.Memory {
+			l.report(LINT_SEVERITY_WARN, LINT_RULE_MEMORY, host,
+				"services recommend %s memory, but host only has %s",
+				humanize.IBytes(memory[host]*1024), humanize.IBytes(facts.Memory*1024))
+		}
+		if speed := facts.MaxNICSpeed(); storage[host] && speed > 0 && speed < LINT_NIC_SPEED {
+			l.report(LINT_SEVERITY_WARN, LINT_RULE_NETWORK, host,
+				"storage services recommend %d Mb/s network, but the fastest NIC is %d Mb/s", LINT_NIC_SPEED, speed)
+		}
+	}
+}
+
+/*
+ * LintTopology performs best-practice validation for topology, the resources
+ * check will be skipped if facts is nil. The issues are sorted by severity,
+ * errors first.
+ */
+func LintTopology(dcs []*topology.DeployConfig, facts map[string]step.HostFacts) []LintIssue {
+	l := &linter{dcs: dcs, facts: facts, issues: []LintIssue{}}
+	l.lintReplicas()
+	l.lintZone()
+	l.lintPort()
+	l.lintDirectory()
+	l.lintResources()
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		return l.issues[i].Severity == LINT_SEVERITY_ERROR &&
+			l.issues[j].Severity != LINT_SEVERITY_ERROR
+	})
+	return l.issues
+}
+
+func addHostFacts(memStorage *utils.SafeMap, host string, facts step.HostFacts) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string]step.HostFacts{}
+		v := kv.Get(comm.KEY_ALL_LINT_HOST_FACTS)
+		if v != nil {
+			m = v.(map[string]step.HostFacts)
+		}
+		m[host] = facts
+		kv.Set(comm.KEY_ALL_LINT_HOST_FACTS, m)
+		return nil
+	})
+}
+
+func NewGatherHostFactsTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	return newGatherHostFactsTask(curveadm, hc)
+}
+
+// NewRefreshHostFactsTask gathers facts of host in hosts, which not required in topology
+func NewRefreshHostFactsTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	return newGatherHostFactsTask(curveadm, hc)
+}
+
+func newGatherHostFactsTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	// new task
+	host := hc.GetHost()
+	subname := fmt.Sprintf("host=%s", host)
+	t := task.NewTask("Gather Host Facts", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var facts step.HostFacts
+	t.AddStep(&step.GatherFacts{
+		MemStorage:  curveadm.MemStorage(),
+		Out:         &facts,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			addHostFacts(curveadm.MemStorage(), host, facts)
+			return nil
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,152 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package checker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	LINT_TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: opencurvedocker/curvebs:v1.2
+  log_dir: /data/logs/${service_role}
+  data_dir: /data/${service_role}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: server-host1
+    - host: server-host2
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: server-host1
+    - host: server-host2
+    - host: server-host3
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 82${format_replicas_sequence}
+    data_dir: /data/chunkserver${service_replicas_sequence}
+    log_dir: /data/chunkserver${service_replicas_sequence}/logs
+  deploy:
+    - host: server-host1
+      replicas: 2
+    - host: server-host2
+      replicas: 2
+`
+)
+
+func parseLintTopology(t *testing.T, data string) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	for _, host := range []string{"server-host1", "server-host2", "server-host3"} {
+		ctx.Add(host, host)
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func findLintIssue(issues []LintIssue, rule, target string) (LintIssue, bool) {
+	for _, issue := range issues {
+		if issue.Rule == rule && issue.Target == target {
+			return issue, true
+		}
+	}
+	return LintIssue{}, false
+}
+
+func TestLintTopology(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := parseLintTopology(t, LINT_TOPOLOGY)
+	issues := LintTopology(dcs, nil)
+
+	issue, ok := findLintIssue(issues, LINT_RULE_REPLICAS, ROLE_CHUNKSERVER)
+	assert.True(ok)
+	assert.Equal(LINT_SEVERITY_ERROR, issue.Severity)
+	issue, ok = findLintIssue(issues, LINT_RULE_QUORUM, ROLE_ETCD)
+	assert.True(ok)
+	assert.Equal(LINT_SEVERITY_WARN, issue.Severity)
+	_, ok = findLintIssue(issues, LINT_RULE_QUORUM, ROLE_MDS)
+	assert.False(ok)
+
+	// errors first
+	assert.Equal(LINT_SEVERITY_ERROR, issues[0].Severity)
+	assert.Equal(LINT_SEVERITY_WARN, issues[len(issues)-1].Severity)
+}
+
+func TestLintTopologyResources(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := parseLintTopology(t, LINT_TOPOLOGY)
+	facts := map[string]step.HostFacts{
+		"server-host1": {CPUs: 2, Memory: 64 * 1024 * 1024, NICs: []step.NIC{{Name: "eth0", Speed: 1000}, {Name: "eth1", Speed: -1}}},
+		"server-host2": {CPUs: 32, Memory: 4 * 1024 * 1024, NICs: []step.NIC{{Name: "eth0", Speed: 25000}}},
+	}
+	issues := LintTopology(dcs, facts)
+
+	issue, ok := findLintIssue(issues, LINT_RULE_CPU, "server-host1")
+	assert.True(ok)
+	assert.Equal(LINT_SEVERITY_WARN, issue.Severity)
+	_, ok = findLintIssue(issues, LINT_RULE_MEMORY, "server-host1")
+	assert.False(ok)
+	_, ok = findLintIssue(issues, LINT_RULE_MEMORY, "server-host2")
+	assert.True(ok)
+	_, ok = findLintIssue(issues, LINT_RULE_FACTS, "server-host3")
+	assert.True(ok)
+	_, ok = findLintIssue(issues, LINT_RULE_NETWORK, "server-host1")
+	assert.True(ok)
+	_, ok = findLintIssue(issues, LINT_RULE_NETWORK, "server-host2")
+	assert.False(ok)
+}
+
+func TestLintTopologyCollisions(t *testing.T) {
+	assert := assert.New(t)
+
+	data := strings.Replace(LINT_TOPOLOGY, "82${format_replicas_sequence}", "8200", 1)
+	data = strings.Replace(data, "/data/chunkserver${service_replicas_sequence}\n", "/data/chunkserver\n", 1)
+	issues := LintTopology(parseLintTopology(t, data), nil)
+
+	issue, ok := findLintIssue(issues, LINT_RULE_PORT, "chunkserver_server-host1_0_1")
+	assert.True(ok)
+	assert.Equal(LINT_SEVERITY_ERROR, issue.Severity)
+	issue, ok = findLintIssue(issues, LINT_RULE_DIRECTORY, "chunkserver_server-host1_0_1")
+	assert.True(ok)
+	assert.Equal(LINT_SEVERITY_ERROR, issue.Severity)
+}
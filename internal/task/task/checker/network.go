@@ -24,6 +24,7 @@ package checker
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -37,30 +38,24 @@ import (
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
 )
 
 const (
 	FORMAT_FILTER_SPORT = "( sport = :%d )"
 
 	HTTP_SERVER_CONTAINER_NAME = "curveadm-precheck-nginx"
-	CHECK_PORT_CONTAINER_NAME  = "curveadm-precheck-port"
+	CHECK_PORT_CONTAINER_NAME  = "curveadm-precheck-port" // only for cleaning container left by old version
+
+	// fallback to netstat if ss not installed
+	CMD_LIST_LISTEN_SOCKETS = "bash -c 'ss --tcp --listening --numeric --processes --no-header 2>/dev/null || " +
+		"netstat --tcp --listening --numeric --programs 2>/dev/null | tail -n +3'"
+	REGEX_LISTEN_PROCESS_ID = `pid=(\d+)|\s(\d+)/\S+\s*$`
+	REGEX_CONTAINER_ID      = "[0-9a-f]{64}"
 )
 
 // TASK: check port in use
-func checkPortInUse(success *bool, out *string, host string, port int) step.LambdaType {
-	return func(ctx *context.Context) error {
-		if !*success {
-			return errno.ERR_GET_CONNECTION_INFORMATION_FAILED.S(*out)
-		}
-
-		if len(*out) > 0 {
-			return errno.ERR_PORT_ALREADY_IN_USE.
-				F("host=%s, port=%d", host, port)
-		}
-		return nil
-	}
-}
-
 func joinPorts(dc *topology.DeployConfig, addresses []Address) string {
 	ports := []string{}
 	for _, address := range addresses {
@@ -79,41 +74,114 @@ func getCheckPortContainerName(curveadm *cli.CurveAdm, dc *topology.DeployConfig
 		curveadm.GetServiceId(dc.GetId()))
 }
 
-type step2CheckPortStatus struct {
-	containerId *string
-	success     *bool
-	dc          *topology.DeployConfig
-	curveadm    *cli.CurveAdm
-	port        int
+type (
+	// listening socket in host
+	listenSocket struct {
+		port int
+		pid  int // 0 if process unknown
+	}
+
+	step2CheckPortStatus struct {
+		out      *string
+		dc       *topology.DeployConfig
+		curveadm *cli.CurveAdm
+	}
+)
+
+/*
+ * parse output of ss or netstat, the local address is the 4th field of both:
+ *
+ *   ss:      LISTEN 0 128 0.0.0.0:2379 0.0.0.0:* users:(("etcd",pid=1234,fd=7))
+ *   netstat: tcp    0 0   0.0.0.0:2379 0.0.0.0:* LISTEN 1234/etcd
+ */
+func parseListenSockets(out string) []listenSocket {
+	sockets := []listenSocket{}
+	pidRegex := regexp.MustCompile(REGEX_LISTEN_PROCESS_ID)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+
+		address := fields[3]
+		idx := strings.LastIndex(address, ":")
+		if idx < 0 {
+			continue
+		}
+		port, err := strconv.Atoi(address[idx+1:])
+		if err != nil {
+			continue
+		}
+
+		pid := 0
+		if mu := pidRegex.FindStringSubmatch(line); len(mu) > 0 {
+			pid, _ = strconv.Atoi(mu[1] + mu[2])
+		}
+		sockets = append(sockets, listenSocket{port: port, pid: pid})
+	}
+	return sockets
 }
 
-// execute the "ss" command within a temporary container
-func (s *step2CheckPortStatus) Execute(ctx *context.Context) error {
-	filter := fmt.Sprintf(FORMAT_FILTER_SPORT, s.port)
-	cli := ctx.Module().Shell().SocketStatistics(filter)
-	cli.AddOption("--no-header")
-	cli.AddOption("--listening")
-	command, err := cli.String()
+// the container which process belongs to, see /proc/PID/cgroup
+func getProcessContainerId(ctx *context.Context, pid int, options module.ExecOptions) string {
+	out, err := ctx.Module().Shell().
+		Cat(fmt.Sprintf("/proc/%d/cgroup", pid)).
+		Execute(options)
 	if err != nil {
-		return err
+		return ""
 	}
+	return regexp.MustCompile(REGEX_CONTAINER_ID).FindString(out)
+}
 
-	var out string
-	steps := []task.Step{}
-	steps = append(steps, &step.ContainerExec{
-		ContainerId: s.containerId,
-		Command:     command,
-		Out:         &out,
-		ExecOptions: s.curveadm.ExecOptions(),
-	})
-	steps = append(steps, &step.Lambda{
-		Lambda: checkPortInUse(s.success, &out, s.dc.GetHost(), s.port),
-	})
+// whether the container is running in host
+func isContainerRunning(ctx *context.Context, containerId string, options module.ExecOptions) bool {
+	out, err := ctx.Module().DockerCli().ListContainers().
+		AddOption("--quiet").
+		AddOption("--filter id=%s", containerId).
+		Execute(options)
+	return err == nil && len(strings.TrimSpace(out)) > 0
+}
 
-	for _, step := range steps {
-		err := step.Execute(ctx)
-		if err != nil {
-			return err
+/*
+ * the port is owned by service itself (e.g: precheck for deployed cluster),
+ * we match the process's container if its pid known, otherwise (e.g: ss can't
+ * see the process without root) we treat the port as owned if service's
+ * container is running, because the port can't be listened by others then.
+ */
+func isOwnedByService(ctx *context.Context, curveadm *cli.CurveAdm,
+	dc *topology.DeployConfig, pid int, options module.ExecOptions) bool {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	expected, err := curveadm.GetContainerId(serviceId)
+	if err != nil || len(expected) == 0 || expected == comm.CLEANED_CONTAINER_ID {
+		return false
+	} else if pid == 0 {
+		return isContainerRunning(ctx, expected, options)
+	}
+	actual := getProcessContainerId(ctx, pid, options)
+	return len(actual) > 0 && strings.HasPrefix(actual, expected)
+}
+
+func formatProcess(pid int) string {
+	if pid == 0 {
+		return "unknown"
+	}
+	return strconv.Itoa(pid)
+}
+
+func (s *step2CheckPortStatus) isOwnedByService(ctx *context.Context, pid int) bool {
+	return isOwnedByService(ctx, s.curveadm, s.dc, pid, s.curveadm.ExecOptions())
+}
+
+func (s *step2CheckPortStatus) Execute(ctx *context.Context) error {
+	sockets := parseListenSockets(*s.out)
+	for _, address := range getServiceListenAddresses(s.dc) {
+		for _, socket := range sockets {
+			if socket.port != address.Port || s.isOwnedByService(ctx, socket.pid) {
+				continue
+			}
+			return errno.ERR_PORT_ALREADY_IN_USE.
+				F("host=%s, port=%d, service=%s (id=%s), process=%s",
+					s.dc.GetHost(), address.Port, s.dc.GetRole(), s.dc.GetId(), formatProcess(socket.pid))
 		}
 	}
 	return nil
@@ -130,37 +198,17 @@ func NewCheckPortInUseTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*
 		dc.GetHost(), dc.GetRole(), joinPorts(dc, addresses))
 	t := task.NewTask("Check Port In Use <network>", subname, hc.GetSSHConfig())
 
-	var containerId, out string
-	var success bool
-	t.AddStep(&step.PullImage{
-		Image:       dc.GetContainerImage(),
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.CreateContainer{
-		Image:       dc.GetContainerImage(),
-		Command:     "-c 'sleep infinity'", // keep the container running
-		Entrypoint:  "/bin/bash",
-		Name:        getCheckPortContainerName(curveadm, dc),
-		Remove:      true,
-		Out:         &containerId,
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.StartContainer{
-		ContainerId: &containerId,
-		Success:     &success,
+	var out string
+	t.AddStep(&step.Command{
+		Command:     CMD_LIST_LISTEN_SOCKETS,
 		Out:         &out,
 		ExecOptions: curveadm.ExecOptions(),
 	})
-
-	for _, address := range addresses {
-		t.AddStep(&step2CheckPortStatus{
-			containerId: &containerId,
-			success:     &success,
-			dc:          dc,
-			curveadm:    curveadm,
-			port:        address.Port,
-		})
-	}
+	t.AddStep(&step2CheckPortStatus{
+		out:      &out,
+		dc:       dc,
+		curveadm: curveadm,
+	})
 
 	return t, nil
 }
@@ -222,8 +270,8 @@ func getNginxListens(dc *topology.DeployConfig) string {
 	addresses := getServiceListenAddresses(dc)
 	listens := []string{}
 	for _, address := range addresses {
-		listens = append(listens, fmt.Sprintf("listen %s:%d;",
-			address.IP, address.Port))
+		listens = append(listens, fmt.Sprintf("listen %s;",
+			utils.JoinHostPort(address.IP, address.Port)))
 	}
 	return strings.Join(listens, " ")
 }
@@ -260,10 +308,18 @@ func NewStartHTTPServerTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (
 	script := scripts.START_NGINX
 	scriptPath := "/usr/bin/start_nginx"
 	command := fmt.Sprintf("%s '%s'", scriptPath, getNginxListens(dc))
-	t.AddStep(&step.PullImage{
-		Image:       dc.GetContainerImage(),
-		ExecOptions: curveadm.ExecOptions(),
-	})
+	if dc.GetImageSource() == topology.IMAGE_SOURCE_LOCAL {
+		t.AddStep(&step.LoadImage{
+			Image:       dc.GetContainerImage(),
+			Archive:     curveadm.ImageArchivePath(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	} else {
+		t.AddStep(&step.PullImage{
+			Image:       dc.GetContainerImage(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step.CreateContainer{
 		Image:       dc.GetContainerImage(),
 		Command:     command,
@@ -308,8 +364,8 @@ func (s *step2CheckConnectStatus) Execute(ctx *context.Context) error {
 	}
 
 	return errno.ERR_CONNET_MOCK_SERVICE_ADDRESS_FAILED.
-		F("role=%s src=%s dest=%s:%d",
-			s.dc.GetRole(), s.dc.GetHost(), s.address.IP, s.address.Port)
+		F("role=%s src=%s dest=%s",
+			s.dc.GetRole(), s.dc.GetHost(), utils.JoinHostPort(s.address.IP, s.address.Port))
 }
 
 func NewCheckNetworkFirewallTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
@@ -330,7 +386,7 @@ func NewCheckNetworkFirewallTask(curveadm *cli.CurveAdm, dc *topology.DeployConf
 	var success bool
 	for _, address := range addresses {
 		t.AddStep(&step.Curl{
-			Url:         fmt.Sprintf("http://%s:%d", address.IP, address.Port),
+			Url:         fmt.Sprintf("http://%s", utils.JoinHostPort(address.IP, address.Port)),
 			Output:      "/dev/null",
 			Success:     &success,
 			Out:         &out,
@@ -43,3 +43,28 @@ func TestWaitNginxStart(t *testing.T) {
 		assert.Less(elapse, second+1)
 	}
 }
+
+func TestParseListenSockets(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		out     string
+		sockets []listenSocket
+	}{
+		{
+			out: `LISTEN 0      4096         0.0.0.0:2379      0.0.0.0:*    users:(("etcd",pid=1234,fd=7))
+LISTEN 0      128             [::]:22           [::]:*
+`,
+			sockets: []listenSocket{{2379, 1234}, {22, 0}},
+		},
+		{
+			out: `tcp        0      0 10.0.0.1:6700           0.0.0.0:*               LISTEN      5678/curvebs-mds
+tcp6       0      0 :::22                   :::*                    LISTEN      -
+`,
+			sockets: []listenSocket{{6700, 5678}, {22, 0}},
+		},
+	}
+	for _, t := range tests {
+		assert.Equal(t.sockets, parseListenSockets(t.out))
+	}
+}
@@ -0,0 +1,269 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package checker
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	DEFAULT_NTP_MAX_OFFSET_MS = 500
+	DEFAULT_NTP_SERVER        = "pool.ntp.org"
+
+	// debian/ubuntu and centos have different layout for chrony
+	CHRONY_CONF_PATH_DEBIAN = "/etc/chrony/chrony.conf"
+	CHRONY_CONF_PATH_CENTOS = "/etc/chrony.conf"
+	CHRONY_SERVICE_DEBIAN   = "chrony"
+	CHRONY_SERVICE_CENTOS   = "chronyd"
+
+	TEMPLATE_CHRONY_CONF = `# Generated by curveadm
+{{- range .servers}}
+server {{.}} iburst
+{{- end}}
+driftfile /var/lib/chrony/drift
+makestep 1.0 3
+rtcsync
+`
+)
+
+/*
+ * offset of host's clock relative to curveadm machine (milliseconds),
+ * we take the middle of round-trip as the moment when remote date executed:
+ *
+ *   curveadm:  start ----------------------- end
+ *   remote:                 date
+ *   offset = date - (start + end) / 2
+ */
+func step2ComputeClockOffset(curveadm *cli.CurveAdm, host string, start *int64, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		end := time.Now().UnixMilli()
+		remote, err := strconv.ParseInt(strings.TrimSpace(*out), 10, 64)
+		if err != nil {
+			return errno.ERR_INVALID_DATE_FORMAT.
+				F("date: %s", *out)
+		}
+
+		offset := remote - (*start+end)/2
+		curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+			m := map[string]int64{}
+			if v := kv.Get(comm.KEY_ALL_HOST_CLOCK_OFFSET); v != nil {
+				m = v.(map[string]int64)
+			}
+			m[host] = offset
+			kv.Set(comm.KEY_ALL_HOST_CLOCK_OFFSET, m)
+			return nil
+		})
+		return nil
+	}
+}
+
+func NewGetHostClockOffsetTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("host=%s", dc.GetHost())
+	t := task.NewTask("Get Host Clock Offset <ntp>", subname, hc.GetSSHConfig())
+
+	var start int64
+	var out string
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			start = time.Now().UnixMilli()
+			return nil
+		},
+	})
+	t.AddStep(&step.Date{
+		Format:      "+%s%3N",
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: step2ComputeClockOffset(curveadm, dc.GetHost(), &start, &out),
+	})
+
+	return t, nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func checkClockSkew(curveadm *cli.CurveAdm) step.LambdaType {
+	return func(ctx *context.Context) error {
+		memStorage := curveadm.MemStorage()
+		offsets := map[string]int64{}
+		if v := memStorage.Get(comm.KEY_ALL_HOST_CLOCK_OFFSET); v != nil {
+			offsets = v.(map[string]int64)
+		}
+		maxOffset := int64(DEFAULT_NTP_MAX_OFFSET_MS)
+		if v := memStorage.Get(comm.KEY_NTP_MAX_OFFSET); v != nil {
+			maxOffset = int64(v.(int))
+		}
+
+		// offset relative to reference host if it specified
+		base := int64(0)
+		if v := memStorage.Get(comm.KEY_NTP_REFERENCE_HOST); v != nil && len(v.(string)) > 0 {
+			offset, ok := offsets[v.(string)]
+			if !ok {
+				return errno.ERR_NTP_REFERENCE_HOST_NOT_FOUND.
+					F("reference host: %s", v.(string))
+			}
+			base = offset
+		}
+
+		skewed := []string{}
+		details := []string{}
+		for host, offset := range offsets {
+			if abs(offset-base) > maxOffset {
+				skewed = append(skewed, host)
+				details = append(details, fmt.Sprintf("%s(%dms)", host, offset-base))
+			}
+		}
+		sort.Strings(skewed)
+		sort.Strings(details)
+		memStorage.Set(comm.KEY_CLOCK_SKEWED_HOSTS, skewed)
+
+		if len(skewed) > 0 {
+			return errno.ERR_HOST_CLOCK_SKEW_EXCEED_THRESHOLD.
+				F("threshold=%dms %s", maxOffset, strings.Join(details, " "))
+		}
+		return nil
+	}
+}
+
+func NewCheckClockSkewTask(curveadm *cli.CurveAdm, c interface{}) (*task.Task, error) {
+	t := task.NewTask("Check Clock Skew <ntp>", "", nil)
+	t.AddStep(&step.Lambda{
+		Lambda: checkClockSkew(curveadm),
+	})
+	return t, nil
+}
+
+type step2ConfigureChrony struct {
+	facts    *step.HostFacts
+	servers  []string
+	curveadm *cli.CurveAdm
+}
+
+func (s *step2ConfigureChrony) Execute(ctx *context.Context) error {
+	options := s.curveadm.ExecOptions()
+	install, path, service := "", "", ""
+	switch s.facts.OS {
+	case comm.OS_RELEASE_DEBIAN, comm.OS_RELEASE_UBUNTU:
+		install = "apt-get install -y chrony"
+		path, service = CHRONY_CONF_PATH_DEBIAN, CHRONY_SERVICE_DEBIAN
+	case comm.OS_RELEASE_CENTOS:
+		install = "yum install -y chrony"
+		path, service = CHRONY_CONF_PATH_CENTOS, CHRONY_SERVICE_CENTOS
+	default:
+		return errno.ERR_UNSUPPORT_LINUX_OS_REELASE.
+			F("os release: %s", s.facts.OS)
+	}
+
+	// (1) install chrony
+	var out string
+	err := (&step.Command{
+		Command:     install,
+		Out:         &out,
+		ExecOptions: options,
+	}).Execute(ctx)
+	if err != nil {
+		return errno.ERR_INSTALL_CHRONY_FAILED.E(err)
+	}
+
+	// (2) configure and restart chrony, then step the clock immediately
+	steps := []task.Step{
+		&step.RenderTemplate{
+			Template:     TEMPLATE_CHRONY_CONF,
+			Variables:    map[string]interface{}{"servers": s.servers},
+			HostDestPath: path,
+			Mode:         "0644",
+			ExecOptions:  options,
+		},
+		&step.EnableUnit{Name: service, ExecOptions: options},
+		&step.RestartUnit{Name: service, ExecOptions: options},
+		&step.Command{Command: "chronyc -a makestep", Out: &out, ExecOptions: options},
+	}
+	for _, step := range steps {
+		if err := step.Execute(ctx); err != nil {
+			return errno.ERR_CONFIGURE_CHRONY_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func NewSyncHostClockTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// only sync the skewed host
+	skewed := []string{}
+	if v := curveadm.MemStorage().Get(comm.KEY_CLOCK_SKEWED_HOSTS); v != nil {
+		skewed = v.([]string)
+	}
+	if !utils.Slice2Map(skewed)[dc.GetHost()] {
+		return nil, nil
+	}
+
+	servers := []string{DEFAULT_NTP_SERVER}
+	if v := curveadm.MemStorage().Get(comm.KEY_NTP_SERVERS); v != nil && len(v.([]string)) > 0 {
+		servers = v.([]string)
+	}
+
+	subname := fmt.Sprintf("host=%s servers=%s", dc.GetHost(), strings.Join(servers, ","))
+	t := task.NewTask("Sync Host Clock <ntp>", subname, hc.GetSSHConfig())
+
+	var facts step.HostFacts
+	t.AddStep(&step.GatherFacts{
+		MemStorage:  curveadm.MemStorage(),
+		Out:         &facts,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2ConfigureChrony{
+		facts:    &facts,
+		servers:  servers,
+		curveadm: curveadm,
+	})
+
+	return t, nil
+}
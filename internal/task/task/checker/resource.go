@@ -0,0 +1,101 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package checker
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+)
+
+/*
+ * the sum of cpus/memory reserved by services on one host must fit
+ * the capacity of host, and the cpuset must be in range of host cpus
+ */
+func checkHostResources(host string, dcs []*topology.DeployConfig, facts step.HostFacts) error {
+	var cpus float64
+	var memory uint64
+	for _, dc := range dcs {
+		if dc.GetHost() != host {
+			continue
+		}
+
+		if n, ok := topology.ParseCpus(dc.GetResourcesCpus()); ok {
+			cpus += n
+		}
+		if n, ok := topology.ParseMemory(dc.GetResourcesMemory()); ok {
+			memory += n
+		}
+		if cpuset, ok := topology.ParseCpusetCpus(dc.GetResourcesCpusetCpus()); ok {
+			for _, cpu := range cpuset {
+				if cpu >= facts.CPUs {
+					return errno.ERR_CPUSET_CPUS_EXCEED_HOST_CPUS.
+						F("host=%s service=%s cpuset_cpus=%s cpus=%d",
+							host, dc.GetId(), dc.GetResourcesCpusetCpus(), facts.CPUs)
+				}
+			}
+		}
+	}
+
+	if cpus > float64(facts.CPUs) {
+		return errno.ERR_HOST_CPUS_NOT_ENOUGH_FOR_SERVICES.
+			F("host=%s reserved=%g cpus=%d", host, cpus, facts.CPUs)
+	} else if memory > facts.Memory*1024 {
+		return errno.ERR_HOST_MEMORY_NOT_ENOUGH_FOR_SERVICES.
+			F("host=%s reserved=%dMiB memory=%dMiB", host, memory>>20, facts.Memory>>10)
+	}
+	return nil
+}
+
+func NewCheckHostResourcesTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	host := dc.GetHost()
+	subname := fmt.Sprintf("host=%s", host)
+	t := task.NewTask("Check Host Resources <resource>", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var facts step.HostFacts
+	dcs := curveadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS).([]*topology.DeployConfig)
+	t.AddStep(&step.GatherFacts{
+		MemStorage:  curveadm.MemStorage(),
+		Out:         &facts,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			return checkHostResources(host, dcs, facts)
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,99 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package checker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/stretchr/testify/assert"
+)
+
+func withResources(data, resources string) string {
+	return strings.Replace(data, "    listen.port: 82${format_replicas_sequence}\n",
+		"    listen.port: 82${format_replicas_sequence}\n"+resources, 1)
+}
+
+func TestParseResources(t *testing.T) {
+	assert := assert.New(t)
+
+	cpus, ok := topology.ParseCpus("2.5")
+	assert.True(ok)
+	assert.Equal(2.5, cpus)
+	_, ok = topology.ParseCpus("-1")
+	assert.False(ok)
+
+	for in, expect := range map[string]uint64{
+		"512m": 512 << 20,
+		"8g":   8 << 30,
+		"8GiB": 8 << 30,
+		"1.5G": 3 << 29,
+		"1024": 1024,
+	} {
+		memory, ok := topology.ParseMemory(in)
+		assert.True(ok, in)
+		assert.Equal(expect, memory, in)
+	}
+	_, ok = topology.ParseMemory("8x")
+	assert.False(ok)
+
+	cpuset, ok := topology.ParseCpusetCpus("0-3,8")
+	assert.True(ok)
+	assert.Equal([]int{0, 1, 2, 3, 8}, cpuset)
+	_, ok = topology.ParseCpusetCpus("3-1")
+	assert.False(ok)
+}
+
+func TestCheckHostResources(t *testing.T) {
+	assert := assert.New(t)
+	facts := step.HostFacts{CPUs: 4, Memory: 16 << 20} // 16GiB
+
+	// no reservation
+	dcs := parseLintTopology(t, LINT_TOPOLOGY)
+	assert.Nil(checkHostResources("server-host1", dcs, facts))
+
+	// fits
+	dcs = parseLintTopology(t, withResources(LINT_TOPOLOGY,
+		"    resources.cpus: 2\n    resources.memory: 8g\n"))
+	assert.Nil(checkHostResources("server-host1", dcs, facts))
+
+	// 2 chunkservers on host1 reserve 6 cpus
+	dcs = parseLintTopology(t, withResources(LINT_TOPOLOGY,
+		"    resources.cpus: 3\n"))
+	err := checkHostResources("server-host1", dcs, facts)
+	assert.Equal(errno.ERR_HOST_CPUS_NOT_ENOUGH_FOR_SERVICES.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// 2 chunkservers on host1 reserve 20GiB
+	dcs = parseLintTopology(t, withResources(LINT_TOPOLOGY,
+		"    resources.memory: 10g\n"))
+	err = checkHostResources("server-host1", dcs, facts)
+	assert.Equal(errno.ERR_HOST_MEMORY_NOT_ENOUGH_FOR_SERVICES.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// cpuset out of range
+	dcs = parseLintTopology(t, withResources(LINT_TOPOLOGY,
+		"    resources.cpuset_cpus: 2-4\n"))
+	err = checkHostResources("server-host1", dcs, facts)
+	assert.Equal(errno.ERR_CPUSET_CPUS_EXCEED_HOST_CPUS.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
@@ -23,8 +23,13 @@
 package checker
 
 import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -37,6 +42,19 @@ import (
 	"github.com/opencurve/curveadm/pkg/module"
 )
 
+const (
+	CMD_HEAD_S3_BUCKET = "curl -s -o /dev/null -w '%%{http_code}' --connect-timeout 3 -I " +
+		"-H 'Date: %s' -H 'Authorization: AWS %s:%s' %s/%s/"
+	CMD_PUT_S3_OBJECT = "curl -s -o /dev/null -w '%%{http_code}' --connect-timeout 3 -X PUT " +
+		"-H 'Content-Type:' -H 'Date: %s' -H 'Authorization: AWS %s:%s' --data-binary '%s' %s%s"
+	CMD_GET_S3_OBJECT = "curl -s --connect-timeout 3 " +
+		"-H 'Date: %s' -H 'Authorization: AWS %s:%s' %s%s"
+	CMD_DELETE_S3_OBJECT = "curl -s -o /dev/null -w '%%{http_code}' --connect-timeout 3 -X DELETE " +
+		"-H 'Date: %s' -H 'Authorization: AWS %s:%s' %s%s"
+
+	S3_PROBE_OBJECT_CONTENT = "curveadm-s3-probe"
+)
+
 type (
 	step2CheckChunkfilePool struct {
 		dc          *topology.DeployConfig
@@ -48,6 +66,10 @@ type (
 		s3SecretKey  string
 		s3Address    string
 		s3BucketName string
+		addressKey   string // configure key for error clue
+		bucketKey    string
+		probeObject  string // write/read/delete the object if not empty
+		execOptions  module.ExecOptions
 	}
 
 	step2CheckClientS3Configure struct {
@@ -98,15 +120,106 @@ func (s *step2CheckChunkfilePool) Execute(ctx *context.Context) error {
 	return nil
 }
 
-func (s *step2CheckS3) Execute(ctx *context.Context) error {
-	/* TODO(P1): validate S3
-	 * see also:
-	 *	  https://aws.github.io/aws-sdk-go-v2/docs/getting-started/#to-get-your-access-key-id-and-secret-access-key
-	 *	  https://www.programminghunter.com/article/7280107216/
-	 */
+func getS3Endpoint(address string) string {
+	if strings.HasPrefix(address, "http://") || strings.HasPrefix(address, "https://") {
+		return strings.TrimSuffix(address, "/")
+	}
+	return "http://" + strings.TrimSuffix(address, "/")
+}
+
+/*
+ * sign the request with AWS signature version 2, which supported
+ * by most S3 compatible storages (e.g. MinIO, Ceph RGW):
+ *   Signature = Base64(HMAC-SHA1(SecretKey, "<VERB>\n\n\n<Date>\n<Resource>"))
+ * the request must not carry Content-MD5 and Content-Type headers.
+ * see also: https://docs.aws.amazon.com/AmazonS3/latest/userguide/RESTAuthentication.html
+ */
+func signS3Request(secretKey, verb, resource, date string) string {
+	stringToSign := fmt.Sprintf("%s\n\n\n%s\n%s", verb, date, resource)
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func checkS3HttpCode(code, addressKey, address, bucketKey, bucket string) error {
+	switch code {
+	case "200":
+		return nil
+	case "401", "403":
+		return errno.ERR_S3_CREDENTIALS_REJECTED.
+			F("%s: %s", addressKey, address)
+	case "404":
+		return errno.ERR_S3_BUCKET_NOT_EXIST.
+			F("%s: %s", bucketKey, bucket)
+	}
+	return errno.ERR_S3_ENDPOINT_UNREACHABLE.
+		F("%s: %s (http code: %s)", addressKey, address, code)
+}
+
+func (s *step2CheckS3) request(ctx *context.Context, format, verb, resource string, args ...interface{}) (string, error) {
+	date := time.Now().UTC().Format(http.TimeFormat)
+	signature := signS3Request(s.s3SecretKey, verb, resource, date)
+	args = append([]interface{}{date, s.s3AccessKey, signature}, args...)
+
+	var out string
+	var success bool
+	err := (&step.Command{
+		Command:     fmt.Sprintf(format, args...),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	return strings.TrimSpace(out), err
+}
+
+// write, read and delete the probe object to make sure the credentials
+// has full permissions which service required
+func (s *step2CheckS3) probe(ctx *context.Context) error {
+	endpoint := getS3Endpoint(s.s3Address)
+	resource := fmt.Sprintf("/%s/%s", s.s3BucketName, s.probeObject)
+	code, err := s.request(ctx, CMD_PUT_S3_OBJECT, http.MethodPut, resource,
+		S3_PROBE_OBJECT_CONTENT, endpoint, resource)
+	if err != nil {
+		return err
+	} else if code != "200" {
+		return errno.ERR_S3_PROBE_OBJECT_FAILED.
+			F("write object %s (http code: %s)", resource, code)
+	}
+
+	content, err := s.request(ctx, CMD_GET_S3_OBJECT, http.MethodGet, resource,
+		endpoint, resource)
+	if err != nil {
+		return err
+	} else if content != S3_PROBE_OBJECT_CONTENT {
+		return errno.ERR_S3_PROBE_OBJECT_FAILED.
+			F("read object %s: content mismatch", resource)
+	}
+
+	code, err = s.request(ctx, CMD_DELETE_S3_OBJECT, http.MethodDelete, resource,
+		endpoint, resource)
+	if err != nil {
+		return err
+	} else if code != "204" && code != "200" {
+		return errno.ERR_S3_PROBE_OBJECT_FAILED.
+			F("delete object %s (http code: %s)", resource, code)
+	}
 	return nil
 }
 
+func (s *step2CheckS3) Execute(ctx *context.Context) error {
+	resource := fmt.Sprintf("/%s/", s.s3BucketName)
+	code, err := s.request(ctx, CMD_HEAD_S3_BUCKET, http.MethodHead, resource,
+		getS3Endpoint(s.s3Address), s.s3BucketName)
+	if err != nil {
+		return err
+	}
+	err = checkS3HttpCode(code, s.addressKey, s.s3Address, s.bucketKey, s.s3BucketName)
+	if err != nil || len(s.probeObject) == 0 {
+		return err
+	}
+	return s.probe(ctx)
+}
+
 func (s *step2CheckClientS3Configure) Execute(ctx *context.Context) error {
 	cc := s.config
 	items := []struct {
@@ -148,16 +261,61 @@ func NewCheckChunkfilePoolTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig
 	return t, nil
 }
 
-func NewCheckS3Task(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
-	subname := fmt.Sprintf("host=%s role=%s", dc.GetHost(), dc.GetRole())
-	t := task.NewTask("Check S3", subname, nil)
+// FilterS3DeployConfigs returns the services which access S3: snapshotclone
+// uploads snapshot to S3 and metaserver deletes data in S3 if configured
+func FilterS3DeployConfigs(dcs []*topology.DeployConfig, skipSnapshotClone bool) []*topology.DeployConfig {
+	out := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		switch dc.GetRole() {
+		case topology.ROLE_SNAPSHOTCLONE:
+			if !skipSnapshotClone {
+				out = append(out, dc)
+			}
+		case topology.ROLE_METASERVER:
+			if len(dc.GetS3Endpoint()) > 0 {
+				out = append(out, dc)
+			}
+		}
+	}
+	return out
+}
 
-	t.AddStep(&step2CheckS3{
+func newCheckS3Step(dc *topology.DeployConfig, execOptions module.ExecOptions) *step2CheckS3 {
+	if dc.GetKind() == topology.KIND_CURVEBS { // snapshotclone
+		return &step2CheckS3{
+			s3AccessKey:  dc.GetS3AccessKey(),
+			s3SecretKey:  dc.GetS3SecretKey(),
+			s3Address:    dc.GetS3Address(),
+			s3BucketName: dc.GetS3BucketName(),
+			addressKey:   topology.CONFIG_S3_ADDRESS.Key(),
+			bucketKey:    topology.CONFIG_S3_BUCKET_NAME.Key(),
+			execOptions:  execOptions,
+		}
+	}
+
+	// metaserver
+	return &step2CheckS3{
 		s3AccessKey:  dc.GetS3AccessKey(),
 		s3SecretKey:  dc.GetS3SecretKey(),
-		s3Address:    dc.GetS3Address(),
-		s3BucketName: dc.GetS3BucketName(),
-	})
+		s3Address:    dc.GetS3Endpoint(),
+		s3BucketName: dc.GetS3FSBucketName(),
+		addressKey:   topology.CONFIG_S3_ENDPOINT.Key(),
+		bucketKey:    topology.CONFIG_S3_FS_BUCKET_NAME.Key(),
+		probeObject:  fmt.Sprintf("curveadm-probe-%s-%d", dc.GetId(), time.Now().UnixNano()),
+		execOptions:  execOptions,
+	}
+}
+
+func NewCheckS3Task(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("host=%s role=%s", dc.GetHost(), dc.GetRole())
+	t := task.NewTask("Check S3 <service>", subname, hc.GetSSHConfig())
+
+	t.AddStep(newCheckS3Step(dc, curveadm.ExecOptions()))
 
 	return t, nil
 }
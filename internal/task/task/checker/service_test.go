@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignS3Request(t *testing.T) {
+	assert := assert.New(t)
+
+	date := "Sun, 10 Sep 2023 08:00:00 GMT"
+	sign := signS3Request("secret", "HEAD", "/curve/", date)
+	assert.NotEmpty(sign)
+	assert.Equal(sign, signS3Request("secret", "HEAD", "/curve/", date))
+	assert.NotEqual(sign, signS3Request("secret", "HEAD", "/curve1/", date))
+	assert.NotEqual(sign, signS3Request("secret1", "HEAD", "/curve/", date))
+	assert.NotEqual(sign, signS3Request("secret", "PUT", "/curve/", date))
+
+	// example from AWS document
+	assert.Equal("qgk2+6Sv9/oM7G3qLEjTH1a1l1g=", signS3Request(
+		"wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY",
+		"GET", "/awsexamplebucket1/photos/puppy.jpg", "Tue, 27 Mar 2007 19:36:42 +0000"))
+}
+
+func TestCheckS3HttpCode(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(checkS3HttpCode("200", "s3.nos_address", "http://127.0.0.1:9000", "s3.snapshot_bucket_name", "curve"))
+	for code, e := range map[string]*errno.ErrorCode{
+		"403": errno.ERR_S3_CREDENTIALS_REJECTED,
+		"401": errno.ERR_S3_CREDENTIALS_REJECTED,
+		"404": errno.ERR_S3_BUCKET_NOT_EXIST,
+		"000": errno.ERR_S3_ENDPOINT_UNREACHABLE,
+		"500": errno.ERR_S3_ENDPOINT_UNREACHABLE,
+	} {
+		err := checkS3HttpCode(code, "s3.endpoint", "http://127.0.0.1:9000", "s3.bucket_name", "curvefs")
+		assert.Equal(e.GetCode(), err.(*errno.ErrorCode).GetCode(), code)
+	}
+}
+
+func TestFilterS3DeployConfigs(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := parseLintTopology(t, `
+kind: mixed
+global:
+  listen.ip: ${service_host}
+
+snapshotclone_services:
+  config:
+    listen.port: 5555
+  deploy:
+    - host: server-host1
+
+metaserver_services:
+  config:
+    listen.port: 6800
+  deploy:
+    - host: server-host1
+      config:
+        s3.endpoint: http://127.0.0.1:9000
+        s3.bucket_name: curvefs
+    - host: server-host2
+`)
+	assert.Len(FilterS3DeployConfigs(dcs, false), 2)
+
+	out := FilterS3DeployConfigs(dcs, true)
+	assert.Len(out, 1)
+	assert.Equal("server-host1", out[0].GetHost())
+	assert.Equal("curvefs", out[0].GetS3FSBucketName())
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
 const (
@@ -69,6 +70,11 @@ type (
 		dcs []*topology.DeployConfig
 	}
 
+	// check whether all services listen on the same IP family (IPv4/IPv6)
+	step2CheckIPFamily struct {
+		dcs []*topology.DeployConfig
+	}
+
 	// check list:
 	//   (1) each role requires at least 3 services
 	//   (2) each requires at least 3 hosts
@@ -154,7 +160,7 @@ func (s *step2CheckAddressDuplicate) Execute(ctx *context.Context) error {
 	for _, dc := range s.dcs {
 		addresses := getServiceListenAddresses(dc)
 		for _, address := range addresses {
-			addr := fmt.Sprintf("%s:%d", address.IP, address.Port)
+			addr := utils.JoinHostPort(address.IP, address.Port)
 			if _, ok := m[addr]; ok {
 				return errno.ERR_DUPLICATE_LISTEN_ADDRESS.
 					F("duplicate address: %s (%s.host[%s])", addr, dc.GetRole(), dc.GetHost())
@@ -165,6 +171,42 @@ func (s *step2CheckAddressDuplicate) Execute(ctx *context.Context) error {
 	return nil
 }
 
+/*
+ * The internal and external (chunkserver/metaserver) network are checked
+ * separately, the address which is a hostname rather than IP is ignored.
+ */
+func (s *step2CheckIPFamily) Execute(ctx *context.Context) error {
+	internal, external := map[string]string{}, map[string]string{}
+	check := func(families map[string]string, dc *topology.DeployConfig, ip string) error {
+		family := utils.IPFamily(ip)
+		if len(family) == 0 {
+			return nil
+		}
+		for other, otherIP := range families {
+			if other != family {
+				return errno.ERR_INCONSISTENT_IP_FAMILY.
+					F("%s.host[%s]: %s (%s) vs %s (%s)",
+						dc.GetRole(), dc.GetHost(), ip, family, otherIP, other)
+			}
+		}
+		families[family] = ip
+		return nil
+	}
+
+	for _, dc := range s.dcs {
+		if err := check(internal, dc, dc.GetListenIp()); err != nil {
+			return err
+		}
+		role := dc.GetRole()
+		if (role == ROLE_CHUNKSERVER || role == ROLE_METASERVER) && dc.GetEnableExternalServer() {
+			if err := check(external, dc, dc.GetListenExternalIp()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (s *step2CheckServices) getHostNum(dcs []*topology.DeployConfig) int {
 	num := 0
 	exist := map[string]bool{}
@@ -179,7 +221,7 @@ func (s *step2CheckServices) getHostNum(dcs []*topology.DeployConfig) int {
 }
 
 func (s *step2CheckServices) skip(role string) bool {
-	kind := s.dcs[0].GetKind()
+	kind := s.dcs[0].GetKind() // services of one part for mixed topology
 	// KIND_CURVEFS
 	if kind == topology.KIND_CURVEFS {
 		if role == ROLE_CHUNKSERVER || role == ROLE_SNAPSHOTCLONE {
@@ -262,7 +304,7 @@ func (s *step2CheckServices) Execute(ctx *context.Context) error {
 func NewCheckTopologyTask(curveadm *cli.CurveAdm, null interface{}) (*task.Task, error) {
 	// new task
 	dcs := curveadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS).([]*topology.DeployConfig)
-	subname := fmt.Sprintf("cluster=%s kind=%s", curveadm.ClusterName(), dcs[0].GetKind())
+	subname := fmt.Sprintf("cluster=%s kind=%s", curveadm.ClusterName(), topology.GetKind(dcs))
 	t := task.NewTask("Check Topology <topology>", subname, nil)
 
 	// add step to task
@@ -277,10 +319,13 @@ func NewCheckTopologyTask(curveadm *cli.CurveAdm, null interface{}) (*task.Task,
 	}
 	t.AddStep(&step2CheckDataDirectoryDuplicate{dcs: dcs})
 	t.AddStep(&step2CheckAddressDuplicate{dcs: dcs})
-	t.AddStep(&step2CheckServices{
-		dcs:      dcs,
-		curveadm: curveadm,
-	})
+	t.AddStep(&step2CheckIPFamily{dcs: dcs})
+	for _, part := range topology.SplitByKind(dcs) { // mixed topology
+		t.AddStep(&step2CheckServices{
+			dcs:      part,
+			curveadm: curveadm,
+		})
+	}
 	for _, dc := range dcs {
 		t.AddStep(&step2CheckS3Configure{
 			dc:       dc,
@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package checker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseTopologyWithIPs(t *testing.T, ips []string) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	for i, host := range []string{"server-host1", "server-host2", "server-host3"} {
+		ctx.Add(host, ips[i])
+	}
+	dcs, err := topology.ParseTopology(LINT_TOPOLOGY, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func TestCheckIPFamily(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		ips        []string
+		consistent bool
+	}{
+		{[]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, true},
+		{[]string{"fd00::1", "fd00::2", "fd00::3"}, true},
+		{[]string{"server-host1", "fd00::2", "fd00::3"}, true}, // hostname ignored
+		{[]string{"10.0.0.1", "fd00::2", "10.0.0.3"}, false},
+	}
+	for _, tt := range tests {
+		s := &step2CheckIPFamily{dcs: parseTopologyWithIPs(t, tt.ips)}
+		err := s.Execute(nil)
+		if tt.consistent {
+			assert.Nil(err)
+		} else {
+			assert.True(errors.Is(err, errno.ERR_INCONSISTENT_IP_FAMILY))
+		}
+	}
+}
+
+func TestGetNginxListens(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := parseTopologyWithIPs(t, []string{"fd00::1", "fd00::2", "fd00::3"})
+	assert.Equal("listen [fd00::1]:2380; listen [fd00::1]:2379;", getNginxListens(dcs[0]))
+	dcs = parseTopologyWithIPs(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"})
+	assert.Equal("listen 10.0.0.1:2380; listen 10.0.0.1:2379;", getNginxListens(dcs[0]))
+}
@@ -0,0 +1,67 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+package common
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+)
+
+// pull image into curveadm machine, which can reach the registry
+func NewPullArtifactTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	image := v.(string)
+	subname := fmt.Sprintf("image=%s", image)
+	t := task.NewTask("Pull Artifact", subname, nil)
+
+	// add step to task
+	options := curveadm.ExecOptions()
+	options.ExecInLocal = true
+	t.AddStep(&step.PullImage{
+		Image:       image,
+		ExecOptions: options,
+	})
+
+	return t, nil
+}
+
+// save all pulled images into one archive in curveadm machine
+func NewSaveArtifactsTask(curveadm *cli.CurveAdm, null interface{}) (*task.Task, error) {
+	images := curveadm.MemStorage().Get(comm.KEY_ARTIFACT_IMAGES).([]string)
+	output := curveadm.MemStorage().Get(comm.KEY_ARTIFACT_OUTPUT).(string)
+	subname := fmt.Sprintf("images=%d output=%s", len(images), output)
+	t := task.NewTask("Export Artifacts", subname, nil)
+
+	// add step to task
+	options := curveadm.ExecOptions()
+	options.ExecInLocal = true
+	t.AddStep(&step.SaveImage{
+		Images:      images,
+		Output:      output,
+		ExecOptions: options,
+	})
+
+	return t, nil
+}
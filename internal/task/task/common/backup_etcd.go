@@ -23,23 +23,122 @@
 package common
 
 import (
+	"encoding/json"
 	"fmt"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
+const (
+	// etcdctl exits with non-zero if any endpoint unreachable, the reachable ones are still printed
+	COMMAND_ETCD_ENDPOINT_STATUS = "bash -c '%s 2>/dev/null; true'"
+
+	ETCD_RESTORE_SNAPSHOT      = "restore.snapshot.db"
+	ETCD_RESTORE_CLUSTER_TOKEN = "curveadm-etcd-restore"
+	ETCD_RESTORE_SCRIPT        = "restore_etcd.sh"
+
+	/*
+	 * restore the snapshot into a new data directory, then replace the
+	 * member directory with it, the old one is kept as member.bak, see also:
+	 *   https://etcd.io/docs/v3.4/op-guide/recovery/
+	 */
+	ETCD_RESTORE_SCRIPT_TEMPLATE = `#!/usr/bin/env bash
+set -e
+g_etcdctl=%s
+g_data_dir=%s
+g_snapshot=${g_data_dir}/%s
+
+export ETCDCTL_API=3
+${g_etcdctl} snapshot status ${g_snapshot}
+rm -rf ${g_data_dir}/restore.member
+${g_etcdctl} snapshot restore ${g_snapshot} \
+    --name %s \
+    --initial-cluster %s \
+    --initial-cluster-token %s \
+    --initial-advertise-peer-urls %s \
+    --data-dir ${g_data_dir}/restore.member
+rm -rf ${g_data_dir}/member.bak
+if [ -d ${g_data_dir}/member ]; then
+    mv ${g_data_dir}/member ${g_data_dir}/member.bak
+fi
+mv ${g_data_dir}/restore.member/member ${g_data_dir}/member
+rm -rf ${g_data_dir}/restore.member ${g_snapshot}
+`
+)
+
+type (
+	etcdEndpointStatus struct {
+		Endpoint string `json:"Endpoint"`
+		Status   struct {
+			Header struct {
+				MemberId uint64 `json:"member_id"`
+			} `json:"header"`
+			Leader uint64 `json:"leader"`
+		} `json:"Status"`
+	}
+
+	EtcdSnapshotStatus struct {
+		Hash      uint64 `json:"hash"`
+		Revision  int64  `json:"revision"`
+		TotalKey  int64  `json:"totalKey"`
+		TotalSize int64  `json:"totalSize"`
+	}
+)
+
+// ParseEtcdLeader returns the endpoint of leader from output of `etcdctl endpoint status --cluster -w json`
+func ParseEtcdLeader(out string) (string, error) {
+	statuses := []etcdEndpointStatus{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &statuses); err != nil {
+		return "", fmt.Errorf("unrecognized endpoint status: %s", out)
+	}
+	for _, status := range statuses {
+		if status.Status.Leader != 0 && status.Status.Header.MemberId == status.Status.Leader {
+			return status.Endpoint, nil
+		}
+	}
+	return "", fmt.Errorf("no leader elected")
+}
+
+// ParseEtcdSnapshotStatus parses and verifies output of `etcdctl snapshot status -w json`,
+// the snapshot of curve cluster can't be empty
+func ParseEtcdSnapshotStatus(out string) (*EtcdSnapshotStatus, error) {
+	status := &EtcdSnapshotStatus{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), status); err != nil {
+		return nil, fmt.Errorf("unrecognized snapshot status: %s", out)
+	} else if status.Revision <= 0 || status.TotalKey <= 0 {
+		return nil, fmt.Errorf("snapshot is empty: revision=%d, totalKey=%d", status.Revision, status.TotalKey)
+	}
+	return status, nil
+}
+
+func genRestoreEtcdScript(dc *topology.DeployConfig, dcs []*topology.DeployConfig) string {
+	layout := dc.GetProjectLayout()
+	return fmt.Sprintf(ETCD_RESTORE_SCRIPT_TEMPLATE,
+		fmt.Sprintf("%s/etcdctl", layout.ServiceBinDir),
+		layout.ServiceDataDir,
+		ETCD_RESTORE_SNAPSHOT,
+		EtcdMemberName(dc),
+		EtcdInitialCluster(dcs),
+		ETCD_RESTORE_CLUSTER_TOKEN,
+		EtcdPeerURL(dc))
+}
+
 func genBackupCommand(dc *topology.DeployConfig) string {
 	layout := dc.GetProjectLayout()
-	binaryPath := fmt.Sprintf("%s/etcdctl", layout.ServiceBinDir)
-	endpoint := fmt.Sprintf("%s:%d", dc.GetListenIp(), dc.GetListenPort())
+	endpoint := fmt.Sprintf("%s://%s", dc.GetEtcdScheme(), utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()))
 	savePath := fmt.Sprintf("%s/snapshot.%s.db", layout.ServiceDataDir, time.Now().Format("2006-01-02-15:04:05"))
-	command := fmt.Sprintf("%s --endpoints %s snapshot save %s", binaryPath, endpoint, savePath)
-	return command
+	return etcdctlWithEndpoints(dc, endpoint, fmt.Sprintf("snapshot save %s", savePath))
 }
 
 func NewBackupEtcdDataTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
@@ -66,3 +165,171 @@ func NewBackupEtcdDataTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*
 	})
 	return t, nil
 }
+
+/*
+ * the snapshot is streamed from leader into the data directory of dc,
+ * then it's verified and downloaded to the local path specified by option,
+ * the snapshot in remote is removed whether succeed or not.
+ */
+func NewBackupEtcdSnapshotTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	localPath := curveadm.MemStorage().Get(comm.KEY_BACKUP_LOCAL_PATH).(string)
+	name := path.Base(localPath)
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s snapshot=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId), name)
+	t := task.NewTask("Backup Etcd Snapshot", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	options := curveadm.ExecOptions()
+	layout := dc.GetProjectLayout()
+	containerPath := fmt.Sprintf("%s/%s", layout.ServiceDataDir, name)
+	hostPath := fmt.Sprintf("%s/%s", dc.GetDataDir(), name)
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command: fmt.Sprintf(COMMAND_ETCD_ENDPOINT_STATUS,
+			etcdctl(dc, "endpoint status --cluster -w json")),
+		Out:         &out,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			leader, err := ParseEtcdLeader(out)
+			if err != nil {
+				return errno.ERR_BACKUP_ETCD_SNAPSHOT_FAILED.E(err)
+			}
+
+			command := etcdctlWithEndpoints(dc, leader, fmt.Sprintf("snapshot save %s", containerPath))
+			out, err := ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+			if err != nil {
+				return errno.ERR_BACKUP_ETCD_SNAPSHOT_FAILED.S(out)
+			}
+
+			command = etcdctl(dc, fmt.Sprintf("snapshot status %s -w json", containerPath))
+			out, err = ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+			if err != nil {
+				return errno.ERR_VERIFY_ETCD_SNAPSHOT_FAILED.S(out)
+			}
+			status, err := ParseEtcdSnapshotStatus(out)
+			if err != nil {
+				return errno.ERR_VERIFY_ETCD_SNAPSHOT_FAILED.E(err)
+			}
+			curveadm.MemStorage().Set(comm.KEY_ETCD_SNAPSHOT_STATUS, status)
+			return nil
+		},
+	})
+	t.AddStep(&step.DownloadFile{
+		RemotePath:  hostPath,
+		LocalPath:   localPath,
+		Verify:      true,
+		ExecOptions: options,
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{hostPath},
+		ExecOptions: options,
+	})
+
+	return t, nil
+}
+
+/*
+ * the service should be stopped before restoring, the snapshot is restored
+ * by a temporary container which shares the data directory with service.
+ */
+func NewRestoreEtcdDataTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	localPath := curveadm.MemStorage().Get(comm.KEY_BACKUP_LOCAL_PATH).(string)
+	dcs := curveadm.MemStorage().Get(comm.KEY_ETCD_CLUSTER).([]*topology.DeployConfig)
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s member=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId), EtcdMemberName(dc))
+	t := task.NewTask("Restore Etcd Data", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var restoreContainerId, code, out string
+	var success bool
+	options := curveadm.ExecOptions()
+	layout := dc.GetProjectLayout()
+	containerName := fmt.Sprintf("curveadm-restore-etcd-%s", serviceId)
+	scriptPath := fmt.Sprintf("%s/%s", layout.ToolsBinDir, ETCD_RESTORE_SCRIPT)
+	script := genRestoreEtcdScript(dc, dcs)
+	t.AddStep(&step.UploadFile{
+		LocalPath:   localPath,
+		RemotePath:  fmt.Sprintf("%s/%s", dc.GetDataDir(), ETCD_RESTORE_SNAPSHOT),
+		Verify:      true,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.RemoveContainer{ // left by last failed restore
+		ContainerId: containerName,
+		Success:     &success,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.CreateContainer{
+		Image:       dc.GetContainerImage(),
+		Command:     scriptPath,
+		Entrypoint:  "/bin/bash",
+		Name:        containerName,
+		Volumes:     []step.Volume{{HostPath: dc.GetDataDir(), ContainerPath: layout.ServiceDataDir}},
+		Out:         &restoreContainerId,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &restoreContainerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       options,
+	})
+	t.AddStep(&step.StartContainer{
+		ContainerId: &restoreContainerId,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.WaitContainer{
+		ContainerId: containerName,
+		Out:         &code,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.ContainerLogs{
+		ContainerId: containerName,
+		Out:         &out,
+		Success:     &success,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.RemoveContainer{
+		ContainerId: containerName,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			if strings.TrimSpace(code) != "0" {
+				return errno.ERR_RESTORE_ETCD_DATA_FAILED.
+					F("exit code %s: %s", strings.TrimSpace(code), out)
+			}
+			return nil
+		},
+	})
+
+	return t, nil
+}
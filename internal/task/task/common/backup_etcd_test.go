@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEtcdLeader(t *testing.T) {
+	assert := assert.New(t)
+
+	out := `[{"Endpoint":"http://10.0.0.1:2379","Status":{"header":{"cluster_id":14841639068965178418,"member_id":10276657743932975437,"revision":15,"raft_term":2},"version":"3.4.10","dbSize":20480,"leader":10501334649042878790,"raftIndex":18,"raftTerm":2}},` +
+		`{"Endpoint":"http://10.0.0.2:2379","Status":{"header":{"cluster_id":14841639068965178418,"member_id":10501334649042878790,"revision":15,"raft_term":2},"version":"3.4.10","dbSize":20480,"leader":10501334649042878790,"raftIndex":18,"raftTerm":2}}]`
+	leader, err := ParseEtcdLeader(out)
+	assert.Nil(err)
+	assert.Equal("http://10.0.0.2:2379", leader)
+
+	_, err = ParseEtcdLeader(`[{"Endpoint":"http://10.0.0.1:2379","Status":{"header":{"member_id":1},"leader":0}}]`)
+	assert.NotNil(err)
+	_, err = ParseEtcdLeader("")
+	assert.NotNil(err)
+}
+
+func TestParseEtcdSnapshotStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	status, err := ParseEtcdSnapshotStatus(`{"hash":3474280893,"revision":15,"totalKey":12,"totalSize":20480}`)
+	assert.Nil(err)
+	assert.Equal(int64(15), status.Revision)
+	assert.Equal(int64(12), status.TotalKey)
+	assert.Equal(int64(20480), status.TotalSize)
+
+	_, err = ParseEtcdSnapshotStatus(`{"hash":0,"revision":1,"totalKey":0,"totalSize":20480}`)
+	assert.NotNil(err)
+	_, err = ParseEtcdSnapshotStatus("Error: snapshot file integrity check failed")
+	assert.NotNil(err)
+}
+
+func TestGenRestoreEtcdScript(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseEtcdMemberTopology(t)
+
+	script := genRestoreEtcdScript(dcs[1], dcs)
+	assert.Contains(script, "--name etcd10")
+	assert.Contains(script, "--initial-cluster "+EtcdInitialCluster(dcs))
+	assert.Contains(script, "--initial-advertise-peer-urls http://10.0.0.2:2380")
+	assert.Contains(script, "g_data_dir="+dcs[1].GetProjectLayout().ServiceDataDir)
+	assert.True(strings.HasPrefix(script, "#!/usr/bin/env bash\nset -e\n"))
+}
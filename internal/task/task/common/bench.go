@@ -0,0 +1,263 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	BENCH_PATTERN_READ      = "read"
+	BENCH_PATTERN_WRITE     = "write"
+	BENCH_PATTERN_RANDREAD  = "randread"
+	BENCH_PATTERN_RANDWRITE = "randwrite"
+	BENCH_PATTERN_RW        = "rw"
+	BENCH_PATTERN_RANDRW    = "randrw"
+
+	FIO_PERCENTILE_P50  = "50.000000"
+	FIO_PERCENTILE_P99  = "99.000000"
+	FIO_PERCENTILE_P999 = "99.900000"
+
+	FORMAT_FIO_COMMAND = "fio --name=curveadm-bench --filename=%s --rw=%s --bs=%s --size=%s" +
+		" --iodepth=%d --numjobs=%d --runtime=%d --time_based --ioengine=libaio" +
+		" --direct=%d --group_reporting --output-format=json"
+)
+
+var (
+	BENCH_PATTERNS = []string{
+		BENCH_PATTERN_READ,
+		BENCH_PATTERN_WRITE,
+		BENCH_PATTERN_RANDREAD,
+		BENCH_PATTERN_RANDWRITE,
+		BENCH_PATTERN_RW,
+		BENCH_PATTERN_RANDRW,
+	}
+)
+
+type (
+	BenchOptions struct {
+		Pattern   string `json:"pattern"`
+		Size      string `json:"size"`
+		BlockSize string `json:"bs"`
+		IODepth   int    `json:"iodepth"`
+		NumJobs   int    `json:"numjobs"`
+		Runtime   int    `json:"runtime"` // seconds
+	}
+
+	// BenchTarget is the file which fio running on in the client container
+	BenchTarget struct {
+		Host        string
+		ContainerId string
+		Filename    string // nbd device for curvebs, file in mount point for curvefs
+		Direct      bool
+		Temporary   bool // remove the file after benchmark
+	}
+
+	// BenchResult is the performance of one client or the whole cluster,
+	// bandwidth in KiB/s and latency in microseconds
+	BenchResult struct {
+		Host    string  `json:"host"`
+		IOPS    float64 `json:"iops"`
+		BW      float64 `json:"bw"`
+		LatMean float64 `json:"lat_mean"`
+		LatP50  float64 `json:"lat_p50"`
+		LatP99  float64 `json:"lat_p99"`
+		LatP999 float64 `json:"lat_p999"`
+	}
+
+	fioIOStat struct {
+		IOPS float64 `json:"iops"`
+		BW   float64 `json:"bw"`
+		Clat struct {
+			Mean       float64            `json:"mean"`
+			Percentile map[string]float64 `json:"percentile"`
+		} `json:"clat_ns"`
+	}
+
+	fioOutput struct {
+		Jobs []struct {
+			Error int       `json:"error"`
+			Read  fioIOStat `json:"read"`
+			Write fioIOStat `json:"write"`
+		} `json:"jobs"`
+	}
+)
+
+func IsValidBenchPattern(pattern string) bool {
+	return utils.Slice2Map(BENCH_PATTERNS)[pattern]
+}
+
+func getFioCommand(target BenchTarget, options BenchOptions) string {
+	direct := 0
+	if target.Direct {
+		direct = 1
+	}
+	return fmt.Sprintf(FORMAT_FIO_COMMAND, target.Filename, options.Pattern,
+		options.BlockSize, options.Size, options.IODepth, options.NumJobs,
+		options.Runtime, direct)
+}
+
+/*
+ * ParseFioOutput parses the json output of fio which run with `--group_reporting`,
+ * the IOPS and bandwidth of read and write are summed up and the latency
+ * is weighted by IOPS, the unit of latency converted from ns to us.
+ */
+func ParseFioOutput(out string) (BenchResult, error) {
+	result := BenchResult{}
+	// fio may print some warnings before the json output
+	index := strings.Index(out, "{")
+	if index < 0 {
+		return result, fmt.Errorf("json output not found")
+	}
+
+	output := fioOutput{}
+	err := json.Unmarshal([]byte(out[index:]), &output)
+	if err != nil {
+		return result, err
+	} else if len(output.Jobs) == 0 {
+		return result, fmt.Errorf("no jobs in output")
+	} else if output.Jobs[0].Error != 0 {
+		return result, fmt.Errorf("job error: %d", output.Jobs[0].Error)
+	}
+
+	job := output.Jobs[0]
+	for _, stat := range []fioIOStat{job.Read, job.Write} {
+		if stat.IOPS <= 0 {
+			continue
+		}
+		result.IOPS += stat.IOPS
+		result.BW += stat.BW
+		result.LatMean += stat.Clat.Mean / 1000 * stat.IOPS
+		result.LatP50 = math.Max(result.LatP50, stat.Clat.Percentile[FIO_PERCENTILE_P50]/1000)
+		result.LatP99 = math.Max(result.LatP99, stat.Clat.Percentile[FIO_PERCENTILE_P99]/1000)
+		result.LatP999 = math.Max(result.LatP999, stat.Clat.Percentile[FIO_PERCENTILE_P999]/1000)
+	}
+	if result.IOPS > 0 {
+		result.LatMean /= result.IOPS
+	}
+	return result, nil
+}
+
+/*
+ * AggregateBenchResults aggregates the results of all clients into the result
+ * of cluster: the IOPS and bandwidth are summed up, the mean latency is weighted
+ * by IOPS and the percentiles take the worst one.
+ */
+func AggregateBenchResults(results []BenchResult) BenchResult {
+	total := BenchResult{}
+	for _, result := range results {
+		total.IOPS += result.IOPS
+		total.BW += result.BW
+		total.LatMean += result.LatMean * result.IOPS
+		total.LatP50 = math.Max(total.LatP50, result.LatP50)
+		total.LatP99 = math.Max(total.LatP99, result.LatP99)
+		total.LatP999 = math.Max(total.LatP999, result.LatP999)
+	}
+	if total.IOPS > 0 {
+		total.LatMean /= total.IOPS
+	}
+	return total
+}
+
+func setBenchResult(curveadm *cli.CurveAdm, result BenchResult) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string]BenchResult{}
+		v := kv.Get(comm.KEY_ALL_BENCH_RESULTS)
+		if v != nil {
+			m = v.(map[string]BenchResult)
+		}
+		m[result.Host] = result
+		kv.Set(comm.KEY_ALL_BENCH_RESULTS, m)
+		return nil
+	})
+}
+
+func saveBenchResult(curveadm *cli.CurveAdm, host string,
+	success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success {
+			return errno.ERR_RUN_FIO_FAILED.S(*out)
+		}
+
+		result, err := ParseFioOutput(*out)
+		if err != nil {
+			return errno.ERR_PARSE_FIO_OUTPUT_FAILED.E(err)
+		}
+		result.Host = host
+		setBenchResult(curveadm, result)
+		return nil
+	}
+}
+
+func NewRunBenchmarkTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	target := v.(BenchTarget)
+	options := curveadm.MemStorage().Get(comm.KEY_BENCH_OPTIONS).(BenchOptions)
+	hc, err := curveadm.GetHost(target.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("host=%s pattern=%s filename=%s containerId=%s",
+		target.Host, options.Pattern, target.Filename, tui.TrimContainerId(target.ContainerId))
+	t := task.NewTask("Run Benchmark", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var success bool
+	var out string
+	execOptions := curveadm.ExecOptions()
+	if execOptions.ExecTimeoutSec > 0 { // fio exits after runtime
+		execOptions.ExecTimeoutSec += options.Runtime
+	}
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &target.ContainerId,
+		Command:     getFioCommand(target, options),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: execOptions,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: saveBenchResult(curveadm, target.Host, &success, &out),
+	})
+	if target.Temporary {
+		t.AddStep(&step.ContainerExec{
+			ContainerId: &target.ContainerId,
+			Command:     fmt.Sprintf("rm -f %s", target.Filename),
+			Out:         &out,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+
+	return t, nil
+}
@@ -0,0 +1,165 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	FIO_OUTPUT_RANDWRITE = `fio: this platform does not support process shared mutexes, forcing use of threads
+{
+  "fio version" : "fio-3.16",
+  "jobs" : [
+    {
+      "jobname" : "curveadm-bench",
+      "error" : 0,
+      "read" : {
+        "bw" : 0,
+        "iops" : 0.000000,
+        "clat_ns" : {
+          "mean" : 0.000000
+        }
+      },
+      "write" : {
+        "bw" : 40000,
+        "iops" : 10000.000000,
+        "clat_ns" : {
+          "mean" : 12800000.000000,
+          "percentile" : {
+            "50.000000" : 12000000,
+            "99.000000" : 25000000,
+            "99.900000" : 40000000
+          }
+        }
+      }
+    }
+  ]
+}`
+
+	FIO_OUTPUT_RANDRW = `{
+  "jobs" : [
+    {
+      "error" : 0,
+      "read" : {
+        "bw" : 12000,
+        "iops" : 3000.000000,
+        "clat_ns" : {
+          "mean" : 1000000.000000,
+          "percentile" : {
+            "50.000000" : 900000,
+            "99.000000" : 3000000,
+            "99.900000" : 5000000
+          }
+        }
+      },
+      "write" : {
+        "bw" : 4000,
+        "iops" : 1000.000000,
+        "clat_ns" : {
+          "mean" : 5000000.000000,
+          "percentile" : {
+            "50.000000" : 4000000,
+            "99.000000" : 8000000,
+            "99.900000" : 9000000
+          }
+        }
+      }
+    }
+  ]
+}`
+)
+
+func TestParseFioOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	result, err := ParseFioOutput(FIO_OUTPUT_RANDWRITE)
+	assert.Nil(err)
+	assert.Equal(10000.0, result.IOPS)
+	assert.Equal(40000.0, result.BW)
+	assert.Equal(12800.0, result.LatMean)
+	assert.Equal(12000.0, result.LatP50)
+	assert.Equal(25000.0, result.LatP99)
+	assert.Equal(40000.0, result.LatP999)
+
+	// read and write are summed up, latency weighted by IOPS
+	result, err = ParseFioOutput(FIO_OUTPUT_RANDRW)
+	assert.Nil(err)
+	assert.Equal(4000.0, result.IOPS)
+	assert.Equal(16000.0, result.BW)
+	assert.Equal(2000.0, result.LatMean)
+	assert.Equal(4000.0, result.LatP50)
+	assert.Equal(8000.0, result.LatP99)
+	assert.Equal(9000.0, result.LatP999)
+
+	_, err = ParseFioOutput("fio: command not found")
+	assert.NotNil(err)
+	_, err = ParseFioOutput(`{"jobs": []}`)
+	assert.NotNil(err)
+	_, err = ParseFioOutput(`{"jobs": [{"error": 5}]}`)
+	assert.NotNil(err)
+}
+
+func TestAggregateBenchResults(t *testing.T) {
+	assert := assert.New(t)
+
+	total := AggregateBenchResults([]BenchResult{
+		{Host: "host1", IOPS: 3000, BW: 12000, LatMean: 100, LatP50: 90, LatP99: 300, LatP999: 500},
+		{Host: "host2", IOPS: 1000, BW: 4000, LatMean: 500, LatP50: 400, LatP99: 200, LatP999: 900},
+	})
+	assert.Equal(4000.0, total.IOPS)
+	assert.Equal(16000.0, total.BW)
+	assert.Equal(200.0, total.LatMean)
+	assert.Equal(400.0, total.LatP50)
+	assert.Equal(300.0, total.LatP99)
+	assert.Equal(900.0, total.LatP999)
+
+	total = AggregateBenchResults([]BenchResult{})
+	assert.Equal(0.0, total.IOPS)
+	assert.Equal(0.0, total.LatMean)
+}
+
+func TestGetFioCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	options := BenchOptions{
+		Pattern:   BENCH_PATTERN_RANDWRITE,
+		Size:      "10G",
+		BlockSize: "4k",
+		IODepth:   128,
+		NumJobs:   1,
+		Runtime:   60,
+	}
+	command := getFioCommand(BenchTarget{Filename: "/dev/nbd0", Direct: true}, options)
+	assert.Contains(command, "--filename=/dev/nbd0 --rw=randwrite --bs=4k --size=10G")
+	assert.Contains(command, "--direct=1")
+	assert.Contains(command, "--output-format=json")
+
+	command = getFioCommand(BenchTarget{Filename: "/curvefs/client/mnt/tmp/bench/file"}, options)
+	assert.Contains(command, "--direct=0")
+
+	assert.True(IsValidBenchPattern("randrw"))
+	assert.False(IsValidBenchPattern("trim"))
+}
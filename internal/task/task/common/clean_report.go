@@ -0,0 +1,171 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	COMMAND_DISK_USAGE_BYTES = "du -sb %s"
+	CLEAN_REPORT_UNKNOWN     = "-"
+)
+
+type (
+	// CleanDir is the directory which will be removed while cleaning service
+	CleanDir struct {
+		Path   string `json:"path"`
+		Device string `json:"device"`
+		Bytes  int64  `json:"bytes"` // -1 means unknown
+	}
+
+	// CleanReport is what will be destroyed for one service
+	CleanReport struct {
+		ServiceId   string     `json:"service_id"`
+		Role        string     `json:"role"`
+		Host        string     `json:"host"`
+		ContainerId string     `json:"container_id"`
+		Dirs        []CleanDir `json:"dirs"`
+	}
+
+	step2GetCleanReport struct {
+		dc          *topology.DeployConfig
+		serviceId   string
+		containerId string
+		files       []string
+		memStorage  *utils.SafeMap
+		execOptions module.ExecOptions
+	}
+)
+
+// output of du: "1048576\t/data/chunkserver0"
+func ParseDiskUsageBytes(out string) (int64, bool) {
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	bytes, err := strconv.ParseInt(fields[0], 10, 64)
+	return bytes, err == nil
+}
+
+// output of df: "Filesystem\n/dev/sdb"
+func ParseDiskFreeSource(out string) (string, bool) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return "", false
+	}
+	return strings.TrimSpace(lines[len(lines)-1]), true
+}
+
+func addCleanReport(memStorage *utils.SafeMap, report CleanReport) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		reports := []CleanReport{}
+		v := kv.Get(comm.KEY_ALL_CLEAN_REPORTS)
+		if v != nil {
+			reports = v.([]CleanReport)
+		}
+		reports = append(reports, report)
+		kv.Set(comm.KEY_ALL_CLEAN_REPORTS, reports)
+		return nil
+	})
+}
+
+func (s *step2GetCleanReport) Execute(ctx *context.Context) error {
+	dirs := []CleanDir{}
+	for _, file := range s.files {
+		if len(file) == 0 { // directory not configured
+			continue
+		}
+		dir := CleanDir{Path: file, Device: CLEAN_REPORT_UNKNOWN, Bytes: -1}
+		out, err := ctx.Module().Shell().
+			Command(fmt.Sprintf(COMMAND_DISK_USAGE_BYTES, file)).
+			Execute(s.execOptions)
+		if bytes, ok := ParseDiskUsageBytes(out); err == nil && ok {
+			dir.Bytes = bytes
+		}
+		out, err = ctx.Module().Shell().
+			DiskFree(file).
+			AddOption("--output=source").
+			Execute(s.execOptions)
+		if device, ok := ParseDiskFreeSource(out); err == nil && ok {
+			dir.Device = device
+		}
+		dirs = append(dirs, dir)
+	}
+
+	addCleanReport(s.memStorage, CleanReport{
+		ServiceId:   s.serviceId,
+		Role:        s.dc.GetRole(),
+		Host:        s.dc.GetHost(),
+		ContainerId: s.containerId,
+		Dirs:        dirs,
+	})
+	return nil
+}
+
+func NewGetCleanReportTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Get Clean Report", subname, hc.GetSSHConfig())
+
+	// add step to task
+	only := curveadm.MemStorage().Get(comm.KEY_CLEAN_ITEMS).([]string)
+	recycle := curveadm.MemStorage().Get(comm.KEY_CLEAN_BY_RECYCLE).(bool)
+	files := getCleanFiles(utils.Slice2Map(only), dc, recycle)
+	sort.Strings(files)
+	t.AddStep(&step2GetCleanReport{
+		dc:          dc,
+		serviceId:   serviceId,
+		containerId: containerId,
+		files:       files,
+		memStorage:  curveadm.MemStorage(),
+		execOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
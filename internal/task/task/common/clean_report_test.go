@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiskUsageBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	bytes, ok := ParseDiskUsageBytes("1048576\t/data/chunkserver0\n")
+	assert.True(ok)
+	assert.Equal(int64(1048576), bytes)
+
+	_, ok = ParseDiskUsageBytes("")
+	assert.False(ok)
+	_, ok = ParseDiskUsageBytes("du: cannot access '/data/chunkserver0': No such file or directory")
+	assert.False(ok)
+}
+
+func TestParseDiskFreeSource(t *testing.T) {
+	assert := assert.New(t)
+
+	device, ok := ParseDiskFreeSource("Filesystem\n/dev/sdb\n")
+	assert.True(ok)
+	assert.Equal("/dev/sdb", device)
+
+	_, ok = ParseDiskFreeSource("")
+	assert.False(ok)
+}
@@ -0,0 +1,337 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+/*
+ * support bundle layout:
+ *
+ *   curveadm-bundle-<cluster>-<time>
+ *     curveadm/    (topology, pool, hosts, database records)
+ *     hosts/
+ *       <host>/    (uname.txt, lsblk.txt, smartctl.txt...)
+ *     tools/       (curve_ops_tool outputs)
+ *     services/
+ *       <role>/
+ *         <service id>/ (conf/, logs/, docker.log)
+ */
+const (
+	BUNDLE_DIR_HOSTS    = "hosts"
+	BUNDLE_DIR_TOOLS    = "tools"
+	BUNDLE_DIR_SERVICES = "services"
+
+	BUNDLE_CONTAINER_LOGS_TARBALL = "/tmp/curveadm-bundle-logs.tar.gz"
+	// archive logs which modified in recent minutes
+	COMMAND_ARCHIVE_RECENT_LOGS = "bash -c 'cd %s && find . -type f -mmin -%d | tar -czf %s -T -'"
+)
+
+type bundleCommand struct {
+	name    string
+	command string
+}
+
+var (
+	BUNDLE_HOST_COMMANDS = []bundleCommand{
+		{"uname", "uname -a"},
+		{"os-release", "cat /etc/os-release"},
+		{"uptime", "uptime"},
+		{"free", "free -m"},
+		{"df", "df -h"},
+		{"lsblk", "lsblk -o NAME,SIZE,TYPE,ROTA,MOUNTPOINT,MODEL"},
+		{"mounts", "cat /proc/mounts"},
+		{"dmesg", "bash -c 'dmesg -T | tail -n 2000'"},
+		{"smartctl", `bash -c 'for d in $(lsblk -dno NAME -e 7,11); do echo "=== /dev/$d"; smartctl -a /dev/$d; done'`},
+	}
+
+	BUNDLE_CURVEBS_TOOL_COMMANDS = []bundleCommand{
+		{"status", "curve_ops_tool status"},
+		{"copysets-status", "curve_ops_tool copysets-status"},
+		{"chunkserver-list", "curve_ops_tool chunkserver-list"},
+		{"space", "curve_ops_tool space"},
+		{"etcd-status", "curve_ops_tool etcd-status"},
+		{"mds-status", "curve_ops_tool mds-status"},
+	}
+
+	BUNDLE_CURVEFS_TOOL_COMMANDS = []bundleCommand{
+		{"status", "%s status"},
+		{"status-copyset", "%s status-copyset"},
+		{"list-topology", "%s list-topology"},
+	}
+)
+
+// the output is saved even if the command failed, it's helpful for diagnosis too
+func writeBundleFile(dir, name, content string, err error) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err != nil {
+		content = fmt.Sprintf("%s\n\n(command failed: %s)\n", content, err)
+	}
+	return os.WriteFile(path.Join(dir, name), []byte(content), 0644)
+}
+
+// createBundleFile creates file which large output (e.g. docker logs) streamed into
+func createBundleFile(dir, name string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return os.Create(path.Join(dir, name))
+}
+
+func bundleDir(curveadm *cli.CurveAdm, elem ...string) string {
+	root := curveadm.MemStorage().Get(comm.KEY_BUNDLE_DIR).(string)
+	return filepath.Join(append([]string{root}, elem...)...)
+}
+
+func bundleSince(curveadm *cli.CurveAdm) time.Duration {
+	since, _ := curveadm.MemStorage().Get(comm.KEY_BUNDLE_SINCE).(time.Duration)
+	return since
+}
+
+func NewCollectBundleHostTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s", dc.GetHost())
+	t := task.NewTask("Collect Host Information", subname, hc.GetSSHConfig())
+
+	// add step to task
+	dir := bundleDir(curveadm, BUNDLE_DIR_HOSTS, dc.GetHost())
+	options := curveadm.ExecOptions()
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			for _, c := range BUNDLE_HOST_COMMANDS {
+				out, err := ctx.Module().Shell().Command(c.command).Execute(options)
+				if err := writeBundleFile(dir, c.name+".txt", out, err); err != nil {
+					return err
+				}
+			}
+			out, err := ctx.Module().DockerCli().ListContainers().AddOption("--all").Execute(options)
+			return writeBundleFile(dir, "containers.txt", out, err)
+		},
+	})
+
+	return t, nil
+}
+
+func NewCollectBundleToolsTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Collect Tool Outputs", subname, hc.GetSSHConfig())
+
+	// add step to task
+	commands := BUNDLE_CURVEBS_TOOL_COMMANDS
+	if dc.GetKind() == topology.KIND_CURVEFS {
+		commands = []bundleCommand{}
+		for _, c := range BUNDLE_CURVEFS_TOOL_COMMANDS {
+			c.command = fmt.Sprintf(c.command, dc.GetProjectLayout().ToolsBinaryPath)
+			commands = append(commands, c)
+		}
+	}
+	dir := bundleDir(curveadm, BUNDLE_DIR_TOOLS)
+	options := curveadm.ExecOptions()
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			for _, c := range commands {
+				out, err := ctx.Module().DockerCli().ContainerExec(containerId, c.command).Execute(options)
+				if err := writeBundleFile(dir, c.name+".txt", out, err); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	return t, nil
+}
+
+func NewCollectBundleServiceTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if len(containerId) == 0 || containerId == comm.CLEANED_CONTAINER_ID {
+		return nil, nil
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Collect Service Bundle", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var success bool
+	since := bundleSince(curveadm)
+	layout := dc.GetProjectLayout()
+	vname := utils.NewVariantName(fmt.Sprintf("%s_%s", serviceId, utils.RandString(5)))
+	remoteSaveDir := path.Join(TEMP_DIR, vname.Name)                                 // /tmp/7b510fb63730_ox1fe
+	remoteTarballPath := path.Join(TEMP_DIR, vname.CompressName)                     // /tmp/7b510fb63730_ox1fe.tar.gz
+	localTarballPath := filepath.Join(utils.LocalTempDir(), vname.LocalCompressName) // /tmp/7b510fb63730_ox1fe.local.tar.gz
+	localDir := bundleDir(curveadm, BUNDLE_DIR_SERVICES, dc.GetRole(), serviceId)
+	localLogsTarball := filepath.Join(localDir, path.Base(BUNDLE_CONTAINER_LOGS_TARBALL))
+	localOptions := curveadm.ExecOptions()
+	localOptions.ExecWithSudo = false
+	localOptions.ExecInLocal = true
+
+	t.AddStep(&step.CreateDirectory{
+		Paths:       []string{remoteSaveDir},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.CopyFromContainer{ // copy conf directory
+		ContainerId:      containerId,
+		ContainerSrcPath: layout.ServiceConfDir,
+		HostDestPath:     remoteSaveDir,
+		ExecOptions:      curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{ // archive recent logs
+		ContainerId: &containerId,
+		Command: fmt.Sprintf(COMMAND_ARCHIVE_RECENT_LOGS, layout.ServiceLogDir,
+			int(since.Minutes()), BUNDLE_CONTAINER_LOGS_TARBALL),
+		Success:     &success,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			if !success {
+				return nil
+			}
+			return (&step.CopyFromContainer{
+				ContainerId:      containerId,
+				ContainerSrcPath: BUNDLE_CONTAINER_LOGS_TARBALL,
+				HostDestPath:     remoteSaveDir,
+				ExecOptions:      curveadm.ExecOptions(),
+			}).Execute(ctx)
+		},
+	})
+	t.AddStep(&step.Lambda{ // stream docker logs into file, it maybe gigabytes
+		Lambda: func(ctx *context.Context) error {
+			file, err := createBundleFile(localDir, "docker.log")
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			return (&step.ContainerLogs{
+				ContainerId: containerId,
+				Since:       since.String(),
+				Writer:      file,
+				ExecOptions: curveadm.ExecOptions(),
+			}).Execute(ctx)
+		},
+	})
+	t.AddStep(&step.Tar{
+		File:        path.Base(remoteSaveDir),
+		Directory:   TEMP_DIR,
+		Archive:     remoteTarballPath,
+		Create:      true,
+		Gzip:        true,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.DownloadFile{
+		RemotePath:  remoteTarballPath,
+		LocalPath:   localTarballPath,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.CreateDirectory{
+		Paths:       []string{filepath.Join(localDir, "logs")},
+		ExecOptions: localOptions,
+	})
+	t.AddStep(&step.Tar{
+		Archive:         localTarballPath,
+		Directory:       localDir,
+		Extract:         true,
+		UnGzip:          true,
+		StripComponents: 1,
+		ExecOptions:     localOptions,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			if success {
+				err := (&step.Tar{
+					Archive:     localLogsTarball,
+					Directory:   filepath.Join(localDir, "logs"),
+					Extract:     true,
+					UnGzip:      true,
+					ExecOptions: localOptions,
+				}).Execute(ctx)
+				if err != nil {
+					return err
+				}
+				os.Remove(localLogsTarball)
+			}
+			return nil
+		},
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{remoteSaveDir, remoteTarballPath},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{localTarballPath},
+		ExecOptions: localOptions,
+	})
+	t.AddPostStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     fmt.Sprintf("rm -f %s", BUNDLE_CONTAINER_LOGS_TARBALL),
+		ExecOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
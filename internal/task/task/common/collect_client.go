@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"path"
+	"path/filepath"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -54,13 +55,13 @@ func NewCollectClientTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, er
 	clientId := client.Id
 	secret := curveadm.MemStorage().Get(comm.KEY_SECRET).(string)
 	urlFormat := curveadm.MemStorage().Get(comm.KEY_SUPPORT_UPLOAD_URL_FORMAT).(string)
-	baseDir := TEMP_DIR
+	baseDir, localDir := TEMP_DIR, utils.LocalTempDir()
 	vname := utils.NewVariantName(fmt.Sprintf("%s_%s", clientId, utils.RandString(5)))
-	remoteSaveDir := fmt.Sprintf("/%s/%s", baseDir, vname.Name)               // /tmp/7b510fb63730_ox1fe
-	remoteTarbllPath := path.Join(baseDir, vname.CompressName)                // /tmp/7b510fb63730_ox1fe.tar.gz
-	localTarballPath := path.Join(baseDir, vname.CompressName)                // /tmp/7b510fb63730_ox1fe.tar.gz
-	localEncryptdTarballPath := path.Join(baseDir, vname.EncryptCompressName) // // /tmp/7b510fb63730_ox1fe-encrypted.tar.gz
-	httpSavePath := path.Join("/", encodeSecret(secret), "client")            // /34701feb224479a20a5090510f648037/client
+	remoteSaveDir := fmt.Sprintf("/%s/%s", baseDir, vname.Name)                    // /tmp/7b510fb63730_ox1fe
+	remoteTarbllPath := path.Join(baseDir, vname.CompressName)                     // /tmp/7b510fb63730_ox1fe.tar.gz
+	localTarballPath := filepath.Join(localDir, vname.CompressName)                // /tmp/7b510fb63730_ox1fe.tar.gz
+	localEncryptdTarballPath := filepath.Join(localDir, vname.EncryptCompressName) // // /tmp/7b510fb63730_ox1fe-encrypted.tar.gz
+	httpSavePath := path.Join("/", encodeSecret(secret), "client")                 // /34701feb224479a20a5090510f648037/client
 	containerLogDir := utils.Choose(client.Kind == topology.KIND_CURVEBS,
 		"/curvebs/nebd/logs", "/curvefs/client/logs")
 	containerConfDir := utils.Choose(client.Kind == topology.KIND_CURVEBS,
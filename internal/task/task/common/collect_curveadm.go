@@ -25,6 +25,7 @@ package common
 import (
 	"fmt"
 	"path"
+	"path/filepath"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -46,11 +47,11 @@ func NewCollectCurveAdmTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (
 	dbPath := curveadm.Config().GetDBPath()
 	secret := curveadm.MemStorage().Get(comm.KEY_SECRET).(string)
 	urlFormat := curveadm.MemStorage().Get(comm.KEY_SUPPORT_UPLOAD_URL_FORMAT).(string)
-	baseDir := TEMP_DIR
+	baseDir := utils.LocalTempDir()
 	vname := utils.NewVariantName(fmt.Sprintf("curveadm_%s", utils.RandString(5)))
-	localPath := path.Join(baseDir, vname.Name)                // /tmp/curveadm_is90x
-	localTarballPath := path.Join(baseDir, vname.CompressName) // /tmp/curveadm_is90x.tar.gz
-	localEncryptdTarballPath := path.Join(baseDir, vname.EncryptCompressName)
+	localPath := filepath.Join(baseDir, vname.Name)                // /tmp/curveadm_is90x
+	localTarballPath := filepath.Join(baseDir, vname.CompressName) // /tmp/curveadm_is90x.tar.gz
+	localEncryptdTarballPath := filepath.Join(baseDir, vname.EncryptCompressName)
 	httpSavePath := path.Join("/", encodeSecret(secret), "data")
 	options := curveadm.ExecOptions()
 	options.ExecWithSudo = false
@@ -83,11 +83,11 @@ func NewCollectReportTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*t
 		var success bool
 		secret := curveadm.MemStorage().Get(comm.KEY_SECRET).(string)
 		urlFormat := curveadm.MemStorage().Get(comm.KEY_SUPPORT_UPLOAD_URL_FORMAT).(string)
-		baseDir := TEMP_DIR
+		baseDir := utils.LocalTempDir()
 		vname := utils.NewVariantName(fmt.Sprintf("report_%s", utils.RandString(5)))
-		localPath := path.Join(baseDir, vname.Name)                // /tmp/report_is90x
-		localTarballPath := path.Join(baseDir, vname.CompressName) // /tmp/report_is90x.tar.gz
-		localEncryptdTarballPath := path.Join(baseDir, vname.EncryptCompressName)
+		localPath := filepath.Join(baseDir, vname.Name)                // /tmp/report_is90x
+		localTarballPath := filepath.Join(baseDir, vname.CompressName) // /tmp/report_is90x.tar.gz
+		localEncryptdTarballPath := filepath.Join(baseDir, vname.EncryptCompressName)
 		httpSavePath := path.Join("/", encodeSecret(secret), "report")
 		options := curveadm.ExecOptions()
 		options.ExecWithSudo = false
@@ -25,6 +25,7 @@ package common
 import (
 	"fmt"
 	"path"
+	"path/filepath"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -99,12 +100,12 @@ func NewCollectServiceTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*
 	var out string
 	secret := curveadm.MemStorage().Get(comm.KEY_SECRET).(string)
 	urlFormat := curveadm.MemStorage().Get(comm.KEY_SUPPORT_UPLOAD_URL_FORMAT).(string)
-	baseDir := TEMP_DIR
+	baseDir, localDir := TEMP_DIR, utils.LocalTempDir()
 	vname := utils.NewVariantName(fmt.Sprintf("%s_%s", serviceId, utils.RandString(5)))
-	remoteSaveDir := fmt.Sprintf("%s/%s", baseDir, vname.Name)                // /tmp/7b510fb63730_ox1fe
-	remoteTarbllPath := path.Join(baseDir, vname.CompressName)                // /tmp/7b510fb63730_ox1fe.tar.gz
-	localTarballPath := path.Join(baseDir, vname.LocalCompressName)           // /tmp/7b510fb63730_ox1fe.local.tar.gz
-	localEncryptdTarballPath := path.Join(baseDir, vname.EncryptCompressName) // /tmp/7b510fb63730_ox1fe-encrypted.tar.gz
+	remoteSaveDir := fmt.Sprintf("%s/%s", baseDir, vname.Name)                     // /tmp/7b510fb63730_ox1fe
+	remoteTarbllPath := path.Join(baseDir, vname.CompressName)                     // /tmp/7b510fb63730_ox1fe.tar.gz
+	localTarballPath := filepath.Join(localDir, vname.LocalCompressName)           // /tmp/7b510fb63730_ox1fe.local.tar.gz
+	localEncryptdTarballPath := filepath.Join(localDir, vname.EncryptCompressName) // /tmp/7b510fb63730_ox1fe-encrypted.tar.gz
 	httpSavePath := path.Join("/", encodeSecret(secret), "service", dc.GetRole())
 	layout := dc.GetProjectLayout()
 	containerLogDir := layout.ServiceLogDir   // /curvebs/etcd/logs
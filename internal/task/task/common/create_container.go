@@ -237,19 +237,24 @@ func NewCreateContainerTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (
 		ExecOptions: options,
 	})
 	t.AddStep(&step.CreateContainer{
-		Image:       dc.GetContainerImage(),
-		Command:     fmt.Sprintf("--role %s --args='%s'", role, getArguments(dc)),
-		AddHost:     []string{fmt.Sprintf("%s:127.0.0.1", hostname)},
-		Envs:        getEnvironments(dc),
-		Hostname:    hostname,
-		Init:        true,
-		Name:        hostname,
-		Privileged:  true,
-		Restart:     getRestartPolicy(dc),
-		Ulimits:     []string{"core=-1"},
-		Volumes:     getMountVolumes(dc),
-		Out:         &containerId,
-		ExecOptions: curveadm.ExecOptions(),
+		Image:         dc.GetContainerImage(),
+		Command:       fmt.Sprintf("--role %s --args='%s'", role, getArguments(dc)),
+		AddHost:       []string{fmt.Sprintf("%s:127.0.0.1", hostname)},
+		CgroupParent:  dc.GetResourcesCgroupParent(),
+		Cpus:          dc.GetResourcesCpus(),
+		CpusetCpus:    dc.GetResourcesCpusetCpus(),
+		Envs:          getEnvironments(dc),
+		Hostname:      hostname,
+		Init:          true,
+		Memory:        dc.GetResourcesMemory(),
+		Name:          hostname,
+		Privileged:    true,
+		Restart:       getRestartPolicy(dc),
+		Ulimits:       []string{"core=-1"},
+		Volumes:       getMountVolumes(dc),
+		IgnoreExisted: true, // the container may be created in last failed deploy
+		Out:           &containerId,
+		ExecOptions:   curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.Lambda{
 		Lambda: TrimContainerId(&containerId),
@@ -45,6 +45,8 @@ import (
 type step2SetClusterPool struct {
 	curveadm    *cli.CurveAdm
 	clusterPool string
+	kind        string
+	mixed       bool
 	storage     *storage.Storage
 }
 
@@ -55,25 +57,51 @@ func getPoolset(curveadm *cli.CurveAdm, kind string) configure.Poolset {
 	return curveadm.MemStorage().Get(comm.KEY_POOLSET).(configure.Poolset)
 }
 
+// loadClusterPool returns the cluster pool in storage, the one cached in
+// curveadm is stale once the other kind of mixed topology persisted its pool
+func loadClusterPool(curveadm *cli.CurveAdm) (configure.CurveClusterTopo, error) {
+	pool := configure.CurveClusterTopo{}
+	clusters, err := curveadm.Storage().GetClusters(curveadm.ClusterName())
+	if err != nil {
+		return pool, errno.ERR_GET_CLUSTER_BY_NAME_FAILED.E(err)
+	} else if len(clusters) == 0 || len(clusters[0].Pool) == 0 {
+		return pool, nil
+	}
+
+	err = json.Unmarshal([]byte(clusters[0].Pool), &pool)
+	return pool, err
+}
+
 func getClusterPool(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (configure.CurveClusterTopo, error) {
-	poolset := getPoolset(curveadm, dc.GetKind())
+	kind := dc.GetKind()
+	poolset := getPoolset(curveadm, kind)
 	oldPool := configure.CurveClusterTopo{}
 	dcs, err := curveadm.ParseTopology()
 	if err != nil {
 		return oldPool, err
 	}
 
-	// 1) generate a new default pool
-	data := curveadm.ClusterPoolData()
-	if len(data) == 0 {
-		return configure.GenerateDefaultClusterPool(dcs, poolset)
+	// 1) load old pool, the pool of mixed topology is the part of its kind
+	if topology.IsMixed(dcs) {
+		stored, err := loadClusterPool(curveadm)
+		if err != nil {
+			return oldPool, err
+		}
+		dcs = topology.SelectByKind(dcs, kind)
+		oldPool = configure.SelectClusterPool(stored, kind)
+	} else if len(curveadm.ClusterPoolData()) > 0 {
+		err = json.Unmarshal([]byte(curveadm.ClusterPoolData()), &oldPool)
+		if err != nil {
+			return oldPool, err
+		}
 	}
 
-	// 2) OR change old pool and return it
-	err = json.Unmarshal([]byte(data), &oldPool)
-	if err != nil {
-		return oldPool, err
+	// 2) generate a new default pool if no old pool
+	if len(oldPool.Servers) == 0 {
+		return configure.GenerateDefaultClusterPool(dcs, poolset)
 	}
+
+	// 3) OR change old pool and return it
 	pool, err := configure.GenerateDefaultClusterPool(dcs, poolset)
 	if err != nil {
 		return pool, err
@@ -86,7 +114,7 @@ func getClusterPool(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (configur
 		oldPool.Servers[i].ExternalIp = server.ExternalIp
 		oldPool.Servers[i].ExternalPort = server.ExternalPort
 	}
-	if dc.GetKind() == topology.KIND_CURVEBS {
+	if kind == topology.KIND_CURVEBS {
 		for i, pool := range pool.LogicalPools {
 			oldPool.LogicalPools[i].Copysets = pool.Copysets
 		}
@@ -174,7 +202,27 @@ func (s *step2SetClusterPool) Execute(ctx *context.Context) error {
 		topology = value.(string)
 	}
 
-	err := s.storage.SetClusterPool(curveadm.ClusterId(), topology, s.clusterPool)
+	// merge the pool of this kind into the stored one for mixed topology
+	clusterPool := s.clusterPool
+	if s.mixed {
+		pool, err := loadClusterPool(curveadm)
+		if err != nil {
+			return err
+		}
+		part := configure.CurveClusterTopo{}
+		err = json.Unmarshal([]byte(s.clusterPool), &part)
+		if err != nil {
+			return errno.ERR_UPDATE_CLUSTER_POOL_FAILED.E(err)
+		}
+		configure.MergeClusterPool(&pool, part, s.kind)
+		bytes, err := json.Marshal(pool)
+		if err != nil {
+			return errno.ERR_UPDATE_CLUSTER_POOL_FAILED.E(err)
+		}
+		clusterPool = string(bytes)
+	}
+
+	err := s.storage.SetClusterPool(curveadm.ClusterId(), topology, clusterPool)
 	if err != nil {
 		return errno.ERR_UPDATE_CLUSTER_POOL_FAILED.E(err)
 	}
@@ -282,9 +330,15 @@ func NewCreateTopologyTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*
 		Lambda: checkCreatePoolStatus(&success, &out),
 	})
 	if pooltype == comm.POOL_TYPE_LOGICAL {
+		dcs, err := curveadm.ParseTopology()
+		if err != nil {
+			return nil, err
+		}
 		t.AddStep(&step2SetClusterPool{
 			curveadm:    curveadm,
 			clusterPool: clusterPoolJson,
+			kind:        dc.GetKind(),
+			mixed:       topology.IsMixed(dcs),
 			storage:     curveadm.Storage(),
 		})
 	}
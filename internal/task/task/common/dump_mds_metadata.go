@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+/*
+ * the mds metadata dump is a text file which consists of outputs of tool
+ * commands, each one is led by its name, e.g:
+ *
+ *   === server-list
+ *   ...
+ *   === logical-pool-list
+ *   ...
+ *
+ * it's used to rebuild or verify the topology after disaster, the data
+ * of metadata is still in etcd which backed up by etcd snapshot.
+ */
+var (
+	MDS_DUMP_CURVEBS_COMMANDS = []bundleCommand{
+		{"server-list", "curve_ops_tool server-list"},
+		{"chunkserver-list", "curve_ops_tool chunkserver-list -checkHealth=false"},
+		{"logical-pool-list", "curve_ops_tool logical-pool-list"},
+		{"file-list", "curve_ops_tool list -fileName=/"},
+	}
+
+	MDS_DUMP_CURVEFS_COMMANDS = []bundleCommand{
+		{"list-topology", "%s list-topology"},
+		{"list-fs", "%s list-fs"},
+	}
+)
+
+func getMdsDumpCommands(dc *topology.DeployConfig) []bundleCommand {
+	if dc.GetKind() != topology.KIND_CURVEFS {
+		return MDS_DUMP_CURVEBS_COMMANDS
+	}
+	commands := []bundleCommand{}
+	for _, c := range MDS_DUMP_CURVEFS_COMMANDS {
+		c.command = fmt.Sprintf(c.command, dc.GetProjectLayout().ToolsBinaryPath)
+		commands = append(commands, c)
+	}
+	return commands
+}
+
+func NewDumpMdsMetadataTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Dump MDS Metadata", subname, hc.GetSSHConfig())
+
+	// add step to task
+	localPath := curveadm.MemStorage().Get(comm.KEY_BACKUP_LOCAL_PATH).(string)
+	commands := getMdsDumpCommands(dc)
+	options := curveadm.ExecOptions()
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			var sb strings.Builder
+			for _, c := range commands {
+				out, err := ctx.Module().DockerCli().ContainerExec(containerId, c.command).Execute(options)
+				if err != nil {
+					return errno.ERR_DUMP_MDS_METADATA_FAILED.
+						F("%s: %s", c.name, out)
+				}
+				fmt.Fprintf(&sb, "=== %s\n%s\n\n", c.name, strings.TrimSpace(out))
+			}
+			return os.WriteFile(localPath, []byte(sb.String()), 0644)
+		},
+	})
+
+	return t, nil
+}
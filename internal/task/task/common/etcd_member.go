@@ -0,0 +1,368 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	ETCD_INITIAL_CLUSTER_STATE          = "initial-cluster-state"
+	ETCD_INITIAL_CLUSTER_STATE_EXISTING = "existing"
+	ETCD_INITIAL_CLUSTER                = "initial-cluster"
+
+	ETCD_MEMBER_STARTED = "started"
+	// e.g: http://10.0.0.1:2379 is healthy: successfully committed proposal: took = 2.1ms
+	REGEX_ETCD_ENDPOINT_HEALTH = `^(\S+) is (healthy|unhealthy)`
+	// etcdctl exits with non-zero if any endpoint unhealthy, and prints it to stderr
+	COMMAND_ETCD_ENDPOINT_HEALTH = "bash -c '%s 2>&1; true'"
+
+	WAIT_ETCD_HEALTHY_INTERVAL = 3 * time.Second
+	WAIT_ETCD_HEALTHY_TIMEOUT  = 5 * time.Minute
+)
+
+// EtcdMember is the member of etcd cluster, the health is of its client url
+type EtcdMember struct {
+	Id        string
+	Name      string
+	PeerURL   string
+	ClientURL string
+	Started   bool
+	Healthy   bool
+}
+
+// same as the member name in ${cluster_etcd_http_addr}
+func EtcdMemberName(dc *topology.DeployConfig) string {
+	return fmt.Sprintf("etcd%d%d", dc.GetHostSequence(), dc.GetInstancesSequence())
+}
+
+func EtcdPeerURL(dc *topology.DeployConfig) string {
+	return fmt.Sprintf("%s://%s", dc.GetEtcdScheme(), utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()))
+}
+
+/*
+ * ParseEtcdMemberId returns the id of member which has the peer url,
+ * output of `etcdctl member list`:
+ *   8e9e05c52164694d, started, etcd00, http://10.0.0.1:2380, http://10.0.0.1:2379, false
+ */
+func ParseEtcdMemberId(out, peerURL string) (string, bool) {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) < 4 {
+			continue
+		}
+		for _, url := range strings.Split(strings.TrimSpace(fields[3]), " ") {
+			if url == peerURL {
+				return strings.TrimSpace(fields[0]), true
+			}
+		}
+	}
+	return "", false
+}
+
+// EtcdInitialCluster returns the value of initial-cluster which consists of dcs
+func EtcdInitialCluster(dcs []*topology.DeployConfig) string {
+	members := []string{}
+	for _, dc := range dcs {
+		members = append(members, fmt.Sprintf("%s=%s", EtcdMemberName(dc), EtcdPeerURL(dc)))
+	}
+	return strings.Join(members, ",")
+}
+
+/*
+ * ParseEtcdMembers parses the output of `etcdctl member list` and
+ * `etcdctl endpoint health --cluster`, the member which not started
+ * has empty name and client url, e.g:
+ *   fd422379fda50e48, unstarted, , http://10.0.0.4:2380, , false
+ */
+func ParseEtcdMembers(members, health string) []EtcdMember {
+	healthy := map[string]bool{}
+	regex := regexp.MustCompile(REGEX_ETCD_ENDPOINT_HEALTH)
+	for _, line := range strings.Split(health, "\n") {
+		if mu := regex.FindStringSubmatch(strings.TrimSpace(line)); mu != nil {
+			healthy[mu[1]] = mu[2] == "healthy"
+		}
+	}
+
+	out := []EtcdMember{}
+	for _, line := range strings.Split(members, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) < 5 {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		member := EtcdMember{
+			Id:        fields[0],
+			Started:   fields[1] == ETCD_MEMBER_STARTED,
+			Name:      fields[2],
+			PeerURL:   strings.Split(fields[3], " ")[0],
+			ClientURL: strings.Split(fields[4], " ")[0],
+		}
+		member.Healthy = member.Started && healthy[member.ClientURL]
+		out = append(out, member)
+	}
+	return out
+}
+
+/*
+ * CheckEtcdMembers checks the members of etcd cluster are exactly the
+ * services in dcs and all of them are healthy, a member is added or
+ * removed only after the check passed, so quorum is kept while scaling.
+ */
+func CheckEtcdMembers(members []EtcdMember, dcs []*topology.DeployConfig) error {
+	expect := map[string]bool{}
+	for _, dc := range dcs {
+		expect[EtcdPeerURL(dc)] = true
+	}
+
+	unexpected, unhealthy := []string{}, []string{}
+	for _, member := range members {
+		if !expect[member.PeerURL] {
+			unexpected = append(unexpected, member.PeerURL)
+		} else if !member.Healthy {
+			unhealthy = append(unhealthy, member.PeerURL)
+		}
+		delete(expect, member.PeerURL)
+	}
+	missing := []string{}
+	for url := range expect {
+		missing = append(missing, url)
+	}
+	sort.Strings(missing)
+
+	switch {
+	case len(unexpected) > 0:
+		return fmt.Errorf("unexpected members: %s", strings.Join(unexpected, ", "))
+	case len(missing) > 0:
+		return fmt.Errorf("missing members: %s", strings.Join(missing, ", "))
+	case len(unhealthy) > 0:
+		return fmt.Errorf("unhealthy members: %s", strings.Join(unhealthy, ", "))
+	}
+	return nil
+}
+
+func etcdctl(dc *topology.DeployConfig, args string) string {
+	endpoint := fmt.Sprintf("%s://%s", dc.GetEtcdScheme(),
+		utils.JoinHostPort(dc.GetListenIp(), dc.GetListenClientPort()))
+	return etcdctlWithEndpoints(dc, endpoint, args)
+}
+
+// etcdctl accesses etcd with the certificate of service if TLS enabled
+func etcdctlWithEndpoints(dc *topology.DeployConfig, endpoints, args string) string {
+	layout := dc.GetProjectLayout()
+	binaryPath := fmt.Sprintf("%s/etcdctl", layout.ServiceBinDir)
+	if options := dc.GetTLSClientOptions(); len(options) > 0 {
+		binaryPath = fmt.Sprintf("%s %s", binaryPath, options)
+	}
+	return fmt.Sprintf("%s --endpoints %s %s", binaryPath, endpoints, args)
+}
+
+/*
+ * the migrated member has the same name with the old one, so we remove
+ * the old member before adding the new one, and the new member should
+ * start with initial cluster state "existing".
+ */
+func NewMigrateEtcdMemberTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Migrate Etcd Member", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	options := curveadm.ExecOptions()
+	migrates := curveadm.MemStorage().Get(comm.KEY_MIGRATE_SERVERS).([]*configure.MigrateServer)
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     etcdctl(dc, "member list"),
+		Out:         &out,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			for _, migrate := range migrates {
+				id, ok := ParseEtcdMemberId(out, EtcdPeerURL(migrate.From))
+				if ok {
+					command := etcdctl(dc, fmt.Sprintf("member remove %s", id))
+					_, err := ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+					if err != nil {
+						return errno.ERR_MIGRATE_ETCD_MEMBER_FAILED.E(err)
+					}
+				}
+
+				command := etcdctl(dc, fmt.Sprintf("member add %s --peer-urls=%s",
+					EtcdMemberName(migrate.To), EtcdPeerURL(migrate.To)))
+				_, err := ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+				if err != nil {
+					return errno.ERR_MIGRATE_ETCD_MEMBER_FAILED.E(err)
+				}
+			}
+			return nil
+		},
+	})
+
+	return t, nil
+}
+
+/*
+ * the member to add or remove is specified by option, they are changed
+ * one at a time and only if the member list hasn't reflected it yet, so
+ * the task is safe to be retried.
+ */
+func newChangeEtcdMemberTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig, add bool) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	member := curveadm.MemStorage().Get(comm.KEY_ETCD_MEMBER).(*topology.DeployConfig)
+	name := utils.Choose(add, "Add Etcd Member", "Remove Etcd Member")
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s member=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId), EtcdPeerURL(member))
+	t := task.NewTask(name, subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	options := curveadm.ExecOptions()
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     etcdctl(dc, "member list"),
+		Out:         &out,
+		ExecOptions: options,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			id, exist := ParseEtcdMemberId(out, EtcdPeerURL(member))
+			command := ""
+			if add && !exist {
+				command = etcdctl(dc, fmt.Sprintf("member add %s --peer-urls=%s",
+					EtcdMemberName(member), EtcdPeerURL(member)))
+			} else if !add && exist {
+				command = etcdctl(dc, fmt.Sprintf("member remove %s", id))
+			}
+			if len(command) == 0 {
+				return nil
+			}
+			_, err := ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+			if err != nil {
+				return errno.ERR_CHANGE_ETCD_MEMBER_FAILED.E(err)
+			}
+			return nil
+		},
+	})
+
+	return t, nil
+}
+
+func NewAddEtcdMemberTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	return newChangeEtcdMemberTask(curveadm, dc, true)
+}
+
+func NewRemoveEtcdMemberTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	return newChangeEtcdMemberTask(curveadm, dc, false)
+}
+
+// wait until the members of etcd cluster are exactly the expected ones and all healthy
+func NewWaitEtcdHealthyTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Wait Etcd Cluster Healthy", subname, hc.GetSSHConfig())
+
+	// add step to task
+	options := curveadm.ExecOptions()
+	expect := curveadm.MemStorage().Get(comm.KEY_ETCD_CLUSTER).([]*topology.DeployConfig)
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			deadline := time.Now().Add(WAIT_ETCD_HEALTHY_TIMEOUT)
+			for {
+				members, _ := ctx.Module().DockerCli().
+					ContainerExec(containerId, etcdctl(dc, "member list")).
+					Execute(options)
+				health, _ := ctx.Module().DockerCli().
+					ContainerExec(containerId, fmt.Sprintf(COMMAND_ETCD_ENDPOINT_HEALTH,
+						etcdctl(dc, "endpoint health --cluster"))).
+					Execute(options)
+				err := CheckEtcdMembers(ParseEtcdMembers(members, health), expect)
+				if err == nil {
+					return nil
+				} else if time.Now().After(deadline) {
+					return errno.ERR_WAIT_ETCD_CLUSTER_HEALTHY_TIMEOUT.
+						F("timeout: %s, %s", WAIT_ETCD_HEALTHY_TIMEOUT, err)
+				}
+				time.Sleep(WAIT_ETCD_HEALTHY_INTERVAL)
+			}
+		},
+	})
+
+	return t, nil
+}
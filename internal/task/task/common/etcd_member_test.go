@@ -0,0 +1,121 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/stretchr/testify/assert"
+)
+
+const ETCD_MEMBER_TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: opencurvedocker/curvebs:v1.2
+  log_dir: /data/logs/${service_role}
+  data_dir: /data/${service_role}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+
+func TestParseEtcdMemberId(t *testing.T) {
+	assert := assert.New(t)
+
+	out := `8e9e05c52164694d, started, etcd00, http://10.0.0.1:2380, http://10.0.0.1:2379, false
+91bc3c398fb3c146, started, etcd10, http://10.0.0.2:2380, http://10.0.0.2:2379, false
+fd422379fda50e48, unstarted, , http://10.0.0.4:2380, , false
+`
+	id, ok := ParseEtcdMemberId(out, "http://10.0.0.2:2380")
+	assert.True(ok)
+	assert.Equal("91bc3c398fb3c146", id)
+	id, ok = ParseEtcdMemberId(out, "http://10.0.0.4:2380")
+	assert.True(ok)
+	assert.Equal("fd422379fda50e48", id)
+	_, ok = ParseEtcdMemberId(out, "http://10.0.0.3:2380")
+	assert.False(ok)
+	_, ok = ParseEtcdMemberId("", "http://10.0.0.1:2380")
+	assert.False(ok)
+}
+
+func parseEtcdMemberTopology(t *testing.T) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	ctx.Add("host1", "10.0.0.1")
+	ctx.Add("host2", "10.0.0.2")
+	ctx.Add("host3", "10.0.0.3")
+	dcs, err := topology.ParseTopology(ETCD_MEMBER_TOPOLOGY, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func TestEtcdInitialCluster(t *testing.T) {
+	dcs := parseEtcdMemberTopology(t)
+	assert.Equal(t, "etcd00=http://10.0.0.1:2380,etcd10=http://10.0.0.2:2380",
+		EtcdInitialCluster(dcs[:2]))
+}
+
+func TestCheckEtcdMembers(t *testing.T) {
+	assert := assert.New(t)
+	dcs := parseEtcdMemberTopology(t)
+
+	members := `8e9e05c52164694d, started, etcd00, http://10.0.0.1:2380, http://10.0.0.1:2379, false
+91bc3c398fb3c146, started, etcd10, http://10.0.0.2:2380, http://10.0.0.2:2379, false
+fd422379fda50e48, unstarted, , http://10.0.0.3:2380, , false
+`
+	health := `http://10.0.0.1:2379 is healthy: successfully committed proposal: took = 2.1ms
+http://10.0.0.2:2379 is unhealthy: failed to commit proposal: context deadline exceeded
+Error: unhealthy cluster
+`
+	parsed := ParseEtcdMembers(members, health)
+	assert.Len(parsed, 3)
+	assert.Equal(EtcdMember{
+		Id:        "8e9e05c52164694d",
+		Name:      "etcd00",
+		PeerURL:   "http://10.0.0.1:2380",
+		ClientURL: "http://10.0.0.1:2379",
+		Started:   true,
+		Healthy:   true,
+	}, parsed[0])
+	assert.False(parsed[1].Healthy)
+	assert.False(parsed[2].Started)
+
+	// the new member not started yet
+	assert.ErrorContains(CheckEtcdMembers(parsed, dcs), "unhealthy members: http://10.0.0.2:2380, http://10.0.0.3:2380")
+	// the removed member still in cluster
+	assert.ErrorContains(CheckEtcdMembers(parsed, dcs[:2]), "unexpected members: http://10.0.0.3:2380")
+	// the added member not in cluster
+	assert.ErrorContains(CheckEtcdMembers(parsed[:2], dcs), "missing members: http://10.0.0.3:2380")
+
+	for i := range parsed {
+		parsed[i].Started, parsed[i].Healthy = true, true
+	}
+	assert.Nil(CheckEtcdMembers(parsed, dcs))
+}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+type (
+	ExecHostOptions struct {
+		Command    string
+		Sudo       bool
+		TimeoutSec int
+	}
+
+	ExecHostResult struct {
+		Host     string
+		Success  bool
+		Out      string
+		Duration time.Duration
+	}
+)
+
+// wrap command by bash, so that pipe, redirection and env in command work on remote
+func getExecHostCommand(envs []string, command string) string {
+	if len(envs) > 0 {
+		command = fmt.Sprintf("export %s; %s", strings.Join(envs, " "), command)
+	}
+	return fmt.Sprintf("bash -c '%s'", strings.ReplaceAll(command, "'", `'\''`))
+}
+
+func setExecHostResult(curveadm *cli.CurveAdm, result ExecHostResult) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string]ExecHostResult{}
+		v := kv.Get(comm.KEY_ALL_EXEC_HOST_RESULTS)
+		if v != nil {
+			m = v.(map[string]ExecHostResult)
+		}
+		m[result.Host] = result
+		kv.Set(comm.KEY_ALL_EXEC_HOST_RESULTS, m)
+		return nil
+	})
+}
+
+func NewExecHostTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_EXEC_HOST_OPTIONS).(ExecHostOptions)
+
+	// new task
+	host := hc.GetHost()
+	subname := fmt.Sprintf("host=%s", host)
+	t := task.NewTask("Execute Command", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var success bool
+	var out string
+	var start time.Time
+	execOptions := curveadm.ExecOptions()
+	execOptions.ExecWithSudo = options.Sudo
+	execOptions.ExecTimeoutSec = options.TimeoutSec
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			start = time.Now()
+			return nil
+		},
+	})
+	t.AddStep(&step.Command{
+		Command:     getExecHostCommand(hc.GetEnvs(), options.Command),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: execOptions,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			setExecHostResult(curveadm, ExecHostResult{
+				Host:     host,
+				Success:  success,
+				Out:      out,
+				Duration: time.Since(start),
+			})
+			return nil
+		},
+	})
+
+	return t, nil
+}
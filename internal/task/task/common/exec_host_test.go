@@ -0,0 +1,39 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExecHostCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("bash -c 'uptime'", getExecHostCommand(nil, "uptime"))
+	assert.Equal("bash -c 'df -h | grep /data'", getExecHostCommand(nil, "df -h | grep /data"))
+	assert.Equal(`bash -c 'echo '\''hello world'\'''`, getExecHostCommand(nil, "echo 'hello world'"))
+	assert.Equal("bash -c 'export A=1 B=2; echo $A'",
+		getExecHostCommand([]string{"A=1", "B=2"}, "echo $A"))
+}
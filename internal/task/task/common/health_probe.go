@@ -0,0 +1,247 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	HEALTH_PROBE_LIVENESS     = "liveness"
+	HEALTH_PROBE_DISK_USAGE   = "disk_usage"
+	HEALTH_PROBE_LEADER_COUNT = "leader_count"
+	HEALTH_PROBE_COPYSET      = "copyset_health"
+
+	HEALTH_STATUS_OK       = "ok"
+	HEALTH_STATUS_WARNING  = "warning"
+	HEALTH_STATUS_CRITICAL = "critical"
+	HEALTH_STATUS_UNKNOWN  = "unknown"
+
+	DISK_USAGE_WARNING_PERCENT  = 80
+	DISK_USAGE_CRITICAL_PERCENT = 90
+
+	// leaders of one service are expected to be copysets/replicas after balanced
+	LEADER_IMBALANCE_RATIO = 0.5
+
+	// braft builtin service, which lists all raft nodes in the process
+	COMMAND_RAFT_STAT          = "curl -g -s --connect-timeout 1 --max-time 3 http://%s/raft_stat"
+	SIGNATURE_RAFT_LEADER      = "state: LEADER"
+	COMMAND_COPYSETS_STATUS    = "curve_ops_tool copysets-status"
+	COMMAND_FS_COPYSETS_STATUS = "curvefs_tool status-copyset"
+	SIGNATURE_UNHEALTHY        = "unhealthy"
+)
+
+/*
+ * HealthProbe is one sample of lightweight probe, the probes only
+ * read states and never change anything, so they are safe to be
+ * executed periodically by `curveadm watch`.
+ */
+type HealthProbe struct {
+	Probe  string
+	Target string // service id
+	Host   string
+	Status string
+	Value  string
+}
+
+type step2ProbeHealth struct {
+	dc          *topology.DeployConfig
+	serviceId   string
+	containerId string
+	memStorage  *utils.SafeMap
+	execOptions module.ExecOptions
+}
+
+func addHealthProbe(memStorage *utils.SafeMap, probe HealthProbe) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		probes := []HealthProbe{}
+		v := kv.Get(comm.KEY_ALL_HEALTH_PROBES)
+		if v != nil {
+			probes = v.([]HealthProbe)
+		}
+		probes = append(probes, probe)
+		kv.Set(comm.KEY_ALL_HEALTH_PROBES, probes)
+		return nil
+	})
+}
+
+func (s *step2ProbeHealth) record(probe, status, value string) {
+	addHealthProbe(s.memStorage, HealthProbe{
+		Probe:  probe,
+		Target: s.serviceId,
+		Host:   s.dc.GetHost(),
+		Status: status,
+		Value:  value,
+	})
+}
+
+func (s *step2ProbeHealth) execInContainer(ctx *context.Context, command string) (string, error) {
+	cmd := ctx.Module().DockerCli().ContainerExec(s.containerId, command)
+	return cmd.Execute(s.execOptions)
+}
+
+func (s *step2ProbeHealth) probeLiveness(ctx *context.Context) bool {
+	out, err := ctx.Module().DockerCli().
+		InspectContainer(s.containerId).
+		AddOption("--format '{{.State.Status}}'").
+		Execute(s.execOptions)
+	out = strings.TrimSpace(out)
+	if err != nil {
+		s.record(HEALTH_PROBE_LIVENESS, HEALTH_STATUS_CRITICAL, "unknown")
+		return false
+	} else if out != "running" {
+		s.record(HEALTH_PROBE_LIVENESS, HEALTH_STATUS_CRITICAL, out)
+		return false
+	}
+	s.record(HEALTH_PROBE_LIVENESS, HEALTH_STATUS_OK, out)
+	return true
+}
+
+// output of df: "Use%\n 45%"
+func (s *step2ProbeHealth) probeDiskUsage(ctx *context.Context) {
+	dataDir := s.dc.GetDataDir()
+	if len(dataDir) == 0 {
+		return
+	}
+
+	out, err := ctx.Module().Shell().
+		DiskFree(dataDir).
+		AddOption("--output=pcent").
+		Execute(s.execOptions)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	usage, perr := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(lines[len(lines)-1]), "%"))
+	if err != nil || perr != nil {
+		s.record(HEALTH_PROBE_DISK_USAGE, HEALTH_STATUS_UNKNOWN, "-")
+		return
+	}
+
+	status := HEALTH_STATUS_OK
+	if usage >= DISK_USAGE_CRITICAL_PERCENT {
+		status = HEALTH_STATUS_CRITICAL
+	} else if usage >= DISK_USAGE_WARNING_PERCENT {
+		status = HEALTH_STATUS_WARNING
+	}
+	s.record(HEALTH_PROBE_DISK_USAGE, status, fmt.Sprintf("%d%%", usage))
+}
+
+// leaderCountStatus compares leaders against the balanced share of copysets,
+// e.g. 0 leader after service restarted is a warning until leaders transferred back
+func leaderCountStatus(copysets, leaders, replicas int) (string, string) {
+	if copysets == 0 || replicas <= 0 {
+		return HEALTH_STATUS_OK, strconv.Itoa(leaders)
+	}
+
+	expected := float64(copysets) / float64(replicas)
+	tolerance := math.Max(1, expected*LEADER_IMBALANCE_RATIO)
+	value := fmt.Sprintf("%d (expected %d)", leaders, int(math.Round(expected)))
+	if math.Abs(float64(leaders)-expected) > tolerance {
+		return HEALTH_STATUS_WARNING, value
+	}
+	return HEALTH_STATUS_OK, value
+}
+
+func (s *step2ProbeHealth) probeLeaderCount(ctx *context.Context) {
+	command := fmt.Sprintf(COMMAND_RAFT_STAT, utils.JoinHostPort(s.dc.GetListenIp(), s.dc.GetListenPort()))
+	out, err := s.execInContainer(ctx, command)
+	if err != nil {
+		s.record(HEALTH_PROBE_LEADER_COUNT, HEALTH_STATUS_UNKNOWN, "-")
+		return
+	}
+	copysets, leaders := parseRaftStat(out)
+	status, value := leaderCountStatus(copysets, leaders, s.dc.GetCopysetReplicas())
+	s.record(HEALTH_PROBE_LEADER_COUNT, status, value)
+}
+
+func (s *step2ProbeHealth) probeCopysetHealth(ctx *context.Context) {
+	command := COMMAND_COPYSETS_STATUS
+	if s.dc.GetKind() == topology.KIND_CURVEFS {
+		command = COMMAND_FS_COPYSETS_STATUS
+	}
+	out, err := s.execInContainer(ctx, command)
+	if err != nil || strings.Contains(strings.ToLower(out), SIGNATURE_UNHEALTHY) {
+		s.record(HEALTH_PROBE_COPYSET, HEALTH_STATUS_CRITICAL, "unhealthy")
+		return
+	}
+	s.record(HEALTH_PROBE_COPYSET, HEALTH_STATUS_OK, "healthy")
+}
+
+func (s *step2ProbeHealth) Execute(ctx *context.Context) error {
+	s.probeDiskUsage(ctx)
+	if !s.probeLiveness(ctx) {
+		return nil
+	}
+
+	dc := s.dc
+	switch dc.GetRole() {
+	case topology.ROLE_CHUNKSERVER, topology.ROLE_METASERVER:
+		s.probeLeaderCount(ctx)
+	case topology.ROLE_MDS:
+		// copyset health is cluster-wide, only probe it in the first mds
+		if dc.GetHostSequence() == 0 && dc.GetInstancesSequence() == 0 {
+			s.probeCopysetHealth(ctx)
+		}
+	}
+	return nil
+}
+
+func NewProbeHealthTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Probe Service Health", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2ProbeHealth{
+		dc:          dc,
+		serviceId:   serviceId,
+		containerId: containerId,
+		memStorage:  curveadm.MemStorage(),
+		execOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
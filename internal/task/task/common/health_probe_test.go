@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderCountStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, c := range []struct {
+		copysets int
+		leaders  int
+		status   string
+		value    string
+	}{
+		{0, 0, HEALTH_STATUS_OK, "0"},
+		{300, 100, HEALTH_STATUS_OK, "100 (expected 100)"},
+		{300, 60, HEALTH_STATUS_OK, "60 (expected 100)"},
+		{300, 40, HEALTH_STATUS_WARNING, "40 (expected 100)"},
+		{300, 160, HEALTH_STATUS_WARNING, "160 (expected 100)"},
+		{300, 0, HEALTH_STATUS_WARNING, "0 (expected 100)"},
+		{3, 0, HEALTH_STATUS_OK, "0 (expected 1)"},
+		{6, 0, HEALTH_STATUS_WARNING, "0 (expected 2)"},
+	} {
+		status, value := leaderCountStatus(c.copysets, c.leaders, 3)
+		assert.Equal(c.status, status, value)
+		assert.Equal(c.value, value)
+	}
+}
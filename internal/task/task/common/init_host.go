@@ -0,0 +1,274 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	INIT_HOST_ITEM_ENGINE = "engine"
+	INIT_HOST_ITEM_MODULE = "module"
+	INIT_HOST_ITEM_SYSCTL = "sysctl"
+	INIT_HOST_ITEM_ULIMIT = "ulimit"
+	INIT_HOST_ITEM_USER   = "user"
+	INIT_HOST_ITEM_DIR    = "dir"
+
+	MODULES_LOAD_CONF_PATH = "/etc/modules-load.d/curve.conf"
+	SYSCTL_CONF_PATH       = "/etc/sysctl.d/99-curve.conf"
+	LIMITS_CONF_PATH       = "/etc/security/limits.d/99-curve.conf"
+
+	CONTENT_SYSCTL_CONF = `# Generated by curveadm
+fs.file-max = 6553600
+fs.aio-max-nr = 1048576
+net.core.somaxconn = 65535
+net.core.netdev_max_backlog = 65535
+net.ipv4.tcp_max_syn_backlog = 65535
+net.ipv4.ip_local_port_range = 10000 65000
+vm.swappiness = 0
+vm.max_map_count = 655360
+kernel.core_pattern = core.%e.%p
+`
+
+	CONTENT_LIMITS_CONF = `# Generated by curveadm
+* soft nofile 1048576
+* hard nofile 1048576
+* soft nproc unlimited
+* hard nproc unlimited
+* soft core unlimited
+* hard core unlimited
+`
+)
+
+var (
+	INIT_HOST_ITEMS = []string{
+		INIT_HOST_ITEM_ENGINE,
+		INIT_HOST_ITEM_MODULE,
+		INIT_HOST_ITEM_SYSCTL,
+		INIT_HOST_ITEM_ULIMIT,
+		INIT_HOST_ITEM_USER,
+		INIT_HOST_ITEM_DIR,
+	}
+
+	DEFAULT_INIT_HOST_MODULES = []string{
+		comm.KERNERL_MODULE_NBD,
+		comm.KERNERL_MODULE_FUSE,
+	}
+)
+
+type (
+	InitHostOptions struct {
+		Skip    []string
+		Modules []string
+		User    string
+		Dirs    map[string][]string // host: directories
+	}
+
+	step2InstallContainerEngine struct {
+		engine   string
+		facts    *step.HostFacts
+		curveadm *cli.CurveAdm
+	}
+)
+
+func getInstallEngineCommand(engine, os string) (string, error) {
+	packages := map[string]string{
+		module.ENGINE_DOCKER: "docker",
+		module.ENGINE_PODMAN: "podman",
+	}
+	pkg, ok := packages[engine]
+	if !ok {
+		return "", errno.ERR_UNSUPPORT_HOST_CONTAINER_ENGINE.
+			F("container engine: %s", engine)
+	}
+
+	switch os {
+	case comm.OS_RELEASE_DEBIAN, comm.OS_RELEASE_UBUNTU:
+		if engine == module.ENGINE_DOCKER {
+			pkg = "docker.io"
+		}
+		return fmt.Sprintf("apt-get install -y %s", pkg), nil
+	case comm.OS_RELEASE_CENTOS:
+		return fmt.Sprintf("yum install -y %s", pkg), nil
+	}
+	return "", errno.ERR_UNSUPPORT_LINUX_OS_REELASE.
+		F("os release: %s", os)
+}
+
+func (s *step2InstallContainerEngine) Execute(ctx *context.Context) error {
+	// (1) skip install if container engine already exist
+	var success bool
+	var out string
+	options := s.curveadm.ExecOptions()
+	err := (&step.Command{
+		Command:     fmt.Sprintf("%s --version", s.engine),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: options,
+	}).Execute(ctx)
+	if err != nil {
+		return err
+	} else if success {
+		return nil
+	}
+
+	// (2) install container engine by package manager
+	command, err := getInstallEngineCommand(s.engine, s.facts.OS)
+	if err != nil {
+		return err
+	}
+	steps := []task.Step{
+		&step.Command{Command: command, Out: &out, ExecOptions: options},
+	}
+	if s.engine == module.ENGINE_DOCKER {
+		steps = append(steps,
+			&step.EnableUnit{Name: s.engine, ExecOptions: options},
+			&step.StartUnit{Name: s.engine, ExecOptions: options},
+		)
+	}
+	for _, step := range steps {
+		if err := step.Execute(ctx); err != nil {
+			return errno.ERR_INSTALL_CONTAINER_ENGINE_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func getHostEngine(curveadm *cli.CurveAdm, hc *hosts.HostConfig) string {
+	engine := hc.GetContainerEngine()
+	if len(engine) == 0 || engine == module.ENGINE_AUTO {
+		return curveadm.Config().GetEngine()
+	}
+	return engine
+}
+
+func NewInitHostTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	options := InitHostOptions{}
+	if v := curveadm.MemStorage().Get(comm.KEY_INIT_HOST_OPTIONS); v != nil {
+		options = v.(InitHostOptions)
+	}
+	skip := utils.Slice2Map(options.Skip)
+	engine := getHostEngine(curveadm, hc)
+	modules := options.Modules
+	if len(modules) == 0 {
+		modules = DEFAULT_INIT_HOST_MODULES
+	}
+
+	// add task
+	subname := fmt.Sprintf("host=%s engine=%s", hc.GetHost(), engine)
+	t := task.NewTask("Init Host", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var facts step.HostFacts
+	var out string
+	execOptions := curveadm.ExecOptions()
+	modulesConf := strings.Join(modules, "\n") + "\n"
+	sysctlConf := CONTENT_SYSCTL_CONF
+	limitsConf := CONTENT_LIMITS_CONF
+	if !skip[INIT_HOST_ITEM_ENGINE] {
+		t.AddStep(&step.GatherFacts{
+			MemStorage:  curveadm.MemStorage(),
+			Out:         &facts,
+			ExecOptions: execOptions,
+		})
+		t.AddStep(&step2InstallContainerEngine{
+			engine:   engine,
+			facts:    &facts,
+			curveadm: curveadm,
+		})
+	}
+	if !skip[INIT_HOST_ITEM_MODULE] {
+		for _, name := range modules {
+			t.AddStep(&step.ModProbe{ // load kernel module
+				Name:        name,
+				ExecOptions: execOptions,
+			})
+		}
+		t.AddStep(&step.InstallFile{ // load kernel modules on boot
+			Content:      &modulesConf,
+			HostDestPath: MODULES_LOAD_CONF_PATH,
+			ExecOptions:  execOptions,
+		})
+	}
+	if !skip[INIT_HOST_ITEM_SYSCTL] {
+		t.AddStep(&step.InstallFile{
+			Content:      &sysctlConf,
+			HostDestPath: SYSCTL_CONF_PATH,
+			ExecOptions:  execOptions,
+		})
+		t.AddStep(&step.Command{
+			Command:     "sysctl --system",
+			Out:         &out,
+			ExecOptions: execOptions,
+		})
+	}
+	if !skip[INIT_HOST_ITEM_ULIMIT] {
+		t.AddStep(&step.InstallFile{
+			Content:      &limitsConf,
+			HostDestPath: LIMITS_CONF_PATH,
+			ExecOptions:  execOptions,
+		})
+	}
+	if !skip[INIT_HOST_ITEM_USER] && len(options.User) > 0 {
+		t.AddStep(&step.Command{
+			Command: fmt.Sprintf("bash -c 'id -u %s || useradd -m -s /bin/bash %s'",
+				options.User, options.User),
+			Out:         &out,
+			ExecOptions: execOptions,
+		})
+		if engine == module.ENGINE_DOCKER {
+			t.AddStep(&step.Command{ // run docker without sudo
+				Command:     fmt.Sprintf("usermod -aG docker %s", options.User),
+				Out:         &out,
+				ExecOptions: execOptions,
+			})
+		}
+	}
+	if dirs := options.Dirs[hc.GetHost()]; !skip[INIT_HOST_ITEM_DIR] && len(dirs) > 0 {
+		t.AddStep(&step.CreateDirectory{
+			Paths:       dirs,
+			ExecOptions: execOptions,
+		})
+		if !skip[INIT_HOST_ITEM_USER] && len(options.User) > 0 {
+			t.AddStep(&step.Command{
+				Command: fmt.Sprintf("chown %s: %s",
+					options.User, strings.Join(dirs, " ")),
+				Out:         &out,
+				ExecOptions: execOptions,
+			})
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,48 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInstallEngineCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	command, err := getInstallEngineCommand("docker", "ubuntu")
+	assert.Nil(err)
+	assert.Equal("apt-get install -y docker.io", command)
+	command, err = getInstallEngineCommand("docker", "centos")
+	assert.Nil(err)
+	assert.Equal("yum install -y docker", command)
+	command, err = getInstallEngineCommand("podman", "debian")
+	assert.Nil(err)
+	assert.Equal("apt-get install -y podman", command)
+
+	_, err = getInstallEngineCommand("nerdctl", "ubuntu")
+	assert.NotNil(err)
+	_, err = getInstallEngineCommand("docker", "unknown")
+	assert.NotNil(err)
+}
@@ -144,6 +144,10 @@ func NewInstallClientTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*
 	name := utils.Choose(kind == KIND_CURVEBS, "CurveBS", "CurveFS")
 	t := task.NewTask(fmt.Sprintf("Install %s Client", name), subname, hc.GetSSHConfig())
 
+	// download package through HTTP proxy of host if any
+	downloadOptions := curveadm.ExecOptions()
+	downloadOptions.ExecWithProxy = true
+
 	// add step to task
 	var input, output string
 	randStr := utils.RandString(10)
@@ -161,6 +165,11 @@ func NewInstallClientTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*
 		Insecure:    true,
 		Output:      tarball,
 		Silent:      true,
+		ExecOptions: downloadOptions,
+	})
+	t.AddStep(&step.VerifyChecksum{
+		Url:         url,
+		Path:        tarball,
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.Tar{
@@ -0,0 +1,179 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	stdctx "context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	PING_HOST_TIMEOUT_SEC = 10
+)
+
+type (
+	PingHostOptions struct {
+		FixKnownHosts bool
+	}
+
+	PingHostResult struct {
+		Host          string
+		Address       string
+		Reachable     bool
+		Authenticated bool
+		Sudo          bool
+		KeyFixed      bool // the changed host key has been refreshed in known_hosts
+		Connect       time.Duration
+		RTT           time.Duration
+		Error         string
+	}
+)
+
+// known_hosts is shared by all hosts, serialize the refreshing of it
+var knownHostsMutex sync.Mutex
+
+func (r PingHostResult) Success() bool {
+	return r.Reachable && r.Authenticated && r.Sudo
+}
+
+func setPingHostResult(curveadm *cli.CurveAdm, result PingHostResult) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string]PingHostResult{}
+		v := kv.Get(comm.KEY_ALL_PING_HOST_RESULTS)
+		if v != nil {
+			m = v.(map[string]PingHostResult)
+		}
+		m[result.Host] = result
+		kv.Set(comm.KEY_ALL_PING_HOST_RESULTS, m)
+		return nil
+	})
+}
+
+func execWithTimeout(transport module.Transport, command string) error {
+	ctx, cancel := stdctx.WithTimeout(stdctx.Background(), PING_HOST_TIMEOUT_SEC*time.Second)
+	defer cancel()
+	_, err := transport.Exec(ctx, command)
+	return err
+}
+
+// connect host without SSH pool, so that every ping measures a fresh handshake
+func connectHost(config module.SSHConfig, options PingHostOptions,
+	result *PingHostResult) (module.Transport, error) {
+	if config.Transport == module.TRANSPORT_LOCAL {
+		return module.NewLocalTransport(config), nil
+	}
+
+	config.ConnectRetries = 1
+	start := time.Now()
+	client, err := module.NewSSHClient(config)
+	if module.IsHostKeyMismatch(err) && options.FixKnownHosts {
+		knownHostsMutex.Lock()
+		addresses := module.KnownHostAddresses(config.Host, config.Port)
+		_, err = module.RemoveKnownHost("", addresses)
+		if err == nil {
+			start = time.Now()
+			client, err = module.NewSSHClient(config)
+			result.KeyFixed = err == nil
+		}
+		knownHostsMutex.Unlock()
+	}
+	result.Connect = time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func pingHost(config module.SSHConfig, sudo string, options PingHostOptions, result *PingHostResult) {
+	// (1) connect and authenticate
+	transport, err := connectHost(config, options, result)
+	if err != nil {
+		if module.IsHostKeyMismatch(err) {
+			result.Reachable = true
+			result.Error = "host key changed, run with --fix-known-hosts to refresh it"
+		} else if module.IsAuthFailed(err) {
+			result.Reachable = true
+			result.Error = "authentication failed"
+		} else {
+			result.Error = strings.TrimSpace(err.Error())
+		}
+		return
+	}
+	defer transport.Close()
+	result.Reachable = true
+	result.Authenticated = true
+
+	// (2) round trip time of executing a no-op command
+	start := time.Now()
+	if err := execWithTimeout(transport, "true"); err != nil {
+		result.Error = strings.TrimSpace(err.Error())
+		return
+	}
+	result.RTT = time.Since(start)
+
+	// (3) sudo without password
+	if err := execWithTimeout(transport, fmt.Sprintf("%s -n true", sudo)); err != nil {
+		result.Error = "sudo requires password or not allowed"
+		return
+	}
+	result.Sudo = true
+}
+
+func NewPingHostTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_PING_HOST_OPTIONS).(PingHostOptions)
+
+	// new task
+	host := hc.GetHost()
+	config := *hc.GetSSHConfig()
+	subname := fmt.Sprintf("host=%s", host)
+	t := task.NewTask("Ping Host", subname, nil)
+
+	// add step to task
+	sudo := utils.Choose(len(curveadm.Config().GetSudoAlias()) > 0,
+		curveadm.Config().GetSudoAlias(), "sudo")
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			result := PingHostResult{
+				Host:    host,
+				Address: utils.JoinHostPort(config.Host, int(config.Port)),
+			}
+			pingHost(config, sudo, options, &result)
+			setPingHostResult(curveadm, result)
+			return nil
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,92 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/plugin"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	sdk "github.com/opencurve/curveadm/pkg/plugin"
+)
+
+type PluginStepOptions struct {
+	Plugin  plugin.Plugin
+	Step    string
+	Options map[string]string
+}
+
+func setPluginStepResult(curveadm *cli.CurveAdm, host string, result *sdk.Result) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		m := map[string]sdk.Result{}
+		v := kv.Get(comm.KEY_ALL_PLUGIN_STEP_RESULTS)
+		if v != nil {
+			m = v.(map[string]sdk.Result)
+		}
+		m[host] = *result
+		kv.Set(comm.KEY_ALL_PLUGIN_STEP_RESULTS, m)
+		return nil
+	})
+}
+
+// NewRunPluginStepTask runs step of plugin for the host, the plugin is executed
+// in the machine where curveadm running and it connects the host by itself
+func NewRunPluginStepTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_PLUGIN_STEP_OPTIONS).(PluginStepOptions)
+
+	// new task
+	host := hc.GetHost()
+	p := options.Plugin
+	subname := fmt.Sprintf("host=%s plugin=%s step=%s", host, p.Name, options.Step)
+	t := task.NewTask("Run Plugin Step", subname, nil)
+
+	// add step to task
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			result, err := p.Step(curveadm.PluginEnv(), &sdk.Request{
+				Cluster: curveadm.ClusterName(),
+				Name:    options.Step,
+				Hosts:   []sdk.Host{plugin.NewHost(hc)},
+				Options: options.Options,
+			})
+			if err != nil {
+				return err
+			}
+			setPluginStepResult(curveadm, host, result)
+			if !result.Success {
+				return errno.ERR_RUN_PLUGIN_FAILED.
+					F("plugin: %s, step: %s, host: %s, %s", p.Name, options.Step, host, result.Message)
+			}
+			return nil
+		},
+	})
+
+	return t, nil
+}
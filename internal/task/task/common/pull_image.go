@@ -41,6 +41,15 @@ func NewPullImageTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.
 
 	// new task
 	subname := fmt.Sprintf("host=%s image=%s", dc.GetHost(), dc.GetContainerImage())
+	if dc.GetImageSource() == topology.IMAGE_SOURCE_LOCAL {
+		t := task.NewTask("Load Image", subname, hc.GetSSHConfig())
+		t.AddStep(&step.LoadImage{
+			Image:       dc.GetContainerImage(),
+			Archive:     curveadm.ImageArchivePath(),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+		return t, nil
+	}
 	t := task.NewTask("Pull Image", subname, hc.GetSSHConfig())
 
 	// add step to task
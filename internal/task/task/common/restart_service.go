@@ -56,6 +56,10 @@ func WaitContainerStart(seconds int) step.LambdaType {
 }
 
 func NewRestartServiceTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if len(dc.GetSystemdUnit()) > 0 {
+		return newServiceUnitTask(curveadm, dc, newRestartUnitTask)
+	}
+
 	serviceId := curveadm.GetServiceId(dc.GetId())
 	containerId, err := curveadm.GetContainerId(serviceId)
 	if curveadm.IsSkip(dc) {
@@ -0,0 +1,152 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	// brpc returns 200 only if the gflag is reloadable and the value is valid
+	COMMAND_CURL_SET_FLAG = "curl -g -s --connect-timeout 1 --max-time 3 -o /dev/null -w %%{http_code} http://%s/flags/%s?setvalue=%s"
+	// the gflag persists only if it's an item of config file
+	COMMAND_GREP_CONFIG_ITEM = "grep -E -q '^[[:space:]]*%s[[:space:]]*=' %s"
+
+	HTTP_STATUS_OK = "200"
+)
+
+// ServiceFlag is the result of setting gflag of service at runtime
+type ServiceFlag struct {
+	Id      string
+	Flag    string
+	Value   string
+	Applied bool
+	Message string // reason if not applied
+	// false if the flag isn't an item of config file (e.g: the template
+	// of image lacks it), the value set at runtime is lost after restarting
+	Persisted bool
+}
+
+/*
+ * ServiceFlagsAddr returns the address of brpc server which serves the
+ * /flags endpoint of service: chunkserver and metaserver serve it on
+ * their listen port, mds and snapshotclone on their dummy port because
+ * the listen port is only served by leader. etcd has no such endpoint.
+ */
+func ServiceFlagsAddr(dc *topology.DeployConfig) (string, bool) {
+	switch dc.GetRole() {
+	case topology.ROLE_CHUNKSERVER, topology.ROLE_METASERVER:
+		return utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()), true
+	case topology.ROLE_MDS, topology.ROLE_SNAPSHOTCLONE:
+		return utils.JoinHostPort(dc.GetListenIp(), dc.GetListenDummyPort()), true
+	}
+	return "", false
+}
+
+func addServiceFlag(memStorage *utils.SafeMap, flag ServiceFlag) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		all := []ServiceFlag{}
+		v := kv.Get(comm.KEY_ALL_SERVICE_FLAGS_SET)
+		if v != nil {
+			all = v.([]ServiceFlag)
+		}
+		all = append(all, flag)
+		kv.Set(comm.KEY_ALL_SERVICE_FLAGS_SET, all)
+		return nil
+	})
+}
+
+func NewSetServiceFlagsTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// flags to set: service id -> flag -> value
+	flags := map[string]string{}
+	if v := curveadm.MemStorage().Get(comm.KEY_SERVICE_FLAGS); v != nil {
+		flags = v.(map[string]map[string]string)[serviceId]
+	}
+	addr, ok := ServiceFlagsAddr(dc)
+	if len(flags) == 0 || !ok {
+		return nil, nil
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Set Service Flags", subname, hc.GetSSHConfig())
+
+	// add step to task
+	names := []string{}
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	options := curveadm.ExecOptions()
+	layout := dc.GetProjectLayout()
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			for _, name := range names {
+				flag := ServiceFlag{Id: serviceId, Flag: name, Value: flags[name]}
+				command := fmt.Sprintf(COMMAND_CURL_SET_FLAG, addr, name, url.QueryEscape(flag.Value))
+				out, err := ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+				out = strings.TrimSpace(out)
+				switch {
+				case err != nil:
+					flag.Message = "endpoint unreachable"
+				case out != HTTP_STATUS_OK:
+					flag.Message = fmt.Sprintf("rejected by service (HTTP %s)", out)
+				default:
+					flag.Applied = true
+					command = fmt.Sprintf(COMMAND_GREP_CONFIG_ITEM, name, layout.ServiceConfPath)
+					_, err = ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+					flag.Persisted = err == nil
+				}
+				addServiceFlag(curveadm.MemStorage(), flag)
+			}
+			return nil
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,178 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	SERVICE_HEALTH_HEALTHY   = "healthy"
+	SERVICE_HEALTH_UNHEALTHY = "unhealthy"
+	SERVICE_HEALTH_UNKNOWN   = "-"
+
+	URL_ETCD_HEALTH             = "%s://%s/health"
+	URL_RAFT_STAT               = "http://%s/raft_stat"
+	URL_METASERVER_PARTITION    = "http://%s/vars/*partition_count*"
+	URL_SNAPSHOTCLONE_VARS      = "http://%s/vars"
+	SIGNATURE_ETCD_HEALTHY      = `"health":"true"`
+	SIGNATURE_RAFT_NODE_STATE   = "state: "
+	REGEX_BVAR_PARTITION_COUNTS = `(?m)^\S*partition_count\S*\s*:\s*(\d+)\s*$`
+)
+
+/*
+ * step2GetServiceHealth queries the service's own health endpoints,
+ * which only executed in deep status mode:
+ *
+ *   etcd:          /health of client port
+ *   mds:           /vars/mds_status of dummy port (and its leadership)
+ *   chunkserver:   copysets and leaders in /raft_stat
+ *   metaserver:    copysets and leaders in /raft_stat, partitions in bvar
+ *   snapshotclone: /vars of dummy port
+ */
+type step2GetServiceHealth struct {
+	dc          *topology.DeployConfig
+	containerId string
+	status      *string
+	isLeader    *bool
+	health      *string
+	execOptions module.ExecOptions
+}
+
+func healthDetail(health string, details ...string) string {
+	if len(details) == 0 {
+		return health
+	}
+	return fmt.Sprintf("%s (%s)", health, strings.Join(details, ", "))
+}
+
+// parseRaftStat returns the number of copysets and leaders in output of /raft_stat
+func parseRaftStat(out string) (int, int) {
+	return strings.Count(out, SIGNATURE_RAFT_NODE_STATE), strings.Count(out, SIGNATURE_RAFT_LEADER)
+}
+
+func parsePartitionCount(out string) (int, bool) {
+	mu := regexp.MustCompile(REGEX_BVAR_PARTITION_COUNTS).FindAllStringSubmatch(out, -1)
+	if len(mu) == 0 {
+		return 0, false
+	}
+
+	count := 0
+	for _, m := range mu {
+		n, _ := strconv.Atoi(m[1])
+		count += n
+	}
+	return count, true
+}
+
+func (s *step2GetServiceHealth) curl(ctx *context.Context, url string) (string, error) {
+	command := fmt.Sprintf(COMMAND_CURL_MDS, url)
+	cmd := ctx.Module().DockerCli().ContainerExec(s.containerId, command)
+	return cmd.Execute(s.execOptions)
+}
+
+func (s *step2GetServiceHealth) etcdHealth(ctx *context.Context) string {
+	dc := s.dc
+	url := fmt.Sprintf(URL_ETCD_HEALTH, dc.GetEtcdScheme(), utils.JoinHostPort(dc.GetListenIp(), dc.GetListenClientPort()))
+	if options := dc.GetTLSClientOptions(); len(options) > 0 { // etcd requires client certificate
+		url = fmt.Sprintf("%s %s", url, options)
+	}
+	out, err := s.curl(ctx, url)
+	if err != nil || !strings.Contains(out, SIGNATURE_ETCD_HEALTHY) {
+		return SERVICE_HEALTH_UNHEALTHY
+	}
+	return SERVICE_HEALTH_HEALTHY
+}
+
+func (s *step2GetServiceHealth) mdsHealth(ctx *context.Context) string {
+	dc := s.dc
+	url := URL_CURVEBS_METRIC_LEADER
+	if dc.GetKind() == topology.KIND_CURVEFS {
+		url = URL_CURVEFS_METRIC_LEADER
+	}
+	out, err := s.curl(ctx, fmt.Sprintf(url, utils.JoinHostPort(dc.GetListenIp(), dc.GetListenDummyPort())))
+	if err != nil || len(strings.TrimSpace(out)) == 0 {
+		return SERVICE_HEALTH_UNHEALTHY
+	} else if *s.isLeader {
+		return healthDetail(SERVICE_HEALTH_HEALTHY, "leader")
+	}
+	return healthDetail(SERVICE_HEALTH_HEALTHY, "follower")
+}
+
+func (s *step2GetServiceHealth) raftHealth(ctx *context.Context) string {
+	dc := s.dc
+	out, err := s.curl(ctx, fmt.Sprintf(URL_RAFT_STAT, utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort())))
+	if err != nil {
+		return SERVICE_HEALTH_UNHEALTHY
+	}
+
+	copysets, leaders := parseRaftStat(out)
+	details := []string{
+		fmt.Sprintf("copysets=%d", copysets),
+		fmt.Sprintf("leaders=%d", leaders),
+	}
+	if dc.GetRole() == topology.ROLE_METASERVER {
+		url := fmt.Sprintf(URL_METASERVER_PARTITION, utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()))
+		out, err := s.curl(ctx, url)
+		if partitions, ok := parsePartitionCount(out); err == nil && ok {
+			details = append(details, fmt.Sprintf("partitions=%d", partitions))
+		}
+	}
+	return healthDetail(SERVICE_HEALTH_HEALTHY, details...)
+}
+
+func (s *step2GetServiceHealth) snapshotCloneHealth(ctx *context.Context) string {
+	dc := s.dc
+	_, err := s.curl(ctx, fmt.Sprintf(URL_SNAPSHOTCLONE_VARS, utils.JoinHostPort(dc.GetListenIp(), dc.GetListenDummyPort())))
+	if err != nil {
+		return SERVICE_HEALTH_UNHEALTHY
+	}
+	return SERVICE_HEALTH_HEALTHY
+}
+
+func (s *step2GetServiceHealth) Execute(ctx *context.Context) error {
+	*s.health = SERVICE_HEALTH_UNKNOWN
+	if !strings.HasPrefix(*s.status, "Up") {
+		return nil
+	}
+
+	switch s.dc.GetRole() {
+	case topology.ROLE_ETCD:
+		*s.health = s.etcdHealth(ctx)
+	case topology.ROLE_MDS:
+		*s.health = s.mdsHealth(ctx)
+	case topology.ROLE_CHUNKSERVER, topology.ROLE_METASERVER:
+		*s.health = s.raftHealth(ctx)
+	case topology.ROLE_SNAPSHOTCLONE:
+		*s.health = s.snapshotCloneHealth(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+// ServiceImage is the image which service container running with
+type ServiceImage struct {
+	Name    string            // e.g: opencurvedocker/curvebs:v1.2
+	Digest  string            // e.g: sha256:3e1c...
+	Configs map[string]string // config files of service, path -> content
+}
+
+// output of inspect: "opencurvedocker/curvebs:v1.2 sha256:3e1c..."
+func ParseServiceImage(out string) ServiceImage {
+	image := ServiceImage{}
+	fields := strings.Fields(out)
+	if len(fields) > 0 {
+		image.Name = fields[0]
+	}
+	if len(fields) > 1 {
+		image.Digest = fields[1]
+	}
+	return image
+}
+
+func addServiceImage(memStorage *utils.SafeMap, serviceId string, image ServiceImage) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		images := map[string]ServiceImage{}
+		v := kv.Get(comm.KEY_ALL_SERVICE_IMAGES)
+		if v != nil {
+			images = v.(map[string]ServiceImage)
+		}
+		images[serviceId] = image
+		kv.Set(comm.KEY_ALL_SERVICE_IMAGES, images)
+		return nil
+	})
+}
+
+// get the image which the service container running with
+func NewGetServiceImageTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Get Service Image", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.InspectContainer{
+		ContainerId: containerId,
+		Format:      "'{{.Config.Image}} {{.Image}}'",
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			image := ParseServiceImage(out)
+			image.Configs = readServiceConfigs(ctx, dc, containerId, curveadm.ExecOptions())
+			addServiceImage(curveadm.MemStorage(), serviceId, image)
+			return nil
+		},
+	})
+
+	return t, nil
+}
+
+// the config file which not exist (e.g: deployed by old version) is ignored
+func readServiceConfigs(ctx *context.Context,
+	dc *topology.DeployConfig,
+	containerId string,
+	options module.ExecOptions) map[string]string {
+	configs := map[string]string{}
+	for _, conf := range dc.GetProjectLayout().ServiceConfFiles {
+		out, err := ctx.Module().DockerCli().
+			ContainerExec(containerId, "cat "+conf.Path).
+			Execute(options)
+		if err == nil {
+			configs[conf.Path] = out
+		}
+	}
+	return configs
+}
+
+// restore the config files which service running with before upgrade,
+// the service without recorded configs is skipped
+func NewRestoreServiceConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	configs := map[string]string{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_PREVIOUS_SERVICE_CONFIGS); v != nil {
+		configs = v.(map[string]map[string]string)[serviceId]
+	}
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) || len(configs) == 0 {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Restore Service Config", subname, hc.GetSSHConfig())
+
+	// add step to task
+	paths := []string{}
+	for path := range configs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		content := configs[path]
+		t.AddStep(&step.InstallFile{
+			ContainerId:       &containerId,
+			ContainerDestPath: path,
+			Content:           &content,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	}
+
+	return t, nil
+}
@@ -0,0 +1,251 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	// glog header: I0827 10:00:00.123456 12345 chunkserver.cpp:100] message
+	REGEX_GLOG_HEADER = `^[IWEF](\d{4} \d{2}:\d{2}:\d{2}\.\d{6}) `
+	GLOG_TIME_LAYOUT  = "0102 15:04:05.000000"
+	ETCD_TIME_LAYOUT  = "2006-01-02 15:04:05.000000" // 2023-08-27 10:00:00.123456 I | message
+	LOGS_SINCE_LAYOUT = "2006-01-02T15:04:05"
+	GLOG_SYMLINK_GLOB = "*.INFO" // symlink to the latest glog file, INFO contains all severities
+	OTHER_LOG_GLOB    = "*.log"  // e.g. etcd.log
+
+	// NOTE: tail -F follows the symlink of glog, so logs are still followed after rotated
+	TEMPLATE_TAIL_LOGS = `bash -c 'files=$(ls -1 %[1]s/%[2]s 2>/dev/null || ls -1 %[1]s/%[3]s 2>/dev/null);` +
+		` [ -n "$files" ] && tail -q -n %[4]s %[5]s $files'`
+)
+
+type (
+	// LogLine is one line of service logs which tagged by its service
+	LogLine struct {
+		Time    time.Time `json:"time"`
+		Host    string    `json:"host"`
+		Service string    `json:"service"`
+		Role    string    `json:"role"`
+		Line    string    `json:"line"`
+	}
+
+	LogsOptions struct {
+		Since  time.Time          // all logs if zero
+		Tail   int                // all logs if <= 0
+		Follow func(line LogLine) // output new lines once read, it's called concurrently
+	}
+)
+
+// ParseLogsSince parses timestamp (e.g. 2023-08-27T10:00:00) or relative duration (e.g. 1h)
+func ParseLogsSince(since string, now time.Time) (time.Time, error) {
+	if len(since) == 0 {
+		return time.Time{}, nil
+	} else if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	} else if t, err := time.Parse(time.RFC3339Nano, since); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation(LOGS_SINCE_LAYOUT, since, time.Local)
+}
+
+// TailLogsCommand returns command which prints glog files (or other logs) of service in dir
+func TailLogsCommand(dir string, tail int, follow bool) string {
+	n, f := "+1", ""
+	if tail > 0 {
+		n = strconv.Itoa(tail)
+	}
+	if follow {
+		n, f = "0", "-F"
+	}
+	return fmt.Sprintf(TEMPLATE_TAIL_LOGS, dir, GLOG_SYMLINK_GLOB, OTHER_LOG_GLOB, n, f)
+}
+
+/*
+ * ParseLogLines parses lines of glog or etcd, e.g:
+ *
+ *   I0827 10:00:00.123456 12345 copyset_node.cpp:100] message
+ *   2023-08-27 10:00:00.123456 I | etcdserver: message
+ *
+ * glog has no year, it's the year of now unless it results in future.
+ * the line without timestamp (e.g. multi-line message) inherits
+ * the timestamp of previous line, so the order is kept after merged.
+ */
+func ParseLogLines(out string, now time.Time) []LogLine {
+	parser := newLogLineParser(now, time.Time{}, nil)
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		parser.parse(line)
+	}
+	return parser.lines
+}
+
+// logLineParser parses logs line by line, so the output can be streamed
+type logLineParser struct {
+	regex  *regexp.Regexp
+	now    time.Time
+	since  time.Time
+	last   time.Time
+	lines  []LogLine
+	output func(line LogLine) // collect into lines if nil
+}
+
+func newLogLineParser(now, since time.Time, output func(LogLine)) *logLineParser {
+	return &logLineParser{
+		regex:  regexp.MustCompile(REGEX_GLOG_HEADER),
+		now:    now,
+		since:  since,
+		lines:  []LogLine{},
+		output: output,
+	}
+}
+
+func (p *logLineParser) parseTime(line string) (time.Time, bool) {
+	if mu := p.regex.FindStringSubmatch(line); len(mu) == 2 {
+		t, err := time.ParseInLocation(GLOG_TIME_LAYOUT, mu[1], time.Local)
+		if err != nil {
+			return t, false
+		}
+		t = t.AddDate(p.now.Year()-t.Year(), 0, 0)
+		if t.After(p.now.Add(24 * time.Hour)) { // logs of last year
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, true
+	} else if len(line) >= len(ETCD_TIME_LAYOUT) {
+		t, err := time.ParseInLocation(ETCD_TIME_LAYOUT, line[:len(ETCD_TIME_LAYOUT)], time.Local)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+func (p *logLineParser) parse(line string) {
+	if len(line) == 0 {
+		return
+	}
+
+	if t, ok := p.parseTime(line); ok {
+		p.last = t
+	}
+	if p.last.Before(p.since) {
+		return
+	}
+	logLine := LogLine{Time: p.last, Line: line}
+	if p.output != nil {
+		p.output(logLine)
+	} else {
+		p.lines = append(p.lines, logLine)
+	}
+}
+
+func addServiceLogs(memStorage *utils.SafeMap, lines []LogLine) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		all := []LogLine{}
+		v := kv.Get(comm.KEY_ALL_SERVICE_LOGS)
+		if v != nil {
+			all = v.([]LogLine)
+		}
+		all = append(all, lines...)
+		kv.Set(comm.KEY_ALL_SERVICE_LOGS, all)
+		return nil
+	})
+}
+
+/*
+ * the logs are read from glog files in 'log_dir' of host, so they are available
+ * even if the container exited, or from the log directory in container if
+ * 'log_dir' isn't specified. with --follow, each line is output once read.
+ */
+func NewGetServiceLogsTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Get Service Logs", subname, hc.GetSSHConfig())
+
+	// add step to task
+	memStorage := curveadm.MemStorage()
+	options := memStorage.Get(comm.KEY_LOGS_OPTIONS).(LogsOptions)
+	var output func(LogLine)
+	if options.Follow != nil {
+		output = func(line LogLine) {
+			line.Host, line.Service, line.Role = dc.GetHost(), serviceId, dc.GetRole()
+			options.Follow(line)
+		}
+	}
+	parser := newLogLineParser(time.Now(), options.Since, output)
+	writer := module.NewLineWriter(parser.parse)
+	follow := options.Follow != nil
+	if logDir := dc.GetLogDir(); len(logDir) > 0 {
+		t.AddStep(&step.Command{
+			Command:     TailLogsCommand(logDir, options.Tail, follow),
+			Writer:      writer,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	} else {
+		t.AddStep(&step.ContainerExec{
+			ContainerId: &containerId,
+			Command:     TailLogsCommand(dc.GetProjectLayout().ServiceLogDir, options.Tail, follow),
+			Writer:      writer,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			writer.Close()
+			lines := parser.lines
+			for i := range lines {
+				lines[i].Host = dc.GetHost()
+				lines[i].Service = serviceId
+				lines[i].Role = dc.GetRole()
+			}
+			addServiceLogs(memStorage, lines)
+			return nil
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogLines(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2023, 8, 28, 0, 0, 0, 0, time.Local)
+	out := "I0827 10:00:00.000001 1234 copyset_node.cpp:100] copyset 1 is unhealthy\n" +
+		"  stack line\n" +
+		"\n" +
+		"2023-08-27 10:00:01.000000 I | etcdserver: published\n" +
+		"E1231 23:59:59.000000 1234 chunkserver.cpp:10] last year\n"
+	lines := ParseLogLines(out, now)
+	t1 := time.Date(2023, 8, 27, 10, 0, 0, 1000, time.Local)
+	t2 := time.Date(2023, 8, 27, 10, 0, 1, 0, time.Local)
+	t3 := time.Date(2022, 12, 31, 23, 59, 59, 0, time.Local)
+	assert.Len(lines, 4)
+	assert.Equal(LogLine{Time: t1, Line: "I0827 10:00:00.000001 1234 copyset_node.cpp:100] copyset 1 is unhealthy"}, lines[0])
+	assert.Equal(LogLine{Time: t1, Line: "  stack line"}, lines[1])
+	assert.Equal(t2, lines[2].Time)
+	assert.Equal(t3, lines[3].Time)
+
+	assert.Len(ParseLogLines("", now), 0)
+
+	// lines before since are dropped, include the lines inherit timestamp
+	parser := newLogLineParser(now, t2, nil)
+	for _, line := range strings.Split(out, "\n") {
+		parser.parse(line)
+	}
+	assert.Len(parser.lines, 1)
+	assert.Equal(t2, parser.lines[0].Time)
+}
+
+func TestParseLogsSince(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2023, 8, 28, 0, 0, 0, 0, time.Local)
+	since, err := ParseLogsSince("1h", now)
+	assert.Nil(err)
+	assert.Equal(now.Add(-time.Hour), since)
+	since, err = ParseLogsSince("2023-08-27T10:00:00", now)
+	assert.Nil(err)
+	assert.Equal(time.Date(2023, 8, 27, 10, 0, 0, 0, time.Local), since)
+	since, err = ParseLogsSince("", now)
+	assert.Nil(err)
+	assert.True(since.IsZero())
+	_, err = ParseLogsSince("yesterday", now)
+	assert.NotNil(err)
+}
+
+func TestTailLogsCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(TailLogsCommand("/logs", 0, false), "tail -q -n +1  $files")
+	assert.Contains(TailLogsCommand("/logs", 100, false), "tail -q -n 100  $files")
+	assert.Contains(TailLogsCommand("/logs", 100, true), "tail -q -n 0 -F $files")
+	assert.Contains(TailLogsCommand("/logs", 0, false), "ls -1 /logs/*.INFO")
+}
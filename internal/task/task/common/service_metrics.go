@@ -0,0 +1,211 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	COMMAND_CURL_VARS = "curl -g -s --connect-timeout 1 --max-time 3 http://%s/vars"
+
+	// e.g: chunkserver_10_0_0_1_8200_write_iops : 1024
+	//      chunkserver_10_0_0_1_8200_write_lat_latency : 356
+	//      metaserver_10_0_0_1_6800_read_iops : 1024
+	// the bvars of copyset or partition (e.g: chunkserver_10_0_0_1_8200_copyset_1_write_iops)
+	// are excluded, because they are already counted in the service one
+	REGEX_IO_BVAR = `^(?:chunkserver|metaserver)_(?:\d+_){4}\d+_(read|write)_(iops|bps|(?:lat_)?latency)\s*:\s*([\d.]+)\s*$`
+
+	IO_READ  = "read"
+	IO_WRITE = "write"
+)
+
+type (
+	// IOStat is the io load of service, bandwidth in bytes/s and latency in us
+	IOStat struct {
+		ReadIOPS     float64
+		WriteIOPS    float64
+		ReadBPS      float64
+		WriteBPS     float64
+		ReadLatency  float64
+		WriteLatency float64
+	}
+
+	ServiceMetrics struct {
+		Id         string
+		Role       string
+		Host       string
+		Pool       string // filled by caller
+		Reachable  bool   // false if metric endpoint can't be reached
+		SpaceUsed  uint64
+		SpaceTotal uint64
+		IOStat
+	}
+)
+
+/*
+ * ParseIOBvars parses the output of /vars, the iops and bandwidth of
+ * all matched bvars are summed, and the latency is averaged.
+ */
+func ParseIOBvars(out string) IOStat {
+	stat := IOStat{}
+	latencies := map[string][]float64{}
+	pattern := regexp.MustCompile(REGEX_IO_BVAR)
+	for _, line := range strings.Split(out, "\n") {
+		mu := pattern.FindStringSubmatch(strings.TrimSpace(line))
+		if len(mu) == 0 {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(mu[3], 64)
+		if err != nil {
+			continue
+		}
+		op, item := mu[1], mu[2]
+		switch {
+		case item == "iops" && op == IO_READ:
+			stat.ReadIOPS += value
+		case item == "iops" && op == IO_WRITE:
+			stat.WriteIOPS += value
+		case item == "bps" && op == IO_READ:
+			stat.ReadBPS += value
+		case item == "bps" && op == IO_WRITE:
+			stat.WriteBPS += value
+		default:
+			latencies[op] = append(latencies[op], value)
+		}
+	}
+
+	stat.ReadLatency = average(latencies[IO_READ])
+	stat.WriteLatency = average(latencies[IO_WRITE])
+	return stat
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// output of `df -B1 --output=used,size`: "Used 1B-blocks\n 1024 4096"
+func ParseDiskFree(out string) (uint64, uint64, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("invalid df output: %s", out)
+	}
+
+	used, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	total, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return used, total, nil
+}
+
+func addServiceMetrics(memStorage *utils.SafeMap, metrics ServiceMetrics) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		all := []ServiceMetrics{}
+		v := kv.Get(comm.KEY_ALL_SERVICE_METRICS)
+		if v != nil {
+			all = v.([]ServiceMetrics)
+		}
+		all = append(all, metrics)
+		kv.Set(comm.KEY_ALL_SERVICE_METRICS, all)
+		return nil
+	})
+}
+
+func NewSampleServiceMetricsTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Sample Service Metrics", subname, hc.GetSSHConfig())
+
+	// add step to task
+	options := curveadm.ExecOptions()
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			metrics := ServiceMetrics{
+				Id:   serviceId,
+				Role: dc.GetRole(),
+				Host: dc.GetHost(),
+			}
+
+			// space usage of data directory
+			out, err := ctx.Module().Shell().
+				DiskFree(dc.GetDataDir()).
+				AddOption("-B1").
+				AddOption("--output=used,size").
+				Execute(options)
+			if err == nil {
+				metrics.SpaceUsed, metrics.SpaceTotal, _ = ParseDiskFree(out)
+			}
+
+			// io load from bvars
+			command := fmt.Sprintf(COMMAND_CURL_VARS, utils.JoinHostPort(dc.GetListenIp(), dc.GetListenPort()))
+			out, err = ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+			if err == nil && len(out) > 0 {
+				metrics.Reachable = true
+				metrics.IOStat = ParseIOBvars(out)
+			}
+
+			addServiceMetrics(curveadm.MemStorage(), metrics)
+			return nil
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIOBvars(t *testing.T) {
+	assert := assert.New(t)
+
+	out := `chunkserver_10_0_0_1_8200_read_iops : 100
+chunkserver_10_0_0_1_8200_write_iops : 200
+chunkserver_10_0_0_1_8200_read_bps : 4096
+chunkserver_10_0_0_1_8200_write_bps : 8192
+chunkserver_10_0_0_1_8200_write_lat_latency : 300
+chunkserver_10_0_0_1_8200_write_lat_max_latency : 9000
+chunkserver_10_0_0_1_8200_read_latency : 100
+copyset_1_1_write_iops : 50
+chunkserver_10_0_0_1_8200_copyset_4294967297_write_iops : 50
+process_cpu_usage : 0.5`
+	stat := ParseIOBvars(out)
+	assert.Equal(100.0, stat.ReadIOPS)
+	assert.Equal(200.0, stat.WriteIOPS)
+	assert.Equal(4096.0, stat.ReadBPS)
+	assert.Equal(8192.0, stat.WriteBPS)
+	assert.Equal(100.0, stat.ReadLatency)
+	assert.Equal(300.0, stat.WriteLatency)
+
+	// metaserver
+	out = `metaserver_10_0_0_1_6800_read_iops : 300
+metaserver_10_0_0_1_6800_write_bps : 1024
+metaserver_10_0_0_1_6800_partition_1_read_iops : 300`
+	stat = ParseIOBvars(out)
+	assert.Equal(300.0, stat.ReadIOPS)
+	assert.Equal(1024.0, stat.WriteBPS)
+
+	assert.Equal(IOStat{}, ParseIOBvars(""))
+}
+
+func TestParseDiskFree(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		out      string
+		used     uint64
+		total    uint64
+		hasError bool
+	}{
+		{"     Used     1B-blocks\n 1024 4096\n", 1024, 4096, false},
+		{"1024 4096", 1024, 4096, false},
+		{"Used 1B-blocks\n", 0, 0, true},
+		{"", 0, 0, true},
+	}
+	for _, t := range tests {
+		used, total, err := ParseDiskFree(t.out)
+		assert.Equal(t.hasError, err != nil, t.out)
+		assert.Equal(t.used, used, t.out)
+		assert.Equal(t.total, total, t.out)
+	}
+}
@@ -42,9 +42,9 @@ import (
 
 const (
 	SIGNATURE_LEADER          = "leader"
-	URL_CURVEBS_METRIC_LEADER = "http://%s:%d/vars/mds_status?console=1"
-	URL_CURVEFS_METRIC_LEADER = "http://%s:%d/vars/curvefs_mds_status?console=1"
-	COMMAND_CURL_MDS          = "curl %s --connect-timeout 1 --max-time 3"
+	URL_CURVEBS_METRIC_LEADER = "http://%s/vars/mds_status?console=1"
+	URL_CURVEFS_METRIC_LEADER = "http://%s/vars/curvefs_mds_status?console=1"
+	COMMAND_CURL_MDS          = "curl -g %s --connect-timeout 1 --max-time 3"
 )
 
 type (
@@ -77,6 +77,7 @@ type (
 		isLeader    *bool
 		ports       *string
 		status      *string
+		health      *string
 		memStorage  *utils.SafeMap
 	}
 
@@ -90,9 +91,10 @@ type (
 		Ports       string
 		IsLeader    bool
 		Status      string
+		Health      string
 		LogDir      string
 		DataDir     string
-		Config      *topology.DeployConfig
+		Config      *topology.DeployConfig `json:"-"`
 	}
 )
 
@@ -120,6 +122,7 @@ func (s *step2InitStatus) Execute(ctx *context.Context) error {
 		Instances:   fmt.Sprintf("1/%d", dc.GetInstances()),
 		ContainerId: tui.TrimContainerId(s.containerId),
 		Status:      comm.SERVICE_STATUS_UNKNOWN,
+		Health:      SERVICE_HEALTH_UNKNOWN,
 		LogDir:      dc.GetLogDir(),
 		DataDir:     dc.GetDataDir(),
 		Config:      dc,
@@ -183,7 +186,7 @@ func (s *step2GetLeader) Execute(ctx *context.Context) error {
 
 	url := utils.Choose(dc.GetKind() == topology.KIND_CURVEBS,
 		URL_CURVEBS_METRIC_LEADER, URL_CURVEFS_METRIC_LEADER)
-	url = fmt.Sprintf(url, dc.GetListenIp(), dc.GetListenDummyPort())
+	url = fmt.Sprintf(url, utils.JoinHostPort(dc.GetListenIp(), dc.GetListenDummyPort()))
 	command := fmt.Sprintf(COMMAND_CURL_MDS, url)
 	cmd := ctx.Module().DockerCli().ContainerExec(s.containerId, command)
 	out, _ := cmd.Execute(s.execOptions)
@@ -211,6 +214,7 @@ func (s *step2FormatServiceStatus) Execute(ctx *context.Context) error {
 		Ports:       *s.ports,
 		IsLeader:    *s.isLeader,
 		Status:      status,
+		Health:      *s.health,
 		LogDir:      dc.GetLogDir(),
 		DataDir:     dc.GetDataDir(),
 		Config:      dc,
@@ -271,6 +275,7 @@ func NewGetServiceStatusTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig)
 	var status string
 	var ports string
 	var isLeader bool
+	health := SERVICE_HEALTH_UNKNOWN
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
 		Format:      `"{{.Status}}"`,
@@ -294,6 +299,16 @@ func NewGetServiceStatusTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig)
 		isLeader:    &isLeader,
 		execOptions: curveadm.ExecOptions(),
 	})
+	if curveadm.MemStorage().Get(comm.KEY_STATUS_DEEP) == true {
+		t.AddStep(&step2GetServiceHealth{
+			dc:          dc,
+			containerId: containerId,
+			status:      &status,
+			isLeader:    &isLeader,
+			health:      &health,
+			execOptions: curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step2FormatServiceStatus{
 		dc:          dc,
 		serviceId:   serviceId,
@@ -301,6 +316,7 @@ func NewGetServiceStatusTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig)
 		isLeader:    &isLeader,
 		ports:       &ports,
 		status:      &status,
+		health:      &health,
 		memStorage:  curveadm.MemStorage(),
 	})
 
@@ -74,6 +74,10 @@ func (s *Step2CheckPostStart) Execute(ctx *context.Context) error {
 }
 
 func NewStartServiceTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if len(dc.GetSystemdUnit()) > 0 {
+		return newServiceUnitTask(curveadm, dc, newStartUnitTask)
+	}
+
 	serviceId := curveadm.GetServiceId(dc.GetId())
 	containerId, err := curveadm.GetContainerId(serviceId)
 	if curveadm.IsSkip(dc) {
@@ -58,6 +58,10 @@ func checkContainerId(containerId string) step.LambdaType {
 }
 
 func NewStopServiceTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if len(dc.GetSystemdUnit()) > 0 {
+		return newServiceUnitTask(curveadm, dc, newStopUnitTask)
+	}
+
 	hc, err := curveadm.GetHost(dc.GetHost())
 	if err != nil {
 		return nil, err
@@ -0,0 +1,141 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/cert"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+// findCertificate returns nil if the CA or the certificate of name not found, the
+// private key of CA is kept in secrets store, so only the certificate of CA returned
+func findCertificate(curveadm *cli.CurveAdm, name string) (*cert.Bundle, *cert.Bundle, error) {
+	certs, err := curveadm.Storage().GetCertificates(curveadm.ClusterId())
+	if err != nil {
+		return nil, nil, errno.ERR_GET_CERTIFICATES_FAILED.E(err)
+	}
+
+	var ca, bundle *cert.Bundle
+	for _, c := range certs {
+		if c.Name == cert.NAME_CA {
+			ca = &cert.Bundle{Cert: c.Cert}
+		} else if c.Name == name {
+			bundle = &cert.Bundle{Cert: c.Cert, Key: c.Key}
+		}
+	}
+	if ca == nil || bundle == nil {
+		return nil, nil, nil
+	}
+	return ca, bundle, nil
+}
+
+// GetServiceCertificate returns the cluster CA and the certificate issued for service
+func GetServiceCertificate(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*cert.Bundle, *cert.Bundle, error) {
+	ca, server, err := findCertificate(curveadm, curveadm.GetServiceId(dc.GetId()))
+	if err != nil {
+		return nil, nil, err
+	} else if ca == nil {
+		return nil, nil, errno.ERR_CERTIFICATE_NOT_FOUND.
+			F("host=%s role=%s, please run 'curveadm cert generate' first", dc.GetHost(), dc.GetRole())
+	}
+	return ca, server, nil
+}
+
+// GetPrometheusCertificate returns the cluster CA and the client certificate of prometheus
+func GetPrometheusCertificate(curveadm *cli.CurveAdm) (*cert.Bundle, *cert.Bundle, error) {
+	ca, client, err := findCertificate(curveadm, cert.NAME_PROMETHEUS)
+	if err != nil {
+		return nil, nil, err
+	} else if ca == nil {
+		return nil, nil, errno.ERR_CERTIFICATE_NOT_FOUND.
+			F("role=prometheus, please run 'curveadm cert generate' first")
+	}
+	return ca, client, nil
+}
+
+func addSyncCertSteps(t *task.Task, curveadm *cli.CurveAdm,
+	dc *topology.DeployConfig, containerId *string) error {
+	ca, server, err := GetServiceCertificate(curveadm, dc)
+	if err != nil {
+		return err
+	}
+
+	layout := dc.GetProjectLayout()
+	files := []struct {
+		content *string
+		path    string
+	}{
+		{&ca.Cert, layout.ServiceCACertPath},
+		{&server.Cert, layout.ServiceCertPath},
+		{&server.Key, layout.ServiceKeyPath},
+	}
+	for _, file := range files {
+		t.AddStep(&step.InstallFile{
+			ContainerId:       containerId,
+			ContainerDestPath: file.path,
+			Content:           file.content,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	}
+	return nil
+}
+
+func NewSyncCertTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Sync Certificate", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.ListContainers{ // gurantee container exist
+		ShowAll:     true,
+		Format:      `"{{.ID}}"`,
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: CheckContainerExist(dc.GetHost(), dc.GetRole(), containerId, &out),
+	})
+	err = addSyncCertSteps(t, curveadm, dc, &containerId)
+	return t, err
+}
@@ -42,6 +42,10 @@ const (
 	TOOLS_V2_CONFIG_DELIMITER = ": "
 
 	CURVE_CRONTAB_FILE = "/tmp/curve_crontab"
+
+	// report usage every hour
+	TEMPLATE_CURVE_CRONTAB = `{{if .service.report_usage}}0 * * * * bash {{.service.tools_bin}}/report.sh {{.service.kind}} {{.cluster.uuid}} {{.service.role}}{{end}}
+`
 )
 
 func NewMutate(dc *topology.DeployConfig, delimiter string, forceRender bool) step.Mutate {
@@ -50,7 +54,7 @@ func NewMutate(dc *topology.DeployConfig, delimiter string, forceRender bool) st
 		if len(key) == 0 {
 			out = in
 			if forceRender { // only for nginx.conf
-				out, err = dc.GetVariables().Rendering(in)
+				out, err = dc.GetVariables().Rendering(topology.BracketAddress(in))
 			}
 			return
 		}
@@ -62,29 +66,24 @@ func NewMutate(dc *topology.DeployConfig, delimiter string, forceRender bool) st
 		}
 
 		// replace variable
-		value, err = dc.GetVariables().Rendering(value)
+		value, err = dc.GetVariables().Rendering(topology.BracketAddress(value))
 		if err != nil {
 			return
 		}
 
+		// enable TLS
+		value = dc.MutateTLSConfig(key, value)
+
 		out = fmt.Sprintf("%s%s%s", key, delimiter, value)
 		return
 	}
 }
 
-func newCrontab(uuid string, dc *topology.DeployConfig, reportScriptPath string) string {
-	var period, command string
-	if dc.GetReportUsage() == true {
-		period = func(minute, hour, day, month, week string) string {
-			return fmt.Sprintf("%s %s %s %s %s", minute, hour, day, month, week)
-		}("0", "*", "*", "*", "*") // every hour
-
-		command = func(format string, args ...interface{}) string {
-			return fmt.Sprintf(format, args...)
-		}("bash %s %s %s %s", reportScriptPath, dc.GetKind(), uuid, dc.GetRole())
+// NewComplete adds the items lacked in main config file of service, e.g. TLS
+func NewComplete(dc *topology.DeployConfig, delimiter string) step.Complete {
+	return func(lines []string) []string {
+		return dc.CompleteTLSConfig(lines, delimiter)
 	}
-
-	return fmt.Sprintf("%s %s\n", period, command)
 }
 
 func NewSyncConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
@@ -111,7 +110,6 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task
 	role := dc.GetRole()
 	reportScript := scripts.REPORT
 	reportScriptPath := fmt.Sprintf("%s/report.sh", layout.ToolsBinDir)
-	crontab := newCrontab(curveadm.ClusterUUId(), dc, reportScriptPath)
 	delimiter := DEFAULT_CONFIG_DELIMITER
 	if role == topology.ROLE_ETCD {
 		delimiter = ETCD_CONFIG_DELIMITER
@@ -128,6 +126,10 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task
 		Lambda: CheckContainerExist(dc.GetHost(), dc.GetRole(), containerId, &out),
 	})
 	for _, conf := range layout.ServiceConfFiles {
+		var complete step.Complete
+		if conf.Path == layout.ServiceConfPath {
+			complete = NewComplete(dc, delimiter)
+		}
 		t.AddStep(&step.SyncFile{ // sync service config
 			ContainerSrcId:    &containerId,
 			ContainerSrcPath:  conf.SourcePath,
@@ -135,6 +137,7 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task
 			ContainerDestPath: conf.Path,
 			KVFieldSplit:      delimiter,
 			Mutate:            NewMutate(dc, delimiter, conf.Name == "nginx.conf"),
+			Complete:          complete,
 			ExecOptions:       curveadm.ExecOptions(),
 		})
 	}
@@ -156,16 +159,23 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task
 		Mutate:            NewMutate(dc, TOOLS_V2_CONFIG_DELIMITER, false),
 		ExecOptions:       curveadm.ExecOptions(),
 	})
+	if dc.GetTLSEnable() { // sync certificates
+		err = addSyncCertSteps(t, curveadm, dc, &containerId)
+		if err != nil {
+			return nil, err
+		}
+	}
 	t.AddStep(&step.InstallFile{ // install report script
 		ContainerId:       &containerId,
 		ContainerDestPath: reportScriptPath,
 		Content:           &reportScript,
 		ExecOptions:       curveadm.ExecOptions(),
 	})
-	t.AddStep(&step.InstallFile{ // install crontab file
+	t.AddStep(&step.RenderTemplate{ // install crontab file
+		Template:          TEMPLATE_CURVE_CRONTAB,
+		Variables:         NewTemplateVariables(curveadm, dc, hc),
 		ContainerId:       &containerId,
 		ContainerDestPath: CURVE_CRONTAB_FILE,
-		Content:           &crontab,
 		ExecOptions:       curveadm.ExecOptions(),
 	})
 
@@ -0,0 +1,132 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+)
+
+/*
+ * the service which specified 'systemd_unit' in topology runs outside container,
+ * so start/stop/restart service manage its unit instead of container:
+ *   start:   daemon-reload -> enable --now -> is-active
+ *   stop:    stop
+ *   restart: daemon-reload -> restart -> is-active
+ */
+
+type newUnitTaskFunc func(*cli.CurveAdm, *topology.DeployConfig, *hosts.HostConfig) *task.Task
+
+func newServiceUnitTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig, fn newUnitTaskFunc) (*task.Task, error) {
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	return fn(curveadm, dc, hc), nil
+}
+
+func checkUnitActive(host, role, unit string, status *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if *status != step.SYSTEMD_UNIT_STATUS_ACTIVE {
+			return errno.ERR_SYSTEMD_UNIT_IS_NOT_ACTIVE.
+				F("host=%s role=%s unit=%s status=%s", host, role, unit, *status)
+		}
+		return nil
+	}
+}
+
+func newUnitTask(name string, dc *topology.DeployConfig, hc *hosts.HostConfig) *task.Task {
+	subname := fmt.Sprintf("host=%s role=%s unit=%s",
+		dc.GetHost(), dc.GetRole(), dc.GetSystemdUnit())
+	return task.NewTask(name, subname, hc.GetSSHConfig())
+}
+
+func newStartUnitTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig, hc *hosts.HostConfig) *task.Task {
+	t := newUnitTask("Start Service", dc, hc)
+
+	// add step to task
+	var status string
+	unit := dc.GetSystemdUnit()
+	t.AddStep(&step.DaemonReload{
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.EnableUnit{
+		Name:        unit,
+		Now:         true,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.GetUnitStatus{
+		Name:        unit,
+		Out:         &status,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkUnitActive(dc.GetHost(), dc.GetRole(), unit, &status),
+	})
+	return t
+}
+
+func newStopUnitTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig, hc *hosts.HostConfig) *task.Task {
+	t := newUnitTask("Stop Service", dc, hc)
+
+	// add step to task
+	t.AddStep(&step.StopUnit{
+		Name:        dc.GetSystemdUnit(),
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	return t
+}
+
+func newRestartUnitTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig, hc *hosts.HostConfig) *task.Task {
+	t := newUnitTask("Restart Service", dc, hc)
+
+	// add step to task
+	var status string
+	unit := dc.GetSystemdUnit()
+	t.AddStep(&step.DaemonReload{
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.RestartUnit{
+		Name:        unit,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.GetUnitStatus{
+		Name:        unit,
+		Out:         &status,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkUnitActive(dc.GetHost(), dc.GetRole(), unit, &status),
+	})
+	return t
+}
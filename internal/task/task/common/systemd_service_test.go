@@ -0,0 +1,44 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUnitActive(t *testing.T) {
+	assert := assert.New(t)
+
+	status := "active"
+	check := checkUnitActive("host1", "mds", "curvebs-mds.service", &status)
+	assert.Nil(check(nil))
+
+	for _, status = range []string{"inactive", "failed", "activating"} {
+		err := check(nil)
+		assert.True(errors.Is(err, errno.ERR_SYSTEMD_UNIT_IS_NOT_ACTIVE), status)
+	}
+}
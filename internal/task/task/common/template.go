@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+)
+
+/*
+ * variables for step.RenderTemplate, e.g:
+ *
+ *   {{.cluster.name}} {{.cluster.uuid}}
+ *   {{.service.id}} {{.service.role}} {{.service.listen_port}} ...
+ *   {{.host.name}} {{.host.hostname}} {{.host.user}}
+ */
+func NewTemplateVariables(curveadm *cli.CurveAdm,
+	dc *topology.DeployConfig,
+	hc *hosts.HostConfig) map[string]interface{} {
+	variables := map[string]interface{}{
+		"cluster": map[string]interface{}{
+			"name": curveadm.ClusterName(),
+			"uuid": curveadm.ClusterUUId(),
+		},
+	}
+
+	if dc != nil {
+		layout := dc.GetProjectLayout()
+		variables["service"] = map[string]interface{}{
+			"id":           dc.GetId(),
+			"kind":         dc.GetKind(),
+			"role":         dc.GetRole(),
+			"host":         dc.GetHost(),
+			"hostname":     dc.GetHostname(),
+			"host_seq":     dc.GetHostSequence(),
+			"instance_seq": dc.GetInstancesSequence(),
+			"listen_ip":    dc.GetListenIp(),
+			"listen_port":  dc.GetListenPort(),
+			"report_usage": dc.GetReportUsage(),
+			"log_dir":      dc.GetLogDir(),
+			"data_dir":     dc.GetDataDir(),
+			"tools_bin":    layout.ToolsBinDir,
+		}
+	}
+
+	if hc != nil {
+		variables["host"] = map[string]interface{}{
+			"name":     hc.GetHost(),
+			"hostname": hc.GetHostname(),
+			"user":     hc.GetUser(),
+		}
+	}
+	return variables
+}
@@ -0,0 +1,112 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+)
+
+const (
+	TEMPLATE_AUTOMOUNT_UNIT = `[Unit]
+Description=CurveFS mount {{.mount_point}} (generated by curveadm)
+After=network-online.target {{.engine}}.service
+Wants=network-online.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStartPre=-/bin/umount -l {{.mount_point}}
+ExecStart=/usr/bin/env {{.engine}} start {{.container}}
+ExecStop=/usr/bin/env {{.engine}} stop {{.container}}
+
+[Install]
+WantedBy=multi-user.target
+`
+)
+
+type (
+	step2RemoveAutomountUnit struct {
+		name     string
+		curveadm *cli.CurveAdm
+	}
+)
+
+func automountUnitName(mountPoint string) string {
+	return fmt.Sprintf("%s.service", mountPoint2ContainerName(mountPoint))
+}
+
+func addInstallAutomountUnitSteps(t *task.Task, curveadm *cli.CurveAdm, mountPoint string) {
+	name := automountUnitName(mountPoint)
+	options := curveadm.ExecOptions()
+	t.AddStep(&step.InstallUnitFile{
+		Name:     name,
+		Template: TEMPLATE_AUTOMOUNT_UNIT,
+		Variables: map[string]interface{}{
+			"mount_point": mountPoint,
+			"engine":      options.ExecWithEngine,
+			"container":   mountPoint2ContainerName(mountPoint),
+		},
+		ExecOptions: options,
+	})
+	t.AddStep(&step.DaemonReload{
+		ExecOptions: options,
+	})
+	t.AddStep(&step.EnableUnit{
+		Name:        name,
+		ExecOptions: options,
+	})
+}
+
+func (s *step2RemoveAutomountUnit) Execute(ctx *context.Context) error {
+	// (1) skip if the filesystem mounted without automount unit
+	var success bool
+	var out string
+	options := s.curveadm.ExecOptions()
+	err := (&step.Command{
+		Command:     fmt.Sprintf("test -f %s", step.UnitFilePath(s.name)),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: options,
+	}).Execute(ctx)
+	if err != nil || !success {
+		return err
+	}
+
+	// (2) disable and remove the unit
+	steps := []task.Step{
+		&step.DisableUnit{Name: s.name, Out: &out, ExecOptions: options},
+		&step.RemoveUnitFile{Name: s.name, ExecOptions: options},
+		&step.DaemonReload{ExecOptions: options},
+	}
+	for _, step := range steps {
+		if err := step.Execute(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
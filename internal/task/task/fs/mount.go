@@ -26,6 +26,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
@@ -58,6 +59,9 @@ type (
 		MountFSName string
 		MountFSType string
 		MountPoint  string
+		Automount   bool // install systemd unit to mount on boot
+		MkdirMount  bool // create mount point if not exist
+		QoS         configure.ClientQoS
 	}
 
 	step2InsertClient struct {
@@ -70,6 +74,8 @@ type (
 	AuxInfo struct {
 		FSName     string `json:"fsname"`
 		MountPoint string `json:"mount_point,"`
+		FSType     string `json:"fstype,omitempty"`
+		Automount  bool   `json:"automount,omitempty"`
 		Config     string `json:"config,omitempty"` // TODO(P1)
 	}
 )
@@ -145,6 +151,52 @@ func newMutate(cc *configure.ClientConfig, delimiter string) step.Mutate {
 	}
 }
 
+// the throttle items in curvefs client.conf, zero means unlimited
+func getThrottleConfig(qos configure.ClientQoS) map[string]int {
+	return map[string]int{
+		"fuseClient.throttle.avgReadIops":   qos.ReadIOPS,
+		"fuseClient.throttle.avgWriteIops":  qos.WriteIOPS,
+		"fuseClient.throttle.avgReadBytes":  qos.ReadBPS,
+		"fuseClient.throttle.avgWriteBytes": qos.WriteBPS,
+	}
+}
+
+func newQoSMutate(cc *configure.ClientConfig, qos configure.ClientQoS, delimiter string) step.Mutate {
+	mutate := newMutate(cc, delimiter)
+	throttle := getThrottleConfig(qos)
+	return func(in, key, value string) (out string, err error) {
+		if v := throttle[key]; v > 0 {
+			out = fmt.Sprintf("%s%s%d", key, delimiter, v)
+			return
+		}
+		return mutate(in, key, value)
+	}
+}
+
+// the throttle items which lack in client.conf of old image are appended
+func newQoSComplete(qos configure.ClientQoS, delimiter string) step.Complete {
+	throttle := getThrottleConfig(qos)
+	return func(lines []string) []string {
+		exist := map[string]bool{}
+		for _, line := range lines {
+			key := strings.TrimSpace(strings.SplitN(line, delimiter, 2)[0])
+			exist[key] = true
+		}
+
+		keys := []string{}
+		for key, v := range throttle {
+			if v > 0 && !exist[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s%s%d", key, delimiter, throttle[key]))
+		}
+		return lines
+	}
+}
+
 func newCurveBSMutate(cc *configure.ClientConfig, delimiter string) step.Mutate {
 	serviceConfig := cc.GetServiceConfig()
 
@@ -246,6 +298,8 @@ func (s *step2InsertClient) Execute(ctx *context.Context) error {
 	auxInfo := &AuxInfo{
 		FSName:     options.MountFSName,
 		MountPoint: options.MountPoint,
+		FSType:     options.MountFSType,
+		Automount:  options.Automount,
 	}
 	bytes, err := json.Marshal(auxInfo)
 	if err != nil {
@@ -300,6 +354,7 @@ func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.T
 	containerName := mountPoint2ContainerName(mountPoint)
 	createfsScript := scripts.CREATE_FS
 	createfsScriptPath := "/client.sh"
+	qos := cc.GetQoS().Override(options.QoS)
 
 	t.AddStep(&step.EngineInfo{
 		Success:     &success,
@@ -319,6 +374,12 @@ func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.T
 	t.AddStep(&step.Lambda{
 		Lambda: checkMountStatus(mountPoint, containerName, &out),
 	})
+	if options.MkdirMount {
+		t.AddStep(&step.CreateDirectory{
+			Paths:       []string{mountPoint},
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step.PullImage{
 		Image:       cc.GetContainerImage(),
 		ExecOptions: curveadm.ExecOptions(),
@@ -366,7 +427,8 @@ func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.T
 		ContainerDestId:   &containerId,
 		ContainerDestPath: fmt.Sprintf("%s/conf/client.conf", prefix),
 		KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
-		Mutate:            newMutate(cc, CLIENT_CONFIG_DELIMITER),
+		Mutate:            newQoSMutate(cc, qos, CLIENT_CONFIG_DELIMITER),
+		Complete:          newQoSComplete(qos, CLIENT_CONFIG_DELIMITER),
 		ExecOptions:       curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.SyncFile{ // sync tools config
@@ -403,6 +465,9 @@ func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.T
 		Lambda: checkStartContainerStatus(&success, &out),
 	})
 	// TODO(P0): wait mount done
+	if options.Automount {
+		addInstallAutomountUnitSteps(t, curveadm, mountPoint)
+	}
 
 	return t, nil
 
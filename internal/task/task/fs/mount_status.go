@@ -0,0 +1,161 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	MOUNT_STATUS_HEALTHY   = "Healthy"
+	MOUNT_STATUS_STALE     = "Stale"
+	MOUNT_STATUS_UNMOUNTED = "Unmounted"
+	MOUNT_STATUS_UNKNOWN   = "Unknown"
+
+	DEFAULT_MOUNT_FSTYPE = "s3"
+
+	SIGNATURE_STALE_MOUNT = "Transport endpoint is not connected"
+	FUSE_FILESYSTEM_TYPE  = "fuse"
+
+	CMD_PROBE_MOUNT       = "stat -f -c %%T %s"
+	CMD_CLEAN_STALE_MOUNT = "bash -c 'stat -f %s 2>&1 | grep -q \"not connected\" && umount -l %s'"
+)
+
+type (
+	MountStatus struct {
+		Id          string
+		Host        string
+		FSName      string
+		FSType      string
+		MountPoint  string
+		ContainerId string
+		Status      string
+	}
+)
+
+func DecodeAuxInfo(data string) (*AuxInfo, error) {
+	auxInfo := &AuxInfo{}
+	err := json.Unmarshal([]byte(data), auxInfo)
+	if err != nil {
+		return nil, errno.ERR_DECODE_FILESYSTEM_INFO_FAILED.E(err)
+	}
+	return auxInfo, nil
+}
+
+/*
+ * statfs on the mount point:
+ *   fuseblk                                                  => healthy
+ *   stat: ...: Transport endpoint is not connected           => stale
+ *   ext2/ext3, xfs...                                        => unmounted
+ */
+func parseMountStatus(success bool, out string) string {
+	out = strings.TrimSpace(out)
+	if strings.Contains(out, SIGNATURE_STALE_MOUNT) {
+		return MOUNT_STATUS_STALE
+	} else if !success {
+		return MOUNT_STATUS_UNKNOWN
+	} else if strings.HasPrefix(out, FUSE_FILESYSTEM_TYPE) {
+		return MOUNT_STATUS_HEALTHY
+	}
+	return MOUNT_STATUS_UNMOUNTED
+}
+
+func setMountStatus(memStorage *utils.SafeMap, status MountStatus) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string]MountStatus{}
+		v := kv.Get(comm.KEY_ALL_MOUNT_STATUS)
+		if v != nil {
+			m = v.(map[string]MountStatus)
+		}
+		m[status.Id] = status
+		kv.Set(comm.KEY_ALL_MOUNT_STATUS, m)
+		return nil
+	})
+}
+
+func saveMountStatus(curveadm *cli.CurveAdm, status MountStatus,
+	success *bool, out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		status.Status = parseMountStatus(*success, *out)
+		setMountStatus(curveadm.MemStorage(), status)
+		return nil
+	}
+}
+
+func NewGetMountStatusTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	client := v.(storage.Client)
+	auxInfo, err := DecodeAuxInfo(client.AuxInfo)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(client.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	mountPoint := auxInfo.MountPoint
+	fsType := auxInfo.FSType
+	if len(fsType) == 0 { // mounted by old curveadm
+		fsType = DEFAULT_MOUNT_FSTYPE
+	}
+	subname := fmt.Sprintf("host=%s mountPoint=%s", client.Host, mountPoint)
+	t := task.NewTask("Get Mount Status", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var success bool
+	var out string
+	status := MountStatus{
+		Id:          client.Id,
+		Host:        client.Host,
+		FSName:      auxInfo.FSName,
+		FSType:      fsType,
+		MountPoint:  mountPoint,
+		ContainerId: client.ContainerId,
+		Status:      MOUNT_STATUS_UNKNOWN,
+	}
+	setMountStatus(curveadm.MemStorage(), status)
+
+	t.AddStep(&step.Command{
+		Command:     fmt.Sprintf(CMD_PROBE_MOUNT, mountPoint),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: saveMountStatus(curveadm, status, &success, &out),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,40 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMountStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(MOUNT_STATUS_HEALTHY, parseMountStatus(true, "fuseblk\n"))
+	assert.Equal(MOUNT_STATUS_UNMOUNTED, parseMountStatus(true, "ext2/ext3"))
+	assert.Equal(MOUNT_STATUS_STALE, parseMountStatus(false,
+		"stat: cannot read file system information for '/mnt/curvefs': Transport endpoint is not connected"))
+	assert.Equal(MOUNT_STATUS_UNKNOWN, parseMountStatus(false,
+		"stat: cannot read file system information for '/mnt/curvefs': No such file or directory"))
+}
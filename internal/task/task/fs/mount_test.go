@@ -58,3 +58,30 @@ func TestConfigureEnv_Basic(t *testing.T) {
 		"LD_PRELOAD=",
 	})
 }
+
+func TestQoSConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	cc, err := configure.NewClientConfig(map[string]interface{}{
+		KEY_KIND:  "curvefs",
+		KEY_ADDRS: "1.1.1.1",
+	})
+	assert.Nil(err)
+	qos := configure.ClientQoS{ReadIOPS: 1000, WriteBPS: 104857600}
+
+	// rewrite the existing items
+	mutate := newQoSMutate(cc, qos, CLIENT_CONFIG_DELIMITER)
+	out, err := mutate("fuseClient.throttle.avgReadIops=0", "fuseClient.throttle.avgReadIops", "0")
+	assert.Nil(err)
+	assert.Equal("fuseClient.throttle.avgReadIops=1000", out)
+	out, err = mutate("fuseClient.throttle.avgWriteIops=0", "fuseClient.throttle.avgWriteIops", "0")
+	assert.Nil(err)
+	assert.Equal("fuseClient.throttle.avgWriteIops=0", out)
+
+	// append the lacked items
+	complete := newQoSComplete(qos, CLIENT_CONFIG_DELIMITER)
+	assert.Equal([]string{
+		"fuseClient.throttle.avgReadIops=1000",
+		"fuseClient.throttle.avgWriteBytes=104857600",
+	}, complete([]string{"fuseClient.throttle.avgReadIops=1000"}))
+}
@@ -134,7 +134,8 @@ func NewUmountFSTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error)
 	t := task.NewTask("Umount FileSystem", subname, hc.GetSSHConfig())
 
 	// add step to task
-	var status string
+	var status, out string
+	var success bool
 	containerId := mountPoint2ContainerName(mountPoint)
 
 	t.AddStep(&step.ListContainers{
@@ -155,6 +156,16 @@ func NewUmountFSTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error)
 		containerId: containerId,
 		curveadm:    curveadm,
 	})
+	t.AddStep(&step2RemoveAutomountUnit{
+		name:     automountUnitName(mountPoint),
+		curveadm: curveadm,
+	})
+	t.AddStep(&step.Command{ // clean up stale FUSE mount left by dead client
+		Command:     fmt.Sprintf(CMD_CLEAN_STALE_MOUNT, mountPoint, mountPoint),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
 	t.AddStep(&step2DeleteClient{
 		curveadm: curveadm,
 		fsId:     fsId,
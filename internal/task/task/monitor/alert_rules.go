@@ -0,0 +1,151 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package monitor
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	// prometheus.yml loads rule files by "rules/*.yml"
+	ALERT_RULES_DIR_NAME       = "rules"
+	ALERT_RULES_CONTAINER_DIR  = "/etc/prometheus/rules"
+	ALERT_RULES_CONTAINER_PATH = "/etc/prometheus/rules/curve.yml"
+)
+
+func checkCommandSuccess(success *bool, out *string, code *errno.ErrorCode) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !*success {
+			return code.S(*out)
+		}
+		return nil
+	}
+}
+
+// copy alert rules into prometheus container, it works for the container
+// which created but not started yet because nothing executed inside it
+func addCopyAlertRulesSteps(t *task.Task, curveadm *cli.CurveAdm, containerId *string, rules *string) {
+	t.AddStep(&step.CreateAndUploadDir{
+		HostDirName:       ALERT_RULES_DIR_NAME,
+		ContainerDestId:   containerId,
+		ContainerDestPath: path.Dir(ALERT_RULES_CONTAINER_DIR),
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.InstallFile{
+		ContainerId:       containerId,
+		ContainerDestPath: ALERT_RULES_CONTAINER_PATH,
+		Content:           rules,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+}
+
+// install alert rules into running prometheus container and check it by promtool
+func addInstallAlertRulesSteps(t *task.Task, curveadm *cli.CurveAdm, containerId *string, rules *string) {
+	var success bool
+	var out string
+	addCopyAlertRulesSteps(t, curveadm, containerId, rules)
+	t.AddStep(&step.ContainerExec{
+		ContainerId: containerId,
+		Command:     fmt.Sprintf("promtool check rules %s", ALERT_RULES_CONTAINER_PATH),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkCommandSuccess(&success, &out, errno.ERR_CHECK_ALERT_RULES_FAILED),
+	})
+}
+
+func NewApplyAlertRulesTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(cfg.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if IsSkip(cfg, []string{ROLE_MONITOR_CONF, ROLE_NODE_EXPORTER, ROLE_GRAFANA}) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(cfg.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		cfg.GetHost(), cfg.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Apply Alert Rules", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out, reloadOut string
+	var success bool
+	rules := curveadm.MemStorage().Get(comm.KEY_ALERT_RULES).(string)
+	t.AddStep(&step.ListContainers{ // gurantee container exist
+		ShowAll:     true,
+		Format:      `"{{.ID}}"`,
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: common.CheckContainerExist(cfg.GetHost(), cfg.GetRole(), containerId, &out),
+	})
+	addInstallAlertRulesSteps(t, curveadm, &containerId, &rules)
+	t.AddStep(&step.ContainerExec{ // prometheus reloads its configure on SIGHUP
+		ContainerId: &containerId,
+		Command:     "kill -HUP 1",
+		Success:     &success,
+		Out:         &reloadOut,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkCommandSuccess(&success, &reloadOut, errno.ERR_RELOAD_PROMETHEUS_FAILED),
+	})
+	return t, nil
+}
+
+// the latest applied alert rules, empty if never applied. The rules had been
+// checked by promtool when applied, we only check its skeleton here because
+// the prometheus container may not be started yet.
+func latestAlertRules(curveadm *cli.CurveAdm) (string, error) {
+	versions, err := curveadm.Storage().GetAlertRules(curveadm.ClusterId())
+	if err != nil {
+		return "", errno.ERR_GET_ALERT_RULES_FAILED.E(err)
+	} else if len(versions) == 0 {
+		return "", nil
+	}
+	_, err = configure.ParseAlertRules(versions[0].Rules)
+	if err != nil {
+		return "", err
+	}
+	return versions[0].Rules, nil
+}
@@ -143,21 +143,22 @@ func NewCreateContainerTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig
 		ExecOptions: options,
 	})
 	t.AddStep(&step.CreateContainer{
-		Image:       cfg.GetImage(),
-		Command:     getArguments(cfg),
-		AddHost:     []string{fmt.Sprintf("%s:127.0.0.1", hostname)},
-		Envs:        getEnvironments(cfg),
-		Hostname:    hostname,
-		Init:        true,
-		Name:        hostname,
-		Privileged:  true,
-		User:        "0:0",
-		Pid:         "host",
-		Restart:     common.POLICY_NEVER_RESTART,
-		Ulimits:     []string{"core=-1"},
-		Volumes:     getMountVolumes(cfg),
-		Out:         &containerId,
-		ExecOptions: curveadm.ExecOptions(),
+		Image:         cfg.GetImage(),
+		Command:       getArguments(cfg),
+		AddHost:       []string{fmt.Sprintf("%s:127.0.0.1", hostname)},
+		Envs:          getEnvironments(cfg),
+		Hostname:      hostname,
+		Init:          true,
+		Name:          hostname,
+		Privileged:    true,
+		User:          "0:0",
+		Pid:           "host",
+		Restart:       common.POLICY_NEVER_RESTART,
+		Ulimits:       []string{"core=-1"},
+		Volumes:       getMountVolumes(cfg),
+		IgnoreExisted: true, // the container may be created in last failed deploy
+		Out:           &containerId,
+		ExecOptions:   curveadm.ExecOptions(),
 	})
 	t.AddStep(&step.Lambda{
 		Lambda: common.TrimContainerId(&containerId),
@@ -34,6 +34,7 @@ import (
 	"github.com/opencurve/curveadm/internal/task/task"
 	"github.com/opencurve/curveadm/internal/task/task/common"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
 const (
@@ -44,16 +45,49 @@ const (
 	DASHBOARD_CONTAINER_PATH  = "/etc/grafana/provisioning/dashboards"
 	GRAFANA_DATA_SOURCE_PATH  = "/etc/grafana/provisioning/datasources/all.yml"
 	CURVE_MANAGER_CONF_PATH   = "/curve-manager/conf/pigeon.yaml"
+	PROMETHEUS_TLS_DIR_NAME   = "tls"
 )
 
 func getNodeExporterAddrs(hosts []string, port int) string {
 	endpoint := []string{}
 	for _, item := range hosts {
-		endpoint = append(endpoint, fmt.Sprintf("'%s:%d'", item, port))
+		endpoint = append(endpoint, fmt.Sprintf("'%s'", utils.JoinHostPort(item, port)))
 	}
 	return fmt.Sprintf("[%s]", strings.Join(endpoint, ","))
 }
 
+// install the client certificate of prometheus which referenced by scripts.PROMETHEUS_TLS_CONFIG
+func addSyncPrometheusCertSteps(t *task.Task, curveadm *cli.CurveAdm, containerId *string) error {
+	ca, client, err := common.GetPrometheusCertificate(curveadm)
+	if err != nil {
+		return err
+	}
+
+	t.AddStep(&step.CreateAndUploadDir{
+		HostDirName:       PROMETHEUS_TLS_DIR_NAME,
+		ContainerDestId:   containerId,
+		ContainerDestPath: PROMETHEUS_CONTAINER_PATH,
+		ExecOptions:       curveadm.ExecOptions(),
+	})
+	files := []struct {
+		content *string
+		name    string
+	}{
+		{&ca.Cert, "ca.crt"},
+		{&client.Cert, "client.crt"},
+		{&client.Key, "client.key"},
+	}
+	for _, file := range files {
+		t.AddStep(&step.InstallFile{
+			ContainerId:       containerId,
+			ContainerDestPath: path.Join(PROMETHEUS_CONTAINER_PATH, PROMETHEUS_TLS_DIR_NAME, file.name),
+			Content:           file.content,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	}
+	return nil
+}
+
 func NewSyncConfigTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*task.Task, error) {
 	serviceId := curveadm.GetServiceId(cfg.GetId())
 	containerId, err := curveadm.GetContainerId(serviceId)
@@ -92,7 +126,14 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*t
 			ContainerDestPath: "/etc",
 			ExecOptions:       curveadm.ExecOptions(),
 		})
-		content := fmt.Sprintf(scripts.PROMETHEUS_YML, cfg.GetListenPort(),
+		tlsConfig := ""
+		if cfg.GetPrometheusTLSEnable() {
+			tlsConfig = scripts.PROMETHEUS_TLS_CONFIG
+			if err := addSyncPrometheusCertSteps(t, curveadm, &containerId); err != nil {
+				return nil, err
+			}
+		}
+		content := fmt.Sprintf(scripts.PROMETHEUS_YML, cfg.GetListenPort(), tlsConfig,
 			getNodeExporterAddrs(cfg.GetNodeIps(), cfg.GetNodeListenPort()))
 		t.AddStep(&step.InstallFile{ // install prometheus.yml file
 			ContainerId:       &containerId,
@@ -107,6 +148,12 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*t
 			Content:           &target,
 			ExecOptions:       curveadm.ExecOptions(),
 		})
+		rules, err := latestAlertRules(curveadm)
+		if err != nil {
+			return nil, err
+		} else if len(rules) > 0 { // keep the applied alert rules after prometheus.yml re-installed
+			addCopyAlertRulesSteps(t, curveadm, &containerId, &rules)
+		}
 	} else if role == ROLE_GRAFANA {
 		serviceId = curveadm.GetServiceId(fmt.Sprintf("%s_%s", ROLE_MONITOR_CONF, cfg.GetHost()))
 		confContainerId, err := curveadm.GetContainerId(serviceId)
@@ -129,7 +176,7 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*t
 			IsDir:             true,
 			ExecOptions:       curveadm.ExecOptions(),
 		})
-		content := fmt.Sprintf(scripts.GRAFANA_DATA_SOURCE, cfg.GetPrometheusIp(), cfg.GetPrometheusListenPort())
+		content := fmt.Sprintf(scripts.GRAFANA_DATA_SOURCE, utils.JoinHostPort(cfg.GetPrometheusIp(), cfg.GetPrometheusListenPort()))
 		t.AddStep(&step.InstallFile{ // install grafana datasource file
 			ContainerId:       &containerId,
 			ContainerDestPath: GRAFANA_DATA_SOURCE_PATH,
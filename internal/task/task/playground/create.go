@@ -32,11 +32,18 @@ import (
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/opencurve/curveadm/pkg/module"
 )
 
 const (
 	FORMAT_MOUNT_OPTION = "type=bind,source=%s,target=%s,bind-propagation=rshared"
+
+	NODE_MODE_CONTROL = "control" // etcd, mds and one chunkserver
+	NODE_MODE_NODE    = "node"    // one chunkserver
+
+	// match the container of playground and its nodes' containers
+	FORMAT_PLAYGROUND_FILTER = "name='^/?%s(-node[0-9]+)?$'"
 )
 
 type (
@@ -92,6 +99,51 @@ func (s *step2InsertPlayGround) Execute(ctx *context.Context) error {
 	return nil
 }
 
+// each node runs in its own container with static ip in playground network
+func addCreateNodesSteps(t *task.Task, curveadm *cli.CurveAdm, cfg *configure.PlaygroundConfig) error {
+	controlIP, err := cfg.GetNodeIP(0)
+	if err != nil {
+		return err
+	}
+
+	var containerId string
+	t.AddStep(&step.CreateNetwork{
+		Name:        cfg.GetNetwork(),
+		Subnet:      cfg.GetSubnet(),
+		Out:         &containerId,
+		ExecOptions: execOptions(curveadm),
+	})
+	for i := 0; i < cfg.GetNodes(); i++ {
+		ip, err := cfg.GetNodeIP(i)
+		if err != nil {
+			return err
+		}
+		t.AddStep(&step.CreateContainer{
+			Image: cfg.GetContainIamge(),
+			Envs: []string{
+				"LD_PRELOAD=/usr/local/lib/libjemalloc.so",
+				"PLAYGROUND_CONTROL_IP=" + controlIP,
+				"PLAYGROUND_DISK_SIZE=" + cfg.GetDiskSize(),
+			},
+			Entrypoint:        "/bin/bash",
+			Command:           "/entrypoint.sh curvebs " + utils.Choose(i == 0, NODE_MODE_CONTROL, NODE_MODE_NODE),
+			Name:              cfg.GetNodeName(i), // playground-curvebs-1656035414-node0
+			Hostname:          cfg.GetNodeName(i),
+			Network:           cfg.GetNetwork(),
+			IP:                ip,
+			Volumes:           getMountVolumes(cfg.GetKind()),
+			Devices:           []string{"/dev/fuse"},
+			SecurityOptions:   []string{"apparmor:unconfined"},
+			LinuxCapabilities: []string{"SYS_ADMIN"},
+			Ulimits:           []string{"core=-1"},
+			Privileged:        true,
+			Out:               &containerId,
+			ExecOptions:       execOptions(curveadm),
+		})
+	}
+	return nil
+}
+
 func NewCreatePlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConfig) (*task.Task, error) {
 	kind := cfg.GetKind()
 	name := cfg.GetName()
@@ -100,6 +152,9 @@ func NewCreatePlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundCo
 
 	// new task
 	subname := fmt.Sprintf("kind=%s name=%s image=%s", kind, name, containerImage)
+	if cfg.IsMultiNode() {
+		subname = fmt.Sprintf("%s nodes=%d", subname, cfg.GetNodes())
+	}
 	t := task.NewTask("Create Playground", subname, nil)
 	var containerId string
 
@@ -111,23 +166,30 @@ func NewCreatePlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundCo
 		Image:       containerImage,
 		ExecOptions: execOptions(curveadm),
 	})
-	t.AddStep(&step.CreateContainer{
-		Image:             containerImage,
-		Envs:              []string{"LD_PRELOAD=/usr/local/lib/libjemalloc.so"},
-		Entrypoint:        "/bin/bash",
-		Command:           "/entrypoint.sh curvebs",
-		Name:              name, // playground-curvebs-1656035414
-		Network:           "bridge",
-		Mount:             getAttchMount(kind, mountPoint),
-		Volumes:           getMountVolumes(kind),
-		Devices:           []string{"/dev/fuse"},
-		SecurityOptions:   []string{"apparmor:unconfined"},
-		LinuxCapabilities: []string{"SYS_ADMIN"},
-		Ulimits:           []string{"core=-1"},
-		Privileged:        true,
-		Out:               &containerId,
-		ExecOptions:       execOptions(curveadm),
-	})
+	if cfg.IsMultiNode() {
+		err := addCreateNodesSteps(t, curveadm, cfg)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		t.AddStep(&step.CreateContainer{
+			Image:             containerImage,
+			Envs:              []string{"LD_PRELOAD=/usr/local/lib/libjemalloc.so"},
+			Entrypoint:        "/bin/bash",
+			Command:           "/entrypoint.sh curvebs",
+			Name:              name, // playground-curvebs-1656035414
+			Network:           "bridge",
+			Mount:             getAttchMount(kind, mountPoint),
+			Volumes:           getMountVolumes(kind),
+			Devices:           []string{"/dev/fuse"},
+			SecurityOptions:   []string{"apparmor:unconfined"},
+			LinuxCapabilities: []string{"SYS_ADMIN"},
+			Ulimits:           []string{"core=-1"},
+			Privileged:        true,
+			Out:               &containerId,
+			ExecOptions:       execOptions(curveadm),
+		})
+	}
 	t.AddStep(&step2InsertPlayGround{
 		curveadm: curveadm,
 		cfg:      cfg,
@@ -101,6 +101,33 @@ func prepare(dcs []*topology.DeployConfig, poolset configure.Poolset) (string, e
 	return string(bytes), err
 }
 
+// all services run in one container unless it's a multi-node playground
+func getContainers(cfg *configure.PlaygroundConfig) []string {
+	if !cfg.IsMultiNode() {
+		return []string{cfg.GetName()}
+	}
+
+	containers := []string{}
+	for i := 0; i < cfg.GetNodes(); i++ {
+		containers = append(containers, cfg.GetNodeName(i))
+	}
+	return containers
+}
+
+func getContainerDeployConfigs(cfg *configure.PlaygroundConfig, container string) []*topology.DeployConfig {
+	if !cfg.IsMultiNode() {
+		return cfg.GetDeployConfigs()
+	}
+
+	dcs := []*topology.DeployConfig{}
+	for _, dc := range cfg.GetDeployConfigs() {
+		if dc.GetHost() == container {
+			dcs = append(dcs, dc)
+		}
+	}
+	return dcs
+}
+
 func NewInitPlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConfig) (*task.Task, error) {
 	// new task
 	kind := cfg.GetKind()
@@ -109,7 +136,6 @@ func NewInitPlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConf
 	t := task.NewTask("Init Playground", subname, nil)
 
 	// add step to task
-	var containerId string
 	layout := topology.GetCurveBSProjectLayout()
 	poolJSONPath := path.Join(layout.ToolsConfDir, "topology.json")
 	poolset := curveadm.MemStorage().Get(comm.KEY_POOLSET).(configure.Poolset)
@@ -118,69 +144,73 @@ func NewInitPlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConf
 		return nil, err
 	}
 
-	t.AddStep(&step.ListContainers{ // gurantee container exist
-		ShowAll:     true,
-		Format:      `"{{.ID}}"`,
-		Filter:      fmt.Sprintf("name=%s", name),
-		Out:         &containerId,
-		ExecOptions: execOptions(curveadm),
-	})
-	t.AddStep(&step.Lambda{
-		Lambda: checkContainerExist(name, &containerId),
-	})
-	for _, dc := range cfg.GetDeployConfigs() {
-		delimiter := DEFAULT_CONFIG_DELIMITER
-		if dc.GetRole() == topology.ROLE_ETCD {
-			delimiter = ETCD_CONFIG_DELIMITER
+	for _, container := range getContainers(cfg) {
+		var containerId string
+		dcs := getContainerDeployConfigs(cfg, container)
+		t.AddStep(&step.ListContainers{ // gurantee container exist
+			ShowAll:     true,
+			Format:      `"{{.ID}}"`,
+			Filter:      fmt.Sprintf("name='^/?%s$'", container),
+			Out:         &containerId,
+			ExecOptions: execOptions(curveadm),
+		})
+		t.AddStep(&step.Lambda{
+			Lambda: checkContainerExist(container, &containerId),
+		})
+		for _, dc := range dcs {
+			delimiter := DEFAULT_CONFIG_DELIMITER
+			if dc.GetRole() == topology.ROLE_ETCD {
+				delimiter = ETCD_CONFIG_DELIMITER
+			}
+			for _, conf := range dc.GetProjectLayout().ServiceConfFiles {
+				t.AddStep(&step.SyncFile{ // sync service config
+					ContainerSrcId:    &containerId,
+					ContainerSrcPath:  conf.SourcePath,
+					ContainerDestId:   &containerId,
+					ContainerDestPath: conf.Path,
+					KVFieldSplit:      delimiter,
+					Mutate:            newMutate(dc, delimiter),
+					ExecOptions:       execOptions(curveadm),
+				})
+			}
+			t.AddStep(&step.SyncFile{ // sync tools config
+				ContainerSrcId:    &containerId,
+				ContainerSrcPath:  layout.ToolsConfSrcPath,
+				ContainerDestId:   &containerId,
+				ContainerDestPath: layout.ToolsConfSystemPath,
+				KVFieldSplit:      DEFAULT_CONFIG_DELIMITER,
+				Mutate:            newMutate(dc, DEFAULT_CONFIG_DELIMITER),
+				ExecOptions:       execOptions(curveadm),
+			})
 		}
-		for _, conf := range dc.GetProjectLayout().ServiceConfFiles {
+		t.AddStep(&step.InstallFile{ // install curvebs/curvefs topology
+			ContainerId:       &containerId,
+			ContainerDestPath: poolJSONPath,
+			Content:           &clusterPoolJson,
+			ExecOptions:       execOptions(curveadm),
+		})
+		for _, conf := range []topology.ConfFile{
+			{SourcePath: "/curvebs/conf/client.conf", Path: "/curvebs/nebd/conf/client.conf"},
+			{SourcePath: "/curvebs/conf/nebd-server.conf", Path: "/etc/nebd/nebd-server.conf"},
+			{SourcePath: "/curvebs/conf/nebd-client.conf", Path: "/etc/nebd/nebd-client.conf"},
+		} {
 			t.AddStep(&step.SyncFile{ // sync service config
 				ContainerSrcId:    &containerId,
 				ContainerSrcPath:  conf.SourcePath,
 				ContainerDestId:   &containerId,
 				ContainerDestPath: conf.Path,
-				KVFieldSplit:      delimiter,
-				Mutate:            newMutate(dc, delimiter),
+				KVFieldSplit:      DEFAULT_CONFIG_DELIMITER,
+				Mutate:            newMutate(cfg.GetClientConfig(), DEFAULT_CONFIG_DELIMITER),
 				ExecOptions:       execOptions(curveadm),
 			})
 		}
-		t.AddStep(&step.SyncFile{ // sync tools config
-			ContainerSrcId:    &containerId,
-			ContainerSrcPath:  layout.ToolsConfSrcPath,
-			ContainerDestId:   &containerId,
-			ContainerDestPath: layout.ToolsConfSystemPath,
-			KVFieldSplit:      DEFAULT_CONFIG_DELIMITER,
-			Mutate:            newMutate(dc, DEFAULT_CONFIG_DELIMITER),
-			ExecOptions:       execOptions(curveadm),
-		})
-	}
-	t.AddStep(&step.InstallFile{ // install curvebs/curvefs topology
-		ContainerId:       &containerId,
-		ContainerDestPath: poolJSONPath,
-		Content:           &clusterPoolJson,
-		ExecOptions:       execOptions(curveadm),
-	})
-	for _, conf := range []topology.ConfFile{
-		{SourcePath: "/curvebs/conf/client.conf", Path: "/curvebs/nebd/conf/client.conf"},
-		{SourcePath: "/curvebs/conf/nebd-server.conf", Path: "/etc/nebd/nebd-server.conf"},
-		{SourcePath: "/curvebs/conf/nebd-client.conf", Path: "/etc/nebd/nebd-client.conf"},
-	} {
-		t.AddStep(&step.SyncFile{ // sync service config
-			ContainerSrcId:    &containerId,
-			ContainerSrcPath:  conf.SourcePath,
-			ContainerDestId:   &containerId,
-			ContainerDestPath: conf.Path,
-			KVFieldSplit:      DEFAULT_CONFIG_DELIMITER,
-			Mutate:            newMutate(cfg.GetClientConfig(), DEFAULT_CONFIG_DELIMITER),
+		t.AddStep(&step.InstallFile{ // install entrypoint
+			ContainerId:       &containerId,
+			ContainerDestPath: "/entrypoint.sh",
+			Content:           &script.ENTRYPOINT,
 			ExecOptions:       execOptions(curveadm),
 		})
 	}
-	t.AddStep(&step.InstallFile{ // install entrypoint
-		ContainerId:       &containerId,
-		ContainerDestPath: "/entrypoint.sh",
-		Content:           &script.ENTRYPOINT,
-		ExecOptions:       execOptions(curveadm),
-	})
 
 	return t, nil
 }
@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package playground
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/task/playground/script"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiNodeDeployConfigs(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &configure.PlaygroundConfig{Name: "playground-curvebs-1", Nodes: 4}
+	ctx := topology.NewContext()
+	nodes := getContainers(cfg)
+	for i, node := range nodes {
+		ip, err := cfg.GetNodeIP(i)
+		assert.Nil(err)
+		ctx.Add(node, ip)
+	}
+	data, err := script.NodesTopology(nodes)
+	assert.Nil(err)
+	cfg.DeployConfigs, err = topology.ParseTopology(data, ctx)
+	assert.Nil(err)
+	assert.Len(cfg.DeployConfigs, 10)
+
+	// control node: 3 etcd, 3 mds and 1 chunkserver
+	dcs := getContainerDeployConfigs(cfg, "playground-curvebs-1-node0")
+	assert.Len(dcs, 7)
+	for _, dc := range dcs {
+		assert.Equal("172.30.0.2", dc.GetListenIp())
+	}
+
+	dcs = getContainerDeployConfigs(cfg, "playground-curvebs-1-node3")
+	assert.Len(dcs, 1)
+	assert.Equal(topology.ROLE_CHUNKSERVER, dcs[0].GetRole())
+	assert.Equal("172.30.0.5", dcs[0].GetListenIp())
+	assert.Equal(8200, dcs[0].GetListenPort())
+}
+
+func TestFormatNodesStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	status := formatNodesStatus([]string{"Up 2 minutes", "Up 2 minutes", "Exited (1) 1 minute ago"})
+	assert.Equal("Up 2 minutes (2/3 nodes up)", status)
+}
@@ -24,6 +24,7 @@ package playground
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -62,10 +63,23 @@ func setPlaygroundStatus(memStorage *utils.SafeMap, id string, status Playground
 	})
 }
 
+// e.g: Up 2 minutes (3/3 nodes up)
+func formatNodesStatus(statuses []string) string {
+	up := 0
+	for _, status := range statuses {
+		if strings.HasPrefix(status, "Up") {
+			up++
+		}
+	}
+	return fmt.Sprintf("%s (%d/%d nodes up)", statuses[0], up, len(statuses))
+}
+
 func (s *step2FormatPlaygroundStatus) Execute(ctx *context.Context) error {
 	status := *s.status
 	if len(status) == 0 { // container losed
 		status = comm.PLAYGROUDN_STATUS_LOSED
+	} else if statuses := strings.Split(status, "\n"); len(statuses) > 1 { // multi-node
+		status = formatNodesStatus(statuses)
 	}
 
 	playground := s.playground
@@ -90,7 +104,7 @@ func NewGetPlaygroundStatusTask(curveadm *cli.CurveAdm, v interface{}) (*task.Ta
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
 		Format:      "'{{.Status}}'",
-		Filter:      fmt.Sprintf("name=%s", playground.Name),
+		Filter:      fmt.Sprintf(FORMAT_PLAYGROUND_FILTER, playground.Name),
 		Out:         &status,
 		ExecOptions: execOptions(curveadm),
 	})
@@ -24,6 +24,7 @@ package playground
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/errno"
@@ -47,21 +48,29 @@ type (
 )
 
 func (s *step2RemoveContainer) Execute(ctx *context.Context) error {
-	containerId := *s.containerId
+	containerIds := strings.Fields(*s.containerId)
 	playground := s.plaground
-	if len(containerId) == 0 {
+	if len(containerIds) == 0 {
 		return nil
 	}
 
 	steps := []task.Step{}
-	steps = append(steps, &step.StopContainer{
-		ContainerId: playground.Name,
-		ExecOptions: execOptions(s.curveadm),
-	})
-	steps = append(steps, &step.RemoveContainer{
-		ContainerId: playground.Name,
-		ExecOptions: execOptions(s.curveadm),
-	})
+	for _, containerId := range containerIds {
+		steps = append(steps, &step.StopContainer{
+			ContainerId: containerId,
+			ExecOptions: execOptions(s.curveadm),
+		})
+		steps = append(steps, &step.RemoveContainer{
+			ContainerId: containerId,
+			ExecOptions: execOptions(s.curveadm),
+		})
+	}
+	if len(containerIds) > 1 { // multi-node playground
+		steps = append(steps, &step.RemoveNetwork{
+			Name:        playground.Name,
+			ExecOptions: execOptions(s.curveadm),
+		})
+	}
 	/*
 		mountPoint := playground.MountPoint
 		if len(playground.MountPoint) > 0 {
@@ -102,7 +111,7 @@ func NewRemovePlaygroundTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task,
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
 		Format:      "'{{.ID}}'",
-		Filter:      fmt.Sprintf("name=%s", playground.Name),
+		Filter:      fmt.Sprintf(FORMAT_PLAYGROUND_FILTER, playground.Name),
 		Out:         &containerId,
 		ExecOptions: execOptions(curveadm),
 	})
@@ -4,12 +4,17 @@ set -e
 set -o pipefail
 
 g_kind="$1"
+g_mode="$2"  # empty for standalone, control or node for multi-node playground
 g_prefix="/${g_kind}/playground"
 g_roles=("etcd" "mds" "chunkserver")
 [ "${g_kind}" = "curvefs" ] && { g_roles=("etcd" "mds" "metaserver"); }
 g_user="playground"
 g_volume="/playground"
 g_topology="/curvebs/tools/conf/topology.json"
+g_node_ip="127.0.0.1"
+[ -n "${g_mode}" ] && { g_node_ip="$(hostname -i | awk '{print $1}')"; }
+g_disk_image="/playground-disk.img"
+g_disk_size="${PLAYGROUND_DISK_SIZE:-10G}"
 
 function start_service() {
     local role=$1
@@ -40,7 +45,7 @@ function start_service() {
                 -raftSnapshotUri=curve://"${data_dir}"/copysets \
                 -raft_sync_segments=true \
                 -raft_max_install_snapshot_tasks_num=1 \
-                -chunkServerIp=127.0.0.1 \
+                -chunkServerIp="${g_node_ip}" \
                 -chunkFilePoolDir="${data_dir}" \
                 -walFilePoolDir="${data_dir}" \
                 -raft_sync=true \
@@ -51,7 +56,7 @@ function start_service() {
                 -chunkServerMetaUri=local://"${data_dir}"/chunkserver.dat \
                 -bthread_concurrency=18 \
                 -raft_sync_meta=true \
-                -chunkServerExternalIp=127.0.0.1 \
+                -chunkServerExternalIp="${g_node_ip}" \
                 -chunkServerPort=820${sequence} \
                 -walFilePoolMetaPath="${data_dir}"/walfilepool.meta \
                 -recycleUri=local://"${data_dir}"/recycler \
@@ -83,6 +88,24 @@ function start_chunkserver() {
     done
 }
 
+# each node has its own pre-formatted loopback disk for chunkserver
+function prepare_disk() {
+    local data_dir="${g_prefix}"/chunkserver0/data
+    mkdir -p "${data_dir}"
+    mountpoint -q "${data_dir}" && return 0
+    if [ ! -f "${g_disk_image}" ]; then
+        truncate -s "${g_disk_size}" "${g_disk_image}"
+        mkfs.ext4 -q -F "${g_disk_image}"
+    fi
+    mount -o loop "${g_disk_image}" "${data_dir}"
+}
+
+function wait_mds() {
+    until (echo > /dev/tcp/"${PLAYGROUND_CONTROL_IP}"/6700) >/dev/null 2>&1; do
+        sleep 1
+    done
+}
+
 function create_physicalpool() {
     /curvebs/tools/sbin/curvebs-tool -op=create_physicalpool -cluster_map="${g_topology}"
 }
@@ -117,7 +140,12 @@ function start_curvebs() {
     start_mds
     sleep 3
     create_physicalpool
-    start_chunkserver
+    if [ "${g_mode}" = "control" ]; then
+        prepare_disk
+        start_service chunkserver 0
+    else
+        start_chunkserver
+    fi
     sleep 25
     create_logicalpool
     sleep 25
@@ -126,8 +154,20 @@ function start_curvebs() {
     map_volume
 }
 
+# node of multi-node playground only runs one chunkserver,
+# which registers to mds after physical pool created by control node
+function start_node() {
+    prepare_disk
+    wait_mds
+    sleep 10
+    start_service chunkserver 0
+    wait -n
+}
+
 function main() {
-    if [ "${1}" = "curvebs" ]; then
+    if [ "${1}" = "curvebs" ] && [ "${g_mode}" = "node" ]; then
+        start_node
+    elif [ "${1}" = "curvebs" ]; then
         start_curvebs
     else
         echo "unsupport kind: ${1}"
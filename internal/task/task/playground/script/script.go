@@ -23,16 +23,42 @@
 package script
 
 import (
+	"bytes"
 	_ "embed"
+	"text/template"
+
+	"github.com/opencurve/curveadm/internal/errno"
 )
 
 var (
 	//go:embed topology.yaml
 	TOPOLOGY string
 
+	//go:embed topology_nodes.yaml
+	TOPOLOGY_NODES string
+
 	//go:embed client.yaml
 	CLIENT string
 
 	//go:embed entrypoint.sh
 	ENTRYPOINT string
 )
+
+// NodesTopology renders the topology of multi-node playground: etcd and mds
+// run on the first node (control), and each node runs one chunkserver.
+func NodesTopology(nodes []string) (string, error) {
+	tmpl, err := template.New("topology").Option("missingkey=error").Parse(TOPOLOGY_NODES)
+	if err != nil {
+		return "", errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+
+	buffer := bytes.NewBufferString("")
+	err = tmpl.Execute(buffer, map[string]interface{}{
+		"Control": nodes[0],
+		"Nodes":   nodes,
+	})
+	if err != nil {
+		return "", errno.ERR_RENDER_TEMPLATE_FAILED.E(err)
+	}
+	return buffer.String(), nil
+}
@@ -46,11 +46,13 @@ func NewStartPlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundCon
 	t := task.NewTask("Start Playground", subname, nil)
 
 	// add step to task
-	containerId := cfg.GetName()
-	t.AddStep(&step.StartContainer{
-		ContainerId: &containerId,
-		ExecOptions: execOptions(curveadm),
-	})
+	for _, container := range getContainers(cfg) { // control node first
+		containerId := container
+		t.AddStep(&step.StartContainer{
+			ContainerId: &containerId,
+			ExecOptions: execOptions(curveadm),
+		})
+	}
 	t.AddStep(&step.Lambda{
 		Lambda: wait(60),
 	})
@@ -25,12 +25,17 @@
 package task
 
 import (
+	stdctx "context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/opencurve/curveadm/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
@@ -52,6 +57,7 @@ type (
 		postSteps []Step
 		sshConfig *module.SSHConfig
 		context   context.Context
+		progress  *context.Progress
 	}
 )
 
@@ -63,6 +69,7 @@ func NewTask(name, subname string, sshConfig *module.SSHConfig) *Task {
 		name:      name,
 		subname:   subname,
 		sshConfig: sshConfig,
+		progress:  context.NewProgress(),
 	}
 }
 
@@ -82,6 +89,19 @@ func (t *Task) Subname() string {
 	return t.subname
 }
 
+// Host returns the host which task executed in, empty for local task
+func (t *Task) Host() string {
+	if t.sshConfig == nil {
+		return ""
+	}
+	return t.sshConfig.Host
+}
+
+// Progress returns the progress message reported by the executing step
+func (t *Task) Progress() string {
+	return t.progress.Get()
+}
+
 func (t *Task) SetTid(tid string) {
 	t.tid = tid
 }
@@ -98,6 +118,11 @@ func (t *Task) AddStep(step Step) {
 	t.steps = append(t.steps, step)
 }
 
+// PrependStep inserts step before all existing steps
+func (t *Task) PrependStep(step Step) {
+	t.steps = append([]Step{step}, t.steps...)
+}
+
 func (t *Task) AddPostStep(step Step) {
 	t.postSteps = append(t.postSteps, step)
 }
@@ -112,24 +137,75 @@ func (t *Task) executePost(ctx *context.Context) {
 }
 
 func (t *Task) Execute() error {
-	var sshClient *module.SSHClient
+	return t.ExecuteContext(stdctx.Background())
+}
+
+// step type without package, e.g: StartContainer
+func stepType(step Step) string {
+	name := fmt.Sprintf("%T", step)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func (t *Task) executeStep(traceCtx stdctx.Context, ctx *context.Context, step Step) error {
+	typ := stepType(step)
+	_, span := tracing.Start(traceCtx, typ, attribute.String(tracing.ATTR_STEP_TYPE, typ))
+	err := step.Execute(ctx)
+	if err == ERR_TASK_DONE || err == ERR_SKIP_TASK {
+		tracing.End(span, nil)
+	} else {
+		tracing.End(span, err)
+	}
+	return t.located(err, typ)
+}
+
+// located records the host and step where the error code occurred
+func (t *Task) located(err error, step string) error {
+	ec, ok := err.(*errno.ErrorCode)
+	if !ok || ec.GetCode() == errno.CODE_CANCEL_OPERATION {
+		return err
+	}
+	return ec.At(t.Host(), fmt.Sprintf("%s (%s)", t.name, step))
+}
+
+// ExecuteContext executes task with the span in traceCtx as its parent span
+func (t *Task) ExecuteContext(traceCtx stdctx.Context) (err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String(tracing.ATTR_TASK_NAME, t.name),
+		attribute.String(tracing.ATTR_TASK_ID, t.tid),
+		attribute.String(tracing.ATTR_SUBNAME, t.subname),
+	}
+	if t.sshConfig != nil {
+		attrs = append(attrs, attribute.String(tracing.ATTR_HOST, t.sshConfig.Host))
+	}
+	traceCtx, span := tracing.Start(traceCtx, t.name, attrs...)
+	defer func() {
+		if err == ERR_SKIP_TASK {
+			tracing.End(span, nil)
+		} else {
+			tracing.End(span, err)
+		}
+	}()
+
+	var transport module.Transport
 	if t.sshConfig != nil {
-		client, err := module.NewSSHClient(*t.sshConfig)
+		tp, err := module.NewTransport(*t.sshConfig)
 		if err != nil {
-			return errno.ERR_SSH_CONNECT_FAILED.E(err)
+			return t.located(errno.ERR_SSH_CONNECT_FAILED.E(err), "Connect")
 		}
-		sshClient = client
+		transport = tp
 	}
 
-	ctx, err := context.NewContext(sshClient)
+	ctx, err := context.NewContext(transport)
 	if err != nil {
 		return err
 	}
 	defer ctx.Close()
 	defer t.executePost(ctx)
+	defer t.progress.Set("")
+	ctx.BindProgress(t.progress)
 
 	for _, step := range t.steps {
-		err := step.Execute(ctx)
+		err := t.executeStep(traceCtx, ctx, step)
 		if err == ERR_TASK_DONE {
 			break
 		} else if err != nil {
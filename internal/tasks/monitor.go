@@ -27,6 +27,7 @@ package tasks
 import (
 	"sync"
 
+	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/task/task"
 )
 
@@ -75,9 +76,17 @@ func (m *monitor) set(bid int, err error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 	m.result[bid] = append(m.result[bid], err)
-	if err != nil && err != task.ERR_SKIP_TASK {
-		m.err = err
+	if err == nil || err == task.ERR_SKIP_TASK {
+		return
 	}
+
+	// the same failure on different hosts is reported once with all hosts
+	last, ok1 := m.err.(*errno.ErrorCode)
+	ec, ok2 := err.(*errno.ErrorCode)
+	if ok1 && ok2 && last.Group(ec) {
+		return
+	}
+	m.err = err
 }
 
 func (m *monitor) get(bid int) int {
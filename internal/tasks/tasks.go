@@ -25,7 +25,10 @@
 package tasks
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"sync"
 
@@ -34,14 +37,20 @@ import (
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/vbauerster/mpb/v7"
 	"github.com/vbauerster/mpb/v7/decor"
+	"golang.org/x/term"
+)
+
+const (
+	NON_TERMINAL_WIDTH = 120
 )
 
 type (
 	ExecOptions struct {
-		Concurrency   uint
-		SilentMainBar bool
-		SilentSubBar  bool
-		SkipError     bool
+		Concurrency     uint
+		HostConcurrency uint // max running tasks in one host, 0 means unlimited
+		SilentMainBar   bool
+		SilentSubBar    bool
+		SkipError       bool
 	}
 
 	Tasks struct {
@@ -55,13 +64,22 @@ type (
 	}
 )
 
-func NewTasks() *Tasks {
+func newProgress(wg *sync.WaitGroup, out io.Writer) *mpb.Progress {
+	options := []mpb.ContainerOption{mpb.WithWaitGroup(wg), mpb.WithOutput(out)}
+	// render bars only once after finished if output is not a terminal, e.g: stream of API
+	if f, ok := out.(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+		options = append(options, mpb.WithWidth(NON_TERMINAL_WIDTH), mpb.WithManualRefresh(make(chan interface{})))
+	}
+	return mpb.New(options...)
+}
+
+func NewTasks(out io.Writer) *Tasks {
 	wg := sync.WaitGroup{}
 	return &Tasks{
 		tasks:    []*task.Task{},
 		monitor:  newMonitor(),
 		wg:       wg,
-		progress: mpb.New(mpb.WithWaitGroup(&wg)),
+		progress: newProgress(&wg, out),
 		mainBar:  nil,
 		subBar:   map[string]*mpb.Bar{},
 	}
@@ -71,6 +89,19 @@ func (ts *Tasks) AddTask(t ...*task.Task) {
 	ts.tasks = append(ts.tasks, t...)
 }
 
+// Name returns the name of tasks, which is same with its first task
+func (ts *Tasks) Name() string {
+	if len(ts.tasks) == 0 {
+		return ""
+	}
+	return ts.tasks[0].Name()
+}
+
+// Count returns the number of tasks
+func (ts *Tasks) Count() int {
+	return len(ts.tasks)
+}
+
 func (ts *Tasks) CountPtid(ptid string) int64 {
 	var sum int64 = 0
 	for _, t := range ts.tasks {
@@ -131,6 +162,23 @@ func (ts *Tasks) displayInstances(t *task.Task) func(static decor.Statistics) st
 	}
 }
 
+// display the progress of running task which belong to the sub bar
+func (ts *Tasks) displayProgress(t *task.Task) func(static decor.Statistics) string {
+	return func(static decor.Statistics) string {
+		if static.Completed {
+			return ""
+		}
+		for _, task := range ts.tasks {
+			if task.Ptid() != t.Ptid() {
+				continue
+			} else if progress := task.Progress(); len(progress) > 0 {
+				return " " + progress
+			}
+		}
+		return ""
+	}
+}
+
 func (ts *Tasks) addMainBar() {
 	ts.mainBar = ts.progress.Add(1, nil,
 		mpb.PrependDecorators(
@@ -155,6 +203,7 @@ func (ts *Tasks) addSubBar(t *task.Task) {
 			decor.Name(" "),
 			decor.OnComplete(decor.Spinner([]string{}), ""),
 			decor.Any(ts.displayStatus()),
+			decor.Any(ts.displayProgress(t)),
 		),
 	)
 }
@@ -172,6 +221,20 @@ func (ts *Tasks) initOptions(options ExecOptions) ExecOptions {
 	return options
 }
 
+func (ts *Tasks) hostWorkers(options ExecOptions) map[string]chan struct{} {
+	if options.HostConcurrency == 0 {
+		return nil
+	}
+
+	workers := map[string]chan struct{}{}
+	for _, t := range ts.tasks {
+		if _, ok := workers[t.Host()]; !ok {
+			workers[t.Host()] = make(chan struct{}, options.HostConcurrency)
+		}
+	}
+	return workers
+}
+
 func (ts *Tasks) setMainBarStatus() {
 	ts.Lock()
 	defer ts.Unlock()
@@ -205,6 +268,11 @@ func (ts *Tasks) setMainBarStatus() {
  *   + host=10.0.0.3  image=opencurvedocker/curvefs [1/10] [OK]
  */
 func (ts *Tasks) Execute(options ExecOptions) error {
+	return ts.ExecuteContext(context.Background(), options)
+}
+
+// ExecuteContext executes tasks with the span in traceCtx as parent span of each task
+func (ts *Tasks) ExecuteContext(traceCtx context.Context, options ExecOptions) error {
 	if len(ts.tasks) == 0 {
 		return nil
 	}
@@ -212,6 +280,7 @@ func (ts *Tasks) Execute(options ExecOptions) error {
 	ts.prettySubname()
 	options = ts.initOptions(options)
 	workers := make(chan struct{}, options.Concurrency)
+	hostWorkers := ts.hostWorkers(options)
 	if !options.SilentMainBar {
 		ts.addMainBar()
 	}
@@ -224,19 +293,30 @@ func (ts *Tasks) Execute(options ExecOptions) error {
 		// 	break
 		// }
 		ts.wg.Add(1)
-		workers <- struct{}{}
+		if hostWorkers == nil {
+			workers <- struct{}{}
+		}
 		if !options.SilentSubBar {
 			ts.addSubBar(t)
 		}
 
 		// worker
 		go func(t *task.Task) {
+			// wait the host has free slot first, so tasks of other hosts
+			// won't be blocked by the busy host
+			if hostWorkers != nil {
+				hostWorkers[t.Host()] <- struct{}{}
+				workers <- struct{}{}
+			}
 			bar := ts.getSubBar(t)
 			defer func() {
 				if bar != nil {
 					bar.IncrBy(1)
 				}
 				<-workers
+				if hostWorkers != nil {
+					<-hostWorkers[t.Host()]
+				}
 				ts.wg.Done()
 			}()
 
@@ -245,7 +325,7 @@ func (ts *Tasks) Execute(options ExecOptions) error {
 			if bar != nil {
 				id = bar.ID()
 			}
-			err := t.Execute()
+			err := t.ExecuteContext(traceCtx)
 			ts.monitor.set(id, err)
 		}(t)
 	}
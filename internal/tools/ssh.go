@@ -34,17 +34,40 @@ import (
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
 )
 
 const (
 	TEMPLATE_SCP                             = `scp -P {{.port}} {{or .options ""}} {{.source}} {{.user}}@{{.host}}:{{.target}}`
 	TEMPLATE_SSH_COMMAND                     = `ssh {{.user}}@{{.host}} -p {{.port}} {{or .options ""}} {{or .become ""}} {{.command}}`
 	TEMPLATE_SSH_ATTACH                      = `ssh -tt {{.user}}@{{.host}} -p {{.port}} {{or .options ""}} {{or .become ""}} {{.command}}`
+	TEMPLATE_LOCAL_COMMAND                   = `{{or .become ""}} {{.command}}`
 	TEMPLATE_COMMAND_EXEC_CONTAINER          = `{{.sudo}} {{.engine}} exec -it {{.container_id}} /bin/bash -c "cd {{.home_dir}}; /bin/bash"`
 	TEMPLATE_LOCAL_EXEC_CONTAINER            = `{{.engine}} exec -it {{.container_id}} /bin/bash` // FIXME: merge it
 	TEMPLATE_COMMAND_EXEC_CONTAINER_NOATTACH = `{{.sudo}} {{.engine}} exec -t {{.container_id}} /bin/bash -c "{{.command}}"`
 )
 
+// container engine of host, which is decided by remote shell if it is "auto"
+func hostEngine(curveadm *cli.CurveAdm, host string) string {
+	hc, err := curveadm.GetHost(host)
+	if err != nil {
+		return curveadm.Config().GetEngine()
+	}
+
+	switch engine := hc.GetContainerEngine(); engine {
+	case "":
+		return curveadm.Config().GetEngine()
+	case module.ENGINE_AUTO:
+		detect := []string{}
+		for _, engine := range module.CONTAINER_ENGINES {
+			detect = append(detect, "command -v "+engine)
+		}
+		return fmt.Sprintf("$(%s)", strings.Join(detect, " || "))
+	default:
+		return engine
+	}
+}
+
 func prepareOptions(curveadm *cli.CurveAdm, host string, become bool, extra map[string]interface{}) (map[string]interface{}, error) {
 	options := map[string]interface{}{}
 	hc, err := curveadm.GetHost(host)
@@ -53,6 +76,9 @@ func prepareOptions(curveadm *cli.CurveAdm, host string, become bool, extra map[
 	}
 
 	config := hc.GetSSHConfig()
+	if config.Transport == module.TRANSPORT_LOCAL { // run by local shell instead of ssh
+		options["local"] = true
+	}
 	options["user"] = config.User
 	options["host"] = config.Host
 	options["port"] = config.Port
@@ -63,6 +89,25 @@ func prepareOptions(curveadm *cli.CurveAdm, host string, become bool, extra map[
 	}
 	if !config.ForwardAgent {
 		opts = append(opts, fmt.Sprintf("-i %s", config.PrivateKeyPath))
+	} else if len(config.IdentityAgent) > 0 {
+		opts = append(opts, fmt.Sprintf("-o IdentityAgent=%s", config.IdentityAgent))
+	}
+	if len(config.CertificatePath) > 0 {
+		opts = append(opts, fmt.Sprintf("-o CertificateFile=%s", config.CertificatePath))
+	}
+	if len(config.ProxyJump) > 0 {
+		jumps, err := module.ParseProxyJump(config.ProxyJump, config.User)
+		if err != nil {
+			return nil, err
+		}
+		hops := []string{}
+		for _, jump := range jumps {
+			hops = append(hops, jump.String())
+		}
+		opts = append(opts, fmt.Sprintf("-J %s", strings.Join(hops, ",")))
+	} else if len(config.ProxyCommand) > 0 {
+		// proxy command contains spaces, so it can't be rendered into template
+		options["proxy_command"] = fmt.Sprintf("ProxyCommand=%s", config.ProxyCommand)
 	}
 	if len(config.BecomeUser) > 0 && become {
 		options["become"] = fmt.Sprintf("%s %s %s",
@@ -85,11 +130,35 @@ func newCommand(curveadm *cli.CurveAdm, text string, options map[string]interfac
 	}
 	command := buffer.String()
 	items := strings.Split(command, " ")
+	if v, ok := options["proxy_command"]; ok {
+		items = append([]string{items[0], "-o", v.(string)}, items[1:]...)
+	}
 	return exec.Command(items[0], items[1:]...), nil
 }
 
+// the command will be executed by "bash -c", so that it needn't split by space
+func newLocalCommand(curveadm *cli.CurveAdm, options map[string]interface{}) (*exec.Cmd, error) {
+	tmpl := template.Must(template.New("local").Parse(TEMPLATE_LOCAL_COMMAND))
+	buffer := bytes.NewBufferString("")
+	if err := tmpl.Execute(buffer, options); err != nil {
+		return nil, errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+	return exec.Command("bash", "-c", strings.TrimSpace(buffer.String())), nil
+}
+
+func isLocal(options map[string]interface{}) bool {
+	local, ok := options["local"].(bool)
+	return ok && local
+}
+
 func runCommand(curveadm *cli.CurveAdm, text string, options map[string]interface{}) error {
-	cmd, err := newCommand(curveadm, text, options)
+	var cmd *exec.Cmd
+	var err error
+	if isLocal(options) {
+		cmd, err = newLocalCommand(curveadm, options)
+	} else {
+		cmd, err = newCommand(curveadm, text, options)
+	}
 	if err != nil {
 		return err
 	}
@@ -100,7 +169,13 @@ func runCommand(curveadm *cli.CurveAdm, text string, options map[string]interfac
 }
 
 func runCommandOutput(curveadm *cli.CurveAdm, text string, options map[string]interface{}) (string, error) {
-	cmd, err := newCommand(curveadm, text, options)
+	var cmd *exec.Cmd
+	var err error
+	if isLocal(options) {
+		cmd, err = newLocalCommand(curveadm, options)
+	} else {
+		cmd, err = newCommand(curveadm, text, options)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -118,6 +193,9 @@ func ssh(curveadm *cli.CurveAdm, options map[string]interface{}) error {
 
 func scp(curveadm *cli.CurveAdm, options map[string]interface{}) error {
 	// TODO: added error code
+	if isLocal(options) {
+		return exec.Command("cp", "-r", options["source"].(string), options["target"].(string)).Run()
+	}
 	_, err := runCommandOutput(curveadm, TEMPLATE_SCP, options)
 	return err
 }
@@ -138,7 +216,7 @@ func AttachRemoteHost(curveadm *cli.CurveAdm, host string, become bool) error {
 func AttachRemoteContainer(curveadm *cli.CurveAdm, host, containerId, home string) error {
 	data := map[string]interface{}{
 		"sudo":         curveadm.Config().GetSudoAlias(),
-		"engine":       curveadm.Config().GetEngine(),
+		"engine":       hostEngine(curveadm, host),
 		"container_id": containerId,
 		"home_dir":     home,
 	}
@@ -174,7 +252,7 @@ func AttachLocalContainer(curveadm *cli.CurveAdm, containerId string) error {
 func ExecCmdInRemoteContainer(curveadm *cli.CurveAdm, host, containerId, cmd string) error {
 	data := map[string]interface{}{
 		"sudo":         curveadm.Config().GetSudoAlias(),
-		"engine":       curveadm.Config().GetEngine(),
+		"engine":       hostEngine(curveadm, host),
 		"container_id": containerId,
 		"command":      cmd,
 	}
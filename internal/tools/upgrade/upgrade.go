@@ -25,6 +25,7 @@
 package upgrade
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/verify"
 )
 
 const (
@@ -91,7 +93,7 @@ func GetLatestVersion(currentVersion string) (string, error) {
 	return v[0], nil
 }
 
-func Upgrade2Latest(currentVersion string) error {
+func Upgrade2Latest(currentVersion string, verifier *verify.Verifier) error {
 	version, err := GetLatestVersion(currentVersion)
 	if err != nil {
 		return err
@@ -102,18 +104,20 @@ func Upgrade2Latest(currentVersion string) error {
 		return nil
 	}
 
-	cmd := exec.Command("/bin/bash", "-c", fmt.Sprintf("curl -fsSL %s | bash", URL_INSTALL_SCRIPT))
-	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=true", ENV_CURVEADM_UPGRADE))
-	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", ENV_CURVEADM_VERSION, version))
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
-	return cmd.Run()
+	return Upgrade(version, verifier)
 }
 
-func Upgrade(version string) error {
-	cmd := exec.Command("/bin/bash", "-c", fmt.Sprintf("curl -fsSL %s | bash", URL_INSTALL_SCRIPT))
+// Upgrade runs the install script only if it matches the published checksum
+func Upgrade(version string, verifier *verify.Verifier) error {
+	script, err := verifier.Download(URL_INSTALL_SCRIPT)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("/bin/bash", "-s")
 	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=true", ENV_CURVEADM_UPGRADE))
 	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", ENV_CURVEADM_VERSION, version))
+	cmd.Stdin = bytes.NewReader(script)
 	cmd.Stderr = os.Stderr
 	cmd.Stdout = os.Stdout
 	return cmd.Run()
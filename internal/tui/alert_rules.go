@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/storage"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func activeDecorate(message string) string {
+	if message == "*" {
+		return color.GreenString(message)
+	}
+	return message
+}
+
+// the first version is the latest one, which is the active version
+func FormatAlertRules(versions []storage.AlertRules) string {
+	lines := [][]interface{}{}
+	title := []string{"Version", "Apply Time", "Groups", "Rules", "Active"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for i, version := range versions {
+		names, rules := []string{}, 0
+		groups, err := configure.ParseAlertRules(version.Rules)
+		if err == nil {
+			for _, group := range groups {
+				names = append(names, group.Name)
+				rules += group.Rules
+			}
+		}
+
+		active := "-"
+		if i == 0 {
+			active = "*"
+		}
+		lines = append(lines, []interface{}{
+			strconv.Itoa(version.Id),
+			version.CreateTime.Format("2006-01-02 15:04:05"),
+			strings.Join(names, ","),
+			strconv.Itoa(rules),
+			tuicommon.DecorateMessage{Message: active, Decorate: activeDecorate},
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -57,8 +57,9 @@ func statusDecorate(message string) string {
 
 func FormatAuditLogs(auditLogs []storage.AuditLog, verbose bool) string {
 	lines := [][]interface{}{}
-	title := []string{"Id", "Status", "Execute Time", "Command"}
+	title := []string{"Id", "Status", "Execute Time", "Operator", "Cluster", "Command"}
 	if verbose {
+		title = append(title, "Source")
 		title = append(title, "Work Directory")
 		title = append(title, "Error Code")
 	}
@@ -80,10 +81,16 @@ func FormatAuditLogs(auditLogs []storage.AuditLog, verbose bool) string {
 		line = append(line, tuicommon.DecorateMessage{Message: status, Decorate: statusDecorate})
 		// execute time
 		line = append(line, auditLog.ExecuteTime.Format("2006-01-02 15:04:05"))
+		// operator
+		line = append(line, utils.Choose(len(auditLog.Operator) > 0, auditLog.Operator, "-"))
+		// cluster
+		line = append(line, utils.Choose(len(auditLog.Cluster) > 0, auditLog.Cluster, "-"))
 		// command
 		line = append(line, auditLog.Command)
 
 		if verbose {
+			// source
+			line = append(line, utils.Choose(len(auditLog.Source) > 0, auditLog.Source, "-"))
 			// work directory
 			line = append(line, auditLog.WorkDirectory)
 			// error code
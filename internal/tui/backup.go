@@ -0,0 +1,108 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/backup"
+	"github.com/opencurve/curveadm/internal/storage"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+func backupStatusDecorate(status string) string {
+	if status == backup.BACKUP_STATUS_SUCCESS {
+		return color.GreenString(status)
+	}
+	return color.RedString(status)
+}
+
+func FormatBackupSchedules(schedules []storage.BackupSchedule, defaultLocation string) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Id",
+		"Target",
+		"Cron",
+		"Location",
+		"Keep",
+		"Last Run",
+		"Status",
+		"Next Run",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	now := time.Now()
+	for _, schedule := range schedules {
+		status, lastRun, nextRun := interface{}("-"), "-", "-"
+		if len(schedule.LastStatus) > 0 {
+			status = tuicommon.DecorateMessage{Message: schedule.LastStatus, Decorate: backupStatusDecorate}
+			lastRun = schedule.LastRunTime.Format("2006-01-02 15:04:05")
+		}
+		if s, err := backup.ParseSchedule(schedule.Cron); err == nil {
+			next := s.Next(schedule.LastRunTime)
+			if !next.IsZero() {
+				// the missed run is executed at next round
+				nextRun = utils.Choose(next.Before(now), "now", next.Format("2006-01-02 15:04"))
+			}
+		}
+		lines = append(lines, []interface{}{
+			strconv.Itoa(schedule.Id),
+			schedule.Target,
+			schedule.Cron,
+			utils.Choose(len(schedule.Location) > 0, schedule.Location, defaultLocation),
+			utils.Choose(schedule.Keep > 0, strconv.Itoa(schedule.Keep), "all"),
+			lastRun,
+			status,
+			nextRun,
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+func FormatBackups(backups []backup.Backup) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Target",
+		"Create Time",
+		"Location",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, b := range backups {
+		lines = append(lines, []interface{}{
+			b.Kind,
+			b.CreateTime.Format("2006-01-02 15:04:05"),
+			b.Location,
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -0,0 +1,141 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func balanceStateDecorate(state string) string {
+	switch state {
+	case bs.BALANCE_STATE_OVERLOAD, bs.BALANCE_STATE_OFFLINE:
+		return color.RedString(state)
+	case bs.BALANCE_STATE_UNDERLOAD:
+		return color.YellowString(state)
+	}
+	return color.GreenString(state)
+}
+
+func FormatBalanceGroups(groups []bs.BalanceGroup) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Pool/Zone",
+		"Chunkservers",
+		"Copysets",
+		"Leaders",
+		"Copyset Deviation",
+		"Leader Deviation",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, group := range groups {
+		lines = append(lines, []interface{}{
+			group.Name,
+			strconv.Itoa(group.Chunkservers),
+			strconv.Itoa(group.Copysets),
+			strconv.Itoa(group.Leaders),
+			fmt.Sprintf("%.1f%%", group.CopysetDeviation),
+			fmt.Sprintf("%.1f%%", group.LeaderDeviation),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+// sort by: pool, zone, address
+func FormatChunkserverLoads(loads []bs.ChunkserverLoad) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Id",
+		"Address",
+		"Pool",
+		"Zone",
+		"Copysets",
+		"Leaders",
+		"State",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(loads, func(i, j int) bool {
+		l1, l2 := loads[i], loads[j]
+		if l1.Pool != l2.Pool {
+			return l1.Pool < l2.Pool
+		} else if l1.Zone != l2.Zone {
+			return l1.Zone < l2.Zone
+		}
+		return l1.Addr < l2.Addr
+	})
+	for _, load := range loads {
+		lines = append(lines, []interface{}{
+			strconv.Itoa(load.Id),
+			load.Addr,
+			load.Pool,
+			load.Zone,
+			strconv.Itoa(load.Copysets),
+			strconv.Itoa(load.Leaders),
+			tuicommon.DecorateMessage{Message: load.State, Decorate: balanceStateDecorate},
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+// FormatChunkserverStatusChanges formats chunkservers whose status will be changed to status
+func FormatChunkserverStatusChanges(loads []bs.ChunkserverLoad, status string) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Id",
+		"Address",
+		"Pool",
+		"Zone",
+		"Copysets",
+		"Status",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, load := range loads {
+		lines = append(lines, []interface{}{
+			strconv.Itoa(load.Id),
+			load.Addr,
+			load.Pool,
+			load.Zone,
+			strconv.Itoa(load.Copysets),
+			fmt.Sprintf("%s -> %s", load.Status, strings.ToUpper(status)),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	BENCH_ROW_TOTAL    = "total"
+	BENCH_ROW_PREVIOUS = "previous"
+	BENCH_ROW_CHANGE   = "change"
+)
+
+func formatBenchLatency(latency float64) string {
+	return fmt.Sprintf("%.0f", latency)
+}
+
+// higher is better for IOPS and bandwidth, lower is better for latency
+func formatBenchChange(current, previous float64, higherBetter bool) interface{} {
+	if previous <= 0 {
+		return "-"
+	}
+	percent := (current - previous) * 100 / previous
+	better := (percent >= 0) == higherBetter
+	return tuicommon.DecorateMessage{
+		Message: fmt.Sprintf("%+.1f%%", percent),
+		Decorate: func(message string) string {
+			if percent == 0 {
+				return message
+			} else if better {
+				return color.GreenString(message)
+			}
+			return color.RedString(message)
+		},
+	}
+}
+
+func formatBenchResult(name string, result task.BenchResult) []interface{} {
+	return []interface{}{
+		name,
+		fmt.Sprintf("%.0f", result.IOPS),
+		humanize.IBytes(uint64(result.BW*1024)) + "/s",
+		formatBenchLatency(result.LatMean),
+		formatBenchLatency(result.LatP50),
+		formatBenchLatency(result.LatP99),
+		formatBenchLatency(result.LatP999),
+	}
+}
+
+/*
+ * FormatBenchResults shows the result of each client and the total, and
+ * compares the total with previous run if exists.
+ */
+func FormatBenchResults(results []task.BenchResult, total task.BenchResult, previous *task.BenchResult) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Host",
+		"IOPS",
+		"Bandwidth",
+		"Lat Mean(us)",
+		"P50(us)",
+		"P99(us)",
+		"P99.9(us)",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Host < results[j].Host
+	})
+	for _, result := range results {
+		lines = append(lines, formatBenchResult(result.Host, result))
+	}
+	lines = append(lines, formatBenchResult(BENCH_ROW_TOTAL, total))
+	if previous != nil {
+		lines = append(lines, formatBenchResult(BENCH_ROW_PREVIOUS, *previous))
+		lines = append(lines, []interface{}{
+			BENCH_ROW_CHANGE,
+			formatBenchChange(total.IOPS, previous.IOPS, true),
+			formatBenchChange(total.BW, previous.BW, true),
+			formatBenchChange(total.LatMean, previous.LatMean, false),
+			formatBenchChange(total.LatP50, previous.LatP50, false),
+			formatBenchChange(total.LatP99, previous.LatP99, false),
+			formatBenchChange(total.LatP999, previous.LatP999, false),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -0,0 +1,121 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	CERT_STATUS_VALID    = "Valid"
+	CERT_STATUS_EXPIRING = "Expiring"
+	CERT_STATUS_EXPIRED  = "Expired"
+	CERT_STATUS_INVALID  = "Invalid"
+
+	CERT_EXPIRING_THRESHOLD = 30 * 24 * time.Hour
+)
+
+type CertificateItem struct {
+	Name string // "ca" or service id
+	Role string
+	Host string
+	Cert *x509.Certificate // nil if the certificate can't be parsed or verified
+}
+
+func certStatus(cert *x509.Certificate, now time.Time) string {
+	if cert == nil {
+		return CERT_STATUS_INVALID
+	} else if now.After(cert.NotAfter) {
+		return CERT_STATUS_EXPIRED
+	} else if cert.NotAfter.Sub(now) < CERT_EXPIRING_THRESHOLD {
+		return CERT_STATUS_EXPIRING
+	}
+	return CERT_STATUS_VALID
+}
+
+func certDecorate(message string) string {
+	switch message {
+	case CERT_STATUS_EXPIRING:
+		return color.YellowString(message)
+	case CERT_STATUS_EXPIRED, CERT_STATUS_INVALID:
+		return color.RedString(message)
+	}
+	return message
+}
+
+func certSANs(cert *x509.Certificate) string {
+	sans := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	return utils.Choose(len(sans) > 0, strings.Join(sans, ","), "-")
+}
+
+func FormatCertificates(items []CertificateItem) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Name",
+		"Role",
+		"Host",
+		"Subject",
+		"SANs",
+		"Not After",
+		"Remaining",
+		"Status",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	now := time.Now()
+	for _, item := range items {
+		status := certStatus(item.Cert, now)
+		subject, sans, notAfter, remaining := "-", "-", "-", "-"
+		if item.Cert != nil {
+			subject = item.Cert.Subject.CommonName
+			sans = certSANs(item.Cert)
+			notAfter = item.Cert.NotAfter.Format("2006-01-02 15:04:05")
+			if status != CERT_STATUS_EXPIRED {
+				remaining = fmt.Sprintf("%dd", int(item.Cert.NotAfter.Sub(now).Hours()/24))
+			}
+		}
+		lines = append(lines, []interface{}{
+			item.Name,
+			item.Role,
+			item.Host,
+			subject,
+			sans,
+			notAfter,
+			remaining,
+			tuicommon.DecorateMessage{Message: status, Decorate: certDecorate},
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
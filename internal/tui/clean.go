@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"github.com/dustin/go-humanize"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func cleanDirSize(bytes int64) string {
+	if bytes < 0 {
+		return task.CLEAN_REPORT_UNKNOWN
+	}
+	return humanize.IBytes(uint64(bytes))
+}
+
+func FormatCleanReports(reports []task.CleanReport) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Id",
+		"Role",
+		"Host",
+		"Container Id",
+		"Directory",
+		"Device",
+		"Size",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, report := range reports {
+		containerId := tuicommon.TrimContainerId(report.ContainerId)
+		if len(report.Dirs) == 0 {
+			lines = append(lines, []interface{}{
+				report.ServiceId, report.Role, report.Host, containerId, "-", "-", "-",
+			})
+		}
+		for _, dir := range report.Dirs {
+			lines = append(lines, []interface{}{
+				report.ServiceId,
+				report.Role,
+				report.Host,
+				containerId,
+				dir.Path,
+				dir.Device,
+				cleanDirSize(dir.Bytes),
+			})
+		}
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/fatih/color"
 	comm "github.com/opencurve/curveadm/internal/common"
 	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 )
 
@@ -91,3 +92,54 @@ func FormatStatus(statuses []task.ClientStatus, verbose bool) string {
 	output := tui.FixedFormat(lines, 2)
 	return output
 }
+
+func mountStatusDecorate(status string) string {
+	switch status {
+	case fs.MOUNT_STATUS_STALE, fs.MOUNT_STATUS_UNKNOWN:
+		return color.RedString(status)
+	case fs.MOUNT_STATUS_UNMOUNTED:
+		return color.YellowString(status)
+	}
+	return status
+}
+
+func FormatMountStatus(statuses []fs.MountStatus) string {
+	lines := [][]interface{}{}
+
+	// title
+	title := []string{
+		"Id",
+		"Host",
+		"FS Name",
+		"FS Type",
+		"Mount Point",
+		"Container Id",
+		"Status",
+	}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	// status
+	sort.Slice(statuses, func(i, j int) bool {
+		s1, s2 := statuses[i], statuses[j]
+		if s1.Host == s2.Host {
+			return s1.MountPoint < s2.MountPoint
+		}
+		return s1.Host < s2.Host
+	})
+	for _, status := range statuses {
+		lines = append(lines, []interface{}{
+			status.Id,
+			status.Host,
+			status.FSName,
+			status.FSType,
+			status.MountPoint,
+			tui.TrimContainerId(status.ContainerId),
+			tui.DecorateMessage{Message: status.Status, Decorate: mountStatusDecorate},
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	return output
+}
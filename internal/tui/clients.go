@@ -21,3 +21,70 @@
  */
 
 package tui
+
+import (
+	"sort"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+// returns (volume, device) for curvebs client and (fsname, mount point) for curvefs client
+func clientTarget(client storage.Client) (string, string) {
+	if client.Kind == topology.KIND_CURVEBS {
+		auxInfo, err := bs.DecodeAuxInfo(client.AuxInfo)
+		if err != nil {
+			return "-", "-"
+		}
+		device := auxInfo.Device
+		if len(device) == 0 {
+			device = "-"
+		}
+		return auxInfo.User + ":" + auxInfo.Volume, device
+	}
+
+	auxInfo, err := fs.DecodeAuxInfo(client.AuxInfo)
+	if err != nil {
+		return "-", "-"
+	}
+	return auxInfo.FSName, auxInfo.MountPoint
+}
+
+func FormatClients(clients []storage.Client) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Id",
+		"Kind",
+		"Host",
+		"Container Id",
+		"Volume/FS",
+		"Device/Path",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(clients, func(i, j int) bool {
+		c1, c2 := clients[i], clients[j]
+		if c1.Host == c2.Host {
+			return c1.Kind < c2.Kind
+		}
+		return c1.Host < c2.Host
+	})
+	for _, client := range clients {
+		target, device := clientTarget(client)
+		lines = append(lines, []interface{}{
+			client.Id,
+			client.Kind,
+			client.Host,
+			tuicommon.TrimContainerId(client.ContainerId),
+			target,
+			device,
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -25,7 +25,9 @@
 package tui
 
 import (
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/internal/storage"
@@ -37,16 +39,28 @@ func currentDecorate(message string) string {
 	return color.GreenString(message)
 }
 
-func FormatClusters(clusters []storage.Cluster, verbose bool) string {
+func protectionStatus(protection storage.ClusterProtection, ok bool, now time.Time) string {
+	if !ok {
+		return "-"
+	} else if now.Unix() < protection.UnlockUntil {
+		return fmt.Sprintf("unlocked (until %s)", time.Unix(protection.UnlockUntil, 0).Format("15:04:05"))
+	}
+	return "protected"
+}
+
+func FormatClusters(clusters []storage.Cluster,
+	protections map[int]storage.ClusterProtection,
+	verbose bool) string {
 	lines := [][]interface{}{}
 	if verbose {
-		title := []string{" ", "Cluster", "Id", "UUId", "Create Time", "Description"}
+		title := []string{" ", "Cluster", "Id", "UUId", "Create Time", "Protection", "Description"}
 		first, second := tuicommon.FormatTitle(title)
 		second[0] = ""
 		lines = append(lines, first)
 		lines = append(lines, second)
 	}
 
+	now := time.Now()
 	for i := 0; i < len(clusters); i++ {
 		line := []interface{}{}
 		cluster := clusters[i]
@@ -62,6 +76,8 @@ func FormatClusters(clusters []storage.Cluster, verbose bool) string {
 			line = append(line, strconv.Itoa(cluster.Id))
 			line = append(line, cluster.UUId)
 			line = append(line, cluster.CreateTime.Format("2006-01-02 15:04:05"))
+			protection, ok := protections[cluster.Id]
+			line = append(line, protectionStatus(protection, ok, now))
 			line = append(line, cluster.Description)
 		}
 
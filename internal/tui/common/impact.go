@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/i18n"
+)
+
+const (
+	// services and directories listed in impact summary at most
+	IMPACT_MAX_ITEMS = 10
+)
+
+// Impact is the summary of what a destructive operation will touch
+type Impact struct {
+	Operation string
+	Services  []string // e.g. chunkserver@host1 (6ff561598c6f)
+	Hosts     []string
+	Dirs      []string      // directories touched, e.g. host1:/data/chunkserver0
+	Estimated time.Duration // average duration of history runs, 0 if no history
+	Runs      int           // number of history runs
+}
+
+func impactItems(items []string) []string {
+	lines := []string{}
+	for i, item := range items {
+		if i == IMPACT_MAX_ITEMS {
+			lines = append(lines, "      "+i18n.Tf("... and %d more", len(items)-i))
+			break
+		}
+		lines = append(lines, "      "+item)
+	}
+	return lines
+}
+
+/*
+ * PromptImpact renders impact summary before confirmation, e.g:
+ *
+ *   Impact summary:
+ *     - Operation : stop service
+ *     - Services  : 2
+ *         chunkserver@host1 (6ff561598c6f)
+ *         chunkserver@host2 (c4d3e1a9b0f2)
+ *     - Hosts     : host1, host2
+ *     - Data dirs : untouched
+ *     - Estimated : ~1m30s (average of last 3 runs)
+ */
+func PromptImpact(impact Impact) string {
+	lines := []string{
+		color.CyanString(i18n.T("Impact summary:")),
+		i18n.T("  - Operation : ") + color.YellowString(i18n.T(impact.Operation)),
+		i18n.T("  - Services  : ") + strconv.Itoa(len(impact.Services)),
+	}
+	lines = append(lines, impactItems(impact.Services)...)
+	lines = append(lines, i18n.T("  - Hosts     : ")+strings.Join(impact.Hosts, ", "))
+	if len(impact.Dirs) == 0 {
+		lines = append(lines, i18n.T("  - Data dirs : ")+i18n.T("untouched"))
+	} else {
+		lines = append(lines, i18n.T("  - Data dirs : ")+strconv.Itoa(len(impact.Dirs)))
+		lines = append(lines, impactItems(impact.Dirs)...)
+	}
+	estimated := "~" + impact.Estimated.Round(time.Second).String()
+	if impact.Estimated < time.Second {
+		estimated = "<1s"
+	}
+	if impact.Runs == 0 {
+		lines = append(lines, i18n.T("  - Estimated : ")+i18n.T("unknown (no history)"))
+	} else {
+		lines = append(lines, i18n.T("  - Estimated : ")+
+			i18n.Tf("%s (average of last %d runs)", estimated, impact.Runs))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
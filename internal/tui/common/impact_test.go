@@ -0,0 +1,65 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptImpact(t *testing.T) {
+	assert := assert.New(t)
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	impact := Impact{
+		Operation: "stop service",
+		Services:  []string{"etcd@host1 (6ff561598c6f)", "etcd@host2 (c4d3e1a9b0f2)"},
+		Hosts:     []string{"host1", "host2"},
+	}
+	assert.Equal("Impact summary:\n"+
+		"  - Operation : stop service\n"+
+		"  - Services  : 2\n"+
+		"      etcd@host1 (6ff561598c6f)\n"+
+		"      etcd@host2 (c4d3e1a9b0f2)\n"+
+		"  - Hosts     : host1, host2\n"+
+		"  - Data dirs : untouched\n"+
+		"  - Estimated : unknown (no history)\n", PromptImpact(impact))
+
+	// long list is cut
+	impact.Dirs = []string{}
+	for i := 0; i < IMPACT_MAX_ITEMS+2; i++ {
+		impact.Dirs = append(impact.Dirs, fmt.Sprintf("host1:/data/chunkserver%d", i))
+	}
+	impact.Estimated, impact.Runs = 90*time.Second+300*time.Millisecond, 3
+	output := PromptImpact(impact)
+	assert.Contains(output, "  - Data dirs : 12\n")
+	assert.Contains(output, "      host1:/data/chunkserver9\n      ... and 2 more\n")
+	assert.NotContains(output, "chunkserver10")
+	assert.Contains(output, "  - Estimated : ~1m30s (average of last 3 runs)\n")
+}
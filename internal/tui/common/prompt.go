@@ -29,8 +29,11 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+	"github.com/opencurve/curveadm/internal/i18n"
 )
 
 const (
@@ -71,35 +74,33 @@ to watch the formatting progress.
 `
 	PROMPT_CANCEL_OPERATION = `[x] {{.operation}} canceled`
 
+	// the clue (usually stderr of remote command) is cut to its tail lines
+	CLUE_EXCERPT_LINES = 10
+
 	DEFAULT_CONFIRM_PROMPT = "Do you want to continue?"
 )
 
 var (
-	PROMPT_ERROR_CODE = strings.Join([]string{
-		color.CyanString("---"),
-		color.CyanString("Error-Code: ") + "{{.code}}",
-		color.CyanString("Error-Description: ") + "{{.description}}",
-		"{{- if .clue}}",
-		color.CyanString("Error-Clue: ") + "{{.clue}}",
-		"{{- end}}",
-		color.CyanString("How to Solve:"),
-		color.CyanString("  * Website: ") + "{{.website}}",
-		"{{- if .logpath}}",
-		color.CyanString("  * Log: ") + "{{.logpath}}",
-		"{{- end}}",
-		color.CyanString("  * WeChat: ") + "{{.wechat}}",
-	}, "\n")
-
 	PROMPT_AUTO_UPGRADE = strings.Join([]string{
 		color.MagentaString("CurveAdm {{.version}} released, we recommend you to upgrade it."),
 		"Upgrade curveadm to {{.version}}?",
 	}, "\n")
 )
 
-type Prompt struct {
-	tmpl *template.Template
-	data map[string]interface{}
-}
+type (
+	Prompt struct {
+		tmpl *template.Template
+		data map[string]interface{}
+	}
+
+	// ErrorDetail is the context and remediation of error code
+	ErrorDetail struct {
+		Hosts []string
+		Step  string
+		Hints []string
+		Doc   string // website of error code, the wiki page if empty
+	}
+)
 
 func NewPrompt(text string) *Prompt {
 	return &Prompt{
@@ -118,31 +119,31 @@ func (p *Prompt) Build() string {
 }
 
 func PromptRemoveCluster(clusterName string) string {
-	prompt := NewPrompt(color.YellowString(PROMPT_WARNING) + DEFAULT_CONFIRM_PROMPT)
-	prompt.data["warning"] = fmt.Sprintf("WARNING: cluster '%s' will be removed,\n"+
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.Tf("WARNING: cluster '%s' will be removed,\n"+
 		"and all data in it will be cleaned up", clusterName)
 	return prompt.Build()
 }
 
 func PromptFormat() string {
-	return color.YellowString(PROMPT_FORMAT)
+	return color.YellowString(i18n.T(PROMPT_FORMAT))
 }
 
 func PromptScaleOut() string {
-	prompt := NewPrompt(color.YellowString(PROMPT_TOPOLOGY_CHANGE_NOTICE) + DEFAULT_CONFIRM_PROMPT)
-	prompt.data["operation"] = "scale out cluster"
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_TOPOLOGY_CHANGE_NOTICE)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["operation"] = i18n.T("scale out cluster")
 	return prompt.Build()
 }
 
 func PromptMigrate() string {
-	prompt := NewPrompt(color.YellowString(PROMPT_TOPOLOGY_CHANGE_NOTICE) + DEFAULT_CONFIRM_PROMPT)
-	prompt.data["operation"] = "migrate services"
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_TOPOLOGY_CHANGE_NOTICE)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["operation"] = i18n.T("migrate services")
 	return prompt.Build()
 }
 
 func PromptStartService(id, role, host string) string {
-	prompt := NewPrompt(color.YellowString(PROMPT_COMMON_WARNING) + DEFAULT_CONFIRM_PROMPT)
-	prompt.data["warning"] = "WARNING: service items which matched will start"
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_COMMON_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.T("WARNING: service items which matched will start")
 	prompt.data["id"] = id
 	prompt.data["role"] = role
 	prompt.data["host"] = host
@@ -150,8 +151,8 @@ func PromptStartService(id, role, host string) string {
 }
 
 func PromptStopService(id, role, host string) string {
-	prompt := NewPrompt(color.YellowString(PROMPT_COMMON_WARNING) + DEFAULT_CONFIRM_PROMPT)
-	prompt.data["warning"] = "WARNING: stop service may cause client IO be hang"
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_COMMON_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.T("WARNING: stop service may cause client IO be hang")
 	prompt.data["id"] = id
 	prompt.data["role"] = role
 	prompt.data["host"] = host
@@ -159,8 +160,8 @@ func PromptStopService(id, role, host string) string {
 }
 
 func PromptRestartService(id, role, host string) string {
-	prompt := NewPrompt(color.YellowString(PROMPT_COMMON_WARNING) + DEFAULT_CONFIRM_PROMPT)
-	prompt.data["warning"] = "WARNING: service items which matched will restart"
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_COMMON_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.T("WARNING: service items which matched will restart")
 	prompt.data["id"] = id
 	prompt.data["role"] = role
 	prompt.data["host"] = host
@@ -168,8 +169,8 @@ func PromptRestartService(id, role, host string) string {
 }
 
 func PromptReloadService(id, role, host string) string {
-	prompt := NewPrompt(color.YellowString(PROMPT_COMMON_WARNING) + DEFAULT_CONFIRM_PROMPT)
-	prompt.data["warning"] = "WARNING: service items which matched will reload"
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_COMMON_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.T("WARNING: service items which matched will reload")
 	prompt.data["id"] = id
 	prompt.data["role"] = role
 	prompt.data["host"] = host
@@ -177,40 +178,125 @@ func PromptReloadService(id, role, host string) string {
 }
 
 func PromptCleanService(role, host string, items []string) string {
-	prompt := NewPrompt(color.YellowString(PROMPT_CLEAN_SERVICE) + DEFAULT_CONFIRM_PROMPT)
-	prompt.data["warning"] = "WARNING: service items which matched will be cleaned up"
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_CLEAN_SERVICE)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.T("WARNING: service items which matched will be cleaned up")
 	prompt.data["role"] = role
 	prompt.data["host"] = host
 	prompt.data["items"] = strings.Join(items, ",")
 	return prompt.Build()
 }
 
+func PromptDestroyCluster(clusterName string) string {
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_WARNING)) + i18n.T("Type the cluster name to confirm:"))
+	prompt.data["warning"] = i18n.Tf("WARNING: data listed above in cluster '%s' will be destroyed,\n"+
+		"and it can't be recovered", clusterName)
+	return prompt.Build()
+}
+
+func PromptInitHosts(hosts []string) string {
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.Tf("WARNING: hosts '%s' will be initialized,\n"+
+		"which install packages and change system settings", strings.Join(hosts, ","))
+	return prompt.Build()
+}
+
+func PromptRestoreEtcd(snapshot string, hosts []string) string {
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.Tf("WARNING: etcd services on hosts '%s' will be stopped,\n"+
+		"and their data will be replaced by snapshot '%s'", strings.Join(hosts, ","), snapshot)
+	return prompt.Build()
+}
+
+func PromptUnmapAll(host string, volumes []string) string {
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.Tf("WARNING: volumes '%s' on host '%s' will be unmapped",
+		strings.Join(volumes, ","), host)
+	return prompt.Build()
+}
+
+func PromptRemoveVolume(volume string) string {
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.Tf("WARNING: volume '%s' will be deleted,\n"+
+		"and it can't be recovered", volume)
+	return prompt.Build()
+}
+
 func PromptCollectService() string {
-	prompt := NewPrompt(color.YellowString(PROMPT_COLLECT_SERVICE) + DEFAULT_CONFIRM_PROMPT)
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_COLLECT_SERVICE)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
 	return prompt.Build()
 }
 
+// labels of error output are translated when rendering
+func promptErrorCode() string {
+	return strings.Join([]string{
+		color.CyanString("---"),
+		color.CyanString(i18n.T("Error-Code: ")) + "{{.code}}",
+		color.CyanString(i18n.T("Error-Description: ")) + color.RedString("{{.description}}"),
+		"{{- if .hosts}}",
+		color.CyanString(i18n.T("Error-Host: ")) + "{{.hosts}}",
+		"{{- end}}",
+		"{{- if .step}}",
+		color.CyanString(i18n.T("Error-Step: ")) + "{{.step}}",
+		"{{- end}}",
+		"{{- if .clue}}",
+		color.CyanString(i18n.T("Error-Clue: ")) + "{{.clue}}",
+		"{{- end}}",
+		"{{- if .hints}}",
+		color.CyanString(i18n.T("Try This:")),
+		"{{- range .hints}}",
+		color.CyanString("  * ") + color.YellowString("{{.}}"),
+		"{{- end}}",
+		"{{- end}}",
+		color.CyanString(i18n.T("How to Solve:")),
+		color.CyanString(i18n.T("  * Website: ")) + "{{.website}}",
+		"{{- if .logpath}}",
+		color.CyanString(i18n.T("  * Log: ")) + "{{.logpath}}",
+		"{{- end}}",
+		color.CyanString(i18n.T("  * WeChat: ")) + "{{.wechat}}",
+	}, "\n")
+}
+
 func prettyClue(clue string) string {
 	items := strings.Split(clue, "\n")
 	for {
 		n := len(items)
-		if len(items[n-1]) > 0 || n == 0 {
+		if n == 0 || len(items[n-1]) > 0 {
 			break
 		}
 		items = items[:n-1]
 	}
-	sep := fmt.Sprintf("\n%s", strings.Repeat(" ", len("Error-Clue: ")))
+	if n := len(items); n > CLUE_EXCERPT_LINES {
+		items = append([]string{i18n.Tf("... (%d lines omitted, see log)", n-CLUE_EXCERPT_LINES)},
+			items[n-CLUE_EXCERPT_LINES:]...)
+	}
+	sep := fmt.Sprintf("\n%s", strings.Repeat(" ", runewidth.StringWidth(i18n.T("Error-Clue: "))))
 	return strings.Join(items, sep)
 }
 
-func PromptErrorCode(code int, description, clue, logpath string) string {
-	prompt := NewPrompt(color.CyanString(PROMPT_ERROR_CODE))
+func PromptErrorCode(code int, description, clue, logpath string, detail ErrorDetail) string {
+	prompt := NewPrompt(color.CyanString(promptErrorCode()))
 	prompt.data["code"] = fmt.Sprintf("%06d", code)
-	prompt.data["description"] = description
+	prompt.data["description"] = i18n.T(description)
+	if len(detail.Hosts) > 0 {
+		prompt.data["hosts"] = strings.Join(detail.Hosts, ", ")
+	}
+	if len(detail.Step) > 0 {
+		prompt.data["step"] = detail.Step
+	}
 	if len(clue) > 0 {
 		prompt.data["clue"] = prettyClue(clue)
 	}
-	prompt.data["website"] = fmt.Sprintf("https://github.com/opencurve/curveadm/wiki/errno%d#%06d", code / 100000, code)
+	if len(detail.Hints) > 0 {
+		hints := []string{}
+		for _, hint := range detail.Hints {
+			hints = append(hints, i18n.T(hint))
+		}
+		prompt.data["hints"] = hints
+	}
+	prompt.data["website"] = fmt.Sprintf("https://github.com/opencurve/curveadm/wiki/errno%d#%06d", code/100000, code)
+	if len(detail.Doc) > 0 {
+		prompt.data["website"] = detail.Doc
+	}
 	if len(logpath) > 0 {
 		prompt.data["logpath"] = logpath
 	}
@@ -220,8 +306,8 @@ func PromptErrorCode(code int, description, clue, logpath string) string {
 }
 
 func PromptCancelOpetation(operation string) string {
-	prompt := NewPrompt(color.YellowString(PROMPT_CANCEL_OPERATION))
-	prompt.data["operation"] = operation
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_CANCEL_OPERATION)))
+	prompt.data["operation"] = i18n.T(operation)
 	return prompt.Build()
 }
 
@@ -230,3 +316,22 @@ func PromptAutoUpgrade(version string) string {
 	prompt.data["version"] = version
 	return prompt.Build()
 }
+
+// PromptProtectedCluster replaces the yes/no question of prompt with typing cluster name
+func PromptProtectedCluster(prompt, clusterName string) string {
+	prompt = strings.TrimSuffix(prompt, i18n.T(DEFAULT_CONFIRM_PROMPT))
+	return prompt + color.RedString(i18n.Tf("Cluster '%s' is protected, type the cluster name to confirm:", clusterName)) + " "
+}
+
+func PromptUnprotectCluster(clusterName string) string {
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.Tf("WARNING: protection of cluster '%s' will be removed,\n"+
+		"destructive operations only require 'yes' to confirm after that", clusterName)
+	return prompt.Build()
+}
+
+func PromptUnlockCluster(clusterName string, duration time.Duration) string {
+	prompt := NewPrompt(color.YellowString(i18n.T(PROMPT_WARNING)) + i18n.T(DEFAULT_CONFIRM_PROMPT))
+	prompt.data["warning"] = i18n.Tf("WARNING: cluster '%s' can be cleaned or removed in next %s", clusterName, duration)
+	return prompt.Build()
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+	"github.com/opencurve/curveadm/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptTranslation(t *testing.T) {
+	assert := assert.New(t)
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+	defer i18n.SetLanguage(i18n.Language())
+	i18n.SetLanguage(i18n.LANG_ZH)
+
+	for _, prompt := range []string{
+		PROMPT_COMMON_WARNING,
+		PROMPT_CLEAN_SERVICE,
+		PROMPT_COLLECT_SERVICE,
+		PROMPT_TOPOLOGY_CHANGE_NOTICE,
+		PROMPT_FORMAT,
+		PROMPT_CANCEL_OPERATION,
+		DEFAULT_CONFIRM_PROMPT,
+	} {
+		assert.NotEqual(prompt, i18n.T(prompt))
+	}
+
+	output := PromptStopService("*", "etcd", "host1")
+	assert.Contains(output, "警告：停止服务可能导致客户端 IO 卡住")
+	assert.Contains(output, "服务角色：etcd")
+	assert.True(strings.HasSuffix(output, "是否继续？"))
+	assert.Equal("[x] 已取消停止服务", PromptCancelOpetation("stop service"))
+
+	output = PromptErrorCode(510000, "SSH connect failed", "", "", ErrorDetail{
+		Hints: []string{"check the load and network of the host, then retry"},
+	})
+	assert.Contains(output, "错误描述：SSH 连接失败")
+	assert.Contains(output, "  * 检查主机的负载和网络后重试")
+}
+
+func TestFormatTitleTranslation(t *testing.T) {
+	assert := assert.New(t)
+	defer i18n.SetLanguage(i18n.Language())
+	i18n.SetLanguage(i18n.LANG_ZH)
+
+	first, second := FormatTitle([]string{"Host", "Status"})
+	lines := [][]interface{}{first, second, {"host1", "Up 2 days"}}
+	output := strings.Split(strings.TrimSuffix(FixedFormat(lines, 2), "\n"), "\n")
+	assert.Equal("主机   状态     ", output[0])
+	assert.Equal("----   ----     ", output[1])
+	assert.Equal("host1  Up 2 days", output[2])
+
+	// columns are aligned by the width in terminal
+	assert.Equal(runewidth.StringWidth(output[0]), runewidth.StringWidth(output[2]))
+}
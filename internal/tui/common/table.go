@@ -0,0 +1,107 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+ * TableOptions selects columns of table output:
+ *   default: all columns except the hidden ones, e.g: Ports without --verbose
+ *   wide:    all columns, and the values are not truncated
+ *   columns: only the specified columns in order, e.g: --columns host,status
+ */
+type TableOptions struct {
+	Columns []string
+	Wide    bool
+}
+
+// ColumnKey returns the key of column for --columns, e.g: "Container Id" -> "container-id"
+func ColumnKey(title string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(title)), " ", "-")
+}
+
+// CheckColumns returns error if any of columns is not in title
+func CheckColumns(title []string, columns []string) error {
+	keys := []string{}
+	exist := map[string]bool{}
+	for _, item := range title {
+		keys = append(keys, ColumnKey(item))
+		exist[ColumnKey(item)] = true
+	}
+	for _, column := range columns {
+		if !exist[ColumnKey(column)] {
+			return fmt.Errorf("unknown column '%s', available columns: %s",
+				column, strings.Join(keys, ","))
+		}
+	}
+	return nil
+}
+
+func (options TableOptions) selected(title []string, hidden []string) []int {
+	locate := map[string]int{}
+	for i, item := range title {
+		locate[ColumnKey(item)] = i
+	}
+
+	indexes := []int{}
+	if len(options.Columns) > 0 {
+		for _, column := range options.Columns {
+			if i, ok := locate[ColumnKey(column)]; ok {
+				indexes = append(indexes, i)
+			}
+		}
+		return indexes
+	}
+
+	skip := map[int]bool{}
+	if !options.Wide {
+		for _, item := range hidden {
+			skip[locate[ColumnKey(item)]] = true
+		}
+	}
+	for i := range title {
+		if !skip[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// FormatTable formats the rows with selected columns, the hidden columns
+// are only displayed if wide or specified in columns
+func FormatTable(title []string, rows [][]interface{}, hidden []string, options TableOptions) string {
+	indexes := options.selected(title, hidden)
+	first, second := FormatTitle(title)
+	lines := [][]interface{}{}
+	for _, row := range append([][]interface{}{first, second}, rows...) {
+		line := []interface{}{}
+		for _, i := range indexes {
+			line = append(line, row[i])
+		}
+		lines = append(lines, line)
+	}
+	return FixedFormat(lines, 2)
+}
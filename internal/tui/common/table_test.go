@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTable(t *testing.T) {
+	assert := assert.New(t)
+	title := []string{"Host", "Container Id", "Status", "Log Dir"}
+	rows := [][]interface{}{
+		{"host1", "6ff561598c6f", "Up 2 days", "/curvebs/logs"},
+	}
+	header := func(output string) string {
+		return strings.Join(strings.Fields(strings.Split(output, "\n")[0]), " ")
+	}
+
+	output := FormatTable(title, rows, []string{"Log Dir"}, TableOptions{})
+	assert.Equal("Host Container Id Status", header(output))
+	assert.NotContains(output, "/curvebs/logs")
+
+	output = FormatTable(title, rows, []string{"Log Dir"}, TableOptions{Wide: true})
+	assert.Equal("Host Container Id Status Log Dir", header(output))
+	assert.Contains(output, "/curvebs/logs")
+
+	// the specified columns in order, hidden column included
+	output = FormatTable(title, rows, []string{"Log Dir"}, TableOptions{Columns: []string{"log-dir", "HOST"}})
+	assert.Equal("Log Dir Host", header(output))
+	assert.Contains(output, "/curvebs/logs  host1")
+}
+
+func TestCheckColumns(t *testing.T) {
+	assert := assert.New(t)
+	title := []string{"Host", "Container Id"}
+	assert.Equal("container-id", ColumnKey("Container Id"))
+	assert.Nil(CheckColumns(title, nil))
+	assert.Nil(CheckColumns(title, []string{"host", "Container-Id"}))
+	assert.ErrorContains(CheckColumns(title, []string{"host", "ports"}), "available columns: host,container-id")
+}
@@ -30,9 +30,18 @@ import (
 	"os"
 	"strings"
 
+	"github.com/mattn/go-runewidth"
+	"github.com/opencurve/curveadm/internal/i18n"
 	"github.com/opencurve/curveadm/internal/utils"
+	"golang.org/x/term"
 )
 
+// answer 'yes' to all confirmations without prompting, see --assume-yes
+var gAssumeYes bool
+
+func SetAssumeYes(yes bool) { gAssumeYes = yes }
+func AssumeYes() bool       { return gAssumeYes }
+
 type DecorateMessage struct {
 	Message  string
 	Decorate func(string) string
@@ -67,9 +76,10 @@ func fixedLength(lines [][]interface{}) []int {
 	for j := 0; j < m; j++ {
 		maxLen := 0
 		for i := 0; i < n; i++ {
-			message := originMessage(lines[i][j])
-			if len(message) > maxLen {
-				maxLen = len(message)
+			// width of CJK character is 2 in terminal
+			width := runewidth.StringWidth(originMessage(lines[i][j]))
+			if width > maxLen {
+				maxLen = width
 			}
 		}
 		fixed = append(fixed, maxLen)
@@ -96,7 +106,7 @@ func FixedFormat(lines [][]interface{}, nspace int) string {
 				first = false
 			}
 
-			padding := strings.Repeat(" ", fixed[j]-len(originMessage(lines[i][j])))
+			padding := strings.Repeat(" ", fixed[j]-runewidth.StringWidth(originMessage(lines[i][j])))
 			output += realMessage(lines[i][j]) + padding
 		}
 		output += "\n"
@@ -105,12 +115,14 @@ func FixedFormat(lines [][]interface{}, nspace int) string {
 	return output
 }
 
+// FormatTitle returns translated title and its underline
 func FormatTitle(title []string) ([]interface{}, []interface{}) {
 	first := []interface{}{}
 	second := []interface{}{}
 	for _, item := range title {
+		item = i18n.T(item)
 		first = append(first, item)
-		second = append(second, strings.Repeat("-", len(item)))
+		second = append(second, strings.Repeat("-", runewidth.StringWidth(item)))
 	}
 	return first, second
 }
@@ -158,7 +170,11 @@ func prompt(prompt string) string {
 }
 
 func ConfirmYes(format string, a ...interface{}) bool {
-	ans := prompt(fmt.Sprintf(format, a...) + " [yes/no]: (default=no)")
+	if gAssumeYes {
+		fmt.Println(fmt.Sprintf(format, a...) + i18n.T(" [yes/no]: yes (assumed)"))
+		return true
+	}
+	ans := prompt(fmt.Sprintf(format, a...) + i18n.T(" [yes/no]: (default=no)"))
 	switch strings.TrimSpace(ans) {
 	case "yes":
 		return true
@@ -166,3 +182,25 @@ func ConfirmYes(format string, a ...interface{}) bool {
 		return false
 	}
 }
+
+// ConfirmInput returns true only if user typed the expected text, e.g: cluster name,
+// it's never assumed because it guards the dangerous operation on protected cluster
+func ConfirmInput(expect, format string, a ...interface{}) bool {
+	ans := prompt(fmt.Sprintf(format, a...))
+	return strings.TrimSpace(ans) == expect
+}
+
+// PromptPassword reads a line from terminal without echo
+func PromptPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+	defer fmt.Println()
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
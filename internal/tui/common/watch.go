@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	ANSI_CLEAR_SCREEN = "\033[H\033[2J"
+	ANSI_HIGHLIGHT    = "\033[7m" // reverse video
+	ANSI_RESET        = "\033[0m"
+)
+
+// Highlighter highlights the lines of output which changed since the last refresh
+type Highlighter struct {
+	last map[string]bool
+}
+
+func NewHighlighter() *Highlighter {
+	return &Highlighter{}
+}
+
+// Highlight returns output whose new lines are highlighted, nothing is highlighted
+// for the first output. The colors inside line are kept by highlighting again after
+// each reset.
+func (h *Highlighter) Highlight(output string) string {
+	lines := strings.Split(output, "\n")
+	current := map[string]bool{}
+	for i, line := range lines {
+		current[line] = true
+		if h.last == nil || h.last[line] || len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		lines[i] = ANSI_HIGHLIGHT +
+			strings.ReplaceAll(line, ANSI_RESET, ANSI_RESET+ANSI_HIGHLIGHT) + ANSI_RESET
+	}
+	h.last = current
+	return strings.Join(lines, "\n")
+}
+
+// Watch calls refresh by the interval until interrupted or refresh failed
+func Watch(interval time.Duration, refresh func() error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := refresh(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
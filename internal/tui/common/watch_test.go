@@ -0,0 +1,47 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHighlighter()
+
+	// nothing highlighted at first
+	first := "Id  Status\n--  ------\n1   Up\n2   Up\n"
+	assert.Equal(first, h.Highlight(first))
+
+	// only changed rows are highlighted
+	second := "Id  Status\n--  ------\n1   Up\n2   \033[31mExited\033[0m\n"
+	assert.Equal("Id  Status\n--  ------\n1   Up\n"+
+		ANSI_HIGHLIGHT+"2   \033[31mExited"+ANSI_RESET+ANSI_HIGHLIGHT+ANSI_RESET+"\n",
+		h.Highlight(second))
+
+	// compared with the last output
+	assert.Equal(second, h.Highlight(second))
+}
@@ -0,0 +1,301 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package dashboard
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+/*
+ * Dashboard is a terminal dashboard which consists of tabs, each tab is
+ * a table whose row can be selected by keyboard and acted by the actions
+ * of tab, e.g:
+ *
+ *   CurveAdm Dashboard    Cluster: my-cluster    Updated: 2023-10-13 10:00:00
+ *
+ *    1:Clusters   2:Services   3:Disks   4:Operations
+ *
+ *     Id            Role  Host   Status
+ *     --            ----  ----   ------
+ *   > 6ff561598c6f  etcd  host1  Up 2 days
+ *     ...
+ *
+ *   ↑/↓ select  ←/→ switch  r refresh  q quit  l logs  s status
+ *
+ * The dashboard only renders and handles keys, the data and actions are
+ * provided by caller, so it's independent of terminal and easy to test.
+ */
+const (
+	KEY_UP     = "up"
+	KEY_DOWN   = "down"
+	KEY_LEFT   = "left"
+	KEY_RIGHT  = "right"
+	KEY_ENTER  = "enter"
+	KEY_TAB    = "tab"
+	KEY_ESC    = "esc"
+	KEY_CTRL_C = "ctrl-c"
+
+	KEY_QUIT    = "q"
+	KEY_REFRESH = "r"
+
+	ANSI_CLEAR_SCREEN = "\033[H\033[2J"
+	ANSI_REVERSE      = "\033[7m"
+	ANSI_RESET        = "\033[0m"
+
+	// lines of header, tab bar and footer
+	RESERVED_LINES = 8
+)
+
+type (
+	Action struct {
+		Key  string
+		Name string
+	}
+
+	Tab struct {
+		Name    string
+		Title   []string
+		Rows    [][]interface{} // string or tuicommon.DecorateMessage
+		Actions []Action
+		Message string // displayed instead of rows if no rows, e.g: error
+	}
+
+	Dashboard struct {
+		Header  string
+		Message string // status line, e.g: result of last action
+		Height  int    // lines of terminal, 0 means unlimited
+		tabs    []*Tab
+		current int
+		cursors []int
+		offsets []int
+	}
+)
+
+func New(tabs []*Tab) *Dashboard {
+	return &Dashboard{
+		tabs:    tabs,
+		cursors: make([]int, len(tabs)),
+		offsets: make([]int, len(tabs)),
+	}
+}
+
+func (d *Dashboard) Current() *Tab { return d.tabs[d.current] }
+
+// Selected returns index of selected row in current tab, -1 if no rows
+func (d *Dashboard) Selected() int {
+	if len(d.Current().Rows) == 0 {
+		return -1
+	}
+	return d.cursors[d.current]
+}
+
+// SetRows replaces rows of tab and keeps the cursor in range
+func (d *Dashboard) SetRows(name string, rows [][]interface{}, message string) {
+	for i, tab := range d.tabs {
+		if tab.Name != name {
+			continue
+		}
+		tab.Rows = rows
+		tab.Message = message
+		if d.cursors[i] >= len(rows) {
+			d.cursors[i] = len(rows) - 1
+		}
+		if d.cursors[i] < 0 {
+			d.cursors[i] = 0
+		}
+	}
+}
+
+func (d *Dashboard) move(delta int) {
+	n := len(d.Current().Rows)
+	if n == 0 {
+		return
+	}
+	cursor := d.cursors[d.current] + delta
+	if cursor < 0 {
+		cursor = 0
+	} else if cursor >= n {
+		cursor = n - 1
+	}
+	d.cursors[d.current] = cursor
+}
+
+func (d *Dashboard) switchTab(index int) {
+	n := len(d.tabs)
+	d.current = ((index % n) + n) % n
+}
+
+// HandleKey handles navigation keys, it returns the key of action
+// which caller should perform, or quit if user wants to exit
+func (d *Dashboard) HandleKey(key string) (action string, quit bool) {
+	switch key {
+	case KEY_QUIT, KEY_CTRL_C, KEY_ESC:
+		return "", true
+	case KEY_UP, "k":
+		d.move(-1)
+	case KEY_DOWN, "j":
+		d.move(1)
+	case KEY_LEFT:
+		d.switchTab(d.current - 1)
+	case KEY_RIGHT, KEY_TAB:
+		d.switchTab(d.current + 1)
+	case KEY_REFRESH:
+		return KEY_REFRESH, false
+	default:
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(d.tabs) {
+			d.switchTab(n - 1)
+			return "", false
+		}
+		for _, a := range d.Current().Actions {
+			if a.Key == key {
+				return key, false
+			}
+		}
+	}
+	return "", false
+}
+
+// visible returns range [start, end) of rows which fit in terminal
+func (d *Dashboard) visible() (int, int) {
+	n := len(d.Current().Rows)
+	if d.Height <= RESERVED_LINES {
+		return 0, n
+	}
+
+	size := d.Height - RESERVED_LINES
+	cursor, offset := d.cursors[d.current], d.offsets[d.current]
+	if cursor < offset {
+		offset = cursor
+	} else if cursor >= offset+size {
+		offset = cursor - size + 1
+	}
+	d.offsets[d.current] = offset
+	end := offset + size
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
+
+func (d *Dashboard) renderTabs() string {
+	items := []string{}
+	for i, tab := range d.tabs {
+		item := fmt.Sprintf(" %d:%s ", i+1, tab.Name)
+		if i == d.current {
+			item = ANSI_REVERSE + item + ANSI_RESET
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, "  ")
+}
+
+func (d *Dashboard) renderTable() []string {
+	tab := d.Current()
+	if len(tab.Rows) == 0 {
+		message := tab.Message
+		if len(message) == 0 {
+			message = "(empty)"
+		}
+		return []string{"  " + message}
+	}
+
+	start, end := d.visible()
+	first, second := tuicommon.FormatTitle(tab.Title)
+	lines := [][]interface{}{first, second}
+	lines = append(lines, tab.Rows[start:end]...)
+	table := strings.Split(strings.TrimSuffix(tuicommon.FixedFormat(lines, 2), "\n"), "\n")
+
+	out := []string{}
+	for i, line := range table {
+		prefix := "  "
+		if i >= 2 && start+i-2 == d.cursors[d.current] {
+			prefix = "> "
+		}
+		out = append(out, prefix+line)
+	}
+	return out
+}
+
+func (d *Dashboard) renderHelp() string {
+	items := []string{"↑/↓ select", "←/→ switch", KEY_REFRESH + " refresh", KEY_QUIT + " quit"}
+	for _, a := range d.Current().Actions {
+		items = append(items, a.Key+" "+a.Name)
+	}
+	return strings.Join(items, "  ")
+}
+
+// Render returns the whole screen, lines are ended with "\r\n" for raw mode
+func (d *Dashboard) Render() string {
+	lines := []string{d.Header, "", d.renderTabs(), ""}
+	lines = append(lines, d.renderTable()...)
+	lines = append(lines, "", d.renderHelp())
+	if len(d.Message) > 0 {
+		lines = append(lines, d.Message)
+	}
+	return ANSI_CLEAR_SCREEN + strings.Join(lines, "\r\n")
+}
+
+// ReadKey reads one key press from terminal in raw mode
+func ReadKey(r *bufio.Reader) (string, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	switch b {
+	case 3:
+		return KEY_CTRL_C, nil
+	case '\t':
+		return KEY_TAB, nil
+	case '\r', '\n':
+		return KEY_ENTER, nil
+	case 0x1b:
+		// a single ESC or escape sequence, e.g: ESC [ A
+		if r.Buffered() < 2 {
+			return KEY_ESC, nil
+		}
+		seq := make([]byte, 2)
+		if _, err := r.Read(seq); err != nil {
+			return "", err
+		}
+		if seq[0] == '[' || seq[0] == 'O' {
+			switch seq[1] {
+			case 'A':
+				return KEY_UP, nil
+			case 'B':
+				return KEY_DOWN, nil
+			case 'C':
+				return KEY_RIGHT, nil
+			case 'D':
+				return KEY_LEFT, nil
+			}
+		}
+		return KEY_ESC, nil
+	}
+	return string(b), nil
+}
@@ -0,0 +1,128 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package dashboard
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDashboard() *Dashboard {
+	d := New([]*Tab{
+		{Name: "Services", Title: []string{"Id", "Role"}, Actions: []Action{{Key: "l", Name: "logs"}}},
+		{Name: "Disks", Title: []string{"Host", "Device"}},
+	})
+	d.SetRows("Services", [][]interface{}{
+		{"6ff561598c6f", "etcd"},
+		{"8a2b0c1d2e3f", "mds"},
+		{"9b3c1d2e3f4a", "chunkserver"},
+	}, "")
+	return d
+}
+
+func TestHandleKey(t *testing.T) {
+	assert := assert.New(t)
+	d := newTestDashboard()
+
+	assert.Equal(0, d.Selected())
+	d.HandleKey(KEY_DOWN)
+	d.HandleKey("j")
+	d.HandleKey(KEY_DOWN)
+	assert.Equal(2, d.Selected())
+	d.HandleKey(KEY_UP)
+	assert.Equal(1, d.Selected())
+
+	action, quit := d.HandleKey("l")
+	assert.Equal("l", action)
+	assert.False(quit)
+	action, _ = d.HandleKey("x")
+	assert.Equal("", action)
+	action, _ = d.HandleKey(KEY_REFRESH)
+	assert.Equal(KEY_REFRESH, action)
+
+	// switch tab, the action of other tab is ignored
+	d.HandleKey(KEY_RIGHT)
+	assert.Equal("Disks", d.Current().Name)
+	assert.Equal(-1, d.Selected())
+	action, _ = d.HandleKey("l")
+	assert.Equal("", action)
+	d.HandleKey(KEY_RIGHT)
+	assert.Equal("Services", d.Current().Name)
+	d.HandleKey("2")
+	assert.Equal("Disks", d.Current().Name)
+	d.HandleKey(KEY_LEFT)
+	assert.Equal("Services", d.Current().Name)
+	assert.Equal(1, d.Selected())
+
+	_, quit = d.HandleKey(KEY_QUIT)
+	assert.True(quit)
+}
+
+func TestSetRows(t *testing.T) {
+	assert := assert.New(t)
+	d := newTestDashboard()
+
+	d.HandleKey(KEY_DOWN)
+	d.HandleKey(KEY_DOWN)
+	d.SetRows("Services", [][]interface{}{{"6ff561598c6f", "etcd"}}, "")
+	assert.Equal(0, d.Selected())
+	d.SetRows("Services", nil, "no services")
+	assert.Equal(-1, d.Selected())
+	assert.Contains(d.Render(), "no services")
+}
+
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+	d := newTestDashboard()
+	d.Header = "CurveAdm Dashboard"
+	d.HandleKey(KEY_DOWN)
+
+	screen := d.Render()
+	assert.True(strings.HasPrefix(screen, ANSI_CLEAR_SCREEN+"CurveAdm Dashboard\r\n"))
+	assert.Contains(screen, "> 8a2b0c1d2e3f  mds")
+	assert.Contains(screen, "  6ff561598c6f  etcd")
+	assert.Contains(screen, "l logs")
+
+	// only rows around cursor are rendered if terminal is small
+	d.Height = RESERVED_LINES + 1
+	d.HandleKey(KEY_DOWN)
+	screen = d.Render()
+	assert.Contains(screen, "> 9b3c1d2e3f4a")
+	assert.NotContains(screen, "6ff561598c6f")
+}
+
+func TestReadKey(t *testing.T) {
+	assert := assert.New(t)
+
+	r := bufio.NewReader(strings.NewReader("\x1b[A\x1b[Bq\r\t\x03"))
+	for _, expected := range []string{KEY_UP, KEY_DOWN, "q", KEY_ENTER, KEY_TAB, KEY_CTRL_C} {
+		key, err := ReadKey(r)
+		assert.Nil(err)
+		assert.Equal(expected, key)
+	}
+	_, err := ReadKey(r)
+	assert.NotNil(err)
+}
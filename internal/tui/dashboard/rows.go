@@ -0,0 +1,156 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/storage"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+var (
+	CLUSTERS_TITLE   = []string{" ", "Cluster", "Create Time", "Description"}
+	SERVICES_TITLE   = []string{"Id", "Role", "Host", "Container Id", "Status", "Health"}
+	DISKS_TITLE      = []string{"Host", "Device", "MountPoint", "Percent", "Chunks", "Status", "Update Time"}
+	OPERATIONS_TITLE = []string{"Id", "Status", "Execute Time", "Operator", "Cluster", "Command"}
+
+	auditStatus = map[int]string{
+		comm.AUDIT_STATUS_ABORT:   "ABORT",
+		comm.AUDIT_STATUS_SUCCESS: "SUCCESS",
+		comm.AUDIT_STATUS_FAIL:    "FAIL",
+		comm.AUDIT_STATUS_CANCEL:  "CANCEL",
+	}
+)
+
+func decorate(message string, fn func(format string, a ...interface{}) string) interface{} {
+	return tuicommon.DecorateMessage{
+		Message:  message,
+		Decorate: func(s string) string { return fn("%s", s) },
+	}
+}
+
+// ClusterRows returns rows of clusters and their names in the same order
+func ClusterRows(clusters []storage.Cluster, current string) ([][]interface{}, []string) {
+	rows := [][]interface{}{}
+	names := []string{}
+	for _, cluster := range clusters {
+		row := []interface{}{" ", cluster.Name}
+		if cluster.Name == current {
+			row = []interface{}{decorate("*", color.GreenString), decorate(cluster.Name, color.GreenString)}
+		}
+		row = append(row, cluster.CreateTime.Format("2006-01-02 15:04:05"), cluster.Description)
+		rows = append(rows, row)
+		names = append(names, cluster.Name)
+	}
+	return rows, names
+}
+
+// ServiceRows returns rows of services sorted by role and host, and their ids
+func ServiceRows(statuses []task.ServiceStatus) ([][]interface{}, []string) {
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].Role == statuses[j].Role {
+			return statuses[i].Host < statuses[j].Host
+		}
+		return statuses[i].Role < statuses[j].Role
+	})
+
+	rows := [][]interface{}{}
+	ids := []string{}
+	for _, s := range statuses {
+		status := interface{}(s.Status)
+		if strings.HasPrefix(s.Status, "Up") {
+			status = decorate(s.Status, color.GreenString)
+		} else if len(s.Status) > 0 {
+			status = decorate(s.Status, color.RedString)
+		}
+		health := interface{}(utils.Choose(len(s.Health) > 0, s.Health, "-"))
+		if strings.HasPrefix(s.Health, task.SERVICE_HEALTH_UNHEALTHY) {
+			health = decorate(s.Health, color.RedString)
+		}
+		rows = append(rows, []interface{}{
+			s.Id, s.Role, s.Host, utils.Choose(len(s.ContainerId) > 0, s.ContainerId, "-"), status, health,
+		})
+		ids = append(ids, s.Id)
+	}
+	return rows, ids
+}
+
+func DiskRows(progresses []storage.FormatProgress) [][]interface{} {
+	sort.Slice(progresses, func(i, j int) bool {
+		if progresses[i].Host == progresses[j].Host {
+			return progresses[i].Device < progresses[j].Device
+		}
+		return progresses[i].Host < progresses[j].Host
+	})
+
+	rows := [][]interface{}{}
+	for _, p := range progresses {
+		percent := interface{}(fmt.Sprintf("%d%%", p.Percent))
+		if p.Percent < 100 {
+			percent = decorate(fmt.Sprintf("%d%%", p.Percent), color.YellowString)
+		}
+		rows = append(rows, []interface{}{
+			p.Host, p.Device, p.MountPoint, percent, strconv.Itoa(p.Chunks), p.Status,
+			p.UpdateTime.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return rows
+}
+
+// OperationRows returns rows of the latest n audit logs, the latest first
+func OperationRows(auditLogs []storage.AuditLog, n int) [][]interface{} {
+	if n > 0 && len(auditLogs) > n {
+		auditLogs = auditLogs[len(auditLogs)-n:]
+	}
+
+	rows := [][]interface{}{}
+	for i := len(auditLogs) - 1; i >= 0; i-- {
+		auditLog := auditLogs[i]
+		status := interface{}("UNKNOWN")
+		switch auditLog.Status {
+		case comm.AUDIT_STATUS_SUCCESS:
+			status = decorate(auditStatus[auditLog.Status], color.GreenString)
+		case comm.AUDIT_STATUS_FAIL:
+			status = decorate(auditStatus[auditLog.Status], color.RedString)
+		case comm.AUDIT_STATUS_ABORT, comm.AUDIT_STATUS_CANCEL:
+			status = decorate(auditStatus[auditLog.Status], color.YellowString)
+		}
+		rows = append(rows, []interface{}{
+			strconv.Itoa(auditLog.Id),
+			status,
+			auditLog.ExecuteTime.Format("2006-01-02 15:04:05"),
+			utils.Choose(len(auditLog.Operator) > 0, auditLog.Operator, "-"),
+			utils.Choose(len(auditLog.Cluster) > 0, auditLog.Cluster, "-"),
+			auditLog.Command,
+		})
+	}
+	return rows
+}
@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+var (
+	DOCTOR_SEVERITY_SCORE = map[string]int{
+		checker.DOCTOR_SEVERITY_CRITICAL: 0,
+		checker.DOCTOR_SEVERITY_WARNING:  1,
+	}
+)
+
+func doctorStatusDecorate(status string) string {
+	if status == checker.DOCTOR_STATUS_FAIL {
+		return color.RedString(status)
+	}
+	return color.GreenString(status)
+}
+
+func doctorSeverityDecorate(severity string) string {
+	if severity == checker.DOCTOR_SEVERITY_CRITICAL {
+		return color.RedString(severity)
+	}
+	return color.YellowString(severity)
+}
+
+// sort by: severity, host, item
+func SortDoctorResults(results []checker.DoctorResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		r1, r2 := results[i], results[j]
+		if r1.Severity != r2.Severity {
+			return DOCTOR_SEVERITY_SCORE[r1.Severity] < DOCTOR_SEVERITY_SCORE[r2.Severity]
+		} else if r1.Host != r2.Host {
+			return r1.Host < r2.Host
+		}
+		return r1.Item < r2.Item
+	})
+}
+
+func FormatDoctorResults(results []checker.DoctorResult) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Severity",
+		"Host",
+		"Item",
+		"Expect",
+		"Actual",
+		"Status",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	SortDoctorResults(results)
+	for _, result := range results {
+		lines = append(lines, []interface{}{
+			tuicommon.DecorateMessage{Message: result.Severity, Decorate: doctorSeverityDecorate},
+			result.Host,
+			result.Item,
+			result.Expect,
+			result.Actual,
+			tuicommon.DecorateMessage{Message: result.Status, Decorate: doctorStatusDecorate},
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+/*
+ * every line of output is prefixed by its host, e.g.
+ *
+ *   host1 | 10:00:00 up 3 days, load average: 0.00, 0.01, 0.05
+ *   host2 | 10:00:00 up 5 days, load average: 0.10, 0.11, 0.15
+ *   host3 | bash: uptime: command not found
+ *   host4 | <unreachable>
+ *
+ *   SUCCESS: 2, FAILED: 2 (host3,host4)
+ */
+func FormatExecHostResults(hosts []string, results map[string]task.ExecHostResult) string {
+	width := 0
+	for _, host := range hosts {
+		if len(host) > width {
+			width = len(host)
+		}
+	}
+
+	lines := []string{}
+	failed := []string{}
+	for _, host := range hosts {
+		result, ok := results[host]
+		if !ok { // connect failed, no result
+			result = task.ExecHostResult{Host: host, Success: false, Out: "<unreachable>"}
+		}
+		if !result.Success {
+			failed = append(failed, host)
+		}
+
+		prefix := fmt.Sprintf("%-*s |", width, host)
+		prefix = utils.Choose(result.Success, color.GreenString(prefix), color.RedString(prefix))
+		if len(result.Out) == 0 {
+			lines = append(lines, prefix)
+			continue
+		}
+		for _, line := range strings.Split(result.Out, "\n") {
+			lines = append(lines, fmt.Sprintf("%s %s", prefix, line))
+		}
+	}
+
+	summary := fmt.Sprintf("SUCCESS: %d, FAILED: %d", len(hosts)-len(failed), len(failed))
+	if len(failed) > 0 {
+		summary = fmt.Sprintf("%s (%s)", summary, color.RedString(strings.Join(failed, ",")))
+	}
+	lines = append(lines, "", summary)
+	return strings.Join(lines, "\n") + "\n"
+}
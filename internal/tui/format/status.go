@@ -23,12 +23,27 @@
 package format
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/task/task/bs"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 )
 
+const (
+	PROGRESS_BAR_WIDTH = 30
+
+	// a formatting disk is slow if its write rate is below the ratio of median
+	SLOW_RATE_RATIO = 0.5
+)
+
+var STATUS_TITLE = []string{"Host", "Device", "MountPoint", "Formatted", "Written", "Rate", "Status", "Chunks"}
+
 func sortStatues(statuses []bs.FormatStatus) {
 	sort.Slice(statuses, func(i, j int) bool {
 		s1, s2 := statuses[i], statuses[j]
@@ -39,28 +54,201 @@ func sortStatues(statuses []bs.FormatStatus) {
 	})
 }
 
-func FormatStatus(statuses []bs.FormatStatus) string {
-	lines := [][]interface{}{}
+func formatBytes(bytes uint64) string {
+	if bytes == 0 {
+		return "-"
+	}
+	return humanize.IBytes(bytes)
+}
 
-	// title
-	title := []string{"Host", "Device", "MountPoint", "Formatted", "Status"}
-	first, second := tui.FormatTitle(title)
-	lines = append(lines, first)
-	lines = append(lines, second)
+func formatRate(rate uint64) string {
+	if rate == 0 {
+		return "-"
+	}
+	return humanize.IBytes(rate) + "/s"
+}
+
+// progress of disk towards its format percent
+func progress(status bs.FormatStatus) int {
+	if status.Percent <= 0 || status.Usage >= status.Percent {
+		return 100
+	}
+	return status.Usage * 100 / status.Percent
+}
 
-	// status
+// FormatStatus formats status of disks, progress bars and summary of hosts,
+// the column Chunks is hidden unless --wide or specified in --columns
+func FormatStatus(statuses []bs.FormatStatus, options tui.TableOptions) string {
+	rows := [][]interface{}{}
 	sortStatues(statuses)
 	for _, status := range statuses {
-		line := []interface{}{
+		rows = append(rows, []interface{}{
 			status.Host,
 			status.Device,
 			status.MountPoint,
 			status.Formatted,
+			formatBytes(status.Written),
+			formatRate(status.Rate),
 			status.Status,
+			strconv.Itoa(status.Chunks),
+		})
+	}
+
+	output := tui.FormatTable(STATUS_TITLE, rows, []string{"Chunks"}, options)
+	return output + "\n" + formatProgressBars(statuses) + "\n" + formatSummary(statuses)
+}
+
+func medianRate(statuses []bs.FormatStatus) uint64 {
+	rates := []uint64{}
+	for _, status := range statuses {
+		if status.Status == "Formatting" && status.Rate > 0 {
+			rates = append(rates, status.Rate)
+		}
+	}
+	if len(rates) == 0 {
+		return 0
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+	return rates[len(rates)/2]
+}
+
+func slowDecorate(note string) string {
+	if note == "stalled" {
+		return color.RedString(note)
+	}
+	return color.YellowString(note)
+}
+
+// the disk which is formatting but writes nothing or much slower than others
+func slowNote(status bs.FormatStatus, median uint64) interface{} {
+	note := ""
+	if status.Status != "Formatting" {
+		return note
+	} else if status.Rate == 0 {
+		note = "stalled"
+	} else if float64(status.Rate) < float64(median)*SLOW_RATE_RATIO {
+		note = "slow"
+	} else {
+		return note
+	}
+	return tui.DecorateMessage{Message: note, Decorate: slowDecorate}
+}
+
+func progressBar(percent int) string {
+	filled := percent * PROGRESS_BAR_WIDTH / 100
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", PROGRESS_BAR_WIDTH-filled) + "]"
+}
+
+/*
+ * progress bars of disks stacked by host, e.g:
+ *
+ *   host1
+ *     /dev/sdb  [###############...............]   50%  4.5 GiB  120 MiB/s
+ *     /dev/sdc  [######........................]   20%  1.8 GiB  12 MiB/s   slow
+ */
+func formatProgressBars(statuses []bs.FormatStatus) string {
+	lines := [][]interface{}{}
+	median := medianRate(statuses)
+	for i, status := range statuses { // statuses already sorted by host
+		if i == 0 || statuses[i-1].Host != status.Host {
+			lines = append(lines, []interface{}{status.Host, "", "", "", "", ""})
+		}
+		lines = append(lines, []interface{}{
+			"  " + status.Device,
+			progressBar(progress(status)),
+			fmt.Sprintf("%3d%%", progress(status)),
+			formatBytes(status.Written),
+			formatRate(status.Rate),
+			slowNote(status, median),
+		})
+	}
+	return tui.FixedFormat(lines, 2)
+}
+
+type summary struct {
+	host    string
+	disks   int
+	done    int
+	usage   int
+	percent int
+}
+
+func (s *summary) add(status bs.FormatStatus) {
+	s.disks++
+	if status.Status == "Done" {
+		s.done++
+	}
+	usage := status.Usage
+	if usage > status.Percent {
+		usage = status.Percent
+	}
+	s.usage += usage
+	s.percent += status.Percent
+}
+
+func (s *summary) line() []interface{} {
+	percent := 100
+	if s.percent > 0 {
+		percent = s.usage * 100 / s.percent
+	}
+	return []interface{}{
+		s.host,
+		strconv.Itoa(s.disks),
+		strconv.Itoa(s.done),
+		fmt.Sprintf("%d%%", percent),
+	}
+}
+
+// aggregate formatting progress of all disks for each host
+func formatSummary(statuses []bs.FormatStatus) string {
+	lines := [][]interface{}{}
+	title := []string{"Host", "Disks", "Done", "Progress"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	hosts := []*summary{}
+	total := &summary{host: "TOTAL"}
+	for _, status := range statuses { // statuses already sorted by host
+		if len(hosts) == 0 || hosts[len(hosts)-1].host != status.Host {
+			hosts = append(hosts, &summary{host: status.Host})
 		}
-		lines = append(lines, line)
+		hosts[len(hosts)-1].add(status)
+		total.add(status)
+	}
+	for _, s := range hosts {
+		lines = append(lines, s.line())
+	}
+	lines = append(lines, total.line())
+
+	return tui.FixedFormat(lines, 2)
+}
+
+func FormatProgresses(progresses []storage.FormatProgress) string {
+	lines := [][]interface{}{}
+	title := []string{"Host", "Device", "MountPoint", "Percent", "Chunks", "Status", "Update Time"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(progresses, func(i, j int) bool {
+		p1, p2 := progresses[i], progresses[j]
+		if p1.Host == p2.Host {
+			return p1.Device < p2.Device
+		}
+		return p1.Host < p2.Host
+	})
+	for _, progress := range progresses {
+		lines = append(lines, []interface{}{
+			progress.Host,
+			progress.Device,
+			progress.MountPoint,
+			fmt.Sprintf("%d%%", progress.Percent),
+			strconv.Itoa(progress.Chunks),
+			progress.Status,
+			progress.UpdateTime.Format("2006-01-02 15:04:05"),
+		})
 	}
 
-	output := tui.FixedFormat(lines, 2)
-	return output
+	return tui.FixedFormat(lines, 2)
 }
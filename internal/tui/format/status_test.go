@@ -0,0 +1,64 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/i18n"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatProgressBars(t *testing.T) {
+	assert := assert.New(t)
+	statuses := []bs.FormatStatus{
+		{Host: "host1", Device: "/dev/sdb", Status: "Formatting", Usage: 45, Percent: 90, Rate: 100 << 20},
+		{Host: "host1", Device: "/dev/sdc", Status: "Formatting", Usage: 9, Percent: 90, Rate: 10 << 20},
+		{Host: "host2", Device: "/dev/sdb", Status: "Formatting", Usage: 18, Percent: 90},
+		{Host: "host2", Device: "/dev/sdc", Status: "Done", Usage: 90, Percent: 90},
+	}
+
+	lines := strings.Split(strings.TrimSuffix(formatProgressBars(statuses), "\n"), "\n")
+	assert.Len(lines, 6)
+	assert.Equal("host1", strings.TrimSpace(lines[0]))
+	assert.Contains(lines[1], "[###############...............]   50%")
+	assert.Contains(lines[1], "100 MiB/s")
+	assert.NotContains(lines[1], "slow")
+	assert.Contains(lines[2], " 10%")
+	assert.Contains(lines[2], "slow")
+	assert.Equal("host2", strings.TrimSpace(lines[3]))
+	assert.Contains(lines[4], "stalled")
+	assert.Contains(lines[5], "[##############################]  100%")
+}
+
+func TestStatusTitleTranslated(t *testing.T) {
+	assert := assert.New(t)
+	defer i18n.SetLanguage(i18n.Language())
+
+	i18n.SetLanguage(i18n.LANG_ZH)
+	for _, item := range STATUS_TITLE {
+		assert.NotEqual(item, i18n.T(item), item)
+	}
+}
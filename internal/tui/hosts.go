@@ -25,10 +25,17 @@
 package tui
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 	configure "github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
 	"github.com/opencurve/curveadm/internal/tui/common"
 	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
@@ -38,23 +45,21 @@ const (
 	FIELD_LIMIT_LENGTH = 30
 )
 
-func FormatHosts(hcs []*configure.HostConfig, verbose bool) string {
-	lines := [][]interface{}{}
-	title := []string{
-		"Host",
-		"Hostname",
-		"User",
-		"Port",
-		"Private Key File",
-		"Forward Agent",
-		"Become User",
-		"Labels",
-		"Envs",
-	}
-	first, second := tuicommon.FormatTitle(title)
-	lines = append(lines, first)
-	lines = append(lines, second)
+var HOSTS_TITLE = []string{
+	"Host",
+	"Hostname",
+	"User",
+	"Port",
+	"Private Key File",
+	"Forward Agent",
+	"Become User",
+	"Labels",
+	"Groups",
+	"Envs",
+}
 
+func FormatHosts(hcs []*configure.HostConfig, verbose bool, options tuicommon.TableOptions) string {
+	rows := [][]interface{}{}
 	for i := 0; i < len(hcs); i++ {
 		hc := hcs[i]
 
@@ -65,15 +70,16 @@ func FormatHosts(hcs []*configure.HostConfig, verbose bool) string {
 		forwardAgent := utils.Choose(hc.GetForwardAgent(), "Y", "N")
 		becomeUser := utils.Choose(len(hc.GetBecomeUser()) > 0, hc.GetBecomeUser(), "-")
 		labels := utils.Choose(len(hc.GetLabels()) > 0, strings.Join(hc.GetLabels(), ","), "-")
+		groups := utils.Choose(len(hc.GetGroups()) > 0, strings.Join(hc.GetGroups(), ","), "-")
 		envs := utils.Choose(len(hc.GetEnvs()) > 0, strings.Join(hc.GetEnvs(), ","), "-")
 		privateKeyFile := hc.GetPrivateKeyFile()
 		if len(privateKeyFile) == 0 {
 			privateKeyFile = "-"
-		} else if !verbose && len(hc.GetPrivateKeyFile()) > FIELD_LIMIT_LENGTH {
+		} else if !verbose && !options.Wide && len(hc.GetPrivateKeyFile()) > FIELD_LIMIT_LENGTH {
 			privateKeyFile = privateKeyFile[:FIELD_LIMIT_LENGTH] + "..."
 		}
 
-		lines = append(lines, []interface{}{
+		rows = append(rows, []interface{}{
 			host,
 			hostname,
 			user,
@@ -82,9 +88,148 @@ func FormatHosts(hcs []*configure.HostConfig, verbose bool) string {
 			forwardAgent,
 			becomeUser,
 			labels,
+			groups,
 			envs,
 		})
 	}
 
+	return tuicommon.FormatTable(HOSTS_TITLE, rows, nil, options)
+}
+
+func formatNICs(nics []step.NIC) string {
+	items := []string{}
+	for _, nic := range nics {
+		speed := "unknown"
+		if nic.Speed > 0 {
+			speed = fmt.Sprintf("%dMb/s", nic.Speed)
+		}
+		items = append(items, fmt.Sprintf("%s:%s", nic.Name, speed))
+	}
+	return utils.Choose(len(items) > 0, strings.Join(items, ","), "-")
+}
+
+func formatDisks(devices []step.BlockDevice) string {
+	items := []string{}
+	for _, device := range devices {
+		if device.Type != "disk" {
+			continue
+		}
+		media := utils.Choose(device.Rotational, "hdd", "ssd")
+		items = append(items, fmt.Sprintf("%s:%s(%s)", device.Name, humanize.IBytes(device.Size), media))
+	}
+	return utils.Choose(len(items) > 0, strings.Join(items, ","), "-")
+}
+
+// FormatHostFacts shows the facts of hosts in order, the host which facts not gathered displays "-"
+func FormatHostFacts(hosts []string, facts map[string]checker.StoredHostFacts, verbose bool) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Host",
+		"OS",
+		"Kernel",
+		"Arch",
+		"CPU",
+		"Memory",
+		"NIC",
+		"Disk",
+		"Update Time",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, host := range hosts {
+		item, ok := facts[host]
+		if !ok {
+			lines = append(lines, []interface{}{host, "-", "-", "-", "-", "-", "-", "-", "-"})
+			continue
+		}
+
+		f := item.Facts
+		cpu := strconv.Itoa(f.CPUs)
+		if len(f.CPUModel) > 0 {
+			model := f.CPUModel
+			if !verbose && len(model) > FIELD_LIMIT_LENGTH {
+				model = model[:FIELD_LIMIT_LENGTH] + "..."
+			}
+			cpu = fmt.Sprintf("%d x %s", f.CPUs, model)
+		}
+		lines = append(lines, []interface{}{
+			host,
+			strings.TrimSpace(fmt.Sprintf("%s %s", f.OS, f.OSVersion)),
+			f.KernelRelease,
+			f.Arch,
+			cpu,
+			humanize.IBytes(f.Memory * 1024),
+			formatNICs(f.NICs),
+			formatDisks(f.BlockDevices),
+			item.UpdateTime.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return common.FixedFormat(lines, 2)
+}
+
+func pingDecorate(message string) string {
+	if message == "N" {
+		return color.RedString(message)
+	}
+	return message
+}
+
+func formatPingItem(checked, ok bool) interface{} {
+	if !checked {
+		return "-"
+	}
+	return tuicommon.DecorateMessage{Message: utils.Choose(ok, "Y", "N"), Decorate: pingDecorate}
+}
+
+func formatDuration(d time.Duration, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return d.Round(100 * time.Microsecond).String()
+}
+
+// FormatPingHostResults shows the ping result of hosts in order, the host without result displays "-"
+func FormatPingHostResults(hosts []string, results map[string]task.PingHostResult) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Host",
+		"Address",
+		"Reachable",
+		"Auth",
+		"Sudo",
+		"Connect",
+		"RTT",
+		"Error",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, host := range hosts {
+		result, ok := results[host]
+		if !ok {
+			lines = append(lines, []interface{}{host, "-", "-", "-", "-", "-", "-", "-"})
+			continue
+		}
+
+		errMessage := utils.Choose(len(result.Error) > 0, result.Error, "-")
+		if result.KeyFixed {
+			errMessage = "host key refreshed"
+		}
+		lines = append(lines, []interface{}{
+			host,
+			result.Address,
+			formatPingItem(true, result.Reachable),
+			formatPingItem(result.Reachable, result.Authenticated),
+			formatPingItem(result.RTT > 0, result.Sudo),
+			formatDuration(result.Connect, result.Authenticated),
+			formatDuration(result.RTT, result.RTT > 0),
+			errMessage,
+		})
+	}
+
 	return common.FixedFormat(lines, 2)
 }
@@ -0,0 +1,60 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func lintSeverityDecorate(severity string) string {
+	if severity == checker.LINT_SEVERITY_ERROR {
+		return color.RedString(severity)
+	}
+	return color.YellowString(severity)
+}
+
+func FormatLintIssues(issues []checker.LintIssue) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Severity",
+		"Rule",
+		"Target",
+		"Message",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, issue := range issues {
+		lines = append(lines, []interface{}{
+			tuicommon.DecorateMessage{Message: issue.Severity, Decorate: lintSeverityDecorate},
+			issue.Rule,
+			issue.Target,
+			issue.Message,
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/plugin"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	sdk "github.com/opencurve/curveadm/pkg/plugin"
+)
+
+type PluginItem struct {
+	Plugin   plugin.Plugin
+	Manifest *sdk.Manifest // nil if the manifest can't be got
+}
+
+func joinOrDash(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ",")
+}
+
+func FormatPlugins(items []PluginItem, verbose bool) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Name",
+		"Version",
+		"Prechecks",
+		"Steps",
+		"Path",
+		"Description",
+	}
+	if verbose {
+		title = append(title, "Shadowed")
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, item := range items {
+		version, prechecks, steps, short := color.RedString("<invalid>"), "-", "-", "-"
+		if item.Manifest != nil {
+			version = item.Manifest.Version
+			prechecks = joinOrDash(item.Manifest.Prechecks)
+			steps = joinOrDash(item.Manifest.Steps)
+			short = item.Manifest.Short
+		}
+		line := []interface{}{
+			item.Plugin.Name,
+			version,
+			prechecks,
+			steps,
+			item.Plugin.Path,
+			short,
+		}
+		if verbose {
+			line = append(line, joinOrDash(item.Plugin.Shadowed))
+		}
+		lines = append(lines, line)
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
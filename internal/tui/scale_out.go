@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/configure"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func formatChange(old, new int) interface{} {
+	if old == new {
+		return fmt.Sprintf("%d", new)
+	}
+	return tuicommon.DecorateMessage{
+		Message: fmt.Sprintf("%d -> %d", old, new),
+		Decorate: func(message string) string {
+			return color.GreenString(message)
+		},
+	}
+}
+
+func FormatScaleOutPlan(plan configure.ScaleOutPlan) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Pool",
+		"Zone",
+		"Hosts",
+		"Services",
+		"Copysets",
+		"Data Movement",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, pool := range plan.Pools {
+		for i, zone := range pool.Zones {
+			name, copysets, movement := "", "", ""
+			if i == 0 { // only show pool summary in the first zone
+				name = pool.Name
+				copysets = fmt.Sprintf("%d", pool.NewCopysets)
+				if pool.NewCopysets != pool.OldCopysets {
+					copysets = fmt.Sprintf("%d (+%d)", pool.NewCopysets, pool.NewCopysets-pool.OldCopysets)
+				}
+				movement = fmt.Sprintf("%.1f%%", pool.MovementRatio*100)
+			}
+			lines = append(lines, []interface{}{
+				name,
+				zone.Zone,
+				fmt.Sprintf("%d", zone.Hosts),
+				formatChange(zone.OldServices, zone.NewServices),
+				copysets,
+				movement,
+			})
+		}
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
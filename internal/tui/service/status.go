@@ -50,6 +50,7 @@ const (
 	ITEM_ID = iota
 	ITEM_CONTAINER_ID
 	ITEM_STATUS
+	ITEM_HEALTH
 	ITEM_PORTS
 	ITEM_LOG_DIR
 	ITEM_DATA_DIR
@@ -64,6 +65,19 @@ const (
 )
 
 var (
+	STATUS_TITLE = []string{
+		"Id",
+		"Role",
+		"Host",
+		"Instances",
+		"Container Id",
+		"Status",
+		"Health",
+		"Ports",
+		"Log Dir",
+		"Data Dir",
+	}
+
 	ROLE_SCORE = map[string]int{
 		ROLE_ETCD:          0,
 		ROLE_MDS:           1,
@@ -88,6 +102,15 @@ func statusDecorate(status string) string {
 	return status
 }
 
+func healthDecorate(health string) string {
+	if strings.HasPrefix(health, task.SERVICE_HEALTH_UNHEALTHY) {
+		return color.RedString(health)
+	} else if strings.HasPrefix(health, task.SERVICE_HEALTH_HEALTHY) {
+		return color.GreenString(health)
+	}
+	return health
+}
+
 func sortStatues(statuses []task.ServiceStatus) {
 	sort.Slice(statuses, func(i, j int) bool {
 		s1, s2 := statuses[i], statuses[j]
@@ -133,6 +156,20 @@ func status(items []string) string {
 	return STATUS_ABNORMAL
 }
 
+// the merged health is healthy only if all instances are healthy
+func health(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+
+	for _, item := range items {
+		if !strings.HasPrefix(item, task.SERVICE_HEALTH_HEALTHY) {
+			return task.SERVICE_HEALTH_UNHEALTHY
+		}
+	}
+	return task.SERVICE_HEALTH_HEALTHY
+}
+
 func dir(items []string) string {
 	if len(items) == 1 {
 		return items[0]
@@ -155,6 +192,8 @@ func merge(statuses []task.ServiceStatus, item int) string {
 			items = append(items, status.ContainerId)
 		case ITEM_STATUS:
 			items = append(items, status.Status)
+		case ITEM_HEALTH:
+			items = append(items, status.Health)
 		case ITEM_PORTS:
 			items = append(items, status.Ports)
 		case ITEM_LOG_DIR:
@@ -172,6 +211,8 @@ func merge(statuses []task.ServiceStatus, item int) string {
 		return id(items)
 	case ITEM_STATUS:
 		return status(items)
+	case ITEM_HEALTH:
+		return health(items)
 	case ITEM_PORTS:
 		return id(items)
 	case ITEM_LOG_DIR:
@@ -196,6 +237,7 @@ func mergeStatues(statuses []task.ServiceStatus) []task.ServiceStatus {
 			Instances:   fmt.Sprintf("%d/%s", j-i, strings.Split(status.Instances, "/")[1]),
 			ContainerId: merge(statuses[i:j], ITEM_CONTAINER_ID),
 			Status:      merge(statuses[i:j], ITEM_STATUS),
+			Health:      merge(statuses[i:j], ITEM_HEALTH),
 			Ports:       merge(statuses[i:j], ITEM_PORTS),
 			LogDir:      merge(statuses[i:j], ITEM_LOG_DIR),
 			DataDir:     merge(statuses[i:j], ITEM_DATA_DIR),
@@ -205,24 +247,8 @@ func mergeStatues(statuses []task.ServiceStatus) []task.ServiceStatus {
 	return ss
 }
 
-func FormatStatus(statuses []task.ServiceStatus, verbose, expand bool) string {
-	lines := [][]interface{}{}
-
-	// title
-	title := []string{
-		"Id",
-		"Role",
-		"Host",
-		"Instances",
-		"Container Id",
-		"Status",
-		"Ports",
-		"Log Dir",
-		"Data Dir",
-	}
-	first, second := tui.FormatTitle(title)
-	lines = append(lines, first)
-	lines = append(lines, second)
+func FormatStatus(statuses []task.ServiceStatus, verbose, expand, deep bool, options tui.TableOptions) string {
+	rows := [][]interface{}{}
 
 	// status
 	sortStatues(statuses)
@@ -230,29 +256,29 @@ func FormatStatus(statuses []task.ServiceStatus, verbose, expand bool) string {
 		statuses = mergeStatues(statuses)
 	}
 	for _, status := range statuses {
-		lines = append(lines, []interface{}{
+		rows = append(rows, []interface{}{
 			status.Id,
 			status.Role,
 			status.Host,
 			status.Instances,
 			status.ContainerId,
 			tui.DecorateMessage{Message: status.Status, Decorate: statusDecorate},
+			tui.DecorateMessage{Message: utils.Choose(len(status.Health) == 0, "-", status.Health), Decorate: healthDecorate},
 			utils.Choose(len(status.Ports) == 0, "-", status.Ports),
 			status.LogDir,
 			status.DataDir,
 		})
 	}
 
-	// cut column
-	locate := utils.Locate(title)
+	// hidden columns, displayed by --wide or --columns
+	hidden := []string{}
 	if !verbose {
-		tui.CutColumn(lines, locate["Ports"])    // Data Dir
-		tui.CutColumn(lines, locate["Data Dir"]) // Data Dir
-		tui.CutColumn(lines, locate["Log Dir"])  // Log Dir
+		hidden = append(hidden, "Ports", "Log Dir", "Data Dir")
 	}
-
-	output := tui.FixedFormat(lines, 2)
-	return output
+	if !deep {
+		hidden = append(hidden, "Health") // "-" if not queried
+	}
+	return tui.FormatTable(STATUS_TITLE, rows, hidden, options)
 }
 
 func sortMonitorStatues(statuses []monitor.MonitorStatus) {
@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func httpDecorate(message string) string {
+	if message == "OK" {
+		return color.GreenString(message)
+	}
+	return color.RedString(message)
+}
+
+func jobStatusDecorate(status string) string {
+	if strings.HasPrefix(status, "error") {
+		return color.RedString(status)
+	}
+	return color.YellowString(status)
+}
+
+func FormatSnapshotCloneStatus(statuses []bs.SnapshotCloneStatus) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Id",
+		"Host",
+		"Address",
+		"HTTP",
+		"Pending Snapshots",
+		"Cloning",
+		"Failed",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Addr < statuses[j].Addr
+	})
+	for _, status := range statuses {
+		http, pending, cloning, failed := "OK", "-", "-", "-"
+		if status.Running {
+			pending = strconv.Itoa(status.Pending)
+			cloning = strconv.Itoa(status.Cloning)
+			failed = strconv.Itoa(status.Failed)
+		} else {
+			http = "Down"
+		}
+		lines = append(lines, []interface{}{
+			status.Id,
+			status.Host,
+			status.Addr,
+			tuicommon.DecorateMessage{Message: http, Decorate: httpDecorate},
+			pending,
+			cloning,
+			failed,
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+// sort by: time
+func FormatSnapshotCloneJobs(jobs []bs.SnapshotCloneJob) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"UUID",
+		"Type",
+		"User",
+		"Source",
+		"Target",
+		"Progress",
+		"Status",
+		"Create Time",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Time < jobs[j].Time
+	})
+	for _, job := range jobs {
+		lines = append(lines, []interface{}{
+			job.UUID,
+			job.Type,
+			job.User,
+			job.Source,
+			job.Target,
+			fmt.Sprintf("%d%%", job.Progress),
+			tuicommon.DecorateMessage{Message: job.Status, Decorate: jobStatusDecorate},
+			time.Unix(job.Time/1000000, 0).Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
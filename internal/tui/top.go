@@ -0,0 +1,175 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	SPACE_USAGE_WARNING_PERCENT = 80
+)
+
+func formatIOPS(read, write float64) string {
+	return fmt.Sprintf("%.0f/%.0f", read, write)
+}
+
+func formatBandwidth(read, write float64) string {
+	return fmt.Sprintf("%s/%s", humanize.IBytes(uint64(read)), humanize.IBytes(uint64(write)))
+}
+
+func formatLatency(read, write float64) string {
+	return fmt.Sprintf("%.0f/%.0f", read, write)
+}
+
+func formatSpace(used, total uint64) interface{} {
+	if total == 0 {
+		return "-"
+	}
+	percent := float64(used) * 100 / float64(total)
+	return tuicommon.DecorateMessage{
+		Message: fmt.Sprintf("%s/%s (%.1f%%)", humanize.IBytes(used), humanize.IBytes(total), percent),
+		Decorate: func(message string) string {
+			if percent >= SPACE_USAGE_WARNING_PERCENT {
+				return color.YellowString(message)
+			}
+			return message
+		},
+	}
+}
+
+// the iops, bandwidth and space are summed, and the latency is averaged
+func aggregateByPool(metrics []task.ServiceMetrics) []task.ServiceMetrics {
+	pools := []task.ServiceMetrics{}
+	index := map[string]int{}
+	count := map[string]int{}
+	for _, m := range metrics {
+		if !m.Reachable {
+			continue
+		}
+		if _, ok := index[m.Pool]; !ok {
+			index[m.Pool] = len(pools)
+			pools = append(pools, task.ServiceMetrics{Pool: m.Pool})
+		}
+		p := &pools[index[m.Pool]]
+		p.ReadIOPS += m.ReadIOPS
+		p.WriteIOPS += m.WriteIOPS
+		p.ReadBPS += m.ReadBPS
+		p.WriteBPS += m.WriteBPS
+		p.ReadLatency += m.ReadLatency
+		p.WriteLatency += m.WriteLatency
+		p.SpaceUsed += m.SpaceUsed
+		p.SpaceTotal += m.SpaceTotal
+		count[m.Pool]++
+	}
+
+	for i := range pools {
+		n := float64(count[pools[i].Pool])
+		pools[i].ReadLatency /= n
+		pools[i].WriteLatency /= n
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Pool < pools[j].Pool
+	})
+	return pools
+}
+
+func FormatTopPools(metrics []task.ServiceMetrics) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Pool",
+		"IOPS (r/w)",
+		"Bandwidth (r/w)",
+		"Latency us (r/w)",
+		"Space",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, p := range aggregateByPool(metrics) {
+		lines = append(lines, []interface{}{
+			p.Pool,
+			formatIOPS(p.ReadIOPS, p.WriteIOPS),
+			formatBandwidth(p.ReadBPS, p.WriteBPS),
+			formatLatency(p.ReadLatency, p.WriteLatency),
+			formatSpace(p.SpaceUsed, p.SpaceTotal),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+// sort by: pool, host, id
+func FormatTopServices(metrics []task.ServiceMetrics) string {
+	lines := [][]interface{}{}
+	title := []string{
+		"Id",
+		"Role",
+		"Host",
+		"Pool",
+		"IOPS (r/w)",
+		"Bandwidth (r/w)",
+		"Latency us (r/w)",
+		"Space",
+	}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(metrics, func(i, j int) bool {
+		m1, m2 := metrics[i], metrics[j]
+		if m1.Pool != m2.Pool {
+			return m1.Pool < m2.Pool
+		} else if m1.Host != m2.Host {
+			return m1.Host < m2.Host
+		}
+		return m1.Id < m2.Id
+	})
+	for _, m := range metrics {
+		iops, bandwidth, latency := "-", "-", "-"
+		if m.Reachable {
+			iops = formatIOPS(m.ReadIOPS, m.WriteIOPS)
+			bandwidth = formatBandwidth(m.ReadBPS, m.WriteBPS)
+			latency = formatLatency(m.ReadLatency, m.WriteLatency)
+		}
+		lines = append(lines, []interface{}{
+			m.Id,
+			m.Role,
+			m.Host,
+			m.Pool,
+			iops,
+			bandwidth,
+			latency,
+			formatSpace(m.SpaceUsed, m.SpaceTotal),
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
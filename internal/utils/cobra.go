@@ -28,6 +28,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
@@ -37,14 +38,13 @@ import (
 
 const (
 	PREFIX_COBRA_COMMAND_ERROR = "Error:\n"
+
+	FLAG_WATCH             = "watch"
+	DEFAULT_WATCH_INTERVAL = 2 * time.Second
 )
 
 var (
-	NoArgs            = cli.NoArgs
-	RequiresMinArgs   = cli.RequiresMinArgs
-	RequiresMaxArgs   = cli.RequiresMaxArgs
-	RequiresRangeArgs = cli.RequiresRangeArgs
-	ExactArgs         = cli.ExactArgs
+	NoArgs = usageArgs(cli.NoArgs)
 
 	ShowHelp = command.ShowHelp
 )
@@ -101,6 +101,45 @@ Run '{{.CommandPath}} COMMAND --help' for more information on a command.
 `
 )
 
+// UsageError is the error returned by command line parser, e.g. unknown command,
+// unknown flag or wrong number of arguments
+type UsageError struct {
+	err error
+}
+
+func NewUsageError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &UsageError{err: err}
+}
+
+func (e *UsageError) Error() string { return e.err.Error() }
+
+func (e *UsageError) Unwrap() error { return e.err }
+
+func usageArgs(fn cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		return NewUsageError(fn(cmd, args))
+	}
+}
+
+func RequiresMinArgs(min int) cobra.PositionalArgs {
+	return usageArgs(cli.RequiresMinArgs(min))
+}
+
+func RequiresMaxArgs(max int) cobra.PositionalArgs {
+	return usageArgs(cli.RequiresMaxArgs(max))
+}
+
+func RequiresRangeArgs(min int, max int) cobra.PositionalArgs {
+	return usageArgs(cli.RequiresRangeArgs(min, max))
+}
+
+func ExactArgs(number int) cobra.PositionalArgs {
+	return usageArgs(cli.ExactArgs(number))
+}
+
 func managementSubCommands(cmd *cobra.Command) []*cobra.Command {
 	cmds := []*cobra.Command{}
 	for _, subCmd := range cmd.Commands() {
@@ -145,7 +184,7 @@ func SetFlagErrorFunc(cmd *cobra.Command) {
 			return nil
 		}
 
-		return errors.New(fmt.Sprintf("%s\nSee '%s --help'.", err, cmd.CommandPath()))
+		return NewUsageError(errors.New(fmt.Sprintf("%s\nSee '%s --help'.", err, cmd.CommandPath())))
 	})
 }
 
@@ -163,6 +202,29 @@ func SetUsageTemplate(cmd *cobra.Command) {
 	cmd.SetUsageTemplate(usageTemplate)
 }
 
+// AddWatchFlag adds flag '--watch [interval]' which refreshes output periodically,
+// the interval is DEFAULT_WATCH_INTERVAL if not specified, use WatchArgs together.
+func AddWatchFlag(cmd *cobra.Command, watch *time.Duration) {
+	flags := cmd.Flags()
+	flags.DurationVar(watch, FLAG_WATCH, 0,
+		fmt.Sprintf("Refresh output by the interval until interrupted (default interval: %s)", DEFAULT_WATCH_INTERVAL))
+	flags.Lookup(FLAG_WATCH).NoOptDefVal = DEFAULT_WATCH_INTERVAL.String()
+}
+
+// WatchArgs is NoArgs except accepting '--watch 5s' besides '--watch=5s',
+// the optional value of flag must be joined with '=' in pflag
+func WatchArgs(watch *time.Duration) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 && cmd.Flags().Changed(FLAG_WATCH) {
+			if interval, err := time.ParseDuration(args[0]); err == nil {
+				*watch = interval
+				return nil
+			}
+		}
+		return NoArgs(cmd, args)
+	}
+}
+
 func SetErr(cmd *cobra.Command, writer io.Writer) {
 	cmd.SetErr(writer)
 }
@@ -146,6 +146,43 @@ func TrimSuffixRepeat(s, suffix string) string {
 	return s
 }
 
+// SplitArgs splits command line into arguments by whitespace like shell,
+// the single or double quoted string is one argument, e.g:
+//
+//	status --role 'chunkserver' --host "host 1"  =>  [status --role chunkserver --host host 1]
+func SplitArgs(s string) ([]string, error) {
+	args := []string{}
+	var arg strings.Builder
+	var quote rune
+	inArg := false
+	for _, c := range s {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(c)
+		case c == '\'' || c == '"':
+			quote, inArg = c, true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(c)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote %c", quote)
+	} else if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
 func Min(nums ...int) int {
 	ret := nums[0]
 	for _, num := range nums {
@@ -250,7 +287,12 @@ func GetCurrentHomeDir() string {
 	return user.HomeDir
 }
 
+// IsValidAddress returns true if address is an IPv4 or IPv6 address
 func IsValidAddress(address string) bool {
+	if IsIPv6(address) {
+		return true
+	}
+
 	regex, err := regexp.Compile(REGEX_IP)
 	if err != nil {
 		return false
@@ -0,0 +1,50 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitArgs(t *testing.T) {
+	assert := assert.New(t)
+	for _, tc := range []struct {
+		s    string
+		args []string
+	}{
+		{"", []string{}},
+		{"  status  --verbose ", []string{"status", "--verbose"}},
+		{`exec --host "host 1" -- 'ls -l'`, []string{"exec", "--host", "host 1", "--", "ls -l"}},
+		{`--label=''`, []string{"--label="}},
+		{`''`, []string{""}},
+	} {
+		args, err := SplitArgs(tc.s)
+		assert.Nil(err)
+		assert.Equal(tc.args, args, tc.s)
+	}
+
+	_, err := SplitArgs(`status --host "host1`)
+	assert.Error(err)
+}
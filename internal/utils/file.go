@@ -72,12 +72,13 @@ func GetFilePermissions(path string) int {
 	return int(info.Mode())
 }
 
+// ReadFile reads local file which maybe edited in Windows, so line endings are normalized
 func ReadFile(filename string) (string, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return "", err
 	}
-	return string(data), nil
+	return NormalizeLineEndings(string(data)), nil
 }
 
 func WriteFile(filename, data string, mode int) error {
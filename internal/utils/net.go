@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package utils
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+/*
+ * IPv6 address must be bracketed when it's followed by port, e.g:
+ *   10.0.0.1:6666
+ *   [fd00::1]:6666
+ */
+const (
+	IP_FAMILY_IPV4 = "ipv4"
+	IP_FAMILY_IPV6 = "ipv6"
+
+	ANY_ADDRESS_IPV4 = "0.0.0.0"
+	ANY_ADDRESS_IPV6 = "::"
+)
+
+func trimBracket(host string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+}
+
+// IsIPv6 returns true if host is an IPv6 address (bracketed or not)
+func IsIPv6(host string) bool {
+	ip := net.ParseIP(trimBracket(host))
+	return ip != nil && ip.To4() == nil
+}
+
+// IPFamily returns "ipv4" or "ipv6" for IP address, empty for hostname
+func IPFamily(host string) string {
+	ip := net.ParseIP(trimBracket(host))
+	if ip == nil {
+		return ""
+	} else if ip.To4() != nil {
+		return IP_FAMILY_IPV4
+	}
+	return IP_FAMILY_IPV6
+}
+
+// BracketHost returns "[fd00::1]" for IPv6 address, otherwise host itself
+func BracketHost(host string) string {
+	if IsIPv6(host) {
+		return "[" + trimBracket(host) + "]"
+	}
+	return host
+}
+
+// JoinHostPort returns "host:port", the IPv6 address bracketed
+func JoinHostPort(host string, port int) string {
+	return net.JoinHostPort(trimBracket(host), strconv.Itoa(port))
+}
+
+// AnyAddress returns wildcard address which has the same family as host
+func AnyAddress(host string) string {
+	if IsIPv6(host) {
+		return ANY_ADDRESS_IPV6
+	}
+	return ANY_ADDRESS_IPV4
+}
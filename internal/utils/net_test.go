@@ -0,0 +1,67 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPFamily(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(IP_FAMILY_IPV4, IPFamily("10.0.0.1"))
+	assert.Equal(IP_FAMILY_IPV6, IPFamily("fd00::1"))
+	assert.Equal(IP_FAMILY_IPV6, IPFamily("[fd00::1]"))
+	assert.Equal("", IPFamily("host1"))
+	assert.True(IsIPv6("::1"))
+	assert.False(IsIPv6("::ffff:10.0.0.1")) // IPv4-mapped
+	assert.False(IsIPv6("host1"))
+}
+
+func TestJoinHostPort(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("10.0.0.1:6666", JoinHostPort("10.0.0.1", 6666))
+	assert.Equal("[fd00::1]:6666", JoinHostPort("fd00::1", 6666))
+	assert.Equal("[fd00::1]:6666", JoinHostPort("[fd00::1]", 6666))
+	assert.Equal("host1:6666", JoinHostPort("host1", 6666))
+
+	assert.Equal("10.0.0.1", BracketHost("10.0.0.1"))
+	assert.Equal("[fd00::1]", BracketHost("fd00::1"))
+	assert.Equal("[fd00::1]", BracketHost("[fd00::1]"))
+
+	assert.Equal("0.0.0.0", AnyAddress("10.0.0.1"))
+	assert.Equal("::", AnyAddress("fd00::1"))
+}
+
+func TestIsValidAddress(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsValidAddress("10.0.0.1"))
+	assert.True(IsValidAddress("fd00::1"))
+	assert.True(IsValidAddress("2001:db8::8a2e:370:7334"))
+	assert.False(IsValidAddress("host1"))
+	assert.False(IsValidAddress("fd00::zz"))
+}
@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+ * The machine where curveadm running (operator machine) maybe Linux, macOS
+ * or WSL, but the managed hosts are always Linux, so:
+ *   (1) paths on remote host are joined by "path" and placed under "/tmp"
+ *   (2) paths on local machine are joined by "path/filepath" and placed under LocalTempDir()
+ */
+
+// LocalTempDir returns temporary directory of local machine, e.g. $TMPDIR in macOS
+func LocalTempDir() string {
+	dir := filepath.Clean(os.TempDir())
+	if len(dir) == 0 || dir == "." {
+		return "/tmp"
+	}
+	return dir
+}
+
+// RandLocalFilename returns random filename under local temporary directory
+func RandLocalFilename() string {
+	return filepath.Join(LocalTempDir(), RandString(8))
+}
+
+// NormalizeLineEndings converts CRLF (Windows) and CR (classic Mac) to LF
+func NormalizeLineEndings(s string) string {
+	if !strings.Contains(s, "\r") {
+		return s
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
@@ -0,0 +1,49 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLineEndings(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("a\nb\n", NormalizeLineEndings("a\nb\n"))
+	assert.Equal("a\nb\n", NormalizeLineEndings("a\r\nb\r\n"))
+	assert.Equal("a\nb\n", NormalizeLineEndings("a\rb\r"))
+	assert.Equal("a\n\nb", NormalizeLineEndings("a\r\n\rb"))
+}
+
+func TestRandLocalFilename(t *testing.T) {
+	assert := assert.New(t)
+	dir := LocalTempDir()
+	assert.True(filepath.IsAbs(dir))
+	filename := RandLocalFilename()
+	assert.Equal(dir, filepath.Dir(filename))
+	assert.True(strings.HasPrefix(filename, dir))
+	assert.NotEqual(filename, RandLocalFilename())
+}
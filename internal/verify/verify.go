@@ -0,0 +1,285 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/opencurve/curveadm/internal/errno"
+	"golang.org/x/crypto/openpgp"
+)
+
+/*
+ * every artifact published at URL comes with:
+ *   URL.sha256      output of sha256sum, e.g. "<hex>  install.sh"
+ *   URL.sha256.asc  armored detached GPG signature of URL.sha256 (required if keyring configured)
+ *
+ * images are verified by cosign against the registry before pulled, and the
+ * digest which verified is pulled instead of the mutable tag. Without cosign key
+ * or identity configured, images are verified by the default trust root: the
+ * keyless signature issued to GitHub Actions workflows of opencurve.
+ */
+const (
+	SUFFIX_CHECKSUM  = ".sha256"
+	SUFFIX_SIGNATURE = ".asc"
+
+	BINARY_COSIGN = "cosign"
+
+	DOWNLOAD_TIMEOUT = 60 * time.Second
+	COSIGN_TIMEOUT   = 5 * time.Minute
+
+	REGEX_SHA256 = "^[0-9a-f]{64}$"
+
+	DEFAULT_COSIGN_IDENTITY_REGEXP = "^https://github\\.com/opencurve/"
+	DEFAULT_COSIGN_ISSUER          = "https://token.actions.githubusercontent.com"
+)
+
+type (
+	Config struct {
+		InsecureSkipVerify bool
+		GPGKeyring         string // armored or binary public keyring which checksum files signed by
+		CosignKey          string // public key of cosign, file path or KMS URI
+		CosignIdentity     string // certificate identity for keyless verification
+		CosignIssuer       string // OIDC issuer for keyless verification
+	}
+
+	Verifier struct {
+		config Config
+		mutex  sync.Mutex
+		images map[string]string // image => verified image with digest
+	}
+
+	cosignPayload struct {
+		Critical struct {
+			Image struct {
+				Digest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+)
+
+var globalVerifier = New(Config{})
+
+func ReplaceGlobals(verifier *Verifier) {
+	globalVerifier = verifier
+}
+
+func Globals() *Verifier {
+	return globalVerifier
+}
+
+func New(config Config) *Verifier {
+	return &Verifier{
+		config: config,
+		images: map[string]string{},
+	}
+}
+
+func (v *Verifier) SetInsecureSkipVerify(skip bool) {
+	v.config.InsecureSkipVerify = skip
+}
+
+func (v *Verifier) SkipVerify() bool {
+	return v.config.InsecureSkipVerify
+}
+
+// ParseChecksum returns the first sha256 digest in output of sha256sum
+func ParseChecksum(data []byte) (string, bool) {
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", false
+	}
+	checksum := strings.ToLower(fields[0])
+	return checksum, regexp.MustCompile(REGEX_SHA256).MatchString(checksum)
+}
+
+func fetch(url string) ([]byte, error) {
+	resp, err := resty.New().SetTimeout(DOWNLOAD_TIMEOUT).R().Get(url)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != 200 {
+		return nil, errno.ERR_DOWNLOAD_ARTIFACT_FAILED.
+			F("url: %s, status: %s", url, resp.Status())
+	}
+	return resp.Body(), nil
+}
+
+// CheckSignature checks the armored detached signature of data by keyring
+func CheckSignature(keyring, data, signature []byte) error {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyring))
+	if err != nil {
+		entities, err = openpgp.ReadKeyRing(bytes.NewReader(keyring))
+	}
+	if err != nil {
+		return errno.ERR_READ_TRUST_ROOT_FAILED.E(err)
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(entities,
+		bytes.NewReader(data), bytes.NewReader(signature))
+	if err != nil {
+		return errno.ERR_VERIFY_ARTIFACT_SIGNATURE_FAILED.E(err)
+	}
+	return nil
+}
+
+// Checksum returns the published sha256 of artifact, the checksum file
+// itself is authenticated by GPG signature if keyring configured.
+func (v *Verifier) Checksum(url string) (string, error) {
+	data, err := fetch(url + SUFFIX_CHECKSUM)
+	if err != nil {
+		return "", errno.ERR_DOWNLOAD_CHECKSUM_FAILED.E(err)
+	}
+
+	if len(v.config.GPGKeyring) > 0 {
+		keyring, err := os.ReadFile(v.config.GPGKeyring)
+		if err != nil {
+			return "", errno.ERR_READ_TRUST_ROOT_FAILED.E(err)
+		}
+		signature, err := fetch(url + SUFFIX_CHECKSUM + SUFFIX_SIGNATURE)
+		if err != nil {
+			return "", errno.ERR_DOWNLOAD_CHECKSUM_FAILED.E(err)
+		} else if err := CheckSignature(keyring, data, signature); err != nil {
+			return "", err
+		}
+	}
+
+	checksum, ok := ParseChecksum(data)
+	if !ok {
+		return "", errno.ERR_DOWNLOAD_CHECKSUM_FAILED.
+			F("invalid checksum file: %s%s", url, SUFFIX_CHECKSUM)
+	}
+	return checksum, nil
+}
+
+// Download fetches the artifact and refuses it if mismatch with published checksum
+func (v *Verifier) Download(url string) ([]byte, error) {
+	data, err := fetch(url)
+	if err != nil {
+		return nil, errno.ERR_DOWNLOAD_ARTIFACT_FAILED.E(err)
+	} else if v.SkipVerify() {
+		return data, nil
+	}
+
+	expected, err := v.Checksum(url)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, errno.ERR_ARTIFACT_CHECKSUM_MISMATCH.
+			F("%s: expected %s, actual %s", url, expected, actual)
+	}
+	return data, nil
+}
+
+func (v *Verifier) CosignArgs(image string) []string {
+	config := v.config
+	args := []string{"verify"}
+	if len(config.CosignKey) > 0 {
+		return append(args, "--key", config.CosignKey, image)
+	}
+
+	issuer := config.CosignIssuer
+	if len(issuer) == 0 {
+		issuer = DEFAULT_COSIGN_ISSUER
+	}
+	if len(config.CosignIdentity) > 0 {
+		args = append(args, "--certificate-identity", config.CosignIdentity)
+	} else {
+		args = append(args, "--certificate-identity-regexp", DEFAULT_COSIGN_IDENTITY_REGEXP)
+	}
+	return append(args, "--certificate-oidc-issuer", issuer, image)
+}
+
+// ParseCosignDigest returns the manifest digest in payloads which cosign verified
+func ParseCosignDigest(out []byte) (string, bool) {
+	payloads := []cosignPayload{}
+	if err := json.Unmarshal(bytes.TrimSpace(out), &payloads); err != nil {
+		return "", false
+	}
+	for _, payload := range payloads {
+		if digest := payload.Critical.Image.Digest; strings.HasPrefix(digest, "sha256:") {
+			return digest, true
+		}
+	}
+	return "", false
+}
+
+// PinImage replaces the tag (or digest) of image with digest,
+// e.g: "opencurvedocker/curvebs:v1.2" => "opencurvedocker/curvebs@sha256:..."
+func PinImage(image, digest string) string {
+	repository := image
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	} else if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	return repository + "@" + digest
+}
+
+/*
+ * VerifyImage verifies signature of image by cosign in curveadm machine, each
+ * image only once, and returns the image pinned to digest which verified, so
+ * the tag re-pointed after verification won't be pulled.
+ */
+func (v *Verifier) VerifyImage(image string) (string, error) {
+	if v.SkipVerify() {
+		return image, nil
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if pinned, ok := v.images[image]; ok {
+		return pinned, nil
+	}
+
+	args := v.CosignArgs(image)
+	ctx, cancel := context.WithTimeout(context.Background(), COSIGN_TIMEOUT)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, BINARY_COSIGN, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errno.ERR_VERIFY_IMAGE_SIGNATURE_FAILED.
+			F("%s %s: %v: %s", BINARY_COSIGN, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	digest, ok := ParseCosignDigest(stdout.Bytes())
+	if !ok {
+		return "", errno.ERR_VERIFY_IMAGE_SIGNATURE_FAILED.
+			F("%s: no image digest in output of cosign", image)
+	}
+	v.images[image] = PinImage(image, digest)
+	return v.images[image], nil
+}
@@ -0,0 +1,196 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package verify
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/openpgp"
+)
+
+func sha256sum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func newServer(files map[string][]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write(data)
+	}))
+}
+
+func TestParseChecksum(t *testing.T) {
+	assert := assert.New(t)
+
+	checksum := sha256sum([]byte("hello"))
+	v, ok := ParseChecksum([]byte(checksum + "  install.sh\n"))
+	assert.True(ok)
+	assert.Equal(checksum, v)
+
+	for _, data := range []string{"", "abc  install.sh", "not-a-checksum"} {
+		_, ok := ParseChecksum([]byte(data))
+		assert.False(ok, data)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	assert := assert.New(t)
+
+	script := []byte("echo install")
+	server := newServer(map[string][]byte{
+		"/install.sh":        script,
+		"/install.sh.sha256": []byte(sha256sum(script) + "  install.sh"),
+		"/bad.sh":            []byte("rm -rf /"),
+		"/bad.sh.sha256":     []byte(sha256sum(script) + "  bad.sh"),
+		"/unsigned.sh":       script,
+	})
+	defer server.Close()
+
+	v := New(Config{})
+	data, err := v.Download(server.URL + "/install.sh")
+	assert.Nil(err)
+	assert.Equal(script, data)
+
+	_, err = v.Download(server.URL + "/bad.sh")
+	assert.NotNil(err)
+	_, err = v.Download(server.URL + "/unsigned.sh")
+	assert.NotNil(err)
+
+	// accept unverifiable artifacts if skipped
+	v.SetInsecureSkipVerify(true)
+	_, err = v.Download(server.URL + "/unsigned.sh")
+	assert.Nil(err)
+}
+
+func TestChecksumWithKeyring(t *testing.T) {
+	assert := assert.New(t)
+
+	signer, err := openpgp.NewEntity("curveadm", "", "curveadm@example.com", nil)
+	assert.Nil(err)
+	other, err := openpgp.NewEntity("other", "", "other@example.com", nil)
+	assert.Nil(err)
+
+	checksum := []byte(sha256sum([]byte("package")) + "  package.tar.gz")
+	sign := func(entity *openpgp.Entity) []byte {
+		var buffer bytes.Buffer
+		err := openpgp.ArmoredDetachSign(&buffer, entity, bytes.NewReader(checksum), nil)
+		assert.Nil(err)
+		return buffer.Bytes()
+	}
+	server := newServer(map[string][]byte{
+		"/good.tar.gz.sha256":     checksum,
+		"/good.tar.gz.sha256.asc": sign(signer),
+		"/evil.tar.gz.sha256":     checksum,
+		"/evil.tar.gz.sha256.asc": sign(other),
+		"/none.tar.gz.sha256":     checksum,
+	})
+	defer server.Close()
+
+	var keyring bytes.Buffer
+	assert.Nil(signer.Serialize(&keyring))
+	keyringPath := path.Join(t.TempDir(), "curveadm.gpg")
+	assert.Nil(os.WriteFile(keyringPath, keyring.Bytes(), 0600))
+
+	v := New(Config{GPGKeyring: keyringPath})
+	sum, err := v.Checksum(server.URL + "/good.tar.gz")
+	assert.Nil(err)
+	assert.Equal(sha256sum([]byte("package")), sum)
+
+	_, err = v.Checksum(server.URL + "/evil.tar.gz")
+	assert.NotNil(err)
+	_, err = v.Checksum(server.URL + "/none.tar.gz")
+	assert.NotNil(err)
+}
+
+func TestCosignArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	image := "opencurvedocker/curvebs:v1.2"
+	args := New(Config{CosignKey: "/etc/cosign.pub"}).CosignArgs(image)
+	assert.Equal([]string{"verify", "--key", "/etc/cosign.pub", image}, args)
+
+	args = New(Config{CosignIdentity: "id", CosignIssuer: "issuer"}).CosignArgs(image)
+	assert.Equal([]string{"verify",
+		"--certificate-identity", "id", "--certificate-oidc-issuer", "issuer", image}, args)
+
+	// default trust root
+	args = New(Config{}).CosignArgs(image)
+	assert.Equal([]string{"verify",
+		"--certificate-identity-regexp", DEFAULT_COSIGN_IDENTITY_REGEXP,
+		"--certificate-oidc-issuer", DEFAULT_COSIGN_ISSUER, image}, args)
+	args = New(Config{CosignIdentity: "id"}).CosignArgs(image)
+	assert.Equal([]string{"verify",
+		"--certificate-identity", "id", "--certificate-oidc-issuer", DEFAULT_COSIGN_ISSUER, image}, args)
+
+	// image is pulled by tag if skipped
+	v := New(Config{})
+	v.SetInsecureSkipVerify(true)
+	pinned, err := v.VerifyImage(image)
+	assert.Nil(err)
+	assert.Equal(image, pinned)
+}
+
+func TestParseCosignDigest(t *testing.T) {
+	assert := assert.New(t)
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	out := `[{"critical":{"identity":{"docker-reference":"opencurvedocker/curvebs"},` +
+		`"image":{"docker-manifest-digest":"` + digest + `"},"type":"cosign container image signature"},"optional":null}]`
+	actual, ok := ParseCosignDigest([]byte(out + "\n"))
+	assert.True(ok)
+	assert.Equal(digest, actual)
+
+	_, ok = ParseCosignDigest([]byte("Verification for opencurvedocker/curvebs:v1.2 --"))
+	assert.False(ok)
+	_, ok = ParseCosignDigest([]byte(`[{"critical":{"image":{}}}]`))
+	assert.False(ok)
+}
+
+func TestPinImage(t *testing.T) {
+	assert := assert.New(t)
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	for _, tt := range []struct{ image, expected string }{
+		{"opencurvedocker/curvebs:v1.2", "opencurvedocker/curvebs@" + digest},
+		{"opencurvedocker/curvebs", "opencurvedocker/curvebs@" + digest},
+		{"harbor.local:5000/curve/curvebs:v1.2", "harbor.local:5000/curve/curvebs@" + digest},
+		{"harbor.local:5000/curve/curvebs", "harbor.local:5000/curve/curvebs@" + digest},
+		{"opencurvedocker/curvebs@sha256:" + strings.Repeat("b", 64), "opencurvedocker/curvebs@" + digest},
+	} {
+		assert.Equal(tt.expected, PinImage(tt.image, digest), tt.image)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package v1
+
+import (
+	"context"
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	METADATA_AUTHORIZATION = "authorization"
+	PREFIX_BEARER          = "Bearer "
+)
+
+type (
+	// TokenCredentials attaches token to each call by metadata 'authorization: Bearer TOKEN'
+	TokenCredentials struct {
+		Token    string
+		Insecure bool // allow sending token without TLS
+	}
+
+	// Client is the client of curveadm API server, e.g:
+	//
+	//	client, err := v1.Dial("127.0.0.1:8001", "TOKEN", nil)
+	//	clusters, err := client.ListClusters(ctx, &v1.ListClustersRequest{})
+	Client struct {
+		CurveAdmClient
+		conn *grpc.ClientConn
+	}
+)
+
+func (c TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{METADATA_AUTHORIZATION: PREFIX_BEARER + c.Token}, nil
+}
+
+func (c TokenCredentials) RequireTransportSecurity() bool {
+	return !c.Insecure
+}
+
+// Dial connects to API server by TLS if config specified, otherwise plaintext
+func Dial(address, token string, config *tls.Config, opts ...grpc.DialOption) (*Client, error) {
+	transport := insecure.NewCredentials()
+	if config != nil {
+		transport = credentials.NewTLS(config)
+	}
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(transport),
+		grpc.WithPerRPCCredentials(TokenCredentials{Token: token, Insecure: config == nil}),
+	}, opts...)
+
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		CurveAdmClient: NewCurveAdmClient(conn),
+		conn:           conn,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
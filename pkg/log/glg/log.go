@@ -25,8 +25,12 @@
 package glg
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
+	"sync"
 
 	"github.com/kpango/glg"
 )
@@ -36,6 +40,62 @@ const (
 	SPACE        = " "
 )
 
+var (
+	LEVELS = []glg.LEVEL{glg.DEBG, glg.INFO, glg.WARN, glg.ERR}
+
+	gLevel  = glg.DEBG
+	gWriter *fileWriter
+)
+
+// fileWriter buffers logs in memory until the log file opened, so that the
+// default log file isn't created if another one specified (e.g. --log-file)
+type fileWriter struct {
+	mutex    sync.Mutex
+	filename string // default log file
+	file     *os.File
+	buffer   bytes.Buffer
+}
+
+func (w *fileWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file != nil {
+		return w.file.Write(p)
+	}
+	return w.buffer.Write(p)
+}
+
+func (w *fileWriter) open(filename string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(filename) == 0 {
+		filename = w.filename
+	}
+	if w.file != nil && w.file.Name() == filename {
+		return nil
+	}
+
+	file := glg.FileWriter(filename, 0666)
+	if file == nil {
+		return fmt.Errorf("open log file '%s' failed", filename)
+	}
+	if w.file != nil {
+		w.file.Close()
+	} else if _, err := file.Write(w.buffer.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+	w.buffer.Reset()
+	w.file = file
+	return nil
+}
+
+func (w *fileWriter) opened() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.file != nil
+}
+
 func convertLevel(level string) glg.LEVEL {
 	switch level {
 	case "debug":
@@ -52,19 +112,57 @@ func convertLevel(level string) glg.LEVEL {
 	return glg.DEBG
 }
 
+// Init writes logs into file, it can be called again to switch the file
 func Init(level, filename string) error {
-	writer := glg.FileWriter(filename, 0666)
+	Defer(level, filename)
+	return Open(filename)
+}
 
-	glg.Get().
+// Defer buffers logs in memory until Open called, the filename is the default log file
+func Defer(level, filename string) {
+	gWriter = &fileWriter{filename: filename}
+	gLevel = convertLevel(level)
+	g := glg.Get().
 		SetMode(glg.WRITER). // default is STD
-		SetLevel(convertLevel(level)).
-		SetLineTraceMode(glg.TraceLineShort).
-		AddLevelWriter(glg.DEBG, writer).
-		AddLevelWriter(glg.INFO, writer).
-		AddLevelWriter(glg.WARN, writer).
-		AddLevelWriter(glg.ERR, writer)
+		SetLevel(gLevel).
+		SetLineTraceMode(glg.TraceLineShort)
+	for _, lv := range LEVELS {
+		g.SetLevelWriter(lv, gWriter)
+	}
+}
 
-	return nil
+// Open opens the log file (the default one if filename is empty) and writes
+// the buffered logs into it, the log file is switched if opened before
+func Open(filename string) error {
+	if gWriter == nil {
+		return fmt.Errorf("logger not initialized")
+	}
+	return gWriter.open(filename)
+}
+
+// Flush writes the buffered logs into the default log file if it never opened,
+// it should be called before process exit
+func Flush() error {
+	if gWriter == nil || gWriter.opened() {
+		return nil
+	}
+	return gWriter.open("")
+}
+
+// Mirror writes logs whose level is not lower than level into w (e.g. stderr)
+// besides the log file, the level of log file is lowered if necessary
+func Mirror(level string, w io.Writer) {
+	mirror := convertLevel(level)
+	g := glg.Get()
+	for _, lv := range LEVELS {
+		if lv >= mirror {
+			g.AddLevelWriter(lv, w)
+		}
+	}
+	if mirror < gLevel {
+		gLevel = mirror
+		g.SetLevel(gLevel)
+	}
 }
 
 func Field(key string, val interface{}) string {
@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package glg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirror(t *testing.T) {
+	assert := assert.New(t)
+	filename := filepath.Join(t.TempDir(), "curveadm.log")
+	assert.Nil(Init("warn", filename))
+
+	buffer := &bytes.Buffer{}
+	Mirror("info", buffer)
+	Debug("debug message")
+	Info("info message")
+	Error("error message")
+
+	assert.NotContains(buffer.String(), "debug message")
+	assert.Contains(buffer.String(), "info message")
+	assert.Contains(buffer.String(), "error message")
+
+	// the log file receives mirrored levels too
+	data, err := os.ReadFile(filename)
+	assert.Nil(err)
+	assert.Contains(string(data), "info message")
+	assert.NotContains(string(data), "debug message")
+}
+
+func TestDefer(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	defaultFile := filepath.Join(dir, "default.log")
+	specifiedFile := filepath.Join(dir, "specified.log")
+
+	// logs are buffered until the log file opened
+	Defer("info", defaultFile)
+	Info("before open")
+	_, err := os.Stat(defaultFile)
+	assert.True(os.IsNotExist(err))
+
+	// the default log file is never created if another one specified
+	assert.Nil(Open(specifiedFile))
+	Info("after open")
+	data, err := os.ReadFile(specifiedFile)
+	assert.Nil(err)
+	assert.Contains(string(data), "before open")
+	assert.Contains(string(data), "after open")
+	_, err = os.Stat(defaultFile)
+	assert.True(os.IsNotExist(err))
+	assert.Nil(Flush())
+	_, err = os.Stat(defaultFile)
+	assert.True(os.IsNotExist(err))
+
+	// flush into the default log file if never opened
+	Defer("info", defaultFile)
+	Info("never opened")
+	assert.Nil(Flush())
+	data, err = os.ReadFile(defaultFile)
+	assert.Nil(err)
+	assert.Contains(string(data), "never opened")
+}
@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ENGINE_AUTO    = "auto"
+	ENGINE_DOCKER  = "docker"
+	ENGINE_PODMAN  = "podman"
+	ENGINE_NERDCTL = "nerdctl"
+
+	DETECT_ENGINE_TIMEOUT = 10 * time.Second
+)
+
+var (
+	// the order is the priority of auto-detection
+	CONTAINER_ENGINES = []string{ENGINE_DOCKER, ENGINE_PODMAN, ENGINE_NERDCTL}
+
+	// host address -> engine name, detected only once in one run
+	detectedEngines sync.Map
+)
+
+type (
+	/*
+	 * ContainerEngine is the container runtime CLI in host, all of them are
+	 * compatible with docker CLI mostly, so DockerCli renders docker style
+	 * command and let engine adjust the options which differ.
+	 */
+	ContainerEngine interface {
+		Name() string
+		Binary() string
+		// return false if option is unsupported and should be dropped
+		Option(subcommand, option string) (string, bool)
+	}
+
+	DockerEngine struct {
+		binary string
+	}
+
+	PodmanEngine struct{}
+
+	NerdctlEngine struct{}
+)
+
+// DockerEngine also acts as custom engine which compatible with docker
+// (e.g: engine: "sudo -E docker" in curveadm.cfg)
+func (e *DockerEngine) Name() string   { return ENGINE_DOCKER }
+func (e *DockerEngine) Binary() string { return e.binary }
+func (e *DockerEngine) Option(subcommand, option string) (string, bool) {
+	return option, true
+}
+
+func (e *PodmanEngine) Name() string   { return ENGINE_PODMAN }
+func (e *PodmanEngine) Binary() string { return ENGINE_PODMAN }
+func (e *PodmanEngine) Option(subcommand, option string) (string, bool) {
+	// podman names container without leading slash
+	if subcommand == "ListContainers" && strings.HasPrefix(option, "--filter name=") {
+		return strings.Replace(option, "^/?", "^", 1), true
+	}
+	return option, true
+}
+
+func (e *NerdctlEngine) Name() string   { return ENGINE_NERDCTL }
+func (e *NerdctlEngine) Binary() string { return ENGINE_NERDCTL }
+func (e *NerdctlEngine) Option(subcommand, option string) (string, bool) {
+	switch {
+	// containerd has no docker-init
+	case subcommand == "CreateContainer" && option == "--init":
+		return "", false
+	// nerdctl ps only supports substring match for name filter
+	case subcommand == "ListContainers" && strings.HasPrefix(option, "--filter name="):
+		return strings.NewReplacer("^/?", "", "$", "").Replace(option), true
+	}
+	return option, true
+}
+
+func NewContainerEngine(name string) ContainerEngine {
+	switch name {
+	case ENGINE_PODMAN:
+		return &PodmanEngine{}
+	case ENGINE_NERDCTL:
+		return &NerdctlEngine{}
+	case "":
+		return &DockerEngine{binary: ENGINE_DOCKER}
+	}
+	return &DockerEngine{binary: name}
+}
+
+func detectEngine(transport Transport) string {
+	key := remoteAddr(transport)
+	if v, ok := detectedEngines.Load(key); ok {
+		return v.(string)
+	}
+
+	command := "for engine in " + strings.Join(CONTAINER_ENGINES, " ") + "; do " +
+		"command -v $engine >/dev/null 2>&1 && echo $engine && break; done"
+	ctx, cancel := context.WithTimeout(context.Background(), DETECT_ENGINE_TIMEOUT)
+	defer cancel()
+	out, err := transport.Exec(ctx, command)
+	engine := strings.TrimSpace(string(out))
+	if err != nil || len(engine) == 0 {
+		return ENGINE_DOCKER // fallback, the later command will tell user what's wrong
+	}
+
+	detectedEngines.Store(key, engine)
+	return engine
+}
+
+/*
+ * GetContainerEngine returns the engine for host which transport reached:
+ *   (1) container_engine in hosts.yaml
+ *   (2) auto-detected if container_engine is "auto"
+ *   (3) engine in curveadm.cfg
+ */
+func GetContainerEngine(transport Transport, options ExecOptions) ContainerEngine {
+	name := options.ExecWithEngine
+	if transport != nil && !options.ExecInLocal {
+		switch engine := transport.Config().ContainerEngine; engine {
+		case "":
+		case ENGINE_AUTO:
+			name = detectEngine(transport)
+		default:
+			name = engine
+		}
+	}
+	return NewContainerEngine(name)
+}
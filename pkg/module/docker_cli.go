@@ -26,6 +26,7 @@ package module
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
 )
@@ -33,6 +34,10 @@ import (
 const (
 	TEMPLATE_DOCKER_INFO         = "{{.engine}} info"
 	TEMPLATE_PULL_IMAGE          = "{{.engine}} pull {{.options}} {{.name}}"
+	TEMPLATE_SAVE_IMAGE          = "{{.engine}} save {{.options}} {{.images}}"
+	TEMPLATE_LOAD_IMAGE          = "{{.engine}} load {{.options}}"
+	TEMPLATE_INSPECT_IMAGE       = "{{.engine}} image inspect {{.options}} {{.name}}"
+	TEMPLATE_TAG_IMAGE           = "{{.engine}} tag {{.options}} {{.source}} {{.target}}"
 	TEMPLATE_CREATE_CONTAINER    = "{{.engine}} create {{.options}} {{.image}} {{.command}}"
 	TEMPLATE_START_CONTAINER     = "{{.engine}} start {{.options}} {{.containers}}"
 	TEMPLATE_STOP_CONTAINER      = "{{.engine}} stop {{.options}} {{.containers}}"
@@ -46,18 +51,21 @@ const (
 	TEMPLATE_INSPECT_CONTAINER   = "{{.engine}} inspect {{.options}} {{.container}}"
 	TEMPLATE_CONTAINER_LOGS      = "{{.engine}} logs {{.options}} {{.container}}"
 	TEMPLATE_UPDATE_CONTAINER    = "{{.engine}} update {{.options}} {{.container}}"
+	TEMPLATE_CREATE_NETWORK      = "{{.engine}} network create {{.options}} {{.name}}"
+	TEMPLATE_REMOVE_NETWORK      = "{{.engine}} network rm {{.options}} {{.name}}"
+	TEMPLATE_DAEMON_PROXY        = "{{.engine}} info --format '{{`{{.HTTPProxy}}{{.HTTPSProxy}}`}}'"
 )
 
 type DockerCli struct {
-	sshClient *SSHClient
+	transport Transport
 	options   []string
 	tmpl      *template.Template
 	data      map[string]interface{}
 }
 
-func NewDockerCli(sshClient *SSHClient) *DockerCli {
+func NewDockerCli(transport Transport) *DockerCli {
 	return &DockerCli{
-		sshClient: sshClient,
+		transport: transport,
 		options:   []string{},
 		tmpl:      nil,
 		data:      map[string]interface{}{},
@@ -69,10 +77,28 @@ func (s *DockerCli) AddOption(format string, args ...interface{}) *DockerCli {
 	return s
 }
 
+func (cli *DockerCli) render(options ExecOptions) {
+	engine := GetContainerEngine(cli.transport, options)
+	opts := []string{}
+	for _, option := range cli.options {
+		if option, ok := engine.Option(cli.tmpl.Name(), option); ok {
+			opts = append(opts, option)
+		}
+	}
+
+	cli.data["options"] = strings.Join(opts, " ")
+	cli.data["engine"] = engine.Binary()
+}
+
 func (cli *DockerCli) Execute(options ExecOptions) (string, error) {
-	cli.data["options"] = strings.Join(cli.options, " ")
-	cli.data["engine"] = options.ExecWithEngine
-	return execCommand(cli.sshClient, cli.tmpl, cli.data, options)
+	cli.render(options)
+	return execCommand(cli.transport, cli.tmpl, cli.data, options)
+}
+
+// ExecuteStream writes output into w instead of buffering it, e.g. logs of container
+func (cli *DockerCli) ExecuteStream(options ExecOptions, w io.Writer) error {
+	cli.render(options)
+	return execCommandStream(cli.transport, cli.tmpl, cli.data, options, w)
 }
 
 func (cli *DockerCli) DockerInfo() *DockerCli {
@@ -86,6 +112,30 @@ func (cli *DockerCli) PullImage(image string) *DockerCli {
 	return cli
 }
 
+func (cli *DockerCli) SaveImage(images ...string) *DockerCli {
+	cli.tmpl = template.Must(template.New("SaveImage").Parse(TEMPLATE_SAVE_IMAGE))
+	cli.data["images"] = strings.Join(images, " ")
+	return cli
+}
+
+func (cli *DockerCli) LoadImage() *DockerCli {
+	cli.tmpl = template.Must(template.New("LoadImage").Parse(TEMPLATE_LOAD_IMAGE))
+	return cli
+}
+
+func (cli *DockerCli) InspectImage(image string) *DockerCli {
+	cli.tmpl = template.Must(template.New("InspectImage").Parse(TEMPLATE_INSPECT_IMAGE))
+	cli.data["name"] = image
+	return cli
+}
+
+func (cli *DockerCli) TagImage(source, target string) *DockerCli {
+	cli.tmpl = template.Must(template.New("TagImage").Parse(TEMPLATE_TAG_IMAGE))
+	cli.data["source"] = source
+	cli.data["target"] = target
+	return cli
+}
+
 func (cli *DockerCli) CreateContainer(image, command string) *DockerCli {
 	cli.tmpl = template.Must(template.New("CreateContainer").Parse(TEMPLATE_CREATE_CONTAINER))
 	cli.data["image"] = image
@@ -162,3 +212,39 @@ func (cli *DockerCli) ContainerLogs(containerId string) *DockerCli {
 	cli.data["container"] = containerId
 	return cli
 }
+
+func (cli *DockerCli) CreateNetwork(name string) *DockerCli {
+	cli.tmpl = template.Must(template.New("CreateNetwork").Parse(TEMPLATE_CREATE_NETWORK))
+	cli.data["name"] = name
+	return cli
+}
+
+// HTTPProxy returns the HTTP proxy of host which CLI executed in
+func (cli *DockerCli) HTTPProxy() HTTPProxyConfig {
+	if cli.transport == nil {
+		return HTTPProxyConfig{}
+	}
+	return cli.transport.Config().HTTPProxy
+}
+
+/*
+ * DaemonProxyMissing returns true if host has HTTP proxy but docker daemon,
+ * which pulls image on behalf of CLI, has no proxy configured, then proxy
+ * injected into CLI takes no effect. Podman and nerdctl pull image in CLI.
+ */
+func (cli *DockerCli) DaemonProxyMissing(options ExecOptions) bool {
+	if cli.transport == nil || cli.transport.Config().HTTPProxy.Empty() ||
+		GetContainerEngine(cli.transport, options).Name() != ENGINE_DOCKER {
+		return false
+	}
+
+	cli.tmpl = template.Must(template.New("DaemonProxy").Parse(TEMPLATE_DAEMON_PROXY))
+	out, err := cli.Execute(options)
+	return err == nil && len(strings.TrimSpace(out)) == 0
+}
+
+func (cli *DockerCli) RemoveNetwork(name string) *DockerCli {
+	cli.tmpl = template.Must(template.New("RemoveNetwork").Parse(TEMPLATE_REMOVE_NETWORK))
+	cli.data["name"] = name
+	return cli
+}
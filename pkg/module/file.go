@@ -27,7 +27,10 @@ package module
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"time"
 
 	log "github.com/opencurve/curveadm/pkg/log/glg"
 )
@@ -41,21 +44,21 @@ var (
 )
 
 type FileManager struct {
-	sshClient *SSHClient
+	transport Transport
 }
 
-func NewFileManager(sshClient *SSHClient) *FileManager {
-	return &FileManager{sshClient: sshClient}
+func NewFileManager(transport Transport) *FileManager {
+	return &FileManager{transport: transport}
 }
 
 func (f *FileManager) Upload(localPath, remotePath string) error {
-	if f.sshClient == nil {
+	if f.transport == nil {
 		return ERR_UNREACHED
 	}
 
-	err := f.sshClient.Client().Upload(localPath, remotePath)
+	err := f.transport.Upload(localPath, remotePath)
 	log.SwitchLevel(err)("UploadFile",
-		log.Field("remoteAddress", remoteAddr(f.sshClient)),
+		log.Field("remoteAddress", remoteAddr(f.transport)),
 		log.Field("localPath", localPath),
 		log.Field("remotePath", remotePath),
 		log.Field("error", err))
@@ -63,13 +66,13 @@ func (f *FileManager) Upload(localPath, remotePath string) error {
 }
 
 func (f *FileManager) Download(remotePath, localPath string) error {
-	if f.sshClient == nil {
+	if f.transport == nil {
 		return ERR_UNREACHED
 	}
 
-	err := f.sshClient.Client().Download(remotePath, localPath)
+	err := f.transport.Download(remotePath, localPath)
 	log.SwitchLevel(err)("DownloadFile",
-		log.Field("remoteAddress", remoteAddr(f.sshClient)),
+		log.Field("remoteAddress", remoteAddr(f.transport)),
 		log.Field("remotePath", remotePath),
 		log.Field("localPath", localPath),
 		log.Field("error", err))
@@ -77,7 +80,8 @@ func (f *FileManager) Download(remotePath, localPath string) error {
 }
 
 func (f *FileManager) Install(content, destPath string) error {
-	file, err := os.CreateTemp(TEMP_DIR, "curevadm.*.install")
+	// create temporary file in the same directory, so that rename won't cross filesystem
+	file, err := os.CreateTemp(filepath.Dir(destPath), "curevadm.*.install")
 	if err != nil {
 		return err
 	}
@@ -92,3 +96,153 @@ func (f *FileManager) Install(content, destPath string) error {
 
 	return os.Rename(file.Name(), destPath)
 }
+
+type TransferOptions struct {
+	Resume    bool                           // continue from the end of destination file
+	RateLimit int64                          // bytes per second, 0 means no limit
+	Progress  func(transferred, total int64) // called after each chunk transferred
+}
+
+const (
+	TRANSFER_CHUNK_SIZE = 32 * 1024
+)
+
+// copy from src to dest with rate limit and report progress
+func transfer(dest io.Writer, src io.Reader, offset, total int64, options TransferOptions) error {
+	buffer := make([]byte, TRANSFER_CHUNK_SIZE)
+	start := time.Now()
+	transferred := offset
+	for {
+		n, err := src.Read(buffer)
+		if n > 0 {
+			if _, werr := dest.Write(buffer[:n]); werr != nil {
+				return werr
+			}
+			transferred += int64(n)
+			if options.Progress != nil {
+				options.Progress(transferred, total)
+			}
+			if options.RateLimit > 0 {
+				expect := time.Duration(float64(transferred-offset) / float64(options.RateLimit) * float64(time.Second))
+				if elapsed := time.Since(start); elapsed < expect {
+					time.Sleep(expect - elapsed)
+				}
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+/*
+ * resumeOffset returns the offset which transfer starts from, the destination
+ * file is truncated if transfer restarts from 0 (e.g: it's larger than source),
+ * otherwise the stale bytes in its tail would be left.
+ */
+func resumeOffset(dest File, size int64, options TransferOptions) (int64, error) {
+	if !options.Resume {
+		return 0, nil // opened with O_TRUNC
+	}
+	if stat, err := dest.Stat(); err == nil && stat.Size() <= size {
+		return stat.Size(), nil
+	}
+	return 0, dest.Truncate(0)
+}
+
+func (f *FileManager) UploadWithOptions(localPath, remotePath string, options TransferOptions) error {
+	if f.transport == nil {
+		return ERR_UNREACHED
+	}
+
+	err := func() error {
+		src, err := os.Open(localPath)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		info, err := src.Stat()
+		if err != nil {
+			return err
+		}
+
+		flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if options.Resume {
+			flag = os.O_WRONLY | os.O_CREATE
+		}
+		dest, err := f.transport.OpenFile(remotePath, flag)
+		if err != nil {
+			return err
+		}
+		defer dest.Close()
+
+		offset, err := resumeOffset(dest, info.Size(), options)
+		if err != nil {
+			return err
+		} else if _, err := src.Seek(offset, io.SeekStart); err != nil {
+			return err
+		} else if _, err := dest.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		return transfer(dest, src, offset, info.Size(), options)
+	}()
+
+	log.SwitchLevel(err)("UploadFile",
+		log.Field("remoteAddress", remoteAddr(f.transport)),
+		log.Field("localPath", localPath),
+		log.Field("remotePath", remotePath),
+		log.Field("resume", options.Resume),
+		log.Field("rateLimit", options.RateLimit),
+		log.Field("error", err))
+	return err
+}
+
+func (f *FileManager) DownloadWithOptions(remotePath, localPath string, options TransferOptions) error {
+	if f.transport == nil {
+		return ERR_UNREACHED
+	}
+
+	err := func() error {
+		src, err := f.transport.OpenFile(remotePath, os.O_RDONLY)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		info, err := src.Stat()
+		if err != nil {
+			return err
+		}
+
+		flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if options.Resume {
+			flag = os.O_WRONLY | os.O_CREATE
+		}
+		dest, err := os.OpenFile(localPath, flag, 0644)
+		if err != nil {
+			return err
+		}
+		defer dest.Close()
+
+		offset, err := resumeOffset(dest, info.Size(), options)
+		if err != nil {
+			return err
+		} else if _, err := src.Seek(offset, io.SeekStart); err != nil {
+			return err
+		} else if _, err := dest.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		return transfer(dest, src, offset, info.Size(), options)
+	}()
+
+	log.SwitchLevel(err)("DownloadFile",
+		log.Field("remoteAddress", remoteAddr(f.transport)),
+		log.Field("remotePath", remotePath),
+		log.Field("localPath", localPath),
+		log.Field("resume", options.Resume),
+		log.Field("rateLimit", options.RateLimit),
+		log.Field("error", err))
+	return err
+}
@@ -0,0 +1,56 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumeTransfer(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	dest := filepath.Join(dir, "dest")
+	f := NewFileManager(NewLocalTransport(SSHConfig{}))
+	options := TransferOptions{Resume: true}
+
+	// continue from the end of destination
+	assert.Nil(os.WriteFile(src, []byte("hello world"), 0644))
+	assert.Nil(os.WriteFile(dest, []byte("hello"), 0644))
+	assert.Nil(f.UploadWithOptions(src, dest, options))
+	data, err := os.ReadFile(dest)
+	assert.Nil(err)
+	assert.Equal("hello world", string(data))
+
+	// restart from 0 if destination is larger than source
+	assert.Nil(os.WriteFile(src, []byte("hi"), 0644))
+	assert.Nil(f.DownloadWithOptions(src, dest, options))
+	data, err = os.ReadFile(dest)
+	assert.Nil(err)
+	assert.Equal("hi", string(data))
+}
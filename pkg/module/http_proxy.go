@@ -0,0 +1,132 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// docker daemon pulls image on behalf of CLI, it only honors the proxy in
+	// its own environment, while podman and nerdctl pull image in CLI process
+	DOCKER_PROXY_DROP_IN = "/etc/systemd/system/docker.service.d/http-proxy.conf"
+)
+
+/*
+ * HTTPProxyConfig is the HTTP(S) proxy which image pulls and artifact
+ * downloads on host go through, because many hosts in data center have
+ * no direct internet access. It is injected into command as environment
+ * variables if ExecWithProxy is set:
+ *
+ *   env HTTP_PROXY='...' http_proxy='...' ... docker pull IMAGE
+ */
+type HTTPProxyConfig struct {
+	HTTPProxy  string // e.g. http://proxy.example.com:3128
+	HTTPSProxy string
+	NoProxy    string // e.g. localhost,127.0.0.1,.example.com
+}
+
+func (c HTTPProxyConfig) Empty() bool {
+	return len(c.HTTPProxy) == 0 && len(c.HTTPSProxy) == 0
+}
+
+// Merge returns config whose empty fields are taken from parent (e.g. global)
+func (c HTTPProxyConfig) Merge(parent HTTPProxyConfig) HTTPProxyConfig {
+	if len(c.HTTPProxy) == 0 {
+		c.HTTPProxy = parent.HTTPProxy
+	}
+	if len(c.HTTPSProxy) == 0 {
+		c.HTTPSProxy = parent.HTTPSProxy
+	}
+	if len(c.NoProxy) == 0 {
+		c.NoProxy = parent.NoProxy
+	}
+	return c
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Envs returns environment variables in both upper and lower case,
+// tools differ in which one they honored (e.g. curl only reads http_proxy)
+func (c HTTPProxyConfig) Envs() []string {
+	if c.Empty() {
+		return nil
+	}
+
+	envs := []string{}
+	for _, item := range []struct{ name, value string }{
+		{"HTTP_PROXY", c.HTTPProxy},
+		{"HTTPS_PROXY", c.HTTPSProxy},
+		{"NO_PROXY", c.NoProxy},
+	} {
+		if len(item.value) == 0 {
+			continue
+		}
+		value := shellQuote(item.value)
+		envs = append(envs, item.name+"="+value, strings.ToLower(item.name)+"="+value)
+	}
+	return envs
+}
+
+/*
+ * SystemdDropIn returns the systemd drop-in which sets proxy for service, e.g:
+ *
+ *   [Service]
+ *   Environment="HTTP_PROXY=http://proxy.example.com:3128"
+ *   Environment="NO_PROXY=localhost,127.0.0.1"
+ */
+func (c HTTPProxyConfig) SystemdDropIn() string {
+	lines := []string{"[Service]"}
+	for _, item := range []struct{ name, value string }{
+		{"HTTP_PROXY", c.HTTPProxy},
+		{"HTTPS_PROXY", c.HTTPSProxy},
+		{"NO_PROXY", c.NoProxy},
+	} {
+		if len(item.value) > 0 { // "%" is specifier of systemd
+			value := strings.ReplaceAll(item.value, "%", "%%")
+			lines = append(lines, fmt.Sprintf("Environment=\"%s=%s\"", item.name, value))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// ValidateHTTPProxy returns error if proxy isn't http(s)://[user:password@]host[:port]
+func ValidateHTTPProxy(proxy string) error {
+	if len(proxy) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return err
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupport scheme '%s'", u.Scheme)
+	} else if len(u.Hostname()) == 0 {
+		return fmt.Errorf("missing host")
+	}
+	return nil
+}
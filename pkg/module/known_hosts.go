@@ -0,0 +1,154 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/melbahja/goph"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	ERR_MESSAGE_HOST_KEY_MISMATCH = "knownhosts: key mismatch"
+	ERR_MESSAGE_UNABLE_TO_AUTH    = "ssh: unable to authenticate"
+)
+
+// the error returned by ssh handshake is formatted by "%v", so we can only check its message
+func IsHostKeyMismatch(err error) bool {
+	return err != nil && strings.Contains(err.Error(), ERR_MESSAGE_HOST_KEY_MISMATCH)
+}
+
+func IsAuthFailed(err error) bool {
+	return err != nil && strings.Contains(err.Error(), ERR_MESSAGE_UNABLE_TO_AUTH)
+}
+
+// KnownHostAddresses returns the addresses which identify host in known_hosts,
+// including the host itself and its resolved IPs, e.g. "10.0.0.1", "[host1]:2222"
+func KnownHostAddresses(host string, port uint) []string {
+	hosts := []string{host}
+	if ips, err := net.LookupHost(host); err == nil {
+		hosts = append(hosts, ips...)
+	}
+
+	addresses := []string{}
+	exist := map[string]bool{}
+	for _, h := range hosts {
+		address := knownhosts.Normalize(net.JoinHostPort(h, strconv.Itoa(int(port))))
+		if !exist[address] {
+			exist[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// hashed host looks like: |1|base64(salt)|base64(hmac-sha1(salt, host))
+func matchHashedHost(hashed, address string) bool {
+	items := strings.Split(hashed, "|")
+	if len(items) != 4 || items[1] != "1" {
+		return false
+	}
+	salt, err := base64.StdEncoding.DecodeString(items[2])
+	if err != nil {
+		return false
+	}
+	want, err := base64.StdEncoding.DecodeString(items[3])
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte(address))
+	return hmac.Equal(mac.Sum(nil), want)
+}
+
+func matchKnownHostLine(line string, addresses []string) bool {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || strings.HasPrefix(fields[0], "#") ||
+		strings.HasPrefix(fields[0], "@") { // keep @cert-authority and @revoked
+		return false
+	}
+
+	for _, pattern := range strings.Split(fields[0], ",") {
+		for _, address := range addresses {
+			if strings.HasPrefix(pattern, "|") {
+				if matchHashedHost(pattern, address) {
+					return true
+				}
+			} else if pattern == address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RemoveKnownHost removes all keys of addresses from known_hosts file
+// (~/.ssh/known_hosts if knownFile is empty) and returns the number of removed lines
+func RemoveKnownHost(knownFile string, addresses []string) (int, error) {
+	if len(knownFile) == 0 {
+		path, err := goph.DefaultKnownHostsPath()
+		if err != nil {
+			return 0, err
+		}
+		knownFile = path
+	}
+
+	data, err := os.ReadFile(knownFile)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	var buffer bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if matchKnownHostLine(line, addresses) {
+			removed++
+			continue
+		}
+		buffer.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	} else if removed == 0 {
+		return 0, nil
+	}
+
+	info, err := os.Stat(knownFile)
+	if err != nil {
+		return 0, err
+	}
+	return removed, os.WriteFile(knownFile, buffer.Bytes(), info.Mode())
+}
@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestMatchKnownHostLine(t *testing.T) {
+	assert := assert.New(t)
+
+	addresses := []string{"10.0.0.1", "[host1]:2222"}
+	assert.True(matchKnownHostLine("10.0.0.1 ssh-ed25519 AAAA", addresses))
+	assert.True(matchKnownHostLine("host2,[host1]:2222 ssh-rsa AAAA", addresses))
+	assert.True(matchKnownHostLine(knownhosts.HashHostname("10.0.0.1")+" ssh-rsa AAAA", addresses))
+	assert.False(matchKnownHostLine("10.0.0.2 ssh-rsa AAAA", addresses))
+	assert.False(matchKnownHostLine("host1 ssh-rsa AAAA", addresses))
+	assert.False(matchKnownHostLine("# 10.0.0.1 ssh-rsa AAAA", addresses))
+	assert.False(matchKnownHostLine("@cert-authority 10.0.0.1 ssh-rsa AAAA", addresses))
+	assert.False(matchKnownHostLine("10.0.0.1", addresses))
+}
+
+func TestRemoveKnownHost(t *testing.T) {
+	assert := assert.New(t)
+
+	knownFile := filepath.Join(t.TempDir(), "known_hosts")
+	n, err := RemoveKnownHost(knownFile, []string{"10.0.0.1"})
+	assert.Nil(err)
+	assert.Equal(0, n)
+
+	data := "10.0.0.1 ssh-ed25519 AAAA\n" +
+		"10.0.0.2 ssh-ed25519 BBBB\n" +
+		knownhosts.HashHostname("10.0.0.1") + " ssh-rsa CCCC\n"
+	assert.Nil(os.WriteFile(knownFile, []byte(data), 0600))
+	n, err = RemoveKnownHost(knownFile, []string{"10.0.0.1"})
+	assert.Nil(err)
+	assert.Equal(2, n)
+	out, err := os.ReadFile(knownFile)
+	assert.Nil(err)
+	assert.Equal("10.0.0.2 ssh-ed25519 BBBB\n", string(out))
+}
+
+func TestClassifyConnectError(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsHostKeyMismatch(errors.New("ssh: handshake failed: knownhosts: key mismatch")))
+	assert.True(IsAuthFailed(errors.New("ssh: handshake failed: ssh: unable to authenticate, attempted methods [none publickey]")))
+	assert.False(IsHostKeyMismatch(nil))
+	assert.False(IsAuthFailed(errors.New("dial tcp 10.0.0.1:22: i/o timeout")))
+}
@@ -28,18 +28,18 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 	"text/template"
 	"time"
 
-	"github.com/melbahja/goph"
 	log "github.com/opencurve/curveadm/pkg/log/glg"
 )
 
 type (
 	Module struct {
-		sshClient *SSHClient
+		transport Transport
 	}
 
 	ExecOptions struct {
@@ -48,6 +48,7 @@ type (
 		ExecSudoAlias  string
 		ExecTimeoutSec int
 		ExecWithEngine string
+		ExecWithProxy  bool // inject HTTP proxy of host into command, for pulls and downloads
 	}
 
 	TimeoutError struct {
@@ -60,44 +61,56 @@ func (e *TimeoutError) Error() string {
 		e.timeout)
 }
 
-func NewModule(sshClient *SSHClient) *Module {
-	return &Module{sshClient: sshClient}
+func NewModule(transport Transport) *Module {
+	return &Module{transport: transport}
 }
 
 func (m *Module) Shell() *Shell {
-	return NewShell(m.sshClient)
+	return NewShell(m.transport)
 }
 
 func (m *Module) File() *FileManager {
-	return NewFileManager(m.sshClient)
+	return NewFileManager(m.transport)
 }
 
 func (m *Module) DockerCli() *DockerCli {
-	return NewDockerCli(m.sshClient)
+	return NewDockerCli(m.transport)
 }
 
 // common utils
-func remoteAddr(client *SSHClient) string {
-	if client == nil {
+func remoteAddr(transport Transport) string {
+	if transport == nil {
 		return "-"
 	}
 
-	config := client.Config()
+	config := transport.Config()
+	if transport.Name() == TRANSPORT_LOCAL {
+		return fmt.Sprintf("local://%s", config.Host)
+	}
 	return fmt.Sprintf("%s@%s:%d", config.User, config.Host, config.Port)
 }
 
-func execCommand(sshClient *SSHClient,
+// runCommand renders command and executes it, the combined output is written into w
+func runCommand(transport Transport,
 	tmpl *template.Template,
 	data map[string]interface{},
-	options ExecOptions) (string, error) {
+	options ExecOptions,
+	w io.Writer) (string, error) {
 	// (1) rendering command template
 	buffer := bytes.NewBufferString("")
 	if err := tmpl.Execute(buffer, data); err != nil {
 		return "", err
 	}
 
-	// (2) handle 'sudo_alias'
+	// (2) handle 'http_proxy', env is placed after sudo which resets environment
 	command := buffer.String()
+	if options.ExecWithProxy && transport != nil && !options.ExecInLocal {
+		if envs := transport.Config().HTTPProxy.Envs(); len(envs) > 0 {
+			command = strings.Join(append([]string{"env"}, append(envs, command)...), " ")
+		}
+	}
+
+	// (3) handle 'sudo_alias'
 	if options.ExecWithSudo {
 		sudo := "sudo"
 		if len(options.ExecSudoAlias) > 0 {
@@ -107,18 +120,18 @@ func execCommand(sshClient *SSHClient,
 	}
 	command = strings.TrimLeft(command, " ")
 
-	// (3) handle 'become_user'
-	if sshClient != nil {
-		becomeMethod := sshClient.Config().BecomeMethod
-		becomeFlags := sshClient.Config().BecomeFlags
-		becomeUser := sshClient.Config().BecomeUser
+	// (4) handle 'become_user'
+	if transport != nil {
+		becomeMethod := transport.Config().BecomeMethod
+		becomeFlags := transport.Config().BecomeFlags
+		becomeUser := transport.Config().BecomeUser
 		if len(becomeUser) > 0 && !options.ExecInLocal {
 			become := strings.Join([]string{becomeMethod, becomeFlags, becomeUser}, " ")
 			command = strings.Join([]string{become, command}, " ")
 		}
 	}
 
-	// (4) create context for timeout
+	// (5) create context for timeout
 	ctx := context.Background()
 	if options.ExecTimeoutSec > 0 {
 		var cancel context.CancelFunc
@@ -126,29 +139,51 @@ func execCommand(sshClient *SSHClient,
 		defer cancel()
 	}
 
-	// (5) execute command
-	var out []byte
+	// (6) execute command
 	var err error
 	if options.ExecInLocal {
 		cmd := exec.CommandContext(ctx, "bash", "-c", command)
 		cmd.Env = []string{"LANG=en_US.UTF-8"}
-		out, err = cmd.CombinedOutput()
+		cmd.Stdout, cmd.Stderr = w, w
+		err = cmd.Run()
+	} else if transport == nil {
+		err = ERR_UNREACHED
 	} else {
-		var cmd *goph.Cmd
-		cmd, err = sshClient.Client().CommandContext(ctx, command)
-		if err == nil {
-			out, err = cmd.CombinedOutput()
-		}
+		err = transport.ExecStream(ctx, command, w)
 	}
 
 	if ctx.Err() == context.DeadlineExceeded {
 		err = &TimeoutError{options.ExecTimeoutSec}
 	}
+	return command, err
+}
 
+func execCommand(transport Transport,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions) (string, error) {
+	out := &bytes.Buffer{}
+	command, err := runCommand(transport, tmpl, data, options, out)
+	log.SwitchLevel(err)("Execute command",
+		log.Field("remoteAddr", remoteAddr(transport)),
+		log.Field("command", command),
+		log.Field("output", strings.TrimSuffix(out.String(), "\n")),
+		log.Field("error", err))
+	return out.String(), err
+}
+
+// execCommandStream is same as execCommand, but the output is never buffered in memory
+func execCommandStream(transport Transport,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions,
+	w io.Writer) error {
+	counter := &countingWriter{w: w}
+	command, err := runCommand(transport, tmpl, data, options, counter)
 	log.SwitchLevel(err)("Execute command",
-		log.Field("remoteAddr", remoteAddr(sshClient)),
+		log.Field("remoteAddr", remoteAddr(transport)),
 		log.Field("command", command),
-		log.Field("output", strings.TrimSuffix(string(out), "\n")),
+		log.Field("output", fmt.Sprintf("(%d bytes streamed)", counter.n)),
 		log.Field("error", err))
-	return string(out), err
+	return err
 }
@@ -27,6 +27,7 @@ package module
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
 )
@@ -43,6 +44,8 @@ const (
 	TEMPLATE_RENAME   = "mv {{.options}} {{.source}} {{.dest}}"
 	TEMPLATE_COPY     = "cp {{.options}} {{.source}} {{.dest}}"
 	TEMPLATE_CHMOD    = "chmod {{.options}} {{.mode}} {{.file}}"
+	TEMPLATE_CHOWN    = "chown {{.options}} {{.owner}} {{.file}}"
+	TEMPLATE_SHA256   = "sha256sum {{.options}} {{.files}}"
 	TEMPLATE_STAT     = "stat {{.options}} {{.files}}"
 	TEMPLATE_CAT      = "cat {{.options}} {{.files}}"
 	TEMPLATE_MKFS     = "mkfs.ext4 {{.options}} {{.device}}"
@@ -53,6 +56,7 @@ const (
 	TEMPLATE_DISKFREE = "df {{.options}} {{.files}}"
 	TEMPLATE_LSBLK    = "lsblk {{.options}} {{.devices}}"
 	TEMPLATE_BLKID    = "blkid {{.options}} {{.device}}"
+	TEMPLATE_FINDMNT  = "findmnt {{.options}}"
 
 	// network
 	TEMPLATE_SS   = "ss {{.options}} '{{.filter}}'"
@@ -72,6 +76,9 @@ const (
 	TEMPLATE_RPM  = "rpm {{.options}}"
 	TEMPLATE_SCP  = "scp {{.options}} {{.source}} {{.user}}@{{.host}}:{{.target}}"
 
+	// systemd
+	TEMPLATE_SYSTEMCTL = "systemctl {{.options}} {{.command}} {{.units}}"
+
 	// bash
 	TEMPLATE_COMMAND     = "{{.command}}"
 	TEMPLATE_BASH_SCEIPT = "bash {{.scriptPath}} {{.arguments}}"
@@ -79,15 +86,15 @@ const (
 
 // TODO(P1): support command pipe
 type Shell struct {
-	sshClient *SSHClient
+	transport Transport
 	options   []string
 	tmpl      *template.Template
 	data      map[string]interface{}
 }
 
-func NewShell(sshClient *SSHClient) *Shell {
+func NewShell(transport Transport) *Shell {
 	return &Shell{
-		sshClient: sshClient,
+		transport: transport,
 		options:   []string{},
 		tmpl:      nil,
 		data:      map[string]interface{}{},
@@ -111,7 +118,13 @@ func (s *Shell) String() (string, error) {
 
 func (s *Shell) Execute(options ExecOptions) (string, error) {
 	s.data["options"] = strings.Join(s.options, " ")
-	return execCommand(s.sshClient, s.tmpl, s.data, options)
+	return execCommand(s.transport, s.tmpl, s.data, options)
+}
+
+// ExecuteStream writes output into w instead of buffering it, for large outputs
+func (s *Shell) ExecuteStream(options ExecOptions, w io.Writer) error {
+	s.data["options"] = strings.Join(s.options, " ")
+	return execCommandStream(s.transport, s.tmpl, s.data, options, w)
 }
 
 // text
@@ -167,6 +180,19 @@ func (s *Shell) Chmod(mode, file string) *Shell {
 	return s
 }
 
+func (s *Shell) Chown(owner, file string) *Shell {
+	s.tmpl = template.Must(template.New("chown").Parse(TEMPLATE_CHOWN))
+	s.data["owner"] = owner
+	s.data["file"] = file
+	return s
+}
+
+func (s *Shell) Sha256Sum(files ...string) *Shell {
+	s.tmpl = template.Must(template.New("sha256sum").Parse(TEMPLATE_SHA256))
+	s.data["files"] = strings.Join(files, " ")
+	return s
+}
+
 func (s *Shell) Stat(files ...string) *Shell {
 	s.tmpl = template.Must(template.New("stat").Parse(TEMPLATE_STAT))
 	s.data["files"] = strings.Join(files, " ")
@@ -228,6 +254,11 @@ func (s *Shell) BlkId(device string) *Shell {
 	return s
 }
 
+func (s *Shell) FindMnt() *Shell {
+	s.tmpl = template.Must(template.New("findmnt").Parse(TEMPLATE_FINDMNT))
+	return s
+}
+
 // network
 func (s *Shell) SocketStatistics(filter string) *Shell {
 	s.tmpl = template.Must(template.New("ss").Parse(TEMPLATE_SS))
@@ -303,6 +334,14 @@ func (s *Shell) Scp(source, user, host, target string) *Shell {
 	return s
 }
 
+// systemd
+func (s *Shell) Systemctl(command string, units ...string) *Shell {
+	s.tmpl = template.Must(template.New("systemctl").Parse(TEMPLATE_SYSTEMCTL))
+	s.data["command"] = command
+	s.data["units"] = strings.Join(units, " ")
+	return s
+}
+
 func (s *Shell) Command(command string) *Shell {
 	s.tmpl = template.Must(template.New("command").Parse(TEMPLATE_COMMAND))
 	s.data["command"] = command
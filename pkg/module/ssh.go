@@ -25,12 +25,15 @@
 package module
 
 import (
+	"context"
 	"errors"
+	"io"
 	"net"
 	"time"
 
 	"github.com/melbahja/goph"
 	log "github.com/opencurve/curveadm/pkg/log/glg"
+	"github.com/pkg/sftp"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -44,13 +47,22 @@ type (
 		BecomeFlags       string
 		BecomeUser        string
 		PrivateKeyPath    string
+		Password          string // offered after private key if specified
+		CertificatePath   string // OpenSSH certificate signed by CA, presented with private key
+		IdentityAgent     string // socket of ssh-agent, $SSH_AUTH_SOCK if empty
 		ConnectRetries    int
 		ConnectTimeoutSec int
+		Transport         string          // ssh (default), local
+		ContainerEngine   string          // docker, podman, nerdctl, auto; use engine in curveadm.cfg if empty
+		ProxyJump         string          // [user@]host[:port][,...], jump hosts use the same auth as target
+		ProxyCommand      string          // command whose stdin/stdout connected to target, e.g. "ssh -W %h:%p bastion"
+		HTTPProxy         HTTPProxyConfig // for image pulls and artifact downloads, see ExecWithProxy
 	}
 
 	SSHClient struct {
-		client *goph.Client
-		config SSHConfig
+		client  *goph.Client
+		config  SSHConfig
+		proxies []io.Closer // jump host connections or proxy command
 	}
 )
 
@@ -89,6 +101,66 @@ func (client *SSHClient) Config() SSHConfig {
 	return client.config
 }
 
+// implement Transport interface
+func (client *SSHClient) Name() string {
+	return TRANSPORT_SSH
+}
+
+func (client *SSHClient) Exec(ctx context.Context, command string) ([]byte, error) {
+	cmd, err := client.client.CommandContext(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	return cmd.CombinedOutput()
+}
+
+func (client *SSHClient) ExecStream(ctx context.Context, command string, w io.Writer) error {
+	cmd, err := client.client.CommandContext(ctx, command)
+	if err != nil {
+		return err
+	}
+	sw := &syncWriter{w: w}
+	cmd.Stdout, cmd.Stderr = sw, sw
+	return cmd.Run()
+}
+
+func (client *SSHClient) Upload(localPath, remotePath string) error {
+	return client.client.Upload(localPath, remotePath)
+}
+
+func (client *SSHClient) Download(remotePath, localPath string) error {
+	return client.client.Download(remotePath, localPath)
+}
+
+type sftpFile struct {
+	*sftp.File
+	client *sftp.Client
+}
+
+func (f *sftpFile) Close() error {
+	defer f.client.Close()
+	return f.File.Close()
+}
+
+func (client *SSHClient) OpenFile(path string, flag int) (File, error) {
+	sftpClient, err := client.client.NewSftp()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := sftpClient.OpenFile(path, flag)
+	if err != nil {
+		sftpClient.Close()
+		return nil, err
+	}
+	return &sftpFile{File: file, client: sftpClient}, nil
+}
+
+func (client *SSHClient) Close() error {
+	defer closeAll(client.proxies)
+	return client.client.Close()
+}
+
 func NewSSHClient(config SSHConfig) (*SSHClient, error) {
 	user := config.User
 	host := config.Host
@@ -98,14 +170,7 @@ func NewSSHClient(config SSHConfig) (*SSHClient, error) {
 	connTimeoutSec := config.ConnectTimeoutSec
 	maxRetries := config.ConnectRetries
 
-	var auth goph.Auth
-	var err error
-	if forwardAgent {
-		auth, err = goph.UseAgent()
-	} else {
-		auth, err = goph.Key(privateKeyPath, "")
-	}
-
+	auth, err := NewSSHAuth(config)
 	if err != nil {
 		log.Error("Create SSH auth",
 			log.Field("user", user),
@@ -113,6 +178,7 @@ func NewSSHClient(config SSHConfig) (*SSHClient, error) {
 			log.Field("port", port),
 			log.Field("forwardAgent", forwardAgent),
 			log.Field("privateKeyPath", privateKeyPath),
+			log.Field("certificatePath", config.CertificatePath),
 			log.Field("error", err))
 		return nil, err
 	}
@@ -120,7 +186,7 @@ func NewSSHClient(config SSHConfig) (*SSHClient, error) {
 	tries := 0
 connect:
 	tries++
-	client, err := goph.NewConn(&goph.Config{
+	client, proxies, err := dialSSH(config, &goph.Config{
 		User:     user,
 		Addr:     host,
 		Port:     port,
@@ -136,6 +202,7 @@ connect:
 		log.Field("forwardAgent", forwardAgent),
 		log.Field("privateKeyPath", privateKeyPath),
 		log.Field("timeoutSec", connTimeoutSec),
+		log.Field("proxyJump", config.ProxyJump),
 		log.Field("maxRetries", maxRetries),
 		log.Field("tries", tries),
 		log.Field("error", err))
@@ -147,7 +214,8 @@ connect:
 	}
 
 	return &SSHClient{
-		client: client,
-		config: config,
+		client:  client,
+		config:  config,
+		proxies: proxies,
 	}, err
 }
@@ -0,0 +1,151 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/melbahja/goph"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	ENV_SSH_AUTH_SOCK = "SSH_AUTH_SOCK"
+)
+
+// ParseCertificate parses OpenSSH certificate (e.g. id_rsa-cert.pub) and checks its validity
+func ParseCertificate(data []byte, now time.Time) (*ssh.Certificate, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil {
+		return nil, err
+	}
+	cert, ok := key.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("not an OpenSSH certificate")
+	} else if cert.CertType != ssh.UserCert {
+		return nil, fmt.Errorf("not a user certificate")
+	}
+
+	unix := uint64(now.Unix())
+	if unix < cert.ValidAfter {
+		return nil, fmt.Errorf("certificate is not yet valid")
+	} else if cert.ValidBefore != ssh.CertTimeInfinity && unix >= cert.ValidBefore {
+		return nil, fmt.Errorf("certificate expired at %s",
+			time.Unix(int64(cert.ValidBefore), 0).Format(time.RFC3339))
+	}
+	return cert, nil
+}
+
+func loadCertificate(path string) (*ssh.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := ParseCertificate(data, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return cert, nil
+}
+
+// NewCertSigner binds certificate to the signer which holds its private key
+func NewCertSigner(cert *ssh.Certificate, signer ssh.Signer) (ssh.Signer, error) {
+	if !bytes.Equal(cert.Key.Marshal(), signer.PublicKey().Marshal()) {
+		return nil, fmt.Errorf("certificate doesn't match the private key")
+	}
+	return ssh.NewCertSigner(cert, signer)
+}
+
+func agentSigners(socket string, cert *ssh.Certificate) func() ([]ssh.Signer, error) {
+	return func() ([]ssh.Signer, error) {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, fmt.Errorf("could not find ssh agent: %w", err)
+		}
+		signers, err := agent.NewClient(conn).Signers()
+		if err != nil || cert == nil {
+			return signers, err
+		}
+
+		// only the key which signed by CA is offered if certificate specified
+		for _, signer := range signers {
+			if s, err := NewCertSigner(cert, signer); err == nil {
+				return []ssh.Signer{s}, nil
+			}
+		}
+		return nil, fmt.Errorf("no key in ssh agent matches the certificate")
+	}
+}
+
+/*
+ * NewSSHAuth returns auth method of host:
+ *   (1) ssh-agent: all keys (including certificates) held by agent are offered,
+ *       the agent socket is IdentityAgent, or $SSH_AUTH_SOCK if not specified
+ *   (2) private key: the key file with passphrase-less
+ *   (3) password: offered after the private key, or alone if the key file unusable
+ * for both ways, the certificate (e.g. signed by Vault/Teleport) is presented
+ * together with its private key if CertificatePath specified.
+ */
+func NewSSHAuth(config SSHConfig) (goph.Auth, error) {
+	var cert *ssh.Certificate
+	var err error
+	if len(config.CertificatePath) > 0 {
+		cert, err = loadCertificate(config.CertificatePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if config.ForwardAgent {
+		socket := config.IdentityAgent
+		if len(socket) == 0 {
+			socket = os.Getenv(ENV_SSH_AUTH_SOCK)
+		}
+		if len(socket) == 0 {
+			return nil, fmt.Errorf("could not find ssh agent: %s not set", ENV_SSH_AUTH_SOCK)
+		}
+		return goph.Auth{ssh.PublicKeysCallback(agentSigners(socket, cert))}, nil
+	}
+
+	signer, err := goph.GetSigner(config.PrivateKeyPath, "")
+	if err != nil && len(config.Password) > 0 {
+		return goph.Auth{ssh.Password(config.Password)}, nil
+	} else if err != nil {
+		return nil, err
+	} else if cert != nil {
+		if signer, err = NewCertSigner(cert, signer); err != nil {
+			return nil, fmt.Errorf("%s: %v", config.CertificatePath, err)
+		}
+	}
+
+	auth := goph.Auth{ssh.PublicKeys(signer)}
+	if len(config.Password) > 0 {
+		auth = append(auth, ssh.Password(config.Password))
+	}
+	return auth, nil
+}
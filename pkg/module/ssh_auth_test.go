@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func newSigner(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	assert.Nil(t, err)
+	return signer
+}
+
+func newCertificate(t *testing.T, ca, signer ssh.Signer, certType uint32, validAfter, validBefore uint64) []byte {
+	cert := &ssh.Certificate{
+		Key:             signer.PublicKey(),
+		CertType:        certType,
+		ValidPrincipals: []string{"curve"},
+		ValidAfter:      validAfter,
+		ValidBefore:     validBefore,
+	}
+	assert.Nil(t, cert.SignCert(rand.Reader, ca))
+	return ssh.MarshalAuthorizedKey(cert)
+}
+
+func TestParseCertificate(t *testing.T) {
+	assert := assert.New(t)
+	ca, signer := newSigner(t), newSigner(t)
+	now := time.Now()
+	unix := uint64(now.Unix())
+
+	// valid
+	data := newCertificate(t, ca, signer, ssh.UserCert, unix-60, unix+60)
+	cert, err := ParseCertificate(data, now)
+	assert.Nil(err)
+	certSigner, err := NewCertSigner(cert, signer)
+	assert.Nil(err)
+	assert.Equal(cert.Marshal(), certSigner.PublicKey().Marshal())
+
+	// never expired
+	_, err = ParseCertificate(newCertificate(t, ca, signer, ssh.UserCert, 0, ssh.CertTimeInfinity), now)
+	assert.Nil(err)
+
+	// expired or not yet valid
+	_, err = ParseCertificate(newCertificate(t, ca, signer, ssh.UserCert, unix-120, unix-60), now)
+	assert.NotNil(err)
+	_, err = ParseCertificate(newCertificate(t, ca, signer, ssh.UserCert, unix+60, unix+120), now)
+	assert.NotNil(err)
+
+	// host certificate or plain public key
+	_, err = ParseCertificate(newCertificate(t, ca, signer, ssh.HostCert, 0, ssh.CertTimeInfinity), now)
+	assert.NotNil(err)
+	_, err = ParseCertificate(ssh.MarshalAuthorizedKey(signer.PublicKey()), now)
+	assert.NotNil(err)
+
+	// certificate doesn't match private key
+	_, err = NewCertSigner(cert, newSigner(t))
+	assert.NotNil(err)
+}
@@ -0,0 +1,433 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/melbahja/goph"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+const (
+	SSH_KEEPALIVE_REQUEST = "keepalive@openssh.com"
+)
+
+var (
+	globalSSHPool     *SSHPool
+	globalSSHPoolRefs int // the number of playbooks which using the global pool
+	globalSSHPoolMu   sync.Mutex
+)
+
+type (
+	SSHPoolOptions struct {
+		MaxSessions  int // the number of transports share one connection at most
+		MaxIdle      int // the number of idle connections kept for each host
+		KeepAliveSec int // interval of keep-alive request, disabled if 0
+	}
+
+	SSHPoolMetrics struct {
+		Dials      int // new connections dialed
+		Reuses     int // transports which reuse existing connection
+		Reconnects int // broken connections which reconnected
+		Closed     int // connections closed
+		Active     int // connections in use
+		Idle       int // connections not in use
+		Peak       int // the maximum number of connections at the same time
+	}
+
+	pooledConn struct {
+		client *SSHClient
+		key    string
+		refs   int  // the number of transports which using this connection
+		broken bool // keep-alive failed or session can't be opened
+	}
+
+	/*
+	 * SSHPool shares connections among tasks, each connection is multiplexed
+	 * by at most MaxSessions transports, every transport opens its own
+	 * session for each command, so that tasks of the same host needn't
+	 * handshake again and again.
+	 */
+	SSHPool struct {
+		mutex   sync.Mutex
+		options SSHPoolOptions
+		conns   map[string][]*pooledConn // key: user@host:port
+		metrics SSHPoolMetrics
+		stop    chan struct{}
+		closed  bool
+		dial    func(config SSHConfig) (*SSHClient, error)
+	}
+
+	/*
+	 * PooledSSHClient is the transport which connection borrowed from pool,
+	 * the connection is shared by configs with same user@host:port and auth,
+	 * but the settings read by each command (e.g. container engine, become
+	 * method, http proxy) are taken from the config of borrower.
+	 */
+	PooledSSHClient struct {
+		*SSHClient
+		config   SSHConfig
+		pool     *SSHPool
+		conn     *pooledConn
+		released bool
+	}
+)
+
+func NewSSHPool(options SSHPoolOptions) *SSHPool {
+	if options.MaxSessions <= 0 {
+		options.MaxSessions = 1
+	}
+	pool := &SSHPool{
+		options: options,
+		conns:   map[string][]*pooledConn{},
+		stop:    make(chan struct{}),
+		dial:    NewSSHClient,
+	}
+	if options.KeepAliveSec > 0 {
+		go pool.keepAlive(time.Duration(options.KeepAliveSec) * time.Second)
+	}
+	return pool
+}
+
+/*
+ * OpenSSHPool opens the pool which all SSH transports borrowed from, playbooks
+ * running at the same time (e.g. in http server) share the same pool, which
+ * options are taken from the first one, and it's closed after the last
+ * playbook invoked CloseSSHPool.
+ */
+func OpenSSHPool(options SSHPoolOptions) *SSHPool {
+	globalSSHPoolMu.Lock()
+	defer globalSSHPoolMu.Unlock()
+	if globalSSHPool == nil {
+		globalSSHPool = NewSSHPool(options)
+	}
+	globalSSHPoolRefs++
+	return globalSSHPool
+}
+
+// CloseSSHPool returns the metrics of pool and true if the pool closed
+func CloseSSHPool() (SSHPoolMetrics, bool) {
+	globalSSHPoolMu.Lock()
+	defer globalSSHPoolMu.Unlock()
+	pool := globalSSHPool
+	if pool == nil {
+		return SSHPoolMetrics{}, false
+	}
+
+	globalSSHPoolRefs--
+	if globalSSHPoolRefs > 0 {
+		return pool.Metrics(), false
+	}
+	globalSSHPool = nil
+	return pool.Close(), true
+}
+
+func GetSSHPool() *SSHPool {
+	globalSSHPoolMu.Lock()
+	defer globalSSHPoolMu.Unlock()
+	return globalSSHPool
+}
+
+func poolKey(config SSHConfig) string {
+	return fmt.Sprintf("%s@%s:%d?key=%s&cert=%s&agent=%t:%s&become=%s&jump=%s&proxy=%s",
+		config.User, config.Host, config.Port, config.PrivateKeyPath, config.CertificatePath,
+		config.ForwardAgent, config.IdentityAgent, config.BecomeUser, config.ProxyJump, config.ProxyCommand)
+}
+
+func (p *SSHPool) count() int {
+	n := 0
+	for _, conns := range p.conns {
+		n += len(conns)
+	}
+	return n
+}
+
+// remove connection from pool and close it, must be called with lock held
+func (p *SSHPool) remove(conn *pooledConn) {
+	conns := p.conns[conn.key]
+	for i, c := range conns {
+		if c == conn {
+			p.conns[conn.key] = append(conns[:i], conns[i+1:]...)
+			break
+		}
+	}
+	if len(p.conns[conn.key]) == 0 {
+		delete(p.conns, conn.key)
+	}
+	conn.client.Close()
+	p.metrics.Closed++
+}
+
+// the least used connection which not full, must be called with lock held
+func (p *SSHPool) pick(key string) *pooledConn {
+	var picked *pooledConn
+	for _, conn := range p.conns[key] {
+		if conn.broken || conn.refs >= p.options.MaxSessions {
+			continue
+		} else if picked == nil || conn.refs < picked.refs {
+			picked = conn
+		}
+	}
+	return picked
+}
+
+func (p *SSHPool) acquire(config SSHConfig) (*pooledConn, error) {
+	key := poolKey(config)
+	p.mutex.Lock()
+	if conn := p.pick(key); conn != nil && !p.closed {
+		conn.refs++
+		p.metrics.Reuses++
+		p.mutex.Unlock()
+		return conn, nil
+	}
+	p.mutex.Unlock()
+
+	// dial without lock, it maybe slow
+	client, err := p.dial(config)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	conn := &pooledConn{client: client, key: key, refs: 1}
+	if p.closed { // pool closed while dialing, the connection won't be pooled
+		conn.key = ""
+		return conn, nil
+	}
+	p.conns[key] = append(p.conns[key], conn)
+	p.metrics.Dials++
+	if n := p.count(); n > p.metrics.Peak {
+		p.metrics.Peak = n
+	}
+	return conn, nil
+}
+
+func (p *SSHPool) release(conn *pooledConn) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(conn.key) == 0 {
+		conn.client.Close()
+		return
+	}
+
+	conn.refs--
+	if conn.refs > 0 {
+		return
+	} else if conn.broken || p.closed {
+		p.remove(conn)
+		return
+	}
+
+	idle := 0
+	for _, c := range p.conns[conn.key] {
+		if c.refs == 0 {
+			idle++
+		}
+	}
+	if idle > p.options.MaxIdle {
+		p.remove(conn)
+	}
+}
+
+// Get borrows a connection from pool, the connection will be dialed if no one available
+func (p *SSHPool) Get(config SSHConfig) (*PooledSSHClient, error) {
+	conn, err := p.acquire(config)
+	if err != nil {
+		return nil, err
+	}
+	return &PooledSSHClient{SSHClient: conn.client, config: config, pool: p, conn: conn}, nil
+}
+
+func (p *SSHPool) markBroken(conn *pooledConn) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	conn.broken = true
+}
+
+// send keep-alive request for all connections, the broken one will be closed if no one using it
+func (p *SSHPool) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.mutex.Lock()
+		conns := []*pooledConn{}
+		for _, cs := range p.conns {
+			conns = append(conns, cs...)
+		}
+		p.mutex.Unlock()
+
+		for _, conn := range conns {
+			_, _, err := conn.client.Client().SendRequest(SSH_KEEPALIVE_REQUEST, true, nil)
+			if err == nil {
+				continue
+			}
+			log.Warn("SSH keep-alive failed",
+				log.Field("key", conn.key),
+				log.Field("error", err))
+			p.mutex.Lock()
+			conn.broken = true
+			if conn.refs == 0 {
+				p.remove(conn)
+			}
+			p.mutex.Unlock()
+		}
+	}
+}
+
+func (p *SSHPool) Metrics() SSHPoolMetrics {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	metrics := p.metrics
+	for _, conns := range p.conns {
+		for _, conn := range conns {
+			if conn.refs > 0 {
+				metrics.Active++
+			} else {
+				metrics.Idle++
+			}
+		}
+	}
+	return metrics
+}
+
+// Close closes all idle connections, the connections in use will be closed once released
+func (p *SSHPool) Close() SSHPoolMetrics {
+	p.mutex.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.stop)
+		for _, conns := range p.conns {
+			for _, conn := range append([]*pooledConn{}, conns...) {
+				if conn.refs == 0 {
+					p.remove(conn)
+				}
+			}
+		}
+	}
+	p.mutex.Unlock()
+	return p.Metrics()
+}
+
+func (m SSHPoolMetrics) String() string {
+	return fmt.Sprintf("dials=%d reuses=%d reconnects=%d closed=%d active=%d idle=%d peak=%d",
+		m.Dials, m.Reuses, m.Reconnects, m.Closed, m.Active, m.Idle, m.Peak)
+}
+
+func (c *PooledSSHClient) Config() SSHConfig {
+	return c.config
+}
+
+// reconnect replaces the broken connection with a new one
+func (c *PooledSSHClient) reconnect() error {
+	c.pool.markBroken(c.conn)
+	c.pool.release(c.conn)
+	conn, err := c.pool.acquire(c.config)
+	if err != nil {
+		c.released = true
+		return err
+	}
+
+	c.pool.mutex.Lock()
+	c.pool.metrics.Reconnects++
+	c.pool.mutex.Unlock()
+	c.conn = conn
+	c.SSHClient = conn.client
+	return nil
+}
+
+// command reconnects and retries once if the session can't be opened, the command never executed in this case
+func (c *PooledSSHClient) command(ctx context.Context, command string) (*goph.Cmd, error) {
+	if c.released {
+		return nil, fmt.Errorf("transport already closed")
+	}
+	cmd, err := c.Client().CommandContext(ctx, command)
+	if err != nil {
+		log.Warn("Open SSH session failed, reconnect",
+			log.Field("key", c.conn.key),
+			log.Field("error", err))
+		if err := c.reconnect(); err != nil {
+			return nil, err
+		}
+		return c.Client().CommandContext(ctx, command)
+	}
+	return cmd, nil
+}
+
+func (c *PooledSSHClient) Exec(ctx context.Context, command string) ([]byte, error) {
+	cmd, err := c.command(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	return cmd.CombinedOutput()
+}
+
+func (c *PooledSSHClient) ExecStream(ctx context.Context, command string, w io.Writer) error {
+	cmd, err := c.command(ctx, command)
+	if err != nil {
+		return err
+	}
+	sw := &syncWriter{w: w}
+	cmd.Stdout, cmd.Stderr = sw, sw
+	return cmd.Run()
+}
+
+func (c *PooledSSHClient) OpenFile(path string, flag int) (File, error) {
+	if c.released {
+		return nil, fmt.Errorf("transport already closed")
+	}
+	file, err := c.SSHClient.OpenFile(path, flag)
+	if err == nil {
+		return file, nil
+	}
+
+	// sftp subsystem can't be started, maybe connection broken
+	if _, _, serr := c.Client().SendRequest(SSH_KEEPALIVE_REQUEST, true, nil); serr != nil {
+		if err := c.reconnect(); err != nil {
+			return nil, err
+		}
+		return c.SSHClient.OpenFile(path, flag)
+	}
+	return nil, err
+}
+
+// Close gives back the connection to pool instead of closing it
+func (c *PooledSSHClient) Close() error {
+	if c.released {
+		return nil
+	}
+	c.released = true
+	c.pool.release(c.conn)
+	return nil
+}
@@ -0,0 +1,324 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-17
+ * Author: agent
+ */
+
+package module
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/melbahja/goph"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHServer echoes the command of exec request, it accepts any user
+type testSSHServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+	mutex    sync.Mutex
+	conns    []*ssh.ServerConn
+}
+
+func newTestSSHServer(t *testing.T) *testSSHServer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	assert.Nil(t, err)
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	s := &testSSHServer{listener: listener, config: config}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *testSSHServer) serve() {
+	for {
+		nconn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			conn, chans, reqs, err := ssh.NewServerConn(nconn, s.config)
+			if err != nil {
+				return
+			}
+			s.mutex.Lock()
+			s.conns = append(s.conns, conn)
+			s.mutex.Unlock()
+			go func() { // keep-alive
+				for req := range reqs {
+					req.Reply(true, nil)
+				}
+			}()
+			for ch := range chans {
+				go s.session(ch)
+			}
+		}()
+	}
+}
+
+func (s *testSSHServer) session(newChannel ssh.NewChannel) {
+	channel, reqs, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+	for req := range reqs {
+		if req.Type != "exec" {
+			req.Reply(false, nil)
+			continue
+		}
+		var payload struct{ Command string }
+		ssh.Unmarshal(req.Payload, &payload)
+		req.Reply(true, nil)
+		channel.Write([]byte(strings.TrimSpace(payload.Command)))
+		channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+		return
+	}
+}
+
+// breakAll closes all connections from server side
+func (s *testSSHServer) breakAll() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *testSSHServer) dial(config SSHConfig) (*SSHClient, error) {
+	client, err := ssh.Dial("tcp", s.listener.Addr().String(), &ssh.ClientConfig{
+		User:            config.User,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SSHClient{client: &goph.Client{Client: client}, config: config}, nil
+}
+
+func newTestSSHPool(t *testing.T, options SSHPoolOptions) (*SSHPool, *testSSHServer) {
+	server := newTestSSHServer(t)
+	pool := NewSSHPool(options)
+	pool.dial = server.dial
+	t.Cleanup(func() { pool.Close() })
+	return pool, server
+}
+
+func TestSSHPool_Refcount(t *testing.T) {
+	assert := assert.New(t)
+	pool, _ := newTestSSHPool(t, SSHPoolOptions{MaxSessions: 2, MaxIdle: 2})
+	config := SSHConfig{User: "curve", Host: "host1", Port: 22}
+
+	// 2 transports share one connection at most
+	clients := []*PooledSSHClient{}
+	for i := 0; i < 3; i++ {
+		client, err := pool.Get(config)
+		assert.Nil(err)
+		clients = append(clients, client)
+	}
+	metrics := pool.Metrics()
+	assert.Equal(2, metrics.Dials)
+	assert.Equal(1, metrics.Reuses)
+	assert.Equal(2, metrics.Active)
+	assert.True(clients[0].conn == clients[1].conn)
+
+	out, err := clients[0].Exec(context.Background(), "hostname")
+	assert.Nil(err)
+	assert.Equal("hostname", string(out))
+
+	// the connection is in use until all transports released
+	assert.Nil(clients[0].Close())
+	assert.Nil(clients[0].Close()) // released only once
+	assert.Equal(2, pool.Metrics().Active)
+	assert.Nil(clients[1].Close())
+	metrics = pool.Metrics()
+	assert.Equal(1, metrics.Active)
+	assert.Equal(1, metrics.Idle)
+
+	// the released transport can't be used any more
+	_, err = clients[0].Exec(context.Background(), "hostname")
+	assert.NotNil(err)
+
+	// the idle connection is reused
+	client, err := pool.Get(config)
+	assert.Nil(err)
+	assert.Equal(2, pool.Metrics().Dials)
+	client.Close()
+	clients[2].Close()
+}
+
+func TestSSHPool_SettingsOfBorrower(t *testing.T) {
+	assert := assert.New(t)
+	pool, _ := newTestSSHPool(t, SSHPoolOptions{MaxSessions: 2, MaxIdle: 1})
+	config1 := SSHConfig{User: "curve", Host: "host1", Port: 22, ContainerEngine: "docker", BecomeMethod: "sudo"}
+	config2 := SSHConfig{User: "curve", Host: "host1", Port: 22, ContainerEngine: "podman", BecomeMethod: "su",
+		HTTPProxy: HTTPProxyConfig{HTTPProxy: "http://proxy:3128"}}
+
+	client1, err := pool.Get(config1)
+	assert.Nil(err)
+	client2, err := pool.Get(config2)
+	assert.Nil(err)
+	assert.True(client1.conn == client2.conn)
+	assert.Equal(config1, client1.Config())
+	assert.Equal(config2, client2.Config())
+	client1.Close()
+	client2.Close()
+}
+
+func TestSSHPool_Evict(t *testing.T) {
+	assert := assert.New(t)
+	pool, _ := newTestSSHPool(t, SSHPoolOptions{MaxSessions: 1, MaxIdle: 1})
+	config := SSHConfig{User: "curve", Host: "host1", Port: 22}
+
+	clients := []*PooledSSHClient{}
+	for i := 0; i < 3; i++ {
+		client, err := pool.Get(config)
+		assert.Nil(err)
+		clients = append(clients, client)
+	}
+	for _, client := range clients {
+		client.Close()
+	}
+
+	// only MaxIdle connections kept
+	metrics := pool.Metrics()
+	assert.Equal(3, metrics.Dials)
+	assert.Equal(2, metrics.Closed)
+	assert.Equal(0, metrics.Active)
+	assert.Equal(1, metrics.Idle)
+	assert.Equal(3, metrics.Peak)
+}
+
+func TestSSHPool_Reconnect(t *testing.T) {
+	assert := assert.New(t)
+	pool, server := newTestSSHPool(t, SSHPoolOptions{MaxSessions: 2, MaxIdle: 1})
+	config := SSHConfig{User: "curve", Host: "host1", Port: 22}
+
+	client, err := pool.Get(config)
+	assert.Nil(err)
+	broken := client.conn
+	server.breakAll()
+	broken.client.Client().Wait()
+
+	// the session can't be opened, the command is retried by new connection
+	out, err := client.Exec(context.Background(), "uptime")
+	assert.Nil(err)
+	assert.Equal("uptime", string(out))
+	assert.False(client.conn == broken)
+	metrics := pool.Metrics()
+	assert.Equal(1, metrics.Reconnects)
+	assert.Equal(2, metrics.Dials)
+	assert.Equal(1, metrics.Closed)
+	assert.Equal(1, metrics.Active)
+
+	// the broken connection is never picked
+	another, err := pool.Get(config)
+	assert.Nil(err)
+	assert.True(another.conn == client.conn)
+	another.Close()
+	client.Close()
+}
+
+func TestSSHPool_CloseInUse(t *testing.T) {
+	assert := assert.New(t)
+	pool, _ := newTestSSHPool(t, SSHPoolOptions{MaxSessions: 1, MaxIdle: 2})
+	config := SSHConfig{User: "curve", Host: "host1", Port: 22}
+
+	inUse, err := pool.Get(config)
+	assert.Nil(err)
+	idle, err := pool.Get(config)
+	assert.Nil(err)
+	idle.Close()
+
+	// the idle one closed, the one in use is still usable
+	metrics := pool.Close()
+	assert.Equal(1, metrics.Closed)
+	assert.Equal(1, metrics.Active)
+	assert.Equal(0, metrics.Idle)
+	out, err := inUse.Exec(context.Background(), "date")
+	assert.Nil(err)
+	assert.Equal("date", string(out))
+
+	// closed once released
+	inUse.Close()
+	metrics = pool.Metrics()
+	assert.Equal(2, metrics.Closed)
+	assert.Equal(0, metrics.Active)
+
+	// the transport borrowed after closed isn't pooled
+	client, err := pool.Get(config)
+	assert.Nil(err)
+	client.Close()
+	metrics = pool.Metrics()
+	assert.Equal(0, metrics.Active+metrics.Idle)
+	assert.Equal(2, metrics.Dials)
+}
+
+func TestSSHPool_SharedByPlaybooks(t *testing.T) {
+	assert := assert.New(t)
+
+	// two playbooks running at the same time share the same pool
+	pool1 := OpenSSHPool(SSHPoolOptions{MaxSessions: 1})
+	pool2 := OpenSSHPool(SSHPoolOptions{MaxSessions: 2})
+	assert.Same(pool1, pool2)
+
+	// the pool is still usable after the first one done
+	_, closed := CloseSSHPool()
+	assert.False(closed)
+	assert.False(pool1.closed)
+
+	_, closed = CloseSSHPool()
+	assert.True(closed)
+	assert.True(pool1.closed)
+
+	// closed already
+	_, closed = CloseSSHPool()
+	assert.False(closed)
+	assert.NotSame(pool1, OpenSSHPool(SSHPoolOptions{}))
+	CloseSSHPool()
+}
+
+func TestSSHPool_NoIdle(t *testing.T) {
+	assert := assert.New(t)
+	pool, _ := newTestSSHPool(t, SSHPoolOptions{MaxSessions: 1, MaxIdle: 0})
+	config := SSHConfig{User: "curve", Host: "host1", Port: 22}
+
+	// the connection closed once no one using it
+	client, err := pool.Get(config)
+	assert.Nil(err)
+	client.Close()
+	metrics := pool.Metrics()
+	assert.Equal(1, metrics.Closed)
+	assert.Equal(0, metrics.Idle)
+}
@@ -0,0 +1,243 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/melbahja/goph"
+	"golang.org/x/crypto/ssh"
+)
+
+type (
+	// JumpHost is one hop of ProxyJump, e.g. user@bastion:2222
+	JumpHost struct {
+		User string
+		Host string
+		Port uint
+	}
+
+	// proxyCommandConn is the connection which stdin/stdout of ProxyCommand as its stream
+	proxyCommandConn struct {
+		cmd    *exec.Cmd
+		reader io.ReadCloser
+		writer io.WriteCloser
+		remote net.Addr
+	}
+
+	// proxiedConn overrides remote address with target's, so that known_hosts works
+	proxiedConn struct {
+		net.Conn
+		remote net.Addr
+	}
+)
+
+/*
+ * ParseProxyJump parses ProxyJump in the format of OpenSSH:
+ *   [user@]host[:port][,[user@]host[:port]...]
+ * the user of jump host is same to target's if not specified.
+ */
+func ParseProxyJump(proxyJump, defaultUser string) ([]JumpHost, error) {
+	jumps := []JumpHost{}
+	if len(strings.TrimSpace(proxyJump)) == 0 {
+		return jumps, nil
+	}
+
+	for _, item := range strings.Split(proxyJump, ",") {
+		item = strings.TrimSpace(item)
+		jump := JumpHost{User: defaultUser, Host: item, Port: 22}
+		if i := strings.LastIndex(item, "@"); i >= 0 {
+			jump.User, jump.Host = item[:i], item[i+1:]
+		}
+		if host, port, err := net.SplitHostPort(jump.Host); err == nil {
+			num, err := strconv.Atoi(port)
+			if err != nil || num <= 0 || num > 65535 {
+				return nil, fmt.Errorf("invalid port in jump host '%s'", item)
+			}
+			jump.Host, jump.Port = host, uint(num)
+		}
+		if len(jump.User) == 0 || len(jump.Host) == 0 || strings.ContainsAny(jump.Host, ":@ ") {
+			return nil, fmt.Errorf("invalid jump host '%s'", item)
+		}
+		jumps = append(jumps, jump)
+	}
+	return jumps, nil
+}
+
+// ExpandProxyCommand replaces tokens %h, %p, %r and %% like OpenSSH does
+func ExpandProxyCommand(command, host string, port uint, user string) string {
+	replacer := strings.NewReplacer(
+		"%%", "%",
+		"%h", host,
+		"%p", strconv.Itoa(int(port)),
+		"%r", user,
+	)
+	return replacer.Replace(command)
+}
+
+func (j JumpHost) String() string {
+	return fmt.Sprintf("%s@%s:%d", j.User, j.Host, j.Port)
+}
+
+func proxyAddr(host string, port uint) net.Addr {
+	if addr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(host, strconv.Itoa(int(port)))); err == nil {
+		return addr
+	}
+	return &net.TCPAddr{IP: net.ParseIP(host), Port: int(port)}
+}
+
+func newProxyCommandConn(command string, remote net.Addr) (net.Conn, error) {
+	cmd := exec.Command("bash", "-c", command)
+	writer, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	} else if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &proxyCommandConn{cmd: cmd, reader: reader, writer: writer, remote: remote}, nil
+}
+
+func (c *proxyCommandConn) Read(b []byte) (int, error)  { return c.reader.Read(b) }
+func (c *proxyCommandConn) Write(b []byte) (int, error) { return c.writer.Write(b) }
+func (c *proxyCommandConn) LocalAddr() net.Addr         { return &net.TCPAddr{} }
+func (c *proxyCommandConn) RemoteAddr() net.Addr        { return c.remote }
+
+func (c *proxyCommandConn) SetDeadline(t time.Time) error      { return nil }
+func (c *proxyCommandConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *proxyCommandConn) SetWriteDeadline(t time.Time) error { return nil }
+
+func (c *proxyCommandConn) Close() error {
+	c.writer.Close()
+	c.reader.Close()
+	if c.cmd.Process != nil {
+		c.cmd.Process.Kill()
+	}
+	c.cmd.Wait()
+	return nil
+}
+
+func (c *proxiedConn) RemoteAddr() net.Addr { return c.remote }
+
+func newClientConfig(user string, auth goph.Auth, timeout time.Duration) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		Timeout:         timeout,
+		HostKeyCallback: VerifyHost,
+	}
+}
+
+func closeAll(closers []io.Closer) {
+	for i := len(closers) - 1; i >= 0; i-- {
+		closers[i].Close()
+	}
+}
+
+// dial the target by hopping through jump hosts one by one, the last one forwards to target
+func dialProxyJump(config SSHConfig, auth goph.Auth, timeout time.Duration) (net.Conn, []io.Closer, error) {
+	jumps, err := ParseProxyJump(config.ProxyJump, config.User)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var client *ssh.Client
+	closers := []io.Closer{}
+	for _, jump := range jumps {
+		addr := net.JoinHostPort(jump.Host, strconv.Itoa(int(jump.Port)))
+		clientConfig := newClientConfig(jump.User, auth, timeout)
+		if client == nil {
+			client, err = ssh.Dial("tcp", addr, clientConfig)
+		} else {
+			var conn net.Conn
+			conn, err = client.Dial("tcp", addr)
+			if err == nil {
+				conn = &proxiedConn{Conn: conn, remote: proxyAddr(jump.Host, jump.Port)}
+				var c ssh.Conn
+				var chans <-chan ssh.NewChannel
+				var reqs <-chan *ssh.Request
+				c, chans, reqs, err = ssh.NewClientConn(conn, addr, clientConfig)
+				if err == nil {
+					client = ssh.NewClient(c, chans, reqs)
+				}
+			}
+		}
+		if err != nil {
+			closeAll(closers)
+			return nil, nil, fmt.Errorf("connect jump host %s: %v", jump, err)
+		}
+		closers = append(closers, client)
+	}
+
+	addr := net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port)))
+	conn, err := client.Dial("tcp", addr)
+	if err != nil {
+		closeAll(closers)
+		return nil, nil, err
+	}
+	return &proxiedConn{Conn: conn, remote: proxyAddr(config.Host, config.Port)}, closers, nil
+}
+
+/*
+ * dialSSH connects the target directly, or through:
+ *   (1) ProxyCommand: the command's stdin/stdout as connection, e.g. "ssh -W %h:%p bastion"
+ *   (2) ProxyJump: jump hosts which share the auth of target
+ * the returned closers should be closed after the client closed.
+ */
+func dialSSH(config SSHConfig, gc *goph.Config) (*goph.Client, []io.Closer, error) {
+	if len(config.ProxyJump) == 0 && len(config.ProxyCommand) == 0 {
+		client, err := goph.NewConn(gc)
+		return client, nil, err
+	}
+
+	var conn net.Conn
+	var closers []io.Closer
+	var err error
+	if len(config.ProxyCommand) > 0 {
+		command := ExpandProxyCommand(config.ProxyCommand, config.Host, config.Port, config.User)
+		conn, err = newProxyCommandConn(command, proxyAddr(config.Host, config.Port))
+	} else {
+		conn, closers, err = dialProxyJump(config, gc.Auth, gc.Timeout)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addr := net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port)))
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, newClientConfig(gc.User, gc.Auth, gc.Timeout))
+	if err != nil {
+		conn.Close()
+		closeAll(closers)
+		return nil, nil, err
+	}
+	return &goph.Client{Client: ssh.NewClient(c, chans, reqs), Config: gc}, closers, nil
+}
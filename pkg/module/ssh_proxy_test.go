@@ -0,0 +1,62 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProxyJump(t *testing.T) {
+	assert := assert.New(t)
+
+	jumps, err := ParseProxyJump("", "curve")
+	assert.Nil(err)
+	assert.Len(jumps, 0)
+
+	jumps, err = ParseProxyJump("bastion", "curve")
+	assert.Nil(err)
+	assert.Equal([]JumpHost{{User: "curve", Host: "bastion", Port: 22}}, jumps)
+
+	jumps, err = ParseProxyJump("admin@10.0.0.1:2222, 10.0.1.1", "curve")
+	assert.Nil(err)
+	assert.Equal([]JumpHost{
+		{User: "admin", Host: "10.0.0.1", Port: 2222},
+		{User: "curve", Host: "10.0.1.1", Port: 22},
+	}, jumps)
+	assert.Equal("admin@10.0.0.1:2222", jumps[0].String())
+
+	for _, proxyJump := range []string{"bastion:abc", "bastion:0", "@bastion", "admin@", "a,,b"} {
+		_, err = ParseProxyJump(proxyJump, "curve")
+		assert.NotNil(err, proxyJump)
+	}
+}
+
+func TestExpandProxyCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("ssh -W 10.0.0.2:22 curve@bastion",
+		ExpandProxyCommand("ssh -W %h:%p %r@bastion", "10.0.0.2", 22, "curve"))
+	assert.Equal("echo %h", ExpandProxyCommand("echo %%h", "10.0.0.2", 22, "curve"))
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+/*
+ * Large remote outputs (e.g. gigabyte-scale container logs) are streamed
+ * into io.Writer sinks by ExecuteStream of Shell and DockerCli instead of
+ * buffered in memory, the sink can be a file, or a LineWriter which calls
+ * back for each line.
+ */
+type (
+	// serializes writes from stdout and stderr of SSH session
+	syncWriter struct {
+		mutex sync.Mutex
+		w     io.Writer
+	}
+
+	countingWriter struct {
+		w io.Writer
+		n int64
+	}
+
+	LineWriter struct {
+		callback func(line string)
+		buffer   []byte
+	}
+)
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.w.Write(p)
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// NewLineWriter returns writer which calls callback for each line without trailing newline
+func NewLineWriter(callback func(line string)) *LineWriter {
+	return &LineWriter{callback: callback}
+}
+
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.buffer = append(w.buffer, p...)
+	start := 0
+	for {
+		idx := bytes.IndexByte(w.buffer[start:], '\n')
+		if idx < 0 {
+			break
+		}
+		w.callback(string(w.buffer[start : start+idx]))
+		start += idx + 1
+	}
+	// only the incomplete line is kept
+	w.buffer = append(w.buffer[:0], w.buffer[start:]...)
+	return len(p), nil
+}
+
+// Close flushes the last line which has no trailing newline
+func (w *LineWriter) Close() error {
+	if len(w.buffer) > 0 {
+		w.callback(string(w.buffer))
+		w.buffer = nil
+	}
+	return nil
+}
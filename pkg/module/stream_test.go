@@ -0,0 +1,68 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineWriter(t *testing.T) {
+	assert := assert.New(t)
+
+	lines := []string{}
+	w := NewLineWriter(func(line string) { lines = append(lines, line) })
+	for _, p := range []string{"hel", "lo\nwor", "ld\n\nlast"} {
+		n, err := w.Write([]byte(p))
+		assert.Nil(err)
+		assert.Equal(len(p), n)
+	}
+	assert.Equal([]string{"hello", "world", ""}, lines)
+
+	assert.Nil(w.Close())
+	assert.Equal([]string{"hello", "world", "", "last"}, lines)
+	assert.Nil(w.Close())
+	assert.Len(lines, 4)
+}
+
+func TestExecCommandStream(t *testing.T) {
+	assert := assert.New(t)
+
+	transport := NewLocalTransport(SSHConfig{})
+	tmpl := template.Must(template.New("Seq").Parse("seq 1 {{.n}}"))
+
+	var buffer bytes.Buffer
+	err := execCommandStream(transport, tmpl, map[string]interface{}{"n": 3}, ExecOptions{}, &buffer)
+	assert.Nil(err)
+	assert.Equal("1\n2\n3\n", buffer.String())
+
+	count := 0
+	w := NewLineWriter(func(line string) { count++ })
+	err = NewShell(transport).Command("seq 1 100000").ExecuteStream(ExecOptions{}, w)
+	assert.Nil(err)
+	assert.Nil(w.Close())
+	assert.Equal(100000, count)
+}
@@ -0,0 +1,144 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package module
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+const (
+	TRANSPORT_SSH   = "ssh"
+	TRANSPORT_LOCAL = "local"
+)
+
+var (
+	TRANSPORTS = []string{TRANSPORT_SSH, TRANSPORT_LOCAL}
+)
+
+type (
+	/*
+	 * Transport is the way to reach the host, all modules (shell, file, docker)
+	 * execute commands and transfer files through it, so that adding new transport
+	 * (e.g. SSM, teleport, kubectl exec) needn't touch any step.
+	 */
+	Transport interface {
+		Name() string
+		Config() SSHConfig
+		Exec(ctx context.Context, command string) ([]byte, error)
+		// ExecStream writes combined output into w as it arrives, for large outputs
+		ExecStream(ctx context.Context, command string, w io.Writer) error
+		Upload(localPath, remotePath string) error
+		Download(remotePath, localPath string) error
+		OpenFile(path string, flag int) (File, error) // random access to remote file
+		Close() error
+	}
+
+	File interface {
+		io.Reader
+		io.Writer
+		io.Seeker
+		io.Closer
+		Stat() (os.FileInfo, error)
+		Truncate(size int64) error
+	}
+
+	// LocalTransport executes commands in the machine where curveadm running
+	LocalTransport struct {
+		config SSHConfig
+	}
+)
+
+func NewTransport(config SSHConfig) (Transport, error) {
+	switch config.Transport {
+	case "", TRANSPORT_SSH:
+		if pool := GetSSHPool(); pool != nil {
+			return pool.Get(config)
+		}
+		return NewSSHClient(config)
+	case TRANSPORT_LOCAL:
+		return NewLocalTransport(config), nil
+	}
+	return nil, fmt.Errorf("unsupported transport '%s'", config.Transport)
+}
+
+func NewLocalTransport(config SSHConfig) *LocalTransport {
+	return &LocalTransport{config: config}
+}
+
+func (t *LocalTransport) Name() string {
+	return TRANSPORT_LOCAL
+}
+
+func (t *LocalTransport) Config() SSHConfig {
+	return t.config
+}
+
+func (t *LocalTransport) Exec(ctx context.Context, command string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "bash", "-c", command)
+	cmd.Env = append(os.Environ(), "LANG=en_US.UTF-8")
+	return cmd.CombinedOutput()
+}
+
+func (t *LocalTransport) ExecStream(ctx context.Context, command string, w io.Writer) error {
+	cmd := exec.CommandContext(ctx, "bash", "-c", command)
+	cmd.Env = append(os.Environ(), "LANG=en_US.UTF-8")
+	cmd.Stdout, cmd.Stderr = w, w
+	return cmd.Run()
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+func (t *LocalTransport) Upload(localPath, remotePath string) error {
+	return copyFile(localPath, remotePath)
+}
+
+func (t *LocalTransport) Download(remotePath, localPath string) error {
+	return copyFile(remotePath, localPath)
+}
+
+func (t *LocalTransport) OpenFile(path string, flag int) (File, error) {
+	return os.OpenFile(path, flag, 0644)
+}
+
+func (t *LocalTransport) Close() error {
+	return nil
+}
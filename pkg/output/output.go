@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+// Package output defines the machine-readable outputs of curveadm, which
+// selected by '--output json' and described by JSON schemas in schemas/.
+//
+// Compatibility policy: every output carries "format_version" as MAJOR.MINOR,
+//
+//   - MINOR is bumped when fields are added, which never breaks consumers
+//     as long as they ignore unknown fields;
+//   - MAJOR is bumped when fields are removed, renamed or their types or
+//     meanings changed, consumers should refuse the major they don't know;
+//   - the order of items in arrays is stable (sorted), while the order of
+//     keys in objects is not guaranteed.
+package output
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	FORMAT_VERSION = "1.1"
+
+	KIND_STATUS = "status"
+	KIND_HOSTS  = "hosts"
+	KIND_DISKS  = "disks"
+
+	FORMAT_TEXT = "text"
+	FORMAT_JSON = "json"
+)
+
+type (
+	Header struct {
+		FormatVersion string `json:"format_version"`
+		Kind          string `json:"kind"`
+	}
+
+	// curveadm status --output json
+	Status struct {
+		Header
+		Cluster     string    `json:"cluster"`
+		ClusterKind string    `json:"cluster_kind"` // curvebs, curvefs or mixed
+		MDS         []MDS     `json:"mds"`
+		Services    []Service `json:"services"`
+	}
+
+	MDS struct {
+		Kind    string   `json:"kind"`
+		Addr    string   `json:"addr"`
+		Leaders []string `json:"leaders"` // service ids, empty if no leader
+	}
+
+	Service struct {
+		Id          string `json:"id"`
+		ParentId    string `json:"parent_id"`
+		Kind        string `json:"kind"`
+		Role        string `json:"role"`
+		Host        string `json:"host"`
+		Instances   string `json:"instances"`
+		ContainerId string `json:"container_id"`
+		Ports       string `json:"ports"`
+		IsLeader    bool   `json:"is_leader"`
+		Status      string `json:"status"`
+		Health      string `json:"health,omitempty"` // only if --deep specified
+		LogDir      string `json:"log_dir"`
+		DataDir     string `json:"data_dir"`
+	}
+
+	// curveadm hosts ls --output json
+	Hosts struct {
+		Header
+		Hosts []Host `json:"hosts"`
+	}
+
+	Host struct {
+		Host           string   `json:"host"`
+		Hostname       string   `json:"hostname"`
+		SSHHostname    string   `json:"ssh_hostname"`
+		SSHPort        int      `json:"ssh_port"`
+		User           string   `json:"user"`
+		PrivateKeyFile string   `json:"private_key_file"`
+		Transport      string   `json:"transport"`
+		Labels         []string `json:"labels"`
+		Groups         []string `json:"groups"`
+	}
+
+	// curveadm format --status --output json
+	Disks struct {
+		Header
+		Disks []Disk `json:"disks"`
+	}
+
+	Disk struct {
+		Host       string `json:"host"`
+		Device     string `json:"device"`
+		MountPoint string `json:"mount_point"`
+		Usage      int    `json:"usage"`   // used percent of disk
+		Percent    int    `json:"percent"` // expected percent of chunkfile pool
+		Chunks     int    `json:"chunks"`  // allocated chunks in chunkfile pool
+		Written    uint64 `json:"written"` // used bytes of disk
+		Size       uint64 `json:"size"`    // total bytes of disk
+		Rate       uint64 `json:"rate"`    // written bytes per second
+		Status     string `json:"status"`
+	}
+)
+
+func NewHeader(kind string) Header {
+	return Header{FormatVersion: FORMAT_VERSION, Kind: kind}
+}
+
+func ValidFormat(format string) bool {
+	return format == FORMAT_TEXT || format == FORMAT_JSON
+}
+
+// Major returns the major of format version, e.g. "1" for "1.0"
+func Major(version string) string {
+	return strings.SplitN(version, ".", 2)[0]
+}
+
+func Marshal(v interface{}) (string, error) {
+	bytes, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type schema struct {
+	Required   []string           `json:"required"`
+	Properties map[string]*schema `json:"properties"`
+	Items      *schema            `json:"items"`
+}
+
+func loadSchema(t *testing.T, filename string) *schema {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func keys(m map[string]interface{}) []string {
+	out := []string{}
+	for key := range m {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// checkObject checks the object has all required keys and no keys undefined in schema
+func checkObject(t *testing.T, s *schema, object map[string]interface{}, path string) {
+	for _, key := range s.Required {
+		_, ok := object[key]
+		assert.True(t, ok, "%s.%s is required", path, key)
+	}
+	for _, key := range keys(object) {
+		property, ok := s.Properties[key]
+		if !assert.True(t, ok, "%s.%s is not defined in schema", path, key) {
+			continue
+		}
+		if items, ok := object[key].([]interface{}); ok && property.Items != nil && property.Items.Properties != nil {
+			for _, item := range items {
+				checkObject(t, property.Items, item.(map[string]interface{}), path+"."+key+"[]")
+			}
+		}
+	}
+}
+
+func TestOutputMatchSchema(t *testing.T) {
+	for _, tc := range []struct {
+		filename string
+		output   interface{}
+	}{
+		{
+			"schemas/status.v1.schema.json",
+			Status{
+				Header:   NewHeader(KIND_STATUS),
+				MDS:      []MDS{{Leaders: []string{"c1"}}},
+				Services: []Service{{Health: "healthy"}},
+			},
+		},
+		{
+			"schemas/hosts.v1.schema.json",
+			Hosts{Header: NewHeader(KIND_HOSTS), Hosts: []Host{{}}},
+		},
+		{
+			"schemas/disks.v1.schema.json",
+			Disks{Header: NewHeader(KIND_DISKS), Disks: []Disk{{}}},
+		},
+	} {
+		data, err := json.Marshal(tc.output)
+		assert.Nil(t, err)
+		object := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal(data, &object))
+		checkObject(t, loadSchema(t, tc.filename), object, tc.filename)
+	}
+}
+
+func TestFormatVersion(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("1", Major(FORMAT_VERSION))
+	assert.Equal("2", Major("2.13"))
+	assert.True(ValidFormat(FORMAT_JSON))
+	assert.True(ValidFormat(FORMAT_TEXT))
+	assert.False(ValidFormat("yaml"))
+}
@@ -0,0 +1,246 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+// Package plugin is the SDK for writing third-party plugins of curveadm.
+//
+// A plugin is an executable named "curveadm-<name>" which placed in plugin
+// directory (~/.curveadm/plugins) or any directory of $PATH, like kubectl:
+//
+//	$ curveadm <name> [ARGS...]          => curveadm-<name> [ARGS...]
+//	$ curveadm precheck                  => curveadm-<name> __precheck <check>  (request from stdin)
+//	$ curveadm plugin run <name> <step>  => curveadm-<name> __step <step>       (request from stdin)
+//
+// The step is run as a playbook step of curveadm, which invokes plugin once
+// per host in parallel, so the hosts in request contain only that host.
+//
+// A minimal plugin looks like:
+//
+//	func main() {
+//		plugin.Serve(&plugin.Plugin{
+//			Name:    "zstack",
+//			Version: "0.1.0",
+//			Short:   "Integrate curvebs with ZStack",
+//			Run: func(ctx *plugin.Context) error { ... },
+//			Prechecks: map[string]plugin.Handler{
+//				"zstack-agent": func(ctx *plugin.Context, req *plugin.Request) error { ... },
+//			},
+//		})
+//	}
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+)
+
+const (
+	// bump it iff the request/result/manifest changed incompatibly
+	PROTOCOL_VERSION = 1
+
+	BINARY_PREFIX = "curveadm-"
+
+	// reserved arguments which curveadm invokes plugin with
+	COMMAND_MANIFEST = "__manifest"
+	COMMAND_PRECHECK = "__precheck"
+	COMMAND_STEP     = "__step"
+
+	// environments which curveadm passed to plugin
+	ENV_CURVEADM_BIN      = "CURVEADM_BIN"
+	ENV_CURVEADM_ROOT_DIR = "CURVEADM_ROOT_DIR"
+	ENV_CURVEADM_CLUSTER  = "CURVEADM_PLUGIN_CLUSTER"
+	ENV_CURVEADM_PROTOCOL = "CURVEADM_PLUGIN_PROTOCOL"
+)
+
+type (
+	// Manifest is printed by plugin in JSON for "curveadm-<name> __manifest"
+	Manifest struct {
+		Name      string   `json:"name"`
+		Version   string   `json:"version"`
+		Protocol  int      `json:"protocol"`
+		Short     string   `json:"short"`
+		Prechecks []string `json:"prechecks,omitempty"`
+		Steps     []string `json:"steps,omitempty"`
+	}
+
+	Host struct {
+		Host           string   `json:"host"`
+		Hostname       string   `json:"hostname"`
+		SSHHostname    string   `json:"ssh_hostname"`
+		SSHPort        int      `json:"ssh_port"`
+		User           string   `json:"user"`
+		PrivateKeyFile string   `json:"private_key_file,omitempty"`
+		Labels         []string `json:"labels,omitempty"`
+	}
+
+	// Request is written to stdin of plugin for prechecks and steps
+	Request struct {
+		Protocol int               `json:"protocol"`
+		Cluster  string            `json:"cluster"`
+		Name     string            `json:"name"` // name of precheck or step
+		Hosts    []Host            `json:"hosts"`
+		Options  map[string]string `json:"options,omitempty"`
+	}
+
+	// Result is printed by plugin in JSON for prechecks and steps
+	Result struct {
+		Success bool   `json:"success"`
+		Message string `json:"message,omitempty"`
+	}
+
+	Context struct {
+		Bin      string // path of curveadm binary
+		RootDir  string // ~/.curveadm
+		Cluster  string // current cluster, maybe empty
+		Protocol int
+		Args     []string
+		Stdin    io.Reader
+		Stdout   io.Writer
+		Stderr   io.Writer
+	}
+
+	// Handler handles precheck or step, returns error if it failed
+	Handler func(ctx *Context, req *Request) error
+
+	Plugin struct {
+		Name      string
+		Version   string
+		Short     string
+		Run       func(ctx *Context) error // curveadm <name> [ARGS...]
+		Prechecks map[string]Handler
+		Steps     map[string]Handler
+	}
+)
+
+func keys(m map[string]Handler) []string {
+	out := []string{}
+	for key := range m {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (p *Plugin) Manifest() Manifest {
+	return Manifest{
+		Name:      p.Name,
+		Version:   p.Version,
+		Protocol:  PROTOCOL_VERSION,
+		Short:     p.Short,
+		Prechecks: keys(p.Prechecks),
+		Steps:     keys(p.Steps),
+	}
+}
+
+func NewContext(args []string, stdin io.Reader, stdout, stderr io.Writer) *Context {
+	protocol, _ := strconv.Atoi(os.Getenv(ENV_CURVEADM_PROTOCOL))
+	return &Context{
+		Bin:      os.Getenv(ENV_CURVEADM_BIN),
+		RootDir:  os.Getenv(ENV_CURVEADM_ROOT_DIR),
+		Cluster:  os.Getenv(ENV_CURVEADM_CLUSTER),
+		Protocol: protocol,
+		Args:     args,
+		Stdin:    stdin,
+		Stdout:   stdout,
+		Stderr:   stderr,
+	}
+}
+
+// Curveadm returns command which invokes curveadm itself on current cluster,
+// e.g. ctx.Curveadm("status", "--format", "json").Output()
+func (ctx *Context) Curveadm(args ...string) *exec.Cmd {
+	bin := ctx.Bin
+	if len(bin) == 0 {
+		bin = "curveadm"
+	}
+	if len(ctx.Cluster) > 0 {
+		args = append([]string{"--cluster", ctx.Cluster}, args...)
+	}
+	cmd := exec.Command(bin, args...)
+	cmd.Stderr = ctx.Stderr
+	return cmd
+}
+
+func (p *Plugin) handle(ctx *Context, handlers map[string]Handler) error {
+	if len(ctx.Args) != 1 {
+		return fmt.Errorf("requires exactly one name")
+	}
+	handler, ok := handlers[ctx.Args[0]]
+	if !ok {
+		return fmt.Errorf("unknown name '%s'", ctx.Args[0])
+	}
+
+	req := &Request{}
+	if err := json.NewDecoder(ctx.Stdin).Decode(req); err != nil {
+		return fmt.Errorf("decode request: %v", err)
+	}
+	err := handler(ctx, req)
+	result := Result{Success: err == nil}
+	if err != nil {
+		result.Message = err.Error()
+	}
+	return json.NewEncoder(ctx.Stdout).Encode(result)
+}
+
+// Execute dispatches args and returns exit code, it's the testable body of Serve
+func (p *Plugin) Execute(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var err error
+	ctx := NewContext(args, stdin, stdout, stderr)
+	if len(args) == 0 {
+		err = p.run(ctx)
+	} else {
+		ctx.Args = args[1:]
+		switch args[0] {
+		case COMMAND_MANIFEST:
+			err = json.NewEncoder(stdout).Encode(p.Manifest())
+		case COMMAND_PRECHECK:
+			err = p.handle(ctx, p.Prechecks)
+		case COMMAND_STEP:
+			err = p.handle(ctx, p.Steps)
+		default:
+			ctx.Args = args
+			err = p.run(ctx)
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "%s%s: %v\n", BINARY_PREFIX, p.Name, err)
+		return 1
+	}
+	return 0
+}
+
+func (p *Plugin) run(ctx *Context) error {
+	if p.Run == nil {
+		return fmt.Errorf("provides no command")
+	}
+	return p.Run(ctx)
+}
+
+// Serve runs plugin with arguments of process and exits
+func Serve(p *Plugin) {
+	os.Exit(p.Execute(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
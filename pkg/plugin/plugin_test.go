@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPlugin() *Plugin {
+	return &Plugin{
+		Name:    "test",
+		Version: "0.1.0",
+		Short:   "Plugin for test",
+		Run: func(ctx *Context) error {
+			fmt.Fprintf(ctx.Stdout, "args: %s", strings.Join(ctx.Args, " "))
+			return nil
+		},
+		Prechecks: map[string]Handler{
+			"ok": func(ctx *Context, req *Request) error { return nil },
+			"fail": func(ctx *Context, req *Request) error {
+				return fmt.Errorf("%d hosts failed", len(req.Hosts))
+			},
+		},
+	}
+}
+
+func execute(p *Plugin, stdin string, args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := p.Execute(args, strings.NewReader(stdin), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestExecute_Manifest(t *testing.T) {
+	assert := assert.New(t)
+	code, stdout, _ := execute(newTestPlugin(), "", COMMAND_MANIFEST)
+	assert.Equal(0, code)
+
+	manifest := Manifest{}
+	assert.Nil(json.Unmarshal([]byte(stdout), &manifest))
+	assert.Equal(Manifest{
+		Name:      "test",
+		Version:   "0.1.0",
+		Protocol:  PROTOCOL_VERSION,
+		Short:     "Plugin for test",
+		Prechecks: []string{"fail", "ok"},
+	}, manifest)
+}
+
+func TestExecute_Run(t *testing.T) {
+	assert := assert.New(t)
+	code, stdout, _ := execute(newTestPlugin(), "", "hello", "world")
+	assert.Equal(0, code)
+	assert.Equal("args: hello world", stdout)
+
+	p := newTestPlugin()
+	p.Run = nil
+	code, _, stderr := execute(p, "")
+	assert.Equal(1, code)
+	assert.Contains(stderr, "curveadm-test: provides no command")
+}
+
+func TestExecute_Precheck(t *testing.T) {
+	assert := assert.New(t)
+	request := `{"protocol": 1, "name": "fail", "hosts": [{"host": "host1"}, {"host": "host2"}]}`
+
+	code, stdout, _ := execute(newTestPlugin(), request, COMMAND_PRECHECK, "fail")
+	assert.Equal(0, code)
+	result := Result{}
+	assert.Nil(json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(Result{Success: false, Message: "2 hosts failed"}, result)
+
+	code, stdout, _ = execute(newTestPlugin(), request, COMMAND_PRECHECK, "ok")
+	assert.Equal(0, code)
+	result = Result{}
+	assert.Nil(json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(Result{Success: true}, result)
+
+	code, _, stderr := execute(newTestPlugin(), request, COMMAND_PRECHECK, "unknown")
+	assert.Equal(1, code)
+	assert.Contains(stderr, "unknown name 'unknown'")
+
+	code, _, stderr = execute(newTestPlugin(), "not json", COMMAND_STEP, "ok")
+	assert.Equal(1, code)
+	assert.Contains(stderr, "unknown name 'ok'")
+}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-16
+ * Author: agent
+ */
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+/*
+ * spans of playbook execution:
+ *
+ *   playbook
+ *   └── tasks (e.g.: Start Service)
+ *       └── task (e.g.: host=host1 role=mds)
+ *           └── step (e.g.: StartContainer)
+ *
+ * the spans are exported to OTLP/HTTP endpoint which configured in curveadm.cfg,
+ * the global tracer provider is noop if endpoint not configured.
+ */
+const (
+	SERVICE_NAME     = "curveadm"
+	TRACER_NAME      = "github.com/opencurve/curveadm"
+	SHUTDOWN_TIMEOUT = 5 * time.Second
+
+	ATTR_CLUSTER    = "curveadm.cluster"
+	ATTR_COMMAND    = "curveadm.command"
+	ATTR_STEP_TYPE  = "curveadm.step.type"
+	ATTR_TASK_NAME  = "curveadm.task.name"
+	ATTR_TASK_COUNT = "curveadm.task.count"
+	ATTR_TASK_ID    = "curveadm.task.id"
+	ATTR_SUBNAME    = "curveadm.task.subname"
+	ATTR_HOST       = "curveadm.host"
+)
+
+var (
+	provider *sdktrace.TracerProvider
+)
+
+func Init(endpoint string, insecure bool, version string) error {
+	if len(endpoint) == 0 {
+		return nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return err
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(SERVICE_NAME),
+			semconv.ServiceVersionKey.String(version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return nil
+}
+
+// Shutdown flushes all pending spans, it should be called before exit
+func Shutdown() {
+	if provider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SHUTDOWN_TIMEOUT)
+	defer cancel()
+	provider.Shutdown(ctx)
+}
+
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TRACER_NAME).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
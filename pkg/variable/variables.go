@@ -150,6 +150,15 @@ func (vars *Variables) Rendering(s string) (string, error) {
 	return value, err
 }
 
+// Values returns value of all variables, they are resolved after Build
+func (vars *Variables) Values() map[string]string {
+	values := map[string]string{}
+	for name, v := range vars.m {
+		values[name] = v.Value
+	}
+	return values
+}
+
 func (vars *Variables) Debug() {
 	for _, v := range vars.m {
 		log.Info("Variable", log.Field(v.Name, v.Value))
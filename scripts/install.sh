@@ -62,6 +62,10 @@ auto_upgrade = true
 [ssh_connections]
 retries = 3
 timeout = 10
+pool = true
+pool_max_sessions = 8
+pool_max_idle = 2
+pool_keepalive = 30
 
 [database]
 url = "${g_db_path}"